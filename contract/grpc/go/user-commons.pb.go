@@ -35,23 +35,116 @@ const (
 	Error_USER_NOT_FOUND Error = 3
 	// Indicates the provided values for he operation were invalid
 	Error_BAD_REQUEST Error = 4
+	// Indicates the saga does not exist
+	Error_SAGA_NOT_FOUND Error = 5
+	// Indicates the operation would move the user to a region other than the region the user must reside in
+	Error_DATA_RESIDENCY_VIOLATION Error = 6
+	// Indicates the magic link token is not valid or the magic link is redeemed from another IP address or device than
+	// the one it is bound to
+	Error_MAGIC_LINK_TOKEN_INVALID Error = 7
+	// Indicates the magic link has expired, a new magic link must be issued
+	Error_MAGIC_LINK_TOKEN_EXPIRED Error = 8
+	// Indicates the magic link is already redeemed, a new magic link must be issued
+	Error_MAGIC_LINK_ALREADY_REDEEMED Error = 9
+	// Indicates the email verification token is not the pending verification token of the user
+	Error_EMAIL_VERIFICATION_TOKEN_INVALID Error = 10
+	// Indicates the pending email verification token of the user has expired
+	Error_EMAIL_VERIFICATION_TOKEN_EXPIRED Error = 11
+	// Indicates the operation belongs to an optional feature the service is not running with
+	Error_FEATURE_DISABLED Error = 12
+	// Indicates the user already has a password, ChangePassword must be used to replace it
+	Error_PASSWORD_ALREADY_SET Error = 13
+	// Indicates the password does not match, or the user or its password does not exist
+	Error_PASSWORD_MISMATCH Error = 14
+	// Indicates the user already has as many API keys as allowed, one of them must be revoked first
+	Error_API_KEY_LIMIT_EXCEEDED Error = 15
+	// Indicates a dependency of the service such as the database is not reachable yet, the operation can be retried
+	Error_DEPENDENCY_UNAVAILABLE Error = 16
+	// Indicates the user is locked after too many failed login attempts, an admin must unlock it with UnlockUser
+	Error_USER_LOCKED Error = 17
+	// Indicates the deadline of the call, or the timeout configured for the operation, passed before the operation completed
+	Error_DEADLINE_EXCEEDED Error = 18
+	// Indicates the operation requires the user to accept the current versions of the required policies with RecordConsent first
+	Error_CONSENT_REQUIRED Error = 19
+	// Indicates the group does not exist
+	Error_GROUP_NOT_FOUND Error = 20
+	// Indicates another group already has the name
+	Error_GROUP_ALREADY_EXISTS Error = 21
+	// Indicates the invitation token is not the token of the invitation sent to the email address
+	Error_INVITATION_TOKEN_INVALID Error = 22
+	// Indicates the invitation has expired, the admins have to invite the email address again
+	Error_INVITATION_TOKEN_EXPIRED Error = 23
+	// Indicates the invitation is already accepted, so its token can not be used again
+	Error_INVITATION_ALREADY_ACCEPTED Error = 24
+	// Indicates the user has no active session with the ID
+	Error_SESSION_NOT_FOUND Error = 25
+	// Indicates the session is revoked, so the login it belongs to must not be used anymore
+	Error_SESSION_REVOKED Error = 26
+	// Indicates the user has no webhook subscription with the ID
+	Error_WEBHOOK_SUBSCRIPTION_NOT_FOUND Error = 27
 )
 
 // Enum value maps for Error.
 var (
 	Error_name = map[int32]string{
-		0: "NO_ERROR",
-		1: "UNKNOWN",
-		2: "USER_ALREADY_EXISTS",
-		3: "USER_NOT_FOUND",
-		4: "BAD_REQUEST",
+		0:  "NO_ERROR",
+		1:  "UNKNOWN",
+		2:  "USER_ALREADY_EXISTS",
+		3:  "USER_NOT_FOUND",
+		4:  "BAD_REQUEST",
+		5:  "SAGA_NOT_FOUND",
+		6:  "DATA_RESIDENCY_VIOLATION",
+		7:  "MAGIC_LINK_TOKEN_INVALID",
+		8:  "MAGIC_LINK_TOKEN_EXPIRED",
+		9:  "MAGIC_LINK_ALREADY_REDEEMED",
+		10: "EMAIL_VERIFICATION_TOKEN_INVALID",
+		11: "EMAIL_VERIFICATION_TOKEN_EXPIRED",
+		12: "FEATURE_DISABLED",
+		13: "PASSWORD_ALREADY_SET",
+		14: "PASSWORD_MISMATCH",
+		15: "API_KEY_LIMIT_EXCEEDED",
+		16: "DEPENDENCY_UNAVAILABLE",
+		17: "USER_LOCKED",
+		18: "DEADLINE_EXCEEDED",
+		19: "CONSENT_REQUIRED",
+		20: "GROUP_NOT_FOUND",
+		21: "GROUP_ALREADY_EXISTS",
+		22: "INVITATION_TOKEN_INVALID",
+		23: "INVITATION_TOKEN_EXPIRED",
+		24: "INVITATION_ALREADY_ACCEPTED",
+		25: "SESSION_NOT_FOUND",
+		26: "SESSION_REVOKED",
+		27: "WEBHOOK_SUBSCRIPTION_NOT_FOUND",
 	}
 	Error_value = map[string]int32{
-		"NO_ERROR":            0,
-		"UNKNOWN":             1,
-		"USER_ALREADY_EXISTS": 2,
-		"USER_NOT_FOUND":      3,
-		"BAD_REQUEST":         4,
+		"NO_ERROR":                         0,
+		"UNKNOWN":                          1,
+		"USER_ALREADY_EXISTS":              2,
+		"USER_NOT_FOUND":                   3,
+		"BAD_REQUEST":                      4,
+		"SAGA_NOT_FOUND":                   5,
+		"DATA_RESIDENCY_VIOLATION":         6,
+		"MAGIC_LINK_TOKEN_INVALID":         7,
+		"MAGIC_LINK_TOKEN_EXPIRED":         8,
+		"MAGIC_LINK_ALREADY_REDEEMED":      9,
+		"EMAIL_VERIFICATION_TOKEN_INVALID": 10,
+		"EMAIL_VERIFICATION_TOKEN_EXPIRED": 11,
+		"FEATURE_DISABLED":                 12,
+		"PASSWORD_ALREADY_SET":             13,
+		"PASSWORD_MISMATCH":                14,
+		"API_KEY_LIMIT_EXCEEDED":           15,
+		"DEPENDENCY_UNAVAILABLE":           16,
+		"USER_LOCKED":                      17,
+		"DEADLINE_EXCEEDED":                18,
+		"CONSENT_REQUIRED":                 19,
+		"GROUP_NOT_FOUND":                  20,
+		"GROUP_ALREADY_EXISTS":             21,
+		"INVITATION_TOKEN_INVALID":         22,
+		"INVITATION_TOKEN_EXPIRED":         23,
+		"INVITATION_ALREADY_ACCEPTED":      24,
+		"SESSION_NOT_FOUND":                25,
+		"SESSION_REVOKED":                  26,
+		"WEBHOOK_SUBSCRIPTION_NOT_FOUND":   27,
 	}
 )
 
@@ -82,18 +175,250 @@ func (Error) EnumDescriptor() ([]byte, []int) {
 	return file_user_commons_proto_rawDescGZIP(), []int{0}
 }
 
+//*
+// Warns the client about using a deprecated operation or field
+type DeprecationWarning struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The full name of the deprecated operation or field, e.g. user.Service.ReadUser or user.ReadUserRequest.email
+	Subject string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Explains what the client should use instead
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *DeprecationWarning) Reset() {
+	*x = DeprecationWarning{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_commons_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeprecationWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeprecationWarning) ProtoMessage() {}
+
+func (x *DeprecationWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_user_commons_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeprecationWarning.ProtoReflect.Descriptor instead.
+func (*DeprecationWarning) Descriptor() ([]byte, []int) {
+	return file_user_commons_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeprecationWarning) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *DeprecationWarning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+//*
+// Describes why a field of the request is invalid, so the clients can show the failure next to the field
+type ValidationError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The path of the invalid field, the nested fields and the items of the lists are separated by dots, e.g.
+	// emails.0
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// The reason the field is invalid, one of required, length, format, range, not_allowed or invalid
+	Code string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	// The reason the field is invalid in English
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ValidationError) Reset() {
+	*x = ValidationError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_commons_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidationError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationError) ProtoMessage() {}
+
+func (x *ValidationError) ProtoReflect() protoreflect.Message {
+	mi := &file_user_commons_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationError.ProtoReflect.Descriptor instead.
+func (*ValidationError) Descriptor() ([]byte, []int) {
+	return file_user_commons_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ValidationError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ValidationError) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ValidationError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+//*
+// Packed in the error details of the responses to the invalid requests, reporting every invalid field of the request
+type ValidationErrors struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The validation failures of the invalid fields
+	Errors []*ValidationError `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *ValidationErrors) Reset() {
+	*x = ValidationErrors{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_user_commons_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidationErrors) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationErrors) ProtoMessage() {}
+
+func (x *ValidationErrors) ProtoReflect() protoreflect.Message {
+	mi := &file_user_commons_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationErrors.ProtoReflect.Descriptor instead.
+func (*ValidationErrors) Descriptor() ([]byte, []int) {
+	return file_user_commons_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ValidationErrors) GetErrors() []*ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
 var File_user_commons_proto protoreflect.FileDescriptor
 
 var file_user_commons_proto_rawDesc = []byte{
 	0x0a, 0x12, 0x75, 0x73, 0x65, 0x72, 0x2d, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x73, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x75, 0x73, 0x65, 0x72, 0x2a, 0x60, 0x0a, 0x05, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x4f, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10,
-	0x00, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x01, 0x12, 0x17,
-	0x0a, 0x13, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x41, 0x4c, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x45,
-	0x58, 0x49, 0x53, 0x54, 0x53, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x55, 0x53, 0x45, 0x52, 0x5f,
-	0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x42,
-	0x41, 0x44, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x04, 0x42, 0x06, 0x5a, 0x04,
-	0x75, 0x73, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x75, 0x73, 0x65, 0x72, 0x22, 0x48, 0x0a, 0x12, 0x44, 0x65,
+	0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x55, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x41, 0x0a, 0x10, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12,
+	0x2d, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2a, 0xd4,
+	0x05, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x4f, 0x5f, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
+	0x4e, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x41, 0x4c, 0x52, 0x45,
+	0x41, 0x44, 0x59, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x53, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e,
+	0x55, 0x53, 0x45, 0x52, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x03,
+	0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x41, 0x44, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10,
+	0x04, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x41, 0x47, 0x41, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f,
+	0x55, 0x4e, 0x44, 0x10, 0x05, 0x12, 0x1c, 0x0a, 0x18, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x52, 0x45,
+	0x53, 0x49, 0x44, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x56, 0x49, 0x4f, 0x4c, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x10, 0x06, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x41, 0x47, 0x49, 0x43, 0x5f, 0x4c, 0x49, 0x4e,
+	0x4b, 0x5f, 0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10,
+	0x07, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x41, 0x47, 0x49, 0x43, 0x5f, 0x4c, 0x49, 0x4e, 0x4b, 0x5f,
+	0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x08, 0x12,
+	0x1f, 0x0a, 0x1b, 0x4d, 0x41, 0x47, 0x49, 0x43, 0x5f, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x41, 0x4c,
+	0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x52, 0x45, 0x44, 0x45, 0x45, 0x4d, 0x45, 0x44, 0x10, 0x09,
+	0x12, 0x24, 0x0a, 0x20, 0x45, 0x4d, 0x41, 0x49, 0x4c, 0x5f, 0x56, 0x45, 0x52, 0x49, 0x46, 0x49,
+	0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x5f, 0x49, 0x4e, 0x56,
+	0x41, 0x4c, 0x49, 0x44, 0x10, 0x0a, 0x12, 0x24, 0x0a, 0x20, 0x45, 0x4d, 0x41, 0x49, 0x4c, 0x5f,
+	0x56, 0x45, 0x52, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x4f, 0x4b,
+	0x45, 0x4e, 0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x0b, 0x12, 0x14, 0x0a, 0x10,
+	0x46, 0x45, 0x41, 0x54, 0x55, 0x52, 0x45, 0x5f, 0x44, 0x49, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x44,
+	0x10, 0x0c, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x41, 0x53, 0x53, 0x57, 0x4f, 0x52, 0x44, 0x5f, 0x41,
+	0x4c, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x53, 0x45, 0x54, 0x10, 0x0d, 0x12, 0x15, 0x0a, 0x11,
+	0x50, 0x41, 0x53, 0x53, 0x57, 0x4f, 0x52, 0x44, 0x5f, 0x4d, 0x49, 0x53, 0x4d, 0x41, 0x54, 0x43,
+	0x48, 0x10, 0x0e, 0x12, 0x1a, 0x0a, 0x16, 0x41, 0x50, 0x49, 0x5f, 0x4b, 0x45, 0x59, 0x5f, 0x4c,
+	0x49, 0x4d, 0x49, 0x54, 0x5f, 0x45, 0x58, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x0f, 0x12,
+	0x1a, 0x0a, 0x16, 0x44, 0x45, 0x50, 0x45, 0x4e, 0x44, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x55, 0x4e,
+	0x41, 0x56, 0x41, 0x49, 0x4c, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x10, 0x12, 0x0f, 0x0a, 0x0b, 0x55,
+	0x53, 0x45, 0x52, 0x5f, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x11, 0x12, 0x15, 0x0a, 0x11,
+	0x44, 0x45, 0x41, 0x44, 0x4c, 0x49, 0x4e, 0x45, 0x5f, 0x45, 0x58, 0x43, 0x45, 0x45, 0x44, 0x45,
+	0x44, 0x10, 0x12, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x4f, 0x4e, 0x53, 0x45, 0x4e, 0x54, 0x5f, 0x52,
+	0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x44, 0x10, 0x13, 0x12, 0x13, 0x0a, 0x0f, 0x47, 0x52, 0x4f,
+	0x55, 0x50, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x14, 0x12, 0x18,
+	0x0a, 0x14, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x5f, 0x41, 0x4c, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f,
+	0x45, 0x58, 0x49, 0x53, 0x54, 0x53, 0x10, 0x15, 0x12, 0x1c, 0x0a, 0x18, 0x49, 0x4e, 0x56, 0x49,
+	0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x5f, 0x49, 0x4e, 0x56,
+	0x41, 0x4c, 0x49, 0x44, 0x10, 0x16, 0x12, 0x1c, 0x0a, 0x18, 0x49, 0x4e, 0x56, 0x49, 0x54, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x5f, 0x45, 0x58, 0x50, 0x49, 0x52,
+	0x45, 0x44, 0x10, 0x17, 0x12, 0x1f, 0x0a, 0x1b, 0x49, 0x4e, 0x56, 0x49, 0x54, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x41, 0x4c, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x41, 0x43, 0x43, 0x45, 0x50,
+	0x54, 0x45, 0x44, 0x10, 0x18, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e,
+	0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x19, 0x12, 0x13, 0x0a, 0x0f,
+	0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x56, 0x4f, 0x4b, 0x45, 0x44, 0x10,
+	0x1a, 0x12, 0x22, 0x0a, 0x1e, 0x57, 0x45, 0x42, 0x48, 0x4f, 0x4f, 0x4b, 0x5f, 0x53, 0x55, 0x42,
+	0x53, 0x43, 0x52, 0x49, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f,
+	0x55, 0x4e, 0x44, 0x10, 0x1b, 0x42, 0x06, 0x5a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -109,15 +434,20 @@ func file_user_commons_proto_rawDescGZIP() []byte {
 }
 
 var file_user_commons_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_user_commons_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_user_commons_proto_goTypes = []interface{}{
-	(Error)(0), // 0: user.Error
+	(Error)(0),                 // 0: user.Error
+	(*DeprecationWarning)(nil), // 1: user.DeprecationWarning
+	(*ValidationError)(nil),    // 2: user.ValidationError
+	(*ValidationErrors)(nil),   // 3: user.ValidationErrors
 }
 var file_user_commons_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	2, // 0: user.ValidationErrors.errors:type_name -> user.ValidationError
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_user_commons_proto_init() }
@@ -125,19 +455,58 @@ func file_user_commons_proto_init() {
 	if File_user_commons_proto != nil {
 		return
 	}
+	if !protoimpl.UnsafeEnabled {
+		file_user_commons_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeprecationWarning); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_commons_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidationError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_user_commons_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidationErrors); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_user_commons_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   0,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_user_commons_proto_goTypes,
 		DependencyIndexes: file_user_commons_proto_depIdxs,
 		EnumInfos:         file_user_commons_proto_enumTypes,
+		MessageInfos:      file_user_commons_proto_msgTypes,
 	}.Build()
 	File_user_commons_proto = out.File
 	file_user_commons_proto_rawDesc = nil
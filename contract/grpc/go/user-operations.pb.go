@@ -29,7 +29,7 @@ var file_user_operations_proto_rawDesc = []byte{
 	0x0a, 0x15, 0x75, 0x73, 0x65, 0x72, 0x2d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
 	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x75, 0x73, 0x65, 0x72, 0x1a, 0x13, 0x75,
 	0x73, 0x65, 0x72, 0x2d, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x32, 0x87, 0x02, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3f,
+	0x74, 0x6f, 0x32, 0x93, 0x29, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3f,
 	0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x17, 0x2e, 0x75,
 	0x73, 0x65, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x43, 0x72, 0x65,
@@ -37,42 +37,609 @@ var file_user_operations_proto_rawDesc = []byte{
 	0x39, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x64, 0x55, 0x73, 0x65, 0x72, 0x12, 0x15, 0x2e, 0x75, 0x73,
 	0x65, 0x72, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x55, 0x73,
-	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x17, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55,
-	0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x17, 0x2e, 0x75, 0x73, 0x65, 0x72,
-	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x06, 0x5a, 0x04,
-	0x75, 0x73, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x52, 0x65,
+	0x61, 0x64, 0x55, 0x73, 0x65, 0x72, 0x42, 0x79, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1c, 0x2e,
+	0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x55, 0x73, 0x65, 0x72, 0x42, 0x79, 0x45,
+	0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x55, 0x73, 0x65, 0x72, 0x42, 0x79, 0x45, 0x6d, 0x61,
+	0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x52, 0x65,
+	0x61, 0x64, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x16, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52,
+	0x65, 0x61, 0x64, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x55, 0x73, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x17, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a,
+	0x12, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x1f, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72,
+	0x6d, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1f, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x45, 0x6d, 0x61, 0x69, 0x6c,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e,
+	0x75, 0x73, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x45, 0x6d, 0x61, 0x69,
+	0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3f, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x17, 0x2e,
+	0x75, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x42, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12,
+	0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x55, 0x73,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53, 0x61, 0x67, 0x61, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x61, 0x67, 0x61, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1b, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x61, 0x67, 0x61,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51,
+	0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x73, 0x12, 0x1d, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x75, 0x64,
+	0x69, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x33, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x13, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x14, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x11, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x55,
+	0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x57, 0x69, 0x74, 0x68, 0x43, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x30, 0x01, 0x12, 0x6c, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x26, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x66, 0x66, 0x65, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
+	0x47, 0x65, 0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x57, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x46,
+	0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x47,
+	0x65, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
+	0x47, 0x65, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x16, 0x50, 0x72,
+	0x65, 0x76, 0x69, 0x65, 0x77, 0x42, 0x75, 0x6c, 0x6b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55,
+	0x73, 0x65, 0x72, 0x73, 0x12, 0x23, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x65, 0x76,
+	0x69, 0x65, 0x77, 0x42, 0x75, 0x6c, 0x6b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x2e, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x42, 0x75, 0x6c, 0x6b, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4e, 0x0a, 0x0f, 0x42, 0x75, 0x6c, 0x6b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65,
+	0x72, 0x73, 0x12, 0x1c, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x45, 0x0a, 0x0c, 0x50, 0x75, 0x72, 0x67, 0x65, 0x42, 0x79, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12,
+	0x19, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x42, 0x79, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x2e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x42, 0x79, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74,
+	0x62, 0x6f, 0x78, 0x4c, 0x61, 0x67, 0x12, 0x19, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x47, 0x65,
+	0x74, 0x4f, 0x75, 0x74, 0x62, 0x6f, 0x78, 0x4c, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x62,
+	0x6f, 0x78, 0x4c, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a,
+	0x11, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x1e, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x46, 0x6c, 0x75, 0x73,
+	0x68, 0x12, 0x17, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x46, 0x6c,
+	0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x2e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x46, 0x6c, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x50,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a,
+	0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x50, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x50, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x48, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x41, 0x76, 0x61, 0x74, 0x61, 0x72,
+	0x12, 0x1a, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x41,
+	0x76, 0x61, 0x74, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x41, 0x76, 0x61, 0x74, 0x61,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0d, 0x53, 0x65, 0x74,
+	0x55, 0x73, 0x65, 0x72, 0x41, 0x76, 0x61, 0x74, 0x61, 0x72, 0x12, 0x1a, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x2e, 0x53, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x41, 0x76, 0x61, 0x74, 0x61, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x65,
+	0x74, 0x55, 0x73, 0x65, 0x72, 0x41, 0x76, 0x61, 0x74, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x12, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x65, 0x72,
+	0x73, 0x6f, 0x6e, 0x61, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x6c, 0x44,
+	0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x6c,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x09,
+	0x45, 0x72, 0x61, 0x73, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x16, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x2e, 0x45, 0x72, 0x61, 0x73, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x45, 0x72, 0x61, 0x73, 0x65, 0x55, 0x73,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x41, 0x64,
+	0x64, 0x55, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x1c, 0x2e,
+	0x75, 0x73, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x55, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x54, 0x65,
+	0x6e, 0x61, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x55, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x54, 0x65, 0x6e, 0x61,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x14, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x65, 0x6e, 0x61,
+	0x6e, 0x74, 0x12, 0x21, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x55, 0x73, 0x65, 0x72, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x65, 0x6e, 0x61, 0x6e,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x4c, 0x69, 0x73,
+	0x74, 0x55, 0x73, 0x65, 0x72, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x54, 0x65, 0x6e, 0x61,
+	0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x14, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x21, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0b, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x14, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x57, 0x69, 0x74,
+	0x68, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x30, 0x01, 0x12, 0x4b, 0x0a, 0x0e, 0x49, 0x73, 0x73,
+	0x75, 0x65, 0x4d, 0x61, 0x67, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x1b, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x4d, 0x61, 0x67, 0x69, 0x63, 0x4c, 0x69, 0x6e,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
+	0x49, 0x73, 0x73, 0x75, 0x65, 0x4d, 0x61, 0x67, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x52, 0x65, 0x64, 0x65, 0x65, 0x6d,
+	0x4d, 0x61, 0x67, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x1c, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x2e, 0x52, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x4d, 0x61, 0x67, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52,
+	0x65, 0x64, 0x65, 0x65, 0x6d, 0x4d, 0x61, 0x67, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x15, 0x53, 0x65, 0x6e, 0x64, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12,
+	0x22, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6d, 0x61, 0x69, 0x6c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x45,
+	0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b,
+	0x53, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x18, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x74,
+	0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x4b, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x12, 0x1b, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a,
+	0x0e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12,
+	0x1b, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x55, 0x73, 0x65, 0x72, 0x12, 0x16, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x10, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x30, 0x01, 0x12, 0x39, 0x0a, 0x0a, 0x57, 0x61, 0x74, 0x63, 0x68, 0x55, 0x73, 0x65, 0x72,
+	0x73, 0x12, 0x17, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x55, 0x73,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x30, 0x01, 0x12, 0x45,
+	0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x12, 0x19,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x50, 0x49, 0x4b,
+	0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x50, 0x49,
+	0x4b, 0x65, 0x79, 0x73, 0x12, 0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x0c, 0x52, 0x65, 0x76,
+	0x6f, 0x6b, 0x65, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x12, 0x19, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x76, 0x6f,
+	0x6b, 0x65, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x57, 0x0a, 0x12, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x41,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x12, 0x1f, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x55, 0x6e, 0x6c,
+	0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x12, 0x17, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55,
+	0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x45, 0x6e,
+	0x72, 0x6f, 0x6c, 0x6c, 0x4d, 0x46, 0x41, 0x12, 0x16, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x45,
+	0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x4d, 0x46, 0x41, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x4d, 0x46, 0x41,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74,
+	0x4d, 0x46, 0x41, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x12, 0x1b, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x46, 0x41, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x4d, 0x46, 0x41, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4d,
+	0x46, 0x41, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1c, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4d, 0x46, 0x41, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x4d, 0x46, 0x41, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x43,
+	0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x45, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x19, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x65,
+	0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x75, 0x73, 0x65,
+	0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x52, 0x65,
+	0x61, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x16, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52,
+	0x65, 0x61, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x18, 0x2e, 0x75, 0x73,
+	0x65, 0x72, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3f, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x17,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4b, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x55, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x12, 0x1b, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x55, 0x73,
+	0x65, 0x72, 0x54, 0x6f, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1c, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x55, 0x73, 0x65, 0x72, 0x54,
+	0x6f, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a,
+	0x0a, 0x13, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x46, 0x72, 0x6f, 0x6d,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x20, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x46, 0x72, 0x6f, 0x6d, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x46, 0x72, 0x6f, 0x6d, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x49, 0x6e,
+	0x76, 0x69, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x17, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
+	0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x55,
+	0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x10, 0x41,
+	0x63, 0x63, 0x65, 0x70, 0x74, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1d, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x49, 0x6e, 0x76,
+	0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x49, 0x6e, 0x76, 0x69,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e,
+	0x0a, 0x0f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x1c, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45,
+	0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19,
+	0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0d, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65,
+	0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x6c, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x2e, 0x75,
+	0x73, 0x65, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a,
+	0x18, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25, 0x2e, 0x75, 0x73, 0x65, 0x72,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x26, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x65, 0x62, 0x68,
+	0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6c, 0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e,
+	0x75, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f,
+	0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0x22, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f,
+	0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x57,
+	0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x06, 0x5a, 0x04, 0x75, 0x73, 0x65, 0x72,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_user_operations_proto_goTypes = []interface{}{
-	(*CreateUserRequest)(nil),  // 0: user.CreateUserRequest
-	(*ReadUserRequest)(nil),    // 1: user.ReadUserRequest
-	(*UpdateUserRequest)(nil),  // 2: user.UpdateUserRequest
-	(*DeleteUserRequest)(nil),  // 3: user.DeleteUserRequest
-	(*CreateUserResponse)(nil), // 4: user.CreateUserResponse
-	(*ReadUserResponse)(nil),   // 5: user.ReadUserResponse
-	(*UpdateUserResponse)(nil), // 6: user.UpdateUserResponse
-	(*DeleteUserResponse)(nil), // 7: user.DeleteUserResponse
+	(*CreateUserRequest)(nil),                 // 0: user.CreateUserRequest
+	(*ReadUserRequest)(nil),                   // 1: user.ReadUserRequest
+	(*ReadUserByEmailRequest)(nil),            // 2: user.ReadUserByEmailRequest
+	(*ReadUsersRequest)(nil),                  // 3: user.ReadUsersRequest
+	(*UpdateUserRequest)(nil),                 // 4: user.UpdateUserRequest
+	(*ChangeEmailRequest)(nil),                // 5: user.ChangeEmailRequest
+	(*RequestEmailChangeRequest)(nil),         // 6: user.RequestEmailChangeRequest
+	(*ConfirmEmailChangeRequest)(nil),         // 7: user.ConfirmEmailChangeRequest
+	(*DeleteUserRequest)(nil),                 // 8: user.DeleteUserRequest
+	(*RestoreUserRequest)(nil),                // 9: user.RestoreUserRequest
+	(*GetSagaStatusRequest)(nil),              // 10: user.GetSagaStatusRequest
+	(*ListAuditRecordsRequest)(nil),           // 11: user.ListAuditRecordsRequest
+	(*SearchRequest)(nil),                     // 12: user.SearchRequest
+	(*StreamSearchUsersRequest)(nil),          // 13: user.StreamSearchUsersRequest
+	(*GetEffectiveConfigurationRequest)(nil),  // 14: user.GetEffectiveConfigurationRequest
+	(*GetEnabledFeaturesRequest)(nil),         // 15: user.GetEnabledFeaturesRequest
+	(*PreviewBulkUpdateUsersRequest)(nil),     // 16: user.PreviewBulkUpdateUsersRequest
+	(*BulkUpdateUsersRequest)(nil),            // 17: user.BulkUpdateUsersRequest
+	(*PurgeByLabelRequest)(nil),               // 18: user.PurgeByLabelRequest
+	(*GetOutboxLagRequest)(nil),               // 19: user.GetOutboxLagRequest
+	(*ListPendingEventsRequest)(nil),          // 20: user.ListPendingEventsRequest
+	(*ForceFlushRequest)(nil),                 // 21: user.ForceFlushRequest
+	(*GetUserPreferencesRequest)(nil),         // 22: user.GetUserPreferencesRequest
+	(*UpdateUserPreferencesRequest)(nil),      // 23: user.UpdateUserPreferencesRequest
+	(*GetUserAvatarRequest)(nil),              // 24: user.GetUserAvatarRequest
+	(*SetUserAvatarRequest)(nil),              // 25: user.SetUserAvatarRequest
+	(*ExportPersonalDataRequest)(nil),         // 26: user.ExportPersonalDataRequest
+	(*EraseUserRequest)(nil),                  // 27: user.EraseUserRequest
+	(*AddUserToTenantRequest)(nil),            // 28: user.AddUserToTenantRequest
+	(*RemoveUserFromTenantRequest)(nil),       // 29: user.RemoveUserFromTenantRequest
+	(*ListUserTenantsRequest)(nil),            // 30: user.ListUserTenantsRequest
+	(*GetReplicationStatusRequest)(nil),       // 31: user.GetReplicationStatusRequest
+	(*ExportUsersRequest)(nil),                // 32: user.ExportUsersRequest
+	(*IssueMagicLinkRequest)(nil),             // 33: user.IssueMagicLinkRequest
+	(*RedeemMagicLinkRequest)(nil),            // 34: user.RedeemMagicLinkRequest
+	(*SendVerificationEmailRequest)(nil),      // 35: user.SendVerificationEmailRequest
+	(*VerifyEmailRequest)(nil),                // 36: user.VerifyEmailRequest
+	(*SetPasswordRequest)(nil),                // 37: user.SetPasswordRequest
+	(*ChangePasswordRequest)(nil),             // 38: user.ChangePasswordRequest
+	(*VerifyPasswordRequest)(nil),             // 39: user.VerifyPasswordRequest
+	(*WatchUserRequest)(nil),                  // 40: user.WatchUserRequest
+	(*WatchUsersRequest)(nil),                 // 41: user.WatchUsersRequest
+	(*CreateAPIKeyRequest)(nil),               // 42: user.CreateAPIKeyRequest
+	(*ListAPIKeysRequest)(nil),                // 43: user.ListAPIKeysRequest
+	(*RevokeAPIKeyRequest)(nil),               // 44: user.RevokeAPIKeyRequest
+	(*RecordLoginAttemptRequest)(nil),         // 45: user.RecordLoginAttemptRequest
+	(*UnlockUserRequest)(nil),                 // 46: user.UnlockUserRequest
+	(*EnrollMFARequest)(nil),                  // 47: user.EnrollMFARequest
+	(*ListMFAMethodsRequest)(nil),             // 48: user.ListMFAMethodsRequest
+	(*RemoveMFAMethodRequest)(nil),            // 49: user.RemoveMFAMethodRequest
+	(*RecordConsentRequest)(nil),              // 50: user.RecordConsentRequest
+	(*ListConsentsRequest)(nil),               // 51: user.ListConsentsRequest
+	(*CreateGroupRequest)(nil),                // 52: user.CreateGroupRequest
+	(*ReadGroupRequest)(nil),                  // 53: user.ReadGroupRequest
+	(*UpdateGroupRequest)(nil),                // 54: user.UpdateGroupRequest
+	(*DeleteGroupRequest)(nil),                // 55: user.DeleteGroupRequest
+	(*ListGroupsRequest)(nil),                 // 56: user.ListGroupsRequest
+	(*AddUserToGroupRequest)(nil),             // 57: user.AddUserToGroupRequest
+	(*RemoveUserFromGroupRequest)(nil),        // 58: user.RemoveUserFromGroupRequest
+	(*InviteUserRequest)(nil),                 // 59: user.InviteUserRequest
+	(*AcceptInvitationRequest)(nil),           // 60: user.AcceptInvitationRequest
+	(*RegisterSessionRequest)(nil),            // 61: user.RegisterSessionRequest
+	(*ListSessionsRequest)(nil),               // 62: user.ListSessionsRequest
+	(*RevokeSessionRequest)(nil),              // 63: user.RevokeSessionRequest
+	(*CreateWebhookSubscriptionRequest)(nil),  // 64: user.CreateWebhookSubscriptionRequest
+	(*ListWebhookSubscriptionsRequest)(nil),   // 65: user.ListWebhookSubscriptionsRequest
+	(*DeleteWebhookSubscriptionRequest)(nil),  // 66: user.DeleteWebhookSubscriptionRequest
+	(*ListWebhookDeliveriesRequest)(nil),      // 67: user.ListWebhookDeliveriesRequest
+	(*CreateUserResponse)(nil),                // 68: user.CreateUserResponse
+	(*ReadUserResponse)(nil),                  // 69: user.ReadUserResponse
+	(*ReadUserByEmailResponse)(nil),           // 70: user.ReadUserByEmailResponse
+	(*ReadUsersResponse)(nil),                 // 71: user.ReadUsersResponse
+	(*UpdateUserResponse)(nil),                // 72: user.UpdateUserResponse
+	(*ChangeEmailResponse)(nil),               // 73: user.ChangeEmailResponse
+	(*RequestEmailChangeResponse)(nil),        // 74: user.RequestEmailChangeResponse
+	(*ConfirmEmailChangeResponse)(nil),        // 75: user.ConfirmEmailChangeResponse
+	(*DeleteUserResponse)(nil),                // 76: user.DeleteUserResponse
+	(*RestoreUserResponse)(nil),               // 77: user.RestoreUserResponse
+	(*GetSagaStatusResponse)(nil),             // 78: user.GetSagaStatusResponse
+	(*ListAuditRecordsResponse)(nil),          // 79: user.ListAuditRecordsResponse
+	(*SearchResponse)(nil),                    // 80: user.SearchResponse
+	(*UserWithCursor)(nil),                    // 81: user.UserWithCursor
+	(*GetEffectiveConfigurationResponse)(nil), // 82: user.GetEffectiveConfigurationResponse
+	(*GetEnabledFeaturesResponse)(nil),        // 83: user.GetEnabledFeaturesResponse
+	(*PreviewBulkUpdateUsersResponse)(nil),    // 84: user.PreviewBulkUpdateUsersResponse
+	(*BulkUpdateUsersResponse)(nil),           // 85: user.BulkUpdateUsersResponse
+	(*PurgeByLabelResponse)(nil),              // 86: user.PurgeByLabelResponse
+	(*GetOutboxLagResponse)(nil),              // 87: user.GetOutboxLagResponse
+	(*ListPendingEventsResponse)(nil),         // 88: user.ListPendingEventsResponse
+	(*ForceFlushResponse)(nil),                // 89: user.ForceFlushResponse
+	(*GetUserPreferencesResponse)(nil),        // 90: user.GetUserPreferencesResponse
+	(*UpdateUserPreferencesResponse)(nil),     // 91: user.UpdateUserPreferencesResponse
+	(*GetUserAvatarResponse)(nil),             // 92: user.GetUserAvatarResponse
+	(*SetUserAvatarResponse)(nil),             // 93: user.SetUserAvatarResponse
+	(*ExportPersonalDataResponse)(nil),        // 94: user.ExportPersonalDataResponse
+	(*EraseUserResponse)(nil),                 // 95: user.EraseUserResponse
+	(*AddUserToTenantResponse)(nil),           // 96: user.AddUserToTenantResponse
+	(*RemoveUserFromTenantResponse)(nil),      // 97: user.RemoveUserFromTenantResponse
+	(*ListUserTenantsResponse)(nil),           // 98: user.ListUserTenantsResponse
+	(*GetReplicationStatusResponse)(nil),      // 99: user.GetReplicationStatusResponse
+	(*IssueMagicLinkResponse)(nil),            // 100: user.IssueMagicLinkResponse
+	(*RedeemMagicLinkResponse)(nil),           // 101: user.RedeemMagicLinkResponse
+	(*SendVerificationEmailResponse)(nil),     // 102: user.SendVerificationEmailResponse
+	(*VerifyEmailResponse)(nil),               // 103: user.VerifyEmailResponse
+	(*SetPasswordResponse)(nil),               // 104: user.SetPasswordResponse
+	(*ChangePasswordResponse)(nil),            // 105: user.ChangePasswordResponse
+	(*VerifyPasswordResponse)(nil),            // 106: user.VerifyPasswordResponse
+	(*UserChange)(nil),                        // 107: user.UserChange
+	(*CreateAPIKeyResponse)(nil),              // 108: user.CreateAPIKeyResponse
+	(*ListAPIKeysResponse)(nil),               // 109: user.ListAPIKeysResponse
+	(*RevokeAPIKeyResponse)(nil),              // 110: user.RevokeAPIKeyResponse
+	(*RecordLoginAttemptResponse)(nil),        // 111: user.RecordLoginAttemptResponse
+	(*UnlockUserResponse)(nil),                // 112: user.UnlockUserResponse
+	(*EnrollMFAResponse)(nil),                 // 113: user.EnrollMFAResponse
+	(*ListMFAMethodsResponse)(nil),            // 114: user.ListMFAMethodsResponse
+	(*RemoveMFAMethodResponse)(nil),           // 115: user.RemoveMFAMethodResponse
+	(*RecordConsentResponse)(nil),             // 116: user.RecordConsentResponse
+	(*ListConsentsResponse)(nil),              // 117: user.ListConsentsResponse
+	(*CreateGroupResponse)(nil),               // 118: user.CreateGroupResponse
+	(*ReadGroupResponse)(nil),                 // 119: user.ReadGroupResponse
+	(*UpdateGroupResponse)(nil),               // 120: user.UpdateGroupResponse
+	(*DeleteGroupResponse)(nil),               // 121: user.DeleteGroupResponse
+	(*ListGroupsResponse)(nil),                // 122: user.ListGroupsResponse
+	(*AddUserToGroupResponse)(nil),            // 123: user.AddUserToGroupResponse
+	(*RemoveUserFromGroupResponse)(nil),       // 124: user.RemoveUserFromGroupResponse
+	(*InviteUserResponse)(nil),                // 125: user.InviteUserResponse
+	(*AcceptInvitationResponse)(nil),          // 126: user.AcceptInvitationResponse
+	(*RegisterSessionResponse)(nil),           // 127: user.RegisterSessionResponse
+	(*ListSessionsResponse)(nil),              // 128: user.ListSessionsResponse
+	(*RevokeSessionResponse)(nil),             // 129: user.RevokeSessionResponse
+	(*CreateWebhookSubscriptionResponse)(nil), // 130: user.CreateWebhookSubscriptionResponse
+	(*ListWebhookSubscriptionsResponse)(nil),  // 131: user.ListWebhookSubscriptionsResponse
+	(*DeleteWebhookSubscriptionResponse)(nil), // 132: user.DeleteWebhookSubscriptionResponse
+	(*ListWebhookDeliveriesResponse)(nil),     // 133: user.ListWebhookDeliveriesResponse
 }
 var file_user_operations_proto_depIdxs = []int32{
-	0, // 0: user.Service.CreateUser:input_type -> user.CreateUserRequest
-	1, // 1: user.Service.ReadUser:input_type -> user.ReadUserRequest
-	2, // 2: user.Service.UpdateUser:input_type -> user.UpdateUserRequest
-	3, // 3: user.Service.DeleteUser:input_type -> user.DeleteUserRequest
-	4, // 4: user.Service.CreateUser:output_type -> user.CreateUserResponse
-	5, // 5: user.Service.ReadUser:output_type -> user.ReadUserResponse
-	6, // 6: user.Service.UpdateUser:output_type -> user.UpdateUserResponse
-	7, // 7: user.Service.DeleteUser:output_type -> user.DeleteUserResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,   // 0: user.Service.CreateUser:input_type -> user.CreateUserRequest
+	1,   // 1: user.Service.ReadUser:input_type -> user.ReadUserRequest
+	2,   // 2: user.Service.ReadUserByEmail:input_type -> user.ReadUserByEmailRequest
+	3,   // 3: user.Service.ReadUsers:input_type -> user.ReadUsersRequest
+	4,   // 4: user.Service.UpdateUser:input_type -> user.UpdateUserRequest
+	5,   // 5: user.Service.ChangeEmail:input_type -> user.ChangeEmailRequest
+	6,   // 6: user.Service.RequestEmailChange:input_type -> user.RequestEmailChangeRequest
+	7,   // 7: user.Service.ConfirmEmailChange:input_type -> user.ConfirmEmailChangeRequest
+	8,   // 8: user.Service.DeleteUser:input_type -> user.DeleteUserRequest
+	9,   // 9: user.Service.RestoreUser:input_type -> user.RestoreUserRequest
+	10,  // 10: user.Service.GetSagaStatus:input_type -> user.GetSagaStatusRequest
+	11,  // 11: user.Service.ListAuditRecords:input_type -> user.ListAuditRecordsRequest
+	12,  // 12: user.Service.Search:input_type -> user.SearchRequest
+	13,  // 13: user.Service.StreamSearchUsers:input_type -> user.StreamSearchUsersRequest
+	14,  // 14: user.Service.GetEffectiveConfiguration:input_type -> user.GetEffectiveConfigurationRequest
+	15,  // 15: user.Service.GetEnabledFeatures:input_type -> user.GetEnabledFeaturesRequest
+	16,  // 16: user.Service.PreviewBulkUpdateUsers:input_type -> user.PreviewBulkUpdateUsersRequest
+	17,  // 17: user.Service.BulkUpdateUsers:input_type -> user.BulkUpdateUsersRequest
+	18,  // 18: user.Service.PurgeByLabel:input_type -> user.PurgeByLabelRequest
+	19,  // 19: user.Service.GetOutboxLag:input_type -> user.GetOutboxLagRequest
+	20,  // 20: user.Service.ListPendingEvents:input_type -> user.ListPendingEventsRequest
+	21,  // 21: user.Service.ForceFlush:input_type -> user.ForceFlushRequest
+	22,  // 22: user.Service.GetUserPreferences:input_type -> user.GetUserPreferencesRequest
+	23,  // 23: user.Service.UpdateUserPreferences:input_type -> user.UpdateUserPreferencesRequest
+	24,  // 24: user.Service.GetUserAvatar:input_type -> user.GetUserAvatarRequest
+	25,  // 25: user.Service.SetUserAvatar:input_type -> user.SetUserAvatarRequest
+	26,  // 26: user.Service.ExportPersonalData:input_type -> user.ExportPersonalDataRequest
+	27,  // 27: user.Service.EraseUser:input_type -> user.EraseUserRequest
+	28,  // 28: user.Service.AddUserToTenant:input_type -> user.AddUserToTenantRequest
+	29,  // 29: user.Service.RemoveUserFromTenant:input_type -> user.RemoveUserFromTenantRequest
+	30,  // 30: user.Service.ListUserTenants:input_type -> user.ListUserTenantsRequest
+	31,  // 31: user.Service.GetReplicationStatus:input_type -> user.GetReplicationStatusRequest
+	32,  // 32: user.Service.ExportUsers:input_type -> user.ExportUsersRequest
+	33,  // 33: user.Service.IssueMagicLink:input_type -> user.IssueMagicLinkRequest
+	34,  // 34: user.Service.RedeemMagicLink:input_type -> user.RedeemMagicLinkRequest
+	35,  // 35: user.Service.SendVerificationEmail:input_type -> user.SendVerificationEmailRequest
+	36,  // 36: user.Service.VerifyEmail:input_type -> user.VerifyEmailRequest
+	37,  // 37: user.Service.SetPassword:input_type -> user.SetPasswordRequest
+	38,  // 38: user.Service.ChangePassword:input_type -> user.ChangePasswordRequest
+	39,  // 39: user.Service.VerifyPassword:input_type -> user.VerifyPasswordRequest
+	40,  // 40: user.Service.WatchUser:input_type -> user.WatchUserRequest
+	41,  // 41: user.Service.WatchUsers:input_type -> user.WatchUsersRequest
+	42,  // 42: user.Service.CreateAPIKey:input_type -> user.CreateAPIKeyRequest
+	43,  // 43: user.Service.ListAPIKeys:input_type -> user.ListAPIKeysRequest
+	44,  // 44: user.Service.RevokeAPIKey:input_type -> user.RevokeAPIKeyRequest
+	45,  // 45: user.Service.RecordLoginAttempt:input_type -> user.RecordLoginAttemptRequest
+	46,  // 46: user.Service.UnlockUser:input_type -> user.UnlockUserRequest
+	47,  // 47: user.Service.EnrollMFA:input_type -> user.EnrollMFARequest
+	48,  // 48: user.Service.ListMFAMethods:input_type -> user.ListMFAMethodsRequest
+	49,  // 49: user.Service.RemoveMFAMethod:input_type -> user.RemoveMFAMethodRequest
+	50,  // 50: user.Service.RecordConsent:input_type -> user.RecordConsentRequest
+	51,  // 51: user.Service.ListConsents:input_type -> user.ListConsentsRequest
+	52,  // 52: user.Service.CreateGroup:input_type -> user.CreateGroupRequest
+	53,  // 53: user.Service.ReadGroup:input_type -> user.ReadGroupRequest
+	54,  // 54: user.Service.UpdateGroup:input_type -> user.UpdateGroupRequest
+	55,  // 55: user.Service.DeleteGroup:input_type -> user.DeleteGroupRequest
+	56,  // 56: user.Service.ListGroups:input_type -> user.ListGroupsRequest
+	57,  // 57: user.Service.AddUserToGroup:input_type -> user.AddUserToGroupRequest
+	58,  // 58: user.Service.RemoveUserFromGroup:input_type -> user.RemoveUserFromGroupRequest
+	59,  // 59: user.Service.InviteUser:input_type -> user.InviteUserRequest
+	60,  // 60: user.Service.AcceptInvitation:input_type -> user.AcceptInvitationRequest
+	61,  // 61: user.Service.RegisterSession:input_type -> user.RegisterSessionRequest
+	62,  // 62: user.Service.ListSessions:input_type -> user.ListSessionsRequest
+	63,  // 63: user.Service.RevokeSession:input_type -> user.RevokeSessionRequest
+	64,  // 64: user.Service.CreateWebhookSubscription:input_type -> user.CreateWebhookSubscriptionRequest
+	65,  // 65: user.Service.ListWebhookSubscriptions:input_type -> user.ListWebhookSubscriptionsRequest
+	66,  // 66: user.Service.DeleteWebhookSubscription:input_type -> user.DeleteWebhookSubscriptionRequest
+	67,  // 67: user.Service.ListWebhookDeliveries:input_type -> user.ListWebhookDeliveriesRequest
+	68,  // 68: user.Service.CreateUser:output_type -> user.CreateUserResponse
+	69,  // 69: user.Service.ReadUser:output_type -> user.ReadUserResponse
+	70,  // 70: user.Service.ReadUserByEmail:output_type -> user.ReadUserByEmailResponse
+	71,  // 71: user.Service.ReadUsers:output_type -> user.ReadUsersResponse
+	72,  // 72: user.Service.UpdateUser:output_type -> user.UpdateUserResponse
+	73,  // 73: user.Service.ChangeEmail:output_type -> user.ChangeEmailResponse
+	74,  // 74: user.Service.RequestEmailChange:output_type -> user.RequestEmailChangeResponse
+	75,  // 75: user.Service.ConfirmEmailChange:output_type -> user.ConfirmEmailChangeResponse
+	76,  // 76: user.Service.DeleteUser:output_type -> user.DeleteUserResponse
+	77,  // 77: user.Service.RestoreUser:output_type -> user.RestoreUserResponse
+	78,  // 78: user.Service.GetSagaStatus:output_type -> user.GetSagaStatusResponse
+	79,  // 79: user.Service.ListAuditRecords:output_type -> user.ListAuditRecordsResponse
+	80,  // 80: user.Service.Search:output_type -> user.SearchResponse
+	81,  // 81: user.Service.StreamSearchUsers:output_type -> user.UserWithCursor
+	82,  // 82: user.Service.GetEffectiveConfiguration:output_type -> user.GetEffectiveConfigurationResponse
+	83,  // 83: user.Service.GetEnabledFeatures:output_type -> user.GetEnabledFeaturesResponse
+	84,  // 84: user.Service.PreviewBulkUpdateUsers:output_type -> user.PreviewBulkUpdateUsersResponse
+	85,  // 85: user.Service.BulkUpdateUsers:output_type -> user.BulkUpdateUsersResponse
+	86,  // 86: user.Service.PurgeByLabel:output_type -> user.PurgeByLabelResponse
+	87,  // 87: user.Service.GetOutboxLag:output_type -> user.GetOutboxLagResponse
+	88,  // 88: user.Service.ListPendingEvents:output_type -> user.ListPendingEventsResponse
+	89,  // 89: user.Service.ForceFlush:output_type -> user.ForceFlushResponse
+	90,  // 90: user.Service.GetUserPreferences:output_type -> user.GetUserPreferencesResponse
+	91,  // 91: user.Service.UpdateUserPreferences:output_type -> user.UpdateUserPreferencesResponse
+	92,  // 92: user.Service.GetUserAvatar:output_type -> user.GetUserAvatarResponse
+	93,  // 93: user.Service.SetUserAvatar:output_type -> user.SetUserAvatarResponse
+	94,  // 94: user.Service.ExportPersonalData:output_type -> user.ExportPersonalDataResponse
+	95,  // 95: user.Service.EraseUser:output_type -> user.EraseUserResponse
+	96,  // 96: user.Service.AddUserToTenant:output_type -> user.AddUserToTenantResponse
+	97,  // 97: user.Service.RemoveUserFromTenant:output_type -> user.RemoveUserFromTenantResponse
+	98,  // 98: user.Service.ListUserTenants:output_type -> user.ListUserTenantsResponse
+	99,  // 99: user.Service.GetReplicationStatus:output_type -> user.GetReplicationStatusResponse
+	81,  // 100: user.Service.ExportUsers:output_type -> user.UserWithCursor
+	100, // 101: user.Service.IssueMagicLink:output_type -> user.IssueMagicLinkResponse
+	101, // 102: user.Service.RedeemMagicLink:output_type -> user.RedeemMagicLinkResponse
+	102, // 103: user.Service.SendVerificationEmail:output_type -> user.SendVerificationEmailResponse
+	103, // 104: user.Service.VerifyEmail:output_type -> user.VerifyEmailResponse
+	104, // 105: user.Service.SetPassword:output_type -> user.SetPasswordResponse
+	105, // 106: user.Service.ChangePassword:output_type -> user.ChangePasswordResponse
+	106, // 107: user.Service.VerifyPassword:output_type -> user.VerifyPasswordResponse
+	107, // 108: user.Service.WatchUser:output_type -> user.UserChange
+	107, // 109: user.Service.WatchUsers:output_type -> user.UserChange
+	108, // 110: user.Service.CreateAPIKey:output_type -> user.CreateAPIKeyResponse
+	109, // 111: user.Service.ListAPIKeys:output_type -> user.ListAPIKeysResponse
+	110, // 112: user.Service.RevokeAPIKey:output_type -> user.RevokeAPIKeyResponse
+	111, // 113: user.Service.RecordLoginAttempt:output_type -> user.RecordLoginAttemptResponse
+	112, // 114: user.Service.UnlockUser:output_type -> user.UnlockUserResponse
+	113, // 115: user.Service.EnrollMFA:output_type -> user.EnrollMFAResponse
+	114, // 116: user.Service.ListMFAMethods:output_type -> user.ListMFAMethodsResponse
+	115, // 117: user.Service.RemoveMFAMethod:output_type -> user.RemoveMFAMethodResponse
+	116, // 118: user.Service.RecordConsent:output_type -> user.RecordConsentResponse
+	117, // 119: user.Service.ListConsents:output_type -> user.ListConsentsResponse
+	118, // 120: user.Service.CreateGroup:output_type -> user.CreateGroupResponse
+	119, // 121: user.Service.ReadGroup:output_type -> user.ReadGroupResponse
+	120, // 122: user.Service.UpdateGroup:output_type -> user.UpdateGroupResponse
+	121, // 123: user.Service.DeleteGroup:output_type -> user.DeleteGroupResponse
+	122, // 124: user.Service.ListGroups:output_type -> user.ListGroupsResponse
+	123, // 125: user.Service.AddUserToGroup:output_type -> user.AddUserToGroupResponse
+	124, // 126: user.Service.RemoveUserFromGroup:output_type -> user.RemoveUserFromGroupResponse
+	125, // 127: user.Service.InviteUser:output_type -> user.InviteUserResponse
+	126, // 128: user.Service.AcceptInvitation:output_type -> user.AcceptInvitationResponse
+	127, // 129: user.Service.RegisterSession:output_type -> user.RegisterSessionResponse
+	128, // 130: user.Service.ListSessions:output_type -> user.ListSessionsResponse
+	129, // 131: user.Service.RevokeSession:output_type -> user.RevokeSessionResponse
+	130, // 132: user.Service.CreateWebhookSubscription:output_type -> user.CreateWebhookSubscriptionResponse
+	131, // 133: user.Service.ListWebhookSubscriptions:output_type -> user.ListWebhookSubscriptionsResponse
+	132, // 134: user.Service.DeleteWebhookSubscription:output_type -> user.DeleteWebhookSubscriptionResponse
+	133, // 135: user.Service.ListWebhookDeliveries:output_type -> user.ListWebhookDeliveriesResponse
+	68,  // [68:136] is the sub-list for method output_type
+	0,   // [0:68] is the sub-list for method input_type
+	0,   // [0:0] is the sub-list for extension type_name
+	0,   // [0:0] is the sub-list for extension extendee
+	0,   // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_user_operations_proto_init() }
@@ -120,14 +687,335 @@ type ServiceClient interface {
 	// request: The request to read an existing user
 	// Returns the result of reading an existing user
 	ReadUser(ctx context.Context, in *ReadUserRequest, opts ...grpc.CallOption) (*ReadUserResponse, error)
+	// ReadUserByEmail reads an exsiting user by its email address
+	// request: The request to read an existing user by its email address
+	// Returns the result of reading an existing user
+	ReadUserByEmail(ctx context.Context, in *ReadUserByEmailRequest, opts ...grpc.CallOption) (*ReadUserByEmailResponse, error)
+	// ReadUsers reads the exsiting users with the given email addresses or IDs at once, the users can only read
+	// themselves unless they are admins
+	// request: The request to read the existing users
+	// Returns the users found and the email addresses and the IDs of the users not found
+	ReadUsers(ctx context.Context, in *ReadUsersRequest, opts ...grpc.CallOption) (*ReadUsersResponse, error)
 	// UpdateUser updates an exsiting user
 	// request: The request to update an existing user
 	// Returns the result of updateing an existing user
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
+	// ChangeEmail changes the email address of an exsiting user, the user keeps its userID. Fails with BAD_REQUEST if the
+	// email address changes must be confirmed, RequestEmailChange and ConfirmEmailChange change it instead
+	// request: The request to change the email address of an existing user
+	// Returns the result of changing the email address of an existing user
+	ChangeEmail(ctx context.Context, in *ChangeEmailRequest, opts ...grpc.CallOption) (*ChangeEmailResponse, error)
+	// RequestEmailChange sends the token that confirms changing the email address of an existing user to the new email
+	// address, fails with FEATURE_DISABLED unless the email address changes must be confirmed
+	// request: The request to change the email address of an existing user
+	// Returns the time the token expires at
+	RequestEmailChange(ctx context.Context, in *RequestEmailChangeRequest, opts ...grpc.CallOption) (*RequestEmailChangeResponse, error)
+	// ConfirmEmailChange changes the email address of an existing user to the one the token is sent to and notifies the
+	// previous email address, fails with EMAIL_VERIFICATION_TOKEN_INVALID or EMAIL_VERIFICATION_TOKEN_EXPIRED if the token
+	// can not confirm the change
+	// request: The request contains the token sent to the new email address
+	// Returns the user with the new email address
+	ConfirmEmailChange(ctx context.Context, in *ConfirmEmailChangeRequest, opts ...grpc.CallOption) (*ConfirmEmailChangeResponse, error)
 	// DeleteUser deletes an exsiting user
 	// request: The request to delete an existing user
 	// Returns the result of deleting an existing user
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
+	// RestoreUser restores an exsiting soft deleted user
+	// request: The request to restore an existing soft deleted user
+	// Returns the result of restoring an existing soft deleted user
+	RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error)
+	// GetSagaStatus reads the progress of an exsiting saga. Only the admins are allowed to call this operation
+	// request: The request to read the progress of an existing saga
+	// Returns the progress of the saga
+	GetSagaStatus(ctx context.Context, in *GetSagaStatusRequest, opts ...grpc.CallOption) (*GetSagaStatusResponse, error)
+	// ListAuditRecords lists the audit records of the mutating operations that matched the criteria. Only the admins are allowed to call this operation
+	// request: The request contains the criteria the audit records are listed by
+	// Returns the audit records that matched the criteria, the latest first
+	ListAuditRecords(ctx context.Context, in *ListAuditRecordsRequest, opts ...grpc.CallOption) (*ListAuditRecordsResponse, error)
+	// Search returns the list of users that matched the search criteria. Only the admins are allowed to call this operation
+	// request: The request contains the search criteria
+	// Returns the list of users that matched the search criteria
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// StreamSearchUsers streams the users that matched the search criteria one by one, so large result sets are not
+	// loaded in memory. The stream fails with the gRPC status of the error if something goes wrong. Only the admins are
+	// allowed to call this operation
+	// request: The request contains the search criteria
+	// Returns the stream of the users that matched the search criteria
+	StreamSearchUsers(ctx context.Context, in *StreamSearchUsersRequest, opts ...grpc.CallOption) (Service_StreamSearchUsersClient, error)
+	// GetEffectiveConfiguration reads the configuration the replica is running with, the secrets are redacted. Only the admins are allowed to call this operation
+	// request: The request to read the effective configuration
+	// Returns the effective configuration
+	GetEffectiveConfiguration(ctx context.Context, in *GetEffectiveConfigurationRequest, opts ...grpc.CallOption) (*GetEffectiveConfigurationResponse, error)
+	// GetEnabledFeatures reads the optional features the replica is running with. Only the admins are allowed to call this operation
+	// request: The request to read the enabled features
+	// Returns the optional features and whether they are enabled
+	GetEnabledFeatures(ctx context.Context, in *GetEnabledFeaturesRequest, opts ...grpc.CallOption) (*GetEnabledFeaturesResponse, error)
+	// PreviewBulkUpdateUsers counts the users that match the filter and returns a sample of them along with the token that confirms
+	// applying the update to them. Only the admins are allowed to call this operation
+	// request: The request contains the filter and the update to preview
+	// Returns the number and a sample of the matched users, and the confirmation token
+	PreviewBulkUpdateUsers(ctx context.Context, in *PreviewBulkUpdateUsersRequest, opts ...grpc.CallOption) (*PreviewBulkUpdateUsersResponse, error)
+	// BulkUpdateUsers applies a previewed update to all the users that match the filter at a limited rate. The update is rejected if
+	// the confirmation token expired or the matched users changed since the preview. Only the admins are allowed to call this operation
+	// request: The request contains the filter, the update and the confirmation token of the preview
+	// Returns the number of the updated users and the users that failed to update
+	BulkUpdateUsers(ctx context.Context, in *BulkUpdateUsersRequest, opts ...grpc.CallOption) (*BulkUpdateUsersResponse, error)
+	// PurgeByLabel permanently deletes all the users tagged with the given test label, including the soft deleted ones. It is only enabled
+	// in the ephemeral environments that set TEST_DATA_PURGE_ENABLED. Only the admins are allowed to call this operation
+	// request: The request contains the test label of the users to purge
+	// Returns the number of the purged users
+	PurgeByLabel(ctx context.Context, in *PurgeByLabelRequest, opts ...grpc.CallOption) (*PurgeByLabelResponse, error)
+	// GetOutboxLag reads how many user lifecycle events the message broker has not confirmed receiving yet and for how long,
+	// so the consumers being stale can be told apart from a publisher backlog. The lag is per replica. Only the admins are
+	// allowed to call this operation
+	// request: Empty request
+	// Returns the publisher lag of the replica
+	GetOutboxLag(ctx context.Context, in *GetOutboxLagRequest, opts ...grpc.CallOption) (*GetOutboxLagResponse, error)
+	// ListPendingEvents lists the user lifecycle events the message broker has not confirmed receiving yet. Only the admins
+	// are allowed to call this operation
+	// request: The request contains the maximum number of the events to list
+	// Returns the pending events of the replica, the oldest first
+	ListPendingEvents(ctx context.Context, in *ListPendingEventsRequest, opts ...grpc.CallOption) (*ListPendingEventsResponse, error)
+	// ForceFlush sends the buffered user lifecycle events and waits for the message broker to confirm receiving them. Only
+	// the admins are allowed to call this operation
+	// request: Empty request
+	// Returns the number of the confirmed events
+	ForceFlush(ctx context.Context, in *ForceFlushRequest, opts ...grpc.CallOption) (*ForceFlushResponse, error)
+	// GetUserPreferences reads the preferences of an exsiting user
+	// request: The request to read the preferences of an existing user
+	// Returns the preferences of the user
+	GetUserPreferences(ctx context.Context, in *GetUserPreferencesRequest, opts ...grpc.CallOption) (*GetUserPreferencesResponse, error)
+	// UpdateUserPreferences merges the preferences into the preferences of an exsiting user, the preferences not in the
+	// request are left as they are
+	// request: The request contains the preferences to set and the keys of the preferences to remove
+	// Returns the preferences of the user after they are merged
+	UpdateUserPreferences(ctx context.Context, in *UpdateUserPreferencesRequest, opts ...grpc.CallOption) (*UpdateUserPreferencesResponse, error)
+	// GetUserAvatar creates the URL the avatar image of an exsiting user is downloaded from, fails with USER_NOT_FOUND if
+	// the user has no avatar. The URL is presigned by the object storage and is only valid until it expires
+	// request: The request to read the avatar of an existing user
+	// Returns the URL of the avatar image and the hash of the image
+	GetUserAvatar(ctx context.Context, in *GetUserAvatarRequest, opts ...grpc.CallOption) (*GetUserAvatarResponse, error)
+	// SetUserAvatar stores the avatar image of an exsiting user, replacing the avatar set before if any. The image must
+	// be within the configured size limit and of one of the configured content types
+	// request: The request contains the avatar image and its content type
+	// Returns the user after the avatar is set and the URL of the avatar image
+	SetUserAvatar(ctx context.Context, in *SetUserAvatarRequest, opts ...grpc.CallOption) (*SetUserAvatarResponse, error)
+	// ExportPersonalData assembles everything stored about an existing user, the profile, the preferences, the API keys and
+	// the audit records made on and made by the user, into a JSON archive the user can download
+	// request: The request to export the personal data of an existing user
+	// Returns the JSON archive and the name it is suggested to be saved with
+	ExportPersonalData(ctx context.Context, in *ExportPersonalDataRequest, opts ...grpc.CallOption) (*ExportPersonalDataResponse, error)
+	// EraseUser erases an existing user on their request. Calling it without the confirmation token requests the erasure and
+	// returns the token, calling it with the token after the grace period ends confirms the erasure. Confirming the erasure
+	// anonymizes the audit records of the user and permanently deletes the user, the user can not be restored afterwards
+	// request: The request contains the user email address and the confirmation token if the erasure is confirmed
+	// Returns either the confirmation token and when it can be used, or whether the user is erased
+	EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error)
+	// AddUserToTenant adds an existing user to a tenant, fails with USER_ALREADY_EXISTS if the user is already a member of the
+	// tenant. Only the admins are allowed to call this operation
+	// request: The request contains the tenant and the role to add the user with
+	// Returns the user after it is added to the tenant
+	AddUserToTenant(ctx context.Context, in *AddUserToTenantRequest, opts ...grpc.CallOption) (*AddUserToTenantResponse, error)
+	// RemoveUserFromTenant removes an existing user from a tenant, fails with USER_NOT_FOUND if the user is not a member of
+	// the tenant. Only the admins are allowed to call this operation
+	// request: The request contains the tenant to remove the user from
+	// Returns the user after it is removed from the tenant
+	RemoveUserFromTenant(ctx context.Context, in *RemoveUserFromTenantRequest, opts ...grpc.CallOption) (*RemoveUserFromTenantResponse, error)
+	// ListUserTenants lists the tenants an existing user is a member of. Only the admins are allowed to call this
+	// operation, the users read their own memberships through ReadUser
+	// request: The request to list the tenants of an existing user
+	// Returns the memberships of the user in the tenants
+	ListUserTenants(ctx context.Context, in *ListUserTenantsRequest, opts ...grpc.CallOption) (*ListUserTenantsResponse, error)
+	// GetReplicationStatus reads how far behind the primary database the standby database is, so the readiness to fail over
+	// to the standby database can be measured. Only the admins are allowed to call this operation
+	// request: Empty request
+	// Returns the latest heartbeats of the primary and the standby databases and the lag between them
+	GetReplicationStatus(ctx context.Context, in *GetReplicationStatusRequest, opts ...grpc.CallOption) (*GetReplicationStatusResponse, error)
+	// ExportUsers streams all the users that matched the filter one by one in the order they are persisted in, so the
+	// export can be resumed after the cursor of the last received user. The stream fails with the gRPC status of the
+	// error if something goes wrong. Only the admins are allowed to call this operation
+	// request: The request contains the filter
+	// Returns the stream of the users that matched the filter
+	ExportUsers(ctx context.Context, in *ExportUsersRequest, opts ...grpc.CallOption) (Service_ExportUsersClient, error)
+	// IssueMagicLink issues a single-use magic link to an existing user and publishes it to be emailed to the user. The
+	// magic link expires after USER_MAGIC_LINK_TOKEN_TTL and replaces the magic link issued to the user before. Only the
+	// admins are allowed to call this operation
+	// request: The request contains the user email address and the IP address and the device the link is requested from
+	// Returns the time the magic link expires at
+	IssueMagicLink(ctx context.Context, in *IssueMagicLinkRequest, opts ...grpc.CallOption) (*IssueMagicLinkResponse, error)
+	// RedeemMagicLink redeems the magic link of a user, the magic link can not be redeemed again. Only the admins are
+	// allowed to call this operation
+	// request: The request contains the user email address, the token and the IP address and the device the link is
+	// redeemed from
+	// Returns the user the magic link signs in
+	RedeemMagicLink(ctx context.Context, in *RedeemMagicLinkRequest, opts ...grpc.CallOption) (*RedeemMagicLinkResponse, error)
+	// SendVerificationEmail sends a new email verification token to the email address of an existing user, replacing the
+	// token sent before. Nothing is sent if the email address is already verified
+	// request: The request to send the verification email
+	// Returns the time the token expires at
+	SendVerificationEmail(ctx context.Context, in *SendVerificationEmailRequest, opts ...grpc.CallOption) (*SendVerificationEmailResponse, error)
+	// VerifyEmail verifies the email address of an existing user with the token sent in the verification email, fails with
+	// EMAIL_VERIFICATION_TOKEN_INVALID or EMAIL_VERIFICATION_TOKEN_EXPIRED if the token can not verify the email address
+	// request: The request contains the token sent in the verification email
+	// Returns the user after its email address is verified
+	VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailResponse, error)
+	// SetPassword sets the first password of an existing user, so the user can sign in without an external identity
+	// provider. Fails with FEATURE_DISABLED unless the password credentials are enabled and with PASSWORD_ALREADY_SET if
+	// the user already has a password
+	// request: The request contains the password to set
+	// Returns the result of setting the password
+	SetPassword(ctx context.Context, in *SetPasswordRequest, opts ...grpc.CallOption) (*SetPasswordResponse, error)
+	// ChangePassword replaces the password of an existing user, fails with PASSWORD_MISMATCH if the current password does
+	// not match
+	// request: The request contains the current and the new password
+	// Returns the result of replacing the password
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
+	// VerifyPassword verifies the password of an existing user, fails with PASSWORD_MISMATCH if the password does not
+	// match and with USER_LOCKED if the user is locked. Only the admins are allowed to call this operation
+	// request: The request contains the password to verify
+	// Returns the user the password matched
+	VerifyPassword(ctx context.Context, in *VerifyPasswordRequest, opts ...grpc.CallOption) (*VerifyPasswordResponse, error)
+	// WatchUser streams the changes made to the user as they happen, so the clients do not have to poll ReadUser. The
+	// stream fails with RESOURCE_EXHAUSTED if the client falls behind the changes, the client must read the user again
+	// before watching it again
+	// request: The request contains the user email address and the types of the changes to watch
+	// Returns the stream of the changes made to the user
+	WatchUser(ctx context.Context, in *WatchUserRequest, opts ...grpc.CallOption) (Service_WatchUserClient, error)
+	// WatchUsers streams the changes made to the users that matched the filter as they happen. The stream fails with
+	// RESOURCE_EXHAUSTED if the client falls behind the changes. If the changes are read from the change stream, every
+	// change carries a resume token the client reconnects with to receive the changes it missed. Only the admins are
+	// allowed to call this operation
+	// request: The request contains the filter
+	// Returns the stream of the changes made to the users that matched the filter
+	WatchUsers(ctx context.Context, in *WatchUsersRequest, opts ...grpc.CallOption) (Service_WatchUsersClient, error)
+	// CreateAPIKey mints a new API key the service accounts can call the service with on behalf of the user by sending it
+	// in the x-api-key metadata instead of a token. Fails with API_KEY_LIMIT_EXCEEDED if the user already has as many API
+	// keys as allowed. The API keys can not be used to call this operation
+	// request: The request contains the name, the scopes and the expiry of the API key
+	// Returns the new API key and the key itself, which is only returned once
+	CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error)
+	// ListAPIKeys lists all the API keys of the user, including the revoked and the expired ones. The API keys can not be
+	// used to call this operation
+	// request: The request contains the user email address
+	// Returns the API keys of the user
+	ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error)
+	// RevokeAPIKey revokes an API key of the user, so it can not be used anymore. The API keys can not be used to call
+	// this operation
+	// request: The request contains the ID of the API key
+	// Returns the result of revoking the API key
+	RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*RevokeAPIKeyResponse, error)
+	// RecordLoginAttempt records the outcome of a login attempt of an existing user. The user is locked once the number
+	// of the failed attempts reaches the lockout threshold, and a successful attempt resets it unless the user is locked.
+	// Only the admins are allowed to call this operation
+	// request: The request contains the outcome of the login attempt
+	// Returns the user after the login attempt is recorded
+	RecordLoginAttempt(ctx context.Context, in *RecordLoginAttemptRequest, opts ...grpc.CallOption) (*RecordLoginAttemptResponse, error)
+	// UnlockUser unlocks an existing user and resets the number of its failed login attempts. Only the admins are
+	// allowed to call this operation
+	// request: The request contains the user email address
+	// Returns the user after it is unlocked
+	UnlockUser(ctx context.Context, in *UnlockUserRequest, opts ...grpc.CallOption) (*UnlockUserResponse, error)
+	// EnrollMFA enrolls an existing user in an MFA method, so the auth gateway can keep the MFA state of the user in
+	// this service. The same TOTP secret or WebAuthn credential can not be enrolled twice and a user has at most one
+	// set of recovery codes. Only the admins are allowed to call this operation
+	// request: The request contains the method to enroll the user in
+	// Returns the method the user is enrolled in
+	EnrollMFA(ctx context.Context, in *EnrollMFARequest, opts ...grpc.CallOption) (*EnrollMFAResponse, error)
+	// ListMFAMethods lists the MFA methods an existing user is enrolled in. Only the admins are allowed to call this
+	// operation
+	// request: The request contains the user email address
+	// Returns the MFA methods of the user
+	ListMFAMethods(ctx context.Context, in *ListMFAMethodsRequest, opts ...grpc.CallOption) (*ListMFAMethodsResponse, error)
+	// RemoveMFAMethod removes an MFA method from an existing user. Only the admins are allowed to call this operation
+	// request: The request contains the ID of the method to remove
+	// Returns the user after the method is removed
+	RemoveMFAMethod(ctx context.Context, in *RemoveMFAMethodRequest, opts ...grpc.CallOption) (*RemoveMFAMethodResponse, error)
+	// RecordConsent records that an existing user accepted a version of a policy, e.g. the terms of service. The version
+	// of a required policy must be its current version. The consents are only ever added, so the versions accepted before
+	// are kept
+	// request: The request contains the policy and the version the user accepted
+	// Returns the recorded consent
+	RecordConsent(ctx context.Context, in *RecordConsentRequest, opts ...grpc.CallOption) (*RecordConsentResponse, error)
+	// ListConsents lists the consents of an existing user and the current versions of the required policies the user
+	// has not accepted yet, the operations that require the consent fail with CONSENT_REQUIRED until they are accepted
+	// request: The request contains the user email address
+	// Returns the consents and the outstanding policy versions of the user
+	ListConsents(ctx context.Context, in *ListConsentsRequest, opts ...grpc.CallOption) (*ListConsentsResponse, error)
+	// CreateGroup creates a new group with no member. Only the admins are allowed to call this operation
+	// request: The request contains the name and the description of the group
+	// Returns the new group
+	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error)
+	// ReadGroup reads an existing group along its members. Only the admins are allowed to call this operation
+	// request: The request contains the ID of the group
+	// Returns the group
+	ReadGroup(ctx context.Context, in *ReadGroupRequest, opts ...grpc.CallOption) (*ReadGroupResponse, error)
+	// UpdateGroup renames and describes an existing group, its members are kept. Only the admins are allowed to call
+	// this operation
+	// request: The request contains the new name and description of the group
+	// Returns the updated group
+	UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*UpdateGroupResponse, error)
+	// DeleteGroup deletes an existing group, its members are not affected. Only the admins are allowed to call this
+	// operation
+	// request: The request contains the ID of the group
+	// Returns the result of deleting the group
+	DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error)
+	// ListGroups lists all the groups, or the groups of a user. Only the admins are allowed to call this operation
+	// request: The request contains the optional email address of the user
+	// Returns the groups ordered by their names
+	ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error)
+	// AddUserToGroup adds an existing user to an existing group, adding a member again does nothing. Only the admins
+	// are allowed to call this operation
+	// request: The request contains the ID of the group and the user email address
+	// Returns the group after the user is added
+	AddUserToGroup(ctx context.Context, in *AddUserToGroupRequest, opts ...grpc.CallOption) (*AddUserToGroupResponse, error)
+	// RemoveUserFromGroup removes a user from an existing group, removing a user that is not a member does nothing.
+	// Only the admins are allowed to call this operation
+	// request: The request contains the ID of the group and the user email address
+	// Returns the group after the user is removed
+	RemoveUserFromGroup(ctx context.Context, in *RemoveUserFromGroupRequest, opts ...grpc.CallOption) (*RemoveUserFromGroupResponse, error)
+	// InviteUser invites an email address to join as a new user, the invitation token is sent to the email address and
+	// expires after USER_INVITATION_TOKEN_TTL. Inviting the email address again replaces the previous invitation. Only
+	// the admins are allowed to call this operation
+	// request: The request contains the email address to invite
+	// Returns the time the invitation expires at
+	InviteUser(ctx context.Context, in *InviteUserRequest, opts ...grpc.CallOption) (*InviteUserResponse, error)
+	// AcceptInvitation creates the user of the invited email address in the token with the invitation token. An
+	// invitation is only accepted once
+	// request: The request contains the invitation token and the user object
+	// Returns the created user object
+	AcceptInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...grpc.CallOption) (*AcceptInvitationResponse, error)
+	// RegisterSession registers a new session of a user or extends an existing one, so it expires USER_SESSION_TTL
+	// after now. The auth gateway calls it on every login and token refresh, and drops the login if the session is
+	// revoked. Only the admins are allowed to call this operation
+	// request: The request contains the ID of the session, the user email address and the device
+	// Returns the registered session
+	RegisterSession(ctx context.Context, in *RegisterSessionRequest, opts ...grpc.CallOption) (*RegisterSessionResponse, error)
+	// ListSessions lists the active sessions of a user
+	// request: The request contains the user email address
+	// Returns the active sessions, the most recently seen first
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	// RevokeSession revokes an active session of a user, the session can not be registered again
+	// request: The request contains the user email address and the ID of the session
+	// Returns the result of revoking the session
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error)
+	// CreateWebhookSubscription subscribes a webhook to the events of a user. The failed calls are retried
+	// USER_WEBHOOK_MAX_ATTEMPTS times, and the webhooks are not called while the webhookNotifications preference of the
+	// user is false
+	// request: The request contains the user email address, the URL, the secret and the event types
+	// Returns the created subscription
+	CreateWebhookSubscription(ctx context.Context, in *CreateWebhookSubscriptionRequest, opts ...grpc.CallOption) (*CreateWebhookSubscriptionResponse, error)
+	// ListWebhookSubscriptions lists the webhook subscriptions of a user
+	// request: The request contains the user email address
+	// Returns the subscriptions, the oldest first
+	ListWebhookSubscriptions(ctx context.Context, in *ListWebhookSubscriptionsRequest, opts ...grpc.CallOption) (*ListWebhookSubscriptionsResponse, error)
+	// DeleteWebhookSubscription deletes a webhook subscription of a user, the webhook is no longer called
+	// request: The request contains the user email address and the ID of the subscription
+	// Returns the result of deleting the subscription
+	DeleteWebhookSubscription(ctx context.Context, in *DeleteWebhookSubscriptionRequest, opts ...grpc.CallOption) (*DeleteWebhookSubscriptionResponse, error)
+	// ListWebhookDeliveries lists the recent deliveries of the events to the webhooks of a user and their statuses
+	// request: The request contains the user email address, the optional ID of the subscription and the limit
+	// Returns the deliveries, the most recent first
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
 }
 
 type serviceClient struct {
@@ -156,6 +1044,24 @@ func (c *serviceClient) ReadUser(ctx context.Context, in *ReadUserRequest, opts
 	return out, nil
 }
 
+func (c *serviceClient) ReadUserByEmail(ctx context.Context, in *ReadUserByEmailRequest, opts ...grpc.CallOption) (*ReadUserByEmailResponse, error) {
+	out := new(ReadUserByEmailResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ReadUserByEmail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ReadUsers(ctx context.Context, in *ReadUsersRequest, opts ...grpc.CallOption) (*ReadUsersResponse, error) {
+	out := new(ReadUsersResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ReadUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error) {
 	out := new(UpdateUserResponse)
 	err := c.cc.Invoke(ctx, "/user.Service/UpdateUser", in, out, opts...)
@@ -165,6 +1071,33 @@ func (c *serviceClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, o
 	return out, nil
 }
 
+func (c *serviceClient) ChangeEmail(ctx context.Context, in *ChangeEmailRequest, opts ...grpc.CallOption) (*ChangeEmailResponse, error) {
+	out := new(ChangeEmailResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ChangeEmail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) RequestEmailChange(ctx context.Context, in *RequestEmailChangeRequest, opts ...grpc.CallOption) (*RequestEmailChangeResponse, error) {
+	out := new(RequestEmailChangeResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/RequestEmailChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ConfirmEmailChange(ctx context.Context, in *ConfirmEmailChangeRequest, opts ...grpc.CallOption) (*ConfirmEmailChangeResponse, error) {
+	out := new(ConfirmEmailChangeResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ConfirmEmailChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error) {
 	out := new(DeleteUserResponse)
 	err := c.cc.Invoke(ctx, "/user.Service/DeleteUser", in, out, opts...)
@@ -174,140 +1107,2701 @@ func (c *serviceClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, o
 	return out, nil
 }
 
-// ServiceServer is the server API for Service service.
-type ServiceServer interface {
-	// CreateUser creates a new user
-	// request: The request to create a new user
-	// Returns the result of creating new user
-	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
-	// ReadUser reads an exsiting user
-	// request: The request to read an existing user
-	// Returns the result of reading an existing user
-	ReadUser(context.Context, *ReadUserRequest) (*ReadUserResponse, error)
-	// UpdateUser updates an exsiting user
-	// request: The request to update an existing user
-	// Returns the result of updateing an existing user
-	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
-	// DeleteUser deletes an exsiting user
-	// request: The request to delete an existing user
-	// Returns the result of deleting an existing user
-	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
+func (c *serviceClient) RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error) {
+	out := new(RestoreUserResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/RestoreUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedServiceServer struct {
+func (c *serviceClient) GetSagaStatus(ctx context.Context, in *GetSagaStatusRequest, opts ...grpc.CallOption) (*GetSagaStatusResponse, error) {
+	out := new(GetSagaStatusResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/GetSagaStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (*UnimplementedServiceServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+func (c *serviceClient) ListAuditRecords(ctx context.Context, in *ListAuditRecordsRequest, opts ...grpc.CallOption) (*ListAuditRecordsResponse, error) {
+	out := new(ListAuditRecordsResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ListAuditRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (*UnimplementedServiceServer) ReadUser(context.Context, *ReadUserRequest) (*ReadUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReadUser not implemented")
+
+func (c *serviceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (*UnimplementedServiceServer) UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+
+func (c *serviceClient) StreamSearchUsers(ctx context.Context, in *StreamSearchUsersRequest, opts ...grpc.CallOption) (Service_StreamSearchUsersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Service_serviceDesc.Streams[0], "/user.Service/StreamSearchUsers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceStreamSearchUsersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
-func (*UnimplementedServiceServer) DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+
+type Service_StreamSearchUsersClient interface {
+	Recv() (*UserWithCursor, error)
+	grpc.ClientStream
 }
 
-func RegisterServiceServer(s *grpc.Server, srv ServiceServer) {
-	s.RegisterService(&_Service_serviceDesc, srv)
+type serviceStreamSearchUsersClient struct {
+	grpc.ClientStream
 }
 
-func _Service_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateUserRequest)
-	if err := dec(in); err != nil {
+func (x *serviceStreamSearchUsersClient) Recv() (*UserWithCursor, error) {
+	m := new(UserWithCursor)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ServiceServer).CreateUser(ctx, in)
+	return m, nil
+}
+
+func (c *serviceClient) GetEffectiveConfiguration(ctx context.Context, in *GetEffectiveConfigurationRequest, opts ...grpc.CallOption) (*GetEffectiveConfigurationResponse, error) {
+	out := new(GetEffectiveConfigurationResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/GetEffectiveConfiguration", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/user.Service/CreateUser",
+	return out, nil
+}
+
+func (c *serviceClient) GetEnabledFeatures(ctx context.Context, in *GetEnabledFeaturesRequest, opts ...grpc.CallOption) (*GetEnabledFeaturesResponse, error) {
+	out := new(GetEnabledFeaturesResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/GetEnabledFeatures", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+	return out, nil
+}
+
+func (c *serviceClient) PreviewBulkUpdateUsers(ctx context.Context, in *PreviewBulkUpdateUsersRequest, opts ...grpc.CallOption) (*PreviewBulkUpdateUsersResponse, error) {
+	out := new(PreviewBulkUpdateUsersResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/PreviewBulkUpdateUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Service_ReadUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReadUserRequest)
-	if err := dec(in); err != nil {
+func (c *serviceClient) BulkUpdateUsers(ctx context.Context, in *BulkUpdateUsersRequest, opts ...grpc.CallOption) (*BulkUpdateUsersResponse, error) {
+	out := new(BulkUpdateUsersResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/BulkUpdateUsers", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ServiceServer).ReadUser(ctx, in)
+	return out, nil
+}
+
+func (c *serviceClient) PurgeByLabel(ctx context.Context, in *PurgeByLabelRequest, opts ...grpc.CallOption) (*PurgeByLabelResponse, error) {
+	out := new(PurgeByLabelResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/PurgeByLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/user.Service/ReadUser",
+	return out, nil
+}
+
+func (c *serviceClient) GetOutboxLag(ctx context.Context, in *GetOutboxLagRequest, opts ...grpc.CallOption) (*GetOutboxLagResponse, error) {
+	out := new(GetOutboxLagResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/GetOutboxLag", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceServer).ReadUser(ctx, req.(*ReadUserRequest))
+	return out, nil
+}
+
+func (c *serviceClient) ListPendingEvents(ctx context.Context, in *ListPendingEventsRequest, opts ...grpc.CallOption) (*ListPendingEventsResponse, error) {
+	out := new(ListPendingEventsResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ListPendingEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Service_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateUserRequest)
-	if err := dec(in); err != nil {
+func (c *serviceClient) ForceFlush(ctx context.Context, in *ForceFlushRequest, opts ...grpc.CallOption) (*ForceFlushResponse, error) {
+	out := new(ForceFlushResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ForceFlush", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ServiceServer).UpdateUser(ctx, in)
+	return out, nil
+}
+
+func (c *serviceClient) GetUserPreferences(ctx context.Context, in *GetUserPreferencesRequest, opts ...grpc.CallOption) (*GetUserPreferencesResponse, error) {
+	out := new(GetUserPreferencesResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/GetUserPreferences", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/user.Service/UpdateUser",
+	return out, nil
+}
+
+func (c *serviceClient) UpdateUserPreferences(ctx context.Context, in *UpdateUserPreferencesRequest, opts ...grpc.CallOption) (*UpdateUserPreferencesResponse, error) {
+	out := new(UpdateUserPreferencesResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/UpdateUserPreferences", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	return out, nil
+}
+
+func (c *serviceClient) GetUserAvatar(ctx context.Context, in *GetUserAvatarRequest, opts ...grpc.CallOption) (*GetUserAvatarResponse, error) {
+	out := new(GetUserAvatarResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/GetUserAvatar", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Service_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteUserRequest)
-	if err := dec(in); err != nil {
+func (c *serviceClient) SetUserAvatar(ctx context.Context, in *SetUserAvatarRequest, opts ...grpc.CallOption) (*SetUserAvatarResponse, error) {
+	out := new(SetUserAvatarResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/SetUserAvatar", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ServiceServer).DeleteUser(ctx, in)
+	return out, nil
+}
+
+func (c *serviceClient) ExportPersonalData(ctx context.Context, in *ExportPersonalDataRequest, opts ...grpc.CallOption) (*ExportPersonalDataResponse, error) {
+	out := new(ExportPersonalDataResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ExportPersonalData", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/user.Service/DeleteUser",
+	return out, nil
+}
+
+func (c *serviceClient) EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error) {
+	out := new(EraseUserResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/EraseUser", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+	return out, nil
+}
+
+func (c *serviceClient) AddUserToTenant(ctx context.Context, in *AddUserToTenantRequest, opts ...grpc.CallOption) (*AddUserToTenantResponse, error) {
+	out := new(AddUserToTenantResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/AddUserToTenant", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-var _Service_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "user.Service",
-	HandlerType: (*ServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateUser",
-			Handler:    _Service_CreateUser_Handler,
-		},
-		{
-			MethodName: "ReadUser",
-			Handler:    _Service_ReadUser_Handler,
-		},
-		{
-			MethodName: "UpdateUser",
-			Handler:    _Service_UpdateUser_Handler,
-		},
-		{
-			MethodName: "DeleteUser",
-			Handler:    _Service_DeleteUser_Handler,
+func (c *serviceClient) RemoveUserFromTenant(ctx context.Context, in *RemoveUserFromTenantRequest, opts ...grpc.CallOption) (*RemoveUserFromTenantResponse, error) {
+	out := new(RemoveUserFromTenantResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/RemoveUserFromTenant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListUserTenants(ctx context.Context, in *ListUserTenantsRequest, opts ...grpc.CallOption) (*ListUserTenantsResponse, error) {
+	out := new(ListUserTenantsResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ListUserTenants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetReplicationStatus(ctx context.Context, in *GetReplicationStatusRequest, opts ...grpc.CallOption) (*GetReplicationStatusResponse, error) {
+	out := new(GetReplicationStatusResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/GetReplicationStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ExportUsers(ctx context.Context, in *ExportUsersRequest, opts ...grpc.CallOption) (Service_ExportUsersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Service_serviceDesc.Streams[1], "/user.Service/ExportUsers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceExportUsersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_ExportUsersClient interface {
+	Recv() (*UserWithCursor, error)
+	grpc.ClientStream
+}
+
+type serviceExportUsersClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceExportUsersClient) Recv() (*UserWithCursor, error) {
+	m := new(UserWithCursor)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) IssueMagicLink(ctx context.Context, in *IssueMagicLinkRequest, opts ...grpc.CallOption) (*IssueMagicLinkResponse, error) {
+	out := new(IssueMagicLinkResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/IssueMagicLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) RedeemMagicLink(ctx context.Context, in *RedeemMagicLinkRequest, opts ...grpc.CallOption) (*RedeemMagicLinkResponse, error) {
+	out := new(RedeemMagicLinkResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/RedeemMagicLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) SendVerificationEmail(ctx context.Context, in *SendVerificationEmailRequest, opts ...grpc.CallOption) (*SendVerificationEmailResponse, error) {
+	out := new(SendVerificationEmailResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/SendVerificationEmail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailResponse, error) {
+	out := new(VerifyEmailResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/VerifyEmail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) SetPassword(ctx context.Context, in *SetPasswordRequest, opts ...grpc.CallOption) (*SetPasswordResponse, error) {
+	out := new(SetPasswordResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/SetPassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error) {
+	out := new(ChangePasswordResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ChangePassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) VerifyPassword(ctx context.Context, in *VerifyPasswordRequest, opts ...grpc.CallOption) (*VerifyPasswordResponse, error) {
+	out := new(VerifyPasswordResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/VerifyPassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) WatchUser(ctx context.Context, in *WatchUserRequest, opts ...grpc.CallOption) (Service_WatchUserClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Service_serviceDesc.Streams[2], "/user.Service/WatchUser", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceWatchUserClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_WatchUserClient interface {
+	Recv() (*UserChange, error)
+	grpc.ClientStream
+}
+
+type serviceWatchUserClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceWatchUserClient) Recv() (*UserChange, error) {
+	m := new(UserChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) WatchUsers(ctx context.Context, in *WatchUsersRequest, opts ...grpc.CallOption) (Service_WatchUsersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Service_serviceDesc.Streams[3], "/user.Service/WatchUsers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceWatchUsersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_WatchUsersClient interface {
+	Recv() (*UserChange, error)
+	grpc.ClientStream
+}
+
+type serviceWatchUsersClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceWatchUsersClient) Recv() (*UserChange, error) {
+	m := new(UserChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error) {
+	out := new(CreateAPIKeyResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/CreateAPIKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error) {
+	out := new(ListAPIKeysResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ListAPIKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*RevokeAPIKeyResponse, error) {
+	out := new(RevokeAPIKeyResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/RevokeAPIKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) RecordLoginAttempt(ctx context.Context, in *RecordLoginAttemptRequest, opts ...grpc.CallOption) (*RecordLoginAttemptResponse, error) {
+	out := new(RecordLoginAttemptResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/RecordLoginAttempt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) UnlockUser(ctx context.Context, in *UnlockUserRequest, opts ...grpc.CallOption) (*UnlockUserResponse, error) {
+	out := new(UnlockUserResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/UnlockUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) EnrollMFA(ctx context.Context, in *EnrollMFARequest, opts ...grpc.CallOption) (*EnrollMFAResponse, error) {
+	out := new(EnrollMFAResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/EnrollMFA", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListMFAMethods(ctx context.Context, in *ListMFAMethodsRequest, opts ...grpc.CallOption) (*ListMFAMethodsResponse, error) {
+	out := new(ListMFAMethodsResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ListMFAMethods", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) RemoveMFAMethod(ctx context.Context, in *RemoveMFAMethodRequest, opts ...grpc.CallOption) (*RemoveMFAMethodResponse, error) {
+	out := new(RemoveMFAMethodResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/RemoveMFAMethod", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) RecordConsent(ctx context.Context, in *RecordConsentRequest, opts ...grpc.CallOption) (*RecordConsentResponse, error) {
+	out := new(RecordConsentResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/RecordConsent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListConsents(ctx context.Context, in *ListConsentsRequest, opts ...grpc.CallOption) (*ListConsentsResponse, error) {
+	out := new(ListConsentsResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ListConsents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error) {
+	out := new(CreateGroupResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/CreateGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ReadGroup(ctx context.Context, in *ReadGroupRequest, opts ...grpc.CallOption) (*ReadGroupResponse, error) {
+	out := new(ReadGroupResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ReadGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*UpdateGroupResponse, error) {
+	out := new(UpdateGroupResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/UpdateGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error) {
+	out := new(DeleteGroupResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/DeleteGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error) {
+	out := new(ListGroupsResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ListGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) AddUserToGroup(ctx context.Context, in *AddUserToGroupRequest, opts ...grpc.CallOption) (*AddUserToGroupResponse, error) {
+	out := new(AddUserToGroupResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/AddUserToGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) RemoveUserFromGroup(ctx context.Context, in *RemoveUserFromGroupRequest, opts ...grpc.CallOption) (*RemoveUserFromGroupResponse, error) {
+	out := new(RemoveUserFromGroupResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/RemoveUserFromGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) InviteUser(ctx context.Context, in *InviteUserRequest, opts ...grpc.CallOption) (*InviteUserResponse, error) {
+	out := new(InviteUserResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/InviteUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) AcceptInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...grpc.CallOption) (*AcceptInvitationResponse, error) {
+	out := new(AcceptInvitationResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/AcceptInvitation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) RegisterSession(ctx context.Context, in *RegisterSessionRequest, opts ...grpc.CallOption) (*RegisterSessionResponse, error) {
+	out := new(RegisterSessionResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/RegisterSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ListSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error) {
+	out := new(RevokeSessionResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/RevokeSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) CreateWebhookSubscription(ctx context.Context, in *CreateWebhookSubscriptionRequest, opts ...grpc.CallOption) (*CreateWebhookSubscriptionResponse, error) {
+	out := new(CreateWebhookSubscriptionResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/CreateWebhookSubscription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListWebhookSubscriptions(ctx context.Context, in *ListWebhookSubscriptionsRequest, opts ...grpc.CallOption) (*ListWebhookSubscriptionsResponse, error) {
+	out := new(ListWebhookSubscriptionsResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ListWebhookSubscriptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) DeleteWebhookSubscription(ctx context.Context, in *DeleteWebhookSubscriptionRequest, opts ...grpc.CallOption) (*DeleteWebhookSubscriptionResponse, error) {
+	out := new(DeleteWebhookSubscriptionResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/DeleteWebhookSubscription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, "/user.Service/ListWebhookDeliveries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ServiceServer is the server API for Service service.
+type ServiceServer interface {
+	// CreateUser creates a new user
+	// request: The request to create a new user
+	// Returns the result of creating new user
+	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
+	// ReadUser reads an exsiting user
+	// request: The request to read an existing user
+	// Returns the result of reading an existing user
+	ReadUser(context.Context, *ReadUserRequest) (*ReadUserResponse, error)
+	// ReadUserByEmail reads an exsiting user by its email address
+	// request: The request to read an existing user by its email address
+	// Returns the result of reading an existing user
+	ReadUserByEmail(context.Context, *ReadUserByEmailRequest) (*ReadUserByEmailResponse, error)
+	// ReadUsers reads the exsiting users with the given email addresses or IDs at once, the users can only read
+	// themselves unless they are admins
+	// request: The request to read the existing users
+	// Returns the users found and the email addresses and the IDs of the users not found
+	ReadUsers(context.Context, *ReadUsersRequest) (*ReadUsersResponse, error)
+	// UpdateUser updates an exsiting user
+	// request: The request to update an existing user
+	// Returns the result of updateing an existing user
+	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
+	// ChangeEmail changes the email address of an exsiting user, the user keeps its userID. Fails with BAD_REQUEST if the
+	// email address changes must be confirmed, RequestEmailChange and ConfirmEmailChange change it instead
+	// request: The request to change the email address of an existing user
+	// Returns the result of changing the email address of an existing user
+	ChangeEmail(context.Context, *ChangeEmailRequest) (*ChangeEmailResponse, error)
+	// RequestEmailChange sends the token that confirms changing the email address of an existing user to the new email
+	// address, fails with FEATURE_DISABLED unless the email address changes must be confirmed
+	// request: The request to change the email address of an existing user
+	// Returns the time the token expires at
+	RequestEmailChange(context.Context, *RequestEmailChangeRequest) (*RequestEmailChangeResponse, error)
+	// ConfirmEmailChange changes the email address of an existing user to the one the token is sent to and notifies the
+	// previous email address, fails with EMAIL_VERIFICATION_TOKEN_INVALID or EMAIL_VERIFICATION_TOKEN_EXPIRED if the token
+	// can not confirm the change
+	// request: The request contains the token sent to the new email address
+	// Returns the user with the new email address
+	ConfirmEmailChange(context.Context, *ConfirmEmailChangeRequest) (*ConfirmEmailChangeResponse, error)
+	// DeleteUser deletes an exsiting user
+	// request: The request to delete an existing user
+	// Returns the result of deleting an existing user
+	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
+	// RestoreUser restores an exsiting soft deleted user
+	// request: The request to restore an existing soft deleted user
+	// Returns the result of restoring an existing soft deleted user
+	RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error)
+	// GetSagaStatus reads the progress of an exsiting saga. Only the admins are allowed to call this operation
+	// request: The request to read the progress of an existing saga
+	// Returns the progress of the saga
+	GetSagaStatus(context.Context, *GetSagaStatusRequest) (*GetSagaStatusResponse, error)
+	// ListAuditRecords lists the audit records of the mutating operations that matched the criteria. Only the admins are allowed to call this operation
+	// request: The request contains the criteria the audit records are listed by
+	// Returns the audit records that matched the criteria, the latest first
+	ListAuditRecords(context.Context, *ListAuditRecordsRequest) (*ListAuditRecordsResponse, error)
+	// Search returns the list of users that matched the search criteria. Only the admins are allowed to call this operation
+	// request: The request contains the search criteria
+	// Returns the list of users that matched the search criteria
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// StreamSearchUsers streams the users that matched the search criteria one by one, so large result sets are not
+	// loaded in memory. The stream fails with the gRPC status of the error if something goes wrong. Only the admins are
+	// allowed to call this operation
+	// request: The request contains the search criteria
+	// Returns the stream of the users that matched the search criteria
+	StreamSearchUsers(*StreamSearchUsersRequest, Service_StreamSearchUsersServer) error
+	// GetEffectiveConfiguration reads the configuration the replica is running with, the secrets are redacted. Only the admins are allowed to call this operation
+	// request: The request to read the effective configuration
+	// Returns the effective configuration
+	GetEffectiveConfiguration(context.Context, *GetEffectiveConfigurationRequest) (*GetEffectiveConfigurationResponse, error)
+	// GetEnabledFeatures reads the optional features the replica is running with. Only the admins are allowed to call this operation
+	// request: The request to read the enabled features
+	// Returns the optional features and whether they are enabled
+	GetEnabledFeatures(context.Context, *GetEnabledFeaturesRequest) (*GetEnabledFeaturesResponse, error)
+	// PreviewBulkUpdateUsers counts the users that match the filter and returns a sample of them along with the token that confirms
+	// applying the update to them. Only the admins are allowed to call this operation
+	// request: The request contains the filter and the update to preview
+	// Returns the number and a sample of the matched users, and the confirmation token
+	PreviewBulkUpdateUsers(context.Context, *PreviewBulkUpdateUsersRequest) (*PreviewBulkUpdateUsersResponse, error)
+	// BulkUpdateUsers applies a previewed update to all the users that match the filter at a limited rate. The update is rejected if
+	// the confirmation token expired or the matched users changed since the preview. Only the admins are allowed to call this operation
+	// request: The request contains the filter, the update and the confirmation token of the preview
+	// Returns the number of the updated users and the users that failed to update
+	BulkUpdateUsers(context.Context, *BulkUpdateUsersRequest) (*BulkUpdateUsersResponse, error)
+	// PurgeByLabel permanently deletes all the users tagged with the given test label, including the soft deleted ones. It is only enabled
+	// in the ephemeral environments that set TEST_DATA_PURGE_ENABLED. Only the admins are allowed to call this operation
+	// request: The request contains the test label of the users to purge
+	// Returns the number of the purged users
+	PurgeByLabel(context.Context, *PurgeByLabelRequest) (*PurgeByLabelResponse, error)
+	// GetOutboxLag reads how many user lifecycle events the message broker has not confirmed receiving yet and for how long,
+	// so the consumers being stale can be told apart from a publisher backlog. The lag is per replica. Only the admins are
+	// allowed to call this operation
+	// request: Empty request
+	// Returns the publisher lag of the replica
+	GetOutboxLag(context.Context, *GetOutboxLagRequest) (*GetOutboxLagResponse, error)
+	// ListPendingEvents lists the user lifecycle events the message broker has not confirmed receiving yet. Only the admins
+	// are allowed to call this operation
+	// request: The request contains the maximum number of the events to list
+	// Returns the pending events of the replica, the oldest first
+	ListPendingEvents(context.Context, *ListPendingEventsRequest) (*ListPendingEventsResponse, error)
+	// ForceFlush sends the buffered user lifecycle events and waits for the message broker to confirm receiving them. Only
+	// the admins are allowed to call this operation
+	// request: Empty request
+	// Returns the number of the confirmed events
+	ForceFlush(context.Context, *ForceFlushRequest) (*ForceFlushResponse, error)
+	// GetUserPreferences reads the preferences of an exsiting user
+	// request: The request to read the preferences of an existing user
+	// Returns the preferences of the user
+	GetUserPreferences(context.Context, *GetUserPreferencesRequest) (*GetUserPreferencesResponse, error)
+	// UpdateUserPreferences merges the preferences into the preferences of an exsiting user, the preferences not in the
+	// request are left as they are
+	// request: The request contains the preferences to set and the keys of the preferences to remove
+	// Returns the preferences of the user after they are merged
+	UpdateUserPreferences(context.Context, *UpdateUserPreferencesRequest) (*UpdateUserPreferencesResponse, error)
+	// GetUserAvatar creates the URL the avatar image of an exsiting user is downloaded from, fails with USER_NOT_FOUND if
+	// the user has no avatar. The URL is presigned by the object storage and is only valid until it expires
+	// request: The request to read the avatar of an existing user
+	// Returns the URL of the avatar image and the hash of the image
+	GetUserAvatar(context.Context, *GetUserAvatarRequest) (*GetUserAvatarResponse, error)
+	// SetUserAvatar stores the avatar image of an exsiting user, replacing the avatar set before if any. The image must
+	// be within the configured size limit and of one of the configured content types
+	// request: The request contains the avatar image and its content type
+	// Returns the user after the avatar is set and the URL of the avatar image
+	SetUserAvatar(context.Context, *SetUserAvatarRequest) (*SetUserAvatarResponse, error)
+	// ExportPersonalData assembles everything stored about an existing user, the profile, the preferences, the API keys and
+	// the audit records made on and made by the user, into a JSON archive the user can download
+	// request: The request to export the personal data of an existing user
+	// Returns the JSON archive and the name it is suggested to be saved with
+	ExportPersonalData(context.Context, *ExportPersonalDataRequest) (*ExportPersonalDataResponse, error)
+	// EraseUser erases an existing user on their request. Calling it without the confirmation token requests the erasure and
+	// returns the token, calling it with the token after the grace period ends confirms the erasure. Confirming the erasure
+	// anonymizes the audit records of the user and permanently deletes the user, the user can not be restored afterwards
+	// request: The request contains the user email address and the confirmation token if the erasure is confirmed
+	// Returns either the confirmation token and when it can be used, or whether the user is erased
+	EraseUser(context.Context, *EraseUserRequest) (*EraseUserResponse, error)
+	// AddUserToTenant adds an existing user to a tenant, fails with USER_ALREADY_EXISTS if the user is already a member of the
+	// tenant. Only the admins are allowed to call this operation
+	// request: The request contains the tenant and the role to add the user with
+	// Returns the user after it is added to the tenant
+	AddUserToTenant(context.Context, *AddUserToTenantRequest) (*AddUserToTenantResponse, error)
+	// RemoveUserFromTenant removes an existing user from a tenant, fails with USER_NOT_FOUND if the user is not a member of
+	// the tenant. Only the admins are allowed to call this operation
+	// request: The request contains the tenant to remove the user from
+	// Returns the user after it is removed from the tenant
+	RemoveUserFromTenant(context.Context, *RemoveUserFromTenantRequest) (*RemoveUserFromTenantResponse, error)
+	// ListUserTenants lists the tenants an existing user is a member of. Only the admins are allowed to call this
+	// operation, the users read their own memberships through ReadUser
+	// request: The request to list the tenants of an existing user
+	// Returns the memberships of the user in the tenants
+	ListUserTenants(context.Context, *ListUserTenantsRequest) (*ListUserTenantsResponse, error)
+	// GetReplicationStatus reads how far behind the primary database the standby database is, so the readiness to fail over
+	// to the standby database can be measured. Only the admins are allowed to call this operation
+	// request: Empty request
+	// Returns the latest heartbeats of the primary and the standby databases and the lag between them
+	GetReplicationStatus(context.Context, *GetReplicationStatusRequest) (*GetReplicationStatusResponse, error)
+	// ExportUsers streams all the users that matched the filter one by one in the order they are persisted in, so the
+	// export can be resumed after the cursor of the last received user. The stream fails with the gRPC status of the
+	// error if something goes wrong. Only the admins are allowed to call this operation
+	// request: The request contains the filter
+	// Returns the stream of the users that matched the filter
+	ExportUsers(*ExportUsersRequest, Service_ExportUsersServer) error
+	// IssueMagicLink issues a single-use magic link to an existing user and publishes it to be emailed to the user. The
+	// magic link expires after USER_MAGIC_LINK_TOKEN_TTL and replaces the magic link issued to the user before. Only the
+	// admins are allowed to call this operation
+	// request: The request contains the user email address and the IP address and the device the link is requested from
+	// Returns the time the magic link expires at
+	IssueMagicLink(context.Context, *IssueMagicLinkRequest) (*IssueMagicLinkResponse, error)
+	// RedeemMagicLink redeems the magic link of a user, the magic link can not be redeemed again. Only the admins are
+	// allowed to call this operation
+	// request: The request contains the user email address, the token and the IP address and the device the link is
+	// redeemed from
+	// Returns the user the magic link signs in
+	RedeemMagicLink(context.Context, *RedeemMagicLinkRequest) (*RedeemMagicLinkResponse, error)
+	// SendVerificationEmail sends a new email verification token to the email address of an existing user, replacing the
+	// token sent before. Nothing is sent if the email address is already verified
+	// request: The request to send the verification email
+	// Returns the time the token expires at
+	SendVerificationEmail(context.Context, *SendVerificationEmailRequest) (*SendVerificationEmailResponse, error)
+	// VerifyEmail verifies the email address of an existing user with the token sent in the verification email, fails with
+	// EMAIL_VERIFICATION_TOKEN_INVALID or EMAIL_VERIFICATION_TOKEN_EXPIRED if the token can not verify the email address
+	// request: The request contains the token sent in the verification email
+	// Returns the user after its email address is verified
+	VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error)
+	// SetPassword sets the first password of an existing user, so the user can sign in without an external identity
+	// provider. Fails with FEATURE_DISABLED unless the password credentials are enabled and with PASSWORD_ALREADY_SET if
+	// the user already has a password
+	// request: The request contains the password to set
+	// Returns the result of setting the password
+	SetPassword(context.Context, *SetPasswordRequest) (*SetPasswordResponse, error)
+	// ChangePassword replaces the password of an existing user, fails with PASSWORD_MISMATCH if the current password does
+	// not match
+	// request: The request contains the current and the new password
+	// Returns the result of replacing the password
+	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
+	// VerifyPassword verifies the password of an existing user, fails with PASSWORD_MISMATCH if the password does not
+	// match and with USER_LOCKED if the user is locked. Only the admins are allowed to call this operation
+	// request: The request contains the password to verify
+	// Returns the user the password matched
+	VerifyPassword(context.Context, *VerifyPasswordRequest) (*VerifyPasswordResponse, error)
+	// WatchUser streams the changes made to the user as they happen, so the clients do not have to poll ReadUser. The
+	// stream fails with RESOURCE_EXHAUSTED if the client falls behind the changes, the client must read the user again
+	// before watching it again
+	// request: The request contains the user email address and the types of the changes to watch
+	// Returns the stream of the changes made to the user
+	WatchUser(*WatchUserRequest, Service_WatchUserServer) error
+	// WatchUsers streams the changes made to the users that matched the filter as they happen. The stream fails with
+	// RESOURCE_EXHAUSTED if the client falls behind the changes. If the changes are read from the change stream, every
+	// change carries a resume token the client reconnects with to receive the changes it missed. Only the admins are
+	// allowed to call this operation
+	// request: The request contains the filter
+	// Returns the stream of the changes made to the users that matched the filter
+	WatchUsers(*WatchUsersRequest, Service_WatchUsersServer) error
+	// CreateAPIKey mints a new API key the service accounts can call the service with on behalf of the user by sending it
+	// in the x-api-key metadata instead of a token. Fails with API_KEY_LIMIT_EXCEEDED if the user already has as many API
+	// keys as allowed. The API keys can not be used to call this operation
+	// request: The request contains the name, the scopes and the expiry of the API key
+	// Returns the new API key and the key itself, which is only returned once
+	CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	// ListAPIKeys lists all the API keys of the user, including the revoked and the expired ones. The API keys can not be
+	// used to call this operation
+	// request: The request contains the user email address
+	// Returns the API keys of the user
+	ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error)
+	// RevokeAPIKey revokes an API key of the user, so it can not be used anymore. The API keys can not be used to call
+	// this operation
+	// request: The request contains the ID of the API key
+	// Returns the result of revoking the API key
+	RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*RevokeAPIKeyResponse, error)
+	// RecordLoginAttempt records the outcome of a login attempt of an existing user. The user is locked once the number
+	// of the failed attempts reaches the lockout threshold, and a successful attempt resets it unless the user is locked.
+	// Only the admins are allowed to call this operation
+	// request: The request contains the outcome of the login attempt
+	// Returns the user after the login attempt is recorded
+	RecordLoginAttempt(context.Context, *RecordLoginAttemptRequest) (*RecordLoginAttemptResponse, error)
+	// UnlockUser unlocks an existing user and resets the number of its failed login attempts. Only the admins are
+	// allowed to call this operation
+	// request: The request contains the user email address
+	// Returns the user after it is unlocked
+	UnlockUser(context.Context, *UnlockUserRequest) (*UnlockUserResponse, error)
+	// EnrollMFA enrolls an existing user in an MFA method, so the auth gateway can keep the MFA state of the user in
+	// this service. The same TOTP secret or WebAuthn credential can not be enrolled twice and a user has at most one
+	// set of recovery codes. Only the admins are allowed to call this operation
+	// request: The request contains the method to enroll the user in
+	// Returns the method the user is enrolled in
+	EnrollMFA(context.Context, *EnrollMFARequest) (*EnrollMFAResponse, error)
+	// ListMFAMethods lists the MFA methods an existing user is enrolled in. Only the admins are allowed to call this
+	// operation
+	// request: The request contains the user email address
+	// Returns the MFA methods of the user
+	ListMFAMethods(context.Context, *ListMFAMethodsRequest) (*ListMFAMethodsResponse, error)
+	// RemoveMFAMethod removes an MFA method from an existing user. Only the admins are allowed to call this operation
+	// request: The request contains the ID of the method to remove
+	// Returns the user after the method is removed
+	RemoveMFAMethod(context.Context, *RemoveMFAMethodRequest) (*RemoveMFAMethodResponse, error)
+	// RecordConsent records that an existing user accepted a version of a policy, e.g. the terms of service. The version
+	// of a required policy must be its current version. The consents are only ever added, so the versions accepted before
+	// are kept
+	// request: The request contains the policy and the version the user accepted
+	// Returns the recorded consent
+	RecordConsent(context.Context, *RecordConsentRequest) (*RecordConsentResponse, error)
+	// ListConsents lists the consents of an existing user and the current versions of the required policies the user
+	// has not accepted yet, the operations that require the consent fail with CONSENT_REQUIRED until they are accepted
+	// request: The request contains the user email address
+	// Returns the consents and the outstanding policy versions of the user
+	ListConsents(context.Context, *ListConsentsRequest) (*ListConsentsResponse, error)
+	// CreateGroup creates a new group with no member. Only the admins are allowed to call this operation
+	// request: The request contains the name and the description of the group
+	// Returns the new group
+	CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error)
+	// ReadGroup reads an existing group along its members. Only the admins are allowed to call this operation
+	// request: The request contains the ID of the group
+	// Returns the group
+	ReadGroup(context.Context, *ReadGroupRequest) (*ReadGroupResponse, error)
+	// UpdateGroup renames and describes an existing group, its members are kept. Only the admins are allowed to call
+	// this operation
+	// request: The request contains the new name and description of the group
+	// Returns the updated group
+	UpdateGroup(context.Context, *UpdateGroupRequest) (*UpdateGroupResponse, error)
+	// DeleteGroup deletes an existing group, its members are not affected. Only the admins are allowed to call this
+	// operation
+	// request: The request contains the ID of the group
+	// Returns the result of deleting the group
+	DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error)
+	// ListGroups lists all the groups, or the groups of a user. Only the admins are allowed to call this operation
+	// request: The request contains the optional email address of the user
+	// Returns the groups ordered by their names
+	ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error)
+	// AddUserToGroup adds an existing user to an existing group, adding a member again does nothing. Only the admins
+	// are allowed to call this operation
+	// request: The request contains the ID of the group and the user email address
+	// Returns the group after the user is added
+	AddUserToGroup(context.Context, *AddUserToGroupRequest) (*AddUserToGroupResponse, error)
+	// RemoveUserFromGroup removes a user from an existing group, removing a user that is not a member does nothing.
+	// Only the admins are allowed to call this operation
+	// request: The request contains the ID of the group and the user email address
+	// Returns the group after the user is removed
+	RemoveUserFromGroup(context.Context, *RemoveUserFromGroupRequest) (*RemoveUserFromGroupResponse, error)
+	// InviteUser invites an email address to join as a new user, the invitation token is sent to the email address and
+	// expires after USER_INVITATION_TOKEN_TTL. Inviting the email address again replaces the previous invitation. Only
+	// the admins are allowed to call this operation
+	// request: The request contains the email address to invite
+	// Returns the time the invitation expires at
+	InviteUser(context.Context, *InviteUserRequest) (*InviteUserResponse, error)
+	// AcceptInvitation creates the user of the invited email address in the token with the invitation token. An
+	// invitation is only accepted once
+	// request: The request contains the invitation token and the user object
+	// Returns the created user object
+	AcceptInvitation(context.Context, *AcceptInvitationRequest) (*AcceptInvitationResponse, error)
+	// RegisterSession registers a new session of a user or extends an existing one, so it expires USER_SESSION_TTL
+	// after now. The auth gateway calls it on every login and token refresh, and drops the login if the session is
+	// revoked. Only the admins are allowed to call this operation
+	// request: The request contains the ID of the session, the user email address and the device
+	// Returns the registered session
+	RegisterSession(context.Context, *RegisterSessionRequest) (*RegisterSessionResponse, error)
+	// ListSessions lists the active sessions of a user
+	// request: The request contains the user email address
+	// Returns the active sessions, the most recently seen first
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	// RevokeSession revokes an active session of a user, the session can not be registered again
+	// request: The request contains the user email address and the ID of the session
+	// Returns the result of revoking the session
+	RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error)
+	// CreateWebhookSubscription subscribes a webhook to the events of a user. The failed calls are retried
+	// USER_WEBHOOK_MAX_ATTEMPTS times, and the webhooks are not called while the webhookNotifications preference of the
+	// user is false
+	// request: The request contains the user email address, the URL, the secret and the event types
+	// Returns the created subscription
+	CreateWebhookSubscription(context.Context, *CreateWebhookSubscriptionRequest) (*CreateWebhookSubscriptionResponse, error)
+	// ListWebhookSubscriptions lists the webhook subscriptions of a user
+	// request: The request contains the user email address
+	// Returns the subscriptions, the oldest first
+	ListWebhookSubscriptions(context.Context, *ListWebhookSubscriptionsRequest) (*ListWebhookSubscriptionsResponse, error)
+	// DeleteWebhookSubscription deletes a webhook subscription of a user, the webhook is no longer called
+	// request: The request contains the user email address and the ID of the subscription
+	// Returns the result of deleting the subscription
+	DeleteWebhookSubscription(context.Context, *DeleteWebhookSubscriptionRequest) (*DeleteWebhookSubscriptionResponse, error)
+	// ListWebhookDeliveries lists the recent deliveries of the events to the webhooks of a user and their statuses
+	// request: The request contains the user email address, the optional ID of the subscription and the limit
+	// Returns the deliveries, the most recent first
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+}
+
+// UnimplementedServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedServiceServer struct {
+}
+
+func (*UnimplementedServiceServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (*UnimplementedServiceServer) ReadUser(context.Context, *ReadUserRequest) (*ReadUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadUser not implemented")
+}
+func (*UnimplementedServiceServer) ReadUserByEmail(context.Context, *ReadUserByEmailRequest) (*ReadUserByEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadUserByEmail not implemented")
+}
+func (*UnimplementedServiceServer) ReadUsers(context.Context, *ReadUsersRequest) (*ReadUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadUsers not implemented")
+}
+func (*UnimplementedServiceServer) UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (*UnimplementedServiceServer) ChangeEmail(context.Context, *ChangeEmailRequest) (*ChangeEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeEmail not implemented")
+}
+func (*UnimplementedServiceServer) RequestEmailChange(context.Context, *RequestEmailChangeRequest) (*RequestEmailChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestEmailChange not implemented")
+}
+func (*UnimplementedServiceServer) ConfirmEmailChange(context.Context, *ConfirmEmailChangeRequest) (*ConfirmEmailChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmEmailChange not implemented")
+}
+func (*UnimplementedServiceServer) DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (*UnimplementedServiceServer) RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreUser not implemented")
+}
+func (*UnimplementedServiceServer) GetSagaStatus(context.Context, *GetSagaStatusRequest) (*GetSagaStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSagaStatus not implemented")
+}
+func (*UnimplementedServiceServer) ListAuditRecords(context.Context, *ListAuditRecordsRequest) (*ListAuditRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditRecords not implemented")
+}
+func (*UnimplementedServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (*UnimplementedServiceServer) StreamSearchUsers(*StreamSearchUsersRequest, Service_StreamSearchUsersServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSearchUsers not implemented")
+}
+func (*UnimplementedServiceServer) GetEffectiveConfiguration(context.Context, *GetEffectiveConfigurationRequest) (*GetEffectiveConfigurationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEffectiveConfiguration not implemented")
+}
+func (*UnimplementedServiceServer) GetEnabledFeatures(context.Context, *GetEnabledFeaturesRequest) (*GetEnabledFeaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEnabledFeatures not implemented")
+}
+func (*UnimplementedServiceServer) PreviewBulkUpdateUsers(context.Context, *PreviewBulkUpdateUsersRequest) (*PreviewBulkUpdateUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewBulkUpdateUsers not implemented")
+}
+func (*UnimplementedServiceServer) BulkUpdateUsers(context.Context, *BulkUpdateUsersRequest) (*BulkUpdateUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkUpdateUsers not implemented")
+}
+func (*UnimplementedServiceServer) PurgeByLabel(context.Context, *PurgeByLabelRequest) (*PurgeByLabelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeByLabel not implemented")
+}
+func (*UnimplementedServiceServer) GetOutboxLag(context.Context, *GetOutboxLagRequest) (*GetOutboxLagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOutboxLag not implemented")
+}
+func (*UnimplementedServiceServer) ListPendingEvents(context.Context, *ListPendingEventsRequest) (*ListPendingEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingEvents not implemented")
+}
+func (*UnimplementedServiceServer) ForceFlush(context.Context, *ForceFlushRequest) (*ForceFlushResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceFlush not implemented")
+}
+func (*UnimplementedServiceServer) GetUserPreferences(context.Context, *GetUserPreferencesRequest) (*GetUserPreferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserPreferences not implemented")
+}
+func (*UnimplementedServiceServer) UpdateUserPreferences(context.Context, *UpdateUserPreferencesRequest) (*UpdateUserPreferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserPreferences not implemented")
+}
+func (*UnimplementedServiceServer) GetUserAvatar(context.Context, *GetUserAvatarRequest) (*GetUserAvatarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserAvatar not implemented")
+}
+func (*UnimplementedServiceServer) SetUserAvatar(context.Context, *SetUserAvatarRequest) (*SetUserAvatarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserAvatar not implemented")
+}
+func (*UnimplementedServiceServer) ExportPersonalData(context.Context, *ExportPersonalDataRequest) (*ExportPersonalDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportPersonalData not implemented")
+}
+func (*UnimplementedServiceServer) EraseUser(context.Context, *EraseUserRequest) (*EraseUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseUser not implemented")
+}
+func (*UnimplementedServiceServer) AddUserToTenant(context.Context, *AddUserToTenantRequest) (*AddUserToTenantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddUserToTenant not implemented")
+}
+func (*UnimplementedServiceServer) RemoveUserFromTenant(context.Context, *RemoveUserFromTenantRequest) (*RemoveUserFromTenantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveUserFromTenant not implemented")
+}
+func (*UnimplementedServiceServer) ListUserTenants(context.Context, *ListUserTenantsRequest) (*ListUserTenantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserTenants not implemented")
+}
+func (*UnimplementedServiceServer) GetReplicationStatus(context.Context, *GetReplicationStatusRequest) (*GetReplicationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplicationStatus not implemented")
+}
+func (*UnimplementedServiceServer) ExportUsers(*ExportUsersRequest, Service_ExportUsersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUsers not implemented")
+}
+func (*UnimplementedServiceServer) IssueMagicLink(context.Context, *IssueMagicLinkRequest) (*IssueMagicLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueMagicLink not implemented")
+}
+func (*UnimplementedServiceServer) RedeemMagicLink(context.Context, *RedeemMagicLinkRequest) (*RedeemMagicLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemMagicLink not implemented")
+}
+func (*UnimplementedServiceServer) SendVerificationEmail(context.Context, *SendVerificationEmailRequest) (*SendVerificationEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendVerificationEmail not implemented")
+}
+func (*UnimplementedServiceServer) VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
+}
+func (*UnimplementedServiceServer) SetPassword(context.Context, *SetPasswordRequest) (*SetPasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPassword not implemented")
+}
+func (*UnimplementedServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (*UnimplementedServiceServer) VerifyPassword(context.Context, *VerifyPasswordRequest) (*VerifyPasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyPassword not implemented")
+}
+func (*UnimplementedServiceServer) WatchUser(*WatchUserRequest, Service_WatchUserServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchUser not implemented")
+}
+func (*UnimplementedServiceServer) WatchUsers(*WatchUsersRequest, Service_WatchUsersServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchUsers not implemented")
+}
+func (*UnimplementedServiceServer) CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIKey not implemented")
+}
+func (*UnimplementedServiceServer) ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPIKeys not implemented")
+}
+func (*UnimplementedServiceServer) RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*RevokeAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIKey not implemented")
+}
+func (*UnimplementedServiceServer) RecordLoginAttempt(context.Context, *RecordLoginAttemptRequest) (*RecordLoginAttemptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordLoginAttempt not implemented")
+}
+func (*UnimplementedServiceServer) UnlockUser(context.Context, *UnlockUserRequest) (*UnlockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockUser not implemented")
+}
+func (*UnimplementedServiceServer) EnrollMFA(context.Context, *EnrollMFARequest) (*EnrollMFAResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollMFA not implemented")
+}
+func (*UnimplementedServiceServer) ListMFAMethods(context.Context, *ListMFAMethodsRequest) (*ListMFAMethodsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMFAMethods not implemented")
+}
+func (*UnimplementedServiceServer) RemoveMFAMethod(context.Context, *RemoveMFAMethodRequest) (*RemoveMFAMethodResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMFAMethod not implemented")
+}
+func (*UnimplementedServiceServer) RecordConsent(context.Context, *RecordConsentRequest) (*RecordConsentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordConsent not implemented")
+}
+func (*UnimplementedServiceServer) ListConsents(context.Context, *ListConsentsRequest) (*ListConsentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConsents not implemented")
+}
+func (*UnimplementedServiceServer) CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (*UnimplementedServiceServer) ReadGroup(context.Context, *ReadGroupRequest) (*ReadGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadGroup not implemented")
+}
+func (*UnimplementedServiceServer) UpdateGroup(context.Context, *UpdateGroupRequest) (*UpdateGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGroup not implemented")
+}
+func (*UnimplementedServiceServer) DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroup not implemented")
+}
+func (*UnimplementedServiceServer) ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroups not implemented")
+}
+func (*UnimplementedServiceServer) AddUserToGroup(context.Context, *AddUserToGroupRequest) (*AddUserToGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddUserToGroup not implemented")
+}
+func (*UnimplementedServiceServer) RemoveUserFromGroup(context.Context, *RemoveUserFromGroupRequest) (*RemoveUserFromGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveUserFromGroup not implemented")
+}
+func (*UnimplementedServiceServer) InviteUser(context.Context, *InviteUserRequest) (*InviteUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteUser not implemented")
+}
+func (*UnimplementedServiceServer) AcceptInvitation(context.Context, *AcceptInvitationRequest) (*AcceptInvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptInvitation not implemented")
+}
+func (*UnimplementedServiceServer) RegisterSession(context.Context, *RegisterSessionRequest) (*RegisterSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterSession not implemented")
+}
+func (*UnimplementedServiceServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (*UnimplementedServiceServer) RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (*UnimplementedServiceServer) CreateWebhookSubscription(context.Context, *CreateWebhookSubscriptionRequest) (*CreateWebhookSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhookSubscription not implemented")
+}
+func (*UnimplementedServiceServer) ListWebhookSubscriptions(context.Context, *ListWebhookSubscriptionsRequest) (*ListWebhookSubscriptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookSubscriptions not implemented")
+}
+func (*UnimplementedServiceServer) DeleteWebhookSubscription(context.Context, *DeleteWebhookSubscriptionRequest) (*DeleteWebhookSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhookSubscription not implemented")
+}
+func (*UnimplementedServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+
+func RegisterServiceServer(s *grpc.Server, srv ServiceServer) {
+	s.RegisterService(&_Service_serviceDesc, srv)
+}
+
+func _Service_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/CreateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ReadUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ReadUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ReadUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ReadUser(ctx, req.(*ReadUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ReadUserByEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadUserByEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ReadUserByEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ReadUserByEmail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ReadUserByEmail(ctx, req.(*ReadUserByEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ReadUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ReadUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ReadUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ReadUsers(ctx, req.(*ReadUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/UpdateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ChangeEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ChangeEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ChangeEmail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ChangeEmail(ctx, req.(*ChangeEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RequestEmailChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEmailChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RequestEmailChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/RequestEmailChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RequestEmailChange(ctx, req.(*RequestEmailChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ConfirmEmailChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmEmailChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ConfirmEmailChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ConfirmEmailChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ConfirmEmailChange(ctx, req.(*ConfirmEmailChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/DeleteUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RestoreUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RestoreUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/RestoreUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RestoreUser(ctx, req.(*RestoreUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetSagaStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSagaStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetSagaStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/GetSagaStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetSagaStatus(ctx, req.(*GetSagaStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListAuditRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListAuditRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ListAuditRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListAuditRecords(ctx, req.(*ListAuditRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_StreamSearchUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamSearchUsersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).StreamSearchUsers(m, &serviceStreamSearchUsersServer{stream})
+}
+
+type Service_StreamSearchUsersServer interface {
+	Send(*UserWithCursor) error
+	grpc.ServerStream
+}
+
+type serviceStreamSearchUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceStreamSearchUsersServer) Send(m *UserWithCursor) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Service_GetEffectiveConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEffectiveConfigurationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetEffectiveConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/GetEffectiveConfiguration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetEffectiveConfiguration(ctx, req.(*GetEffectiveConfigurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetEnabledFeatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEnabledFeaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetEnabledFeatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/GetEnabledFeatures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetEnabledFeatures(ctx, req.(*GetEnabledFeaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_PreviewBulkUpdateUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewBulkUpdateUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).PreviewBulkUpdateUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/PreviewBulkUpdateUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).PreviewBulkUpdateUsers(ctx, req.(*PreviewBulkUpdateUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_BulkUpdateUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkUpdateUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).BulkUpdateUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/BulkUpdateUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).BulkUpdateUsers(ctx, req.(*BulkUpdateUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_PurgeByLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeByLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).PurgeByLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/PurgeByLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).PurgeByLabel(ctx, req.(*PurgeByLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetOutboxLag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOutboxLagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetOutboxLag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/GetOutboxLag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetOutboxLag(ctx, req.(*GetOutboxLagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListPendingEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListPendingEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ListPendingEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListPendingEvents(ctx, req.(*ListPendingEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ForceFlush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceFlushRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ForceFlush(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ForceFlush",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ForceFlush(ctx, req.(*ForceFlushRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetUserPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetUserPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/GetUserPreferences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetUserPreferences(ctx, req.(*GetUserPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_UpdateUserPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).UpdateUserPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/UpdateUserPreferences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).UpdateUserPreferences(ctx, req.(*UpdateUserPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetUserAvatar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserAvatarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetUserAvatar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/GetUserAvatar",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetUserAvatar(ctx, req.(*GetUserAvatarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_SetUserAvatar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserAvatarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).SetUserAvatar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/SetUserAvatar",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).SetUserAvatar(ctx, req.(*SetUserAvatarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ExportPersonalData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportPersonalDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ExportPersonalData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ExportPersonalData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ExportPersonalData(ctx, req.(*ExportPersonalDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_EraseUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).EraseUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/EraseUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).EraseUser(ctx, req.(*EraseUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_AddUserToTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddUserToTenantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).AddUserToTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/AddUserToTenant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).AddUserToTenant(ctx, req.(*AddUserToTenantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RemoveUserFromTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveUserFromTenantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RemoveUserFromTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/RemoveUserFromTenant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RemoveUserFromTenant(ctx, req.(*RemoveUserFromTenantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListUserTenants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserTenantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListUserTenants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ListUserTenants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListUserTenants(ctx, req.(*ListUserTenantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetReplicationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReplicationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetReplicationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/GetReplicationStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetReplicationStatus(ctx, req.(*GetReplicationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ExportUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUsersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).ExportUsers(m, &serviceExportUsersServer{stream})
+}
+
+type Service_ExportUsersServer interface {
+	Send(*UserWithCursor) error
+	grpc.ServerStream
+}
+
+type serviceExportUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceExportUsersServer) Send(m *UserWithCursor) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Service_IssueMagicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueMagicLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).IssueMagicLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/IssueMagicLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).IssueMagicLink(ctx, req.(*IssueMagicLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RedeemMagicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemMagicLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RedeemMagicLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/RedeemMagicLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RedeemMagicLink(ctx, req.(*RedeemMagicLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_SendVerificationEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendVerificationEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).SendVerificationEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/SendVerificationEmail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).SendVerificationEmail(ctx, req.(*SendVerificationEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/VerifyEmail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).VerifyEmail(ctx, req.(*VerifyEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_SetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).SetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/SetPassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).SetPassword(ctx, req.(*SetPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ChangePassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_VerifyPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).VerifyPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/VerifyPassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).VerifyPassword(ctx, req.(*VerifyPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_WatchUser_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchUserRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).WatchUser(m, &serviceWatchUserServer{stream})
+}
+
+type Service_WatchUserServer interface {
+	Send(*UserChange) error
+	grpc.ServerStream
+}
+
+type serviceWatchUserServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceWatchUserServer) Send(m *UserChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Service_WatchUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchUsersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).WatchUsers(m, &serviceWatchUsersServer{stream})
+}
+
+type Service_WatchUsersServer interface {
+	Send(*UserChange) error
+	grpc.ServerStream
+}
+
+type serviceWatchUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceWatchUsersServer) Send(m *UserChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Service_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/CreateAPIKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).CreateAPIKey(ctx, req.(*CreateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListAPIKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAPIKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListAPIKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ListAPIKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListAPIKeys(ctx, req.(*ListAPIKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RevokeAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RevokeAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/RevokeAPIKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RevokeAPIKey(ctx, req.(*RevokeAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RecordLoginAttempt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordLoginAttemptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RecordLoginAttempt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/RecordLoginAttempt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RecordLoginAttempt(ctx, req.(*RecordLoginAttemptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_UnlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).UnlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/UnlockUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).UnlockUser(ctx, req.(*UnlockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_EnrollMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollMFARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).EnrollMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/EnrollMFA",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).EnrollMFA(ctx, req.(*EnrollMFARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListMFAMethods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMFAMethodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListMFAMethods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ListMFAMethods",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListMFAMethods(ctx, req.(*ListMFAMethodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RemoveMFAMethod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMFAMethodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RemoveMFAMethod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/RemoveMFAMethod",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RemoveMFAMethod(ctx, req.(*RemoveMFAMethodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RecordConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RecordConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/RecordConsent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RecordConsent(ctx, req.(*RecordConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListConsents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConsentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListConsents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ListConsents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListConsents(ctx, req.(*ListConsentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/CreateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).CreateGroup(ctx, req.(*CreateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ReadGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ReadGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ReadGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ReadGroup(ctx, req.(*ReadGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_UpdateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).UpdateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/UpdateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).UpdateGroup(ctx, req.(*UpdateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).DeleteGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/DeleteGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).DeleteGroup(ctx, req.(*DeleteGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ListGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListGroups(ctx, req.(*ListGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_AddUserToGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddUserToGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).AddUserToGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/AddUserToGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).AddUserToGroup(ctx, req.(*AddUserToGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RemoveUserFromGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveUserFromGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RemoveUserFromGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/RemoveUserFromGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RemoveUserFromGroup(ctx, req.(*RemoveUserFromGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_InviteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).InviteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/InviteUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).InviteUser(ctx, req.(*InviteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_AcceptInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).AcceptInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/AcceptInvitation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).AcceptInvitation(ctx, req.(*AcceptInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RegisterSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RegisterSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/RegisterSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RegisterSession(ctx, req.(*RegisterSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ListSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/RevokeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_CreateWebhookSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).CreateWebhookSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/CreateWebhookSubscription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).CreateWebhookSubscription(ctx, req.(*CreateWebhookSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListWebhookSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookSubscriptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListWebhookSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ListWebhookSubscriptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListWebhookSubscriptions(ctx, req.(*ListWebhookSubscriptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_DeleteWebhookSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).DeleteWebhookSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/DeleteWebhookSubscription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).DeleteWebhookSubscription(ctx, req.(*DeleteWebhookSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.Service/ListWebhookDeliveries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Service_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "user.Service",
+	HandlerType: (*ServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateUser",
+			Handler:    _Service_CreateUser_Handler,
+		},
+		{
+			MethodName: "ReadUser",
+			Handler:    _Service_ReadUser_Handler,
+		},
+		{
+			MethodName: "ReadUserByEmail",
+			Handler:    _Service_ReadUserByEmail_Handler,
+		},
+		{
+			MethodName: "ReadUsers",
+			Handler:    _Service_ReadUsers_Handler,
+		},
+		{
+			MethodName: "UpdateUser",
+			Handler:    _Service_UpdateUser_Handler,
+		},
+		{
+			MethodName: "ChangeEmail",
+			Handler:    _Service_ChangeEmail_Handler,
+		},
+		{
+			MethodName: "RequestEmailChange",
+			Handler:    _Service_RequestEmailChange_Handler,
+		},
+		{
+			MethodName: "ConfirmEmailChange",
+			Handler:    _Service_ConfirmEmailChange_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _Service_DeleteUser_Handler,
+		},
+		{
+			MethodName: "RestoreUser",
+			Handler:    _Service_RestoreUser_Handler,
+		},
+		{
+			MethodName: "GetSagaStatus",
+			Handler:    _Service_GetSagaStatus_Handler,
+		},
+		{
+			MethodName: "ListAuditRecords",
+			Handler:    _Service_ListAuditRecords_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _Service_Search_Handler,
+		},
+		{
+			MethodName: "GetEffectiveConfiguration",
+			Handler:    _Service_GetEffectiveConfiguration_Handler,
+		},
+		{
+			MethodName: "GetEnabledFeatures",
+			Handler:    _Service_GetEnabledFeatures_Handler,
+		},
+		{
+			MethodName: "PreviewBulkUpdateUsers",
+			Handler:    _Service_PreviewBulkUpdateUsers_Handler,
+		},
+		{
+			MethodName: "BulkUpdateUsers",
+			Handler:    _Service_BulkUpdateUsers_Handler,
+		},
+		{
+			MethodName: "PurgeByLabel",
+			Handler:    _Service_PurgeByLabel_Handler,
+		},
+		{
+			MethodName: "GetOutboxLag",
+			Handler:    _Service_GetOutboxLag_Handler,
+		},
+		{
+			MethodName: "ListPendingEvents",
+			Handler:    _Service_ListPendingEvents_Handler,
+		},
+		{
+			MethodName: "ForceFlush",
+			Handler:    _Service_ForceFlush_Handler,
+		},
+		{
+			MethodName: "GetUserPreferences",
+			Handler:    _Service_GetUserPreferences_Handler,
+		},
+		{
+			MethodName: "UpdateUserPreferences",
+			Handler:    _Service_UpdateUserPreferences_Handler,
+		},
+		{
+			MethodName: "GetUserAvatar",
+			Handler:    _Service_GetUserAvatar_Handler,
+		},
+		{
+			MethodName: "SetUserAvatar",
+			Handler:    _Service_SetUserAvatar_Handler,
+		},
+		{
+			MethodName: "ExportPersonalData",
+			Handler:    _Service_ExportPersonalData_Handler,
+		},
+		{
+			MethodName: "EraseUser",
+			Handler:    _Service_EraseUser_Handler,
+		},
+		{
+			MethodName: "AddUserToTenant",
+			Handler:    _Service_AddUserToTenant_Handler,
+		},
+		{
+			MethodName: "RemoveUserFromTenant",
+			Handler:    _Service_RemoveUserFromTenant_Handler,
+		},
+		{
+			MethodName: "ListUserTenants",
+			Handler:    _Service_ListUserTenants_Handler,
+		},
+		{
+			MethodName: "GetReplicationStatus",
+			Handler:    _Service_GetReplicationStatus_Handler,
+		},
+		{
+			MethodName: "IssueMagicLink",
+			Handler:    _Service_IssueMagicLink_Handler,
+		},
+		{
+			MethodName: "RedeemMagicLink",
+			Handler:    _Service_RedeemMagicLink_Handler,
+		},
+		{
+			MethodName: "SendVerificationEmail",
+			Handler:    _Service_SendVerificationEmail_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _Service_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "SetPassword",
+			Handler:    _Service_SetPassword_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _Service_ChangePassword_Handler,
+		},
+		{
+			MethodName: "VerifyPassword",
+			Handler:    _Service_VerifyPassword_Handler,
+		},
+		{
+			MethodName: "CreateAPIKey",
+			Handler:    _Service_CreateAPIKey_Handler,
+		},
+		{
+			MethodName: "ListAPIKeys",
+			Handler:    _Service_ListAPIKeys_Handler,
+		},
+		{
+			MethodName: "RevokeAPIKey",
+			Handler:    _Service_RevokeAPIKey_Handler,
+		},
+		{
+			MethodName: "RecordLoginAttempt",
+			Handler:    _Service_RecordLoginAttempt_Handler,
+		},
+		{
+			MethodName: "UnlockUser",
+			Handler:    _Service_UnlockUser_Handler,
+		},
+		{
+			MethodName: "EnrollMFA",
+			Handler:    _Service_EnrollMFA_Handler,
+		},
+		{
+			MethodName: "ListMFAMethods",
+			Handler:    _Service_ListMFAMethods_Handler,
+		},
+		{
+			MethodName: "RemoveMFAMethod",
+			Handler:    _Service_RemoveMFAMethod_Handler,
+		},
+		{
+			MethodName: "RecordConsent",
+			Handler:    _Service_RecordConsent_Handler,
+		},
+		{
+			MethodName: "ListConsents",
+			Handler:    _Service_ListConsents_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _Service_CreateGroup_Handler,
+		},
+		{
+			MethodName: "ReadGroup",
+			Handler:    _Service_ReadGroup_Handler,
+		},
+		{
+			MethodName: "UpdateGroup",
+			Handler:    _Service_UpdateGroup_Handler,
+		},
+		{
+			MethodName: "DeleteGroup",
+			Handler:    _Service_DeleteGroup_Handler,
+		},
+		{
+			MethodName: "ListGroups",
+			Handler:    _Service_ListGroups_Handler,
+		},
+		{
+			MethodName: "AddUserToGroup",
+			Handler:    _Service_AddUserToGroup_Handler,
+		},
+		{
+			MethodName: "RemoveUserFromGroup",
+			Handler:    _Service_RemoveUserFromGroup_Handler,
+		},
+		{
+			MethodName: "InviteUser",
+			Handler:    _Service_InviteUser_Handler,
+		},
+		{
+			MethodName: "AcceptInvitation",
+			Handler:    _Service_AcceptInvitation_Handler,
+		},
+		{
+			MethodName: "RegisterSession",
+			Handler:    _Service_RegisterSession_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _Service_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _Service_RevokeSession_Handler,
+		},
+		{
+			MethodName: "CreateWebhookSubscription",
+			Handler:    _Service_CreateWebhookSubscription_Handler,
+		},
+		{
+			MethodName: "ListWebhookSubscriptions",
+			Handler:    _Service_ListWebhookSubscriptions_Handler,
+		},
+		{
+			MethodName: "DeleteWebhookSubscription",
+			Handler:    _Service_DeleteWebhookSubscription_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _Service_ListWebhookDeliveries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamSearchUsers",
+			Handler:       _Service_StreamSearchUsers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportUsers",
+			Handler:       _Service_ExportUsers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchUser",
+			Handler:       _Service_WatchUser_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchUsers",
+			Handler:       _Service_WatchUsers_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "user-operations.proto",
 }
@@ -4,19 +4,31 @@ go 1.16
 
 require (
 	github.com/asaskevich/govalidator v0.0.0-20210307081110-f21760c49a8d // indirect
+	github.com/aws/aws-sdk-go v1.34.28
+	github.com/cpuguy83/go-md2man/v2 v2.0.2 // indirect
 	github.com/go-kit/kit v0.10.0
 	github.com/go-ozzo/ozzo-validation v3.6.0+incompatible
 	github.com/golang/mock v1.6.0
+	github.com/graph-gophers/graphql-go v1.3.0
+	github.com/jackc/pgconn v1.10.1
+	github.com/jackc/pgx/v4 v4.14.1
 	github.com/lestrrat-go/jwx v1.2.1
 	github.com/lucsky/cuid v1.2.0
 	github.com/micro-business/go-core v0.6.2
+	github.com/nats-io/nats.go v1.11.0
 	github.com/onsi/ginkgo v1.16.4
 	github.com/onsi/gomega v1.13.0
+	github.com/opentracing/opentracing-go v1.1.0
+	github.com/peterh/liner v1.2.1
 	github.com/prometheus/client_golang v1.11.0
 	github.com/savsgio/atreugo/v11 v11.7.2
 	github.com/spf13/cobra v1.1.3
 	go.mongodb.org/mongo-driver v1.5.3
 	go.uber.org/zap v1.17.0
+	golang.org/x/crypto v0.0.0-20210711020723-a769d52b0f97
+	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013
 	google.golang.org/grpc v1.38.0
 	google.golang.org/protobuf v1.26.0
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -0,0 +1,582 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/pkg/client"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	benchCallTimeout = 30 * time.Second
+
+	benchOperationCreate = "create"
+	benchOperationRead   = "read"
+	benchOperationSearch = "search"
+)
+
+var benchOperations = []string{benchOperationCreate, benchOperationRead, benchOperationSearch}
+
+type benchLatency struct {
+	Min  string `json:"min"`
+	Mean string `json:"mean"`
+	P50  string `json:"p50"`
+	P90  string `json:"p90"`
+	P95  string `json:"p95"`
+	P99  string `json:"p99"`
+	Max  string `json:"max"`
+}
+
+type benchOperationResult struct {
+	Requests   int          `json:"requests"`
+	Errors     int          `json:"errors"`
+	ErrorRate  float64      `json:"errorRate"`
+	Throughput float64      `json:"throughput"`
+	Latency    benchLatency `json:"latency"`
+}
+
+type benchResult struct {
+	Tag         string                           `json:"tag"`
+	Duration    string                           `json:"duration"`
+	Concurrency int                              `json:"concurrency"`
+	Requests    int                              `json:"requests"`
+	Errors      int                              `json:"errors"`
+	ErrorRate   float64                          `json:"errorRate"`
+	Throughput  float64                          `json:"throughput"`
+	Operations  map[string]*benchOperationResult `json:"operations"`
+	FirstError  string                           `json:"firstError,omitempty"`
+}
+
+// benchRun contains the state shared by the workers of a benchmark run
+type benchRun struct {
+	userClient *client.Client
+	adminEmail string
+	pageSize   int32
+	tag        string
+	domain     string
+
+	// pool contains the users created before the run, the read and search workloads are made against them
+	pool []string
+
+	lock       sync.Mutex
+	created    []string
+	latencies  map[string][]time.Duration
+	errors     map[string]int
+	firstError string
+}
+
+func newBenchCommand() *cobra.Command {
+	var address string
+	var useTLS bool
+	var duration time.Duration
+	var requests int
+	var rate int
+	var concurrency int
+	var mix map[string]int
+	var userCount int
+	var pageSize int32
+	var tag string
+	var domain string
+	var adminEmail string
+	var signingKeyFile string
+	var signingAlgorithm string
+	var cleanup bool
+	var maxErrorRate float64
+	var maxP99 time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Benchmark the gRPC API with concurrent create, read and search workloads",
+		Long: `Benchmark the gRPC API with concurrent create, read and search workloads and report the latency
+percentiles, the throughput and the error rate of every operation.
+
+A pool of users is created before the run starts, the read and search workloads are made against the pool
+while the create workload creates new users. The mix sets the relative weight of every operation, e.g.
+create=10,read=70,search=20. The search is made as the admin email address, which must be listed in
+ADMIN_EMAILS of the service.
+
+The access tokens are signed with the given private JWK like the loadgen command does, so only point the
+benchmark at test or staging environments. The command fails if the error rate or the p99 latency of the
+run exceeds the given limits, so it can gate a release.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if duration <= 0 && requests < 1 {
+				return fmt.Errorf("either duration or requests must be provided")
+			}
+
+			if rate < 0 {
+				return fmt.Errorf("rate must not be negative")
+			}
+
+			if concurrency < 1 {
+				return fmt.Errorf("concurrency must be at least 1")
+			}
+
+			if userCount < 1 {
+				return fmt.Errorf("users must be at least 1")
+			}
+
+			if pageSize < 1 {
+				return fmt.Errorf("page size must be at least 1")
+			}
+
+			if err := validateBenchMix(mix); err != nil {
+				return err
+			}
+
+			if mix[benchOperationSearch] > 0 && adminEmail == "" {
+				return fmt.Errorf("admin email must be provided to run the search workload")
+			}
+
+			signingKey, err := readLoadgenSigningKey(signingKeyFile)
+			if err != nil {
+				return err
+			}
+
+			if tag == "" {
+				tag = "bench-" + time.Now().UTC().Format("20060102150405")
+			}
+
+			transportCredentials := grpc.WithInsecure()
+			if useTLS {
+				transportCredentials = grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, ""))
+			}
+
+			userClient, err := client.NewClient(cmd.Context(), address, &client.Options{
+				TokenProvider: func(ctx context.Context) (string, error) {
+					email, _ := ctx.Value(loadgenEmailContextKey{}).(string)
+
+					return signLoadgenToken(signingKey, jwa.SignatureAlgorithm(signingAlgorithm), email)
+				},
+				DialOptions: []grpc.DialOption{transportCredentials},
+			})
+			if err != nil {
+				return err
+			}
+
+			defer userClient.Close()
+
+			run := &benchRun{
+				userClient: userClient,
+				adminEmail: adminEmail,
+				pageSize:   pageSize,
+				tag:        tag,
+				domain:     domain,
+				latencies:  map[string][]time.Duration{},
+				errors:     map[string]int{},
+			}
+
+			if cleanup {
+				defer run.cleanup()
+			}
+
+			if err := run.createPool(cmd.Context(), userCount, concurrency); err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			if duration > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, duration)
+				defer cancel()
+			}
+
+			startedAt := time.Now()
+			run.bench(ctx, mix, requests, rate, concurrency)
+			result := run.result(time.Since(startedAt), concurrency)
+
+			if err := printOutput(cmd, result); err != nil {
+				return err
+			}
+
+			if result.ErrorRate > maxErrorRate {
+				return fmt.Errorf("the error rate %.4f exceeds %.4f", result.ErrorRate, maxErrorRate)
+			}
+
+			if maxP99 > 0 {
+				for _, operation := range benchOperations {
+					operationResult, ok := result.Operations[operation]
+					if !ok {
+						continue
+					}
+
+					if p99, _ := time.ParseDuration(operationResult.Latency.P99); p99 > maxP99 {
+						return fmt.Errorf("the p99 latency of %s %s exceeds %s", operation, operationResult.Latency.P99, maxP99)
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", "localhost:80", "The address of the user service gRPC endpoint")
+	cmd.Flags().BoolVar(&useTLS, "tls", false, "Connect to the user service using TLS")
+	cmd.Flags().DurationVar(&duration, "duration", time.Minute, "How long to run the benchmark for, runs until the requests are made if zero")
+	cmd.Flags().IntVar(&requests, "requests", 0, "The number of requests to make, runs until the duration passes if zero")
+	cmd.Flags().IntVar(&rate, "rate", 0, "The maximum number of requests per second, unlimited if zero")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 8, "The number of the requests made in parallel")
+	cmd.Flags().StringToIntVar(&mix, "mix", map[string]int{benchOperationCreate: 10, benchOperationRead: 70, benchOperationSearch: 20}, "The relative weight of the create, read and search operations")
+	cmd.Flags().IntVar(&userCount, "users", 100, "The number of users created before the run the read and search operations are made against")
+	cmd.Flags().Int32Var(&pageSize, "page-size", 10, "The number of users requested by every search")
+	cmd.Flags().StringVar(&tag, "tag", "", "The tag added to the email addresses of the users, a random tag is used if not provided")
+	cmd.Flags().StringVar(&domain, "domain", "example.com", "The domain of the email addresses of the users")
+	cmd.Flags().StringVar(&adminEmail, "admin-email", "", "The email address the search is made as, must be listed in ADMIN_EMAILS of the service")
+	cmd.Flags().StringVar(&signingKeyFile, "signing-key", "", "The file containing the private JWK the access tokens are signed with")
+	cmd.Flags().StringVar(&signingAlgorithm, "signing-algorithm", string(jwa.RS256), "The algorithm the access tokens are signed with, e.g. RS256 or ES256")
+	cmd.Flags().BoolVar(&cleanup, "cleanup", true, "Delete the users created by the benchmark when it ends")
+	cmd.Flags().Float64Var(&maxErrorRate, "max-error-rate", 0.01, "The error rate above which the benchmark fails, between 0 and 1")
+	cmd.Flags().DurationVar(&maxP99, "max-p99", 0, "The p99 latency of any operation above which the benchmark fails, not checked if zero")
+	_ = cmd.MarkFlagRequired("signing-key")
+
+	return cmd
+}
+
+func validateBenchMix(mix map[string]int) error {
+	total := 0
+
+	for operation, weight := range mix {
+		if !isBenchOperation(operation) {
+			return fmt.Errorf("unknown operation %q in the mix, must be one of %s", operation, strings.Join(benchOperations, ", "))
+		}
+
+		if weight < 0 {
+			return fmt.Errorf("the weight of %s must not be negative", operation)
+		}
+
+		total += weight
+	}
+
+	if total == 0 {
+		return fmt.Errorf("the mix must contain at least one operation with a positive weight")
+	}
+
+	return nil
+}
+
+func isBenchOperation(operation string) bool {
+	for _, benchOperation := range benchOperations {
+		if operation == benchOperation {
+			return true
+		}
+	}
+
+	return false
+}
+
+// createPool creates the users the read and search workloads are made against, the pool is not measured
+// ctx: Mandatory The reference to the context
+// count: Mandatory. The number of users in the pool
+// concurrency: Mandatory. The number of the calls made in parallel
+// Returns error if any user of the pool cannot be created
+func (run *benchRun) createPool(ctx context.Context, count int, concurrency int) error {
+	run.pool = make([]string, 0, count)
+	for index := 1; index <= count; index++ {
+		run.pool = append(run.pool, fmt.Sprintf("user+%s-pool-%d@%s", run.tag, index, run.domain))
+	}
+
+	var waitGroup sync.WaitGroup
+	var firstError error
+
+	queue := make(chan string)
+	for worker := 0; worker < concurrency; worker++ {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			for email := range queue {
+				if _, err := createLoadgenUser(ctx, run.userClient, email); err != nil {
+					run.lock.Lock()
+					if firstError == nil {
+						firstError = fmt.Errorf("failed to create the user pool: %s: %w", email, err)
+					}
+					run.lock.Unlock()
+
+					continue
+				}
+
+				run.lock.Lock()
+				run.created = append(run.created, email)
+				run.lock.Unlock()
+			}
+		}()
+	}
+
+	for _, email := range run.pool {
+		queue <- email
+	}
+
+	close(queue)
+	waitGroup.Wait()
+
+	return firstError
+}
+
+// bench makes the operations of the given mix until the context is done or the given number of requests are made
+// ctx: Mandatory The reference to the context, the benchmark runs until it is done
+// mix: Mandatory. The relative weight of every operation
+// requests: Optional. The number of requests to make, unlimited if zero
+// rate: Optional. The maximum number of requests per second, unlimited if zero
+// concurrency: Mandatory. The number of the requests made in parallel
+func (run *benchRun) bench(ctx context.Context, mix map[string]int, requests int, rate int, concurrency int) {
+	var waitGroup sync.WaitGroup
+
+	queue := make(chan int)
+	for worker := 0; worker < concurrency; worker++ {
+		waitGroup.Add(1)
+
+		go func(seed int64) {
+			defer waitGroup.Done()
+
+			random := rand.New(rand.NewSource(seed))
+			for index := range queue {
+				run.operate(random, chooseBenchOperation(random, mix), index)
+			}
+		}(time.Now().UnixNano() + int64(worker))
+	}
+
+	var ticks <-chan time.Time
+	if rate > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(rate))
+		defer ticker.Stop()
+
+		ticks = ticker.C
+	}
+
+	for index := 1; ctx.Err() == nil && (requests == 0 || index <= requests); index++ {
+		if ticks != nil {
+			select {
+			case <-ctx.Done():
+				continue
+			case <-ticks:
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+		case queue <- index:
+		}
+	}
+
+	close(queue)
+	waitGroup.Wait()
+}
+
+// chooseBenchOperation picks a random operation with a probability proportional to its weight in the mix
+func chooseBenchOperation(random *rand.Rand, mix map[string]int) string {
+	total := 0
+	for _, operation := range benchOperations {
+		total += mix[operation]
+	}
+
+	choice := random.Intn(total)
+	for _, operation := range benchOperations {
+		if choice < mix[operation] {
+			return operation
+		}
+
+		choice -= mix[operation]
+	}
+
+	return benchOperations[len(benchOperations)-1]
+}
+
+// operate makes and measures a single operation. The operation is not cancelled when the run ends, so the latency of
+// every request made is measured.
+func (run *benchRun) operate(random *rand.Rand, operation string, index int) {
+	ctx, cancel := context.WithTimeout(context.Background(), benchCallTimeout)
+	defer cancel()
+
+	var err error
+
+	startedAt := time.Now()
+
+	switch operation {
+	case benchOperationCreate:
+		email := fmt.Sprintf("user+%s-%d@%s", run.tag, index, run.domain)
+		if err = run.createUser(ctx, email); err == nil {
+			run.lock.Lock()
+			run.created = append(run.created, email)
+			run.lock.Unlock()
+		}
+	case benchOperationRead:
+		err = run.readUser(ctx, run.pool[random.Intn(len(run.pool))])
+	case benchOperationSearch:
+		err = run.search(ctx)
+	}
+
+	run.record(operation, time.Since(startedAt), err)
+}
+
+func (run *benchRun) createUser(ctx context.Context, email string) error {
+	response, err := run.userClient.ServiceClient.CreateUser(
+		context.WithValue(ctx, loadgenEmailContextKey{}, email),
+		&userGRPCContract.CreateUserRequest{User: &userGRPCContract.User{}})
+	if err != nil {
+		return err
+	}
+
+	if response.Error != userGRPCContract.Error_NO_ERROR {
+		return fmt.Errorf("%s: %s", response.Error, response.ErrorMessage)
+	}
+
+	return nil
+}
+
+func (run *benchRun) readUser(ctx context.Context, email string) error {
+	response, err := run.userClient.ServiceClient.ReadUser(
+		context.WithValue(ctx, loadgenEmailContextKey{}, email),
+		&userGRPCContract.ReadUserRequest{Email: email})
+	if err != nil {
+		return err
+	}
+
+	if response.Error != userGRPCContract.Error_NO_ERROR {
+		return fmt.Errorf("%s: %s", response.Error, response.ErrorMessage)
+	}
+
+	return nil
+}
+
+func (run *benchRun) search(ctx context.Context) error {
+	response, err := run.userClient.ServiceClient.Search(
+		context.WithValue(ctx, loadgenEmailContextKey{}, run.adminEmail),
+		&userGRPCContract.SearchRequest{
+			First:          run.pageSize,
+			Emails:         run.pool,
+			SortingOptions: []*userGRPCContract.SortingOptionPair{{Name: "email", Direction: userGRPCContract.SortingDirection_ASCENDING}},
+		})
+	if err != nil {
+		return err
+	}
+
+	if response.Error != userGRPCContract.Error_NO_ERROR {
+		return fmt.Errorf("%s: %s", response.Error, response.ErrorMessage)
+	}
+
+	return nil
+}
+
+func (run *benchRun) record(operation string, latency time.Duration, err error) {
+	run.lock.Lock()
+	defer run.lock.Unlock()
+
+	run.latencies[operation] = append(run.latencies[operation], latency)
+
+	if err != nil {
+		run.errors[operation]++
+		if run.firstError == "" {
+			run.firstError = fmt.Sprintf("%s: %v", operation, err)
+		}
+	}
+}
+
+// result summarizes the measured operations
+// elapsed: Mandatory. How long the benchmark ran for
+// concurrency: Mandatory. The number of the requests made in parallel
+// Returns the result of the run
+func (run *benchRun) result(elapsed time.Duration, concurrency int) *benchResult {
+	run.lock.Lock()
+	defer run.lock.Unlock()
+
+	result := &benchResult{
+		Tag:         run.tag,
+		Duration:    elapsed.Round(time.Millisecond).String(),
+		Concurrency: concurrency,
+		Operations:  map[string]*benchOperationResult{},
+		FirstError:  run.firstError,
+	}
+
+	for operation, latencies := range run.latencies {
+		result.Operations[operation] = &benchOperationResult{
+			Requests:   len(latencies),
+			Errors:     run.errors[operation],
+			ErrorRate:  benchRatio(run.errors[operation], len(latencies)),
+			Throughput: benchThroughput(len(latencies), elapsed),
+			Latency:    summarizeBenchLatencies(latencies),
+		}
+
+		result.Requests += len(latencies)
+		result.Errors += run.errors[operation]
+	}
+
+	result.ErrorRate = benchRatio(result.Errors, result.Requests)
+	result.Throughput = benchThroughput(result.Requests, elapsed)
+
+	return result
+}
+
+// cleanup deletes the users created by the benchmark
+func (run *benchRun) cleanup() {
+	for _, email := range run.created {
+		ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), loadgenEmailContextKey{}, email), benchCallTimeout)
+		_, _ = run.userClient.ServiceClient.DeleteUser(ctx, &userGRPCContract.DeleteUserRequest{Email: email})
+		cancel()
+	}
+}
+
+// summarizeBenchLatencies returns the mean and the percentiles of the latencies using the nearest-rank method
+func summarizeBenchLatencies(latencies []time.Duration) benchLatency {
+	if len(latencies) == 0 {
+		return benchLatency{}
+	}
+
+	sorted := append([]time.Duration{}, latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, latency := range sorted {
+		total += latency
+	}
+
+	percentile := func(percent float64) string {
+		rank := int(math.Ceil(percent / 100 * float64(len(sorted))))
+		if rank < 1 {
+			rank = 1
+		}
+
+		return formatBenchLatency(sorted[rank-1])
+	}
+
+	return benchLatency{
+		Min:  formatBenchLatency(sorted[0]),
+		Mean: formatBenchLatency(total / time.Duration(len(sorted))),
+		P50:  percentile(50),
+		P90:  percentile(90),
+		P95:  percentile(95),
+		P99:  percentile(99),
+		Max:  formatBenchLatency(sorted[len(sorted)-1]),
+	}
+}
+
+func formatBenchLatency(latency time.Duration) string {
+	return latency.Round(time.Microsecond).String()
+}
+
+func benchRatio(count int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return float64(count) / float64(total)
+}
+
+func benchThroughput(count int, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return math.Round(float64(count)/elapsed.Seconds()*100) / 100
+}
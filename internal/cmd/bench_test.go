@@ -0,0 +1,206 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/jwk"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bench Tests", func() {
+	var (
+		service        *fakeUserService
+		address        string
+		stopService    func()
+		directory      string
+		signingKeyFile string
+	)
+
+	type benchOperationResult struct {
+		Requests  int     `json:"requests"`
+		Errors    int     `json:"errors"`
+		ErrorRate float64 `json:"errorRate"`
+		Latency   struct {
+			Min string `json:"min"`
+			P50 string `json:"p50"`
+			P99 string `json:"p99"`
+			Max string `json:"max"`
+		} `json:"latency"`
+	}
+
+	type benchResult struct {
+		Tag         string                          `json:"tag"`
+		Concurrency int                             `json:"concurrency"`
+		Requests    int                             `json:"requests"`
+		Errors      int                             `json:"errors"`
+		ErrorRate   float64                         `json:"errorRate"`
+		Operations  map[string]benchOperationResult `json:"operations"`
+		FirstError  string                          `json:"firstError"`
+	}
+
+	// runBench runs a short benchmark against the fake service and decodes its result
+	runBench := func(args ...string) (benchResult, error) {
+		output, err := execute(append([]string{
+			"bench",
+			"--address", address,
+			"--signing-key", signingKeyFile,
+			"--admin-email", "admin@test.com",
+			"--tag", "bench-test",
+			"--domain", "test.com",
+			"--duration", "0",
+			"--requests", "60",
+			"--users", "5",
+			"--page-size", "3",
+			"--concurrency", "4",
+			"--mix", "create=1,read=1,search=1",
+			"-o", "json",
+		}, args...)...)
+
+		var result benchResult
+		if start := strings.Index(output, "{"); start != -1 {
+			Ω(json.NewDecoder(strings.NewReader(output[start:])).Decode(&result)).Should(Succeed())
+		}
+
+		return result, err
+	}
+
+	BeforeEach(func() {
+		service, address, stopService = startFakeUserService()
+
+		var err error
+		directory, err = ioutil.TempDir("", "bench")
+		Ω(err).Should(BeNil())
+
+		var keySet jwk.Set
+		signingKeyFile, keySet = writeSigningKeyFile(directory)
+		service.verifyTokensWith(keySet)
+	})
+
+	AfterEach(func() {
+		stopService()
+		os.RemoveAll(directory)
+	})
+
+	Context("the benchmark runs", func() {
+		It("should make the requested number of operations of the mix and report their latencies", func() {
+			result, err := runBench()
+			Ω(err).Should(BeNil())
+			Ω(result.Tag).Should(Equal("bench-test"))
+			Ω(result.Concurrency).Should(Equal(4))
+			Ω(result.Requests).Should(Equal(60))
+			Ω(result.Errors).Should(Equal(0), result.FirstError)
+			Ω(result.ErrorRate).Should(Equal(0.0))
+
+			total := 0
+			for _, operation := range []string{"create", "read", "search"} {
+				operationResult := result.Operations[operation]
+				Ω(operationResult.Requests).Should(BeNumerically(">", 0), operation)
+				Ω(operationResult.Latency.Min).ShouldNot(BeEmpty(), operation)
+				Ω(operationResult.Latency.P99).ShouldNot(BeEmpty(), operation)
+
+				total += operationResult.Requests
+			}
+
+			Ω(total).Should(Equal(60))
+
+			// The searches are made against the pool, which is created before the run
+			searchRequests := service.getSearchRequests()
+			Ω(searchRequests).Should(HaveLen(result.Operations["search"].Requests))
+			for _, searchRequest := range searchRequests {
+				Ω(searchRequest.First).Should(Equal(int32(3)))
+				Ω(searchRequest.Emails).Should(HaveLen(5))
+			}
+
+			for _, email := range service.getCreatedEmails() {
+				Ω(email).Should(MatchRegexp(`^user\+bench-test-(pool-)?[0-9]+@test\.com$`))
+			}
+
+			// The created users are deleted once the benchmark ends
+			Ω(service.getEmails()).Should(BeEmpty())
+		})
+
+		It("should only make the operations with a positive weight", func() {
+			result, err := runBench("--mix", "create=0,read=1,search=0")
+			Ω(err).Should(BeNil())
+			Ω(result.Operations).Should(HaveLen(1))
+			Ω(result.Operations["read"].Requests).Should(Equal(60))
+
+			// The pool is still created for the read operations
+			Ω(service.getCreatedEmails()).Should(HaveLen(5))
+		})
+
+		When("the cleanup is disabled", func() {
+			It("should keep the created users", func() {
+				result, err := runBench("--cleanup=false")
+				Ω(err).Should(BeNil())
+				Ω(service.getEmails()).Should(HaveLen(5 + result.Operations["create"].Requests))
+			})
+		})
+
+		When("the operations fail", func() {
+			BeforeEach(func() {
+				service.injectFaults(true, false)
+			})
+
+			It("should report the error rate and fail if it exceeds the maximum error rate", func() {
+				result, err := runBench("--mix", "create=0,read=1,search=0")
+				Ω(err).Should(MatchError(ContainSubstring("the error rate 1.0000 exceeds 0.0100")))
+				Ω(result.Errors).Should(Equal(60))
+				Ω(result.Operations["read"].ErrorRate).Should(Equal(1.0))
+				Ω(result.FirstError).Should(HavePrefix("read: USER_NOT_FOUND"))
+			})
+
+			It("should not fail if the error rate does not exceed the maximum error rate", func() {
+				result, err := runBench("--mix", "create=0,read=1,search=0", "--max-error-rate", "1")
+				Ω(err).Should(BeNil())
+				Ω(result.ErrorRate).Should(Equal(1.0))
+			})
+		})
+
+		When("the p99 latency exceeds the maximum", func() {
+			It("should fail naming the operation", func() {
+				_, err := runBench("--mix", "create=0,read=1,search=0", "--max-p99", "1ns")
+				Ω(err).Should(MatchError(ContainSubstring("the p99 latency of read")))
+			})
+		})
+
+		When("the user pool can not be created", func() {
+			It("should fail without running the benchmark", func() {
+				// The service rejects the access tokens as none of them is signed with a key of the empty key set
+				service.verifyTokensWith(jwk.NewSet())
+
+				_, err := runBench()
+				Ω(err).Should(MatchError(ContainSubstring("failed to create the user pool: user+bench-test-pool-")))
+				Ω(service.getSearchRequests()).Should(BeEmpty())
+			})
+		})
+	})
+
+	Context("the arguments are invalid", func() {
+		It("should return error without operating on any user", func() {
+			for _, args := range [][]string{
+				{"--requests", "0"},
+				{"--rate", "-1"},
+				{"--concurrency", "0"},
+				{"--users", "0"},
+				{"--page-size", "0"},
+				{"--mix", "update=1"},
+				{"--mix", "create=-1,read=1"},
+				{"--mix", "create=0,read=0,search=0"},
+				{"--admin-email", ""},
+				{"--signing-key", filepath.Join(directory, "missing.json")},
+			} {
+				_, err := runBench(args...)
+				Ω(err).ShouldNot(BeNil(), strings.Join(args, " "))
+			}
+
+			Ω(service.getCreatedEmails()).Should(BeEmpty())
+		})
+	})
+})
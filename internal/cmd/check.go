@@ -0,0 +1,71 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"github.com/decentralized-cloud/user/pkg/util"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/spf13/cobra"
+)
+
+// checkReport renders the validation report as a table listing every failure and reachability check
+type checkReport struct {
+	configuration.ValidationReport
+}
+
+func (report checkReport) TableHeaders() []string {
+	return []string{"NAME", "KIND", "STATUS", "MESSAGE"}
+}
+
+func (report checkReport) TableRows() [][]string {
+	rows := make([][]string, 0, len(report.Results))
+	for _, result := range report.Results {
+		rows = append(rows, []string{result.Name, result.Kind, result.Status, result.Message})
+	}
+
+	return rows
+}
+
+func newCheckCommand() *cobra.Command {
+	var skipReachabilityChecks bool
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Validate the configuration and the reachability of the database and the JWKS endpoint",
+		Long: `Validate every option of the configuration the way the service parses it and verify the database and
+the JWKS endpoint can be reached, reporting all the problems at once. The command fails if any problem is found,
+so it can run before the service is deployed, e.g. as an init container.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configurationService, err := getConfigurationService(cmd)
+			if err != nil {
+				return err
+			}
+
+			checks := []configuration.ValidationCheck{}
+			if !skipReachabilityChecks {
+				checks = util.NewReachabilityChecks(configurationService)
+			}
+
+			report := configuration.Validate(cmd.Context(), configurationService, checks...)
+			if err := printOutput(cmd, checkReport{report}); err != nil {
+				return err
+			}
+
+			return report.Err()
+		},
+	}
+
+	addSkipReachabilityChecksFlag(cmd, &skipReachabilityChecks)
+
+	return cmd
+}
+
+// addSkipReachabilityChecksFlag registers the flag that skips verifying the dependencies of the service can be reached
+// cmd: Mandatory. The command to register the flag on
+// skipReachabilityChecks: Mandatory. The variable the value of the flag is stored in
+func addSkipReachabilityChecksFlag(cmd *cobra.Command, skipReachabilityChecks *bool) {
+	cmd.Flags().BoolVar(
+		skipReachabilityChecks,
+		"skip-reachability-checks",
+		false,
+		"Only validate the options, without verifying the database and the JWKS endpoint can be reached")
+}
@@ -0,0 +1,47 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newCompletionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion bash|zsh|fish|powershell",
+		Short: "Generate the shell completion script",
+		Long: `Generate the shell completion script for the user CLI.
+
+Bash:
+  $ source <(user completion bash)
+
+Zsh:
+  $ user completion zsh > "${fpath[1]}/_user"
+
+Fish:
+  $ user completion fish > ~/.config/fish/completions/user.fish
+
+PowerShell:
+  PS> user completion powershell | Out-String | Invoke-Expression`,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.ExactValidArgs(1),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			writer := cmd.OutOrStdout()
+
+			switch args[0] {
+			case "bash":
+				return cmd.Root().GenBashCompletion(writer)
+			case "zsh":
+				return cmd.Root().GenZshCompletion(writer)
+			case "fish":
+				return cmd.Root().GenFishCompletion(writer, true)
+			case "powershell":
+				return cmd.Root().GenPowerShellCompletion(writer)
+			default:
+				return fmt.Errorf("unsupported shell %q", args[0])
+			}
+		},
+	}
+}
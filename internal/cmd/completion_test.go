@@ -0,0 +1,84 @@
+package cmd_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Completion And Docs Tests", func() {
+	Context("the shell completion script is generated", func() {
+		When("a supported shell is requested", func() {
+			It("should print the completion script of the shell that completes the commands", func() {
+				for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+					output, err := execute("completion", shell)
+					Ω(err).Should(BeNil())
+					Ω(output).Should(ContainSubstring(shell + " completion for user"))
+					Ω(output).Should(ContainSubstring("__user_debug"))
+				}
+			})
+		})
+
+		When("an unsupported shell is requested", func() {
+			It("should return error", func() {
+				_, err := execute("completion", "tcsh")
+				Ω(err).ShouldNot(BeNil())
+			})
+		})
+
+		When("no shell is requested", func() {
+			It("should return error", func() {
+				_, err := execute("completion")
+				Ω(err).ShouldNot(BeNil())
+			})
+		})
+	})
+
+	Context("the CLI reference documentation is generated", func() {
+		var (
+			directory string
+		)
+
+		BeforeEach(func() {
+			var err error
+			directory, err = ioutil.TempDir("", "docs")
+			Ω(err).Should(BeNil())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(directory)
+		})
+
+		When("the markdown format is requested", func() {
+			It("should write a page for every command", func() {
+				_, err := execute("docs", "--dir", directory)
+				Ω(err).Should(BeNil())
+
+				for _, page := range []string{"user.md", "user_version.md", "user_completion.md", "user_config_init.md"} {
+					Ω(filepath.Join(directory, page)).Should(BeAnExistingFile())
+				}
+			})
+		})
+
+		When("the man format is requested", func() {
+			It("should write a man page for every command", func() {
+				_, err := execute("docs", "--format", "man", "--dir", directory)
+				Ω(err).Should(BeNil())
+
+				for _, page := range []string{"user.1", "user-version.1", "user-completion.1"} {
+					Ω(filepath.Join(directory, page)).Should(BeAnExistingFile())
+				}
+			})
+		})
+
+		When("an unsupported format is requested", func() {
+			It("should return error", func() {
+				_, err := execute("docs", "--format", "html", "--dir", directory)
+				Ω(err).Should(MatchError(ContainSubstring("markdown|man")))
+			})
+		})
+	})
+})
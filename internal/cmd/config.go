@@ -0,0 +1,119 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/spf13/cobra"
+)
+
+// addConfigFlag registers the global --config flag on the given command
+// cmd: Mandatory. The root command
+func addConfigFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringP(
+		"config",
+		"c",
+		"",
+		"Read the configuration from the given YAML or JSON file, as generated by config init. The environment variables take precedence over the file")
+}
+
+// getConfigurationService returns the configuration service that reads the configuration file requested by the user,
+// or the environment variables only if no configuration file is requested
+// cmd: Mandatory. The command that is being executed
+// Returns either the configuration service or error if something goes wrong
+func getConfigurationService(cmd *cobra.Command) (configuration.ConfigurationContract, error) {
+	file, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return nil, err
+	}
+
+	if file == "" {
+		return configuration.NewEnvConfigurationService()
+	}
+
+	return configuration.NewFileConfigurationService(file)
+}
+
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the User service configuration",
+	}
+
+	cmd.AddCommand(newConfigInitCommand())
+
+	return cmd
+}
+
+func newConfigInitCommand() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Generate a commented configuration template with all the options and their defaults",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if file == "" {
+				return writeConfigTemplate(cmd.OutOrStdout())
+			}
+
+			output, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+			if err != nil {
+				return err
+			}
+
+			defer output.Close()
+
+			return writeConfigTemplate(output)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Write the template to the given file instead of the standard output, fails if the file exists")
+
+	return cmd
+}
+
+// writeConfigTemplate writes the YAML configuration template. Every key is the environment variable the option is read
+// from, so the template can be used as a docker-compose environment section or converted to a Kubernetes container env.
+// writer: Mandatory. The writer to write the template to
+// Returns error if something goes wrong
+func writeConfigTemplate(writer io.Writer) error {
+	if _, err := fmt.Fprint(writer, `# User service configuration template
+#
+# Every option is read from the environment variable with the same name as the key.
+# Options left empty use the default value, the service fails to start if a required option is empty.
+# Pass this file to the --config flag to read the options from it, the environment variables take precedence over it.
+`); err != nil {
+		return err
+	}
+
+	section := ""
+	for _, option := range configuration.Options() {
+		if option.Section != section {
+			section = option.Section
+			if _, err := fmt.Fprintf(writer, "\n# ---------- %s ----------\n", section); err != nil {
+				return err
+			}
+		}
+
+		notes := ""
+		switch {
+		case option.Required:
+			notes = " (required)"
+		case option.Default != "":
+			notes = fmt.Sprintf(" (default: %s)", option.Default)
+		}
+
+		if option.Secret {
+			notes += " (secret)"
+		}
+
+		if _, err := fmt.Fprintf(writer, "\n# %s%s\n%s: %q\n", option.Description, notes, option.EnvironmentVariable, option.Default); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
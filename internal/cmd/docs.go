@@ -0,0 +1,43 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+func newDocsCommand() *cobra.Command {
+	var format string
+	var directory string
+
+	cmd := &cobra.Command{
+		Use:    "docs",
+		Short:  "Generate the CLI reference documentation",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := os.MkdirAll(directory, 0755); err != nil {
+				return err
+			}
+
+			root := cmd.Root()
+			root.DisableAutoGenTag = true
+
+			switch format {
+			case "markdown":
+				return doc.GenMarkdownTree(root, directory)
+			case "man":
+				return doc.GenManTree(root, &doc.GenManHeader{Title: "USER", Section: "1"}, directory)
+			default:
+				return fmt.Errorf("unsupported documentation format %q, must be one of: markdown|man", format)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "markdown", "Documentation format. One of: markdown|man")
+	cmd.Flags().StringVar(&directory, "dir", "docs", "The directory to write the documentation to")
+
+	return cmd
+}
@@ -1 +0,0 @@
-package cmd_test
@@ -0,0 +1,285 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	exportFormatNDJSON = "ndjson"
+	exportFormatCSV    = "csv"
+
+	// exportProgressInterval is the number of the exported users the progress is reported after
+	exportProgressInterval = 10000
+)
+
+// exportRecord is a user written to the export file
+type exportRecord struct {
+	Email         string             `json:"email"`
+	DataResidency string             `json:"dataResidency,omitempty"`
+	Memberships   []exportMembership `json:"memberships,omitempty"`
+	Cursor        string             `json:"cursor"`
+	CreatedAt     string             `json:"createdAt,omitempty"`
+	DeletedAt     string             `json:"deletedAt,omitempty"`
+}
+
+type exportMembership struct {
+	TenantID string `json:"tenantID"`
+	Role     string `json:"role"`
+}
+
+// exportWriter writes the exported users in the format requested by the user
+type exportWriter interface {
+	write(record exportRecord) error
+	flush() error
+}
+
+func newExportCommand() *cobra.Command {
+	flags := &userConnectionFlags{}
+
+	var tenantID string
+	var createdAfter string
+	var createdBefore string
+	var includeDeleted bool
+	var after string
+	var format string
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export all the users through the gRPC API of a running user service",
+		Long: `Export all the users, optionally filtered by the tenant or the creation date, through the gRPC API of a
+running user service as newline delimited JSON or CSV. The call is made with the given access token, which
+must be the token of an admin user.
+
+The users are streamed from the repository cursors in the order they are persisted in, so exporting
+millions of users does not load them in memory. The progress and the cursor of the last exported user
+are reported to the standard error, an interrupted export is resumed by passing the cursor to --after
+and appending to the same file.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			request := &userGRPCContract.ExportUsersRequest{
+				TenantID:       tenantID,
+				IncludeDeleted: includeDeleted,
+				After:          after,
+			}
+
+			var err error
+			if request.CreatedAfter, err = parseExportTime("created-after", createdAfter); err != nil {
+				return err
+			}
+
+			if request.CreatedBefore, err = parseExportTime("created-before", createdBefore); err != nil {
+				return err
+			}
+
+			format = strings.ToLower(strings.Trim(format, " "))
+			if format != exportFormatNDJSON && format != exportFormatCSV {
+				return fmt.Errorf("unsupported export format %q, must be one of: ndjson|csv", format)
+			}
+
+			output := cmd.OutOrStdout()
+			if file != "" {
+				// The file is appended to, so a resumed export continues the file the interrupted one wrote
+				outputFile, err := os.OpenFile(file, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+				if err != nil {
+					return err
+				}
+
+				defer outputFile.Close()
+
+				output = outputFile
+			}
+
+			userClient, err := newUserServiceClient(cmd.Context(), flags.address, getAccessToken(flags.token), flags.useTLS)
+			if err != nil {
+				return err
+			}
+
+			defer userClient.Close()
+
+			// The export takes as long as streaming all the users does, so it is not bound by the call timeout
+			stream, err := userClient.ExportUsers(cmd.Context(), request)
+			if err != nil {
+				return err
+			}
+
+			bufferedOutput := bufio.NewWriter(output)
+			writer := newExportWriter(format, bufferedOutput, after == "")
+			progress := cmd.ErrOrStderr()
+			startedAt := time.Now()
+			exportedCount := 0
+			lastCursor := after
+
+			for {
+				user, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+
+				if err == nil {
+					err = writer.write(mapExportRecord(user))
+				}
+
+				if err != nil {
+					// The users received so far are written, so the export can be resumed after the last one of them
+					_ = writer.flush()
+					_ = bufferedOutput.Flush()
+					fmt.Fprintf(progress, "Export failed after %d users, resume it with --after %q\n", exportedCount, lastCursor)
+
+					return err
+				}
+
+				exportedCount++
+				lastCursor = user.Cursor
+
+				if exportedCount%exportProgressInterval == 0 {
+					fmt.Fprintf(progress, "Exported %d users, last cursor %q\n", exportedCount, lastCursor)
+				}
+			}
+
+			if err := writer.flush(); err != nil {
+				return err
+			}
+
+			if err := bufferedOutput.Flush(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(
+				progress,
+				"Exported %d users in %s, last cursor %q\n",
+				exportedCount,
+				time.Since(startedAt).Round(time.Millisecond),
+				lastCursor)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.address, "address", "localhost:80", "The address of the user service gRPC endpoint")
+	cmd.Flags().StringVar(&flags.token, "token", "", "The access token the call is made with, defaults to USER_ACCESS_TOKEN environment variable")
+	cmd.Flags().BoolVar(&flags.useTLS, "tls", false, "Connect to the user service using TLS")
+	cmd.Flags().StringVar(&tenantID, "tenant", "", "Export only the members of the given tenant")
+	cmd.Flags().StringVar(&createdAfter, "created-after", "", "Export only the users created at or after the given RFC3339 time")
+	cmd.Flags().StringVar(&createdBefore, "created-before", "", "Export only the users created before the given RFC3339 time")
+	cmd.Flags().BoolVar(&includeDeleted, "include-deleted", false, "Export the soft deleted users as well")
+	cmd.Flags().StringVar(&after, "after", "", "Resume the export after the given cursor of the last exported user")
+	cmd.Flags().StringVar(&format, "format", exportFormatNDJSON, "The format of the exported users. One of: ndjson|csv")
+	cmd.Flags().StringVar(&file, "file", "", "Append the exported users to the given file instead of writing them to the standard output")
+
+	return cmd
+}
+
+// parseExportTime parses the time provided in RFC3339 format
+// name: Mandatory. The name of the flag the time is provided by
+// value: Optional. The time to parse
+// Returns either the parsed time, nil if not provided, or error if the time is invalid
+func parseExportTime(name string, value string) (*timestamppb.Timestamp, error) {
+	if strings.Trim(value, " ") == "" {
+		return nil, nil
+	}
+
+	parsedTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s time %q, must be in RFC3339 format, e.g. 2021-01-01T00:00:00Z", name, value)
+	}
+
+	return timestamppb.New(parsedTime), nil
+}
+
+func mapExportRecord(user *userGRPCContract.UserWithCursor) exportRecord {
+	record := exportRecord{
+		Email:         user.Email,
+		DataResidency: user.User.GetDataResidency(),
+		Cursor:        user.Cursor,
+	}
+
+	for _, membership := range user.User.GetMemberships() {
+		record.Memberships = append(record.Memberships, exportMembership{
+			TenantID: membership.TenantID,
+			Role:     membership.Role,
+		})
+	}
+
+	if user.CreatedAt != nil {
+		record.CreatedAt = user.CreatedAt.AsTime().Format(time.RFC3339)
+	}
+
+	if user.DeletedAt != nil {
+		record.DeletedAt = user.DeletedAt.AsTime().Format(time.RFC3339)
+	}
+
+	return record
+}
+
+// newExportWriter creates the writer of the exported users in the given format
+// format: Mandatory. The format of the exported users
+// output: Mandatory. The writer the exported users are written to
+// writeHeader: Mandatory. Whether to write the CSV header row, not written when an export is resumed
+// Returns the writer of the exported users
+func newExportWriter(format string, output io.Writer, writeHeader bool) exportWriter {
+	if format == exportFormatCSV {
+		return &csvExportWriter{writer: csv.NewWriter(output), writeHeader: writeHeader}
+	}
+
+	return &ndjsonExportWriter{encoder: json.NewEncoder(output)}
+}
+
+type ndjsonExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (writer *ndjsonExportWriter) write(record exportRecord) error {
+	return writer.encoder.Encode(record)
+}
+
+func (writer *ndjsonExportWriter) flush() error {
+	return nil
+}
+
+type csvExportWriter struct {
+	writer      *csv.Writer
+	writeHeader bool
+}
+
+func (writer *csvExportWriter) write(record exportRecord) error {
+	if writer.writeHeader {
+		writer.writeHeader = false
+		if err := writer.writer.Write([]string{"email", "dataResidency", "memberships", "cursor", "createdAt", "deletedAt"}); err != nil {
+			return err
+		}
+	}
+
+	// The memberships are written as tenantID:role pairs separated by semicolons to keep one row per user
+	memberships := make([]string, 0, len(record.Memberships))
+	for _, membership := range record.Memberships {
+		memberships = append(memberships, membership.TenantID+":"+membership.Role)
+	}
+
+	return writer.writer.Write([]string{
+		record.Email,
+		record.DataResidency,
+		strings.Join(memberships, ";"),
+		record.Cursor,
+		record.CreatedAt,
+		record.DeletedAt,
+	})
+}
+
+func (writer *csvExportWriter) flush() error {
+	writer.writer.Flush()
+
+	return writer.writer.Error()
+}
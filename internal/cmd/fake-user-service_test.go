@@ -0,0 +1,361 @@
+package cmd_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	. "github.com/onsi/gomega"
+)
+
+// fakeUserService is the user service the commands are tested against. It keeps the users in memory, ordered by
+// their email addresses that are their cursors too, and records the requests it receives.
+type fakeUserService struct {
+	userGRPCContract.UnimplementedServiceServer
+
+	lock           sync.Mutex
+	keySet         jwk.Set
+	users          map[string]*userGRPCContract.User
+	createdEmails  []string
+	sagas          map[string]*userGRPCContract.Saga
+	searchRequests []*userGRPCContract.SearchRequest
+	authorizations []string
+
+	// The faults of the storage the service pretends to have
+	loseCreatedUsers   bool
+	duplicateSearchHit bool
+}
+
+// startFakeUserService serves a new fake user service on a local port
+// Returns the fake service, the address it is served on and the function that stops serving it
+func startFakeUserService() (*fakeUserService, string, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Ω(err).Should(BeNil())
+
+	service := &fakeUserService{
+		users: map[string]*userGRPCContract.User{},
+		sagas: map[string]*userGRPCContract.Saga{},
+	}
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(service.recordAuthorization))
+	userGRPCContract.RegisterServiceServer(server, service)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	return service, listener.Addr().String(), server.Stop
+}
+
+// writeSigningKeyFile writes a new private JWK to a file in the given directory, as the loadgen and soak commands read
+// the key the access tokens are signed with
+// Returns the file and the key set containing the public key the tokens can be verified with
+func writeSigningKeyFile(directory string) (string, jwk.Set) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	Ω(err).Should(BeNil())
+
+	signingKey, err := jwk.New(privateKey)
+	Ω(err).Should(BeNil())
+
+	_ = signingKey.Set(jwk.KeyIDKey, "test")
+	_ = signingKey.Set(jwk.AlgorithmKey, jwa.RS256)
+
+	content, err := json.Marshal(signingKey)
+	Ω(err).Should(BeNil())
+
+	file := filepath.Join(directory, "signing-key.json")
+	Ω(ioutil.WriteFile(file, content, 0600)).Should(Succeed())
+
+	publicKey, err := jwk.PublicKeyOf(signingKey)
+	Ω(err).Should(BeNil())
+
+	keySet := jwk.NewSet()
+	keySet.Add(publicKey)
+
+	return file, keySet
+}
+
+// addUsers adds the users with the given email addresses
+func (service *fakeUserService) addUsers(emails ...string) {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	for _, email := range emails {
+		service.users[email] = &userGRPCContract.User{}
+	}
+}
+
+// addSaga adds the given saga
+func (service *fakeUserService) addSaga(saga *userGRPCContract.Saga) {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	service.sagas[saga.SagaID] = saga
+}
+
+// verifyTokensWith makes the service reject the access tokens that are not signed with the keys of the given set
+func (service *fakeUserService) verifyTokensWith(keySet jwk.Set) {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	service.keySet = keySet
+}
+
+// injectFaults makes the service lose the created users or return every user it finds twice
+func (service *fakeUserService) injectFaults(loseCreatedUsers bool, duplicateSearchHit bool) {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	service.loseCreatedUsers = loseCreatedUsers
+	service.duplicateSearchHit = duplicateSearchHit
+}
+
+// getEmails returns the email addresses of the users the service keeps, sorted
+func (service *fakeUserService) getEmails() []string {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	emails := []string{}
+	for email := range service.users {
+		emails = append(emails, email)
+	}
+
+	sort.Strings(emails)
+
+	return emails
+}
+
+// getCreatedEmails returns the email addresses of the users created so far, in the order they are created
+func (service *fakeUserService) getCreatedEmails() []string {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	return append([]string{}, service.createdEmails...)
+}
+
+// getSearchRequests returns the search requests received so far
+func (service *fakeUserService) getSearchRequests() []*userGRPCContract.SearchRequest {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	return append([]*userGRPCContract.SearchRequest{}, service.searchRequests...)
+}
+
+// getAuthorizations returns the authorization headers of the calls received so far
+func (service *fakeUserService) getAuthorizations() []string {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	return append([]string{}, service.authorizations...)
+}
+
+func (service *fakeUserService) recordAuthorization(
+	ctx context.Context,
+	request interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	service.lock.Lock()
+	service.authorizations = append(service.authorizations, strings.Join(md.Get("authorization"), ","))
+	service.lock.Unlock()
+
+	return handler(ctx, request)
+}
+
+func (service *fakeUserService) CreateUser(
+	ctx context.Context,
+	request *userGRPCContract.CreateUserRequest) (*userGRPCContract.CreateUserResponse, error) {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	// The same as the user service, the email address of the user is the one the access token is issued for
+	md, _ := metadata.FromIncomingContext(ctx)
+	options := []jwt.ParseOption{}
+	if service.keySet != nil {
+		options = append(options, jwt.WithKeySet(service.keySet))
+	}
+
+	token, err := jwt.ParseString(strings.TrimPrefix(strings.Join(md.Get("authorization"), ""), "Bearer "), options...)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	email, _ := token.Get("email")
+	castedEmail, _ := email.(string)
+
+	if _, found := service.users[castedEmail]; found {
+		return &userGRPCContract.CreateUserResponse{
+			Error:        userGRPCContract.Error_USER_ALREADY_EXISTS,
+			ErrorMessage: "user already exists",
+		}, nil
+	}
+
+	if !service.loseCreatedUsers {
+		service.users[castedEmail] = request.User
+	}
+
+	service.createdEmails = append(service.createdEmails, castedEmail)
+
+	return &userGRPCContract.CreateUserResponse{User: request.User, Cursor: castedEmail}, nil
+}
+
+func (service *fakeUserService) ReadUser(
+	ctx context.Context,
+	request *userGRPCContract.ReadUserRequest) (*userGRPCContract.ReadUserResponse, error) {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	user, found := service.users[request.Email]
+	if !found {
+		return &userGRPCContract.ReadUserResponse{
+			Error:        userGRPCContract.Error_USER_NOT_FOUND,
+			ErrorMessage: "user not found",
+		}, nil
+	}
+
+	return &userGRPCContract.ReadUserResponse{User: user}, nil
+}
+
+func (service *fakeUserService) UpdateUser(
+	ctx context.Context,
+	request *userGRPCContract.UpdateUserRequest) (*userGRPCContract.UpdateUserResponse, error) {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	if _, found := service.users[request.Email]; !found {
+		return &userGRPCContract.UpdateUserResponse{
+			Error:        userGRPCContract.Error_USER_NOT_FOUND,
+			ErrorMessage: "user not found",
+		}, nil
+	}
+
+	service.users[request.Email] = request.User
+
+	return &userGRPCContract.UpdateUserResponse{User: request.User}, nil
+}
+
+func (service *fakeUserService) DeleteUser(
+	ctx context.Context,
+	request *userGRPCContract.DeleteUserRequest) (*userGRPCContract.DeleteUserResponse, error) {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	if _, found := service.users[request.Email]; !found {
+		return &userGRPCContract.DeleteUserResponse{
+			Error:        userGRPCContract.Error_USER_NOT_FOUND,
+			ErrorMessage: "user not found",
+		}, nil
+	}
+
+	delete(service.users, request.Email)
+
+	return &userGRPCContract.DeleteUserResponse{SagaID: "delete-" + request.Email}, nil
+}
+
+func (service *fakeUserService) Search(
+	ctx context.Context,
+	request *userGRPCContract.SearchRequest) (*userGRPCContract.SearchResponse, error) {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	service.searchRequests = append(service.searchRequests, request)
+
+	emails := []string{}
+	for email := range service.users {
+		if len(request.Emails) == 0 || contains(request.Emails, email) {
+			emails = append(emails, email)
+		}
+	}
+
+	descending := len(request.SortingOptions) > 0 && request.SortingOptions[0].Direction == userGRPCContract.SortingDirection_DESCENDING
+	sort.Slice(emails, func(i, j int) bool {
+		if descending {
+			return emails[i] > emails[j]
+		}
+
+		return emails[i] < emails[j]
+	})
+
+	start, end := 0, len(emails)
+	for index, email := range emails {
+		if email == request.After {
+			start = index + 1
+		}
+
+		if email == request.Before {
+			end = index
+		}
+	}
+
+	if request.First > 0 && start+int(request.First) < end {
+		end = start + int(request.First)
+	}
+
+	if request.Last > 0 && end-int(request.Last) > start {
+		start = end - int(request.Last)
+	}
+
+	response := &userGRPCContract.SearchResponse{
+		TotalCount:      int64(len(emails)),
+		HasPreviousPage: start > 0,
+		HasNextPage:     end < len(emails),
+	}
+
+	for _, email := range emails[start:end] {
+		response.Users = append(response.Users, &userGRPCContract.UserWithCursor{
+			Email:  email,
+			User:   service.users[email],
+			Cursor: email,
+		})
+
+		if service.duplicateSearchHit {
+			response.Users = append(response.Users, response.Users[len(response.Users)-1])
+		}
+	}
+
+	return response, nil
+}
+
+func (service *fakeUserService) GetSagaStatus(
+	ctx context.Context,
+	request *userGRPCContract.GetSagaStatusRequest) (*userGRPCContract.GetSagaStatusResponse, error) {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	saga, found := service.sagas[request.SagaID]
+	if !found {
+		return &userGRPCContract.GetSagaStatusResponse{
+			Error:        userGRPCContract.Error_SAGA_NOT_FOUND,
+			ErrorMessage: "saga not found",
+		}, nil
+	}
+
+	return &userGRPCContract.GetSagaStatusResponse{Saga: saga}, nil
+}
+
+func contains(items []string, item string) bool {
+	for _, current := range items {
+		if current == item {
+			return true
+		}
+	}
+
+	return false
+}
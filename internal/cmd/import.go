@@ -0,0 +1,367 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/pkg/util"
+	"github.com/decentralized-cloud/user/services/business"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	importCallTimeout = 30 * time.Second
+
+	importFormatAuto = "auto"
+	importFormatCSV  = "csv"
+	importFormatJSON = "json"
+)
+
+// importRecord is a user read from the import file
+type importRecord struct {
+	Email         string `json:"email"`
+	DataResidency string `json:"dataResidency"`
+
+	// row is the line of the CSV file or the position in the JSON array the user is read from, starting from 1
+	row int
+}
+
+type importFailedRow struct {
+	Row           int    `json:"row"`
+	Email         string `json:"email"`
+	DataResidency string `json:"dataResidency,omitempty"`
+	Error         string `json:"error"`
+}
+
+type importResult struct {
+	File           string            `json:"file"`
+	Total          int               `json:"total"`
+	Created        int               `json:"created"`
+	AlreadyExisted int               `json:"alreadyExisted"`
+	Failed         int               `json:"failed"`
+	Duration       string            `json:"duration"`
+	FailedRows     []importFailedRow `json:"failedRows,omitempty"`
+}
+
+func newImportCommand() *cobra.Command {
+	var format string
+	var batchSize int
+	var failedRowsFile string
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Create the users read from a CSV or JSON file straight in the database",
+		Long: `Create the users read from a CSV or JSON file straight in the database of the region every user resides in.
+
+The CSV file must start with a header row naming the email and dataResidency columns, the JSON file must
+contain an array of objects with the same fields. Every user is validated with the same rules the service
+applies before any user is created, the users that already exist are skipped.
+
+The database is read from the same environment variables or configuration file the service reads it from.
+The users are created through the repository, so no user lifecycle event is published and no audit record
+is written for them.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if batchSize < 1 {
+				return fmt.Errorf("batch size must be at least 1")
+			}
+
+			records, err := readImportFile(args[0], format)
+			if err != nil {
+				return err
+			}
+
+			result := &importResult{File: args[0], Total: len(records)}
+			startedAt := time.Now()
+
+			validRecords := validateImportRecords(records, result)
+
+			if len(validRecords) > 0 {
+				configurationService, err := getConfigurationService(cmd)
+				if err != nil {
+					return err
+				}
+
+				repositoryService, err := util.NewDatabaseRepositoryService(configurationService)
+				if err != nil {
+					return err
+				}
+
+				importUsers(cmd.Context(), cmd.ErrOrStderr(), repositoryService, validRecords, batchSize, result)
+			}
+
+			result.Duration = time.Since(startedAt).Round(time.Millisecond).String()
+
+			// The users of a batch are created in parallel, so their failures are recorded out of order
+			sort.Slice(result.FailedRows, func(i, j int) bool { return result.FailedRows[i].Row < result.FailedRows[j].Row })
+
+			if failedRowsFile != "" && len(result.FailedRows) > 0 {
+				if err := writeImportFailedRows(failedRowsFile, result.FailedRows); err != nil {
+					return err
+				}
+			}
+
+			return printOutput(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", importFormatAuto, "The format of the file. One of: auto|csv|json, auto detects the format by the file extension")
+	cmd.Flags().IntVar(&batchSize, "batch-size", 50, "The number of users created in parallel before the progress is reported")
+	cmd.Flags().StringVar(&failedRowsFile, "failed-rows", "", "Write the rows that failed to the given CSV file, which can be imported again once fixed")
+
+	return cmd
+}
+
+// readImportFile reads the users from the CSV or JSON file
+// file: Mandatory. The file to read the users from
+// format: Mandatory. The format of the file, detected by the file extension if auto
+// Returns either the users or error if the file cannot be read or parsed
+func readImportFile(file string, format string) ([]importRecord, error) {
+	format = strings.ToLower(strings.Trim(format, " "))
+	if format == importFormatAuto {
+		format = importFormatCSV
+		if strings.EqualFold(filepath.Ext(file), ".json") {
+			format = importFormatJSON
+		}
+	}
+
+	input, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+
+	defer input.Close()
+
+	switch format {
+	case importFormatCSV:
+		return readImportCSV(input)
+	case importFormatJSON:
+		return readImportJSON(input)
+	default:
+		return nil, fmt.Errorf("unsupported import format %q, must be one of: auto|csv|json", format)
+	}
+}
+
+func readImportCSV(reader io.Reader) ([]importRecord, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return []importRecord{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	emailColumn, dataResidencyColumn := -1, -1
+	for index, name := range header {
+		switch strings.ToLower(strings.Trim(name, " ")) {
+		case "email":
+			emailColumn = index
+		case "dataresidency":
+			dataResidencyColumn = index
+		}
+	}
+
+	if emailColumn == -1 {
+		return nil, fmt.Errorf("the header row of the CSV file does not contain the email column")
+	}
+
+	records := []importRecord{}
+	for row := 2; ; row++ {
+		fields, err := csvReader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		record := importRecord{row: row}
+		if emailColumn < len(fields) {
+			record.Email = fields[emailColumn]
+		}
+
+		if dataResidencyColumn != -1 && dataResidencyColumn < len(fields) {
+			record.DataResidency = fields[dataResidencyColumn]
+		}
+
+		records = append(records, record)
+	}
+}
+
+func readImportJSON(reader io.Reader) ([]importRecord, error) {
+	records := []importRecord{}
+	if err := json.NewDecoder(reader).Decode(&records); err != nil {
+		return nil, fmt.Errorf("failed to parse the JSON file: %w", err)
+	}
+
+	for index := range records {
+		records[index].row = index + 1
+	}
+
+	return records, nil
+}
+
+// validateImportRecords validates the users with the rules the service applies when the users are created and records
+// the invalid ones as failed
+// records: Mandatory. The users read from the import file
+// result: Mandatory. The result of the import the invalid users are recorded in
+// Returns the valid users
+func validateImportRecords(records []importRecord, result *importResult) []importRecord {
+	validRecords := make([]importRecord, 0, len(records))
+	rows := map[string]int{}
+
+	for _, record := range records {
+		record.Email = strings.Trim(record.Email, " ")
+		record.DataResidency = strings.Trim(record.DataResidency, " ")
+
+		request := business.CreateUserRequest{
+			Email: record.Email,
+			User:  models.User{DataResidency: record.DataResidency},
+		}
+
+		if err := request.Validate(); err != nil {
+			recordImportFailure(result, record, err)
+
+			continue
+		}
+
+		if row, ok := rows[strings.ToLower(record.Email)]; ok {
+			recordImportFailure(result, record, fmt.Errorf("duplicate of row %d", row))
+
+			continue
+		}
+
+		rows[strings.ToLower(record.Email)] = record.row
+		validRecords = append(validRecords, record)
+	}
+
+	return validRecords
+}
+
+// importUsers creates the users in batches and reports the progress after every batch
+// ctx: Mandatory The reference to the context
+// progress: Mandatory. The writer the progress is reported to
+// repositoryService: Mandatory. The repository service the users are created through
+// records: Mandatory. The valid users to create
+// batchSize: Mandatory. The number of users created in parallel
+// result: Mandatory. The result of the import the outcome of every user is recorded in
+func importUsers(
+	ctx context.Context,
+	progress io.Writer,
+	repositoryService repository.RepositoryContract,
+	records []importRecord,
+	batchSize int,
+	result *importResult) {
+	var lock sync.Mutex
+
+	for start := 0; start < len(records); start += batchSize {
+		end := start + batchSize
+		if end > len(records) {
+			end = len(records)
+		}
+
+		var waitGroup sync.WaitGroup
+		for _, record := range records[start:end] {
+			waitGroup.Add(1)
+
+			go func(record importRecord) {
+				defer waitGroup.Done()
+
+				err := createImportUser(ctx, repositoryService, record)
+
+				lock.Lock()
+				defer lock.Unlock()
+
+				switch {
+				case commonErrors.IsAlreadyExistsError(err):
+					result.AlreadyExisted++
+				case err != nil:
+					recordImportFailure(result, record, err)
+				default:
+					result.Created++
+				}
+			}(record)
+		}
+
+		waitGroup.Wait()
+
+		fmt.Fprintf(
+			progress,
+			"Imported %d/%d users: %d created, %d already existed, %d failed\n",
+			end,
+			len(records),
+			result.Created,
+			result.AlreadyExisted,
+			result.Failed)
+	}
+}
+
+func createImportUser(ctx context.Context, repositoryService repository.RepositoryContract, record importRecord) error {
+	ctx, cancel := context.WithTimeout(ctx, importCallTimeout)
+	defer cancel()
+
+	_, err := repositoryService.CreateUser(ctx, &repository.CreateUserRequest{
+		Email: record.Email,
+		User:  models.User{DataResidency: record.DataResidency},
+	})
+
+	return err
+}
+
+func recordImportFailure(result *importResult, record importRecord, err error) {
+	result.Failed++
+	result.FailedRows = append(result.FailedRows, importFailedRow{
+		Row:           record.row,
+		Email:         record.Email,
+		DataResidency: record.DataResidency,
+		Error:         err.Error(),
+	})
+}
+
+// writeImportFailedRows writes the failed rows to a CSV file in the import format, so the file can be imported again
+// once the rows are fixed. The error column is ignored when the file is imported.
+// file: Mandatory. The file to write the failed rows to
+// failedRows: Mandatory. The failed rows
+// Returns error if something goes wrong
+func writeImportFailedRows(file string, failedRows []importFailedRow) error {
+	output, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	defer output.Close()
+
+	csvWriter := csv.NewWriter(output)
+	if err := csvWriter.Write([]string{"email", "dataResidency", "error"}); err != nil {
+		return err
+	}
+
+	for _, failedRow := range failedRows {
+		if err := csvWriter.Write([]string{failedRow.Email, failedRow.DataResidency, failedRow.Error}); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
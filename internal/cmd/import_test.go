@@ -0,0 +1,186 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Import Tests", func() {
+	var (
+		directory string
+	)
+
+	type importFailedRow struct {
+		Row           int    `json:"row"`
+		Email         string `json:"email"`
+		DataResidency string `json:"dataResidency"`
+		Error         string `json:"error"`
+	}
+
+	type importResult struct {
+		Total          int               `json:"total"`
+		Created        int               `json:"created"`
+		AlreadyExisted int               `json:"alreadyExisted"`
+		Failed         int               `json:"failed"`
+		FailedRows     []importFailedRow `json:"failedRows"`
+	}
+
+	// writeFile writes the import file with the given content
+	writeFile := func(name string, content string) string {
+		file := filepath.Join(directory, name)
+		Ω(ioutil.WriteFile(file, []byte(content), 0600)).Should(Succeed())
+
+		return file
+	}
+
+	// runImport imports the given file and decodes the result
+	runImport := func(args ...string) (importResult, error) {
+		output, err := execute(append([]string{"import", "-o", "json"}, args...)...)
+
+		var result importResult
+		if err == nil {
+			Ω(json.Unmarshal([]byte(output), &result)).Should(Succeed())
+		}
+
+		return result, err
+	}
+
+	BeforeEach(func() {
+		var err error
+		directory, err = ioutil.TempDir("", "import")
+		Ω(err).Should(BeNil())
+
+		for _, option := range configuration.Options() {
+			os.Unsetenv(option.EnvironmentVariable)
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+
+		for _, option := range configuration.Options() {
+			os.Unsetenv(option.EnvironmentVariable)
+		}
+	})
+
+	// The valid users are created straight in the database, so these tests import the files whose users are all
+	// invalid. Creating the users is tested by the integration tests.
+	Context("the users are validated", func() {
+		When("the CSV file contains invalid users", func() {
+			It("should record every invalid user as failed with its line number without connecting to the database", func() {
+				file := writeFile("users.csv", "dataResidency, email\n,not-an-email\neu,\nEU,user@test.com\n")
+
+				result, err := runImport(file)
+				Ω(err).Should(BeNil())
+				Ω(result.Total).Should(Equal(3))
+				Ω(result.Created).Should(Equal(0))
+				Ω(result.Failed).Should(Equal(3))
+				Ω(result.FailedRows).Should(HaveLen(3))
+
+				for index, failedRow := range result.FailedRows {
+					Ω(failedRow.Row).Should(Equal(index + 2))
+					Ω(failedRow.Error).ShouldNot(BeEmpty())
+				}
+
+				Ω(result.FailedRows[0].Email).Should(Equal("not-an-email"))
+				Ω(result.FailedRows[2].DataResidency).Should(Equal("EU"))
+			})
+		})
+
+		When("the JSON file contains invalid users", func() {
+			It("should record every invalid user as failed with its position in the array", func() {
+				file := writeFile("users.json", `[{"email": "first"}, {"email": " "}]`)
+
+				result, err := runImport(file)
+				Ω(err).Should(BeNil())
+				Ω(result.Total).Should(Equal(2))
+				Ω(result.Failed).Should(Equal(2))
+				Ω(result.FailedRows[0].Row).Should(Equal(1))
+				Ω(result.FailedRows[1].Row).Should(Equal(2))
+				Ω(result.FailedRows[1].Email).Should(BeEmpty())
+			})
+		})
+
+		When("the failed rows are written to a file", func() {
+			It("should write them in the import format so the file can be imported again", func() {
+				file := writeFile("users.csv", "email,dataResidency\nfirst,eu\nsecond,us\n")
+				failedRowsFile := filepath.Join(directory, "failed.csv")
+
+				_, err := runImport(file, "--failed-rows", failedRowsFile)
+				Ω(err).Should(BeNil())
+
+				content, err := ioutil.ReadFile(failedRowsFile)
+				Ω(err).Should(BeNil())
+				Ω(string(content)).Should(HavePrefix("email,dataResidency,error\nfirst,eu,"))
+
+				result, err := runImport(failedRowsFile)
+				Ω(err).Should(BeNil())
+				Ω(result.Total).Should(Equal(2))
+				Ω(result.FailedRows[0].Email).Should(Equal("first"))
+				Ω(result.FailedRows[1].DataResidency).Should(Equal("us"))
+			})
+		})
+
+		When("the file is empty", func() {
+			It("should import nothing", func() {
+				result, err := runImport(writeFile("users.csv", ""))
+				Ω(err).Should(BeNil())
+				Ω(result.Total).Should(Equal(0))
+				Ω(result.FailedRows).Should(BeEmpty())
+			})
+		})
+
+		When("the users are valid but the repository is kept in the memory of the service", func() {
+			It("should return error", func() {
+				os.Setenv("REPOSITORY_TYPE", "memory")
+				os.Setenv("DATABASE_TYPE", "mongodb")
+
+				_, err := runImport(writeFile("users.csv", "email\nuser@test.com\n"))
+				Ω(err).Should(MatchError(ContainSubstring("can not be managed by the commands")))
+			})
+		})
+	})
+
+	Context("the format of the file is detected", func() {
+		When("the format is provided", func() {
+			It("should parse the file in the format regardless of its extension", func() {
+				result, err := runImport(writeFile("users.txt", `[{"email": "first"}]`), "--format", "JSON")
+				Ω(err).Should(BeNil())
+				Ω(result.Total).Should(Equal(1))
+			})
+		})
+
+		When("the format is not provided", func() {
+			It("should parse the files with any extension but .json as CSV", func() {
+				result, err := runImport(writeFile("users.txt", "email\nfirst\nsecond\n"))
+				Ω(err).Should(BeNil())
+				Ω(result.Total).Should(Equal(2))
+			})
+		})
+	})
+
+	Context("the arguments are invalid", func() {
+		It("should return error", func() {
+			csvFile := writeFile("users.csv", "email\nfirst\n")
+
+			for _, args := range [][]string{
+				{},
+				{filepath.Join(directory, "missing.csv")},
+				{csvFile, "--format", "xml"},
+				{csvFile, "--batch-size", "0"},
+				{writeFile("no-email.csv", "name,dataResidency\nfirst,eu\n")},
+				{writeFile("invalid.json", `{"email": "first"}`)},
+			} {
+				_, err := runImport(args...)
+				Ω(err).ShouldNot(BeNil())
+			}
+		})
+	})
+})
@@ -0,0 +1,148 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/repository/mongodb"
+	"github.com/spf13/cobra"
+)
+
+const indexAdvisorTimeout = time.Minute
+
+type indexAdviceRow struct {
+	Region         string `json:"region"`
+	FilterShape    string `json:"filterShape"`
+	SortField      string `json:"sortField"`
+	Status         string `json:"status"`
+	Index          string `json:"index,omitempty"`
+	SuggestedIndex string `json:"suggestedIndex,omitempty"`
+}
+
+type indexAdvisorResult struct {
+	MissingIndexes int              `json:"missingIndexes"`
+	Searches       []indexAdviceRow `json:"searches"`
+}
+
+// TableHeaders returns the column headers of the index advice table
+func (result indexAdvisorResult) TableHeaders() []string {
+	return []string{"REGION", "FILTER", "SORT", "STATUS", "INDEX", "SUGGESTED INDEX"}
+}
+
+// TableRows returns the advice of every search, one per row
+func (result indexAdvisorResult) TableRows() [][]string {
+	rows := make([][]string, 0, len(result.Searches))
+	for _, search := range result.Searches {
+		filterShape := search.FilterShape
+		if filterShape == "" {
+			filterShape = "-"
+		}
+
+		rows = append(rows, []string{search.Region, filterShape, search.SortField, search.Status, search.Index, search.SuggestedIndex})
+	}
+
+	return rows
+}
+
+func newIndexAdvisorCommand() *cobra.Command {
+	var failOnMissing bool
+
+	cmd := &cobra.Command{
+		Use:   "index-advisor",
+		Short: "Report the MongoDB indexes the allowed searches are missing",
+		Long: `Report whether the MongoDB users collection of every region has an index for every search the service allows.
+
+The searches are the combinations of the filter shapes and the sortable fields. The filter shapes are the
+unfiltered search, the search by the email addresses and the shapes USER_DATABASE_SEARCH_INDEX_HINTS is set
+for, the sortable fields are set by USER_DATABASE_SEARCH_SORTABLE_FIELDS. A search that has no index makes
+MongoDB scan and sort the whole collection, so either the suggested index should be created or the field
+should be removed from the sortable fields. The database is read from the same environment variables or
+configuration file the service reads it from.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := adviseIndexes(cmd)
+			if err != nil {
+				return err
+			}
+
+			if err = printOutput(cmd, result); err != nil {
+				return err
+			}
+
+			if failOnMissing && result.MissingIndexes > 0 {
+				return fmt.Errorf("%d search(es) have no index", result.MissingIndexes)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&failOnMissing, "fail-on-missing", false, "Exit with an error if any search has no index, e.g. to gate a rollout")
+
+	return cmd
+}
+
+// adviseIndexes reports whether the users collection of every region has an index for every search the service allows
+// cmd: Mandatory. The command that is being executed
+// Returns either the advice of every search of every region or error if something goes wrong
+func adviseIndexes(cmd *cobra.Command) (indexAdvisorResult, error) {
+	configurationService, err := getConfigurationService(cmd)
+	if err != nil {
+		return indexAdvisorResult{}, err
+	}
+
+	databaseType, err := configurationService.GetDatabaseType()
+	if err != nil {
+		return indexAdvisorResult{}, err
+	}
+
+	if databaseType != "mongodb" {
+		return indexAdvisorResult{}, fmt.Errorf("only the indexes of the mongodb database can be reported, the %s database is in use", databaseType)
+	}
+
+	regionConfigurationServices, err := getRegionConfigurationServices(configurationService)
+	if err != nil {
+		return indexAdvisorResult{}, err
+	}
+
+	regions := make([]string, 0, len(regionConfigurationServices))
+	for region := range regionConfigurationServices {
+		regions = append(regions, region)
+	}
+
+	sort.Strings(regions)
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), indexAdvisorTimeout)
+	defer cancel()
+
+	result := indexAdvisorResult{Searches: []indexAdviceRow{}}
+	for _, region := range regions {
+		advice, err := mongodb.AdviseSearchIndexes(ctx, regionConfigurationServices[region])
+		if err != nil {
+			return indexAdvisorResult{}, fmt.Errorf("failed to report the indexes of region %s: %w", region, err)
+		}
+
+		for _, search := range advice {
+			row := indexAdviceRow{
+				Region:         region,
+				FilterShape:    search.FilterShape,
+				SortField:      search.SortField,
+				Status:         "indexed",
+				Index:          search.Index,
+				SuggestedIndex: search.SuggestedIndex,
+			}
+
+			if search.Index == "" {
+				row.Status = "missing"
+				result.MissingIndexes++
+			}
+
+			result.Searches = append(result.Searches, row)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,318 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/pkg/client"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	loadgenCallTimeout   = 30 * time.Second
+	loadgenTokenLifetime = time.Hour
+
+	// defaultLoadgenEmailTemplate tags every generated email address with the tag of the run, so the generated
+	// users can be found and cleaned up by the tag
+	defaultLoadgenEmailTemplate = "{{.FirstName}}.{{.LastName}}+{{.Tag}}-{{.Index}}@{{.Domain}}"
+)
+
+type loadgenEmailContextKey struct{}
+
+var loadgenFirstNames = []string{
+	"james", "mary", "robert", "patricia", "john", "jennifer", "michael", "linda", "david", "elizabeth",
+	"william", "barbara", "richard", "susan", "joseph", "jessica", "thomas", "sarah", "charles", "karen",
+	"wei", "mei", "hiroshi", "yuki", "arjun", "priya", "mohammed", "fatima", "olga", "ivan",
+	"lucas", "sofia", "mateo", "valentina", "noah", "emma", "liam", "olivia", "kofi", "amara",
+}
+
+var loadgenLastNames = []string{
+	"smith", "johnson", "williams", "brown", "jones", "garcia", "miller", "davis", "rodriguez", "martinez",
+	"hernandez", "lopez", "gonzalez", "wilson", "anderson", "thomas", "taylor", "moore", "jackson", "martin",
+	"wang", "li", "zhang", "sato", "suzuki", "kumar", "sharma", "khan", "ivanov", "petrova",
+	"silva", "santos", "muller", "schmidt", "rossi", "dubois", "novak", "mensah", "okafor", "nguyen",
+}
+
+// loadgenUser contains the fields the email template of the generated users can use
+type loadgenUser struct {
+	FirstName string
+	LastName  string
+	Tag       string
+	Index     int
+	Domain    string
+}
+
+type loadgenResult struct {
+	Tag            string `json:"tag"`
+	Requested      int    `json:"requested"`
+	Created        int    `json:"created"`
+	AlreadyExisted int    `json:"alreadyExisted"`
+	Failed         int    `json:"failed"`
+	Duration       string `json:"duration"`
+	FirstError     string `json:"firstError,omitempty"`
+}
+
+func newLoadgenCommand() *cobra.Command {
+	var address string
+	var useTLS bool
+	var count int
+	var rate int
+	var concurrency int
+	var tag string
+	var domain string
+	var emailTemplate string
+	var signingKeyFile string
+	var signingAlgorithm string
+
+	cmd := &cobra.Command{
+		Use:   "loadgen",
+		Short: "Generate synthetic users through the gRPC API for capacity testing",
+		Long: `Generate synthetic users through the gRPC API at a limited rate for capacity testing.
+
+Every user is created with an access token issued for its own email address, signed with the
+given private JWK. The user service must be configured with a JWKS_URL that contains the matching
+public key, so only point the generator at test environments.
+
+The email address of every generated user contains the tag of the run, which is printed along
+with the result so the generated users can be cleaned up afterwards.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if count < 1 {
+				return fmt.Errorf("count must be at least 1")
+			}
+
+			if rate < 1 {
+				return fmt.Errorf("rate must be at least 1")
+			}
+
+			if concurrency < 1 {
+				return fmt.Errorf("concurrency must be at least 1")
+			}
+
+			parsedTemplate, err := template.New("email").Parse(emailTemplate)
+			if err != nil {
+				return fmt.Errorf("invalid email template: %w", err)
+			}
+
+			signingKey, err := readLoadgenSigningKey(signingKeyFile)
+			if err != nil {
+				return err
+			}
+
+			if tag == "" {
+				tag = "loadgen-" + time.Now().UTC().Format("20060102150405")
+			}
+
+			transportCredentials := grpc.WithInsecure()
+			if useTLS {
+				transportCredentials = grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, ""))
+			}
+
+			userClient, err := client.NewClient(cmd.Context(), address, &client.Options{
+				TokenProvider: func(ctx context.Context) (string, error) {
+					email, _ := ctx.Value(loadgenEmailContextKey{}).(string)
+
+					return signLoadgenToken(signingKey, jwa.SignatureAlgorithm(signingAlgorithm), email)
+				},
+				DialOptions: []grpc.DialOption{transportCredentials},
+			})
+			if err != nil {
+				return err
+			}
+
+			defer userClient.Close()
+
+			result, err := generateUsers(cmd.Context(), userClient, parsedTemplate, loadgenUser{Tag: tag, Domain: domain}, count, rate, concurrency)
+			if err != nil {
+				return err
+			}
+
+			return printOutput(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", "localhost:80", "The address of the user service gRPC endpoint")
+	cmd.Flags().BoolVar(&useTLS, "tls", false, "Connect to the user service using TLS")
+	cmd.Flags().IntVar(&count, "count", 100, "The number of users to generate")
+	cmd.Flags().IntVar(&rate, "rate", 10, "The maximum number of users created per second")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 4, "The number of the calls made in parallel")
+	cmd.Flags().StringVar(&tag, "tag", "", "The tag added to the email addresses of the generated users, a random tag is used if not provided")
+	cmd.Flags().StringVar(&domain, "domain", "example.com", "The domain of the email addresses of the generated users")
+	cmd.Flags().StringVar(&emailTemplate, "email-template", defaultLoadgenEmailTemplate, "The Go template of the email addresses, using .FirstName, .LastName, .Tag, .Index and .Domain")
+	cmd.Flags().StringVar(&signingKeyFile, "signing-key", "", "The file containing the private JWK the access tokens of the generated users are signed with")
+	cmd.Flags().StringVar(&signingAlgorithm, "signing-algorithm", string(jwa.RS256), "The algorithm the access tokens are signed with, e.g. RS256 or ES256")
+	_ = cmd.MarkFlagRequired("signing-key")
+
+	return cmd
+}
+
+// generateUsers creates the given number of users at the given rate and returns how many of them were created
+// ctx: Mandatory The reference to the context
+// userClient: Mandatory. The client of the user service
+// emailTemplate: Mandatory. The template the email addresses are generated with
+// base: Mandatory. The tag and the domain of the generated users
+// count: Mandatory. The number of users to generate
+// rate: Mandatory. The maximum number of users created per second
+// concurrency: Mandatory. The number of the calls made in parallel
+// Returns either the result of the run or error if generating the email addresses fails
+func generateUsers(
+	ctx context.Context,
+	userClient *client.Client,
+	emailTemplate *template.Template,
+	base loadgenUser,
+	count int,
+	rate int,
+	concurrency int) (*loadgenResult, error) {
+	emails, err := generateLoadgenEmails(emailTemplate, base, count)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &loadgenResult{Tag: base.Tag, Requested: count}
+	startedAt := time.Now()
+
+	var lock sync.Mutex
+	var waitGroup sync.WaitGroup
+
+	queue := make(chan string)
+	for worker := 0; worker < concurrency; worker++ {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			for email := range queue {
+				alreadyExisted, err := createLoadgenUser(ctx, userClient, email)
+
+				lock.Lock()
+				switch {
+				case err != nil:
+					result.Failed++
+					if result.FirstError == "" {
+						result.FirstError = fmt.Sprintf("%s: %v", email, err)
+					}
+				case alreadyExisted:
+					result.AlreadyExisted++
+				default:
+					result.Created++
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(rate))
+	defer ticker.Stop()
+
+	for index, email := range emails {
+		if index > 0 {
+			select {
+			case <-ctx.Done():
+			case <-ticker.C:
+			}
+		}
+
+		if ctx.Err() != nil {
+			result.Failed += len(emails) - index
+
+			break
+		}
+
+		queue <- email
+	}
+
+	close(queue)
+	waitGroup.Wait()
+
+	result.Duration = time.Since(startedAt).Round(time.Millisecond).String()
+
+	return result, nil
+}
+
+// createLoadgenUser creates the user with the given email address using an access token issued for it
+// Returns whether the user already existed or error if creating the user fails
+func createLoadgenUser(ctx context.Context, userClient *client.Client, email string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.WithValue(ctx, loadgenEmailContextKey{}, email), loadgenCallTimeout)
+	defer cancel()
+
+	response, err := userClient.ServiceClient.CreateUser(ctx, &userGRPCContract.CreateUserRequest{User: &userGRPCContract.User{}})
+	if err != nil {
+		return false, err
+	}
+
+	switch response.Error {
+	case userGRPCContract.Error_NO_ERROR:
+		return false, nil
+	case userGRPCContract.Error_USER_ALREADY_EXISTS:
+		return true, nil
+	default:
+		return false, fmt.Errorf("%s: %s", response.Error, response.ErrorMessage)
+	}
+}
+
+// generateLoadgenEmails generates the email addresses of the users up front, so an invalid template fails the run
+// before any user is created
+func generateLoadgenEmails(emailTemplate *template.Template, base loadgenUser, count int) ([]string, error) {
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	emails := make([]string, 0, count)
+
+	for index := 1; index <= count; index++ {
+		user := base
+		user.Index = index
+		user.FirstName = loadgenFirstNames[random.Intn(len(loadgenFirstNames))]
+		user.LastName = loadgenLastNames[random.Intn(len(loadgenLastNames))]
+
+		var email bytes.Buffer
+		if err := emailTemplate.Execute(&email, user); err != nil {
+			return nil, fmt.Errorf("failed to generate the email address: %w", err)
+		}
+
+		emails = append(emails, strings.ToLower(email.String()))
+	}
+
+	return emails, nil
+}
+
+func readLoadgenSigningKey(file string) (jwk.Key, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := jwk.ParseKey(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the signing key: %w", err)
+	}
+
+	return key, nil
+}
+
+// signLoadgenToken issues an access token for the given email address
+func signLoadgenToken(key jwk.Key, algorithm jwa.SignatureAlgorithm, email string) (string, error) {
+	now := time.Now()
+	token := jwt.New()
+	_ = token.Set(jwt.SubjectKey, email)
+	_ = token.Set(jwt.IssuedAtKey, now)
+	_ = token.Set(jwt.ExpirationKey, now.Add(loadgenTokenLifetime))
+	_ = token.Set("email", email)
+
+	signed, err := jwt.Sign(token, algorithm, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign the access token: %w", err)
+	}
+
+	return string(signed), nil
+}
@@ -0,0 +1,158 @@
+package cmd_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Loadgen Tests", func() {
+	var (
+		service        *fakeUserService
+		address        string
+		stopService    func()
+		directory      string
+		signingKeyFile string
+	)
+
+	type loadgenResult struct {
+		Tag            string `json:"tag"`
+		Requested      int    `json:"requested"`
+		Created        int    `json:"created"`
+		AlreadyExisted int    `json:"alreadyExisted"`
+		Failed         int    `json:"failed"`
+		FirstError     string `json:"firstError"`
+	}
+
+	// runLoadgen runs the generator against the fake service and decodes its result
+	runLoadgen := func(args ...string) (loadgenResult, error) {
+		output, err := execute(append([]string{"loadgen", "--address", address, "--signing-key", signingKeyFile, "-o", "json"}, args...)...)
+
+		var result loadgenResult
+		if err == nil {
+			Ω(json.Unmarshal([]byte(output), &result)).Should(Succeed())
+		}
+
+		return result, err
+	}
+
+	BeforeEach(func() {
+		service, address, stopService = startFakeUserService()
+
+		var err error
+		directory, err = ioutil.TempDir("", "loadgen")
+		Ω(err).Should(BeNil())
+
+		var keySet jwk.Set
+		signingKeyFile, keySet = writeSigningKeyFile(directory)
+		service.verifyTokensWith(keySet)
+	})
+
+	AfterEach(func() {
+		stopService()
+		os.RemoveAll(directory)
+	})
+
+	Context("the users are generated", func() {
+		When("the users do not exist", func() {
+			It("should create every user with an access token issued for its own email address", func() {
+				result, err := runLoadgen("--count", "5", "--rate", "100", "--tag", "run-1", "--domain", "test.com")
+				Ω(err).Should(BeNil())
+				Ω(result).Should(Equal(loadgenResult{Tag: "run-1", Requested: 5, Created: 5}))
+
+				emails := service.getCreatedEmails()
+				Ω(emails).Should(HaveLen(5))
+
+				for _, email := range emails {
+					Ω(email).Should(MatchRegexp(`^[a-z]+\.[a-z]+\+run-1-[1-5]@test\.com$`))
+				}
+			})
+		})
+
+		When("some of the users already exist", func() {
+			It("should count them separately from the created users", func() {
+				service.addUsers("user-1@test.com", "user-2@test.com")
+
+				result, err := runLoadgen("--count", "3", "--rate", "100", "--email-template", "user-{{.Index}}@test.com")
+				Ω(err).Should(BeNil())
+				Ω(result.Created).Should(Equal(1))
+				Ω(result.AlreadyExisted).Should(Equal(2))
+				Ω(result.Failed).Should(Equal(0))
+				Ω(result.Tag).Should(HavePrefix("loadgen-"))
+				Ω(service.getCreatedEmails()).Should(Equal([]string{"user-3@test.com"}))
+			})
+		})
+
+		When("the email template uses upper case letters", func() {
+			It("should lower case the email addresses", func() {
+				_, err := runLoadgen("--count", "1", "--email-template", "User-{{.Index}}@Test.COM")
+				Ω(err).Should(BeNil())
+				Ω(service.getCreatedEmails()).Should(Equal([]string{"user-1@test.com"}))
+			})
+		})
+
+		When("the rate is limited", func() {
+			It("should not create the users faster than the rate", func() {
+				startedAt := time.Now()
+
+				result, err := runLoadgen("--count", "5", "--rate", "10", "--concurrency", "5")
+				Ω(err).Should(BeNil())
+				Ω(result.Created).Should(Equal(5))
+				Ω(time.Since(startedAt)).Should(BeNumerically(">=", 400*time.Millisecond))
+			})
+		})
+
+		When("the service rejects the access tokens", func() {
+			It("should count the failed users and report the first error", func() {
+				otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+				Ω(err).Should(BeNil())
+
+				publicKey, err := jwk.New(&otherKey.PublicKey)
+				Ω(err).Should(BeNil())
+
+				_ = publicKey.Set(jwk.KeyIDKey, "test")
+				_ = publicKey.Set(jwk.AlgorithmKey, jwa.RS256)
+
+				keySet := jwk.NewSet()
+				keySet.Add(publicKey)
+				service.verifyTokensWith(keySet)
+
+				result, err := runLoadgen("--count", "2", "--rate", "100", "--email-template", "user-{{.Index}}@test.com")
+				Ω(err).Should(BeNil())
+				Ω(result.Created).Should(Equal(0))
+				Ω(result.Failed).Should(Equal(2))
+				Ω(result.FirstError).Should(ContainSubstring("Unauthenticated"))
+				Ω(service.getCreatedEmails()).Should(BeEmpty())
+			})
+		})
+	})
+
+	Context("the arguments are invalid", func() {
+		It("should return error without creating any user", func() {
+			for _, args := range [][]string{
+				{"--count", "0"},
+				{"--rate", "0"},
+				{"--concurrency", "0"},
+				{"--email-template", "{{.Unknown"},
+				{"--email-template", "{{.Unknown}}"},
+				{"--signing-key", filepath.Join(directory, "missing.json")},
+			} {
+				_, err := runLoadgen(args...)
+				Ω(err).ShouldNot(BeNil(), strings.Join(args, " "))
+			}
+
+			Ω(service.getCreatedEmails()).Should(BeEmpty())
+		})
+	})
+})
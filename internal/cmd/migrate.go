@@ -0,0 +1,281 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/repository/mongodb"
+	"github.com/decentralized-cloud/user/services/repository/mongodb/migrations"
+	"github.com/decentralized-cloud/user/services/repository/regional"
+	"github.com/spf13/cobra"
+)
+
+const migrateTimeout = 10 * time.Minute
+
+type migrationRow struct {
+	Region      string     `json:"region"`
+	Version     int        `json:"version"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	AppliedAt   *time.Time `json:"appliedAt,omitempty"`
+}
+
+type migrateResult struct {
+	Migrations []migrationRow `json:"migrations"`
+}
+
+// TableHeaders returns the column headers of the migrations table
+func (result migrateResult) TableHeaders() []string {
+	return []string{"REGION", "VERSION", "DESCRIPTION", "STATUS", "APPLIED AT"}
+}
+
+// TableRows returns the migrations, one per row
+func (result migrateResult) TableRows() [][]string {
+	rows := make([][]string, 0, len(result.Migrations))
+	for _, migration := range result.Migrations {
+		appliedAt := ""
+		if migration.AppliedAt != nil {
+			appliedAt = migration.AppliedAt.Format(time.RFC3339)
+		}
+
+		rows = append(rows, []string{migration.Region, strconv.Itoa(migration.Version), migration.Description, migration.Status, appliedAt})
+	}
+
+	return rows
+}
+
+func newMigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply, revert and list the migrations of the MongoDB users collection",
+		Long: `Apply, revert and list the migrations of the MongoDB users collection of every region.
+
+The service applies the pending migrations when it starts, this command allows applying them ahead of a
+rollout and reverting them before rolling back to an older version. The database is read from the same
+environment variables or configuration file the service reads it from. The PostgreSQL schema is migrated
+when the service starts and can not be migrated by this command.`,
+	}
+
+	cmd.AddCommand(
+		newMigrateUpCommand(),
+		newMigrateDownCommand(),
+		newMigrateStatusCommand(),
+	)
+
+	return cmd
+}
+
+func newMigrateUpCommand() *cobra.Command {
+	var targetVersion int
+
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Apply the pending migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if targetVersion < 0 {
+				return fmt.Errorf("target version must not be negative")
+			}
+
+			return runMigrate(cmd, func(ctx context.Context, migrator migrations.MigratorContract) error {
+				_, err := migrator.Up(ctx, targetVersion)
+
+				return err
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&targetVersion, "to", 0, "The version to migrate up to, every pending migration is applied if 0")
+
+	return cmd
+}
+
+func newMigrateDownCommand() *cobra.Command {
+	var targetVersion int
+
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Revert the applied migrations, only the latest one unless the target version is given",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if targetVersion < 0 {
+				return fmt.Errorf("target version must not be negative")
+			}
+
+			targetVersionChanged := cmd.Flags().Changed("to")
+
+			return runMigrate(cmd, func(ctx context.Context, migrator migrations.MigratorContract) error {
+				version := targetVersion
+				if !targetVersionChanged {
+					statuses, err := migrator.Status(ctx)
+					if err != nil {
+						return err
+					}
+
+					version = getPreviousAppliedVersion(statuses)
+				}
+
+				_, err := migrator.Down(ctx, version)
+
+				return err
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&targetVersion, "to", 0, "The version to migrate down to, every applied migration is reverted if 0")
+
+	return cmd
+}
+
+func newMigrateStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "List the migrations and whether they are applied",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMigrate(cmd, nil)
+		},
+	}
+}
+
+// runMigrate runs the migration operation against the users collection of every region, then prints the status of
+// the migrations of every region
+// cmd: Mandatory. The command that is being executed
+// operation: Optional. The operation to run, only the status is printed if nil
+// Returns error if something goes wrong
+func runMigrate(cmd *cobra.Command, operation func(ctx context.Context, migrator migrations.MigratorContract) error) error {
+	configurationService, err := getConfigurationService(cmd)
+	if err != nil {
+		return err
+	}
+
+	databaseType, err := configurationService.GetDatabaseType()
+	if err != nil {
+		return err
+	}
+
+	if databaseType != "mongodb" {
+		return fmt.Errorf("the %s database is migrated when the service starts, only the mongodb database can be migrated by this command", databaseType)
+	}
+
+	regionConfigurationServices, err := getRegionConfigurationServices(configurationService)
+	if err != nil {
+		return err
+	}
+
+	regions := make([]string, 0, len(regionConfigurationServices))
+	for region := range regionConfigurationServices {
+		regions = append(regions, region)
+	}
+
+	sort.Strings(regions)
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), migrateTimeout)
+	defer cancel()
+
+	result := migrateResult{Migrations: []migrationRow{}}
+	for _, region := range regions {
+		rows, err := migrateRegion(ctx, regionConfigurationServices[region], operation)
+		if err != nil {
+			return fmt.Errorf("failed to migrate region %s: %w", region, err)
+		}
+
+		for _, row := range rows {
+			row.Region = region
+			result.Migrations = append(result.Migrations, row)
+		}
+	}
+
+	return printOutput(cmd, result)
+}
+
+// migrateRegion runs the migration operation against the users collection of a region
+// ctx: Mandatory The reference to the context
+// configurationService: Mandatory. Reference to the service that provides the configurations of the region
+// operation: Optional. The operation to run, only the status is read if nil
+// Returns either the status of the migrations of the region or error if something goes wrong
+func migrateRegion(
+	ctx context.Context,
+	configurationService configuration.ConfigurationContract,
+	operation func(ctx context.Context, migrator migrations.MigratorContract) error) ([]migrationRow, error) {
+	migrator, disconnect, err := mongodb.NewMigrator(ctx, configurationService)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect()
+
+	if operation != nil {
+		if err := operation(ctx, migrator); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]migrationRow, 0, len(statuses))
+	for _, status := range statuses {
+		row := migrationRow{
+			Version:     status.Version,
+			Description: status.Description,
+			Status:      "pending",
+			AppliedAt:   status.AppliedAt,
+		}
+
+		switch {
+		case status.Unknown:
+			row.Status = "applied by a newer version"
+		case status.Applied:
+			row.Status = "applied"
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// getRegionConfigurationServices returns the configuration service of the database of every region, the default
+// region uses the database set by the database connection string
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns either the configuration services by their regions or error if something goes wrong
+func getRegionConfigurationServices(configurationService configuration.ConfigurationContract) (map[string]configuration.ConfigurationContract, error) {
+	defaultRegion, err := configurationService.GetDataResidencyDefaultRegion()
+	if err != nil {
+		return nil, err
+	}
+
+	routes, err := configurationService.GetDataResidencyRoutes()
+	if err != nil {
+		return nil, err
+	}
+
+	regionConfigurationServices := map[string]configuration.ConfigurationContract{defaultRegion: configurationService}
+	for region, connectionString := range routes {
+		regionConfigurationServices[region] = regional.NewRegionConfigurationService(configurationService, connectionString)
+	}
+
+	return regionConfigurationServices, nil
+}
+
+// getPreviousAppliedVersion returns the version the latest applied migration is reverted to, the greatest applied
+// version below it or 0 if it is the only applied migration
+func getPreviousAppliedVersion(statuses []migrations.MigrationStatus) int {
+	applied := []int{}
+	for _, status := range statuses {
+		if status.Applied {
+			applied = append(applied, status.Version)
+		}
+	}
+
+	if len(applied) < 2 {
+		return 0
+	}
+
+	return applied[len(applied)-2]
+}
@@ -0,0 +1,186 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	outputFormatJSON  = "json"
+	outputFormatYAML  = "yaml"
+	outputFormatTable = "table"
+)
+
+var supportedOutputFormats = []string{outputFormatJSON, outputFormatYAML, outputFormatTable}
+
+// tabular is implemented by the command results that know how to render themselves as a table
+type tabular interface {
+	// TableHeaders returns the column headers of the table
+	TableHeaders() []string
+
+	// TableRows returns the rows of the table, every row must have the same number of columns as the headers
+	TableRows() [][]string
+}
+
+// addOutputFlag registers the global --output flag on the given command
+// cmd: Mandatory. The root command
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringP(
+		"output",
+		"o",
+		outputFormatTable,
+		fmt.Sprintf("Output format. One of: %s", strings.Join(supportedOutputFormats, "|")))
+}
+
+// getOutputFormat returns the output format requested by the user
+// cmd: Mandatory. The command that is being executed
+// Returns either the output format or error if the format is not supported
+func getOutputFormat(cmd *cobra.Command) (string, error) {
+	outputFormat, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return "", err
+	}
+
+	outputFormat = strings.ToLower(strings.Trim(outputFormat, " "))
+	for _, supportedOutputFormat := range supportedOutputFormats {
+		if outputFormat == supportedOutputFormat {
+			return outputFormat, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported output format %q, must be one of: %s", outputFormat, strings.Join(supportedOutputFormats, "|"))
+}
+
+// printOutput writes the command result in the format requested by the user
+// cmd: Mandatory. The command that is being executed
+// result: Mandatory. The command result. Its JSON tags define the schema of the JSON and YAML outputs
+// Returns error if something goes wrong
+func printOutput(cmd *cobra.Command, result interface{}) error {
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(cmd.OutOrStdout(), outputFormat, result)
+}
+
+func writeOutput(writer io.Writer, outputFormat string, result interface{}) error {
+	switch outputFormat {
+	case outputFormatJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(result)
+
+	case outputFormatYAML:
+		// Going through JSON keeps the YAML schema identical to the JSON one
+		generic, err := toGeneric(result)
+		if err != nil {
+			return err
+		}
+
+		data, err := yaml.Marshal(generic)
+		if err != nil {
+			return err
+		}
+
+		_, err = writer.Write(data)
+
+		return err
+
+	default:
+		return writeTable(writer, result)
+	}
+}
+
+func writeTable(writer io.Writer, result interface{}) error {
+	var headers []string
+	var rows [][]string
+
+	if table, ok := result.(tabular); ok {
+		headers = table.TableHeaders()
+		rows = table.TableRows()
+	} else {
+		generic, err := toGeneric(result)
+		if err != nil {
+			return err
+		}
+
+		headers = []string{"KEY", "VALUE"}
+		rows = flatten("", generic)
+	}
+
+	tableWriter := tabwriter.NewWriter(writer, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tableWriter, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		fmt.Fprintln(tableWriter, strings.Join(row, "\t"))
+	}
+
+	return tableWriter.Flush()
+}
+
+// toGeneric converts the result to maps, slices and scalars following its JSON schema
+func toGeneric(result interface{}) (interface{}, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err = json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+
+	return generic, nil
+}
+
+// flatten converts the generic value to key/value rows sorted by key, nested keys are joined by "."
+func flatten(prefix string, value interface{}) [][]string {
+	switch castedValue := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(castedValue))
+		for key := range castedValue {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		rows := [][]string{}
+		for _, key := range keys {
+			rows = append(rows, flatten(joinKey(prefix, key), castedValue[key])...)
+		}
+
+		return rows
+
+	case []interface{}:
+		rows := [][]string{}
+		for index, item := range castedValue {
+			rows = append(rows, flatten(joinKey(prefix, fmt.Sprintf("%d", index)), item)...)
+		}
+
+		return rows
+
+	case nil:
+		return [][]string{{prefix, ""}}
+
+	default:
+		return [][]string{{prefix, fmt.Sprintf("%v", castedValue)}}
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
@@ -0,0 +1,113 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/decentralized-cloud/user/internal/cmd"
+	"gopkg.in/yaml.v2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestCmd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CLI Command Tests")
+}
+
+// execute runs the CLI with the given arguments
+// args: Mandatory. The arguments the CLI is run with
+// Returns the standard output of the command and the error the command failed with
+func execute(args ...string) (string, error) {
+	var output bytes.Buffer
+
+	command := cmd.NewRootCommand()
+	command.SetOut(&output)
+	command.SetErr(&output)
+	command.SetArgs(args)
+	err := command.Execute()
+
+	return output.String(), err
+}
+
+var _ = Describe("Output Tests", func() {
+	Context("the version is printed", func() {
+		When("the JSON output is requested", func() {
+			It("should print the version as JSON", func() {
+				output, err := execute("version", "--output", "json")
+				Ω(err).Should(BeNil())
+
+				var version interface{}
+				Ω(json.Unmarshal([]byte(output), &version)).Should(Succeed())
+			})
+		})
+
+		When("the YAML output is requested", func() {
+			It("should print the version as YAML with the same schema as the JSON output", func() {
+				jsonOutput, err := execute("version", "-o", "json")
+				Ω(err).Should(BeNil())
+
+				yamlOutput, err := execute("version", "-o", "yaml")
+				Ω(err).Should(BeNil())
+
+				var fromJSON, fromYAML interface{}
+				Ω(json.Unmarshal([]byte(jsonOutput), &fromJSON)).Should(Succeed())
+				Ω(yaml.Unmarshal([]byte(yamlOutput), &fromYAML)).Should(Succeed())
+
+				// The YAML decoder keys the maps by interface{}, going through JSON makes both comparable
+				normalized, err := json.Marshal(toStringKeys(fromYAML))
+				Ω(err).Should(BeNil())
+				Ω(normalized).Should(MatchJSON(jsonOutput))
+			})
+		})
+
+		When("the output format is not set", func() {
+			It("should print the version as a table", func() {
+				output, err := execute("version")
+				Ω(err).Should(BeNil())
+				Ω(strings.Fields(strings.SplitN(output, "\n", 2)[0])).Should(Equal([]string{"KEY", "VALUE"}))
+			})
+		})
+
+		When("the output format is given in upper case", func() {
+			It("should accept the format", func() {
+				output, err := execute("version", "-o", "JSON")
+				Ω(err).Should(BeNil())
+				Ω(json.Valid([]byte(output))).Should(BeTrue())
+			})
+		})
+
+		When("an unsupported output format is requested", func() {
+			It("should return error listing the supported formats", func() {
+				_, err := execute("version", "-o", "xml")
+				Ω(err).Should(MatchError(ContainSubstring("json|yaml|table")))
+			})
+		})
+	})
+})
+
+// toStringKeys converts the maps the YAML decoder returns to maps keyed by strings
+func toStringKeys(value interface{}) interface{} {
+	switch castedValue := value.(type) {
+	case map[interface{}]interface{}:
+		converted := map[string]interface{}{}
+		for key, item := range castedValue {
+			converted[key.(string)] = toStringKeys(item)
+		}
+
+		return converted
+
+	case []interface{}:
+		for index, item := range castedValue {
+			castedValue[index] = toStringKeys(item)
+		}
+
+		return castedValue
+
+	default:
+		return castedValue
+	}
+}
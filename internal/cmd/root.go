@@ -14,11 +14,34 @@ func NewRootCommand() *cobra.Command {
 		PreRun: func(cmd *cobra.Command, args []string) {
 			printHeader()
 		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			_, err := getOutputFormat(cmd)
+
+			return err
+		},
 	}
 
+	addOutputFlag(cmd)
+	addConfigFlag(cmd)
+
 	// Register all commands
 	cmd.AddCommand(
 		newStartCommand(),
+		newShellCommand(),
+		newUserCommand(),
+		newImportCommand(),
+		newExportCommand(),
+		newMigrateCommand(),
+		newIndexAdvisorCommand(),
+		newConfigCommand(),
+		newCheckCommand(),
+		newSloCommand(),
+		newLoadgenCommand(),
+		newSoakCommand(),
+		newBenchCommand(),
+		newSupportBundleCommand(),
+		newCompletionCommand(),
+		newDocsCommand(),
 		newVersionCommand(),
 	)
 
@@ -0,0 +1,425 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/pkg/client"
+	"github.com/peterh/liner"
+	"github.com/spf13/cobra"
+)
+
+const (
+	shellHistoryFileName = ".user_shell_history"
+	shellCallTimeout     = 30 * time.Second
+)
+
+var shellCommands = []string{"help", "read", "search", "next", "prev", "sort", "saga", "output", "exit", "quit"}
+
+type shell struct {
+	client       *client.Client
+	writer       io.Writer
+	outputFormat string
+	pageSize     int32
+	lastSearch   *userGRPCContract.SearchRequest
+	lastPage     *userGRPCContract.SearchResponse
+	sorting      []*userGRPCContract.SortingOptionPair
+}
+
+type userResult struct {
+	Email         string `json:"email"`
+	Cursor        string `json:"cursor,omitempty"`
+	DataResidency string `json:"dataResidency,omitempty"`
+}
+
+type searchResult struct {
+	TotalCount      int64        `json:"totalCount"`
+	HasPreviousPage bool         `json:"hasPreviousPage"`
+	HasNextPage     bool         `json:"hasNextPage"`
+	Users           []userResult `json:"users"`
+}
+
+type sagaStepResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Attempts int32  `json:"attempts"`
+	Error    string `json:"error,omitempty"`
+}
+
+type sagaResult struct {
+	SagaID    string           `json:"sagaID"`
+	Name      string           `json:"name"`
+	Status    string           `json:"status"`
+	Error     string           `json:"error,omitempty"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+	Steps     []sagaStepResult `json:"steps"`
+}
+
+// TableHeaders returns the column headers of the search result table
+func (result searchResult) TableHeaders() []string {
+	return []string{"EMAIL", "CURSOR"}
+}
+
+// TableRows returns the found users, one per row
+func (result searchResult) TableRows() [][]string {
+	rows := make([][]string, 0, len(result.Users))
+	for _, user := range result.Users {
+		rows = append(rows, []string{user.Email, user.Cursor})
+	}
+
+	return rows
+}
+
+// TableHeaders returns the column headers of the saga table
+func (result sagaResult) TableHeaders() []string {
+	return []string{"STEP", "STATUS", "ATTEMPTS", "ERROR"}
+}
+
+// TableRows returns the saga steps, one per row
+func (result sagaResult) TableRows() [][]string {
+	rows := make([][]string, 0, len(result.Steps))
+	for _, step := range result.Steps {
+		rows = append(rows, []string{step.Name, step.Status, strconv.Itoa(int(step.Attempts)), step.Error})
+	}
+
+	return rows
+}
+
+func newShellCommand() *cobra.Command {
+	var address string
+	var token string
+	var useTLS bool
+	var pageSize int32
+
+	cmd := &cobra.Command{
+		Use:   "shell",
+		Short: "Start an interactive shell to browse and search the users",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat, err := getOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			userClient, err := newUserServiceClient(cmd.Context(), address, getAccessToken(token), useTLS)
+			if err != nil {
+				return err
+			}
+
+			defer userClient.Close()
+
+			return (&shell{
+				client:       userClient,
+				writer:       cmd.OutOrStdout(),
+				outputFormat: outputFormat,
+				pageSize:     pageSize,
+			}).run()
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", "localhost:80", "The address of the user service gRPC endpoint")
+	cmd.Flags().StringVar(&token, "token", "", "The access token of an admin user, defaults to USER_ACCESS_TOKEN environment variable")
+	cmd.Flags().BoolVar(&useTLS, "tls", false, "Connect to the user service using TLS")
+	cmd.Flags().Int32Var(&pageSize, "page-size", 20, "The number of users returned per search page")
+
+	return cmd
+}
+
+func (shell *shell) run() error {
+	line := liner.NewLiner()
+	defer line.Close()
+
+	line.SetCtrlCAborts(true)
+	line.SetCompleter(shell.complete)
+
+	historyPath := ""
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		historyPath = filepath.Join(homeDir, shellHistoryFileName)
+		if historyFile, err := os.Open(historyPath); err == nil {
+			_, _ = line.ReadHistory(historyFile)
+			historyFile.Close()
+		}
+	}
+
+	defer func() {
+		if historyPath == "" {
+			return
+		}
+
+		if historyFile, err := os.Create(historyPath); err == nil {
+			_, _ = line.WriteHistory(historyFile)
+			historyFile.Close()
+		}
+	}()
+
+	fmt.Fprintln(shell.writer, `User shell, type "help" for the list of the commands`)
+
+	for {
+		input, err := line.Prompt("user> ")
+		if errors.Is(err, liner.ErrPromptAborted) || errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		fields := strings.Fields(input)
+		if len(fields) == 0 {
+			continue
+		}
+
+		line.AppendHistory(input)
+
+		if fields[0] == "exit" || fields[0] == "quit" {
+			return nil
+		}
+
+		if err = shell.execute(fields[0], fields[1:]); err != nil {
+			fmt.Fprintf(shell.writer, "error: %v\n", err)
+		}
+	}
+}
+
+// complete returns the candidate lines for the tab completion, the commands and the output formats are completed
+func (shell *shell) complete(input string) []string {
+	if strings.HasPrefix(input, "output ") {
+		return completeFrom("output ", strings.TrimPrefix(input, "output "), supportedOutputFormats)
+	}
+
+	if !strings.Contains(input, " ") {
+		return completeFrom("", input, shellCommands)
+	}
+
+	return nil
+}
+
+func completeFrom(prefix, partial string, candidates []string) []string {
+	completions := []string{}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, partial) {
+			completions = append(completions, prefix+candidate)
+		}
+	}
+
+	return completions
+}
+
+func (shell *shell) execute(command string, args []string) error {
+	switch command {
+	case "help":
+		shell.printHelp()
+
+		return nil
+
+	case "read":
+		if len(args) != 1 {
+			return errors.New("usage: read <email>")
+		}
+
+		return shell.readUser(args[0])
+
+	case "search":
+		shell.lastSearch = &userGRPCContract.SearchRequest{
+			First:          shell.pageSize,
+			Emails:         args,
+			SortingOptions: shell.sorting,
+		}
+
+		return shell.search(shell.lastSearch)
+
+	case "next":
+		if shell.lastPage == nil || !shell.lastPage.HasNextPage || len(shell.lastPage.Users) == 0 {
+			return errors.New("there is no next page")
+		}
+
+		return shell.search(&userGRPCContract.SearchRequest{
+			After:          shell.lastPage.Users[len(shell.lastPage.Users)-1].Cursor,
+			First:          shell.pageSize,
+			Emails:         shell.lastSearch.Emails,
+			SortingOptions: shell.lastSearch.SortingOptions,
+		})
+
+	case "prev":
+		if shell.lastPage == nil || !shell.lastPage.HasPreviousPage || len(shell.lastPage.Users) == 0 {
+			return errors.New("there is no previous page")
+		}
+
+		return shell.search(&userGRPCContract.SearchRequest{
+			Before:         shell.lastPage.Users[0].Cursor,
+			Last:           shell.pageSize,
+			Emails:         shell.lastSearch.Emails,
+			SortingOptions: shell.lastSearch.SortingOptions,
+		})
+
+	case "sort":
+		return shell.setSorting(args)
+
+	case "saga":
+		if len(args) != 1 {
+			return errors.New("usage: saga <sagaID>")
+		}
+
+		return shell.getSagaStatus(args[0])
+
+	case "output":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: output %s", strings.Join(supportedOutputFormats, "|"))
+		}
+
+		for _, supportedOutputFormat := range supportedOutputFormats {
+			if args[0] == supportedOutputFormat {
+				shell.outputFormat = supportedOutputFormat
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("unsupported output format %q", args[0])
+
+	default:
+		return fmt.Errorf("unknown command %q, type \"help\" for the list of the commands", command)
+	}
+}
+
+func (shell *shell) printHelp() {
+	fmt.Fprintln(shell.writer, `Commands:
+  read <email>                   Read the user with the given email address
+  search [email ...]             Search for the users, optionally filtered by the email addresses
+  next                           Show the next page of the last search
+  prev                           Show the previous page of the last search
+  sort [field [asc|desc]]        Sort the next searches by the given field, clears the sorting if no field is provided
+  saga <sagaID>                  Show the progress of a saga
+  output json|yaml|table         Change the output format
+  help                           Show this help
+  exit, quit                     Exit the shell`)
+}
+
+func (shell *shell) readUser(email string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shellCallTimeout)
+	defer cancel()
+
+	response, err := shell.client.ServiceClient.ReadUser(ctx, &userGRPCContract.ReadUserRequest{Email: email})
+	if err != nil {
+		return err
+	}
+
+	if response.Error != userGRPCContract.Error_NO_ERROR {
+		return fmt.Errorf("%s: %s", response.Error, response.ErrorMessage)
+	}
+
+	return writeOutput(shell.writer, shell.outputFormat, userResult{Email: email})
+}
+
+func (shell *shell) search(request *userGRPCContract.SearchRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shellCallTimeout)
+	defer cancel()
+
+	response, err := shell.client.ServiceClient.Search(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	if response.Error != userGRPCContract.Error_NO_ERROR {
+		return fmt.Errorf("%s: %s", response.Error, response.ErrorMessage)
+	}
+
+	shell.lastPage = response
+
+	result := searchResult{
+		TotalCount:      response.TotalCount,
+		HasPreviousPage: response.HasPreviousPage,
+		HasNextPage:     response.HasNextPage,
+		Users:           make([]userResult, 0, len(response.Users)),
+	}
+
+	for _, user := range response.Users {
+		result.Users = append(result.Users, userResult{Email: user.Email, Cursor: user.Cursor})
+	}
+
+	if err = writeOutput(shell.writer, shell.outputFormat, result); err != nil {
+		return err
+	}
+
+	if shell.outputFormat == outputFormatTable {
+		fmt.Fprintf(shell.writer, "%d user(s) in total, has previous page: %t, has next page: %t\n", result.TotalCount, result.HasPreviousPage, result.HasNextPage)
+	}
+
+	return nil
+}
+
+func (shell *shell) setSorting(args []string) error {
+	switch len(args) {
+	case 0:
+		shell.sorting = nil
+
+		return nil
+
+	case 1, 2:
+		direction := userGRPCContract.SortingDirection_ASCENDING
+		if len(args) == 2 {
+			switch strings.ToLower(args[1]) {
+			case "asc":
+			case "desc":
+				direction = userGRPCContract.SortingDirection_DESCENDING
+			default:
+				return errors.New("usage: sort [field [asc|desc]]")
+			}
+		}
+
+		shell.sorting = []*userGRPCContract.SortingOptionPair{{Name: args[0], Direction: direction}}
+
+		return nil
+
+	default:
+		return errors.New("usage: sort [field [asc|desc]]")
+	}
+}
+
+func (shell *shell) getSagaStatus(sagaID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shellCallTimeout)
+	defer cancel()
+
+	response, err := shell.client.GetSagaStatus(ctx, &userGRPCContract.GetSagaStatusRequest{SagaID: sagaID})
+	if err != nil {
+		return err
+	}
+
+	if response.Error != userGRPCContract.Error_NO_ERROR {
+		return fmt.Errorf("%s: %s", response.Error, response.ErrorMessage)
+	}
+
+	result := sagaResult{
+		SagaID:    response.Saga.SagaID,
+		Name:      response.Saga.Name,
+		Status:    response.Saga.Status.String(),
+		Error:     response.Saga.Error,
+		CreatedAt: response.Saga.CreatedAt.AsTime(),
+		UpdatedAt: response.Saga.UpdatedAt.AsTime(),
+		Steps:     make([]sagaStepResult, 0, len(response.Saga.Steps)),
+	}
+
+	for _, step := range response.Saga.Steps {
+		result.Steps = append(result.Steps, sagaStepResult{
+			Name:     step.Name,
+			Status:   step.Status.String(),
+			Attempts: step.Attempts,
+			Error:    step.Error,
+		})
+	}
+
+	if shell.outputFormat == outputFormatTable {
+		fmt.Fprintf(shell.writer, "Saga %s (%s): %s\n", result.SagaID, result.Name, result.Status)
+	}
+
+	return writeOutput(shell.writer, shell.outputFormat, result)
+}
@@ -0,0 +1,262 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/peterh/liner"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Shell Tests", func() {
+	var (
+		service      *fakeUserService
+		address      string
+		stopService  func()
+		originalHome string
+		homeDir      string
+		stdin        *os.File
+	)
+
+	// runShell runs the shell typing the given lines into it
+	runShell := func(lines ...string) (string, error) {
+		reader, writer, err := os.Pipe()
+		Ω(err).Should(BeNil())
+
+		_, err = writer.WriteString(strings.Join(lines, "\n") + "\n")
+		Ω(err).Should(BeNil())
+		Ω(writer.Close()).Should(Succeed())
+
+		os.Stdin = reader
+		defer reader.Close()
+
+		return execute("shell", "--address", address, "--token", "shell-token", "--page-size", "2")
+	}
+
+	BeforeEach(func() {
+		// The shell switches the terminal to raw mode if the tests run in one, so the lines could not be typed into it
+		if _, err := liner.TerminalMode(); err == nil {
+			Skip("the standard input is a terminal")
+		}
+
+		service, address, stopService = startFakeUserService()
+		service.addUsers("a@test.com", "b@test.com", "c@test.com")
+
+		var err error
+		homeDir, err = ioutil.TempDir("", "shell")
+		Ω(err).Should(BeNil())
+
+		originalHome = os.Getenv("HOME")
+		os.Setenv("HOME", homeDir)
+		stdin = os.Stdin
+	})
+
+	AfterEach(func() {
+		if service == nil {
+			return
+		}
+
+		stopService()
+		os.Stdin = stdin
+		os.Setenv("HOME", originalHome)
+		os.RemoveAll(homeDir)
+		service = nil
+	})
+
+	Context("the shell is started", func() {
+		When("the input ends", func() {
+			It("should print the welcome message and exit", func() {
+				output, err := runShell()
+				Ω(err).Should(BeNil())
+				Ω(output).Should(ContainSubstring(`User shell, type "help" for the list of the commands`))
+			})
+		})
+
+		When("exit is typed", func() {
+			It("should not execute the rest of the lines", func() {
+				output, err := runShell("exit", "read a@test.com")
+				Ω(err).Should(BeNil())
+				Ω(output).ShouldNot(ContainSubstring("a@test.com"))
+			})
+		})
+
+		When("help is typed", func() {
+			It("should print the list of the commands", func() {
+				output, err := runShell("help")
+				Ω(err).Should(BeNil())
+
+				for _, command := range []string{"read <email>", "search [email ...]", "next", "prev", "sort [field [asc|desc]]", "saga <sagaID>", "output json|yaml|table"} {
+					Ω(output).Should(ContainSubstring(command))
+				}
+			})
+		})
+
+		When("the lines are typed", func() {
+			It("should keep them in the history file", func() {
+				_, err := runShell("help", "output json")
+				Ω(err).Should(BeNil())
+
+				history, err := ioutil.ReadFile(homeDir + "/.user_shell_history")
+				Ω(err).Should(BeNil())
+				Ω(string(history)).Should(Equal("help\noutput json\n"))
+			})
+		})
+	})
+
+	Context("the commands fail", func() {
+		When("an unknown command is typed", func() {
+			It("should print the error and continue", func() {
+				output, err := runShell("unknown", "read a@test.com")
+				Ω(err).Should(BeNil())
+				Ω(output).Should(ContainSubstring(`error: unknown command "unknown"`))
+				Ω(output).Should(ContainSubstring("a@test.com"))
+			})
+		})
+
+		When("the user does not exist", func() {
+			It("should print the error returned by the service", func() {
+				output, err := runShell("read missing@test.com")
+				Ω(err).Should(BeNil())
+				Ω(output).Should(ContainSubstring("error: USER_NOT_FOUND: user not found"))
+			})
+		})
+
+		When("the saga does not exist", func() {
+			It("should print the error returned by the service", func() {
+				output, err := runShell("saga missing")
+				Ω(err).Should(BeNil())
+				Ω(output).Should(ContainSubstring("error: SAGA_NOT_FOUND: saga not found"))
+			})
+		})
+
+		When("next is typed before searching", func() {
+			It("should print there is no next page", func() {
+				output, err := runShell("next", "prev")
+				Ω(err).Should(BeNil())
+				Ω(output).Should(ContainSubstring("error: there is no next page"))
+				Ω(output).Should(ContainSubstring("error: there is no previous page"))
+			})
+		})
+
+		When("an unsupported sorting direction is typed", func() {
+			It("should print the usage", func() {
+				output, err := runShell("sort email up")
+				Ω(err).Should(BeNil())
+				Ω(output).Should(ContainSubstring("error: usage: sort [field [asc|desc]]"))
+			})
+		})
+
+		When("an unsupported output format is typed", func() {
+			It("should print the error", func() {
+				output, err := runShell("output xml")
+				Ω(err).Should(BeNil())
+				Ω(output).Should(ContainSubstring(`error: unsupported output format "xml"`))
+			})
+		})
+	})
+
+	Context("the users are searched", func() {
+		When("the pages are browsed", func() {
+			It("should request the pages after and before the cursors of the shown page", func() {
+				output, err := runShell("search", "next", "prev", "next", "next")
+				Ω(err).Should(BeNil())
+				Ω(output).Should(ContainSubstring("3 user(s) in total, has previous page: false, has next page: true"))
+				Ω(output).Should(ContainSubstring("3 user(s) in total, has previous page: true, has next page: false"))
+				Ω(output).Should(ContainSubstring("error: there is no next page"))
+
+				requests := service.getSearchRequests()
+				Ω(requests).Should(HaveLen(4))
+				Ω(requests[0].First).Should(Equal(int32(2)))
+				Ω(requests[1].After).Should(Equal("b@test.com"))
+				Ω(requests[2].Before).Should(Equal("c@test.com"))
+				Ω(requests[2].Last).Should(Equal(int32(2)))
+				Ω(requests[3].After).Should(Equal("b@test.com"))
+			})
+		})
+
+		When("the email addresses are typed", func() {
+			It("should filter the users and keep the filter while browsing the pages", func() {
+				_, err := runShell("search a@test.com b@test.com c@test.com", "next")
+				Ω(err).Should(BeNil())
+
+				requests := service.getSearchRequests()
+				Ω(requests).Should(HaveLen(2))
+
+				for _, request := range requests {
+					Ω(request.Emails).Should(Equal([]string{"a@test.com", "b@test.com", "c@test.com"}))
+				}
+			})
+		})
+
+		When("the sorting is set", func() {
+			It("should sort the next searches until the sorting is cleared", func() {
+				output, err := runShell("sort email desc", "output json", "search", "next", "sort", "search")
+				Ω(err).Should(BeNil())
+
+				requests := service.getSearchRequests()
+				Ω(requests).Should(HaveLen(3))
+
+				for _, request := range requests[:2] {
+					Ω(request.SortingOptions).Should(HaveLen(1))
+					Ω(request.SortingOptions[0].Name).Should(Equal("email"))
+					Ω(request.SortingOptions[0].Direction).Should(Equal(userGRPCContract.SortingDirection_DESCENDING))
+				}
+
+				Ω(requests[1].After).Should(Equal("b@test.com"))
+				Ω(requests[2].SortingOptions).Should(BeEmpty())
+
+				decoder := json.NewDecoder(strings.NewReader(output[strings.Index(output, "{"):]))
+
+				var firstPage struct {
+					Users []struct {
+						Email string `json:"email"`
+					} `json:"users"`
+				}
+
+				Ω(decoder.Decode(&firstPage)).Should(Succeed())
+				Ω(firstPage.Users).Should(HaveLen(2))
+				Ω(firstPage.Users[0].Email).Should(Equal("c@test.com"))
+				Ω(firstPage.Users[1].Email).Should(Equal("b@test.com"))
+			})
+		})
+	})
+
+	Context("the progress of a saga is requested", func() {
+		It("should print the saga and its steps", func() {
+			service.addSaga(&userGRPCContract.Saga{
+				SagaID: "saga-1",
+				Name:   "DeleteUser",
+				Status: userGRPCContract.SagaStatus_COMPENSATING,
+				Steps: []*userGRPCContract.SagaStep{
+					{Name: "DeleteCredentials", Status: userGRPCContract.SagaStepStatus_STEP_COMPLETED, Attempts: 1},
+					{Name: "DeleteUser", Status: userGRPCContract.SagaStepStatus_STEP_FAILED, Attempts: 3, Error: "timeout"},
+				},
+			})
+
+			output, err := runShell("saga saga-1")
+			Ω(err).Should(BeNil())
+			Ω(output).Should(ContainSubstring("Saga saga-1 (DeleteUser): COMPENSATING"))
+			Ω(output).Should(MatchRegexp(`DeleteCredentials\s+STEP_COMPLETED\s+1`))
+			Ω(output).Should(MatchRegexp(`DeleteUser\s+STEP_FAILED\s+3\s+timeout`))
+		})
+	})
+
+	Context("the service is called", func() {
+		It("should send the access token with every call", func() {
+			_, err := runShell("read a@test.com", "search", "saga missing")
+			Ω(err).Should(BeNil())
+
+			authorizations := service.getAuthorizations()
+			Ω(authorizations).Should(HaveLen(3))
+
+			for _, authorization := range authorizations {
+				Ω(authorization).Should(Equal("Bearer shell-token"))
+			}
+		})
+	})
+})
@@ -0,0 +1,109 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/decentralized-cloud/user/services/slo"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// prometheusRule is the Prometheus Operator resource the rules are wrapped in when generated for Kubernetes
+type prometheusRule struct {
+	APIVersion string       `yaml:"apiVersion"`
+	Kind       string       `yaml:"kind"`
+	Metadata   ruleMetadata `yaml:"metadata"`
+	Spec       slo.RuleFile `yaml:"spec"`
+}
+
+type ruleMetadata struct {
+	Name string `yaml:"name"`
+}
+
+func newSloCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "slo",
+		Short: "Manage the User service level objectives",
+	}
+
+	cmd.AddCommand(newSloRulesCommand())
+
+	return cmd
+}
+
+func newSloRulesCommand() *cobra.Command {
+	var file string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "rules",
+		Short: "Generate the Prometheus recording and burn rate alerting rules for the configured service level objectives",
+		Long: `Generate the Prometheus recording and burn rate alerting rules for the service level objectives.
+
+The objectives are read from the same environment variables or configuration file the service reads them from
+(SLO_AVAILABILITY_OBJECTIVE, SLO_LATENCY_OBJECTIVE and SLO_WINDOW), so the generated rules
+match the metrics the service exports when it runs with the same configuration.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "prometheus" && format != "kubernetes" {
+				return fmt.Errorf("unsupported rules format %q, must be one of: prometheus|kubernetes", format)
+			}
+
+			configurationService, err := getConfigurationService(cmd)
+			if err != nil {
+				return err
+			}
+
+			objectives, err := slo.ReadObjectives(configurationService)
+			if err != nil {
+				return err
+			}
+
+			if file == "" {
+				return writeSloRules(cmd.OutOrStdout(), format, *objectives)
+			}
+
+			output, err := os.Create(file)
+			if err != nil {
+				return err
+			}
+
+			defer output.Close()
+
+			return writeSloRules(output, format, *objectives)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Write the rules to the given file instead of the standard output")
+	cmd.Flags().StringVar(&format, "format", "prometheus", "Rules format. One of: prometheus|kubernetes")
+
+	return cmd
+}
+
+// writeSloRules writes the generated rules either as a Prometheus rule file or as a Prometheus Operator PrometheusRule resource
+// writer: Mandatory. The writer to write the rules to
+// format: Mandatory. The format of the rules, either prometheus or kubernetes
+// objectives: Mandatory. The service level objectives to generate the rules for
+// Returns error if something goes wrong
+func writeSloRules(writer io.Writer, format string, objectives slo.Objectives) error {
+	var rules interface{} = slo.GenerateRules(objectives)
+	if format == "kubernetes" {
+		rules = prometheusRule{
+			APIVersion: "monitoring.coreos.com/v1",
+			Kind:       "PrometheusRule",
+			Metadata:   ruleMetadata{Name: "user-slo"},
+			Spec:       slo.GenerateRules(objectives),
+		}
+	}
+
+	content, err := yaml.Marshal(rules)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(content)
+
+	return err
+}
@@ -0,0 +1,566 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/pkg/client"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	soakCallTimeout = 30 * time.Second
+
+	// maxSoakViolationDetails limits the number of the violations kept for the result, all of them are reported as
+	// they happen
+	maxSoakViolationDetails = 100
+
+	soakInvariantReadAfterWrite         = "read-after-write"
+	soakInvariantUniqueness             = "uniqueness"
+	soakInvariantPaginationCompleteness = "pagination-completeness"
+)
+
+type soakUserState int
+
+const (
+	soakUserAbsent soakUserState = iota
+	soakUserLive
+	soakUserDeleted
+)
+
+// soakUser is the state the soak test expects the user to be in, the lock is held while the user is operated on and
+// verified so the state matches the state of the service
+type soakUser struct {
+	lock  sync.Mutex
+	email string
+	state soakUserState
+}
+
+type soakViolation struct {
+	At        string `json:"at"`
+	Invariant string `json:"invariant"`
+	Email     string `json:"email,omitempty"`
+	Details   string `json:"details"`
+}
+
+type soakResult struct {
+	Tag        string          `json:"tag"`
+	Duration   string          `json:"duration"`
+	Operations map[string]int  `json:"operations"`
+	Errors     int             `json:"errors"`
+	FirstError string          `json:"firstError,omitempty"`
+	Violations int             `json:"violations"`
+	Details    []soakViolation `json:"details,omitempty"`
+}
+
+// soakRun contains the state shared by the workers of a soak test run
+type soakRun struct {
+	userClient *client.Client
+	adminEmail string
+	pageSize   int32
+	progress   io.Writer
+	users      []*soakUser
+
+	// snapshotLock is held exclusively while the pagination is verified, so no user changes while it is paged through
+	snapshotLock sync.RWMutex
+
+	resultLock sync.Mutex
+	result     *soakResult
+}
+
+func newSoakCommand() *cobra.Command {
+	var address string
+	var useTLS bool
+	var duration time.Duration
+	var rate int
+	var concurrency int
+	var userCount int
+	var pageSize int32
+	var verifyInterval time.Duration
+	var tag string
+	var domain string
+	var adminEmail string
+	var signingKeyFile string
+	var signingAlgorithm string
+	var cleanup bool
+
+	cmd := &cobra.Command{
+		Use:    "soak",
+		Short:  "Run randomized operations against the gRPC API while verifying the invariants of the storage",
+		Hidden: true,
+		Long: `Run randomized create, read, update, delete and search operations through the gRPC API against a pool
+of users, verifying after every operation that the storage keeps its invariants:
+
+  read-after-write         a created or updated user is read right away, a deleted user is not found
+  uniqueness               creating an existing user fails, no user is returned twice by the search
+  pagination completeness  paging through the search returns every live user of the pool exactly once
+
+The access tokens are signed with the given private JWK like the loadgen command does, so only point the
+soak test at test environments. The admin email address must be listed in ADMIN_EMAILS of the service to
+verify the pagination. The command fails if any invariant is violated.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if rate < 1 {
+				return fmt.Errorf("rate must be at least 1")
+			}
+
+			if concurrency < 1 {
+				return fmt.Errorf("concurrency must be at least 1")
+			}
+
+			if userCount < 1 {
+				return fmt.Errorf("users must be at least 1")
+			}
+
+			if pageSize < 1 {
+				return fmt.Errorf("page size must be at least 1")
+			}
+
+			signingKey, err := readLoadgenSigningKey(signingKeyFile)
+			if err != nil {
+				return err
+			}
+
+			if tag == "" {
+				tag = "soak-" + time.Now().UTC().Format("20060102150405")
+			}
+
+			transportCredentials := grpc.WithInsecure()
+			if useTLS {
+				transportCredentials = grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, ""))
+			}
+
+			userClient, err := client.NewClient(cmd.Context(), address, &client.Options{
+				TokenProvider: func(ctx context.Context) (string, error) {
+					email, _ := ctx.Value(loadgenEmailContextKey{}).(string)
+
+					return signLoadgenToken(signingKey, jwa.SignatureAlgorithm(signingAlgorithm), email)
+				},
+				DialOptions: []grpc.DialOption{transportCredentials},
+			})
+			if err != nil {
+				return err
+			}
+
+			defer userClient.Close()
+
+			ctx := cmd.Context()
+			if duration > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, duration)
+				defer cancel()
+			}
+
+			run := &soakRun{
+				userClient: userClient,
+				adminEmail: adminEmail,
+				pageSize:   pageSize,
+				progress:   cmd.ErrOrStderr(),
+				result:     &soakResult{Tag: tag, Operations: map[string]int{}},
+			}
+
+			for index := 1; index <= userCount; index++ {
+				run.users = append(run.users, &soakUser{email: fmt.Sprintf("user+%s-%d@%s", tag, index, domain)})
+			}
+
+			startedAt := time.Now()
+			run.soak(ctx, rate, concurrency, verifyInterval)
+			run.result.Duration = time.Since(startedAt).Round(time.Millisecond).String()
+
+			if cleanup {
+				run.cleanup()
+			}
+
+			if err := printOutput(cmd, run.result); err != nil {
+				return err
+			}
+
+			if run.result.Violations > 0 {
+				return fmt.Errorf("%d invariant violations found", run.result.Violations)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", "localhost:80", "The address of the user service gRPC endpoint")
+	cmd.Flags().BoolVar(&useTLS, "tls", false, "Connect to the user service using TLS")
+	cmd.Flags().DurationVar(&duration, "duration", 10*time.Minute, "How long to run the soak test for, runs until interrupted if zero")
+	cmd.Flags().IntVar(&rate, "rate", 10, "The maximum number of operations per second")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 4, "The number of the operations made in parallel")
+	cmd.Flags().IntVar(&userCount, "users", 50, "The number of users in the pool the operations are made against")
+	cmd.Flags().Int32Var(&pageSize, "page-size", 10, "The number of users requested per page when the pagination is verified")
+	cmd.Flags().DurationVar(&verifyInterval, "verify-interval", 30*time.Second, "How often to verify the pagination of the whole pool")
+	cmd.Flags().StringVar(&tag, "tag", "", "The tag added to the email addresses of the users, a random tag is used if not provided")
+	cmd.Flags().StringVar(&domain, "domain", "example.com", "The domain of the email addresses of the users")
+	cmd.Flags().StringVar(&adminEmail, "admin-email", "", "The email address the search is made as, must be listed in ADMIN_EMAILS of the service")
+	cmd.Flags().StringVar(&signingKeyFile, "signing-key", "", "The file containing the private JWK the access tokens are signed with")
+	cmd.Flags().StringVar(&signingAlgorithm, "signing-algorithm", string(jwa.RS256), "The algorithm the access tokens are signed with, e.g. RS256 or ES256")
+	cmd.Flags().BoolVar(&cleanup, "cleanup", true, "Delete the users of the pool when the soak test ends")
+	_ = cmd.MarkFlagRequired("signing-key")
+	_ = cmd.MarkFlagRequired("admin-email")
+
+	return cmd
+}
+
+// soak runs the randomized operations at the given rate until the context is done and verifies the pagination
+// periodically
+// ctx: Mandatory The reference to the context, the soak test runs until it is done
+// rate: Mandatory. The maximum number of operations per second
+// concurrency: Mandatory. The number of the operations made in parallel
+// verifyInterval: Mandatory. How often to verify the pagination, never if zero
+func (run *soakRun) soak(ctx context.Context, rate int, concurrency int, verifyInterval time.Duration) {
+	var waitGroup sync.WaitGroup
+
+	queue := make(chan struct{})
+	for worker := 0; worker < concurrency; worker++ {
+		waitGroup.Add(1)
+
+		go func(seed int64) {
+			defer waitGroup.Done()
+
+			random := rand.New(rand.NewSource(seed))
+			for range queue {
+				run.operate(random)
+			}
+		}(time.Now().UnixNano() + int64(worker))
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(rate))
+	defer ticker.Stop()
+
+	var verifyTicks <-chan time.Time
+	if verifyInterval > 0 {
+		verifyTicker := time.NewTicker(verifyInterval)
+		defer verifyTicker.Stop()
+
+		verifyTicks = verifyTicker.C
+	}
+
+	for ctx.Err() == nil {
+		select {
+		case <-ctx.Done():
+		case <-verifyTicks:
+			run.verifyPagination()
+		case <-ticker.C:
+			select {
+			case queue <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}
+	}
+
+	close(queue)
+	waitGroup.Wait()
+}
+
+// operate makes a random operation against a random user of the pool and verifies its outcome. The operation is not
+// cancelled when the run ends, so the state of the user stays known.
+func (run *soakRun) operate(random *rand.Rand) {
+	ctx, cancel := context.WithTimeout(context.Background(), soakCallTimeout)
+	defer cancel()
+
+	run.snapshotLock.RLock()
+	defer run.snapshotLock.RUnlock()
+
+	user := run.users[random.Intn(len(run.users))]
+	user.lock.Lock()
+	defer user.lock.Unlock()
+
+	if user.state != soakUserLive {
+		run.createUser(ctx, user)
+
+		return
+	}
+
+	switch choice := random.Intn(100); {
+	case choice < 40:
+		run.countOperation("read")
+		run.expectUser(ctx, user, true, "read")
+	case choice < 65:
+		run.updateUser(ctx, user)
+	case choice < 75:
+		run.createDuplicateUser(ctx, user)
+	default:
+		run.deleteUser(ctx, user)
+	}
+}
+
+func (run *soakRun) createUser(ctx context.Context, user *soakUser) {
+	run.countOperation("create")
+
+	response, err := run.userClient.ServiceClient.CreateUser(run.userContext(ctx, user.email), &userGRPCContract.CreateUserRequest{User: &userGRPCContract.User{}})
+	if err != nil {
+		run.recordError(user.email, err)
+
+		return
+	}
+
+	switch {
+	case response.Error == userGRPCContract.Error_NO_ERROR:
+	case response.Error == userGRPCContract.Error_USER_ALREADY_EXISTS && user.state == soakUserDeleted:
+		// The soft deleted users keep their email address until they are purged, so they are restored instead
+		if !run.restoreUser(ctx, user) {
+			return
+		}
+	case response.Error == userGRPCContract.Error_USER_ALREADY_EXISTS:
+		run.recordViolation(soakInvariantUniqueness, user.email, "creating a user that does not exist failed because it already exists")
+
+		return
+	default:
+		run.recordError(user.email, fmt.Errorf("%s: %s", response.Error, response.ErrorMessage))
+
+		return
+	}
+
+	user.state = soakUserLive
+	run.expectUser(ctx, user, true, "create")
+}
+
+func (run *soakRun) restoreUser(ctx context.Context, user *soakUser) bool {
+	run.countOperation("restore")
+
+	response, err := run.userClient.ServiceClient.RestoreUser(run.userContext(ctx, user.email), &userGRPCContract.RestoreUserRequest{Email: user.email})
+	if err != nil {
+		run.recordError(user.email, err)
+
+		return false
+	}
+
+	if response.Error != userGRPCContract.Error_NO_ERROR {
+		run.recordError(user.email, fmt.Errorf("%s: %s", response.Error, response.ErrorMessage))
+
+		return false
+	}
+
+	return true
+}
+
+func (run *soakRun) createDuplicateUser(ctx context.Context, user *soakUser) {
+	run.countOperation("create-duplicate")
+
+	response, err := run.userClient.ServiceClient.CreateUser(run.userContext(ctx, user.email), &userGRPCContract.CreateUserRequest{User: &userGRPCContract.User{}})
+	if err != nil {
+		run.recordError(user.email, err)
+
+		return
+	}
+
+	if response.Error == userGRPCContract.Error_NO_ERROR {
+		run.recordViolation(soakInvariantUniqueness, user.email, "creating a user that already exists succeeded")
+	} else if response.Error != userGRPCContract.Error_USER_ALREADY_EXISTS {
+		run.recordError(user.email, fmt.Errorf("%s: %s", response.Error, response.ErrorMessage))
+	}
+}
+
+func (run *soakRun) updateUser(ctx context.Context, user *soakUser) {
+	run.countOperation("update")
+
+	response, err := run.userClient.ServiceClient.UpdateUser(run.userContext(ctx, user.email), &userGRPCContract.UpdateUserRequest{
+		Email: user.email,
+		User:  &userGRPCContract.User{},
+	})
+	if err != nil {
+		run.recordError(user.email, err)
+
+		return
+	}
+
+	switch response.Error {
+	case userGRPCContract.Error_NO_ERROR:
+		run.expectUser(ctx, user, true, "update")
+	case userGRPCContract.Error_USER_NOT_FOUND:
+		run.recordViolation(soakInvariantReadAfterWrite, user.email, "updating a live user failed because it is not found")
+	default:
+		run.recordError(user.email, fmt.Errorf("%s: %s", response.Error, response.ErrorMessage))
+	}
+}
+
+func (run *soakRun) deleteUser(ctx context.Context, user *soakUser) {
+	run.countOperation("delete")
+
+	response, err := run.userClient.ServiceClient.DeleteUser(run.userContext(ctx, user.email), &userGRPCContract.DeleteUserRequest{Email: user.email})
+	if err != nil {
+		run.recordError(user.email, err)
+
+		return
+	}
+
+	switch response.Error {
+	case userGRPCContract.Error_NO_ERROR:
+		user.state = soakUserDeleted
+		run.expectUser(ctx, user, false, "delete")
+	case userGRPCContract.Error_USER_NOT_FOUND:
+		run.recordViolation(soakInvariantReadAfterWrite, user.email, "deleting a live user failed because it is not found")
+	default:
+		run.recordError(user.email, fmt.Errorf("%s: %s", response.Error, response.ErrorMessage))
+	}
+}
+
+// expectUser reads the user and records a read-after-write violation if it is found while it must not be or the other
+// way around
+// ctx: Mandatory The reference to the context
+// user: Mandatory. The user to read
+// found: Mandatory. Whether the user must be found
+// operation: Mandatory. The operation the user was changed by, used to describe the violation
+func (run *soakRun) expectUser(ctx context.Context, user *soakUser, found bool, operation string) {
+	response, err := run.userClient.ServiceClient.ReadUser(run.userContext(ctx, user.email), &userGRPCContract.ReadUserRequest{Email: user.email})
+	if err != nil {
+		run.recordError(user.email, err)
+
+		return
+	}
+
+	switch {
+	case response.Error == userGRPCContract.Error_NO_ERROR && !found:
+		run.recordViolation(soakInvariantReadAfterWrite, user.email, fmt.Sprintf("the user is read after %s", operation))
+	case response.Error == userGRPCContract.Error_USER_NOT_FOUND && found:
+		run.recordViolation(soakInvariantReadAfterWrite, user.email, fmt.Sprintf("the user is not found after %s", operation))
+	case response.Error != userGRPCContract.Error_NO_ERROR && response.Error != userGRPCContract.Error_USER_NOT_FOUND:
+		run.recordError(user.email, fmt.Errorf("%s: %s", response.Error, response.ErrorMessage))
+	}
+}
+
+// verifyPagination pages through the search filtered by the email addresses of the pool and verifies every live user
+// is returned exactly once and no other user is returned. No user changes while the pagination is verified.
+func (run *soakRun) verifyPagination() {
+	ctx, cancel := context.WithTimeout(context.Background(), soakCallTimeout)
+	defer cancel()
+
+	run.snapshotLock.Lock()
+	defer run.snapshotLock.Unlock()
+
+	run.countOperation("search")
+
+	emails := make([]string, 0, len(run.users))
+	live := map[string]bool{}
+
+	for _, user := range run.users {
+		emails = append(emails, user.email)
+		if user.state == soakUserLive {
+			live[user.email] = true
+		}
+	}
+
+	seen := map[string]int{}
+	after := ""
+
+	for page := 1; ; page++ {
+		response, err := run.userClient.ServiceClient.Search(run.userContext(ctx, run.adminEmail), &userGRPCContract.SearchRequest{
+			After:          after,
+			First:          run.pageSize,
+			Emails:         emails,
+			SortingOptions: []*userGRPCContract.SortingOptionPair{{Name: "email", Direction: userGRPCContract.SortingDirection_ASCENDING}},
+		})
+		if err != nil {
+			run.recordError("", err)
+
+			return
+		}
+
+		if response.Error != userGRPCContract.Error_NO_ERROR {
+			run.recordError("", fmt.Errorf("%s: %s", response.Error, response.ErrorMessage))
+
+			return
+		}
+
+		if page == 1 && response.TotalCount != int64(len(live)) {
+			run.recordViolation(soakInvariantPaginationCompleteness, "", fmt.Sprintf("the search counts %d users while %d are live", response.TotalCount, len(live)))
+		}
+
+		for _, user := range response.Users {
+			seen[user.Email]++
+			if seen[user.Email] == 2 {
+				run.recordViolation(soakInvariantUniqueness, user.Email, "the search returns the user more than once")
+			}
+		}
+
+		if !response.HasNextPage || len(response.Users) == 0 {
+			break
+		}
+
+		after = response.Users[len(response.Users)-1].Cursor
+	}
+
+	missing := []string{}
+	for email := range live {
+		if seen[email] == 0 {
+			missing = append(missing, email)
+		}
+	}
+
+	sort.Strings(missing)
+
+	for _, email := range missing {
+		run.recordViolation(soakInvariantPaginationCompleteness, email, "paging through the search does not return the live user")
+	}
+
+	for email := range seen {
+		if !live[email] {
+			run.recordViolation(soakInvariantPaginationCompleteness, email, "paging through the search returns the user that is not live")
+		}
+	}
+}
+
+// cleanup deletes the live users of the pool
+func (run *soakRun) cleanup() {
+	for _, user := range run.users {
+		if user.state != soakUserLive {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), soakCallTimeout)
+		_, _ = run.userClient.ServiceClient.DeleteUser(run.userContext(ctx, user.email), &userGRPCContract.DeleteUserRequest{Email: user.email})
+		cancel()
+	}
+}
+
+// userContext returns the context the call is made with the access token of the given email address
+func (run *soakRun) userContext(ctx context.Context, email string) context.Context {
+	return context.WithValue(ctx, loadgenEmailContextKey{}, email)
+}
+
+func (run *soakRun) countOperation(operation string) {
+	run.resultLock.Lock()
+	defer run.resultLock.Unlock()
+
+	run.result.Operations[operation]++
+}
+
+func (run *soakRun) recordError(email string, err error) {
+	run.resultLock.Lock()
+	defer run.resultLock.Unlock()
+
+	run.result.Errors++
+	if run.result.FirstError == "" {
+		run.result.FirstError = fmt.Sprintf("%s: %v", email, err)
+	}
+}
+
+func (run *soakRun) recordViolation(invariant string, email string, details string) {
+	violation := soakViolation{
+		At:        time.Now().UTC().Format(time.RFC3339),
+		Invariant: invariant,
+		Email:     email,
+		Details:   details,
+	}
+
+	run.resultLock.Lock()
+	defer run.resultLock.Unlock()
+
+	fmt.Fprintf(run.progress, "%s violation: %s %s\n", violation.Invariant, violation.Email, violation.Details)
+
+	run.result.Violations++
+	if len(run.result.Details) < maxSoakViolationDetails {
+		run.result.Details = append(run.result.Details, violation)
+	}
+}
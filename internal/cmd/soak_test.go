@@ -0,0 +1,160 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/jwk"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Soak Tests", func() {
+	var (
+		service        *fakeUserService
+		address        string
+		stopService    func()
+		directory      string
+		signingKeyFile string
+	)
+
+	type soakViolation struct {
+		Invariant string `json:"invariant"`
+		Email     string `json:"email"`
+		Details   string `json:"details"`
+	}
+
+	type soakResult struct {
+		Tag        string          `json:"tag"`
+		Operations map[string]int  `json:"operations"`
+		Errors     int             `json:"errors"`
+		FirstError string          `json:"firstError"`
+		Violations int             `json:"violations"`
+		Details    []soakViolation `json:"details"`
+	}
+
+	// runSoak runs a short soak test against the fake service and decodes its result
+	runSoak := func(args ...string) (soakResult, error) {
+		output, err := execute(append([]string{
+			"soak",
+			"--address", address,
+			"--signing-key", signingKeyFile,
+			"--admin-email", "admin@test.com",
+			"--tag", "soak-test",
+			"--domain", "test.com",
+			"--duration", "1s",
+			"--rate", "200",
+			"--users", "5",
+			"--page-size", "2",
+			"--verify-interval", "100ms",
+			"-o", "json",
+		}, args...)...)
+
+		// The violations are reported as they happen, before the result is printed
+		var result soakResult
+		if start := strings.Index("\n"+output, "\n{"); start != -1 {
+			Ω(json.NewDecoder(strings.NewReader(output[start:])).Decode(&result)).Should(Succeed())
+		}
+
+		return result, err
+	}
+
+	// hasViolation returns whether the result reports a violation of the given invariant
+	hasViolation := func(result soakResult, invariant string) bool {
+		for _, violation := range result.Details {
+			if violation.Invariant == invariant {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	BeforeEach(func() {
+		service, address, stopService = startFakeUserService()
+
+		var err error
+		directory, err = ioutil.TempDir("", "soak")
+		Ω(err).Should(BeNil())
+
+		var keySet jwk.Set
+		signingKeyFile, keySet = writeSigningKeyFile(directory)
+		service.verifyTokensWith(keySet)
+	})
+
+	AfterEach(func() {
+		stopService()
+		os.RemoveAll(directory)
+	})
+
+	Context("the soak test runs", func() {
+		When("the storage keeps its invariants", func() {
+			It("should operate on the users of the pool and verify the pagination without finding any violation", func() {
+				result, err := runSoak()
+				Ω(err).Should(BeNil())
+				Ω(result.Tag).Should(Equal("soak-test"))
+				Ω(result.Violations).Should(Equal(0))
+				Ω(result.Errors).Should(Equal(0), result.FirstError)
+				Ω(result.Operations["create"]).Should(BeNumerically(">", 0))
+				Ω(result.Operations["search"]).Should(BeNumerically(">", 0))
+				Ω(result.Operations["read"] + result.Operations["update"] + result.Operations["delete"]).Should(BeNumerically(">", 0))
+
+				for _, email := range service.getCreatedEmails() {
+					Ω(email).Should(MatchRegexp(`^user\+soak-test-[1-5]@test\.com$`))
+				}
+
+				// The live users are deleted once the soak test ends
+				Ω(service.getEmails()).Should(BeEmpty())
+			})
+		})
+
+		When("the cleanup is disabled", func() {
+			It("should keep the live users", func() {
+				result, err := runSoak("--cleanup=false")
+				Ω(err).Should(BeNil())
+				Ω(result.Violations).Should(Equal(0))
+				Ω(service.getEmails()).ShouldNot(BeEmpty())
+			})
+		})
+
+		When("the storage loses the created users", func() {
+			It("should report the read-after-write violations and fail", func() {
+				service.injectFaults(true, false)
+
+				result, err := runSoak()
+				Ω(err).Should(MatchError(ContainSubstring("invariant violations found")))
+				Ω(result.Violations).Should(BeNumerically(">", 0))
+				Ω(hasViolation(result, "read-after-write")).Should(BeTrue())
+			})
+		})
+
+		When("the search returns the users more than once", func() {
+			It("should report the uniqueness violations and fail", func() {
+				service.injectFaults(false, true)
+
+				result, err := runSoak()
+				Ω(err).Should(MatchError(ContainSubstring("invariant violations found")))
+				Ω(hasViolation(result, "uniqueness")).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("the arguments are invalid", func() {
+		It("should return error without operating on any user", func() {
+			for _, args := range [][]string{
+				{"--rate", "0"},
+				{"--concurrency", "0"},
+				{"--users", "0"},
+				{"--page-size", "0"},
+			} {
+				_, err := runSoak(args...)
+				Ω(err).ShouldNot(BeNil(), strings.Join(args, " "))
+			}
+
+			Ω(service.getCreatedEmails()).Should(BeEmpty())
+		})
+	})
+})
@@ -6,18 +6,48 @@ import (
 	"time"
 
 	"github.com/decentralized-cloud/user/pkg/util"
+	"github.com/decentralized-cloud/user/services/configuration"
 	gocoreUtil "github.com/micro-business/go-core/pkg/util"
 	"github.com/spf13/cobra"
 )
 
 func newStartCommand() *cobra.Command {
-	return &cobra.Command{
+	var skipReachabilityChecks bool
+
+	cmd := &cobra.Command{
 		Use:   "start",
 		Short: "Start the User service",
-		Run: func(cmd *cobra.Command, args []string) {
+		Long: `Start the User service. The configuration is validated first, the service does not start and all the
+problems found are reported if any option is invalid or the database or the JWKS endpoint can not be reached. The
+database is not checked if DATABASE_STARTUP_CHECK_ENABLED is set, the service waits for it in the background then.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configurationService, err := getConfigurationService(cmd)
+			if err != nil {
+				return err
+			}
+
 			gocoreUtil.PrintInfo(fmt.Sprintf("Copyright (C) %d, Micro Business Ltd.\n", time.Now().Year()))
 			gocoreUtil.PrintYAML(gocoreUtil.GetVersion())
-			util.StartService()
+
+			checks := []configuration.ValidationCheck{}
+			if !skipReachabilityChecks {
+				checks = util.NewStartupReachabilityChecks(configurationService)
+			}
+
+			report := configuration.Validate(cmd.Context(), configurationService, checks...)
+			if err := report.Err(); err != nil {
+				_ = writeOutput(cmd.ErrOrStderr(), outputFormatTable, checkReport{report})
+
+				return err
+			}
+
+			util.StartService(configurationService)
+
+			return nil
 		},
 	}
+
+	addSkipReachabilityChecksFlag(cmd, &skipReachabilityChecks)
+
+	return cmd
 }
@@ -0,0 +1,352 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/micro-business/go-core/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	supportBundleRequestTimeout = 30 * time.Second
+
+	// supportBundleMaxLogLineLength is the longest log line kept, the structured log lines carrying a stack trace
+	// can be long but a single line must not fill the bundle
+	supportBundleMaxLogLineLength = 1024 * 1024
+
+	// supportBundleRedacted replaces the secret values found in the logs
+	supportBundleRedacted = "[REDACTED]"
+)
+
+// supportBundleEntry is a file of the support bundle, the files that could not be collected are listed with the
+// error instead, so a partially reachable service still gets a bundle
+type supportBundleEntry struct {
+	Name  string `json:"name"`
+	Size  int    `json:"size"`
+	Error string `json:"error,omitempty"`
+}
+
+// supportBundleManifest describes the content of the support bundle, it is written to the bundle as well
+type supportBundleManifest struct {
+	File      string               `json:"file"`
+	CreatedAt string               `json:"createdAt"`
+	Address   string               `json:"address"`
+	Entries   []supportBundleEntry `json:"entries"`
+}
+
+// TableHeaders returns the column headers of the support bundle table
+func (manifest supportBundleManifest) TableHeaders() []string {
+	return []string{"FILE", "SIZE", "ERROR"}
+}
+
+// TableRows returns the files of the support bundle
+func (manifest supportBundleManifest) TableRows() [][]string {
+	rows := make([][]string, 0, len(manifest.Entries))
+	for _, entry := range manifest.Entries {
+		rows = append(rows, []string{entry.Name, strconv.Itoa(entry.Size), entry.Error})
+	}
+
+	return rows
+}
+
+// supportBundleWriter writes the files of the support bundle to a gzipped tar archive and lists them in the manifest
+type supportBundleWriter struct {
+	archive  *tar.Writer
+	manifest *supportBundleManifest
+	now      time.Time
+}
+
+func newSupportBundleCommand() *cobra.Command {
+	var file string
+	var address string
+	var logFiles []string
+	var logLines int
+
+	cmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Gather the diagnostics of a running User service into a single archive to attach to an incident ticket",
+		Long: `Gather the diagnostics of a running User service into a single gzipped tar archive:
+
+  manifest.json            the files of the bundle and the errors the missing ones could not be collected with
+  version/cli.json         the version of this CLI
+  version/service.json     the version of the running service
+  config.json              the effective configuration, the secrets are redacted
+  health.json              the health report of the service and its dependencies
+  metrics.txt              the Prometheus metrics of the service
+  profiles/goroutine.pb.gz the goroutine profile, read it with go tool pprof
+  profiles/heap.pb.gz      the heap profile, read it with go tool pprof
+  logs/<file>              the last lines of the given log files, the secret values are redacted
+
+The configuration is read from the same environment variables or configuration file the service reads it from,
+so run the command next to the service, e.g. with kubectl exec, or pass the same --config file. The service is
+reached on its HTTP port, the profiles are only served if HTTP_PROFILING_ENABLED is set. The files that could not
+be collected are listed with their error rather than failing the command.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if logLines < 1 {
+				return fmt.Errorf("log-lines must be at least 1")
+			}
+
+			configurationService, err := getConfigurationService(cmd)
+			if err != nil {
+				return err
+			}
+
+			if address == "" {
+				if address, err = getServiceHTTPAddress(configurationService); err != nil {
+					return err
+				}
+			}
+
+			now := time.Now().UTC()
+			if file == "" {
+				file = fmt.Sprintf("user-support-bundle-%s.tar.gz", now.Format("20060102T150405Z"))
+			}
+
+			output, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+			if err != nil {
+				return err
+			}
+
+			defer output.Close()
+
+			manifest := &supportBundleManifest{
+				File:      file,
+				CreatedAt: now.Format(time.RFC3339),
+				Address:   address,
+				Entries:   []supportBundleEntry{},
+			}
+
+			if err = writeSupportBundle(output, manifest, configurationService, logFiles, logLines, now); err != nil {
+				return err
+			}
+
+			return printOutput(cmd, manifest)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Write the bundle to the given file, fails if the file exists. Defaults to user-support-bundle-<time>.tar.gz")
+	cmd.Flags().StringVar(&address, "address", "", "The base URL of the HTTP server of the service. Defaults to the HTTP_HOST and HTTP_PORT of the configuration")
+	cmd.Flags().StringArrayVar(&logFiles, "log-file", nil, "Include the last lines of the given log file, e.g. the output of kubectl logs. Can be repeated")
+	cmd.Flags().IntVar(&logLines, "log-lines", 1000, "The number of the last lines included from every log file")
+
+	return cmd
+}
+
+// getServiceHTTPAddress returns the base URL the HTTP server of the service is reached on, the service listening on
+// all the interfaces is reached on localhost
+// configurationService: Mandatory. Reference to the service that provides the HTTP host and port
+// Returns either the base URL or error if something goes wrong
+func getServiceHTTPAddress(configurationService configuration.ConfigurationContract) (string, error) {
+	host, err := configurationService.GetHttpHost()
+	if err != nil {
+		return "", err
+	}
+
+	port, err := configurationService.GetHttpPort()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the HTTP server of the service, set HTTP_PORT or pass --address: %w", err)
+	}
+
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+
+	return "http://" + net.JoinHostPort(host, strconv.Itoa(port)), nil
+}
+
+// writeSupportBundle collects the diagnostics and writes them to the bundle, followed by the manifest
+// writer: Mandatory. The writer to write the gzipped tar archive to
+// manifest: Mandatory. The manifest the collected files are listed in
+// configurationService: Mandatory. Reference to the service the effective configuration is read from
+// logFiles: Optional. The log files to include the last lines of
+// logLines: Mandatory. The number of the last lines included from every log file
+// now: Mandatory. The time the bundle is created at
+// Returns error if the bundle could not be written
+func writeSupportBundle(
+	writer io.Writer,
+	manifest *supportBundleManifest,
+	configurationService configuration.ConfigurationContract,
+	logFiles []string,
+	logLines int,
+	now time.Time) error {
+	compressor := gzip.NewWriter(writer)
+	bundle := &supportBundleWriter{
+		archive:  tar.NewWriter(compressor),
+		manifest: manifest,
+		now:      now,
+	}
+
+	client := &http.Client{Timeout: supportBundleRequestTimeout}
+	address := strings.TrimSuffix(manifest.Address, "/")
+
+	if err := bundle.addJSON("version/cli.json", util.GetVersion()); err != nil {
+		return err
+	}
+
+	// The service is asked for everything below, the parts it can not provide are listed with the error
+	for _, request := range []struct {
+		name string
+		path string
+	}{
+		{name: "version/service.json", path: "/version"},
+		{name: "health.json", path: "/health"},
+		{name: "metrics.txt", path: "/metrics"},
+		{name: "profiles/goroutine.pb.gz", path: "/debug/pprof/goroutine"},
+		{name: "profiles/heap.pb.gz", path: "/debug/pprof/heap"},
+	} {
+		content, err := fetchSupportBundleFile(client, address+request.path)
+		if err = bundle.add(request.name, content, err); err != nil {
+			return err
+		}
+	}
+
+	if err := bundle.addJSON("config.json", configuration.GetEffectiveOptions(configurationService)); err != nil {
+		return err
+	}
+
+	secretValues := configuration.GetSecretValues(configurationService)
+	for _, logFile := range logFiles {
+		content, err := readLastLogLines(logFile, logLines, secretValues)
+		if err = bundle.add("logs/"+filepath.Base(logFile), content, err); err != nil {
+			return err
+		}
+	}
+
+	if err := bundle.addJSON("manifest.json", manifest); err != nil {
+		return err
+	}
+
+	if err := bundle.archive.Close(); err != nil {
+		return err
+	}
+
+	return compressor.Close()
+}
+
+// add writes the file to the bundle and lists it in the manifest, the file is only listed with the error if it
+// could not be collected
+// name: Mandatory. The path of the file in the bundle
+// content: Optional. The content of the file
+// collectErr: Optional. The error the file could not be collected with
+// Returns error if the file could not be written to the bundle
+func (bundle *supportBundleWriter) add(name string, content []byte, collectErr error) error {
+	if collectErr != nil {
+		bundle.manifest.Entries = append(bundle.manifest.Entries, supportBundleEntry{Name: name, Error: collectErr.Error()})
+
+		return nil
+	}
+
+	if err := bundle.archive.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(content)),
+		ModTime: bundle.now,
+	}); err != nil {
+		return err
+	}
+
+	if _, err := bundle.archive.Write(content); err != nil {
+		return err
+	}
+
+	bundle.manifest.Entries = append(bundle.manifest.Entries, supportBundleEntry{Name: name, Size: len(content)})
+
+	return nil
+}
+
+// addJSON writes the value to the bundle as an indented JSON file
+// name: Mandatory. The path of the file in the bundle
+// value: Mandatory. The value to write
+// Returns error if the file could not be written to the bundle
+func (bundle *supportBundleWriter) addJSON(name string, value interface{}) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+
+	return bundle.add(name, content, err)
+}
+
+// fetchSupportBundleFile reads the response of the HTTP server of the service. The health report is returned with
+// 503 Service Unavailable when a dependency is down, that is exactly when it is needed, so it is accepted too.
+// client: Mandatory. The HTTP client to send the request with
+// url: Mandatory. The URL to read
+// Returns either the response body or error if something goes wrong
+func fetchSupportBundleFile(client *http.Client, url string) ([]byte, error) {
+	response, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	content, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK && (response.StatusCode != http.StatusServiceUnavailable || len(content) == 0) {
+		return nil, fmt.Errorf("GET %s returned %s", url, response.Status)
+	}
+
+	return content, nil
+}
+
+// readLastLogLines reads the last lines of the log file and replaces the secret values found in them
+// file: Mandatory. The log file to read
+// count: Mandatory. The number of the last lines to read
+// secretValues: Optional. The values to redact
+// Returns either the last lines of the log file or error if something goes wrong
+func readLastLogLines(file string, count int, secretValues []string) ([]byte, error) {
+	input, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+
+	defer input.Close()
+
+	// The lines are kept in a ring, so the log file is never held in memory as a whole
+	lines := make([]string, count)
+	read := 0
+
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 64*1024), supportBundleMaxLogLineLength)
+	for scanner.Scan() {
+		lines[read%count] = scanner.Text()
+		read++
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	first := 0
+	if read > count {
+		first = read - count
+	}
+
+	var builder strings.Builder
+	for index := first; index < read; index++ {
+		line := lines[index%count]
+		for _, secretValue := range secretValues {
+			line = strings.ReplaceAll(line, secretValue, supportBundleRedacted)
+		}
+
+		builder.WriteString(line)
+		builder.WriteString("\n")
+	}
+
+	return []byte(builder.String()), nil
+}
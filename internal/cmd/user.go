@@ -0,0 +1,310 @@
+// Package cmd implements different commands that can be executed against user service
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/pkg/client"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const userCallTimeout = 30 * time.Second
+
+type deleteUserResult struct {
+	Email  string `json:"email"`
+	SagaID string `json:"sagaID"`
+}
+
+// userConnectionFlags contains the flags every user subcommand connects to the user service with
+type userConnectionFlags struct {
+	address string
+	token   string
+	useTLS  bool
+}
+
+func newUserCommand() *cobra.Command {
+	flags := &userConnectionFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage the users through the gRPC API of a running user service",
+		Long: `Manage the users through the gRPC API of a running user service.
+
+Every call is made with the given access token. The create, get and delete subcommands only
+accept the token issued for the email address of the user, the search subcommand requires the
+token of an admin user.`,
+	}
+
+	cmd.PersistentFlags().StringVar(&flags.address, "address", "localhost:80", "The address of the user service gRPC endpoint")
+	cmd.PersistentFlags().StringVar(&flags.token, "token", "", "The access token the calls are made with, defaults to USER_ACCESS_TOKEN environment variable")
+	cmd.PersistentFlags().BoolVar(&flags.useTLS, "tls", false, "Connect to the user service using TLS")
+
+	cmd.AddCommand(
+		newUserCreateCommand(flags),
+		newUserGetCommand(flags),
+		newUserDeleteCommand(flags),
+		newUserSearchCommand(flags),
+	)
+
+	return cmd
+}
+
+func newUserCreateCommand(flags *userConnectionFlags) *cobra.Command {
+	var dataResidency string
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create the user the access token is issued for",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return callUserService(cmd, flags, func(ctx context.Context, userClient *client.Client) (interface{}, error) {
+				response, err := userClient.ServiceClient.CreateUser(ctx, &userGRPCContract.CreateUserRequest{
+					User: &userGRPCContract.User{DataResidency: dataResidency},
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				if response.Error != userGRPCContract.Error_NO_ERROR {
+					return nil, fmt.Errorf("%s: %s", response.Error, response.ErrorMessage)
+				}
+
+				return userResult{
+					Email:         getTokenEmail(getAccessToken(flags.token)),
+					Cursor:        response.Cursor,
+					DataResidency: response.User.GetDataResidency(),
+				}, nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&dataResidency, "data-residency", "", "The region the user must be persisted in, the default region is used if not provided")
+
+	return cmd
+}
+
+func newUserGetCommand(flags *userConnectionFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <email>",
+		Short: "Read the user with the given email address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return callUserService(cmd, flags, func(ctx context.Context, userClient *client.Client) (interface{}, error) {
+				response, err := userClient.ServiceClient.ReadUser(ctx, &userGRPCContract.ReadUserRequest{Email: args[0]})
+				if err != nil {
+					return nil, err
+				}
+
+				if response.Error != userGRPCContract.Error_NO_ERROR {
+					return nil, fmt.Errorf("%s: %s", response.Error, response.ErrorMessage)
+				}
+
+				return userResult{
+					Email:         args[0],
+					DataResidency: response.User.GetDataResidency(),
+				}, nil
+			})
+		},
+	}
+}
+
+func newUserDeleteCommand(flags *userConnectionFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <email>",
+		Short: "Delete the user with the given email address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return callUserService(cmd, flags, func(ctx context.Context, userClient *client.Client) (interface{}, error) {
+				response, err := userClient.ServiceClient.DeleteUser(ctx, &userGRPCContract.DeleteUserRequest{Email: args[0]})
+				if err != nil {
+					return nil, err
+				}
+
+				if response.Error != userGRPCContract.Error_NO_ERROR {
+					return nil, fmt.Errorf("%s: %s", response.Error, response.ErrorMessage)
+				}
+
+				return deleteUserResult{Email: args[0], SagaID: response.SagaID}, nil
+			})
+		},
+	}
+}
+
+func newUserSearchCommand(flags *userConnectionFlags) *cobra.Command {
+	var emails []string
+	var sorting []string
+	var after string
+	var before string
+	var first int32
+	var last int32
+	var includeDeleted bool
+
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Search for the users, optionally filtered by the email addresses",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sortingOptions, err := parseSortingOptions(sorting)
+			if err != nil {
+				return err
+			}
+
+			return callUserService(cmd, flags, func(ctx context.Context, userClient *client.Client) (interface{}, error) {
+				response, err := userClient.ServiceClient.Search(ctx, &userGRPCContract.SearchRequest{
+					After:          after,
+					First:          first,
+					Before:         before,
+					Last:           last,
+					Emails:         emails,
+					SortingOptions: sortingOptions,
+					IncludeDeleted: includeDeleted,
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				if response.Error != userGRPCContract.Error_NO_ERROR {
+					return nil, fmt.Errorf("%s: %s", response.Error, response.ErrorMessage)
+				}
+
+				result := searchResult{
+					TotalCount:      response.TotalCount,
+					HasPreviousPage: response.HasPreviousPage,
+					HasNextPage:     response.HasNextPage,
+					Users:           make([]userResult, 0, len(response.Users)),
+				}
+
+				for _, user := range response.Users {
+					result.Users = append(result.Users, userResult{
+						Email:         user.Email,
+						Cursor:        user.Cursor,
+						DataResidency: user.User.GetDataResidency(),
+					})
+				}
+
+				return result, nil
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&emails, "email", nil, "The email addresses to filter the users by, can be repeated")
+	cmd.Flags().StringSliceVar(&sorting, "sort", nil, "The field to sort the users by followed by an optional :asc or :desc, e.g. email:desc, can be repeated")
+	cmd.Flags().StringVar(&after, "after", "", "Return the users after the given cursor")
+	cmd.Flags().Int32Var(&first, "first", 20, "Return the first n users, all the users are returned if zero")
+	cmd.Flags().StringVar(&before, "before", "", "Return the users before the given cursor")
+	cmd.Flags().Int32Var(&last, "last", 0, "Return the last n users, ignored if zero")
+	cmd.Flags().BoolVar(&includeDeleted, "include-deleted", false, "Return the soft deleted users as well")
+
+	return cmd
+}
+
+// callUserService connects to the user service, makes the call and prints its result in the format requested by the user
+// cmd: Mandatory. The command that is being executed
+// flags: Mandatory. The flags to connect to the user service with
+// call: Mandatory. The call to make, returns the result to print
+// Returns error if connecting to the user service or the call fails
+func callUserService(
+	cmd *cobra.Command,
+	flags *userConnectionFlags,
+	call func(ctx context.Context, userClient *client.Client) (interface{}, error)) error {
+	userClient, err := newUserServiceClient(cmd.Context(), flags.address, getAccessToken(flags.token), flags.useTLS)
+	if err != nil {
+		return err
+	}
+
+	defer userClient.Close()
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), userCallTimeout)
+	defer cancel()
+
+	result, err := call(ctx, userClient)
+	if err != nil {
+		return err
+	}
+
+	return printOutput(cmd, result)
+}
+
+// newUserServiceClient creates the client of the user service that makes every call with the given access token
+// ctx: Mandatory The reference to the context
+// address: Mandatory. The address of the user service gRPC endpoint
+// token: Optional. The access token the calls are made with
+// useTLS: Mandatory. Whether to connect to the user service using TLS
+// Returns either the new client or error if something goes wrong
+func newUserServiceClient(ctx context.Context, address string, token string, useTLS bool) (*client.Client, error) {
+	transportCredentials := grpc.WithInsecure()
+	if useTLS {
+		transportCredentials = grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, ""))
+	}
+
+	return client.NewClient(ctx, address, &client.Options{
+		TokenProvider: func(ctx context.Context) (string, error) {
+			return token, nil
+		},
+		DialOptions: []grpc.DialOption{transportCredentials},
+	})
+}
+
+// getAccessToken returns the access token provided by the user, or the USER_ACCESS_TOKEN environment variable if not provided
+// token: Optional. The access token provided by the user
+// Returns the access token
+func getAccessToken(token string) string {
+	if strings.Trim(token, " ") == "" {
+		return os.Getenv("USER_ACCESS_TOKEN")
+	}
+
+	return token
+}
+
+// getTokenEmail reads the email address the access token is issued for without verifying the token, the user service
+// verifies the token when it is called
+// token: Mandatory. The access token
+// Returns the email address or empty if the token does not contain it
+func getTokenEmail(token string) string {
+	parsedToken, err := jwt.ParseString(token)
+	if err != nil {
+		return ""
+	}
+
+	email, _ := parsedToken.Get("email")
+	castedEmail, _ := email.(string)
+
+	return castedEmail
+}
+
+// parseSortingOptions parses the sorting options provided as field[:asc|desc]
+// sorting: Optional. The sorting options to parse
+// Returns either the parsed sorting options or error if any of them is invalid
+func parseSortingOptions(sorting []string) ([]*userGRPCContract.SortingOptionPair, error) {
+	sortingOptions := make([]*userGRPCContract.SortingOptionPair, 0, len(sorting))
+	for _, option := range sorting {
+		parts := strings.SplitN(option, ":", 2)
+		direction := userGRPCContract.SortingDirection_ASCENDING
+
+		if len(parts) == 2 {
+			switch strings.ToLower(parts[1]) {
+			case "asc":
+			case "desc":
+				direction = userGRPCContract.SortingDirection_DESCENDING
+			default:
+				return nil, fmt.Errorf("invalid sorting option %q, must be field[:asc|desc]", option)
+			}
+		}
+
+		if strings.Trim(parts[0], " ") == "" {
+			return nil, fmt.Errorf("invalid sorting option %q, must be field[:asc|desc]", option)
+		}
+
+		sortingOptions = append(sortingOptions, &userGRPCContract.SortingOptionPair{Name: parts[0], Direction: direction})
+	}
+
+	return sortingOptions, nil
+}
@@ -0,0 +1,238 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("User Command Tests", func() {
+	var (
+		service     *fakeUserService
+		address     string
+		stopService func()
+		token       string
+	)
+
+	// issueToken issues an access token for the given email address, the fake service does not verify its signature
+	issueToken := func(email string) string {
+		token := jwt.New()
+		_ = token.Set("email", email)
+
+		signed, err := jwt.Sign(token, jwa.HS256, []byte("secret"))
+		Ω(err).Should(BeNil())
+
+		return string(signed)
+	}
+
+	// runUser runs the user subcommand with the given arguments against the fake service
+	runUser := func(args ...string) (string, error) {
+		return execute(append(append([]string{"user"}, args...), "--address", address, "--token", token)...)
+	}
+
+	// decode decodes the JSON output of the command
+	decode := func(output string) map[string]interface{} {
+		result := map[string]interface{}{}
+		Ω(json.Unmarshal([]byte(output), &result)).Should(Succeed())
+
+		return result
+	}
+
+	BeforeEach(func() {
+		service, address, stopService = startFakeUserService()
+		token = issueToken("user@test.com")
+	})
+
+	AfterEach(func() {
+		stopService()
+		os.Unsetenv("USER_ACCESS_TOKEN")
+	})
+
+	Context("the user is created", func() {
+		When("the user does not exist", func() {
+			It("should create the user the access token is issued for", func() {
+				output, err := runUser("create", "--data-residency", "eu", "-o", "json")
+				Ω(err).Should(BeNil())
+				Ω(decode(output)).Should(Equal(map[string]interface{}{
+					"email":         "user@test.com",
+					"cursor":        "user@test.com",
+					"dataResidency": "eu",
+				}))
+				Ω(service.getCreatedEmails()).Should(Equal([]string{"user@test.com"}))
+				Ω(service.getAuthorizations()).Should(Equal([]string{"Bearer " + token}))
+			})
+		})
+
+		When("the user already exists", func() {
+			It("should return the error returned by the service", func() {
+				service.addUsers("user@test.com")
+
+				_, err := runUser("create")
+				Ω(err).Should(MatchError(ContainSubstring("USER_ALREADY_EXISTS")))
+			})
+		})
+
+		When("the access token is not provided", func() {
+			It("should use the access token in USER_ACCESS_TOKEN environment variable", func() {
+				envToken := issueToken("env@test.com")
+				os.Setenv("USER_ACCESS_TOKEN", envToken)
+				token = ""
+
+				output, err := runUser("create", "-o", "json")
+				Ω(err).Should(BeNil())
+				Ω(decode(output)["email"]).Should(Equal("env@test.com"))
+				Ω(service.getAuthorizations()).Should(Equal([]string{"Bearer " + envToken}))
+			})
+		})
+	})
+
+	Context("the user is read", func() {
+		When("the user exists", func() {
+			It("should print the user", func() {
+				_, err := runUser("create", "--data-residency", "us")
+				Ω(err).Should(BeNil())
+
+				output, err := runUser("get", "user@test.com", "-o", "json")
+				Ω(err).Should(BeNil())
+				Ω(decode(output)).Should(Equal(map[string]interface{}{
+					"email":         "user@test.com",
+					"dataResidency": "us",
+				}))
+			})
+		})
+
+		When("the user does not exist", func() {
+			It("should return the error returned by the service", func() {
+				_, err := runUser("get", "missing@test.com")
+				Ω(err).Should(MatchError(ContainSubstring("USER_NOT_FOUND")))
+			})
+		})
+
+		When("the email address is not provided", func() {
+			It("should return error", func() {
+				_, err := runUser("get")
+				Ω(err).ShouldNot(BeNil())
+			})
+		})
+	})
+
+	Context("the user is deleted", func() {
+		When("the user exists", func() {
+			It("should delete the user and print the saga deleting its data", func() {
+				service.addUsers("user@test.com")
+
+				output, err := runUser("delete", "user@test.com", "-o", "json")
+				Ω(err).Should(BeNil())
+				Ω(decode(output)).Should(Equal(map[string]interface{}{
+					"email":  "user@test.com",
+					"sagaID": "delete-user@test.com",
+				}))
+
+				_, err = runUser("get", "user@test.com")
+				Ω(err).Should(MatchError(ContainSubstring("USER_NOT_FOUND")))
+			})
+		})
+
+		When("the user does not exist", func() {
+			It("should return the error returned by the service", func() {
+				_, err := runUser("delete", "missing@test.com")
+				Ω(err).Should(MatchError(ContainSubstring("USER_NOT_FOUND")))
+			})
+		})
+	})
+
+	Context("the users are searched", func() {
+		BeforeEach(func() {
+			service.addUsers("a@test.com", "b@test.com", "c@test.com")
+		})
+
+		When("the flags are provided", func() {
+			It("should pass them to the service", func() {
+				_, err := runUser(
+					"search",
+					"--email", "a@test.com",
+					"--email", "b@test.com,c@test.com",
+					"--sort", "email:desc",
+					"--sort", "createdAt",
+					"--after", "after-cursor",
+					"--first", "5",
+					"--before", "before-cursor",
+					"--last", "3",
+					"--include-deleted")
+				Ω(err).Should(BeNil())
+
+				requests := service.getSearchRequests()
+				Ω(requests).Should(HaveLen(1))
+				Ω(requests[0].Emails).Should(Equal([]string{"a@test.com", "b@test.com", "c@test.com"}))
+				Ω(requests[0].SortingOptions).Should(HaveLen(2))
+				Ω(requests[0].SortingOptions[0].Name).Should(Equal("email"))
+				Ω(requests[0].SortingOptions[0].Direction).Should(Equal(userGRPCContract.SortingDirection_DESCENDING))
+				Ω(requests[0].SortingOptions[1].Name).Should(Equal("createdAt"))
+				Ω(requests[0].SortingOptions[1].Direction).Should(Equal(userGRPCContract.SortingDirection_ASCENDING))
+				Ω(requests[0].After).Should(Equal("after-cursor"))
+				Ω(requests[0].First).Should(Equal(int32(5)))
+				Ω(requests[0].Before).Should(Equal("before-cursor"))
+				Ω(requests[0].Last).Should(Equal(int32(3)))
+				Ω(requests[0].IncludeDeleted).Should(BeTrue())
+			})
+		})
+
+		When("the flags are not provided", func() {
+			It("should return the first 20 users", func() {
+				_, err := runUser("search")
+				Ω(err).Should(BeNil())
+
+				requests := service.getSearchRequests()
+				Ω(requests).Should(HaveLen(1))
+				Ω(requests[0].First).Should(Equal(int32(20)))
+				Ω(requests[0].Emails).Should(BeEmpty())
+				Ω(requests[0].SortingOptions).Should(BeEmpty())
+				Ω(requests[0].IncludeDeleted).Should(BeFalse())
+			})
+		})
+
+		When("the table output is requested", func() {
+			It("should print a row per user", func() {
+				output, err := runUser("search", "--first", "2", "-o", "table")
+				Ω(err).Should(BeNil())
+
+				lines := strings.Split(strings.TrimSpace(output), "\n")
+				Ω(lines).Should(HaveLen(3))
+				Ω(strings.Fields(lines[0])).Should(Equal([]string{"EMAIL", "CURSOR"}))
+				Ω(strings.Fields(lines[1])).Should(Equal([]string{"a@test.com", "a@test.com"}))
+				Ω(strings.Fields(lines[2])).Should(Equal([]string{"b@test.com", "b@test.com"}))
+			})
+		})
+
+		When("the JSON output is requested", func() {
+			It("should print the page information along with the users", func() {
+				output, err := runUser("search", "--first", "2", "-o", "json")
+				Ω(err).Should(BeNil())
+
+				result := decode(output)
+				Ω(result["totalCount"]).Should(Equal(float64(3)))
+				Ω(result["hasPreviousPage"]).Should(BeFalse())
+				Ω(result["hasNextPage"]).Should(BeTrue())
+				Ω(result["users"]).Should(HaveLen(2))
+			})
+		})
+
+		When("the sorting option is invalid", func() {
+			It("should return error without calling the service", func() {
+				for _, sorting := range []string{"email:sideways", ":asc", " "} {
+					_, err := runUser("search", "--sort", sorting)
+					Ω(err).Should(MatchError(ContainSubstring("must be field[:asc|desc]")))
+				}
+
+				Ω(service.getSearchRequests()).Should(BeEmpty())
+			})
+		})
+	})
+})
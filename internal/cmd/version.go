@@ -13,10 +13,13 @@ func newVersionCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
 		Short: "Get User CLI version",
-		Run: func(cmd *cobra.Command, args []string) {
-			util.PrintInfo("User CLI\n")
-			util.PrintInfo(fmt.Sprintf("Copyright (C) %d, Micro Business Ltd.\n", time.Now().Year()))
-			util.PrintYAML(util.GetVersion())
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if outputFormat, _ := getOutputFormat(cmd); outputFormat == outputFormatTable {
+				util.PrintInfo("User CLI\n")
+				util.PrintInfo(fmt.Sprintf("Copyright (C) %d, Micro Business Ltd.\n", time.Now().Year()))
+			}
+
+			return printOutput(cmd, util.GetVersion())
 		},
 	}
 }
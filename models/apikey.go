@@ -0,0 +1,48 @@
+// Package models defines the different object models used in User
+package models
+
+import "time"
+
+// APIKeyScope defines the operations an API key is allowed to call
+type APIKeyScope string
+
+const (
+	// APIKeyScopeRead allows the API key to read the user that owns it
+	APIKeyScopeRead APIKeyScope = "Read"
+
+	// APIKeyScopeWrite allows the API key to change the user that owns it
+	APIKeyScopeWrite APIKeyScope = "Write"
+)
+
+// APIKey defines the key a service account calls the service with on behalf of the user that owns it. Only the hash
+// of the key is persisted, the key itself is only returned once when it is created.
+type APIKey struct {
+	KeyID     string
+	UserID    string
+	Name      string
+	Scopes    []APIKeyScope
+	KeyHash   string
+	CreatedAt time.Time
+	ExpiresAt *time.Time
+	RevokedAt *time.Time
+}
+
+// HasScope indicates whether the API key is allowed the scope
+// scope: Mandatory. The scope to check
+// Returns true if the API key is allowed the scope
+func (apiKey APIKey) HasScope(scope APIKeyScope) bool {
+	for _, allowedScope := range apiKey.Scopes {
+		if allowedScope == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsActive indicates whether the API key is neither revoked nor expired
+// now: Mandatory. The current time
+// Returns true if the API key can still be used
+func (apiKey APIKey) IsActive(now time.Time) bool {
+	return apiKey.RevokedAt == nil && (apiKey.ExpiresAt == nil || now.Before(*apiKey.ExpiresAt))
+}
@@ -0,0 +1,60 @@
+// Package models defines the different object models used in User
+package models
+
+import "time"
+
+// AuditOperation defines the mutating operation an audit record is recorded for
+type AuditOperation string
+
+const (
+	// AuditOperationCreate indicates the user is created
+	AuditOperationCreate AuditOperation = "Create"
+
+	// AuditOperationUpdate indicates the user is updated
+	AuditOperationUpdate AuditOperation = "Update"
+
+	// AuditOperationDelete indicates the user is deleted
+	AuditOperationDelete AuditOperation = "Delete"
+
+	// AuditOperationRestore indicates the soft deleted user is restored
+	AuditOperationRestore AuditOperation = "Restore"
+
+	// AuditOperationErase indicates the user is erased on their request, the personal data of the user is removed
+	// from the audit records once it is recorded
+	AuditOperationErase AuditOperation = "Erase"
+
+	// AuditOperationChangeEmail indicates the email address of the user is changed, it is recorded under the previous
+	// email address so it closes the audit trail kept under it
+	AuditOperationChangeEmail AuditOperation = "ChangeEmail"
+)
+
+// AuditChange defines the change of a single user field made by a mutating operation. The values are JSON encoded
+// and empty if the field did not exist before or after the operation.
+type AuditChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// AuditRecord defines who made a mutating operation on which user and when, along with what it changed
+type AuditRecord struct {
+	RecordID     string
+	Operation    AuditOperation
+	ActorSubject string
+	ActorEmail   string
+	Email        string
+	Before       *User
+	After        *User
+	Changes      []AuditChange
+	CreatedAt    time.Time
+}
+
+// AuditRecordFilter defines the criteria the audit records are listed by. The empty criteria are ignored.
+type AuditRecordFilter struct {
+	Email         string
+	ActorEmail    string
+	Operations    []AuditOperation
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Limit         int
+}
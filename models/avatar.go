@@ -0,0 +1,13 @@
+// Package models defines the different object models used in User
+package models
+
+// Avatar contains where the avatar image of a user is stored. The image itself is kept in the object storage rather
+// than on the user.
+type Avatar struct {
+	// ObjectKey is the key the image is stored with in the avatar bucket
+	ObjectKey string
+
+	// ContentHash is the hex encoded SHA-256 hash of the image, so the clients can tell whether the image changed
+	// without downloading it
+	ContentHash string
+}
@@ -0,0 +1,21 @@
+// Package models defines the different object models used in User
+package models
+
+import "time"
+
+// Consent defines the acceptance of a version of a policy, e.g. the terms of service or the privacy policy, by a
+// user. The consents are only ever added, so accepting a new version of a policy keeps the acceptance of the older
+// versions.
+type Consent struct {
+	ConsentID  string
+	Email      string
+	Policy     string
+	Version    string
+	AcceptedAt time.Time
+}
+
+// PolicyVersion defines a version of a policy the users must accept
+type PolicyVersion struct {
+	Policy  string
+	Version string
+}
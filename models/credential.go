@@ -0,0 +1,12 @@
+// Package models defines the different object models used in User
+package models
+
+import "time"
+
+// PasswordCredential defines the password a user can sign in with, persisted apart from the user so the hash is never
+// read or returned along with the user
+type PasswordCredential struct {
+	Email        string
+	PasswordHash string
+	UpdatedAt    time.Time
+}
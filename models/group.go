@@ -0,0 +1,15 @@
+// Package models defines the different object models used in User
+package models
+
+import "time"
+
+// Group defines a named set of users, e.g. a team, the admins can search the users by and grant the admin role to.
+// The members are identified by their email addresses and the names of the groups are unique.
+type Group struct {
+	GroupID     string
+	Name        string
+	Description string
+	Members     []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
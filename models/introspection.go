@@ -0,0 +1,18 @@
+// Package models defines the different object models used in User
+package models
+
+// ConfigurationOption defines the value a running replica loaded for a single configuration option
+type ConfigurationOption struct {
+	Name     string
+	Value    string
+	Default  string
+	Redacted bool
+	Error    string
+}
+
+// Feature defines whether an optional feature is enabled in a running replica
+type Feature struct {
+	Name    string
+	Enabled bool
+	Detail  string
+}
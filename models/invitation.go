@@ -0,0 +1,17 @@
+// Package models defines the different object models used in User
+package models
+
+import "time"
+
+// Invitation defines the invitation an admin sent to an email address to join as a new user. Only the hash of the
+// token the invitation is accepted with is persisted, and an email address has a single invitation at a time, so
+// inviting the email address again replaces the invitation sent before.
+type Invitation struct {
+	InvitationID string
+	Email        string
+	InvitedBy    string
+	TokenHash    string
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+	AcceptedAt   *time.Time
+}
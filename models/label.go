@@ -0,0 +1,225 @@
+// Package models defines the different object models used in User
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LabelSelectorOperator defines the operators the label selector requirements match the labels with
+type LabelSelectorOperator string
+
+const (
+	// LabelSelectorOperatorIn matches the users that have the label set to any of the values. The equality
+	// requirements, e.g. region=eu, are the In requirements with a single value.
+	LabelSelectorOperatorIn LabelSelectorOperator = "in"
+
+	// LabelSelectorOperatorNotIn matches the users that do not have the label set to any of the values, including the
+	// users that do not have the label. The inequality requirements, e.g. region!=eu, are the NotIn requirements with
+	// a single value.
+	LabelSelectorOperatorNotIn LabelSelectorOperator = "notin"
+
+	// LabelSelectorOperatorExists matches the users that have the label, whatever its value is
+	LabelSelectorOperatorExists LabelSelectorOperator = "exists"
+
+	// LabelSelectorOperatorDoesNotExist matches the users that do not have the label
+	LabelSelectorOperatorDoesNotExist LabelSelectorOperator = "!"
+)
+
+const (
+	// MaxLabels is the maximum number of the labels a user can have
+	MaxLabels = 64
+
+	// MaxLabelKeyLength is the maximum length of the label keys
+	MaxLabelKeyLength = 63
+
+	// MaxLabelValueLength is the maximum length of the label values
+	MaxLabelValueLength = 63
+)
+
+// LabelSelectorRequirement is a single requirement of the label selector, the users match the selector if they match
+// all of its requirements
+type LabelSelectorRequirement struct {
+	Key      string
+	Operator LabelSelectorOperator
+
+	// Values are the values the label is matched against, only used by the In and NotIn operators
+	Values []string
+}
+
+// TestLabelPattern is the format of the labels the test users can be tagged with
+var TestLabelPattern = regexp.MustCompile(`^[A-Za-z0-9._]+(-[A-Za-z0-9._]+)*$`)
+
+// labelKeyPattern matches the keys of the user labels, e.g. region or billing_plan. The keys can not contain dots, as
+// they are persisted as the field names of the documents.
+var labelKeyPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_-]*[A-Za-z0-9])?$`)
+
+// labelValuePattern matches the values of the user labels, e.g. eu-west or v1.2, the values can be empty
+var labelValuePattern = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9_.-]*[A-Za-z0-9])?)?$`)
+
+// labelSetRequirementPattern matches the set-based requirements, e.g. region in (eu, us) or plan notin (free)
+var labelSetRequirementPattern = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+
+// GetTestLabelEmailPattern returns the regular expression that matches the email addresses of the users tagged
+// with the given test label. The test users are tagged by adding the label to the sub-address of their email address,
+// optionally followed by a dash and a suffix, e.g. jane.doe+loadgen-20210101-1@example.com has the
+// loadgen-20210101 label and every label that is a dash separated prefix of it.
+// label: Mandatory. The test label that matches TestLabelPattern
+// Returns the regular expression compatible with both the MongoDB and the PostgreSQL regular expressions
+func GetTestLabelEmailPattern(label string) string {
+	return `\+` + regexp.QuoteMeta(label) + `[-@]`
+}
+
+// ValidateLabels validates the labels of the user
+// labels: Optional. The labels of the user
+// Returns error if there are too many labels or any of the keys or values is invalid
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > MaxLabels {
+		return fmt.Errorf("the user can have at most %d labels", MaxLabels)
+	}
+
+	// The keys are sorted so the same labels always fail with the same error
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := validateLabelKey(key); err != nil {
+			return err
+		}
+
+		if err := validateLabelValue(labels[key]); err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+	}
+
+	return nil
+}
+
+// ParseLabelSelector parses the label selector, the comma separated list of the requirements the users must all
+// match. The requirements are either equality-based, e.g. region=eu, region==eu or plan!=free, set-based, e.g.
+// region in (eu, us) or plan notin (free, trial), or check the label exists, e.g. plan, or does not exist, e.g. !plan.
+// selector: Optional. The label selector
+// Returns either the requirements of the selector, nil if the selector is empty, or error if the selector is invalid
+func ParseLabelSelector(selector string) ([]LabelSelectorRequirement, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+
+	requirements := []LabelSelectorRequirement{}
+	for _, item := range splitLabelSelector(selector) {
+		requirement, err := parseLabelSelectorRequirement(strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+
+		requirements = append(requirements, requirement)
+	}
+
+	return requirements, nil
+}
+
+// splitLabelSelector splits the selector into its requirements, the commas separating the values of the set-based
+// requirements are kept
+func splitLabelSelector(selector string) []string {
+	items := []string{}
+	depth := 0
+	start := 0
+
+	for index, character := range selector {
+		switch character {
+		case '(':
+			depth++
+
+		case ')':
+			depth--
+
+		case ',':
+			if depth == 0 {
+				items = append(items, selector[start:index])
+				start = index + 1
+			}
+		}
+	}
+
+	return append(items, selector[start:])
+}
+
+func parseLabelSelectorRequirement(item string) (LabelSelectorRequirement, error) {
+	if item == "" {
+		return LabelSelectorRequirement{}, fmt.Errorf("the label selector contains an empty requirement")
+	}
+
+	if matches := labelSetRequirementPattern.FindStringSubmatch(item); matches != nil {
+		if strings.TrimSpace(matches[3]) == "" {
+			return LabelSelectorRequirement{}, fmt.Errorf("%s: the set of the values can not be empty", item)
+		}
+
+		return newLabelSelectorRequirement(matches[1], LabelSelectorOperator(matches[2]), strings.Split(matches[3], ",")...)
+	}
+
+	if index := strings.Index(item, "!="); index >= 0 {
+		return newLabelSelectorRequirement(item[:index], LabelSelectorOperatorNotIn, item[index+2:])
+	}
+
+	if index := strings.Index(item, "=="); index >= 0 {
+		return newLabelSelectorRequirement(item[:index], LabelSelectorOperatorIn, item[index+2:])
+	}
+
+	if index := strings.Index(item, "="); index >= 0 {
+		return newLabelSelectorRequirement(item[:index], LabelSelectorOperatorIn, item[index+1:])
+	}
+
+	if strings.HasPrefix(item, "!") {
+		return newLabelSelectorRequirement(item[1:], LabelSelectorOperatorDoesNotExist)
+	}
+
+	return newLabelSelectorRequirement(item, LabelSelectorOperatorExists)
+}
+
+func newLabelSelectorRequirement(key string, operator LabelSelectorOperator, values ...string) (LabelSelectorRequirement, error) {
+	key = strings.TrimSpace(key)
+	if err := validateLabelKey(key); err != nil {
+		return LabelSelectorRequirement{}, err
+	}
+
+	for index, value := range values {
+		values[index] = strings.TrimSpace(value)
+		if err := validateLabelValue(values[index]); err != nil {
+			return LabelSelectorRequirement{}, fmt.Errorf("%s: %v", key, err)
+		}
+	}
+
+	return LabelSelectorRequirement{
+		Key:      key,
+		Operator: operator,
+		Values:   values,
+	}, nil
+}
+
+func validateLabelKey(key string) error {
+	if len(key) == 0 || len(key) > MaxLabelKeyLength || !labelKeyPattern.MatchString(key) {
+		return fmt.Errorf(
+			"%q is not a valid label key, it must be 1 to %d alphanumeric characters, dashes or underscores and start and end with an alphanumeric character",
+			key,
+			MaxLabelKeyLength)
+	}
+
+	return nil
+}
+
+func validateLabelValue(value string) error {
+	if len(value) > MaxLabelValueLength || !labelValuePattern.MatchString(value) {
+		return fmt.Errorf(
+			"%q is not a valid label value, it must be up to %d alphanumeric characters, dashes, underscores or dots and start and end with an alphanumeric character",
+			value,
+			MaxLabelValueLength)
+	}
+
+	return nil
+}
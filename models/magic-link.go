@@ -0,0 +1,18 @@
+// Package models defines the different object models used in User
+package models
+
+import "time"
+
+// MagicLink defines the single-use link a user signs in with instead of a password. Only the hashes of the token and
+// of the IP address and the device the link is bound to are persisted, and an email address has a single magic link
+// at a time, so issuing a new magic link replaces the one issued before.
+type MagicLink struct {
+	MagicLinkID   string
+	Email         string
+	TokenHash     string
+	IPAddressHash string
+	DeviceIDHash  string
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+	RedeemedAt    *time.Time
+}
@@ -0,0 +1,45 @@
+// Package models defines the different object models used in User
+package models
+
+import "time"
+
+// MFAMethodType defines the kind of the second factor a user enrolled in
+type MFAMethodType string
+
+const (
+	// MFAMethodTypeTOTP is a time based one time password generator. The secret itself is kept by the auth gateway,
+	// only the reference to it is persisted.
+	MFAMethodTypeTOTP MFAMethodType = "TOTP"
+
+	// MFAMethodTypeWebAuthn is a WebAuthn authenticator identified by its credential ID
+	MFAMethodTypeWebAuthn MFAMethodType = "WebAuthn"
+
+	// MFAMethodTypeRecoveryCodes is the set of the single use recovery codes, only their hashes are persisted. A user
+	// has at most one set of the recovery codes.
+	MFAMethodTypeRecoveryCodes MFAMethodType = "RecoveryCodes"
+)
+
+// MFAMethod defines the second factor a user enrolled in. Only the field that matches the type of the method is set.
+type MFAMethod struct {
+	MethodID             string
+	Type                 MFAMethodType
+	Name                 string
+	TOTPSecretRef        string   `json:",omitempty"`
+	WebAuthnCredentialID string   `json:",omitempty"`
+	RecoveryCodeHashes   []string `json:",omitempty"`
+	EnrolledAt           time.Time
+}
+
+// CredentialKey returns the key that identifies the credential of the method, so the same credential can not be
+// enrolled twice by the same user
+// Returns the key of the credential
+func (method MFAMethod) CredentialKey() string {
+	switch method.Type {
+	case MFAMethodTypeTOTP:
+		return string(method.Type) + ":" + method.TOTPSecretRef
+	case MFAMethodTypeWebAuthn:
+		return string(method.Type) + ":" + method.WebAuthnCredentialID
+	default:
+		return string(method.Type)
+	}
+}
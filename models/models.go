@@ -1,6 +1,8 @@
 // Package models defines the different object models used in User
 package models
 
+import "time"
+
 type contextKey string
 
 func (c contextKey) String() string {
@@ -14,17 +16,123 @@ var (
 
 // ParsedToken contains details that are encoded in the received JWT token
 type ParsedToken struct {
-	Email string
+	Subject string
+	Email   string
+
+	// Service is the identity of the internal service the token is issued to, empty for the tokens issued to the users
+	Service string
+
+	// TenantID is the tenant the caller belongs to in the multi-tenant mode, the operations only see and change the
+	// users of the tenant. It is empty in the single-tenant mode and for the callers not scoped to a tenant.
+	TenantID string
+
+	// CrossTenantReads indicates the token carries the scope that allows reading the users of all the tenants. The
+	// users are still only changed within the tenant of the caller.
+	CrossTenantReads bool
+
+	// OwnerOnly indicates the endpoint only allows the caller to access its own user, while the request names the user
+	// by its ID so the ownership could not be verified before the user is read. The users of the others are reported
+	// as not found.
+	OwnerOnly bool
 }
 
 // User defines the user object
 type User struct {
+	// ID is the stable identifier of the user, e.g. the MongoDB document ID, the repository assigns when the user is
+	// created. Unlike the email address it never changes, so the other services refer to the user by it. It is set
+	// by the repository, so the values of the updates are ignored.
+	ID string `json:",omitempty"`
+
+	// Memberships are the tenants the user is a member of. They are only changed through the dedicated tenant
+	// membership operations, so updating the user leaves them as they are.
+	Memberships []TenantMembership
+
+	// DataResidency is the region the user and everything about the user must be persisted in, e.g. "eu". The
+	// default region is used if empty. The region of an existing user cannot be changed.
+	DataResidency string
+
+	// EmailVerified indicates the user proved owning the email address by returning the token sent in the
+	// verification email. It is only changed through the dedicated email verification operations, so updating the
+	// user leaves it as it is, like the verification token fields below. It is omitted from JSON when false, so the
+	// audit records of the users that never verified their email address do not report it as a change.
+	EmailVerified bool `json:",omitempty"`
+
+	// EmailVerificationTokenHash is the SHA-256 hash of the token sent in the latest verification email, empty if
+	// no verification is pending. The token itself is never persisted.
+	EmailVerificationTokenHash string
+
+	// EmailVerificationExpiresAt is the time the pending verification token expires at, nil if no verification is
+	// pending
+	EmailVerificationExpiresAt *time.Time
+
+	// FailedLoginAttempts is the number of the failed login attempts since the last successful one or since an admin
+	// unlocked the user. It is only changed through the dedicated login attempt operations, like LockedAt below.
+	FailedLoginAttempts int `json:",omitempty"`
+
+	// LockedAt is the time the user got locked at after too many failed login attempts, nil if the user is not
+	// locked. A locked user stays locked until an admin unlocks it.
+	LockedAt *time.Time `json:",omitempty"`
+
+	// MFAMethods are the second factors the user enrolled in. They are only changed through the dedicated MFA
+	// operations, so updating the user leaves them as they are. They are omitted from JSON when empty, so the audit
+	// records of the users that never enrolled do not report them as a change.
+	MFAMethods []MFAMethod `json:",omitempty"`
+
+	// Labels are the free-form attributes the users can be searched by with a label selector, e.g. region=eu or
+	// plan in (pro, enterprise). Updating the user replaces all of its labels.
+	Labels map[string]string `json:",omitempty"`
+
+	// Avatar is where the avatar image of the user is stored, nil if the user has no avatar. It is only changed
+	// through the dedicated avatar operation, so updating the user leaves it as it is.
+	Avatar *Avatar `json:",omitempty"`
+
+	// CreatedAt and UpdatedAt are the times the user was created and last changed at, and CreatedBy and UpdatedBy are
+	// the callers that made those changes, either the email address of a user or the identity of an internal service.
+	// They are set by the repository, so the values of the updates are ignored. UpdatedAt is nil for the users that
+	// have not changed since it is recorded. The failed and successful login attempts are not considered changes.
+	CreatedAt *time.Time `json:",omitempty"`
+	UpdatedAt *time.Time `json:",omitempty"`
+	CreatedBy string     `json:",omitempty"`
+	UpdatedBy string     `json:",omitempty"`
+
+	// TenantID is the tenant the user belongs to in the multi-tenant mode, taken from the token of the caller that
+	// created the user. It is set by the repository and never changes. It is empty for the users created in the
+	// single-tenant mode.
+	TenantID string `json:",omitempty"`
 }
 
 // UserWithCursor implements the pair of the user with a cursor that determines the
 // location of the tennat in the repository.
 type UserWithCursor struct {
-	UserID string
-	User   User
-	Cursor string
+	UserID    string
+	Email     string
+	User      User
+	Cursor    string
+	CreatedAt time.Time
+	DeletedAt *time.Time
+}
+
+// SortingDirection defines the direction the search result should be sorted in
+type SortingDirection int
+
+const (
+	// Ascending sorts the search result in ascending order
+	Ascending SortingDirection = iota
+
+	// Descending sorts the search result in descending order
+	Descending
+)
+
+// SortingOptionPair defines the field name and the direction the search result should be sorted by
+type SortingOptionPair struct {
+	Name      string
+	Direction SortingDirection
+}
+
+// Pagination defines the Relay style pagination details used to page through the search result
+type Pagination struct {
+	After  *string
+	First  *int
+	Before *string
+	Last   *int
 }
@@ -0,0 +1,25 @@
+// Package models defines the different object models used in User
+package models
+
+import "time"
+
+// PendingEvent defines a user lifecycle event that is handed to the message broker client but the broker has not
+// confirmed receiving yet
+type PendingEvent struct {
+	Sequence   int64
+	Subject    string
+	Email      string
+	OccurredAt time.Time
+}
+
+// OutboxLag defines how far behind the publisher of the user lifecycle events is, so the consumers being stale can be
+// told apart from the events not being delivered to the message broker yet
+type OutboxLag struct {
+	Broker                string
+	Connected             bool
+	PendingEvents         int64
+	PendingBytes          int64
+	OldestPendingEventAt  *time.Time
+	LastConfirmedAt       *time.Time
+	LastConfirmationError string
+}
@@ -0,0 +1,19 @@
+// Package models defines the different object models used in User
+package models
+
+const (
+	// PreferenceTheme is the key of the user interface theme the user prefers, one of light, dark or system
+	PreferenceTheme = "theme"
+
+	// PreferenceLanguage is the key of the BCP 47 tag of the language the user prefers, e.g. en or en-AU
+	PreferenceLanguage = "language"
+
+	// PreferenceEmailNotifications is the key of whether the user receives the notifications by email, true or false
+	PreferenceEmailNotifications = "emailNotifications"
+
+	// PreferencePushNotifications is the key of whether the user receives the push notifications, true or false
+	PreferencePushNotifications = "pushNotifications"
+
+	// PreferenceWebhookNotifications is the key of whether the webhooks of the user are called, true or false
+	PreferenceWebhookNotifications = "webhookNotifications"
+)
@@ -0,0 +1,71 @@
+// Package models defines the different object models used in User
+package models
+
+import (
+	"fmt"
+	"sort"
+)
+
+// userFieldProjections copies the user fields the callers can request, keyed by their names in the GRPC contract, from
+// the user to its projection. The locked field is derived from the time the user got locked at.
+var userFieldProjections = map[string]func(projection *User, user *User){
+	"memberships":         func(projection *User, user *User) { projection.Memberships = user.Memberships },
+	"dataResidency":       func(projection *User, user *User) { projection.DataResidency = user.DataResidency },
+	"emailVerified":       func(projection *User, user *User) { projection.EmailVerified = user.EmailVerified },
+	"locked":              func(projection *User, user *User) { projection.LockedAt = user.LockedAt },
+	"lockedAt":            func(projection *User, user *User) { projection.LockedAt = user.LockedAt },
+	"failedLoginAttempts": func(projection *User, user *User) { projection.FailedLoginAttempts = user.FailedLoginAttempts },
+	"labels":              func(projection *User, user *User) { projection.Labels = user.Labels },
+	"createdAt":           func(projection *User, user *User) { projection.CreatedAt = user.CreatedAt },
+	"updatedAt":           func(projection *User, user *User) { projection.UpdatedAt = user.UpdatedAt },
+	"createdBy":           func(projection *User, user *User) { projection.CreatedBy = user.CreatedBy },
+	"updatedBy":           func(projection *User, user *User) { projection.UpdatedBy = user.UpdatedBy },
+}
+
+// GetUserProjectionFields returns the names of the user fields the callers can request, sorted by name
+// Returns the names of the fields as they are named in the GRPC contract
+func GetUserProjectionFields() []string {
+	fields := make([]string, 0, len(userFieldProjections))
+	for field := range userFieldProjections {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	return fields
+}
+
+// ProjectUser returns the user with only the requested fields set, so the callers listing many users only receive
+// the fields they show
+// user: Mandatory. The user to project
+// fields: Optional. The names of the requested fields as they are named in the GRPC contract, the whole user is
+// returned if empty
+// Returns the projection of the user
+func ProjectUser(user User, fields []string) User {
+	if len(fields) == 0 {
+		return user
+	}
+
+	// The ID identifies the projected user, so it is returned whatever fields are requested
+	projection := User{ID: user.ID}
+	for _, field := range fields {
+		if project, ok := userFieldProjections[field]; ok {
+			project(&projection, &user)
+		}
+	}
+
+	return projection
+}
+
+// IsUserProjectionField validates the value is the name of a user field the callers can request
+// value: Mandatory. The name of the field
+// Returns error if the callers can not request the field
+func IsUserProjectionField(value interface{}) error {
+	if field, ok := value.(string); ok {
+		if _, ok = userFieldProjections[field]; !ok {
+			return fmt.Errorf("%s is not a user field", field)
+		}
+	}
+
+	return nil
+}
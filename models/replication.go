@@ -0,0 +1,40 @@
+// Package models defines the different object models used in User
+package models
+
+import "time"
+
+// ReplicationHeartbeat defines the change cursor written to the primary database and received by the standby database
+// through the replication. The sequence is incremented every time the heartbeat is written.
+type ReplicationHeartbeat struct {
+	Sequence  int64
+	WrittenAt time.Time
+}
+
+// ReplicationStatus defines how far behind the primary database the standby database is, so the readiness to fail over
+// to the standby database can be measured
+type ReplicationStatus struct {
+	// Configured indicates whether a standby database is configured, the other fields are not set if it is not
+	Configured bool
+
+	// Primary is the latest heartbeat written to the primary database
+	Primary *ReplicationHeartbeat
+
+	// Standby is the latest heartbeat the standby database received
+	Standby *ReplicationHeartbeat
+
+	// LagChanges is the number of the heartbeats the standby database has not received yet
+	LagChanges int64
+
+	// Lag is how long ago the latest heartbeat the standby database received was written, zero if the standby
+	// database received the latest heartbeat written to the primary database
+	Lag time.Duration
+
+	// FailoverReady indicates the standby database is reachable and not further behind than the maximum lag
+	FailoverReady bool
+
+	// Error is the reason the heartbeats could not be read, empty if they were read successfully
+	Error string
+
+	// CheckedAt is when the heartbeats were read
+	CheckedAt time.Time
+}
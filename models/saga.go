@@ -0,0 +1,63 @@
+// Package models defines the different object models used in User
+package models
+
+import "time"
+
+// SagaStatus defines the status of a saga
+type SagaStatus string
+
+const (
+	// SagaRunning indicates the saga steps are being executed
+	SagaRunning SagaStatus = "Running"
+
+	// SagaCompleted indicates all the saga steps are executed successfully
+	SagaCompleted SagaStatus = "Completed"
+
+	// SagaCompensating indicates one of the saga steps failed and the completed steps are being compensated
+	SagaCompensating SagaStatus = "Compensating"
+
+	// SagaCompensated indicates one of the saga steps failed and all the completed steps are compensated
+	SagaCompensated SagaStatus = "Compensated"
+
+	// SagaFailed indicates one of the saga steps failed and at least one of the completed steps could not be compensated
+	SagaFailed SagaStatus = "Failed"
+)
+
+// SagaStepStatus defines the status of a single saga step
+type SagaStepStatus string
+
+const (
+	// SagaStepPending indicates the step is not executed yet
+	SagaStepPending SagaStepStatus = "Pending"
+
+	// SagaStepCompleted indicates the step is executed successfully
+	SagaStepCompleted SagaStepStatus = "Completed"
+
+	// SagaStepFailed indicates the step failed after exhausting all the attempts
+	SagaStepFailed SagaStepStatus = "Failed"
+
+	// SagaStepCompensated indicates the step is compensated successfully
+	SagaStepCompensated SagaStepStatus = "Compensated"
+
+	// SagaStepCompensationFailed indicates the step could not be compensated after exhausting all the attempts
+	SagaStepCompensationFailed SagaStepStatus = "CompensationFailed"
+)
+
+// SagaStepState defines the persisted progress of a single saga step
+type SagaStepState struct {
+	Name     string
+	Status   SagaStepStatus
+	Attempts int
+	Error    string
+}
+
+// SagaState defines the persisted progress of a saga
+type SagaState struct {
+	SagaID    string
+	Name      string
+	Status    SagaStatus
+	Steps     []SagaStepState
+	Error     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,19 @@
+// Package models defines the different object models used in User
+package models
+
+import "time"
+
+// Session defines a login of a user on a device the auth gateway registers, so the user can see the active logins
+// and revoke them. The session expires unless the auth gateway registers it again in time, and a revoked session is
+// kept until it expires, so it can not be registered again.
+type Session struct {
+	SessionID  string
+	Email      string
+	DeviceName string
+	UserAgent  string
+	IPAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+}
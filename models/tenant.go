@@ -0,0 +1,22 @@
+// Package models defines the different object models used in User
+package models
+
+import "time"
+
+const (
+	// TenantRoleOwner is the role of the members that own the tenant
+	TenantRoleOwner = "owner"
+
+	// TenantRoleAdmin is the role of the members that administer the tenant
+	TenantRoleAdmin = "admin"
+
+	// TenantRoleMember is the role of the regular members of the tenant
+	TenantRoleMember = "member"
+)
+
+// TenantMembership defines the membership of the user in a tenant managed by the tenant service
+type TenantMembership struct {
+	TenantID string
+	Role     string
+	JoinedAt time.Time
+}
@@ -2,11 +2,46 @@
 package models
 
 import (
+	"regexp"
+
 	validation "github.com/go-ozzo/ozzo-validation"
 )
 
+// dataResidencyPattern matches the region names, e.g. eu or us-east
+var dataResidencyPattern = regexp.MustCompile("^[a-z0-9]+(-[a-z0-9]+)*$")
+
 // Validate validates the User and return error if the validation failes
 // Returns error if validation failes
 func (val User) Validate() error {
-	return validation.ValidateStruct(&val)
+	return validation.ValidateStruct(&val,
+		// DataResidency is optional, but must be a lower case region name if provided
+		validation.Field(&val.DataResidency, validation.Length(1, 32), validation.Match(dataResidencyPattern)),
+
+		// Labels are optional, but their keys and values must be valid
+		validation.Field(&val.Labels, validation.By(func(value interface{}) error {
+			return ValidateLabels(val.Labels)
+		})),
+	)
+}
+
+// Validate validates the SortingOptionPair and return error if the validation failes
+// Returns error if validation failes
+func (val SortingOptionPair) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Name is required
+		validation.Field(&val.Name, validation.Required),
+		// Direction must be one of the supported sorting directions
+		validation.Field(&val.Direction, validation.In(Ascending, Descending)),
+	)
+}
+
+// Validate validates the Pagination and return error if the validation failes
+// Returns error if validation failes
+func (val Pagination) Validate() error {
+	return validation.ValidateStruct(&val,
+		// First cannot be negative
+		validation.Field(&val.First, validation.Min(0)),
+		// Last cannot be negative
+		validation.Field(&val.Last, validation.Min(0)),
+	)
 }
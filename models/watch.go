@@ -0,0 +1,49 @@
+// Package models defines the different object models used in User
+package models
+
+import "time"
+
+// UserChangeType defines the kind of the change a watcher is notified of
+type UserChangeType string
+
+const (
+	// UserChangeCreated indicates the user is created
+	UserChangeCreated UserChangeType = "Created"
+
+	// UserChangeUpdated indicates the user is updated
+	UserChangeUpdated UserChangeType = "Updated"
+
+	// UserChangeDeleted indicates the user is deleted
+	UserChangeDeleted UserChangeType = "Deleted"
+
+	// UserChangeRestored indicates the soft deleted user is restored
+	UserChangeRestored UserChangeType = "Restored"
+)
+
+// UserChange defines the change made to a user the watchers are notified of. The user is only set once the change is
+// sent to a watcher and never for the deleted users.
+type UserChange struct {
+	Type        UserChangeType
+	Email       string
+	Cursor      string
+	OccurredAt  time.Time
+	SoftDeleted bool
+	User        *User
+
+	// ResumeToken is the token a watcher resumes watching after this change with, only set if the changes are read
+	// from the change stream
+	ResumeToken string
+}
+
+// UserChangeFilter defines the changes a watcher is notified of
+type UserChangeFilter struct {
+	// Emails limits the changes to the given users, all the users if empty
+	Emails []string
+
+	// Types limits the changes to the given kinds, all the kinds if empty
+	Types []UserChangeType
+
+	// ResumeToken resumes watching after the change the token is received with, only the changes made after it are
+	// sent. Watching starts from the current change if empty.
+	ResumeToken string
+}
@@ -0,0 +1,71 @@
+// Package models defines the different object models used in User
+package models
+
+import "time"
+
+// WebhookEventType defines the kind of the user event a webhook is called for
+type WebhookEventType string
+
+const (
+	// WebhookEventUserCreated is raised when the user is created
+	WebhookEventUserCreated WebhookEventType = "user.created"
+
+	// WebhookEventUserUpdated is raised when the user is updated
+	WebhookEventUserUpdated WebhookEventType = "user.updated"
+
+	// WebhookEventUserDeleted is raised when the user is deleted
+	WebhookEventUserDeleted WebhookEventType = "user.deleted"
+
+	// WebhookEventUserRestored is raised when the soft deleted user is restored
+	WebhookEventUserRestored WebhookEventType = "user.restored"
+)
+
+// WebhookEventTypes contains all the event types the webhooks can be subscribed to
+var WebhookEventTypes = []WebhookEventType{
+	WebhookEventUserCreated,
+	WebhookEventUserUpdated,
+	WebhookEventUserDeleted,
+	WebhookEventUserRestored,
+}
+
+// WebhookSubscription defines an outbound webhook a user registers to be called for the events of the user. The
+// calls are signed with the secret, so the receiver can verify they are sent by the service.
+type WebhookSubscription struct {
+	SubscriptionID string
+	Email          string
+	URL            string
+	Secret         string
+	EventTypes     []WebhookEventType
+	CreatedAt      time.Time
+}
+
+// WebhookDeliveryStatus defines the status of the delivery of an event to a webhook
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending indicates the webhook is being called or the call is waiting to be retried
+	WebhookDeliveryPending WebhookDeliveryStatus = "Pending"
+
+	// WebhookDeliverySucceeded indicates the webhook responded with a successful status code
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "Succeeded"
+
+	// WebhookDeliveryFailed indicates the webhook call failed after exhausting all the attempts, or with an error
+	// retrying would not help
+	WebhookDeliveryFailed WebhookDeliveryStatus = "Failed"
+)
+
+// WebhookDelivery defines the delivery of an event to a webhook and the result of its last attempt. The delivery is
+// kept until it expires, so the user can check the status of the recent deliveries.
+type WebhookDelivery struct {
+	DeliveryID     string
+	SubscriptionID string
+	Email          string
+	EventType      WebhookEventType
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	StatusCode     int
+	Error          string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ExpiresAt      time.Time
+}
@@ -0,0 +1,264 @@
+// Package client provides the SDK other services use to call the user service over GRPC.
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	natsgo "github.com/nats-io/nats.go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	readUserMethod        = "/user.Service/ReadUser"
+	readUserByEmailMethod = "/user.Service/ReadUserByEmail"
+)
+
+type cacheEntry struct {
+	response  proto.Message
+	expiresAt time.Time
+}
+
+// ResponseCache caches the successful ReadUser and ReadUserByEmail responses per user email address and caller
+// authorization.
+// Entries are invalidated by the user lifecycle events and expire after the configured TTL in case an event is missed.
+type ResponseCache struct {
+	ttl           time.Duration
+	lock          sync.Mutex
+	generation    uint64
+	entries       map[string]map[string]cacheEntry
+	subscriptions []*natsgo.Subscription
+}
+
+// NewResponseCache creates new instance of the ResponseCache
+// ttl: Mandatory. The maximum time a response is served from the cache
+// Returns either the new cache or error if something goes wrong
+func NewResponseCache(ttl time.Duration) (*ResponseCache, error) {
+	if ttl <= 0 {
+		return nil, commonErrors.NewArgumentError("ttl", "ttl must be greater than zero")
+	}
+
+	return &ResponseCache{
+		ttl:     ttl,
+		entries: map[string]map[string]cacheEntry{},
+	}, nil
+}
+
+// SubscribeToEvents subscribes to the user lifecycle events and invalidates the cached user whenever it is created, updated,
+// deleted or restored
+// connection: Mandatory. The NATS connection the user events are published to
+// subjectPrefix: Mandatory. The subject prefix the user service publishes the events with
+// Returns error if something goes wrong
+func (cache *ResponseCache) SubscribeToEvents(connection *natsgo.Conn, subjectPrefix string) error {
+	if connection == nil {
+		return commonErrors.NewArgumentNilError("connection", "connection is required")
+	}
+
+	if strings.Trim(subjectPrefix, " ") == "" {
+		return commonErrors.NewArgumentError("subjectPrefix", "subjectPrefix is required")
+	}
+
+	events := map[string]func() emailEvent{
+		"created":  func() emailEvent { return &userGRPCContract.UserCreatedEvent{} },
+		"updated":  func() emailEvent { return &userGRPCContract.UserUpdatedEvent{} },
+		"deleted":  func() emailEvent { return &userGRPCContract.UserDeletedEvent{} },
+		"restored": func() emailEvent { return &userGRPCContract.UserRestoredEvent{} },
+	}
+
+	for eventName, newEvent := range events {
+		newEvent := newEvent
+		subscription, err := connection.Subscribe(fmt.Sprintf("%s.%s", subjectPrefix, eventName), func(msg *natsgo.Msg) {
+			event := newEvent()
+			if err := proto.Unmarshal(msg.Data, event); err != nil {
+				// The email address is unknown, so drop everything rather than serving stale users
+				cache.Clear()
+
+				return
+			}
+
+			cache.Invalidate(event.GetEmail())
+
+			// The user is no longer found by the email address it had before its email address is changed
+			if changedEvent, ok := event.(previousEmailEvent); ok && changedEvent.GetPreviousEmail() != "" {
+				cache.Invalidate(changedEvent.GetPreviousEmail())
+			}
+		})
+
+		if err != nil {
+			_ = cache.Close()
+
+			return commonErrors.NewUnknownErrorWithError("failed to subscribe to the user events", err)
+		}
+
+		cache.lock.Lock()
+		cache.subscriptions = append(cache.subscriptions, subscription)
+		cache.lock.Unlock()
+	}
+
+	return nil
+}
+
+// Invalidate removes the cached responses of the given user
+// email: Mandatory. The user email address
+func (cache *ResponseCache) Invalidate(email string) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.generation++
+	delete(cache.entries, email)
+}
+
+// Clear removes all the cached responses
+func (cache *ResponseCache) Clear() {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.generation++
+	cache.entries = map[string]map[string]cacheEntry{}
+}
+
+// Close unsubscribes from the user events
+// Returns error if something goes wrong
+func (cache *ResponseCache) Close() error {
+	cache.lock.Lock()
+	subscriptions := cache.subscriptions
+	cache.subscriptions = nil
+	cache.lock.Unlock()
+
+	for _, subscription := range subscriptions {
+		if err := subscription.Unsubscribe(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewCacheUnaryClientInterceptor creates an interceptor that serves the ReadUser and ReadUserByEmail calls from the
+// cache. The responses are cached per caller authorization, so it must run after the auth interceptor. The calls that
+// only read some of the user fields are never cached, as the calls reading the whole user must not be served from
+// them, nor the calls reading the user by its ID, as the events do not tell which ID the cached users have.
+// cache: Mandatory. The cache to store the responses in
+// Returns the new interceptor
+func NewCacheUnaryClientInterceptor(cache *ResponseCache) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption) error {
+		email, cacheable := getCachedEmail(method, req)
+		if !cacheable {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		// The responses of the two methods are different messages, so they are cached apart
+		key := method
+		if md, ok := metadata.FromOutgoingContext(ctx); ok {
+			key += " " + strings.Join(md.Get("authorization"), ",")
+		}
+
+		castedReply := reply.(cachedResponse)
+		if cache.get(email, key, castedReply) {
+			return nil
+		}
+
+		generation := cache.currentGeneration()
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return err
+		}
+
+		if castedReply.GetError() == userGRPCContract.Error_NO_ERROR {
+			cache.set(email, key, castedReply, generation)
+		}
+
+		return nil
+	}
+}
+
+// getCachedEmail returns the email address of the user the call reads, and whether the call is served from the cache
+func getCachedEmail(method string, req interface{}) (string, bool) {
+	switch method {
+	case readUserMethod:
+		request := req.(*userGRPCContract.ReadUserRequest)
+
+		return request.Email, request.Email != "" && len(request.Fields) == 0
+	case readUserByEmailMethod:
+		request := req.(*userGRPCContract.ReadUserByEmailRequest)
+
+		return request.Email, len(request.Fields) == 0
+	default:
+		return "", false
+	}
+}
+
+// cachedResponse is implemented by the responses the cache stores
+type cachedResponse interface {
+	proto.Message
+	GetError() userGRPCContract.Error
+}
+
+// emailEvent is implemented by all the user lifecycle events
+type emailEvent interface {
+	proto.Message
+	GetEmail() string
+}
+
+// previousEmailEvent is implemented by the events that carry the email address the user had before
+type previousEmailEvent interface {
+	GetPreviousEmail() string
+}
+
+func (cache *ResponseCache) currentGeneration() uint64 {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	return cache.generation
+}
+
+func (cache *ResponseCache) get(email, key string, reply proto.Message) bool {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	entry, ok := cache.entries[email][key]
+	if !ok {
+		return false
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		delete(cache.entries[email], key)
+
+		return false
+	}
+
+	proto.Merge(reply, entry.response)
+
+	return true
+}
+
+// set stores the response unless the cache is invalidated after the call started, as the response might be stale
+func (cache *ResponseCache) set(email, key string, response proto.Message, generation uint64) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if cache.generation != generation {
+		return
+	}
+
+	if _, ok := cache.entries[email]; !ok {
+		cache.entries[email] = map[string]cacheEntry{}
+	}
+
+	cache.entries[email][key] = cacheEntry{
+		response:  proto.Clone(response),
+		expiresAt: time.Now().Add(cache.ttl),
+	}
+}
@@ -0,0 +1,164 @@
+package client_test
+
+import (
+	"context"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/pkg/client"
+	"github.com/lucsky/cuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Response Cache Tests", func() {
+	var (
+		ctx         context.Context
+		cache       *client.ResponseCache
+		interceptor grpc.UnaryClientInterceptor
+		email       string
+		calls       int
+		errorCode   userGRPCContract.Error
+	)
+
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		reply.(*userGRPCContract.ReadUserResponse).Error = errorCode
+		reply.(*userGRPCContract.ReadUserResponse).User = &userGRPCContract.User{}
+
+		return nil
+	}
+
+	readUser := func(ctx context.Context) *userGRPCContract.ReadUserResponse {
+		reply := &userGRPCContract.ReadUserResponse{}
+		err := interceptor(ctx, "/user.Service/ReadUser", &userGRPCContract.ReadUserRequest{Email: email}, reply, nil, invoker)
+		Ω(err).Should(BeNil())
+
+		return reply
+	}
+
+	BeforeEach(func() {
+		ctx = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+cuid.New())
+		cache, _ = client.NewResponseCache(time.Minute)
+		interceptor = client.NewCacheUnaryClientInterceptor(cache)
+		email = cuid.New() + "@test.com"
+		calls = 0
+		errorCode = userGRPCContract.Error_NO_ERROR
+	})
+
+	When("ttl is not greater than zero", func() {
+		It("should return ArgumentError", func() {
+			cache, err := client.NewResponseCache(0)
+			Ω(cache).Should(BeNil())
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	When("the same caller reads the same user twice", func() {
+		It("should serve the second call from the cache", func() {
+			Ω(readUser(ctx).Error).Should(Equal(userGRPCContract.Error_NO_ERROR))
+			Ω(readUser(ctx).User).ShouldNot(BeNil())
+			Ω(calls).Should(Equal(1))
+		})
+	})
+
+	When("the same caller only reads some of the user fields", func() {
+		It("should neither serve the call from the cache nor cache the response", func() {
+			request := &userGRPCContract.ReadUserRequest{Email: email, Fields: []string{"labels"}}
+			err := interceptor(ctx, "/user.Service/ReadUser", request, &userGRPCContract.ReadUserResponse{}, nil, invoker)
+			Ω(err).Should(BeNil())
+
+			readUser(ctx)
+			Ω(calls).Should(Equal(2))
+		})
+	})
+
+	When("the same caller reads the same user by its email address twice", func() {
+		It("should serve the second call from the cache apart from the ReadUser calls", func() {
+			byEmailInvoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				calls++
+				reply.(*userGRPCContract.ReadUserByEmailResponse).User = &userGRPCContract.User{}
+
+				return nil
+			}
+
+			for i := 0; i < 2; i++ {
+				reply := &userGRPCContract.ReadUserByEmailResponse{}
+				err := interceptor(ctx, "/user.Service/ReadUserByEmail", &userGRPCContract.ReadUserByEmailRequest{Email: email}, reply, nil, byEmailInvoker)
+				Ω(err).Should(BeNil())
+				Ω(reply.User).ShouldNot(BeNil())
+			}
+
+			readUser(ctx)
+			Ω(calls).Should(Equal(2))
+		})
+	})
+
+	When("the same caller reads a user by its ID", func() {
+		It("should not cache the response", func() {
+			for i := 0; i < 2; i++ {
+				err := interceptor(ctx, "/user.Service/ReadUser", &userGRPCContract.ReadUserRequest{UserID: cuid.New()}, &userGRPCContract.ReadUserResponse{}, nil, invoker)
+				Ω(err).Should(BeNil())
+			}
+
+			Ω(calls).Should(Equal(2))
+		})
+	})
+
+	When("another caller reads the same user", func() {
+		It("should not serve the call from the cache", func() {
+			readUser(ctx)
+			readUser(metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+cuid.New()))
+			Ω(calls).Should(Equal(2))
+		})
+	})
+
+	When("the user is invalidated", func() {
+		It("should call the service again", func() {
+			readUser(ctx)
+			cache.Invalidate(email)
+			readUser(ctx)
+			Ω(calls).Should(Equal(2))
+		})
+	})
+
+	When("the service returns error", func() {
+		It("should not cache the response", func() {
+			errorCode = userGRPCContract.Error_USER_NOT_FOUND
+			readUser(ctx)
+			readUser(ctx)
+			Ω(calls).Should(Equal(2))
+		})
+	})
+
+	When("the entry is expired", func() {
+		It("should call the service again", func() {
+			cache, _ = client.NewResponseCache(time.Millisecond)
+			interceptor = client.NewCacheUnaryClientInterceptor(cache)
+
+			readUser(ctx)
+			time.Sleep(5 * time.Millisecond)
+			readUser(ctx)
+			Ω(calls).Should(Equal(2))
+		})
+	})
+
+	When("other methods are called", func() {
+		It("should not cache the response", func() {
+			for i := 0; i < 2; i++ {
+				err := interceptor(ctx, "/user.Service/UpdateUser", &userGRPCContract.UpdateUserRequest{Email: email}, &userGRPCContract.UpdateUserResponse{}, nil,
+					func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+						calls++
+
+						return nil
+					})
+				Ω(err).Should(BeNil())
+			}
+
+			Ω(calls).Should(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,127 @@
+// Package client provides the SDK other services use to call the user service over GRPC.
+package client
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc"
+)
+
+const (
+	defaultMaxAttempts  = 3
+	defaultRetryBackoff = 100 * time.Millisecond
+)
+
+// Options contains the optional settings used to create the user service client
+type Options struct {
+	// TokenProvider provides the access token attached to every call. No token is attached if not provided
+	TokenProvider TokenProvider
+
+	// MaxAttempts is the maximum number of attempts made for a call failing with UNAVAILABLE. Defaults to 3
+	MaxAttempts int
+
+	// RetryBackoff is the initial delay between the attempts, doubled after each attempt. Defaults to 100ms
+	RetryBackoff time.Duration
+
+	// Timeout is the deadline of the calls made with a context without one, covering all of their attempts. No
+	// deadline is set if zero
+	Timeout time.Duration
+
+	// Tracer is used to trace the calls. Defaults to the opentracing global tracer
+	Tracer opentracing.Tracer
+
+	// Cache serves the ReadUser calls from the cache if provided. The cache is owned by the caller and is not closed with the client
+	Cache *ResponseCache
+
+	// DialOptions are appended to the dial options the client is created with
+	DialOptions []grpc.DialOption
+}
+
+// Client is the user service client that is backed by a GRPC connection. The user operations, e.g. CreateUser, map
+// the requests and the responses from and to the models and return the errors the service reports as Go errors,
+// the rest of the operations are called through the embedded GRPC client.
+type Client struct {
+	userGRPCContract.ServiceClient
+	connection *grpc.ClientConn
+}
+
+// NewClient dials the user service and returns a client with the auth, retry, tracing, metrics and optionally timeout and cache interceptors installed
+// ctx: Mandatory The reference to the context
+// address: Mandatory. The address of the user service
+// options: Optional. The client options, defaults are used if not provided
+// Returns either the new client or error if something goes wrong
+func NewClient(
+	ctx context.Context,
+	address string,
+	options *Options) (*Client, error) {
+	if ctx == nil {
+		return nil, commonErrors.NewArgumentNilError("ctx", "ctx is required")
+	}
+
+	if strings.Trim(address, " ") == "" {
+		return nil, commonErrors.NewArgumentError("address", "address is required")
+	}
+
+	if options == nil {
+		options = &Options{}
+	}
+
+	maxAttempts := options.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+
+	retryBackoff := options.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = defaultRetryBackoff
+	}
+
+	tracer := options.Tracer
+	if tracer == nil {
+		tracer = opentracing.GlobalTracer()
+	}
+
+	interceptors := []grpc.UnaryClientInterceptor{}
+	if options.Timeout > 0 {
+		interceptors = append(interceptors, NewTimeoutUnaryClientInterceptor(options.Timeout))
+	}
+
+	interceptors = append(
+		interceptors,
+		NewMetricsUnaryClientInterceptor(),
+		NewTracingUnaryClientInterceptor(tracer),
+		NewRetryUnaryClientInterceptor(maxAttempts, retryBackoff))
+
+	if options.TokenProvider != nil {
+		interceptors = append(interceptors, NewAuthUnaryClientInterceptor(options.TokenProvider))
+	}
+
+	if options.Cache != nil {
+		interceptors = append(interceptors, NewCacheUnaryClientInterceptor(options.Cache))
+	}
+
+	dialOptions := append(
+		[]grpc.DialOption{grpc.WithChainUnaryInterceptor(interceptors...)},
+		options.DialOptions...)
+
+	connection, err := grpc.DialContext(ctx, address, dialOptions...)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("Failed to connect to the user service", err)
+	}
+
+	return &Client{
+		ServiceClient: userGRPCContract.NewServiceClient(connection),
+		connection:    connection,
+	}, nil
+}
+
+// Close closes the underlying GRPC connection
+// Returns error if something goes wrong
+func (client *Client) Close() error {
+	return client.connection.Close()
+}
@@ -0,0 +1,96 @@
+// Package client provides the SDK other services use to call the user service over GRPC.
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ServiceError is the error the user service failed the operation with
+type ServiceError struct {
+	// Code is the error the service reported, e.g. USER_NOT_FOUND
+	Code userGRPCContract.Error
+
+	// Message is the error message the service reported, meant for the operators rather than the end users
+	Message string
+
+	// Details are the google.rpc error details the service attached to the error, e.g. the field violations of
+	// the invalid requests
+	Details []*anypb.Any
+}
+
+// Error returns message for the ServiceError error type
+// Returns the formatted error message
+func (e ServiceError) Error() string {
+	return fmt.Sprintf("The user service failed with %s. Error message: %s", e.Code, e.Message)
+}
+
+// IsServiceError indicates whether the error is caused by the user service failing the operation with the given code
+// err: The error to check whether it is caused by the user service failing the operation
+// code: The error the user service reported
+// Returns true if the error is caused by the user service failing the operation with the code, otherwise false
+func IsServiceError(err error, code userGRPCContract.Error) bool {
+	var serviceError ServiceError
+
+	return errors.As(err, &serviceError) && serviceError.Code == code
+}
+
+// GetValidationErrors returns the reason every invalid field of the request the user service rejected is invalid for,
+// so the failures can be shown next to the fields of the form the request is made from
+// err: Optional. The error the client returned
+// Returns the validation failures, empty if the error is not caused by an invalid request
+func GetValidationErrors(err error) []*userGRPCContract.ValidationError {
+	var serviceError ServiceError
+	if !errors.As(err, &serviceError) {
+		return nil
+	}
+
+	for _, detail := range serviceError.Details {
+		validationErrors := &userGRPCContract.ValidationErrors{}
+		if detail.MessageIs(validationErrors) && detail.UnmarshalTo(validationErrors) == nil {
+			return validationErrors.Errors
+		}
+	}
+
+	return nil
+}
+
+// newServiceError creates the error the client returns for the error the user service reported. The errors the
+// business services fail with are returned the same way, so they are checked the way the service checks them, e.g.
+// with commonErrors.IsNotFoundError, and the rest are returned as ServiceError.
+// code: Mandatory. The error the service reported
+// message: Optional. The error message the service reported
+// details: Optional. The error details the service reported
+// Returns the error, nil if the service did not report an error
+func newServiceError(code userGRPCContract.Error, message string, details []*anypb.Any) error {
+	if code == userGRPCContract.Error_NO_ERROR {
+		return nil
+	}
+
+	serviceError := ServiceError{
+		Code:    code,
+		Message: message,
+		Details: details,
+	}
+
+	switch code {
+	case userGRPCContract.Error_USER_NOT_FOUND:
+		return commonErrors.NewNotFoundErrorWithError(serviceError)
+
+	case userGRPCContract.Error_USER_ALREADY_EXISTS:
+		return commonErrors.NewAlreadyExistsErrorWithError(serviceError)
+
+	case userGRPCContract.Error_BAD_REQUEST:
+		return commonErrors.NewArgumentErrorWithError("request", message, serviceError)
+
+	case userGRPCContract.Error_UNKNOWN:
+		return commonErrors.NewUnknownErrorWithError(message, serviceError)
+
+	default:
+		return serviceError
+	}
+}
@@ -0,0 +1,192 @@
+// Package client provides the SDK other services use to call the user service over GRPC.
+package client
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TokenProvider returns the access token that is attached to the outgoing calls
+type TokenProvider func(ctx context.Context) (string, error)
+
+var callsCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "user_client_calls_total",
+		Help: "The number of calls made to the user service grouped by the method and the returned status code",
+	},
+	[]string{"method", "code"})
+
+var callsDuration = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "user_client_call_duration_seconds",
+		Help:    "The duration of the calls made to the user service grouped by the method",
+		Buckets: prometheus.DefBuckets,
+	},
+	[]string{"method"})
+
+// NewAuthUnaryClientInterceptor creates an interceptor that attaches the access token as the bearer authorization header
+// tokenProvider: Mandatory. The provider of the access token
+// Returns the new interceptor
+func NewAuthUnaryClientInterceptor(tokenProvider TokenProvider) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption) error {
+		token, err := tokenProvider(ctx)
+		if err != nil {
+			return status.Errorf(codes.Unauthenticated, "Failed to get the access token: %v", err)
+		}
+
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// NewTimeoutUnaryClientInterceptor creates an interceptor that sets the deadline of the calls made with a context
+// without one, so a call and all of its retries fail once the timeout elapses
+// timeout: Mandatory. The time the calls are allowed to take
+// Returns the new interceptor
+func NewTimeoutUnaryClientInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// NewRetryUnaryClientInterceptor creates an interceptor that retries the calls failing with UNAVAILABLE using exponential backoff
+// maxAttempts: Mandatory. The maximum number of attempts, including the first one
+// backoff: Mandatory. The delay before the first retry, doubled after each retry
+// Returns the new interceptor
+func NewRetryUnaryClientInterceptor(maxAttempts int, backoff time.Duration) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption) error {
+		delay := backoff
+
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || attempt >= maxAttempts || status.Code(err) != codes.Unavailable {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(delay):
+			}
+
+			delay *= 2
+		}
+	}
+}
+
+// NewTracingUnaryClientInterceptor creates an interceptor that starts a client span for every call and propagates it in the metadata
+// tracer: Mandatory. The tracer to create the spans with
+// Returns the new interceptor
+func NewTracingUnaryClientInterceptor(tracer opentracing.Tracer) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption) error {
+		var parentSpanContext opentracing.SpanContext
+		if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
+			parentSpanContext = parentSpan.Context()
+		}
+
+		span := tracer.StartSpan(method, opentracing.ChildOf(parentSpanContext), ext.SpanKindRPCClient)
+		defer span.Finish()
+
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if ok {
+			md = md.Copy()
+		} else {
+			md = metadata.MD{}
+		}
+
+		if err := tracer.Inject(span.Context(), opentracing.HTTPHeaders, metadataCarrier(md)); err != nil {
+			span.LogKV("event", "inject", "error", err.Error())
+		}
+
+		err := invoker(metadata.NewOutgoingContext(ctx, md), method, req, reply, cc, opts...)
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.SetTag("grpc.code", status.Code(err).String())
+		}
+
+		return err
+	}
+}
+
+// NewMetricsUnaryClientInterceptor creates an interceptor that records the number and the duration of the calls
+// Returns the new interceptor
+func NewMetricsUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption) error {
+		startedAt := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		callsDuration.WithLabelValues(method).Observe(time.Since(startedAt).Seconds())
+		callsCounter.WithLabelValues(method, status.Code(err).String()).Inc()
+
+		return err
+	}
+}
+
+// metadataCarrier adapts GRPC metadata to the opentracing text map carrier
+type metadataCarrier metadata.MD
+
+// Set implements opentracing.TextMapWriter
+func (carrier metadataCarrier) Set(key, value string) {
+	key = strings.ToLower(key)
+	carrier[key] = append(carrier[key], value)
+}
+
+// ForeachKey implements opentracing.TextMapReader
+func (carrier metadataCarrier) ForeachKey(handler func(key, value string) error) error {
+	for key, values := range carrier {
+		for _, value := range values {
+			if err := handler(key, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
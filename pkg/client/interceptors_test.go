@@ -0,0 +1,177 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/pkg/client"
+	"github.com/lucsky/cuid"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestClient(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Client Tests")
+}
+
+var _ = Describe("Client Interceptors Tests", func() {
+	var (
+		ctx      context.Context
+		method   string
+		calls    int
+		received []metadata.MD
+	)
+
+	createInvoker := func(errs ...error) grpc.UnaryInvoker {
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			received = append(received, md)
+			calls++
+
+			if calls <= len(errs) {
+				return errs[calls-1]
+			}
+
+			return nil
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		method = "/user.Service/" + cuid.New()
+		calls = 0
+		received = []metadata.MD{}
+	})
+
+	Describe("NewAuthUnaryClientInterceptor", func() {
+		When("token provider returns the token", func() {
+			It("should attach the token as bearer authorization header", func() {
+				token := cuid.New()
+				interceptor := client.NewAuthUnaryClientInterceptor(func(ctx context.Context) (string, error) {
+					return token, nil
+				})
+
+				err := interceptor(ctx, method, nil, nil, nil, createInvoker())
+				Ω(err).Should(BeNil())
+				Ω(received[0].Get("authorization")).Should(Equal([]string{"Bearer " + token}))
+			})
+		})
+
+		When("token provider returns error", func() {
+			It("should fail with UNAUTHENTICATED without calling the service", func() {
+				interceptor := client.NewAuthUnaryClientInterceptor(func(ctx context.Context) (string, error) {
+					return "", errors.New(cuid.New())
+				})
+
+				err := interceptor(ctx, method, nil, nil, nil, createInvoker())
+				Ω(status.Code(err)).Should(Equal(codes.Unauthenticated))
+				Ω(calls).Should(Equal(0))
+			})
+		})
+	})
+
+	Describe("NewRetryUnaryClientInterceptor", func() {
+		When("the service is unavailable and then recovers", func() {
+			It("should retry the call", func() {
+				interceptor := client.NewRetryUnaryClientInterceptor(3, time.Millisecond)
+
+				err := interceptor(ctx, method, nil, nil, nil, createInvoker(status.Error(codes.Unavailable, cuid.New())))
+				Ω(err).Should(BeNil())
+				Ω(calls).Should(Equal(2))
+			})
+		})
+
+		When("the service stays unavailable", func() {
+			It("should stop after the maximum number of attempts", func() {
+				unavailableErr := status.Error(codes.Unavailable, cuid.New())
+				interceptor := client.NewRetryUnaryClientInterceptor(3, time.Millisecond)
+
+				err := interceptor(ctx, method, nil, nil, nil, createInvoker(unavailableErr, unavailableErr, unavailableErr, unavailableErr))
+				Ω(err).Should(Equal(unavailableErr))
+				Ω(calls).Should(Equal(3))
+			})
+		})
+
+		When("the call fails with other status code", func() {
+			It("should not retry the call", func() {
+				expectedErr := status.Error(codes.PermissionDenied, cuid.New())
+				interceptor := client.NewRetryUnaryClientInterceptor(3, time.Millisecond)
+
+				err := interceptor(ctx, method, nil, nil, nil, createInvoker(expectedErr))
+				Ω(err).Should(Equal(expectedErr))
+				Ω(calls).Should(Equal(1))
+			})
+		})
+	})
+
+	Describe("NewTimeoutUnaryClientInterceptor", func() {
+		When("the context has no deadline", func() {
+			It("should call the service with the timeout as deadline", func() {
+				var deadline time.Time
+				var hasDeadline bool
+				interceptor := client.NewTimeoutUnaryClientInterceptor(time.Minute)
+
+				err := interceptor(ctx, method, nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+					deadline, hasDeadline = ctx.Deadline()
+
+					return nil
+				})
+				Ω(err).Should(BeNil())
+				Ω(hasDeadline).Should(BeTrue())
+				Ω(deadline).Should(BeTemporally("~", time.Now().Add(time.Minute), time.Second))
+			})
+		})
+
+		When("the context already has a deadline", func() {
+			It("should keep the deadline of the caller", func() {
+				var deadline time.Time
+				expectedDeadline := time.Now().Add(time.Hour)
+				callerCtx, cancel := context.WithDeadline(ctx, expectedDeadline)
+				defer cancel()
+
+				interceptor := client.NewTimeoutUnaryClientInterceptor(time.Minute)
+
+				err := interceptor(callerCtx, method, nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+					deadline, _ = ctx.Deadline()
+
+					return nil
+				})
+				Ω(err).Should(BeNil())
+				Ω(deadline).Should(Equal(expectedDeadline))
+			})
+		})
+	})
+
+	Describe("NewTracingUnaryClientInterceptor", func() {
+		It("should finish a client span and propagate it in the metadata", func() {
+			tracer := mocktracer.New()
+			interceptor := client.NewTracingUnaryClientInterceptor(tracer)
+
+			err := interceptor(ctx, method, nil, nil, nil, createInvoker())
+			Ω(err).Should(BeNil())
+			Ω(tracer.FinishedSpans()).Should(HaveLen(1))
+			Ω(tracer.FinishedSpans()[0].OperationName).Should(Equal(method))
+			Ω(received[0].Get("mockpfx-ids-traceid")).ShouldNot(BeEmpty())
+		})
+	})
+
+	Describe("NewMetricsUnaryClientInterceptor", func() {
+		It("should return the result of the call", func() {
+			expectedErr := status.Error(codes.NotFound, cuid.New())
+			interceptor := client.NewMetricsUnaryClientInterceptor()
+
+			err := interceptor(ctx, method, nil, nil, nil, createInvoker(expectedErr))
+			Ω(err).Should(Equal(expectedErr))
+			Ω(calls).Should(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,385 @@
+// Package client provides the SDK other services use to call the user service over GRPC.
+package client
+
+import (
+	"context"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SearchRequest contains the criteria to search for the users with
+type SearchRequest struct {
+	Pagination     models.Pagination
+	SortingOptions []models.SortingOptionPair
+	Emails         []string
+	IncludeDeleted bool
+
+	// LabelSelector is the label selector the users must match, e.g. region=eu,plan in (pro, enterprise)
+	LabelSelector string
+
+	// Query is the free-text query the email address of the users must start with regardless of the case
+	Query string
+
+	// Fields are the names of the user fields to return, e.g. labels, all the fields are returned if empty
+	Fields []string
+}
+
+// SearchResponse contains the users that matched the search criteria
+type SearchResponse struct {
+	HasPreviousPage bool
+	HasNextPage     bool
+	TotalCount      int64
+	Users           []models.UserWithCursor
+}
+
+// ReadUsersResponse contains the users read at once
+type ReadUsersResponse struct {
+	// Users are the users found, in the order their email addresses or IDs are requested in
+	Users []models.UserWithCursor
+
+	// MissingEmails are the email addresses of the users not found
+	MissingEmails []string
+
+	// MissingUserIDs are the IDs of the users not found
+	MissingUserIDs []string
+}
+
+// CreateUser creates the user of the caller, the email address of the user is the email address of the caller
+// ctx: Mandatory The reference to the context
+// user: Mandatory. The user to create
+// Returns either the created user and its cursor or error if something goes wrong
+func (client *Client) CreateUser(ctx context.Context, user models.User) (models.User, string, error) {
+	response, err := client.ServiceClient.CreateUser(ctx, &userGRPCContract.CreateUserRequest{User: mapUserToGRPC(user)})
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	if err = newServiceError(response.Error, response.ErrorMessage, response.ErrorDetails); err != nil {
+		return models.User{}, "", err
+	}
+
+	return mapUserFromGRPC(response.User), response.Cursor, nil
+}
+
+// ReadUser reads an existing user
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns either the user or error if something goes wrong
+func (client *Client) ReadUser(ctx context.Context, email string) (models.User, error) {
+	response, err := client.ServiceClient.ReadUserByEmail(ctx, &userGRPCContract.ReadUserByEmailRequest{Email: email})
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if err = newServiceError(response.Error, response.ErrorMessage, response.ErrorDetails); err != nil {
+		return models.User{}, err
+	}
+
+	return mapUserFromGRPC(response.User), nil
+}
+
+// ReadUserByID reads an existing user by its ID, which does not change when the email address of the user is changed
+// ctx: Mandatory The reference to the context
+// userID: Mandatory. The ID of the user
+// Returns either the email address of the user and the user or error if something goes wrong
+func (client *Client) ReadUserByID(ctx context.Context, userID string) (string, models.User, error) {
+	response, err := client.ServiceClient.ReadUser(ctx, &userGRPCContract.ReadUserRequest{UserID: userID})
+	if err != nil {
+		return "", models.User{}, err
+	}
+
+	if err = newServiceError(response.Error, response.ErrorMessage, response.ErrorDetails); err != nil {
+		return "", models.User{}, err
+	}
+
+	return response.Email, mapUserFromGRPC(response.User), nil
+}
+
+// ReadUsers reads the existing users with the given email addresses at once, instead of calling ReadUser for each of
+// them
+// ctx: Mandatory The reference to the context
+// emails: Mandatory. The email addresses of the users, up to 1000
+// Returns either the users found along with the email addresses of the users not found or error if something goes
+// wrong
+func (client *Client) ReadUsers(ctx context.Context, emails []string) (*ReadUsersResponse, error) {
+	return client.readUsers(ctx, &userGRPCContract.ReadUsersRequest{Emails: emails})
+}
+
+// ReadUsersByID reads the existing users with the given IDs at once, instead of calling ReadUserByID for each of them
+// ctx: Mandatory The reference to the context
+// userIDs: Mandatory. The IDs of the users, up to 1000
+// Returns either the users found along with the IDs of the users not found or error if something goes wrong
+func (client *Client) ReadUsersByID(ctx context.Context, userIDs []string) (*ReadUsersResponse, error) {
+	return client.readUsers(ctx, &userGRPCContract.ReadUsersRequest{UserIDs: userIDs})
+}
+
+func (client *Client) readUsers(ctx context.Context, request *userGRPCContract.ReadUsersRequest) (*ReadUsersResponse, error) {
+	response, err := client.ServiceClient.ReadUsers(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = newServiceError(response.Error, response.ErrorMessage, response.ErrorDetails); err != nil {
+		return nil, err
+	}
+
+	users := make([]models.UserWithCursor, 0, len(response.Users))
+	for _, user := range response.Users {
+		users = append(users, mapUserWithCursorFromGRPC(user))
+	}
+
+	return &ReadUsersResponse{
+		Users:          users,
+		MissingEmails:  response.MissingEmails,
+		MissingUserIDs: response.MissingUserIDs,
+	}, nil
+}
+
+// UpdateUser updates an existing user
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// user: Mandatory. The user to update the existing user to
+// Returns either the updated user and its cursor or error if something goes wrong
+func (client *Client) UpdateUser(ctx context.Context, email string, user models.User) (models.User, string, error) {
+	response, err := client.ServiceClient.UpdateUser(ctx, &userGRPCContract.UpdateUserRequest{
+		Email: email,
+		User:  mapUserToGRPC(user),
+	})
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	if err = newServiceError(response.Error, response.ErrorMessage, response.ErrorDetails); err != nil {
+		return models.User{}, "", err
+	}
+
+	return mapUserFromGRPC(response.User), response.Cursor, nil
+}
+
+// ChangeEmail changes the email address of an existing user, the user keeps its ID
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The current email address of the user
+// newEmail: Mandatory. The new email address of the user
+// Returns either the user and its cursor or error if something goes wrong
+func (client *Client) ChangeEmail(ctx context.Context, email string, newEmail string) (models.User, string, error) {
+	response, err := client.ServiceClient.ChangeEmail(ctx, &userGRPCContract.ChangeEmailRequest{
+		Email:    email,
+		NewEmail: newEmail,
+	})
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	if err = newServiceError(response.Error, response.ErrorMessage, response.ErrorDetails); err != nil {
+		return models.User{}, "", err
+	}
+
+	return mapUserFromGRPC(response.User), response.Cursor, nil
+}
+
+// RequestEmailChange sends the token that confirms changing the email address of an existing user to the new email
+// address, the email address is not changed until the change is confirmed
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The current email address of the user
+// newEmail: Mandatory. The new email address of the user
+// Returns either the time the token expires at or error if something goes wrong
+func (client *Client) RequestEmailChange(ctx context.Context, email string, newEmail string) (time.Time, error) {
+	response, err := client.ServiceClient.RequestEmailChange(ctx, &userGRPCContract.RequestEmailChangeRequest{
+		Email:    email,
+		NewEmail: newEmail,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if err = newServiceError(response.Error, response.ErrorMessage, response.ErrorDetails); err != nil {
+		return time.Time{}, err
+	}
+
+	return response.ExpiresAt.AsTime(), nil
+}
+
+// ConfirmEmailChange changes the email address of an existing user to the one the token is sent to
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The current email address of the user
+// token: Mandatory. The token sent to the new email address
+// Returns either the user with the new email address and its cursor or error if something goes wrong
+func (client *Client) ConfirmEmailChange(ctx context.Context, email string, token string) (models.User, string, error) {
+	response, err := client.ServiceClient.ConfirmEmailChange(ctx, &userGRPCContract.ConfirmEmailChangeRequest{
+		Email: email,
+		Token: token,
+	})
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	if err = newServiceError(response.Error, response.ErrorMessage, response.ErrorDetails); err != nil {
+		return models.User{}, "", err
+	}
+
+	return mapUserFromGRPC(response.User), response.Cursor, nil
+}
+
+// DeleteUser deletes an existing user
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns either the ID of the saga that deletes the user or error if something goes wrong
+func (client *Client) DeleteUser(ctx context.Context, email string) (string, error) {
+	response, err := client.ServiceClient.DeleteUser(ctx, &userGRPCContract.DeleteUserRequest{Email: email})
+	if err != nil {
+		return "", err
+	}
+
+	if err = newServiceError(response.Error, response.ErrorMessage, response.ErrorDetails); err != nil {
+		return "", err
+	}
+
+	return response.SagaID, nil
+}
+
+// RestoreUser restores an existing soft deleted user
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns either the restored user and its cursor or error if something goes wrong
+func (client *Client) RestoreUser(ctx context.Context, email string) (models.User, string, error) {
+	response, err := client.ServiceClient.RestoreUser(ctx, &userGRPCContract.RestoreUserRequest{Email: email})
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	if err = newServiceError(response.Error, response.ErrorMessage, response.ErrorDetails); err != nil {
+		return models.User{}, "", err
+	}
+
+	return mapUserFromGRPC(response.User), response.Cursor, nil
+}
+
+// Search returns the users that matched the criteria
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The search criteria
+// Returns either the users that matched the criteria or error if something goes wrong
+func (client *Client) Search(ctx context.Context, request SearchRequest) (*SearchResponse, error) {
+	grpcRequest := &userGRPCContract.SearchRequest{
+		Emails:         request.Emails,
+		IncludeDeleted: request.IncludeDeleted,
+		LabelSelector:  request.LabelSelector,
+		Query:          request.Query,
+		Fields:         request.Fields,
+	}
+
+	if request.Pagination.After != nil {
+		grpcRequest.After = *request.Pagination.After
+	}
+
+	if request.Pagination.First != nil {
+		grpcRequest.First = int32(*request.Pagination.First)
+	}
+
+	if request.Pagination.Before != nil {
+		grpcRequest.Before = *request.Pagination.Before
+	}
+
+	if request.Pagination.Last != nil {
+		grpcRequest.Last = int32(*request.Pagination.Last)
+	}
+
+	for _, sortingOption := range request.SortingOptions {
+		direction := userGRPCContract.SortingDirection_ASCENDING
+		if sortingOption.Direction == models.Descending {
+			direction = userGRPCContract.SortingDirection_DESCENDING
+		}
+
+		grpcRequest.SortingOptions = append(grpcRequest.SortingOptions, &userGRPCContract.SortingOptionPair{
+			Name:      sortingOption.Name,
+			Direction: direction,
+		})
+	}
+
+	response, err := client.ServiceClient.Search(ctx, grpcRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = newServiceError(response.Error, response.ErrorMessage, response.ErrorDetails); err != nil {
+		return nil, err
+	}
+
+	users := make([]models.UserWithCursor, 0, len(response.Users))
+	for _, user := range response.Users {
+		users = append(users, mapUserWithCursorFromGRPC(user))
+	}
+
+	return &SearchResponse{
+		HasPreviousPage: response.HasPreviousPage,
+		HasNextPage:     response.HasNextPage,
+		TotalCount:      response.TotalCount,
+		Users:           users,
+	}, nil
+}
+
+// mapUserToGRPC maps the user to the GRPC object, only the fields the service accepts are mapped
+func mapUserToGRPC(user models.User) *userGRPCContract.User {
+	return &userGRPCContract.User{
+		DataResidency: user.DataResidency,
+		Labels:        user.Labels,
+	}
+}
+
+// mapUserFromGRPC maps the user from the GRPC object, the fields redacted for the caller are left empty
+func mapUserFromGRPC(user *userGRPCContract.User) models.User {
+	if user == nil {
+		return models.User{}
+	}
+
+	memberships := make([]models.TenantMembership, 0, len(user.Memberships))
+	for _, membership := range user.Memberships {
+		memberships = append(memberships, models.TenantMembership{
+			TenantID: membership.TenantID,
+			Role:     membership.Role,
+			JoinedAt: membership.JoinedAt.AsTime(),
+		})
+	}
+
+	return models.User{
+		ID:                  user.UserID,
+		Memberships:         memberships,
+		DataResidency:       user.DataResidency,
+		EmailVerified:       user.EmailVerified,
+		FailedLoginAttempts: int(user.FailedLoginAttempts),
+		Labels:              user.Labels,
+		CreatedAt:           mapTimestampFromGRPC(user.CreatedAt),
+		UpdatedAt:           mapTimestampFromGRPC(user.UpdatedAt),
+		CreatedBy:           user.CreatedBy,
+		UpdatedBy:           user.UpdatedBy,
+		LockedAt:            mapTimestampFromGRPC(user.LockedAt),
+	}
+}
+
+// mapUserWithCursorFromGRPC maps the user with cursor from the GRPC object
+func mapUserWithCursorFromGRPC(user *userGRPCContract.UserWithCursor) models.UserWithCursor {
+	mappedUser := models.UserWithCursor{
+		Email:     user.Email,
+		User:      mapUserFromGRPC(user.User),
+		Cursor:    user.Cursor,
+		DeletedAt: mapTimestampFromGRPC(user.DeletedAt),
+	}
+
+	if user.CreatedAt != nil {
+		mappedUser.CreatedAt = user.CreatedAt.AsTime()
+	}
+
+	return mappedUser
+}
+
+func mapTimestampFromGRPC(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+
+	mappedTime := timestamp.AsTime()
+
+	return &mappedTime
+}
@@ -0,0 +1,342 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/pkg/client"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Client User Operations Tests", func() {
+	var (
+		ctx      context.Context
+		sut      *client.Client
+		request  interface{}
+		response proto.Message
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		request = nil
+		response = nil
+
+		var err error
+		sut, err = client.NewClient(ctx, "localhost:0", &client.Options{
+			DialOptions: []grpc.DialOption{
+				grpc.WithInsecure(),
+				grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+					request = req
+					proto.Merge(reply.(proto.Message), response)
+
+					return nil
+				}),
+			},
+		})
+		Ω(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		Ω(sut.Close()).Should(BeNil())
+	})
+
+	Describe("CreateUser", func() {
+		When("the service creates the user", func() {
+			It("should return the created user and its cursor", func() {
+				createdAt := time.Now().UTC().Truncate(time.Second)
+				labels := map[string]string{"region": cuid.New()}
+				cursor := cuid.New()
+				createdBy := cuid.New()
+				response = &userGRPCContract.CreateUserResponse{
+					User: &userGRPCContract.User{
+						DataResidency: "eu",
+						Labels:        labels,
+						CreatedAt:     timestamppb.New(createdAt),
+						CreatedBy:     createdBy,
+					},
+					Cursor: cursor,
+				}
+
+				user, returnedCursor, err := sut.CreateUser(ctx, models.User{DataResidency: "eu", Labels: labels})
+				Ω(err).Should(BeNil())
+				Ω(request.(*userGRPCContract.CreateUserRequest).User.DataResidency).Should(Equal("eu"))
+				Ω(request.(*userGRPCContract.CreateUserRequest).User.Labels).Should(Equal(labels))
+				Ω(returnedCursor).Should(Equal(cursor))
+				Ω(user.DataResidency).Should(Equal("eu"))
+				Ω(user.Labels).Should(Equal(labels))
+				Ω(*user.CreatedAt).Should(BeTemporally("==", createdAt))
+				Ω(user.CreatedBy).Should(Equal(createdBy))
+				Ω(user.UpdatedAt).Should(BeNil())
+			})
+		})
+
+		When("the service reports the user already exists", func() {
+			It("should return AlreadyExistsError", func() {
+				response = &userGRPCContract.CreateUserResponse{
+					Error:        userGRPCContract.Error_USER_ALREADY_EXISTS,
+					ErrorMessage: cuid.New(),
+				}
+
+				_, _, err := sut.CreateUser(ctx, models.User{})
+				Ω(commonErrors.IsAlreadyExistsError(err)).Should(BeTrue())
+				Ω(client.IsServiceError(err, userGRPCContract.Error_USER_ALREADY_EXISTS)).Should(BeTrue())
+			})
+		})
+	})
+
+	Describe("ReadUsers", func() {
+		When("the service reads the users", func() {
+			It("should return the users found and the email addresses of the missing ones", func() {
+				emails := []string{cuid.New() + "@test.com", cuid.New() + "@test.com"}
+				cursor := cuid.New()
+				response = &userGRPCContract.ReadUsersResponse{
+					Users: []*userGRPCContract.UserWithCursor{{
+						Email:  emails[0],
+						User:   &userGRPCContract.User{Labels: map[string]string{"plan": "pro"}},
+						Cursor: cursor,
+					}},
+					MissingEmails: emails[1:],
+				}
+
+				readResponse, err := sut.ReadUsers(ctx, emails)
+				Ω(err).Should(BeNil())
+				Ω(request.(*userGRPCContract.ReadUsersRequest).Emails).Should(Equal(emails))
+				Ω(readResponse.Users).Should(HaveLen(1))
+				Ω(readResponse.Users[0].Email).Should(Equal(emails[0]))
+				Ω(readResponse.Users[0].Cursor).Should(Equal(cursor))
+				Ω(readResponse.Users[0].User.Labels).Should(Equal(map[string]string{"plan": "pro"}))
+				Ω(readResponse.MissingEmails).Should(Equal(emails[1:]))
+			})
+		})
+
+		When("the service reports the request is invalid", func() {
+			It("should return ArgumentError", func() {
+				response = &userGRPCContract.ReadUsersResponse{
+					Error:        userGRPCContract.Error_BAD_REQUEST,
+					ErrorMessage: cuid.New(),
+				}
+
+				_, err := sut.ReadUsers(ctx, nil)
+				Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Describe("ReadUser", func() {
+		When("the service reports the user not found", func() {
+			It("should return NotFoundError", func() {
+				email := cuid.New()
+				message := cuid.New()
+				response = &userGRPCContract.ReadUserByEmailResponse{
+					Error:        userGRPCContract.Error_USER_NOT_FOUND,
+					ErrorMessage: message,
+				}
+
+				_, err := sut.ReadUser(ctx, email)
+				Ω(request.(*userGRPCContract.ReadUserByEmailRequest).Email).Should(Equal(email))
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+				Ω(client.IsServiceError(err, userGRPCContract.Error_USER_NOT_FOUND)).Should(BeTrue())
+				Ω(client.IsServiceError(err, userGRPCContract.Error_UNKNOWN)).Should(BeFalse())
+
+				var serviceError client.ServiceError
+				Ω(errors.As(err, &serviceError)).Should(BeTrue())
+				Ω(serviceError.Message).Should(Equal(message))
+			})
+		})
+
+		When("the service reports the request is invalid", func() {
+			It("should return ArgumentError", func() {
+				response = &userGRPCContract.ReadUserByEmailResponse{
+					Error:        userGRPCContract.Error_BAD_REQUEST,
+					ErrorMessage: cuid.New(),
+				}
+
+				_, err := sut.ReadUser(ctx, cuid.New())
+				Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+				Ω(client.IsServiceError(err, userGRPCContract.Error_BAD_REQUEST)).Should(BeTrue())
+			})
+		})
+
+		When("the service reports the invalid fields of the request", func() {
+			It("should return the validation failure of every invalid field", func() {
+				validationError := &userGRPCContract.ValidationError{
+					Field:   "email",
+					Code:    "format",
+					Message: "must be a valid email address",
+				}
+
+				detail, err := anypb.New(&userGRPCContract.ValidationErrors{
+					Errors: []*userGRPCContract.ValidationError{validationError},
+				})
+				Ω(err).Should(BeNil())
+
+				response = &userGRPCContract.ReadUserByEmailResponse{
+					Error:        userGRPCContract.Error_BAD_REQUEST,
+					ErrorMessage: cuid.New(),
+					ErrorDetails: []*anypb.Any{detail},
+				}
+
+				_, err = sut.ReadUser(ctx, cuid.New())
+				validationErrors := client.GetValidationErrors(err)
+				Ω(validationErrors).Should(HaveLen(1))
+				Ω(proto.Equal(validationErrors[0], validationError)).Should(BeTrue())
+			})
+		})
+	})
+
+	Describe("ReadUserByID", func() {
+		It("should read the user by its ID and return its email address", func() {
+			userID := cuid.New()
+			email := cuid.New() + "@test.com"
+			response = &userGRPCContract.ReadUserResponse{
+				Email: email,
+				User:  &userGRPCContract.User{UserID: userID},
+			}
+
+			returnedEmail, user, err := sut.ReadUserByID(ctx, userID)
+			Ω(err).Should(BeNil())
+			Ω(request.(*userGRPCContract.ReadUserRequest).UserID).Should(Equal(userID))
+			Ω(request.(*userGRPCContract.ReadUserRequest).Email).Should(BeEmpty())
+			Ω(returnedEmail).Should(Equal(email))
+			Ω(user.ID).Should(Equal(userID))
+		})
+	})
+
+	Describe("ChangeEmail", func() {
+		It("should return the user keeping its ID along with its cursor", func() {
+			email := cuid.New() + "@test.com"
+			newEmail := cuid.New() + "@test.com"
+			userID := cuid.New()
+			cursor := cuid.New()
+			response = &userGRPCContract.ChangeEmailResponse{
+				User:   &userGRPCContract.User{UserID: userID},
+				Cursor: cursor,
+			}
+
+			user, returnedCursor, err := sut.ChangeEmail(ctx, email, newEmail)
+			Ω(err).Should(BeNil())
+			Ω(request.(*userGRPCContract.ChangeEmailRequest).Email).Should(Equal(email))
+			Ω(request.(*userGRPCContract.ChangeEmailRequest).NewEmail).Should(Equal(newEmail))
+			Ω(user.ID).Should(Equal(userID))
+			Ω(returnedCursor).Should(Equal(cursor))
+		})
+
+		It("should return AlreadyExistsError if another user has the new email address", func() {
+			response = &userGRPCContract.ChangeEmailResponse{
+				Error:        userGRPCContract.Error_USER_ALREADY_EXISTS,
+				ErrorMessage: cuid.New(),
+			}
+
+			_, _, err := sut.ChangeEmail(ctx, cuid.New()+"@test.com", cuid.New()+"@test.com")
+			Ω(commonErrors.IsAlreadyExistsError(err)).Should(BeTrue())
+		})
+	})
+
+	Describe("RequestEmailChange", func() {
+		It("should return the time the token sent to the new email address expires at", func() {
+			email := cuid.New() + "@test.com"
+			newEmail := cuid.New() + "@test.com"
+			expiresAt := time.Now().Add(time.Hour).UTC()
+			response = &userGRPCContract.RequestEmailChangeResponse{ExpiresAt: timestamppb.New(expiresAt)}
+
+			returnedExpiresAt, err := sut.RequestEmailChange(ctx, email, newEmail)
+			Ω(err).Should(BeNil())
+			Ω(request.(*userGRPCContract.RequestEmailChangeRequest).Email).Should(Equal(email))
+			Ω(request.(*userGRPCContract.RequestEmailChangeRequest).NewEmail).Should(Equal(newEmail))
+			Ω(returnedExpiresAt).Should(Equal(expiresAt))
+		})
+	})
+
+	Describe("ConfirmEmailChange", func() {
+		It("should return the user with the new email address along with its cursor", func() {
+			email := cuid.New() + "@test.com"
+			token := cuid.New()
+			userID := cuid.New()
+			cursor := cuid.New()
+			response = &userGRPCContract.ConfirmEmailChangeResponse{
+				User:   &userGRPCContract.User{UserID: userID},
+				Cursor: cursor,
+			}
+
+			user, returnedCursor, err := sut.ConfirmEmailChange(ctx, email, token)
+			Ω(err).Should(BeNil())
+			Ω(request.(*userGRPCContract.ConfirmEmailChangeRequest).Email).Should(Equal(email))
+			Ω(request.(*userGRPCContract.ConfirmEmailChangeRequest).Token).Should(Equal(token))
+			Ω(user.ID).Should(Equal(userID))
+			Ω(returnedCursor).Should(Equal(cursor))
+		})
+
+		It("should return ServiceError if the token can not confirm the change", func() {
+			response = &userGRPCContract.ConfirmEmailChangeResponse{
+				Error:        userGRPCContract.Error_EMAIL_VERIFICATION_TOKEN_EXPIRED,
+				ErrorMessage: cuid.New(),
+			}
+
+			_, _, err := sut.ConfirmEmailChange(ctx, cuid.New()+"@test.com", cuid.New())
+			Ω(client.IsServiceError(err, userGRPCContract.Error_EMAIL_VERIFICATION_TOKEN_EXPIRED)).Should(BeTrue())
+		})
+	})
+
+	Describe("DeleteUser", func() {
+		It("should return the ID of the saga deleting the user", func() {
+			sagaID := cuid.New()
+			response = &userGRPCContract.DeleteUserResponse{SagaID: sagaID}
+
+			returnedSagaID, err := sut.DeleteUser(ctx, cuid.New())
+			Ω(err).Should(BeNil())
+			Ω(returnedSagaID).Should(Equal(sagaID))
+		})
+	})
+
+	Describe("Search", func() {
+		It("should map the search criteria and the users that matched them", func() {
+			first := 10
+			after := cuid.New()
+			labelSelector := "region=eu"
+			email := cuid.New()
+			cursor := cuid.New()
+			response = &userGRPCContract.SearchResponse{
+				HasNextPage: true,
+				TotalCount:  1,
+				Users: []*userGRPCContract.UserWithCursor{{
+					Email:  email,
+					Cursor: cursor,
+					User:   &userGRPCContract.User{DataResidency: "eu"},
+				}},
+			}
+
+			searchResponse, err := sut.Search(ctx, client.SearchRequest{
+				Pagination:     models.Pagination{After: &after, First: &first},
+				SortingOptions: []models.SortingOptionPair{{Name: "email", Direction: models.Descending}},
+				LabelSelector:  labelSelector,
+			})
+			Ω(err).Should(BeNil())
+
+			grpcRequest := request.(*userGRPCContract.SearchRequest)
+			Ω(grpcRequest.After).Should(Equal(after))
+			Ω(grpcRequest.First).Should(Equal(int32(first)))
+			Ω(grpcRequest.LabelSelector).Should(Equal(labelSelector))
+			Ω(grpcRequest.SortingOptions).Should(HaveLen(1))
+			Ω(grpcRequest.SortingOptions[0].Name).Should(Equal("email"))
+			Ω(grpcRequest.SortingOptions[0].Direction).Should(Equal(userGRPCContract.SortingDirection_DESCENDING))
+
+			Ω(searchResponse.HasNextPage).Should(BeTrue())
+			Ω(searchResponse.TotalCount).Should(Equal(int64(1)))
+			Ω(searchResponse.Users).Should(HaveLen(1))
+			Ω(searchResponse.Users[0].Email).Should(Equal(email))
+			Ω(searchResponse.Users[0].Cursor).Should(Equal(cursor))
+			Ω(searchResponse.Users[0].User.DataResidency).Should(Equal("eu"))
+		})
+	})
+})
@@ -0,0 +1 @@
+package fips_test
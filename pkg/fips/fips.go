@@ -0,0 +1,19 @@
+// Package fips reports whether the user service is built to use the FIPS 140-2 validated BoringCrypto module.
+//
+// The FIPS build is selected with the fips build tag and must be compiled with the BoringCrypto Go toolchain
+// (e.g. the goboring/golang images) with cgo enabled, see the build-fips make target. In that build every use of
+// the standard crypto packages, i.e. the token signature verification, the HMAC of the bulk update confirmation
+// tokens, the PBKDF2-SHA256 password hashes and TLS, is served by BoringCrypto and TLS is restricted to the FIPS
+// approved versions, cipher suites, curves and signature algorithms. The passwords can only be hashed with
+// PBKDF2-SHA256, the other password hashing algorithms are not FIPS approved.
+package fips
+
+// Mode returns the name of the crypto module the binary is built with
+// Returns BoringCrypto if the FIPS build is running with BoringCrypto enabled, otherwise the standard Go crypto
+func Mode() string {
+	if Enabled() {
+		return "BoringCrypto"
+	}
+
+	return "Go crypto"
+}
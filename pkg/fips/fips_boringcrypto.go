@@ -0,0 +1,21 @@
+//go:build fips
+// +build fips
+
+// Package fips reports whether the user service is built to use the FIPS 140-2 validated BoringCrypto module.
+package fips
+
+import (
+	"crypto/boring"
+
+	// Restricts TLS to the FIPS approved settings
+	_ "crypto/tls/fipsonly"
+)
+
+// Built is true as the binary is the FIPS build
+const Built = true
+
+// Enabled returns whether the crypto operations are served by BoringCrypto
+// Returns true if the binary is the FIPS build and BoringCrypto is enabled
+func Enabled() bool {
+	return boring.Enabled()
+}
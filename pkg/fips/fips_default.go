@@ -0,0 +1,14 @@
+//go:build !fips
+// +build !fips
+
+// Package fips reports whether the user service is built to use the FIPS 140-2 validated BoringCrypto module.
+package fips
+
+// Built is false as the binary is not the FIPS build
+const Built = false
+
+// Enabled returns whether the crypto operations are served by BoringCrypto
+// Returns false as the binary is not the FIPS build
+func Enabled() bool {
+	return false
+}
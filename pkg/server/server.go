@@ -0,0 +1,103 @@
+// Package server hosts the user service in-process, so other Go programs such as the integration test harnesses or
+// the monoliths the service is migrated out of can run it without executing the binary.
+//
+// The service is configured exactly like the binary, through the configuration service passed to Run, and any of its
+// dependencies can be replaced through the options, e.g. the repository the users are persisted in:
+//
+//	err := server.Run(ctx, configurationService, server.WithRepository(repositoryService))
+package server
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/pkg/util"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/eventing"
+	"github.com/decentralized-cloud/user/services/mailer"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+)
+
+// Option overrides a dependency the hosted service is created with
+type Option = util.Option
+
+// WithLogger sets the logger the hosted service logs with instead of the logger created from the configuration
+// logger: Mandatory. Reference to the logger service
+// Returns the option
+func WithLogger(logger *zap.Logger) Option {
+	return util.WithLogger(logger)
+}
+
+// WithLogLevel sets the level the logger provided through WithLogger logs at, so the level can be changed over the
+// HTTPS endpoint the configuration enables
+// logLevel: Mandatory. The level the logger logs at
+// Returns the option
+func WithLogLevel(logLevel zap.AtomicLevel) Option {
+	return util.WithLogLevel(logLevel)
+}
+
+// WithRepository sets the repository the hosted service persists the users in instead of the repository the
+// configuration selects, e.g. a repository shared with the host program or a fake the test harness inspects
+// repositoryService: Mandatory. Reference to the repository service
+// Returns the option
+func WithRepository(repositoryService repository.RepositoryContract) Option {
+	return util.WithRepositoryService(repositoryService)
+}
+
+// WithClock sets the service that provides the current time, e.g. a fake clock the test harness moves forward
+// clockService: Mandatory. Reference to the clock service
+// Returns the option
+func WithClock(clockService clock.ClockContract) Option {
+	return util.WithClockService(clockService)
+}
+
+// WithEventing sets the service the hosted service publishes the events through instead of the configured broker
+// eventingService: Mandatory. Reference to the eventing service
+// Returns the option
+func WithEventing(eventingService eventing.EventingContract) Option {
+	return util.WithEventingService(eventingService)
+}
+
+// WithMailer sets the service the hosted service sends the verification emails through
+// mailerService: Mandatory. Reference to the mailer service
+// Returns the option
+func WithMailer(mailerService mailer.MailerContract) Option {
+	return util.WithMailerService(mailerService)
+}
+
+// Run creates the user service and serves it until the context is cancelled or any of its services fails, then
+// stops it. It blocks for as long as the service runs.
+// ctx: Mandatory The reference to the context that stops the service once it is cancelled
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// options: Optional. The options that override the dependencies of the service
+// Returns error if the service fails to start, fails while running or fails to stop, otherwise nil once the context
+// is cancelled and the service is stopped
+func Run(ctx context.Context, configurationService configuration.ConfigurationContract, options ...Option) error {
+	if ctx == nil {
+		return commonErrors.NewArgumentNilError("ctx", "ctx is required")
+	}
+
+	server, err := util.NewServer(configurationService, options...)
+	if err != nil {
+		return err
+	}
+
+	errs, err := server.Start()
+	if err != nil {
+		_ = server.Stop()
+
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return server.Stop()
+
+	case err = <-errs:
+		_ = server.Stop()
+
+		return err
+	}
+}
@@ -0,0 +1,182 @@
+package server_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/pkg/server"
+	"github.com/decentralized-cloud/user/pkg/util"
+	apiKeyMock "github.com/decentralized-cloud/user/services/apikey/mock"
+	auditMock "github.com/decentralized-cloud/user/services/audit/mock"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	consentMock "github.com/decentralized-cloud/user/services/consent/mock"
+	credentialMock "github.com/decentralized-cloud/user/services/credential/mock"
+	groupMock "github.com/decentralized-cloud/user/services/group/mock"
+	invitationMock "github.com/decentralized-cloud/user/services/invitation/mock"
+	magicLinkMock "github.com/decentralized-cloud/user/services/magiclink/mock"
+	"github.com/decentralized-cloud/user/services/repository/memory"
+	sagaMock "github.com/decentralized-cloud/user/services/saga/mock"
+	sessionMock "github.com/decentralized-cloud/user/services/session/mock"
+	webhookMock "github.com/decentralized-cloud/user/services/webhook/mock"
+	"github.com/golang/mock/gomock"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestServer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Server Tests")
+}
+
+var _ = Describe("Server Tests", func() {
+	var (
+		mockCtrl             *gomock.Controller
+		configurationService configuration.ConfigurationContract
+		options              []server.Option
+		grpcAddress          string
+	)
+
+	// getFreePort returns a port nothing listens on
+	getFreePort := func() int {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Ω(err).Should(BeNil())
+
+		defer listener.Close()
+
+		return listener.Addr().(*net.TCPAddr).Port
+	}
+
+	// checkHealth calls the health service of the hosted service over a new connection
+	checkHealth := func() (healthpb.HealthCheckResponse_ServingStatus, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		connection, err := grpc.DialContext(ctx, grpcAddress, grpc.WithInsecure(), grpc.WithBlock())
+		if err != nil {
+			return healthpb.HealthCheckResponse_UNKNOWN, err
+		}
+
+		defer connection.Close()
+
+		response, err := healthpb.NewHealthClient(connection).Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			return healthpb.HealthCheckResponse_UNKNOWN, err
+		}
+
+		return response.Status, nil
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		clockService, err := clock.NewClockService()
+		Ω(err).Should(BeNil())
+
+		repositoryService, err := memory.NewMemoryRepositoryService(clockService)
+		Ω(err).Should(BeNil())
+
+		// The services persisting their data in the database are replaced, so the service runs without a database
+		options = []server.Option{
+			server.WithLogger(zap.NewNop()),
+			server.WithClock(clockService),
+			server.WithRepository(repositoryService),
+			util.WithAPIKeyService(apiKeyMock.NewMockAPIKeyContract(mockCtrl)),
+			util.WithAuditService(auditMock.NewMockAuditContract(mockCtrl)),
+			util.WithConsentService(consentMock.NewMockConsentContract(mockCtrl)),
+			util.WithCredentialService(credentialMock.NewMockCredentialContract(mockCtrl)),
+			util.WithGroupService(groupMock.NewMockGroupContract(mockCtrl)),
+			util.WithInvitationService(invitationMock.NewMockInvitationContract(mockCtrl)),
+			util.WithMagicLinkService(magicLinkMock.NewMockMagicLinkContract(mockCtrl)),
+			util.WithSagaService(sagaMock.NewMockSagaContract(mockCtrl)),
+			util.WithSessionService(sessionMock.NewMockSessionContract(mockCtrl)),
+			util.WithWebhookService(webhookMock.NewMockWebhookContract(mockCtrl)),
+		}
+
+		for _, option := range configuration.Options() {
+			os.Unsetenv(option.EnvironmentVariable)
+		}
+
+		grpcPort := getFreePort()
+		grpcAddress = net.JoinHostPort("127.0.0.1", strconv.Itoa(grpcPort))
+
+		for variableName, value := range map[string]string{
+			"GRPC_HOST":                     "127.0.0.1",
+			"GRPC_PORT":                     strconv.Itoa(grpcPort),
+			"GRPC_SHUTDOWN_DRAIN_DELAY":     "0s",
+			"HTTP_HOST":                     "127.0.0.1",
+			"HTTP_PORT":                     strconv.Itoa(getFreePort()),
+			"GRAPHQL_HOST":                  "127.0.0.1",
+			"GRAPHQL_PORT":                  strconv.Itoa(getFreePort()),
+			"DATABASE_CONNECTION_STRING":    "mongodb://127.0.0.1:1",
+			"USER_DATABASE_NAME":            "user",
+			"USER_DATABASE_COLLECTION_NAME": "user",
+			"JWKS_URL":                      "http://127.0.0.1:1/jwks",
+		} {
+			os.Setenv(variableName, value)
+		}
+
+		configurationService, err = configuration.NewEnvConfigurationService()
+		Ω(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+
+		for _, option := range configuration.Options() {
+			os.Unsetenv(option.EnvironmentVariable)
+		}
+	})
+
+	Context("the service is run", func() {
+		When("the context is not provided", func() {
+			It("should return ArgumentNilError", func() {
+				err := server.Run(nil, configurationService)
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("the configuration is invalid", func() {
+			It("should return error without serving anything", func() {
+				os.Setenv("GRPC_SHUTDOWN_TIMEOUT", "not-a-duration")
+
+				err := server.Run(context.Background(), configurationService, options...)
+				Ω(err).ShouldNot(BeNil())
+
+				_, err = checkHealth()
+				Ω(err).ShouldNot(BeNil())
+			})
+		})
+
+		When("the service runs until the context is cancelled", func() {
+			It("should serve the user service until the context is cancelled, then stop it", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				stopped := make(chan error, 1)
+				go func() {
+					stopped <- server.Run(ctx, configurationService, options...)
+				}()
+
+				Eventually(checkHealth, 10*time.Second, 50*time.Millisecond).Should(Equal(healthpb.HealthCheckResponse_SERVING))
+				Consistently(stopped, 200*time.Millisecond).ShouldNot(Receive())
+
+				cancel()
+
+				Eventually(stopped, 10*time.Second).Should(Receive(BeNil()))
+
+				_, err := checkHealth()
+				Ω(err).ShouldNot(BeNil())
+			})
+		})
+	})
+})
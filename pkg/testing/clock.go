@@ -0,0 +1,43 @@
+package testing
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultNow is the time the fakes are created at unless another clock is provided, so the times they return are the
+// same on every run
+var DefaultNow = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// FakeClock is the clock the fakes read the current time from. The time only moves when the test moves it.
+type FakeClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+// NewFakeClock creates the clock stopped at the given time
+// now: Mandatory. The time the clock is stopped at
+// Returns the new clock
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		now: now.UTC(),
+	}
+}
+
+// Now returns the time the clock is stopped at
+// Returns the current time in UTC
+func (clock *FakeClock) Now() time.Time {
+	clock.lock.Lock()
+	defer clock.lock.Unlock()
+
+	return clock.now
+}
+
+// Advance moves the clock forward
+// duration: Mandatory. How far the clock is moved forward
+func (clock *FakeClock) Advance(duration time.Duration) {
+	clock.lock.Lock()
+	defer clock.lock.Unlock()
+
+	clock.now = clock.now.Add(duration)
+}
@@ -0,0 +1,517 @@
+package testing
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/services/business"
+)
+
+// GetSagaStatus is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) GetSagaStatus(
+	ctx context.Context,
+	request *business.GetSagaStatusRequest) (*business.GetSagaStatusResponse, error) {
+	return &business.GetSagaStatusResponse{
+		Err: newUnsupportedError("GetSagaStatus"),
+	}, nil
+}
+
+// ListAuditRecords is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) ListAuditRecords(
+	ctx context.Context,
+	request *business.ListAuditRecordsRequest) (*business.ListAuditRecordsResponse, error) {
+	return &business.ListAuditRecordsResponse{
+		Err: newUnsupportedError("ListAuditRecords"),
+	}, nil
+}
+
+// GetEffectiveConfiguration is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) GetEffectiveConfiguration(
+	ctx context.Context,
+	request *business.GetEffectiveConfigurationRequest) (*business.GetEffectiveConfigurationResponse, error) {
+	return &business.GetEffectiveConfigurationResponse{
+		Err: newUnsupportedError("GetEffectiveConfiguration"),
+	}, nil
+}
+
+// GetEnabledFeatures is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) GetEnabledFeatures(
+	ctx context.Context,
+	request *business.GetEnabledFeaturesRequest) (*business.GetEnabledFeaturesResponse, error) {
+	return &business.GetEnabledFeaturesResponse{
+		Err: newUnsupportedError("GetEnabledFeatures"),
+	}, nil
+}
+
+// PreviewBulkUpdateUsers is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) PreviewBulkUpdateUsers(
+	ctx context.Context,
+	request *business.PreviewBulkUpdateUsersRequest) (*business.PreviewBulkUpdateUsersResponse, error) {
+	return &business.PreviewBulkUpdateUsersResponse{
+		Err: newUnsupportedError("PreviewBulkUpdateUsers"),
+	}, nil
+}
+
+// BulkUpdateUsers is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) BulkUpdateUsers(
+	ctx context.Context,
+	request *business.BulkUpdateUsersRequest) (*business.BulkUpdateUsersResponse, error) {
+	return &business.BulkUpdateUsersResponse{
+		Err: newUnsupportedError("BulkUpdateUsers"),
+	}, nil
+}
+
+// GetOutboxLag is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) GetOutboxLag(
+	ctx context.Context,
+	request *business.GetOutboxLagRequest) (*business.GetOutboxLagResponse, error) {
+	return &business.GetOutboxLagResponse{
+		Err: newUnsupportedError("GetOutboxLag"),
+	}, nil
+}
+
+// ListPendingEvents is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) ListPendingEvents(
+	ctx context.Context,
+	request *business.ListPendingEventsRequest) (*business.ListPendingEventsResponse, error) {
+	return &business.ListPendingEventsResponse{
+		Err: newUnsupportedError("ListPendingEvents"),
+	}, nil
+}
+
+// ForceFlush is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) ForceFlush(
+	ctx context.Context,
+	request *business.ForceFlushRequest) (*business.ForceFlushResponse, error) {
+	return &business.ForceFlushResponse{
+		Err: newUnsupportedError("ForceFlush"),
+	}, nil
+}
+
+// GetUserAvatar is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) GetUserAvatar(
+	ctx context.Context,
+	request *business.GetUserAvatarRequest) (*business.GetUserAvatarResponse, error) {
+	return &business.GetUserAvatarResponse{
+		Err: newUnsupportedError("GetUserAvatar"),
+	}, nil
+}
+
+// SetUserAvatar is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) SetUserAvatar(
+	ctx context.Context,
+	request *business.SetUserAvatarRequest) (*business.SetUserAvatarResponse, error) {
+	return &business.SetUserAvatarResponse{
+		Err: newUnsupportedError("SetUserAvatar"),
+	}, nil
+}
+
+// GetReplicationStatus is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) GetReplicationStatus(
+	ctx context.Context,
+	request *business.GetReplicationStatusRequest) (*business.GetReplicationStatusResponse, error) {
+	return &business.GetReplicationStatusResponse{
+		Err: newUnsupportedError("GetReplicationStatus"),
+	}, nil
+}
+
+// ExportUsers is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) ExportUsers(
+	ctx context.Context,
+	request *business.ExportUsersRequest) (*business.ExportUsersResponse, error) {
+	return &business.ExportUsersResponse{
+		Err: newUnsupportedError("ExportUsers"),
+	}, nil
+}
+
+// ExportPersonalData is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) ExportPersonalData(
+	ctx context.Context,
+	request *business.ExportPersonalDataRequest) (*business.ExportPersonalDataResponse, error) {
+	return &business.ExportPersonalDataResponse{
+		Err: newUnsupportedError("ExportPersonalData"),
+	}, nil
+}
+
+// EraseUser is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) EraseUser(
+	ctx context.Context,
+	request *business.EraseUserRequest) (*business.EraseUserResponse, error) {
+	return &business.EraseUserResponse{
+		Err: newUnsupportedError("EraseUser"),
+	}, nil
+}
+
+// RequestEmailChange is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) RequestEmailChange(
+	ctx context.Context,
+	request *business.RequestEmailChangeRequest) (*business.RequestEmailChangeResponse, error) {
+	return &business.RequestEmailChangeResponse{
+		Err: newUnsupportedError("RequestEmailChange"),
+	}, nil
+}
+
+// ConfirmEmailChange is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) ConfirmEmailChange(
+	ctx context.Context,
+	request *business.ConfirmEmailChangeRequest) (*business.ConfirmEmailChangeResponse, error) {
+	return &business.ConfirmEmailChangeResponse{
+		Err: newUnsupportedError("ConfirmEmailChange"),
+	}, nil
+}
+
+// SendVerificationEmail is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) SendVerificationEmail(
+	ctx context.Context,
+	request *business.SendVerificationEmailRequest) (*business.SendVerificationEmailResponse, error) {
+	return &business.SendVerificationEmailResponse{
+		Err: newUnsupportedError("SendVerificationEmail"),
+	}, nil
+}
+
+// VerifyEmail is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) VerifyEmail(
+	ctx context.Context,
+	request *business.VerifyEmailRequest) (*business.VerifyEmailResponse, error) {
+	return &business.VerifyEmailResponse{
+		Err: newUnsupportedError("VerifyEmail"),
+	}, nil
+}
+
+// SetPassword is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) SetPassword(
+	ctx context.Context,
+	request *business.SetPasswordRequest) (*business.SetPasswordResponse, error) {
+	return &business.SetPasswordResponse{
+		Err: newUnsupportedError("SetPassword"),
+	}, nil
+}
+
+// ChangePassword is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) ChangePassword(
+	ctx context.Context,
+	request *business.ChangePasswordRequest) (*business.ChangePasswordResponse, error) {
+	return &business.ChangePasswordResponse{
+		Err: newUnsupportedError("ChangePassword"),
+	}, nil
+}
+
+// VerifyPassword is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) VerifyPassword(
+	ctx context.Context,
+	request *business.VerifyPasswordRequest) (*business.VerifyPasswordResponse, error) {
+	return &business.VerifyPasswordResponse{
+		Err: newUnsupportedError("VerifyPassword"),
+	}, nil
+}
+
+// WatchUsers is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) WatchUsers(
+	ctx context.Context,
+	request *business.WatchUsersRequest) (*business.WatchUsersResponse, error) {
+	return &business.WatchUsersResponse{
+		Err: newUnsupportedError("WatchUsers"),
+	}, nil
+}
+
+// CreateAPIKey is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) CreateAPIKey(
+	ctx context.Context,
+	request *business.CreateAPIKeyRequest) (*business.CreateAPIKeyResponse, error) {
+	return &business.CreateAPIKeyResponse{
+		Err: newUnsupportedError("CreateAPIKey"),
+	}, nil
+}
+
+// ListAPIKeys is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) ListAPIKeys(
+	ctx context.Context,
+	request *business.ListAPIKeysRequest) (*business.ListAPIKeysResponse, error) {
+	return &business.ListAPIKeysResponse{
+		Err: newUnsupportedError("ListAPIKeys"),
+	}, nil
+}
+
+// RevokeAPIKey is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) RevokeAPIKey(
+	ctx context.Context,
+	request *business.RevokeAPIKeyRequest) (*business.RevokeAPIKeyResponse, error) {
+	return &business.RevokeAPIKeyResponse{
+		Err: newUnsupportedError("RevokeAPIKey"),
+	}, nil
+}
+
+// RecordLoginAttempt is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) RecordLoginAttempt(
+	ctx context.Context,
+	request *business.RecordLoginAttemptRequest) (*business.RecordLoginAttemptResponse, error) {
+	return &business.RecordLoginAttemptResponse{
+		Err: newUnsupportedError("RecordLoginAttempt"),
+	}, nil
+}
+
+// UnlockUser is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) UnlockUser(
+	ctx context.Context,
+	request *business.UnlockUserRequest) (*business.UnlockUserResponse, error) {
+	return &business.UnlockUserResponse{
+		Err: newUnsupportedError("UnlockUser"),
+	}, nil
+}
+
+// IssueMagicLink is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) IssueMagicLink(
+	ctx context.Context,
+	request *business.IssueMagicLinkRequest) (*business.IssueMagicLinkResponse, error) {
+	return &business.IssueMagicLinkResponse{
+		Err: newUnsupportedError("IssueMagicLink"),
+	}, nil
+}
+
+// RedeemMagicLink is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) RedeemMagicLink(
+	ctx context.Context,
+	request *business.RedeemMagicLinkRequest) (*business.RedeemMagicLinkResponse, error) {
+	return &business.RedeemMagicLinkResponse{
+		Err: newUnsupportedError("RedeemMagicLink"),
+	}, nil
+}
+
+// EnrollMFA is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) EnrollMFA(
+	ctx context.Context,
+	request *business.EnrollMFARequest) (*business.EnrollMFAResponse, error) {
+	return &business.EnrollMFAResponse{
+		Err: newUnsupportedError("EnrollMFA"),
+	}, nil
+}
+
+// ListMFAMethods is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) ListMFAMethods(
+	ctx context.Context,
+	request *business.ListMFAMethodsRequest) (*business.ListMFAMethodsResponse, error) {
+	return &business.ListMFAMethodsResponse{
+		Err: newUnsupportedError("ListMFAMethods"),
+	}, nil
+}
+
+// RemoveMFAMethod is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) RemoveMFAMethod(
+	ctx context.Context,
+	request *business.RemoveMFAMethodRequest) (*business.RemoveMFAMethodResponse, error) {
+	return &business.RemoveMFAMethodResponse{
+		Err: newUnsupportedError("RemoveMFAMethod"),
+	}, nil
+}
+
+// RecordConsent is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) RecordConsent(
+	ctx context.Context,
+	request *business.RecordConsentRequest) (*business.RecordConsentResponse, error) {
+	return &business.RecordConsentResponse{
+		Err: newUnsupportedError("RecordConsent"),
+	}, nil
+}
+
+// ListConsents is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) ListConsents(
+	ctx context.Context,
+	request *business.ListConsentsRequest) (*business.ListConsentsResponse, error) {
+	return &business.ListConsentsResponse{
+		Err: newUnsupportedError("ListConsents"),
+	}, nil
+}
+
+// CreateGroup is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) CreateGroup(
+	ctx context.Context,
+	request *business.CreateGroupRequest) (*business.CreateGroupResponse, error) {
+	return &business.CreateGroupResponse{
+		Err: newUnsupportedError("CreateGroup"),
+	}, nil
+}
+
+// ReadGroup is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) ReadGroup(
+	ctx context.Context,
+	request *business.ReadGroupRequest) (*business.ReadGroupResponse, error) {
+	return &business.ReadGroupResponse{
+		Err: newUnsupportedError("ReadGroup"),
+	}, nil
+}
+
+// UpdateGroup is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) UpdateGroup(
+	ctx context.Context,
+	request *business.UpdateGroupRequest) (*business.UpdateGroupResponse, error) {
+	return &business.UpdateGroupResponse{
+		Err: newUnsupportedError("UpdateGroup"),
+	}, nil
+}
+
+// DeleteGroup is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) DeleteGroup(
+	ctx context.Context,
+	request *business.DeleteGroupRequest) (*business.DeleteGroupResponse, error) {
+	return &business.DeleteGroupResponse{
+		Err: newUnsupportedError("DeleteGroup"),
+	}, nil
+}
+
+// ListGroups is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) ListGroups(
+	ctx context.Context,
+	request *business.ListGroupsRequest) (*business.ListGroupsResponse, error) {
+	return &business.ListGroupsResponse{
+		Err: newUnsupportedError("ListGroups"),
+	}, nil
+}
+
+// AddUserToGroup is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) AddUserToGroup(
+	ctx context.Context,
+	request *business.AddUserToGroupRequest) (*business.AddUserToGroupResponse, error) {
+	return &business.AddUserToGroupResponse{
+		Err: newUnsupportedError("AddUserToGroup"),
+	}, nil
+}
+
+// RemoveUserFromGroup is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) RemoveUserFromGroup(
+	ctx context.Context,
+	request *business.RemoveUserFromGroupRequest) (*business.RemoveUserFromGroupResponse, error) {
+	return &business.RemoveUserFromGroupResponse{
+		Err: newUnsupportedError("RemoveUserFromGroup"),
+	}, nil
+}
+
+// InviteUser is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) InviteUser(
+	ctx context.Context,
+	request *business.InviteUserRequest) (*business.InviteUserResponse, error) {
+	return &business.InviteUserResponse{
+		Err: newUnsupportedError("InviteUser"),
+	}, nil
+}
+
+// AcceptInvitation is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) AcceptInvitation(
+	ctx context.Context,
+	request *business.AcceptInvitationRequest) (*business.AcceptInvitationResponse, error) {
+	return &business.AcceptInvitationResponse{
+		Err: newUnsupportedError("AcceptInvitation"),
+	}, nil
+}
+
+// RegisterSession is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) RegisterSession(
+	ctx context.Context,
+	request *business.RegisterSessionRequest) (*business.RegisterSessionResponse, error) {
+	return &business.RegisterSessionResponse{
+		Err: newUnsupportedError("RegisterSession"),
+	}, nil
+}
+
+// ListSessions is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) ListSessions(
+	ctx context.Context,
+	request *business.ListSessionsRequest) (*business.ListSessionsResponse, error) {
+	return &business.ListSessionsResponse{
+		Err: newUnsupportedError("ListSessions"),
+	}, nil
+}
+
+// RevokeSession is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) RevokeSession(
+	ctx context.Context,
+	request *business.RevokeSessionRequest) (*business.RevokeSessionResponse, error) {
+	return &business.RevokeSessionResponse{
+		Err: newUnsupportedError("RevokeSession"),
+	}, nil
+}
+
+// CreateWebhookSubscription is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) CreateWebhookSubscription(
+	ctx context.Context,
+	request *business.CreateWebhookSubscriptionRequest) (*business.CreateWebhookSubscriptionResponse, error) {
+	return &business.CreateWebhookSubscriptionResponse{
+		Err: newUnsupportedError("CreateWebhookSubscription"),
+	}, nil
+}
+
+// ListWebhookSubscriptions is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) ListWebhookSubscriptions(
+	ctx context.Context,
+	request *business.ListWebhookSubscriptionsRequest) (*business.ListWebhookSubscriptionsResponse, error) {
+	return &business.ListWebhookSubscriptionsResponse{
+		Err: newUnsupportedError("ListWebhookSubscriptions"),
+	}, nil
+}
+
+// DeleteWebhookSubscription is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) DeleteWebhookSubscription(
+	ctx context.Context,
+	request *business.DeleteWebhookSubscriptionRequest) (*business.DeleteWebhookSubscriptionResponse, error) {
+	return &business.DeleteWebhookSubscriptionResponse{
+		Err: newUnsupportedError("DeleteWebhookSubscription"),
+	}, nil
+}
+
+// ListWebhookDeliveries is not supported by the fake
+// Returns UnknownError
+func (service *FakeBusinessService) ListWebhookDeliveries(
+	ctx context.Context,
+	request *business.ListWebhookDeliveriesRequest) (*business.ListWebhookDeliveriesResponse, error) {
+	return &business.ListWebhookDeliveriesResponse{
+		Err: newUnsupportedError("ListWebhookDeliveries"),
+	}, nil
+}
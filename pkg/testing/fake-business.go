@@ -0,0 +1,536 @@
+// Package testing provides the fakes of the user service the other services embed in their tests instead of
+// generating their own mocks against its contract. The fakes keep the users in memory and return the same results on
+// every run, as the time only moves when the test moves the clock and the cursors are assigned in sequence.
+//
+// FakeBusinessService implements the business contract and FakeServer serves it over GRPC, so the services that call
+// the user service through the client SDK can be tested against it without changing how they are configured:
+//
+//	fakeServer, err := testing.NewFakeServer(fakeBusinessService)
+//	...
+//	connection, err := grpc.Dial(fakeServer.Address(), grpc.WithInsecure())
+//
+// Only the operations on the users themselves are faked, the other operations return UnknownError. A test that needs
+// one of them embeds the fake in its own type and overrides the operation.
+package testing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/business"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/repository/memory"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// FakeBusinessService is the deterministic in-memory fake of the business service
+type FakeBusinessService struct {
+	clock             *FakeClock
+	softDeleteEnabled bool
+	repositoryService repository.RepositoryContract
+}
+
+// FakeOption changes how the fake business service behaves
+type FakeOption func(service *FakeBusinessService)
+
+// WithClock sets the clock the fake reads the current time from, so the test can move it forward
+// clock: Mandatory. The clock the fake reads the current time from
+// Returns the option
+func WithClock(clock *FakeClock) FakeOption {
+	return func(service *FakeBusinessService) {
+		service.clock = clock
+	}
+}
+
+// WithSoftDelete makes the fake only mark the deleted users as deleted, so they can be restored, as the service does
+// when SOFT_DELETE_ENABLED is set
+// Returns the option
+func WithSoftDelete() FakeOption {
+	return func(service *FakeBusinessService) {
+		service.softDeleteEnabled = true
+	}
+}
+
+// NewFakeBusinessService creates the fake business service with no user
+// options: Optional. The options that change how the fake behaves
+// Returns either the new fake or error if something goes wrong
+func NewFakeBusinessService(options ...FakeOption) (*FakeBusinessService, error) {
+	service := &FakeBusinessService{}
+	for _, option := range options {
+		option(service)
+	}
+
+	if service.clock == nil {
+		service.clock = NewFakeClock(DefaultNow)
+	}
+
+	repositoryService, err := memory.NewMemoryRepositoryService(service.clock)
+	if err != nil {
+		return nil, err
+	}
+
+	service.repositoryService = repositoryService
+
+	return service, nil
+}
+
+// Clock returns the clock the fake reads the current time from
+// Returns the clock of the fake
+func (service *FakeBusinessService) Clock() *FakeClock {
+	return service.clock
+}
+
+// CreateUser creates a new user.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to create a new user
+// Returns either the result of creating new user or error if something goes wrong.
+func (service *FakeBusinessService) CreateUser(
+	ctx context.Context,
+	request *business.CreateUserRequest) (*business.CreateUserResponse, error) {
+	if strings.TrimSpace(request.Email) == "" {
+		return &business.CreateUserResponse{
+			Err: business.NewRequestValidationError(commonErrors.NewArgumentError("Email", "email is required")),
+		}, nil
+	}
+
+	response, err := service.repositoryService.CreateUser(ctx, &repository.CreateUserRequest{
+		Email: request.Email,
+		User:  request.User,
+	})
+
+	if err != nil {
+		return &business.CreateUserResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &business.CreateUserResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// ReadUser read an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read an existing user
+// Returns either the result of reading an existing user or error if something goes wrong.
+func (service *FakeBusinessService) ReadUser(
+	ctx context.Context,
+	request *business.ReadUserRequest) (*business.ReadUserResponse, error) {
+	response, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email:  request.Email,
+		UserID: request.UserID,
+		Fields: request.Fields,
+	})
+
+	if err != nil {
+		return &business.ReadUserResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &business.ReadUserResponse{
+		Email: response.Email,
+		User:  models.ProjectUser(response.User, request.Fields),
+	}, nil
+}
+
+// ReadUsers reads the existing users with the given email addresses or IDs at once
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the existing users
+// Returns either the users found along with the email addresses and the IDs of the users not found or error if
+// something goes wrong.
+func (service *FakeBusinessService) ReadUsers(
+	ctx context.Context,
+	request *business.ReadUsersRequest) (*business.ReadUsersResponse, error) {
+	emails := []string{}
+	requested := map[string]bool{}
+	for _, email := range request.Emails {
+		if !requested[email] {
+			requested[email] = true
+			emails = append(emails, email)
+		}
+	}
+
+	userIDs := []string{}
+	for _, userID := range request.UserIDs {
+		if !requested["id:"+userID] {
+			requested["id:"+userID] = true
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	readResponse, err := service.repositoryService.ReadUsers(ctx, &repository.ReadUsersRequest{Emails: emails, UserIDs: userIDs})
+	if err != nil {
+		return &business.ReadUsersResponse{
+			Err: err,
+		}, nil
+	}
+
+	foundUsers := map[string]models.UserWithCursor{}
+	foundUsersByID := map[string]models.UserWithCursor{}
+	for _, user := range readResponse.Users {
+		foundUsers[user.Email] = user
+		foundUsersByID[user.User.ID] = user
+	}
+
+	response := &business.ReadUsersResponse{
+		Users:          []models.UserWithCursor{},
+		MissingEmails:  []string{},
+		MissingUserIDs: []string{},
+	}
+
+	returned := map[string]bool{}
+	for _, email := range emails {
+		user, found := foundUsers[email]
+		if !found {
+			response.MissingEmails = append(response.MissingEmails, email)
+
+			continue
+		}
+
+		returned[user.Email] = true
+		user.User = models.ProjectUser(user.User, request.Fields)
+		response.Users = append(response.Users, user)
+	}
+
+	for _, userID := range userIDs {
+		user, found := foundUsersByID[userID]
+		if !found {
+			response.MissingUserIDs = append(response.MissingUserIDs, userID)
+
+			continue
+		}
+
+		if returned[user.Email] {
+			continue
+		}
+
+		returned[user.Email] = true
+		user.User = models.ProjectUser(user.User, request.Fields)
+		response.Users = append(response.Users, user)
+	}
+
+	return response, nil
+}
+
+// UpdateUser update an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to update an existing user
+// Returns either the result of updateing an existing user or error if something goes wrong.
+func (service *FakeBusinessService) UpdateUser(
+	ctx context.Context,
+	request *business.UpdateUserRequest) (*business.UpdateUserResponse, error) {
+	response, err := service.repositoryService.UpdateUser(ctx, &repository.UpdateUserRequest{
+		Email: request.Email,
+		User:  request.User,
+	})
+
+	if err != nil {
+		return &business.UpdateUserResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &business.UpdateUserResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// ChangeEmail changes the email address of an existing user, the user keeps its ID
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to change the email address of an existing user
+// Returns either the result of changing the email address of an existing user or error if something goes wrong.
+func (service *FakeBusinessService) ChangeEmail(
+	ctx context.Context,
+	request *business.ChangeEmailRequest) (*business.ChangeEmailResponse, error) {
+	response, err := service.repositoryService.ChangeEmail(ctx, &repository.ChangeEmailRequest{
+		Email:    request.Email,
+		NewEmail: request.NewEmail,
+	})
+
+	if err != nil {
+		return &business.ChangeEmailResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &business.ChangeEmailResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// DeleteUser delete an existing user. The user is only marked as deleted if the fake is created with WithSoftDelete.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to delete an existing user
+// Returns either the result of deleting an existing user or error if something goes wrong.
+func (service *FakeBusinessService) DeleteUser(
+	ctx context.Context,
+	request *business.DeleteUserRequest) (*business.DeleteUserResponse, error) {
+	_, err := service.repositoryService.DeleteUser(ctx, &repository.DeleteUserRequest{
+		Email:      request.Email,
+		SoftDelete: service.softDeleteEnabled,
+	})
+
+	if err != nil {
+		return &business.DeleteUserResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &business.DeleteUserResponse{}, nil
+}
+
+// RestoreUser restores an existing soft deleted user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to restore an existing soft deleted user
+// Returns either the result of restoring the user or error if something goes wrong.
+func (service *FakeBusinessService) RestoreUser(
+	ctx context.Context,
+	request *business.RestoreUserRequest) (*business.RestoreUserResponse, error) {
+	response, err := service.repositoryService.RestoreUser(ctx, &repository.RestoreUserRequest{
+		Email: request.Email,
+	})
+
+	if err != nil {
+		return &business.RestoreUserResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &business.RestoreUserResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// Search returns the list of users that matched the criteria. The users can not be searched by group.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria
+// Returns the list of users that matched the criteria
+func (service *FakeBusinessService) Search(
+	ctx context.Context,
+	request *business.SearchRequest) (*business.SearchResponse, error) {
+	if len(request.GroupIDs) > 0 {
+		return &business.SearchResponse{
+			Err: newUnsupportedError("Search by group"),
+		}, nil
+	}
+
+	labelSelector, err := models.ParseLabelSelector(request.LabelSelector)
+	if err != nil {
+		return &business.SearchResponse{
+			Err: commonErrors.NewArgumentErrorWithError("request.LabelSelector", "label selector is invalid", err),
+		}, nil
+	}
+
+	response, err := service.repositoryService.Search(ctx, &repository.SearchRequest{
+		Pagination:     request.Pagination,
+		SortingOptions: request.SortingOptions,
+		Emails:         request.Emails,
+		IncludeDeleted: request.IncludeDeleted,
+		LabelSelector:  labelSelector,
+		Query:          strings.TrimSpace(request.Query),
+		Fields:         request.Fields,
+	})
+
+	if err != nil {
+		return &business.SearchResponse{
+			Err: err,
+		}, nil
+	}
+
+	for index := range response.Users {
+		response.Users[index].User = models.ProjectUser(response.Users[index].User, request.Fields)
+	}
+
+	return &business.SearchResponse{
+		HasPreviousPage: response.HasPreviousPage,
+		HasNextPage:     response.HasNextPage,
+		TotalCount:      response.TotalCount,
+		Users:           response.Users,
+	}, nil
+}
+
+// StreamSearch sends the users that matched the criteria one by one
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria and the function to send the users to
+// Returns either the number of the sent users or error if something goes wrong.
+func (service *FakeBusinessService) StreamSearch(
+	ctx context.Context,
+	request *business.StreamSearchRequest) (*business.StreamSearchResponse, error) {
+	response, err := service.repositoryService.StreamSearch(ctx, &repository.StreamSearchRequest{
+		SortingOptions: request.SortingOptions,
+		Emails:         request.Emails,
+		IncludeDeleted: request.IncludeDeleted,
+		Send:           request.Send,
+	})
+
+	if err != nil {
+		return &business.StreamSearchResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &business.StreamSearchResponse{
+		SentCount: response.SentCount,
+	}, nil
+}
+
+// PurgeByLabel permanently deletes all the users tagged with the given test label
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the test label of the users to purge
+// Returns either the number of the purged users or error if something goes wrong.
+func (service *FakeBusinessService) PurgeByLabel(
+	ctx context.Context,
+	request *business.PurgeByLabelRequest) (*business.PurgeByLabelResponse, error) {
+	response, err := service.repositoryService.PurgeUsersByLabel(ctx, &repository.PurgeUsersByLabelRequest{
+		Label: request.Label,
+	})
+
+	if err != nil {
+		return &business.PurgeByLabelResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &business.PurgeByLabelResponse{
+		PurgedCount: response.PurgedCount,
+	}, nil
+}
+
+// GetUserPreferences reads the preferences of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the preferences of an existing user
+// Returns either the preferences of the user or error if something goes wrong.
+func (service *FakeBusinessService) GetUserPreferences(
+	ctx context.Context,
+	request *business.GetUserPreferencesRequest) (*business.GetUserPreferencesResponse, error) {
+	response, err := service.repositoryService.ReadUserPreferences(ctx, &repository.ReadUserPreferencesRequest{
+		Email: request.Email,
+	})
+
+	if err != nil {
+		return &business.GetUserPreferencesResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &business.GetUserPreferencesResponse{
+		Preferences: response.Preferences,
+	}, nil
+}
+
+// UpdateUserPreferences merges the preferences into the preferences of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the preferences to set and the keys of the preferences to remove
+// Returns either the preferences of the user after they are merged or error if something goes wrong.
+func (service *FakeBusinessService) UpdateUserPreferences(
+	ctx context.Context,
+	request *business.UpdateUserPreferencesRequest) (*business.UpdateUserPreferencesResponse, error) {
+	response, err := service.repositoryService.UpdateUserPreferences(ctx, &repository.UpdateUserPreferencesRequest{
+		Email:       request.Email,
+		Preferences: request.Preferences,
+		RemovedKeys: request.RemovedKeys,
+	})
+
+	if err != nil {
+		return &business.UpdateUserPreferencesResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &business.UpdateUserPreferencesResponse{
+		Preferences: response.Preferences,
+	}, nil
+}
+
+// AddUserToTenant adds an existing user to a tenant with the given role
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the tenant and the role to add the user with
+// Returns either the user after it is added to the tenant or error if something goes wrong.
+func (service *FakeBusinessService) AddUserToTenant(
+	ctx context.Context,
+	request *business.AddUserToTenantRequest) (*business.AddUserToTenantResponse, error) {
+	response, err := service.repositoryService.AddUserToTenant(ctx, &repository.AddUserToTenantRequest{
+		Email: request.Email,
+		Membership: models.TenantMembership{
+			TenantID: request.TenantID,
+			Role:     request.Role,
+			JoinedAt: service.clock.Now(),
+		},
+	})
+
+	if err != nil {
+		return &business.AddUserToTenantResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &business.AddUserToTenantResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// RemoveUserFromTenant removes an existing user from a tenant
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the tenant to remove the user from
+// Returns either the user after it is removed from the tenant or error if something goes wrong.
+func (service *FakeBusinessService) RemoveUserFromTenant(
+	ctx context.Context,
+	request *business.RemoveUserFromTenantRequest) (*business.RemoveUserFromTenantResponse, error) {
+	response, err := service.repositoryService.RemoveUserFromTenant(ctx, &repository.RemoveUserFromTenantRequest{
+		Email:    request.Email,
+		TenantID: request.TenantID,
+	})
+
+	if err != nil {
+		return &business.RemoveUserFromTenantResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &business.RemoveUserFromTenantResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// ListUserTenants lists the tenants an existing user is a member of
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to list the tenants of an existing user
+// Returns either the memberships of the user or error if something goes wrong.
+func (service *FakeBusinessService) ListUserTenants(
+	ctx context.Context,
+	request *business.ListUserTenantsRequest) (*business.ListUserTenantsResponse, error) {
+	response, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	})
+
+	if err != nil {
+		return &business.ListUserTenantsResponse{
+			Err: err,
+		}, nil
+	}
+
+	memberships := response.User.Memberships
+	if memberships == nil {
+		memberships = []models.TenantMembership{}
+	}
+
+	return &business.ListUserTenantsResponse{
+		Memberships: memberships,
+	}, nil
+}
+
+// newUnsupportedError creates the error the operations the fake does not support return
+// operation: Mandatory. The name of the operation
+// Returns the new error
+func newUnsupportedError(operation string) error {
+	return commonErrors.NewUnknownError(fmt.Sprintf("%s is not supported by the fake business service", operation))
+}
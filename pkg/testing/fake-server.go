@@ -0,0 +1,638 @@
+package testing
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/business"
+	"github.com/lestrrat-go/jwx/jwt"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// bearerTokenPrefix is the prefix of the authorization header the access tokens are sent with
+const bearerTokenPrefix = "Bearer "
+
+// FakeServer serves the business service over GRPC on a loopback port. The access tokens are not verified, the
+// caller is only read from the email claim of the token to create the user with, as the service does. The operations
+// the fake does not serve fail with Unimplemented.
+type FakeServer struct {
+	userGRPCContract.UnimplementedServiceServer
+
+	businessService business.BusinessContract
+	listener        net.Listener
+	server          *grpc.Server
+	stopOnce        sync.Once
+}
+
+// NewFakeServer starts serving the business service on a loopback port
+// businessService: Mandatory. The business service to serve, usually the FakeBusinessService
+// Returns either the started server or error if something goes wrong
+func NewFakeServer(businessService business.BusinessContract) (*FakeServer, error) {
+	if businessService == nil {
+		return nil, commonErrors.NewArgumentNilError("businessService", "businessService is required")
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to listen on a loopback port", err)
+	}
+
+	fakeServer := &FakeServer{
+		businessService: businessService,
+		listener:        listener,
+		server:          grpc.NewServer(),
+	}
+
+	userGRPCContract.RegisterServiceServer(fakeServer.server, fakeServer)
+
+	go func() {
+		_ = fakeServer.server.Serve(listener)
+	}()
+
+	return fakeServer, nil
+}
+
+// Address returns the address the server listens on, the clients dial it without TLS
+// Returns the host and the port the server listens on
+func (fakeServer *FakeServer) Address() string {
+	return fakeServer.listener.Addr().String()
+}
+
+// Stop stops serving and closes the open connections. It is safe to call it more than once.
+func (fakeServer *FakeServer) Stop() {
+	fakeServer.stopOnce.Do(fakeServer.server.Stop)
+}
+
+// CreateUser creates the user of the caller
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to create a new user
+// Returns either the result of creating new user or error if something goes wrong.
+func (fakeServer *FakeServer) CreateUser(
+	ctx context.Context,
+	request *userGRPCContract.CreateUserRequest) (*userGRPCContract.CreateUserResponse, error) {
+	email, err := readCallerEmail(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := fakeServer.businessService.CreateUser(ctx, &business.CreateUserRequest{
+		Email: email,
+		User:  mapUserFromGRPC(request.User),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err != nil {
+		return &userGRPCContract.CreateUserResponse{
+			Error:        mapError(response.Err),
+			ErrorMessage: response.Err.Error(),
+		}, nil
+	}
+
+	return &userGRPCContract.CreateUserResponse{
+		Error:  userGRPCContract.Error_NO_ERROR,
+		User:   mapUserToGRPC(response.User),
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// ReadUser read an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read an existing user
+// Returns either the result of reading an existing user or error if something goes wrong.
+func (fakeServer *FakeServer) ReadUser(
+	ctx context.Context,
+	request *userGRPCContract.ReadUserRequest) (*userGRPCContract.ReadUserResponse, error) {
+	response, err := fakeServer.businessService.ReadUser(ctx, &business.ReadUserRequest{
+		Email:  request.Email,
+		UserID: request.UserID,
+		Fields: request.Fields,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err != nil {
+		return &userGRPCContract.ReadUserResponse{
+			Error:        mapError(response.Err),
+			ErrorMessage: response.Err.Error(),
+		}, nil
+	}
+
+	return &userGRPCContract.ReadUserResponse{
+		Error: userGRPCContract.Error_NO_ERROR,
+		Email: response.Email,
+		User:  mapUserToGRPC(response.User),
+	}, nil
+}
+
+// ReadUserByEmail reads an existing user by its email address
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read an existing user by its email address
+// Returns either the result of reading an existing user or error if something goes wrong.
+func (fakeServer *FakeServer) ReadUserByEmail(
+	ctx context.Context,
+	request *userGRPCContract.ReadUserByEmailRequest) (*userGRPCContract.ReadUserByEmailResponse, error) {
+	response, err := fakeServer.businessService.ReadUser(ctx, &business.ReadUserRequest{
+		Email:  request.Email,
+		Fields: request.Fields,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err != nil {
+		return &userGRPCContract.ReadUserByEmailResponse{
+			Error:        mapError(response.Err),
+			ErrorMessage: response.Err.Error(),
+		}, nil
+	}
+
+	return &userGRPCContract.ReadUserByEmailResponse{
+		Error: userGRPCContract.Error_NO_ERROR,
+		User:  mapUserToGRPC(response.User),
+	}, nil
+}
+
+// ReadUsers reads the existing users with the given email addresses or IDs at once
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the existing users
+// Returns either the users found along with the email addresses and the IDs of the users not found or error if
+// something goes wrong.
+func (fakeServer *FakeServer) ReadUsers(
+	ctx context.Context,
+	request *userGRPCContract.ReadUsersRequest) (*userGRPCContract.ReadUsersResponse, error) {
+	response, err := fakeServer.businessService.ReadUsers(ctx, &business.ReadUsersRequest{
+		Emails:  request.Emails,
+		Fields:  request.Fields,
+		UserIDs: request.UserIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err != nil {
+		return &userGRPCContract.ReadUsersResponse{
+			Error:        mapError(response.Err),
+			ErrorMessage: response.Err.Error(),
+		}, nil
+	}
+
+	users := make([]*userGRPCContract.UserWithCursor, 0, len(response.Users))
+	for _, user := range response.Users {
+		users = append(users, mapUserWithCursorToGRPC(user))
+	}
+
+	return &userGRPCContract.ReadUsersResponse{
+		Error:          userGRPCContract.Error_NO_ERROR,
+		Users:          users,
+		MissingEmails:  response.MissingEmails,
+		MissingUserIDs: response.MissingUserIDs,
+	}, nil
+}
+
+// UpdateUser update an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to update an existing user
+// Returns either the result of updateing an existing user or error if something goes wrong.
+func (fakeServer *FakeServer) UpdateUser(
+	ctx context.Context,
+	request *userGRPCContract.UpdateUserRequest) (*userGRPCContract.UpdateUserResponse, error) {
+	response, err := fakeServer.businessService.UpdateUser(ctx, &business.UpdateUserRequest{
+		Email: request.Email,
+		User:  mapUserFromGRPC(request.User),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err != nil {
+		return &userGRPCContract.UpdateUserResponse{
+			Error:        mapError(response.Err),
+			ErrorMessage: response.Err.Error(),
+		}, nil
+	}
+
+	return &userGRPCContract.UpdateUserResponse{
+		Error:  userGRPCContract.Error_NO_ERROR,
+		User:   mapUserToGRPC(response.User),
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// ChangeEmail changes the email address of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to change the email address of an existing user
+// Returns either the result of changing the email address of an existing user or error if something goes wrong.
+func (fakeServer *FakeServer) ChangeEmail(
+	ctx context.Context,
+	request *userGRPCContract.ChangeEmailRequest) (*userGRPCContract.ChangeEmailResponse, error) {
+	response, err := fakeServer.businessService.ChangeEmail(ctx, &business.ChangeEmailRequest{
+		Email:    request.Email,
+		NewEmail: request.NewEmail,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err != nil {
+		return &userGRPCContract.ChangeEmailResponse{
+			Error:        mapError(response.Err),
+			ErrorMessage: response.Err.Error(),
+		}, nil
+	}
+
+	return &userGRPCContract.ChangeEmailResponse{
+		Error:  userGRPCContract.Error_NO_ERROR,
+		User:   mapUserToGRPC(response.User),
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// DeleteUser delete an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to delete an existing user
+// Returns either the result of deleting an existing user or error if something goes wrong.
+func (fakeServer *FakeServer) DeleteUser(
+	ctx context.Context,
+	request *userGRPCContract.DeleteUserRequest) (*userGRPCContract.DeleteUserResponse, error) {
+	response, err := fakeServer.businessService.DeleteUser(ctx, &business.DeleteUserRequest{
+		Email: request.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err != nil {
+		return &userGRPCContract.DeleteUserResponse{
+			Error:        mapError(response.Err),
+			ErrorMessage: response.Err.Error(),
+			SagaID:       response.SagaID,
+		}, nil
+	}
+
+	return &userGRPCContract.DeleteUserResponse{
+		Error:  userGRPCContract.Error_NO_ERROR,
+		SagaID: response.SagaID,
+	}, nil
+}
+
+// RestoreUser restores an existing soft deleted user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to restore an existing soft deleted user
+// Returns either the result of restoring the user or error if something goes wrong.
+func (fakeServer *FakeServer) RestoreUser(
+	ctx context.Context,
+	request *userGRPCContract.RestoreUserRequest) (*userGRPCContract.RestoreUserResponse, error) {
+	response, err := fakeServer.businessService.RestoreUser(ctx, &business.RestoreUserRequest{
+		Email: request.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err != nil {
+		return &userGRPCContract.RestoreUserResponse{
+			Error:        mapError(response.Err),
+			ErrorMessage: response.Err.Error(),
+		}, nil
+	}
+
+	return &userGRPCContract.RestoreUserResponse{
+		Error:  userGRPCContract.Error_NO_ERROR,
+		User:   mapUserToGRPC(response.User),
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// Search returns the list of users that matched the criteria
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria
+// Returns the list of users that matched the criteria
+func (fakeServer *FakeServer) Search(
+	ctx context.Context,
+	request *userGRPCContract.SearchRequest) (*userGRPCContract.SearchResponse, error) {
+	businessRequest := business.SearchRequest{
+		Emails:         request.Emails,
+		IncludeDeleted: request.IncludeDeleted,
+		LabelSelector:  request.LabelSelector,
+		Query:          request.Query,
+		Fields:         request.Fields,
+		GroupIDs:       request.GroupIDs,
+	}
+
+	if request.After != "" {
+		after := request.After
+		businessRequest.Pagination.After = &after
+	}
+
+	if request.First != 0 {
+		first := int(request.First)
+		businessRequest.Pagination.First = &first
+	}
+
+	if request.Before != "" {
+		before := request.Before
+		businessRequest.Pagination.Before = &before
+	}
+
+	if request.Last != 0 {
+		last := int(request.Last)
+		businessRequest.Pagination.Last = &last
+	}
+
+	for _, sortingOption := range request.SortingOptions {
+		direction := models.Ascending
+		if sortingOption.Direction == userGRPCContract.SortingDirection_DESCENDING {
+			direction = models.Descending
+		}
+
+		businessRequest.SortingOptions = append(businessRequest.SortingOptions, models.SortingOptionPair{
+			Name:      sortingOption.Name,
+			Direction: direction,
+		})
+	}
+
+	response, err := fakeServer.businessService.Search(ctx, &businessRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err != nil {
+		return &userGRPCContract.SearchResponse{
+			Error:        mapError(response.Err),
+			ErrorMessage: response.Err.Error(),
+		}, nil
+	}
+
+	users := make([]*userGRPCContract.UserWithCursor, 0, len(response.Users))
+	for _, user := range response.Users {
+		users = append(users, mapUserWithCursorToGRPC(user))
+	}
+
+	return &userGRPCContract.SearchResponse{
+		Error:           userGRPCContract.Error_NO_ERROR,
+		HasPreviousPage: response.HasPreviousPage,
+		HasNextPage:     response.HasNextPage,
+		TotalCount:      response.TotalCount,
+		Users:           users,
+	}, nil
+}
+
+// GetUserPreferences reads the preferences of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the preferences of an existing user
+// Returns either the preferences of the user or error if something goes wrong.
+func (fakeServer *FakeServer) GetUserPreferences(
+	ctx context.Context,
+	request *userGRPCContract.GetUserPreferencesRequest) (*userGRPCContract.GetUserPreferencesResponse, error) {
+	response, err := fakeServer.businessService.GetUserPreferences(ctx, &business.GetUserPreferencesRequest{
+		Email: request.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err != nil {
+		return &userGRPCContract.GetUserPreferencesResponse{
+			Error:        mapError(response.Err),
+			ErrorMessage: response.Err.Error(),
+		}, nil
+	}
+
+	return &userGRPCContract.GetUserPreferencesResponse{
+		Error:       userGRPCContract.Error_NO_ERROR,
+		Preferences: response.Preferences,
+	}, nil
+}
+
+// UpdateUserPreferences merges the preferences into the preferences of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the preferences to set and the keys of the preferences to remove
+// Returns either the preferences of the user after they are merged or error if something goes wrong.
+func (fakeServer *FakeServer) UpdateUserPreferences(
+	ctx context.Context,
+	request *userGRPCContract.UpdateUserPreferencesRequest) (*userGRPCContract.UpdateUserPreferencesResponse, error) {
+	response, err := fakeServer.businessService.UpdateUserPreferences(ctx, &business.UpdateUserPreferencesRequest{
+		Email:       request.Email,
+		Preferences: request.Preferences,
+		RemovedKeys: request.RemovedKeys,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err != nil {
+		return &userGRPCContract.UpdateUserPreferencesResponse{
+			Error:        mapError(response.Err),
+			ErrorMessage: response.Err.Error(),
+		}, nil
+	}
+
+	return &userGRPCContract.UpdateUserPreferencesResponse{
+		Error:       userGRPCContract.Error_NO_ERROR,
+		Preferences: response.Preferences,
+	}, nil
+}
+
+// AddUserToTenant adds an existing user to a tenant with the given role
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the tenant and the role to add the user with
+// Returns either the user after it is added to the tenant or error if something goes wrong.
+func (fakeServer *FakeServer) AddUserToTenant(
+	ctx context.Context,
+	request *userGRPCContract.AddUserToTenantRequest) (*userGRPCContract.AddUserToTenantResponse, error) {
+	response, err := fakeServer.businessService.AddUserToTenant(ctx, &business.AddUserToTenantRequest{
+		Email:    request.Email,
+		TenantID: request.TenantID,
+		Role:     request.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err != nil {
+		return &userGRPCContract.AddUserToTenantResponse{
+			Error:        mapError(response.Err),
+			ErrorMessage: response.Err.Error(),
+		}, nil
+	}
+
+	return &userGRPCContract.AddUserToTenantResponse{
+		Error:  userGRPCContract.Error_NO_ERROR,
+		User:   mapUserToGRPC(response.User),
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// RemoveUserFromTenant removes an existing user from a tenant
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the tenant to remove the user from
+// Returns either the user after it is removed from the tenant or error if something goes wrong.
+func (fakeServer *FakeServer) RemoveUserFromTenant(
+	ctx context.Context,
+	request *userGRPCContract.RemoveUserFromTenantRequest) (*userGRPCContract.RemoveUserFromTenantResponse, error) {
+	response, err := fakeServer.businessService.RemoveUserFromTenant(ctx, &business.RemoveUserFromTenantRequest{
+		Email:    request.Email,
+		TenantID: request.TenantID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err != nil {
+		return &userGRPCContract.RemoveUserFromTenantResponse{
+			Error:        mapError(response.Err),
+			ErrorMessage: response.Err.Error(),
+		}, nil
+	}
+
+	return &userGRPCContract.RemoveUserFromTenantResponse{
+		Error:  userGRPCContract.Error_NO_ERROR,
+		User:   mapUserToGRPC(response.User),
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// ListUserTenants lists the tenants an existing user is a member of
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to list the tenants of an existing user
+// Returns either the memberships of the user or error if something goes wrong.
+func (fakeServer *FakeServer) ListUserTenants(
+	ctx context.Context,
+	request *userGRPCContract.ListUserTenantsRequest) (*userGRPCContract.ListUserTenantsResponse, error) {
+	response, err := fakeServer.businessService.ListUserTenants(ctx, &business.ListUserTenantsRequest{
+		Email: request.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err != nil {
+		return &userGRPCContract.ListUserTenantsResponse{
+			Error:        mapError(response.Err),
+			ErrorMessage: response.Err.Error(),
+		}, nil
+	}
+
+	return &userGRPCContract.ListUserTenantsResponse{
+		Error:       userGRPCContract.Error_NO_ERROR,
+		Memberships: mapTenantMembershipsToGRPC(response.Memberships),
+	}, nil
+}
+
+// readCallerEmail reads the email address of the caller from the access token the call is made with, without
+// verifying the token
+// ctx: Mandatory The reference to the context
+// Returns either the email address of the caller or Unauthenticated error if the call has no readable token
+func readCallerEmail(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 || !strings.HasPrefix(values[0], bearerTokenPrefix) {
+		return "", status.Errorf(codes.Unauthenticated, "authorization token is not provided")
+	}
+
+	token, err := jwt.ParseString(values[0][len(bearerTokenPrefix):])
+	if err != nil {
+		return "", status.Errorf(codes.Unauthenticated, "Failed to parse the received token")
+	}
+
+	email, _ := token.Get("email")
+	if castedEmail, ok := email.(string); ok && castedEmail != "" {
+		return castedEmail, nil
+	}
+
+	return "", status.Errorf(codes.Unauthenticated, "the received token has no email claim")
+}
+
+// mapError maps the error the business service returned to the error the service responds with
+func mapError(err error) userGRPCContract.Error {
+	switch {
+	case commonErrors.IsUnknownError(err):
+		return userGRPCContract.Error_UNKNOWN
+	case commonErrors.IsAlreadyExistsError(err):
+		return userGRPCContract.Error_USER_ALREADY_EXISTS
+	case commonErrors.IsNotFoundError(err):
+		return userGRPCContract.Error_USER_NOT_FOUND
+	case commonErrors.IsArgumentNilError(err) || commonErrors.IsArgumentError(err):
+		return userGRPCContract.Error_BAD_REQUEST
+	default:
+		return userGRPCContract.Error_UNKNOWN
+	}
+}
+
+func mapUserFromGRPC(user *userGRPCContract.User) models.User {
+	return models.User{
+		DataResidency: user.GetDataResidency(),
+		Labels:        user.GetLabels(),
+	}
+}
+
+func mapUserToGRPC(user models.User) *userGRPCContract.User {
+	mappedUser := &userGRPCContract.User{
+		UserID:              user.ID,
+		Memberships:         mapTenantMembershipsToGRPC(user.Memberships),
+		DataResidency:       user.DataResidency,
+		EmailVerified:       user.EmailVerified,
+		Locked:              user.LockedAt != nil,
+		FailedLoginAttempts: int32(user.FailedLoginAttempts),
+		Labels:              user.Labels,
+		CreatedBy:           user.CreatedBy,
+		UpdatedBy:           user.UpdatedBy,
+	}
+
+	if user.LockedAt != nil {
+		mappedUser.LockedAt = timestamppb.New(*user.LockedAt)
+	}
+
+	if user.CreatedAt != nil {
+		mappedUser.CreatedAt = timestamppb.New(*user.CreatedAt)
+	}
+
+	if user.UpdatedAt != nil {
+		mappedUser.UpdatedAt = timestamppb.New(*user.UpdatedAt)
+	}
+
+	return mappedUser
+}
+
+func mapUserWithCursorToGRPC(user models.UserWithCursor) *userGRPCContract.UserWithCursor {
+	userWithCursor := &userGRPCContract.UserWithCursor{
+		Email:  user.Email,
+		User:   mapUserToGRPC(user.User),
+		Cursor: user.Cursor,
+	}
+
+	if user.DeletedAt != nil {
+		userWithCursor.DeletedAt = timestamppb.New(*user.DeletedAt)
+	}
+
+	if !user.CreatedAt.IsZero() {
+		userWithCursor.CreatedAt = timestamppb.New(user.CreatedAt)
+	}
+
+	return userWithCursor
+}
+
+func mapTenantMembershipsToGRPC(memberships []models.TenantMembership) []*userGRPCContract.TenantMembership {
+	mappedMemberships := make([]*userGRPCContract.TenantMembership, 0, len(memberships))
+	for _, membership := range memberships {
+		mappedMemberships = append(mappedMemberships, &userGRPCContract.TenantMembership{
+			TenantID: membership.TenantID,
+			Role:     membership.Role,
+			JoinedAt: timestamppb.New(membership.JoinedAt),
+		})
+	}
+
+	return mappedMemberships
+}
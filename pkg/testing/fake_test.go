@@ -0,0 +1,218 @@
+package testing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/models"
+	userTesting "github.com/decentralized-cloud/user/pkg/testing"
+	"github.com/decentralized-cloud/user/services/business"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestFakes(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Fakes Tests")
+}
+
+var _ = Describe("Fake Business Service Tests", func() {
+	var (
+		sut   *userTesting.FakeBusinessService
+		ctx   context.Context
+		email string
+	)
+
+	BeforeEach(func() {
+		var err error
+		sut, err = userTesting.NewFakeBusinessService(userTesting.WithSoftDelete())
+		Ω(err).Should(BeNil())
+
+		ctx = context.Background()
+		email = cuid.New() + "@test.com"
+	})
+
+	When("the user is created", func() {
+		It("should return the same cursor and times on every run", func() {
+			response, err := sut.CreateUser(ctx, &business.CreateUserRequest{Email: email})
+			Ω(err).Should(BeNil())
+			Ω(response.Err).Should(BeNil())
+			Ω(response.Cursor).Should(Equal("1"))
+			Ω(*response.User.CreatedAt).Should(Equal(userTesting.DefaultNow))
+
+			sut.Clock().Advance(time.Hour)
+
+			updateResponse, err := sut.UpdateUser(ctx, &business.UpdateUserRequest{
+				Email: email,
+				User:  models.User{Labels: map[string]string{"plan": "pro"}},
+			})
+			Ω(err).Should(BeNil())
+			Ω(updateResponse.Err).Should(BeNil())
+			Ω(*updateResponse.User.UpdatedAt).Should(Equal(userTesting.DefaultNow.Add(time.Hour)))
+		})
+	})
+
+	When("the user is created twice", func() {
+		It("should return AlreadyExistsError", func() {
+			_, _ = sut.CreateUser(ctx, &business.CreateUserRequest{Email: email})
+
+			response, err := sut.CreateUser(ctx, &business.CreateUserRequest{Email: email})
+			Ω(err).Should(BeNil())
+			Ω(commonErrors.IsAlreadyExistsError(response.Err)).Should(BeTrue())
+		})
+	})
+
+	When("the user is deleted and restored", func() {
+		It("should only read the user while it is not deleted", func() {
+			_, _ = sut.CreateUser(ctx, &business.CreateUserRequest{Email: email})
+
+			deleteResponse, err := sut.DeleteUser(ctx, &business.DeleteUserRequest{Email: email})
+			Ω(err).Should(BeNil())
+			Ω(deleteResponse.Err).Should(BeNil())
+
+			readResponse, err := sut.ReadUser(ctx, &business.ReadUserRequest{Email: email})
+			Ω(err).Should(BeNil())
+			Ω(commonErrors.IsNotFoundError(readResponse.Err)).Should(BeTrue())
+
+			restoreResponse, err := sut.RestoreUser(ctx, &business.RestoreUserRequest{Email: email})
+			Ω(err).Should(BeNil())
+			Ω(restoreResponse.Err).Should(BeNil())
+
+			readResponse, err = sut.ReadUser(ctx, &business.ReadUserRequest{Email: email})
+			Ω(err).Should(BeNil())
+			Ω(readResponse.Err).Should(BeNil())
+		})
+	})
+
+	When("the email address of the user is changed", func() {
+		It("should keep the ID of the user and read it by its new email address", func() {
+			createResponse, _ := sut.CreateUser(ctx, &business.CreateUserRequest{Email: email})
+			newEmail := cuid.New() + "@test.com"
+
+			changeResponse, err := sut.ChangeEmail(ctx, &business.ChangeEmailRequest{Email: email, NewEmail: newEmail})
+			Ω(err).Should(BeNil())
+			Ω(changeResponse.Err).Should(BeNil())
+			Ω(changeResponse.User.ID).Should(Equal(createResponse.User.ID))
+
+			readResponse, err := sut.ReadUser(ctx, &business.ReadUserRequest{UserID: createResponse.User.ID})
+			Ω(err).Should(BeNil())
+			Ω(readResponse.Err).Should(BeNil())
+			Ω(readResponse.Email).Should(Equal(newEmail))
+		})
+	})
+
+	When("the users are read at once", func() {
+		It("should return the users in the requested order along with the missing ones", func() {
+			otherEmail := cuid.New() + "@test.com"
+			missingEmail := cuid.New() + "@test.com"
+			_, _ = sut.CreateUser(ctx, &business.CreateUserRequest{Email: email})
+			_, _ = sut.CreateUser(ctx, &business.CreateUserRequest{Email: otherEmail})
+
+			response, err := sut.ReadUsers(ctx, &business.ReadUsersRequest{Emails: []string{otherEmail, missingEmail, email}})
+			Ω(err).Should(BeNil())
+			Ω(response.Err).Should(BeNil())
+			Ω(response.Users).Should(HaveLen(2))
+			Ω(response.Users[0].Email).Should(Equal(otherEmail))
+			Ω(response.Users[1].Email).Should(Equal(email))
+			Ω(response.MissingEmails).Should(Equal([]string{missingEmail}))
+		})
+	})
+
+	When("an operation the fake does not support is called", func() {
+		It("should return UnknownError", func() {
+			response, err := sut.GetSagaStatus(ctx, &business.GetSagaStatusRequest{SagaID: cuid.New()})
+			Ω(err).Should(BeNil())
+			Ω(commonErrors.IsUnknownError(response.Err)).Should(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("Fake Server Tests", func() {
+	var (
+		fakeServer *userTesting.FakeServer
+		connection *grpc.ClientConn
+		client     userGRPCContract.ServiceClient
+		email      string
+	)
+
+	BeforeEach(func() {
+		businessService, err := userTesting.NewFakeBusinessService()
+		Ω(err).Should(BeNil())
+
+		fakeServer, err = userTesting.NewFakeServer(businessService)
+		Ω(err).Should(BeNil())
+
+		connection, err = grpc.Dial(fakeServer.Address(), grpc.WithInsecure())
+		Ω(err).Should(BeNil())
+
+		client = userGRPCContract.NewServiceClient(connection)
+		email = cuid.New() + "@test.com"
+	})
+
+	AfterEach(func() {
+		_ = connection.Close()
+		fakeServer.Stop()
+	})
+
+	withToken := func(email string) context.Context {
+		token := jwt.New()
+		_ = token.Set("email", email)
+
+		signed, err := jwt.Sign(token, jwa.HS256, []byte(cuid.New()))
+		Ω(err).Should(BeNil())
+
+		return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+string(signed))
+	}
+
+	When("the caller creates and reads its user", func() {
+		It("should create the user of the email address in the token", func() {
+			createResponse, err := client.CreateUser(withToken(email), &userGRPCContract.CreateUserRequest{
+				User: &userGRPCContract.User{Labels: map[string]string{"plan": "pro"}},
+			})
+			Ω(err).Should(BeNil())
+			Ω(createResponse.Error).Should(Equal(userGRPCContract.Error_NO_ERROR))
+
+			readResponse, err := client.ReadUserByEmail(context.Background(), &userGRPCContract.ReadUserByEmailRequest{Email: email})
+			Ω(err).Should(BeNil())
+			Ω(readResponse.Error).Should(Equal(userGRPCContract.Error_NO_ERROR))
+			Ω(readResponse.User.Labels).Should(Equal(map[string]string{"plan": "pro"}))
+
+			readByIDResponse, err := client.ReadUser(context.Background(), &userGRPCContract.ReadUserRequest{UserID: createResponse.User.UserID})
+			Ω(err).Should(BeNil())
+			Ω(readByIDResponse.Error).Should(Equal(userGRPCContract.Error_NO_ERROR))
+			Ω(readByIDResponse.Email).Should(Equal(email))
+		})
+	})
+
+	When("the caller reads a user that does not exist", func() {
+		It("should respond with USER_NOT_FOUND", func() {
+			response, err := client.ReadUser(context.Background(), &userGRPCContract.ReadUserRequest{Email: email})
+			Ω(err).Should(BeNil())
+			Ω(response.Error).Should(Equal(userGRPCContract.Error_USER_NOT_FOUND))
+		})
+	})
+
+	When("the caller creates a user without a token", func() {
+		It("should fail with Unauthenticated", func() {
+			_, err := client.CreateUser(context.Background(), &userGRPCContract.CreateUserRequest{User: &userGRPCContract.User{}})
+			Ω(status.Code(err)).Should(Equal(codes.Unauthenticated))
+		})
+	})
+
+	When("the caller calls an operation the fake does not serve", func() {
+		It("should fail with Unimplemented", func() {
+			_, err := client.GetSagaStatus(context.Background(), &userGRPCContract.GetSagaStatusRequest{SagaID: cuid.New()})
+			Ω(status.Code(err)).Should(Equal(codes.Unimplemented))
+		})
+	})
+})
@@ -1 +0,0 @@
-package util_test
@@ -0,0 +1,368 @@
+// Package util implements different utilities required by the user service
+package util
+
+import (
+	"sync"
+
+	"github.com/decentralized-cloud/user/services/apikey"
+	"github.com/decentralized-cloud/user/services/audit"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/consent"
+	"github.com/decentralized-cloud/user/services/correlation"
+	"github.com/decentralized-cloud/user/services/credential"
+	"github.com/decentralized-cloud/user/services/deprecation"
+	"github.com/decentralized-cloud/user/services/endpoint"
+	"github.com/decentralized-cloud/user/services/eventing"
+	"github.com/decentralized-cloud/user/services/group"
+	"github.com/decentralized-cloud/user/services/idgenerator"
+	"github.com/decentralized-cloud/user/services/invitation"
+	"github.com/decentralized-cloud/user/services/magiclink"
+	"github.com/decentralized-cloud/user/services/mailer"
+	"github.com/decentralized-cloud/user/services/objectstorage"
+	"github.com/decentralized-cloud/user/services/payloadlogging"
+	"github.com/decentralized-cloud/user/services/redaction"
+	"github.com/decentralized-cloud/user/services/replication"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/repository/cached"
+	"github.com/decentralized-cloud/user/services/saga"
+	"github.com/decentralized-cloud/user/services/session"
+	"github.com/decentralized-cloud/user/services/slo"
+	"github.com/decentralized-cloud/user/services/watch"
+	"github.com/decentralized-cloud/user/services/webhook"
+	"github.com/micro-business/go-core/gokit/middleware"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+)
+
+// runnable is a service the server runs in the background until it is stopped
+type runnable interface {
+	Start() error
+	Stop() error
+}
+
+// namedRunnable is a service the server runs along the name it is logged with
+type namedRunnable struct {
+	name    string
+	service runnable
+}
+
+// Server contains all the services the user service is made of. The services that are not provided through the
+// options are created from the configuration when the server is created.
+type Server struct {
+	logger                    *zap.Logger
+	logLevel                  *zap.AtomicLevel
+	configurationService      configuration.ConfigurationContract
+	endpointCreatorService    endpoint.EndpointCreatorContract
+	middlewareProviderService middleware.MiddlewareProviderContract
+	eventingService           eventing.EventingContract
+	repositoryService         repository.RepositoryContract
+	sloService                slo.SloContract
+	deprecationService        deprecation.DeprecationContract
+	redactionService          redaction.RedactionContract
+	payloadLoggingService     payloadlogging.PayloadLoggingContract
+	correlationService        correlation.CorrelationContract
+	clockService              clock.ClockContract
+	idGeneratorService        idgenerator.IDGeneratorContract
+	cacheInvalidationBus      cached.InvalidationBusContract
+	replicationService        replication.ReplicationContract
+	watchService              watch.WatchContract
+	watchSourceService        watch.SourceContract
+	apiKeyService             apikey.APIKeyContract
+	sagaService               saga.SagaContract
+	auditService              audit.AuditContract
+	mailerService             mailer.MailerContract
+	credentialService         credential.CredentialContract
+	objectStorageService      objectstorage.ObjectStorageContract
+	consentService            consent.ConsentContract
+	magicLinkService          magiclink.MagicLinkContract
+	groupService              group.GroupContract
+	invitationService         invitation.InvitationContract
+	sessionService            session.SessionContract
+	webhookService            webhook.WebhookContract
+	runnables                 []namedRunnable
+	lock                      sync.Mutex
+	started                   bool
+	stopped                   bool
+}
+
+// Option overrides a service the server is created with, e.g. to replace a dependency with a fake in the tests
+type Option func(server *Server)
+
+// WithLogger sets the logger the services log with instead of the logger created from the configuration
+// logger: Mandatory. Reference to the logger service
+// Returns the option
+func WithLogger(logger *zap.Logger) Option {
+	return func(server *Server) {
+		server.logger = logger
+	}
+}
+
+// WithLogLevel sets the level the logger provided through WithLogger logs at, so the level can be changed while the
+// server is running
+// logLevel: Mandatory. The level the logger logs at
+// Returns the option
+func WithLogLevel(logLevel zap.AtomicLevel) Option {
+	return func(server *Server) {
+		server.logLevel = &logLevel
+	}
+}
+
+// WithClockService sets the service that provides the current time
+// clockService: Mandatory. Reference to the clock service
+// Returns the option
+func WithClockService(clockService clock.ClockContract) Option {
+	return func(server *Server) {
+		server.clockService = clockService
+	}
+}
+
+// WithIDGeneratorService sets the service that generates the IDs
+// idGeneratorService: Mandatory. Reference to the ID generator service
+// Returns the option
+func WithIDGeneratorService(idGeneratorService idgenerator.IDGeneratorContract) Option {
+	return func(server *Server) {
+		server.idGeneratorService = idGeneratorService
+	}
+}
+
+// WithRepositoryService sets the repository the users are persisted in instead of the repository the configuration
+// selects
+// repositoryService: Mandatory. Reference to the repository service
+// Returns the option
+func WithRepositoryService(repositoryService repository.RepositoryContract) Option {
+	return func(server *Server) {
+		server.repositoryService = repositoryService
+	}
+}
+
+// WithWatchService sets the service that notifies the watchers of the changed users
+// watchService: Mandatory. Reference to the watch service
+// Returns the option
+func WithWatchService(watchService watch.WatchContract) Option {
+	return func(server *Server) {
+		server.watchService = watchService
+	}
+}
+
+// WithEventingService sets the service the events are published through instead of the configured broker
+// eventingService: Mandatory. Reference to the eventing service
+// Returns the option
+func WithEventingService(eventingService eventing.EventingContract) Option {
+	return func(server *Server) {
+		server.eventingService = eventingService
+	}
+}
+
+// WithSagaService sets the service that runs the sagas
+// sagaService: Mandatory. Reference to the saga service
+// Returns the option
+func WithSagaService(sagaService saga.SagaContract) Option {
+	return func(server *Server) {
+		server.sagaService = sagaService
+	}
+}
+
+// WithAuditService sets the service that records the audit trail
+// auditService: Mandatory. Reference to the audit service
+// Returns the option
+func WithAuditService(auditService audit.AuditContract) Option {
+	return func(server *Server) {
+		server.auditService = auditService
+	}
+}
+
+// WithReplicationService sets the service that reports the replication lag of the standby database
+// replicationService: Mandatory. Reference to the replication service
+// Returns the option
+func WithReplicationService(replicationService replication.ReplicationContract) Option {
+	return func(server *Server) {
+		server.replicationService = replicationService
+	}
+}
+
+// WithMailerService sets the service the verification emails are sent through
+// mailerService: Mandatory. Reference to the mailer service
+// Returns the option
+func WithMailerService(mailerService mailer.MailerContract) Option {
+	return func(server *Server) {
+		server.mailerService = mailerService
+	}
+}
+
+// WithCredentialService sets the service that manages the passwords
+// credentialService: Mandatory. Reference to the credential service
+// Returns the option
+func WithCredentialService(credentialService credential.CredentialContract) Option {
+	return func(server *Server) {
+		server.credentialService = credentialService
+	}
+}
+
+// WithAPIKeyService sets the service that manages the API keys
+// apiKeyService: Mandatory. Reference to the API key service
+// Returns the option
+func WithAPIKeyService(apiKeyService apikey.APIKeyContract) Option {
+	return func(server *Server) {
+		server.apiKeyService = apiKeyService
+	}
+}
+
+// WithObjectStorageService sets the service the avatars are stored in
+// objectStorageService: Mandatory. Reference to the object storage service
+// Returns the option
+func WithObjectStorageService(objectStorageService objectstorage.ObjectStorageContract) Option {
+	return func(server *Server) {
+		server.objectStorageService = objectStorageService
+	}
+}
+
+// WithConsentService sets the service that records the policies the users accepted
+// consentService: Mandatory. Reference to the consent service
+// Returns the option
+func WithConsentService(consentService consent.ConsentContract) Option {
+	return func(server *Server) {
+		server.consentService = consentService
+	}
+}
+
+// WithMagicLinkService sets the service that issues and redeems the magic links the users sign in with
+// magicLinkService: Mandatory. Reference to the magic link service
+// Returns the option
+func WithMagicLinkService(magicLinkService magiclink.MagicLinkContract) Option {
+	return func(server *Server) {
+		server.magicLinkService = magicLinkService
+	}
+}
+
+// WithGroupService sets the service that manages the groups of users
+// groupService: Mandatory. Reference to the group service
+// Returns the option
+func WithGroupService(groupService group.GroupContract) Option {
+	return func(server *Server) {
+		server.groupService = groupService
+	}
+}
+
+// WithInvitationService sets the service that issues and accepts the invitations of the new users
+// invitationService: Mandatory. Reference to the invitation service
+// Returns the option
+func WithInvitationService(invitationService invitation.InvitationContract) Option {
+	return func(server *Server) {
+		server.invitationService = invitationService
+	}
+}
+
+// WithSessionService sets the service that registers the sessions of the users
+// sessionService: Mandatory. Reference to the session service
+// Returns the option
+func WithSessionService(sessionService session.SessionContract) Option {
+	return func(server *Server) {
+		server.sessionService = sessionService
+	}
+}
+
+// WithWebhookService sets the service that manages the webhook subscriptions of the users and delivers their events
+// webhookService: Mandatory. Reference to the webhook service
+// Returns the option
+func WithWebhookService(webhookService webhook.WebhookContract) Option {
+	return func(server *Server) {
+		server.webhookService = webhookService
+	}
+}
+
+// NewServer creates the server, creating every service that is not provided through the options from the
+// configuration. Nothing is served until the server is started.
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// options: Optional. The options that override the services the server is created with
+// Returns either the new server or error if something goes wrong
+func NewServer(configurationService configuration.ConfigurationContract, options ...Option) (*Server, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	server := &Server{configurationService: configurationService}
+	for _, option := range options {
+		option(server)
+	}
+
+	if err := server.setupDependencies(); err != nil {
+		return nil, err
+	}
+
+	if err := server.setupRunnables(); err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
+
+// Start starts serving the transports and running the background services, each of them in its own goroutine
+// Returns either the channel that receives the error of every service that fails while running or error if the
+// server is already started or stopped
+func (server *Server) Start() (<-chan error, error) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	if server.started || server.stopped {
+		return nil, commonErrors.NewUnknownError("the server can only be started once")
+	}
+
+	server.started = true
+
+	// The channel has room for every service, so a failing service never blocks on a caller that stopped listening
+	errs := make(chan error, len(server.runnables))
+	for _, item := range server.runnables {
+		go func(item namedRunnable) {
+			if err := item.service.Start(); err != nil {
+				errs <- commonErrors.NewUnknownErrorWithError("failed to start "+item.name, err)
+			}
+		}(item)
+	}
+
+	return errs, nil
+}
+
+// Stop stops the transports and the background services and closes the connections to the brokers. Every service is
+// stopped even if stopping another one fails.
+// Returns the first error stopping the services failed with, or nil if all of them are stopped
+func (server *Server) Stop() error {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	if server.stopped {
+		return nil
+	}
+
+	server.stopped = true
+
+	var firstErr error
+	record := func(name string, err error) {
+		if err == nil {
+			return
+		}
+
+		server.logger.Error("failed to stop "+name, zap.Error(err))
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if server.started {
+		for _, item := range server.runnables {
+			record(item.name, item.service.Stop())
+		}
+	}
+
+	record("eventing service", server.eventingService.Close())
+
+	if server.watchSourceService != nil {
+		record("watch source service", server.watchSourceService.Close())
+	}
+
+	if server.cacheInvalidationBus != nil {
+		record("cache invalidation bus", server.cacheInvalidationBus.Close())
+	}
+
+	return firstErr
+}
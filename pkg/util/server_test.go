@@ -0,0 +1,258 @@
+package util_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/pkg/util"
+	apiKeyMock "github.com/decentralized-cloud/user/services/apikey/mock"
+	auditMock "github.com/decentralized-cloud/user/services/audit/mock"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	consentMock "github.com/decentralized-cloud/user/services/consent/mock"
+	credentialMock "github.com/decentralized-cloud/user/services/credential/mock"
+	groupMock "github.com/decentralized-cloud/user/services/group/mock"
+	invitationMock "github.com/decentralized-cloud/user/services/invitation/mock"
+	magicLinkMock "github.com/decentralized-cloud/user/services/magiclink/mock"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/repository/memory"
+	sagaMock "github.com/decentralized-cloud/user/services/saga/mock"
+	sessionMock "github.com/decentralized-cloud/user/services/session/mock"
+	webhookMock "github.com/decentralized-cloud/user/services/webhook/mock"
+	"github.com/golang/mock/gomock"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestServer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Server Tests")
+}
+
+var _ = Describe("Server Tests", func() {
+	var (
+		mockCtrl             *gomock.Controller
+		configurationService configuration.ConfigurationContract
+		grpcAddress          string
+	)
+
+	// getFreePort returns a port nothing listens on
+	getFreePort := func() int {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Ω(err).Should(BeNil())
+
+		defer listener.Close()
+
+		return listener.Addr().(*net.TCPAddr).Port
+	}
+
+	// readUser reads the user over gRPC with the API key of the user
+	readUser := func(email string) (*userGRPCContract.ReadUserResponse, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		connection, err := grpc.DialContext(ctx, grpcAddress, grpc.WithInsecure(), grpc.WithBlock())
+		if err != nil {
+			return nil, err
+		}
+
+		defer connection.Close()
+
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", "test-key")
+
+		return userGRPCContract.NewServiceClient(connection).ReadUser(ctx, &userGRPCContract.ReadUserRequest{Email: email})
+	}
+
+	// newServer creates the server replacing the services that connect to the database with mocks, the given options
+	// are applied after them
+	newServer := func(options ...util.Option) (*util.Server, error) {
+		return util.NewServer(configurationService, append([]util.Option{
+			util.WithLogger(zap.NewNop()),
+			util.WithSagaService(sagaMock.NewMockSagaContract(mockCtrl)),
+			util.WithAuditService(auditMock.NewMockAuditContract(mockCtrl)),
+			util.WithMagicLinkService(magicLinkMock.NewMockMagicLinkContract(mockCtrl)),
+			util.WithCredentialService(credentialMock.NewMockCredentialContract(mockCtrl)),
+			util.WithAPIKeyService(apiKeyMock.NewMockAPIKeyContract(mockCtrl)),
+			util.WithConsentService(consentMock.NewMockConsentContract(mockCtrl)),
+			util.WithGroupService(groupMock.NewMockGroupContract(mockCtrl)),
+			util.WithInvitationService(invitationMock.NewMockInvitationContract(mockCtrl)),
+			util.WithSessionService(sessionMock.NewMockSessionContract(mockCtrl)),
+			util.WithWebhookService(webhookMock.NewMockWebhookContract(mockCtrl)),
+		}, options...)...)
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		for _, option := range configuration.Options() {
+			os.Unsetenv(option.EnvironmentVariable)
+		}
+
+		grpcPort := getFreePort()
+		grpcAddress = "127.0.0.1:" + strconv.Itoa(grpcPort)
+
+		os.Setenv("REPOSITORY_TYPE", "memory")
+		os.Setenv("JWKS_URL", "http://127.0.0.1:1/jwks")
+		os.Setenv("GRPC_HOST", "127.0.0.1")
+		os.Setenv("GRPC_PORT", strconv.Itoa(grpcPort))
+		os.Setenv("GRPC_SHUTDOWN_DRAIN_DELAY", "0s")
+		os.Setenv("HTTP_HOST", "127.0.0.1")
+		os.Setenv("HTTP_PORT", strconv.Itoa(getFreePort()))
+		os.Setenv("GRAPHQL_HOST", "127.0.0.1")
+		os.Setenv("GRAPHQL_PORT", strconv.Itoa(getFreePort()))
+
+		var err error
+		configurationService, err = configuration.NewEnvConfigurationService()
+		Ω(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+
+		for _, option := range configuration.Options() {
+			os.Unsetenv(option.EnvironmentVariable)
+		}
+	})
+
+	Context("the server is created", func() {
+		When("the configuration service is not provided", func() {
+			It("should return ArgumentNilError", func() {
+				server, err := util.NewServer(nil)
+				Ω(server).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("the configuration is invalid", func() {
+			It("should return error", func() {
+				os.Setenv("REPOSITORY_TYPE", "unknown")
+
+				var err error
+				configurationService, err = configuration.NewEnvConfigurationService()
+				Ω(err).Should(BeNil())
+
+				server, err := newServer()
+				Ω(server).Should(BeNil())
+				Ω(err).ShouldNot(BeNil())
+			})
+		})
+
+		It("should serve the users from the services provided through the options", func() {
+			clockService, err := clock.NewClockService()
+			Ω(err).Should(BeNil())
+
+			repositoryService, err := memory.NewMemoryRepositoryService(clockService)
+			Ω(err).Should(BeNil())
+
+			_, err = repositoryService.CreateUser(context.Background(), &repository.CreateUserRequest{Email: "user@test.com"})
+			Ω(err).Should(BeNil())
+
+			mockAPIKeyService := apiKeyMock.NewMockAPIKeyContract(mockCtrl)
+			mockAPIKeyService.EXPECT().ResolveAPIKey(gomock.Any(), "test-key").Return(&models.APIKey{
+				KeyID:  "test",
+				UserID: "test-user",
+				Scopes: []models.APIKeyScope{models.APIKeyScopeRead},
+			}, "user@test.com", nil).AnyTimes()
+
+			server, err := newServer(
+				util.WithClockService(clockService),
+				util.WithRepositoryService(repositoryService),
+				util.WithAPIKeyService(mockAPIKeyService))
+			Ω(err).Should(BeNil())
+
+			_, err = server.Start()
+			Ω(err).Should(BeNil())
+
+			defer func() {
+				Ω(server.Stop()).Should(BeNil())
+			}()
+
+			var response *userGRPCContract.ReadUserResponse
+			Eventually(func() error {
+				response, err = readUser("user@test.com")
+
+				return err
+			}, 5*time.Second, 50*time.Millisecond).Should(Succeed())
+
+			Ω(response.Error).Should(Equal(userGRPCContract.Error_NO_ERROR), response.ErrorMessage)
+			Ω(response.Email).Should(Equal("user@test.com"))
+		})
+	})
+
+	Context("the server is started", func() {
+		var (
+			server *util.Server
+		)
+
+		BeforeEach(func() {
+			var err error
+			server, err = newServer()
+			Ω(err).Should(BeNil())
+		})
+
+		AfterEach(func() {
+			Ω(server.Stop()).Should(BeNil())
+		})
+
+		It("should serve until it is stopped", func() {
+			_, err := server.Start()
+			Ω(err).Should(BeNil())
+
+			Eventually(func() error {
+				connection, err := net.Dial("tcp", grpcAddress)
+				if err == nil {
+					connection.Close()
+				}
+
+				return err
+			}, 5*time.Second, 50*time.Millisecond).Should(Succeed())
+
+			Ω(server.Stop()).Should(BeNil())
+
+			_, err = net.Dial("tcp", grpcAddress)
+			Ω(err).ShouldNot(BeNil())
+		})
+
+		It("should return error if it is started more than once", func() {
+			_, err := server.Start()
+			Ω(err).Should(BeNil())
+
+			_, err = server.Start()
+			Ω(err).ShouldNot(BeNil())
+		})
+
+		It("should return error if it is started after it is stopped", func() {
+			Ω(server.Stop()).Should(BeNil())
+
+			_, err := server.Start()
+			Ω(err).ShouldNot(BeNil())
+		})
+
+		When("a service fails to start", func() {
+			It("should report the failed service through the channel", func() {
+				listener, err := net.Listen("tcp", grpcAddress)
+				Ω(err).Should(BeNil())
+
+				defer listener.Close()
+
+				errs, err := server.Start()
+				Ω(err).Should(BeNil())
+
+				var startErr error
+				Eventually(errs, 5*time.Second).Should(Receive(&startErr))
+				Ω(startErr.Error()).Should(ContainSubstring("failed to start gRPC transport service"))
+			})
+		})
+	})
+})
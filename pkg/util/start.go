@@ -5,25 +5,90 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"syscall"
 
+	"github.com/decentralized-cloud/user/pkg/fips"
+	"github.com/decentralized-cloud/user/services/apikey"
+	apiKeyMongodb "github.com/decentralized-cloud/user/services/apikey/mongodb"
+	apiKeyPostgres "github.com/decentralized-cloud/user/services/apikey/postgres"
+	"github.com/decentralized-cloud/user/services/audit"
+	auditMongodb "github.com/decentralized-cloud/user/services/audit/mongodb"
+	auditPostgres "github.com/decentralized-cloud/user/services/audit/postgres"
 	"github.com/decentralized-cloud/user/services/business"
+	"github.com/decentralized-cloud/user/services/canary"
+	"github.com/decentralized-cloud/user/services/clock"
 	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/consent"
+	consentMongodb "github.com/decentralized-cloud/user/services/consent/mongodb"
+	consentPostgres "github.com/decentralized-cloud/user/services/consent/postgres"
+	"github.com/decentralized-cloud/user/services/correlation"
+	"github.com/decentralized-cloud/user/services/credential"
+	credentialMongodb "github.com/decentralized-cloud/user/services/credential/mongodb"
+	credentialPostgres "github.com/decentralized-cloud/user/services/credential/postgres"
+	"github.com/decentralized-cloud/user/services/deprecation"
 	"github.com/decentralized-cloud/user/services/endpoint"
-	"github.com/decentralized-cloud/user/services/repository/mongodb"
+	"github.com/decentralized-cloud/user/services/eventing"
+	"github.com/decentralized-cloud/user/services/eventing/delivering"
+	"github.com/decentralized-cloud/user/services/eventing/nats"
+	"github.com/decentralized-cloud/user/services/eventing/noop"
+	"github.com/decentralized-cloud/user/services/eventing/notifying"
+	"github.com/decentralized-cloud/user/services/group"
+	groupMongodb "github.com/decentralized-cloud/user/services/group/mongodb"
+	groupPostgres "github.com/decentralized-cloud/user/services/group/postgres"
+	"github.com/decentralized-cloud/user/services/idgenerator"
+	"github.com/decentralized-cloud/user/services/invitation"
+	invitationMongodb "github.com/decentralized-cloud/user/services/invitation/mongodb"
+	invitationPostgres "github.com/decentralized-cloud/user/services/invitation/postgres"
+	"github.com/decentralized-cloud/user/services/magiclink"
+	magiclinkMongodb "github.com/decentralized-cloud/user/services/magiclink/mongodb"
+	magiclinkPostgres "github.com/decentralized-cloud/user/services/magiclink/postgres"
+	"github.com/decentralized-cloud/user/services/mailer"
+	mailerEvent "github.com/decentralized-cloud/user/services/mailer/event"
+	mailerSmtp "github.com/decentralized-cloud/user/services/mailer/smtp"
+	"github.com/decentralized-cloud/user/services/objectstorage"
+	objectStorageDisabled "github.com/decentralized-cloud/user/services/objectstorage/disabled"
+	objectStorageS3 "github.com/decentralized-cloud/user/services/objectstorage/s3"
+	"github.com/decentralized-cloud/user/services/payloadlogging"
+	"github.com/decentralized-cloud/user/services/purger"
+	"github.com/decentralized-cloud/user/services/redaction"
+	"github.com/decentralized-cloud/user/services/replication"
+	replicationMongodb "github.com/decentralized-cloud/user/services/replication/mongodb"
+	replicationNoop "github.com/decentralized-cloud/user/services/replication/noop"
+	replicationPostgres "github.com/decentralized-cloud/user/services/replication/postgres"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/repository/factory"
+	"github.com/decentralized-cloud/user/services/saga"
+	sagaMongodb "github.com/decentralized-cloud/user/services/saga/mongodb"
+	sagaPostgres "github.com/decentralized-cloud/user/services/saga/postgres"
+	"github.com/decentralized-cloud/user/services/session"
+	sessionMongodb "github.com/decentralized-cloud/user/services/session/mongodb"
+	sessionPostgres "github.com/decentralized-cloud/user/services/session/postgres"
+	"github.com/decentralized-cloud/user/services/slo"
+	"github.com/decentralized-cloud/user/services/transport/graphql"
 	"github.com/decentralized-cloud/user/services/transport/grpc"
 	"github.com/decentralized-cloud/user/services/transport/https"
+	"github.com/decentralized-cloud/user/services/watch"
+	watchMongodb "github.com/decentralized-cloud/user/services/watch/mongodb"
+	watchNats "github.com/decentralized-cloud/user/services/watch/nats"
+	"github.com/decentralized-cloud/user/services/webhook"
+	webhookMongodb "github.com/decentralized-cloud/user/services/webhook/mongodb"
+	webhookPostgres "github.com/decentralized-cloud/user/services/webhook/postgres"
 	"github.com/micro-business/go-core/gokit/middleware"
+	commonErrors "github.com/micro-business/go-core/system/errors"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
-var configurationService configuration.ConfigurationContract
-var endpointCreatorService endpoint.EndpointCreatorContract
-var middlewareProviderService middleware.MiddlewareProviderContract
-
 // StartService setups all dependecies required to start the user service and
 // start the service
-func StartService() {
-	logger, err := zap.NewProduction()
+// configurationServiceToUse: Mandatory. Reference to the service that provides required configurations
+func StartService(configurationServiceToUse configuration.ConfigurationContract) {
+	if configurationServiceToUse == nil {
+		log.Fatal("configurationService is required")
+	}
+
+	logger, logLevel, err := setupLogger(configurationServiceToUse)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -32,81 +97,723 @@ func StartService() {
 		_ = logger.Sync()
 	}()
 
-	if err = setupDependencies(logger); err != nil {
-		logger.Fatal("failed to setup dependecies", zap.Error(err))
+	// The FIPS build must not fall back to the standard Go crypto silently, e.g. on a platform BoringCrypto does not support
+	if fips.Built && !fips.Enabled() {
+		logger.Fatal("the FIPS build is running without BoringCrypto")
 	}
 
-	grpcTransportService, err := grpc.NewTransportService(
-		logger,
-		configurationService,
-		endpointCreatorService,
-		middlewareProviderService)
+	logger.Info("Crypto module", zap.String("mode", fips.Mode()))
+
+	server, err := NewServer(configurationServiceToUse, WithLogger(logger), WithLogLevel(logLevel))
 	if err != nil {
-		logger.Fatal("failed to create gRPC transport service", zap.Error(err))
+		logger.Fatal("failed to setup dependecies", zap.Error(err))
 	}
 
-	httpsTansportService, err := https.NewTransportService(
-		logger,
-		configurationService)
+	errs, err := server.Start()
 	if err != nil {
-		logger.Fatal("failed to create HTTPS transport service", zap.Error(err))
+		logger.Fatal("failed to start the services", zap.Error(err))
 	}
 
 	signalChan := make(chan os.Signal, 1)
-	cleanupDone := make(chan struct{})
 	signal.Notify(signalChan, os.Interrupt)
 
-	go func() {
-		if serviceErr := grpcTransportService.Start(); serviceErr != nil {
-			logger.Fatal("failed to start gRPC transport service", zap.Error(serviceErr))
-		}
-	}()
+	// SIGUSR1 switches the logger between the debug level and the configured level while the service is running
+	levelSignalChan := make(chan os.Signal, 1)
+	signal.Notify(levelSignalChan, syscall.SIGUSR1)
 
-	go func() {
-		if serviceErr := httpsTansportService.Start(); serviceErr != nil {
-			logger.Fatal("failed to start HTTPS transport service", zap.Error(serviceErr))
+	configuredLevel := logLevel.Level()
+
+waitLoop:
+	for {
+		select {
+		case err = <-errs:
+			logger.Fatal("service failed", zap.Error(err))
+		case <-levelSignalChan:
+			toggleLogLevel(logger, logLevel, configuredLevel)
+		case <-signalChan:
+			break waitLoop
 		}
-	}()
+	}
 
-	go func() {
-		<-signalChan
-		logger.Info("Received an interrupt, stopping services...")
+	logger.Info("Received an interrupt, stopping services...")
 
-		if err := grpcTransportService.Stop(); err != nil {
-			logger.Error("failed to stop gRPC transport service", zap.Error(err))
-		}
+	_ = server.Stop()
+}
 
-		if err := httpsTansportService.Stop(); err != nil {
-			logger.Error("failed to stop HTTPS transport service", zap.Error(err))
+// setupDependencies creates every service of the server that is not provided through the options
+// Returns error if something goes wrong
+func (server *Server) setupDependencies() (err error) {
+	if server.logger == nil {
+		var logLevel zap.AtomicLevel
+		if server.logger, logLevel, err = setupLogger(server.configurationService); err != nil {
+			return
 		}
 
-		close(cleanupDone)
-	}()
-	<-cleanupDone
-}
+		server.logLevel = &logLevel
+	}
+
+	if server.middlewareProviderService, err = middleware.NewMiddlewareProviderService(server.logger, true, ""); err != nil {
+		return
+	}
+
+	if server.sloService, err = slo.NewSloService(server.configurationService); err != nil {
+		return
+	}
+
+	if server.deprecationService, err = deprecation.NewDeprecationService(protoregistry.GlobalFiles, deprecation.Guidance); err != nil {
+		return
+	}
+
+	if server.correlationService, err = correlation.NewCorrelationService(server.logger); err != nil {
+		return
+	}
 
-func setupDependencies(logger *zap.Logger) (err error) {
-	if configurationService, err = configuration.NewEnvConfigurationService(); err != nil {
+	redactedUserFields, err := server.configurationService.GetRedactedUserFields()
+	if err != nil {
 		return
 	}
 
-	if middlewareProviderService, err = middleware.NewMiddlewareProviderService(logger, true, ""); err != nil {
+	if server.redactionService, err = redaction.NewRedactionService(redactedUserFields); err != nil {
+		return
+	}
+
+	payloadLoggingEnabled, err := server.configurationService.GetPayloadLoggingEnabled()
+	if err != nil {
 		return
 	}
 
-	repositoryService, err := mongodb.NewMongodbRepositoryService(configurationService)
+	payloadLoggingRedactedFields, err := server.configurationService.GetPayloadLoggingRedactedFields()
 	if err != nil {
 		return
 	}
 
-	businessService, err := business.NewBusinessService(repositoryService)
+	if server.payloadLoggingService, err = payloadlogging.NewPayloadLoggingService(
+		server.logger,
+		payloadLoggingEnabled,
+		payloadLoggingRedactedFields); err != nil {
+		return
+	}
+
+	if server.clockService == nil {
+		if server.clockService, err = clock.NewClockService(); err != nil {
+			return
+		}
+	}
+
+	if server.idGeneratorService == nil {
+		if server.idGeneratorService, err = idgenerator.NewIDGeneratorService(); err != nil {
+			return
+		}
+	}
+
+	if server.repositoryService == nil {
+		if err = server.setupRepositoryService(); err != nil {
+			return
+		}
+	}
+
+	if server.watchService == nil {
+		if server.watchService, err = server.setupWatchService(); err != nil {
+			return
+		}
+	}
+
+	if server.webhookService == nil {
+		if server.webhookService, err = server.setupWebhookService(); err != nil {
+			return
+		}
+	}
+
+	if server.eventingService == nil {
+		if server.eventingService, err = server.setupEventingService(); err != nil {
+			return
+		}
+	}
+
+	if server.sagaService == nil {
+		if server.sagaService, err = server.setupSagaService(); err != nil {
+			return
+		}
+	}
+
+	if server.auditService == nil {
+		if server.auditService, err = server.setupAuditService(); err != nil {
+			return
+		}
+	}
+
+	if server.replicationService == nil {
+		if server.replicationService, err = server.setupReplicationService(); err != nil {
+			return
+		}
+	}
+
+	if server.mailerService == nil {
+		if server.mailerService, err = server.setupMailerService(); err != nil {
+			return
+		}
+	}
+
+	if server.credentialService == nil {
+		if server.credentialService, err = server.setupCredentialService(); err != nil {
+			return
+		}
+	}
+
+	if server.apiKeyService == nil {
+		if server.apiKeyService, err = server.setupAPIKeyService(); err != nil {
+			return
+		}
+	}
+
+	if server.objectStorageService == nil {
+		if server.objectStorageService, err = server.setupObjectStorageService(); err != nil {
+			return
+		}
+	}
+
+	if server.consentService == nil {
+		if server.consentService, err = server.setupConsentService(); err != nil {
+			return
+		}
+	}
+
+	if server.groupService == nil {
+		if server.groupService, err = server.setupGroupService(); err != nil {
+			return
+		}
+	}
+
+	if server.invitationService == nil {
+		if server.invitationService, err = server.setupInvitationService(); err != nil {
+			return
+		}
+	}
+
+	if server.sessionService == nil {
+		if server.sessionService, err = server.setupSessionService(); err != nil {
+			return
+		}
+	}
+
+	if server.magicLinkService == nil {
+		if server.magicLinkService, err = server.setupMagicLinkService(); err != nil {
+			return
+		}
+	}
+
+	businessService, err := business.NewBusinessService(business.Dependencies{
+		Logger:               server.logger,
+		ConfigurationService: server.configurationService,
+		RepositoryService:    server.repositoryService,
+		EventingService:      server.eventingService,
+		SagaService:          server.sagaService,
+		AuditService:         server.auditService,
+		ReplicationService:   server.replicationService,
+		ClockService:         server.clockService,
+		MailerService:        server.mailerService,
+		CredentialService:    server.credentialService,
+		WatchService:         server.watchService,
+		APIKeyService:        server.apiKeyService,
+		ObjectStorageService: server.objectStorageService,
+		ConsentService:       server.consentService,
+		GroupService:         server.groupService,
+		InvitationService:    server.invitationService,
+		SessionService:       server.sessionService,
+		WebhookService:       server.webhookService,
+		MagicLinkService:     server.magicLinkService,
+	})
 	if err != nil {
 		return err
 	}
 
-	if endpointCreatorService, err = endpoint.NewEndpointCreatorService(businessService); err != nil {
+	canaryValidationService, err := canary.NewCanaryValidationService(server.logger, server.configurationService, canary.Rules)
+	if err != nil {
+		return
+	}
+
+	if server.endpointCreatorService, err = endpoint.NewEndpointCreatorService(businessService, canaryValidationService, server.configurationService); err != nil {
 		return
 	}
 
 	return
 }
+
+// setupRunnables creates the transports and the background services the server runs once it is started
+// Returns error if something goes wrong
+func (server *Server) setupRunnables() error {
+	grpcTransportService, err := grpc.NewTransportService(
+		server.logger,
+		server.configurationService,
+		server.endpointCreatorService,
+		server.middlewareProviderService,
+		server.sloService,
+		server.deprecationService,
+		server.correlationService,
+		server.redactionService,
+		server.payloadLoggingService,
+		server.apiKeyService,
+		server.groupService)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to create gRPC transport service", err)
+	}
+
+	graphqlTransportService, err := graphql.NewTransportService(
+		server.logger,
+		server.configurationService,
+		server.endpointCreatorService,
+		server.middlewareProviderService,
+		server.sloService,
+		server.correlationService)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to create GraphQL transport service", err)
+	}
+
+	httpsTansportService, err := https.NewTransportService(
+		server.logger,
+		server.logLevel,
+		server.configurationService,
+		server.repositoryService,
+		grpcTransportService)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to create HTTPS transport service", err)
+	}
+
+	purgerService, err := purger.NewPurgerService(
+		server.logger,
+		server.configurationService,
+		server.repositoryService,
+		server.clockService)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to create purger service", err)
+	}
+
+	server.runnables = []namedRunnable{
+		{name: "gRPC transport service", service: grpcTransportService},
+		{name: "GraphQL transport service", service: graphqlTransportService},
+		{name: "HTTPS transport service", service: httpsTansportService},
+		{name: "purger service", service: purgerService},
+		{name: "replication service", service: server.replicationService},
+	}
+
+	return nil
+}
+
+// setupLogger creates the logger that logs at the level and in the format set by the configuration
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns either the logger and the level it logs at, which can be changed while the logger is in use, or error if
+// something goes wrong
+func setupLogger(configurationService configuration.ConfigurationContract) (*zap.Logger, zap.AtomicLevel, error) {
+	level, err := configurationService.GetLogLevel()
+	if err != nil {
+		return nil, zap.AtomicLevel{}, err
+	}
+
+	format, err := configurationService.GetLogFormat()
+	if err != nil {
+		return nil, zap.AtomicLevel{}, err
+	}
+
+	config := zap.NewProductionConfig()
+	if format == "console" {
+		config.Encoding = "console"
+		config.EncoderConfig = zap.NewDevelopmentEncoderConfig()
+	}
+
+	if err = config.Level.UnmarshalText([]byte(level)); err != nil {
+		return nil, zap.AtomicLevel{}, err
+	}
+
+	logger, err := config.Build()
+	if err != nil {
+		return nil, zap.AtomicLevel{}, err
+	}
+
+	return logger, config.Level, nil
+}
+
+// toggleLogLevel switches the logger to the debug level, or back to the configured level if it already logs at the
+// debug level
+// logger: Mandatory. Reference to the logger service
+// logLevel: Mandatory. The level the logger logs at
+// configuredLevel: Mandatory. The level set by the configuration
+func toggleLogLevel(logger *zap.Logger, logLevel zap.AtomicLevel, configuredLevel zapcore.Level) {
+	level := zapcore.DebugLevel
+	if logLevel.Level() == zapcore.DebugLevel {
+		level = configuredLevel
+	}
+
+	logLevel.SetLevel(level)
+	logger.Info("Log level changed", zap.Stringer("level", level))
+}
+
+// NewDatabaseRepositoryService creates the repository service that persists every user straight into the database of
+// the region the user resides in, bypassing the cache. It is used by the commands that manage the users without
+// starting the service.
+// configurationServiceToUse: Mandatory. Reference to the service that provides required configurations
+// Returns either the repository service or error if something goes wrong
+func NewDatabaseRepositoryService(configurationServiceToUse configuration.ConfigurationContract) (repository.RepositoryContract, error) {
+	if configurationServiceToUse == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationServiceToUse", "configurationServiceToUse is required")
+	}
+
+	clockService, err := clock.NewClockService()
+	if err != nil {
+		return nil, err
+	}
+
+	idGeneratorService, err := idgenerator.NewIDGeneratorService()
+	if err != nil {
+		return nil, err
+	}
+
+	return factory.NewDatabaseRepositoryService(factory.Dependencies{
+		ConfigurationService: configurationServiceToUse,
+		ClockService:         clockService,
+		IDGeneratorService:   idGeneratorService,
+	})
+}
+
+func (server *Server) setupRepositoryService() (err error) {
+	server.repositoryService, server.cacheInvalidationBus, err = factory.NewRepositoryService(factory.Dependencies{
+		Logger:               server.logger,
+		ConfigurationService: server.configurationService,
+		ClockService:         server.clockService,
+		IDGeneratorService:   server.idGeneratorService,
+	})
+
+	return
+}
+
+func (server *Server) setupSagaService() (saga.SagaContract, error) {
+	databaseType, err := server.configurationService.GetDatabaseType()
+	if err != nil {
+		return nil, err
+	}
+
+	var storeService saga.StoreContract
+	if databaseType == "postgres" {
+		storeService, err = sagaPostgres.NewPostgresStoreService(server.configurationService)
+	} else {
+		storeService, err = sagaMongodb.NewMongodbStoreService(server.configurationService)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return saga.NewSagaService(server.logger, server.configurationService, storeService, server.clockService, server.idGeneratorService)
+}
+
+func (server *Server) setupAuditService() (audit.AuditContract, error) {
+	databaseType, err := server.configurationService.GetDatabaseType()
+	if err != nil {
+		return nil, err
+	}
+
+	var storeService audit.StoreContract
+	if databaseType == "postgres" {
+		storeService, err = auditPostgres.NewPostgresStoreService(server.configurationService)
+	} else {
+		storeService, err = auditMongodb.NewMongodbStoreService(server.configurationService)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return audit.NewAuditService(server.logger, storeService, server.clockService, server.idGeneratorService)
+}
+
+func (server *Server) setupMagicLinkService() (magiclink.MagicLinkContract, error) {
+	databaseType, err := server.configurationService.GetDatabaseType()
+	if err != nil {
+		return nil, err
+	}
+
+	var storeService magiclink.StoreContract
+	if databaseType == "postgres" {
+		storeService, err = magiclinkPostgres.NewPostgresStoreService(server.configurationService)
+	} else {
+		storeService, err = magiclinkMongodb.NewMongodbStoreService(server.configurationService)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return magiclink.NewMagicLinkService(server.configurationService, storeService, server.clockService, server.idGeneratorService)
+}
+
+func (server *Server) setupCredentialService() (credential.CredentialContract, error) {
+	databaseType, err := server.configurationService.GetDatabaseType()
+	if err != nil {
+		return nil, err
+	}
+
+	var storeService credential.StoreContract
+	if databaseType == "postgres" {
+		storeService, err = credentialPostgres.NewPostgresStoreService(server.configurationService)
+	} else {
+		storeService, err = credentialMongodb.NewMongodbStoreService(server.configurationService)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return credential.NewCredentialService(server.configurationService, storeService, server.clockService)
+}
+
+func (server *Server) setupAPIKeyService() (apikey.APIKeyContract, error) {
+	databaseType, err := server.configurationService.GetDatabaseType()
+	if err != nil {
+		return nil, err
+	}
+
+	var storeService apikey.StoreContract
+	if databaseType == "postgres" {
+		storeService, err = apiKeyPostgres.NewPostgresStoreService(server.configurationService)
+	} else {
+		storeService, err = apiKeyMongodb.NewMongodbStoreService(server.configurationService)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return apikey.NewAPIKeyService(server.configurationService, storeService, server.repositoryService, server.clockService, server.idGeneratorService)
+}
+
+func (server *Server) setupConsentService() (consent.ConsentContract, error) {
+	databaseType, err := server.configurationService.GetDatabaseType()
+	if err != nil {
+		return nil, err
+	}
+
+	var storeService consent.StoreContract
+	if databaseType == "postgres" {
+		storeService, err = consentPostgres.NewPostgresStoreService(server.configurationService)
+	} else {
+		storeService, err = consentMongodb.NewMongodbStoreService(server.configurationService)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return consent.NewConsentService(server.configurationService, storeService, server.clockService, server.idGeneratorService)
+}
+
+func (server *Server) setupGroupService() (group.GroupContract, error) {
+	databaseType, err := server.configurationService.GetDatabaseType()
+	if err != nil {
+		return nil, err
+	}
+
+	var storeService group.StoreContract
+	if databaseType == "postgres" {
+		storeService, err = groupPostgres.NewPostgresStoreService(server.configurationService)
+	} else {
+		storeService, err = groupMongodb.NewMongodbStoreService(server.configurationService)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return group.NewGroupService(server.configurationService, storeService, server.clockService, server.idGeneratorService)
+}
+
+func (server *Server) setupInvitationService() (invitation.InvitationContract, error) {
+	databaseType, err := server.configurationService.GetDatabaseType()
+	if err != nil {
+		return nil, err
+	}
+
+	var storeService invitation.StoreContract
+	if databaseType == "postgres" {
+		storeService, err = invitationPostgres.NewPostgresStoreService(server.configurationService)
+	} else {
+		storeService, err = invitationMongodb.NewMongodbStoreService(server.configurationService)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation.NewInvitationService(server.configurationService, storeService, server.clockService, server.idGeneratorService)
+}
+
+func (server *Server) setupSessionService() (session.SessionContract, error) {
+	databaseType, err := server.configurationService.GetDatabaseType()
+	if err != nil {
+		return nil, err
+	}
+
+	var storeService session.StoreContract
+	if databaseType == "postgres" {
+		storeService, err = sessionPostgres.NewPostgresStoreService(server.configurationService)
+	} else {
+		storeService, err = sessionMongodb.NewMongodbStoreService(server.configurationService)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return session.NewSessionService(server.configurationService, storeService, server.clockService)
+}
+
+func (server *Server) setupWebhookService() (webhook.WebhookContract, error) {
+	databaseType, err := server.configurationService.GetDatabaseType()
+	if err != nil {
+		return nil, err
+	}
+
+	var storeService webhook.StoreContract
+	if databaseType == "postgres" {
+		storeService, err = webhookPostgres.NewPostgresStoreService(server.configurationService)
+	} else {
+		storeService, err = webhookMongodb.NewMongodbStoreService(server.configurationService)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook.NewWebhookService(server.logger, server.configurationService, storeService, server.clockService, server.idGeneratorService)
+}
+
+func (server *Server) setupReplicationService() (replication.ReplicationContract, error) {
+	standbyConnectionString, err := server.configurationService.GetReplicationStandbyConnectionString()
+	if err != nil {
+		return nil, err
+	}
+
+	if standbyConnectionString == "" {
+		return replicationNoop.NewNoopReplicationService()
+	}
+
+	primaryConnectionString, err := server.configurationService.GetDatabaseConnectionString()
+	if err != nil {
+		return nil, err
+	}
+
+	databaseType, err := server.configurationService.GetDatabaseType()
+	if err != nil {
+		return nil, err
+	}
+
+	var primaryStoreService, standbyStoreService replication.HeartbeatStoreContract
+	if databaseType == "postgres" {
+		if primaryStoreService, err = replicationPostgres.NewPostgresHeartbeatStoreService(server.configurationService, primaryConnectionString, false); err != nil {
+			return nil, err
+		}
+
+		// The standby database is read only until the failover, the heartbeat table is replicated from the primary database
+		standbyStoreService, err = replicationPostgres.NewPostgresHeartbeatStoreService(server.configurationService, standbyConnectionString, true)
+	} else {
+		if primaryStoreService, err = replicationMongodb.NewMongodbHeartbeatStoreService(server.configurationService, primaryConnectionString); err != nil {
+			return nil, err
+		}
+
+		standbyStoreService, err = replicationMongodb.NewMongodbHeartbeatStoreService(server.configurationService, standbyConnectionString)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return replication.NewReplicationService(server.logger, server.configurationService, server.clockService, primaryStoreService, standbyStoreService)
+}
+
+func (server *Server) setupWatchService() (watch.WatchContract, error) {
+	source, err := server.configurationService.GetWatchSource()
+	if err != nil {
+		return nil, err
+	}
+
+	if source != "change-stream" {
+		return watch.NewWatchService(server.logger, server.configurationService)
+	}
+
+	databaseType, err := server.configurationService.GetDatabaseType()
+	if err != nil {
+		return nil, err
+	}
+
+	if databaseType != "mongodb" {
+		return nil, commonErrors.NewUnknownError("USER_WATCH_SOURCE change-stream requires DATABASE_TYPE to be mongodb")
+	}
+
+	return watchMongodb.NewMongodbChangeStreamService(server.logger, server.configurationService)
+}
+
+func (server *Server) setupEventingService() (eventing.EventingContract, error) {
+	eventingService, err := server.setupBrokerEventingService()
+	if err != nil {
+		return nil, err
+	}
+
+	return delivering.NewDeliveringEventingService(server.logger, eventingService, server.repositoryService, server.webhookService)
+}
+
+func (server *Server) setupBrokerEventingService() (eventing.EventingContract, error) {
+	broker, err := server.configurationService.GetEventingBroker()
+	if err != nil {
+		return nil, err
+	}
+
+	watchSource, err := server.configurationService.GetWatchSource()
+	if err != nil {
+		return nil, err
+	}
+
+	// The watchers are notified by a single source, so a change is never delivered twice. Every replica receives the
+	// events all the replicas publish to NATS, otherwise only the changes this replica makes can be watched. The
+	// change stream already carries the changes all the replicas make.
+	if broker == "nats" {
+		if watchSource == "events" {
+			if server.watchSourceService, err = watchNats.NewNatsSourceService(server.logger, server.configurationService, server.watchService); err != nil {
+				return nil, err
+			}
+		}
+
+		return nats.NewNatsEventingService(server.logger, server.configurationService)
+	}
+
+	noopEventingService, err := noop.NewNoopEventingService()
+	if err != nil {
+		return nil, err
+	}
+
+	return notifying.NewNotifyingEventingService(noopEventingService, server.watchService, server.clockService)
+}
+
+func (server *Server) setupMailerService() (mailer.MailerContract, error) {
+	provider, err := server.configurationService.GetEmailVerificationProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	if provider == "smtp" {
+		return mailerSmtp.NewSmtpMailerService(server.configurationService)
+	}
+
+	return mailerEvent.NewEventMailerService(server.eventingService)
+}
+
+func (server *Server) setupObjectStorageService() (objectstorage.ObjectStorageContract, error) {
+	avatarsEnabled, err := server.configurationService.GetAvatarsEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	// The object storage is only required by the avatars, so the bucket does not need to be configured otherwise
+	if avatarsEnabled {
+		return objectStorageS3.NewS3ObjectStorageService(server.configurationService)
+	}
+
+	return objectStorageDisabled.NewDisabledObjectStorageService()
+}
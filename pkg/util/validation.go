@@ -0,0 +1,125 @@
+// Package util implements different utilities required by the user service
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/lestrrat-go/jwx/jwk"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+const (
+	reachabilityCheckDatabase = "database"
+	reachabilityCheckJwks     = "jwks"
+)
+
+// NewReachabilityChecks returns the checks verifying the database and the JWKS endpoint the configuration points at
+// can be reached. The checks only connect to them, nothing is created or migrated.
+// configurationServiceToUse: Mandatory. Reference to the service that provides required configurations
+// Returns the reachability checks to validate the configuration with
+func NewReachabilityChecks(configurationServiceToUse configuration.ConfigurationContract) []configuration.ValidationCheck {
+	return []configuration.ValidationCheck{
+		{
+			Name: reachabilityCheckDatabase,
+			Check: func(ctx context.Context) error {
+				return checkDatabaseReachability(ctx, configurationServiceToUse)
+			},
+		},
+		{
+			Name: reachabilityCheckJwks,
+			Check: func(ctx context.Context) error {
+				return checkJwksReachability(ctx, configurationServiceToUse)
+			},
+		},
+	}
+}
+
+// NewStartupReachabilityChecks returns the reachability checks the service is validated with before it starts. The
+// database is not checked if the startup check is enabled, the service is meant to start and wait for the database in
+// the background then.
+// configurationServiceToUse: Mandatory. Reference to the service that provides required configurations
+// Returns the reachability checks to validate the configuration with
+func NewStartupReachabilityChecks(configurationServiceToUse configuration.ConfigurationContract) []configuration.ValidationCheck {
+	checks := NewReachabilityChecks(configurationServiceToUse)
+
+	// The invalid option is reported by the validation of the options, so the database is simply checked then
+	if startupCheckEnabled, err := configurationServiceToUse.GetDatabaseStartupCheckEnabled(); err != nil || !startupCheckEnabled {
+		return checks
+	}
+
+	startupChecks := []configuration.ValidationCheck{}
+	for _, check := range checks {
+		if check.Name != reachabilityCheckDatabase {
+			startupChecks = append(startupChecks, check)
+		}
+	}
+
+	return startupChecks
+}
+
+func checkDatabaseReachability(ctx context.Context, configurationService configuration.ConfigurationContract) error {
+	databaseType, err := configurationService.GetDatabaseType()
+	if err != nil {
+		return err
+	}
+
+	connectionString, err := configurationService.GetDatabaseConnectionString()
+	if err != nil {
+		return err
+	}
+
+	switch databaseType {
+	case "postgres":
+		pool, err := pgxpool.Connect(ctx, connectionString)
+		if err != nil {
+			return fmt.Errorf("could not connect to postgres database: %w", err)
+		}
+
+		defer pool.Close()
+
+		if err = pool.Ping(ctx); err != nil {
+			return fmt.Errorf("could not ping postgres database: %w", err)
+		}
+
+		return nil
+
+	default:
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI(connectionString))
+		if err != nil {
+			return fmt.Errorf("could not connect to mongodb database: %w", err)
+		}
+
+		defer func() {
+			_ = client.Disconnect(context.Background())
+		}()
+
+		if err = client.Ping(ctx, readpref.Primary()); err != nil {
+			return fmt.Errorf("could not ping mongodb database: %w", err)
+		}
+
+		return nil
+	}
+}
+
+func checkJwksReachability(ctx context.Context, configurationService configuration.ConfigurationContract) error {
+	jwksURL, err := configurationService.GetJwksURL()
+	if err != nil {
+		return err
+	}
+
+	keySet, err := jwk.Fetch(ctx, jwksURL)
+	if err != nil {
+		return fmt.Errorf("could not fetch the JSON Web Key Set from %s: %w", jwksURL, err)
+	}
+
+	if keySet.Len() == 0 {
+		return fmt.Errorf("the JSON Web Key Set fetched from %s contains no key", jwksURL)
+	}
+
+	return nil
+}
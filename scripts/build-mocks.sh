@@ -24,3 +24,22 @@ docker cp extract-mock-builder:/src/services/transport/mock/mock-contract.go ./s
 docker cp extract-mock-builder:/src/services/business/mock/mock-contract.go ./services/business/mock/mock-contract.go
 docker cp extract-mock-builder:/src/services/configuration/mock/mock-contract.go ./services/configuration/mock/mock-contract.go
 docker cp extract-mock-builder:/src/services/endpoint/mock/mock-contract.go ./services/endpoint/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/eventing/mock/mock-contract.go ./services/eventing/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/saga/mock/mock-contract.go ./services/saga/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/canary/mock/mock-contract.go ./services/canary/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/audit/mock/mock-contract.go ./services/audit/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/clock/mock/mock-contract.go ./services/clock/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/idgenerator/mock/mock-contract.go ./services/idgenerator/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/repository/cached/mock/mock-contract.go ./services/repository/cached/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/replication/mock/mock-contract.go ./services/replication/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/magiclink/mock/mock-contract.go ./services/magiclink/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/mailer/mock/mock-contract.go ./services/mailer/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/objectstorage/mock/mock-contract.go ./services/objectstorage/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/credential/mock/mock-contract.go ./services/credential/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/watch/mock/mock-contract.go ./services/watch/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/apikey/mock/mock-contract.go ./services/apikey/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/consent/mock/mock-contract.go ./services/consent/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/group/mock/mock-contract.go ./services/group/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/invitation/mock/mock-contract.go ./services/invitation/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/session/mock/mock-contract.go ./services/session/mock/mock-contract.go
+docker cp extract-mock-builder:/src/services/webhook/mock/mock-contract.go ./services/webhook/mock/mock-contract.go
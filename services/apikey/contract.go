@@ -0,0 +1,95 @@
+// Package apikey implements the API keys the service accounts call the service with on behalf of the users, instead
+// of a token issued by the identity provider
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// APIKeyContract declares the service that mints, persists and resolves the API keys of the users
+type APIKeyContract interface {
+	// CreateAPIKey mints a new API key for the user and persists its hash
+	// ctx: Mandatory The reference to the context
+	// userID: Mandatory. The ID of the user that owns the API key
+	// name: Mandatory. The name the user recognizes the API key by
+	// scopes: Mandatory. The scopes the API key is allowed
+	// expiresAt: Optional. The time the API key expires at, the API key never expires if not provided
+	// Returns either the persisted API key and the key itself or error if something goes wrong. The key is not
+	// persisted, so it can not be returned again.
+	CreateAPIKey(
+		ctx context.Context,
+		userID string,
+		name string,
+		scopes []models.APIKeyScope,
+		expiresAt *time.Time) (*models.APIKey, string, error)
+
+	// ListAPIKeys lists all the API keys of the user, including the revoked and the expired ones
+	// ctx: Mandatory The reference to the context
+	// userID: Mandatory. The ID of the user
+	// Returns either the API keys of the user or error if something goes wrong.
+	ListAPIKeys(
+		ctx context.Context,
+		userID string) ([]models.APIKey, error)
+
+	// RevokeAPIKey revokes the API key of the user, so it can not be used anymore
+	// ctx: Mandatory The reference to the context
+	// userID: Mandatory. The ID of the user that owns the API key
+	// keyID: Mandatory. The ID of the API key
+	// Returns error if something goes wrong. NotFoundError is returned if the user has no API key with the ID.
+	RevokeAPIKey(
+		ctx context.Context,
+		userID string,
+		keyID string) error
+
+	// ResolveAPIKey resolves the key to the API key it is minted as and the current email address of the user that
+	// owns it
+	// ctx: Mandatory The reference to the context
+	// key: Mandatory. The key the caller provided
+	// Returns either the API key and the email address of its user or error if something goes wrong. NotFoundError
+	// is returned if the key is not a key minted by the service, if it is revoked or expired, or if the user that owns
+	// it no longer exists.
+	ResolveAPIKey(
+		ctx context.Context,
+		key string) (*models.APIKey, string, error)
+}
+
+// StoreContract declares the service that persists the hashes of the API keys
+type StoreContract interface {
+	// CreateAPIKey persists the new API key
+	// ctx: Mandatory The reference to the context
+	// apiKey: Mandatory. The API key to persist
+	// Returns error if something goes wrong.
+	CreateAPIKey(
+		ctx context.Context,
+		apiKey *models.APIKey) error
+
+	// ReadAPIKey reads the persisted API key
+	// ctx: Mandatory The reference to the context
+	// keyID: Mandatory. The ID of the API key
+	// Returns either the API key or error if something goes wrong. NotFoundError is returned if there is no API key
+	// with the ID.
+	ReadAPIKey(
+		ctx context.Context,
+		keyID string) (*models.APIKey, error)
+
+	// ListAPIKeys lists all the persisted API keys of the user ordered by the time they are created at
+	// ctx: Mandatory The reference to the context
+	// userID: Mandatory. The ID of the user
+	// Returns either the API keys of the user or error if something goes wrong.
+	ListAPIKeys(
+		ctx context.Context,
+		userID string) ([]models.APIKey, error)
+
+	// RevokeAPIKey records the time the API key is revoked at, it does nothing if the API key is already revoked
+	// ctx: Mandatory The reference to the context
+	// keyID: Mandatory. The ID of the API key
+	// revokedAt: Mandatory. The time the API key is revoked at
+	// Returns error if something goes wrong. NotFoundError is returned if there is no API key with the ID.
+	RevokeAPIKey(
+		ctx context.Context,
+		keyID string,
+		revokedAt time.Time) error
+}
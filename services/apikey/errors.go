@@ -0,0 +1,41 @@
+// Package apikey implements the API keys the service accounts call the service with on behalf of the users, instead
+// of a token issued by the identity provider
+package apikey
+
+import (
+	"errors"
+	"fmt"
+)
+
+// LimitExceededError indicates the user already has as many API keys as allowed, one of them must be revoked before a
+// new one is created
+type LimitExceededError struct {
+	UserID string
+	Limit  int
+}
+
+// Error returns message for the LimitExceededError error type
+// Returns the formatted error message
+func (e LimitExceededError) Error() string {
+	return fmt.Sprintf("the user %s already has %d API keys that are neither revoked nor expired", e.UserID, e.Limit)
+}
+
+// IsLimitExceededError indicates whether the error is of type LimitExceededError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type LimitExceededError
+func IsLimitExceededError(err error) bool {
+	var limitExceededError LimitExceededError
+
+	return errors.As(err, &limitExceededError)
+}
+
+// NewLimitExceededError creates a new LimitExceededError error
+// userID: Mandatory. The ID of the user
+// limit: Mandatory. The maximum number of the API keys a user can have
+// Returns the new error
+func NewLimitExceededError(userID string, limit int) error {
+	return LimitExceededError{
+		UserID: userID,
+		Limit:  limit,
+	}
+}
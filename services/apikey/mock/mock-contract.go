@@ -0,0 +1,179 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/apikey/contract.go
+
+// Package mock_apikey is a generated GoMock package.
+package mock_apikey
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/decentralized-cloud/user/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAPIKeyContract is a mock of APIKeyContract interface.
+type MockAPIKeyContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyContractMockRecorder
+}
+
+// MockAPIKeyContractMockRecorder is the mock recorder for MockAPIKeyContract.
+type MockAPIKeyContractMockRecorder struct {
+	mock *MockAPIKeyContract
+}
+
+// NewMockAPIKeyContract creates a new mock instance.
+func NewMockAPIKeyContract(ctrl *gomock.Controller) *MockAPIKeyContract {
+	mock := &MockAPIKeyContract{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyContract) EXPECT() *MockAPIKeyContractMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKeyContract) CreateAPIKey(ctx context.Context, userID, name string, scopes []models.APIKeyScope, expiresAt *time.Time) (*models.APIKey, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, userID, name, scopes, expiresAt)
+	ret0, _ := ret[0].(*models.APIKey)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeyContractMockRecorder) CreateAPIKey(ctx, userID, name, scopes, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKeyContract)(nil).CreateAPIKey), ctx, userID, name, scopes, expiresAt)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockAPIKeyContract) ListAPIKeys(ctx context.Context, userID string) ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx, userID)
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockAPIKeyContractMockRecorder) ListAPIKeys(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockAPIKeyContract)(nil).ListAPIKeys), ctx, userID)
+}
+
+// ResolveAPIKey mocks base method.
+func (m *MockAPIKeyContract) ResolveAPIKey(ctx context.Context, key string) (*models.APIKey, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveAPIKey", ctx, key)
+	ret0, _ := ret[0].(*models.APIKey)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveAPIKey indicates an expected call of ResolveAPIKey.
+func (mr *MockAPIKeyContractMockRecorder) ResolveAPIKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveAPIKey", reflect.TypeOf((*MockAPIKeyContract)(nil).ResolveAPIKey), ctx, key)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyContract) RevokeAPIKey(ctx context.Context, userID, keyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, userID, keyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyContractMockRecorder) RevokeAPIKey(ctx, userID, keyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyContract)(nil).RevokeAPIKey), ctx, userID, keyID)
+}
+
+// MockStoreContract is a mock of StoreContract interface.
+type MockStoreContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreContractMockRecorder
+}
+
+// MockStoreContractMockRecorder is the mock recorder for MockStoreContract.
+type MockStoreContractMockRecorder struct {
+	mock *MockStoreContract
+}
+
+// NewMockStoreContract creates a new mock instance.
+func NewMockStoreContract(ctrl *gomock.Controller) *MockStoreContract {
+	mock := &MockStoreContract{ctrl: ctrl}
+	mock.recorder = &MockStoreContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreContract) EXPECT() *MockStoreContractMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockStoreContract) CreateAPIKey(ctx context.Context, apiKey *models.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, apiKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockStoreContractMockRecorder) CreateAPIKey(ctx, apiKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockStoreContract)(nil).CreateAPIKey), ctx, apiKey)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockStoreContract) ListAPIKeys(ctx context.Context, userID string) ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx, userID)
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockStoreContractMockRecorder) ListAPIKeys(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockStoreContract)(nil).ListAPIKeys), ctx, userID)
+}
+
+// ReadAPIKey mocks base method.
+func (m *MockStoreContract) ReadAPIKey(ctx context.Context, keyID string) (*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAPIKey", ctx, keyID)
+	ret0, _ := ret[0].(*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAPIKey indicates an expected call of ReadAPIKey.
+func (mr *MockStoreContractMockRecorder) ReadAPIKey(ctx, keyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAPIKey", reflect.TypeOf((*MockStoreContract)(nil).ReadAPIKey), ctx, keyID)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockStoreContract) RevokeAPIKey(ctx context.Context, keyID string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, keyID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockStoreContractMockRecorder) RevokeAPIKey(ctx, keyID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockStoreContract)(nil).RevokeAPIKey), ctx, keyID, revokedAt)
+}
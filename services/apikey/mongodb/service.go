@@ -0,0 +1,190 @@
+// Package mongodb implements the MongoDB store that persists the hashes of the API keys
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/apikey"
+	"github.com/decentralized-cloud/user/services/configuration"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongodbStoreService struct {
+	getConnectionString func() (string, error)
+	databaseName        string
+	collectionName      string
+}
+
+// NewMongodbStoreService creates new instance of the mongodbStoreService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewMongodbStoreService(
+	configurationService configuration.ConfigurationContract) (apikey.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if _, err := configurationService.GetDatabaseConnectionString(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	databaseName, err := configurationService.GetDatabaseName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database name", err)
+	}
+
+	collectionName, err := configurationService.GetAPIKeyCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the API key collection name", err)
+	}
+
+	return &mongodbStoreService{
+		getConnectionString: configurationService.GetDatabaseConnectionString,
+		databaseName:        databaseName,
+		collectionName:      collectionName,
+	}, nil
+}
+
+// CreateAPIKey persists the new API key
+// ctx: Mandatory The reference to the context
+// apiKey: Mandatory. The API key to persist
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) CreateAPIKey(
+	ctx context.Context,
+	apiKey *models.APIKey) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	if _, err = collection.InsertOne(ctx, apiKey); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to create API key", err)
+	}
+
+	return nil
+}
+
+// ReadAPIKey reads the persisted API key
+// ctx: Mandatory The reference to the context
+// keyID: Mandatory. The ID of the API key
+// Returns either the API key or error if something goes wrong. NotFoundError is returned if there is no API key
+// with the ID.
+func (service *mongodbStoreService) ReadAPIKey(
+	ctx context.Context,
+	keyID string) (*models.APIKey, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(ctx, client)
+
+	var apiKey models.APIKey
+
+	filter := bson.D{{Key: "keyid", Value: keyID}}
+	err = collection.FindOne(ctx, filter).Decode(&apiKey)
+	if err == mongo.ErrNoDocuments {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read API key", err)
+	}
+
+	return &apiKey, nil
+}
+
+// ListAPIKeys lists all the persisted API keys of the user ordered by the time they are created at
+// ctx: Mandatory The reference to the context
+// userID: Mandatory. The ID of the user
+// Returns either the API keys of the user or error if something goes wrong.
+func (service *mongodbStoreService) ListAPIKeys(
+	ctx context.Context,
+	userID string) ([]models.APIKey, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(ctx, client)
+
+	filter := bson.D{{Key: "userid", Value: userID}}
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "createdat", Value: 1}}))
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list API keys", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	apiKeys := []models.APIKey{}
+	if err = cursor.All(ctx, &apiKeys); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to decode API keys", err)
+	}
+
+	return apiKeys, nil
+}
+
+// RevokeAPIKey records the time the API key is revoked at, it does nothing if the API key is already revoked
+// ctx: Mandatory The reference to the context
+// keyID: Mandatory. The ID of the API key
+// revokedAt: Mandatory. The time the API key is revoked at
+// Returns error if something goes wrong. NotFoundError is returned if there is no API key with the ID.
+func (service *mongodbStoreService) RevokeAPIKey(
+	ctx context.Context,
+	keyID string,
+	revokedAt time.Time) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	// Only the API key that is not revoked yet is updated, so the time it is first revoked at is kept
+	filter := bson.D{{Key: "keyid", Value: keyID}, {Key: "revokedat", Value: nil}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "revokedat", Value: revokedAt}}}}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to revoke API key", err)
+	}
+
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	count, err := collection.CountDocuments(ctx, bson.D{{Key: "keyid", Value: keyID}})
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to read API key", err)
+	}
+
+	if count == 0 {
+		return commonErrors.NewNotFoundError()
+	}
+
+	return nil
+}
+
+func (service *mongodbStoreService) createClientAndCollection(ctx context.Context) (*mongo.Client, *mongo.Collection, error) {
+	connectionString, err := service.getConnectionString()
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	clientOptions := options.Client().ApplyURI(connectionString)
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("could not connect to mongodb database", err)
+	}
+
+	return client, client.Database(service.databaseName).Collection(service.collectionName), nil
+}
+
+func disconnect(ctx context.Context, client *mongo.Client) {
+	_ = client.Disconnect(ctx)
+}
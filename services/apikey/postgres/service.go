@@ -0,0 +1,230 @@
+// Package postgres implements the PostgreSQL store that persists the hashes of the API keys
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/apikey"
+	"github.com/decentralized-cloud/user/services/configuration"
+	configurationPostgres "github.com/decentralized-cloud/user/services/configuration/postgres"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+const apiKeyColumns = "key_id, user_id, name, scopes, key_hash, created_at, expires_at, revoked_at"
+
+type postgresStoreService struct {
+	pool      *pgxpool.Pool
+	tableName string
+}
+
+// NewPostgresStoreService creates new instance of the postgresStoreService, setting up all dependencies, creating
+// the API key table if it does not exist yet and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewPostgresStoreService(
+	configurationService configuration.ConfigurationContract) (apikey.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	connectionString, err := configurationService.GetDatabaseConnectionString()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to postgres", err)
+	}
+
+	// The API key collection name is used as the name of the table the API keys are persisted in
+	tableName, err := configurationService.GetAPIKeyCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the API key table name", err)
+	}
+
+	ctx := context.Background()
+	pool, err := configurationPostgres.Connect(ctx, connectionString, configurationService.GetDatabaseConnectionString)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("could not connect to postgres database", err)
+	}
+
+	service := &postgresStoreService{
+		pool:      pool,
+		tableName: tableName,
+	}
+
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (
+			key_id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			name TEXT NOT NULL,
+			scopes TEXT[] NOT NULL,
+			key_hash TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			expires_at TIMESTAMPTZ,
+			revoked_at TIMESTAMPTZ)`,
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the API key table", err)
+	}
+
+	// The API keys used to be kept by the email address of the user. The keys minted before are not resolved anymore
+	// as they carry no user ID, so they must be minted again.
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		"ALTER TABLE %s ADD COLUMN IF NOT EXISTS user_id TEXT NOT NULL DEFAULT '', DROP COLUMN IF EXISTS email",
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to migrate the API key table", err)
+	}
+
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		"CREATE INDEX IF NOT EXISTS %s ON %s (user_id)",
+		pgx.Identifier{tableName + "_user_id_idx"}.Sanitize(),
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the API key user ID index", err)
+	}
+
+	return service, nil
+}
+
+// CreateAPIKey persists the new API key
+// ctx: Mandatory The reference to the context
+// apiKey: Mandatory. The API key to persist
+// Returns error if something goes wrong.
+func (service *postgresStoreService) CreateAPIKey(
+	ctx context.Context,
+	apiKey *models.APIKey) error {
+	scopes := make([]string, 0, len(apiKey.Scopes))
+	for _, scope := range apiKey.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("INSERT INTO %s (%s) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)", service.table(), apiKeyColumns),
+		apiKey.KeyID,
+		apiKey.UserID,
+		apiKey.Name,
+		scopes,
+		apiKey.KeyHash,
+		apiKey.CreatedAt,
+		apiKey.ExpiresAt,
+		apiKey.RevokedAt); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to create API key", err)
+	}
+
+	return nil
+}
+
+// ReadAPIKey reads the persisted API key
+// ctx: Mandatory The reference to the context
+// keyID: Mandatory. The ID of the API key
+// Returns either the API key or error if something goes wrong. NotFoundError is returned if there is no API key
+// with the ID.
+func (service *postgresStoreService) ReadAPIKey(
+	ctx context.Context,
+	keyID string) (*models.APIKey, error) {
+	apiKey, err := scanAPIKey(service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE key_id = $1", apiKeyColumns, service.table()),
+		keyID))
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read API key", err)
+	}
+
+	return apiKey, nil
+}
+
+// ListAPIKeys lists all the persisted API keys of the user ordered by the time they are created at
+// ctx: Mandatory The reference to the context
+// userID: Mandatory. The ID of the user
+// Returns either the API keys of the user or error if something goes wrong.
+func (service *postgresStoreService) ListAPIKeys(
+	ctx context.Context,
+	userID string) ([]models.APIKey, error) {
+	rows, err := service.pool.Query(
+		ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE user_id = $1 ORDER BY created_at", apiKeyColumns, service.table()),
+		userID)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list API keys", err)
+	}
+
+	defer rows.Close()
+
+	apiKeys := []models.APIKey{}
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, commonErrors.NewUnknownErrorWithError("failed to read API key", err)
+		}
+
+		apiKeys = append(apiKeys, *apiKey)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list API keys", err)
+	}
+
+	return apiKeys, nil
+}
+
+// RevokeAPIKey records the time the API key is revoked at, it does nothing if the API key is already revoked
+// ctx: Mandatory The reference to the context
+// keyID: Mandatory. The ID of the API key
+// revokedAt: Mandatory. The time the API key is revoked at
+// Returns error if something goes wrong. NotFoundError is returned if there is no API key with the ID.
+func (service *postgresStoreService) RevokeAPIKey(
+	ctx context.Context,
+	keyID string,
+	revokedAt time.Time) error {
+	// The time the API key is first revoked at is kept
+	commandTag, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("UPDATE %s SET revoked_at = COALESCE(revoked_at, $2) WHERE key_id = $1", service.table()),
+		keyID,
+		revokedAt)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to revoke API key", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return commonErrors.NewNotFoundError()
+	}
+
+	return nil
+}
+
+func (service *postgresStoreService) table() string {
+	return pgx.Identifier{service.tableName}.Sanitize()
+}
+
+func scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	var apiKey models.APIKey
+	var scopes []string
+
+	if err := row.Scan(
+		&apiKey.KeyID,
+		&apiKey.UserID,
+		&apiKey.Name,
+		&scopes,
+		&apiKey.KeyHash,
+		&apiKey.CreatedAt,
+		&apiKey.ExpiresAt,
+		&apiKey.RevokedAt); err != nil {
+		return nil, err
+	}
+
+	for _, scope := range scopes {
+		apiKey.Scopes = append(apiKey.Scopes, models.APIKeyScope(scope))
+	}
+
+	return &apiKey, nil
+}
@@ -0,0 +1,245 @@
+// Package apikey implements the API keys the service accounts call the service with on behalf of the users, instead
+// of a token issued by the identity provider
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/idgenerator"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+const (
+	// KeyPrefix is the prefix of every key minted by the service, so a leaked key can be recognized by secret scanners
+	KeyPrefix = "uak_"
+
+	// secretLength is the number of the random bytes of the key, the key is not a password so hashing it with SHA-256
+	// is enough to protect it at rest
+	secretLength = 32
+)
+
+type apiKeyService struct {
+	storeService       StoreContract
+	repositoryService  repository.RepositoryContract
+	clockService       clock.ClockContract
+	idGeneratorService idgenerator.IDGeneratorContract
+	maxPerUser         int
+}
+
+// NewAPIKeyService creates new instance of the apiKeyService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// storeService: Mandatory. Reference to the service that persists the hashes of the API keys
+// repositoryService: Mandatory. Reference to the service that reads the users that own the API keys
+// clockService: Mandatory. Reference to the service that provides the current time
+// idGeneratorService: Mandatory. Reference to the service that generates the IDs of the API keys
+// Returns the new service or error if something goes wrong
+func NewAPIKeyService(
+	configurationService configuration.ConfigurationContract,
+	storeService StoreContract,
+	repositoryService repository.RepositoryContract,
+	clockService clock.ClockContract,
+	idGeneratorService idgenerator.IDGeneratorContract) (APIKeyContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if storeService == nil {
+		return nil, commonErrors.NewArgumentNilError("storeService", "storeService is required")
+	}
+
+	if repositoryService == nil {
+		return nil, commonErrors.NewArgumentNilError("repositoryService", "repositoryService is required")
+	}
+
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	if idGeneratorService == nil {
+		return nil, commonErrors.NewArgumentNilError("idGeneratorService", "idGeneratorService is required")
+	}
+
+	maxPerUser, err := configurationService.GetAPIKeyMaxPerUser()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the maximum number of the API keys per user", err)
+	}
+
+	return &apiKeyService{
+		storeService:       storeService,
+		repositoryService:  repositoryService,
+		clockService:       clockService,
+		idGeneratorService: idGeneratorService,
+		maxPerUser:         maxPerUser,
+	}, nil
+}
+
+// CreateAPIKey mints a new API key for the user and persists its hash
+// ctx: Mandatory The reference to the context
+// userID: Mandatory. The ID of the user that owns the API key
+// name: Mandatory. The name the user recognizes the API key by
+// scopes: Mandatory. The scopes the API key is allowed
+// expiresAt: Optional. The time the API key expires at, the API key never expires if not provided
+// Returns either the persisted API key and the key itself or error if something goes wrong. The key is not
+// persisted, so it can not be returned again.
+func (service *apiKeyService) CreateAPIKey(
+	ctx context.Context,
+	userID string,
+	name string,
+	scopes []models.APIKeyScope,
+	expiresAt *time.Time) (*models.APIKey, string, error) {
+	now := service.clockService.Now()
+
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, "", commonErrors.NewArgumentError("expiresAt", "expiresAt must be in the future")
+	}
+
+	apiKeys, err := service.storeService.ListAPIKeys(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	activeCount := 0
+	for _, apiKey := range apiKeys {
+		if apiKey.IsActive(now) {
+			activeCount++
+		}
+	}
+
+	if activeCount >= service.maxPerUser {
+		return nil, "", NewLimitExceededError(userID, service.maxPerUser)
+	}
+
+	secret := make([]byte, secretLength)
+	if _, err = rand.Read(secret); err != nil {
+		return nil, "", commonErrors.NewUnknownErrorWithError("failed to generate the API key", err)
+	}
+
+	// The ID is part of the key, so the key is resolved by reading a single API key instead of comparing the hashes
+	keyID := service.idGeneratorService.NewID()
+	key := KeyPrefix + keyID + "_" + base64.RawURLEncoding.EncodeToString(secret)
+
+	apiKey := &models.APIKey{
+		KeyID:     keyID,
+		UserID:    userID,
+		Name:      name,
+		Scopes:    scopes,
+		KeyHash:   hashKey(key),
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+
+	if err = service.storeService.CreateAPIKey(ctx, apiKey); err != nil {
+		return nil, "", err
+	}
+
+	return apiKey, key, nil
+}
+
+// ListAPIKeys lists all the API keys of the user, including the revoked and the expired ones
+// ctx: Mandatory The reference to the context
+// userID: Mandatory. The ID of the user
+// Returns either the API keys of the user or error if something goes wrong.
+func (service *apiKeyService) ListAPIKeys(
+	ctx context.Context,
+	userID string) ([]models.APIKey, error) {
+	return service.storeService.ListAPIKeys(ctx, userID)
+}
+
+// RevokeAPIKey revokes the API key of the user, so it can not be used anymore
+// ctx: Mandatory The reference to the context
+// userID: Mandatory. The ID of the user that owns the API key
+// keyID: Mandatory. The ID of the API key
+// Returns error if something goes wrong. NotFoundError is returned if the user has no API key with the ID.
+func (service *apiKeyService) RevokeAPIKey(
+	ctx context.Context,
+	userID string,
+	keyID string) error {
+	apiKey, err := service.storeService.ReadAPIKey(ctx, keyID)
+	if err != nil {
+		return err
+	}
+
+	// The API keys of the other users are reported as not found, so their IDs can not be probed
+	if apiKey.UserID != userID {
+		return commonErrors.NewNotFoundError()
+	}
+
+	return service.storeService.RevokeAPIKey(ctx, keyID, service.clockService.Now())
+}
+
+// ResolveAPIKey resolves the key to the API key it is minted as and the current email address of the user that owns
+// it
+// ctx: Mandatory The reference to the context
+// key: Mandatory. The key the caller provided
+// Returns either the API key and the email address of its user or error if something goes wrong. NotFoundError is
+// returned if the key is not a key minted by the service, if it is revoked or expired, or if the user that owns it no
+// longer exists.
+func (service *apiKeyService) ResolveAPIKey(
+	ctx context.Context,
+	key string) (*models.APIKey, string, error) {
+	keyID, ok := parseKeyID(key)
+	if !ok {
+		return nil, "", commonErrors.NewNotFoundError()
+	}
+
+	apiKey, err := service.storeService.ReadAPIKey(ctx, keyID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// The API keys minted before they were kept by the ID of the user carry no user ID, so they must be minted again
+	if subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(hashKey(key))) != 1 ||
+		!apiKey.IsActive(service.clockService.Now()) ||
+		apiKey.UserID == "" {
+		return nil, "", commonErrors.NewNotFoundError()
+	}
+
+	// The API keys are revoked when their user is deleted, but a key must not outlive its user even if revoking it
+	// failed, so the user is read on every call. The API keys are kept by the ID of the user, so reading the user also
+	// resolves its current email address. Only the fields every read returns are requested.
+	response, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		UserID: apiKey.UserID,
+		Fields: []string{"createdAt"},
+	})
+
+	if err != nil {
+		if commonErrors.IsNotFoundError(err) {
+			return nil, "", commonErrors.NewNotFoundError()
+		}
+
+		return nil, "", err
+	}
+
+	return apiKey, response.Email, nil
+}
+
+// parseKeyID extracts the ID of the API key from the key, the secret may contain underscores but the ID does not
+func parseKeyID(key string) (string, bool) {
+	if !strings.HasPrefix(key, KeyPrefix) {
+		return "", false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(key, KeyPrefix), "_", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", false
+	}
+
+	return parts[0], true
+}
+
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(hash[:])
+}
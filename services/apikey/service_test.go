@@ -0,0 +1,320 @@
+package apikey_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/apikey"
+	apiKeyMock "github.com/decentralized-cloud/user/services/apikey/mock"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	idgeneratorMock "github.com/decentralized-cloud/user/services/idgenerator/mock"
+	"github.com/decentralized-cloud/user/services/repository"
+	repositoryMock "github.com/decentralized-cloud/user/services/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestAPIKeyService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "API Key Service Tests")
+}
+
+var _ = Describe("API Key Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      apikey.APIKeyContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		mockStoreService         *apiKeyMock.MockStoreContract
+		mockRepositoryService    *repositoryMock.MockRepositoryContract
+		mockClockService         *clockMock.MockClockContract
+		mockIDGeneratorService   *idgeneratorMock.MockIDGeneratorContract
+		apiKeys                  map[string]models.APIKey
+		deletedUsers             map[string]bool
+		now                      time.Time
+		ctx                      context.Context
+		userID                   string
+		email                    string
+		name                     string
+		scopes                   []models.APIKeyScope
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetAPIKeyMaxPerUser().
+			Return(2, nil).
+			AnyTimes()
+
+		// The store keeps the API keys in memory, so the hashes the service persists can be resolved back
+		apiKeys = map[string]models.APIKey{}
+		mockStoreService = apiKeyMock.NewMockStoreContract(mockCtrl)
+		mockStoreService.
+			EXPECT().
+			CreateAPIKey(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, apiKey *models.APIKey) error {
+				apiKeys[apiKey.KeyID] = *apiKey
+
+				return nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			ReadAPIKey(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, keyID string) (*models.APIKey, error) {
+				apiKey, ok := apiKeys[keyID]
+				if !ok {
+					return nil, commonErrors.NewNotFoundError()
+				}
+
+				return &apiKey, nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			ListAPIKeys(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, userID string) ([]models.APIKey, error) {
+				result := []models.APIKey{}
+				for _, apiKey := range apiKeys {
+					if apiKey.UserID == userID {
+						result = append(result, apiKey)
+					}
+				}
+
+				return result, nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			RevokeAPIKey(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, keyID string, revokedAt time.Time) error {
+				apiKey := apiKeys[keyID]
+				apiKey.RevokedAt = &revokedAt
+				apiKeys[keyID] = apiKey
+
+				return nil
+			}).
+			AnyTimes()
+
+		deletedUsers = map[string]bool{}
+		mockRepositoryService = repositoryMock.NewMockRepositoryContract(mockCtrl)
+		mockRepositoryService.
+			EXPECT().
+			ReadUser(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, request *repository.ReadUserRequest) (*repository.ReadUserResponse, error) {
+				if request.UserID != userID || deletedUsers[request.UserID] {
+					return nil, commonErrors.NewNotFoundError()
+				}
+
+				return &repository.ReadUserResponse{Email: email}, nil
+			}).
+			AnyTimes()
+
+		now = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			DoAndReturn(func() time.Time { return now }).
+			AnyTimes()
+
+		mockIDGeneratorService = idgeneratorMock.NewMockIDGeneratorContract(mockCtrl)
+		mockIDGeneratorService.
+			EXPECT().
+			NewID().
+			DoAndReturn(cuid.New).
+			AnyTimes()
+
+		ctx = context.Background()
+		userID = cuid.New()
+		email = cuid.New() + "@test.com"
+		name = cuid.New()
+		scopes = []models.APIKeyScope{models.APIKeyScopeRead}
+
+		var err error
+		sut, err = apikey.NewAPIKeyService(mockConfigurationService, mockStoreService, mockRepositoryService, mockClockService, mockIDGeneratorService)
+		Ω(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate APIKeyService", func() {
+		When("configuration service is not provided and NewAPIKeyService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := apikey.NewAPIKeyService(nil, mockStoreService, mockRepositoryService, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("store service is not provided and NewAPIKeyService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := apikey.NewAPIKeyService(mockConfigurationService, nil, mockRepositoryService, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("repository service is not provided and NewAPIKeyService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := apikey.NewAPIKeyService(mockConfigurationService, mockStoreService, nil, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("clock service is not provided and NewAPIKeyService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := apikey.NewAPIKeyService(mockConfigurationService, mockStoreService, mockRepositoryService, nil, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("ID generator service is not provided and NewAPIKeyService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := apikey.NewAPIKeyService(mockConfigurationService, mockStoreService, mockRepositoryService, mockClockService, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("APIKeyService is instantiated", func() {
+		When("CreateAPIKey is called", func() {
+			It("should return the key and only persist its hash", func() {
+				apiKey, key, err := sut.CreateAPIKey(ctx, userID, name, scopes, nil)
+				Ω(err).Should(BeNil())
+				Ω(key).Should(HavePrefix(apikey.KeyPrefix + apiKey.KeyID + "_"))
+				Ω(apiKeys[apiKey.KeyID].KeyHash).ShouldNot(BeEmpty())
+				Ω(apiKeys[apiKey.KeyID].KeyHash).ShouldNot(ContainSubstring(key))
+				Ω(apiKeys[apiKey.KeyID].UserID).Should(Equal(userID))
+				Ω(apiKeys[apiKey.KeyID].Name).Should(Equal(name))
+				Ω(apiKeys[apiKey.KeyID].Scopes).Should(Equal(scopes))
+				Ω(apiKeys[apiKey.KeyID].CreatedAt).Should(Equal(now))
+			})
+
+			It("should return ArgumentError if the API key expires in the past", func() {
+				expiresAt := now.Add(-time.Minute)
+				_, _, err := sut.CreateAPIKey(ctx, userID, name, scopes, &expiresAt)
+				Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+				Ω(apiKeys).Should(BeEmpty())
+			})
+
+			It("should return LimitExceededError if the user already has the maximum number of the active API keys", func() {
+				_, _, err := sut.CreateAPIKey(ctx, userID, name, scopes, nil)
+				Ω(err).Should(BeNil())
+				apiKey, _, err := sut.CreateAPIKey(ctx, userID, name, scopes, nil)
+				Ω(err).Should(BeNil())
+
+				_, _, err = sut.CreateAPIKey(ctx, userID, name, scopes, nil)
+				Ω(apikey.IsLimitExceededError(err)).Should(BeTrue())
+
+				Ω(sut.RevokeAPIKey(ctx, userID, apiKey.KeyID)).Should(BeNil())
+				_, _, err = sut.CreateAPIKey(ctx, userID, name, scopes, nil)
+				Ω(err).Should(BeNil())
+			})
+		})
+
+		When("ResolveAPIKey is called", func() {
+			var (
+				apiKey *models.APIKey
+				key    string
+			)
+
+			BeforeEach(func() {
+				expiresAt := now.Add(time.Hour)
+
+				var err error
+				apiKey, key, err = sut.CreateAPIKey(ctx, userID, name, scopes, &expiresAt)
+				Ω(err).Should(BeNil())
+			})
+
+			It("should resolve the key to the API key and the current email address of its user", func() {
+				resolvedAPIKey, resolvedEmail, err := sut.ResolveAPIKey(ctx, key)
+				Ω(err).Should(BeNil())
+				Ω(resolvedAPIKey.KeyID).Should(Equal(apiKey.KeyID))
+				Ω(resolvedAPIKey.UserID).Should(Equal(userID))
+				Ω(resolvedEmail).Should(Equal(email))
+			})
+
+			It("should resolve the key to the new email address once the email address of the user is changed", func() {
+				email = cuid.New() + "@test.com"
+
+				_, resolvedEmail, err := sut.ResolveAPIKey(ctx, key)
+				Ω(err).Should(BeNil())
+				Ω(resolvedEmail).Should(Equal(email))
+			})
+
+			It("should return NotFoundError if the API key carries no user ID", func() {
+				storedAPIKey := apiKeys[apiKey.KeyID]
+				storedAPIKey.UserID = ""
+				apiKeys[apiKey.KeyID] = storedAPIKey
+
+				_, _, err := sut.ResolveAPIKey(ctx, key)
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+
+			It("should return NotFoundError if the secret of the key does not match", func() {
+				_, _, err := sut.ResolveAPIKey(ctx, key+"x")
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+
+			It("should return NotFoundError if the key is malformed", func() {
+				_, _, err := sut.ResolveAPIKey(ctx, cuid.New())
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+				_, _, err = sut.ResolveAPIKey(ctx, apikey.KeyPrefix+apiKey.KeyID)
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+
+			It("should return NotFoundError if the API key is revoked", func() {
+				Ω(sut.RevokeAPIKey(ctx, userID, apiKey.KeyID)).Should(BeNil())
+
+				_, _, err := sut.ResolveAPIKey(ctx, key)
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+
+			It("should return NotFoundError if the API key is expired", func() {
+				now = now.Add(time.Hour)
+
+				_, _, err := sut.ResolveAPIKey(ctx, key)
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+
+			It("should return NotFoundError if the user that owns the API key no longer exists", func() {
+				deletedUsers[userID] = true
+
+				_, _, err := sut.ResolveAPIKey(ctx, key)
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("RevokeAPIKey is called for the API key of another user", func() {
+			It("should return NotFoundError and leave the API key active", func() {
+				apiKey, key, err := sut.CreateAPIKey(ctx, userID, name, scopes, nil)
+				Ω(err).Should(BeNil())
+
+				err = sut.RevokeAPIKey(ctx, cuid.New(), apiKey.KeyID)
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+				_, _, err = sut.ResolveAPIKey(ctx, key)
+				Ω(err).Should(BeNil())
+			})
+		})
+	})
+})
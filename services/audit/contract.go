@@ -0,0 +1,71 @@
+// Package audit implements the audit log recording who made the mutating operations on the users, when and what
+// they changed
+package audit
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// AuditContract declares the service that records the mutating operations on the users and lists them for review
+type AuditContract interface {
+	// RecordOperation records the given mutating operation made by the user found in the context, along with the
+	// changes made by the operation
+	// ctx: Mandatory The reference to the context
+	// operation: Mandatory. The mutating operation made
+	// email: Mandatory. The email address of the user the operation is made on
+	// before: Optional. The user before the operation, nil if the operation created the user
+	// after: Optional. The user after the operation, nil if the operation deleted the user
+	// Returns error if something goes wrong.
+	RecordOperation(
+		ctx context.Context,
+		operation models.AuditOperation,
+		email string,
+		before *models.User,
+		after *models.User) error
+
+	// ListAuditRecords lists the audit records that matched the criteria, the latest first
+	// ctx: Mandatory The reference to the context
+	// filter: Mandatory. The criteria the audit records are listed by
+	// Returns either the audit records or error if something goes wrong.
+	ListAuditRecords(
+		ctx context.Context,
+		filter *models.AuditRecordFilter) ([]models.AuditRecord, error)
+
+	// AnonymizeAuditRecords replaces the email address of the erased user with a pseudonym in all the audit records
+	// made on or by the user, and removes the personal data the records contain
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the erased user
+	// Returns either the pseudonym the email address is replaced with or error if something goes wrong.
+	AnonymizeAuditRecords(
+		ctx context.Context,
+		email string) (string, error)
+}
+
+// StoreContract declares the service that persists the audit records
+type StoreContract interface {
+	// SaveAuditRecord persists a new audit record
+	// ctx: Mandatory The reference to the context
+	// record: Mandatory. The audit record to persist
+	// Returns error if something goes wrong.
+	SaveAuditRecord(
+		ctx context.Context,
+		record *models.AuditRecord) error
+
+	// ListAuditRecords lists the persisted audit records that matched the criteria, the latest first
+	// ctx: Mandatory The reference to the context
+	// filter: Mandatory. The criteria the audit records are listed by
+	// Returns either the audit records or error if something goes wrong.
+	ListAuditRecords(
+		ctx context.Context,
+		filter *models.AuditRecordFilter) ([]models.AuditRecord, error)
+
+	// ReplaceAuditRecord replaces the persisted audit record with the same record ID
+	// ctx: Mandatory The reference to the context
+	// record: Mandatory. The audit record to persist in place of the existing one
+	// Returns error if something goes wrong.
+	ReplaceAuditRecord(
+		ctx context.Context,
+		record *models.AuditRecord) error
+}
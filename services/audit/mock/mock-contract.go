@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/audit/contract.go
+
+// Package mock_audit is a generated GoMock package.
+package mock_audit
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/decentralized-cloud/user/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuditContract is a mock of AuditContract interface.
+type MockAuditContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditContractMockRecorder
+}
+
+// MockAuditContractMockRecorder is the mock recorder for MockAuditContract.
+type MockAuditContractMockRecorder struct {
+	mock *MockAuditContract
+}
+
+// NewMockAuditContract creates a new mock instance.
+func NewMockAuditContract(ctrl *gomock.Controller) *MockAuditContract {
+	mock := &MockAuditContract{ctrl: ctrl}
+	mock.recorder = &MockAuditContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditContract) EXPECT() *MockAuditContractMockRecorder {
+	return m.recorder
+}
+
+// AnonymizeAuditRecords mocks base method.
+func (m *MockAuditContract) AnonymizeAuditRecords(ctx context.Context, email string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeAuditRecords", ctx, email)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeAuditRecords indicates an expected call of AnonymizeAuditRecords.
+func (mr *MockAuditContractMockRecorder) AnonymizeAuditRecords(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeAuditRecords", reflect.TypeOf((*MockAuditContract)(nil).AnonymizeAuditRecords), ctx, email)
+}
+
+// ListAuditRecords mocks base method.
+func (m *MockAuditContract) ListAuditRecords(ctx context.Context, filter *models.AuditRecordFilter) ([]models.AuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditRecords", ctx, filter)
+	ret0, _ := ret[0].([]models.AuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditRecords indicates an expected call of ListAuditRecords.
+func (mr *MockAuditContractMockRecorder) ListAuditRecords(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditRecords", reflect.TypeOf((*MockAuditContract)(nil).ListAuditRecords), ctx, filter)
+}
+
+// RecordOperation mocks base method.
+func (m *MockAuditContract) RecordOperation(ctx context.Context, operation models.AuditOperation, email string, before, after *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordOperation", ctx, operation, email, before, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordOperation indicates an expected call of RecordOperation.
+func (mr *MockAuditContractMockRecorder) RecordOperation(ctx, operation, email, before, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordOperation", reflect.TypeOf((*MockAuditContract)(nil).RecordOperation), ctx, operation, email, before, after)
+}
+
+// MockStoreContract is a mock of StoreContract interface.
+type MockStoreContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreContractMockRecorder
+}
+
+// MockStoreContractMockRecorder is the mock recorder for MockStoreContract.
+type MockStoreContractMockRecorder struct {
+	mock *MockStoreContract
+}
+
+// NewMockStoreContract creates a new mock instance.
+func NewMockStoreContract(ctrl *gomock.Controller) *MockStoreContract {
+	mock := &MockStoreContract{ctrl: ctrl}
+	mock.recorder = &MockStoreContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreContract) EXPECT() *MockStoreContractMockRecorder {
+	return m.recorder
+}
+
+// ListAuditRecords mocks base method.
+func (m *MockStoreContract) ListAuditRecords(ctx context.Context, filter *models.AuditRecordFilter) ([]models.AuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditRecords", ctx, filter)
+	ret0, _ := ret[0].([]models.AuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditRecords indicates an expected call of ListAuditRecords.
+func (mr *MockStoreContractMockRecorder) ListAuditRecords(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditRecords", reflect.TypeOf((*MockStoreContract)(nil).ListAuditRecords), ctx, filter)
+}
+
+// ReplaceAuditRecord mocks base method.
+func (m *MockStoreContract) ReplaceAuditRecord(ctx context.Context, record *models.AuditRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceAuditRecord", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceAuditRecord indicates an expected call of ReplaceAuditRecord.
+func (mr *MockStoreContractMockRecorder) ReplaceAuditRecord(ctx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceAuditRecord", reflect.TypeOf((*MockStoreContract)(nil).ReplaceAuditRecord), ctx, record)
+}
+
+// SaveAuditRecord mocks base method.
+func (m *MockStoreContract) SaveAuditRecord(ctx context.Context, record *models.AuditRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAuditRecord", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAuditRecord indicates an expected call of SaveAuditRecord.
+func (mr *MockStoreContractMockRecorder) SaveAuditRecord(ctx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAuditRecord", reflect.TypeOf((*MockStoreContract)(nil).SaveAuditRecord), ctx, record)
+}
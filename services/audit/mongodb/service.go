@@ -0,0 +1,178 @@
+// Package mongodb implements the MongoDB store that persists the audit records
+package mongodb
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/audit"
+	"github.com/decentralized-cloud/user/services/configuration"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongodbStoreService struct {
+	getConnectionString func() (string, error)
+	databaseName        string
+	collectionName      string
+}
+
+// NewMongodbStoreService creates new instance of the mongodbStoreService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewMongodbStoreService(
+	configurationService configuration.ConfigurationContract) (audit.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if _, err := configurationService.GetDatabaseConnectionString(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	databaseName, err := configurationService.GetDatabaseName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database name", err)
+	}
+
+	collectionName, err := configurationService.GetAuditCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the audit collection name", err)
+	}
+
+	return &mongodbStoreService{
+		getConnectionString: configurationService.GetDatabaseConnectionString,
+		databaseName:        databaseName,
+		collectionName:      collectionName,
+	}, nil
+}
+
+// SaveAuditRecord persists a new audit record
+// ctx: Mandatory The reference to the context
+// record: Mandatory. The audit record to persist
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) SaveAuditRecord(
+	ctx context.Context,
+	record *models.AuditRecord) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	if _, err = collection.InsertOne(ctx, record); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to save audit record", err)
+	}
+
+	return nil
+}
+
+// ListAuditRecords lists the persisted audit records that matched the criteria, the latest first
+// ctx: Mandatory The reference to the context
+// filter: Mandatory. The criteria the audit records are listed by
+// Returns either the audit records or error if something goes wrong.
+func (service *mongodbStoreService) ListAuditRecords(
+	ctx context.Context,
+	filter *models.AuditRecordFilter) ([]models.AuditRecord, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(ctx, client)
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "createdat", Value: -1}})
+	if filter.Limit > 0 {
+		findOptions.SetLimit(int64(filter.Limit))
+	}
+
+	cursor, err := collection.Find(ctx, createFilterQuery(filter), findOptions)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list audit records", err)
+	}
+
+	defer func() {
+		_ = cursor.Close(ctx)
+	}()
+
+	records := []models.AuditRecord{}
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to decode audit records", err)
+	}
+
+	return records, nil
+}
+
+// ReplaceAuditRecord replaces the persisted audit record with the same record ID
+// ctx: Mandatory The reference to the context
+// record: Mandatory. The audit record to persist in place of the existing one
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) ReplaceAuditRecord(
+	ctx context.Context,
+	record *models.AuditRecord) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	if _, err = collection.ReplaceOne(ctx, bson.M{"recordid": record.RecordID}, record); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to replace audit record", err)
+	}
+
+	return nil
+}
+
+func createFilterQuery(filter *models.AuditRecordFilter) bson.M {
+	query := bson.M{}
+
+	if filter.Email != "" {
+		query["email"] = filter.Email
+	}
+
+	if filter.ActorEmail != "" {
+		query["actoremail"] = filter.ActorEmail
+	}
+
+	if len(filter.Operations) > 0 {
+		query["operation"] = bson.M{"$in": filter.Operations}
+	}
+
+	createdAt := bson.M{}
+	if filter.CreatedAfter != nil {
+		createdAt["$gte"] = *filter.CreatedAfter
+	}
+
+	if filter.CreatedBefore != nil {
+		createdAt["$lt"] = *filter.CreatedBefore
+	}
+
+	if len(createdAt) > 0 {
+		query["createdat"] = createdAt
+	}
+
+	return query
+}
+
+func (service *mongodbStoreService) createClientAndCollection(ctx context.Context) (*mongo.Client, *mongo.Collection, error) {
+	connectionString, err := service.getConnectionString()
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	clientOptions := options.Client().ApplyURI(connectionString)
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("could not connect to mongodb database", err)
+	}
+
+	return client, client.Database(service.databaseName).Collection(service.collectionName), nil
+}
+
+func disconnect(ctx context.Context, client *mongo.Client) {
+	_ = client.Disconnect(ctx)
+}
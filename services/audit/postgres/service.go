@@ -0,0 +1,214 @@
+// Package postgres implements the PostgreSQL store that persists the audit records
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/audit"
+	"github.com/decentralized-cloud/user/services/configuration"
+	configurationPostgres "github.com/decentralized-cloud/user/services/configuration/postgres"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+type postgresStoreService struct {
+	pool      *pgxpool.Pool
+	tableName string
+}
+
+// NewPostgresStoreService creates new instance of the postgresStoreService, setting up all dependencies, creating
+// the audit table if it does not exist yet and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewPostgresStoreService(
+	configurationService configuration.ConfigurationContract) (audit.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	connectionString, err := configurationService.GetDatabaseConnectionString()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to postgres", err)
+	}
+
+	// The audit collection name is used as the name of the table the audit records are persisted in
+	tableName, err := configurationService.GetAuditCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the audit table name", err)
+	}
+
+	ctx := context.Background()
+	pool, err := configurationPostgres.Connect(ctx, connectionString, configurationService.GetDatabaseConnectionString)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("could not connect to postgres database", err)
+	}
+
+	service := &postgresStoreService{
+		pool:      pool,
+		tableName: tableName,
+	}
+
+	// The filtered columns are duplicated out of the record so they can be indexed
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (
+			record_id TEXT PRIMARY KEY,
+			operation TEXT NOT NULL,
+			actor_email TEXT NOT NULL,
+			email TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			record JSONB NOT NULL)`,
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the audit table", err)
+	}
+
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		"CREATE INDEX IF NOT EXISTS %s ON %s (email, created_at DESC)",
+		pgx.Identifier{tableName + "_email_created_at_idx"}.Sanitize(),
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the audit table index", err)
+	}
+
+	return service, nil
+}
+
+// SaveAuditRecord persists a new audit record
+// ctx: Mandatory The reference to the context
+// record: Mandatory. The audit record to persist
+// Returns error if something goes wrong.
+func (service *postgresStoreService) SaveAuditRecord(
+	ctx context.Context,
+	record *models.AuditRecord) error {
+	_, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf(
+			"INSERT INTO %s (record_id, operation, actor_email, email, created_at, record) VALUES ($1, $2, $3, $4, $5, $6)",
+			service.table()),
+		record.RecordID,
+		string(record.Operation),
+		record.ActorEmail,
+		record.Email,
+		record.CreatedAt,
+		record)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to save audit record", err)
+	}
+
+	return nil
+}
+
+// ListAuditRecords lists the persisted audit records that matched the criteria, the latest first
+// ctx: Mandatory The reference to the context
+// filter: Mandatory. The criteria the audit records are listed by
+// Returns either the audit records or error if something goes wrong.
+func (service *postgresStoreService) ListAuditRecords(
+	ctx context.Context,
+	filter *models.AuditRecordFilter) ([]models.AuditRecord, error) {
+	query, args := service.createFilterQuery(filter)
+
+	rows, err := service.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list audit records", err)
+	}
+
+	defer rows.Close()
+
+	records := []models.AuditRecord{}
+	for rows.Next() {
+		var record models.AuditRecord
+		if err = rows.Scan(&record); err != nil {
+			return nil, commonErrors.NewUnknownErrorWithError("failed to decode audit record", err)
+		}
+
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list audit records", err)
+	}
+
+	return records, nil
+}
+
+// ReplaceAuditRecord replaces the persisted audit record with the same record ID
+// ctx: Mandatory The reference to the context
+// record: Mandatory. The audit record to persist in place of the existing one
+// Returns error if something goes wrong.
+func (service *postgresStoreService) ReplaceAuditRecord(
+	ctx context.Context,
+	record *models.AuditRecord) error {
+	_, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf(
+			"UPDATE %s SET operation = $2, actor_email = $3, email = $4, created_at = $5, record = $6 WHERE record_id = $1",
+			service.table()),
+		record.RecordID,
+		string(record.Operation),
+		record.ActorEmail,
+		record.Email,
+		record.CreatedAt,
+		record)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to replace audit record", err)
+	}
+
+	return nil
+}
+
+func (service *postgresStoreService) createFilterQuery(filter *models.AuditRecordFilter) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Email != "" {
+		addCondition("email = $%d", filter.Email)
+	}
+
+	if filter.ActorEmail != "" {
+		addCondition("actor_email = $%d", filter.ActorEmail)
+	}
+
+	if len(filter.Operations) > 0 {
+		operations := make([]string, 0, len(filter.Operations))
+		for _, operation := range filter.Operations {
+			operations = append(operations, string(operation))
+		}
+
+		addCondition("operation = ANY($%d)", operations)
+	}
+
+	if filter.CreatedAfter != nil {
+		addCondition("created_at >= $%d", *filter.CreatedAfter)
+	}
+
+	if filter.CreatedBefore != nil {
+		addCondition("created_at < $%d", *filter.CreatedBefore)
+	}
+
+	query := fmt.Sprintf("SELECT record FROM %s", service.table())
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY created_at DESC"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	return query, args
+}
+
+func (service *postgresStoreService) table() string {
+	return pgx.Identifier{service.tableName}.Sanitize()
+}
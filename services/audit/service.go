@@ -0,0 +1,256 @@
+// Package audit implements the audit log recording who made the mutating operations on the users, when and what
+// they changed
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/correlation"
+	"github.com/decentralized-cloud/user/services/idgenerator"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+)
+
+// DefaultListLimit is the maximum number of the audit records listed if the filter does not limit them
+const DefaultListLimit = 100
+
+// erasedPseudonymPrefix prefixes the pseudonyms the email addresses of the erased users are replaced with in the
+// audit records
+const erasedPseudonymPrefix = "erased-"
+
+type auditService struct {
+	logger             *zap.Logger
+	storeService       StoreContract
+	clockService       clock.ClockContract
+	idGeneratorService idgenerator.IDGeneratorContract
+}
+
+// NewAuditService creates new instance of the auditService, setting up all dependencies and returns the instance
+// logger: Mandatory. Reference to the logger service
+// storeService: Mandatory. Reference to the service that persists the audit records
+// clockService: Mandatory. Reference to the service that provides the current time
+// idGeneratorService: Mandatory. Reference to the service that generates the audit record ids
+// Returns the new service or error if something goes wrong
+func NewAuditService(
+	logger *zap.Logger,
+	storeService StoreContract,
+	clockService clock.ClockContract,
+	idGeneratorService idgenerator.IDGeneratorContract) (AuditContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	if storeService == nil {
+		return nil, commonErrors.NewArgumentNilError("storeService", "storeService is required")
+	}
+
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	if idGeneratorService == nil {
+		return nil, commonErrors.NewArgumentNilError("idGeneratorService", "idGeneratorService is required")
+	}
+
+	return &auditService{
+		logger:             logger,
+		storeService:       storeService,
+		clockService:       clockService,
+		idGeneratorService: idGeneratorService,
+	}, nil
+}
+
+// RecordOperation records the given mutating operation made by the user found in the context, along with the
+// changes made by the operation. The record is logged if it could not be persisted, so it is not lost.
+// ctx: Mandatory The reference to the context
+// operation: Mandatory. The mutating operation made
+// email: Mandatory. The email address of the user the operation is made on
+// before: Optional. The user before the operation, nil if the operation created the user
+// after: Optional. The user after the operation, nil if the operation deleted the user
+// Returns error if something goes wrong.
+func (service *auditService) RecordOperation(
+	ctx context.Context,
+	operation models.AuditOperation,
+	email string,
+	before *models.User,
+	after *models.User) error {
+	changes, err := getChanges(before, after)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to find the changes made by the operation", err)
+	}
+
+	// The operations not made on behalf of an authenticated user, e.g. the saga compensations, are recorded
+	// without an actor
+	parsedToken, _ := ctx.Value(models.ContextKeyParsedToken).(models.ParsedToken)
+
+	record := &models.AuditRecord{
+		RecordID:     service.idGeneratorService.NewID(),
+		Operation:    operation,
+		ActorSubject: parsedToken.Subject,
+		ActorEmail:   parsedToken.Email,
+		Email:        email,
+		Before:       before,
+		After:        after,
+		Changes:      changes,
+		CreatedAt:    service.clockService.Now(),
+	}
+
+	if err = service.storeService.SaveAuditRecord(ctx, record); err != nil {
+		correlation.GetLogger(ctx, service.logger).Error(
+			"failed to save audit record",
+			zap.String("recordID", record.RecordID),
+			zap.String("operation", string(record.Operation)),
+			zap.String("actorSubject", record.ActorSubject),
+			zap.String("actorEmail", record.ActorEmail),
+			zap.String("email", record.Email),
+			zap.Any("changes", record.Changes),
+			zap.Time("createdAt", record.CreatedAt),
+			zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+// ListAuditRecords lists the audit records that matched the criteria, the latest first
+// ctx: Mandatory The reference to the context
+// filter: Mandatory. The criteria the audit records are listed by
+// Returns either the audit records or error if something goes wrong.
+func (service *auditService) ListAuditRecords(
+	ctx context.Context,
+	filter *models.AuditRecordFilter) ([]models.AuditRecord, error) {
+	limitedFilter := *filter
+	if limitedFilter.Limit <= 0 {
+		limitedFilter.Limit = DefaultListLimit
+	}
+
+	return service.storeService.ListAuditRecords(ctx, &limitedFilter)
+}
+
+// AnonymizeAuditRecords replaces the email address of the erased user with a pseudonym in all the audit records
+// made on or by the user, and removes the personal data the records contain. The records still tell which operation
+// was made when and which fields it changed, but not the values of the fields. The records made by the user keep
+// the pseudonym as the actor without the subject, so the operations made by the same erased user can still be told
+// apart. Anonymizing the records again is harmless, so a failed erasure can be retried.
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the erased user
+// Returns either the pseudonym the email address is replaced with or error if something goes wrong.
+func (service *auditService) AnonymizeAuditRecords(
+	ctx context.Context,
+	email string) (string, error) {
+	// The records are not limited, every record of the user must be anonymized
+	madeOnRecords, err := service.storeService.ListAuditRecords(ctx, &models.AuditRecordFilter{Email: email})
+	if err != nil {
+		return "", err
+	}
+
+	madeByRecords, err := service.storeService.ListAuditRecords(ctx, &models.AuditRecordFilter{ActorEmail: email})
+	if err != nil {
+		return "", err
+	}
+
+	pseudonym := erasedPseudonymPrefix + service.idGeneratorService.NewID()
+
+	records := map[string]models.AuditRecord{}
+	for _, record := range append(madeOnRecords, madeByRecords...) {
+		records[record.RecordID] = record
+	}
+
+	for _, record := range records {
+		anonymizedRecord := anonymizeAuditRecord(record, email, pseudonym)
+		if err = service.storeService.ReplaceAuditRecord(ctx, &anonymizedRecord); err != nil {
+			return "", err
+		}
+	}
+
+	return pseudonym, nil
+}
+
+// anonymizeAuditRecord replaces the email address with the pseudonym in the record and removes the values of the
+// user fields from the record if it is made on the user
+func anonymizeAuditRecord(record models.AuditRecord, email string, pseudonym string) models.AuditRecord {
+	if record.Email == email {
+		record.Email = pseudonym
+		record.Before = nil
+		record.After = nil
+
+		changes := make([]models.AuditChange, 0, len(record.Changes))
+		for _, change := range record.Changes {
+			changes = append(changes, models.AuditChange{Field: change.Field})
+		}
+
+		record.Changes = changes
+	}
+
+	if record.ActorEmail == email {
+		record.ActorEmail = pseudonym
+		record.ActorSubject = ""
+	}
+
+	return record
+}
+
+// getChanges compares the JSON encoded fields of the user before and after the operation and returns the fields
+// that are changed, sorted by the field name
+func getChanges(before *models.User, after *models.User) ([]models.AuditChange, error) {
+	beforeFields, err := getFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := getFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []models.AuditChange{}
+	for field, beforeValue := range beforeFields {
+		if afterValue := afterFields[field]; beforeValue != afterValue {
+			changes = append(changes, models.AuditChange{Field: field, Before: beforeValue, After: afterValue})
+		}
+	}
+
+	for field, afterValue := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes = append(changes, models.AuditChange{Field: field, After: afterValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes, nil
+}
+
+func getFields(user *models.User) (map[string]string, error) {
+	fields := map[string]string{}
+	if user == nil {
+		return fields, nil
+	}
+
+	encodedUser, err := json.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+
+	rawFields := map[string]json.RawMessage{}
+	if err = json.Unmarshal(encodedUser, &rawFields); err != nil {
+		return nil, err
+	}
+
+	// The null and empty string fields are treated as if they did not exist, so e.g. a user with no memberships is not
+	// reported as a change from no field to null
+	for field, value := range rawFields {
+		if string(value) != "null" && string(value) != `""` {
+			fields[field] = string(value)
+		}
+	}
+
+	return fields, nil
+}
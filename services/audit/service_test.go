@@ -0,0 +1,332 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/audit"
+	auditMock "github.com/decentralized-cloud/user/services/audit/mock"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	idGeneratorMock "github.com/decentralized-cloud/user/services/idgenerator/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestAuditService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Audit Service Tests")
+}
+
+var _ = Describe("Audit Service Tests", func() {
+	var (
+		mockCtrl               *gomock.Controller
+		sut                    audit.AuditContract
+		mockStoreService       *auditMock.MockStoreContract
+		mockClockService       *clockMock.MockClockContract
+		mockIDGeneratorService *idGeneratorMock.MockIDGeneratorContract
+		now                    time.Time
+		recordID               string
+		logs                   *observer.ObservedLogs
+		logger                 *zap.Logger
+		ctx                    context.Context
+		parsedToken            models.ParsedToken
+		email                  string
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockStoreService = auditMock.NewMockStoreContract(mockCtrl)
+
+		var core zapcore.Core
+		core, logs = observer.New(zapcore.InfoLevel)
+		logger = zap.New(core)
+
+		parsedToken = models.ParsedToken{Subject: cuid.New(), Email: cuid.New() + "@test.com"}
+		ctx = context.WithValue(context.Background(), models.ContextKeyParsedToken, parsedToken)
+		email = cuid.New() + "@test.com"
+
+		now = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			Return(now).
+			AnyTimes()
+
+		recordID = cuid.New()
+		mockIDGeneratorService = idGeneratorMock.NewMockIDGeneratorContract(mockCtrl)
+		mockIDGeneratorService.
+			EXPECT().
+			NewID().
+			Return(recordID).
+			AnyTimes()
+
+		sut, _ = audit.NewAuditService(logger, mockStoreService, mockClockService, mockIDGeneratorService)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate AuditService", func() {
+		When("logger is not provided and NewAuditService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := audit.NewAuditService(nil, mockStoreService, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("store service is not provided and NewAuditService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := audit.NewAuditService(logger, nil, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("clock service is not provided and NewAuditService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := audit.NewAuditService(logger, mockStoreService, nil, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("id generator service is not provided and NewAuditService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := audit.NewAuditService(logger, mockStoreService, mockClockService, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("all dependencies are resolved and NewAuditService is called", func() {
+			It("should instantiate the new AuditService", func() {
+				service, err := audit.NewAuditService(logger, mockStoreService, mockClockService, mockIDGeneratorService)
+				Ω(err).Should(BeNil())
+				Ω(service).ShouldNot(BeNil())
+			})
+		})
+	})
+
+	Context("AuditService is instantiated", func() {
+		When("RecordOperation is called", func() {
+			It("should save the record with the actor found in the context", func() {
+				after := models.User{}
+				mockStoreService.
+					EXPECT().
+					SaveAuditRecord(ctx, gomock.Any()).
+					Do(func(_ context.Context, record *models.AuditRecord) {
+						Ω(record.RecordID).Should(Equal(recordID))
+						Ω(record.Operation).Should(Equal(models.AuditOperationCreate))
+						Ω(record.ActorSubject).Should(Equal(parsedToken.Subject))
+						Ω(record.ActorEmail).Should(Equal(parsedToken.Email))
+						Ω(record.Email).Should(Equal(email))
+						Ω(record.Before).Should(BeNil())
+						Ω(record.After).Should(Equal(&after))
+						Ω(record.Changes).Should(BeEmpty())
+						Ω(record.CreatedAt).Should(Equal(now))
+					}).
+					Return(nil)
+
+				Ω(sut.RecordOperation(ctx, models.AuditOperationCreate, email, nil, &after)).Should(Succeed())
+			})
+		})
+
+		When("RecordOperation is called for an update that changed the user", func() {
+			It("should record the changed fields", func() {
+				before := models.User{}
+				after := models.User{
+					Memberships: []models.TenantMembership{{TenantID: "tenant-1", Role: models.TenantRoleMember, JoinedAt: now}},
+				}
+
+				mockStoreService.
+					EXPECT().
+					SaveAuditRecord(ctx, gomock.Any()).
+					Do(func(_ context.Context, record *models.AuditRecord) {
+						Ω(record.Changes).Should(HaveLen(1))
+						Ω(record.Changes[0].Field).Should(Equal("Memberships"))
+						Ω(record.Changes[0].Before).Should(BeEmpty())
+						Ω(record.Changes[0].After).Should(ContainSubstring(`"TenantID":"tenant-1"`))
+					}).
+					Return(nil)
+
+				Ω(sut.RecordOperation(ctx, models.AuditOperationUpdate, email, &before, &after)).Should(Succeed())
+			})
+		})
+
+		When("RecordOperation is called without an authenticated user in the context", func() {
+			It("should save the record without an actor", func() {
+				mockStoreService.
+					EXPECT().
+					SaveAuditRecord(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, record *models.AuditRecord) {
+						Ω(record.ActorSubject).Should(BeEmpty())
+						Ω(record.ActorEmail).Should(BeEmpty())
+					}).
+					Return(nil)
+
+				Ω(sut.RecordOperation(context.Background(), models.AuditOperationDelete, email, &models.User{}, nil)).Should(Succeed())
+			})
+		})
+
+		When("store service fails to save the record", func() {
+			It("should log the record and return the same error", func() {
+				expectedErr := errors.New(cuid.New())
+				mockStoreService.
+					EXPECT().
+					SaveAuditRecord(gomock.Any(), gomock.Any()).
+					Return(expectedErr)
+
+				err := sut.RecordOperation(ctx, models.AuditOperationUpdate, email, &models.User{}, &models.User{})
+				Ω(err).Should(Equal(expectedErr))
+
+				entries := logs.FilterMessage("failed to save audit record").All()
+				Ω(entries).Should(HaveLen(1))
+				Ω(entries[0].ContextMap()).Should(HaveKeyWithValue("operation", string(models.AuditOperationUpdate)))
+				Ω(entries[0].ContextMap()).Should(HaveKeyWithValue("actorEmail", parsedToken.Email))
+				Ω(entries[0].ContextMap()).Should(HaveKeyWithValue("email", email))
+			})
+		})
+
+		When("ListAuditRecords is called without a limit", func() {
+			It("should list the records using the default limit", func() {
+				filter := models.AuditRecordFilter{Email: email}
+				expectedRecords := []models.AuditRecord{{RecordID: cuid.New(), Email: email}}
+				mockStoreService.
+					EXPECT().
+					ListAuditRecords(ctx, gomock.Any()).
+					Do(func(_ context.Context, limitedFilter *models.AuditRecordFilter) {
+						Ω(limitedFilter.Email).Should(Equal(email))
+						Ω(limitedFilter.Limit).Should(Equal(audit.DefaultListLimit))
+					}).
+					Return(expectedRecords, nil)
+
+				records, err := sut.ListAuditRecords(ctx, &filter)
+				Ω(err).Should(BeNil())
+				Ω(records).Should(Equal(expectedRecords))
+				Ω(filter.Limit).Should(BeZero())
+			})
+		})
+
+		When("ListAuditRecords is called with a limit", func() {
+			It("should list the records using the given limit", func() {
+				mockStoreService.
+					EXPECT().
+					ListAuditRecords(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, limitedFilter *models.AuditRecordFilter) {
+						Ω(limitedFilter.Limit).Should(Equal(10))
+					}).
+					Return([]models.AuditRecord{}, nil)
+
+				_, err := sut.ListAuditRecords(ctx, &models.AuditRecordFilter{Limit: 10})
+				Ω(err).Should(BeNil())
+			})
+		})
+
+		When("store service fails to list the records", func() {
+			It("should return the same error", func() {
+				expectedErr := errors.New(cuid.New())
+				mockStoreService.
+					EXPECT().
+					ListAuditRecords(gomock.Any(), gomock.Any()).
+					Return(nil, expectedErr)
+
+				_, err := sut.ListAuditRecords(ctx, &models.AuditRecordFilter{})
+				Ω(err).Should(Equal(expectedErr))
+			})
+		})
+
+		When("AnonymizeAuditRecords is called", func() {
+			It("should replace the email address with a pseudonym and remove the personal data from every record of the user", func() {
+				otherEmail := cuid.New() + "@test.com"
+				madeOn := models.AuditRecord{
+					RecordID:     cuid.New(),
+					Operation:    models.AuditOperationUpdate,
+					ActorSubject: cuid.New(),
+					ActorEmail:   otherEmail,
+					Email:        email,
+					Before:       &models.User{Labels: map[string]string{"plan": "free"}},
+					After:        &models.User{Labels: map[string]string{"plan": "pro"}},
+					Changes:      []models.AuditChange{{Field: "Labels", Before: `{"plan":"free"}`, After: `{"plan":"pro"}`}},
+				}
+				madeBy := models.AuditRecord{
+					RecordID:     cuid.New(),
+					Operation:    models.AuditOperationUpdate,
+					ActorSubject: cuid.New(),
+					ActorEmail:   email,
+					Email:        otherEmail,
+					After:        &models.User{},
+					Changes:      []models.AuditChange{{Field: "Labels", After: `{"plan":"pro"}`}},
+				}
+
+				mockStoreService.
+					EXPECT().
+					ListAuditRecords(ctx, &models.AuditRecordFilter{Email: email}).
+					Return([]models.AuditRecord{madeOn}, nil)
+
+				mockStoreService.
+					EXPECT().
+					ListAuditRecords(ctx, &models.AuditRecordFilter{ActorEmail: email}).
+					Return([]models.AuditRecord{madeBy}, nil)
+
+				replaced := map[string]models.AuditRecord{}
+				mockStoreService.
+					EXPECT().
+					ReplaceAuditRecord(ctx, gomock.Any()).
+					Do(func(_ context.Context, record *models.AuditRecord) {
+						replaced[record.RecordID] = *record
+					}).
+					Return(nil).
+					Times(2)
+
+				pseudonym, err := sut.AnonymizeAuditRecords(ctx, email)
+				Ω(err).Should(BeNil())
+				Ω(pseudonym).Should(Equal("erased-" + recordID))
+
+				Ω(replaced[madeOn.RecordID].Email).Should(Equal(pseudonym))
+				Ω(replaced[madeOn.RecordID].ActorEmail).Should(Equal(otherEmail))
+				Ω(replaced[madeOn.RecordID].ActorSubject).Should(Equal(madeOn.ActorSubject))
+				Ω(replaced[madeOn.RecordID].Before).Should(BeNil())
+				Ω(replaced[madeOn.RecordID].After).Should(BeNil())
+				Ω(replaced[madeOn.RecordID].Changes).Should(Equal([]models.AuditChange{{Field: "Labels"}}))
+
+				Ω(replaced[madeBy.RecordID].Email).Should(Equal(otherEmail))
+				Ω(replaced[madeBy.RecordID].ActorEmail).Should(Equal(pseudonym))
+				Ω(replaced[madeBy.RecordID].ActorSubject).Should(BeEmpty())
+				Ω(replaced[madeBy.RecordID].After).ShouldNot(BeNil())
+				Ω(replaced[madeBy.RecordID].Changes).Should(Equal(madeBy.Changes))
+			})
+		})
+
+		When("store service fails to replace a record", func() {
+			It("should return the same error", func() {
+				expectedErr := errors.New(cuid.New())
+				mockStoreService.
+					EXPECT().
+					ListAuditRecords(ctx, gomock.Any()).
+					Return([]models.AuditRecord{{RecordID: cuid.New(), Email: email, ActorEmail: email}}, nil).
+					Times(2)
+
+				mockStoreService.
+					EXPECT().
+					ReplaceAuditRecord(ctx, gomock.Any()).
+					Return(expectedErr)
+
+				_, err := sut.AnonymizeAuditRecords(ctx, email)
+				Ω(err).Should(Equal(expectedErr))
+			})
+		})
+	})
+})
@@ -0,0 +1,98 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+)
+
+// maxAPIKeyNameLength is the maximum length of the names the users recognize their API keys by
+const maxAPIKeyNameLength = 100
+
+// CreateAPIKey mints a new API key for an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the name, the scopes and the expiry of the API key
+// Returns either the API key and the key itself, which is only returned once, or error if something goes wrong.
+func (service *businessService) CreateAPIKey(
+	ctx context.Context,
+	request *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	if err := service.validationPolicy.validateName(apiKeyNameField, request.Name); err != nil {
+		return &CreateAPIKeyResponse{
+			Err: NewRequestValidationError(err),
+		}, nil
+	}
+
+	// The API keys are kept by the stable ID of the user, so they keep working when the email address changes
+	email, user, err := service.readRequestedUser(ctx, request.Email, request.UserID)
+	if err != nil {
+		return &CreateAPIKeyResponse{
+			Err: err,
+		}, nil
+	}
+
+	if err = service.requireConsent(ctx, "CreateAPIKey", email); err != nil {
+		return &CreateAPIKeyResponse{
+			Err: err,
+		}, nil
+	}
+
+	apiKey, key, err := service.apiKeyService.CreateAPIKey(ctx, user.ID, request.Name, request.Scopes, request.ExpiresAt)
+	if err != nil {
+		return &CreateAPIKeyResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &CreateAPIKeyResponse{
+		APIKey: *apiKey,
+		Key:    key,
+	}, nil
+}
+
+// ListAPIKeys lists all the API keys of the user, including the revoked and the expired ones
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains either the email address or the ID of the user
+// Returns either the API keys of the user or error if something goes wrong.
+func (service *businessService) ListAPIKeys(
+	ctx context.Context,
+	request *ListAPIKeysRequest) (*ListAPIKeysResponse, error) {
+	_, user, err := service.readRequestedUser(ctx, request.Email, request.UserID)
+	if err != nil {
+		return &ListAPIKeysResponse{
+			Err: err,
+		}, nil
+	}
+
+	apiKeys, err := service.apiKeyService.ListAPIKeys(ctx, user.ID)
+	if err != nil {
+		return &ListAPIKeysResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &ListAPIKeysResponse{
+		APIKeys: apiKeys,
+	}, nil
+}
+
+// RevokeAPIKey revokes an API key of the user, so it can not be used anymore
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the ID of the API key
+// Returns error if something goes wrong.
+func (service *businessService) RevokeAPIKey(
+	ctx context.Context,
+	request *RevokeAPIKeyRequest) (*RevokeAPIKeyResponse, error) {
+	_, user, err := service.readRequestedUser(ctx, request.Email, request.UserID)
+	if err != nil {
+		return &RevokeAPIKeyResponse{
+			Err: err,
+		}, nil
+	}
+
+	if err = service.apiKeyService.RevokeAPIKey(ctx, user.ID, request.KeyID); err != nil {
+		return &RevokeAPIKeyResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &RevokeAPIKeyResponse{}, nil
+}
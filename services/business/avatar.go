@@ -0,0 +1,235 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/eventing"
+	"github.com/decentralized-cloud/user/services/objectstorage"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// avatarsFeature is the name GetEnabledFeatures reports the optional avatars with
+const avatarsFeature = "avatars"
+
+// GetUserAvatar creates the URL the avatar image of an existing user is downloaded from. The image is downloaded from
+// the object storage directly, so the URL is only valid for a limited time.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the avatar of an existing user
+// Returns either the URL of the avatar image or error if something goes wrong. NotFoundError is returned if the user
+// has no avatar.
+func (service *businessService) GetUserAvatar(
+	ctx context.Context,
+	request *GetUserAvatarRequest) (*GetUserAvatarResponse, error) {
+	if !service.avatarsEnabled {
+		return &GetUserAvatarResponse{
+			Err: NewFeatureDisabledError(avatarsFeature),
+		}, nil
+	}
+
+	email, user, err := service.readRequestedUser(ctx, request.Email, request.UserID)
+	if err != nil {
+		return &GetUserAvatarResponse{
+			Err: err,
+		}, nil
+	}
+
+	avatar := user.Avatar
+	if avatar == nil {
+		return &GetUserAvatarResponse{
+			Err: commonErrors.NewNotFoundErrorWithError(fmt.Errorf("user %s has no avatar", email)),
+		}, nil
+	}
+
+	url, expiresAt, err := service.presignAvatar(ctx, avatar)
+	if err != nil {
+		return &GetUserAvatarResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &GetUserAvatarResponse{
+		URL:         url,
+		ContentHash: avatar.ContentHash,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// SetUserAvatar stores the avatar image of an existing user in the object storage, replacing the avatar set before
+// if any. The image must be of one of the allowed content types and its content must match the type.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the avatar image
+// Returns either the user after the avatar is set and the URL of the avatar image or error if something goes wrong.
+func (service *businessService) SetUserAvatar(
+	ctx context.Context,
+	request *SetUserAvatarRequest) (*SetUserAvatarResponse, error) {
+	if !service.avatarsEnabled {
+		return &SetUserAvatarResponse{
+			Err: NewFeatureDisabledError(avatarsFeature),
+		}, nil
+	}
+
+	contentType, err := service.validateAvatar(request)
+	if err != nil {
+		return &SetUserAvatarResponse{
+			Err: err,
+		}, nil
+	}
+
+	// The user is read before it is updated so the audit record contains what the update changed, and the image set
+	// before can be deleted once it is replaced
+	email, user, err := service.readRequestedUser(ctx, request.Email, request.UserID)
+	if err != nil {
+		return &SetUserAvatarResponse{
+			Err: err,
+		}, nil
+	}
+
+	if err = service.requireConsent(ctx, "SetUserAvatar", email); err != nil {
+		return &SetUserAvatarResponse{
+			Err: err,
+		}, nil
+	}
+
+	contentHash := sha256.Sum256(request.Content)
+	avatar := models.Avatar{ContentHash: hex.EncodeToString(contentHash[:])}
+	avatar.ObjectKey = getAvatarObjectKey(user.ID, avatar.ContentHash)
+
+	// The image is stored before the user refers to it, so the user never refers to an image that is not stored
+	if err = service.objectStorageService.PutObject(ctx, &objectstorage.Object{
+		Key:         avatar.ObjectKey,
+		ContentType: contentType,
+		Content:     request.Content,
+	}); err != nil {
+		return &SetUserAvatarResponse{
+			Err: err,
+		}, nil
+	}
+
+	response, err := service.repositoryService.SetUserAvatar(ctx, &repository.SetUserAvatarRequest{
+		Email:  email,
+		Avatar: avatar,
+	})
+
+	if err != nil {
+		return &SetUserAvatarResponse{
+			Err: err,
+		}, nil
+	}
+
+	// The image set before is only deleted once the user no longer refers to it. Failing to delete it only leaves an
+	// image nobody refers to in the bucket.
+	if previousAvatar := user.Avatar; previousAvatar != nil && previousAvatar.ObjectKey != avatar.ObjectKey {
+		service.logIgnoredError(ctx, "failed to delete the previous avatar image", service.objectStorageService.DeleteObject(ctx, previousAvatar.ObjectKey))
+	}
+
+	service.logIgnoredError(ctx, "failed to record the operation in the audit log", service.auditService.RecordOperation(ctx, models.AuditOperationUpdate, email, &user, &response.User))
+
+	service.logIgnoredError(ctx, "failed to publish the user updated event", service.eventingService.PublishUserUpdated(ctx, &eventing.UserUpdatedEvent{
+		Email:  email,
+		User:   response.User,
+		Cursor: response.Cursor,
+	}))
+
+	url, expiresAt, err := service.presignAvatar(ctx, &avatar)
+	if err != nil {
+		return &SetUserAvatarResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &SetUserAvatarResponse{
+		User:        response.User,
+		Cursor:      response.Cursor,
+		URL:         url,
+		ContentHash: avatar.ContentHash,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// validateAvatar checks the avatar image is within the size limit and of one of the allowed content types. The type
+// is detected from the content rather than trusted, so an image declared as PNG must also look like a PNG image.
+// request: Mandatory. The request contains the avatar image
+// Returns either the content type the image is stored with or error if the image is not accepted
+func (service *businessService) validateAvatar(request *SetUserAvatarRequest) (string, error) {
+	maxSize, err := service.configurationService.GetAvatarMaxSize()
+	if err != nil {
+		return "", err
+	}
+
+	if len(request.Content) > maxSize {
+		return "", commonErrors.NewArgumentError("content", fmt.Sprintf("avatar image must be at most %d bytes", maxSize))
+	}
+
+	allowedContentTypes, err := service.configurationService.GetAvatarAllowedContentTypes()
+	if err != nil {
+		return "", err
+	}
+
+	contentType, _, err := mime.ParseMediaType(request.ContentType)
+	if err != nil {
+		return "", commonErrors.NewArgumentErrorWithError("contentType", "content type is invalid", err)
+	}
+
+	allowed := false
+	for _, allowedContentType := range allowedContentTypes {
+		if contentType == allowedContentType {
+			allowed = true
+
+			break
+		}
+	}
+
+	if !allowed {
+		return "", commonErrors.NewArgumentError(
+			"contentType",
+			fmt.Sprintf("avatar image must be one of %s", strings.Join(allowedContentTypes, ", ")))
+	}
+
+	if detectedContentType := http.DetectContentType(request.Content); detectedContentType != contentType {
+		return "", commonErrors.NewArgumentError(
+			"content",
+			fmt.Sprintf("avatar image content is %s rather than %s", detectedContentType, contentType))
+	}
+
+	return contentType, nil
+}
+
+// presignAvatar creates the URL the avatar image is downloaded from
+// ctx: Mandatory The reference to the context
+// avatar: Mandatory. The avatar to create the URL for
+// Returns either the URL and the time it expires at or error if something goes wrong
+func (service *businessService) presignAvatar(ctx context.Context, avatar *models.Avatar) (string, time.Time, error) {
+	expiry, err := service.configurationService.GetAvatarURLExpiry()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiresAt := service.clockService.Now().Add(expiry)
+
+	url, err := service.objectStorageService.PresignGetObject(ctx, avatar.ObjectKey, expiry)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return url, expiresAt, nil
+}
+
+// getAvatarObjectKey returns the key the avatar image is stored with. The key is derived from the hash of the stable
+// ID of the user, so the image stays with the user when its email address changes and the key is made of the same
+// characters whatever the format of the ID is, and from the hash of the image, so the clients caching the image by
+// its URL see the new image once it changes.
+func getAvatarObjectKey(userID string, contentHash string) string {
+	userIDHash := sha256.Sum256([]byte(userID))
+
+	return fmt.Sprintf("avatars/%s/%s", hex.EncodeToString(userIDHash[:]), contentHash)
+}
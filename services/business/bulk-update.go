@@ -0,0 +1,358 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/eventing"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// defaultBulkUpdateSampleSize is the number of the matched users returned by a preview if the request does not
+// specify it
+const defaultBulkUpdateSampleSize = 10
+
+// errTooManyUsersMatched stops streaming the matched users once there are more than a bulk update can update
+var errTooManyUsersMatched = errors.New("too many users matched")
+
+// bulkUpdatePreview contains everything the confirmation token of a preview is bound to, so the token can only
+// confirm the exact update that is previewed, applied to the same number of users
+type bulkUpdatePreview struct {
+	Emails       []string
+	User         models.User
+	UpdateMask   []string
+	MatchedCount int64
+	ExpiresAt    int64
+}
+
+// PreviewBulkUpdateUsers counts the users that match the filter and returns a sample of them along with the
+// token that confirms applying the update to them
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the filter and the update to preview
+// Returns either the preview or error if something goes wrong.
+func (service *businessService) PreviewBulkUpdateUsers(
+	ctx context.Context,
+	request *PreviewBulkUpdateUsersRequest) (*PreviewBulkUpdateUsersResponse, error) {
+	if err := service.validationPolicy.validateUpdatedUser(request.User, request.UpdateMask); err != nil {
+		return &PreviewBulkUpdateUsersResponse{Err: NewRequestValidationError(err)}, nil
+	}
+
+	secret, err := service.getBulkUpdateTokenSecret()
+	if err != nil {
+		return &PreviewBulkUpdateUsersResponse{Err: err}, nil
+	}
+
+	previewTTL, err := service.configurationService.GetBulkUpdatePreviewTTL()
+	if err != nil {
+		return &PreviewBulkUpdateUsersResponse{Err: err}, nil
+	}
+
+	maxUsers, err := service.configurationService.GetBulkUpdateMaxUsers()
+	if err != nil {
+		return &PreviewBulkUpdateUsersResponse{Err: err}, nil
+	}
+
+	sampleSize := request.SampleSize
+	if sampleSize == 0 {
+		sampleSize = defaultBulkUpdateSampleSize
+	}
+
+	response, err := service.repositoryService.Search(ctx, &repository.SearchRequest{
+		Pagination: models.Pagination{First: &sampleSize},
+		Emails:     request.Emails,
+	})
+	if err != nil {
+		return &PreviewBulkUpdateUsersResponse{Err: err}, nil
+	}
+
+	if response.TotalCount > int64(maxUsers) {
+		return &PreviewBulkUpdateUsersResponse{
+			Err: commonErrors.NewArgumentError(
+				"request",
+				fmt.Sprintf("the filter matches %d users, a bulk update can update up to %d users", response.TotalCount, maxUsers)),
+		}, nil
+	}
+
+	expiresAt := service.clockService.Now().Add(previewTTL).Truncate(time.Second)
+	token, err := signBulkUpdatePreview(secret, bulkUpdatePreview{
+		Emails:       request.Emails,
+		User:         request.User,
+		UpdateMask:   request.UpdateMask,
+		MatchedCount: response.TotalCount,
+		ExpiresAt:    expiresAt.Unix(),
+	})
+	if err != nil {
+		return &PreviewBulkUpdateUsersResponse{Err: err}, nil
+	}
+
+	return &PreviewBulkUpdateUsersResponse{
+		MatchedCount:      response.TotalCount,
+		Sample:            response.Users,
+		ConfirmationToken: token,
+		ExpiresAt:         expiresAt,
+	}, nil
+}
+
+// BulkUpdateUsers applies the update to the fields in the update mask of all the users that match the filter.
+// The update must be previewed first, and is rejected if the confirmation token of the preview expired or the
+// number of the matched users changed since. The users are updated one by one at the configured rate, each of
+// them is audited and published as updated.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the filter, the update and the confirmation token of the preview
+// Returns either the result of the bulk update or error if something goes wrong.
+func (service *businessService) BulkUpdateUsers(
+	ctx context.Context,
+	request *BulkUpdateUsersRequest) (*BulkUpdateUsersResponse, error) {
+	if err := service.validationPolicy.validateUpdatedUser(request.User, request.UpdateMask); err != nil {
+		return &BulkUpdateUsersResponse{Err: NewRequestValidationError(err)}, nil
+	}
+
+	secret, err := service.getBulkUpdateTokenSecret()
+	if err != nil {
+		return &BulkUpdateUsersResponse{Err: err}, nil
+	}
+
+	rateLimit, err := service.configurationService.GetBulkUpdateRateLimit()
+	if err != nil {
+		return &BulkUpdateUsersResponse{Err: err}, nil
+	}
+
+	maxUsers, err := service.configurationService.GetBulkUpdateMaxUsers()
+	if err != nil {
+		return &BulkUpdateUsersResponse{Err: err}, nil
+	}
+
+	expiresAt, err := parseBulkUpdateTokenExpiry(request.ConfirmationToken, service.clockService.Now())
+	if err != nil {
+		return &BulkUpdateUsersResponse{Err: err}, nil
+	}
+
+	emails := []string{}
+	tooManyUsersMatched := false
+	_, err = service.repositoryService.StreamSearch(ctx, &repository.StreamSearchRequest{
+		Emails: request.Emails,
+		Send: func(user models.UserWithCursor) error {
+			if len(emails) == maxUsers {
+				tooManyUsersMatched = true
+
+				return errTooManyUsersMatched
+			}
+
+			emails = append(emails, user.Email)
+
+			return nil
+		},
+	})
+
+	// Matching more users than allowed changes the number of the matched users as well, which the token check reports
+	if err != nil && !tooManyUsersMatched {
+		return &BulkUpdateUsersResponse{Err: err}, nil
+	}
+
+	matchedCount := int64(len(emails))
+	if tooManyUsersMatched {
+		matchedCount++
+	}
+
+	expectedToken, err := signBulkUpdatePreview(secret, bulkUpdatePreview{
+		Emails:       request.Emails,
+		User:         request.User,
+		UpdateMask:   request.UpdateMask,
+		MatchedCount: matchedCount,
+		ExpiresAt:    expiresAt.Unix(),
+	})
+	if err != nil {
+		return &BulkUpdateUsersResponse{Err: err}, nil
+	}
+
+	if !hmac.Equal([]byte(expectedToken), []byte(request.ConfirmationToken)) {
+		return &BulkUpdateUsersResponse{
+			Err: commonErrors.NewArgumentError(
+				"confirmationToken",
+				"confirmationToken does not match the filter and the update, or the matched users changed since the preview, preview the update again"),
+		}, nil
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(rateLimit))
+	defer ticker.Stop()
+
+	response := &BulkUpdateUsersResponse{
+		FailedEmails: []string{},
+	}
+
+	for index, email := range emails {
+		if index > 0 {
+			select {
+			case <-ctx.Done():
+				response.Err = commonErrors.NewUnknownErrorWithError("bulk update is cancelled", ctx.Err())
+				response.FailedEmails = append(response.FailedEmails, emails[index:]...)
+
+				return response, nil
+			case <-ticker.C:
+			}
+		}
+
+		if err = service.updateMaskedUserFields(ctx, email, request.User, request.UpdateMask); err != nil {
+			response.FailedEmails = append(response.FailedEmails, email)
+
+			continue
+		}
+
+		response.UpdatedCount++
+	}
+
+	return response, nil
+}
+
+func (service *businessService) updateMaskedUserFields(
+	ctx context.Context,
+	email string,
+	update models.User,
+	updateMask []string) error {
+	readUserResponse, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: email,
+	})
+	if err != nil {
+		return err
+	}
+
+	updateUserResponse, err := service.repositoryService.UpdateUser(ctx, &repository.UpdateUserRequest{
+		Email: email,
+		User:  applyUpdateMask(readUserResponse.User, update, updateMask),
+	})
+	if err != nil {
+		return err
+	}
+
+	service.logIgnoredError(ctx, "failed to record the operation in the audit log", service.auditService.RecordOperation(ctx, models.AuditOperationUpdate, email, &readUserResponse.User, &updateUserResponse.User))
+
+	service.logIgnoredError(ctx, "failed to publish the user updated event", service.eventingService.PublishUserUpdated(ctx, &eventing.UserUpdatedEvent{
+		Email:  email,
+		User:   updateUserResponse.User,
+		Cursor: updateUserResponse.Cursor,
+	}))
+
+	return nil
+}
+
+func (service *businessService) getBulkUpdateTokenSecret() (string, error) {
+	secret, err := service.configurationService.GetBulkUpdateTokenSecret()
+	if err != nil {
+		return "", err
+	}
+
+	if secret == "" {
+		return "", commonErrors.NewUnknownError("bulk update is disabled as BULK_UPDATE_TOKEN_SECRET is not provided")
+	}
+
+	return secret, nil
+}
+
+// signBulkUpdatePreview returns the confirmation token of the preview, made of the expiry time of the token and the
+// signature of the preview. The emails and the update mask are sorted so their order does not matter.
+func signBulkUpdatePreview(secret string, preview bulkUpdatePreview) (string, error) {
+	preview.Emails = sortedCopy(preview.Emails)
+	preview.UpdateMask = sortedCopy(preview.UpdateMask)
+
+	payload, err := json.Marshal(preview)
+	if err != nil {
+		return "", commonErrors.NewUnknownErrorWithError("failed to encode the bulk update preview", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(payload)
+
+	return strconv.FormatInt(preview.ExpiresAt, 10) + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+func parseBulkUpdateTokenExpiry(token string, now time.Time) (time.Time, error) {
+	separatorIndex := strings.Index(token, ".")
+	if separatorIndex <= 0 {
+		return time.Time{}, commonErrors.NewArgumentError("confirmationToken", "confirmationToken is malformed")
+	}
+
+	expiresAt, err := strconv.ParseInt(token[:separatorIndex], 10, 64)
+	if err != nil {
+		return time.Time{}, commonErrors.NewArgumentError("confirmationToken", "confirmationToken is malformed")
+	}
+
+	if now.Unix() >= expiresAt {
+		return time.Time{}, commonErrors.NewArgumentError("confirmationToken", "confirmationToken is expired, preview the update again")
+	}
+
+	return time.Unix(expiresAt, 0).UTC(), nil
+}
+
+func sortedCopy(values []string) []string {
+	sortedValues := append([]string{}, values...)
+	sort.Strings(sortedValues)
+
+	return sortedValues
+}
+
+// bulkUpdatableUserFields contains the only user fields a bulk update can change. The other fields are either changed
+// through their dedicated operations, e.g. the memberships by adding the user to and removing it from the tenants, or
+// are set by the repository, so the fields added to the user later can not be bulk updated unless they are listed here.
+var bulkUpdatableUserFields = map[string]bool{
+	"Labels":        true,
+	"DataResidency": true,
+}
+
+// applyUpdateMask returns the user with the fields listed in the update mask copied from the update
+func applyUpdateMask(user models.User, update models.User, updateMask []string) models.User {
+	userValue := reflect.ValueOf(&user).Elem()
+	updateValue := reflect.ValueOf(update)
+
+	for _, path := range updateMask {
+		if field, ok := getUserFieldsByPath()[path]; ok {
+			userValue.FieldByIndex(field.Index).Set(updateValue.FieldByIndex(field.Index))
+		}
+	}
+
+	return user
+}
+
+// getUserFieldsByPath returns the exported fields of the user keyed by their update mask path, which is the field
+// name starting with a lower case letter the same way the fields are named in the GRPC contract. Only the fields that
+// can be bulk updated are returned.
+func getUserFieldsByPath() map[string]reflect.StructField {
+	userType := reflect.TypeOf(models.User{})
+	fields := map[string]reflect.StructField{}
+
+	for index := 0; index < userType.NumField(); index++ {
+		field := userType.Field(index)
+		if field.PkgPath != "" || !bulkUpdatableUserFields[field.Name] {
+			continue
+		}
+
+		path := []rune(field.Name)
+		path[0] = unicode.ToLower(path[0])
+		fields[string(path)] = field
+	}
+
+	return fields
+}
+
+func isUpdateMaskPath(value interface{}) error {
+	if path, ok := value.(string); ok {
+		if _, ok = getUserFieldsByPath()[path]; !ok {
+			return fmt.Errorf("%s is not a user field that can be bulk updated", path)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/consent"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+const (
+	// maxPolicyNameLength is the maximum length of the names of the policies the users accept
+	maxPolicyNameLength = 100
+
+	// maxPolicyVersionLength is the maximum length of the versions of the policies the users accept
+	maxPolicyVersionLength = 100
+)
+
+// consentOperations are the operations that can be configured to require the user to accept the required policies
+// first. Reading, exporting, deleting and erasing the user never require it, so a user that does not accept a new
+// version of a policy can still take their data and leave.
+var consentOperations = map[string]bool{
+	"UpdateUser":            true,
+	"ChangeEmail":           true,
+	"UpdateUserPreferences": true,
+	"SetUserAvatar":         true,
+	"CreateAPIKey":          true,
+	"SetPassword":           true,
+	"ChangePassword":        true,
+	"EnrollMFA":             true,
+}
+
+// RecordConsent records that an existing user accepted a version of a policy
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the policy and the version the user accepted
+// Returns either the recorded consent or error if something goes wrong.
+func (service *businessService) RecordConsent(
+	ctx context.Context,
+	request *RecordConsentRequest) (*RecordConsentResponse, error) {
+	if _, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	}); err != nil {
+		return &RecordConsentResponse{
+			Err: err,
+		}, nil
+	}
+
+	consent, err := service.consentService.RecordConsent(ctx, request.Email, request.Policy, request.Version)
+	if err != nil {
+		return &RecordConsentResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &RecordConsentResponse{
+		Consent: *consent,
+	}, nil
+}
+
+// ListConsents lists the consents of an existing user and the required policy versions the user has not accepted yet
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the email address of the user
+// Returns either the consents and the outstanding policy versions of the user or error if something goes wrong.
+func (service *businessService) ListConsents(
+	ctx context.Context,
+	request *ListConsentsRequest) (*ListConsentsResponse, error) {
+	consents, err := service.consentService.ListConsents(ctx, request.Email)
+	if err != nil {
+		return &ListConsentsResponse{
+			Err: err,
+		}, nil
+	}
+
+	outstandingPolicies, err := service.consentService.ListOutstandingPolicies(ctx, request.Email)
+	if err != nil {
+		return &ListConsentsResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &ListConsentsResponse{
+		Consents:            consents,
+		OutstandingPolicies: outstandingPolicies,
+	}, nil
+}
+
+// requireConsent fails with ConsentRequiredError if the operation requires the user to accept the required policies
+// first and the user has not accepted the current version of any of them yet
+func (service *businessService) requireConsent(
+	ctx context.Context,
+	operation string,
+	email string) error {
+	if !service.consentRequiredOperations[operation] {
+		return nil
+	}
+
+	outstandingPolicies, err := service.consentService.ListOutstandingPolicies(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if len(outstandingPolicies) > 0 {
+		return consent.NewConsentRequiredError(email, outstandingPolicies)
+	}
+
+	return nil
+}
+
+// getConsentRequiredOperations returns the configured operations that require the user to accept the required
+// policies first, and fails if any of them can not require it
+func getConsentRequiredOperations(configurationService configuration.ConfigurationContract) (map[string]bool, error) {
+	operations, err := configurationService.GetConsentRequiredOperations()
+	if err != nil {
+		return nil, err
+	}
+
+	consentRequiredOperations := map[string]bool{}
+	for _, operation := range operations {
+		if !consentOperations[operation] {
+			return nil, commonErrors.NewUnknownError(fmt.Sprintf("CONSENT_REQUIRED_OPERATIONS contains unsupported operation: %s", operation))
+		}
+
+		consentRequiredOperations[operation] = true
+	}
+
+	return consentRequiredOperations, nil
+}
@@ -3,8 +3,8 @@ package business
 
 import "context"
 
-// BusinessContract declares the service that can create new user, read, update
-// and delete existing users.
+// BusinessContract declares the service that can create new user, read, update,
+// delete and restore existing users.
 type BusinessContract interface {
 	// CreateUser creates a new user.
 	// ctx: Mandatory The reference to the context
@@ -22,6 +22,15 @@ type BusinessContract interface {
 		ctx context.Context,
 		request *ReadUserRequest) (*ReadUserResponse, error)
 
+	// ReadUsers reads the existing users with the given email addresses or IDs at once
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to read the existing users
+	// Returns either the users found along with the email addresses and the IDs of the users not found or error if
+	// something goes wrong.
+	ReadUsers(
+		ctx context.Context,
+		request *ReadUsersRequest) (*ReadUsersResponse, error)
+
 	// UpdateUser update an existing user
 	// ctx: Mandatory The reference to the context
 	// request: Mandatory. The request to update an existing user
@@ -30,6 +39,33 @@ type BusinessContract interface {
 		ctx context.Context,
 		request *UpdateUserRequest) (*UpdateUserResponse, error)
 
+	// ChangeEmail changes the email address of an existing user. The user keeps its ID, and the data stored for
+	// the user elsewhere is moved to the new email address.
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to change the email address of an existing user
+	// Returns either the result of changing the email address of an existing user or error if something goes wrong.
+	ChangeEmail(
+		ctx context.Context,
+		request *ChangeEmailRequest) (*ChangeEmailResponse, error)
+
+	// RequestEmailChange sends the token that confirms changing the email address of an existing user to the new
+	// email address, the email address is not changed until the change is confirmed
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to change the email address of an existing user
+	// Returns either the time the token expires at or error if something goes wrong.
+	RequestEmailChange(
+		ctx context.Context,
+		request *RequestEmailChangeRequest) (*RequestEmailChangeResponse, error)
+
+	// ConfirmEmailChange changes the email address of an existing user to the one the token sent to the new email
+	// address is issued for, and notifies the previous email address of the change
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the token sent to the new email address
+	// Returns either the result of changing the email address of an existing user or error if something goes wrong.
+	ConfirmEmailChange(
+		ctx context.Context,
+		request *ConfirmEmailChangeRequest) (*ConfirmEmailChangeResponse, error)
+
 	// DeleteUser delete an existing user
 	// ctx: Mandatory The reference to the context
 	// request: Mandatory. The request to delete an existing user
@@ -37,4 +73,485 @@ type BusinessContract interface {
 	DeleteUser(
 		ctx context.Context,
 		request *DeleteUserRequest) (*DeleteUserResponse, error)
+
+	// RestoreUser restores an existing soft deleted user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to restore an existing soft deleted user
+	// Returns either the result of restoring the user or error if something goes wrong.
+	RestoreUser(
+		ctx context.Context,
+		request *RestoreUserRequest) (*RestoreUserResponse, error)
+
+	// Search returns the list of users that matched the criteria
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the search criteria
+	// Returns the list of users that matched the criteria
+	Search(
+		ctx context.Context,
+		request *SearchRequest) (*SearchResponse, error)
+
+	// StreamSearch sends the users that matched the criteria one by one without loading all of them in memory
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the search criteria and the function to send the users to
+	// Returns either the number of the sent users or error if something goes wrong.
+	StreamSearch(
+		ctx context.Context,
+		request *StreamSearchRequest) (*StreamSearchResponse, error)
+
+	// GetSagaStatus reads the progress of an existing saga
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to read the progress of an existing saga
+	// Returns either the progress of the saga or error if something goes wrong.
+	GetSagaStatus(
+		ctx context.Context,
+		request *GetSagaStatusRequest) (*GetSagaStatusResponse, error)
+
+	// ListAuditRecords lists the audit records of the mutating operations that matched the criteria, the latest first
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the criteria the audit records are listed by
+	// Returns either the audit records or error if something goes wrong.
+	ListAuditRecords(
+		ctx context.Context,
+		request *ListAuditRecordsRequest) (*ListAuditRecordsResponse, error)
+
+	// GetEffectiveConfiguration reads the configuration the service is running with, the secrets are redacted
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to read the effective configuration
+	// Returns either the effective configuration or error if something goes wrong.
+	GetEffectiveConfiguration(
+		ctx context.Context,
+		request *GetEffectiveConfigurationRequest) (*GetEffectiveConfigurationResponse, error)
+
+	// GetEnabledFeatures reads the optional features the service is running with
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to read the enabled features
+	// Returns either the optional features or error if something goes wrong.
+	GetEnabledFeatures(
+		ctx context.Context,
+		request *GetEnabledFeaturesRequest) (*GetEnabledFeaturesResponse, error)
+
+	// PreviewBulkUpdateUsers counts the users that match the filter and returns a sample of them along with the
+	// token that confirms applying the update to them
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the filter and the update to preview
+	// Returns either the preview or error if something goes wrong.
+	PreviewBulkUpdateUsers(
+		ctx context.Context,
+		request *PreviewBulkUpdateUsersRequest) (*PreviewBulkUpdateUsersResponse, error)
+
+	// BulkUpdateUsers applies the previewed update to the fields in the update mask of all the users that match the filter
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the filter, the update and the confirmation token of the preview
+	// Returns either the result of the bulk update or error if something goes wrong.
+	BulkUpdateUsers(
+		ctx context.Context,
+		request *BulkUpdateUsersRequest) (*BulkUpdateUsersResponse, error)
+
+	// PurgeByLabel permanently deletes all the users tagged with the given test label. It is only enabled in the
+	// ephemeral environments to reset their state between the test runs.
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the test label of the users to purge
+	// Returns either the result of purging the users or error if something goes wrong.
+	PurgeByLabel(
+		ctx context.Context,
+		request *PurgeByLabelRequest) (*PurgeByLabelResponse, error)
+
+	// GetOutboxLag reads how many user lifecycle events the message broker has not confirmed receiving yet and for
+	// how long, so the consumers being stale can be told apart from a publisher backlog
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to read the publisher lag
+	// Returns either the publisher lag or error if something goes wrong.
+	GetOutboxLag(
+		ctx context.Context,
+		request *GetOutboxLagRequest) (*GetOutboxLagResponse, error)
+
+	// ListPendingEvents lists the user lifecycle events the message broker has not confirmed receiving yet
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the maximum number of the events to list
+	// Returns either the pending events, the oldest first, or error if something goes wrong.
+	ListPendingEvents(
+		ctx context.Context,
+		request *ListPendingEventsRequest) (*ListPendingEventsResponse, error)
+
+	// ForceFlush sends the buffered user lifecycle events and waits for the message broker to confirm receiving them
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to flush the pending events
+	// Returns either the number of the confirmed events or error if something goes wrong.
+	ForceFlush(
+		ctx context.Context,
+		request *ForceFlushRequest) (*ForceFlushResponse, error)
+
+	// GetUserPreferences reads the preferences of an existing user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to read the preferences of an existing user
+	// Returns either the preferences of the user or error if something goes wrong.
+	GetUserPreferences(
+		ctx context.Context,
+		request *GetUserPreferencesRequest) (*GetUserPreferencesResponse, error)
+
+	// UpdateUserPreferences merges the preferences into the preferences of an existing user, the preferences not in
+	// the request are left as they are
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the preferences to set and the keys of the preferences to remove
+	// Returns either the preferences of the user after they are merged or error if something goes wrong.
+	UpdateUserPreferences(
+		ctx context.Context,
+		request *UpdateUserPreferencesRequest) (*UpdateUserPreferencesResponse, error)
+
+	// GetUserAvatar creates the URL the avatar image of an existing user is downloaded from
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to read the avatar of an existing user
+	// Returns either the URL of the avatar image or error if something goes wrong.
+	GetUserAvatar(
+		ctx context.Context,
+		request *GetUserAvatarRequest) (*GetUserAvatarResponse, error)
+
+	// SetUserAvatar stores the avatar image of an existing user, replacing the avatar set before if any
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the avatar image
+	// Returns either the user after the avatar is set and the URL of the avatar image or error if something goes wrong.
+	SetUserAvatar(
+		ctx context.Context,
+		request *SetUserAvatarRequest) (*SetUserAvatarResponse, error)
+
+	// AddUserToTenant adds an existing user to a tenant with the given role
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the tenant and the role to add the user with
+	// Returns either the user after it is added to the tenant or error if something goes wrong.
+	AddUserToTenant(
+		ctx context.Context,
+		request *AddUserToTenantRequest) (*AddUserToTenantResponse, error)
+
+	// RemoveUserFromTenant removes an existing user from a tenant
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the tenant to remove the user from
+	// Returns either the user after it is removed from the tenant or error if something goes wrong.
+	RemoveUserFromTenant(
+		ctx context.Context,
+		request *RemoveUserFromTenantRequest) (*RemoveUserFromTenantResponse, error)
+
+	// ListUserTenants lists the tenants an existing user is a member of
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to list the tenants of an existing user
+	// Returns either the memberships of the user or error if something goes wrong.
+	ListUserTenants(
+		ctx context.Context,
+		request *ListUserTenantsRequest) (*ListUserTenantsResponse, error)
+
+	// GetReplicationStatus reads how far behind the primary database the standby database is, so the readiness to
+	// fail over to the standby database can be measured
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to read the replication status
+	// Returns either the replication status or error if something goes wrong.
+	GetReplicationStatus(
+		ctx context.Context,
+		request *GetReplicationStatusRequest) (*GetReplicationStatusResponse, error)
+
+	// ExportUsers sends all the users that matched the filter one by one, ordered by the order they are persisted in,
+	// so the export can be resumed after the cursor of the last sent user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the filter and the function to send the users to
+	// Returns either the number of the exported users or error if something goes wrong.
+	ExportUsers(
+		ctx context.Context,
+		request *ExportUsersRequest) (*ExportUsersResponse, error)
+
+	// IssueMagicLink issues a single-use magic link to an existing user and publishes its token, so the service that
+	// sends the emails can send it to the email address of the user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the email address of the user and the IP address and the device the
+	// magic link is requested from
+	// Returns either the time the magic link expires at or error if something goes wrong.
+	IssueMagicLink(
+		ctx context.Context,
+		request *IssueMagicLinkRequest) (*IssueMagicLinkResponse, error)
+
+	// RedeemMagicLink redeems the magic link sent to the email address of an existing user and returns the user it
+	// signs in
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the email address of the user, the token and the IP address and the
+	// device the magic link is redeemed from
+	// Returns either the user the magic link signs in or error if something goes wrong.
+	RedeemMagicLink(
+		ctx context.Context,
+		request *RedeemMagicLinkRequest) (*RedeemMagicLinkResponse, error)
+
+	// ExportPersonalData assembles everything stored about an existing user into a JSON archive the user can download
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to export the personal data of an existing user
+	// Returns either the JSON archive or error if something goes wrong.
+	ExportPersonalData(
+		ctx context.Context,
+		request *ExportPersonalDataRequest) (*ExportPersonalDataResponse, error)
+
+	// EraseUser erases an existing user on their request. The erasure is requested first, which returns the token
+	// that confirms it once the grace period ends. Confirming the erasure anonymizes the audit records of the user
+	// and permanently deletes the user.
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to request or confirm the erasure of an existing user
+	// Returns either the confirmation token or whether the user is erased, or error if something goes wrong.
+	EraseUser(
+		ctx context.Context,
+		request *EraseUserRequest) (*EraseUserResponse, error)
+
+	// SendVerificationEmail generates a new verification token for an existing user and sends it to the email address
+	// of the user. The token replaces the token sent before, so only the latest verification email can verify the address.
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to send the verification email to an existing user
+	// Returns either the time the token expires at or error if something goes wrong.
+	SendVerificationEmail(
+		ctx context.Context,
+		request *SendVerificationEmailRequest) (*SendVerificationEmailResponse, error)
+
+	// VerifyEmail verifies the email address of an existing user with the token sent in the verification email
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the token sent in the verification email
+	// Returns either the user after the email address is verified or error if something goes wrong.
+	VerifyEmail(
+		ctx context.Context,
+		request *VerifyEmailRequest) (*VerifyEmailResponse, error)
+
+	// SetPassword sets the first password of an existing user, so the user can sign in without an external identity
+	// provider. It fails if the user already has a password, ChangePassword must be used to replace it.
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the password to set
+	// Returns error if something goes wrong.
+	SetPassword(
+		ctx context.Context,
+		request *SetPasswordRequest) (*SetPasswordResponse, error)
+
+	// ChangePassword replaces the password of an existing user after verifying the current password
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the current and the new password
+	// Returns error if something goes wrong.
+	ChangePassword(
+		ctx context.Context,
+		request *ChangePasswordRequest) (*ChangePasswordResponse, error)
+
+	// VerifyPassword verifies the password matches the password of an existing user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the password to verify
+	// Returns either the user the password matched or error if something goes wrong.
+	VerifyPassword(
+		ctx context.Context,
+		request *VerifyPasswordRequest) (*VerifyPasswordResponse, error)
+
+	// WatchUsers sends the changes made to the users that matched the filter one by one as they happen, until the
+	// context is done
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the filter and the function to send the changes to
+	// Returns either the number of the sent changes or error if something goes wrong.
+	WatchUsers(
+		ctx context.Context,
+		request *WatchUsersRequest) (*WatchUsersResponse, error)
+
+	// CreateAPIKey mints a new API key for an existing user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the name, the scopes and the expiry of the API key
+	// Returns either the API key and the key itself, which is only returned once, or error if something goes wrong.
+	CreateAPIKey(
+		ctx context.Context,
+		request *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+
+	// ListAPIKeys lists all the API keys of the user, including the revoked and the expired ones
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the email address of the user
+	// Returns either the API keys of the user or error if something goes wrong.
+	ListAPIKeys(
+		ctx context.Context,
+		request *ListAPIKeysRequest) (*ListAPIKeysResponse, error)
+
+	// RevokeAPIKey revokes an API key of the user, so it can not be used anymore
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the ID of the API key
+	// Returns error if something goes wrong.
+	RevokeAPIKey(
+		ctx context.Context,
+		request *RevokeAPIKeyRequest) (*RevokeAPIKeyResponse, error)
+
+	// RecordLoginAttempt records the outcome of a login attempt of an existing user, locking the user once the number
+	// of the failed attempts reaches the configured lockout threshold
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the outcome of the login attempt
+	// Returns either the user after the login attempt is recorded or error if something goes wrong.
+	RecordLoginAttempt(
+		ctx context.Context,
+		request *RecordLoginAttemptRequest) (*RecordLoginAttemptResponse, error)
+
+	// UnlockUser unlocks an existing user and resets the number of its failed login attempts
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the email address of the user
+	// Returns either the user after it is unlocked or error if something goes wrong.
+	UnlockUser(
+		ctx context.Context,
+		request *UnlockUserRequest) (*UnlockUserResponse, error)
+
+	// EnrollMFA enrolls an existing user in an MFA method
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the method to enroll the user in
+	// Returns either the method the user is enrolled in or error if something goes wrong.
+	EnrollMFA(
+		ctx context.Context,
+		request *EnrollMFARequest) (*EnrollMFAResponse, error)
+
+	// ListMFAMethods lists the MFA methods an existing user is enrolled in
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the email address of the user
+	// Returns either the MFA methods of the user or error if something goes wrong.
+	ListMFAMethods(
+		ctx context.Context,
+		request *ListMFAMethodsRequest) (*ListMFAMethodsResponse, error)
+
+	// RemoveMFAMethod removes an MFA method from an existing user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the ID of the method to remove
+	// Returns either the user after the method is removed or error if something goes wrong.
+	RemoveMFAMethod(
+		ctx context.Context,
+		request *RemoveMFAMethodRequest) (*RemoveMFAMethodResponse, error)
+
+	// RecordConsent records that an existing user accepted a version of a policy
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the policy and the version the user accepted
+	// Returns either the recorded consent or error if something goes wrong.
+	RecordConsent(
+		ctx context.Context,
+		request *RecordConsentRequest) (*RecordConsentResponse, error)
+
+	// ListConsents lists the consents of an existing user and the required policy versions the user has not accepted yet
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the email address of the user
+	// Returns either the consents and the outstanding policy versions of the user or error if something goes wrong.
+	ListConsents(
+		ctx context.Context,
+		request *ListConsentsRequest) (*ListConsentsResponse, error)
+
+	// CreateGroup creates a new group with no member
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the name and the description of the group
+	// Returns either the new group or error if something goes wrong.
+	CreateGroup(
+		ctx context.Context,
+		request *CreateGroupRequest) (*CreateGroupResponse, error)
+
+	// ReadGroup reads an existing group along its members
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the ID of the group
+	// Returns either the group or error if something goes wrong.
+	ReadGroup(
+		ctx context.Context,
+		request *ReadGroupRequest) (*ReadGroupResponse, error)
+
+	// UpdateGroup renames and describes an existing group, its members are kept
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the new name and description of the group
+	// Returns either the updated group or error if something goes wrong.
+	UpdateGroup(
+		ctx context.Context,
+		request *UpdateGroupRequest) (*UpdateGroupResponse, error)
+
+	// DeleteGroup deletes an existing group, its members are not affected
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the ID of the group
+	// Returns either the result of deleting the group or error if something goes wrong.
+	DeleteGroup(
+		ctx context.Context,
+		request *DeleteGroupRequest) (*DeleteGroupResponse, error)
+
+	// ListGroups lists all the groups, or the groups of a user if the email address of the user is provided
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the optional email address of the user
+	// Returns either the groups ordered by their names or error if something goes wrong.
+	ListGroups(
+		ctx context.Context,
+		request *ListGroupsRequest) (*ListGroupsResponse, error)
+
+	// AddUserToGroup adds an existing user to an existing group
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the ID of the group and the email address of the user
+	// Returns either the group after the user is added or error if something goes wrong.
+	AddUserToGroup(
+		ctx context.Context,
+		request *AddUserToGroupRequest) (*AddUserToGroupResponse, error)
+
+	// RemoveUserFromGroup removes a user from an existing group
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the ID of the group and the email address of the user
+	// Returns either the group after the user is removed or error if something goes wrong.
+	RemoveUserFromGroup(
+		ctx context.Context,
+		request *RemoveUserFromGroupRequest) (*RemoveUserFromGroupResponse, error)
+
+	// InviteUser invites an email address to join as a new user and sends the invitation token to the email address
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the email address to invite
+	// Returns either the time the invitation expires at or error if something goes wrong.
+	InviteUser(
+		ctx context.Context,
+		request *InviteUserRequest) (*InviteUserResponse, error)
+
+	// AcceptInvitation accepts the invitation sent to the email address and creates its user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the invitation token and the user to create
+	// Returns either the created user or error if something goes wrong.
+	AcceptInvitation(
+		ctx context.Context,
+		request *AcceptInvitationRequest) (*AcceptInvitationResponse, error)
+
+	// RegisterSession registers a new session of an existing user or extends an existing one
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the ID of the session, the email address of the user and the device
+	// Returns either the registered session or error if something goes wrong.
+	RegisterSession(
+		ctx context.Context,
+		request *RegisterSessionRequest) (*RegisterSessionResponse, error)
+
+	// ListSessions lists the active sessions of a user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the email address of the user
+	// Returns either the active sessions or error if something goes wrong.
+	ListSessions(
+		ctx context.Context,
+		request *ListSessionsRequest) (*ListSessionsResponse, error)
+
+	// RevokeSession revokes an active session of a user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the email address of the user and the ID of the session
+	// Returns either the result of revoking the session or error if something goes wrong.
+	RevokeSession(
+		ctx context.Context,
+		request *RevokeSessionRequest) (*RevokeSessionResponse, error)
+
+	// CreateWebhookSubscription subscribes a webhook to the events of an existing user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the email address of the user, the URL, the secret and the event types
+	// Returns either the created subscription or error if something goes wrong.
+	CreateWebhookSubscription(
+		ctx context.Context,
+		request *CreateWebhookSubscriptionRequest) (*CreateWebhookSubscriptionResponse, error)
+
+	// ListWebhookSubscriptions lists the webhook subscriptions of a user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the email address of the user
+	// Returns either the subscriptions or error if something goes wrong.
+	ListWebhookSubscriptions(
+		ctx context.Context,
+		request *ListWebhookSubscriptionsRequest) (*ListWebhookSubscriptionsResponse, error)
+
+	// DeleteWebhookSubscription deletes a webhook subscription of a user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the email address of the user and the ID of the subscription
+	// Returns either the result of deleting the subscription or error if something goes wrong.
+	DeleteWebhookSubscription(
+		ctx context.Context,
+		request *DeleteWebhookSubscriptionRequest) (*DeleteWebhookSubscriptionResponse, error)
+
+	// ListWebhookDeliveries lists the recent deliveries of the events to the webhooks of a user and their statuses
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the email address of the user, the optional ID of the subscription
+	// and the maximum number of the deliveries to list
+	// Returns either the deliveries or error if something goes wrong.
+	ListWebhookDeliveries(
+		ctx context.Context,
+		request *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
 }
@@ -0,0 +1,170 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// passwordCredentialsFeature is the name GetEnabledFeatures reports the optional password credentials with
+const passwordCredentialsFeature = "password_credentials"
+
+// SetPassword sets the first password of an existing user, so the user can sign in without an external identity
+// provider. It fails if the user already has a password, ChangePassword must be used to replace it.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the password to set
+// Returns error if something goes wrong.
+func (service *businessService) SetPassword(
+	ctx context.Context,
+	request *SetPasswordRequest) (*SetPasswordResponse, error) {
+	if !service.passwordCredentialsEnabled {
+		return &SetPasswordResponse{
+			Err: NewFeatureDisabledError(passwordCredentialsFeature),
+		}, nil
+	}
+
+	if err := service.credentialService.ValidatePassword(request.Password); err != nil {
+		return &SetPasswordResponse{
+			Err: err,
+		}, nil
+	}
+
+	if _, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	}); err != nil {
+		return &SetPasswordResponse{
+			Err: err,
+		}, nil
+	}
+
+	if err := service.requireConsent(ctx, "SetPassword", request.Email); err != nil {
+		return &SetPasswordResponse{
+			Err: err,
+		}, nil
+	}
+
+	hasPassword, err := service.credentialService.HasPassword(ctx, request.Email)
+	if err != nil {
+		return &SetPasswordResponse{
+			Err: err,
+		}, nil
+	}
+
+	if hasPassword {
+		return &SetPasswordResponse{
+			Err: NewPasswordAlreadySetError(request.Email),
+		}, nil
+	}
+
+	if err = service.credentialService.SetPassword(ctx, request.Email, request.Password); err != nil {
+		return &SetPasswordResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &SetPasswordResponse{}, nil
+}
+
+// ChangePassword replaces the password of an existing user after verifying the current password
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the current and the new password
+// Returns error if something goes wrong.
+func (service *businessService) ChangePassword(
+	ctx context.Context,
+	request *ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	if !service.passwordCredentialsEnabled {
+		return &ChangePasswordResponse{
+			Err: NewFeatureDisabledError(passwordCredentialsFeature),
+		}, nil
+	}
+
+	// The new password is validated first, so a weak new password does not cost hashing the current one
+	if err := service.credentialService.ValidatePassword(request.NewPassword); err != nil {
+		return &ChangePasswordResponse{
+			Err: err,
+		}, nil
+	}
+
+	matched, err := service.credentialService.VerifyPassword(ctx, request.Email, request.CurrentPassword)
+	if err != nil {
+		return &ChangePasswordResponse{
+			Err: err,
+		}, nil
+	}
+
+	if !matched {
+		return &ChangePasswordResponse{
+			Err: NewPasswordMismatchError(request.Email),
+		}, nil
+	}
+
+	if err = service.requireConsent(ctx, "ChangePassword", request.Email); err != nil {
+		return &ChangePasswordResponse{
+			Err: err,
+		}, nil
+	}
+
+	if err = service.credentialService.SetPassword(ctx, request.Email, request.NewPassword); err != nil {
+		return &ChangePasswordResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &ChangePasswordResponse{}, nil
+}
+
+// VerifyPassword verifies the password matches the password of an existing user. The same error is returned whether
+// the user does not exist, has no password or the password does not match, so the callers can not find out which
+// users exist. A locked user is rejected with UserLockedError until an admin unlocks it.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the password to verify
+// Returns either the user the password matched or error if something goes wrong.
+func (service *businessService) VerifyPassword(
+	ctx context.Context,
+	request *VerifyPasswordRequest) (*VerifyPasswordResponse, error) {
+	if !service.passwordCredentialsEnabled {
+		return &VerifyPasswordResponse{
+			Err: NewFeatureDisabledError(passwordCredentialsFeature),
+		}, nil
+	}
+
+	// The user is read first, so a locked user is rejected without verifying the password
+	response, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	})
+
+	if err != nil {
+		if commonErrors.IsNotFoundError(err) {
+			err = NewPasswordMismatchError(request.Email)
+		}
+
+		return &VerifyPasswordResponse{
+			Err: err,
+		}, nil
+	}
+
+	if response.User.LockedAt != nil {
+		return &VerifyPasswordResponse{
+			Err: NewUserLockedError(request.Email),
+		}, nil
+	}
+
+	matched, err := service.credentialService.VerifyPassword(ctx, request.Email, request.Password)
+	if err != nil {
+		return &VerifyPasswordResponse{
+			Err: err,
+		}, nil
+	}
+
+	if !matched {
+		return &VerifyPasswordResponse{
+			Err: NewPasswordMismatchError(request.Email),
+		}, nil
+	}
+
+	return &VerifyPasswordResponse{
+		User: response.User,
+	}, nil
+}
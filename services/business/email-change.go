@@ -0,0 +1,200 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/mailer"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// emailChangeConfirmationFeature is the name GetEnabledFeatures reports the optional email address change
+// confirmation with
+const emailChangeConfirmationFeature = "email_change_confirmation"
+
+// emailChangeRequest contains everything the confirmation token of a requested email address change is bound to
+type emailChangeRequest struct {
+	UserID    string
+	Email     string
+	NewEmail  string
+	ExpiresAt int64
+}
+
+// RequestEmailChange sends the token that confirms changing the email address of an existing user to the new email
+// address. The token is stateless, it is only valid for the user and the email addresses it is issued for until it
+// expires, and the email address is not changed until the token is returned to ConfirmEmailChange, which proves the
+// user owns the new email address.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to change the email address of an existing user
+// Returns either the time the token expires at or error if something goes wrong.
+func (service *businessService) RequestEmailChange(
+	ctx context.Context,
+	request *RequestEmailChangeRequest) (*RequestEmailChangeResponse, error) {
+	secret, err := service.configurationService.GetEmailChangeTokenSecret()
+	if err != nil {
+		return &RequestEmailChangeResponse{Err: err}, nil
+	}
+
+	if secret == "" {
+		return &RequestEmailChangeResponse{Err: NewFeatureDisabledError(emailChangeConfirmationFeature)}, nil
+	}
+
+	tokenTTL, err := service.configurationService.GetEmailChangeTokenTTL()
+	if err != nil {
+		return &RequestEmailChangeResponse{Err: err}, nil
+	}
+
+	readUserResponse, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	})
+	if err != nil {
+		return &RequestEmailChangeResponse{Err: err}, nil
+	}
+
+	// The new email address is checked now so no token is sent for a change that can not be confirmed. It is
+	// checked again when the change is confirmed, as the email address can be taken in between.
+	if _, err = service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.NewEmail,
+	}); err == nil {
+		return &RequestEmailChangeResponse{Err: commonErrors.NewAlreadyExistsError()}, nil
+	} else if !commonErrors.IsNotFoundError(err) {
+		return &RequestEmailChangeResponse{Err: err}, nil
+	}
+
+	expiresAt := service.clockService.Now().Add(tokenTTL).Truncate(time.Second)
+	token, err := signEmailChangeRequest(secret, emailChangeRequest{
+		UserID:    readUserResponse.User.ID,
+		Email:     request.Email,
+		NewEmail:  request.NewEmail,
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return &RequestEmailChangeResponse{Err: err}, nil
+	}
+
+	if err = service.mailerService.SendEmailChangeEmail(ctx, &mailer.EmailChangeEmail{
+		Email:     request.Email,
+		NewEmail:  request.NewEmail,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return &RequestEmailChangeResponse{
+			Err: commonErrors.NewUnknownErrorWithError("failed to send the email address change email", err),
+		}, nil
+	}
+
+	return &RequestEmailChangeResponse{
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// ConfirmEmailChange changes the email address of an existing user to the one the token sent to the new email address
+// is issued for, the same way ChangeEmail does, then notifies the previous email address of the change. The token
+// can not be used once the email address is changed, as it is bound to the previous email address.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the token sent to the new email address
+// Returns either the result of changing the email address of an existing user or error if something goes wrong.
+func (service *businessService) ConfirmEmailChange(
+	ctx context.Context,
+	request *ConfirmEmailChangeRequest) (*ConfirmEmailChangeResponse, error) {
+	secret, err := service.configurationService.GetEmailChangeTokenSecret()
+	if err != nil {
+		return &ConfirmEmailChangeResponse{Err: err}, nil
+	}
+
+	if secret == "" {
+		return &ConfirmEmailChangeResponse{Err: NewFeatureDisabledError(emailChangeConfirmationFeature)}, nil
+	}
+
+	// The user is read before its email address is changed so the audit record contains what the change changed
+	readUserResponse, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	})
+	if err != nil {
+		return &ConfirmEmailChangeResponse{Err: err}, nil
+	}
+
+	tokenRequest, ok := parseEmailChangeToken(request.Token)
+	if !ok {
+		return &ConfirmEmailChangeResponse{Err: NewEmailVerificationTokenError(request.Email, false)}, nil
+	}
+
+	tokenRequest.UserID = readUserResponse.User.ID
+	tokenRequest.Email = request.Email
+	expectedToken, err := signEmailChangeRequest(secret, tokenRequest)
+	if err != nil {
+		return &ConfirmEmailChangeResponse{Err: err}, nil
+	}
+
+	if !hmac.Equal([]byte(expectedToken), []byte(request.Token)) {
+		return &ConfirmEmailChangeResponse{Err: NewEmailVerificationTokenError(request.Email, false)}, nil
+	}
+
+	if service.clockService.Now().Unix() >= tokenRequest.ExpiresAt {
+		return &ConfirmEmailChangeResponse{Err: NewEmailVerificationTokenError(request.Email, true)}, nil
+	}
+
+	response, err := service.changeEmail(ctx, request.Email, tokenRequest.NewEmail, readUserResponse.User)
+	if err != nil {
+		return &ConfirmEmailChangeResponse{Err: err}, nil
+	}
+
+	// The change is already made, so failing to notify the previous email address does not fail the confirmation
+	service.logIgnoredError(ctx, "failed to notify the previous email address of the change", service.mailerService.SendEmailChangedNotification(ctx, &mailer.EmailChangedNotification{
+		Email:    request.Email,
+		NewEmail: tokenRequest.NewEmail,
+	}))
+
+	return &ConfirmEmailChangeResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// signEmailChangeRequest returns the confirmation token of the requested email address change, made of the new
+// email address, the time the token expires at and the signature of the request
+func signEmailChangeRequest(secret string, request emailChangeRequest) (string, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return "", commonErrors.NewUnknownErrorWithError("failed to encode the email address change request", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(payload)
+
+	return base64.RawURLEncoding.EncodeToString([]byte(request.NewEmail)) + "." +
+		strconv.FormatInt(request.ExpiresAt, 10) + "." +
+		base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// parseEmailChangeToken returns the new email address and the time the confirmation token expires at, and whether
+// the token is well formed
+func parseEmailChangeToken(token string) (emailChangeRequest, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return emailChangeRequest{}, false
+	}
+
+	newEmail, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return emailChangeRequest{}, false
+	}
+
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return emailChangeRequest{}, false
+	}
+
+	return emailChangeRequest{
+		NewEmail:  string(newEmail),
+		ExpiresAt: expiresAt,
+	}, true
+}
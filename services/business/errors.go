@@ -0,0 +1,275 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EmailVerificationTokenError indicates the token the email address is verified with is not the pending verification
+// token of the user or the pending verification token has expired
+type EmailVerificationTokenError struct {
+	Email   string
+	Expired bool
+}
+
+// Error returns message for the EmailVerificationTokenError error type
+// Returns the formatted error message
+func (e EmailVerificationTokenError) Error() string {
+	if e.Expired {
+		return fmt.Sprintf("the email verification token of the user %s has expired", e.Email)
+	}
+
+	return fmt.Sprintf("the email verification token of the user %s is invalid", e.Email)
+}
+
+// IsEmailVerificationTokenError indicates whether the error is of type EmailVerificationTokenError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type EmailVerificationTokenError
+func IsEmailVerificationTokenError(err error) bool {
+	var emailVerificationTokenError EmailVerificationTokenError
+
+	return errors.As(err, &emailVerificationTokenError)
+}
+
+// IsEmailVerificationTokenExpiredError indicates whether the error is of type EmailVerificationTokenError raised
+// because the pending verification token has expired
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type EmailVerificationTokenError and the token has expired
+func IsEmailVerificationTokenExpiredError(err error) bool {
+	var emailVerificationTokenError EmailVerificationTokenError
+
+	return errors.As(err, &emailVerificationTokenError) && emailVerificationTokenError.Expired
+}
+
+// NewEmailVerificationTokenError creates a new EmailVerificationTokenError error
+// email: Mandatory. The email address of the user
+// expired: Mandatory. Whether the token is rejected because the pending verification token has expired
+// Returns the new error
+func NewEmailVerificationTokenError(email string, expired bool) error {
+	return EmailVerificationTokenError{
+		Email:   email,
+		Expired: expired,
+	}
+}
+
+// FeatureDisabledError indicates the operation belongs to an optional feature the service is not running with
+type FeatureDisabledError struct {
+	Feature string
+}
+
+// Error returns message for the FeatureDisabledError error type
+// Returns the formatted error message
+func (e FeatureDisabledError) Error() string {
+	return fmt.Sprintf("the feature %s is not enabled", e.Feature)
+}
+
+// IsFeatureDisabledError indicates whether the error is of type FeatureDisabledError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type FeatureDisabledError
+func IsFeatureDisabledError(err error) bool {
+	var featureDisabledError FeatureDisabledError
+
+	return errors.As(err, &featureDisabledError)
+}
+
+// NewFeatureDisabledError creates a new FeatureDisabledError error
+// feature: Mandatory. The name of the optional feature as reported by GetEnabledFeatures
+// Returns the new error
+func NewFeatureDisabledError(feature string) error {
+	return FeatureDisabledError{
+		Feature: feature,
+	}
+}
+
+// PasswordAlreadySetError indicates the user already has a password, so the password must be changed instead
+type PasswordAlreadySetError struct {
+	Email string
+}
+
+// Error returns message for the PasswordAlreadySetError error type
+// Returns the formatted error message
+func (e PasswordAlreadySetError) Error() string {
+	return fmt.Sprintf("the user %s already has a password", e.Email)
+}
+
+// IsPasswordAlreadySetError indicates whether the error is of type PasswordAlreadySetError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type PasswordAlreadySetError
+func IsPasswordAlreadySetError(err error) bool {
+	var passwordAlreadySetError PasswordAlreadySetError
+
+	return errors.As(err, &passwordAlreadySetError)
+}
+
+// NewPasswordAlreadySetError creates a new PasswordAlreadySetError error
+// email: Mandatory. The email address of the user
+// Returns the new error
+func NewPasswordAlreadySetError(email string) error {
+	return PasswordAlreadySetError{
+		Email: email,
+	}
+}
+
+// PasswordMismatchError indicates the password does not match the password of the user. It is also returned if the
+// user or its password does not exist, so the error does not reveal which users exist.
+type PasswordMismatchError struct {
+	Email string
+}
+
+// Error returns message for the PasswordMismatchError error type
+// Returns the formatted error message
+func (e PasswordMismatchError) Error() string {
+	return fmt.Sprintf("the password of the user %s does not match", e.Email)
+}
+
+// IsPasswordMismatchError indicates whether the error is of type PasswordMismatchError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type PasswordMismatchError
+func IsPasswordMismatchError(err error) bool {
+	var passwordMismatchError PasswordMismatchError
+
+	return errors.As(err, &passwordMismatchError)
+}
+
+// NewPasswordMismatchError creates a new PasswordMismatchError error
+// email: Mandatory. The email address of the user
+// Returns the new error
+func NewPasswordMismatchError(email string) error {
+	return PasswordMismatchError{
+		Email: email,
+	}
+}
+
+// UserLockedError indicates the user is locked after too many failed login attempts, so an admin must unlock it
+// before its password can be verified again
+type UserLockedError struct {
+	Email string
+}
+
+// Error returns message for the UserLockedError error type
+// Returns the formatted error message
+func (e UserLockedError) Error() string {
+	return fmt.Sprintf("the user %s is locked after too many failed login attempts", e.Email)
+}
+
+// IsUserLockedError indicates whether the error is of type UserLockedError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type UserLockedError
+func IsUserLockedError(err error) bool {
+	var userLockedError UserLockedError
+
+	return errors.As(err, &userLockedError)
+}
+
+// NewUserLockedError creates a new UserLockedError error
+// email: Mandatory. The email address of the user
+// Returns the new error
+func NewUserLockedError(email string) error {
+	return UserLockedError{
+		Email: email,
+	}
+}
+
+// WatchLaggedError indicates the watcher fell behind the user changes and is disconnected, so the client must read
+// the users again before watching them again
+type WatchLaggedError struct {
+}
+
+// Error returns message for the WatchLaggedError error type
+// Returns the formatted error message
+func (e WatchLaggedError) Error() string {
+	return "the watcher fell behind the user changes and is disconnected"
+}
+
+// IsWatchLaggedError indicates whether the error is of type WatchLaggedError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type WatchLaggedError
+func IsWatchLaggedError(err error) bool {
+	var watchLaggedError WatchLaggedError
+
+	return errors.As(err, &watchLaggedError)
+}
+
+// NewWatchLaggedError creates a new WatchLaggedError error
+// Returns the new error
+func NewWatchLaggedError() error {
+	return WatchLaggedError{}
+}
+
+// The codes of the validation failures the clients can map to their own messages
+const (
+	// ValidationCodeRequired indicates the field is missing or empty
+	ValidationCodeRequired = "required"
+
+	// ValidationCodeLength indicates the field is too short or too long
+	ValidationCodeLength = "length"
+
+	// ValidationCodeFormat indicates the field is not in the expected format, e.g. not an email address
+	ValidationCodeFormat = "format"
+
+	// ValidationCodeRange indicates the field is less than the minimum or greater than the maximum allowed
+	ValidationCodeRange = "range"
+
+	// ValidationCodeNotAllowed indicates the field is not one of the allowed values or must not be set at all
+	ValidationCodeNotAllowed = "not_allowed"
+
+	// ValidationCodeInvalid indicates the field is invalid for any other reason
+	ValidationCodeInvalid = "invalid"
+)
+
+// ValidationFailure describes why a field of the request is invalid
+type ValidationFailure struct {
+	// Field is the path of the invalid field, the nested fields and the items of the lists are separated by dots,
+	// e.g. user.emails.0
+	Field string
+
+	// Code is the reason the field is invalid, one of the ValidationCode constants
+	Code string
+
+	// Message is the reason the field is invalid in English
+	Message string
+}
+
+// ValidationError indicates the request is invalid, reporting every invalid field of it so the clients can show the
+// failures next to the fields of their forms
+type ValidationError struct {
+	Failures []ValidationFailure
+	Err      error
+}
+
+// Error returns message for the ValidationError error type
+// Returns the formatted error message
+func (e ValidationError) Error() string {
+	if e.Err == nil {
+		return "the request is invalid"
+	}
+
+	return e.Err.Error()
+}
+
+// Unwrap returns the validation errors the failures are created from
+// Returns the validation errors
+func (e ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// IsValidationError indicates whether the error is of type ValidationError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type ValidationError
+func IsValidationError(err error) bool {
+	var validationError ValidationError
+
+	return errors.As(err, &validationError)
+}
+
+// NewValidationError creates a new ValidationError error
+// failures: Mandatory. The reasons the fields of the request are invalid
+// err: Optional. The validation errors the failures are created from
+// Returns the new error
+func NewValidationError(failures []ValidationFailure, err error) error {
+	return ValidationError{
+		Failures: failures,
+		Err:      err,
+	}
+}
@@ -0,0 +1,36 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/services/repository"
+)
+
+// ExportUsers sends all the users that matched the filter one by one, ordered by the order they are persisted in, so
+// the export can be resumed after the cursor of the last sent user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the filter and the function to send the users to
+// Returns either the number of the exported users or error if something goes wrong.
+func (service *businessService) ExportUsers(
+	ctx context.Context,
+	request *ExportUsersRequest) (*ExportUsersResponse, error) {
+	response, err := service.repositoryService.StreamSearch(ctx, &repository.StreamSearchRequest{
+		IncludeDeleted: request.IncludeDeleted,
+		TenantID:       request.TenantID,
+		CreatedAfter:   request.CreatedAfter,
+		CreatedBefore:  request.CreatedBefore,
+		After:          request.After,
+		Send:           request.Send,
+	})
+
+	if err != nil {
+		return &ExportUsersResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &ExportUsersResponse{
+		ExportedCount: response.SentCount,
+	}, nil
+}
@@ -0,0 +1,401 @@
+// Package business implements different business services required by the user service
+package business
+
+// The responses implement the go-kit endpoint.Failer interface, so the middlewares can tell the failed calls apart
+// without knowing the response types
+
+// Failed returns the error the CreateUser operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val CreateUserResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ReadUser operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ReadUserResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ReadUsers operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ReadUsersResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the UpdateUser operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val UpdateUserResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ChangeEmail operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ChangeEmailResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the RequestEmailChange operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val RequestEmailChangeResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ConfirmEmailChange operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ConfirmEmailChangeResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the DeleteUser operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val DeleteUserResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the RestoreUser operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val RestoreUserResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the Search operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val SearchResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the StreamSearch operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val StreamSearchResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the GetSagaStatus operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val GetSagaStatusResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ListAuditRecords operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ListAuditRecordsResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the GetEffectiveConfiguration operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val GetEffectiveConfigurationResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the GetEnabledFeatures operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val GetEnabledFeaturesResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the PreviewBulkUpdateUsers operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val PreviewBulkUpdateUsersResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the BulkUpdateUsers operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val BulkUpdateUsersResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the PurgeByLabel operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val PurgeByLabelResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the GetOutboxLag operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val GetOutboxLagResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ListPendingEvents operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ListPendingEventsResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ForceFlush operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ForceFlushResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the GetUserPreferences operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val GetUserPreferencesResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the UpdateUserPreferences operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val UpdateUserPreferencesResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the GetUserAvatar operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val GetUserAvatarResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the SetUserAvatar operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val SetUserAvatarResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the AddUserToTenant operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val AddUserToTenantResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the RemoveUserFromTenant operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val RemoveUserFromTenantResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ListUserTenants operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ListUserTenantsResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the GetReplicationStatus operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val GetReplicationStatusResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ExportUsers operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ExportUsersResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the IssueMagicLink operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val IssueMagicLinkResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the RedeemMagicLink operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val RedeemMagicLinkResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ExportPersonalData operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ExportPersonalDataResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the EraseUser operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val EraseUserResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the SendVerificationEmail operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val SendVerificationEmailResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the VerifyEmail operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val VerifyEmailResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the SetPassword operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val SetPasswordResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ChangePassword operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ChangePasswordResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the VerifyPassword operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val VerifyPasswordResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the WatchUsers operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val WatchUsersResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the CreateAPIKey operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val CreateAPIKeyResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ListAPIKeys operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ListAPIKeysResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the RevokeAPIKey operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val RevokeAPIKeyResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the RecordLoginAttempt operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val RecordLoginAttemptResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the UnlockUser operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val UnlockUserResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the EnrollMFA operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val EnrollMFAResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ListMFAMethods operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ListMFAMethodsResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the RemoveMFAMethod operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val RemoveMFAMethodResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the RecordConsent operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val RecordConsentResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ListConsents operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ListConsentsResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the CreateGroup operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val CreateGroupResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ReadGroup operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ReadGroupResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the UpdateGroup operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val UpdateGroupResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the DeleteGroup operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val DeleteGroupResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ListGroups operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ListGroupsResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the AddUserToGroup operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val AddUserToGroupResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the RemoveUserFromGroup operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val RemoveUserFromGroupResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the InviteUser operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val InviteUserResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the AcceptInvitation operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val AcceptInvitationResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the RegisterSession operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val RegisterSessionResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ListSessions operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ListSessionsResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the RevokeSession operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val RevokeSessionResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the CreateWebhookSubscription operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val CreateWebhookSubscriptionResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ListWebhookSubscriptions operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ListWebhookSubscriptionsResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the DeleteWebhookSubscription operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val DeleteWebhookSubscriptionResponse) Failed() error {
+	return val.Err
+}
+
+// Failed returns the error the ListWebhookDeliveries operation failed with
+// Returns the error or nil if the operation completed successfully
+func (val ListWebhookDeliveriesResponse) Failed() error {
+	return val.Err
+}
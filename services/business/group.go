@@ -0,0 +1,193 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/services/repository"
+)
+
+const (
+	// maxGroupNameLength is the maximum length of the names of the groups
+	maxGroupNameLength = 100
+
+	// maxGroupDescriptionLength is the maximum length of the descriptions of the groups
+	maxGroupDescriptionLength = 1000
+
+	// maxGroupIDs is the maximum number of the groups a single request can filter the users by
+	maxGroupIDs = 100
+)
+
+// CreateGroup creates a new group with no member
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the name and the description of the group
+// Returns either the new group or error if something goes wrong.
+func (service *businessService) CreateGroup(
+	ctx context.Context,
+	request *CreateGroupRequest) (*CreateGroupResponse, error) {
+	group, err := service.groupService.CreateGroup(ctx, request.Name, request.Description)
+	if err != nil {
+		return &CreateGroupResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &CreateGroupResponse{
+		Group: *group,
+	}, nil
+}
+
+// ReadGroup reads an existing group along its members
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the ID of the group
+// Returns either the group or error if something goes wrong.
+func (service *businessService) ReadGroup(
+	ctx context.Context,
+	request *ReadGroupRequest) (*ReadGroupResponse, error) {
+	group, err := service.groupService.ReadGroup(ctx, request.GroupID)
+	if err != nil {
+		return &ReadGroupResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &ReadGroupResponse{
+		Group: *group,
+	}, nil
+}
+
+// UpdateGroup renames and describes an existing group, its members are kept
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the new name and description of the group
+// Returns either the updated group or error if something goes wrong.
+func (service *businessService) UpdateGroup(
+	ctx context.Context,
+	request *UpdateGroupRequest) (*UpdateGroupResponse, error) {
+	group, err := service.groupService.UpdateGroup(ctx, request.GroupID, request.Name, request.Description)
+	if err != nil {
+		return &UpdateGroupResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &UpdateGroupResponse{
+		Group: *group,
+	}, nil
+}
+
+// DeleteGroup deletes an existing group, its members are not affected
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the ID of the group
+// Returns either the result of deleting the group or error if something goes wrong.
+func (service *businessService) DeleteGroup(
+	ctx context.Context,
+	request *DeleteGroupRequest) (*DeleteGroupResponse, error) {
+	if err := service.groupService.DeleteGroup(ctx, request.GroupID); err != nil {
+		return &DeleteGroupResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &DeleteGroupResponse{}, nil
+}
+
+// ListGroups lists all the groups, or the groups of a user if the email address of the user is provided
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the optional email address of the user
+// Returns either the groups ordered by their names or error if something goes wrong.
+func (service *businessService) ListGroups(
+	ctx context.Context,
+	request *ListGroupsRequest) (*ListGroupsResponse, error) {
+	if request.Email == "" {
+		groups, err := service.groupService.ListGroups(ctx)
+		if err != nil {
+			return &ListGroupsResponse{
+				Err: err,
+			}, nil
+		}
+
+		return &ListGroupsResponse{
+			Groups: groups,
+		}, nil
+	}
+
+	groups, err := service.groupService.ListUserGroups(ctx, request.Email)
+	if err != nil {
+		return &ListGroupsResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &ListGroupsResponse{
+		Groups: groups,
+	}, nil
+}
+
+// AddUserToGroup adds an existing user to an existing group
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the ID of the group and the email address of the user
+// Returns either the group after the user is added or error if something goes wrong.
+func (service *businessService) AddUserToGroup(
+	ctx context.Context,
+	request *AddUserToGroupRequest) (*AddUserToGroupResponse, error) {
+	if _, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	}); err != nil {
+		return &AddUserToGroupResponse{
+			Err: err,
+		}, nil
+	}
+
+	group, err := service.groupService.AddMember(ctx, request.GroupID, request.Email)
+	if err != nil {
+		return &AddUserToGroupResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &AddUserToGroupResponse{
+		Group: *group,
+	}, nil
+}
+
+// RemoveUserFromGroup removes a user from an existing group. The user is not required to exist, so the members left
+// behind by the deleted users can be removed too.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the ID of the group and the email address of the user
+// Returns either the group after the user is removed or error if something goes wrong.
+func (service *businessService) RemoveUserFromGroup(
+	ctx context.Context,
+	request *RemoveUserFromGroupRequest) (*RemoveUserFromGroupResponse, error) {
+	group, err := service.groupService.RemoveMember(ctx, request.GroupID, request.Email)
+	if err != nil {
+		return &RemoveUserFromGroupResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &RemoveUserFromGroupResponse{
+		Group: *group,
+	}, nil
+}
+
+// filterEmails limits the email addresses the users are searched by to the members of the groups. The members are
+// returned as they are if the users are not searched by their email addresses.
+func filterEmails(members []string, emails []string) []string {
+	if len(emails) == 0 {
+		return members
+	}
+
+	isMember := map[string]bool{}
+	for _, member := range members {
+		isMember[member] = true
+	}
+
+	filteredEmails := []string{}
+	for _, email := range emails {
+		if isMember[email] {
+			filteredEmails = append(filteredEmails, email)
+		}
+	}
+
+	return filteredEmails
+}
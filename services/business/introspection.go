@@ -0,0 +1,175 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/pkg/fips"
+	"github.com/decentralized-cloud/user/services/configuration"
+)
+
+// GetEffectiveConfiguration reads the configuration the service is running with, the secrets are redacted
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the effective configuration
+// Returns either the effective configuration or error if something goes wrong.
+func (service *businessService) GetEffectiveConfiguration(
+	ctx context.Context,
+	request *GetEffectiveConfigurationRequest) (*GetEffectiveConfigurationResponse, error) {
+	return &GetEffectiveConfigurationResponse{
+		Options: configuration.GetEffectiveOptions(service.configurationService),
+	}, nil
+}
+
+// GetEnabledFeatures reads the optional features the service is running with
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the enabled features
+// Returns either the optional features or error if something goes wrong.
+func (service *businessService) GetEnabledFeatures(
+	ctx context.Context,
+	request *GetEnabledFeaturesRequest) (*GetEnabledFeaturesResponse, error) {
+	databaseType, err := service.configurationService.GetDatabaseType()
+	if err != nil {
+		return &GetEnabledFeaturesResponse{Err: err}, nil
+	}
+
+	eventingBroker, err := service.configurationService.GetEventingBroker()
+	if err != nil {
+		return &GetEnabledFeaturesResponse{Err: err}, nil
+	}
+
+	indexHints, err := service.configurationService.GetDatabaseSearchIndexHints()
+	if err != nil {
+		return &GetEnabledFeaturesResponse{Err: err}, nil
+	}
+
+	queryPlanStatisticsEnabled, err := service.configurationService.GetDatabaseSearchQueryPlanStatisticsEnabled()
+	if err != nil {
+		return &GetEnabledFeaturesResponse{Err: err}, nil
+	}
+
+	sagaMaxAttempts, err := service.configurationService.GetSagaMaxAttempts()
+	if err != nil {
+		return &GetEnabledFeaturesResponse{Err: err}, nil
+	}
+
+	adminEmails, err := service.configurationService.GetAdminEmails()
+	if err != nil {
+		return &GetEnabledFeaturesResponse{Err: err}, nil
+	}
+
+	softDeleteRetentionPeriod, err := service.configurationService.GetSoftDeleteRetentionPeriod()
+	if err != nil {
+		return &GetEnabledFeaturesResponse{Err: err}, nil
+	}
+
+	testDataPurgeEnabled, err := service.configurationService.GetTestDataPurgeEnabled()
+	if err != nil {
+		return &GetEnabledFeaturesResponse{Err: err}, nil
+	}
+
+	erasureTokenSecret, err := service.configurationService.GetErasureTokenSecret()
+	if err != nil {
+		return &GetEnabledFeaturesResponse{Err: err}, nil
+	}
+
+	erasureGracePeriod, err := service.configurationService.GetErasureGracePeriod()
+	if err != nil {
+		return &GetEnabledFeaturesResponse{Err: err}, nil
+	}
+
+	emailChangeTokenSecret, err := service.configurationService.GetEmailChangeTokenSecret()
+	if err != nil {
+		return &GetEnabledFeaturesResponse{Err: err}, nil
+	}
+
+	cacheEnabled, err := service.configurationService.GetCacheEnabled()
+	if err != nil {
+		return &GetEnabledFeaturesResponse{Err: err}, nil
+	}
+
+	cacheDetail := ""
+	if cacheEnabled {
+		cacheTTL, err := service.configurationService.GetCacheTTL()
+		if err != nil {
+			return &GetEnabledFeaturesResponse{Err: err}, nil
+		}
+
+		cacheDetail = fmt.Sprintf("ttl: %s", cacheTTL)
+	}
+
+	return &GetEnabledFeaturesResponse{
+		Features: []models.Feature{
+			{
+				Name:    "eventing",
+				Enabled: eventingBroker != "none",
+				Detail:  fmt.Sprintf("broker: %s", eventingBroker),
+			},
+			{
+				Name:    "causal_consistency",
+				Enabled: databaseType == "mongodb",
+				Detail:  fmt.Sprintf("database: %s", databaseType),
+			},
+			{
+				Name:    "search_index_hints",
+				Enabled: databaseType == "mongodb" && len(indexHints) > 0,
+				Detail:  fmt.Sprintf("%d hint(s)", len(indexHints)),
+			},
+			{
+				Name:    "search_query_plan_statistics",
+				Enabled: databaseType == "mongodb" && queryPlanStatisticsEnabled,
+			},
+			{
+				Name:    "repository_cache",
+				Enabled: cacheEnabled,
+				Detail:  cacheDetail,
+			},
+			{
+				Name:    "saga_retries",
+				Enabled: sagaMaxAttempts > 1,
+				Detail:  fmt.Sprintf("%d attempt(s)", sagaMaxAttempts),
+			},
+			{
+				Name:    "soft_delete",
+				Enabled: service.softDeleteEnabled,
+				Detail:  fmt.Sprintf("retention period: %s", softDeleteRetentionPeriod),
+			},
+			{
+				Name:    "admin_operations",
+				Enabled: len(adminEmails) > 0,
+				Detail:  fmt.Sprintf("%d admin(s)", len(adminEmails)),
+			},
+			{
+				Name:    "test_data_purge",
+				Enabled: testDataPurgeEnabled,
+			},
+			{
+				Name:    magicLinksFeature,
+				Enabled: service.magicLinksEnabled,
+			},
+			{
+				Name:    passwordCredentialsFeature,
+				Enabled: service.passwordCredentialsEnabled,
+			},
+			{
+				Name:    avatarsFeature,
+				Enabled: service.avatarsEnabled,
+			},
+			{
+				Name:    erasureFeature,
+				Enabled: erasureTokenSecret != "",
+				Detail:  fmt.Sprintf("grace period: %s", erasureGracePeriod),
+			},
+			{
+				Name:    emailChangeConfirmationFeature,
+				Enabled: emailChangeTokenSecret != "",
+			},
+			{
+				Name:    "fips_crypto",
+				Enabled: fips.Enabled(),
+				Detail:  fmt.Sprintf("crypto module: %s", fips.Mode()),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,97 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/services/mailer"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// InviteUser invites an email address to join as a new user and sends the invitation token to the email address.
+// Inviting the email address again replaces the invitation sent before, so only the latest token can be accepted.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the email address to invite
+// Returns either the time the invitation expires at or error if something goes wrong.
+func (service *businessService) InviteUser(
+	ctx context.Context,
+	request *InviteUserRequest) (*InviteUserResponse, error) {
+	_, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	})
+
+	if err == nil {
+		return &InviteUserResponse{
+			Err: commonErrors.NewAlreadyExistsError(),
+		}, nil
+	}
+
+	if !commonErrors.IsNotFoundError(err) {
+		return &InviteUserResponse{
+			Err: err,
+		}, nil
+	}
+
+	invitation, token, err := service.invitationService.CreateInvitation(ctx, request.Email, repository.GetActor(ctx))
+	if err != nil {
+		return &InviteUserResponse{
+			Err: err,
+		}, nil
+	}
+
+	if err = service.mailerService.SendInvitationEmail(ctx, &mailer.InvitationEmail{
+		Email:     request.Email,
+		InvitedBy: invitation.InvitedBy,
+		Token:     token,
+		ExpiresAt: invitation.ExpiresAt,
+	}); err != nil {
+		return &InviteUserResponse{
+			Err: commonErrors.NewUnknownErrorWithError("failed to send the invitation email", err),
+		}, nil
+	}
+
+	return &InviteUserResponse{
+		ExpiresAt: invitation.ExpiresAt,
+	}, nil
+}
+
+// AcceptInvitation accepts the invitation sent to the email address and creates its user. The invitation is accepted
+// before the user is created, so of the concurrent calls with the same token only one creates the user.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the invitation token and the user to create
+// Returns either the created user or error if something goes wrong.
+func (service *businessService) AcceptInvitation(
+	ctx context.Context,
+	request *AcceptInvitationRequest) (*AcceptInvitationResponse, error) {
+	invitation, err := service.invitationService.AcceptInvitation(ctx, request.Email, request.Token)
+	if err != nil {
+		return &AcceptInvitationResponse{
+			Err: err,
+		}, nil
+	}
+
+	response, err := service.CreateUser(ctx, &CreateUserRequest{
+		Email: request.Email,
+		User:  request.User,
+	})
+
+	if err == nil {
+		err = response.Err
+	}
+
+	if err != nil {
+		// The invitation is released, so it can be accepted again once the reason the user could not be created with
+		// is fixed, e.g. the user is invalid
+		service.logIgnoredError(ctx, "failed to release the invitation", service.invitationService.ReleaseInvitation(ctx, invitation))
+
+		return &AcceptInvitationResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &AcceptInvitationResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
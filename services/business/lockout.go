@@ -0,0 +1,99 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/eventing"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// RecordLoginAttempt records the outcome of a login attempt of an existing user, locking the user once the number
+// of the failed attempts reaches the configured lockout threshold. A successful attempt resets the number of the
+// failed attempts unless the user is already locked.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the outcome of the login attempt
+// Returns either the user after the login attempt is recorded or error if something goes wrong.
+func (service *businessService) RecordLoginAttempt(
+	ctx context.Context,
+	request *RecordLoginAttemptRequest) (*RecordLoginAttemptResponse, error) {
+	lockoutThreshold, err := service.configurationService.GetLockoutThreshold()
+	if err != nil {
+		return &RecordLoginAttemptResponse{
+			Err: commonErrors.NewUnknownErrorWithError("failed to get the lockout threshold", err),
+		}, nil
+	}
+
+	// The repository counts the attempt and locks the user atomically, so the concurrent failed attempts can not
+	// exceed the threshold without locking the user
+	response, err := service.repositoryService.RecordLoginAttempt(ctx, &repository.RecordLoginAttemptRequest{
+		Email:            request.Email,
+		Succeeded:        request.Succeeded,
+		LockoutThreshold: lockoutThreshold,
+		AttemptedAt:      service.clockService.Now(),
+	})
+
+	if err != nil {
+		return &RecordLoginAttemptResponse{
+			Err: err,
+		}, nil
+	}
+
+	// Only the attempt that locks the user changes its visible state, the subscribers are not notified of every
+	// failed attempt
+	if !request.Succeeded && response.User.LockedAt != nil && response.User.FailedLoginAttempts == lockoutThreshold {
+		service.logIgnoredError(ctx, "failed to publish the user updated event", service.eventingService.PublishUserUpdated(ctx, &eventing.UserUpdatedEvent{
+			Email:  request.Email,
+			User:   response.User,
+			Cursor: response.Cursor,
+		}))
+	}
+
+	return &RecordLoginAttemptResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// UnlockUser unlocks an existing user and resets the number of its failed login attempts
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the email address of the user
+// Returns either the user after it is unlocked or error if something goes wrong.
+func (service *businessService) UnlockUser(
+	ctx context.Context,
+	request *UnlockUserRequest) (*UnlockUserResponse, error) {
+	readUserResponse, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	})
+
+	if err != nil {
+		return &UnlockUserResponse{
+			Err: err,
+		}, nil
+	}
+
+	response, err := service.repositoryService.UnlockUser(ctx, &repository.UnlockUserRequest{
+		Email: request.Email,
+	})
+
+	if err != nil {
+		return &UnlockUserResponse{
+			Err: err,
+		}, nil
+	}
+
+	service.logIgnoredError(ctx, "failed to record the operation in the audit log", service.auditService.RecordOperation(ctx, models.AuditOperationUpdate, request.Email, &readUserResponse.User, &response.User))
+
+	service.logIgnoredError(ctx, "failed to publish the user updated event", service.eventingService.PublishUserUpdated(ctx, &eventing.UserUpdatedEvent{
+		Email:  request.Email,
+		User:   response.User,
+		Cursor: response.Cursor,
+	}))
+
+	return &UnlockUserResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
@@ -0,0 +1,118 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/services/eventing"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// magicLinksFeature is the name GetEnabledFeatures reports the optional magic link sign-in with
+const magicLinksFeature = "magic_links"
+
+// IssueMagicLink issues a single-use magic link to an existing user and publishes its token, so the service that sends
+// the emails can send it to the email address of the user. Issuing a magic link again replaces the one issued before,
+// so only the latest token can be redeemed. A locked user is rejected with UserLockedError until an admin unlocks it.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the email address of the user and the IP address and the device the
+// magic link is requested from
+// Returns either the time the magic link expires at or error if something goes wrong.
+func (service *businessService) IssueMagicLink(
+	ctx context.Context,
+	request *IssueMagicLinkRequest) (*IssueMagicLinkResponse, error) {
+	if !service.magicLinksEnabled {
+		return &IssueMagicLinkResponse{
+			Err: NewFeatureDisabledError(magicLinksFeature),
+		}, nil
+	}
+
+	response, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	})
+
+	if err != nil {
+		return &IssueMagicLinkResponse{
+			Err: err,
+		}, nil
+	}
+
+	if response.User.LockedAt != nil {
+		return &IssueMagicLinkResponse{
+			Err: NewUserLockedError(request.Email),
+		}, nil
+	}
+
+	magicLink, token, err := service.magicLinkService.IssueMagicLink(ctx, request.Email, request.IPAddress, request.DeviceID)
+	if err != nil {
+		return &IssueMagicLinkResponse{
+			Err: err,
+		}, nil
+	}
+
+	// Unlike the user events, the magic link is useless if the event never reaches the service that sends the emails,
+	// so failing to publish it fails the request and the user can ask for a new one
+	if err = service.eventingService.PublishMagicLinkIssued(ctx, &eventing.MagicLinkIssuedEvent{
+		Email:     request.Email,
+		Token:     token,
+		ExpiresAt: magicLink.ExpiresAt,
+	}); err != nil {
+		return &IssueMagicLinkResponse{
+			Err: commonErrors.NewUnknownErrorWithError("failed to publish the magic link", err),
+		}, nil
+	}
+
+	return &IssueMagicLinkResponse{
+		ExpiresAt: magicLink.ExpiresAt,
+	}, nil
+}
+
+// RedeemMagicLink redeems the magic link sent to the email address of an existing user and returns the user it signs
+// in. The magic link is redeemed before the user is read, so the same error is returned whether the user exists or
+// not, and of the concurrent calls with the same token only one signs the user in. A locked user is rejected with
+// UserLockedError until an admin unlocks it.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the email address of the user, the token and the IP address and the
+// device the magic link is redeemed from
+// Returns either the user the magic link signs in or error if something goes wrong.
+func (service *businessService) RedeemMagicLink(
+	ctx context.Context,
+	request *RedeemMagicLinkRequest) (*RedeemMagicLinkResponse, error) {
+	if !service.magicLinksEnabled {
+		return &RedeemMagicLinkResponse{
+			Err: NewFeatureDisabledError(magicLinksFeature),
+		}, nil
+	}
+
+	if _, err := service.magicLinkService.RedeemMagicLink(
+		ctx,
+		request.Email,
+		request.Token,
+		request.IPAddress,
+		request.DeviceID); err != nil {
+		return &RedeemMagicLinkResponse{
+			Err: err,
+		}, nil
+	}
+
+	response, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	})
+
+	if err != nil {
+		return &RedeemMagicLinkResponse{
+			Err: err,
+		}, nil
+	}
+
+	if response.User.LockedAt != nil {
+		return &RedeemMagicLinkResponse{
+			Err: NewUserLockedError(request.Email),
+		}, nil
+	}
+
+	return &RedeemMagicLinkResponse{
+		User: response.User,
+	}, nil
+}
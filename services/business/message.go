@@ -2,6 +2,8 @@
 package business
 
 import (
+	"time"
+
 	"github.com/decentralized-cloud/user/models"
 )
 
@@ -21,18 +23,50 @@ type CreateUserResponse struct {
 // ReadUserRequest contains the request to read an existing user
 type ReadUserRequest struct {
 	Email string
+
+	// UserID is the stable ID of the user to read, the user is read by its email address if empty
+	UserID string
+
+	// Fields are the names of the user fields to return as they are named in the GRPC contract, all the fields are
+	// returned if empty
+	Fields []string
 }
 
 // ReadUserResponse contains the result of reading an existing user
 type ReadUserResponse struct {
-	Err  error
-	User models.User
+	Err   error
+	Email string
+	User  models.User
+}
+
+// ReadUsersRequest contains the request to read the existing users with the given email addresses or IDs at once
+type ReadUsersRequest struct {
+	Emails []string
+
+	// Fields are the names of the user fields to return as they are named in the GRPC contract, all the fields are
+	// returned if empty
+	Fields []string
+
+	// UserIDs are the stable IDs of the users to read along with the users named by their email addresses
+	UserIDs []string
+}
+
+// ReadUsersResponse contains the result of reading the existing users. The users are in the order their email
+// addresses and then their IDs are requested in.
+type ReadUsersResponse struct {
+	Err            error
+	Users          []models.UserWithCursor
+	MissingEmails  []string
+	MissingUserIDs []string
 }
 
 // UpdateUserRequest contains the request to update an existing user
 type UpdateUserRequest struct {
 	Email string
 	User  models.User
+
+	// UserID is the stable ID of the user, the user is named by its email address if empty
+	UserID string
 }
 
 // UpdateUserResponse contains the result of updating an existing user
@@ -42,12 +76,848 @@ type UpdateUserResponse struct {
 	Cursor string
 }
 
+// ChangeEmailRequest contains the request to change the email address of an existing user
+type ChangeEmailRequest struct {
+	Email    string
+	NewEmail string
+
+	// UserID is the stable ID of the user, the user is named by its email address if empty
+	UserID string
+}
+
+// ChangeEmailResponse contains the result of changing the email address of an existing user
+type ChangeEmailResponse struct {
+	Err    error
+	User   models.User
+	Cursor string
+}
+
+// RequestEmailChangeRequest contains the request to change the email address of an existing user once the change is
+// confirmed
+type RequestEmailChangeRequest struct {
+	Email    string
+	NewEmail string
+}
+
+// RequestEmailChangeResponse contains the time the token sent to the new email address expires at
+type RequestEmailChangeResponse struct {
+	Err       error
+	ExpiresAt time.Time
+}
+
+// ConfirmEmailChangeRequest contains the token sent to the new email address to confirm the email address change with
+type ConfirmEmailChangeRequest struct {
+	Email string
+	Token string
+}
+
+// ConfirmEmailChangeResponse contains the result of confirming the email address change of an existing user
+type ConfirmEmailChangeResponse struct {
+	Err    error
+	User   models.User
+	Cursor string
+}
+
 // DeleteUserRequest contains the request to delete an existing user
 type DeleteUserRequest struct {
 	Email string
+
+	// UserID is the stable ID of the user, the user is named by its email address if empty
+	UserID string
 }
 
 // DeleteUserResponse contains the result of deleting an existing user
 type DeleteUserResponse struct {
+	Err    error
+	SagaID string
+}
+
+// RestoreUserRequest contains the request to restore an existing soft deleted user
+type RestoreUserRequest struct {
+	Email string
+
+	// UserID is the stable ID of the user, the user is named by its email address if empty
+	UserID string
+}
+
+// RestoreUserResponse contains the result of restoring an existing soft deleted user
+type RestoreUserResponse struct {
+	Err    error
+	User   models.User
+	Cursor string
+}
+
+// SearchRequest defines the request to search for users
+type SearchRequest struct {
+	Pagination     models.Pagination
+	SortingOptions []models.SortingOptionPair
+	Emails         []string
+	IncludeDeleted bool
+
+	// LabelSelector is the label selector the users must match, e.g. region=eu,plan in (pro, enterprise). The users
+	// are not filtered by their labels if empty.
+	LabelSelector string
+
+	// Query is the free-text query the email address of the users must start with regardless of the case, the users
+	// are not filtered by it if empty
+	Query string
+
+	// Fields are the names of the user fields to return as they are named in the GRPC contract, all the fields are
+	// returned if empty
+	Fields []string
+
+	// GroupIDs are the IDs of the groups the users must be a member of any of, the users are not filtered by their
+	// groups if empty
+	GroupIDs []string
+}
+
+// SearchResponse defines the result of searching for users
+type SearchResponse struct {
+	Err             error
+	HasPreviousPage bool
+	HasNextPage     bool
+	TotalCount      int64
+	Users           []models.UserWithCursor
+}
+
+// StreamSearchRequest defines the request to stream the users that matched the criteria to the given Send function
+type StreamSearchRequest struct {
+	SortingOptions []models.SortingOptionPair
+	Emails         []string
+	IncludeDeleted bool
+	Send           func(user models.UserWithCursor) error
+}
+
+// StreamSearchResponse defines the result of streaming the users that matched the criteria
+type StreamSearchResponse struct {
+	Err       error
+	SentCount int64
+}
+
+// GetSagaStatusRequest contains the request to read the progress of an existing saga
+type GetSagaStatusRequest struct {
+	SagaID string
+}
+
+// GetSagaStatusResponse contains the progress of an existing saga
+type GetSagaStatusResponse struct {
+	Err  error
+	Saga models.SagaState
+}
+
+// ListAuditRecordsRequest contains the criteria the audit records are listed by. The empty criteria are ignored.
+type ListAuditRecordsRequest struct {
+	Email         string
+	ActorEmail    string
+	Operations    []models.AuditOperation
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Limit         int
+}
+
+// ListAuditRecordsResponse contains the audit records that matched the criteria
+type ListAuditRecordsResponse struct {
+	Err          error
+	AuditRecords []models.AuditRecord
+}
+
+// GetEffectiveConfigurationRequest contains the request to read the configuration the service is running with
+type GetEffectiveConfigurationRequest struct {
+}
+
+// GetEffectiveConfigurationResponse contains the configuration the service is running with, the secrets are redacted
+type GetEffectiveConfigurationResponse struct {
+	Err     error
+	Options []models.ConfigurationOption
+}
+
+// GetEnabledFeaturesRequest contains the request to read the optional features the service is running with
+type GetEnabledFeaturesRequest struct {
+}
+
+// GetEnabledFeaturesResponse contains the optional features and whether they are enabled
+type GetEnabledFeaturesResponse struct {
+	Err      error
+	Features []models.Feature
+}
+
+// PreviewBulkUpdateUsersRequest contains the filter of the users and the update to preview applying to them
+type PreviewBulkUpdateUsersRequest struct {
+	Emails     []string
+	User       models.User
+	UpdateMask []string
+	SampleSize int
+}
+
+// PreviewBulkUpdateUsersResponse contains the number and a sample of the matched users, and the token that
+// confirms applying the update to them
+type PreviewBulkUpdateUsersResponse struct {
+	Err               error
+	MatchedCount      int64
+	Sample            []models.UserWithCursor
+	ConfirmationToken string
+	ExpiresAt         time.Time
+}
+
+// BulkUpdateUsersRequest contains the filter of the users, the update to apply to them and the token of its preview
+type BulkUpdateUsersRequest struct {
+	Emails            []string
+	User              models.User
+	UpdateMask        []string
+	ConfirmationToken string
+}
+
+// BulkUpdateUsersResponse contains the result of applying the update to the matched users
+type BulkUpdateUsersResponse struct {
+	Err          error
+	UpdatedCount int64
+	FailedEmails []string
+}
+
+// PurgeByLabelRequest contains the test label of the users to permanently delete
+type PurgeByLabelRequest struct {
+	Label string
+}
+
+// PurgeByLabelResponse contains the result of permanently deleting the users tagged with the test label
+type PurgeByLabelResponse struct {
+	Err         error
+	PurgedCount int64
+}
+
+// GetOutboxLagRequest contains the request to read how far behind the publisher of the user lifecycle events is
+type GetOutboxLagRequest struct {
+}
+
+// GetOutboxLagResponse contains the result of reading how far behind the publisher of the user lifecycle events is
+type GetOutboxLagResponse struct {
+	Err                   error
+	OutboxLag             models.OutboxLag
+	OldestPendingEventAge time.Duration
+}
+
+// ListPendingEventsRequest contains the request to list the user lifecycle events not confirmed by the message broker
+type ListPendingEventsRequest struct {
+	Limit int
+}
+
+// ListPendingEventsResponse contains the user lifecycle events not confirmed by the message broker, the oldest first
+type ListPendingEventsResponse struct {
+	Err           error
+	PendingEvents []models.PendingEvent
+}
+
+// ForceFlushRequest contains the request to flush the pending user lifecycle events
+type ForceFlushRequest struct {
+}
+
+// ForceFlushResponse contains the result of flushing the pending user lifecycle events
+type ForceFlushResponse struct {
+	Err           error
+	FlushedCount  int64
+	PendingEvents int64
+}
+
+// GetUserPreferencesRequest contains the request to read the preferences of an existing user
+type GetUserPreferencesRequest struct {
+	Email string
+
+	// UserID is the stable ID of the user, the user is named by its email address if empty
+	UserID string
+}
+
+// GetUserPreferencesResponse contains the preferences of an existing user
+type GetUserPreferencesResponse struct {
+	Err         error
+	Preferences map[string]string
+}
+
+// UpdateUserPreferencesRequest contains the request to merge the preferences into the preferences of an existing
+// user. The preferences not in the request are left as they are, except the ones in RemovedKeys that are removed.
+type UpdateUserPreferencesRequest struct {
+	Email       string
+	Preferences map[string]string
+	RemovedKeys []string
+
+	// UserID is the stable ID of the user, the user is named by its email address if empty
+	UserID string
+}
+
+// UpdateUserPreferencesResponse contains the preferences of an existing user after they are merged
+type UpdateUserPreferencesResponse struct {
+	Err         error
+	Preferences map[string]string
+}
+
+// GetUserAvatarRequest contains the request to read the avatar of an existing user
+type GetUserAvatarRequest struct {
+	Email string
+
+	// UserID is the stable ID of the user, the user is named by its email address if empty
+	UserID string
+}
+
+// GetUserAvatarResponse contains the URL the avatar image of an existing user is downloaded from
+type GetUserAvatarResponse struct {
+	Err         error
+	URL         string
+	ContentHash string
+	ExpiresAt   time.Time
+}
+
+// SetUserAvatarRequest contains the request to set the avatar image of an existing user
+type SetUserAvatarRequest struct {
+	Email       string
+	ContentType string
+	Content     []byte
+
+	// UserID is the stable ID of the user, the user is named by its email address if empty
+	UserID string
+}
+
+// SetUserAvatarResponse contains the user after the avatar is set and the URL the avatar image is downloaded from
+type SetUserAvatarResponse struct {
+	Err         error
+	User        models.User
+	Cursor      string
+	URL         string
+	ContentHash string
+	ExpiresAt   time.Time
+}
+
+// AddUserToTenantRequest contains the request to add an existing user to a tenant with the given role
+type AddUserToTenantRequest struct {
+	Email    string
+	TenantID string
+	Role     string
+}
+
+// AddUserToTenantResponse contains the user after it is added to the tenant
+type AddUserToTenantResponse struct {
+	Err    error
+	User   models.User
+	Cursor string
+}
+
+// RemoveUserFromTenantRequest contains the request to remove an existing user from a tenant
+type RemoveUserFromTenantRequest struct {
+	Email    string
+	TenantID string
+}
+
+// RemoveUserFromTenantResponse contains the user after it is removed from the tenant
+type RemoveUserFromTenantResponse struct {
+	Err    error
+	User   models.User
+	Cursor string
+}
+
+// ListUserTenantsRequest contains the request to list the tenants an existing user is a member of
+type ListUserTenantsRequest struct {
+	Email string
+}
+
+// ListUserTenantsResponse contains the memberships of an existing user in the tenants
+type ListUserTenantsResponse struct {
+	Err         error
+	Memberships []models.TenantMembership
+}
+
+// GetReplicationStatusRequest contains the request to read how far behind the primary database the standby database is
+type GetReplicationStatusRequest struct {
+}
+
+// GetReplicationStatusResponse contains how far behind the primary database the standby database is
+type GetReplicationStatusResponse struct {
+	Err               error
+	ReplicationStatus models.ReplicationStatus
+}
+
+// ExportUsersRequest defines the request to export all the users that matched the filter to the given Send function
+type ExportUsersRequest struct {
+	TenantID       string
+	CreatedAfter   *time.Time
+	CreatedBefore  *time.Time
+	IncludeDeleted bool
+	After          string
+	Send           func(user models.UserWithCursor) error
+}
+
+// ExportUsersResponse defines the result of exporting the users that matched the filter
+type ExportUsersResponse struct {
+	Err           error
+	ExportedCount int64
+}
+
+// IssueMagicLinkRequest contains the request to issue a magic link to an existing user
+type IssueMagicLinkRequest struct {
+	Email     string
+	IPAddress string
+	DeviceID  string
+}
+
+// IssueMagicLinkResponse contains the result of issuing the magic link
+type IssueMagicLinkResponse struct {
+	Err       error
+	ExpiresAt time.Time
+}
+
+// RedeemMagicLinkRequest contains the request to redeem the magic link sent to the email address of an existing user
+type RedeemMagicLinkRequest struct {
+	Email     string
+	Token     string
+	IPAddress string
+	DeviceID  string
+}
+
+// RedeemMagicLinkResponse contains the user the magic link signs in
+type RedeemMagicLinkResponse struct {
+	Err  error
+	User models.User
+}
+
+// ExportPersonalDataRequest contains the request to export the personal data of an existing user
+type ExportPersonalDataRequest struct {
+	Email string
+}
+
+// ExportPersonalDataResponse contains the JSON archive of the personal data of an existing user
+type ExportPersonalDataResponse struct {
+	Err      error
+	Archive  []byte
+	FileName string
+}
+
+// EraseUserRequest contains the request to erase an existing user. The erasure is requested if ConfirmationToken
+// is not provided, and confirmed otherwise.
+type EraseUserRequest struct {
+	Email             string
+	ConfirmationToken string
+}
+
+// EraseUserResponse contains the token that confirms the requested erasure and when it can be confirmed, or
+// whether the user is erased if the erasure is confirmed
+type EraseUserResponse struct {
+	Err               error
+	Erased            bool
+	ConfirmationToken string
+	ConfirmableAt     time.Time
+	ExpiresAt         time.Time
+}
+
+// SendVerificationEmailRequest contains the request to send the verification email to an existing user
+type SendVerificationEmailRequest struct {
+	Email string
+}
+
+// SendVerificationEmailResponse contains the result of sending the verification email to an existing user
+type SendVerificationEmailResponse struct {
+	Err             error
+	ExpiresAt       time.Time
+	AlreadyVerified bool
+}
+
+// VerifyEmailRequest contains the request to verify the email address of an existing user
+type VerifyEmailRequest struct {
+	Email string
+	Token string
+}
+
+// VerifyEmailResponse contains the user after its email address is verified
+type VerifyEmailResponse struct {
+	Err    error
+	User   models.User
+	Cursor string
+}
+
+// SetPasswordRequest contains the request to set the first password of an existing user
+type SetPasswordRequest struct {
+	Email    string
+	Password string
+}
+
+// SetPasswordResponse contains the result of setting the first password of an existing user
+type SetPasswordResponse struct {
+	Err error
+}
+
+// ChangePasswordRequest contains the request to replace the password of an existing user
+type ChangePasswordRequest struct {
+	Email           string
+	CurrentPassword string
+	NewPassword     string
+}
+
+// ChangePasswordResponse contains the result of replacing the password of an existing user
+type ChangePasswordResponse struct {
+	Err error
+}
+
+// VerifyPasswordRequest contains the request to verify the password of an existing user
+type VerifyPasswordRequest struct {
+	Email    string
+	Password string
+}
+
+// VerifyPasswordResponse contains the user the password matched
+type VerifyPasswordResponse struct {
+	Err  error
+	User models.User
+}
+
+// WatchUsersRequest defines the request to send the changes made to the users that matched the filter to the given
+// Send function
+type WatchUsersRequest struct {
+	Emails      []string
+	Types       []models.UserChangeType
+	ResumeToken string
+	Send        func(change models.UserChange) error
+}
+
+// WatchUsersResponse defines the result of watching the users that matched the filter
+type WatchUsersResponse struct {
+	Err       error
+	SentCount int64
+}
+
+// CreateAPIKeyRequest contains the request to mint a new API key for an existing user
+type CreateAPIKeyRequest struct {
+	Email     string
+	Name      string
+	Scopes    []models.APIKeyScope
+	ExpiresAt *time.Time
+
+	// UserID is the stable ID of the user, the user is named by its email address if empty
+	UserID string
+}
+
+// CreateAPIKeyResponse contains the new API key and the key itself, which is only returned once
+type CreateAPIKeyResponse struct {
+	Err    error
+	APIKey models.APIKey
+	Key    string
+}
+
+// ListAPIKeysRequest contains the request to list the API keys of a user
+type ListAPIKeysRequest struct {
+	Email string
+
+	// UserID is the stable ID of the user, the user is named by its email address if empty
+	UserID string
+}
+
+// ListAPIKeysResponse contains the API keys of the user
+type ListAPIKeysResponse struct {
+	Err     error
+	APIKeys []models.APIKey
+}
+
+// RevokeAPIKeyRequest contains the request to revoke an API key of a user
+type RevokeAPIKeyRequest struct {
+	Email string
+	KeyID string
+
+	// UserID is the stable ID of the user, the user is named by its email address if empty
+	UserID string
+}
+
+// RevokeAPIKeyResponse contains the result of revoking an API key of a user
+type RevokeAPIKeyResponse struct {
+	Err error
+}
+
+// RecordLoginAttemptRequest contains the request to record the outcome of a login attempt of an existing user
+type RecordLoginAttemptRequest struct {
+	Email     string
+	Succeeded bool
+}
+
+// RecordLoginAttemptResponse contains the user after the login attempt is recorded
+type RecordLoginAttemptResponse struct {
+	Err    error
+	User   models.User
+	Cursor string
+}
+
+// UnlockUserRequest contains the request to unlock an existing user
+type UnlockUserRequest struct {
+	Email string
+}
+
+// UnlockUserResponse contains the user after it is unlocked
+type UnlockUserResponse struct {
+	Err    error
+	User   models.User
+	Cursor string
+}
+
+// EnrollMFARequest contains the request to enroll an existing user in an MFA method. Only the field that matches the
+// type of the method is set.
+type EnrollMFARequest struct {
+	Email                string
+	Type                 models.MFAMethodType
+	Name                 string
+	TOTPSecretRef        string
+	WebAuthnCredentialID string
+	RecoveryCodeHashes   []string
+
+	// UserID is the stable ID of the user, the user is named by its email address if empty
+	UserID string
+}
+
+// EnrollMFAResponse contains the MFA method the user is enrolled in
+type EnrollMFAResponse struct {
+	Err    error
+	Method models.MFAMethod
+	User   models.User
+	Cursor string
+}
+
+// ListMFAMethodsRequest contains the request to list the MFA methods an existing user is enrolled in
+type ListMFAMethodsRequest struct {
+	Email string
+
+	// UserID is the stable ID of the user, the user is named by its email address if empty
+	UserID string
+}
+
+// ListMFAMethodsResponse contains the MFA methods an existing user is enrolled in
+type ListMFAMethodsResponse struct {
+	Err     error
+	Methods []models.MFAMethod
+}
+
+// RemoveMFAMethodRequest contains the request to remove an MFA method from an existing user
+type RemoveMFAMethodRequest struct {
+	Email    string
+	MethodID string
+
+	// UserID is the stable ID of the user, the user is named by its email address if empty
+	UserID string
+}
+
+// RemoveMFAMethodResponse contains the user after the MFA method is removed
+type RemoveMFAMethodResponse struct {
+	Err    error
+	User   models.User
+	Cursor string
+}
+
+// RecordConsentRequest contains the request to record that an existing user accepted a version of a policy
+type RecordConsentRequest struct {
+	Email   string
+	Policy  string
+	Version string
+}
+
+// RecordConsentResponse contains the recorded consent
+type RecordConsentResponse struct {
+	Err     error
+	Consent models.Consent
+}
+
+// ListConsentsRequest contains the request to list the consents of an existing user
+type ListConsentsRequest struct {
+	Email string
+}
+
+// ListConsentsResponse contains the consents of an existing user and the required policy versions the user has not
+// accepted yet
+type ListConsentsResponse struct {
+	Err                 error
+	Consents            []models.Consent
+	OutstandingPolicies []models.PolicyVersion
+}
+
+// CreateGroupRequest contains the request to create a new group
+type CreateGroupRequest struct {
+	Name        string
+	Description string
+}
+
+// CreateGroupResponse contains the new group
+type CreateGroupResponse struct {
+	Err   error
+	Group models.Group
+}
+
+// ReadGroupRequest contains the request to read an existing group
+type ReadGroupRequest struct {
+	GroupID string
+}
+
+// ReadGroupResponse contains the group
+type ReadGroupResponse struct {
+	Err   error
+	Group models.Group
+}
+
+// UpdateGroupRequest contains the request to rename and describe an existing group
+type UpdateGroupRequest struct {
+	GroupID     string
+	Name        string
+	Description string
+}
+
+// UpdateGroupResponse contains the updated group
+type UpdateGroupResponse struct {
+	Err   error
+	Group models.Group
+}
+
+// DeleteGroupRequest contains the request to delete an existing group
+type DeleteGroupRequest struct {
+	GroupID string
+}
+
+// DeleteGroupResponse contains the result of deleting the group
+type DeleteGroupResponse struct {
 	Err error
 }
+
+// ListGroupsRequest contains the request to list all the groups, or the groups of a user
+type ListGroupsRequest struct {
+	// Email is the email address of the user to list the groups of, all the groups are listed if empty
+	Email string
+}
+
+// ListGroupsResponse contains the groups ordered by their names
+type ListGroupsResponse struct {
+	Err    error
+	Groups []models.Group
+}
+
+// AddUserToGroupRequest contains the request to add an existing user to an existing group
+type AddUserToGroupRequest struct {
+	GroupID string
+	Email   string
+}
+
+// AddUserToGroupResponse contains the group after the user is added
+type AddUserToGroupResponse struct {
+	Err   error
+	Group models.Group
+}
+
+// RemoveUserFromGroupRequest contains the request to remove a user from an existing group
+type RemoveUserFromGroupRequest struct {
+	GroupID string
+	Email   string
+}
+
+// RemoveUserFromGroupResponse contains the group after the user is removed
+type RemoveUserFromGroupResponse struct {
+	Err   error
+	Group models.Group
+}
+
+// InviteUserRequest contains the request to invite an email address to join as a new user
+type InviteUserRequest struct {
+	Email string
+}
+
+// InviteUserResponse contains the result of inviting the email address
+type InviteUserResponse struct {
+	Err       error
+	ExpiresAt time.Time
+}
+
+// AcceptInvitationRequest contains the request to accept the invitation sent to the email address and create its user
+type AcceptInvitationRequest struct {
+	Email string
+	Token string
+	User  models.User
+}
+
+// AcceptInvitationResponse contains the result of accepting the invitation
+type AcceptInvitationResponse struct {
+	Err    error
+	User   models.User
+	Cursor string
+}
+
+// RegisterSessionRequest contains the request to register a new session of an existing user or extend an existing one
+type RegisterSessionRequest struct {
+	Email      string
+	SessionID  string
+	DeviceName string
+	UserAgent  string
+	IPAddress  string
+}
+
+// RegisterSessionResponse contains the registered session
+type RegisterSessionResponse struct {
+	Err     error
+	Session models.Session
+}
+
+// ListSessionsRequest contains the request to list the active sessions of a user
+type ListSessionsRequest struct {
+	Email string
+}
+
+// ListSessionsResponse contains the active sessions of the user
+type ListSessionsResponse struct {
+	Err      error
+	Sessions []models.Session
+}
+
+// RevokeSessionRequest contains the request to revoke an active session of a user
+type RevokeSessionRequest struct {
+	Email     string
+	SessionID string
+}
+
+// RevokeSessionResponse contains the result of revoking the session
+type RevokeSessionResponse struct {
+	Err error
+}
+
+// CreateWebhookSubscriptionRequest contains the request to subscribe a webhook to the events of an existing user
+type CreateWebhookSubscriptionRequest struct {
+	Email      string
+	URL        string
+	Secret     string
+	EventTypes []models.WebhookEventType
+}
+
+// CreateWebhookSubscriptionResponse contains the created webhook subscription
+type CreateWebhookSubscriptionResponse struct {
+	Err          error
+	Subscription models.WebhookSubscription
+}
+
+// ListWebhookSubscriptionsRequest contains the request to list the webhook subscriptions of a user
+type ListWebhookSubscriptionsRequest struct {
+	Email string
+}
+
+// ListWebhookSubscriptionsResponse contains the webhook subscriptions of the user
+type ListWebhookSubscriptionsResponse struct {
+	Err           error
+	Subscriptions []models.WebhookSubscription
+}
+
+// DeleteWebhookSubscriptionRequest contains the request to delete a webhook subscription of a user
+type DeleteWebhookSubscriptionRequest struct {
+	Email          string
+	SubscriptionID string
+}
+
+// DeleteWebhookSubscriptionResponse contains the result of deleting the webhook subscription
+type DeleteWebhookSubscriptionResponse struct {
+	Err error
+}
+
+// ListWebhookDeliveriesRequest contains the request to list the recent deliveries to the webhooks of a user
+type ListWebhookDeliveriesRequest struct {
+	Email          string
+	SubscriptionID string
+	Limit          int
+}
+
+// ListWebhookDeliveriesResponse contains the recent deliveries to the webhooks of the user
+type ListWebhookDeliveriesResponse struct {
+	Err        error
+	Deliveries []models.WebhookDelivery
+}
@@ -0,0 +1,160 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+const (
+	// maxMFAMethodNameLength is the maximum length of the names the users recognize their MFA methods by
+	maxMFAMethodNameLength = 100
+
+	// maxMFACredentialLength is the maximum length of the TOTP secret references, the WebAuthn credential IDs and the
+	// recovery code hashes
+	maxMFACredentialLength = 2048
+
+	// maxRecoveryCodes is the maximum number of the recovery codes in a set
+	maxRecoveryCodes = 32
+
+	// mfaMethodIDLength is the number of the random bytes the IDs of the MFA methods are generated from
+	mfaMethodIDLength = 16
+)
+
+// EnrollMFA enrolls an existing user in an MFA method. The same TOTP secret or WebAuthn credential can not be enrolled
+// twice and a user has at most one set of the recovery codes, the existing set must be removed to replace it.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the method to enroll the user in
+// Returns either the method the user is enrolled in or error if something goes wrong.
+func (service *businessService) EnrollMFA(
+	ctx context.Context,
+	request *EnrollMFARequest) (*EnrollMFAResponse, error) {
+	if err := service.validationPolicy.validateName(mfaMethodNameField, request.Name); err != nil {
+		return &EnrollMFAResponse{
+			Err: NewRequestValidationError(err),
+		}, nil
+	}
+
+	methodID, err := generateMFAMethodID()
+	if err != nil {
+		return &EnrollMFAResponse{
+			Err: err,
+		}, nil
+	}
+
+	// The user is read before it is updated so the audit record contains what the update changed
+	email, user, err := service.readRequestedUser(ctx, request.Email, request.UserID)
+	if err != nil {
+		return &EnrollMFAResponse{
+			Err: err,
+		}, nil
+	}
+
+	if err = service.requireConsent(ctx, "EnrollMFA", email); err != nil {
+		return &EnrollMFAResponse{
+			Err: err,
+		}, nil
+	}
+
+	method := models.MFAMethod{
+		MethodID:             methodID,
+		Type:                 request.Type,
+		Name:                 request.Name,
+		TOTPSecretRef:        request.TOTPSecretRef,
+		WebAuthnCredentialID: request.WebAuthnCredentialID,
+		RecoveryCodeHashes:   request.RecoveryCodeHashes,
+		EnrolledAt:           service.clockService.Now(),
+	}
+
+	response, err := service.repositoryService.AddMFAMethod(ctx, &repository.AddMFAMethodRequest{
+		Email:  email,
+		Method: method,
+	})
+
+	if err != nil {
+		return &EnrollMFAResponse{
+			Err: err,
+		}, nil
+	}
+
+	// The other services are not notified, the MFA methods are not part of the user the events carry
+	service.logIgnoredError(ctx, "failed to record the operation in the audit log", service.auditService.RecordOperation(ctx, models.AuditOperationUpdate, email, &user, &response.User))
+
+	return &EnrollMFAResponse{
+		Method: method,
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// ListMFAMethods lists the MFA methods an existing user is enrolled in
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains either the email address or the ID of the user
+// Returns either the MFA methods of the user or error if something goes wrong.
+func (service *businessService) ListMFAMethods(
+	ctx context.Context,
+	request *ListMFAMethodsRequest) (*ListMFAMethodsResponse, error) {
+	_, user, err := service.readRequestedUser(ctx, request.Email, request.UserID)
+	if err != nil {
+		return &ListMFAMethodsResponse{
+			Err: err,
+		}, nil
+	}
+
+	methods := user.MFAMethods
+	if methods == nil {
+		methods = []models.MFAMethod{}
+	}
+
+	return &ListMFAMethodsResponse{
+		Methods: methods,
+	}, nil
+}
+
+// RemoveMFAMethod removes an MFA method from an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the ID of the method to remove
+// Returns either the user after the method is removed or error if something goes wrong.
+func (service *businessService) RemoveMFAMethod(
+	ctx context.Context,
+	request *RemoveMFAMethodRequest) (*RemoveMFAMethodResponse, error) {
+	// The user is read before it is updated so the audit record contains what the update changed
+	email, user, err := service.readRequestedUser(ctx, request.Email, request.UserID)
+	if err != nil {
+		return &RemoveMFAMethodResponse{
+			Err: err,
+		}, nil
+	}
+
+	response, err := service.repositoryService.RemoveMFAMethod(ctx, &repository.RemoveMFAMethodRequest{
+		Email:    email,
+		MethodID: request.MethodID,
+	})
+
+	if err != nil {
+		return &RemoveMFAMethodResponse{
+			Err: err,
+		}, nil
+	}
+
+	service.logIgnoredError(ctx, "failed to record the operation in the audit log", service.auditService.RecordOperation(ctx, models.AuditOperationUpdate, email, &user, &response.User))
+
+	return &RemoveMFAMethodResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+func generateMFAMethodID() (string, error) {
+	methodID := make([]byte, mfaMethodIDLength)
+	if _, err := rand.Read(methodID); err != nil {
+		return "", commonErrors.NewUnknownErrorWithError("failed to generate the MFA method ID", err)
+	}
+
+	return hex.EncodeToString(methodID), nil
+}
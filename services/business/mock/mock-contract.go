@@ -35,6 +35,141 @@ func (m *MockBusinessContract) EXPECT() *MockBusinessContractMockRecorder {
 	return m.recorder
 }
 
+// AcceptInvitation mocks base method.
+func (m *MockBusinessContract) AcceptInvitation(ctx context.Context, request *business.AcceptInvitationRequest) (*business.AcceptInvitationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, request)
+	ret0, _ := ret[0].(*business.AcceptInvitationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockBusinessContractMockRecorder) AcceptInvitation(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockBusinessContract)(nil).AcceptInvitation), ctx, request)
+}
+
+// AddUserToGroup mocks base method.
+func (m *MockBusinessContract) AddUserToGroup(ctx context.Context, request *business.AddUserToGroupRequest) (*business.AddUserToGroupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserToGroup", ctx, request)
+	ret0, _ := ret[0].(*business.AddUserToGroupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddUserToGroup indicates an expected call of AddUserToGroup.
+func (mr *MockBusinessContractMockRecorder) AddUserToGroup(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToGroup", reflect.TypeOf((*MockBusinessContract)(nil).AddUserToGroup), ctx, request)
+}
+
+// AddUserToTenant mocks base method.
+func (m *MockBusinessContract) AddUserToTenant(ctx context.Context, request *business.AddUserToTenantRequest) (*business.AddUserToTenantResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserToTenant", ctx, request)
+	ret0, _ := ret[0].(*business.AddUserToTenantResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddUserToTenant indicates an expected call of AddUserToTenant.
+func (mr *MockBusinessContractMockRecorder) AddUserToTenant(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToTenant", reflect.TypeOf((*MockBusinessContract)(nil).AddUserToTenant), ctx, request)
+}
+
+// BulkUpdateUsers mocks base method.
+func (m *MockBusinessContract) BulkUpdateUsers(ctx context.Context, request *business.BulkUpdateUsersRequest) (*business.BulkUpdateUsersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateUsers", ctx, request)
+	ret0, _ := ret[0].(*business.BulkUpdateUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdateUsers indicates an expected call of BulkUpdateUsers.
+func (mr *MockBusinessContractMockRecorder) BulkUpdateUsers(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateUsers", reflect.TypeOf((*MockBusinessContract)(nil).BulkUpdateUsers), ctx, request)
+}
+
+// ChangeEmail mocks base method.
+func (m *MockBusinessContract) ChangeEmail(ctx context.Context, request *business.ChangeEmailRequest) (*business.ChangeEmailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmail", ctx, request)
+	ret0, _ := ret[0].(*business.ChangeEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeEmail indicates an expected call of ChangeEmail.
+func (mr *MockBusinessContractMockRecorder) ChangeEmail(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmail", reflect.TypeOf((*MockBusinessContract)(nil).ChangeEmail), ctx, request)
+}
+
+// ChangePassword mocks base method.
+func (m *MockBusinessContract) ChangePassword(ctx context.Context, request *business.ChangePasswordRequest) (*business.ChangePasswordResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, request)
+	ret0, _ := ret[0].(*business.ChangePasswordResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockBusinessContractMockRecorder) ChangePassword(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockBusinessContract)(nil).ChangePassword), ctx, request)
+}
+
+// ConfirmEmailChange mocks base method.
+func (m *MockBusinessContract) ConfirmEmailChange(ctx context.Context, request *business.ConfirmEmailChangeRequest) (*business.ConfirmEmailChangeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmailChange", ctx, request)
+	ret0, _ := ret[0].(*business.ConfirmEmailChangeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmEmailChange indicates an expected call of ConfirmEmailChange.
+func (mr *MockBusinessContractMockRecorder) ConfirmEmailChange(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailChange", reflect.TypeOf((*MockBusinessContract)(nil).ConfirmEmailChange), ctx, request)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockBusinessContract) CreateAPIKey(ctx context.Context, request *business.CreateAPIKeyRequest) (*business.CreateAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, request)
+	ret0, _ := ret[0].(*business.CreateAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockBusinessContractMockRecorder) CreateAPIKey(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockBusinessContract)(nil).CreateAPIKey), ctx, request)
+}
+
+// CreateGroup mocks base method.
+func (m *MockBusinessContract) CreateGroup(ctx context.Context, request *business.CreateGroupRequest) (*business.CreateGroupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", ctx, request)
+	ret0, _ := ret[0].(*business.CreateGroupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockBusinessContractMockRecorder) CreateGroup(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockBusinessContract)(nil).CreateGroup), ctx, request)
+}
+
 // CreateUser mocks base method.
 func (m *MockBusinessContract) CreateUser(ctx context.Context, request *business.CreateUserRequest) (*business.CreateUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +185,36 @@ func (mr *MockBusinessContractMockRecorder) CreateUser(ctx, request interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockBusinessContract)(nil).CreateUser), ctx, request)
 }
 
+// CreateWebhookSubscription mocks base method.
+func (m *MockBusinessContract) CreateWebhookSubscription(ctx context.Context, request *business.CreateWebhookSubscriptionRequest) (*business.CreateWebhookSubscriptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookSubscription", ctx, request)
+	ret0, _ := ret[0].(*business.CreateWebhookSubscriptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookSubscription indicates an expected call of CreateWebhookSubscription.
+func (mr *MockBusinessContractMockRecorder) CreateWebhookSubscription(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookSubscription", reflect.TypeOf((*MockBusinessContract)(nil).CreateWebhookSubscription), ctx, request)
+}
+
+// DeleteGroup mocks base method.
+func (m *MockBusinessContract) DeleteGroup(ctx context.Context, request *business.DeleteGroupRequest) (*business.DeleteGroupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroup", ctx, request)
+	ret0, _ := ret[0].(*business.DeleteGroupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGroup indicates an expected call of DeleteGroup.
+func (mr *MockBusinessContractMockRecorder) DeleteGroup(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockBusinessContract)(nil).DeleteGroup), ctx, request)
+}
+
 // DeleteUser mocks base method.
 func (m *MockBusinessContract) DeleteUser(ctx context.Context, request *business.DeleteUserRequest) (*business.DeleteUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +230,426 @@ func (mr *MockBusinessContractMockRecorder) DeleteUser(ctx, request interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockBusinessContract)(nil).DeleteUser), ctx, request)
 }
 
+// DeleteWebhookSubscription mocks base method.
+func (m *MockBusinessContract) DeleteWebhookSubscription(ctx context.Context, request *business.DeleteWebhookSubscriptionRequest) (*business.DeleteWebhookSubscriptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookSubscription", ctx, request)
+	ret0, _ := ret[0].(*business.DeleteWebhookSubscriptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebhookSubscription indicates an expected call of DeleteWebhookSubscription.
+func (mr *MockBusinessContractMockRecorder) DeleteWebhookSubscription(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookSubscription", reflect.TypeOf((*MockBusinessContract)(nil).DeleteWebhookSubscription), ctx, request)
+}
+
+// EnrollMFA mocks base method.
+func (m *MockBusinessContract) EnrollMFA(ctx context.Context, request *business.EnrollMFARequest) (*business.EnrollMFAResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollMFA", ctx, request)
+	ret0, _ := ret[0].(*business.EnrollMFAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollMFA indicates an expected call of EnrollMFA.
+func (mr *MockBusinessContractMockRecorder) EnrollMFA(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollMFA", reflect.TypeOf((*MockBusinessContract)(nil).EnrollMFA), ctx, request)
+}
+
+// EraseUser mocks base method.
+func (m *MockBusinessContract) EraseUser(ctx context.Context, request *business.EraseUserRequest) (*business.EraseUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", ctx, request)
+	ret0, _ := ret[0].(*business.EraseUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockBusinessContractMockRecorder) EraseUser(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockBusinessContract)(nil).EraseUser), ctx, request)
+}
+
+// ExportPersonalData mocks base method.
+func (m *MockBusinessContract) ExportPersonalData(ctx context.Context, request *business.ExportPersonalDataRequest) (*business.ExportPersonalDataResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportPersonalData", ctx, request)
+	ret0, _ := ret[0].(*business.ExportPersonalDataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportPersonalData indicates an expected call of ExportPersonalData.
+func (mr *MockBusinessContractMockRecorder) ExportPersonalData(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportPersonalData", reflect.TypeOf((*MockBusinessContract)(nil).ExportPersonalData), ctx, request)
+}
+
+// ExportUsers mocks base method.
+func (m *MockBusinessContract) ExportUsers(ctx context.Context, request *business.ExportUsersRequest) (*business.ExportUsersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUsers", ctx, request)
+	ret0, _ := ret[0].(*business.ExportUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUsers indicates an expected call of ExportUsers.
+func (mr *MockBusinessContractMockRecorder) ExportUsers(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUsers", reflect.TypeOf((*MockBusinessContract)(nil).ExportUsers), ctx, request)
+}
+
+// ForceFlush mocks base method.
+func (m *MockBusinessContract) ForceFlush(ctx context.Context, request *business.ForceFlushRequest) (*business.ForceFlushResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceFlush", ctx, request)
+	ret0, _ := ret[0].(*business.ForceFlushResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceFlush indicates an expected call of ForceFlush.
+func (mr *MockBusinessContractMockRecorder) ForceFlush(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceFlush", reflect.TypeOf((*MockBusinessContract)(nil).ForceFlush), ctx, request)
+}
+
+// GetEffectiveConfiguration mocks base method.
+func (m *MockBusinessContract) GetEffectiveConfiguration(ctx context.Context, request *business.GetEffectiveConfigurationRequest) (*business.GetEffectiveConfigurationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectiveConfiguration", ctx, request)
+	ret0, _ := ret[0].(*business.GetEffectiveConfigurationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffectiveConfiguration indicates an expected call of GetEffectiveConfiguration.
+func (mr *MockBusinessContractMockRecorder) GetEffectiveConfiguration(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectiveConfiguration", reflect.TypeOf((*MockBusinessContract)(nil).GetEffectiveConfiguration), ctx, request)
+}
+
+// GetEnabledFeatures mocks base method.
+func (m *MockBusinessContract) GetEnabledFeatures(ctx context.Context, request *business.GetEnabledFeaturesRequest) (*business.GetEnabledFeaturesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledFeatures", ctx, request)
+	ret0, _ := ret[0].(*business.GetEnabledFeaturesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabledFeatures indicates an expected call of GetEnabledFeatures.
+func (mr *MockBusinessContractMockRecorder) GetEnabledFeatures(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledFeatures", reflect.TypeOf((*MockBusinessContract)(nil).GetEnabledFeatures), ctx, request)
+}
+
+// GetOutboxLag mocks base method.
+func (m *MockBusinessContract) GetOutboxLag(ctx context.Context, request *business.GetOutboxLagRequest) (*business.GetOutboxLagResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxLag", ctx, request)
+	ret0, _ := ret[0].(*business.GetOutboxLagResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutboxLag indicates an expected call of GetOutboxLag.
+func (mr *MockBusinessContractMockRecorder) GetOutboxLag(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxLag", reflect.TypeOf((*MockBusinessContract)(nil).GetOutboxLag), ctx, request)
+}
+
+// GetReplicationStatus mocks base method.
+func (m *MockBusinessContract) GetReplicationStatus(ctx context.Context, request *business.GetReplicationStatusRequest) (*business.GetReplicationStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplicationStatus", ctx, request)
+	ret0, _ := ret[0].(*business.GetReplicationStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplicationStatus indicates an expected call of GetReplicationStatus.
+func (mr *MockBusinessContractMockRecorder) GetReplicationStatus(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicationStatus", reflect.TypeOf((*MockBusinessContract)(nil).GetReplicationStatus), ctx, request)
+}
+
+// GetSagaStatus mocks base method.
+func (m *MockBusinessContract) GetSagaStatus(ctx context.Context, request *business.GetSagaStatusRequest) (*business.GetSagaStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSagaStatus", ctx, request)
+	ret0, _ := ret[0].(*business.GetSagaStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSagaStatus indicates an expected call of GetSagaStatus.
+func (mr *MockBusinessContractMockRecorder) GetSagaStatus(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSagaStatus", reflect.TypeOf((*MockBusinessContract)(nil).GetSagaStatus), ctx, request)
+}
+
+// GetUserAvatar mocks base method.
+func (m *MockBusinessContract) GetUserAvatar(ctx context.Context, request *business.GetUserAvatarRequest) (*business.GetUserAvatarResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAvatar", ctx, request)
+	ret0, _ := ret[0].(*business.GetUserAvatarResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAvatar indicates an expected call of GetUserAvatar.
+func (mr *MockBusinessContractMockRecorder) GetUserAvatar(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAvatar", reflect.TypeOf((*MockBusinessContract)(nil).GetUserAvatar), ctx, request)
+}
+
+// GetUserPreferences mocks base method.
+func (m *MockBusinessContract) GetUserPreferences(ctx context.Context, request *business.GetUserPreferencesRequest) (*business.GetUserPreferencesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPreferences", ctx, request)
+	ret0, _ := ret[0].(*business.GetUserPreferencesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPreferences indicates an expected call of GetUserPreferences.
+func (mr *MockBusinessContractMockRecorder) GetUserPreferences(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPreferences", reflect.TypeOf((*MockBusinessContract)(nil).GetUserPreferences), ctx, request)
+}
+
+// InviteUser mocks base method.
+func (m *MockBusinessContract) InviteUser(ctx context.Context, request *business.InviteUserRequest) (*business.InviteUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteUser", ctx, request)
+	ret0, _ := ret[0].(*business.InviteUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteUser indicates an expected call of InviteUser.
+func (mr *MockBusinessContractMockRecorder) InviteUser(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteUser", reflect.TypeOf((*MockBusinessContract)(nil).InviteUser), ctx, request)
+}
+
+// IssueMagicLink mocks base method.
+func (m *MockBusinessContract) IssueMagicLink(ctx context.Context, request *business.IssueMagicLinkRequest) (*business.IssueMagicLinkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueMagicLink", ctx, request)
+	ret0, _ := ret[0].(*business.IssueMagicLinkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueMagicLink indicates an expected call of IssueMagicLink.
+func (mr *MockBusinessContractMockRecorder) IssueMagicLink(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueMagicLink", reflect.TypeOf((*MockBusinessContract)(nil).IssueMagicLink), ctx, request)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockBusinessContract) ListAPIKeys(ctx context.Context, request *business.ListAPIKeysRequest) (*business.ListAPIKeysResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx, request)
+	ret0, _ := ret[0].(*business.ListAPIKeysResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockBusinessContractMockRecorder) ListAPIKeys(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockBusinessContract)(nil).ListAPIKeys), ctx, request)
+}
+
+// ListAuditRecords mocks base method.
+func (m *MockBusinessContract) ListAuditRecords(ctx context.Context, request *business.ListAuditRecordsRequest) (*business.ListAuditRecordsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditRecords", ctx, request)
+	ret0, _ := ret[0].(*business.ListAuditRecordsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditRecords indicates an expected call of ListAuditRecords.
+func (mr *MockBusinessContractMockRecorder) ListAuditRecords(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditRecords", reflect.TypeOf((*MockBusinessContract)(nil).ListAuditRecords), ctx, request)
+}
+
+// ListConsents mocks base method.
+func (m *MockBusinessContract) ListConsents(ctx context.Context, request *business.ListConsentsRequest) (*business.ListConsentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConsents", ctx, request)
+	ret0, _ := ret[0].(*business.ListConsentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConsents indicates an expected call of ListConsents.
+func (mr *MockBusinessContractMockRecorder) ListConsents(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConsents", reflect.TypeOf((*MockBusinessContract)(nil).ListConsents), ctx, request)
+}
+
+// ListGroups mocks base method.
+func (m *MockBusinessContract) ListGroups(ctx context.Context, request *business.ListGroupsRequest) (*business.ListGroupsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroups", ctx, request)
+	ret0, _ := ret[0].(*business.ListGroupsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroups indicates an expected call of ListGroups.
+func (mr *MockBusinessContractMockRecorder) ListGroups(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroups", reflect.TypeOf((*MockBusinessContract)(nil).ListGroups), ctx, request)
+}
+
+// ListMFAMethods mocks base method.
+func (m *MockBusinessContract) ListMFAMethods(ctx context.Context, request *business.ListMFAMethodsRequest) (*business.ListMFAMethodsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMFAMethods", ctx, request)
+	ret0, _ := ret[0].(*business.ListMFAMethodsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMFAMethods indicates an expected call of ListMFAMethods.
+func (mr *MockBusinessContractMockRecorder) ListMFAMethods(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMFAMethods", reflect.TypeOf((*MockBusinessContract)(nil).ListMFAMethods), ctx, request)
+}
+
+// ListPendingEvents mocks base method.
+func (m *MockBusinessContract) ListPendingEvents(ctx context.Context, request *business.ListPendingEventsRequest) (*business.ListPendingEventsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingEvents", ctx, request)
+	ret0, _ := ret[0].(*business.ListPendingEventsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingEvents indicates an expected call of ListPendingEvents.
+func (mr *MockBusinessContractMockRecorder) ListPendingEvents(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingEvents", reflect.TypeOf((*MockBusinessContract)(nil).ListPendingEvents), ctx, request)
+}
+
+// ListSessions mocks base method.
+func (m *MockBusinessContract) ListSessions(ctx context.Context, request *business.ListSessionsRequest) (*business.ListSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, request)
+	ret0, _ := ret[0].(*business.ListSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockBusinessContractMockRecorder) ListSessions(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockBusinessContract)(nil).ListSessions), ctx, request)
+}
+
+// ListUserTenants mocks base method.
+func (m *MockBusinessContract) ListUserTenants(ctx context.Context, request *business.ListUserTenantsRequest) (*business.ListUserTenantsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserTenants", ctx, request)
+	ret0, _ := ret[0].(*business.ListUserTenantsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserTenants indicates an expected call of ListUserTenants.
+func (mr *MockBusinessContractMockRecorder) ListUserTenants(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserTenants", reflect.TypeOf((*MockBusinessContract)(nil).ListUserTenants), ctx, request)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockBusinessContract) ListWebhookDeliveries(ctx context.Context, request *business.ListWebhookDeliveriesRequest) (*business.ListWebhookDeliveriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", ctx, request)
+	ret0, _ := ret[0].(*business.ListWebhookDeliveriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockBusinessContractMockRecorder) ListWebhookDeliveries(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockBusinessContract)(nil).ListWebhookDeliveries), ctx, request)
+}
+
+// ListWebhookSubscriptions mocks base method.
+func (m *MockBusinessContract) ListWebhookSubscriptions(ctx context.Context, request *business.ListWebhookSubscriptionsRequest) (*business.ListWebhookSubscriptionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookSubscriptions", ctx, request)
+	ret0, _ := ret[0].(*business.ListWebhookSubscriptionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookSubscriptions indicates an expected call of ListWebhookSubscriptions.
+func (mr *MockBusinessContractMockRecorder) ListWebhookSubscriptions(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookSubscriptions", reflect.TypeOf((*MockBusinessContract)(nil).ListWebhookSubscriptions), ctx, request)
+}
+
+// PreviewBulkUpdateUsers mocks base method.
+func (m *MockBusinessContract) PreviewBulkUpdateUsers(ctx context.Context, request *business.PreviewBulkUpdateUsersRequest) (*business.PreviewBulkUpdateUsersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewBulkUpdateUsers", ctx, request)
+	ret0, _ := ret[0].(*business.PreviewBulkUpdateUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewBulkUpdateUsers indicates an expected call of PreviewBulkUpdateUsers.
+func (mr *MockBusinessContractMockRecorder) PreviewBulkUpdateUsers(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewBulkUpdateUsers", reflect.TypeOf((*MockBusinessContract)(nil).PreviewBulkUpdateUsers), ctx, request)
+}
+
+// PurgeByLabel mocks base method.
+func (m *MockBusinessContract) PurgeByLabel(ctx context.Context, request *business.PurgeByLabelRequest) (*business.PurgeByLabelResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeByLabel", ctx, request)
+	ret0, _ := ret[0].(*business.PurgeByLabelResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeByLabel indicates an expected call of PurgeByLabel.
+func (mr *MockBusinessContractMockRecorder) PurgeByLabel(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeByLabel", reflect.TypeOf((*MockBusinessContract)(nil).PurgeByLabel), ctx, request)
+}
+
+// ReadGroup mocks base method.
+func (m *MockBusinessContract) ReadGroup(ctx context.Context, request *business.ReadGroupRequest) (*business.ReadGroupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadGroup", ctx, request)
+	ret0, _ := ret[0].(*business.ReadGroupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadGroup indicates an expected call of ReadGroup.
+func (mr *MockBusinessContractMockRecorder) ReadGroup(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadGroup", reflect.TypeOf((*MockBusinessContract)(nil).ReadGroup), ctx, request)
+}
+
 // ReadUser mocks base method.
 func (m *MockBusinessContract) ReadUser(ctx context.Context, request *business.ReadUserRequest) (*business.ReadUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +665,291 @@ func (mr *MockBusinessContractMockRecorder) ReadUser(ctx, request interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUser", reflect.TypeOf((*MockBusinessContract)(nil).ReadUser), ctx, request)
 }
 
+// ReadUsers mocks base method.
+func (m *MockBusinessContract) ReadUsers(ctx context.Context, request *business.ReadUsersRequest) (*business.ReadUsersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUsers", ctx, request)
+	ret0, _ := ret[0].(*business.ReadUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUsers indicates an expected call of ReadUsers.
+func (mr *MockBusinessContractMockRecorder) ReadUsers(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUsers", reflect.TypeOf((*MockBusinessContract)(nil).ReadUsers), ctx, request)
+}
+
+// RecordConsent mocks base method.
+func (m *MockBusinessContract) RecordConsent(ctx context.Context, request *business.RecordConsentRequest) (*business.RecordConsentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordConsent", ctx, request)
+	ret0, _ := ret[0].(*business.RecordConsentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordConsent indicates an expected call of RecordConsent.
+func (mr *MockBusinessContractMockRecorder) RecordConsent(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordConsent", reflect.TypeOf((*MockBusinessContract)(nil).RecordConsent), ctx, request)
+}
+
+// RecordLoginAttempt mocks base method.
+func (m *MockBusinessContract) RecordLoginAttempt(ctx context.Context, request *business.RecordLoginAttemptRequest) (*business.RecordLoginAttemptResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLoginAttempt", ctx, request)
+	ret0, _ := ret[0].(*business.RecordLoginAttemptResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordLoginAttempt indicates an expected call of RecordLoginAttempt.
+func (mr *MockBusinessContractMockRecorder) RecordLoginAttempt(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoginAttempt", reflect.TypeOf((*MockBusinessContract)(nil).RecordLoginAttempt), ctx, request)
+}
+
+// RedeemMagicLink mocks base method.
+func (m *MockBusinessContract) RedeemMagicLink(ctx context.Context, request *business.RedeemMagicLinkRequest) (*business.RedeemMagicLinkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemMagicLink", ctx, request)
+	ret0, _ := ret[0].(*business.RedeemMagicLinkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeemMagicLink indicates an expected call of RedeemMagicLink.
+func (mr *MockBusinessContractMockRecorder) RedeemMagicLink(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemMagicLink", reflect.TypeOf((*MockBusinessContract)(nil).RedeemMagicLink), ctx, request)
+}
+
+// RegisterSession mocks base method.
+func (m *MockBusinessContract) RegisterSession(ctx context.Context, request *business.RegisterSessionRequest) (*business.RegisterSessionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterSession", ctx, request)
+	ret0, _ := ret[0].(*business.RegisterSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterSession indicates an expected call of RegisterSession.
+func (mr *MockBusinessContractMockRecorder) RegisterSession(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSession", reflect.TypeOf((*MockBusinessContract)(nil).RegisterSession), ctx, request)
+}
+
+// RemoveMFAMethod mocks base method.
+func (m *MockBusinessContract) RemoveMFAMethod(ctx context.Context, request *business.RemoveMFAMethodRequest) (*business.RemoveMFAMethodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMFAMethod", ctx, request)
+	ret0, _ := ret[0].(*business.RemoveMFAMethodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveMFAMethod indicates an expected call of RemoveMFAMethod.
+func (mr *MockBusinessContractMockRecorder) RemoveMFAMethod(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMFAMethod", reflect.TypeOf((*MockBusinessContract)(nil).RemoveMFAMethod), ctx, request)
+}
+
+// RemoveUserFromGroup mocks base method.
+func (m *MockBusinessContract) RemoveUserFromGroup(ctx context.Context, request *business.RemoveUserFromGroupRequest) (*business.RemoveUserFromGroupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserFromGroup", ctx, request)
+	ret0, _ := ret[0].(*business.RemoveUserFromGroupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveUserFromGroup indicates an expected call of RemoveUserFromGroup.
+func (mr *MockBusinessContractMockRecorder) RemoveUserFromGroup(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromGroup", reflect.TypeOf((*MockBusinessContract)(nil).RemoveUserFromGroup), ctx, request)
+}
+
+// RemoveUserFromTenant mocks base method.
+func (m *MockBusinessContract) RemoveUserFromTenant(ctx context.Context, request *business.RemoveUserFromTenantRequest) (*business.RemoveUserFromTenantResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserFromTenant", ctx, request)
+	ret0, _ := ret[0].(*business.RemoveUserFromTenantResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveUserFromTenant indicates an expected call of RemoveUserFromTenant.
+func (mr *MockBusinessContractMockRecorder) RemoveUserFromTenant(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromTenant", reflect.TypeOf((*MockBusinessContract)(nil).RemoveUserFromTenant), ctx, request)
+}
+
+// RequestEmailChange mocks base method.
+func (m *MockBusinessContract) RequestEmailChange(ctx context.Context, request *business.RequestEmailChangeRequest) (*business.RequestEmailChangeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestEmailChange", ctx, request)
+	ret0, _ := ret[0].(*business.RequestEmailChangeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestEmailChange indicates an expected call of RequestEmailChange.
+func (mr *MockBusinessContractMockRecorder) RequestEmailChange(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmailChange", reflect.TypeOf((*MockBusinessContract)(nil).RequestEmailChange), ctx, request)
+}
+
+// RestoreUser mocks base method.
+func (m *MockBusinessContract) RestoreUser(ctx context.Context, request *business.RestoreUserRequest) (*business.RestoreUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, request)
+	ret0, _ := ret[0].(*business.RestoreUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockBusinessContractMockRecorder) RestoreUser(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockBusinessContract)(nil).RestoreUser), ctx, request)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockBusinessContract) RevokeAPIKey(ctx context.Context, request *business.RevokeAPIKeyRequest) (*business.RevokeAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, request)
+	ret0, _ := ret[0].(*business.RevokeAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockBusinessContractMockRecorder) RevokeAPIKey(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockBusinessContract)(nil).RevokeAPIKey), ctx, request)
+}
+
+// RevokeSession mocks base method.
+func (m *MockBusinessContract) RevokeSession(ctx context.Context, request *business.RevokeSessionRequest) (*business.RevokeSessionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, request)
+	ret0, _ := ret[0].(*business.RevokeSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockBusinessContractMockRecorder) RevokeSession(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockBusinessContract)(nil).RevokeSession), ctx, request)
+}
+
+// Search mocks base method.
+func (m *MockBusinessContract) Search(ctx context.Context, request *business.SearchRequest) (*business.SearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, request)
+	ret0, _ := ret[0].(*business.SearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockBusinessContractMockRecorder) Search(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockBusinessContract)(nil).Search), ctx, request)
+}
+
+// SendVerificationEmail mocks base method.
+func (m *MockBusinessContract) SendVerificationEmail(ctx context.Context, request *business.SendVerificationEmailRequest) (*business.SendVerificationEmailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerificationEmail", ctx, request)
+	ret0, _ := ret[0].(*business.SendVerificationEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendVerificationEmail indicates an expected call of SendVerificationEmail.
+func (mr *MockBusinessContractMockRecorder) SendVerificationEmail(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerificationEmail", reflect.TypeOf((*MockBusinessContract)(nil).SendVerificationEmail), ctx, request)
+}
+
+// SetPassword mocks base method.
+func (m *MockBusinessContract) SetPassword(ctx context.Context, request *business.SetPasswordRequest) (*business.SetPasswordResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", ctx, request)
+	ret0, _ := ret[0].(*business.SetPasswordResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockBusinessContractMockRecorder) SetPassword(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockBusinessContract)(nil).SetPassword), ctx, request)
+}
+
+// SetUserAvatar mocks base method.
+func (m *MockBusinessContract) SetUserAvatar(ctx context.Context, request *business.SetUserAvatarRequest) (*business.SetUserAvatarResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserAvatar", ctx, request)
+	ret0, _ := ret[0].(*business.SetUserAvatarResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserAvatar indicates an expected call of SetUserAvatar.
+func (mr *MockBusinessContractMockRecorder) SetUserAvatar(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserAvatar", reflect.TypeOf((*MockBusinessContract)(nil).SetUserAvatar), ctx, request)
+}
+
+// StreamSearch mocks base method.
+func (m *MockBusinessContract) StreamSearch(ctx context.Context, request *business.StreamSearchRequest) (*business.StreamSearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamSearch", ctx, request)
+	ret0, _ := ret[0].(*business.StreamSearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamSearch indicates an expected call of StreamSearch.
+func (mr *MockBusinessContractMockRecorder) StreamSearch(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSearch", reflect.TypeOf((*MockBusinessContract)(nil).StreamSearch), ctx, request)
+}
+
+// UnlockUser mocks base method.
+func (m *MockBusinessContract) UnlockUser(ctx context.Context, request *business.UnlockUserRequest) (*business.UnlockUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, request)
+	ret0, _ := ret[0].(*business.UnlockUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockBusinessContractMockRecorder) UnlockUser(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockBusinessContract)(nil).UnlockUser), ctx, request)
+}
+
+// UpdateGroup mocks base method.
+func (m *MockBusinessContract) UpdateGroup(ctx context.Context, request *business.UpdateGroupRequest) (*business.UpdateGroupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroup", ctx, request)
+	ret0, _ := ret[0].(*business.UpdateGroupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGroup indicates an expected call of UpdateGroup.
+func (mr *MockBusinessContractMockRecorder) UpdateGroup(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockBusinessContract)(nil).UpdateGroup), ctx, request)
+}
+
 // UpdateUser mocks base method.
 func (m *MockBusinessContract) UpdateUser(ctx context.Context, request *business.UpdateUserRequest) (*business.UpdateUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +964,63 @@ func (mr *MockBusinessContractMockRecorder) UpdateUser(ctx, request interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockBusinessContract)(nil).UpdateUser), ctx, request)
 }
+
+// UpdateUserPreferences mocks base method.
+func (m *MockBusinessContract) UpdateUserPreferences(ctx context.Context, request *business.UpdateUserPreferencesRequest) (*business.UpdateUserPreferencesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPreferences", ctx, request)
+	ret0, _ := ret[0].(*business.UpdateUserPreferencesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserPreferences indicates an expected call of UpdateUserPreferences.
+func (mr *MockBusinessContractMockRecorder) UpdateUserPreferences(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPreferences", reflect.TypeOf((*MockBusinessContract)(nil).UpdateUserPreferences), ctx, request)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockBusinessContract) VerifyEmail(ctx context.Context, request *business.VerifyEmailRequest) (*business.VerifyEmailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, request)
+	ret0, _ := ret[0].(*business.VerifyEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockBusinessContractMockRecorder) VerifyEmail(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockBusinessContract)(nil).VerifyEmail), ctx, request)
+}
+
+// VerifyPassword mocks base method.
+func (m *MockBusinessContract) VerifyPassword(ctx context.Context, request *business.VerifyPasswordRequest) (*business.VerifyPasswordResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPassword", ctx, request)
+	ret0, _ := ret[0].(*business.VerifyPasswordResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyPassword indicates an expected call of VerifyPassword.
+func (mr *MockBusinessContractMockRecorder) VerifyPassword(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPassword", reflect.TypeOf((*MockBusinessContract)(nil).VerifyPassword), ctx, request)
+}
+
+// WatchUsers mocks base method.
+func (m *MockBusinessContract) WatchUsers(ctx context.Context, request *business.WatchUsersRequest) (*business.WatchUsersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchUsers", ctx, request)
+	ret0, _ := ret[0].(*business.WatchUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchUsers indicates an expected call of WatchUsers.
+func (mr *MockBusinessContractMockRecorder) WatchUsers(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchUsers", reflect.TypeOf((*MockBusinessContract)(nil).WatchUsers), ctx, request)
+}
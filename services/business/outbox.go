@@ -0,0 +1,85 @@
+// Package business implements different business services required by the user service
+package business
+
+import "context"
+
+// DefaultPendingEventsListLimit is the maximum number of the pending events listed if the request does not limit them
+const DefaultPendingEventsListLimit = 100
+
+// GetOutboxLag reads how many user lifecycle events the message broker has not confirmed receiving yet and for
+// how long, so the consumers being stale can be told apart from a publisher backlog
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the publisher lag
+// Returns either the publisher lag or error if something goes wrong.
+func (service *businessService) GetOutboxLag(
+	ctx context.Context,
+	request *GetOutboxLagRequest) (*GetOutboxLagResponse, error) {
+	outboxLag, err := service.eventingService.GetOutboxLag(ctx)
+	if err != nil {
+		return &GetOutboxLagResponse{
+			Err: err,
+		}, nil
+	}
+
+	response := &GetOutboxLagResponse{
+		OutboxLag: *outboxLag,
+	}
+
+	if outboxLag.OldestPendingEventAt != nil {
+		response.OldestPendingEventAge = service.clockService.Now().Sub(*outboxLag.OldestPendingEventAt)
+	}
+
+	return response, nil
+}
+
+// ListPendingEvents lists the user lifecycle events the message broker has not confirmed receiving yet
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the maximum number of the events to list
+// Returns either the pending events, the oldest first, or error if something goes wrong.
+func (service *businessService) ListPendingEvents(
+	ctx context.Context,
+	request *ListPendingEventsRequest) (*ListPendingEventsResponse, error) {
+	limit := request.Limit
+	if limit <= 0 {
+		limit = DefaultPendingEventsListLimit
+	}
+
+	pendingEvents, err := service.eventingService.ListPendingEvents(ctx, limit)
+	if err != nil {
+		return &ListPendingEventsResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &ListPendingEventsResponse{
+		PendingEvents: pendingEvents,
+	}, nil
+}
+
+// ForceFlush sends the buffered user lifecycle events and waits for the message broker to confirm receiving them
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to flush the pending events
+// Returns either the number of the confirmed events or error if something goes wrong.
+func (service *businessService) ForceFlush(
+	ctx context.Context,
+	request *ForceFlushRequest) (*ForceFlushResponse, error) {
+	flushedCount, err := service.eventingService.Flush(ctx)
+	if err != nil {
+		return &ForceFlushResponse{
+			Err: err,
+		}, nil
+	}
+
+	// The events published while flushing are still pending
+	outboxLag, err := service.eventingService.GetOutboxLag(ctx)
+	if err != nil {
+		return &ForceFlushResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &ForceFlushResponse{
+		FlushedCount:  flushedCount,
+		PendingEvents: outboxLag.PendingEvents,
+	}, nil
+}
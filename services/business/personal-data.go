@@ -0,0 +1,337 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/eventing"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// erasureFeature is the name GetEnabledFeatures reports the optional erasure with
+const erasureFeature = "erasure"
+
+// personalDataArchiveFileName is the name the personal data archive is suggested to be saved with
+const personalDataArchiveFileName = "personal-data.json"
+
+// maxExportedAuditRecords is the maximum number of the audit records made on and made by the user that are
+// exported each
+const maxExportedAuditRecords = 10000
+
+// personalDataArchive is everything stored about a user, as exported to the user
+type personalDataArchive struct {
+	Email        string               `json:"email"`
+	ExportedAt   time.Time            `json:"exportedAt"`
+	Profile      models.User          `json:"profile"`
+	Preferences  map[string]string    `json:"preferences"`
+	APIKeys      []models.APIKey      `json:"apiKeys"`
+	Consents     []models.Consent     `json:"consents"`
+	AuditRecords []models.AuditRecord `json:"auditRecords"`
+}
+
+// erasureRequest contains everything the confirmation token of a requested erasure is bound to
+type erasureRequest struct {
+	Email         string
+	ConfirmableAt int64
+	ExpiresAt     int64
+}
+
+// ExportPersonalData assembles everything stored about an existing user, the profile, the preferences, the API keys,
+// the consents and the audit records made on and made by the user, into a JSON archive the user can download. The hashes of the
+// secrets, e.g. the pending email verification token, the recovery codes and the API keys, are left out as they
+// tell nothing about the user but would weaken the credentials if the archive leaked.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to export the personal data of an existing user
+// Returns either the JSON archive or error if something goes wrong.
+func (service *businessService) ExportPersonalData(
+	ctx context.Context,
+	request *ExportPersonalDataRequest) (*ExportPersonalDataResponse, error) {
+	readUserResponse, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	})
+	if err != nil {
+		return &ExportPersonalDataResponse{Err: err}, nil
+	}
+
+	readUserPreferencesResponse, err := service.repositoryService.ReadUserPreferences(ctx, &repository.ReadUserPreferencesRequest{
+		Email: request.Email,
+	})
+	if err != nil {
+		return &ExportPersonalDataResponse{Err: err}, nil
+	}
+
+	apiKeys, err := service.apiKeyService.ListAPIKeys(ctx, readUserResponse.User.ID)
+	if err != nil {
+		return &ExportPersonalDataResponse{Err: err}, nil
+	}
+
+	for index := range apiKeys {
+		apiKeys[index].KeyHash = ""
+	}
+
+	consents, err := service.consentService.ListConsents(ctx, request.Email)
+	if err != nil {
+		return &ExportPersonalDataResponse{Err: err}, nil
+	}
+
+	auditRecords, err := service.listPersonalAuditRecords(ctx, request.Email)
+	if err != nil {
+		return &ExportPersonalDataResponse{Err: err}, nil
+	}
+
+	archive, err := json.MarshalIndent(personalDataArchive{
+		Email:        request.Email,
+		ExportedAt:   service.clockService.Now(),
+		Profile:      withoutSecretHashes(readUserResponse.User),
+		Preferences:  readUserPreferencesResponse.Preferences,
+		APIKeys:      apiKeys,
+		Consents:     consents,
+		AuditRecords: auditRecords,
+	}, "", "  ")
+	if err != nil {
+		return &ExportPersonalDataResponse{
+			Err: commonErrors.NewUnknownErrorWithError("failed to encode the personal data archive", err),
+		}, nil
+	}
+
+	return &ExportPersonalDataResponse{
+		Archive:  archive,
+		FileName: personalDataArchiveFileName,
+	}, nil
+}
+
+// EraseUser erases an existing user on their request. The erasure is requested first, which returns the token that
+// confirms it once the grace period ends, so the user can change their mind by not confirming it. The token is
+// stateless, it is only valid for the user it is returned for until the confirmation window passes. Confirming the
+// erasure deletes the password, revokes the API keys, deletes the avatar and the consents of the user and removes the
+// user from the groups, anonymizes the audit records made on and made by the user, then permanently deletes the user
+// even if soft delete is enabled. Every step can be repeated, so a failed erasure can be confirmed again with the same
+// token.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to request or confirm the erasure of an existing user
+// Returns either the confirmation token or whether the user is erased, or error if something goes wrong.
+func (service *businessService) EraseUser(
+	ctx context.Context,
+	request *EraseUserRequest) (*EraseUserResponse, error) {
+	secret, err := service.configurationService.GetErasureTokenSecret()
+	if err != nil {
+		return &EraseUserResponse{Err: err}, nil
+	}
+
+	if secret == "" {
+		return &EraseUserResponse{Err: NewFeatureDisabledError(erasureFeature)}, nil
+	}
+
+	gracePeriod, err := service.configurationService.GetErasureGracePeriod()
+	if err != nil {
+		return &EraseUserResponse{Err: err}, nil
+	}
+
+	confirmationWindow, err := service.configurationService.GetErasureConfirmationWindow()
+	if err != nil {
+		return &EraseUserResponse{Err: err}, nil
+	}
+
+	readUserResponse, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	})
+	if err != nil {
+		return &EraseUserResponse{Err: err}, nil
+	}
+
+	now := service.clockService.Now()
+
+	if request.ConfirmationToken == "" {
+		confirmableAt := now.Add(gracePeriod).Truncate(time.Second)
+		expiresAt := confirmableAt.Add(confirmationWindow)
+		token, err := signErasureRequest(secret, erasureRequest{
+			Email:         request.Email,
+			ConfirmableAt: confirmableAt.Unix(),
+			ExpiresAt:     expiresAt.Unix(),
+		})
+		if err != nil {
+			return &EraseUserResponse{Err: err}, nil
+		}
+
+		return &EraseUserResponse{
+			ConfirmationToken: token,
+			ConfirmableAt:     confirmableAt,
+			ExpiresAt:         expiresAt,
+		}, nil
+	}
+
+	tokenRequest, err := parseErasureToken(request.ConfirmationToken, now)
+	if err != nil {
+		return &EraseUserResponse{Err: err}, nil
+	}
+
+	tokenRequest.Email = request.Email
+	expectedToken, err := signErasureRequest(secret, tokenRequest)
+	if err != nil {
+		return &EraseUserResponse{Err: err}, nil
+	}
+
+	if !hmac.Equal([]byte(expectedToken), []byte(request.ConfirmationToken)) {
+		return &EraseUserResponse{
+			Err: commonErrors.NewArgumentError("confirmationToken", "confirmationToken is not returned by requesting the erasure of the user"),
+		}, nil
+	}
+
+	if err = service.eraseUser(ctx, request.Email, readUserResponse.User); err != nil {
+		return &EraseUserResponse{Err: err}, nil
+	}
+
+	return &EraseUserResponse{
+		Erased: true,
+	}, nil
+}
+
+func (service *businessService) eraseUser(
+	ctx context.Context,
+	email string,
+	user models.User) error {
+	if err := service.purgeUserData(ctx, email, user); err != nil {
+		return err
+	}
+
+	// The erasure is recorded before the audit records are anonymized, so the record of the erasure itself is
+	// anonymized too
+	service.logIgnoredError(ctx, "failed to record the operation in the audit log", service.auditService.RecordOperation(ctx, models.AuditOperationErase, email, nil, nil))
+
+	if _, err := service.auditService.AnonymizeAuditRecords(ctx, email); err != nil {
+		return err
+	}
+
+	if _, err := service.repositoryService.DeleteUser(ctx, &repository.DeleteUserRequest{
+		Email:      email,
+		SoftDelete: false,
+	}); err != nil {
+		return err
+	}
+
+	// The other services erase what they store about the user when they receive the event
+	service.logIgnoredError(ctx, "failed to publish the user deleted event", service.eventingService.PublishUserDeleted(ctx, &eventing.UserDeletedEvent{
+		Email:       email,
+		SoftDeleted: false,
+	}))
+
+	return nil
+}
+
+// listPersonalAuditRecords lists the audit records made on and made by the user, the latest first
+func (service *businessService) listPersonalAuditRecords(
+	ctx context.Context,
+	email string) ([]models.AuditRecord, error) {
+	madeOnRecords, err := service.auditService.ListAuditRecords(ctx, &models.AuditRecordFilter{
+		Email: email,
+		Limit: maxExportedAuditRecords,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	madeByRecords, err := service.auditService.ListAuditRecords(ctx, &models.AuditRecordFilter{
+		ActorEmail: email,
+		Limit:      maxExportedAuditRecords,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The operations the user made on themselves are both made on and made by the user
+	recordIDs := map[string]bool{}
+	records := []models.AuditRecord{}
+	for _, record := range append(madeOnRecords, madeByRecords...) {
+		if recordIDs[record.RecordID] {
+			continue
+		}
+
+		recordIDs[record.RecordID] = true
+		records = append(records, record)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CreatedAt.After(records[j].CreatedAt)
+	})
+
+	return records, nil
+}
+
+// withoutSecretHashes returns the user without the hashes of its secrets
+func withoutSecretHashes(user models.User) models.User {
+	user.EmailVerificationTokenHash = ""
+
+	// The methods are copied so the user read from the repository is left as it is
+	if len(user.MFAMethods) > 0 {
+		mfaMethods := make([]models.MFAMethod, 0, len(user.MFAMethods))
+		for _, method := range user.MFAMethods {
+			method.RecoveryCodeHashes = nil
+			mfaMethods = append(mfaMethods, method)
+		}
+
+		user.MFAMethods = mfaMethods
+	}
+
+	return user
+}
+
+// signErasureRequest returns the confirmation token of the requested erasure, made of the time the erasure can be
+// confirmed from, the time the token expires at and the signature of the request
+func signErasureRequest(secret string, request erasureRequest) (string, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return "", commonErrors.NewUnknownErrorWithError("failed to encode the erasure request", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(payload)
+
+	return strconv.FormatInt(request.ConfirmableAt, 10) + "." +
+		strconv.FormatInt(request.ExpiresAt, 10) + "." +
+		base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// parseErasureToken returns the times the confirmation token is bound to, and fails if the grace period has not
+// ended yet or the token is expired
+func parseErasureToken(token string, now time.Time) (erasureRequest, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return erasureRequest{}, commonErrors.NewArgumentError("confirmationToken", "confirmationToken is malformed")
+	}
+
+	confirmableAt, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return erasureRequest{}, commonErrors.NewArgumentError("confirmationToken", "confirmationToken is malformed")
+	}
+
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return erasureRequest{}, commonErrors.NewArgumentError("confirmationToken", "confirmationToken is malformed")
+	}
+
+	if now.Unix() < confirmableAt {
+		return erasureRequest{}, commonErrors.NewArgumentError(
+			"confirmationToken",
+			fmt.Sprintf("the grace period of the erasure ends at %s, confirm the erasure after it", time.Unix(confirmableAt, 0).UTC().Format(time.RFC3339)))
+	}
+
+	if now.Unix() >= expiresAt {
+		return erasureRequest{}, commonErrors.NewArgumentError("confirmationToken", "confirmationToken is expired, request the erasure again")
+	}
+
+	return erasureRequest{
+		ConfirmableAt: confirmableAt,
+		ExpiresAt:     expiresAt,
+	}, nil
+}
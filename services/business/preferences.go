@@ -0,0 +1,128 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/repository"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// languageTagPattern matches the BCP 47 language tags, e.g. en, en-AU or zh-Hant-TW
+var languageTagPattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// preferenceRules contains the rules the value of each known preference must follow. The preferences not in the
+// list are rejected, so the clients can not store arbitrary data in the user preferences. The preferences are
+// removed through the removed keys rather than set to an empty value, so all the values are required.
+var preferenceRules = map[string][]validation.Rule{
+	models.PreferenceTheme:                {validation.Required, validation.In("light", "dark", "system")},
+	models.PreferenceLanguage:             {validation.Required, validation.Length(2, 35), validation.Match(languageTagPattern)},
+	models.PreferenceEmailNotifications:   {validation.Required, validation.In("true", "false")},
+	models.PreferencePushNotifications:    {validation.Required, validation.In("true", "false")},
+	models.PreferenceWebhookNotifications: {validation.Required, validation.In("true", "false")},
+}
+
+// GetUserPreferences reads the preferences of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the preferences of an existing user
+// Returns either the preferences of the user or error if something goes wrong.
+func (service *businessService) GetUserPreferences(
+	ctx context.Context,
+	request *GetUserPreferencesRequest) (*GetUserPreferencesResponse, error) {
+	email, err := service.resolveEmail(ctx, request.Email, request.UserID, false)
+	if err != nil {
+		return &GetUserPreferencesResponse{
+			Err: err,
+		}, nil
+	}
+
+	response, err := service.repositoryService.ReadUserPreferences(ctx, &repository.ReadUserPreferencesRequest{
+		Email: email,
+	})
+
+	if err != nil {
+		return &GetUserPreferencesResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &GetUserPreferencesResponse{
+		Preferences: response.Preferences,
+	}, nil
+}
+
+// UpdateUserPreferences merges the preferences into the preferences of an existing user, the preferences not in
+// the request are left as they are
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the preferences to set and the keys of the preferences to remove
+// Returns either the preferences of the user after they are merged or error if something goes wrong.
+func (service *businessService) UpdateUserPreferences(
+	ctx context.Context,
+	request *UpdateUserPreferencesRequest) (*UpdateUserPreferencesResponse, error) {
+	email, err := service.resolveEmail(ctx, request.Email, request.UserID, false)
+	if err != nil {
+		return &UpdateUserPreferencesResponse{
+			Err: err,
+		}, nil
+	}
+
+	if err = service.requireConsent(ctx, "UpdateUserPreferences", email); err != nil {
+		return &UpdateUserPreferencesResponse{
+			Err: err,
+		}, nil
+	}
+
+	response, err := service.repositoryService.UpdateUserPreferences(ctx, &repository.UpdateUserPreferencesRequest{
+		Email:       email,
+		Preferences: request.Preferences,
+		RemovedKeys: request.RemovedKeys,
+	})
+
+	if err != nil {
+		return &UpdateUserPreferencesResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &UpdateUserPreferencesResponse{
+		Preferences: response.Preferences,
+	}, nil
+}
+
+func arePreferencesValid(value interface{}) error {
+	preferences, _ := value.(map[string]string)
+
+	// The keys are sorted so the same request always fails with the same error
+	keys := make([]string, 0, len(preferences))
+	for key := range preferences {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := isPreferenceKey(key); err != nil {
+			return err
+		}
+
+		if err := validation.Validate(preferences[key], preferenceRules[key]...); err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+	}
+
+	return nil
+}
+
+func isPreferenceKey(value interface{}) error {
+	if key, ok := value.(string); ok {
+		if _, ok = preferenceRules[key]; !ok {
+			return fmt.Errorf("%s is not a known preference", key)
+		}
+	}
+
+	return nil
+}
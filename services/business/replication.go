@@ -0,0 +1,24 @@
+// Package business implements different business services required by the user service
+package business
+
+import "context"
+
+// GetReplicationStatus reads how far behind the primary database the standby database is, so the readiness to fail
+// over to the standby database can be measured
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the replication status
+// Returns either the replication status or error if something goes wrong.
+func (service *businessService) GetReplicationStatus(
+	ctx context.Context,
+	request *GetReplicationStatusRequest) (*GetReplicationStatusResponse, error) {
+	replicationStatus, err := service.replicationService.GetReplicationStatus(ctx)
+	if err != nil {
+		return &GetReplicationStatusResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &GetReplicationStatusResponse{
+		ReplicationStatus: *replicationStatus,
+	}, nil
+}
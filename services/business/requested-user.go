@@ -0,0 +1,90 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// resolveEmail returns the email address of the user the request names, either by its email address or by its stable
+// ID. The user is only read if it is named by its ID.
+// ctx: Mandatory The reference to the context
+// email: Optional. The email address the request names the user by
+// userID: Optional. The stable ID the request names the user by, only used if the email address is empty
+// includeDeleted: Mandatory. Whether the soft deleted user named by its ID is resolved too, e.g. to restore it
+// Returns either the email address of the user or error if something goes wrong. NotFoundError is returned if the
+// user is not found, or if it is not the user of the caller while the caller is only allowed to access its own user.
+func (service *businessService) resolveEmail(
+	ctx context.Context,
+	email string,
+	userID string,
+	includeDeleted bool) (string, error) {
+	if email != "" {
+		return email, nil
+	}
+
+	// Only the fields every read returns are requested, as only the email address is needed
+	response, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		UserID:         userID,
+		IncludeDeleted: includeDeleted,
+		Fields:         []string{"createdAt"},
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	if err = verifyOwnership(ctx, response.Email); err != nil {
+		return "", err
+	}
+
+	return response.Email, nil
+}
+
+// readRequestedUser reads the user the request names, either by its email address or by its stable ID
+// ctx: Mandatory The reference to the context
+// email: Optional. The email address the request names the user by
+// userID: Optional. The stable ID the request names the user by, only used if the email address is empty
+// Returns either the email address of the user and the user or error if something goes wrong. NotFoundError is
+// returned if the user is not found, or if it is not the user of the caller while the caller is only allowed to access
+// its own user.
+func (service *businessService) readRequestedUser(
+	ctx context.Context,
+	email string,
+	userID string) (string, models.User, error) {
+	response, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email:  email,
+		UserID: userID,
+	})
+
+	if err != nil {
+		return "", models.User{}, err
+	}
+
+	if email != "" {
+		return email, response.User, nil
+	}
+
+	if err = verifyOwnership(ctx, response.Email); err != nil {
+		return "", models.User{}, err
+	}
+
+	return response.Email, response.User, nil
+}
+
+// verifyOwnership verifies the user named by its ID is the user of the caller, if the caller is only allowed to access
+// its own user. The ownership could only be verified once the user is read, so the users of the others are reported
+// as not found and the callers can not tell which IDs exist.
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user named by its ID
+// Returns NotFoundError if the caller is not allowed to access the user
+func verifyOwnership(ctx context.Context, email string) error {
+	if parsedToken, ok := ctx.Value(models.ContextKeyParsedToken).(models.ParsedToken); ok && parsedToken.OwnerOnly && parsedToken.Email != email {
+		return commonErrors.NewNotFoundError()
+	}
+
+	return nil
+}
@@ -3,29 +3,277 @@ package business
 
 import (
 	"context"
+	"strings"
 
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/apikey"
+	"github.com/decentralized-cloud/user/services/audit"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/consent"
+	"github.com/decentralized-cloud/user/services/correlation"
+	"github.com/decentralized-cloud/user/services/credential"
+	"github.com/decentralized-cloud/user/services/eventing"
+	"github.com/decentralized-cloud/user/services/group"
+	"github.com/decentralized-cloud/user/services/invitation"
+	"github.com/decentralized-cloud/user/services/magiclink"
+	"github.com/decentralized-cloud/user/services/mailer"
+	"github.com/decentralized-cloud/user/services/objectstorage"
+	"github.com/decentralized-cloud/user/services/replication"
 	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/saga"
+	"github.com/decentralized-cloud/user/services/session"
+	"github.com/decentralized-cloud/user/services/watch"
+	"github.com/decentralized-cloud/user/services/webhook"
 	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
 )
 
 type businessService struct {
-	repositoryService repository.RepositoryContract
+	logger                     *zap.Logger
+	configurationService       configuration.ConfigurationContract
+	repositoryService          repository.RepositoryContract
+	eventingService            eventing.EventingContract
+	sagaService                saga.SagaContract
+	auditService               audit.AuditContract
+	replicationService         replication.ReplicationContract
+	clockService               clock.ClockContract
+	mailerService              mailer.MailerContract
+	credentialService          credential.CredentialContract
+	watchService               watch.WatchContract
+	apiKeyService              apikey.APIKeyContract
+	objectStorageService       objectstorage.ObjectStorageContract
+	consentService             consent.ConsentContract
+	groupService               group.GroupContract
+	invitationService          invitation.InvitationContract
+	sessionService             session.SessionContract
+	webhookService             webhook.WebhookContract
+	magicLinkService           magiclink.MagicLinkContract
+	softDeleteEnabled          bool
+	passwordCredentialsEnabled bool
+	avatarsEnabled             bool
+	consentRequiredOperations  map[string]bool
+	validationPolicy           validationPolicy
+	sortableFields             []string
+	magicLinksEnabled          bool
+}
+
+// Dependencies contains the services the business service is created with
+type Dependencies struct {
+	// Logger is mandatory and logs the operations of the service
+	Logger *zap.Logger
+
+	// ConfigurationService is mandatory and provides required configurations
+	ConfigurationService configuration.ConfigurationContract
+
+	// RepositoryService is mandatory and persists the user related data
+	RepositoryService repository.RepositoryContract
+
+	// EventingService is mandatory and publishes the user lifecycle events
+	EventingService eventing.EventingContract
+
+	// SagaService is mandatory and executes the operations spanning multiple services
+	SagaService saga.SagaContract
+
+	// AuditService is mandatory and records the mutating operations in the audit log
+	AuditService audit.AuditContract
+
+	// ReplicationService is mandatory and reports how far behind the primary database the standby database is
+	ReplicationService replication.ReplicationContract
+
+	// ClockService is mandatory and provides the current time
+	ClockService clock.ClockContract
+
+	// MailerService is mandatory and sends the verification emails to the users
+	MailerService mailer.MailerContract
+
+	// CredentialService is mandatory and hashes, persists and verifies the passwords of the users
+	CredentialService credential.CredentialContract
+
+	// WatchService is mandatory and notifies the watchers of the user changes
+	WatchService watch.WatchContract
+
+	// APIKeyService is mandatory and mints, persists and resolves the API keys of the users
+	APIKeyService apikey.APIKeyContract
+
+	// ObjectStorageService is mandatory and stores the avatar images of the users
+	ObjectStorageService objectstorage.ObjectStorageContract
+
+	// ConsentService is mandatory and records the policy versions the users accepted
+	ConsentService consent.ConsentContract
+
+	// GroupService is mandatory and manages the groups and their members
+	GroupService group.GroupContract
+
+	// InvitationService is mandatory and issues and accepts the invitations of the new users
+	InvitationService invitation.InvitationContract
+
+	// SessionService is mandatory and registers the sessions of the users
+	SessionService session.SessionContract
+
+	// WebhookService is mandatory and manages the webhook subscriptions of the users
+	WebhookService webhook.WebhookContract
+
+	// MagicLinkService is mandatory and issues and redeems the magic links the users sign in with
+	MagicLinkService magiclink.MagicLinkContract
 }
 
 // NewBusinessService creates new instance of the BusinessService, setting up all dependencies and returns the instance
-// repositoryService: Mandatory. Reference to the repository service that can persist the user related data
+// dependencies: Mandatory. The services the business service is created with
 // Returns the new service or error if something goes wrong
-func NewBusinessService(
-	repositoryService repository.RepositoryContract) (BusinessContract, error) {
-	if repositoryService == nil {
-		return nil, commonErrors.NewArgumentNilError("repositoryService", "repositoryService is required")
+func NewBusinessService(dependencies Dependencies) (BusinessContract, error) {
+	if err := validateDependencies(dependencies); err != nil {
+		return nil, err
+	}
+
+	configurationService := dependencies.ConfigurationService
+
+	softDeleteEnabled, err := configurationService.GetSoftDeleteEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	passwordCredentialsEnabled, err := configurationService.GetPasswordCredentialsEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	avatarsEnabled, err := configurationService.GetAvatarsEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	consentRequiredOperations, err := getConsentRequiredOperations(configurationService)
+	if err != nil {
+		return nil, err
+	}
+
+	validationPolicy, err := newValidationPolicy(configurationService)
+	if err != nil {
+		return nil, err
+	}
+
+	sortableFields, err := getSortableFields(configurationService)
+	if err != nil {
+		return nil, err
+	}
+
+	magicLinksEnabled, err := configurationService.GetMagicLinksEnabled()
+	if err != nil {
+		return nil, err
 	}
 
 	return &businessService{
-		repositoryService: repositoryService,
+		logger:                     dependencies.Logger,
+		configurationService:       configurationService,
+		repositoryService:          dependencies.RepositoryService,
+		eventingService:            dependencies.EventingService,
+		sagaService:                dependencies.SagaService,
+		auditService:               dependencies.AuditService,
+		replicationService:         dependencies.ReplicationService,
+		clockService:               dependencies.ClockService,
+		mailerService:              dependencies.MailerService,
+		credentialService:          dependencies.CredentialService,
+		watchService:               dependencies.WatchService,
+		apiKeyService:              dependencies.APIKeyService,
+		objectStorageService:       dependencies.ObjectStorageService,
+		consentService:             dependencies.ConsentService,
+		groupService:               dependencies.GroupService,
+		invitationService:          dependencies.InvitationService,
+		sessionService:             dependencies.SessionService,
+		webhookService:             dependencies.WebhookService,
+		magicLinkService:           dependencies.MagicLinkService,
+		softDeleteEnabled:          softDeleteEnabled,
+		passwordCredentialsEnabled: passwordCredentialsEnabled,
+		avatarsEnabled:             avatarsEnabled,
+		consentRequiredOperations:  consentRequiredOperations,
+		validationPolicy:           validationPolicy,
+		sortableFields:             sortableFields,
+		magicLinksEnabled:          magicLinksEnabled,
 	}, nil
 }
 
+// validateDependencies returns ArgumentNilError if any of the mandatory dependencies is not provided
+func validateDependencies(dependencies Dependencies) error {
+	if dependencies.Logger == nil {
+		return commonErrors.NewArgumentNilError("Logger", "Logger is required")
+	}
+
+	if dependencies.ConfigurationService == nil {
+		return commonErrors.NewArgumentNilError("ConfigurationService", "ConfigurationService is required")
+	}
+
+	if dependencies.RepositoryService == nil {
+		return commonErrors.NewArgumentNilError("RepositoryService", "RepositoryService is required")
+	}
+
+	if dependencies.EventingService == nil {
+		return commonErrors.NewArgumentNilError("EventingService", "EventingService is required")
+	}
+
+	if dependencies.SagaService == nil {
+		return commonErrors.NewArgumentNilError("SagaService", "SagaService is required")
+	}
+
+	if dependencies.AuditService == nil {
+		return commonErrors.NewArgumentNilError("AuditService", "AuditService is required")
+	}
+
+	if dependencies.ReplicationService == nil {
+		return commonErrors.NewArgumentNilError("ReplicationService", "ReplicationService is required")
+	}
+
+	if dependencies.ClockService == nil {
+		return commonErrors.NewArgumentNilError("ClockService", "ClockService is required")
+	}
+
+	if dependencies.MailerService == nil {
+		return commonErrors.NewArgumentNilError("MailerService", "MailerService is required")
+	}
+
+	if dependencies.CredentialService == nil {
+		return commonErrors.NewArgumentNilError("CredentialService", "CredentialService is required")
+	}
+
+	if dependencies.WatchService == nil {
+		return commonErrors.NewArgumentNilError("WatchService", "WatchService is required")
+	}
+
+	if dependencies.APIKeyService == nil {
+		return commonErrors.NewArgumentNilError("APIKeyService", "APIKeyService is required")
+	}
+
+	if dependencies.ObjectStorageService == nil {
+		return commonErrors.NewArgumentNilError("ObjectStorageService", "ObjectStorageService is required")
+	}
+
+	if dependencies.ConsentService == nil {
+		return commonErrors.NewArgumentNilError("ConsentService", "ConsentService is required")
+	}
+
+	if dependencies.GroupService == nil {
+		return commonErrors.NewArgumentNilError("GroupService", "GroupService is required")
+	}
+
+	if dependencies.InvitationService == nil {
+		return commonErrors.NewArgumentNilError("InvitationService", "InvitationService is required")
+	}
+
+	if dependencies.SessionService == nil {
+		return commonErrors.NewArgumentNilError("SessionService", "SessionService is required")
+	}
+
+	if dependencies.WebhookService == nil {
+		return commonErrors.NewArgumentNilError("WebhookService", "WebhookService is required")
+	}
+
+	if dependencies.MagicLinkService == nil {
+		return commonErrors.NewArgumentNilError("MagicLinkService", "MagicLinkService is required")
+	}
+
+	return nil
+}
+
 // CreateUser creates a new user.
 // ctx: Mandatory The reference to the context
 // request: Mandatory. The request to create a new user
@@ -33,9 +281,15 @@ func NewBusinessService(
 func (service *businessService) CreateUser(
 	ctx context.Context,
 	request *CreateUserRequest) (*CreateUserResponse, error) {
+	if err := service.validationPolicy.validateNewUser(request.Email, request.User); err != nil {
+		return &CreateUserResponse{
+			Err: NewRequestValidationError(err),
+		}, nil
+	}
+
 	response, err := service.repositoryService.CreateUser(ctx, &repository.CreateUserRequest{
 		Email: request.Email,
-		User:  request.User,
+		User:  getWritableUser(request.User),
 	})
 
 	if err != nil {
@@ -44,6 +298,22 @@ func (service *businessService) CreateUser(
 		}, nil
 	}
 
+	// A password left behind by a deleted user with the same email address must not let anyone sign in as the new
+	// user. The new user has no password yet, so failing to delete it is no worse than not deleting it.
+	if service.passwordCredentialsEnabled {
+		service.logIgnoredError(ctx, "failed to delete the password left behind by a deleted user", service.credentialService.DeletePassword(ctx, request.Email))
+	}
+
+	// The user is already persisted at this point, so failing to record the operation or to publish the event
+	// must not fail the operation, the failure is only logged.
+	service.logIgnoredError(ctx, "failed to record the operation in the audit log", service.auditService.RecordOperation(ctx, models.AuditOperationCreate, request.Email, nil, &response.User))
+
+	service.logIgnoredError(ctx, "failed to publish the user created event", service.eventingService.PublishUserCreated(ctx, &eventing.UserCreatedEvent{
+		Email:  request.Email,
+		User:   response.User,
+		Cursor: response.Cursor,
+	}))
+
 	return &CreateUserResponse{
 		User:   response.User,
 		Cursor: response.Cursor,
@@ -58,7 +328,9 @@ func (service *businessService) ReadUser(
 	ctx context.Context,
 	request *ReadUserRequest) (*ReadUserResponse, error) {
 	response, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
-		Email: request.Email,
+		Email:  request.Email,
+		UserID: request.UserID,
+		Fields: request.Fields,
 	})
 
 	if err != nil {
@@ -67,8 +339,85 @@ func (service *businessService) ReadUser(
 		}, nil
 	}
 
+	// Not every repository leaves the fields that are not requested out, so the user is projected either way
 	return &ReadUserResponse{
-		User: response.User,
+		Email: response.Email,
+		User:  models.ProjectUser(response.User, request.Fields),
+	}, nil
+}
+
+// ReadUsers reads the existing users with the given email addresses or IDs at once, with a single query to the
+// repository
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the existing users
+// Returns either the users found along with the email addresses and the IDs of the users not found or error if
+// something goes wrong.
+func (service *businessService) ReadUsers(
+	ctx context.Context,
+	request *ReadUsersRequest) (*ReadUsersResponse, error) {
+	// The email addresses and the IDs requested more than once are only returned once
+	emails := uniqueValues(request.Emails)
+	userIDs := uniqueValues(request.UserIDs)
+
+	response, err := service.repositoryService.ReadUsers(ctx, &repository.ReadUsersRequest{
+		Emails:  emails,
+		UserIDs: userIDs,
+		Fields:  request.Fields,
+	})
+
+	if err != nil {
+		return &ReadUsersResponse{
+			Err: err,
+		}, nil
+	}
+
+	usersByEmail := map[string]models.UserWithCursor{}
+	usersByID := map[string]models.UserWithCursor{}
+	for _, user := range response.Users {
+		usersByEmail[user.Email] = user
+		usersByID[user.User.ID] = user
+	}
+
+	users := []models.UserWithCursor{}
+	returned := map[string]bool{}
+	missingEmails := []string{}
+	for _, email := range emails {
+		user, found := usersByEmail[email]
+		if !found {
+			missingEmails = append(missingEmails, email)
+
+			continue
+		}
+
+		returned[user.Email] = true
+		user.User = models.ProjectUser(user.User, request.Fields)
+		users = append(users, user)
+	}
+
+	missingUserIDs := []string{}
+	for _, userID := range userIDs {
+		// The users of the others are reported as not found if the caller is only allowed to read its own user
+		user, found := usersByID[userID]
+		if !found || verifyOwnership(ctx, user.Email) != nil {
+			missingUserIDs = append(missingUserIDs, userID)
+
+			continue
+		}
+
+		// The user requested by both its email address and its ID is only returned once
+		if returned[user.Email] {
+			continue
+		}
+
+		returned[user.Email] = true
+		user.User = models.ProjectUser(user.User, request.Fields)
+		users = append(users, user)
+	}
+
+	return &ReadUsersResponse{
+		Users:          users,
+		MissingEmails:  missingEmails,
+		MissingUserIDs: missingUserIDs,
 	}, nil
 }
 
@@ -79,8 +428,28 @@ func (service *businessService) ReadUser(
 func (service *businessService) UpdateUser(
 	ctx context.Context,
 	request *UpdateUserRequest) (*UpdateUserResponse, error) {
+	if err := service.validationPolicy.validateUpdatedUser(request.User, nil); err != nil {
+		return &UpdateUserResponse{
+			Err: NewRequestValidationError(err),
+		}, nil
+	}
+
+	// The user is read before it is updated so the audit record contains what the update changed
+	email, user, err := service.readRequestedUser(ctx, request.Email, request.UserID)
+	if err != nil {
+		return &UpdateUserResponse{
+			Err: err,
+		}, nil
+	}
+
+	if err = service.requireConsent(ctx, "UpdateUser", email); err != nil {
+		return &UpdateUserResponse{
+			Err: err,
+		}, nil
+	}
+
 	response, err := service.repositoryService.UpdateUser(ctx, &repository.UpdateUserRequest{
-		Email: request.Email,
+		Email: email,
 		User:  request.User,
 	})
 
@@ -90,28 +459,527 @@ func (service *businessService) UpdateUser(
 		}, nil
 	}
 
+	service.logIgnoredError(ctx, "failed to record the operation in the audit log", service.auditService.RecordOperation(ctx, models.AuditOperationUpdate, email, &user, &response.User))
+
+	service.logIgnoredError(ctx, "failed to publish the user updated event", service.eventingService.PublishUserUpdated(ctx, &eventing.UserUpdatedEvent{
+		Email:  email,
+		User:   response.User,
+		Cursor: response.Cursor,
+	}))
+
 	return &UpdateUserResponse{
 		User:   response.User,
 		Cursor: response.Cursor,
 	}, nil
 }
 
-// DeleteUser delete an existing user
+// ChangeEmail changes the email address of an existing user. The user keeps its ID, and the data stored for the
+// user elsewhere is moved to the new email address. The sessions of the user are deleted, as they were registered
+// for the previous email address. The change is undone if any of the data can not be moved. The email address can
+// not be changed right away if the email address changes must be confirmed, RequestEmailChange and
+// ConfirmEmailChange change it instead.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to change the email address of an existing user
+// Returns either the result of changing the email address of an existing user or error if something goes wrong.
+func (service *businessService) ChangeEmail(
+	ctx context.Context,
+	request *ChangeEmailRequest) (*ChangeEmailResponse, error) {
+	secret, err := service.configurationService.GetEmailChangeTokenSecret()
+	if err != nil {
+		return &ChangeEmailResponse{
+			Err: err,
+		}, nil
+	}
+
+	if secret != "" {
+		return &ChangeEmailResponse{
+			Err: commonErrors.NewArgumentError("newEmail", "the email address change must be confirmed, request the change and confirm it with the token sent to the new email address"),
+		}, nil
+	}
+
+	// The user is read before its email address is changed so the audit record contains what the change changed
+	email, user, err := service.readRequestedUser(ctx, request.Email, request.UserID)
+	if err != nil {
+		return &ChangeEmailResponse{
+			Err: err,
+		}, nil
+	}
+
+	if email == request.NewEmail {
+		return &ChangeEmailResponse{
+			Err: commonErrors.NewArgumentError("newEmail", "must differ from the current email address"),
+		}, nil
+	}
+
+	response, err := service.changeEmail(ctx, email, request.NewEmail, user)
+	if err != nil {
+		return &ChangeEmailResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &ChangeEmailResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// changeEmail changes the email address of the user read before, moves the data stored for the user elsewhere and
+// records and publishes the change. The email address is changed and the data is moved as a saga, so the email
+// address is changed back and the data moved so far is moved back if any of the data can not be moved.
+func (service *businessService) changeEmail(
+	ctx context.Context,
+	email string,
+	newEmail string,
+	user models.User) (*repository.ChangeEmailResponse, error) {
+	if err := service.requireConsent(ctx, "ChangeEmail", email); err != nil {
+		return nil, err
+	}
+
+	var response *repository.ChangeEmailResponse
+	steps := []saga.Step{
+		{
+			Name: "ChangeEmail",
+			Action: func(ctx context.Context) (err error) {
+				response, err = service.repositoryService.ChangeEmail(ctx, &repository.ChangeEmailRequest{
+					Email:    email,
+					NewEmail: newEmail,
+				})
+
+				return
+			},
+			Compensation: func(ctx context.Context) error {
+				_, err := service.repositoryService.ChangeEmail(ctx, &repository.ChangeEmailRequest{
+					Email:    newEmail,
+					NewEmail: email,
+				})
+
+				return err
+			},
+		},
+	}
+
+	steps = append(steps, service.moveUserDataSteps(email, newEmail)...)
+	steps = append(steps, saga.Step{
+		Name: "DeleteSessions",
+		Action: func(ctx context.Context) error {
+			return service.sessionService.DeleteSessions(ctx, email)
+		},
+	})
+
+	if _, err := service.sagaService.Execute(ctx, &saga.Definition{
+		Name:  "ChangeEmail",
+		Steps: steps,
+	}); err != nil {
+		return nil, err
+	}
+
+	service.logIgnoredError(ctx, "failed to record the operation in the audit log", service.auditService.RecordOperation(ctx, models.AuditOperationChangeEmail, email, &user, &response.User))
+
+	service.logIgnoredError(ctx, "failed to publish the user updated event", service.eventingService.PublishUserUpdated(ctx, &eventing.UserUpdatedEvent{
+		Email:         newEmail,
+		User:          response.User,
+		Cursor:        response.Cursor,
+		PreviousEmail: email,
+	}))
+
+	return response, nil
+}
+
+// DeleteUser delete an existing user. The user is deleted as a saga so the deletion is undone if the other
+// services could not be notified about it. The user is only marked as deleted if soft delete is enabled, so it
+// can be restored until the retention period passes. The password, the consents, the group memberships and the
+// webhook subscriptions of the user are moved aside as part of the saga, so they are moved back if the deletion is
+// undone, and deleted as the last steps of the saga, when the API keys of the user are revoked too. They are not given
+// back when a soft deleted user is restored. The sessions of the user are deleted as part of the saga too, but are not restored if the
+// deletion is undone, the user signs in again instead. The avatar object is deleted as part of the saga unless the user
+// is only marked as deleted.
 // ctx: Mandatory The reference to the context
 // request: Mandatory. The request to delete an existing user
 // Returns either the result of deleting an existing user or error if something goes wrong.
 func (service *businessService) DeleteUser(
 	ctx context.Context,
 	request *DeleteUserRequest) (*DeleteUserResponse, error) {
-	_, err := service.repositoryService.DeleteUser(ctx, &repository.DeleteUserRequest{
-		Email: request.Email,
+	email, err := service.resolveEmail(ctx, request.Email, request.UserID, false)
+	if err != nil {
+		return &DeleteUserResponse{
+			Err: err,
+		}, nil
+	}
+
+	var deletedUser models.User
+
+	deletedUserDataEmail := service.deletedUserDataEmail(email)
+	steps := []saga.Step{
+		{
+			Name: "DeleteUser",
+			Action: func(ctx context.Context) error {
+				readUserResponse, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+					Email: email,
+				})
+				if err != nil {
+					return err
+				}
+
+				deletedUser = readUserResponse.User
+				_, err = service.repositoryService.DeleteUser(ctx, &repository.DeleteUserRequest{
+					Email:      email,
+					SoftDelete: service.softDeleteEnabled,
+				})
+
+				return err
+			},
+			Compensation: func(ctx context.Context) error {
+				if service.softDeleteEnabled {
+					_, err := service.repositoryService.RestoreUser(ctx, &repository.RestoreUserRequest{
+						Email: email,
+					})
+
+					return err
+				}
+
+				_, err := service.repositoryService.CreateUser(ctx, &repository.CreateUserRequest{
+					Email: email,
+					User:  deletedUser,
+				})
+
+				return err
+			},
+		},
+	}
+
+	steps = append(steps, service.moveUserDataSteps(email, deletedUserDataEmail)...)
+	steps = append(steps,
+		saga.Step{
+			Name: "DeleteSessions",
+			Action: func(ctx context.Context) error {
+				return service.sessionService.DeleteSessions(ctx, email)
+			},
+		},
+		// The data moved aside can not be brought back once it is deleted, so it is deleted last, only before the
+		// deletion is published. If deleting it fails, whatever is left of it is moved back to the restored user.
+		saga.Step{
+			Name: "DeleteUserData",
+			Action: func(ctx context.Context) error {
+				return service.deleteUserData(ctx, deletedUserDataEmail, deletedUser.ID)
+			},
+		})
+
+	// A soft deleted user can be restored with the avatar it had, so the avatar object is only deleted with the user
+	if !service.softDeleteEnabled {
+		steps = append(steps, saga.Step{
+			Name: "DeleteAvatar",
+			Action: func(ctx context.Context) error {
+				return service.deleteAvatarObject(ctx, deletedUser)
+			},
+		})
+	}
+
+	steps = append(steps,
+		saga.Step{
+			Name: "PublishUserDeleted",
+			Action: func(ctx context.Context) error {
+				return service.eventingService.PublishUserDeleted(ctx, &eventing.UserDeletedEvent{
+					Email:       email,
+					SoftDeleted: service.softDeleteEnabled,
+				})
+			},
+		})
+
+	state, err := service.sagaService.Execute(ctx, &saga.Definition{
+		Name:  "DeleteUser",
+		Steps: steps,
+	})
+
+	if err == nil {
+		service.logIgnoredError(ctx, "failed to record the operation in the audit log", service.auditService.RecordOperation(ctx, models.AuditOperationDelete, email, &deletedUser, nil))
+	}
+
+	response := &DeleteUserResponse{
+		Err: err,
+	}
+
+	if state != nil {
+		response.SagaID = state.SagaID
+	}
+
+	return response, nil
+}
+
+// RestoreUser restores an existing soft deleted user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to restore an existing soft deleted user
+// Returns either the result of restoring the user or error if something goes wrong.
+func (service *businessService) RestoreUser(
+	ctx context.Context,
+	request *RestoreUserRequest) (*RestoreUserResponse, error) {
+	email, err := service.resolveEmail(ctx, request.Email, request.UserID, true)
+	if err != nil {
+		return &RestoreUserResponse{
+			Err: err,
+		}, nil
+	}
+
+	response, err := service.repositoryService.RestoreUser(ctx, &repository.RestoreUserRequest{
+		Email: email,
 	})
 
 	if err != nil {
-		return &DeleteUserResponse{
+		return &RestoreUserResponse{
+			Err: err,
+		}, nil
+	}
+
+	service.logIgnoredError(ctx, "failed to record the operation in the audit log", service.auditService.RecordOperation(ctx, models.AuditOperationRestore, email, nil, &response.User))
+
+	service.logIgnoredError(ctx, "failed to publish the user restored event", service.eventingService.PublishUserRestored(ctx, &eventing.UserRestoredEvent{
+		Email:  email,
+		User:   response.User,
+		Cursor: response.Cursor,
+	}))
+
+	return &RestoreUserResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// Search returns the list of users that matched the criteria
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria
+// Returns the list of users that matched the criteria
+func (service *businessService) Search(
+	ctx context.Context,
+	request *SearchRequest) (*SearchResponse, error) {
+	if err := service.validateSortingOptions(request.SortingOptions); err != nil {
+		return &SearchResponse{
+			Err: err,
+		}, nil
+	}
+
+	labelSelector, err := models.ParseLabelSelector(request.LabelSelector)
+	if err != nil {
+		return &SearchResponse{
+			Err: commonErrors.NewArgumentErrorWithError("request.LabelSelector", "label selector is invalid", err),
+		}, nil
+	}
+
+	emails := request.Emails
+	if len(request.GroupIDs) > 0 {
+		members, err := service.groupService.ListMembers(ctx, request.GroupIDs)
+		if err != nil {
+			return &SearchResponse{
+				Err: err,
+			}, nil
+		}
+
+		// The groups with no member match no user, rather than leaving the users unfiltered
+		if emails = filterEmails(members, request.Emails); len(emails) == 0 {
+			return &SearchResponse{
+				Users: []models.UserWithCursor{},
+			}, nil
+		}
+	}
+
+	response, err := service.repositoryService.Search(ctx, &repository.SearchRequest{
+		Pagination:     request.Pagination,
+		SortingOptions: request.SortingOptions,
+		Emails:         emails,
+		IncludeDeleted: request.IncludeDeleted,
+		LabelSelector:  labelSelector,
+		Query:          strings.TrimSpace(request.Query),
+		Fields:         request.Fields,
+	})
+
+	if err != nil {
+		return &SearchResponse{
 			Err: err,
 		}, nil
 	}
 
-	return &DeleteUserResponse{}, nil
+	// Not every repository leaves the fields that are not requested out, so the users are projected either way
+	for index := range response.Users {
+		response.Users[index].User = models.ProjectUser(response.Users[index].User, request.Fields)
+	}
+
+	return &SearchResponse{
+		HasPreviousPage: response.HasPreviousPage,
+		HasNextPage:     response.HasNextPage,
+		TotalCount:      response.TotalCount,
+		Users:           response.Users,
+	}, nil
+}
+
+// StreamSearch sends the users that matched the criteria one by one without loading all of them in memory
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria and the function to send the users to
+// Returns either the number of the sent users or error if something goes wrong.
+func (service *businessService) StreamSearch(
+	ctx context.Context,
+	request *StreamSearchRequest) (*StreamSearchResponse, error) {
+	if err := service.validateSortingOptions(request.SortingOptions); err != nil {
+		return &StreamSearchResponse{
+			Err: err,
+		}, nil
+	}
+
+	response, err := service.repositoryService.StreamSearch(ctx, &repository.StreamSearchRequest{
+		SortingOptions: request.SortingOptions,
+		Emails:         request.Emails,
+		IncludeDeleted: request.IncludeDeleted,
+		Send:           request.Send,
+	})
+
+	if err != nil {
+		return &StreamSearchResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &StreamSearchResponse{
+		SentCount: response.SentCount,
+	}, nil
+}
+
+// GetSagaStatus reads the progress of an existing saga
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the progress of an existing saga
+// Returns either the progress of the saga or error if something goes wrong.
+func (service *businessService) GetSagaStatus(
+	ctx context.Context,
+	request *GetSagaStatusRequest) (*GetSagaStatusResponse, error) {
+	state, err := service.sagaService.ReadSagaState(ctx, request.SagaID)
+	if err != nil {
+		return &GetSagaStatusResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &GetSagaStatusResponse{
+		Saga: *state,
+	}, nil
+}
+
+// ListAuditRecords lists the audit records of the mutating operations that matched the criteria, the latest first
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the criteria the audit records are listed by
+// Returns either the audit records or error if something goes wrong.
+func (service *businessService) ListAuditRecords(
+	ctx context.Context,
+	request *ListAuditRecordsRequest) (*ListAuditRecordsResponse, error) {
+	records, err := service.auditService.ListAuditRecords(ctx, &models.AuditRecordFilter{
+		Email:         request.Email,
+		ActorEmail:    request.ActorEmail,
+		Operations:    request.Operations,
+		CreatedAfter:  request.CreatedAfter,
+		CreatedBefore: request.CreatedBefore,
+		Limit:         request.Limit,
+	})
+
+	if err != nil {
+		return &ListAuditRecordsResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &ListAuditRecordsResponse{
+		AuditRecords: records,
+	}, nil
+}
+
+// PurgeByLabel permanently deletes all the users tagged with the given test label. It is only enabled in the
+// ephemeral environments to reset their state between the test runs. The data stored for every user outside the
+// repository, their avatar objects and their sessions are deleted before the users are, so a user created again with
+// the same email address does not get the password or the API keys of the purged user.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the test label of the users to purge
+// Returns either the result of purging the users or error if something goes wrong.
+func (service *businessService) PurgeByLabel(
+	ctx context.Context,
+	request *PurgeByLabelRequest) (*PurgeByLabelResponse, error) {
+	purgeEnabled, err := service.configurationService.GetTestDataPurgeEnabled()
+	if err != nil {
+		return &PurgeByLabelResponse{
+			Err: err,
+		}, nil
+	}
+
+	if !purgeEnabled {
+		return &PurgeByLabelResponse{
+			Err: commonErrors.NewUnknownError("PurgeByLabel is disabled as TEST_DATA_PURGE_ENABLED is not set"),
+		}, nil
+	}
+
+	listResponse, err := service.repositoryService.ListUsersByLabel(ctx, &repository.ListUsersByLabelRequest{
+		Label: request.Label,
+	})
+
+	if err != nil {
+		return &PurgeByLabelResponse{
+			Err: err,
+		}, nil
+	}
+
+	for _, user := range listResponse.Users {
+		if err = service.purgeUserData(ctx, user.Email, user.User); err != nil {
+			return &PurgeByLabelResponse{
+				Err: err,
+			}, nil
+		}
+	}
+
+	response, err := service.repositoryService.PurgeUsersByLabel(ctx, &repository.PurgeUsersByLabelRequest{
+		Label: request.Label,
+	})
+
+	if err != nil {
+		return &PurgeByLabelResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &PurgeByLabelResponse{
+		PurgedCount: response.PurgedCount,
+	}, nil
+}
+
+// logIgnoredError logs the failure of a step that must not fail the operation, e.g. publishing the event of a user that
+// is already persisted
+// ctx: Mandatory The reference to the context
+// message: Mandatory. The message the failure is logged with
+// err: Optional. The error the step failed with, nothing is logged if it is nil
+func (service *businessService) logIgnoredError(ctx context.Context, message string, err error) {
+	if err == nil {
+		return
+	}
+
+	correlation.GetLogger(ctx, service.logger).Warn(message, zap.Error(err))
+}
+
+// getWritableUser returns the user with only the fields the callers can set when creating the user. The other fields
+// are either set by the repository or changed through the dedicated operations, so the created user the repository
+// returns does not carry the values the caller sent for them.
+// user: Mandatory. The user the caller sent
+// Returns the user with only the writable fields
+func getWritableUser(user models.User) models.User {
+	return models.User{
+		DataResidency: user.DataResidency,
+		Labels:        user.Labels,
+	}
+}
+
+// uniqueValues returns the values without the ones repeated, in the order they are first given in
+// values: Optional. The values to remove the repeated ones from
+// Returns the unique values
+func uniqueValues(values []string) []string {
+	uniques := []string{}
+	seen := map[string]bool{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			uniques = append(uniques, value)
+		}
+	}
+
+	return uniques
 }
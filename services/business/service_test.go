@@ -2,19 +2,55 @@ package business_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"math/rand"
+	"os"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/apikey"
+	apiKeyMock "github.com/decentralized-cloud/user/services/apikey/mock"
+	auditMock "github.com/decentralized-cloud/user/services/audit/mock"
 	"github.com/decentralized-cloud/user/services/business"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/consent"
+	consentMock "github.com/decentralized-cloud/user/services/consent/mock"
+	credentialMock "github.com/decentralized-cloud/user/services/credential/mock"
+	"github.com/decentralized-cloud/user/services/eventing"
+	eventingMock "github.com/decentralized-cloud/user/services/eventing/mock"
+	"github.com/decentralized-cloud/user/services/group"
+	groupMock "github.com/decentralized-cloud/user/services/group/mock"
+	"github.com/decentralized-cloud/user/services/idgenerator"
+	"github.com/decentralized-cloud/user/services/invitation"
+	invitationMock "github.com/decentralized-cloud/user/services/invitation/mock"
+	"github.com/decentralized-cloud/user/services/magiclink"
+	magiclinkMock "github.com/decentralized-cloud/user/services/magiclink/mock"
+	"github.com/decentralized-cloud/user/services/mailer"
+	mailerMock "github.com/decentralized-cloud/user/services/mailer/mock"
+	"github.com/decentralized-cloud/user/services/objectstorage"
+	objectStorageMock "github.com/decentralized-cloud/user/services/objectstorage/mock"
+	replicationMock "github.com/decentralized-cloud/user/services/replication/mock"
 	repository "github.com/decentralized-cloud/user/services/repository"
 	repsoitoryMock "github.com/decentralized-cloud/user/services/repository/mock"
+	"github.com/decentralized-cloud/user/services/saga"
+	sagaMock "github.com/decentralized-cloud/user/services/saga/mock"
+	"github.com/decentralized-cloud/user/services/session"
+	sessionMock "github.com/decentralized-cloud/user/services/session/mock"
+	watchMock "github.com/decentralized-cloud/user/services/watch/mock"
+	"github.com/decentralized-cloud/user/services/webhook"
+	webhookMock "github.com/decentralized-cloud/user/services/webhook/mock"
 	"github.com/golang/mock/gomock"
 	"github.com/lucsky/cuid"
 	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -29,17 +65,195 @@ func TestBusinessService(t *testing.T) {
 
 var _ = Describe("Business Service Tests", func() {
 	var (
-		mockCtrl              *gomock.Controller
-		sut                   business.BusinessContract
-		mockRepositoryService *repsoitoryMock.MockRepositoryContract
-		ctx                   context.Context
+		mockCtrl                 *gomock.Controller
+		sut                      business.BusinessContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		mockRepositoryService    *repsoitoryMock.MockRepositoryContract
+		mockEventingService      *eventingMock.MockEventingContract
+		mockSagaStoreService     *sagaMock.MockStoreContract
+		sagaService              saga.SagaContract
+		mockAuditService         *auditMock.MockAuditContract
+		mockReplicationService   *replicationMock.MockReplicationContract
+		mockClockService         *clockMock.MockClockContract
+		mockMagicLinkService     *magiclinkMock.MockMagicLinkContract
+		magicLinksEnabled        bool
+		mockMailerService        *mailerMock.MockMailerContract
+		mockCredentialService    *credentialMock.MockCredentialContract
+		mockWatchService         *watchMock.MockWatchContract
+		mockAPIKeyService        *apiKeyMock.MockAPIKeyContract
+		mockObjectStorageService *objectStorageMock.MockObjectStorageContract
+		mockConsentService       *consentMock.MockConsentContract
+		mockGroupService         *groupMock.MockGroupContract
+		mockInvitationService    *invitationMock.MockInvitationContract
+		mockSessionService       *sessionMock.MockSessionContract
+		mockWebhookService       *webhookMock.MockWebhookContract
+		passwordsEnabled         bool
+		avatarsEnabled           bool
+		consentOperations        []string
+		allowedEmailDomains      []string
+		fieldPatterns            map[string]string
+		requiredFields           []string
+		sortableFields           []string
+		emailChangeTokenSecret   string
+		now                      time.Time
+		recordedOperations       []models.AuditOperation
+		ctx                      context.Context
 	)
 
+	// newDependencies returns the dependencies the service under test is created with
+	newDependencies := func() business.Dependencies {
+		return business.Dependencies{
+			Logger:               zap.NewNop(),
+			ConfigurationService: mockConfigurationService,
+			RepositoryService:    mockRepositoryService,
+			EventingService:      mockEventingService,
+			SagaService:          sagaService,
+			AuditService:         mockAuditService,
+			ReplicationService:   mockReplicationService,
+			ClockService:         mockClockService,
+			MailerService:        mockMailerService,
+			CredentialService:    mockCredentialService,
+			WatchService:         mockWatchService,
+			APIKeyService:        mockAPIKeyService,
+			ObjectStorageService: mockObjectStorageService,
+			ConsentService:       mockConsentService,
+			GroupService:         mockGroupService,
+			InvitationService:    mockInvitationService,
+			SessionService:       mockSessionService,
+			WebhookService:       mockWebhookService,
+			MagicLinkService:     mockMagicLinkService,
+		}
+	}
+
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 
 		mockRepositoryService = repsoitoryMock.NewMockRepositoryContract(mockCtrl)
-		sut, _ = business.NewBusinessService(mockRepositoryService)
+		mockEventingService = eventingMock.NewMockEventingContract(mockCtrl)
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetSagaMaxAttempts().
+			Return(1, nil)
+
+		mockConfigurationService.
+			EXPECT().
+			GetSagaRetryBackoff().
+			Return(time.Duration(0), nil)
+
+		mockConfigurationService.
+			EXPECT().
+			GetSoftDeleteEnabled().
+			Return(false, nil).
+			AnyTimes()
+
+		magicLinksEnabled = false
+		mockConfigurationService.
+			EXPECT().
+			GetMagicLinksEnabled().
+			DoAndReturn(func() (bool, error) { return magicLinksEnabled, nil }).
+			AnyTimes()
+
+		passwordsEnabled = false
+		mockConfigurationService.
+			EXPECT().
+			GetPasswordCredentialsEnabled().
+			DoAndReturn(func() (bool, error) { return passwordsEnabled, nil }).
+			AnyTimes()
+
+		avatarsEnabled = false
+		mockConfigurationService.
+			EXPECT().
+			GetAvatarsEnabled().
+			DoAndReturn(func() (bool, error) { return avatarsEnabled, nil }).
+			AnyTimes()
+
+		emailChangeTokenSecret = ""
+		mockConfigurationService.
+			EXPECT().
+			GetEmailChangeTokenSecret().
+			DoAndReturn(func() (string, error) { return emailChangeTokenSecret, nil }).
+			AnyTimes()
+
+		consentOperations = []string{}
+		mockConfigurationService.
+			EXPECT().
+			GetConsentRequiredOperations().
+			DoAndReturn(func() ([]string, error) { return consentOperations, nil }).
+			AnyTimes()
+
+		allowedEmailDomains = []string{}
+		mockConfigurationService.
+			EXPECT().
+			GetValidationPolicyAllowedEmailDomains().
+			DoAndReturn(func() ([]string, error) { return allowedEmailDomains, nil }).
+			AnyTimes()
+
+		fieldPatterns = map[string]string{}
+		mockConfigurationService.
+			EXPECT().
+			GetValidationPolicyFieldPatterns().
+			DoAndReturn(func() (map[string]string, error) { return fieldPatterns, nil }).
+			AnyTimes()
+
+		requiredFields = []string{}
+		mockConfigurationService.
+			EXPECT().
+			GetValidationPolicyRequiredFields().
+			DoAndReturn(func() ([]string, error) { return requiredFields, nil }).
+			AnyTimes()
+
+		sortableFields = []string{"email", "createdAt"}
+		mockConfigurationService.
+			EXPECT().
+			GetDatabaseSearchSortableFields().
+			DoAndReturn(func() ([]string, error) { return sortableFields, nil }).
+			AnyTimes()
+
+		mockSagaStoreService = sagaMock.NewMockStoreContract(mockCtrl)
+		mockSagaStoreService.
+			EXPECT().
+			SaveSagaState(gomock.Any(), gomock.Any()).
+			Return(nil).
+			AnyTimes()
+
+		now = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			DoAndReturn(func() time.Time { return now }).
+			AnyTimes()
+
+		idGeneratorService, _ := idgenerator.NewIDGeneratorService()
+		sagaService, _ = saga.NewSagaService(zap.NewNop(), mockConfigurationService, mockSagaStoreService, mockClockService, idGeneratorService)
+
+		recordedOperations = []models.AuditOperation{}
+		mockAuditService = auditMock.NewMockAuditContract(mockCtrl)
+		mockAuditService.
+			EXPECT().
+			RecordOperation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_ context.Context, operation models.AuditOperation, _ string, _ *models.User, _ *models.User) {
+				recordedOperations = append(recordedOperations, operation)
+			}).
+			Return(nil).
+			AnyTimes()
+
+		mockReplicationService = replicationMock.NewMockReplicationContract(mockCtrl)
+		mockMagicLinkService = magiclinkMock.NewMockMagicLinkContract(mockCtrl)
+		mockMailerService = mailerMock.NewMockMailerContract(mockCtrl)
+		mockCredentialService = credentialMock.NewMockCredentialContract(mockCtrl)
+		mockWatchService = watchMock.NewMockWatchContract(mockCtrl)
+		mockAPIKeyService = apiKeyMock.NewMockAPIKeyContract(mockCtrl)
+		mockObjectStorageService = objectStorageMock.NewMockObjectStorageContract(mockCtrl)
+		mockConsentService = consentMock.NewMockConsentContract(mockCtrl)
+		mockGroupService = groupMock.NewMockGroupContract(mockCtrl)
+		mockInvitationService = invitationMock.NewMockInvitationContract(mockCtrl)
+		mockSessionService = sessionMock.NewMockSessionContract(mockCtrl)
+		mockWebhookService = webhookMock.NewMockWebhookContract(mockCtrl)
+
+		sut, _ = business.NewBusinessService(newDependencies())
 		ctx = context.Background()
 	})
 
@@ -48,17 +262,276 @@ var _ = Describe("Business Service Tests", func() {
 	})
 
 	Context("user tries to instantiate BusinessService", func() {
+		When("logger is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.Logger = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("Logger", "", err)
+			})
+		})
+
+		When("configuration service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.ConfigurationService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("ConfigurationService", "", err)
+			})
+		})
+
 		When("user repository service is not provided and NewBusinessService is called", func() {
 			It("should return ArgumentNilError", func() {
-				service, err := business.NewBusinessService(nil)
+				dependencies := newDependencies()
+				dependencies.RepositoryService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("RepositoryService", "", err)
+			})
+		})
+
+		When("user eventing service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.EventingService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("EventingService", "", err)
+			})
+		})
+
+		When("saga service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.SagaService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("SagaService", "", err)
+			})
+		})
+
+		When("audit service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.AuditService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("AuditService", "", err)
+			})
+		})
+
+		When("replication service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.ReplicationService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("ReplicationService", "", err)
+			})
+		})
+
+		When("clock service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.ClockService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("ClockService", "", err)
+			})
+		})
+
+		When("magic link service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.MagicLinkService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("MagicLinkService", "", err)
+			})
+		})
+
+		When("mailer service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.MailerService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("MailerService", "", err)
+			})
+		})
+
+		When("credential service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.CredentialService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("CredentialService", "", err)
+			})
+		})
+
+		When("watch service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.WatchService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("WatchService", "", err)
+			})
+		})
+
+		When("API key service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.APIKeyService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("APIKeyService", "", err)
+			})
+		})
+
+		When("object storage service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.ObjectStorageService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("ObjectStorageService", "", err)
+			})
+		})
+
+		When("consent service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.ConsentService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("ConsentService", "", err)
+			})
+		})
+
+		When("group service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.GroupService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("GroupService", "", err)
+			})
+		})
+
+		When("invitation service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.InvitationService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("InvitationService", "", err)
+			})
+		})
+
+		When("session service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.SessionService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("SessionService", "", err)
+			})
+		})
+
+		When("webhook service is not provided and NewBusinessService is called", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies := newDependencies()
+				dependencies.WebhookService = nil
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("WebhookService", "", err)
+			})
+		})
+
+		When("an operation that can not require the consent is configured to require it", func() {
+			It("should return error", func() {
+				consentOperations = []string{"DeleteUser"}
+
+				service, err := business.NewBusinessService(newDependencies())
+				Ω(service).Should(BeNil())
+				Ω(err).ShouldNot(BeNil())
+			})
+		})
+
+		When("the validation policy sets a pattern for an unsupported field", func() {
+			It("should return error", func() {
+				fieldPatterns = map[string]string{"password": "^.{12,}$"}
+
+				service, err := business.NewBusinessService(newDependencies())
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+			})
+		})
+
+		When("the validation policy contains an invalid pattern", func() {
+			It("should return error", func() {
+				fieldPatterns = map[string]string{"labels.department": "^[A-Z"}
+
+				service, err := business.NewBusinessService(newDependencies())
 				Ω(service).Should(BeNil())
-				assertArgumentNilError("repositoryService", "", err)
+				Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+			})
+		})
+
+		When("the search result is allowed to be sorted by an unsupported field", func() {
+			It("should return error", func() {
+				sortableFields = []string{"email", "password"}
+
+				service, err := business.NewBusinessService(newDependencies())
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+			})
+		})
+
+		When("configuration service fails to return whether soft delete is enabled", func() {
+			It("should return the same error", func() {
+				expectedError := commonErrors.NewUnknownError(cuid.New())
+				failingConfigurationService := configurationMock.NewMockConfigurationContract(mockCtrl)
+				failingConfigurationService.
+					EXPECT().
+					GetSoftDeleteEnabled().
+					Return(false, expectedError)
+
+				dependencies := newDependencies()
+				dependencies.ConfigurationService = failingConfigurationService
+
+				service, err := business.NewBusinessService(dependencies)
+				Ω(service).Should(BeNil())
+				Ω(err).Should(Equal(expectedError))
 			})
 		})
 
 		When("all dependencies are resolved and NewBusinessService is called", func() {
 			It("should instantiate the new BusinessService", func() {
-				service, err := business.NewBusinessService(mockRepositoryService)
+				service, err := business.NewBusinessService(newDependencies())
 				Ω(err).Should(BeNil())
 				Ω(service).ShouldNot(BeNil())
 			})
@@ -87,6 +560,11 @@ var _ = Describe("Business Service Tests", func() {
 						}).
 						Return(&repository.CreateUserResponse{}, nil)
 
+					mockEventingService.
+						EXPECT().
+						PublishUserCreated(gomock.Any(), gomock.Any()).
+						Return(nil)
+
 					response, err := sut.CreateUser(ctx, &request)
 					Ω(err).Should(BeNil())
 					Ω(response.Err).Should(BeNil())
@@ -118,196 +596,5662 @@ var _ = Describe("Business Service Tests", func() {
 							CreateUser(gomock.Any(), gomock.Any()).
 							Return(&expectedResponse, nil)
 
+						mockEventingService.
+							EXPECT().
+							PublishUserCreated(gomock.Any(), gomock.Any()).
+							Return(nil)
+
 						response, err := sut.CreateUser(ctx, &request)
 						Ω(err).Should(BeNil())
 						Ω(response.Err).Should(BeNil())
 						Ω(response.User).Should(Equal(expectedResponse.User))
 					})
+
+					It("should publish the UserCreated event", func() {
+						expectedResponse := repository.CreateUserResponse{
+							User:   models.User{},
+							Cursor: cuid.New(),
+						}
+
+						mockRepositoryService.
+							EXPECT().
+							CreateUser(gomock.Any(), gomock.Any()).
+							Return(&expectedResponse, nil)
+
+						mockEventingService.
+							EXPECT().
+							PublishUserCreated(ctx, gomock.Any()).
+							Do(func(_ context.Context, event *eventing.UserCreatedEvent) {
+								Ω(event.Email).Should(Equal(request.Email))
+								Ω(event.User).Should(Equal(expectedResponse.User))
+								Ω(event.Cursor).Should(Equal(expectedResponse.Cursor))
+							}).
+							Return(nil)
+
+						response, err := sut.CreateUser(ctx, &request)
+						Ω(err).Should(BeNil())
+						Ω(response.Err).Should(BeNil())
+					})
+
+					It("should record the Create operation in the audit log", func() {
+						mockRepositoryService.
+							EXPECT().
+							CreateUser(gomock.Any(), gomock.Any()).
+							Return(&repository.CreateUserResponse{User: models.User{}, Cursor: cuid.New()}, nil)
+
+						mockEventingService.
+							EXPECT().
+							PublishUserCreated(gomock.Any(), gomock.Any()).
+							Return(nil)
+
+						_, _ = sut.CreateUser(ctx, &request)
+						Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationCreate}))
+					})
+
+					When("And eventing service PublishUserCreated returns error", func() {
+						It("should still return the created user", func() {
+							expectedResponse := repository.CreateUserResponse{
+								User:   models.User{},
+								Cursor: cuid.New(),
+							}
+
+							mockRepositoryService.
+								EXPECT().
+								CreateUser(gomock.Any(), gomock.Any()).
+								Return(&expectedResponse, nil)
+
+							mockEventingService.
+								EXPECT().
+								PublishUserCreated(gomock.Any(), gomock.Any()).
+								Return(errors.New(cuid.New()))
+
+							response, err := sut.CreateUser(ctx, &request)
+							Ω(err).Should(BeNil())
+							Ω(response.Err).Should(BeNil())
+							Ω(response.Cursor).Should(Equal(expectedResponse.Cursor))
+						})
+					})
+
+					When("And audit service RecordOperation returns error", func() {
+						It("should still return the created user", func() {
+							auditMockCtrl := gomock.NewController(GinkgoT())
+							defer auditMockCtrl.Finish()
+
+							failingAuditService := auditMock.NewMockAuditContract(auditMockCtrl)
+							failingAuditService.
+								EXPECT().
+								RecordOperation(gomock.Any(), models.AuditOperationCreate, request.Email, gomock.Nil(), gomock.Any()).
+								Return(errors.New(cuid.New()))
+
+							dependencies := newDependencies()
+							dependencies.AuditService = failingAuditService
+
+							sut, _ = business.NewBusinessService(dependencies)
+
+							expectedResponse := repository.CreateUserResponse{
+								User:   models.User{},
+								Cursor: cuid.New(),
+							}
+
+							mockRepositoryService.
+								EXPECT().
+								CreateUser(gomock.Any(), gomock.Any()).
+								Return(&expectedResponse, nil)
+
+							mockEventingService.
+								EXPECT().
+								PublishUserCreated(gomock.Any(), gomock.Any()).
+								Return(nil)
+
+							response, err := sut.CreateUser(ctx, &request)
+							Ω(err).Should(BeNil())
+							Ω(response.Err).Should(BeNil())
+							Ω(response.Cursor).Should(Equal(expectedResponse.Cursor))
+						})
+					})
 				})
 			})
 		})
 	})
 
-	Describe("ReadUser", func() {
+	Describe("ReadUsers", func() {
 		var (
-			request business.ReadUserRequest
+			request business.ReadUsersRequest
+			emails  []string
 		)
 
 		BeforeEach(func() {
-			request = business.ReadUserRequest{
-				Email: cuid.New() + "@test.com",
+			emails = []string{cuid.New() + "@test.com", cuid.New() + "@test.com", cuid.New() + "@test.com"}
+			request = business.ReadUsersRequest{
+				Emails: emails,
 			}
 		})
 
 		Context("user service is instantiated", func() {
-			When("ReadUser is called", func() {
-				It("should call user repository ReadUser method", func() {
+			When("ReadUsers is called", func() {
+				It("should read the users with a single call and return them in the requested order along with the missing ones", func() {
+					request.Emails = append(request.Emails, emails[0])
+
 					mockRepositoryService.
 						EXPECT().
-						ReadUser(ctx, gomock.Any()).
-						Do(func(_ context.Context, mappedRequest *repository.ReadUserRequest) {
-							Ω(mappedRequest.Email).Should(Equal(request.Email))
+						ReadUsers(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.ReadUsersRequest) {
+							Ω(mappedRequest.Emails).Should(Equal(emails))
 						}).
-						Return(&repository.ReadUserResponse{}, nil)
+						Return(&repository.ReadUsersResponse{
+							Users: []models.UserWithCursor{{Email: emails[2], Cursor: "2"}, {Email: emails[0], Cursor: "1"}},
+						}, nil)
 
-					response, err := sut.ReadUser(ctx, &request)
+					response, err := sut.ReadUsers(ctx, &request)
 					Ω(err).Should(BeNil())
 					Ω(response.Err).Should(BeNil())
+					Ω(response.Users).Should(HaveLen(2))
+					Ω(response.Users[0].Email).Should(Equal(emails[0]))
+					Ω(response.Users[1].Email).Should(Equal(emails[2]))
+					Ω(response.MissingEmails).Should(Equal([]string{emails[1]}))
 				})
 			})
 
-			When("And user repository ReadUser returns error", func() {
-				It("should return the same error", func() {
-					expectedError := errors.New(cuid.New())
+			When("ReadUsers is called with the IDs of the users", func() {
+				It("should return the users named by their IDs after the ones named by their email addresses only once, along with the missing IDs", func() {
+					userIDs := []string{cuid.New(), cuid.New(), cuid.New()}
+					request.Emails = emails[:1]
+					request.UserIDs = append(userIDs, userIDs[2])
+
 					mockRepositoryService.
 						EXPECT().
-						ReadUser(gomock.Any(), gomock.Any()).
-						Return(nil, expectedError)
+						ReadUsers(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.ReadUsersRequest) {
+							Ω(mappedRequest.Emails).Should(Equal(emails[:1]))
+							Ω(mappedRequest.UserIDs).Should(Equal(userIDs))
+						}).
+						Return(&repository.ReadUsersResponse{
+							Users: []models.UserWithCursor{
+								{Email: emails[1], User: models.User{ID: userIDs[2]}, Cursor: "2"},
+								{Email: emails[0], User: models.User{ID: userIDs[0]}, Cursor: "1"},
+							},
+						}, nil)
 
-					response, err := sut.ReadUser(ctx, &request)
+					response, err := sut.ReadUsers(ctx, &request)
 					Ω(err).Should(BeNil())
-					Ω(response.Err).Should(Equal(expectedError))
+					Ω(response.Err).Should(BeNil())
+					Ω(response.Users).Should(HaveLen(2))
+					Ω(response.Users[0].Email).Should(Equal(emails[0]))
+					Ω(response.Users[1].Email).Should(Equal(emails[1]))
+					Ω(response.MissingEmails).Should(BeEmpty())
+					Ω(response.MissingUserIDs).Should(Equal([]string{userIDs[1]}))
 				})
-			})
 
-			When("And user repository ReadUser return no error", func() {
-				It("should return the user details", func() {
-					expectedResponse := repository.ReadUserResponse{
-						User: models.User{},
-					}
+				It("should report the users of the others as missing if the caller is only allowed to access its own user", func() {
+					userIDs := []string{cuid.New(), cuid.New()}
+					request = business.ReadUsersRequest{UserIDs: userIDs}
+					ownerCtx := context.WithValue(ctx, models.ContextKeyParsedToken, models.ParsedToken{
+						Email:     emails[0],
+						OwnerOnly: true,
+					})
 
 					mockRepositoryService.
 						EXPECT().
-						ReadUser(gomock.Any(), gomock.Any()).
-						Return(&expectedResponse, nil)
+						ReadUsers(gomock.Any(), gomock.Any()).
+						Return(&repository.ReadUsersResponse{
+							Users: []models.UserWithCursor{
+								{Email: emails[0], User: models.User{ID: userIDs[0]}, Cursor: "1"},
+								{Email: emails[1], User: models.User{ID: userIDs[1]}, Cursor: "2"},
+							},
+						}, nil)
 
-					response, err := sut.ReadUser(ctx, &request)
+					response, err := sut.ReadUsers(ownerCtx, &request)
 					Ω(err).Should(BeNil())
 					Ω(response.Err).Should(BeNil())
-					Ω(response.User).Should(Equal(expectedResponse.User))
+					Ω(response.Users).Should(HaveLen(1))
+					Ω(response.Users[0].Email).Should(Equal(emails[0]))
+					Ω(response.MissingUserIDs).Should(Equal([]string{userIDs[1]}))
 				})
 			})
-		})
-	})
-
-	Describe("UpdateUser", func() {
-		var (
-			request business.UpdateUserRequest
-		)
 
-		BeforeEach(func() {
-			request = business.UpdateUserRequest{
-				Email: cuid.New() + "@test.com",
-				User:  models.User{},
-			}
-		})
+			When("ReadUsers is called with the fields to return", func() {
+				It("should only return the requested fields of the users", func() {
+					request.Fields = []string{"labels"}
+					now := time.Now()
 
-		Context("user service is instantiated", func() {
-			When("UpdateUser is called", func() {
-				It("should call user repository UpdateUser method", func() {
 					mockRepositoryService.
 						EXPECT().
-						UpdateUser(ctx, gomock.Any()).
-						Do(func(_ context.Context, mappedRequest *repository.UpdateUserRequest) {
-							Ω(mappedRequest.Email).Should(Equal(request.Email))
+						ReadUsers(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.ReadUsersRequest) {
+							Ω(mappedRequest.Fields).Should(Equal(request.Fields))
 						}).
-						Return(&repository.UpdateUserResponse{}, nil)
+						Return(&repository.ReadUsersResponse{
+							Users: []models.UserWithCursor{{
+								Email: emails[0],
+								User:  models.User{Labels: map[string]string{"plan": "pro"}, CreatedAt: &now},
+							}},
+						}, nil)
 
-					response, err := sut.UpdateUser(ctx, &request)
+					response, err := sut.ReadUsers(ctx, &request)
 					Ω(err).Should(BeNil())
-					Ω(response.Err).Should(BeNil())
+					Ω(response.Users[0].User.Labels).Should(Equal(map[string]string{"plan": "pro"}))
+					Ω(response.Users[0].User.CreatedAt).Should(BeNil())
 				})
 			})
 
-			When("And user repository UpdateUser returns error", func() {
+			When("And user repository ReadUsers returns error", func() {
 				It("should return the same error", func() {
 					expectedError := errors.New(cuid.New())
 					mockRepositoryService.
 						EXPECT().
-						UpdateUser(gomock.Any(), gomock.Any()).
+						ReadUsers(gomock.Any(), gomock.Any()).
 						Return(nil, expectedError)
 
-					response, err := sut.UpdateUser(ctx, &request)
+					response, err := sut.ReadUsers(ctx, &request)
 					Ω(err).Should(BeNil())
 					Ω(response.Err).Should(Equal(expectedError))
 				})
 			})
+		})
+	})
+
+	Describe("ReadUser", func() {
+		var (
+			request business.ReadUserRequest
+		)
+
+		BeforeEach(func() {
+			request = business.ReadUserRequest{
+				Email: cuid.New() + "@test.com",
+			}
+		})
+
+		Context("user service is instantiated", func() {
+			When("ReadUser is called", func() {
+				It("should call user repository ReadUser method", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.ReadUserRequest) {
+							Ω(mappedRequest.Email).Should(Equal(request.Email))
+						}).
+						Return(&repository.ReadUserResponse{}, nil)
+
+					response, err := sut.ReadUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+			})
+
+			When("And user repository ReadUser returns error", func() {
+				It("should return the same error", func() {
+					expectedError := errors.New(cuid.New())
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.ReadUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("ReadUser is called with the fields to return", func() {
+				It("should only return the requested fields of the user", func() {
+					request.Fields = []string{"labels"}
+					now := time.Now()
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.ReadUserRequest) {
+							Ω(mappedRequest.Fields).Should(Equal(request.Fields))
+						}).
+						Return(&repository.ReadUserResponse{
+							User: models.User{Labels: map[string]string{"plan": "pro"}, CreatedAt: &now},
+						}, nil)
+
+					response, err := sut.ReadUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.User.Labels).Should(Equal(map[string]string{"plan": "pro"}))
+					Ω(response.User.CreatedAt).Should(BeNil())
+				})
+			})
+
+			When("And user repository ReadUser return no error", func() {
+				It("should return the user details", func() {
+					expectedResponse := repository.ReadUserResponse{
+						User: models.User{},
+					}
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(gomock.Any(), gomock.Any()).
+						Return(&expectedResponse, nil)
+
+					response, err := sut.ReadUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.User).Should(Equal(expectedResponse.User))
+				})
+			})
+
+			When("ReadUser is called with the user ID", func() {
+				It("should read the user by its ID and return its email address", func() {
+					request = business.ReadUserRequest{UserID: cuid.New()}
+					email := cuid.New() + "@test.com"
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.ReadUserRequest) {
+							Ω(mappedRequest.UserID).Should(Equal(request.UserID))
+							Ω(mappedRequest.Email).Should(BeEmpty())
+						}).
+						Return(&repository.ReadUserResponse{Email: email, User: models.User{ID: request.UserID}}, nil)
+
+					response, err := sut.ReadUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.Email).Should(Equal(email))
+					Ω(response.User.ID).Should(Equal(request.UserID))
+				})
+			})
+		})
+	})
+
+	Describe("ChangeEmail is called", func() {
+		var (
+			request business.ChangeEmailRequest
+			email   string
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+			request = business.ChangeEmailRequest{
+				Email:    email,
+				NewEmail: cuid.New() + "@test.com",
+			}
+
+			mockRepositoryService.
+				EXPECT().
+				ReadUser(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ *repository.ReadUserRequest) (*repository.ReadUserResponse, error) {
+					return &repository.ReadUserResponse{Email: email, User: models.User{}}, nil
+				}).
+				AnyTimes()
+		})
+
+		When("the email address is changed", func() {
+			var expectedResponse repository.ChangeEmailResponse
+
+			BeforeEach(func() {
+				expectedResponse = repository.ChangeEmailResponse{
+					User:   models.User{ID: cuid.New()},
+					Cursor: cuid.New(),
+				}
+
+				mockRepositoryService.
+					EXPECT().
+					ChangeEmail(ctx, gomock.Any()).
+					Do(func(_ context.Context, mappedRequest *repository.ChangeEmailRequest) {
+						Ω(mappedRequest.Email).Should(Equal(request.Email))
+						Ω(mappedRequest.NewEmail).Should(Equal(request.NewEmail))
+					}).
+					Return(&expectedResponse, nil)
+			})
+
+			It("should move the data of the user, delete its sessions and publish the UserUpdated event", func() {
+				mockConsentService.EXPECT().ChangeEmail(ctx, request.Email, request.NewEmail).Return(nil)
+				mockGroupService.EXPECT().ChangeEmail(ctx, request.Email, request.NewEmail).Return(nil)
+				mockWebhookService.EXPECT().ChangeEmail(ctx, request.Email, request.NewEmail).Return(nil)
+				mockSessionService.EXPECT().DeleteSessions(ctx, request.Email).Return(nil)
+
+				mockEventingService.
+					EXPECT().
+					PublishUserUpdated(ctx, gomock.Any()).
+					Do(func(_ context.Context, event *eventing.UserUpdatedEvent) {
+						Ω(event.Email).Should(Equal(request.NewEmail))
+						Ω(event.PreviousEmail).Should(Equal(request.Email))
+						Ω(event.User).Should(Equal(expectedResponse.User))
+						Ω(event.Cursor).Should(Equal(expectedResponse.Cursor))
+					}).
+					Return(nil)
+
+				response, err := sut.ChangeEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.User).Should(Equal(expectedResponse.User))
+				Ω(response.Cursor).Should(Equal(expectedResponse.Cursor))
+				Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationChangeEmail}))
+			})
+
+			It("should return the error moving the data of the user failed with", func() {
+				expectedError := errors.New(cuid.New())
+				mockConsentService.EXPECT().ChangeEmail(ctx, request.Email, request.NewEmail).Return(expectedError)
+				mockRepositoryService.
+					EXPECT().
+					ChangeEmail(gomock.Any(), &repository.ChangeEmailRequest{Email: request.NewEmail, NewEmail: request.Email}).
+					Return(&repository.ChangeEmailResponse{}, nil)
+
+				response, err := sut.ChangeEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+				Ω(recordedOperations).Should(BeEmpty())
+			})
+
+			It("should move the data moved so far back and change the email address back if moving the data failed", func() {
+				expectedError := errors.New(cuid.New())
+				gomock.InOrder(
+					mockConsentService.EXPECT().ChangeEmail(ctx, request.Email, request.NewEmail).Return(nil),
+					mockGroupService.EXPECT().ChangeEmail(ctx, request.Email, request.NewEmail).Return(nil),
+					mockWebhookService.EXPECT().ChangeEmail(ctx, request.Email, request.NewEmail).Return(expectedError),
+					mockGroupService.EXPECT().ChangeEmail(gomock.Any(), request.NewEmail, request.Email).Return(nil),
+					mockConsentService.EXPECT().ChangeEmail(gomock.Any(), request.NewEmail, request.Email).Return(nil),
+					mockRepositoryService.
+						EXPECT().
+						ChangeEmail(gomock.Any(), &repository.ChangeEmailRequest{Email: request.NewEmail, NewEmail: request.Email}).
+						Return(&repository.ChangeEmailResponse{}, nil),
+				)
+
+				response, err := sut.ChangeEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+				Ω(recordedOperations).Should(BeEmpty())
+			})
+		})
+
+		When("And user repository ChangeEmail returns error", func() {
+			It("should return the same error", func() {
+				expectedError := errors.New(cuid.New())
+				mockRepositoryService.
+					EXPECT().
+					ChangeEmail(gomock.Any(), gomock.Any()).
+					Return(nil, expectedError)
+
+				response, err := sut.ChangeEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+			})
+		})
+
+		When("the email address changes must be confirmed", func() {
+			It("should return ArgumentError without changing the email address", func() {
+				emailChangeTokenSecret = cuid.New()
+
+				response, err := sut.ChangeEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+				Ω(recordedOperations).Should(BeEmpty())
+			})
+		})
+
+		When("the user named by its ID already has the new email address", func() {
+			It("should return ArgumentError without changing the email address", func() {
+				request = business.ChangeEmailRequest{
+					UserID:   cuid.New(),
+					NewEmail: request.NewEmail,
+				}
+
+				email = request.NewEmail
+				mockRepositoryService.
+					EXPECT().
+					ChangeEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				response, err := sut.ChangeEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+				Ω(recordedOperations).Should(BeEmpty())
+			})
+		})
+	})
+
+	Describe("email address change confirmation", func() {
+		var (
+			email    string
+			newEmail string
+			userID   string
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+			newEmail = cuid.New() + "@test.com"
+			userID = cuid.New()
+			emailChangeTokenSecret = cuid.New()
+
+			mockConfigurationService.
+				EXPECT().
+				GetEmailChangeTokenTTL().
+				Return(time.Hour, nil).
+				AnyTimes()
+
+			mockRepositoryService.
+				EXPECT().
+				ReadUser(gomock.Any(), &repository.ReadUserRequest{Email: email}).
+				DoAndReturn(func(_ context.Context, _ *repository.ReadUserRequest) (*repository.ReadUserResponse, error) {
+					return &repository.ReadUserResponse{User: models.User{ID: userID}}, nil
+				}).
+				AnyTimes()
+		})
+
+		requestEmailChange := func() *mailer.EmailChangeEmail {
+			var sentEmail *mailer.EmailChangeEmail
+
+			mockRepositoryService.
+				EXPECT().
+				ReadUser(ctx, &repository.ReadUserRequest{Email: newEmail}).
+				Return(nil, commonErrors.NewNotFoundError())
+
+			mockMailerService.
+				EXPECT().
+				SendEmailChangeEmail(ctx, gomock.Any()).
+				Do(func(_ context.Context, email *mailer.EmailChangeEmail) {
+					sentEmail = email
+				}).
+				Return(nil)
+
+			response, err := sut.RequestEmailChange(ctx, &business.RequestEmailChangeRequest{Email: email, NewEmail: newEmail})
+			Ω(err).Should(BeNil())
+			Ω(response.Err).Should(BeNil())
+			Ω(response.ExpiresAt).Should(Equal(now.Add(time.Hour)))
+
+			return sentEmail
+		}
+
+		Describe("RequestEmailChange is called", func() {
+			When("the email address changes do not have to be confirmed", func() {
+				It("should return FeatureDisabledError", func() {
+					emailChangeTokenSecret = ""
+
+					response, err := sut.RequestEmailChange(ctx, &business.RequestEmailChangeRequest{Email: email, NewEmail: newEmail})
+					Ω(err).Should(BeNil())
+					Ω(business.IsFeatureDisabledError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("another user has the new email address", func() {
+				It("should return AlreadyExistsError without sending the token", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: newEmail}).
+						Return(&repository.ReadUserResponse{}, nil)
+
+					response, err := sut.RequestEmailChange(ctx, &business.RequestEmailChangeRequest{Email: email, NewEmail: newEmail})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsAlreadyExistsError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the new email address is available", func() {
+				It("should send the token to the new email address without changing the email address", func() {
+					sentEmail := requestEmailChange()
+					Ω(sentEmail.Email).Should(Equal(email))
+					Ω(sentEmail.NewEmail).Should(Equal(newEmail))
+					Ω(sentEmail.Token).ShouldNot(BeEmpty())
+					Ω(sentEmail.ExpiresAt).Should(Equal(now.Add(time.Hour)))
+					Ω(recordedOperations).Should(BeEmpty())
+				})
+			})
+
+			When("mailer service SendEmailChangeEmail returns error", func() {
+				It("should return UnknownError", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: newEmail}).
+						Return(nil, commonErrors.NewNotFoundError())
+
+					mockMailerService.
+						EXPECT().
+						SendEmailChangeEmail(ctx, gomock.Any()).
+						Return(errors.New(cuid.New()))
+
+					response, err := sut.RequestEmailChange(ctx, &business.RequestEmailChangeRequest{Email: email, NewEmail: newEmail})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsUnknownError(response.Err)).Should(BeTrue())
+				})
+			})
+		})
+
+		Describe("ConfirmEmailChange is called", func() {
+			When("the token is confirmed", func() {
+				It("should change the email address and notify the previous email address", func() {
+					token := requestEmailChange().Token
+					expectedResponse := repository.ChangeEmailResponse{
+						User:   models.User{ID: userID},
+						Cursor: cuid.New(),
+					}
+
+					mockRepositoryService.
+						EXPECT().
+						ChangeEmail(ctx, &repository.ChangeEmailRequest{Email: email, NewEmail: newEmail}).
+						Return(&expectedResponse, nil)
+
+					mockConsentService.EXPECT().ChangeEmail(ctx, email, newEmail).Return(nil)
+					mockGroupService.EXPECT().ChangeEmail(ctx, email, newEmail).Return(nil)
+					mockWebhookService.EXPECT().ChangeEmail(ctx, email, newEmail).Return(nil)
+					mockSessionService.EXPECT().DeleteSessions(ctx, email).Return(nil)
+					mockEventingService.EXPECT().PublishUserUpdated(ctx, gomock.Any()).Return(nil)
+
+					mockMailerService.
+						EXPECT().
+						SendEmailChangedNotification(ctx, &mailer.EmailChangedNotification{Email: email, NewEmail: newEmail}).
+						Return(nil)
+
+					response, err := sut.ConfirmEmailChange(ctx, &business.ConfirmEmailChangeRequest{Email: email, Token: token})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.User).Should(Equal(expectedResponse.User))
+					Ω(response.Cursor).Should(Equal(expectedResponse.Cursor))
+					Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationChangeEmail}))
+				})
+			})
+
+			When("the token has expired", func() {
+				It("should return the expired EmailVerificationTokenError", func() {
+					token := requestEmailChange().Token
+					now = now.Add(time.Hour)
+
+					response, err := sut.ConfirmEmailChange(ctx, &business.ConfirmEmailChangeRequest{Email: email, Token: token})
+					Ω(err).Should(BeNil())
+					Ω(business.IsEmailVerificationTokenExpiredError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the token is issued for another user with the same email address", func() {
+				It("should return EmailVerificationTokenError", func() {
+					token := requestEmailChange().Token
+					userID = cuid.New()
+
+					response, err := sut.ConfirmEmailChange(ctx, &business.ConfirmEmailChangeRequest{Email: email, Token: token})
+					Ω(err).Should(BeNil())
+					Ω(business.IsEmailVerificationTokenError(response.Err)).Should(BeTrue())
+					Ω(business.IsEmailVerificationTokenExpiredError(response.Err)).Should(BeFalse())
+				})
+			})
+
+			When("the new email address in the token is tampered with", func() {
+				It("should return EmailVerificationTokenError", func() {
+					token := requestEmailChange().Token
+					parts := strings.Split(token, ".")
+					parts[0] = base64.RawURLEncoding.EncodeToString([]byte(cuid.New() + "@test.com"))
+
+					response, err := sut.ConfirmEmailChange(ctx, &business.ConfirmEmailChangeRequest{Email: email, Token: strings.Join(parts, ".")})
+					Ω(err).Should(BeNil())
+					Ω(business.IsEmailVerificationTokenError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the token is malformed", func() {
+				It("should return EmailVerificationTokenError", func() {
+					response, err := sut.ConfirmEmailChange(ctx, &business.ConfirmEmailChangeRequest{Email: email, Token: cuid.New()})
+					Ω(err).Should(BeNil())
+					Ω(business.IsEmailVerificationTokenError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("mailer service SendEmailChangedNotification returns error", func() {
+				It("should still change the email address", func() {
+					token := requestEmailChange().Token
+
+					mockRepositoryService.
+						EXPECT().
+						ChangeEmail(ctx, gomock.Any()).
+						Return(&repository.ChangeEmailResponse{User: models.User{ID: userID}}, nil)
+
+					mockConsentService.EXPECT().ChangeEmail(ctx, email, newEmail).Return(nil)
+					mockGroupService.EXPECT().ChangeEmail(ctx, email, newEmail).Return(nil)
+					mockWebhookService.EXPECT().ChangeEmail(ctx, email, newEmail).Return(nil)
+					mockSessionService.EXPECT().DeleteSessions(ctx, email).Return(nil)
+					mockEventingService.EXPECT().PublishUserUpdated(ctx, gomock.Any()).Return(nil)
+
+					mockMailerService.
+						EXPECT().
+						SendEmailChangedNotification(ctx, gomock.Any()).
+						Return(errors.New(cuid.New()))
+
+					response, err := sut.ConfirmEmailChange(ctx, &business.ConfirmEmailChangeRequest{Email: email, Token: token})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+			})
+		})
+	})
+
+	Describe("validation policy", func() {
+		var (
+			getValidationError func(err error) business.ValidationError
+		)
+
+		BeforeEach(func() {
+			allowedEmailDomains = []string{"test.com"}
+			fieldPatterns = map[string]string{"labels.department": "^[A-Z]{2,4}$", "apiKeyName": "^[a-z-]+$"}
+			requiredFields = []string{"labels.department"}
+
+			sut, _ = business.NewBusinessService(newDependencies())
+
+			getValidationError = func(err error) business.ValidationError {
+				Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+
+				var validationError business.ValidationError
+				Ω(errors.As(err, &validationError)).Should(BeTrue())
+
+				return validationError
+			}
+		})
+
+		When("CreateUser is called with an email address of a domain that is not allowed", func() {
+			It("should return ArgumentError without creating the user", func() {
+				response, err := sut.CreateUser(ctx, &business.CreateUserRequest{
+					Email: cuid.New() + "@example.com",
+					User:  models.User{Labels: map[string]string{"department": "RND"}},
+				})
+				Ω(err).Should(BeNil())
+				Ω(getValidationError(response.Err).Failures).Should(Equal([]business.ValidationFailure{{
+					Field:   "email",
+					Code:    business.ValidationCodeNotAllowed,
+					Message: "must belong to one of the allowed email domains",
+				}}))
+			})
+		})
+
+		When("CreateUser is called without a required label", func() {
+			It("should return ArgumentError without creating the user", func() {
+				response, err := sut.CreateUser(ctx, &business.CreateUserRequest{
+					Email: cuid.New() + "@TEST.com",
+					User:  models.User{},
+				})
+				Ω(err).Should(BeNil())
+				Ω(getValidationError(response.Err).Failures).Should(Equal([]business.ValidationFailure{{
+					Field:   "user.labels.department",
+					Code:    business.ValidationCodeRequired,
+					Message: "cannot be blank",
+				}}))
+			})
+		})
+
+		When("UpdateUser is called with a label that does not match its pattern", func() {
+			It("should return ArgumentError without updating the user", func() {
+				response, err := sut.UpdateUser(ctx, &business.UpdateUserRequest{
+					Email: cuid.New() + "@example.com",
+					User:  models.User{Labels: map[string]string{"department": "research"}},
+				})
+				Ω(err).Should(BeNil())
+				Ω(getValidationError(response.Err).Failures).Should(Equal([]business.ValidationFailure{{
+					Field:   "user.labels.department",
+					Code:    business.ValidationCodeFormat,
+					Message: "must be in a valid format",
+				}}))
+			})
+		})
+
+		When("PreviewBulkUpdateUsers is called without updating the labels", func() {
+			It("should not require the labels", func() {
+				mockConfigurationService.
+					EXPECT().
+					GetBulkUpdateTokenSecret().
+					Return("", nil)
+
+				response, err := sut.PreviewBulkUpdateUsers(ctx, &business.PreviewBulkUpdateUsersRequest{
+					User:       models.User{DataResidency: "eu"},
+					UpdateMask: []string{"dataResidency"},
+				})
+				Ω(err).Should(BeNil())
+				Ω(business.IsValidationError(response.Err)).Should(BeFalse())
+				Ω(commonErrors.IsUnknownError(response.Err)).Should(BeTrue())
+			})
+		})
+
+		When("CreateAPIKey is called with a name that does not match its pattern", func() {
+			It("should return ArgumentError without creating the API key", func() {
+				response, err := sut.CreateAPIKey(ctx, &business.CreateAPIKeyRequest{
+					Email: cuid.New() + "@test.com",
+					Name:  "CI Key",
+				})
+				Ω(err).Should(BeNil())
+				Ω(getValidationError(response.Err).Failures[0].Field).Should(Equal("name"))
+			})
+		})
+	})
+
+	Describe("UpdateUser", func() {
+		var (
+			request business.UpdateUserRequest
+		)
+
+		BeforeEach(func() {
+			request = business.UpdateUserRequest{
+				Email: cuid.New() + "@test.com",
+				User:  models.User{},
+			}
+
+			mockRepositoryService.
+				EXPECT().
+				ReadUser(gomock.Any(), gomock.Any()).
+				Return(&repository.ReadUserResponse{User: models.User{}}, nil).
+				AnyTimes()
+		})
+
+		Context("user service is instantiated", func() {
+			When("UpdateUser is called", func() {
+				It("should call user repository UpdateUser method", func() {
+					mockRepositoryService.
+						EXPECT().
+						UpdateUser(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.UpdateUserRequest) {
+							Ω(mappedRequest.Email).Should(Equal(request.Email))
+						}).
+						Return(&repository.UpdateUserResponse{}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserUpdated(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					response, err := sut.UpdateUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+			})
+
+			When("And user repository UpdateUser returns error", func() {
+				It("should return the same error", func() {
+					expectedError := errors.New(cuid.New())
+					mockRepositoryService.
+						EXPECT().
+						UpdateUser(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.UpdateUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("And user repository UpdateUser return no error", func() {
+				It("should return expected details", func() {
+					expectedResponse := repository.UpdateUserResponse{
+						User:   models.User{},
+						Cursor: cuid.New(),
+					}
+					mockRepositoryService.
+						EXPECT().
+						UpdateUser(gomock.Any(), gomock.Any()).
+						Return(&expectedResponse, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserUpdated(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					response, err := sut.UpdateUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.User).Should(Equal(expectedResponse.User))
+				})
+
+				It("should publish the UserUpdated event", func() {
+					expectedResponse := repository.UpdateUserResponse{
+						User:   models.User{},
+						Cursor: cuid.New(),
+					}
+					mockRepositoryService.
+						EXPECT().
+						UpdateUser(gomock.Any(), gomock.Any()).
+						Return(&expectedResponse, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserUpdated(ctx, gomock.Any()).
+						Do(func(_ context.Context, event *eventing.UserUpdatedEvent) {
+							Ω(event.Email).Should(Equal(request.Email))
+							Ω(event.User).Should(Equal(expectedResponse.User))
+							Ω(event.Cursor).Should(Equal(expectedResponse.Cursor))
+						}).
+						Return(nil)
+
+					response, err := sut.UpdateUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+
+				It("should record the Update operation in the audit log", func() {
+					mockRepositoryService.
+						EXPECT().
+						UpdateUser(gomock.Any(), gomock.Any()).
+						Return(&repository.UpdateUserResponse{User: models.User{}, Cursor: cuid.New()}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserUpdated(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					_, _ = sut.UpdateUser(ctx, &request)
+					Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationUpdate}))
+				})
+			})
+
+			When("And user repository UpdateUser returns error", func() {
+				It("should not record the operation in the audit log", func() {
+					mockRepositoryService.
+						EXPECT().
+						UpdateUser(gomock.Any(), gomock.Any()).
+						Return(nil, errors.New(cuid.New()))
+
+					_, _ = sut.UpdateUser(ctx, &request)
+					Ω(recordedOperations).Should(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("DeleteUser is called", func() {
+		var (
+			request       business.DeleteUserRequest
+			email         string
+			user          models.User
+			apiKeys       []models.APIKey
+			userDataCalls []string
+			consentsError error
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+			request = business.DeleteUserRequest{
+				Email: email,
+			}
+
+			user = models.User{ID: cuid.New()}
+			mockRepositoryService.
+				EXPECT().
+				ReadUser(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ *repository.ReadUserRequest) (*repository.ReadUserResponse, error) {
+					return &repository.ReadUserResponse{Email: email, User: user}, nil
+				}).
+				AnyTimes()
+
+			// The calls made to the services that store the data of the user are recorded in the order they are made
+			userDataCalls = []string{}
+			consentsError = nil
+			recordMove := func(store string) func(context.Context, string, string) error {
+				return func(_ context.Context, email string, newEmail string) error {
+					userDataCalls = append(userDataCalls, store+" moved from "+email+" to "+newEmail)
+
+					return nil
+				}
+			}
+
+			recordDelete := func(store string) func(context.Context, string) error {
+				return func(_ context.Context, email string) error {
+					userDataCalls = append(userDataCalls, store+" deleted for "+email)
+
+					return nil
+				}
+			}
+
+			mockCredentialService.EXPECT().ChangeEmail(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(recordMove("password")).AnyTimes()
+			mockConsentService.EXPECT().ChangeEmail(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(recordMove("consents")).AnyTimes()
+			mockGroupService.EXPECT().ChangeEmail(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(recordMove("group memberships")).AnyTimes()
+			mockWebhookService.EXPECT().ChangeEmail(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(recordMove("webhook subscriptions")).AnyTimes()
+			mockCredentialService.EXPECT().DeletePassword(gomock.Any(), gomock.Any()).DoAndReturn(recordDelete("password")).AnyTimes()
+			mockConsentService.
+				EXPECT().
+				DeleteConsents(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, email string) error {
+					if consentsError != nil {
+						return consentsError
+					}
+
+					userDataCalls = append(userDataCalls, "consents deleted for "+email)
+
+					return nil
+				}).
+				AnyTimes()
+
+			mockGroupService.EXPECT().RemoveUserFromGroups(gomock.Any(), gomock.Any()).DoAndReturn(recordDelete("group memberships")).AnyTimes()
+			mockWebhookService.EXPECT().DeleteSubscriptions(gomock.Any(), gomock.Any()).DoAndReturn(recordDelete("webhook subscriptions")).AnyTimes()
+			mockSessionService.EXPECT().DeleteSessions(gomock.Any(), gomock.Any()).DoAndReturn(recordDelete("sessions")).AnyTimes()
+
+			apiKeys = []models.APIKey{}
+			mockAPIKeyService.
+				EXPECT().
+				ListAPIKeys(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string) ([]models.APIKey, error) {
+					return apiKeys, nil
+				}).
+				AnyTimes()
+
+			mockAPIKeyService.
+				EXPECT().
+				RevokeAPIKey(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, userID string, keyID string) error {
+					userDataCalls = append(userDataCalls, "API key "+keyID+" revoked for "+userID)
+
+					return nil
+				}).
+				AnyTimes()
+
+			mockObjectStorageService.
+				EXPECT().
+				DeleteObject(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, objectKey string) error {
+					userDataCalls = append(userDataCalls, "avatar "+objectKey+" deleted")
+
+					return nil
+				}).
+				AnyTimes()
+		})
+
+		Context("user service is instantiated", func() {
+			When("DeleteUser is called", func() {
+				It("should call user repository DeleteUser method", func() {
+					mockRepositoryService.
+						EXPECT().
+						DeleteUser(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.DeleteUserRequest) {
+							Ω(mappedRequest.Email).Should(Equal(request.Email))
+							Ω(mappedRequest.SoftDelete).Should(BeFalse())
+						}).
+						Return(&repository.DeleteUserResponse{}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserDeleted(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					response, err := sut.DeleteUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+			})
+
+			When("DeleteUser is called with the user ID", func() {
+				It("should resolve the email address of the user and delete the user", func() {
+					request = business.DeleteUserRequest{UserID: user.ID}
+
+					mockRepositoryService.
+						EXPECT().
+						DeleteUser(ctx, &repository.DeleteUserRequest{Email: email}).
+						Return(&repository.DeleteUserResponse{}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserDeleted(ctx, &eventing.UserDeletedEvent{Email: email}).
+						Return(nil)
+
+					response, err := sut.DeleteUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+			})
+
+			When("user repository DeleteUser returns error", func() {
+				It("should return the same error", func() {
+					expectedError := errors.New(cuid.New())
+					mockRepositoryService.
+						EXPECT().
+						DeleteUser(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.DeleteUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("user repository DeleteUser completes successfully", func() {
+				It("should return no error", func() {
+					mockRepositoryService.
+						EXPECT().
+						DeleteUser(gomock.Any(), gomock.Any()).
+						Return(&repository.DeleteUserResponse{}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserDeleted(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					response, err := sut.DeleteUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.SagaID).ShouldNot(BeEmpty())
+				})
+
+				It("should publish the UserDeleted event", func() {
+					mockRepositoryService.
+						EXPECT().
+						DeleteUser(gomock.Any(), gomock.Any()).
+						Return(&repository.DeleteUserResponse{}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserDeleted(ctx, gomock.Any()).
+						Do(func(_ context.Context, event *eventing.UserDeletedEvent) {
+							Ω(event.Email).Should(Equal(request.Email))
+						}).
+						Return(nil)
+
+					response, err := sut.DeleteUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+
+				It("should record the Delete operation in the audit log", func() {
+					mockRepositoryService.
+						EXPECT().
+						DeleteUser(gomock.Any(), gomock.Any()).
+						Return(&repository.DeleteUserResponse{}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserDeleted(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					_, _ = sut.DeleteUser(ctx, &request)
+					Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationDelete}))
+				})
+
+				It("should move the data of the user aside and delete it once the user is deleted", func() {
+					passwordsEnabled = true
+					sut, _ = business.NewBusinessService(newDependencies())
+
+					revokedAt := time.Now()
+					activeKeyID := cuid.New()
+					apiKeys = []models.APIKey{
+						{KeyID: activeKeyID},
+						{KeyID: cuid.New(), RevokedAt: &revokedAt},
+					}
+
+					mockRepositoryService.
+						EXPECT().
+						DeleteUser(gomock.Any(), gomock.Any()).
+						Return(&repository.DeleteUserResponse{}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserDeleted(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					response, err := sut.DeleteUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+
+					deletedUserDataEmail := strings.TrimPrefix(userDataCalls[0], "password moved from "+request.Email+" to ")
+					Ω(deletedUserDataEmail).Should(HaveSuffix(request.Email))
+					Ω(deletedUserDataEmail).ShouldNot(Equal(request.Email))
+					Ω(userDataCalls).Should(Equal([]string{
+						"password moved from " + request.Email + " to " + deletedUserDataEmail,
+						"consents moved from " + request.Email + " to " + deletedUserDataEmail,
+						"group memberships moved from " + request.Email + " to " + deletedUserDataEmail,
+						"webhook subscriptions moved from " + request.Email + " to " + deletedUserDataEmail,
+						"sessions deleted for " + request.Email,
+						"password deleted for " + deletedUserDataEmail,
+						"API key " + activeKeyID + " revoked for " + user.ID,
+						"consents deleted for " + deletedUserDataEmail,
+						"group memberships deleted for " + deletedUserDataEmail,
+						"webhook subscriptions deleted for " + deletedUserDataEmail,
+					}))
+				})
+
+				It("should delete the avatar object of the user", func() {
+					mockConfigurationService.EXPECT().GetAvatarMaxSize().Return(1024, nil).AnyTimes()
+					mockConfigurationService.EXPECT().GetAvatarAllowedContentTypes().Return([]string{"image/png"}, nil).AnyTimes()
+					mockConfigurationService.EXPECT().GetAvatarURLExpiry().Return(time.Hour, nil).AnyTimes()
+
+					avatarsEnabled = true
+					sut, _ = business.NewBusinessService(newDependencies())
+
+					user = models.User{Avatar: &models.Avatar{ObjectKey: cuid.New()}}
+
+					mockRepositoryService.
+						EXPECT().
+						DeleteUser(gomock.Any(), gomock.Any()).
+						Return(&repository.DeleteUserResponse{}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserDeleted(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					response, err := sut.DeleteUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(userDataCalls).Should(ContainElement("avatar " + user.Avatar.ObjectKey + " deleted"))
+				})
+			})
+
+			When("deleting the data moved aside fails", func() {
+				It("should recreate the deleted user, move the data left back and return the same error", func() {
+					expectedError := commonErrors.NewUnknownError(cuid.New())
+					consentsError = expectedError
+
+					mockRepositoryService.
+						EXPECT().
+						DeleteUser(gomock.Any(), gomock.Any()).
+						Return(&repository.DeleteUserResponse{}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserDeleted(gomock.Any(), gomock.Any()).
+						Times(0)
+
+					mockRepositoryService.
+						EXPECT().
+						CreateUser(gomock.Any(), gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.CreateUserRequest) {
+							Ω(mappedRequest.Email).Should(Equal(request.Email))
+						}).
+						Return(&repository.CreateUserResponse{}, nil)
+
+					response, err := sut.DeleteUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+					Ω(recordedOperations).Should(BeEmpty())
+
+					deletedUserDataEmail := strings.TrimPrefix(userDataCalls[0], "consents moved from "+request.Email+" to ")
+					Ω(userDataCalls).Should(Equal([]string{
+						"consents moved from " + request.Email + " to " + deletedUserDataEmail,
+						"group memberships moved from " + request.Email + " to " + deletedUserDataEmail,
+						"webhook subscriptions moved from " + request.Email + " to " + deletedUserDataEmail,
+						"sessions deleted for " + request.Email,
+						"webhook subscriptions moved from " + deletedUserDataEmail + " to " + request.Email,
+						"group memberships moved from " + deletedUserDataEmail + " to " + request.Email,
+						"consents moved from " + deletedUserDataEmail + " to " + request.Email,
+					}))
+				})
+			})
+
+			When("user repository DeleteUser returns NotFoundError", func() {
+				It("should leave the data of the user untouched", func() {
+					mockRepositoryService.
+						EXPECT().
+						DeleteUser(gomock.Any(), gomock.Any()).
+						Return(nil, commonErrors.NewNotFoundError())
+
+					response, err := sut.DeleteUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsNotFoundError(response.Err)).Should(BeTrue())
+					Ω(userDataCalls).Should(BeEmpty())
+				})
+			})
+
+			When("eventing service PublishUserDeleted returns error", func() {
+				It("should recreate the deleted user and return the same error", func() {
+					expectedError := errors.New(cuid.New())
+					mockRepositoryService.
+						EXPECT().
+						DeleteUser(gomock.Any(), gomock.Any()).
+						Return(&repository.DeleteUserResponse{}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserDeleted(gomock.Any(), gomock.Any()).
+						Return(expectedError)
+
+					mockRepositoryService.
+						EXPECT().
+						CreateUser(gomock.Any(), gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.CreateUserRequest) {
+							Ω(mappedRequest.Email).Should(Equal(request.Email))
+						}).
+						Return(&repository.CreateUserResponse{}, nil)
+
+					apiKeys = []models.APIKey{{KeyID: cuid.New()}}
+
+					response, err := sut.DeleteUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+					Ω(response.SagaID).ShouldNot(BeEmpty())
+					Ω(recordedOperations).Should(BeEmpty())
+
+					deletedUserDataEmail := strings.TrimPrefix(userDataCalls[0], "consents moved from "+request.Email+" to ")
+					Ω(userDataCalls).Should(Equal([]string{
+						"consents moved from " + request.Email + " to " + deletedUserDataEmail,
+						"group memberships moved from " + request.Email + " to " + deletedUserDataEmail,
+						"webhook subscriptions moved from " + request.Email + " to " + deletedUserDataEmail,
+						"sessions deleted for " + request.Email,
+						"API key " + apiKeys[0].KeyID + " revoked for " + user.ID,
+						"consents deleted for " + deletedUserDataEmail,
+						"group memberships deleted for " + deletedUserDataEmail,
+						"webhook subscriptions deleted for " + deletedUserDataEmail,
+						"webhook subscriptions moved from " + deletedUserDataEmail + " to " + request.Email,
+						"group memberships moved from " + deletedUserDataEmail + " to " + request.Email,
+						"consents moved from " + deletedUserDataEmail + " to " + request.Email,
+					}))
+				})
+			})
+		})
+
+		Context("soft delete is enabled", func() {
+			BeforeEach(func() {
+				softDeleteConfigurationService := configurationMock.NewMockConfigurationContract(mockCtrl)
+				softDeleteConfigurationService.
+					EXPECT().
+					GetSoftDeleteEnabled().
+					Return(true, nil)
+
+				softDeleteConfigurationService.
+					EXPECT().
+					GetMagicLinksEnabled().
+					Return(false, nil)
+
+				softDeleteConfigurationService.
+					EXPECT().
+					GetPasswordCredentialsEnabled().
+					Return(false, nil)
+
+				softDeleteConfigurationService.
+					EXPECT().
+					GetAvatarsEnabled().
+					Return(false, nil)
+
+				softDeleteConfigurationService.
+					EXPECT().
+					GetConsentRequiredOperations().
+					Return([]string{}, nil)
+
+				softDeleteConfigurationService.
+					EXPECT().
+					GetValidationPolicyAllowedEmailDomains().
+					Return([]string{}, nil)
+
+				softDeleteConfigurationService.
+					EXPECT().
+					GetValidationPolicyFieldPatterns().
+					Return(map[string]string{}, nil)
+
+				softDeleteConfigurationService.
+					EXPECT().
+					GetValidationPolicyRequiredFields().
+					Return([]string{}, nil)
+
+				softDeleteConfigurationService.
+					EXPECT().
+					GetDatabaseSearchSortableFields().
+					Return([]string{"email", "createdAt"}, nil)
+
+				dependencies := newDependencies()
+				dependencies.ConfigurationService = softDeleteConfigurationService
+
+				sut, _ = business.NewBusinessService(dependencies)
+			})
+
+			When("DeleteUser is called", func() {
+				It("should soft delete the user and publish the UserDeleted event", func() {
+					mockRepositoryService.
+						EXPECT().
+						DeleteUser(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.DeleteUserRequest) {
+							Ω(mappedRequest.Email).Should(Equal(request.Email))
+							Ω(mappedRequest.SoftDelete).Should(BeTrue())
+						}).
+						Return(&repository.DeleteUserResponse{}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserDeleted(ctx, gomock.Any()).
+						Do(func(_ context.Context, event *eventing.UserDeletedEvent) {
+							Ω(event.Email).Should(Equal(request.Email))
+							Ω(event.SoftDeleted).Should(BeTrue())
+						}).
+						Return(nil)
+
+					response, err := sut.DeleteUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+			})
+
+			When("eventing service PublishUserDeleted returns error", func() {
+				It("should restore the soft deleted user and return the same error", func() {
+					expectedError := errors.New(cuid.New())
+					mockRepositoryService.
+						EXPECT().
+						DeleteUser(gomock.Any(), gomock.Any()).
+						Return(&repository.DeleteUserResponse{}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserDeleted(gomock.Any(), gomock.Any()).
+						Return(expectedError)
+
+					mockRepositoryService.
+						EXPECT().
+						RestoreUser(gomock.Any(), gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.RestoreUserRequest) {
+							Ω(mappedRequest.Email).Should(Equal(request.Email))
+						}).
+						Return(&repository.RestoreUserResponse{}, nil)
+
+					response, err := sut.DeleteUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+		})
+	})
+
+	Describe("RestoreUser is called", func() {
+		var (
+			request business.RestoreUserRequest
+		)
+
+		BeforeEach(func() {
+			request = business.RestoreUserRequest{
+				Email: cuid.New() + "@test.com",
+			}
+		})
+
+		Context("user service is instantiated", func() {
+			When("user repository RestoreUser returns error", func() {
+				It("should return the same error", func() {
+					expectedError := errors.New(cuid.New())
+					mockRepositoryService.
+						EXPECT().
+						RestoreUser(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.RestoreUserRequest) {
+							Ω(mappedRequest.Email).Should(Equal(request.Email))
+						}).
+						Return(nil, expectedError)
+
+					response, err := sut.RestoreUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("user repository RestoreUser completes successfully", func() {
+				It("should return the restored user and publish the UserRestored event", func() {
+					expectedResponse := repository.RestoreUserResponse{
+						User:   models.User{},
+						Cursor: cuid.New(),
+					}
+					mockRepositoryService.
+						EXPECT().
+						RestoreUser(gomock.Any(), gomock.Any()).
+						Return(&expectedResponse, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserRestored(ctx, gomock.Any()).
+						Do(func(_ context.Context, event *eventing.UserRestoredEvent) {
+							Ω(event.Email).Should(Equal(request.Email))
+							Ω(event.Cursor).Should(Equal(expectedResponse.Cursor))
+						}).
+						Return(nil)
+
+					response, err := sut.RestoreUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.User).Should(Equal(expectedResponse.User))
+					Ω(response.Cursor).Should(Equal(expectedResponse.Cursor))
+					Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationRestore}))
+				})
+			})
+
+			When("the user is named by its ID", func() {
+				It("should read the soft deleted user to resolve its email address and restore it", func() {
+					email := request.Email
+					request = business.RestoreUserRequest{UserID: cuid.New()}
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{UserID: request.UserID, IncludeDeleted: true, Fields: []string{"createdAt"}}).
+						Return(&repository.ReadUserResponse{Email: email}, nil)
+
+					mockRepositoryService.
+						EXPECT().
+						RestoreUser(ctx, &repository.RestoreUserRequest{Email: email}).
+						Return(&repository.RestoreUserResponse{}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserRestored(ctx, gomock.Any()).
+						Return(nil)
+
+					response, err := sut.RestoreUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+
+				It("should return NotFoundError if the caller is only allowed to access its own user and the user is not its user", func() {
+					request = business.RestoreUserRequest{UserID: cuid.New()}
+					ownerCtx := context.WithValue(ctx, models.ContextKeyParsedToken, models.ParsedToken{
+						Email:     cuid.New() + "@test.com",
+						OwnerOnly: true,
+					})
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(gomock.Any(), gomock.Any()).
+						Return(&repository.ReadUserResponse{Email: cuid.New() + "@test.com"}, nil)
+
+					mockRepositoryService.
+						EXPECT().
+						RestoreUser(gomock.Any(), gomock.Any()).
+						Times(0)
+
+					response, err := sut.RestoreUser(ownerCtx, &request)
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsNotFoundError(response.Err)).Should(BeTrue())
+				})
+			})
+		})
+	})
+
+	Describe("GetSagaStatus is called", func() {
+		var (
+			request business.GetSagaStatusRequest
+		)
+
+		BeforeEach(func() {
+			request = business.GetSagaStatusRequest{
+				SagaID: cuid.New(),
+			}
+		})
+
+		Context("user service is instantiated", func() {
+			When("saga store ReadSagaState returns error", func() {
+				It("should return the same error", func() {
+					expectedError := commonErrors.NewNotFoundError()
+					mockSagaStoreService.
+						EXPECT().
+						ReadSagaState(ctx, request.SagaID).
+						Return(nil, expectedError)
+
+					response, err := sut.GetSagaStatus(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("saga store ReadSagaState returns the saga state", func() {
+				It("should return the saga state", func() {
+					expectedState := models.SagaState{
+						SagaID: request.SagaID,
+						Name:   "DeleteUser",
+						Status: models.SagaCompensated,
+					}
+
+					mockSagaStoreService.
+						EXPECT().
+						ReadSagaState(ctx, request.SagaID).
+						Return(&expectedState, nil)
+
+					response, err := sut.GetSagaStatus(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.Saga).Should(Equal(expectedState))
+				})
+			})
+		})
+	})
+
+	Describe("ListAuditRecords is called", func() {
+		var (
+			request business.ListAuditRecordsRequest
+		)
+
+		BeforeEach(func() {
+			createdAfter := time.Now().Add(-time.Hour)
+			request = business.ListAuditRecordsRequest{
+				Email:        cuid.New() + "@test.com",
+				ActorEmail:   cuid.New() + "@test.com",
+				Operations:   []models.AuditOperation{models.AuditOperationUpdate},
+				CreatedAfter: &createdAfter,
+				Limit:        rand.Intn(1000),
+			}
+		})
+
+		Context("user service is instantiated", func() {
+			When("audit service ListAuditRecords returns error", func() {
+				It("should return the same error", func() {
+					expectedError := errors.New(cuid.New())
+					mockAuditService.
+						EXPECT().
+						ListAuditRecords(ctx, gomock.Any()).
+						Do(func(_ context.Context, filter *models.AuditRecordFilter) {
+							Ω(filter.Email).Should(Equal(request.Email))
+							Ω(filter.ActorEmail).Should(Equal(request.ActorEmail))
+							Ω(filter.Operations).Should(Equal(request.Operations))
+							Ω(filter.CreatedAfter).Should(Equal(request.CreatedAfter))
+							Ω(filter.CreatedBefore).Should(BeNil())
+							Ω(filter.Limit).Should(Equal(request.Limit))
+						}).
+						Return(nil, expectedError)
+
+					response, err := sut.ListAuditRecords(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("audit service ListAuditRecords returns the audit records", func() {
+				It("should return the audit records", func() {
+					expectedRecords := []models.AuditRecord{
+						{
+							RecordID:   cuid.New(),
+							Operation:  models.AuditOperationUpdate,
+							ActorEmail: request.ActorEmail,
+							Email:      request.Email,
+						},
+					}
+
+					mockAuditService.
+						EXPECT().
+						ListAuditRecords(gomock.Any(), gomock.Any()).
+						Return(expectedRecords, nil)
+
+					response, err := sut.ListAuditRecords(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.AuditRecords).Should(Equal(expectedRecords))
+				})
+			})
+		})
+	})
+
+	Describe("GetEffectiveConfiguration is called", func() {
+		var (
+			connectionString string
+		)
+
+		BeforeEach(func() {
+			connectionString = "mongodb://" + cuid.New() + ":27017"
+			unsetConfigurationEnvironmentVariables()
+			os.Setenv("DATABASE_CONNECTION_STRING", connectionString)
+			os.Setenv("USER_DATABASE_SEARCH_INDEX_HINTS", "email=email_1")
+			os.Setenv("GRPC_PORT", "80")
+
+			configurationService, _ := configuration.NewEnvConfigurationService()
+			dependencies := newDependencies()
+			dependencies.ConfigurationService = configurationService
+
+			sut, _ = business.NewBusinessService(dependencies)
+		})
+
+		AfterEach(func() {
+			unsetConfigurationEnvironmentVariables()
+		})
+
+		It("should return every configuration option with the secrets redacted", func() {
+			response, err := sut.GetEffectiveConfiguration(ctx, &business.GetEffectiveConfigurationRequest{})
+			Ω(err).Should(BeNil())
+			Ω(response.Err).Should(BeNil())
+			Ω(response.Options).Should(HaveLen(len(configuration.Options())))
+
+			options := map[string]models.ConfigurationOption{}
+			for _, option := range response.Options {
+				options[option.Name] = option
+				Ω(option.Value).ShouldNot(ContainSubstring(connectionString))
+			}
+
+			Ω(options["DATABASE_CONNECTION_STRING"].Redacted).Should(BeTrue())
+			Ω(options["DATABASE_CONNECTION_STRING"].Value).Should(BeEmpty())
+			Ω(options["GRPC_PORT"].Value).Should(Equal("80"))
+			Ω(options["SAGA_MAX_ATTEMPTS"].Value).Should(Equal("3"))
+			Ω(options["SAGA_RETRY_BACKOFF"].Value).Should(Equal("100ms"))
+			Ω(options["USER_DATABASE_SEARCH_INDEX_HINTS"].Value).Should(Equal("email=email_1"))
+			Ω(options["JWKS_URL"].Error).ShouldNot(BeEmpty())
+		})
+	})
+
+	Describe("GetEnabledFeatures is called", func() {
+		BeforeEach(func() {
+			mockConfigurationService.
+				EXPECT().
+				GetDatabaseType().
+				Return("mongodb", nil).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetDatabaseSearchIndexHints().
+				Return(map[string]string{}, nil).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetDatabaseSearchQueryPlanStatisticsEnabled().
+				Return(true, nil).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetSagaMaxAttempts().
+				Return(3, nil).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetAdminEmails().
+				Return([]string{}, nil).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetSoftDeleteRetentionPeriod().
+				Return(time.Hour, nil).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetTestDataPurgeEnabled().
+				Return(false, nil).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetErasureTokenSecret().
+				Return("", nil).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetErasureGracePeriod().
+				Return(24*time.Hour, nil).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetCacheEnabled().
+				Return(true, nil).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetCacheTTL().
+				Return(time.Minute, nil).
+				AnyTimes()
+		})
+
+		When("configuration service returns error", func() {
+			It("should return the same error", func() {
+				expectedError := commonErrors.NewUnknownError(cuid.New())
+				mockConfigurationService.
+					EXPECT().
+					GetEventingBroker().
+					Return("", expectedError)
+
+				response, err := sut.GetEnabledFeatures(ctx, &business.GetEnabledFeaturesRequest{})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+			})
+		})
+
+		When("configuration service returns the configuration", func() {
+			It("should return the features derived from the configuration", func() {
+				mockConfigurationService.
+					EXPECT().
+					GetEventingBroker().
+					Return("nats", nil)
+
+				response, err := sut.GetEnabledFeatures(ctx, &business.GetEnabledFeaturesRequest{})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+
+				features := map[string]bool{}
+				for _, feature := range response.Features {
+					features[feature.Name] = feature.Enabled
+				}
+
+				Ω(features).Should(Equal(map[string]bool{
+					"eventing":                     true,
+					"causal_consistency":           true,
+					"search_index_hints":           false,
+					"search_query_plan_statistics": true,
+					"repository_cache":             true,
+					"saga_retries":                 true,
+					"soft_delete":                  false,
+					"admin_operations":             false,
+					"test_data_purge":              false,
+					"magic_links":                  false,
+					"password_credentials":         false,
+					"avatars":                      false,
+					"erasure":                      false,
+					"email_change_confirmation":    false,
+					"fips_crypto":                  false,
+				}))
+			})
+		})
+	})
+
+	Describe("Search is called", func() {
+		var (
+			request business.SearchRequest
+		)
+
+		BeforeEach(func() {
+			first := 10
+			request = business.SearchRequest{
+				Pagination: models.Pagination{First: &first},
+				SortingOptions: []models.SortingOptionPair{
+					{Name: "email", Direction: models.Descending},
+				},
+				Emails:         []string{cuid.New() + "@test.com"},
+				IncludeDeleted: true,
+			}
+		})
+
+		Context("user service is instantiated", func() {
+			When("Search is called", func() {
+				It("should call user repository Search method", func() {
+					mockRepositoryService.
+						EXPECT().
+						Search(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.SearchRequest) {
+							Ω(mappedRequest.Pagination).Should(Equal(request.Pagination))
+							Ω(mappedRequest.SortingOptions).Should(Equal(request.SortingOptions))
+							Ω(mappedRequest.Emails).Should(Equal(request.Emails))
+							Ω(mappedRequest.IncludeDeleted).Should(BeTrue())
+						}).
+						Return(&repository.SearchResponse{}, nil)
+
+					response, err := sut.Search(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+			})
+
+			When("Search is called with the sorting options of a field that is not sortable", func() {
+				It("should return ArgumentError without calling user repository Search method", func() {
+					request.SortingOptions = []models.SortingOptionPair{{Name: "updatedAt", Direction: models.Ascending}}
+
+					response, err := sut.Search(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+					Ω(response.Err.Error()).Should(ContainSubstring("email, createdAt"))
+				})
+			})
+
+			When("the field is made sortable by the configuration", func() {
+				It("should call user repository Search method", func() {
+					sortableFields = []string{"email", "createdAt", "updatedAt"}
+					sut, _ = business.NewBusinessService(newDependencies())
+					request.SortingOptions = []models.SortingOptionPair{{Name: "updatedAt", Direction: models.Ascending}}
+
+					mockRepositoryService.
+						EXPECT().
+						Search(ctx, gomock.Any()).
+						Return(&repository.SearchResponse{}, nil)
+
+					response, err := sut.Search(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+			})
+
+			When("Search is called with a label selector", func() {
+				It("should call user repository Search method with the requirements of the label selector", func() {
+					request.LabelSelector = "region=eu, plan in (pro, enterprise),!trial"
+
+					mockRepositoryService.
+						EXPECT().
+						Search(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.SearchRequest) {
+							Ω(mappedRequest.LabelSelector).Should(Equal([]models.LabelSelectorRequirement{
+								{Key: "region", Operator: models.LabelSelectorOperatorIn, Values: []string{"eu"}},
+								{Key: "plan", Operator: models.LabelSelectorOperatorIn, Values: []string{"pro", "enterprise"}},
+								{Key: "trial", Operator: models.LabelSelectorOperatorDoesNotExist},
+							}))
+						}).
+						Return(&repository.SearchResponse{}, nil)
+
+					response, err := sut.Search(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+
+				It("should return error if the label selector is invalid", func() {
+					request.LabelSelector = "region in (eu"
+
+					response, err := sut.Search(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("Search is called with the IDs of the groups", func() {
+				var (
+					members []string
+				)
+
+				BeforeEach(func() {
+					request.GroupIDs = []string{cuid.New()}
+					members = []string{request.Emails[0], cuid.New() + "@test.com"}
+				})
+
+				It("should call user repository Search method with the members of the groups that are searched by", func() {
+					mockGroupService.
+						EXPECT().
+						ListMembers(ctx, request.GroupIDs).
+						Return(members, nil)
+
+					mockRepositoryService.
+						EXPECT().
+						Search(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.SearchRequest) {
+							Ω(mappedRequest.Emails).Should(Equal(request.Emails))
+						}).
+						Return(&repository.SearchResponse{}, nil)
+
+					response, err := sut.Search(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+
+				It("should call user repository Search method with all the members if the users are not searched by their email addresses", func() {
+					request.Emails = nil
+
+					mockGroupService.
+						EXPECT().
+						ListMembers(ctx, request.GroupIDs).
+						Return(members, nil)
+
+					mockRepositoryService.
+						EXPECT().
+						Search(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.SearchRequest) {
+							Ω(mappedRequest.Emails).Should(Equal(members))
+						}).
+						Return(&repository.SearchResponse{}, nil)
+
+					response, err := sut.Search(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+
+				It("should return no user without calling user repository Search method if no member is searched by", func() {
+					mockGroupService.
+						EXPECT().
+						ListMembers(ctx, request.GroupIDs).
+						Return([]string{cuid.New() + "@test.com"}, nil)
+
+					response, err := sut.Search(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.Users).Should(BeEmpty())
+				})
+
+				It("should return GroupNotFoundError if a group does not exist", func() {
+					mockGroupService.
+						EXPECT().
+						ListMembers(ctx, request.GroupIDs).
+						Return(nil, group.NewGroupNotFoundError(request.GroupIDs[0]))
+
+					response, err := sut.Search(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(group.IsGroupNotFoundError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("Search is called with a free-text query", func() {
+				It("should call user repository Search method with the trimmed query", func() {
+					request.Query = "  john "
+
+					mockRepositoryService.
+						EXPECT().
+						Search(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.SearchRequest) {
+							Ω(mappedRequest.Query).Should(Equal("john"))
+						}).
+						Return(&repository.SearchResponse{}, nil)
+
+					response, err := sut.Search(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+			})
+
+			When("Search is called with the fields to return", func() {
+				It("should only return the requested fields of the matched users", func() {
+					request.Fields = []string{"emailVerified"}
+					now := time.Now()
+
+					mockRepositoryService.
+						EXPECT().
+						Search(ctx, gomock.Any()).
+						Do(func(_ context.Context, mappedRequest *repository.SearchRequest) {
+							Ω(mappedRequest.Fields).Should(Equal(request.Fields))
+						}).
+						Return(&repository.SearchResponse{
+							Users: []models.UserWithCursor{
+								{
+									UserID: cuid.New(),
+									Email:  cuid.New() + "@test.com",
+									User:   models.User{EmailVerified: true, UpdatedAt: &now},
+								},
+							},
+						}, nil)
+
+					response, err := sut.Search(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.Users[0].User.EmailVerified).Should(BeTrue())
+					Ω(response.Users[0].User.UpdatedAt).Should(BeNil())
+				})
+			})
+
+			When("user repository Search returns error", func() {
+				It("should return the same error", func() {
+					expectedError := errors.New(cuid.New())
+					mockRepositoryService.
+						EXPECT().
+						Search(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.Search(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("user repository Search returns the list of matched users", func() {
+				It("should return the list of matched users", func() {
+					expectedResponse := repository.SearchResponse{
+						HasPreviousPage: true,
+						HasNextPage:     true,
+						TotalCount:      30,
+						Users: []models.UserWithCursor{
+							{
+								UserID: cuid.New(),
+								Email:  request.Emails[0],
+								User:   models.User{},
+								Cursor: cuid.New(),
+							},
+						},
+					}
+
+					mockRepositoryService.
+						EXPECT().
+						Search(gomock.Any(), gomock.Any()).
+						Return(&expectedResponse, nil)
+
+					response, err := sut.Search(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.HasPreviousPage).Should(Equal(expectedResponse.HasPreviousPage))
+					Ω(response.HasNextPage).Should(Equal(expectedResponse.HasNextPage))
+					Ω(response.TotalCount).Should(Equal(expectedResponse.TotalCount))
+					Ω(response.Users).Should(Equal(expectedResponse.Users))
+				})
+			})
+		})
+	})
+
+	Describe("StreamSearch is called", func() {
+		var (
+			request   business.StreamSearchRequest
+			sentUsers []models.UserWithCursor
+		)
+
+		BeforeEach(func() {
+			sentUsers = []models.UserWithCursor{}
+			request = business.StreamSearchRequest{
+				SortingOptions: []models.SortingOptionPair{
+					{Name: "email", Direction: models.Descending},
+				},
+				Emails:         []string{cuid.New() + "@test.com"},
+				IncludeDeleted: true,
+				Send: func(user models.UserWithCursor) error {
+					sentUsers = append(sentUsers, user)
+
+					return nil
+				},
+			}
+		})
+
+		Context("user service is instantiated", func() {
+			When("StreamSearch is called", func() {
+				It("should call user repository StreamSearch method", func() {
+					user := models.UserWithCursor{
+						UserID: cuid.New(),
+						Email:  request.Emails[0],
+						User:   models.User{},
+						Cursor: cuid.New(),
+					}
+
+					mockRepositoryService.
+						EXPECT().
+						StreamSearch(ctx, gomock.Any()).
+						DoAndReturn(func(_ context.Context, mappedRequest *repository.StreamSearchRequest) (*repository.StreamSearchResponse, error) {
+							Ω(mappedRequest.SortingOptions).Should(Equal(request.SortingOptions))
+							Ω(mappedRequest.Emails).Should(Equal(request.Emails))
+							Ω(mappedRequest.IncludeDeleted).Should(BeTrue())
+							Ω(mappedRequest.Send(user)).Should(Succeed())
+
+							return &repository.StreamSearchResponse{SentCount: 1}, nil
+						})
+
+					response, err := sut.StreamSearch(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.SentCount).Should(Equal(int64(1)))
+					Ω(sentUsers).Should(Equal([]models.UserWithCursor{user}))
+				})
+			})
+
+			When("StreamSearch is called with the sorting options of a field that is not sortable", func() {
+				It("should return ArgumentError without calling user repository StreamSearch method", func() {
+					request.SortingOptions = []models.SortingOptionPair{{Name: "createdBy", Direction: models.Ascending}}
+
+					response, err := sut.StreamSearch(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("user repository StreamSearch returns error", func() {
+				It("should return the same error", func() {
+					expectedError := errors.New(cuid.New())
+					mockRepositoryService.
+						EXPECT().
+						StreamSearch(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.StreamSearch(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+		})
+	})
+
+	Describe("Bulk update is called", func() {
+		var (
+			tokenSecret    string
+			maxUsers       int
+			emails         []string
+			previewRequest business.PreviewBulkUpdateUsersRequest
+			request        business.BulkUpdateUsersRequest
+		)
+
+		BeforeEach(func() {
+			tokenSecret = cuid.New()
+			maxUsers = 2
+			emails = []string{cuid.New() + "@test.com", cuid.New() + "@test.com"}
+
+			mockConfigurationService.
+				EXPECT().
+				GetBulkUpdateTokenSecret().
+				DoAndReturn(func() (string, error) { return tokenSecret, nil }).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetBulkUpdatePreviewTTL().
+				Return(10*time.Minute, nil).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetBulkUpdateRateLimit().
+				Return(1000, nil).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetBulkUpdateMaxUsers().
+				DoAndReturn(func() (int, error) { return maxUsers, nil }).
+				AnyTimes()
+
+			previewRequest = business.PreviewBulkUpdateUsersRequest{
+				Emails:     emails,
+				User:       models.User{},
+				UpdateMask: []string{},
+			}
+
+			request = business.BulkUpdateUsersRequest{
+				Emails:     emails,
+				User:       models.User{},
+				UpdateMask: []string{},
+			}
+		})
+
+		preview := func(matchedCount int64) *business.PreviewBulkUpdateUsersResponse {
+			mockRepositoryService.
+				EXPECT().
+				Search(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, mappedRequest *repository.SearchRequest) (*repository.SearchResponse, error) {
+					Ω(mappedRequest.Emails).Should(Equal(emails))
+					Ω(*mappedRequest.Pagination.First).Should(Equal(10))
+
+					return &repository.SearchResponse{
+						TotalCount: matchedCount,
+						Users:      []models.UserWithCursor{{Email: emails[0], Cursor: cuid.New()}},
+					}, nil
+				})
+
+			response, err := sut.PreviewBulkUpdateUsers(ctx, &previewRequest)
+			Ω(err).Should(BeNil())
+
+			return response
+		}
+
+		expectStreamSearch := func(matchedEmails []string) {
+			mockRepositoryService.
+				EXPECT().
+				StreamSearch(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, mappedRequest *repository.StreamSearchRequest) (*repository.StreamSearchResponse, error) {
+					Ω(mappedRequest.Emails).Should(Equal(emails))
+					Ω(mappedRequest.IncludeDeleted).Should(BeFalse())
+
+					for _, email := range matchedEmails {
+						if err := mappedRequest.Send(models.UserWithCursor{Email: email}); err != nil {
+							return nil, commonErrors.NewUnknownErrorWithError("failed to send user", err)
+						}
+					}
+
+					return &repository.StreamSearchResponse{SentCount: int64(len(matchedEmails))}, nil
+				})
+		}
+
+		Context("PreviewBulkUpdateUsers is called", func() {
+			When("the token secret is not configured", func() {
+				It("should return UnknownError", func() {
+					tokenSecret = ""
+
+					response, err := sut.PreviewBulkUpdateUsers(ctx, &previewRequest)
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsUnknownError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("user repository Search returns error", func() {
+				It("should return the same error", func() {
+					expectedError := errors.New(cuid.New())
+					mockRepositoryService.
+						EXPECT().
+						Search(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.PreviewBulkUpdateUsers(ctx, &previewRequest)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("the filter matches more users than allowed", func() {
+				It("should return ArgumentError", func() {
+					response := preview(int64(maxUsers + 1))
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+					Ω(response.ConfirmationToken).Should(BeEmpty())
+				})
+			})
+
+			When("the filter matches the allowed number of users", func() {
+				It("should return the number and a sample of the matched users with the confirmation token", func() {
+					response := preview(int64(len(emails)))
+					Ω(response.Err).Should(BeNil())
+					Ω(response.MatchedCount).Should(Equal(int64(len(emails))))
+					Ω(response.Sample).Should(HaveLen(1))
+					Ω(response.ConfirmationToken).ShouldNot(BeEmpty())
+					Ω(response.ExpiresAt).Should(Equal(now.Add(10 * time.Minute)))
+				})
+			})
+		})
+
+		Context("BulkUpdateUsers is called", func() {
+			When("the confirmation token is malformed", func() {
+				It("should return ArgumentError", func() {
+					request.ConfirmationToken = cuid.New()
+
+					response, err := sut.BulkUpdateUsers(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the confirmation token is expired", func() {
+				It("should return ArgumentError", func() {
+					request.ConfirmationToken = "1." + cuid.New()
+
+					response, err := sut.BulkUpdateUsers(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the preview TTL passed since the preview", func() {
+				It("should return ArgumentError without updating any user", func() {
+					request.ConfirmationToken = preview(int64(len(emails))).ConfirmationToken
+					now = now.Add(10 * time.Minute)
+
+					response, err := sut.BulkUpdateUsers(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+					Ω(recordedOperations).Should(BeEmpty())
+				})
+			})
+
+			When("the confirmation token is for a different update", func() {
+				It("should return ArgumentError without updating any user", func() {
+					request.ConfirmationToken = preview(int64(len(emails))).ConfirmationToken
+					request.UpdateMask = []string{cuid.New()}
+					expectStreamSearch(emails)
+
+					response, err := sut.BulkUpdateUsers(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+					Ω(recordedOperations).Should(BeEmpty())
+				})
+			})
+
+			When("the matched users changed since the preview", func() {
+				It("should return ArgumentError without updating any user", func() {
+					request.ConfirmationToken = preview(int64(len(emails))).ConfirmationToken
+					expectStreamSearch(emails[:1])
+
+					response, err := sut.BulkUpdateUsers(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+					Ω(recordedOperations).Should(BeEmpty())
+				})
+			})
+
+			When("the filter matches more users than allowed since the preview", func() {
+				It("should return ArgumentError without updating any user", func() {
+					request.ConfirmationToken = preview(int64(len(emails))).ConfirmationToken
+					expectStreamSearch(append(emails, cuid.New()+"@test.com"))
+
+					response, err := sut.BulkUpdateUsers(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+					Ω(recordedOperations).Should(BeEmpty())
+				})
+			})
+
+			When("user repository StreamSearch returns error", func() {
+				It("should return the same error", func() {
+					request.ConfirmationToken = preview(int64(len(emails))).ConfirmationToken
+					expectedError := errors.New(cuid.New())
+					mockRepositoryService.
+						EXPECT().
+						StreamSearch(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.BulkUpdateUsers(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("the confirmation token matches the filter and the update", func() {
+				It("should update, audit and publish every matched user", func() {
+					request.ConfirmationToken = preview(int64(len(emails))).ConfirmationToken
+					expectStreamSearch(emails)
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, gomock.Any()).
+						Return(&repository.ReadUserResponse{User: models.User{}}, nil).
+						Times(len(emails))
+
+					updatedEmails := []string{}
+					mockRepositoryService.
+						EXPECT().
+						UpdateUser(ctx, gomock.Any()).
+						DoAndReturn(func(_ context.Context, mappedRequest *repository.UpdateUserRequest) (*repository.UpdateUserResponse, error) {
+							updatedEmails = append(updatedEmails, mappedRequest.Email)
+
+							return &repository.UpdateUserResponse{User: mappedRequest.User, Cursor: cuid.New()}, nil
+						}).
+						Times(len(emails))
+
+					mockEventingService.
+						EXPECT().
+						PublishUserUpdated(ctx, gomock.Any()).
+						Return(nil).
+						Times(len(emails))
+
+					response, err := sut.BulkUpdateUsers(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.UpdatedCount).Should(Equal(int64(len(emails))))
+					Ω(response.FailedEmails).Should(BeEmpty())
+					Ω(updatedEmails).Should(Equal(emails))
+					Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationUpdate, models.AuditOperationUpdate}))
+				})
+			})
+
+			When("user repository UpdateUser returns error for a matched user", func() {
+				It("should report the user as failed and update the rest", func() {
+					request.ConfirmationToken = preview(int64(len(emails))).ConfirmationToken
+					expectStreamSearch(emails)
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, gomock.Any()).
+						Return(&repository.ReadUserResponse{User: models.User{}}, nil).
+						Times(len(emails))
+
+					mockRepositoryService.
+						EXPECT().
+						UpdateUser(ctx, gomock.Any()).
+						DoAndReturn(func(_ context.Context, mappedRequest *repository.UpdateUserRequest) (*repository.UpdateUserResponse, error) {
+							if mappedRequest.Email == emails[0] {
+								return nil, errors.New(cuid.New())
+							}
+
+							return &repository.UpdateUserResponse{User: mappedRequest.User, Cursor: cuid.New()}, nil
+						}).
+						Times(len(emails))
+
+					mockEventingService.
+						EXPECT().
+						PublishUserUpdated(ctx, gomock.Any()).
+						Return(nil)
+
+					response, err := sut.BulkUpdateUsers(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.UpdatedCount).Should(Equal(int64(1)))
+					Ω(response.FailedEmails).Should(Equal([]string{emails[0]}))
+					Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationUpdate}))
+				})
+			})
+		})
+	})
+
+	Describe("PurgeByLabel is called", func() {
+		var (
+			purgeEnabled  bool
+			request       business.PurgeByLabelRequest
+			users         []models.UserWithCursor
+			userDataCalls []string
+			consentsError error
+			listError     error
+		)
+
+		BeforeEach(func() {
+			purgeEnabled = true
+			request = business.PurgeByLabelRequest{
+				Label: "loadgen-" + cuid.New(),
+			}
+
+			mockConfigurationService.
+				EXPECT().
+				GetTestDataPurgeEnabled().
+				DoAndReturn(func() (bool, error) { return purgeEnabled, nil }).
+				AnyTimes()
+
+			mockConfigurationService.EXPECT().GetAvatarMaxSize().Return(1024, nil).AnyTimes()
+			mockConfigurationService.EXPECT().GetAvatarAllowedContentTypes().Return([]string{"image/png"}, nil).AnyTimes()
+			mockConfigurationService.EXPECT().GetAvatarURLExpiry().Return(time.Hour, nil).AnyTimes()
+
+			passwordsEnabled = true
+			avatarsEnabled = true
+			sut, _ = business.NewBusinessService(newDependencies())
+
+			users = []models.UserWithCursor{
+				{Email: "user+" + request.Label + "-1@test.com", User: models.User{ID: cuid.New(), Avatar: &models.Avatar{ObjectKey: "avatar-1"}}},
+				{Email: "user+" + request.Label + "-2@test.com", User: models.User{ID: cuid.New()}},
+			}
+
+			mockRepositoryService.
+				EXPECT().
+				ListUsersByLabel(ctx, &repository.ListUsersByLabelRequest{Label: request.Label}).
+				DoAndReturn(func(_ context.Context, _ *repository.ListUsersByLabelRequest) (*repository.ListUsersByLabelResponse, error) {
+					if listError != nil {
+						return nil, listError
+					}
+
+					return &repository.ListUsersByLabelResponse{Users: users}, nil
+				}).
+				AnyTimes()
+
+			// The calls made to the services that store the data of the users are recorded in the order they are made
+			userDataCalls = []string{}
+			consentsError = nil
+			listError = nil
+			recordDelete := func(store string) func(context.Context, string) error {
+				return func(_ context.Context, email string) error {
+					userDataCalls = append(userDataCalls, store+" deleted for "+email)
+
+					return nil
+				}
+			}
+
+			mockCredentialService.EXPECT().DeletePassword(gomock.Any(), gomock.Any()).DoAndReturn(recordDelete("password")).AnyTimes()
+			mockConsentService.
+				EXPECT().
+				DeleteConsents(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, email string) error {
+					if consentsError != nil && email == users[1].Email {
+						return consentsError
+					}
+
+					userDataCalls = append(userDataCalls, "consents deleted for "+email)
+
+					return nil
+				}).
+				AnyTimes()
+			mockGroupService.EXPECT().RemoveUserFromGroups(gomock.Any(), gomock.Any()).DoAndReturn(recordDelete("group memberships")).AnyTimes()
+			mockWebhookService.EXPECT().DeleteSubscriptions(gomock.Any(), gomock.Any()).DoAndReturn(recordDelete("webhook subscriptions")).AnyTimes()
+			mockSessionService.EXPECT().DeleteSessions(gomock.Any(), gomock.Any()).DoAndReturn(recordDelete("sessions")).AnyTimes()
+			mockAPIKeyService.
+				EXPECT().
+				ListAPIKeys(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, userID string) ([]models.APIKey, error) {
+					return []models.APIKey{{KeyID: "key", UserID: userID}}, nil
+				}).
+				AnyTimes()
+
+			mockAPIKeyService.
+				EXPECT().
+				RevokeAPIKey(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, userID string, keyID string) error {
+					userDataCalls = append(userDataCalls, "API key "+keyID+" revoked for "+userID)
+
+					return nil
+				}).
+				AnyTimes()
+
+			mockObjectStorageService.
+				EXPECT().
+				DeleteObject(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, objectKey string) error {
+					userDataCalls = append(userDataCalls, "avatar "+objectKey+" deleted")
+
+					return nil
+				}).
+				AnyTimes()
+		})
+
+		When("test data purge is not enabled", func() {
+			It("should return error and should not call the repository service", func() {
+				purgeEnabled = false
+				mockRepositoryService.
+					EXPECT().
+					PurgeUsersByLabel(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				response, err := sut.PurgeByLabel(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(commonErrors.IsUnknownError(response.Err)).Should(BeTrue())
+				Ω(userDataCalls).Should(BeEmpty())
+			})
+		})
+
+		When("test data purge is enabled", func() {
+			It("should delete the data of every user before calling the repository service PurgeUsersByLabel function and return the purged count", func() {
+				purgedCount := rand.Int63()
+				mockRepositoryService.
+					EXPECT().
+					PurgeUsersByLabel(ctx, &repository.PurgeUsersByLabelRequest{Label: request.Label}).
+					DoAndReturn(func(_ context.Context, _ *repository.PurgeUsersByLabelRequest) (*repository.PurgeUsersByLabelResponse, error) {
+						userDataCalls = append(userDataCalls, "users purged")
+
+						return &repository.PurgeUsersByLabelResponse{PurgedCount: purgedCount}, nil
+					})
+
+				response, err := sut.PurgeByLabel(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.PurgedCount).Should(Equal(purgedCount))
+
+				expectedCalls := []string{}
+				for _, user := range users {
+					expectedCalls = append(expectedCalls,
+						"password deleted for "+user.Email,
+						"API key key revoked for "+user.User.ID,
+						"consents deleted for "+user.Email,
+						"group memberships deleted for "+user.Email,
+						"webhook subscriptions deleted for "+user.Email)
+
+					if user.User.Avatar != nil {
+						expectedCalls = append(expectedCalls, "avatar "+user.User.Avatar.ObjectKey+" deleted")
+					}
+
+					expectedCalls = append(expectedCalls, "sessions deleted for "+user.Email)
+				}
+
+				Ω(userDataCalls).Should(Equal(append(expectedCalls, "users purged")))
+			})
+
+			When("deleting the data of a user fails", func() {
+				It("should return the same error without purging the users", func() {
+					expectedError := commonErrors.NewUnknownError(cuid.New())
+					consentsError = expectedError
+
+					mockRepositoryService.
+						EXPECT().
+						PurgeUsersByLabel(gomock.Any(), gomock.Any()).
+						Times(0)
+
+					response, err := sut.PurgeByLabel(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("repository service ListUsersByLabel returns error", func() {
+				It("should return the same error without purging the users", func() {
+					expectedError := commonErrors.NewUnknownError(cuid.New())
+					listError = expectedError
+
+					mockRepositoryService.
+						EXPECT().
+						PurgeUsersByLabel(gomock.Any(), gomock.Any()).
+						Times(0)
+
+					response, err := sut.PurgeByLabel(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+					Ω(userDataCalls).Should(BeEmpty())
+				})
+			})
+
+			When("repository service PurgeUsersByLabel returns error", func() {
+				It("should return the same error", func() {
+					expectedError := commonErrors.NewUnknownError(cuid.New())
+					mockRepositoryService.
+						EXPECT().
+						PurgeUsersByLabel(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.PurgeByLabel(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+		})
+	})
+
+	Describe("GetOutboxLag is called", func() {
+		When("eventing service GetOutboxLag returns error", func() {
+			It("should return the same error", func() {
+				expectedError := errors.New(cuid.New())
+				mockEventingService.
+					EXPECT().
+					GetOutboxLag(ctx).
+					Return(nil, expectedError)
+
+				response, err := sut.GetOutboxLag(ctx, &business.GetOutboxLagRequest{})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+			})
+		})
+
+		When("eventing service GetOutboxLag returns the lag", func() {
+			It("should return the lag along with the age of the oldest pending event", func() {
+				oldestPendingEventAt := now.Add(-90 * time.Second)
+				expectedLag := models.OutboxLag{
+					Broker:               "nats",
+					Connected:            true,
+					PendingEvents:        rand.Int63(),
+					OldestPendingEventAt: &oldestPendingEventAt,
+				}
+
+				mockEventingService.
+					EXPECT().
+					GetOutboxLag(ctx).
+					Return(&expectedLag, nil)
+
+				response, err := sut.GetOutboxLag(ctx, &business.GetOutboxLagRequest{})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.OutboxLag).Should(Equal(expectedLag))
+				Ω(response.OldestPendingEventAge).Should(Equal(90 * time.Second))
+			})
+		})
+
+		When("there is no pending event", func() {
+			It("should return zero age", func() {
+				mockEventingService.
+					EXPECT().
+					GetOutboxLag(ctx).
+					Return(&models.OutboxLag{Broker: "none"}, nil)
+
+				response, err := sut.GetOutboxLag(ctx, &business.GetOutboxLagRequest{})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.OldestPendingEventAge).Should(BeZero())
+			})
+		})
+	})
+
+	Describe("ListPendingEvents is called", func() {
+		When("the request does not limit the events", func() {
+			It("should list the events up to the default limit", func() {
+				expectedEvents := []models.PendingEvent{
+					{
+						Sequence: rand.Int63(),
+						Subject:  "user.created",
+						Email:    cuid.New() + "@test.com",
+					},
+				}
+
+				mockEventingService.
+					EXPECT().
+					ListPendingEvents(ctx, business.DefaultPendingEventsListLimit).
+					Return(expectedEvents, nil)
+
+				response, err := sut.ListPendingEvents(ctx, &business.ListPendingEventsRequest{})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.PendingEvents).Should(Equal(expectedEvents))
+			})
+		})
+
+		When("eventing service ListPendingEvents returns error", func() {
+			It("should return the same error", func() {
+				expectedError := errors.New(cuid.New())
+				mockEventingService.
+					EXPECT().
+					ListPendingEvents(ctx, 10).
+					Return(nil, expectedError)
+
+				response, err := sut.ListPendingEvents(ctx, &business.ListPendingEventsRequest{Limit: 10})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+			})
+		})
+	})
+
+	Describe("ForceFlush is called", func() {
+		When("eventing service Flush returns error", func() {
+			It("should return the same error", func() {
+				expectedError := errors.New(cuid.New())
+				mockEventingService.
+					EXPECT().
+					Flush(ctx).
+					Return(int64(0), expectedError)
+
+				response, err := sut.ForceFlush(ctx, &business.ForceFlushRequest{})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+			})
+		})
+
+		When("eventing service Flush confirms the events", func() {
+			It("should return the number of the confirmed events and the events still pending", func() {
+				flushedCount := rand.Int63()
+				gomock.InOrder(
+					mockEventingService.
+						EXPECT().
+						Flush(ctx).
+						Return(flushedCount, nil),
+					mockEventingService.
+						EXPECT().
+						GetOutboxLag(ctx).
+						Return(&models.OutboxLag{PendingEvents: 2}, nil))
+
+				response, err := sut.ForceFlush(ctx, &business.ForceFlushRequest{})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.FlushedCount).Should(Equal(flushedCount))
+				Ω(response.PendingEvents).Should(Equal(int64(2)))
+			})
+		})
+	})
+	Describe("GetUserPreferences is called", func() {
+		var (
+			request business.GetUserPreferencesRequest
+		)
+
+		BeforeEach(func() {
+			request = business.GetUserPreferencesRequest{
+				Email: cuid.New() + "@test.com",
+			}
+		})
+
+		When("user repository ReadUserPreferences returns error", func() {
+			It("should return the same error", func() {
+				expectedError := commonErrors.NewNotFoundError()
+				mockRepositoryService.
+					EXPECT().
+					ReadUserPreferences(ctx, &repository.ReadUserPreferencesRequest{Email: request.Email}).
+					Return(nil, expectedError)
+
+				response, err := sut.GetUserPreferences(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+			})
+		})
+
+		When("user repository ReadUserPreferences returns the preferences", func() {
+			It("should return the same preferences", func() {
+				expectedPreferences := map[string]string{models.PreferenceTheme: "dark"}
+				mockRepositoryService.
+					EXPECT().
+					ReadUserPreferences(ctx, &repository.ReadUserPreferencesRequest{Email: request.Email}).
+					Return(&repository.ReadUserPreferencesResponse{Preferences: expectedPreferences}, nil)
+
+				response, err := sut.GetUserPreferences(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.Preferences).Should(Equal(expectedPreferences))
+			})
+		})
+	})
+
+	Describe("UpdateUserPreferences is called", func() {
+		var (
+			request business.UpdateUserPreferencesRequest
+		)
+
+		BeforeEach(func() {
+			request = business.UpdateUserPreferencesRequest{
+				Email:       cuid.New() + "@test.com",
+				Preferences: map[string]string{models.PreferenceLanguage: "en-AU"},
+				RemovedKeys: []string{models.PreferenceTheme},
+			}
+		})
+
+		When("user repository UpdateUserPreferences returns error", func() {
+			It("should return the same error", func() {
+				expectedError := errors.New(cuid.New())
+				mockRepositoryService.
+					EXPECT().
+					UpdateUserPreferences(gomock.Any(), gomock.Any()).
+					Return(nil, expectedError)
+
+				response, err := sut.UpdateUserPreferences(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+			})
+		})
+
+		When("user repository UpdateUserPreferences merges the preferences", func() {
+			It("should pass the preferences and the removed keys and return the merged preferences", func() {
+				mergedPreferences := map[string]string{
+					models.PreferenceLanguage:           "en-AU",
+					models.PreferenceEmailNotifications: "true",
+				}
+
+				mockRepositoryService.
+					EXPECT().
+					UpdateUserPreferences(ctx, &repository.UpdateUserPreferencesRequest{
+						Email:       request.Email,
+						Preferences: request.Preferences,
+						RemovedKeys: request.RemovedKeys,
+					}).
+					Return(&repository.UpdateUserPreferencesResponse{Preferences: mergedPreferences}, nil)
+
+				response, err := sut.UpdateUserPreferences(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.Preferences).Should(Equal(mergedPreferences))
+			})
+		})
+	})
+	Describe("tenant memberships", func() {
+		var (
+			email      string
+			membership models.TenantMembership
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+			membership = models.TenantMembership{TenantID: cuid.New(), Role: models.TenantRoleAdmin, JoinedAt: now}
+		})
+
+		When("AddUserToTenant is called", func() {
+			It("should add the membership joined now, record the change and publish the updated user", func() {
+				updatedUser := models.User{Memberships: []models.TenantMembership{membership}}
+				cursor := cuid.New()
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+					Return(&repository.ReadUserResponse{User: models.User{}}, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					AddUserToTenant(ctx, &repository.AddUserToTenantRequest{Email: email, Membership: membership}).
+					Return(&repository.AddUserToTenantResponse{User: updatedUser, Cursor: cursor}, nil)
+
+				mockEventingService.
+					EXPECT().
+					PublishUserUpdated(ctx, &eventing.UserUpdatedEvent{Email: email, User: updatedUser, Cursor: cursor}).
+					Return(nil)
+
+				response, err := sut.AddUserToTenant(ctx, &business.AddUserToTenantRequest{
+					Email:    email,
+					TenantID: membership.TenantID,
+					Role:     membership.Role,
+				})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.User).Should(Equal(updatedUser))
+				Ω(response.Cursor).Should(Equal(cursor))
+				Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationUpdate}))
+			})
+		})
+
+		When("user repository AddUserToTenant returns error", func() {
+			It("should return the same error without recording or publishing anything", func() {
+				expectedError := commonErrors.NewAlreadyExistsError()
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(gomock.Any(), gomock.Any()).
+					Return(&repository.ReadUserResponse{User: models.User{}}, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					AddUserToTenant(gomock.Any(), gomock.Any()).
+					Return(nil, expectedError)
+
+				response, err := sut.AddUserToTenant(ctx, &business.AddUserToTenantRequest{
+					Email:    email,
+					TenantID: membership.TenantID,
+					Role:     membership.Role,
+				})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+				Ω(recordedOperations).Should(BeEmpty())
+			})
+		})
+
+		When("RemoveUserFromTenant is called for a user that does not exist", func() {
+			It("should return NotFoundError", func() {
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+					Return(nil, commonErrors.NewNotFoundError())
+
+				response, err := sut.RemoveUserFromTenant(ctx, &business.RemoveUserFromTenantRequest{
+					Email:    email,
+					TenantID: membership.TenantID,
+				})
+				Ω(err).Should(BeNil())
+				Ω(commonErrors.IsNotFoundError(response.Err)).Should(BeTrue())
+			})
+		})
+
+		When("RemoveUserFromTenant is called", func() {
+			It("should remove the membership, record the change and publish the updated user", func() {
+				cursor := cuid.New()
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+					Return(&repository.ReadUserResponse{User: models.User{Memberships: []models.TenantMembership{membership}}}, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					RemoveUserFromTenant(ctx, &repository.RemoveUserFromTenantRequest{Email: email, TenantID: membership.TenantID}).
+					Return(&repository.RemoveUserFromTenantResponse{User: models.User{}, Cursor: cursor}, nil)
+
+				mockEventingService.
+					EXPECT().
+					PublishUserUpdated(ctx, &eventing.UserUpdatedEvent{Email: email, User: models.User{}, Cursor: cursor}).
+					Return(nil)
+
+				response, err := sut.RemoveUserFromTenant(ctx, &business.RemoveUserFromTenantRequest{
+					Email:    email,
+					TenantID: membership.TenantID,
+				})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.Cursor).Should(Equal(cursor))
+				Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationUpdate}))
+			})
+		})
+
+		When("ListUserTenants is called for a user that is not a member of any tenant", func() {
+			It("should return no membership", func() {
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+					Return(&repository.ReadUserResponse{User: models.User{}}, nil)
+
+				response, err := sut.ListUserTenants(ctx, &business.ListUserTenantsRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.Memberships).ShouldNot(BeNil())
+				Ω(response.Memberships).Should(BeEmpty())
+			})
+		})
+
+		When("ListUserTenants is called", func() {
+			It("should return the memberships of the user", func() {
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+					Return(&repository.ReadUserResponse{User: models.User{Memberships: []models.TenantMembership{membership}}}, nil)
+
+				response, err := sut.ListUserTenants(ctx, &business.ListUserTenantsRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.Memberships).Should(Equal([]models.TenantMembership{membership}))
+			})
+		})
+	})
+
+	Describe("GetReplicationStatus is called", func() {
+		When("replication service GetReplicationStatus returns error", func() {
+			It("should return the same error", func() {
+				expectedError := errors.New(cuid.New())
+				mockReplicationService.
+					EXPECT().
+					GetReplicationStatus(ctx).
+					Return(nil, expectedError)
+
+				response, err := sut.GetReplicationStatus(ctx, &business.GetReplicationStatusRequest{})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+			})
+		})
+
+		When("replication service GetReplicationStatus returns the status", func() {
+			It("should return the same status", func() {
+				expectedStatus := models.ReplicationStatus{
+					Configured:    true,
+					Primary:       &models.ReplicationHeartbeat{Sequence: 10, WrittenAt: now},
+					Standby:       &models.ReplicationHeartbeat{Sequence: 8, WrittenAt: now.Add(-20 * time.Second)},
+					LagChanges:    2,
+					Lag:           20 * time.Second,
+					FailoverReady: true,
+					CheckedAt:     now,
+				}
+
+				mockReplicationService.
+					EXPECT().
+					GetReplicationStatus(ctx).
+					Return(&expectedStatus, nil)
+
+				response, err := sut.GetReplicationStatus(ctx, &business.GetReplicationStatusRequest{})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.ReplicationStatus).Should(Equal(expectedStatus))
+			})
+		})
+	})
+
+	Describe("ExportUsers is called", func() {
+		var (
+			request       business.ExportUsersRequest
+			exportedUsers []models.UserWithCursor
+		)
+
+		BeforeEach(func() {
+			createdAfter := now.Add(-24 * time.Hour)
+			exportedUsers = []models.UserWithCursor{}
+			request = business.ExportUsersRequest{
+				TenantID:       cuid.New(),
+				CreatedAfter:   &createdAfter,
+				CreatedBefore:  &now,
+				IncludeDeleted: true,
+				After:          cuid.New(),
+				Send: func(user models.UserWithCursor) error {
+					exportedUsers = append(exportedUsers, user)
+
+					return nil
+				},
+			}
+		})
+
+		When("ExportUsers is called", func() {
+			It("should call user repository StreamSearch method with the filter and no sorting options", func() {
+				user := models.UserWithCursor{
+					UserID:    cuid.New(),
+					Email:     cuid.New() + "@test.com",
+					Cursor:    cuid.New(),
+					CreatedAt: now.Add(-time.Hour),
+				}
+
+				mockRepositoryService.
+					EXPECT().
+					StreamSearch(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, mappedRequest *repository.StreamSearchRequest) (*repository.StreamSearchResponse, error) {
+						Ω(mappedRequest.SortingOptions).Should(BeEmpty())
+						Ω(mappedRequest.Emails).Should(BeEmpty())
+						Ω(mappedRequest.TenantID).Should(Equal(request.TenantID))
+						Ω(mappedRequest.CreatedAfter).Should(Equal(request.CreatedAfter))
+						Ω(mappedRequest.CreatedBefore).Should(Equal(request.CreatedBefore))
+						Ω(mappedRequest.IncludeDeleted).Should(BeTrue())
+						Ω(mappedRequest.After).Should(Equal(request.After))
+						Ω(mappedRequest.Send(user)).Should(Succeed())
+
+						return &repository.StreamSearchResponse{SentCount: 1}, nil
+					})
+
+				response, err := sut.ExportUsers(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.ExportedCount).Should(Equal(int64(1)))
+				Ω(exportedUsers).Should(Equal([]models.UserWithCursor{user}))
+			})
+		})
+
+		When("user repository StreamSearch returns error", func() {
+			It("should return the same error", func() {
+				expectedError := errors.New(cuid.New())
+				mockRepositoryService.
+					EXPECT().
+					StreamSearch(gomock.Any(), gomock.Any()).
+					Return(nil, expectedError)
+
+				response, err := sut.ExportUsers(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+			})
+		})
+	})
+
+	Describe("magic links", func() {
+		var (
+			email           string
+			token           string
+			ipAddress       string
+			deviceID        string
+			issuedMagicLink models.MagicLink
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+			token = cuid.New()
+			ipAddress = "10.0.0.1"
+			deviceID = cuid.New()
+			issuedMagicLink = models.MagicLink{
+				MagicLinkID: cuid.New(),
+				Email:       email,
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(15 * time.Minute),
+			}
+		})
+
+		When("magic links are disabled", func() {
+			It("should return FeatureDisabledError from IssueMagicLink", func() {
+				response, err := sut.IssueMagicLink(ctx, &business.IssueMagicLinkRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(business.IsFeatureDisabledError(response.Err)).Should(BeTrue())
+			})
+
+			It("should return FeatureDisabledError from RedeemMagicLink", func() {
+				response, err := sut.RedeemMagicLink(ctx, &business.RedeemMagicLinkRequest{Email: email, Token: token})
+				Ω(err).Should(BeNil())
+				Ω(business.IsFeatureDisabledError(response.Err)).Should(BeTrue())
+			})
+		})
+
+		When("magic links are enabled", func() {
+			BeforeEach(func() {
+				magicLinksEnabled = true
+				sut, _ = business.NewBusinessService(newDependencies())
+			})
+
+			Describe("IssueMagicLink is called", func() {
+				It("should publish the magic link with the token and return the time the magic link expires at", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: models.User{}}, nil)
+
+					mockMagicLinkService.
+						EXPECT().
+						IssueMagicLink(ctx, email, ipAddress, deviceID).
+						Return(&issuedMagicLink, token, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishMagicLinkIssued(ctx, &eventing.MagicLinkIssuedEvent{Email: email, Token: token, ExpiresAt: issuedMagicLink.ExpiresAt}).
+						Return(nil)
+
+					response, err := sut.IssueMagicLink(ctx, &business.IssueMagicLinkRequest{Email: email, IPAddress: ipAddress, DeviceID: deviceID})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.ExpiresAt).Should(Equal(issuedMagicLink.ExpiresAt))
+				})
+
+				It("should return NotFoundError without issuing the magic link if the user does not exist", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, gomock.Any()).
+						Return(nil, commonErrors.NewNotFoundError())
+
+					response, err := sut.IssueMagicLink(ctx, &business.IssueMagicLinkRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsNotFoundError(response.Err)).Should(BeTrue())
+				})
+
+				It("should return UserLockedError without issuing the magic link if the user is locked", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, gomock.Any()).
+						Return(&repository.ReadUserResponse{User: models.User{LockedAt: &now}}, nil)
+
+					response, err := sut.IssueMagicLink(ctx, &business.IssueMagicLinkRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(business.IsUserLockedError(response.Err)).Should(BeTrue())
+				})
+
+				It("should return UnknownError if the magic link can not be published", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, gomock.Any()).
+						Return(&repository.ReadUserResponse{User: models.User{}}, nil)
+
+					mockMagicLinkService.
+						EXPECT().
+						IssueMagicLink(ctx, email, "", "").
+						Return(&issuedMagicLink, token, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishMagicLinkIssued(ctx, gomock.Any()).
+						Return(errors.New(cuid.New()))
+
+					response, err := sut.IssueMagicLink(ctx, &business.IssueMagicLinkRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsUnknownError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			Describe("RedeemMagicLink is called", func() {
+				It("should return the user the magic link signs in", func() {
+					expectedUser := models.User{DataResidency: cuid.New()}
+
+					mockMagicLinkService.
+						EXPECT().
+						RedeemMagicLink(ctx, email, token, ipAddress, deviceID).
+						Return(&issuedMagicLink, nil)
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: expectedUser}, nil)
+
+					response, err := sut.RedeemMagicLink(ctx, &business.RedeemMagicLinkRequest{Email: email, Token: token, IPAddress: ipAddress, DeviceID: deviceID})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.User).Should(Equal(expectedUser))
+				})
+
+				It("should return the error without reading the user if the magic link can not be redeemed", func() {
+					mockMagicLinkService.
+						EXPECT().
+						RedeemMagicLink(ctx, email, token, "", "").
+						Return(nil, magiclink.NewMagicLinkAlreadyRedeemedError(email))
+
+					response, err := sut.RedeemMagicLink(ctx, &business.RedeemMagicLinkRequest{Email: email, Token: token})
+					Ω(err).Should(BeNil())
+					Ω(magiclink.IsMagicLinkAlreadyRedeemedError(response.Err)).Should(BeTrue())
+				})
+
+				It("should return UserLockedError if the user is locked", func() {
+					mockMagicLinkService.
+						EXPECT().
+						RedeemMagicLink(ctx, email, token, "", "").
+						Return(&issuedMagicLink, nil)
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, gomock.Any()).
+						Return(&repository.ReadUserResponse{User: models.User{LockedAt: &now}}, nil)
+
+					response, err := sut.RedeemMagicLink(ctx, &business.RedeemMagicLinkRequest{Email: email, Token: token})
+					Ω(err).Should(BeNil())
+					Ω(business.IsUserLockedError(response.Err)).Should(BeTrue())
+				})
+			})
+		})
+	})
+	Describe("SendVerificationEmail is called", func() {
+		var (
+			request business.SendVerificationEmailRequest
+		)
+
+		BeforeEach(func() {
+			request = business.SendVerificationEmailRequest{
+				Email: cuid.New() + "@test.com",
+			}
+		})
+
+		When("the email address of the user is not verified", func() {
+			It("should persist the hash of a new token and send the token to the user", func() {
+				var sentEmail *mailer.VerificationEmail
+
+				mockConfigurationService.
+					EXPECT().
+					GetEmailVerificationTokenTTL().
+					Return(time.Hour, nil)
+
+				gomock.InOrder(
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: request.Email}).
+						Return(&repository.ReadUserResponse{User: models.User{}}, nil),
+					mockRepositoryService.
+						EXPECT().
+						SetEmailVerificationToken(ctx, gomock.Any()).
+						Return(&repository.SetEmailVerificationTokenResponse{}, nil),
+					mockMailerService.
+						EXPECT().
+						SendVerificationEmail(ctx, gomock.Any()).
+						Do(func(_ context.Context, email *mailer.VerificationEmail) {
+							sentEmail = email
+						}).
+						Return(nil),
+				)
+
+				response, err := sut.SendVerificationEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.AlreadyVerified).Should(BeFalse())
+				Ω(response.ExpiresAt).Should(Equal(now.Add(time.Hour)))
+				Ω(sentEmail.Email).Should(Equal(request.Email))
+				Ω(sentEmail.Token).ShouldNot(BeEmpty())
+				Ω(sentEmail.ExpiresAt).Should(Equal(response.ExpiresAt))
+			})
+
+			It("should persist the hash of the sent token rather than the token", func() {
+				var persistedRequest *repository.SetEmailVerificationTokenRequest
+				var sentEmail *mailer.VerificationEmail
+
+				mockConfigurationService.
+					EXPECT().
+					GetEmailVerificationTokenTTL().
+					Return(time.Hour, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, gomock.Any()).
+					Return(&repository.ReadUserResponse{}, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					SetEmailVerificationToken(ctx, gomock.Any()).
+					Do(func(_ context.Context, request *repository.SetEmailVerificationTokenRequest) {
+						persistedRequest = request
+					}).
+					Return(&repository.SetEmailVerificationTokenResponse{}, nil)
+
+				mockMailerService.
+					EXPECT().
+					SendVerificationEmail(ctx, gomock.Any()).
+					Do(func(_ context.Context, email *mailer.VerificationEmail) {
+						sentEmail = email
+					}).
+					Return(nil)
+
+				_, _ = sut.SendVerificationEmail(ctx, &request)
+				Ω(persistedRequest.Email).Should(Equal(request.Email))
+				Ω(persistedRequest.TokenHash).Should(Equal(hashToken(sentEmail.Token)))
+				Ω(persistedRequest.ExpiresAt).Should(Equal(now.Add(time.Hour)))
+			})
+		})
+
+		When("the email address of the user is already verified", func() {
+			It("should not send the verification email", func() {
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, gomock.Any()).
+					Return(&repository.ReadUserResponse{User: models.User{EmailVerified: true}}, nil)
+
+				response, err := sut.SendVerificationEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.AlreadyVerified).Should(BeTrue())
+			})
+		})
+
+		When("user repository ReadUser returns error", func() {
+			It("should return the same error", func() {
+				expectedError := commonErrors.NewNotFoundError()
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(gomock.Any(), gomock.Any()).
+					Return(nil, expectedError)
+
+				response, err := sut.SendVerificationEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+			})
+		})
+
+		When("mailer service SendVerificationEmail returns error", func() {
+			It("should return UnknownError", func() {
+				mockConfigurationService.
+					EXPECT().
+					GetEmailVerificationTokenTTL().
+					Return(time.Hour, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(gomock.Any(), gomock.Any()).
+					Return(&repository.ReadUserResponse{}, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					SetEmailVerificationToken(gomock.Any(), gomock.Any()).
+					Return(&repository.SetEmailVerificationTokenResponse{}, nil)
+
+				mockMailerService.
+					EXPECT().
+					SendVerificationEmail(gomock.Any(), gomock.Any()).
+					Return(errors.New(cuid.New()))
+
+				response, err := sut.SendVerificationEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(commonErrors.IsUnknownError(response.Err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Describe("VerifyEmail is called", func() {
+		var (
+			request  business.VerifyEmailRequest
+			user     models.User
+			verified models.User
+		)
+
+		BeforeEach(func() {
+			expiresAt := now.Add(time.Hour)
+			request = business.VerifyEmailRequest{
+				Email: cuid.New() + "@test.com",
+				Token: cuid.New(),
+			}
+
+			user = models.User{
+				EmailVerificationTokenHash: hashToken(request.Token),
+				EmailVerificationExpiresAt: &expiresAt,
+			}
+
+			verified = models.User{EmailVerified: true}
+		})
+
+		When("the token is the pending verification token of the user and has not expired", func() {
+			It("should verify the email address and publish the UserUpdated event", func() {
+				cursor := cuid.New()
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{Email: request.Email}).
+					Return(&repository.ReadUserResponse{User: user}, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					VerifyEmail(ctx, &repository.VerifyEmailRequest{Email: request.Email, TokenHash: hashToken(request.Token)}).
+					Return(&repository.VerifyEmailResponse{User: verified, Cursor: cursor}, nil)
+
+				mockEventingService.
+					EXPECT().
+					PublishUserUpdated(ctx, &eventing.UserUpdatedEvent{Email: request.Email, User: verified, Cursor: cursor}).
+					Return(nil)
+
+				response, err := sut.VerifyEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.User).Should(Equal(verified))
+				Ω(response.Cursor).Should(Equal(cursor))
+				Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationUpdate}))
+			})
+		})
+
+		When("the email address of the user is already verified", func() {
+			It("should return the user without checking the token", func() {
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, gomock.Any()).
+					Return(&repository.ReadUserResponse{User: verified}, nil)
+
+				response, err := sut.VerifyEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.User).Should(Equal(verified))
+			})
+		})
+
+		When("the token is not the pending verification token of the user", func() {
+			It("should return EmailVerificationTokenError", func() {
+				user.EmailVerificationTokenHash = hashToken(cuid.New())
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, gomock.Any()).
+					Return(&repository.ReadUserResponse{User: user}, nil)
+
+				response, err := sut.VerifyEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(business.IsEmailVerificationTokenError(response.Err)).Should(BeTrue())
+				Ω(business.IsEmailVerificationTokenExpiredError(response.Err)).Should(BeFalse())
+			})
+		})
+
+		When("the user has no pending verification token", func() {
+			It("should return EmailVerificationTokenError", func() {
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, gomock.Any()).
+					Return(&repository.ReadUserResponse{}, nil)
+
+				response, err := sut.VerifyEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(business.IsEmailVerificationTokenError(response.Err)).Should(BeTrue())
+			})
+		})
+
+		When("the pending verification token has expired", func() {
+			It("should return the expired EmailVerificationTokenError", func() {
+				expiresAt := now
+				user.EmailVerificationExpiresAt = &expiresAt
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, gomock.Any()).
+					Return(&repository.ReadUserResponse{User: user}, nil)
+
+				response, err := sut.VerifyEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(business.IsEmailVerificationTokenExpiredError(response.Err)).Should(BeTrue())
+			})
+		})
+
+		When("the token is replaced after the user is read", func() {
+			It("should return EmailVerificationTokenError", func() {
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, gomock.Any()).
+					Return(&repository.ReadUserResponse{User: user}, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					VerifyEmail(ctx, gomock.Any()).
+					Return(nil, commonErrors.NewNotFoundError())
+
+				response, err := sut.VerifyEmail(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(business.IsEmailVerificationTokenError(response.Err)).Should(BeTrue())
+				Ω(recordedOperations).Should(BeEmpty())
+			})
+		})
+	})
+	Describe("password credentials", func() {
+		var (
+			email string
+			user  models.User
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+			user = models.User{EmailVerified: true}
+
+			passwordsEnabled = true
+			sut, _ = business.NewBusinessService(newDependencies())
+		})
+
+		When("the password credentials are not enabled", func() {
+			It("should return FeatureDisabledError from every operation", func() {
+				passwordsEnabled = false
+				sut, _ = business.NewBusinessService(newDependencies())
+
+				setResponse, err := sut.SetPassword(ctx, &business.SetPasswordRequest{Email: email, Password: cuid.New()})
+				Ω(err).Should(BeNil())
+				Ω(business.IsFeatureDisabledError(setResponse.Err)).Should(BeTrue())
+
+				changeResponse, err := sut.ChangePassword(ctx, &business.ChangePasswordRequest{Email: email, CurrentPassword: cuid.New(), NewPassword: cuid.New()})
+				Ω(err).Should(BeNil())
+				Ω(business.IsFeatureDisabledError(changeResponse.Err)).Should(BeTrue())
+
+				verifyResponse, err := sut.VerifyPassword(ctx, &business.VerifyPasswordRequest{Email: email, Password: cuid.New()})
+				Ω(err).Should(BeNil())
+				Ω(business.IsFeatureDisabledError(verifyResponse.Err)).Should(BeTrue())
+			})
+		})
+
+		When("a user is created", func() {
+			It("should delete the password left behind by a deleted user with the same email address", func() {
+				mockRepositoryService.
+					EXPECT().
+					CreateUser(ctx, gomock.Any()).
+					Return(&repository.CreateUserResponse{}, nil)
+
+				mockCredentialService.
+					EXPECT().
+					DeletePassword(ctx, email).
+					Return(nil)
+
+				mockEventingService.
+					EXPECT().
+					PublishUserCreated(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				response, err := sut.CreateUser(ctx, &business.CreateUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+			})
+		})
+
+		Describe("SetPassword is called", func() {
+			var request business.SetPasswordRequest
+
+			BeforeEach(func() {
+				request = business.SetPasswordRequest{Email: email, Password: cuid.New()}
+			})
+
+			When("the user has no password", func() {
+				It("should set the password", func() {
+					mockCredentialService.
+						EXPECT().
+						ValidatePassword(request.Password).
+						Return(nil)
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: user}, nil)
+
+					mockCredentialService.
+						EXPECT().
+						HasPassword(ctx, email).
+						Return(false, nil)
+
+					mockCredentialService.
+						EXPECT().
+						SetPassword(ctx, email, request.Password).
+						Return(nil)
+
+					response, err := sut.SetPassword(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+			})
+
+			When("the password does not satisfy the password policy", func() {
+				It("should return the same error", func() {
+					expectedError := commonErrors.NewArgumentError("password", cuid.New())
+					mockCredentialService.
+						EXPECT().
+						ValidatePassword(request.Password).
+						Return(expectedError)
+
+					response, err := sut.SetPassword(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("the user does not exist", func() {
+				It("should return the same error", func() {
+					expectedError := commonErrors.NewNotFoundError()
+					mockCredentialService.
+						EXPECT().
+						ValidatePassword(gomock.Any()).
+						Return(nil)
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.SetPassword(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("the user already has a password", func() {
+				It("should return PasswordAlreadySetError", func() {
+					mockCredentialService.
+						EXPECT().
+						ValidatePassword(gomock.Any()).
+						Return(nil)
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, gomock.Any()).
+						Return(&repository.ReadUserResponse{User: user}, nil)
+
+					mockCredentialService.
+						EXPECT().
+						HasPassword(ctx, email).
+						Return(true, nil)
+
+					response, err := sut.SetPassword(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(business.IsPasswordAlreadySetError(response.Err)).Should(BeTrue())
+				})
+			})
+		})
+
+		Describe("ChangePassword is called", func() {
+			var request business.ChangePasswordRequest
+
+			BeforeEach(func() {
+				request = business.ChangePasswordRequest{Email: email, CurrentPassword: cuid.New(), NewPassword: cuid.New()}
+			})
+
+			When("the current password matches", func() {
+				It("should replace the password", func() {
+					mockCredentialService.
+						EXPECT().
+						ValidatePassword(request.NewPassword).
+						Return(nil)
+
+					mockCredentialService.
+						EXPECT().
+						VerifyPassword(ctx, email, request.CurrentPassword).
+						Return(true, nil)
+
+					mockCredentialService.
+						EXPECT().
+						SetPassword(ctx, email, request.NewPassword).
+						Return(nil)
+
+					response, err := sut.ChangePassword(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+			})
+
+			When("the current password does not match", func() {
+				It("should return PasswordMismatchError", func() {
+					mockCredentialService.
+						EXPECT().
+						ValidatePassword(gomock.Any()).
+						Return(nil)
+
+					mockCredentialService.
+						EXPECT().
+						VerifyPassword(ctx, email, request.CurrentPassword).
+						Return(false, nil)
+
+					response, err := sut.ChangePassword(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(business.IsPasswordMismatchError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the new password does not satisfy the password policy", func() {
+				It("should return the same error without verifying the current password", func() {
+					expectedError := commonErrors.NewArgumentError("password", cuid.New())
+					mockCredentialService.
+						EXPECT().
+						ValidatePassword(request.NewPassword).
+						Return(expectedError)
+
+					response, err := sut.ChangePassword(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+		})
+
+		Describe("VerifyPassword is called", func() {
+			var request business.VerifyPasswordRequest
+
+			BeforeEach(func() {
+				request = business.VerifyPasswordRequest{Email: email, Password: cuid.New()}
+			})
+
+			When("the password matches", func() {
+				It("should return the user", func() {
+					mockCredentialService.
+						EXPECT().
+						VerifyPassword(ctx, email, request.Password).
+						Return(true, nil)
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: user}, nil)
+
+					response, err := sut.VerifyPassword(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.User).Should(Equal(user))
+				})
+			})
+
+			When("the password does not match", func() {
+				It("should return PasswordMismatchError", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: user}, nil)
+
+					mockCredentialService.
+						EXPECT().
+						VerifyPassword(ctx, email, request.Password).
+						Return(false, nil)
+
+					response, err := sut.VerifyPassword(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(business.IsPasswordMismatchError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the user does not exist", func() {
+				It("should return PasswordMismatchError without verifying the password", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, gomock.Any()).
+						Return(nil, commonErrors.NewNotFoundError())
+
+					response, err := sut.VerifyPassword(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(business.IsPasswordMismatchError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the user is locked", func() {
+				It("should return UserLockedError without verifying the password", func() {
+					lockedAt := now
+					user.LockedAt = &lockedAt
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: user}, nil)
+
+					response, err := sut.VerifyPassword(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(business.IsUserLockedError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("credential service returns error", func() {
+				It("should return the same error", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: user}, nil)
+
+					expectedError := commonErrors.NewUnknownError(cuid.New())
+					mockCredentialService.
+						EXPECT().
+						VerifyPassword(ctx, email, request.Password).
+						Return(false, expectedError)
+
+					response, err := sut.VerifyPassword(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+		})
+	})
+
+	Describe("WatchUsers is called", func() {
+		var (
+			request      business.WatchUsersRequest
+			sentChanges  []models.UserChange
+			changes      chan models.UserChange
+			watchCtx     context.Context
+			cancelWatch  context.CancelFunc
+			updatedEmail string
+			deletedEmail string
+		)
+
+		BeforeEach(func() {
+			updatedEmail = cuid.New() + "@test.com"
+			deletedEmail = cuid.New() + "@test.com"
+			sentChanges = []models.UserChange{}
+			changes = make(chan models.UserChange, 2)
+			watchCtx, cancelWatch = context.WithCancel(ctx)
+			request = business.WatchUsersRequest{
+				Emails: []string{updatedEmail, deletedEmail},
+				Types:  []models.UserChangeType{models.UserChangeUpdated, models.UserChangeDeleted},
+				Send: func(change models.UserChange) error {
+					sentChanges = append(sentChanges, change)
+
+					return nil
+				},
+			}
+
+			changes <- models.UserChange{Type: models.UserChangeUpdated, Email: updatedEmail, Cursor: cuid.New()}
+			changes <- models.UserChange{Type: models.UserChangeDeleted, Email: deletedEmail, SoftDeleted: true}
+			close(changes)
+		})
+
+		AfterEach(func() {
+			cancelWatch()
+		})
+
+		When("the context is done after the changes are received", func() {
+			It("should send every change with the state of the user after the change", func() {
+				user := models.User{DataResidency: cuid.New()}
+				request.ResumeToken = cuid.New()
+
+				mockWatchService.
+					EXPECT().
+					Subscribe(watchCtx, models.UserChangeFilter{Emails: request.Emails, Types: request.Types, ResumeToken: request.ResumeToken}).
+					Return(changes, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(watchCtx, &repository.ReadUserRequest{Email: updatedEmail}).
+					Return(&repository.ReadUserResponse{User: user}, nil)
+
+				cancelWatch()
+
+				response, err := sut.WatchUsers(watchCtx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.SentCount).Should(Equal(int64(2)))
+				Ω(sentChanges).Should(HaveLen(2))
+				Ω(sentChanges[0].Email).Should(Equal(updatedEmail))
+				Ω(*sentChanges[0].User).Should(Equal(user))
+				Ω(sentChanges[1].Email).Should(Equal(deletedEmail))
+				Ω(sentChanges[1].User).Should(BeNil())
+			})
+		})
+
+		When("the user is deleted before it is read", func() {
+			It("should skip the change", func() {
+				mockWatchService.
+					EXPECT().
+					Subscribe(gomock.Any(), gomock.Any()).
+					Return(changes, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(gomock.Any(), gomock.Any()).
+					Return(nil, commonErrors.NewNotFoundError())
+
+				cancelWatch()
+
+				response, err := sut.WatchUsers(watchCtx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.SentCount).Should(Equal(int64(1)))
+				Ω(sentChanges[0].Email).Should(Equal(deletedEmail))
+			})
+		})
+
+		When("the changes stop before the context is done", func() {
+			It("should return WatchLaggedError", func() {
+				mockWatchService.
+					EXPECT().
+					Subscribe(gomock.Any(), gomock.Any()).
+					Return(changes, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(gomock.Any(), gomock.Any()).
+					Return(&repository.ReadUserResponse{}, nil)
+
+				response, err := sut.WatchUsers(watchCtx, &request)
+				Ω(err).Should(BeNil())
+				Ω(business.IsWatchLaggedError(response.Err)).Should(BeTrue())
+				Ω(response.SentCount).Should(Equal(int64(2)))
+			})
+		})
+
+		When("watch service Subscribe returns error", func() {
+			It("should return the same error", func() {
+				expectedError := errors.New(cuid.New())
+				mockWatchService.
+					EXPECT().
+					Subscribe(gomock.Any(), gomock.Any()).
+					Return(nil, expectedError)
+
+				response, err := sut.WatchUsers(watchCtx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+			})
+		})
+
+		When("user repository ReadUser returns error", func() {
+			It("should return the same error", func() {
+				expectedError := errors.New(cuid.New())
+				mockWatchService.
+					EXPECT().
+					Subscribe(gomock.Any(), gomock.Any()).
+					Return(changes, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(gomock.Any(), gomock.Any()).
+					Return(nil, expectedError)
+
+				response, err := sut.WatchUsers(watchCtx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+				Ω(sentChanges).Should(BeEmpty())
+			})
+		})
+
+		When("sending the change fails", func() {
+			It("should return the same error", func() {
+				expectedError := errors.New(cuid.New())
+				request.Send = func(models.UserChange) error {
+					return expectedError
+				}
+
+				mockWatchService.
+					EXPECT().
+					Subscribe(gomock.Any(), gomock.Any()).
+					Return(changes, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(gomock.Any(), gomock.Any()).
+					Return(&repository.ReadUserResponse{}, nil)
+
+				response, err := sut.WatchUsers(watchCtx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+				Ω(response.SentCount).Should(BeZero())
+			})
+		})
+	})
+
+	Describe("API keys", func() {
+		var (
+			email  string
+			userID string
+			apiKey models.APIKey
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+			userID = cuid.New()
+			apiKey = models.APIKey{
+				KeyID:     cuid.New(),
+				UserID:    userID,
+				Name:      cuid.New(),
+				Scopes:    []models.APIKeyScope{models.APIKeyScopeRead},
+				CreatedAt: now,
+			}
+		})
+
+		Describe("CreateAPIKey is called", func() {
+			var request business.CreateAPIKeyRequest
+
+			BeforeEach(func() {
+				expiresAt := now.Add(time.Hour)
+				request = business.CreateAPIKeyRequest{
+					Email:     email,
+					Name:      apiKey.Name,
+					Scopes:    apiKey.Scopes,
+					ExpiresAt: &expiresAt,
+				}
+			})
+
+			When("the user exists", func() {
+				It("should mint the API key and return the key", func() {
+					key := apikey.KeyPrefix + cuid.New()
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{Email: email, User: models.User{ID: userID}}, nil)
+
+					mockAPIKeyService.
+						EXPECT().
+						CreateAPIKey(ctx, userID, request.Name, request.Scopes, request.ExpiresAt).
+						Return(&apiKey, key, nil)
+
+					response, err := sut.CreateAPIKey(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.APIKey).Should(Equal(apiKey))
+					Ω(response.Key).Should(Equal(key))
+				})
+			})
+
+			When("user repository ReadUser returns error", func() {
+				It("should return the same error without minting the API key", func() {
+					expectedError := commonErrors.NewNotFoundError()
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.CreateAPIKey(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("API key service CreateAPIKey returns error", func() {
+				It("should return the same error", func() {
+					expectedError := apikey.NewLimitExceededError(userID, 10)
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(gomock.Any(), gomock.Any()).
+						Return(&repository.ReadUserResponse{}, nil)
+
+					mockAPIKeyService.
+						EXPECT().
+						CreateAPIKey(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, "", expectedError)
+
+					response, err := sut.CreateAPIKey(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+					Ω(response.Key).Should(BeEmpty())
+				})
+			})
+		})
+
+		Describe("ListAPIKeys is called", func() {
+			BeforeEach(func() {
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(gomock.Any(), gomock.Any()).
+					Return(&repository.ReadUserResponse{Email: email, User: models.User{ID: userID}}, nil).
+					AnyTimes()
+			})
+
+			When("API key service ListAPIKeys succeeds", func() {
+				It("should return the API keys of the user", func() {
+					mockAPIKeyService.
+						EXPECT().
+						ListAPIKeys(ctx, userID).
+						Return([]models.APIKey{apiKey}, nil)
+
+					response, err := sut.ListAPIKeys(ctx, &business.ListAPIKeysRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.APIKeys).Should(Equal([]models.APIKey{apiKey}))
+				})
+			})
+
+			When("the user is named by its ID", func() {
+				It("should return the API keys of the user", func() {
+					mockAPIKeyService.
+						EXPECT().
+						ListAPIKeys(ctx, userID).
+						Return([]models.APIKey{apiKey}, nil)
+
+					response, err := sut.ListAPIKeys(ctx, &business.ListAPIKeysRequest{UserID: userID})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.APIKeys).Should(Equal([]models.APIKey{apiKey}))
+				})
+
+				It("should return NotFoundError if the caller is only allowed to access its own user and the user is not its user", func() {
+					ownerCtx := context.WithValue(ctx, models.ContextKeyParsedToken, models.ParsedToken{
+						Email:     cuid.New() + "@test.com",
+						OwnerOnly: true,
+					})
+
+					mockAPIKeyService.
+						EXPECT().
+						ListAPIKeys(gomock.Any(), gomock.Any()).
+						Times(0)
+
+					response, err := sut.ListAPIKeys(ownerCtx, &business.ListAPIKeysRequest{UserID: userID})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsNotFoundError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("API key service ListAPIKeys returns error", func() {
+				It("should return the same error", func() {
+					expectedError := errors.New(cuid.New())
+					mockAPIKeyService.
+						EXPECT().
+						ListAPIKeys(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.ListAPIKeys(ctx, &business.ListAPIKeysRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+		})
+
+		Describe("RevokeAPIKey is called", func() {
+			BeforeEach(func() {
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(gomock.Any(), gomock.Any()).
+					Return(&repository.ReadUserResponse{Email: email, User: models.User{ID: userID}}, nil).
+					AnyTimes()
+			})
+
+			When("API key service RevokeAPIKey succeeds", func() {
+				It("should revoke the API key of the user", func() {
+					mockAPIKeyService.
+						EXPECT().
+						RevokeAPIKey(ctx, userID, apiKey.KeyID).
+						Return(nil)
+
+					response, err := sut.RevokeAPIKey(ctx, &business.RevokeAPIKeyRequest{Email: email, KeyID: apiKey.KeyID})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+			})
+
+			When("API key service RevokeAPIKey returns error", func() {
+				It("should return the same error", func() {
+					expectedError := commonErrors.NewNotFoundError()
+					mockAPIKeyService.
+						EXPECT().
+						RevokeAPIKey(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(expectedError)
+
+					response, err := sut.RevokeAPIKey(ctx, &business.RevokeAPIKeyRequest{Email: email, KeyID: apiKey.KeyID})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+		})
+	})
+
+	Describe("account lockout", func() {
+		var (
+			email    string
+			cursor   string
+			lockedAt time.Time
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+			cursor = cuid.New()
+			lockedAt = now
+
+			mockConfigurationService.
+				EXPECT().
+				GetLockoutThreshold().
+				Return(3, nil).
+				AnyTimes()
+		})
+
+		Describe("RecordLoginAttempt is called", func() {
+			When("a failed attempt locks the user", func() {
+				It("should record the attempt and publish the user update", func() {
+					user := models.User{FailedLoginAttempts: 3, LockedAt: &lockedAt}
+					mockRepositoryService.
+						EXPECT().
+						RecordLoginAttempt(ctx, &repository.RecordLoginAttemptRequest{
+							Email:            email,
+							Succeeded:        false,
+							LockoutThreshold: 3,
+							AttemptedAt:      now,
+						}).
+						Return(&repository.RecordLoginAttemptResponse{User: user, Cursor: cursor}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserUpdated(ctx, &eventing.UserUpdatedEvent{Email: email, User: user, Cursor: cursor}).
+						Return(nil)
+
+					response, err := sut.RecordLoginAttempt(ctx, &business.RecordLoginAttemptRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.User).Should(Equal(user))
+					Ω(response.Cursor).Should(Equal(cursor))
+				})
+			})
+
+			When("a failed attempt does not lock the user", func() {
+				It("should record the attempt without publishing the user update", func() {
+					user := models.User{FailedLoginAttempts: 1}
+					mockRepositoryService.
+						EXPECT().
+						RecordLoginAttempt(ctx, gomock.Any()).
+						Return(&repository.RecordLoginAttemptResponse{User: user, Cursor: cursor}, nil)
+
+					response, err := sut.RecordLoginAttempt(ctx, &business.RecordLoginAttemptRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.User).Should(Equal(user))
+				})
+			})
+
+			When("a failed attempt is recorded for the user that is already locked", func() {
+				It("should not publish the user update again", func() {
+					user := models.User{FailedLoginAttempts: 4, LockedAt: &lockedAt}
+					mockRepositoryService.
+						EXPECT().
+						RecordLoginAttempt(ctx, gomock.Any()).
+						Return(&repository.RecordLoginAttemptResponse{User: user, Cursor: cursor}, nil)
+
+					response, err := sut.RecordLoginAttempt(ctx, &business.RecordLoginAttemptRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+			})
+
+			When("a successful attempt is recorded", func() {
+				It("should pass the outcome to the repository", func() {
+					mockRepositoryService.
+						EXPECT().
+						RecordLoginAttempt(ctx, &repository.RecordLoginAttemptRequest{
+							Email:            email,
+							Succeeded:        true,
+							LockoutThreshold: 3,
+							AttemptedAt:      now,
+						}).
+						Return(&repository.RecordLoginAttemptResponse{Cursor: cursor}, nil)
+
+					response, err := sut.RecordLoginAttempt(ctx, &business.RecordLoginAttemptRequest{Email: email, Succeeded: true})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+				})
+			})
+
+			When("repository service RecordLoginAttempt returns error", func() {
+				It("should return the same error", func() {
+					expectedError := commonErrors.NewNotFoundError()
+					mockRepositoryService.
+						EXPECT().
+						RecordLoginAttempt(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.RecordLoginAttempt(ctx, &business.RecordLoginAttemptRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+		})
+
+		Describe("UnlockUser is called", func() {
+			When("the user exists", func() {
+				It("should unlock the user, audit the update and publish the user update", func() {
+					user := models.User{}
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: models.User{FailedLoginAttempts: 3, LockedAt: &lockedAt}}, nil)
+
+					mockRepositoryService.
+						EXPECT().
+						UnlockUser(ctx, &repository.UnlockUserRequest{Email: email}).
+						Return(&repository.UnlockUserResponse{User: user, Cursor: cursor}, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserUpdated(ctx, &eventing.UserUpdatedEvent{Email: email, User: user, Cursor: cursor}).
+						Return(nil)
+
+					response, err := sut.UnlockUser(ctx, &business.UnlockUserRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.User).Should(Equal(user))
+					Ω(response.Cursor).Should(Equal(cursor))
+					Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationUpdate}))
+				})
+			})
+
+			When("repository service ReadUser returns error", func() {
+				It("should return the same error without unlocking the user", func() {
+					expectedError := commonErrors.NewNotFoundError()
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.UnlockUser(ctx, &business.UnlockUserRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("repository service UnlockUser returns error", func() {
+				It("should return the same error", func() {
+					expectedError := commonErrors.NewUnknownError(cuid.New())
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(gomock.Any(), gomock.Any()).
+						Return(&repository.ReadUserResponse{}, nil)
+
+					mockRepositoryService.
+						EXPECT().
+						UnlockUser(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.UnlockUser(ctx, &business.UnlockUserRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+		})
+	})
+
+	Describe("multi-factor authentication", func() {
+		var (
+			email  string
+			cursor string
+			method models.MFAMethod
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+			cursor = cuid.New()
+			method = models.MFAMethod{
+				MethodID:             cuid.New(),
+				Type:                 models.MFAMethodTypeWebAuthn,
+				Name:                 cuid.New(),
+				WebAuthnCredentialID: cuid.New(),
+				EnrolledAt:           now,
+			}
+		})
+
+		Describe("EnrollMFA is called", func() {
+			var request business.EnrollMFARequest
+
+			BeforeEach(func() {
+				request = business.EnrollMFARequest{
+					Email:                email,
+					Type:                 method.Type,
+					Name:                 method.Name,
+					WebAuthnCredentialID: method.WebAuthnCredentialID,
+				}
+			})
+
+			When("the user exists", func() {
+				It("should add the method with a new ID and audit the update", func() {
+					var addedMethod models.MFAMethod
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: models.User{}}, nil)
+
+					mockRepositoryService.
+						EXPECT().
+						AddMFAMethod(ctx, gomock.Any()).
+						DoAndReturn(func(_ context.Context, request *repository.AddMFAMethodRequest) (*repository.AddMFAMethodResponse, error) {
+							Ω(request.Email).Should(Equal(email))
+							addedMethod = request.Method
+
+							return &repository.AddMFAMethodResponse{
+								User:   models.User{MFAMethods: []models.MFAMethod{request.Method}},
+								Cursor: cursor,
+							}, nil
+						})
+
+					response, err := sut.EnrollMFA(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(addedMethod.MethodID).ShouldNot(BeEmpty())
+
+					method.MethodID = addedMethod.MethodID
+					Ω(addedMethod).Should(Equal(method))
+					Ω(response.Method).Should(Equal(method))
+					Ω(response.User.MFAMethods).Should(Equal([]models.MFAMethod{method}))
+					Ω(response.Cursor).Should(Equal(cursor))
+					Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationUpdate}))
+				})
+			})
+
+			When("repository service ReadUser returns error", func() {
+				It("should return the same error without adding the method", func() {
+					expectedError := commonErrors.NewNotFoundError()
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.EnrollMFA(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("repository service AddMFAMethod returns error", func() {
+				It("should return the same error without auditing the update", func() {
+					expectedError := commonErrors.NewAlreadyExistsError()
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(gomock.Any(), gomock.Any()).
+						Return(&repository.ReadUserResponse{}, nil)
+
+					mockRepositoryService.
+						EXPECT().
+						AddMFAMethod(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.EnrollMFA(ctx, &request)
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+					Ω(recordedOperations).Should(BeEmpty())
+				})
+			})
+		})
+
+		Describe("ListMFAMethods is called", func() {
+			When("the user is enrolled in MFA methods", func() {
+				It("should return the methods", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: models.User{MFAMethods: []models.MFAMethod{method}}}, nil)
+
+					response, err := sut.ListMFAMethods(ctx, &business.ListMFAMethodsRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.Methods).Should(Equal([]models.MFAMethod{method}))
+				})
+			})
+
+			When("the user is not enrolled in any MFA method", func() {
+				It("should return an empty list", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: models.User{}}, nil)
+
+					response, err := sut.ListMFAMethods(ctx, &business.ListMFAMethodsRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.Methods).ShouldNot(BeNil())
+					Ω(response.Methods).Should(BeEmpty())
+				})
+			})
+
+			When("repository service ReadUser returns error", func() {
+				It("should return the same error", func() {
+					expectedError := commonErrors.NewNotFoundError()
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.ListMFAMethods(ctx, &business.ListMFAMethodsRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+		})
+
+		Describe("RemoveMFAMethod is called", func() {
+			When("the user is enrolled in the method", func() {
+				It("should remove the method and audit the update", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: models.User{MFAMethods: []models.MFAMethod{method}}}, nil)
+
+					mockRepositoryService.
+						EXPECT().
+						RemoveMFAMethod(ctx, &repository.RemoveMFAMethodRequest{Email: email, MethodID: method.MethodID}).
+						Return(&repository.RemoveMFAMethodResponse{User: models.User{}, Cursor: cursor}, nil)
+
+					response, err := sut.RemoveMFAMethod(ctx, &business.RemoveMFAMethodRequest{Email: email, MethodID: method.MethodID})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.User).Should(Equal(models.User{}))
+					Ω(response.Cursor).Should(Equal(cursor))
+					Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationUpdate}))
+				})
+			})
+
+			When("repository service RemoveMFAMethod returns error", func() {
+				It("should return the same error without auditing the update", func() {
+					expectedError := commonErrors.NewNotFoundError()
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(gomock.Any(), gomock.Any()).
+						Return(&repository.ReadUserResponse{}, nil)
+
+					mockRepositoryService.
+						EXPECT().
+						RemoveMFAMethod(gomock.Any(), gomock.Any()).
+						Return(nil, expectedError)
+
+					response, err := sut.RemoveMFAMethod(ctx, &business.RemoveMFAMethodRequest{Email: email, MethodID: method.MethodID})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+					Ω(recordedOperations).Should(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("avatars", func() {
+		var (
+			email  string
+			cursor string
+			png    []byte
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+			cursor = cuid.New()
+			png = append([]byte("\x89PNG\x0D\x0A\x1A\x0A"), []byte(cuid.New())...)
+
+			mockConfigurationService.
+				EXPECT().
+				GetAvatarMaxSize().
+				Return(1024, nil).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetAvatarAllowedContentTypes().
+				Return([]string{"image/png", "image/jpeg"}, nil).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetAvatarURLExpiry().
+				Return(time.Hour, nil).
+				AnyTimes()
+
+			avatarsEnabled = true
+			sut, _ = business.NewBusinessService(newDependencies())
+		})
+
+		When("the avatars are not enabled", func() {
+			It("should return FeatureDisabledError from every operation", func() {
+				avatarsEnabled = false
+				sut, _ = business.NewBusinessService(newDependencies())
+
+				getResponse, err := sut.GetUserAvatar(ctx, &business.GetUserAvatarRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(business.IsFeatureDisabledError(getResponse.Err)).Should(BeTrue())
+
+				setResponse, err := sut.SetUserAvatar(ctx, &business.SetUserAvatarRequest{Email: email, ContentType: "image/png", Content: png})
+				Ω(err).Should(BeNil())
+				Ω(business.IsFeatureDisabledError(setResponse.Err)).Should(BeTrue())
+			})
+		})
+
+		Describe("GetUserAvatar is called", func() {
+			When("the user has no avatar", func() {
+				It("should return NotFoundError", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: models.User{}}, nil)
+
+					response, err := sut.GetUserAvatar(ctx, &business.GetUserAvatarRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsNotFoundError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the user has an avatar", func() {
+				It("should return the presigned URL of the avatar image", func() {
+					avatar := models.Avatar{ObjectKey: cuid.New(), ContentHash: cuid.New()}
+					url := "https://" + cuid.New()
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: models.User{Avatar: &avatar}}, nil)
+
+					mockObjectStorageService.
+						EXPECT().
+						PresignGetObject(ctx, avatar.ObjectKey, time.Hour).
+						Return(url, nil)
+
+					response, err := sut.GetUserAvatar(ctx, &business.GetUserAvatarRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.URL).Should(Equal(url))
+					Ω(response.ContentHash).Should(Equal(avatar.ContentHash))
+					Ω(response.ExpiresAt).Should(Equal(now.Add(time.Hour)))
+				})
+			})
+		})
+
+		Describe("SetUserAvatar is called", func() {
+			When("the avatar image is larger than the maximum size", func() {
+				It("should return ArgumentError without storing the image", func() {
+					response, err := sut.SetUserAvatar(ctx, &business.SetUserAvatarRequest{Email: email, ContentType: "image/png", Content: make([]byte, 1025)})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the content type is not allowed", func() {
+				It("should return ArgumentError without storing the image", func() {
+					response, err := sut.SetUserAvatar(ctx, &business.SetUserAvatarRequest{Email: email, ContentType: "image/gif", Content: []byte("GIF89a")})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the content does not match the content type", func() {
+				It("should return ArgumentError without storing the image", func() {
+					response, err := sut.SetUserAvatar(ctx, &business.SetUserAvatarRequest{Email: email, ContentType: "image/jpeg", Content: png})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("object storage service PutObject returns error", func() {
+				It("should return the same error without updating the user", func() {
+					expectedError := commonErrors.NewUnknownError(cuid.New())
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: models.User{}}, nil)
+
+					mockObjectStorageService.
+						EXPECT().
+						PutObject(ctx, gomock.Any()).
+						Return(expectedError)
+
+					response, err := sut.SetUserAvatar(ctx, &business.SetUserAvatarRequest{Email: email, ContentType: "image/png", Content: png})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+					Ω(recordedOperations).Should(BeEmpty())
+				})
+			})
+
+			When("the avatar image is accepted", func() {
+				It("should store the image, refer to it from the user and delete the image set before", func() {
+					previousAvatar := models.Avatar{ObjectKey: cuid.New(), ContentHash: cuid.New()}
+					contentHash := sha256.Sum256(png)
+					url := "https://" + cuid.New()
+					var storedObject *objectstorage.Object
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: models.User{Avatar: &previousAvatar}}, nil)
+
+					mockObjectStorageService.
+						EXPECT().
+						PutObject(ctx, gomock.Any()).
+						DoAndReturn(func(_ context.Context, object *objectstorage.Object) error {
+							storedObject = object
+
+							return nil
+						})
+
+					mockRepositoryService.
+						EXPECT().
+						SetUserAvatar(ctx, gomock.Any()).
+						DoAndReturn(func(_ context.Context, request *repository.SetUserAvatarRequest) (*repository.SetUserAvatarResponse, error) {
+							Ω(request.Email).Should(Equal(email))
+							Ω(request.Avatar.ObjectKey).Should(Equal(storedObject.Key))
+							Ω(request.Avatar.ContentHash).Should(Equal(hex.EncodeToString(contentHash[:])))
+
+							return &repository.SetUserAvatarResponse{
+								User:   models.User{Avatar: &request.Avatar},
+								Cursor: cursor,
+							}, nil
+						})
+
+					mockObjectStorageService.
+						EXPECT().
+						DeleteObject(ctx, previousAvatar.ObjectKey).
+						Return(nil)
+
+					mockObjectStorageService.
+						EXPECT().
+						PresignGetObject(ctx, gomock.Any(), time.Hour).
+						Return(url, nil)
+
+					mockEventingService.
+						EXPECT().
+						PublishUserUpdated(ctx, gomock.Any()).
+						Return(nil)
+
+					response, err := sut.SetUserAvatar(ctx, &business.SetUserAvatarRequest{Email: email, ContentType: "image/png", Content: png})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(storedObject.ContentType).Should(Equal("image/png"))
+					Ω(storedObject.Content).Should(Equal(png))
+					Ω(storedObject.Key).ShouldNot(ContainSubstring(email))
+					Ω(response.URL).Should(Equal(url))
+					Ω(response.ContentHash).Should(Equal(hex.EncodeToString(contentHash[:])))
+					Ω(response.Cursor).Should(Equal(cursor))
+					Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationUpdate}))
+				})
+			})
+		})
+	})
+
+	Describe("personal data", func() {
+		var (
+			email       string
+			userID      string
+			tokenSecret string
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+			userID = cuid.New()
+			tokenSecret = cuid.New()
+
+			mockConfigurationService.
+				EXPECT().
+				GetErasureTokenSecret().
+				DoAndReturn(func() (string, error) { return tokenSecret, nil }).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetErasureGracePeriod().
+				Return(24*time.Hour, nil).
+				AnyTimes()
+
+			mockConfigurationService.
+				EXPECT().
+				GetErasureConfirmationWindow().
+				Return(7*24*time.Hour, nil).
+				AnyTimes()
+		})
+
+		Describe("ExportPersonalData is called", func() {
+			When("user repository ReadUser returns error", func() {
+				It("should return the same error", func() {
+					expectedError := commonErrors.NewNotFoundError()
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(nil, expectedError)
+
+					response, err := sut.ExportPersonalData(ctx, &business.ExportPersonalDataRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+					Ω(response.Archive).Should(BeNil())
+				})
+			})
+
+			When("the user exists", func() {
+				It("should return the archive of the user without the hashes of the secrets", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: models.User{
+							ID:                         userID,
+							EmailVerificationTokenHash: cuid.New(),
+							Labels:                     map[string]string{"plan": "pro"},
+							MFAMethods: []models.MFAMethod{{
+								MethodID:           cuid.New(),
+								Type:               models.MFAMethodTypeRecoveryCodes,
+								RecoveryCodeHashes: []string{cuid.New()},
+							}},
+						}}, nil)
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUserPreferences(ctx, &repository.ReadUserPreferencesRequest{Email: email}).
+						Return(&repository.ReadUserPreferencesResponse{Preferences: map[string]string{models.PreferenceTheme: "dark"}}, nil)
+
+					mockAPIKeyService.
+						EXPECT().
+						ListAPIKeys(ctx, userID).
+						Return([]models.APIKey{{KeyID: cuid.New(), UserID: userID, KeyHash: cuid.New()}}, nil)
+
+					mockConsentService.
+						EXPECT().
+						ListConsents(ctx, email).
+						Return([]models.Consent{{ConsentID: cuid.New(), Email: email, Policy: "terms-of-service", Version: "1"}}, nil)
+
+					selfUpdate := models.AuditRecord{RecordID: cuid.New(), Email: email, ActorEmail: email, CreatedAt: now.Add(-time.Hour)}
+					creation := models.AuditRecord{RecordID: cuid.New(), Email: email, CreatedAt: now.Add(-2 * time.Hour)}
+					madeOnOtherUser := models.AuditRecord{RecordID: cuid.New(), Email: cuid.New() + "@test.com", ActorEmail: email, CreatedAt: now}
+
+					mockAuditService.
+						EXPECT().
+						ListAuditRecords(ctx, &models.AuditRecordFilter{Email: email, Limit: 10000}).
+						Return([]models.AuditRecord{selfUpdate, creation}, nil)
+
+					mockAuditService.
+						EXPECT().
+						ListAuditRecords(ctx, &models.AuditRecordFilter{ActorEmail: email, Limit: 10000}).
+						Return([]models.AuditRecord{madeOnOtherUser, selfUpdate}, nil)
+
+					response, err := sut.ExportPersonalData(ctx, &business.ExportPersonalDataRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(BeNil())
+					Ω(response.FileName).Should(Equal("personal-data.json"))
+
+					archive := struct {
+						Email        string
+						Profile      models.User
+						Preferences  map[string]string
+						APIKeys      []models.APIKey
+						Consents     []models.Consent
+						AuditRecords []models.AuditRecord
+					}{}
+					Ω(json.Unmarshal(response.Archive, &archive)).Should(BeNil())
+					Ω(archive.Email).Should(Equal(email))
+					Ω(archive.Profile.Labels).Should(Equal(map[string]string{"plan": "pro"}))
+					Ω(archive.Profile.EmailVerificationTokenHash).Should(BeEmpty())
+					Ω(archive.Profile.MFAMethods).Should(HaveLen(1))
+					Ω(archive.Profile.MFAMethods[0].RecoveryCodeHashes).Should(BeEmpty())
+					Ω(archive.Preferences).Should(Equal(map[string]string{models.PreferenceTheme: "dark"}))
+					Ω(archive.APIKeys).Should(HaveLen(1))
+					Ω(archive.APIKeys[0].KeyHash).Should(BeEmpty())
+					Ω(archive.Consents).Should(HaveLen(1))
+					Ω(archive.AuditRecords).Should(HaveLen(3))
+					Ω(archive.AuditRecords[0].RecordID).Should(Equal(madeOnOtherUser.RecordID))
+					Ω(archive.AuditRecords[1].RecordID).Should(Equal(selfUpdate.RecordID))
+					Ω(archive.AuditRecords[2].RecordID).Should(Equal(creation.RecordID))
+				})
+			})
+		})
+
+		Describe("EraseUser is called", func() {
+			expectReadUser := func(user models.User) {
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+					Return(&repository.ReadUserResponse{User: user}, nil)
+			}
+
+			requestErasure := func() *business.EraseUserResponse {
+				expectReadUser(models.User{})
+
+				response, err := sut.EraseUser(ctx, &business.EraseUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+
+				return response
+			}
+
+			When("the token secret is not configured", func() {
+				It("should return FeatureDisabledError", func() {
+					tokenSecret = ""
+
+					response, err := sut.EraseUser(ctx, &business.EraseUserRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(business.IsFeatureDisabledError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("user repository ReadUser returns error", func() {
+				It("should return the same error", func() {
+					expectedError := commonErrors.NewNotFoundError()
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(nil, expectedError)
+
+					response, err := sut.EraseUser(ctx, &business.EraseUserRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+				})
+			})
+
+			When("the erasure is requested", func() {
+				It("should return the confirmation token without erasing the user", func() {
+					response := requestErasure()
+					Ω(response.Erased).Should(BeFalse())
+					Ω(response.ConfirmationToken).ShouldNot(BeEmpty())
+					Ω(response.ConfirmableAt).Should(Equal(now.Add(24 * time.Hour)))
+					Ω(response.ExpiresAt).Should(Equal(now.Add(8 * 24 * time.Hour)))
+					Ω(recordedOperations).Should(BeEmpty())
+				})
+			})
+
+			When("the erasure is confirmed before the grace period ends", func() {
+				It("should return ArgumentError", func() {
+					token := requestErasure().ConfirmationToken
+					now = now.Add(time.Hour)
+					expectReadUser(models.User{})
+
+					response, err := sut.EraseUser(ctx, &business.EraseUserRequest{Email: email, ConfirmationToken: token})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+					Ω(response.Erased).Should(BeFalse())
+				})
+			})
+
+			When("the erasure is confirmed after the confirmation token expired", func() {
+				It("should return ArgumentError", func() {
+					token := requestErasure().ConfirmationToken
+					now = now.Add(8 * 24 * time.Hour)
+					expectReadUser(models.User{})
+
+					response, err := sut.EraseUser(ctx, &business.EraseUserRequest{Email: email, ConfirmationToken: token})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the erasure is confirmed with the token of another user", func() {
+				It("should return ArgumentError", func() {
+					token := requestErasure().ConfirmationToken
+					now = now.Add(25 * time.Hour)
+					email = cuid.New() + "@test.com"
+					expectReadUser(models.User{})
+
+					response, err := sut.EraseUser(ctx, &business.EraseUserRequest{Email: email, ConfirmationToken: token})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsArgumentError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the erasure is confirmed after the grace period ends", func() {
+				It("should revoke the API keys, delete the consents, remove the user from the groups, anonymize the audit records and permanently delete the user", func() {
+					token := requestErasure().ConfirmationToken
+					now = now.Add(25 * time.Hour)
+					expectReadUser(models.User{ID: userID})
+
+					activeKeyID := cuid.New()
+					mockAPIKeyService.
+						EXPECT().
+						ListAPIKeys(ctx, userID).
+						Return([]models.APIKey{{KeyID: activeKeyID}, {KeyID: cuid.New(), RevokedAt: &now}}, nil)
+
+					mockAPIKeyService.
+						EXPECT().
+						RevokeAPIKey(ctx, userID, activeKeyID).
+						Return(nil)
+
+					mockConsentService.
+						EXPECT().
+						DeleteConsents(ctx, email).
+						Return(nil)
+
+					mockGroupService.
+						EXPECT().
+						RemoveUserFromGroups(ctx, email).
+						Return(nil)
+
+					mockSessionService.
+						EXPECT().
+						DeleteSessions(ctx, email).
+						Return(nil)
+
+					mockWebhookService.
+						EXPECT().
+						DeleteSubscriptions(ctx, email).
+						Return(nil)
+
+					mockAuditService.
+						EXPECT().
+						AnonymizeAuditRecords(ctx, email).
+						Return("erased-"+cuid.New(), nil)
 
-			When("And user repository UpdateUser return no error", func() {
-				It("should return expected details", func() {
-					expectedResponse := repository.UpdateUserResponse{
-						User:   models.User{},
-						Cursor: cuid.New(),
-					}
 					mockRepositoryService.
 						EXPECT().
-						UpdateUser(gomock.Any(), gomock.Any()).
-						Return(&expectedResponse, nil)
+						DeleteUser(ctx, &repository.DeleteUserRequest{Email: email, SoftDelete: false}).
+						Return(&repository.DeleteUserResponse{}, nil)
 
-					response, err := sut.UpdateUser(ctx, &request)
+					mockEventingService.
+						EXPECT().
+						PublishUserDeleted(ctx, &eventing.UserDeletedEvent{Email: email, SoftDeleted: false}).
+						Return(nil)
+
+					response, err := sut.EraseUser(ctx, &business.EraseUserRequest{Email: email, ConfirmationToken: token})
 					Ω(err).Should(BeNil())
 					Ω(response.Err).Should(BeNil())
-					Ω(response.User).Should(Equal(expectedResponse.User))
+					Ω(response.Erased).Should(BeTrue())
+					Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationErase}))
+				})
+			})
+
+			When("audit service AnonymizeAuditRecords returns error", func() {
+				It("should return the same error without deleting the user", func() {
+					token := requestErasure().ConfirmationToken
+					now = now.Add(25 * time.Hour)
+					expectReadUser(models.User{ID: userID})
+
+					mockAPIKeyService.
+						EXPECT().
+						ListAPIKeys(ctx, userID).
+						Return([]models.APIKey{}, nil)
+
+					mockConsentService.
+						EXPECT().
+						DeleteConsents(ctx, email).
+						Return(nil)
+
+					mockGroupService.
+						EXPECT().
+						RemoveUserFromGroups(ctx, email).
+						Return(nil)
+
+					mockSessionService.
+						EXPECT().
+						DeleteSessions(ctx, email).
+						Return(nil)
+
+					mockWebhookService.
+						EXPECT().
+						DeleteSubscriptions(ctx, email).
+						Return(nil)
+
+					expectedError := commonErrors.NewUnknownError(cuid.New())
+					mockAuditService.
+						EXPECT().
+						AnonymizeAuditRecords(ctx, email).
+						Return("", expectedError)
+
+					response, err := sut.EraseUser(ctx, &business.EraseUserRequest{Email: email, ConfirmationToken: token})
+					Ω(err).Should(BeNil())
+					Ω(response.Err).Should(Equal(expectedError))
+					Ω(response.Erased).Should(BeFalse())
 				})
 			})
 		})
 	})
 
-	Describe("DeleteUser is called", func() {
+	Describe("consents", func() {
 		var (
-			request business.DeleteUserRequest
+			email string
 		)
 
 		BeforeEach(func() {
-			request = business.DeleteUserRequest{
-				Email: cuid.New() + "@test.com",
-			}
+			email = cuid.New() + "@test.com"
 		})
 
-		Context("user service is instantiated", func() {
-			When("DeleteUser is called", func() {
-				It("should call user repository DeleteUser method", func() {
+		Describe("RecordConsent is called", func() {
+			When("the user does not exist", func() {
+				It("should return NotFoundError without recording the consent", func() {
 					mockRepositoryService.
 						EXPECT().
-						DeleteUser(ctx, gomock.Any()).
-						Do(func(_ context.Context, mappedRequest *repository.DeleteUserRequest) {
-							Ω(mappedRequest.Email).Should(Equal(request.Email))
-						}).
-						Return(&repository.DeleteUserResponse{}, nil)
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(nil, commonErrors.NewNotFoundError())
 
-					response, err := sut.DeleteUser(ctx, &request)
+					response, err := sut.RecordConsent(ctx, &business.RecordConsentRequest{Email: email, Policy: "terms-of-service", Version: "2"})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsNotFoundError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the user exists", func() {
+				It("should return the recorded consent", func() {
+					recordedConsent := models.Consent{ConsentID: cuid.New(), Email: email, Policy: "terms-of-service", Version: "2", AcceptedAt: now}
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: models.User{}}, nil)
+
+					mockConsentService.
+						EXPECT().
+						RecordConsent(ctx, email, "terms-of-service", "2").
+						Return(&recordedConsent, nil)
+
+					response, err := sut.RecordConsent(ctx, &business.RecordConsentRequest{Email: email, Policy: "terms-of-service", Version: "2"})
 					Ω(err).Should(BeNil())
 					Ω(response.Err).Should(BeNil())
+					Ω(response.Consent).Should(Equal(recordedConsent))
 				})
 			})
+		})
+
+		Describe("ListConsents is called", func() {
+			It("should return the consents and the outstanding policy versions of the user", func() {
+				consents := []models.Consent{{ConsentID: cuid.New(), Email: email, Policy: "terms-of-service", Version: "1"}}
+				outstandingPolicies := []models.PolicyVersion{{Policy: "terms-of-service", Version: "2"}}
+
+				mockConsentService.
+					EXPECT().
+					ListConsents(ctx, email).
+					Return(consents, nil)
+
+				mockConsentService.
+					EXPECT().
+					ListOutstandingPolicies(ctx, email).
+					Return(outstandingPolicies, nil)
+
+				response, err := sut.ListConsents(ctx, &business.ListConsentsRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.Consents).Should(Equal(consents))
+				Ω(response.OutstandingPolicies).Should(Equal(outstandingPolicies))
+			})
+		})
+
+		Context("UpdateUser requires the consent", func() {
+			BeforeEach(func() {
+				consentOperations = []string{"UpdateUser"}
+				sut, _ = business.NewBusinessService(newDependencies())
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+					Return(&repository.ReadUserResponse{User: models.User{}}, nil)
+			})
+
+			When("the user has not accepted a required policy version", func() {
+				It("should return ConsentRequiredError without updating the user", func() {
+					mockConsentService.
+						EXPECT().
+						ListOutstandingPolicies(ctx, email).
+						Return([]models.PolicyVersion{{Policy: "terms-of-service", Version: "2"}}, nil)
+
+					response, err := sut.UpdateUser(ctx, &business.UpdateUserRequest{Email: email, User: models.User{}})
+					Ω(err).Should(BeNil())
+					Ω(consent.IsConsentRequiredError(response.Err)).Should(BeTrue())
+					Ω(recordedOperations).Should(BeEmpty())
+				})
+			})
+
+			When("the user accepted all the required policy versions", func() {
+				It("should update the user", func() {
+					mockConsentService.
+						EXPECT().
+						ListOutstandingPolicies(ctx, email).
+						Return([]models.PolicyVersion{}, nil)
 
-			When("user repository DeleteUser returns error", func() {
-				It("should return the same error", func() {
-					expectedError := errors.New(cuid.New())
 					mockRepositoryService.
 						EXPECT().
-						DeleteUser(gomock.Any(), gomock.Any()).
-						Return(nil, expectedError)
+						UpdateUser(ctx, gomock.Any()).
+						Return(&repository.UpdateUserResponse{User: models.User{}, Cursor: cuid.New()}, nil)
 
-					response, err := sut.DeleteUser(ctx, &request)
+					mockEventingService.
+						EXPECT().
+						PublishUserUpdated(ctx, gomock.Any()).
+						Return(nil)
+
+					response, err := sut.UpdateUser(ctx, &business.UpdateUserRequest{Email: email, User: models.User{}})
 					Ω(err).Should(BeNil())
-					Ω(response.Err).Should(Equal(expectedError))
+					Ω(response.Err).Should(BeNil())
+					Ω(recordedOperations).Should(Equal([]models.AuditOperation{models.AuditOperationUpdate}))
 				})
 			})
+		})
+	})
 
-			When("user repository DeleteUser completes successfully", func() {
-				It("should return no error", func() {
+	Describe("groups", func() {
+		var (
+			email   string
+			groupID string
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+			groupID = cuid.New()
+		})
+
+		Describe("CreateGroup is called", func() {
+			It("should return the created group", func() {
+				createdGroup := models.Group{GroupID: groupID, Name: "engineering", Members: []string{}, CreatedAt: now, UpdatedAt: now}
+
+				mockGroupService.
+					EXPECT().
+					CreateGroup(ctx, "engineering", "").
+					Return(&createdGroup, nil)
+
+				response, err := sut.CreateGroup(ctx, &business.CreateGroupRequest{Name: "engineering"})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.Group).Should(Equal(createdGroup))
+			})
+
+			It("should return GroupAlreadyExistsError if another group has the name", func() {
+				mockGroupService.
+					EXPECT().
+					CreateGroup(ctx, "engineering", "").
+					Return(nil, group.NewGroupAlreadyExistsError("engineering"))
+
+				response, err := sut.CreateGroup(ctx, &business.CreateGroupRequest{Name: "engineering"})
+				Ω(err).Should(BeNil())
+				Ω(group.IsGroupAlreadyExistsError(response.Err)).Should(BeTrue())
+			})
+		})
+
+		Describe("ListGroups is called", func() {
+			It("should return all the groups if the email address of the user is not provided", func() {
+				groups := []models.Group{{GroupID: groupID, Name: "engineering"}}
+
+				mockGroupService.
+					EXPECT().
+					ListGroups(ctx).
+					Return(groups, nil)
+
+				response, err := sut.ListGroups(ctx, &business.ListGroupsRequest{})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.Groups).Should(Equal(groups))
+			})
+
+			It("should return the groups of the user if the email address of the user is provided", func() {
+				groups := []models.Group{{GroupID: groupID, Name: "engineering", Members: []string{email}}}
+
+				mockGroupService.
+					EXPECT().
+					ListUserGroups(ctx, email).
+					Return(groups, nil)
+
+				response, err := sut.ListGroups(ctx, &business.ListGroupsRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.Groups).Should(Equal(groups))
+			})
+		})
+
+		Describe("AddUserToGroup is called", func() {
+			When("the user does not exist", func() {
+				It("should return NotFoundError without adding the user to the group", func() {
 					mockRepositoryService.
 						EXPECT().
-						DeleteUser(gomock.Any(), gomock.Any()).
-						Return(&repository.DeleteUserResponse{}, nil)
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(nil, commonErrors.NewNotFoundError())
 
-					response, err := sut.DeleteUser(ctx, &request)
+					response, err := sut.AddUserToGroup(ctx, &business.AddUserToGroupRequest{GroupID: groupID, Email: email})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsNotFoundError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the user exists", func() {
+				It("should return the group the user is added to", func() {
+					updatedGroup := models.Group{GroupID: groupID, Name: "engineering", Members: []string{email}}
+
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: models.User{}}, nil)
+
+					mockGroupService.
+						EXPECT().
+						AddMember(ctx, groupID, email).
+						Return(&updatedGroup, nil)
+
+					response, err := sut.AddUserToGroup(ctx, &business.AddUserToGroupRequest{GroupID: groupID, Email: email})
 					Ω(err).Should(BeNil())
 					Ω(response.Err).Should(BeNil())
+					Ω(response.Group).Should(Equal(updatedGroup))
 				})
 			})
 		})
+
+		Describe("RemoveUserFromGroup is called", func() {
+			It("should return the group the user is removed from without reading the user", func() {
+				updatedGroup := models.Group{GroupID: groupID, Name: "engineering", Members: []string{}}
+
+				mockGroupService.
+					EXPECT().
+					RemoveMember(ctx, groupID, email).
+					Return(&updatedGroup, nil)
+
+				response, err := sut.RemoveUserFromGroup(ctx, &business.RemoveUserFromGroupRequest{GroupID: groupID, Email: email})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.Group).Should(Equal(updatedGroup))
+			})
+		})
+	})
+
+	Describe("invitations", func() {
+		var (
+			email             string
+			token             string
+			pendingInvitation models.Invitation
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+			token = cuid.New()
+			pendingInvitation = models.Invitation{
+				InvitationID: cuid.New(),
+				Email:        email,
+				CreatedAt:    now,
+				ExpiresAt:    now.Add(24 * time.Hour),
+			}
+		})
+
+		Describe("InviteUser is called", func() {
+			It("should send the invitation email with the token and return the time the invitation expires at", func() {
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, gomock.Any()).
+					Return(nil, commonErrors.NewNotFoundError())
+
+				mockInvitationService.
+					EXPECT().
+					CreateInvitation(ctx, email, "").
+					Return(&pendingInvitation, token, nil)
+
+				mockMailerService.
+					EXPECT().
+					SendInvitationEmail(ctx, gomock.Any()).
+					Do(func(_ context.Context, invitationEmail *mailer.InvitationEmail) {
+						Ω(invitationEmail.Email).Should(Equal(email))
+						Ω(invitationEmail.Token).Should(Equal(token))
+						Ω(invitationEmail.ExpiresAt).Should(Equal(pendingInvitation.ExpiresAt))
+					}).
+					Return(nil)
+
+				response, err := sut.InviteUser(ctx, &business.InviteUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.ExpiresAt).Should(Equal(pendingInvitation.ExpiresAt))
+			})
+
+			It("should return AlreadyExistsError without inviting the email address if the user already exists", func() {
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, gomock.Any()).
+					Return(&repository.ReadUserResponse{}, nil)
+
+				response, err := sut.InviteUser(ctx, &business.InviteUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(commonErrors.IsAlreadyExistsError(response.Err)).Should(BeTrue())
+			})
+
+			It("should return UnknownError if the invitation email can not be sent", func() {
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, gomock.Any()).
+					Return(nil, commonErrors.NewNotFoundError())
+
+				mockInvitationService.
+					EXPECT().
+					CreateInvitation(ctx, email, "").
+					Return(&pendingInvitation, token, nil)
+
+				mockMailerService.
+					EXPECT().
+					SendInvitationEmail(ctx, gomock.Any()).
+					Return(errors.New(cuid.New()))
+
+				response, err := sut.InviteUser(ctx, &business.InviteUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(commonErrors.IsUnknownError(response.Err)).Should(BeTrue())
+			})
+		})
+
+		Describe("AcceptInvitation is called", func() {
+			It("should create the user of the accepted invitation", func() {
+				expectedResponse := repository.CreateUserResponse{
+					User:   models.User{},
+					Cursor: cuid.New(),
+				}
+
+				mockInvitationService.
+					EXPECT().
+					AcceptInvitation(ctx, email, token).
+					Return(&pendingInvitation, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					CreateUser(ctx, gomock.Any()).
+					Do(func(_ context.Context, mappedRequest *repository.CreateUserRequest) {
+						Ω(mappedRequest.Email).Should(Equal(email))
+					}).
+					Return(&expectedResponse, nil)
+
+				mockEventingService.
+					EXPECT().
+					PublishUserCreated(ctx, gomock.Any()).
+					Return(nil)
+
+				response, err := sut.AcceptInvitation(ctx, &business.AcceptInvitationRequest{Email: email, Token: token})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.User).Should(Equal(expectedResponse.User))
+				Ω(response.Cursor).Should(Equal(expectedResponse.Cursor))
+			})
+
+			It("should return the error without creating the user if the invitation can not be accepted", func() {
+				mockInvitationService.
+					EXPECT().
+					AcceptInvitation(ctx, email, token).
+					Return(nil, invitation.NewInvitationAlreadyAcceptedError(email))
+
+				response, err := sut.AcceptInvitation(ctx, &business.AcceptInvitationRequest{Email: email, Token: token})
+				Ω(err).Should(BeNil())
+				Ω(invitation.IsInvitationAlreadyAcceptedError(response.Err)).Should(BeTrue())
+			})
+
+			It("should release the invitation if the user can not be created", func() {
+				expectedError := commonErrors.NewAlreadyExistsError()
+
+				mockInvitationService.
+					EXPECT().
+					AcceptInvitation(ctx, email, token).
+					Return(&pendingInvitation, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					CreateUser(ctx, gomock.Any()).
+					Return(nil, expectedError)
+
+				mockInvitationService.
+					EXPECT().
+					ReleaseInvitation(ctx, &pendingInvitation).
+					Return(nil)
+
+				response, err := sut.AcceptInvitation(ctx, &business.AcceptInvitationRequest{Email: email, Token: token})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(Equal(expectedError))
+			})
+		})
+	})
+
+	Describe("sessions", func() {
+		var (
+			email     string
+			sessionID string
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+			sessionID = cuid.New()
+		})
+
+		Describe("RegisterSession is called", func() {
+			It("should register the session of the user", func() {
+				request := business.RegisterSessionRequest{Email: email, SessionID: sessionID, DeviceName: "laptop", UserAgent: "Mozilla/5.0", IPAddress: "10.0.0.1"}
+				registeredSession := models.Session{SessionID: sessionID, Email: email, DeviceName: "laptop", UserAgent: "Mozilla/5.0", IPAddress: "10.0.0.1", CreatedAt: now, LastSeenAt: now, ExpiresAt: now.Add(time.Hour)}
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+					Return(&repository.ReadUserResponse{User: models.User{}}, nil)
+
+				mockSessionService.
+					EXPECT().
+					RegisterSession(ctx, &models.Session{SessionID: sessionID, Email: email, DeviceName: "laptop", UserAgent: "Mozilla/5.0", IPAddress: "10.0.0.1"}).
+					Return(&registeredSession, nil)
+
+				response, err := sut.RegisterSession(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.Session).Should(Equal(registeredSession))
+			})
+
+			When("the user does not exist", func() {
+				It("should return NotFoundError without registering the session", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(nil, commonErrors.NewNotFoundError())
+
+					response, err := sut.RegisterSession(ctx, &business.RegisterSessionRequest{Email: email, SessionID: sessionID})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsNotFoundError(response.Err)).Should(BeTrue())
+				})
+			})
+
+			When("the session is revoked", func() {
+				It("should return SessionRevokedError", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{User: models.User{}}, nil)
+
+					mockSessionService.
+						EXPECT().
+						RegisterSession(ctx, gomock.Any()).
+						Return(nil, session.NewSessionRevokedError(sessionID))
+
+					response, err := sut.RegisterSession(ctx, &business.RegisterSessionRequest{Email: email, SessionID: sessionID})
+					Ω(err).Should(BeNil())
+					Ω(session.IsSessionRevokedError(response.Err)).Should(BeTrue())
+				})
+			})
+		})
+
+		Describe("ListSessions is called", func() {
+			It("should return the active sessions of the user", func() {
+				sessions := []models.Session{{SessionID: sessionID, Email: email}}
+
+				mockSessionService.
+					EXPECT().
+					ListSessions(ctx, email).
+					Return(sessions, nil)
+
+				response, err := sut.ListSessions(ctx, &business.ListSessionsRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.Sessions).Should(Equal(sessions))
+			})
+		})
+
+		Describe("RevokeSession is called", func() {
+			It("should return SessionNotFoundError if the user has no active session with the ID", func() {
+				mockSessionService.
+					EXPECT().
+					RevokeSession(ctx, email, sessionID).
+					Return(session.NewSessionNotFoundError(sessionID))
+
+				response, err := sut.RevokeSession(ctx, &business.RevokeSessionRequest{Email: email, SessionID: sessionID})
+				Ω(err).Should(BeNil())
+				Ω(session.IsSessionNotFoundError(response.Err)).Should(BeTrue())
+			})
+		})
+	})
+	Describe("webhooks", func() {
+		var (
+			email          string
+			subscriptionID string
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+			subscriptionID = cuid.New()
+		})
+
+		Describe("CreateWebhookSubscription is called", func() {
+			It("should subscribe the webhook to the events of the user", func() {
+				eventTypes := []models.WebhookEventType{models.WebhookEventUserUpdated}
+				createdSubscription := models.WebhookSubscription{SubscriptionID: subscriptionID, Email: email, URL: "https://example.com/hook", Secret: "0123456789abcdef", EventTypes: eventTypes, CreatedAt: now}
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+					Return(&repository.ReadUserResponse{User: models.User{}}, nil)
+
+				mockWebhookService.
+					EXPECT().
+					CreateSubscription(ctx, &models.WebhookSubscription{Email: email, URL: "https://example.com/hook", Secret: "0123456789abcdef", EventTypes: eventTypes}).
+					Return(&createdSubscription, nil)
+
+				response, err := sut.CreateWebhookSubscription(ctx, &business.CreateWebhookSubscriptionRequest{Email: email, URL: "https://example.com/hook", Secret: "0123456789abcdef", EventTypes: eventTypes})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.Subscription).Should(Equal(createdSubscription))
+			})
+
+			When("the user does not exist", func() {
+				It("should return NotFoundError without creating the subscription", func() {
+					mockRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(nil, commonErrors.NewNotFoundError())
+
+					response, err := sut.CreateWebhookSubscription(ctx, &business.CreateWebhookSubscriptionRequest{Email: email, URL: "https://example.com/hook"})
+					Ω(err).Should(BeNil())
+					Ω(commonErrors.IsNotFoundError(response.Err)).Should(BeTrue())
+				})
+			})
+		})
+
+		Describe("DeleteWebhookSubscription is called", func() {
+			It("should return SubscriptionNotFoundError if the user has no subscription with the ID", func() {
+				mockWebhookService.
+					EXPECT().
+					DeleteSubscription(ctx, email, subscriptionID).
+					Return(webhook.NewSubscriptionNotFoundError(subscriptionID))
+
+				response, err := sut.DeleteWebhookSubscription(ctx, &business.DeleteWebhookSubscriptionRequest{Email: email, SubscriptionID: subscriptionID})
+				Ω(err).Should(BeNil())
+				Ω(webhook.IsSubscriptionNotFoundError(response.Err)).Should(BeTrue())
+			})
+		})
+
+		Describe("ListWebhookDeliveries is called", func() {
+			It("should list the default number of the deliveries if the request does not limit them", func() {
+				deliveries := []models.WebhookDelivery{{DeliveryID: cuid.New(), SubscriptionID: subscriptionID, Email: email}}
+
+				mockWebhookService.
+					EXPECT().
+					ListDeliveries(ctx, email, subscriptionID, business.DefaultWebhookDeliveriesListLimit).
+					Return(deliveries, nil)
+
+				response, err := sut.ListWebhookDeliveries(ctx, &business.ListWebhookDeliveriesRequest{Email: email, SubscriptionID: subscriptionID})
+				Ω(err).Should(BeNil())
+				Ω(response.Err).Should(BeNil())
+				Ω(response.Deliveries).Should(Equal(deliveries))
+			})
+		})
 	})
 })
 
@@ -325,3 +6269,15 @@ func assertArgumentNilError(expectedArgumentName, expectedMessage string, err er
 		Ω(strings.Contains(argumentNilErr.Error(), expectedMessage)).Should(BeTrue())
 	}
 }
+
+func unsetConfigurationEnvironmentVariables() {
+	for _, option := range configuration.Options() {
+		os.Unsetenv(option.EnvironmentVariable)
+	}
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
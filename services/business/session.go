@@ -0,0 +1,89 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/repository"
+)
+
+const (
+	// maxSessionIDLength is the maximum length of the IDs the auth gateway registers the sessions with
+	maxSessionIDLength = 256
+
+	// maxDeviceNameLength is the maximum length of the names of the devices of the sessions
+	maxDeviceNameLength = 256
+
+	// maxUserAgentLength is the maximum length of the user agents of the sessions
+	maxUserAgentLength = 1024
+)
+
+// RegisterSession registers a new session of an existing user or extends an existing one
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the ID of the session, the email address of the user and the device
+// Returns either the registered session or error if something goes wrong.
+func (service *businessService) RegisterSession(
+	ctx context.Context,
+	request *RegisterSessionRequest) (*RegisterSessionResponse, error) {
+	if _, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	}); err != nil {
+		return &RegisterSessionResponse{
+			Err: err,
+		}, nil
+	}
+
+	session, err := service.sessionService.RegisterSession(ctx, &models.Session{
+		SessionID:  request.SessionID,
+		Email:      request.Email,
+		DeviceName: request.DeviceName,
+		UserAgent:  request.UserAgent,
+		IPAddress:  request.IPAddress,
+	})
+
+	if err != nil {
+		return &RegisterSessionResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &RegisterSessionResponse{
+		Session: *session,
+	}, nil
+}
+
+// ListSessions lists the active sessions of a user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the email address of the user
+// Returns either the active sessions or error if something goes wrong.
+func (service *businessService) ListSessions(
+	ctx context.Context,
+	request *ListSessionsRequest) (*ListSessionsResponse, error) {
+	sessions, err := service.sessionService.ListSessions(ctx, request.Email)
+	if err != nil {
+		return &ListSessionsResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &ListSessionsResponse{
+		Sessions: sessions,
+	}, nil
+}
+
+// RevokeSession revokes an active session of a user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the email address of the user and the ID of the session
+// Returns either the result of revoking the session or error if something goes wrong.
+func (service *businessService) RevokeSession(
+	ctx context.Context,
+	request *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	if err := service.sessionService.RevokeSession(ctx, request.Email, request.SessionID); err != nil {
+		return &RevokeSessionResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &RevokeSessionResponse{}, nil
+}
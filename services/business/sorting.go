@@ -0,0 +1,68 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// supportedSortFields are the name of the fields every repository can sort the search result by
+var supportedSortFields = map[string]bool{
+	"email":     true,
+	"createdAt": true,
+	"updatedAt": true,
+	"createdBy": true,
+	"updatedBy": true,
+}
+
+// getSortableFields loads the fields the deployment allows sorting the search result by, the fields the database
+// has indexes for
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns either the sortable fields or error if any of them can not be sorted by
+func getSortableFields(configurationService configuration.ConfigurationContract) ([]string, error) {
+	fields, err := configurationService.GetDatabaseSearchSortableFields()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range fields {
+		if !supportedSortFields[field] {
+			return nil, commonErrors.NewUnknownError(fmt.Sprintf("USER_DATABASE_SEARCH_SORTABLE_FIELDS contains unsupported field: %s", field))
+		}
+	}
+
+	return fields, nil
+}
+
+// validateSortingOptions verifies the search result is only sorted by the sortable fields, so a search never makes
+// the database sort the whole collection in memory
+// sortingOptions: Optional. The sorting options to verify
+// Returns ArgumentError if any of the fields is not sortable
+func (service *businessService) validateSortingOptions(sortingOptions []models.SortingOptionPair) error {
+	for _, sortingOption := range sortingOptions {
+		if !isSortableField(service.sortableFields, sortingOption.Name) {
+			return commonErrors.NewArgumentError(
+				"request.SortingOptions",
+				fmt.Sprintf(
+					"sorting by %s is not allowed as it is not indexed, the search result can be sorted by: %s",
+					sortingOption.Name,
+					strings.Join(service.sortableFields, ", ")))
+		}
+	}
+
+	return nil
+}
+
+func isSortableField(sortableFields []string, field string) bool {
+	for _, sortableField := range sortableFields {
+		if sortableField == field {
+			return true
+		}
+	}
+
+	return false
+}
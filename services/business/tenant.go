@@ -0,0 +1,136 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/eventing"
+	"github.com/decentralized-cloud/user/services/repository"
+)
+
+// maxTenantIDLength is the maximum length of the IDs of the tenants the users can be members of
+const maxTenantIDLength = 128
+
+// AddUserToTenant adds an existing user to a tenant with the given role. The user is added only if it is not a
+// member of the tenant yet, the role of an existing membership is not changed.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the tenant and the role to add the user with
+// Returns either the user after it is added to the tenant or error if something goes wrong.
+func (service *businessService) AddUserToTenant(
+	ctx context.Context,
+	request *AddUserToTenantRequest) (*AddUserToTenantResponse, error) {
+	// The user is read before it is updated so the audit record contains what the update changed
+	readUserResponse, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	})
+
+	if err != nil {
+		return &AddUserToTenantResponse{
+			Err: err,
+		}, nil
+	}
+
+	response, err := service.repositoryService.AddUserToTenant(ctx, &repository.AddUserToTenantRequest{
+		Email: request.Email,
+		Membership: models.TenantMembership{
+			TenantID: request.TenantID,
+			Role:     request.Role,
+			JoinedAt: service.clockService.Now(),
+		},
+	})
+
+	if err != nil {
+		return &AddUserToTenantResponse{
+			Err: err,
+		}, nil
+	}
+
+	service.publishMembershipChange(ctx, request.Email, &readUserResponse.User, response.User, response.Cursor)
+
+	return &AddUserToTenantResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// RemoveUserFromTenant removes an existing user from a tenant
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the tenant to remove the user from
+// Returns either the user after it is removed from the tenant or error if something goes wrong.
+func (service *businessService) RemoveUserFromTenant(
+	ctx context.Context,
+	request *RemoveUserFromTenantRequest) (*RemoveUserFromTenantResponse, error) {
+	// The user is read before it is updated so the audit record contains what the update changed
+	readUserResponse, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	})
+
+	if err != nil {
+		return &RemoveUserFromTenantResponse{
+			Err: err,
+		}, nil
+	}
+
+	response, err := service.repositoryService.RemoveUserFromTenant(ctx, &repository.RemoveUserFromTenantRequest{
+		Email:    request.Email,
+		TenantID: request.TenantID,
+	})
+
+	if err != nil {
+		return &RemoveUserFromTenantResponse{
+			Err: err,
+		}, nil
+	}
+
+	service.publishMembershipChange(ctx, request.Email, &readUserResponse.User, response.User, response.Cursor)
+
+	return &RemoveUserFromTenantResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// ListUserTenants lists the tenants an existing user is a member of
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to list the tenants of an existing user
+// Returns either the memberships of the user or error if something goes wrong.
+func (service *businessService) ListUserTenants(
+	ctx context.Context,
+	request *ListUserTenantsRequest) (*ListUserTenantsResponse, error) {
+	response, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	})
+
+	if err != nil {
+		return &ListUserTenantsResponse{
+			Err: err,
+		}, nil
+	}
+
+	memberships := response.User.Memberships
+	if memberships == nil {
+		memberships = []models.TenantMembership{}
+	}
+
+	return &ListUserTenantsResponse{
+		Memberships: memberships,
+	}, nil
+}
+
+// publishMembershipChange records the change of the memberships in the audit log and notifies the other services the
+// user is updated, the same way an update of the user does
+func (service *businessService) publishMembershipChange(
+	ctx context.Context,
+	email string,
+	before *models.User,
+	after models.User,
+	cursor string) {
+	service.logIgnoredError(ctx, "failed to record the operation in the audit log", service.auditService.RecordOperation(ctx, models.AuditOperationUpdate, email, before, &after))
+
+	service.logIgnoredError(ctx, "failed to publish the user updated event", service.eventingService.PublishUserUpdated(ctx, &eventing.UserUpdatedEvent{
+		Email:  email,
+		User:   after,
+		Cursor: cursor,
+	}))
+}
@@ -0,0 +1,153 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/saga"
+)
+
+// moveUserDataSteps creates the saga steps that move the data stored for the user outside the repository, i.e. the
+// password, the consents, the group memberships and the webhook subscriptions, from one email address to another. The
+// API keys are kept by the ID of the user, so they are not moved. Every step is compensated by moving the data back, so
+// a failed saga leaves the data where it was.
+// email: Mandatory. The email address the data is stored for
+// newEmail: Mandatory. The email address to move the data to
+// Returns the saga steps in the order they must be executed
+func (service *businessService) moveUserDataSteps(email string, newEmail string) []saga.Step {
+	steps := []saga.Step{}
+	if service.passwordCredentialsEnabled {
+		steps = append(steps, saga.Step{
+			Name: "MovePassword",
+			Action: func(ctx context.Context) error {
+				return service.credentialService.ChangeEmail(ctx, email, newEmail)
+			},
+			Compensation: func(ctx context.Context) error {
+				return service.credentialService.ChangeEmail(ctx, newEmail, email)
+			},
+		})
+	}
+
+	return append(steps,
+		saga.Step{
+			Name: "MoveConsents",
+			Action: func(ctx context.Context) error {
+				return service.consentService.ChangeEmail(ctx, email, newEmail)
+			},
+			Compensation: func(ctx context.Context) error {
+				return service.consentService.ChangeEmail(ctx, newEmail, email)
+			},
+		},
+		saga.Step{
+			Name: "MoveGroupMemberships",
+			Action: func(ctx context.Context) error {
+				return service.groupService.ChangeEmail(ctx, email, newEmail)
+			},
+			Compensation: func(ctx context.Context) error {
+				return service.groupService.ChangeEmail(ctx, newEmail, email)
+			},
+		},
+		saga.Step{
+			Name: "MoveWebhookSubscriptions",
+			Action: func(ctx context.Context) error {
+				return service.webhookService.ChangeEmail(ctx, email, newEmail)
+			},
+			Compensation: func(ctx context.Context) error {
+				return service.webhookService.ChangeEmail(ctx, newEmail, email)
+			},
+		})
+}
+
+// deleteUserData deletes the data stored for the user outside the repository the moveUserDataSteps move and revokes
+// the API keys of the user
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address the data is stored for
+// userID: Mandatory. The ID of the user the API keys are kept for
+// Returns error if something goes wrong.
+func (service *businessService) deleteUserData(
+	ctx context.Context,
+	email string,
+	userID string) error {
+	if service.passwordCredentialsEnabled {
+		if err := service.credentialService.DeletePassword(ctx, email); err != nil {
+			return err
+		}
+	}
+
+	if err := service.revokeAPIKeys(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := service.consentService.DeleteConsents(ctx, email); err != nil {
+		return err
+	}
+
+	if err := service.groupService.RemoveUserFromGroups(ctx, email); err != nil {
+		return err
+	}
+
+	return service.webhookService.DeleteSubscriptions(ctx, email)
+}
+
+// purgeUserData deletes everything stored for the user outside the repository before the user is permanently deleted,
+// i.e. the data the deleteUserData deletes, the avatar object and the sessions of the user
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address the data is stored for
+// user: Mandatory. The user to delete the data of
+// Returns error if something goes wrong.
+func (service *businessService) purgeUserData(
+	ctx context.Context,
+	email string,
+	user models.User) error {
+	if err := service.deleteUserData(ctx, email, user.ID); err != nil {
+		return err
+	}
+
+	if err := service.deleteAvatarObject(ctx, user); err != nil {
+		return err
+	}
+
+	return service.sessionService.DeleteSessions(ctx, email)
+}
+
+// deleteAvatarObject deletes the object the avatar of the user is stored in, if the user has any
+func (service *businessService) deleteAvatarObject(
+	ctx context.Context,
+	user models.User) error {
+	if !service.avatarsEnabled || user.Avatar == nil {
+		return nil
+	}
+
+	return service.objectStorageService.DeleteObject(ctx, user.Avatar.ObjectKey)
+}
+
+// revokeAPIKeys revokes the active API keys of the user
+func (service *businessService) revokeAPIKeys(
+	ctx context.Context,
+	userID string) error {
+	apiKeys, err := service.apiKeyService.ListAPIKeys(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, apiKey := range apiKeys {
+		if apiKey.RevokedAt != nil {
+			continue
+		}
+
+		if err = service.apiKeyService.RevokeAPIKey(ctx, userID, apiKey.KeyID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deletedUserDataEmail returns the email address the data of a deleted user is moved to until it is deleted. It is
+// not a valid email address, so no user can ever be created with it, and it is unique to the deletion, so the data
+// of a user deleted again with the same email address is not mixed with it.
+func (service *businessService) deletedUserDataEmail(email string) string {
+	return fmt.Sprintf("deleted:%d:%s", service.clockService.Now().UnixNano(), email)
+}
@@ -0,0 +1,120 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// validationCodes maps the messages of the validation rules to the codes of the validation failures. The validation
+// rules only report the messages, so the codes are derived from them, the more specific messages come first.
+var validationCodes = []struct {
+	messagePrefix string
+	code          string
+}{
+	{messagePrefix: "cannot be blank", code: ValidationCodeRequired},
+	{messagePrefix: "is required", code: ValidationCodeRequired},
+	{messagePrefix: "the length must be", code: ValidationCodeLength},
+	{messagePrefix: "the value must be empty", code: ValidationCodeLength},
+	{messagePrefix: "must be a valid value", code: ValidationCodeNotAllowed},
+	{messagePrefix: "must not be in list", code: ValidationCodeNotAllowed},
+	{messagePrefix: "must be blank", code: ValidationCodeNotAllowed},
+	{messagePrefix: "must belong to one of the allowed", code: ValidationCodeNotAllowed},
+	{messagePrefix: "must be no less than", code: ValidationCodeRange},
+	{messagePrefix: "must be no greater than", code: ValidationCodeRange},
+	{messagePrefix: "must be less than", code: ValidationCodeRange},
+	{messagePrefix: "must be greater than", code: ValidationCodeRange},
+	{messagePrefix: "must be in a valid format", code: ValidationCodeFormat},
+	{messagePrefix: "must be a valid", code: ValidationCodeFormat},
+}
+
+// NewRequestValidationError creates the error the invalid requests fail with, reporting every invalid field of the
+// request along the code and the message of the failure
+// err: Mandatory. The error the validation of the request failed with
+// Returns the argument error that wraps the validation error
+func NewRequestValidationError(err error) error {
+	var failures []ValidationFailure
+
+	var validationErrors validation.Errors
+	if errors.As(err, &validationErrors) {
+		failures = appendValidationFailures(nil, "", validationErrors)
+	} else {
+		failures = []ValidationFailure{newValidationFailure("", err)}
+	}
+
+	return commonErrors.NewArgumentErrorWithError("request", "", NewValidationError(failures, err))
+}
+
+func appendValidationFailures(
+	failures []ValidationFailure,
+	path string,
+	validationErrors validation.Errors) []ValidationFailure {
+	// The fields are sorted, so the same invalid request is always reported the same way
+	fields := make([]string, 0, len(validationErrors))
+	for field := range validationErrors {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		fieldPath := strings.TrimPrefix(path+"."+toContractFieldName(field), ".")
+
+		var nestedErrors validation.Errors
+		if errors.As(validationErrors[field], &nestedErrors) {
+			failures = appendValidationFailures(failures, fieldPath, nestedErrors)
+
+			continue
+		}
+
+		failures = append(failures, newValidationFailure(fieldPath, validationErrors[field]))
+	}
+
+	return failures
+}
+
+// toContractFieldName converts the name of the field of the request to the name it has in the GRPC contract, e.g.
+// TOTPSecret to totpSecret, the indexes of the items of the lists are not changed
+func toContractFieldName(field string) string {
+	runes := []rune(field)
+
+	upperCaseCount := 0
+	for upperCaseCount < len(runes) && unicode.IsUpper(runes[upperCaseCount]) {
+		upperCaseCount++
+	}
+
+	// The last of the leading upper case letters starts the next word unless the whole name is upper case
+	if upperCaseCount > 1 && upperCaseCount < len(runes) {
+		upperCaseCount--
+	}
+
+	for index := 0; index < upperCaseCount; index++ {
+		runes[index] = unicode.ToLower(runes[index])
+	}
+
+	return string(runes)
+}
+
+func newValidationFailure(field string, err error) ValidationFailure {
+	message := err.Error()
+	code := ValidationCodeInvalid
+
+	for _, validationCode := range validationCodes {
+		if strings.HasPrefix(message, validationCode.messagePrefix) {
+			code = validationCode.code
+
+			break
+		}
+	}
+
+	return ValidationFailure{
+		Field:   field,
+		Code:    code,
+		Message: message,
+	}
+}
@@ -0,0 +1,217 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	validation "github.com/go-ozzo/ozzo-validation"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+const (
+	// labelsFieldPrefix prefixes the names of the policy fields that apply to a label, e.g. labels.department
+	labelsFieldPrefix = "labels."
+
+	// apiKeyNameField is the name of the policy field that applies to the names of the API keys
+	apiKeyNameField = "apiKeyName"
+
+	// mfaMethodNameField is the name of the policy field that applies to the names of the MFA methods
+	mfaMethodNameField = "mfaMethodName"
+)
+
+// validationPolicy contains the validation rules the deployment adds to the built-in validation rules of the requests
+type validationPolicy struct {
+	allowedEmailDomains map[string]bool
+	patterns            map[string]*regexp.Regexp
+	requiredFields      []string
+}
+
+// newValidationPolicy loads the validation policy of the deployment from the configuration
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns either the validation policy or error if the policy refers to unknown fields or contains invalid patterns
+func newValidationPolicy(configurationService configuration.ConfigurationContract) (validationPolicy, error) {
+	domains, err := configurationService.GetValidationPolicyAllowedEmailDomains()
+	if err != nil {
+		return validationPolicy{}, err
+	}
+
+	fieldPatterns, err := configurationService.GetValidationPolicyFieldPatterns()
+	if err != nil {
+		return validationPolicy{}, err
+	}
+
+	requiredFields, err := configurationService.GetValidationPolicyRequiredFields()
+	if err != nil {
+		return validationPolicy{}, err
+	}
+
+	policy := validationPolicy{
+		allowedEmailDomains: map[string]bool{},
+		patterns:            map[string]*regexp.Regexp{},
+		requiredFields:      requiredFields,
+	}
+
+	for _, domain := range domains {
+		policy.allowedEmailDomains[strings.ToLower(domain)] = true
+	}
+
+	for field, pattern := range fieldPatterns {
+		if !isPolicyUserField(field) && field != "email" && field != apiKeyNameField && field != mfaMethodNameField {
+			return validationPolicy{}, commonErrors.NewUnknownError(fmt.Sprintf("VALIDATION_POLICY_FIELD_PATTERNS contains unsupported field: %s", field))
+		}
+
+		if policy.patterns[field], err = regexp.Compile(pattern); err != nil {
+			return validationPolicy{}, commonErrors.NewUnknownErrorWithError(fmt.Sprintf("VALIDATION_POLICY_FIELD_PATTERNS contains invalid pattern for %s", field), err)
+		}
+	}
+
+	for _, field := range requiredFields {
+		if !isPolicyUserField(field) {
+			return validationPolicy{}, commonErrors.NewUnknownError(fmt.Sprintf("VALIDATION_POLICY_REQUIRED_FIELDS contains unsupported field: %s", field))
+		}
+	}
+
+	return policy, nil
+}
+
+// validateNewUser validates the email address and the user a new user is created with
+// email: Mandatory. The email address of the new user
+// user: Mandatory. The new user
+// Returns the validation errors keyed by the request field, or nil if the user complies with the policy
+func (policy validationPolicy) validateNewUser(email string, user models.User) error {
+	violations := validation.Errors{}
+
+	if err := policy.validateEmail(email); err != nil {
+		violations["email"] = err
+	}
+
+	if err := policy.validateUser(user, nil); err != nil {
+		violations["user"] = err
+	}
+
+	return violations.Filter()
+}
+
+// validateUpdatedUser validates the user the existing users are updated with. The email address of the existing users
+// is not validated, so the users created before the policy are still updated.
+// user: Mandatory. The updated user
+// updateMask: Optional. The paths of the updated user fields, all the fields are updated if nil
+// Returns the validation errors keyed by the request field, or nil if the user complies with the policy
+func (policy validationPolicy) validateUpdatedUser(user models.User, updateMask []string) error {
+	if err := policy.validateUser(user, updateMask); err != nil {
+		return validation.Errors{"user": err}
+	}
+
+	return nil
+}
+
+// validateName validates the name of an API key or an MFA method
+// field: Mandatory. The name of the policy field the name is validated by, either apiKeyName or mfaMethodName
+// name: Optional. The name to validate, the empty names are only validated by the built-in rules
+// Returns the validation errors keyed by the request field, or nil if the name complies with the policy
+func (policy validationPolicy) validateName(field string, name string) error {
+	if pattern, ok := policy.patterns[field]; ok && name != "" {
+		if err := validation.Validate(name, validation.Match(pattern)); err != nil {
+			return validation.Errors{"name": err}
+		}
+	}
+
+	return nil
+}
+
+// validateEmail validates the email address belongs to one of the allowed domains and matches the pattern set for it
+func (policy validationPolicy) validateEmail(email string) error {
+	if len(policy.allowedEmailDomains) > 0 {
+		domain := strings.ToLower(email[strings.LastIndex(email, "@")+1:])
+		if !policy.allowedEmailDomains[domain] {
+			return errors.New("must belong to one of the allowed email domains")
+		}
+	}
+
+	if pattern, ok := policy.patterns["email"]; ok {
+		return validation.Validate(email, validation.Match(pattern))
+	}
+
+	return nil
+}
+
+// validateUser validates the user has the required fields and its fields match the patterns set for them
+// user: Mandatory. The user to validate
+// updateMask: Optional. The paths of the user fields to validate, all the fields are validated if nil
+// Returns the validation errors keyed by the user field, or nil if the user complies with the policy
+func (policy validationPolicy) validateUser(user models.User, updateMask []string) error {
+	masked := func(field string) bool {
+		if updateMask == nil {
+			return true
+		}
+
+		root := strings.SplitN(field, ".", 2)[0]
+		for _, path := range updateMask {
+			if path == root {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	violations := validation.Errors{}
+	labelViolations := validation.Errors{}
+
+	for _, field := range policy.requiredFields {
+		if !masked(field) {
+			continue
+		}
+
+		if err := validation.Validate(getPolicyUserField(user, field), validation.Required); err != nil {
+			setPolicyViolation(violations, labelViolations, field, err)
+		}
+	}
+
+	for field, pattern := range policy.patterns {
+		if !isPolicyUserField(field) || !masked(field) {
+			continue
+		}
+
+		// The empty fields are only rejected if they are required
+		if value := getPolicyUserField(user, field); value != "" {
+			if err := validation.Validate(value, validation.Match(pattern)); err != nil {
+				setPolicyViolation(violations, labelViolations, field, err)
+			}
+		}
+	}
+
+	if len(labelViolations) > 0 {
+		violations["labels"] = labelViolations
+	}
+
+	return violations.Filter()
+}
+
+// isPolicyUserField indicates whether the policy field is a user field, either the data residency or a label
+func isPolicyUserField(field string) bool {
+	return field == "dataResidency" || (strings.HasPrefix(field, labelsFieldPrefix) && len(field) > len(labelsFieldPrefix))
+}
+
+func getPolicyUserField(user models.User, field string) string {
+	if field == "dataResidency" {
+		return user.DataResidency
+	}
+
+	return user.Labels[strings.TrimPrefix(field, labelsFieldPrefix)]
+}
+
+func setPolicyViolation(violations validation.Errors, labelViolations validation.Errors, field string, err error) {
+	if strings.HasPrefix(field, labelsFieldPrefix) {
+		labelViolations[strings.TrimPrefix(field, labelsFieldPrefix)] = err
+
+		return
+	}
+
+	violations[field] = err
+}
@@ -2,16 +2,44 @@
 package business
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/decentralized-cloud/user/models"
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/go-ozzo/ozzo-validation/is"
 )
 
+const (
+	// maxEmailLength is the maximum length of the email addresses, the longest address a mail server accepts
+	maxEmailLength = 254
+
+	// maxUserIDLength is the maximum length of the user IDs, well above the length of the IDs the repositories assign
+	// including the region prefix
+	maxUserIDLength = 128
+
+	// maxEmails is the maximum number of the email addresses a single request can filter the users by
+	maxEmails = 1000
+
+	// maxLabelSelectorLength is the maximum length of the label selectors, long enough for a requirement on every
+	// label a user can have
+	maxLabelSelectorLength = 8192
+
+	// maxQueryLength is the maximum length of the free-text search queries, a query longer than an email address can
+	// not match any user
+	maxQueryLength = maxEmailLength
+
+	// maxConfirmationTokenLength is the maximum length of the confirmation tokens, well above the length of the
+	// tokens the service signs
+	maxConfirmationTokenLength = 1024
+)
+
 // Validate validates the CreateUserRequest model and return error if the validation failes
 // Returns error if validation failes
 func (val CreateUserRequest) Validate() error {
 	return validation.ValidateStruct(&val,
 		// Check that email address is valid
-		validation.Field(&val.Email, validation.Required, is.Email),
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
 
 		// Validate User using its own validation rules
 		validation.Field(&val.User),
@@ -22,8 +50,37 @@ func (val CreateUserRequest) Validate() error {
 // Returns error if validation failes
 func (val ReadUserRequest) Validate() error {
 	return validation.ValidateStruct(&val,
-		// Check that email address is valid
-		validation.Field(&val.Email, validation.Required, is.Email),
+		// Check that either the email address or the user ID is provided, but not both, and the email address is valid
+		validation.Field(&val.Email, emailOrUserID(val.UserID), validation.Length(1, maxEmailLength), is.Email),
+		validation.Field(&val.UserID, validation.Length(1, maxUserIDLength)),
+
+		// Fields are optional, but must be the user fields the callers can request
+		validation.Field(&val.Fields, validation.Each(validation.By(models.IsUserProjectionField))),
+	)
+}
+
+// Validate validates the ReadUsersRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ReadUsersRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that at least one email address or user ID is provided, up to maxEmails together, and all of them are valid
+		validation.Field(&val.Emails, validation.By(func(value interface{}) error {
+			if len(val.Emails) == 0 && len(val.UserIDs) == 0 {
+				return errors.New("either the email addresses or the user IDs must be provided")
+			}
+
+			return nil
+		}), validation.Length(0, maxEmails), validation.Each(validation.Required, validation.Length(1, maxEmailLength), is.Email)),
+		validation.Field(&val.UserIDs, validation.Each(validation.Required, validation.Length(1, maxUserIDLength)), validation.By(func(value interface{}) error {
+			if len(val.UserIDs) > 0 && len(val.Emails)+len(val.UserIDs) > maxEmails {
+				return fmt.Errorf("at most %d email addresses and user IDs can be read together", maxEmails)
+			}
+
+			return nil
+		})),
+
+		// Fields are optional, but must be the user fields the callers can request
+		validation.Field(&val.Fields, validation.Each(validation.By(models.IsUserProjectionField))),
 	)
 }
 
@@ -31,19 +88,808 @@ func (val ReadUserRequest) Validate() error {
 // Returns error if validation failes
 func (val UpdateUserRequest) Validate() error {
 	return validation.ValidateStruct(&val,
-		// Check that email address is valid
-		validation.Field(&val.Email, validation.Required, is.Email),
+		// Check that either the email address or the user ID is provided, but not both, and the email address is valid
+		validation.Field(&val.Email, emailOrUserID(val.UserID), validation.Length(1, maxEmailLength), is.Email),
+		validation.Field(&val.UserID, validation.Length(1, maxUserIDLength)),
 
 		// Validate User using its own validation rules
 		validation.Field(&val.User),
 	)
 }
 
+// Validate validates the ChangeEmailRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ChangeEmailRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that either the email address or the user ID is provided, but not both, and the email address is valid
+		validation.Field(&val.Email, emailOrUserID(val.UserID), validation.Length(1, maxEmailLength), is.Email),
+		validation.Field(&val.UserID, validation.Length(1, maxUserIDLength)),
+
+		// Check that the new email address is valid and differs from the current one
+		validation.Field(&val.NewEmail, validation.Required, validation.Length(1, maxEmailLength), is.Email, validation.NotIn(val.Email).Error("must differ from the current email address")),
+	)
+}
+
+// Validate validates the RequestEmailChangeRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val RequestEmailChangeRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that the new email address is valid and differs from the current one
+		validation.Field(&val.NewEmail, validation.Required, validation.Length(1, maxEmailLength), is.Email, validation.NotIn(val.Email).Error("must differ from the current email address")),
+	)
+}
+
+// Validate validates the ConfirmEmailChangeRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ConfirmEmailChangeRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that the token sent to the new email address is provided
+		validation.Field(&val.Token, validation.Required, validation.Length(1, maxConfirmationTokenLength)),
+	)
+}
+
 // Validate validates the DeleteUserRequest model and return error if the validation failes
 // Returns error if validation failes
 func (val DeleteUserRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that either the email address or the user ID is provided, but not both, and the email address is valid
+		validation.Field(&val.Email, emailOrUserID(val.UserID), validation.Length(1, maxEmailLength), is.Email),
+		validation.Field(&val.UserID, validation.Length(1, maxUserIDLength)),
+	)
+}
+
+// Validate validates the RestoreUserRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val RestoreUserRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that either the email address or the user ID is provided, but not both, and the email address is valid
+		validation.Field(&val.Email, emailOrUserID(val.UserID), validation.Length(1, maxEmailLength), is.Email),
+		validation.Field(&val.UserID, validation.Length(1, maxUserIDLength)),
+	)
+}
+
+// Validate validates the SearchRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val SearchRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Validate Pagination using its own validation rules
+		validation.Field(&val.Pagination),
+
+		// Validate SortingOptions using their own validation rules
+		validation.Field(&val.SortingOptions),
+
+		// Check that all email addresses are valid
+		validation.Field(&val.Emails, validation.Length(0, maxEmails), validation.Each(validation.Length(0, maxEmailLength), is.Email)),
+
+		// LabelSelector is optional, but must be a valid label selector if provided
+		validation.Field(&val.LabelSelector, validation.Length(0, maxLabelSelectorLength), validation.By(func(value interface{}) error {
+			_, err := models.ParseLabelSelector(val.LabelSelector)
+
+			return err
+		})),
+
+		// Query is optional, but must not be longer than the email addresses it is matched against
+		validation.Field(&val.Query, validation.Length(0, maxQueryLength)),
+
+		// Fields are optional, but must be the user fields the callers can request
+		validation.Field(&val.Fields, validation.Each(validation.By(models.IsUserProjectionField))),
+
+		// GroupIDs are optional, but none of them can be empty
+		validation.Field(&val.GroupIDs, validation.Length(0, maxGroupIDs), validation.Each(validation.Required)),
+	)
+}
+
+// Validate validates the StreamSearchRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val StreamSearchRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Validate SortingOptions using their own validation rules
+		validation.Field(&val.SortingOptions),
+
+		// Check that all email addresses are valid
+		validation.Field(&val.Emails, validation.Length(0, maxEmails), validation.Each(validation.Length(0, maxEmailLength), is.Email)),
+
+		// Send must be provided to receive the users
+		validation.Field(&val.Send, validation.NotNil),
+	)
+}
+
+// Validate validates the GetSagaStatusRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val GetSagaStatusRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// SagaID is required
+		validation.Field(&val.SagaID, validation.Required),
+	)
+}
+
+// Validate validates the ListAuditRecordsRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ListAuditRecordsRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Email and ActorEmail must be valid email addresses if provided
+		validation.Field(&val.Email, validation.Length(0, maxEmailLength), is.Email),
+		validation.Field(&val.ActorEmail, validation.Length(0, maxEmailLength), is.Email),
+
+		// Operations must only contain the mutating operations that are audited
+		validation.Field(&val.Operations, validation.Each(validation.In(
+			models.AuditOperationCreate,
+			models.AuditOperationUpdate,
+			models.AuditOperationDelete,
+			models.AuditOperationRestore,
+			models.AuditOperationErase,
+			models.AuditOperationChangeEmail))),
+
+		// Limit must be between 0 and 1000, the default limit is applied if it is 0
+		validation.Field(&val.Limit, validation.Min(0), validation.Max(1000)),
+	)
+}
+
+// Validate validates the PreviewBulkUpdateUsersRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val PreviewBulkUpdateUsersRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that all email addresses are valid
+		validation.Field(&val.Emails, validation.Length(0, maxEmails), validation.Each(validation.Length(0, maxEmailLength), is.Email)),
+
+		// Validate User using its own validation rules
+		validation.Field(&val.User),
+
+		// UpdateMask must only contain the paths of the user fields that can be bulk updated
+		validation.Field(&val.UpdateMask, validation.Required, validation.Each(validation.By(isUpdateMaskPath))),
+
+		// SampleSize must be between 0 and 100, the default sample size is applied if it is 0
+		validation.Field(&val.SampleSize, validation.Min(0), validation.Max(100)),
+	)
+}
+
+// Validate validates the BulkUpdateUsersRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val BulkUpdateUsersRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that all email addresses are valid
+		validation.Field(&val.Emails, validation.Length(0, maxEmails), validation.Each(validation.Length(0, maxEmailLength), is.Email)),
+
+		// Validate User using its own validation rules
+		validation.Field(&val.User),
+
+		// UpdateMask must only contain the paths of the user fields that can be bulk updated
+		validation.Field(&val.UpdateMask, validation.Required, validation.Each(validation.By(isUpdateMaskPath))),
+
+		// ConfirmationToken is required, it is returned by previewing the same update
+		validation.Field(&val.ConfirmationToken, validation.Required, validation.Length(1, maxConfirmationTokenLength)),
+	)
+}
+
+// Validate validates the PurgeByLabelRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val PurgeByLabelRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Label is required and must be a valid test label, so it can not match the users that are not tagged with it
+		validation.Field(&val.Label, validation.Required, validation.Length(1, 64), validation.Match(models.TestLabelPattern)),
+	)
+}
+
+// Validate validates the ListPendingEventsRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ListPendingEventsRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Limit must be between 0 and 1000, the default limit is applied if it is 0
+		validation.Field(&val.Limit, validation.Min(0), validation.Max(1000)),
+	)
+}
+
+// Validate validates the GetUserPreferencesRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val GetUserPreferencesRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that either the email address or the user ID is provided, but not both, and the email address is valid
+		validation.Field(&val.Email, emailOrUserID(val.UserID), validation.Length(1, maxEmailLength), is.Email),
+		validation.Field(&val.UserID, validation.Length(1, maxUserIDLength)),
+	)
+}
+
+// Validate validates the UpdateUserPreferencesRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val UpdateUserPreferencesRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that either the email address or the user ID is provided, but not both, and the email address is valid
+		validation.Field(&val.Email, emailOrUserID(val.UserID), validation.Length(1, maxEmailLength), is.Email),
+		validation.Field(&val.UserID, validation.Length(1, maxUserIDLength)),
+
+		// Preferences must only contain the known preferences with the values they support
+		validation.Field(&val.Preferences, validation.By(arePreferencesValid)),
+
+		// RemovedKeys must only contain the known preferences that are not set by the same request
+		validation.Field(&val.RemovedKeys, validation.Each(validation.By(isPreferenceKey)), validation.By(func(value interface{}) error {
+			for _, key := range val.RemovedKeys {
+				if _, ok := val.Preferences[key]; ok {
+					return fmt.Errorf("%s can not be both set and removed", key)
+				}
+			}
+
+			return nil
+		})),
+	)
+}
+
+// Validate validates the GetUserAvatarRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val GetUserAvatarRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that either the email address or the user ID is provided, but not both, and the email address is valid
+		validation.Field(&val.Email, emailOrUserID(val.UserID), validation.Length(1, maxEmailLength), is.Email),
+		validation.Field(&val.UserID, validation.Length(1, maxUserIDLength)),
+	)
+}
+
+// Validate validates the SetUserAvatarRequest model and return error if the validation failes. The size and the type
+// of the image are checked against the configured limits by the business service.
+// Returns error if validation failes
+func (val SetUserAvatarRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that either the email address or the user ID is provided, but not both, and the email address is valid
+		validation.Field(&val.Email, emailOrUserID(val.UserID), validation.Length(1, maxEmailLength), is.Email),
+		validation.Field(&val.UserID, validation.Length(1, maxUserIDLength)),
+
+		// Check that the content type is provided
+		validation.Field(&val.ContentType, validation.Required),
+
+		// Check that the image is provided
+		validation.Field(&val.Content, validation.Required),
+	)
+}
+
+// Validate validates the AddUserToTenantRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val AddUserToTenantRequest) Validate() error {
 	return validation.ValidateStruct(&val,
 		// Check that email address is valid
-		validation.Field(&val.Email, validation.Required, is.Email),
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that the tenant ID is provided
+		validation.Field(&val.TenantID, validation.Required, validation.Length(1, maxTenantIDLength)),
+
+		// Check that the role is one of the tenant roles
+		validation.Field(&val.Role, validation.Required, validation.In(models.TenantRoleOwner, models.TenantRoleAdmin, models.TenantRoleMember)),
 	)
 }
+
+// Validate validates the RemoveUserFromTenantRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val RemoveUserFromTenantRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that the tenant ID is provided
+		validation.Field(&val.TenantID, validation.Required, validation.Length(1, maxTenantIDLength)),
+	)
+}
+
+// Validate validates the ListUserTenantsRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ListUserTenantsRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+	)
+}
+
+// Validate validates the ExportUsersRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ExportUsersRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that the tenant ID is not too long if provided
+		validation.Field(&val.TenantID, validation.Length(1, maxTenantIDLength)),
+
+		// CreatedBefore must be after CreatedAfter if both are provided
+		validation.Field(&val.CreatedBefore, validation.By(func(value interface{}) error {
+			if val.CreatedAfter != nil && val.CreatedBefore != nil && !val.CreatedBefore.After(*val.CreatedAfter) {
+				return fmt.Errorf("must be after createdAfter")
+			}
+
+			return nil
+		})),
+
+		// Send must be provided to receive the users
+		validation.Field(&val.Send, validation.NotNil),
+	)
+}
+
+// Validate validates the IssueMagicLinkRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val IssueMagicLinkRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that the IP address is valid if provided
+		validation.Field(&val.IPAddress, is.IP),
+	)
+}
+
+// Validate validates the RedeemMagicLinkRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val RedeemMagicLinkRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that the token sent in the magic link is provided
+		validation.Field(&val.Token, validation.Required),
+
+		// Check that the IP address is valid if provided
+		validation.Field(&val.IPAddress, is.IP),
+	)
+}
+
+// Validate validates the ExportPersonalDataRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ExportPersonalDataRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+	)
+}
+
+// Validate validates the EraseUserRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val EraseUserRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// ConfirmationToken is optional, it is returned by requesting the erasure of the same user
+		validation.Field(&val.ConfirmationToken, validation.Length(0, maxConfirmationTokenLength)),
+	)
+}
+
+// Validate validates the SendVerificationEmailRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val SendVerificationEmailRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+	)
+}
+
+// Validate validates the VerifyEmailRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val VerifyEmailRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that the token sent in the verification email is provided
+		validation.Field(&val.Token, validation.Required),
+	)
+}
+
+// Validate validates the SetPasswordRequest model and return error if the validation failes. The password policy is
+// enforced by the credential service.
+// Returns error if validation failes
+func (val SetPasswordRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that the password is provided
+		validation.Field(&val.Password, validation.Required),
+	)
+}
+
+// Validate validates the ChangePasswordRequest model and return error if the validation failes. The password policy
+// is enforced by the credential service.
+// Returns error if validation failes
+func (val ChangePasswordRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that both the current and the new password are provided
+		validation.Field(&val.CurrentPassword, validation.Required),
+		validation.Field(&val.NewPassword, validation.Required),
+	)
+}
+
+// Validate validates the VerifyPasswordRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val VerifyPasswordRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that the password is provided
+		validation.Field(&val.Password, validation.Required),
+	)
+}
+
+// Validate validates the WatchUsersRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val WatchUsersRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that every email address is valid
+		validation.Field(&val.Emails, validation.Length(0, maxEmails), validation.Each(validation.Length(0, maxEmailLength), is.Email)),
+
+		// Check that every change type is known
+		validation.Field(&val.Types, validation.Each(validation.In(
+			models.UserChangeCreated,
+			models.UserChangeUpdated,
+			models.UserChangeDeleted,
+			models.UserChangeRestored))),
+
+		// Check that the resume token is not longer than a token the change stream returns
+		validation.Field(&val.ResumeToken, validation.Length(0, maxResumeTokenLength)),
+
+		// Send must be provided to receive the changes
+		validation.Field(&val.Send, validation.NotNil),
+	)
+}
+
+// Validate validates the CreateAPIKeyRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val CreateAPIKeyRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that either the email address or the user ID is provided, but not both, and the email address is valid
+		validation.Field(&val.Email, emailOrUserID(val.UserID), validation.Length(1, maxEmailLength), is.Email),
+		validation.Field(&val.UserID, validation.Length(1, maxUserIDLength)),
+
+		// Check that the name is provided and is not too long
+		validation.Field(&val.Name, validation.Required, validation.Length(1, maxAPIKeyNameLength)),
+
+		// Check that at least one scope is provided and every scope is known
+		validation.Field(&val.Scopes, validation.Required, validation.Each(validation.In(
+			models.APIKeyScopeRead,
+			models.APIKeyScopeWrite))),
+	)
+}
+
+// Validate validates the ListAPIKeysRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ListAPIKeysRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that either the email address or the user ID is provided, but not both, and the email address is valid
+		validation.Field(&val.Email, emailOrUserID(val.UserID), validation.Length(1, maxEmailLength), is.Email),
+		validation.Field(&val.UserID, validation.Length(1, maxUserIDLength)),
+	)
+}
+
+// Validate validates the RevokeAPIKeyRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val RevokeAPIKeyRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that either the email address or the user ID is provided, but not both, and the email address is valid
+		validation.Field(&val.Email, emailOrUserID(val.UserID), validation.Length(1, maxEmailLength), is.Email),
+		validation.Field(&val.UserID, validation.Length(1, maxUserIDLength)),
+
+		// Check that the ID of the API key is provided
+		validation.Field(&val.KeyID, validation.Required),
+	)
+}
+
+// Validate validates the RecordLoginAttemptRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val RecordLoginAttemptRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+	)
+}
+
+// Validate validates the UnlockUserRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val UnlockUserRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+	)
+}
+
+// Validate validates the EnrollMFARequest model and return error if the validation failes
+// Returns error if validation failes
+func (val EnrollMFARequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that either the email address or the user ID is provided, but not both, and the email address is valid
+		validation.Field(&val.Email, emailOrUserID(val.UserID), validation.Length(1, maxEmailLength), is.Email),
+		validation.Field(&val.UserID, validation.Length(1, maxUserIDLength)),
+
+		// Check that the type of the method is known
+		validation.Field(&val.Type, validation.Required, validation.In(
+			models.MFAMethodTypeTOTP,
+			models.MFAMethodTypeWebAuthn,
+			models.MFAMethodTypeRecoveryCodes)),
+
+		// Check that the name is not too long
+		validation.Field(&val.Name, validation.Length(0, maxMFAMethodNameLength)),
+
+		// Check that only the credential that matches the type of the method is provided
+		validation.Field(&val.TOTPSecretRef, getMFACredentialRules(
+			val.Type,
+			models.MFAMethodTypeTOTP,
+			validation.Length(1, maxMFACredentialLength))...),
+		validation.Field(&val.WebAuthnCredentialID, getMFACredentialRules(
+			val.Type,
+			models.MFAMethodTypeWebAuthn,
+			validation.Length(1, maxMFACredentialLength))...),
+		validation.Field(&val.RecoveryCodeHashes, getMFACredentialRules(
+			val.Type,
+			models.MFAMethodTypeRecoveryCodes,
+			validation.Length(1, maxRecoveryCodes),
+			validation.Each(validation.Required, validation.Length(1, maxMFACredentialLength)))...),
+	)
+}
+
+// Validate validates the ListMFAMethodsRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ListMFAMethodsRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that either the email address or the user ID is provided, but not both, and the email address is valid
+		validation.Field(&val.Email, emailOrUserID(val.UserID), validation.Length(1, maxEmailLength), is.Email),
+		validation.Field(&val.UserID, validation.Length(1, maxUserIDLength)),
+	)
+}
+
+// Validate validates the RemoveMFAMethodRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val RemoveMFAMethodRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that either the email address or the user ID is provided, but not both, and the email address is valid
+		validation.Field(&val.Email, emailOrUserID(val.UserID), validation.Length(1, maxEmailLength), is.Email),
+		validation.Field(&val.UserID, validation.Length(1, maxUserIDLength)),
+
+		// Check that the ID of the method is provided
+		validation.Field(&val.MethodID, validation.Required),
+	)
+}
+
+// Validate validates the RecordConsentRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val RecordConsentRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that the policy and its version are provided and are not too long
+		validation.Field(&val.Policy, validation.Required, validation.Length(1, maxPolicyNameLength)),
+		validation.Field(&val.Version, validation.Required, validation.Length(1, maxPolicyVersionLength)),
+	)
+}
+
+// Validate validates the ListConsentsRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ListConsentsRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+	)
+}
+
+// Validate validates the CreateGroupRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val CreateGroupRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that the name is provided and the name and the description are not too long
+		validation.Field(&val.Name, validation.Required, validation.Length(1, maxGroupNameLength)),
+		validation.Field(&val.Description, validation.Length(0, maxGroupDescriptionLength)),
+	)
+}
+
+// Validate validates the ReadGroupRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ReadGroupRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that the ID of the group is provided
+		validation.Field(&val.GroupID, validation.Required),
+	)
+}
+
+// Validate validates the UpdateGroupRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val UpdateGroupRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that the ID of the group is provided
+		validation.Field(&val.GroupID, validation.Required),
+
+		// Check that the name is provided and the name and the description are not too long
+		validation.Field(&val.Name, validation.Required, validation.Length(1, maxGroupNameLength)),
+		validation.Field(&val.Description, validation.Length(0, maxGroupDescriptionLength)),
+	)
+}
+
+// Validate validates the DeleteGroupRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val DeleteGroupRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that the ID of the group is provided
+		validation.Field(&val.GroupID, validation.Required),
+	)
+}
+
+// Validate validates the ListGroupsRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ListGroupsRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Email address is optional, but must be valid if provided
+		validation.Field(&val.Email, validation.Length(0, maxEmailLength), is.Email),
+	)
+}
+
+// Validate validates the AddUserToGroupRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val AddUserToGroupRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that the ID of the group is provided
+		validation.Field(&val.GroupID, validation.Required),
+
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+	)
+}
+
+// Validate validates the RemoveUserFromGroupRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val RemoveUserFromGroupRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that the ID of the group is provided
+		validation.Field(&val.GroupID, validation.Required),
+
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+	)
+}
+
+// Validate validates the InviteUserRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val InviteUserRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+	)
+}
+
+// Validate validates the AcceptInvitationRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val AcceptInvitationRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that the token sent in the invitation is provided
+		validation.Field(&val.Token, validation.Required),
+
+		// Validate User using its own validation rules
+		validation.Field(&val.User),
+	)
+}
+
+// Validate validates the RegisterSessionRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val RegisterSessionRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that the ID of the session is provided and the device is not described too long
+		validation.Field(&val.SessionID, validation.Required, validation.Length(1, maxSessionIDLength)),
+		validation.Field(&val.DeviceName, validation.Length(0, maxDeviceNameLength)),
+		validation.Field(&val.UserAgent, validation.Length(0, maxUserAgentLength)),
+
+		// IP address is optional, but must be valid if provided
+		validation.Field(&val.IPAddress, is.IP),
+	)
+}
+
+// Validate validates the ListSessionsRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ListSessionsRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+	)
+}
+
+// Validate validates the RevokeSessionRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val RevokeSessionRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that the ID of the session is provided
+		validation.Field(&val.SessionID, validation.Required),
+	)
+}
+
+// Validate validates the CreateWebhookSubscriptionRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val CreateWebhookSubscriptionRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that the webhook is called over HTTPS, so the events and their signatures can not be read on the way
+		validation.Field(&val.URL, validation.Required, validation.Length(1, maxWebhookURLLength), is.RequestURL, validation.Match(webhookURLPattern)),
+
+		// Check that the secret is long enough to sign the calls with
+		validation.Field(&val.Secret, validation.Required, validation.Length(minWebhookSecretLength, maxWebhookSecretLength)),
+
+		// Check that the webhook is subscribed to at least one of the known event types
+		validation.Field(&val.EventTypes, validation.Required, validation.Each(validation.In(
+			models.WebhookEventUserCreated,
+			models.WebhookEventUserUpdated,
+			models.WebhookEventUserDeleted,
+			models.WebhookEventUserRestored))),
+	)
+}
+
+// Validate validates the ListWebhookSubscriptionsRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ListWebhookSubscriptionsRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+	)
+}
+
+// Validate validates the DeleteWebhookSubscriptionRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val DeleteWebhookSubscriptionRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Check that the ID of the subscription is provided
+		validation.Field(&val.SubscriptionID, validation.Required),
+	)
+}
+
+// Validate validates the ListWebhookDeliveriesRequest model and return error if the validation failes
+// Returns error if validation failes
+func (val ListWebhookDeliveriesRequest) Validate() error {
+	return validation.ValidateStruct(&val,
+		// Check that email address is valid
+		validation.Field(&val.Email, validation.Required, validation.Length(1, maxEmailLength), is.Email),
+
+		// Limit must be between 0 and 100, the default limit is applied if it is 0
+		validation.Field(&val.Limit, validation.Min(0), validation.Max(maxWebhookDeliveriesListLimit)),
+	)
+}
+
+// getMFACredentialRules returns the rules of the credential field of the given type, the field is required if the
+// method is of its type and must be empty otherwise
+func getMFACredentialRules(
+	methodType models.MFAMethodType,
+	credentialType models.MFAMethodType,
+	rules ...validation.Rule) []validation.Rule {
+	if methodType == credentialType {
+		return append([]validation.Rule{validation.Required}, rules...)
+	}
+
+	return []validation.Rule{validation.By(func(value interface{}) error {
+		if !validation.IsEmpty(value) {
+			return fmt.Errorf("must be empty for the %s methods", methodType)
+		}
+
+		return nil
+	})}
+}
+
+// emailOrUserID returns the rule that checks either the email address or the user ID names the user, but not both
+// userID: Mandatory. The user ID of the request the email address is validated of
+// Returns the rule to validate the email address of the request with
+func emailOrUserID(userID string) validation.Rule {
+	return validation.By(func(value interface{}) error {
+		if validation.IsEmpty(value) == (userID == "") {
+			return errors.New("either the email address or the user ID must be provided")
+		}
+
+		return nil
+	})
+}
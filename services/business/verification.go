@@ -0,0 +1,172 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/eventing"
+	"github.com/decentralized-cloud/user/services/mailer"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// emailVerificationTokenLength is the number of the random bytes the email verification token is generated from
+const emailVerificationTokenLength = 32
+
+// SendVerificationEmail generates a new verification token for an existing user and sends it to the email address of
+// the user. The token replaces the token sent before, so only the latest verification email can verify the address.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to send the verification email to an existing user
+// Returns either the time the token expires at or error if something goes wrong.
+func (service *businessService) SendVerificationEmail(
+	ctx context.Context,
+	request *SendVerificationEmailRequest) (*SendVerificationEmailResponse, error) {
+	readUserResponse, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	})
+
+	if err != nil {
+		return &SendVerificationEmailResponse{
+			Err: err,
+		}, nil
+	}
+
+	if readUserResponse.User.EmailVerified {
+		return &SendVerificationEmailResponse{
+			AlreadyVerified: true,
+		}, nil
+	}
+
+	tokenTTL, err := service.configurationService.GetEmailVerificationTokenTTL()
+	if err != nil {
+		return &SendVerificationEmailResponse{
+			Err: err,
+		}, nil
+	}
+
+	token, err := generateEmailVerificationToken()
+	if err != nil {
+		return &SendVerificationEmailResponse{
+			Err: err,
+		}, nil
+	}
+
+	expiresAt := service.clockService.Now().Add(tokenTTL)
+
+	// Only the hash of the token is persisted, so the token can not be read back from the database
+	if _, err = service.repositoryService.SetEmailVerificationToken(ctx, &repository.SetEmailVerificationTokenRequest{
+		Email:     request.Email,
+		TokenHash: hashEmailVerificationToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return &SendVerificationEmailResponse{
+			Err: err,
+		}, nil
+	}
+
+	if err = service.mailerService.SendVerificationEmail(ctx, &mailer.VerificationEmail{
+		Email:     request.Email,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return &SendVerificationEmailResponse{
+			Err: commonErrors.NewUnknownErrorWithError("failed to send the verification email", err),
+		}, nil
+	}
+
+	return &SendVerificationEmailResponse{
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// VerifyEmail verifies the email address of an existing user with the token sent in the verification email. Verifying
+// an email address that is already verified succeeds without checking the token.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the token sent in the verification email
+// Returns either the user after the email address is verified or error if something goes wrong.
+func (service *businessService) VerifyEmail(
+	ctx context.Context,
+	request *VerifyEmailRequest) (*VerifyEmailResponse, error) {
+	readUserResponse, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	})
+
+	if err != nil {
+		return &VerifyEmailResponse{
+			Err: err,
+		}, nil
+	}
+
+	if readUserResponse.User.EmailVerified {
+		return &VerifyEmailResponse{
+			User: readUserResponse.User,
+		}, nil
+	}
+
+	tokenHash := hashEmailVerificationToken(request.Token)
+	pendingTokenHash := readUserResponse.User.EmailVerificationTokenHash
+
+	if pendingTokenHash == "" || subtle.ConstantTimeCompare([]byte(tokenHash), []byte(pendingTokenHash)) != 1 {
+		return &VerifyEmailResponse{
+			Err: NewEmailVerificationTokenError(request.Email, false),
+		}, nil
+	}
+
+	expiresAt := readUserResponse.User.EmailVerificationExpiresAt
+	if expiresAt == nil || !service.clockService.Now().Before(*expiresAt) {
+		return &VerifyEmailResponse{
+			Err: NewEmailVerificationTokenError(request.Email, true),
+		}, nil
+	}
+
+	// The repository only verifies the email address if the token is still the pending one, so a verification email
+	// sent after the user is read invalidates the token
+	response, err := service.repositoryService.VerifyEmail(ctx, &repository.VerifyEmailRequest{
+		Email:     request.Email,
+		TokenHash: tokenHash,
+	})
+
+	if err != nil {
+		if commonErrors.IsNotFoundError(err) {
+			err = NewEmailVerificationTokenError(request.Email, false)
+		}
+
+		return &VerifyEmailResponse{
+			Err: err,
+		}, nil
+	}
+
+	service.logIgnoredError(ctx, "failed to record the operation in the audit log", service.auditService.RecordOperation(ctx, models.AuditOperationUpdate, request.Email, &readUserResponse.User, &response.User))
+
+	service.logIgnoredError(ctx, "failed to publish the user updated event", service.eventingService.PublishUserUpdated(ctx, &eventing.UserUpdatedEvent{
+		Email:  request.Email,
+		User:   response.User,
+		Cursor: response.Cursor,
+	}))
+
+	return &VerifyEmailResponse{
+		User:   response.User,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+func generateEmailVerificationToken() (string, error) {
+	token := make([]byte, emailVerificationTokenLength)
+	if _, err := rand.Read(token); err != nil {
+		return "", commonErrors.NewUnknownErrorWithError("failed to generate the email verification token", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+func hashEmailVerificationToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,86 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// maxResumeTokenLength is the maximum length of the resume tokens, well above the length of the tokens the change
+// stream returns
+const maxResumeTokenLength = 4096
+
+// WatchUsers sends the changes made to the users that matched the filter one by one as they happen, until the
+// context is done
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the filter and the function to send the changes to
+// Returns either the number of the sent changes or error if something goes wrong.
+func (service *businessService) WatchUsers(
+	ctx context.Context,
+	request *WatchUsersRequest) (*WatchUsersResponse, error) {
+	changes, err := service.watchService.Subscribe(ctx, models.UserChangeFilter{
+		Emails:      request.Emails,
+		Types:       request.Types,
+		ResumeToken: request.ResumeToken,
+	})
+
+	if err != nil {
+		return &WatchUsersResponse{
+			Err: err,
+		}, nil
+	}
+
+	var sentCount int64
+
+	for change := range changes {
+		// The events only identify the user, so the user is read to send its state after the change. The user
+		// deleted again before it is read is skipped, its deletion is sent next.
+		if change.Type != models.UserChangeDeleted {
+			response, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+				Email: change.Email,
+			})
+
+			if err != nil {
+				if commonErrors.IsNotFoundError(err) {
+					continue
+				}
+
+				if ctx.Err() != nil {
+					break
+				}
+
+				return &WatchUsersResponse{
+					Err:       err,
+					SentCount: sentCount,
+				}, nil
+			}
+
+			user := response.User
+			change.User = &user
+		}
+
+		if err = request.Send(change); err != nil {
+			return &WatchUsersResponse{
+				Err:       err,
+				SentCount: sentCount,
+			}, nil
+		}
+
+		sentCount++
+	}
+
+	// The channel is closed before the context is done only if the watcher fell behind the changes
+	if ctx.Err() == nil {
+		return &WatchUsersResponse{
+			Err:       NewWatchLaggedError(),
+			SentCount: sentCount,
+		}, nil
+	}
+
+	return &WatchUsersResponse{
+		SentCount: sentCount,
+	}, nil
+}
@@ -0,0 +1,125 @@
+// Package business implements different business services required by the user service
+package business
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/repository"
+)
+
+// DefaultWebhookDeliveriesListLimit is the maximum number of the webhook deliveries listed if the request does not
+// limit them
+const DefaultWebhookDeliveriesListLimit = 20
+
+const (
+	// maxWebhookDeliveriesListLimit is the maximum number of the webhook deliveries a single request can list
+	maxWebhookDeliveriesListLimit = 100
+
+	// maxWebhookURLLength is the maximum length of the URLs of the webhooks
+	maxWebhookURLLength = 2048
+
+	// minWebhookSecretLength is the minimum length of the secrets the webhook calls are signed with
+	minWebhookSecretLength = 16
+
+	// maxWebhookSecretLength is the maximum length of the secrets the webhook calls are signed with
+	maxWebhookSecretLength = 256
+)
+
+// webhookURLPattern matches the HTTPS URLs, the webhooks are not called over plain HTTP. The webhook service refuses
+// the URLs that are not public, both when subscribed and when called.
+var webhookURLPattern = regexp.MustCompile(`^https://`)
+
+// CreateWebhookSubscription subscribes a webhook to the events of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the email address of the user, the URL, the secret and the event types
+// Returns either the created subscription or error if something goes wrong.
+func (service *businessService) CreateWebhookSubscription(
+	ctx context.Context,
+	request *CreateWebhookSubscriptionRequest) (*CreateWebhookSubscriptionResponse, error) {
+	if _, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+		Email: request.Email,
+	}); err != nil {
+		return &CreateWebhookSubscriptionResponse{
+			Err: err,
+		}, nil
+	}
+
+	subscription, err := service.webhookService.CreateSubscription(ctx, &models.WebhookSubscription{
+		Email:      request.Email,
+		URL:        request.URL,
+		Secret:     request.Secret,
+		EventTypes: request.EventTypes,
+	})
+
+	if err != nil {
+		return &CreateWebhookSubscriptionResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &CreateWebhookSubscriptionResponse{
+		Subscription: *subscription,
+	}, nil
+}
+
+// ListWebhookSubscriptions lists the webhook subscriptions of a user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the email address of the user
+// Returns either the subscriptions or error if something goes wrong.
+func (service *businessService) ListWebhookSubscriptions(
+	ctx context.Context,
+	request *ListWebhookSubscriptionsRequest) (*ListWebhookSubscriptionsResponse, error) {
+	subscriptions, err := service.webhookService.ListSubscriptions(ctx, request.Email)
+	if err != nil {
+		return &ListWebhookSubscriptionsResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &ListWebhookSubscriptionsResponse{
+		Subscriptions: subscriptions,
+	}, nil
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription of a user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the email address of the user and the ID of the subscription
+// Returns either the result of deleting the subscription or error if something goes wrong.
+func (service *businessService) DeleteWebhookSubscription(
+	ctx context.Context,
+	request *DeleteWebhookSubscriptionRequest) (*DeleteWebhookSubscriptionResponse, error) {
+	if err := service.webhookService.DeleteSubscription(ctx, request.Email, request.SubscriptionID); err != nil {
+		return &DeleteWebhookSubscriptionResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &DeleteWebhookSubscriptionResponse{}, nil
+}
+
+// ListWebhookDeliveries lists the recent deliveries of the events to the webhooks of a user and their statuses
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the email address of the user, the optional ID of the subscription and
+// the maximum number of the deliveries to list
+// Returns either the deliveries, the most recent first, or error if something goes wrong.
+func (service *businessService) ListWebhookDeliveries(
+	ctx context.Context,
+	request *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	limit := request.Limit
+	if limit <= 0 {
+		limit = DefaultWebhookDeliveriesListLimit
+	}
+
+	deliveries, err := service.webhookService.ListDeliveries(ctx, request.Email, request.SubscriptionID, limit)
+	if err != nil {
+		return &ListWebhookDeliveriesResponse{
+			Err: err,
+		}, nil
+	}
+
+	return &ListWebhookDeliveriesResponse{
+		Deliveries: deliveries,
+	}, nil
+}
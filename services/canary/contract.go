@@ -0,0 +1,13 @@
+// Package canary implements the validation rules that are rolled out in the warn mode first, reporting the
+// violations without rejecting the requests, so they can be enforced once the clients are known to comply
+package canary
+
+// CanaryValidationContract declares the service that applies the validation rules being rolled out
+type CanaryValidationContract interface {
+	// Validate applies the validation rules being rolled out to the given request fields. The violations of the
+	// rules in the warn mode are only logged and counted, the ones in the enforce mode reject the request.
+	// operation: Mandatory. The name of the operation the request is received for
+	// fields: Mandatory. The request fields to validate keyed by the field name, e.g. email
+	// Returns error if the request violates any of the enforced rules
+	Validate(operation string, fields map[string]interface{}) error
+}
@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/canary/contract.go
+
+// Package mock_canary is a generated GoMock package.
+package mock_canary
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCanaryValidationContract is a mock of CanaryValidationContract interface.
+type MockCanaryValidationContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockCanaryValidationContractMockRecorder
+}
+
+// MockCanaryValidationContractMockRecorder is the mock recorder for MockCanaryValidationContract.
+type MockCanaryValidationContractMockRecorder struct {
+	mock *MockCanaryValidationContract
+}
+
+// NewMockCanaryValidationContract creates a new mock instance.
+func NewMockCanaryValidationContract(ctrl *gomock.Controller) *MockCanaryValidationContract {
+	mock := &MockCanaryValidationContract{ctrl: ctrl}
+	mock.recorder = &MockCanaryValidationContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCanaryValidationContract) EXPECT() *MockCanaryValidationContractMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockCanaryValidationContract) Validate(operation string, fields map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", operation, fields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockCanaryValidationContractMockRecorder) Validate(operation, fields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockCanaryValidationContract)(nil).Validate), operation, fields)
+}
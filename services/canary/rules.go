@@ -0,0 +1,59 @@
+// Package canary implements the validation rules that are rolled out in the warn mode first, reporting the
+// violations without rejecting the requests, so they can be enforced once the clients are known to comply
+package canary
+
+import (
+	"errors"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Rule is a validation rule that is rolled out in the warn mode before it is enforced
+type Rule struct {
+	// Name identifies the rule in the configuration, the logs and the metrics
+	Name string
+
+	// Field is the name of the request field the rule applies to, e.g. email
+	Field string
+
+	// Rule validates the field value
+	Rule validation.Rule
+}
+
+// Rules contains the validation rules being rolled out. Once a rule is enforced everywhere it should be moved to
+// the Validate method of the request it applies to.
+var Rules = []Rule{
+	{
+		Name:  "email_lowercase",
+		Field: "email",
+		Rule:  validation.By(isLowercase),
+	},
+	{
+		Name:  "email_domain_has_tld",
+		Field: "email",
+		Rule:  validation.By(hasTopLevelDomain),
+	},
+}
+
+func isLowercase(value interface{}) error {
+	if str, ok := value.(string); ok && str != strings.ToLower(str) {
+		return errors.New("must be in lower case")
+	}
+
+	return nil
+}
+
+func hasTopLevelDomain(value interface{}) error {
+	str, ok := value.(string)
+	if !ok || str == "" {
+		return nil
+	}
+
+	domain := str[strings.LastIndex(str, "@")+1:]
+	if index := strings.LastIndex(domain, "."); index <= 0 || index == len(domain)-1 {
+		return errors.New("must have a domain with a top level domain")
+	}
+
+	return nil
+}
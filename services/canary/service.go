@@ -0,0 +1,122 @@
+// Package canary implements the validation rules that are rolled out in the warn mode first, reporting the
+// violations without rejecting the requests, so they can be enforced once the clients are known to comply
+package canary
+
+import (
+	"github.com/decentralized-cloud/user/services/configuration"
+	validation "github.com/go-ozzo/ozzo-validation"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+const (
+	// ModeWarn only logs and counts the violations of the rule
+	ModeWarn = "warn"
+
+	// ModeEnforce rejects the requests that violate the rule
+	ModeEnforce = "enforce"
+
+	// ModeOff does not apply the rule at all
+	ModeOff = "off"
+)
+
+var violationsCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "user_validation_rule_violations_total",
+		Help: "The number of the requests that violated the validation rules being rolled out grouped by the rule, the operation and the mode (warn or enforce)",
+	},
+	[]string{"rule", "operation", "mode"})
+
+type canaryValidationService struct {
+	logger *zap.Logger
+	rules  []Rule
+	modes  map[string]string
+}
+
+// NewCanaryValidationService creates new instance of the canaryValidationService, setting up all dependencies and returns the instance
+// logger: Mandatory. Reference to the logger service
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// rules: Mandatory. The validation rules being rolled out
+// Returns the new service or error if something goes wrong
+func NewCanaryValidationService(
+	logger *zap.Logger,
+	configurationService configuration.ConfigurationContract,
+	rules []Rule) (CanaryValidationContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	modes, err := configurationService.GetValidationRuleModes()
+	if err != nil {
+		return nil, err
+	}
+
+	return &canaryValidationService{
+		logger: logger,
+		rules:  rules,
+		modes:  modes,
+	}, nil
+}
+
+// Validate applies the validation rules being rolled out to the given request fields. The violations of the
+// rules in the warn mode are only logged and counted, the ones in the enforce mode reject the request.
+// operation: Mandatory. The name of the operation the request is received for
+// fields: Mandatory. The request fields to validate keyed by the field name, e.g. email
+// Returns error if the request violates any of the enforced rules
+func (service *canaryValidationService) Validate(operation string, fields map[string]interface{}) error {
+	violations := validation.Errors{}
+
+	for _, rule := range service.rules {
+		value, ok := fields[rule.Field]
+		if !ok {
+			continue
+		}
+
+		mode := service.getMode(rule.Name)
+		if mode == ModeOff {
+			continue
+		}
+
+		err := validation.Validate(value, rule.Rule)
+		if err == nil {
+			continue
+		}
+
+		violationsCounter.WithLabelValues(rule.Name, operation, mode).Inc()
+
+		if mode == ModeEnforce {
+			violations[rule.Field] = err
+
+			continue
+		}
+
+		// The field value is not logged as it can contain personal data
+		service.logger.Warn(
+			"Request violates a validation rule that is not enforced yet",
+			zap.String("rule", rule.Name),
+			zap.String("operation", operation),
+			zap.String("field", rule.Field),
+			zap.String("violation", err.Error()))
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return violations
+}
+
+// getMode returns the configured mode of the given rule, the rules are only warned about unless configured otherwise
+func (service *canaryValidationService) getMode(ruleName string) string {
+	if mode, ok := service.modes[ruleName]; ok {
+		return mode
+	}
+
+	return ModeWarn
+}
@@ -0,0 +1,205 @@
+package canary_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/decentralized-cloud/user/services/canary"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestCanaryValidationService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Canary Validation Service Tests")
+}
+
+var _ = Describe("Canary Validation Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		logs                     *observer.ObservedLogs
+		logger                   *zap.Logger
+		rules                    []canary.Rule
+		ruleName                 string
+		operation                string
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+
+		var core zapcore.Core
+		core, logs = observer.New(zapcore.InfoLevel)
+		logger = zap.New(core)
+
+		ruleName = cuid.New()
+		operation = cuid.New()
+		rules = []canary.Rule{
+			{
+				Name:  ruleName,
+				Field: "email",
+				Rule: validation.By(func(value interface{}) error {
+					if strings.HasPrefix(value.(string), "invalid") {
+						return errors.New("is invalid")
+					}
+
+					return nil
+				}),
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	createService := func(modes map[string]string) canary.CanaryValidationContract {
+		mockConfigurationService.
+			EXPECT().
+			GetValidationRuleModes().
+			Return(modes, nil)
+
+		service, err := canary.NewCanaryValidationService(logger, mockConfigurationService, rules)
+		Ω(err).Should(BeNil())
+
+		return service
+	}
+
+	Context("user tries to instantiate CanaryValidationService", func() {
+		When("logger is not provided and NewCanaryValidationService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := canary.NewCanaryValidationService(nil, mockConfigurationService, rules)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("configuration service is not provided and NewCanaryValidationService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := canary.NewCanaryValidationService(logger, nil, rules)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("configuration service fails to return the rule modes", func() {
+			It("should return the same error", func() {
+				expectedErr := errors.New(cuid.New())
+				mockConfigurationService.
+					EXPECT().
+					GetValidationRuleModes().
+					Return(nil, expectedErr)
+
+				service, err := canary.NewCanaryValidationService(logger, mockConfigurationService, rules)
+				Ω(service).Should(BeNil())
+				Ω(err).Should(Equal(expectedErr))
+			})
+		})
+
+		When("all dependencies are resolved and NewCanaryValidationService is called", func() {
+			It("should instantiate the new CanaryValidationService", func() {
+				Ω(createService(map[string]string{})).ShouldNot(BeNil())
+			})
+		})
+	})
+
+	Context("CanaryValidationService is instantiated", func() {
+		When("the request does not violate the rule", func() {
+			It("should neither return error nor log anything", func() {
+				sut := createService(map[string]string{ruleName: canary.ModeEnforce})
+
+				Ω(sut.Validate(operation, map[string]interface{}{"email": cuid.New()})).Should(BeNil())
+				Ω(logs.Len()).Should(Equal(0))
+			})
+		})
+
+		When("the request does not contain the field the rule applies to", func() {
+			It("should not return error", func() {
+				sut := createService(map[string]string{ruleName: canary.ModeEnforce})
+
+				Ω(sut.Validate(operation, map[string]interface{}{cuid.New(): "invalid"})).Should(BeNil())
+			})
+		})
+
+		When("the rule is not configured and the request violates it", func() {
+			It("should only warn about the violation", func() {
+				sut := createService(map[string]string{})
+
+				Ω(sut.Validate(operation, map[string]interface{}{"email": "invalid" + cuid.New()})).Should(BeNil())
+				Ω(logs.FilterField(zap.String("rule", ruleName)).Len()).Should(Equal(1))
+			})
+		})
+
+		When("the rule is in the warn mode and the request violates it", func() {
+			It("should log the violation without the field value", func() {
+				sut := createService(map[string]string{ruleName: canary.ModeWarn})
+				email := "invalid" + cuid.New()
+
+				Ω(sut.Validate(operation, map[string]interface{}{"email": email})).Should(BeNil())
+
+				entries := logs.FilterField(zap.String("rule", ruleName)).All()
+				Ω(entries).Should(HaveLen(1))
+				Ω(entries[0].Level).Should(Equal(zapcore.WarnLevel))
+				Ω(entries[0].ContextMap()).Should(HaveKeyWithValue("operation", operation))
+				Ω(entries[0].ContextMap()).Should(HaveKeyWithValue("field", "email"))
+				Ω(entries[0].ContextMap()).ShouldNot(ContainElement(email))
+			})
+		})
+
+		When("the rule is in the enforce mode and the request violates it", func() {
+			It("should return the violation keyed by the field", func() {
+				sut := createService(map[string]string{ruleName: canary.ModeEnforce})
+
+				err := sut.Validate(operation, map[string]interface{}{"email": "invalid" + cuid.New()})
+				Ω(err).ShouldNot(BeNil())
+				Ω(err.(validation.Errors)).Should(HaveKey("email"))
+				Ω(logs.Len()).Should(Equal(0))
+			})
+		})
+
+		When("the rule is off and the request violates it", func() {
+			It("should neither return error nor log anything", func() {
+				sut := createService(map[string]string{ruleName: canary.ModeOff})
+
+				Ω(sut.Validate(operation, map[string]interface{}{"email": "invalid" + cuid.New()})).Should(BeNil())
+				Ω(logs.Len()).Should(Equal(0))
+			})
+		})
+	})
+
+	Context("the rules being rolled out", func() {
+		var ruleByName = func(name string) validation.Rule {
+			for _, rule := range canary.Rules {
+				if rule.Name == name {
+					return rule.Rule
+				}
+			}
+
+			Fail("rule not found: " + name)
+
+			return nil
+		}
+
+		It("email_lowercase should reject the emails with upper case letters", func() {
+			Ω(validation.Validate("user@test.com", ruleByName("email_lowercase"))).Should(BeNil())
+			Ω(validation.Validate("User@test.com", ruleByName("email_lowercase"))).ShouldNot(BeNil())
+		})
+
+		It("email_domain_has_tld should reject the emails without a top level domain", func() {
+			Ω(validation.Validate("user@test.com", ruleByName("email_domain_has_tld"))).Should(BeNil())
+			Ω(validation.Validate("user@localhost", ruleByName("email_domain_has_tld"))).ShouldNot(BeNil())
+			Ω(validation.Validate("user@test.", ruleByName("email_domain_has_tld"))).ShouldNot(BeNil())
+		})
+	})
+})
@@ -0,0 +1,12 @@
+// Package clock implements the source of the current time, so the expiry and retention logic can be tested
+// deterministically without sleeping
+package clock
+
+import "time"
+
+// ClockContract declares the service that provides the current time
+type ClockContract interface {
+	// Now returns the current time
+	// Returns the current time in UTC
+	Now() time.Time
+}
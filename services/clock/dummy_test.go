@@ -0,0 +1 @@
+package clock_test
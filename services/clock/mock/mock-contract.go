@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/clock/contract.go
+
+// Package mock_clock is a generated GoMock package.
+package mock_clock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockClockContract is a mock of ClockContract interface.
+type MockClockContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockClockContractMockRecorder
+}
+
+// MockClockContractMockRecorder is the mock recorder for MockClockContract.
+type MockClockContractMockRecorder struct {
+	mock *MockClockContract
+}
+
+// NewMockClockContract creates a new mock instance.
+func NewMockClockContract(ctrl *gomock.Controller) *MockClockContract {
+	mock := &MockClockContract{ctrl: ctrl}
+	mock.recorder = &MockClockContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClockContract) EXPECT() *MockClockContractMockRecorder {
+	return m.recorder
+}
+
+// Now mocks base method.
+func (m *MockClockContract) Now() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Now")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// Now indicates an expected call of Now.
+func (mr *MockClockContractMockRecorder) Now() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Now", reflect.TypeOf((*MockClockContract)(nil).Now))
+}
@@ -0,0 +1,20 @@
+// Package clock implements the source of the current time, so the expiry and retention logic can be tested
+// deterministically without sleeping
+package clock
+
+import "time"
+
+type clockService struct {
+}
+
+// NewClockService creates new instance of the clockService that reads the system clock, setting up all dependencies and returns the instance
+// Returns the new service or error if something goes wrong
+func NewClockService() (ClockContract, error) {
+	return &clockService{}, nil
+}
+
+// Now returns the current time
+// Returns the current time in UTC
+func (service *clockService) Now() time.Time {
+	return time.Now().UTC()
+}
@@ -1,7 +1,10 @@
 // Package configuration implements configuration service required by the user service
 package configuration
 
-// ConfigurationContract declares the service that provides configuration required by different Tenat modules
+import "time"
+
+// ConfigurationContract declares the service that provides configuration required by different Tenat modules.
+// Every new option must also be described in Options so it shows up in the generated configuration template.
 type ConfigurationContract interface {
 	// GetGrpcHost retrieves the gRPC host name
 	// Returns the gRPC host name or error if something goes wrong
@@ -11,6 +14,39 @@ type ConfigurationContract interface {
 	// Returns the gRPC port number or error if something goes wrong
 	GetGrpcPort() (int, error)
 
+	// GetGrpcShutdownTimeout retrieves the time the gRPC server waits for the in-flight calls to finish when stopping
+	// Returns the gRPC shutdown timeout or error if something goes wrong
+	GetGrpcShutdownTimeout() (time.Duration, error)
+
+	// GetGrpcShutdownDrainDelay retrieves the time the gRPC server keeps serving when stopping after it reports it is
+	// not ready, so the load balancers stop routing the new calls to it before it stops accepting them
+	// Returns the gRPC shutdown drain delay or error if something goes wrong
+	GetGrpcShutdownDrainDelay() (time.Duration, error)
+
+	// GetGrpcReflectionEnabled retrieves whether the gRPC server reflection is registered so tools such as grpcurl can
+	// discover the operations without the proto files
+	// Returns true if the gRPC server reflection is enabled or error if something goes wrong
+	GetGrpcReflectionEnabled() (bool, error)
+
+	// GetGrpcStrictDecodingEnabled retrieves whether the UpdateUser requests carrying the fields this service does not
+	// know, e.g. sent by the clients built against a newer contract, are rejected instead of the fields being dropped
+	// Returns true if the requests carrying unknown fields are rejected or error if something goes wrong
+	GetGrpcStrictDecodingEnabled() (bool, error)
+
+	// GetGrpcMaxReceiveMessageSize retrieves the maximum size of the messages the gRPC server receives, including the
+	// Connect requests served on the HTTP port
+	// Returns the maximum size in bytes or error if something goes wrong
+	GetGrpcMaxReceiveMessageSize() (int, error)
+
+	// GetEndpointDefaultTimeout retrieves the time the calls to the endpoints that have no timeout of their own are
+	// cancelled after, the streaming endpoints only have a timeout if it is set for them
+	// Returns the default timeout, zero if the calls have no timeout, or error if something goes wrong
+	GetEndpointDefaultTimeout() (time.Duration, error)
+
+	// GetEndpointTimeouts retrieves the time the calls to each endpoint are cancelled after, overriding the default timeout
+	// Returns the map of the endpoint name to its timeout or error if something goes wrong
+	GetEndpointTimeouts() (map[string]time.Duration, error)
+
 	// GetHttpHost retrieves the HTTP host name
 	// Returns the HTTP host name or error if something goes wrong
 	GetHttpHost() (string, error)
@@ -19,7 +55,66 @@ type ConfigurationContract interface {
 	// Returns the HTTP port number or error if something goes wrong
 	GetHttpPort() (int, error)
 
-	// GetDatabaseConnectionString retrieves the database connection string
+	// GetHttpProfilingEnabled retrieves whether the HTTP server serves the runtime profiles, e.g. the goroutine and
+	// heap profiles the support bundle collects
+	// Returns true if the runtime profiles are served or error if something goes wrong
+	GetHttpProfilingEnabled() (bool, error)
+
+	// GetConnectEnabled retrieves whether the HTTP server serves the gRPC operations over the Connect protocol so the
+	// browsers, e.g. the web console, can call the service directly
+	// Returns true if the Connect protocol is served or error if something goes wrong
+	GetConnectEnabled() (bool, error)
+
+	// GetCorsAllowedOrigins retrieves the origins the browsers are allowed to call the HTTP endpoints, e.g. the
+	// operations served over the Connect protocol, from
+	// Returns the list of the allowed origins or error if something goes wrong
+	GetCorsAllowedOrigins() ([]string, error)
+
+	// GetCorsAllowedMethods retrieves the methods the browsers are allowed to call the HTTP endpoints with
+	// Returns the list of the allowed methods or error if something goes wrong
+	GetCorsAllowedMethods() ([]string, error)
+
+	// GetCorsAllowedHeaders retrieves the headers the browsers are allowed to send to the HTTP endpoints on top of the
+	// ones the service always allows, e.g. the headers the ingress requires
+	// Returns the list of the allowed headers or error if something goes wrong
+	GetCorsAllowedHeaders() ([]string, error)
+
+	// GetCorsMaxAge retrieves how long the browsers cache the result of the CORS preflight requests
+	// Returns the max age of the preflight results or error if something goes wrong
+	GetCorsMaxAge() (time.Duration, error)
+
+	// GetHstsMaxAge retrieves how long the browsers only call the host of the HTTP endpoints over HTTPS
+	// Returns the max age of the Strict-Transport-Security header, zero if it is not sent, or error if something goes
+	// wrong
+	GetHstsMaxAge() (time.Duration, error)
+
+	// GetHstsIncludeSubdomains retrieves whether the browsers only call the subdomains of the host of the HTTP endpoints
+	// over HTTPS too
+	// Returns true if the Strict-Transport-Security header includes the subdomains or error if something goes wrong
+	GetHstsIncludeSubdomains() (bool, error)
+
+	// GetContentSecurityPolicy retrieves the Content-Security-Policy the responses of the HTTP endpoints are sent with
+	// Returns the content security policy, empty if it is not sent, or error if something goes wrong
+	GetContentSecurityPolicy() (string, error)
+
+	// GetGraphQLHost retrieves the GraphQL host name
+	// Returns the GraphQL host name or error if something goes wrong
+	GetGraphQLHost() (string, error)
+
+	// GetGraphQLPort retrieves the GraphQL port number
+	// Returns the GraphQL port number or error if something goes wrong
+	GetGraphQLPort() (int, error)
+
+	// GetDatabaseType retrieves the type of the database to persist the users in
+	// Returns the database type or error if something goes wrong
+	GetDatabaseType() (string, error)
+
+	// GetRepositoryType retrieves the type of the repository the users are kept in
+	// Returns the repository type or error if something goes wrong
+	GetRepositoryType() (string, error)
+
+	// GetDatabaseConnectionString retrieves the database connection string. The connection string changes once the
+	// secret it is read from is rotated, so it is read again whenever a new database connection is made.
 	// Returns the database connection string or error if something goes wrong
 	GetDatabaseConnectionString() (string, error)
 
@@ -31,7 +126,537 @@ type ConfigurationContract interface {
 	// Returns the database collection name or error if something goes wrong
 	GetDatabaseCollectionName() (string, error)
 
+	// GetDatabaseSearchIndexHints retrieves the index hints to use for search based on the shape of the search filter
+	// Returns the map of the filter shape to the index name or error if something goes wrong
+	GetDatabaseSearchIndexHints() (map[string]string, error)
+
+	// GetDatabaseSearchSortableFields retrieves the fields the search result is allowed to be sorted by, the fields
+	// the database has indexes for
+	// Returns the list of the field names or error if something goes wrong
+	GetDatabaseSearchSortableFields() ([]string, error)
+
+	// GetDatabaseSearchQueryPlanStatisticsEnabled retrieves whether the query plan statistics of the searches should be recorded
+	// Returns true if the query plan statistics should be recorded or error if something goes wrong
+	GetDatabaseSearchQueryPlanStatisticsEnabled() (bool, error)
+
+	// GetDatabaseStartupCheckEnabled retrieves whether the database is checked in the background at startup, so the
+	// service starts and reports it is not ready while the database is unavailable instead of failing to start
+	// Returns true if the startup check is enabled or error if something goes wrong
+	GetDatabaseStartupCheckEnabled() (bool, error)
+
+	// GetDatabaseStartupCheckInitialBackoff retrieves how long the startup check waits before retrying the database
+	// for the first time, the wait doubles after every failed attempt
+	// Returns the initial backoff or error if something goes wrong
+	GetDatabaseStartupCheckInitialBackoff() (time.Duration, error)
+
+	// GetDatabaseStartupCheckMaxBackoff retrieves the longest the startup check waits between two attempts
+	// Returns the maximum backoff or error if something goes wrong
+	GetDatabaseStartupCheckMaxBackoff() (time.Duration, error)
+
+	// GetDatabaseReadPreference retrieves the members of the MongoDB replica set the users are read from by ReadUser,
+	// ReadUsers and the searches, the other operations always read from the primary
+	// Returns the read preference mode or error if something goes wrong
+	GetDatabaseReadPreference() (string, error)
+
+	// GetDatabaseReadConcern retrieves the level of the MongoDB read concern the users are read with
+	// Returns the read concern level or error if something goes wrong
+	GetDatabaseReadConcern() (string, error)
+
+	// GetDatabaseWriteConcern retrieves the MongoDB write concern the users are written with, either majority or the
+	// number of the replica set members that must acknowledge the writes
+	// Returns the write concern or error if something goes wrong
+	GetDatabaseWriteConcern() (string, error)
+
+	// GetDataResidencyDefaultRegion retrieves the region the database set by the database connection string is in. The
+	// users that do not ask for a specific region are persisted in this region.
+	// Returns the default region name or error if something goes wrong
+	GetDataResidencyDefaultRegion() (string, error)
+
+	// GetDataResidencyRoutes retrieves the connection strings of the databases the users residing in the regions other
+	// than the default region are persisted in
+	// Returns the map of the region name to the database connection string or error if something goes wrong
+	GetDataResidencyRoutes() (map[string]string, error)
+
+	// GetReplicationStandbyConnectionString retrieves the connection string of the standby database the primary database
+	// is replicated to
+	// Returns the standby database connection string, empty if there is no standby database, or error if something goes wrong
+	GetReplicationStandbyConnectionString() (string, error)
+
+	// GetReplicationHeartbeatCollectionName retrieves the name of the database collection the replication heartbeats are
+	// written to
+	// Returns the replication heartbeat collection name or error if something goes wrong
+	GetReplicationHeartbeatCollectionName() (string, error)
+
+	// GetReplicationHeartbeatInterval retrieves how often the replication heartbeat is written to the primary database
+	// and read from the standby database
+	// Returns the heartbeat interval or error if something goes wrong
+	GetReplicationHeartbeatInterval() (time.Duration, error)
+
+	// GetReplicationMaxLag retrieves how far behind the primary database the standby database can be while still being
+	// ready to fail over to
+	// Returns the maximum replication lag or error if something goes wrong
+	GetReplicationMaxLag() (time.Duration, error)
+
+	// GetCacheEnabled retrieves whether the users read from the database are cached in Redis
+	// Returns true if the users are cached or error if something goes wrong
+	GetCacheEnabled() (bool, error)
+
+	// GetCacheRedisAddress retrieves the address of the Redis server the users are cached in
+	// Returns the Redis server address or error if something goes wrong
+	GetCacheRedisAddress() (string, error)
+
+	// GetCacheRedisPassword retrieves the password used to authenticate with the Redis server
+	// Returns the password, empty if the Redis server does not require authentication, or error if something goes wrong
+	GetCacheRedisPassword() (string, error)
+
+	// GetCacheRedisDatabase retrieves the number of the Redis database the users are cached in
+	// Returns the Redis database number or error if something goes wrong
+	GetCacheRedisDatabase() (int, error)
+
+	// GetCacheTTL retrieves how long a cached user is kept before it is read from the database again
+	// Returns the time the cached users are kept for or error if something goes wrong
+	GetCacheTTL() (time.Duration, error)
+
+	// GetCacheKeyPrefix retrieves the prefix of the Redis keys the users are cached under
+	// Returns the key prefix or error if something goes wrong
+	GetCacheKeyPrefix() (string, error)
+
+	// GetCacheLocalTTL retrieves how long a user is kept in the memory of the replica in front of Redis
+	// Returns the time the users are kept in memory for, zero if the users are not kept in memory, or error if something goes wrong
+	GetCacheLocalTTL() (time.Duration, error)
+
+	// GetCacheLocalMaxEntries retrieves the maximum number of the users kept in the memory of the replica
+	// Returns the maximum number of the users kept in memory or error if something goes wrong
+	GetCacheLocalMaxEntries() (int, error)
+
+	// GetReadDeduplicationEnabled retrieves whether the concurrent identical reads share a single repository query
+	// Returns whether the reads are deduplicated or error if something goes wrong
+	GetReadDeduplicationEnabled() (bool, error)
+
+	// GetEventingBroker retrieves the message broker to publish the user lifecycle events to
+	// Returns the message broker name or error if something goes wrong
+	GetEventingBroker() (string, error)
+
+	// GetEventingConnectionString retrieves the message broker connection string
+	// Returns the message broker connection string or error if something goes wrong
+	GetEventingConnectionString() (string, error)
+
+	// GetEventingSubjectPrefix retrieves the prefix of the subjects the user lifecycle events are published to
+	// Returns the subject prefix or error if something goes wrong
+	GetEventingSubjectPrefix() (string, error)
+
+	// GetWatchBufferSize retrieves how many user changes are buffered for a watcher before the watcher is considered too
+	// slow and its stream is ended
+	// Returns the watch buffer size or error if something goes wrong
+	GetWatchBufferSize() (int, error)
+
+	// GetWatchSource retrieves where the user changes the watchers are notified of are read from
+	// Returns the watch source name or error if something goes wrong
+	GetWatchSource() (string, error)
+
+	// GetEmailVerificationProvider retrieves how the verification emails are sent, either by publishing an event for
+	// another service to send them or by sending them through the SMTP server
+	// Returns the email verification provider name or error if something goes wrong
+	GetEmailVerificationProvider() (string, error)
+
+	// GetEmailVerificationTokenTTL retrieves how long the token sent in a verification email is valid for
+	// Returns the time the token is valid for or error if something goes wrong
+	GetEmailVerificationTokenTTL() (time.Duration, error)
+
+	// GetEmailVerificationURL retrieves the URL of the page the users verify their email address on, the verification
+	// email links to it with the email address and the token as query parameters
+	// Returns the email verification URL or error if something goes wrong
+	GetEmailVerificationURL() (string, error)
+
+	// GetSmtpAddress retrieves the host and port of the SMTP server the verification emails are sent through
+	// Returns the SMTP server address or error if something goes wrong
+	GetSmtpAddress() (string, error)
+
+	// GetSmtpUsername retrieves the username used to authenticate with the SMTP server
+	// Returns the username, empty if the SMTP server does not require authentication, or error if something goes wrong
+	GetSmtpUsername() (string, error)
+
+	// GetSmtpPassword retrieves the password used to authenticate with the SMTP server
+	// Returns the password or error if something goes wrong
+	GetSmtpPassword() (string, error)
+
+	// GetSmtpFrom retrieves the email address the verification emails are sent from
+	// Returns the sender email address or error if something goes wrong
+	GetSmtpFrom() (string, error)
+
+	// GetAvatarsEnabled retrieves whether the users can upload an avatar image, which requires an object storage
+	// Returns true if the avatars are enabled or error if something goes wrong
+	GetAvatarsEnabled() (bool, error)
+
+	// GetAvatarMaxSize retrieves the maximum size of the avatar images
+	// Returns the maximum size in bytes or error if something goes wrong
+	GetAvatarMaxSize() (int, error)
+
+	// GetAvatarAllowedContentTypes retrieves the content types the avatar images are accepted in
+	// Returns the list of the MIME types or error if something goes wrong
+	GetAvatarAllowedContentTypes() ([]string, error)
+
+	// GetAvatarURLExpiry retrieves how long the presigned URLs the avatar images are downloaded from are valid for
+	// Returns the expiry of the URLs or error if something goes wrong
+	GetAvatarURLExpiry() (time.Duration, error)
+
+	// GetObjectStorageEndpoint retrieves the URL of the S3 compatible object storage, e.g. MinIO
+	// Returns the URL, or empty to use Amazon S3, or error if something goes wrong
+	GetObjectStorageEndpoint() (string, error)
+
+	// GetObjectStorageRegion retrieves the region of the object storage bucket
+	// Returns the region or error if something goes wrong
+	GetObjectStorageRegion() (string, error)
+
+	// GetObjectStorageBucket retrieves the name of the bucket the files of the users are stored in
+	// Returns the bucket name or error if something goes wrong
+	GetObjectStorageBucket() (string, error)
+
+	// GetObjectStorageAccessKeyID retrieves the access key ID used to authenticate with the object storage
+	// Returns the access key ID, or empty to use the default AWS credential chain, or error if something goes wrong
+	GetObjectStorageAccessKeyID() (string, error)
+
+	// GetObjectStorageSecretAccessKey retrieves the secret access key used to authenticate with the object storage
+	// Returns the secret access key or error if something goes wrong
+	GetObjectStorageSecretAccessKey() (string, error)
+
+	// GetObjectStoragePathStyleEnabled retrieves whether the bucket is addressed in the path rather than in the host
+	// name of the URLs, as most of the self-hosted object storages such as MinIO require
+	// Returns true if the path style is enabled or error if something goes wrong
+	GetObjectStoragePathStyleEnabled() (bool, error)
+
+	// GetSagaCollectionName retrieves the name of the database collection the saga states are persisted in
+	// Returns the saga collection name or error if something goes wrong
+	GetSagaCollectionName() (string, error)
+
+	// GetSagaMaxAttempts retrieves the maximum number of times a saga step is attempted before it is considered failed
+	// Returns the maximum number of attempts or error if something goes wrong
+	GetSagaMaxAttempts() (int, error)
+
+	// GetSagaRetryBackoff retrieves the time to wait before retrying a failed saga step for the first time. The
+	// time is doubled for every subsequent attempt
+	// Returns the retry backoff or error if something goes wrong
+	GetSagaRetryBackoff() (time.Duration, error)
+
+	// GetAuditCollectionName retrieves the name of the database collection the audit records are persisted in
+	// Returns the audit collection name or error if something goes wrong
+	GetAuditCollectionName() (string, error)
+
+	// GetPasswordCredentialsEnabled retrieves whether the users can sign in with a password managed by the service, for
+	// the deployments that cannot rely only on an external identity provider
+	// Returns true if the password credentials are enabled or error if something goes wrong
+	GetPasswordCredentialsEnabled() (bool, error)
+
+	// GetCredentialCollectionName retrieves the name of the database collection the password hashes are persisted in
+	// Returns the credential collection name or error if something goes wrong
+	GetCredentialCollectionName() (string, error)
+
+	// GetPasswordHashingAlgorithm retrieves the algorithm the new passwords are hashed with. The passwords hashed with
+	// another algorithm are rehashed the next time they are verified.
+	// Returns the password hashing algorithm or error if something goes wrong
+	GetPasswordHashingAlgorithm() (string, error)
+
+	// GetPasswordMinLength retrieves the minimum number of the characters a password must contain
+	// Returns the minimum password length or error if something goes wrong
+	GetPasswordMinLength() (int, error)
+
+	// GetPasswordRequiredCharacterClasses retrieves how many of the lower case letters, upper case letters, digits and
+	// symbols a password must contain
+	// Returns the number of the required character classes or error if something goes wrong
+	GetPasswordRequiredCharacterClasses() (int, error)
+
+	// GetAPIKeyCollectionName retrieves the name of the database collection the API keys are persisted in
+	// Returns the API key collection name or error if something goes wrong
+	GetAPIKeyCollectionName() (string, error)
+
+	// GetAPIKeyMaxPerUser retrieves the maximum number of the API keys a user can have that are not revoked or expired
+	// Returns the maximum number of the API keys per user or error if something goes wrong
+	GetAPIKeyMaxPerUser() (int, error)
+
+	// GetConsentCollectionName retrieves the name of the database collection the consents of the users are persisted in
+	// Returns the consent collection name or error if something goes wrong
+	GetConsentCollectionName() (string, error)
+
+	// GetConsentRequiredPolicies retrieves the current versions of the policies the users must accept, keyed by the
+	// policy name
+	// Returns the map of the policy name to its current version, empty if no policy is required, or error if something goes wrong
+	GetConsentRequiredPolicies() (map[string]string, error)
+
+	// GetConsentRequiredOperations retrieves the operations that require the user to accept the current versions of
+	// the required policies first
+	// Returns the list of the operation names or error if something goes wrong
+	GetConsentRequiredOperations() ([]string, error)
+
+	// GetGroupCollectionName retrieves the name of the database collection the groups are persisted in
+	// Returns the group collection name or error if something goes wrong
+	GetGroupCollectionName() (string, error)
+
+	// GetInvitationCollectionName retrieves the name of the database collection the invitations are persisted in
+	// Returns the invitation collection name or error if something goes wrong
+	GetInvitationCollectionName() (string, error)
+
+	// GetInvitationTokenTTL retrieves how long the token sent in an invitation is valid for
+	// Returns the time the token is valid for or error if something goes wrong
+	GetInvitationTokenTTL() (time.Duration, error)
+
+	// GetInvitationURL retrieves the URL of the page the invited users accept their invitation on, the invitation
+	// email links to it with the email address and the token as query parameters
+	// Returns the invitation URL, empty if not provided, or error if something goes wrong
+	GetInvitationURL() (string, error)
+
+	// GetSessionCollectionName retrieves the name of the database collection the sessions are persisted in
+	// Returns the session collection name or error if something goes wrong
+	GetSessionCollectionName() (string, error)
+
+	// GetSessionTTL retrieves how long a session stays active after the auth gateway registers it the last time
+	// Returns the time the session stays active for or error if something goes wrong
+	GetSessionTTL() (time.Duration, error)
+
+	// GetWebhookSubscriptionCollectionName retrieves the name of the database collection the webhook subscriptions
+	// are persisted in
+	// Returns the webhook subscription collection name or error if something goes wrong
+	GetWebhookSubscriptionCollectionName() (string, error)
+
+	// GetWebhookDeliveryCollectionName retrieves the name of the database collection the webhook deliveries are
+	// persisted in
+	// Returns the webhook delivery collection name or error if something goes wrong
+	GetWebhookDeliveryCollectionName() (string, error)
+
+	// GetWebhookDeliveryRetention retrieves how long the deliveries of the events to the webhooks are kept for
+	// Returns the webhook delivery retention or error if something goes wrong
+	GetWebhookDeliveryRetention() (time.Duration, error)
+
+	// GetWebhookMaxAttempts retrieves the maximum number of times a webhook call is attempted before its delivery is
+	// considered failed
+	// Returns the maximum number of attempts or error if something goes wrong
+	GetWebhookMaxAttempts() (int, error)
+
+	// GetWebhookRetryBackoff retrieves the time to wait before retrying a failed webhook call for the first time. The
+	// time is doubled for every subsequent attempt
+	// Returns the retry backoff or error if something goes wrong
+	GetWebhookRetryBackoff() (time.Duration, error)
+
+	// GetWebhookTimeout retrieves how long a single webhook call can take before it is considered failed
+	// Returns the webhook call timeout or error if something goes wrong
+	GetWebhookTimeout() (time.Duration, error)
+
+	// GetWebhookPrivateNetworksAllowed retrieves whether the webhooks can be called in the private, loopback and
+	// link-local networks, e.g. to test the webhooks locally
+	// Returns true if the webhooks can be called in the private networks or error if something goes wrong
+	GetWebhookPrivateNetworksAllowed() (bool, error)
+
+	// GetLockoutThreshold retrieves the number of the failed login attempts the users are locked after
+	// Returns the lockout threshold, zero if the users are never locked, or error if something goes wrong
+	GetLockoutThreshold() (int, error)
+
+	// GetBulkUpdateTokenSecret retrieves the secret the bulk update confirmation tokens are signed with
+	// Returns the secret, empty if the bulk update is disabled, or error if something goes wrong
+	GetBulkUpdateTokenSecret() (string, error)
+
+	// GetBulkUpdatePreviewTTL retrieves how long the bulk update confirmation token of a preview is valid for
+	// Returns the time the token is valid for or error if something goes wrong
+	GetBulkUpdatePreviewTTL() (time.Duration, error)
+
+	// GetBulkUpdateRateLimit retrieves the maximum number of users updated per second by a bulk update
+	// Returns the rate limit or error if something goes wrong
+	GetBulkUpdateRateLimit() (int, error)
+
+	// GetBulkUpdateMaxUsers retrieves the maximum number of users a single bulk update can update
+	// Returns the maximum number of users or error if something goes wrong
+	GetBulkUpdateMaxUsers() (int, error)
+
+	// GetErasureTokenSecret retrieves the secret the erasure confirmation tokens are signed with
+	// Returns the secret, empty if the erasure is disabled, or error if something goes wrong
+	GetErasureTokenSecret() (string, error)
+
+	// GetErasureGracePeriod retrieves how long after the erasure is requested it can be confirmed
+	// Returns the grace period or error if something goes wrong
+	GetErasureGracePeriod() (time.Duration, error)
+
+	// GetErasureConfirmationWindow retrieves how long after the grace period ends the erasure can still be confirmed
+	// Returns the confirmation window or error if something goes wrong
+	GetErasureConfirmationWindow() (time.Duration, error)
+
+	// GetEmailChangeTokenSecret retrieves the secret the email address change confirmation tokens are signed with
+	// Returns the secret, empty if the email address is changed without a confirmation, or error if something goes wrong
+	GetEmailChangeTokenSecret() (string, error)
+
+	// GetEmailChangeTokenTTL retrieves how long the token sent to confirm an email address change is valid for
+	// Returns the time the token is valid for or error if something goes wrong
+	GetEmailChangeTokenTTL() (time.Duration, error)
+
+	// GetEmailChangeURL retrieves the URL of the page the users confirm their email address change on, the email sent
+	// to the new email address links to it with the current email address and the token as query parameters
+	// Returns the email change URL, empty if not provided, or error if something goes wrong
+	GetEmailChangeURL() (string, error)
+
+	// GetSoftDeleteEnabled retrieves whether the deleted users are only marked as deleted so they can be restored
+	// Returns true if the users are soft deleted or error if something goes wrong
+	GetSoftDeleteEnabled() (bool, error)
+
+	// GetSoftDeleteRetentionPeriod retrieves how long the soft deleted users are kept before they are purged
+	// Returns the retention period or error if something goes wrong
+	GetSoftDeleteRetentionPeriod() (time.Duration, error)
+
+	// GetSoftDeletePurgeInterval retrieves how often the soft deleted users that passed the retention period are purged
+	// Returns the purge interval or error if something goes wrong
+	GetSoftDeletePurgeInterval() (time.Duration, error)
+
+	// GetTestDataPurgeEnabled retrieves whether the users labelled as test data can be purged by the PurgeByLabel operation
+	// Returns true if purging the test data is enabled or error if something goes wrong
+	GetTestDataPurgeEnabled() (bool, error)
+
+	// GetMagicLinksEnabled retrieves whether the users can sign in with the single-use links sent to their email
+	// addresses instead of a password
+	// Returns true if the magic links are enabled or error if something goes wrong
+	GetMagicLinksEnabled() (bool, error)
+
+	// GetMagicLinkCollectionName retrieves the name of the database collection the magic links are persisted in
+	// Returns the magic link collection name or error if something goes wrong
+	GetMagicLinkCollectionName() (string, error)
+
+	// GetMagicLinkTokenTTL retrieves how long the token sent in a magic link is valid for
+	// Returns the time the token is valid for or error if something goes wrong
+	GetMagicLinkTokenTTL() (time.Duration, error)
+
+	// GetMagicLinkIPAddressBindingEnabled retrieves whether a magic link can only be redeemed from the IP address it
+	// is issued for
+	// Returns true if the magic links are bound to the IP address or error if something goes wrong
+	GetMagicLinkIPAddressBindingEnabled() (bool, error)
+
+	// GetMagicLinkDeviceBindingEnabled retrieves whether a magic link can only be redeemed from the device it is
+	// issued for
+	// Returns true if the magic links are bound to the device or error if something goes wrong
+	GetMagicLinkDeviceBindingEnabled() (bool, error)
+
+	// GetSloAvailabilityObjective retrieves the ratio of the calls that must not fail with a server error
+	// Returns the availability objective or error if something goes wrong
+	GetSloAvailabilityObjective() (float64, error)
+
+	// GetSloLatencyObjective retrieves the ratio of the calls that must complete within the latency threshold
+	// Returns the latency objective or error if something goes wrong
+	GetSloLatencyObjective() (float64, error)
+
+	// GetSloLatencyThreshold retrieves the duration a call must complete within to count towards the latency objective
+	// Returns the latency threshold or error if something goes wrong
+	GetSloLatencyThreshold() (time.Duration, error)
+
+	// GetSloWindow retrieves the rolling window the error budgets are computed over
+	// Returns the SLO window or error if something goes wrong
+	GetSloWindow() (time.Duration, error)
+
+	// GetValidationRuleModes retrieves how the validation rules being rolled out are applied, keyed by the rule name.
+	// The mode is either warn, enforce or off.
+	// Returns the map of the rule name to its mode or error if something goes wrong
+	GetValidationRuleModes() (map[string]string, error)
+
+	// GetValidationPolicyAllowedEmailDomains retrieves the domains the email addresses of the new users must belong to
+	// Returns the list of the allowed domains, empty if any domain is allowed, or error if something goes wrong
+	GetValidationPolicyAllowedEmailDomains() ([]string, error)
+
+	// GetValidationPolicyFieldPatterns retrieves the regular expressions the request fields must match, keyed by the
+	// field name, e.g. apiKeyName or labels.department
+	// Returns the map of the field name to its pattern or error if something goes wrong
+	GetValidationPolicyFieldPatterns() (map[string]string, error)
+
+	// GetValidationPolicyRequiredFields retrieves the user fields the users must be created and updated with, e.g.
+	// dataResidency or labels.department
+	// Returns the list of the required field names or error if something goes wrong
+	GetValidationPolicyRequiredFields() ([]string, error)
+
+	// GetAdminEmails retrieves the email addresses of the users that are allowed to call the admin operations
+	// Returns the list of the admin email addresses or error if something goes wrong
+	GetAdminEmails() ([]string, error)
+
+	// GetAdminGroups retrieves the names of the groups whose members are allowed to call the admin operations
+	// Returns the list of the admin group names or error if something goes wrong
+	GetAdminGroups() ([]string, error)
+
 	// GetJwksURL retrieves the JWKS URL
 	// Returns the JWKS URL or error if something goes wrong
 	GetJwksURL() (string, error)
+
+	// GetJwksRefreshInterval retrieves how often the cached JSON Web Key Set the access tokens are verified with is refreshed
+	// Returns the refresh interval or error if something goes wrong
+	GetJwksRefreshInterval() (time.Duration, error)
+
+	// GetJwtAcceptedIssuers retrieves the issuers the access tokens are accepted from
+	// Returns the list of the accepted issuers, empty if the issuer is not checked, or error if something goes wrong
+	GetJwtAcceptedIssuers() ([]string, error)
+
+	// GetJwtAcceptedAudiences retrieves the audiences the access tokens are accepted for
+	// Returns the list of the accepted audiences, empty if the audience is not checked, or error if something goes wrong
+	GetJwtAcceptedAudiences() ([]string, error)
+
+	// GetJwtClaimMapping retrieves the claims of the access tokens the email address and the subject of the caller are read from
+	// Returns the map of the field, either email, subject, service or tenant, to the claim it is read from or error if something goes wrong
+	GetJwtClaimMapping() (map[string]string, error)
+
+	// GetServiceIdentityAllowlist retrieves the identities of the internal services allowed to call each endpoint with a
+	// client credential token
+	// Returns the map of the endpoint name to the allowed service identities or error if something goes wrong
+	GetServiceIdentityAllowlist() (map[string][]string, error)
+
+	// GetTenancyMode retrieves whether the users are shared by all the callers or scoped to the tenant of the caller
+	// Returns either single or multi, or error if something goes wrong
+	GetTenancyMode() (string, error)
+
+	// GetTenancyCrossTenantReadScope retrieves the scope of the access tokens allowed to read the users of all the
+	// tenants in the multi-tenant mode
+	// Returns the scope or error if something goes wrong
+	GetTenancyCrossTenantReadScope() (string, error)
+
+	// GetAuthorizationDecisionLoggingEnabled retrieves whether the path of the checks that allowed or denied a call is logged
+	// Returns true if the authorization decisions are logged or error if something goes wrong
+	GetAuthorizationDecisionLoggingEnabled() (bool, error)
+
+	// GetAuthorizationPolicyFile retrieves the path to the policy file the rules of the endpoints are tailored with
+	// Returns the path to the policy file or error if something goes wrong
+	GetAuthorizationPolicyFile() (string, error)
+
+	// GetRedactedUserFields retrieves the fields of the user that are removed from the responses sent to the callers
+	// that are neither the owner of the user nor an admin
+	// Returns the list of the user field names as they are named in the proto files or error if something goes wrong
+	GetRedactedUserFields() ([]string, error)
+
+	// GetLogLevel retrieves the minimum level of the logged messages
+	// Returns the log level or error if something goes wrong
+	GetLogLevel() (string, error)
+
+	// GetLogFormat retrieves the format the log messages are written in
+	// Returns the log format or error if something goes wrong
+	GetLogFormat() (string, error)
+
+	// GetLogLevelEndpointEnabled retrieves whether the HTTP server serves the endpoint that changes the log level
+	// Returns true if the log level endpoint is served or error if something goes wrong
+	GetLogLevelEndpointEnabled() (bool, error)
+
+	// GetPayloadLoggingEnabled retrieves whether the request and response payloads of the calls are logged at the debug
+	// level
+	// Returns true if the payloads are logged or error if something goes wrong
+	GetPayloadLoggingEnabled() (bool, error)
+
+	// GetPayloadLoggingRedactedFields retrieves the fields whose values are replaced in the logged payloads
+	// Returns the list of the field names as they are named in the proto files or error if something goes wrong
+	GetPayloadLoggingRedactedFields() ([]string, error)
+
+	// GetSecretsRefreshInterval retrieves how often the secrets referenced by the secret options are read again
+	// Returns the refresh interval or error if something goes wrong
+	GetSecretsRefreshInterval() (time.Duration, error)
+
+	// GetVaultAddress retrieves the address of the HashiCorp Vault server the secrets are read from
+	// Returns the Vault address or error if something goes wrong
+	GetVaultAddress() (string, error)
+
+	// GetVaultToken retrieves the token the secrets are read from Vault with
+	// Returns the Vault token or error if something goes wrong
+	GetVaultToken() (string, error)
+
+	// GetVaultKubernetesRole retrieves the Vault role the service logs in as with its Kubernetes service account token
+	// Returns the Vault role or error if something goes wrong
+	GetVaultKubernetesRole() (string, error)
+
+	// GetVaultKubernetesAuthPath retrieves the path the Kubernetes auth method is enabled at in Vault
+	// Returns the path of the auth method or error if something goes wrong
+	GetVaultKubernetesAuthPath() (string, error)
 }
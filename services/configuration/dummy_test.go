@@ -1 +0,0 @@
-package configuration_test
@@ -0,0 +1,101 @@
+// Package configuration implements configuration service required by the user service
+package configuration
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// GetEffectiveOptions reads the value of every option the way the configuration service parses it, the secrets are
+// redacted
+// configurationService: Mandatory. Reference to the service the options are read from
+// Returns the effective options in the order they are declared
+func GetEffectiveOptions(configurationService ConfigurationContract) []models.ConfigurationOption {
+	configurationValue := reflect.ValueOf(configurationService)
+	options := []models.ConfigurationOption{}
+
+	for _, option := range Options() {
+		effectiveOption := models.ConfigurationOption{
+			Name:    option.EnvironmentVariable,
+			Default: option.Default,
+		}
+
+		// Going through the getters reports the values exactly the way the service parses them, including the errors
+		if value, err := readOption(configurationValue, option); err != nil {
+			effectiveOption.Error = err.Error()
+		} else {
+			effectiveOption.Value = value
+		}
+
+		if option.Secret && effectiveOption.Value != "" {
+			effectiveOption.Value = ""
+			effectiveOption.Redacted = true
+		}
+
+		options = append(options, effectiveOption)
+	}
+
+	return options
+}
+
+// GetSecretValues reads the values of the secret options, so they can be removed from the text that may contain them
+// configurationService: Mandatory. Reference to the service the options are read from
+// Returns the values of the secret options that are set
+func GetSecretValues(configurationService ConfigurationContract) []string {
+	configurationValue := reflect.ValueOf(configurationService)
+	values := []string{}
+
+	for _, option := range Options() {
+		if !option.Secret {
+			continue
+		}
+
+		if value, err := readOption(configurationValue, option); err == nil && value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func readOption(configurationValue reflect.Value, option Option) (string, error) {
+	getter := configurationValue.MethodByName(option.Getter)
+	if !getter.IsValid() {
+		return "", fmt.Errorf("%s is not supported by the configuration service", option.Getter)
+	}
+
+	results := getter.Call(nil)
+	if err, _ := results[1].Interface().(error); err != nil {
+		return "", err
+	}
+
+	return formatValue(results[0].Interface()), nil
+}
+
+func formatValue(value interface{}) string {
+	switch castedValue := value.(type) {
+	case map[string]string:
+		pairs := make([]string, 0, len(castedValue))
+		for key, item := range castedValue {
+			pairs = append(pairs, key+"="+item)
+		}
+
+		sort.Strings(pairs)
+
+		return strings.Join(pairs, ",")
+
+	case []string:
+		return strings.Join(castedValue, ",")
+
+	case time.Duration:
+		return castedValue.String()
+
+	default:
+		return fmt.Sprint(castedValue)
+	}
+}
@@ -0,0 +1,105 @@
+package configuration_test
+
+import (
+	"os"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Effective Configuration Tests", func() {
+	var (
+		sut configuration.ConfigurationContract
+	)
+
+	// getEffectiveOptions reads the effective options keyed by their environment variables
+	getEffectiveOptions := func() map[string]models.ConfigurationOption {
+		effectiveOptions := map[string]models.ConfigurationOption{}
+		for _, option := range configuration.GetEffectiveOptions(sut) {
+			effectiveOptions[option.Name] = option
+		}
+
+		return effectiveOptions
+	}
+
+	BeforeEach(func() {
+		for _, option := range configuration.Options() {
+			os.Unsetenv(option.EnvironmentVariable)
+		}
+
+		var err error
+		sut, err = configuration.NewEnvConfigurationService()
+		Ω(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		for _, option := range configuration.Options() {
+			os.Unsetenv(option.EnvironmentVariable)
+		}
+	})
+
+	Context("the effective options are read", func() {
+		It("should return every option in the order they are declared along with its default", func() {
+			effectiveOptions := configuration.GetEffectiveOptions(sut)
+			options := configuration.Options()
+			Ω(effectiveOptions).Should(HaveLen(len(options)))
+
+			for index, option := range options {
+				Ω(effectiveOptions[index].Name).Should(Equal(option.EnvironmentVariable))
+				Ω(effectiveOptions[index].Default).Should(Equal(option.Default))
+			}
+		})
+
+		It("should return the values the way the configuration service parses them", func() {
+			os.Setenv("GRPC_SHUTDOWN_TIMEOUT", "90s")
+			os.Setenv("ADMIN_EMAILS", "b@test.com, a@test.com")
+			os.Setenv("JWT_CLAIM_MAPPING", "subject=sub,email=preferred_username")
+
+			effectiveOptions := getEffectiveOptions()
+			Ω(effectiveOptions["GRPC_SHUTDOWN_TIMEOUT"].Value).Should(Equal("1m30s"))
+			Ω(effectiveOptions["ADMIN_EMAILS"].Value).Should(Equal("b@test.com,a@test.com"))
+			Ω(effectiveOptions["JWT_CLAIM_MAPPING"].Value).Should(Equal("email=preferred_username,subject=sub"))
+		})
+
+		When("the value of an option is invalid", func() {
+			It("should return the error the configuration service fails with instead of the value", func() {
+				os.Setenv("GRPC_SHUTDOWN_TIMEOUT", "not-a-duration")
+
+				effectiveOption := getEffectiveOptions()["GRPC_SHUTDOWN_TIMEOUT"]
+				Ω(effectiveOption.Value).Should(BeEmpty())
+				Ω(effectiveOption.Error).Should(ContainSubstring("GRPC_SHUTDOWN_TIMEOUT"))
+			})
+		})
+
+		When("a secret option is set", func() {
+			It("should redact its value", func() {
+				os.Setenv("SMTP_PASSWORD", "s3cret")
+
+				effectiveOption := getEffectiveOptions()["SMTP_PASSWORD"]
+				Ω(effectiveOption.Value).Should(BeEmpty())
+				Ω(effectiveOption.Redacted).Should(BeTrue())
+			})
+		})
+
+		When("a secret option is not set", func() {
+			It("should not report its value is redacted", func() {
+				effectiveOption := getEffectiveOptions()["SMTP_PASSWORD"]
+				Ω(effectiveOption.Value).Should(BeEmpty())
+				Ω(effectiveOption.Redacted).Should(BeFalse())
+			})
+		})
+	})
+
+	Context("the secret values are read", func() {
+		It("should return the values of the secret options that are set only", func() {
+			os.Setenv("SMTP_PASSWORD", "smtp-s3cret")
+			os.Setenv("VAULT_TOKEN", "vault-s3cret")
+			os.Setenv("ADMIN_EMAILS", "admin@test.com")
+
+			Ω(configuration.GetSecretValues(sut)).Should(ConsistOf("smtp-s3cret", "vault-s3cret"))
+		})
+	})
+})
@@ -2,32 +2,49 @@
 package configuration
 
 import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/decentralized-cloud/user/pkg/fips"
+	"github.com/go-ozzo/ozzo-validation/is"
 	commonErrors "github.com/micro-business/go-core/system/errors"
 )
 
+// validationPolicyFieldName matches the names of the fields the validation policy patterns are set for, e.g.
+// apiKeyName or labels.department
+var validationPolicyFieldName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*(\.[A-Za-z0-9][A-Za-z0-9._/-]*)?$`)
+
 type envConfigurationService struct {
+	// getVariable returns the value of the given option, the environment variables are read unless the options are
+	// read from a configuration file
+	getVariable func(variableName string) string
 }
 
-// NewEnvConfigurationService creates new instance of the EnvConfigurationService, setting up all dependencies and returns the instance
+// NewEnvConfigurationService creates new instance of the EnvConfigurationService, setting up all dependencies and returns the instance.
+// The secret options referencing a file or a Vault secret are replaced with the secrets they reference.
 // Returns the new service or error if something goes wrong
 func NewEnvConfigurationService() (ConfigurationContract, error) {
-	return &envConfigurationService{}, nil
+	return newEnvConfigurationService(os.Getenv)
 }
 
 // GetGrpcHost retrieves the gRPC host name
 // Returns the gRPC host name or error if something goes wrong
 func (service *envConfigurationService) GetGrpcHost() (string, error) {
-	return os.Getenv("GRPC_HOST"), nil
+	return service.getHost("GRPC_HOST")
 }
 
 // GetGrpcPort retrieves the gRPC port number
 // Returns the gRPC port number or error if something goes wrong
 func (service *envConfigurationService) GetGrpcPort() (int, error) {
-	portNumberString := os.Getenv("GRPC_PORT")
+	portNumberString := service.getVariable("GRPC_PORT")
 	if strings.Trim(portNumberString, " ") == "" {
 		return 0, commonErrors.NewUnknownError("GRPC_PORT is required")
 	}
@@ -40,16 +57,160 @@ func (service *envConfigurationService) GetGrpcPort() (int, error) {
 	return portNumber, nil
 }
 
+// GetGrpcShutdownTimeout retrieves the time the gRPC server waits for the in-flight calls to finish when stopping
+// Returns the gRPC shutdown timeout or error if something goes wrong
+func (service *envConfigurationService) GetGrpcShutdownTimeout() (time.Duration, error) {
+	shutdownTimeoutString := strings.Trim(service.getVariable("GRPC_SHUTDOWN_TIMEOUT"), " ")
+	if shutdownTimeoutString == "" {
+		return 30 * time.Second, nil
+	}
+
+	shutdownTimeout, err := time.ParseDuration(shutdownTimeoutString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert GRPC_SHUTDOWN_TIMEOUT to duration", err)
+	}
+
+	return shutdownTimeout, nil
+}
+
+// GetGrpcShutdownDrainDelay retrieves the time the gRPC server keeps serving when stopping after it reports it is not
+// ready, so the load balancers stop routing the new calls to it before it stops accepting them
+// Returns the gRPC shutdown drain delay or error if something goes wrong
+func (service *envConfigurationService) GetGrpcShutdownDrainDelay() (time.Duration, error) {
+	drainDelayString := strings.Trim(service.getVariable("GRPC_SHUTDOWN_DRAIN_DELAY"), " ")
+	if drainDelayString == "" {
+		return 5 * time.Second, nil
+	}
+
+	drainDelay, err := time.ParseDuration(drainDelayString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert GRPC_SHUTDOWN_DRAIN_DELAY to duration", err)
+	}
+
+	if drainDelay < 0 {
+		return 0, commonErrors.NewUnknownError("GRPC_SHUTDOWN_DRAIN_DELAY must not be negative")
+	}
+
+	return drainDelay, nil
+}
+
+// GetGrpcReflectionEnabled retrieves whether the gRPC server reflection is registered so tools such as grpcurl can
+// discover the operations without the proto files
+// Returns true if the gRPC server reflection is enabled or error if something goes wrong
+func (service *envConfigurationService) GetGrpcReflectionEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("GRPC_REFLECTION_ENABLED"), " ")
+	if enabledString == "" {
+		return true, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert GRPC_REFLECTION_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetGrpcStrictDecodingEnabled retrieves whether the UpdateUser requests carrying the fields this service does not
+// know, e.g. sent by the clients built against a newer contract, are rejected instead of the fields being dropped
+// Returns true if the requests carrying unknown fields are rejected or error if something goes wrong
+func (service *envConfigurationService) GetGrpcStrictDecodingEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("GRPC_STRICT_DECODING_ENABLED"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert GRPC_STRICT_DECODING_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetGrpcMaxReceiveMessageSize retrieves the maximum size of the messages the gRPC server receives, including the
+// Connect requests served on the HTTP port
+// Returns the maximum size in bytes or error if something goes wrong
+func (service *envConfigurationService) GetGrpcMaxReceiveMessageSize() (int, error) {
+	maxSizeString := strings.Trim(service.getVariable("GRPC_MAX_RECEIVE_MESSAGE_SIZE"), " ")
+	if maxSizeString == "" {
+		return 4 * 1024 * 1024, nil
+	}
+
+	maxSize, err := strconv.Atoi(maxSizeString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert GRPC_MAX_RECEIVE_MESSAGE_SIZE to integer", err)
+	}
+
+	if maxSize <= 0 {
+		return 0, commonErrors.NewUnknownError("GRPC_MAX_RECEIVE_MESSAGE_SIZE must be greater than zero")
+	}
+
+	return maxSize, nil
+}
+
+// GetEndpointDefaultTimeout retrieves the time the calls to the endpoints that have no timeout of their own are
+// cancelled after, the streaming endpoints only have a timeout if it is set for them
+// Returns the default timeout, zero if the calls have no timeout, or error if something goes wrong
+func (service *envConfigurationService) GetEndpointDefaultTimeout() (time.Duration, error) {
+	timeoutString := strings.Trim(service.getVariable("ENDPOINT_DEFAULT_TIMEOUT"), " ")
+	if timeoutString == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(timeoutString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert ENDPOINT_DEFAULT_TIMEOUT to duration", err)
+	}
+
+	if timeout <= 0 {
+		return 0, commonErrors.NewUnknownError("ENDPOINT_DEFAULT_TIMEOUT must be greater than zero")
+	}
+
+	return timeout, nil
+}
+
+// GetEndpointTimeouts retrieves the time the calls to each endpoint are cancelled after, overriding the default timeout
+// Returns the map of the endpoint name to its timeout or error if something goes wrong
+func (service *envConfigurationService) GetEndpointTimeouts() (map[string]time.Duration, error) {
+	timeouts := map[string]time.Duration{}
+	timeoutsString := strings.Trim(service.getVariable("ENDPOINT_TIMEOUTS"), " ")
+
+	if timeoutsString == "" {
+		return timeouts, nil
+	}
+
+	for _, pair := range strings.Split(timeoutsString, ",") {
+		parts := strings.Split(pair, "=")
+		if len(parts) != 2 || strings.Trim(parts[0], " ") == "" {
+			return nil, commonErrors.NewUnknownError(fmt.Sprintf("ENDPOINT_TIMEOUTS contains invalid endpoint=timeout pair: %s", pair))
+		}
+
+		timeout, err := time.ParseDuration(strings.Trim(parts[1], " "))
+		if err != nil {
+			return nil, commonErrors.NewUnknownErrorWithError(fmt.Sprintf("failed to convert the timeout of ENDPOINT_TIMEOUTS pair %s to duration", pair), err)
+		}
+
+		if timeout <= 0 {
+			return nil, commonErrors.NewUnknownError(fmt.Sprintf("ENDPOINT_TIMEOUTS contains timeout that is not greater than zero: %s", pair))
+		}
+
+		timeouts[strings.Trim(parts[0], " ")] = timeout
+	}
+
+	return timeouts, nil
+}
+
 // GetHttpHost retrieves the HTTP host name
 // Returns the HTTP host name or error if something goes wrong
 func (service *envConfigurationService) GetHttpHost() (string, error) {
-	return os.Getenv("HTTP_HOST"), nil
+	return service.getHost("HTTP_HOST")
 }
 
 // GetHttpPort retrieves the HTTP port number
 // Returns the HTTP port number or error if something goes wrong
 func (service *envConfigurationService) GetHttpPort() (int, error) {
-	portNumberString := os.Getenv("HTTP_PORT")
+	portNumberString := service.getVariable("HTTP_PORT")
 	if strings.Trim(portNumberString, " ") == "" {
 		return 0, commonErrors.NewUnknownError("HTTP_PORT is required")
 	}
@@ -62,10 +223,220 @@ func (service *envConfigurationService) GetHttpPort() (int, error) {
 	return portNumber, nil
 }
 
+// GetHttpProfilingEnabled retrieves whether the HTTP server serves the runtime profiles, e.g. the goroutine and heap
+// profiles the support bundle collects
+// Returns true if the runtime profiles are served or error if something goes wrong
+func (service *envConfigurationService) GetHttpProfilingEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("HTTP_PROFILING_ENABLED"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert HTTP_PROFILING_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetConnectEnabled retrieves whether the HTTP server serves the gRPC operations over the Connect protocol so the
+// browsers, e.g. the web console, can call the service directly
+// Returns true if the Connect protocol is served or error if something goes wrong
+func (service *envConfigurationService) GetConnectEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("CONNECT_ENABLED"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert CONNECT_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetCorsAllowedOrigins retrieves the origins the browsers are allowed to call the HTTP endpoints, e.g. the
+// operations served over the Connect protocol, from
+// Returns the list of the allowed origins or error if something goes wrong
+func (service *envConfigurationService) GetCorsAllowedOrigins() ([]string, error) {
+	allowedOrigins := []string{}
+
+	for _, origin := range strings.Split(service.getVariable("CORS_ALLOWED_ORIGINS"), ",") {
+		if origin = strings.Trim(origin, " "); origin != "" {
+			allowedOrigins = append(allowedOrigins, origin)
+		}
+	}
+
+	return allowedOrigins, nil
+}
+
+// GetCorsAllowedMethods retrieves the methods the browsers are allowed to call the HTTP endpoints with
+// Returns the list of the allowed methods or error if something goes wrong
+func (service *envConfigurationService) GetCorsAllowedMethods() ([]string, error) {
+	methodsString := strings.Trim(service.getVariable("CORS_ALLOWED_METHODS"), " ")
+	if methodsString == "" {
+		return []string{http.MethodGet, http.MethodPost}, nil
+	}
+
+	allowedMethods := []string{}
+
+	for _, method := range strings.Split(methodsString, ",") {
+		if method = strings.ToUpper(strings.Trim(method, " ")); method != "" {
+			allowedMethods = append(allowedMethods, method)
+		}
+	}
+
+	return allowedMethods, nil
+}
+
+// GetCorsAllowedHeaders retrieves the headers the browsers are allowed to send to the HTTP endpoints on top of the
+// ones the service always allows, e.g. the headers the ingress requires
+// Returns the list of the allowed headers or error if something goes wrong
+func (service *envConfigurationService) GetCorsAllowedHeaders() ([]string, error) {
+	allowedHeaders := []string{}
+
+	for _, header := range strings.Split(service.getVariable("CORS_ALLOWED_HEADERS"), ",") {
+		if header = strings.Trim(header, " "); header != "" {
+			allowedHeaders = append(allowedHeaders, header)
+		}
+	}
+
+	return allowedHeaders, nil
+}
+
+// GetCorsMaxAge retrieves how long the browsers cache the result of the CORS preflight requests
+// Returns the max age of the preflight results or error if something goes wrong
+func (service *envConfigurationService) GetCorsMaxAge() (time.Duration, error) {
+	maxAgeString := strings.Trim(service.getVariable("CORS_MAX_AGE"), " ")
+	if maxAgeString == "" {
+		return 10 * time.Minute, nil
+	}
+
+	maxAge, err := time.ParseDuration(maxAgeString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert CORS_MAX_AGE to duration", err)
+	}
+
+	if maxAge < 0 {
+		return 0, commonErrors.NewUnknownError("CORS_MAX_AGE must not be negative")
+	}
+
+	return maxAge, nil
+}
+
+// GetHstsMaxAge retrieves how long the browsers only call the host of the HTTP endpoints over HTTPS
+// Returns the max age of the Strict-Transport-Security header, zero if it is not sent, or error if something goes
+// wrong
+func (service *envConfigurationService) GetHstsMaxAge() (time.Duration, error) {
+	maxAgeString := strings.Trim(service.getVariable("HSTS_MAX_AGE"), " ")
+	if maxAgeString == "" {
+		return 0, nil
+	}
+
+	maxAge, err := time.ParseDuration(maxAgeString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert HSTS_MAX_AGE to duration", err)
+	}
+
+	if maxAge < 0 {
+		return 0, commonErrors.NewUnknownError("HSTS_MAX_AGE must not be negative")
+	}
+
+	return maxAge, nil
+}
+
+// GetHstsIncludeSubdomains retrieves whether the browsers only call the subdomains of the host of the HTTP endpoints
+// over HTTPS too
+// Returns true if the Strict-Transport-Security header includes the subdomains or error if something goes wrong
+func (service *envConfigurationService) GetHstsIncludeSubdomains() (bool, error) {
+	includeString := strings.Trim(service.getVariable("HSTS_INCLUDE_SUBDOMAINS"), " ")
+	if includeString == "" {
+		return false, nil
+	}
+
+	include, err := strconv.ParseBool(includeString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert HSTS_INCLUDE_SUBDOMAINS to boolean", err)
+	}
+
+	return include, nil
+}
+
+// GetContentSecurityPolicy retrieves the Content-Security-Policy the responses of the HTTP endpoints are sent with
+// Returns the content security policy, empty if it is not sent, or error if something goes wrong
+func (service *envConfigurationService) GetContentSecurityPolicy() (string, error) {
+	policy := strings.Trim(service.getVariable("CONTENT_SECURITY_POLICY"), " ")
+	if policy == "" {
+		return "default-src 'none'; frame-ancestors 'none'", nil
+	}
+
+	// The ingress may set its own policy, so the service can be told not to send one
+	if strings.EqualFold(policy, "off") {
+		return "", nil
+	}
+
+	return policy, nil
+}
+
+// GetGraphQLHost retrieves the GraphQL host name
+// Returns the GraphQL host name or error if something goes wrong
+func (service *envConfigurationService) GetGraphQLHost() (string, error) {
+	return service.getHost("GRAPHQL_HOST")
+}
+
+// GetGraphQLPort retrieves the GraphQL port number
+// Returns the GraphQL port number or error if something goes wrong
+func (service *envConfigurationService) GetGraphQLPort() (int, error) {
+	portNumberString := service.getVariable("GRAPHQL_PORT")
+	if strings.Trim(portNumberString, " ") == "" {
+		return 0, commonErrors.NewUnknownError("GRAPHQL_PORT is required")
+	}
+
+	portNumber, err := strconv.Atoi(portNumberString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert GRAPHQL_PORT to integer", err)
+	}
+
+	return portNumber, nil
+}
+
+// GetDatabaseType retrieves the type of the database to persist the users in
+// Returns the database type or error if something goes wrong
+func (service *envConfigurationService) GetDatabaseType() (string, error) {
+	databaseType := strings.ToLower(strings.Trim(service.getVariable("DATABASE_TYPE"), " "))
+	if databaseType == "" {
+		return "mongodb", nil
+	}
+
+	if databaseType != "mongodb" && databaseType != "postgres" {
+		return "", commonErrors.NewUnknownError(fmt.Sprintf("DATABASE_TYPE is not supported: %s", databaseType))
+	}
+
+	return databaseType, nil
+}
+
+// GetRepositoryType retrieves the type of the repository the users are kept in. The repository is the database set by
+// the database type unless a different one is asked for.
+// Returns the repository type or error if something goes wrong
+func (service *envConfigurationService) GetRepositoryType() (string, error) {
+	repositoryType := strings.ToLower(strings.Trim(service.getVariable("REPOSITORY_TYPE"), " "))
+	if repositoryType == "" {
+		return service.GetDatabaseType()
+	}
+
+	if repositoryType != "mongodb" && repositoryType != "postgres" && repositoryType != "memory" && repositoryType != "cached" {
+		return "", commonErrors.NewUnknownError(fmt.Sprintf("REPOSITORY_TYPE is not supported: %s", repositoryType))
+	}
+
+	return repositoryType, nil
+}
+
 // GetDatabaseConnectionString retrieves the database connection string
 // Returns the database connection string or error if something goes wrong
 func (service *envConfigurationService) GetDatabaseConnectionString() (string, error) {
-	connectionString := os.Getenv("DATABASE_CONNECTION_STRING")
+	connectionString := service.getVariable("DATABASE_CONNECTION_STRING")
 
 	if strings.Trim(connectionString, " ") == "" {
 		return "", commonErrors.NewUnknownError("DATABASE_CONNECTION_STRING is required")
@@ -77,7 +448,7 @@ func (service *envConfigurationService) GetDatabaseConnectionString() (string, e
 // GetDatabaseName retrieves the database name
 // Returns the database name or error if something goes wrong
 func (service *envConfigurationService) GetDatabaseName() (string, error) {
-	databaseName := os.Getenv("USER_DATABASE_NAME")
+	databaseName := service.getVariable("USER_DATABASE_NAME")
 
 	if strings.Trim(databaseName, " ") == "" {
 		return "", commonErrors.NewUnknownError("USER_DATABASE_NAME is required")
@@ -89,7 +460,7 @@ func (service *envConfigurationService) GetDatabaseName() (string, error) {
 // GetDatabaseCollectionName retrieves the database collection name
 // Returns the database collection name or error if something goes wrong
 func (service *envConfigurationService) GetDatabaseCollectionName() (string, error) {
-	databaseCollectionName := os.Getenv("USER_DATABASE_COLLECTION_NAME")
+	databaseCollectionName := service.getVariable("USER_DATABASE_COLLECTION_NAME")
 
 	if strings.Trim(databaseCollectionName, " ") == "" {
 		return "", commonErrors.NewUnknownError("USER_DATABASE_COLLECTION_NAME is required")
@@ -98,14 +469,2052 @@ func (service *envConfigurationService) GetDatabaseCollectionName() (string, err
 	return databaseCollectionName, nil
 }
 
-// GetJwksURL retrieves the JWKS URL
-// Returns the JWKS URL or error if something goes wrong
-func (service *envConfigurationService) GetJwksURL() (string, error) {
-	jwksURL := os.Getenv("JWKS_URL")
+// GetDatabaseSearchIndexHints retrieves the index hints to use for search based on the shape of the search filter.
+// The hints are provided as comma separated list of shape=index pairs, where shape is the list of the filtered
+// field names joined by "+" (e.g. "email=email_1,email+labels=email_1_labels_1").
+// Returns the map of the filter shape to the index name or error if something goes wrong
+func (service *envConfigurationService) GetDatabaseSearchIndexHints() (map[string]string, error) {
+	indexHints := map[string]string{}
+	indexHintsString := strings.Trim(service.getVariable("USER_DATABASE_SEARCH_INDEX_HINTS"), " ")
 
-	if strings.Trim(jwksURL, " ") == "" {
-		return "", commonErrors.NewUnknownError("JWKS_URL is required")
+	if indexHintsString == "" {
+		return indexHints, nil
 	}
 
-	return jwksURL, nil
+	for _, pair := range strings.Split(indexHintsString, ",") {
+		parts := strings.Split(pair, "=")
+		if len(parts) != 2 || strings.Trim(parts[0], " ") == "" || strings.Trim(parts[1], " ") == "" {
+			return nil, commonErrors.NewUnknownError(fmt.Sprintf("USER_DATABASE_SEARCH_INDEX_HINTS contains invalid shape=index pair: %s", pair))
+		}
+
+		fields := strings.Split(parts[0], "+")
+		for index, field := range fields {
+			fields[index] = strings.Trim(field, " ")
+		}
+
+		sort.Strings(fields)
+		indexHints[strings.Join(fields, "+")] = strings.Trim(parts[1], " ")
+	}
+
+	return indexHints, nil
+}
+
+// GetDatabaseSearchSortableFields retrieves the fields the search result is allowed to be sorted by, the fields
+// the database has indexes for. Sorting by the other fields makes the database scan the whole collection.
+// Returns the list of the field names or error if something goes wrong
+func (service *envConfigurationService) GetDatabaseSearchSortableFields() ([]string, error) {
+	fieldsString := strings.Trim(service.getVariable("USER_DATABASE_SEARCH_SORTABLE_FIELDS"), " ")
+	if fieldsString == "" {
+		return []string{"email", "createdAt"}, nil
+	}
+
+	fields := []string{}
+
+	for _, field := range strings.Split(fieldsString, ",") {
+		if field = strings.Trim(field, " "); field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, nil
+}
+
+// GetDatabaseSearchQueryPlanStatisticsEnabled retrieves whether the query plan statistics of the searches should be recorded
+// Returns true if the query plan statistics should be recorded or error if something goes wrong
+func (service *envConfigurationService) GetDatabaseSearchQueryPlanStatisticsEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("USER_DATABASE_SEARCH_QUERY_PLAN_STATISTICS"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert USER_DATABASE_SEARCH_QUERY_PLAN_STATISTICS to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetDatabaseStartupCheckEnabled retrieves whether the database is checked in the background at startup, so the
+// service starts and reports it is not ready while the database is unavailable instead of failing to start
+// Returns true if the startup check is enabled or error if something goes wrong
+func (service *envConfigurationService) GetDatabaseStartupCheckEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("USER_DATABASE_STARTUP_CHECK_ENABLED"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert USER_DATABASE_STARTUP_CHECK_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetDatabaseStartupCheckInitialBackoff retrieves how long the startup check waits before retrying the database
+// for the first time, the wait doubles after every failed attempt
+// Returns the initial backoff or error if something goes wrong
+func (service *envConfigurationService) GetDatabaseStartupCheckInitialBackoff() (time.Duration, error) {
+	backoffString := strings.Trim(service.getVariable("USER_DATABASE_STARTUP_CHECK_INITIAL_BACKOFF"), " ")
+	if backoffString == "" {
+		return time.Second, nil
+	}
+
+	backoff, err := time.ParseDuration(backoffString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_DATABASE_STARTUP_CHECK_INITIAL_BACKOFF to duration", err)
+	}
+
+	if backoff <= 0 {
+		return 0, commonErrors.NewUnknownError("USER_DATABASE_STARTUP_CHECK_INITIAL_BACKOFF must be positive")
+	}
+
+	return backoff, nil
+}
+
+// GetDatabaseStartupCheckMaxBackoff retrieves the longest the startup check waits between two attempts
+// Returns the maximum backoff or error if something goes wrong
+func (service *envConfigurationService) GetDatabaseStartupCheckMaxBackoff() (time.Duration, error) {
+	backoffString := strings.Trim(service.getVariable("USER_DATABASE_STARTUP_CHECK_MAX_BACKOFF"), " ")
+	if backoffString == "" {
+		return 30 * time.Second, nil
+	}
+
+	backoff, err := time.ParseDuration(backoffString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_DATABASE_STARTUP_CHECK_MAX_BACKOFF to duration", err)
+	}
+
+	if backoff <= 0 {
+		return 0, commonErrors.NewUnknownError("USER_DATABASE_STARTUP_CHECK_MAX_BACKOFF must be positive")
+	}
+
+	return backoff, nil
+}
+
+// GetDatabaseReadPreference retrieves the members of the MongoDB replica set the users are read from by ReadUser,
+// ReadUsers and the searches, the other operations always read from the primary. Defaults to the primary, as the
+// causally consistent sessions only carry the cluster and operation times observed by the same process, so a read
+// served by a secondary through another replica of the service may not reflect a write made through this one.
+// Returns the read preference mode or error if something goes wrong
+func (service *envConfigurationService) GetDatabaseReadPreference() (string, error) {
+	readPreference := strings.Trim(service.getVariable("USER_DATABASE_READ_PREFERENCE"), " ")
+	if readPreference == "" {
+		return "primary", nil
+	}
+
+	for _, mode := range []string{"primary", "primaryPreferred", "secondary", "secondaryPreferred", "nearest"} {
+		if strings.EqualFold(readPreference, mode) {
+			return mode, nil
+		}
+	}
+
+	return "", commonErrors.NewUnknownError(fmt.Sprintf("USER_DATABASE_READ_PREFERENCE contains unsupported read preference: %s", readPreference))
+}
+
+// GetDatabaseReadConcern retrieves the level of the MongoDB read concern the users are read with. The reads are only
+// causally consistent with the writes if both the read and the write concerns are majority.
+// Returns the read concern level or error if something goes wrong
+func (service *envConfigurationService) GetDatabaseReadConcern() (string, error) {
+	readConcern := strings.Trim(service.getVariable("USER_DATABASE_READ_CONCERN"), " ")
+	if readConcern == "" {
+		return "majority", nil
+	}
+
+	for _, level := range []string{"local", "available", "majority", "linearizable"} {
+		if strings.EqualFold(readConcern, level) {
+			return level, nil
+		}
+	}
+
+	return "", commonErrors.NewUnknownError(fmt.Sprintf("USER_DATABASE_READ_CONCERN contains unsupported read concern: %s", readConcern))
+}
+
+// GetDatabaseWriteConcern retrieves the MongoDB write concern the users are written with, either majority or the
+// number of the replica set members that must acknowledge the writes. The writes must be acknowledged, so the
+// service can report the operations that failed.
+// Returns the write concern or error if something goes wrong
+func (service *envConfigurationService) GetDatabaseWriteConcern() (string, error) {
+	writeConcern := strings.Trim(service.getVariable("USER_DATABASE_WRITE_CONCERN"), " ")
+	if writeConcern == "" || strings.EqualFold(writeConcern, "majority") {
+		return "majority", nil
+	}
+
+	members, err := strconv.Atoi(writeConcern)
+	if err != nil {
+		return "", commonErrors.NewUnknownErrorWithError("failed to convert USER_DATABASE_WRITE_CONCERN to majority or number", err)
+	}
+
+	if members <= 0 {
+		return "", commonErrors.NewUnknownError("USER_DATABASE_WRITE_CONCERN must be majority or positive")
+	}
+
+	return writeConcern, nil
+}
+
+// GetDataResidencyDefaultRegion retrieves the region the database set by the database connection string is in. The
+// users that do not ask for a specific region are persisted in this region.
+// Returns the default region name or error if something goes wrong
+func (service *envConfigurationService) GetDataResidencyDefaultRegion() (string, error) {
+	return strings.ToLower(strings.Trim(service.getVariable("USER_DATA_RESIDENCY_DEFAULT_REGION"), " ")), nil
+}
+
+// GetDataResidencyRoutes retrieves the connection strings of the databases the users residing in the regions other
+// than the default region are persisted in. The routes are provided as comma separated list of region=connectionString
+// pairs (e.g. "eu=mongodb://mongodb-eu:27017,us=mongodb://mongodb-us:27017"). Only the first "=" of each pair
+// separates the region from the connection string, so the connection string can contain "=".
+// Returns the map of the region name to the database connection string or error if something goes wrong
+func (service *envConfigurationService) GetDataResidencyRoutes() (map[string]string, error) {
+	routes := map[string]string{}
+	routesString := strings.Trim(service.getVariable("USER_DATA_RESIDENCY_ROUTES"), " ")
+
+	if routesString == "" {
+		return routes, nil
+	}
+
+	defaultRegion, err := service.GetDataResidencyDefaultRegion()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pair := range strings.Split(routesString, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.Trim(parts[0], " ") == "" || strings.Trim(parts[1], " ") == "" {
+			return nil, commonErrors.NewUnknownError("USER_DATA_RESIDENCY_ROUTES contains invalid region=connectionString pair")
+		}
+
+		region := strings.ToLower(strings.Trim(parts[0], " "))
+		if region == defaultRegion {
+			return nil, commonErrors.NewUnknownError(fmt.Sprintf("USER_DATA_RESIDENCY_ROUTES must not contain the default region: %s", region))
+		}
+
+		if _, ok := routes[region]; ok {
+			return nil, commonErrors.NewUnknownError(fmt.Sprintf("USER_DATA_RESIDENCY_ROUTES contains duplicate region: %s", region))
+		}
+
+		routes[region] = strings.Trim(parts[1], " ")
+	}
+
+	return routes, nil
+}
+
+// GetReplicationStandbyConnectionString retrieves the connection string of the standby database the primary database
+// is replicated to
+// Returns the standby database connection string, empty if there is no standby database, or error if something goes wrong
+func (service *envConfigurationService) GetReplicationStandbyConnectionString() (string, error) {
+	return strings.Trim(service.getVariable("USER_REPLICATION_STANDBY_CONNECTION_STRING"), " "), nil
+}
+
+// GetReplicationHeartbeatCollectionName retrieves the name of the database collection the replication heartbeats are
+// written to
+// Returns the replication heartbeat collection name or error if something goes wrong
+func (service *envConfigurationService) GetReplicationHeartbeatCollectionName() (string, error) {
+	collectionName := strings.Trim(service.getVariable("USER_REPLICATION_HEARTBEAT_COLLECTION_NAME"), " ")
+	if collectionName == "" {
+		return "replication_heartbeat", nil
+	}
+
+	return collectionName, nil
+}
+
+// GetReplicationHeartbeatInterval retrieves how often the replication heartbeat is written to the primary database
+// and read from the standby database
+// Returns the heartbeat interval or error if something goes wrong
+func (service *envConfigurationService) GetReplicationHeartbeatInterval() (time.Duration, error) {
+	intervalString := strings.Trim(service.getVariable("USER_REPLICATION_HEARTBEAT_INTERVAL"), " ")
+	if intervalString == "" {
+		return 10 * time.Second, nil
+	}
+
+	interval, err := time.ParseDuration(intervalString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_REPLICATION_HEARTBEAT_INTERVAL to duration", err)
+	}
+
+	if interval <= 0 {
+		return 0, commonErrors.NewUnknownError("USER_REPLICATION_HEARTBEAT_INTERVAL must be greater than zero")
+	}
+
+	return interval, nil
+}
+
+// GetReplicationMaxLag retrieves how far behind the primary database the standby database can be while still being
+// ready to fail over to
+// Returns the maximum replication lag or error if something goes wrong
+func (service *envConfigurationService) GetReplicationMaxLag() (time.Duration, error) {
+	maxLagString := strings.Trim(service.getVariable("USER_REPLICATION_MAX_LAG"), " ")
+	if maxLagString == "" {
+		return time.Minute, nil
+	}
+
+	maxLag, err := time.ParseDuration(maxLagString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_REPLICATION_MAX_LAG to duration", err)
+	}
+
+	if maxLag <= 0 {
+		return 0, commonErrors.NewUnknownError("USER_REPLICATION_MAX_LAG must be greater than zero")
+	}
+
+	return maxLag, nil
+}
+
+// GetCacheEnabled retrieves whether the users read from the database are cached in Redis
+// Returns true if the users are cached or error if something goes wrong
+func (service *envConfigurationService) GetCacheEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("USER_CACHE_ENABLED"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert USER_CACHE_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetCacheRedisAddress retrieves the address of the Redis server the users are cached in
+// Returns the Redis server address or error if something goes wrong
+func (service *envConfigurationService) GetCacheRedisAddress() (string, error) {
+	address := strings.Trim(service.getVariable("USER_CACHE_REDIS_ADDRESS"), " ")
+	if address == "" {
+		return "", commonErrors.NewUnknownError("USER_CACHE_REDIS_ADDRESS is required")
+	}
+
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return "", commonErrors.NewUnknownErrorWithError("USER_CACHE_REDIS_ADDRESS must be in the host:port format", err)
+	}
+
+	return address, nil
+}
+
+// GetCacheRedisPassword retrieves the password used to authenticate with the Redis server
+// Returns the password, empty if the Redis server does not require authentication, or error if something goes wrong
+func (service *envConfigurationService) GetCacheRedisPassword() (string, error) {
+	return service.getVariable("USER_CACHE_REDIS_PASSWORD"), nil
+}
+
+// GetCacheRedisDatabase retrieves the number of the Redis database the users are cached in
+// Returns the Redis database number or error if something goes wrong
+func (service *envConfigurationService) GetCacheRedisDatabase() (int, error) {
+	databaseString := strings.Trim(service.getVariable("USER_CACHE_REDIS_DATABASE"), " ")
+	if databaseString == "" {
+		return 0, nil
+	}
+
+	database, err := strconv.Atoi(databaseString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_CACHE_REDIS_DATABASE to integer", err)
+	}
+
+	if database < 0 {
+		return 0, commonErrors.NewUnknownError("USER_CACHE_REDIS_DATABASE must not be negative")
+	}
+
+	return database, nil
+}
+
+// GetCacheTTL retrieves how long a cached user is kept before it is read from the database again
+// Returns the time the cached users are kept for or error if something goes wrong
+func (service *envConfigurationService) GetCacheTTL() (time.Duration, error) {
+	ttlString := strings.Trim(service.getVariable("USER_CACHE_TTL"), " ")
+	if ttlString == "" {
+		return 5 * time.Minute, nil
+	}
+
+	ttl, err := time.ParseDuration(ttlString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_CACHE_TTL to duration", err)
+	}
+
+	if ttl < time.Millisecond {
+		return 0, commonErrors.NewUnknownError("USER_CACHE_TTL must be at least 1ms")
+	}
+
+	return ttl, nil
+}
+
+// GetCacheKeyPrefix retrieves the prefix of the Redis keys the users are cached under
+// Returns the key prefix or error if something goes wrong
+func (service *envConfigurationService) GetCacheKeyPrefix() (string, error) {
+	keyPrefix := strings.Trim(service.getVariable("USER_CACHE_KEY_PREFIX"), " ")
+	if keyPrefix == "" {
+		return "user:", nil
+	}
+
+	return keyPrefix, nil
+}
+
+// GetCacheLocalTTL retrieves how long a user is kept in the memory of the replica in front of Redis
+// Returns the time the users are kept in memory for, zero if the users are not kept in memory, or error if something goes wrong
+func (service *envConfigurationService) GetCacheLocalTTL() (time.Duration, error) {
+	ttlString := strings.Trim(service.getVariable("USER_CACHE_LOCAL_TTL"), " ")
+	if ttlString == "" {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(ttlString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_CACHE_LOCAL_TTL to duration", err)
+	}
+
+	if ttl < 0 {
+		return 0, commonErrors.NewUnknownError("USER_CACHE_LOCAL_TTL must not be negative")
+	}
+
+	return ttl, nil
+}
+
+// GetCacheLocalMaxEntries retrieves the maximum number of the users kept in the memory of the replica
+// Returns the maximum number of the users kept in memory or error if something goes wrong
+func (service *envConfigurationService) GetCacheLocalMaxEntries() (int, error) {
+	maxEntriesString := strings.Trim(service.getVariable("USER_CACHE_LOCAL_MAX_ENTRIES"), " ")
+	if maxEntriesString == "" {
+		return 10000, nil
+	}
+
+	maxEntries, err := strconv.Atoi(maxEntriesString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_CACHE_LOCAL_MAX_ENTRIES to integer", err)
+	}
+
+	if maxEntries < 1 {
+		return 0, commonErrors.NewUnknownError("USER_CACHE_LOCAL_MAX_ENTRIES must be at least 1")
+	}
+
+	return maxEntries, nil
+}
+
+// GetReadDeduplicationEnabled retrieves whether the concurrent identical reads share a single repository query
+// Returns whether the reads are deduplicated or error if something goes wrong
+func (service *envConfigurationService) GetReadDeduplicationEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("USER_READ_DEDUPLICATION_ENABLED"), " ")
+	if enabledString == "" {
+		return true, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert USER_READ_DEDUPLICATION_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetEventingBroker retrieves the message broker to publish the user lifecycle events to
+// Returns the message broker name or error if something goes wrong
+func (service *envConfigurationService) GetEventingBroker() (string, error) {
+	broker := strings.ToLower(strings.Trim(service.getVariable("EVENTING_BROKER"), " "))
+	if broker == "" {
+		return "none", nil
+	}
+
+	if broker != "none" && broker != "nats" {
+		return "", commonErrors.NewUnknownError(fmt.Sprintf("EVENTING_BROKER is not supported: %s", broker))
+	}
+
+	return broker, nil
+}
+
+// GetEventingConnectionString retrieves the message broker connection string
+// Returns the message broker connection string or error if something goes wrong
+func (service *envConfigurationService) GetEventingConnectionString() (string, error) {
+	connectionString := service.getVariable("EVENTING_CONNECTION_STRING")
+
+	if strings.Trim(connectionString, " ") == "" {
+		return "", commonErrors.NewUnknownError("EVENTING_CONNECTION_STRING is required")
+	}
+
+	return connectionString, nil
+}
+
+// GetEventingSubjectPrefix retrieves the prefix of the subjects the user lifecycle events are published to
+// Returns the subject prefix or error if something goes wrong
+func (service *envConfigurationService) GetEventingSubjectPrefix() (string, error) {
+	subjectPrefix := strings.Trim(service.getVariable("USER_EVENTING_SUBJECT_PREFIX"), " ")
+	if subjectPrefix == "" {
+		return "user", nil
+	}
+
+	return subjectPrefix, nil
+}
+
+// GetWatchBufferSize retrieves how many user changes are buffered for a watcher before the watcher is considered too
+// slow and its stream is ended
+// Returns the watch buffer size or error if something goes wrong
+func (service *envConfigurationService) GetWatchBufferSize() (int, error) {
+	bufferSizeString := strings.Trim(service.getVariable("USER_WATCH_BUFFER_SIZE"), " ")
+	if bufferSizeString == "" {
+		return 64, nil
+	}
+
+	bufferSize, err := strconv.Atoi(bufferSizeString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_WATCH_BUFFER_SIZE to integer", err)
+	}
+
+	if bufferSize < 1 {
+		return 0, commonErrors.NewUnknownError("USER_WATCH_BUFFER_SIZE must be at least 1")
+	}
+
+	return bufferSize, nil
+}
+
+// GetWatchSource retrieves where the user changes the watchers are notified of are read from
+// Returns the watch source name or error if something goes wrong
+func (service *envConfigurationService) GetWatchSource() (string, error) {
+	source := strings.ToLower(strings.Trim(service.getVariable("USER_WATCH_SOURCE"), " "))
+	if source == "" {
+		return "events", nil
+	}
+
+	if source != "events" && source != "change-stream" {
+		return "", commonErrors.NewUnknownError(fmt.Sprintf("USER_WATCH_SOURCE is not supported: %s", source))
+	}
+
+	return source, nil
+}
+
+// GetEmailVerificationProvider retrieves how the verification emails are sent, either by publishing an event for
+// another service to send them or by sending them through the SMTP server
+// Returns the email verification provider name or error if something goes wrong
+func (service *envConfigurationService) GetEmailVerificationProvider() (string, error) {
+	provider := strings.ToLower(strings.Trim(service.getVariable("USER_EMAIL_VERIFICATION_PROVIDER"), " "))
+	if provider == "" {
+		return "event", nil
+	}
+
+	if provider != "event" && provider != "smtp" {
+		return "", commonErrors.NewUnknownError(fmt.Sprintf("USER_EMAIL_VERIFICATION_PROVIDER is not supported: %s", provider))
+	}
+
+	return provider, nil
+}
+
+// GetEmailVerificationTokenTTL retrieves how long the token sent in a verification email is valid for
+// Returns the time the token is valid for or error if something goes wrong
+func (service *envConfigurationService) GetEmailVerificationTokenTTL() (time.Duration, error) {
+	tokenTTLString := strings.Trim(service.getVariable("USER_EMAIL_VERIFICATION_TOKEN_TTL"), " ")
+	if tokenTTLString == "" {
+		return 24 * time.Hour, nil
+	}
+
+	tokenTTL, err := time.ParseDuration(tokenTTLString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_EMAIL_VERIFICATION_TOKEN_TTL to duration", err)
+	}
+
+	if tokenTTL <= 0 {
+		return 0, commonErrors.NewUnknownError("USER_EMAIL_VERIFICATION_TOKEN_TTL must be greater than zero")
+	}
+
+	return tokenTTL, nil
+}
+
+// GetEmailVerificationURL retrieves the URL of the page the users verify their email address on, the verification
+// email links to it with the email address and the token as query parameters
+// Returns the email verification URL or error if something goes wrong
+func (service *envConfigurationService) GetEmailVerificationURL() (string, error) {
+	verificationURL := strings.Trim(service.getVariable("USER_EMAIL_VERIFICATION_URL"), " ")
+	if verificationURL == "" {
+		return "", commonErrors.NewUnknownError("USER_EMAIL_VERIFICATION_URL is required")
+	}
+
+	parsedURL, err := url.Parse(verificationURL)
+	if err != nil || !parsedURL.IsAbs() {
+		return "", commonErrors.NewUnknownError(fmt.Sprintf("USER_EMAIL_VERIFICATION_URL must be an absolute URL: %s", verificationURL))
+	}
+
+	return verificationURL, nil
+}
+
+// GetSmtpAddress retrieves the host and port of the SMTP server the verification emails are sent through
+// Returns the SMTP server address or error if something goes wrong
+func (service *envConfigurationService) GetSmtpAddress() (string, error) {
+	address := strings.Trim(service.getVariable("SMTP_ADDRESS"), " ")
+	if address == "" {
+		return "", commonErrors.NewUnknownError("SMTP_ADDRESS is required")
+	}
+
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return "", commonErrors.NewUnknownErrorWithError("SMTP_ADDRESS must be in host:port format", err)
+	}
+
+	return address, nil
+}
+
+// GetSmtpUsername retrieves the username used to authenticate with the SMTP server
+// Returns the username, empty if the SMTP server does not require authentication, or error if something goes wrong
+func (service *envConfigurationService) GetSmtpUsername() (string, error) {
+	return strings.Trim(service.getVariable("SMTP_USERNAME"), " "), nil
+}
+
+// GetSmtpPassword retrieves the password used to authenticate with the SMTP server
+// Returns the password or error if something goes wrong
+func (service *envConfigurationService) GetSmtpPassword() (string, error) {
+	return service.getVariable("SMTP_PASSWORD"), nil
+}
+
+// GetSmtpFrom retrieves the email address the verification emails are sent from
+// Returns the sender email address or error if something goes wrong
+func (service *envConfigurationService) GetSmtpFrom() (string, error) {
+	from := strings.Trim(service.getVariable("SMTP_FROM"), " ")
+	if from == "" {
+		return "", commonErrors.NewUnknownError("SMTP_FROM is required")
+	}
+
+	return from, nil
+}
+
+// GetAvatarsEnabled retrieves whether the users can upload an avatar image, which requires an object storage
+// Returns true if the avatars are enabled or error if something goes wrong
+func (service *envConfigurationService) GetAvatarsEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("USER_AVATARS_ENABLED"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert USER_AVATARS_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetAvatarMaxSize retrieves the maximum size of the avatar images
+// Returns the maximum size in bytes or error if something goes wrong
+func (service *envConfigurationService) GetAvatarMaxSize() (int, error) {
+	maxSizeString := strings.Trim(service.getVariable("USER_AVATAR_MAX_SIZE"), " ")
+	if maxSizeString == "" {
+		return 1024 * 1024, nil
+	}
+
+	maxSize, err := strconv.Atoi(maxSizeString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_AVATAR_MAX_SIZE to integer", err)
+	}
+
+	if maxSize <= 0 {
+		return 0, commonErrors.NewUnknownError("USER_AVATAR_MAX_SIZE must be greater than zero")
+	}
+
+	return maxSize, nil
+}
+
+// GetAvatarAllowedContentTypes retrieves the content types the avatar images are accepted in
+// Returns the list of the MIME types or error if something goes wrong
+func (service *envConfigurationService) GetAvatarAllowedContentTypes() ([]string, error) {
+	contentTypes := []string{}
+
+	for _, contentType := range strings.Split(service.getVariable("USER_AVATAR_ALLOWED_CONTENT_TYPES"), ",") {
+		if contentType = strings.ToLower(strings.Trim(contentType, " ")); contentType != "" {
+			contentTypes = append(contentTypes, contentType)
+		}
+	}
+
+	if len(contentTypes) == 0 {
+		return []string{"image/png", "image/jpeg", "image/webp"}, nil
+	}
+
+	return contentTypes, nil
+}
+
+// GetAvatarURLExpiry retrieves how long the presigned URLs the avatar images are downloaded from are valid for
+// Returns the expiry of the URLs or error if something goes wrong
+func (service *envConfigurationService) GetAvatarURLExpiry() (time.Duration, error) {
+	expiryString := strings.Trim(service.getVariable("USER_AVATAR_URL_EXPIRY"), " ")
+	if expiryString == "" {
+		return 15 * time.Minute, nil
+	}
+
+	expiry, err := time.ParseDuration(expiryString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_AVATAR_URL_EXPIRY to duration", err)
+	}
+
+	// The presigned URLs of the S3 signature version 4 can not be valid for longer than a week
+	if expiry <= 0 || expiry > 7*24*time.Hour {
+		return 0, commonErrors.NewUnknownError("USER_AVATAR_URL_EXPIRY must be greater than zero and at most 168h")
+	}
+
+	return expiry, nil
+}
+
+// GetObjectStorageEndpoint retrieves the URL of the S3 compatible object storage, e.g. MinIO
+// Returns the URL, or empty to use Amazon S3, or error if something goes wrong
+func (service *envConfigurationService) GetObjectStorageEndpoint() (string, error) {
+	return strings.Trim(service.getVariable("OBJECT_STORAGE_ENDPOINT"), " "), nil
+}
+
+// GetObjectStorageRegion retrieves the region of the object storage bucket
+// Returns the region or error if something goes wrong
+func (service *envConfigurationService) GetObjectStorageRegion() (string, error) {
+	region := strings.Trim(service.getVariable("OBJECT_STORAGE_REGION"), " ")
+	if region == "" {
+		return "us-east-1", nil
+	}
+
+	return region, nil
+}
+
+// GetObjectStorageBucket retrieves the name of the bucket the files of the users are stored in
+// Returns the bucket name or error if something goes wrong
+func (service *envConfigurationService) GetObjectStorageBucket() (string, error) {
+	bucket := strings.Trim(service.getVariable("OBJECT_STORAGE_BUCKET"), " ")
+	if bucket == "" {
+		return "", commonErrors.NewUnknownError("OBJECT_STORAGE_BUCKET is required")
+	}
+
+	return bucket, nil
+}
+
+// GetObjectStorageAccessKeyID retrieves the access key ID used to authenticate with the object storage
+// Returns the access key ID, or empty to use the default AWS credential chain, or error if something goes wrong
+func (service *envConfigurationService) GetObjectStorageAccessKeyID() (string, error) {
+	return strings.Trim(service.getVariable("OBJECT_STORAGE_ACCESS_KEY_ID"), " "), nil
+}
+
+// GetObjectStorageSecretAccessKey retrieves the secret access key used to authenticate with the object storage
+// Returns the secret access key or error if something goes wrong
+func (service *envConfigurationService) GetObjectStorageSecretAccessKey() (string, error) {
+	return service.getVariable("OBJECT_STORAGE_SECRET_ACCESS_KEY"), nil
+}
+
+// GetObjectStoragePathStyleEnabled retrieves whether the bucket is addressed in the path rather than in the host
+// name of the URLs, as most of the self-hosted object storages such as MinIO require
+// Returns true if the path style is enabled or error if something goes wrong
+func (service *envConfigurationService) GetObjectStoragePathStyleEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("OBJECT_STORAGE_PATH_STYLE_ENABLED"), " ")
+	if enabledString == "" {
+		return true, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert OBJECT_STORAGE_PATH_STYLE_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetSagaCollectionName retrieves the name of the database collection the saga states are persisted in
+// Returns the saga collection name or error if something goes wrong
+func (service *envConfigurationService) GetSagaCollectionName() (string, error) {
+	collectionName := strings.Trim(service.getVariable("USER_SAGA_COLLECTION_NAME"), " ")
+	if collectionName == "" {
+		return "saga", nil
+	}
+
+	return collectionName, nil
+}
+
+// GetSagaMaxAttempts retrieves the maximum number of times a saga step is attempted before it is considered failed
+// Returns the maximum number of attempts or error if something goes wrong
+func (service *envConfigurationService) GetSagaMaxAttempts() (int, error) {
+	maxAttemptsString := strings.Trim(service.getVariable("SAGA_MAX_ATTEMPTS"), " ")
+	if maxAttemptsString == "" {
+		return 3, nil
+	}
+
+	maxAttempts, err := strconv.Atoi(maxAttemptsString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert SAGA_MAX_ATTEMPTS to integer", err)
+	}
+
+	if maxAttempts < 1 {
+		return 0, commonErrors.NewUnknownError("SAGA_MAX_ATTEMPTS must be at least 1")
+	}
+
+	return maxAttempts, nil
+}
+
+// GetSagaRetryBackoff retrieves the time to wait before retrying a failed saga step for the first time. The
+// time is doubled for every subsequent attempt
+// Returns the retry backoff or error if something goes wrong
+func (service *envConfigurationService) GetSagaRetryBackoff() (time.Duration, error) {
+	retryBackoffString := strings.Trim(service.getVariable("SAGA_RETRY_BACKOFF"), " ")
+	if retryBackoffString == "" {
+		return 100 * time.Millisecond, nil
+	}
+
+	retryBackoff, err := time.ParseDuration(retryBackoffString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert SAGA_RETRY_BACKOFF to duration", err)
+	}
+
+	return retryBackoff, nil
+}
+
+// GetAuditCollectionName retrieves the name of the database collection the audit records are persisted in
+// Returns the audit collection name or error if something goes wrong
+func (service *envConfigurationService) GetAuditCollectionName() (string, error) {
+	collectionName := strings.Trim(service.getVariable("USER_AUDIT_COLLECTION_NAME"), " ")
+	if collectionName == "" {
+		return "audit", nil
+	}
+
+	return collectionName, nil
+}
+
+// GetPasswordCredentialsEnabled retrieves whether the users can sign in with a password managed by the service, for
+// the deployments that cannot rely only on an external identity provider
+// Returns true if the password credentials are enabled or error if something goes wrong
+func (service *envConfigurationService) GetPasswordCredentialsEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("USER_PASSWORD_CREDENTIALS_ENABLED"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert USER_PASSWORD_CREDENTIALS_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetCredentialCollectionName retrieves the name of the database collection the password hashes are persisted in
+// Returns the credential collection name or error if something goes wrong
+func (service *envConfigurationService) GetCredentialCollectionName() (string, error) {
+	collectionName := strings.Trim(service.getVariable("USER_CREDENTIAL_COLLECTION_NAME"), " ")
+	if collectionName == "" {
+		return "credentials", nil
+	}
+
+	return collectionName, nil
+}
+
+// GetPasswordHashingAlgorithm retrieves the algorithm the new passwords are hashed with. The passwords hashed with
+// another algorithm are rehashed the next time they are verified.
+// Returns the password hashing algorithm or error if something goes wrong
+func (service *envConfigurationService) GetPasswordHashingAlgorithm() (string, error) {
+	algorithm := strings.ToLower(strings.Trim(service.getVariable("USER_PASSWORD_HASHING_ALGORITHM"), " "))
+	if algorithm == "" {
+		// argon2id is not FIPS approved, so the FIPS build hashes the passwords with PBKDF2 unless configured otherwise
+		if fips.Built {
+			return "pbkdf2-sha256", nil
+		}
+
+		return "argon2id", nil
+	}
+
+	if algorithm != "argon2id" && algorithm != "bcrypt" && algorithm != "pbkdf2-sha256" {
+		return "", commonErrors.NewUnknownError(fmt.Sprintf("USER_PASSWORD_HASHING_ALGORITHM is not supported: %s", algorithm))
+	}
+
+	return algorithm, nil
+}
+
+// GetPasswordMinLength retrieves the minimum number of the characters a password must contain
+// Returns the minimum password length or error if something goes wrong
+func (service *envConfigurationService) GetPasswordMinLength() (int, error) {
+	minLengthString := strings.Trim(service.getVariable("USER_PASSWORD_MIN_LENGTH"), " ")
+	if minLengthString == "" {
+		return 12, nil
+	}
+
+	minLength, err := strconv.Atoi(minLengthString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_PASSWORD_MIN_LENGTH to integer", err)
+	}
+
+	if minLength < 1 {
+		return 0, commonErrors.NewUnknownError("USER_PASSWORD_MIN_LENGTH must be at least 1")
+	}
+
+	return minLength, nil
+}
+
+// GetPasswordRequiredCharacterClasses retrieves how many of the lower case letters, upper case letters, digits and
+// symbols a password must contain
+// Returns the number of the required character classes or error if something goes wrong
+func (service *envConfigurationService) GetPasswordRequiredCharacterClasses() (int, error) {
+	requiredClassesString := strings.Trim(service.getVariable("USER_PASSWORD_REQUIRED_CHARACTER_CLASSES"), " ")
+	if requiredClassesString == "" {
+		return 3, nil
+	}
+
+	requiredClasses, err := strconv.Atoi(requiredClassesString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_PASSWORD_REQUIRED_CHARACTER_CLASSES to integer", err)
+	}
+
+	if requiredClasses < 0 || requiredClasses > 4 {
+		return 0, commonErrors.NewUnknownError("USER_PASSWORD_REQUIRED_CHARACTER_CLASSES must be between 0 and 4")
+	}
+
+	return requiredClasses, nil
+}
+
+// GetAPIKeyCollectionName retrieves the name of the database collection the API keys are persisted in
+// Returns the API key collection name or error if something goes wrong
+func (service *envConfigurationService) GetAPIKeyCollectionName() (string, error) {
+	collectionName := strings.Trim(service.getVariable("USER_API_KEY_COLLECTION_NAME"), " ")
+	if collectionName == "" {
+		return "api_keys", nil
+	}
+
+	return collectionName, nil
+}
+
+// GetAPIKeyMaxPerUser retrieves the maximum number of the API keys a user can have that are not revoked or expired
+// Returns the maximum number of the API keys per user or error if something goes wrong
+func (service *envConfigurationService) GetAPIKeyMaxPerUser() (int, error) {
+	maxPerUserString := strings.Trim(service.getVariable("USER_API_KEY_MAX_PER_USER"), " ")
+	if maxPerUserString == "" {
+		return 10, nil
+	}
+
+	maxPerUser, err := strconv.Atoi(maxPerUserString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_API_KEY_MAX_PER_USER to integer", err)
+	}
+
+	if maxPerUser < 1 {
+		return 0, commonErrors.NewUnknownError("USER_API_KEY_MAX_PER_USER must be at least 1")
+	}
+
+	return maxPerUser, nil
+}
+
+// GetConsentCollectionName retrieves the name of the database collection the consents of the users are persisted in
+// Returns the consent collection name or error if something goes wrong
+func (service *envConfigurationService) GetConsentCollectionName() (string, error) {
+	collectionName := strings.Trim(service.getVariable("USER_CONSENT_COLLECTION_NAME"), " ")
+	if collectionName == "" {
+		return "consents", nil
+	}
+
+	return collectionName, nil
+}
+
+// GetConsentRequiredPolicies retrieves the current versions of the policies the users must accept, keyed by the
+// policy name
+// Returns the map of the policy name to its current version, empty if no policy is required, or error if something goes wrong
+func (service *envConfigurationService) GetConsentRequiredPolicies() (map[string]string, error) {
+	policies := map[string]string{}
+	policiesString := strings.Trim(service.getVariable("CONSENT_REQUIRED_POLICIES"), " ")
+
+	if policiesString == "" {
+		return policies, nil
+	}
+
+	for _, pair := range strings.Split(policiesString, ",") {
+		parts := strings.Split(pair, "=")
+		if len(parts) != 2 || strings.Trim(parts[0], " ") == "" || strings.Trim(parts[1], " ") == "" {
+			return nil, commonErrors.NewUnknownError(fmt.Sprintf("CONSENT_REQUIRED_POLICIES contains invalid policy=version pair: %s", pair))
+		}
+
+		policies[strings.Trim(parts[0], " ")] = strings.Trim(parts[1], " ")
+	}
+
+	return policies, nil
+}
+
+// GetConsentRequiredOperations retrieves the operations that require the user to accept the current versions of
+// the required policies first
+// Returns the list of the operation names or error if something goes wrong
+func (service *envConfigurationService) GetConsentRequiredOperations() ([]string, error) {
+	operations := []string{}
+
+	for _, operation := range strings.Split(service.getVariable("CONSENT_REQUIRED_OPERATIONS"), ",") {
+		if operation = strings.Trim(operation, " "); operation != "" {
+			operations = append(operations, operation)
+		}
+	}
+
+	return operations, nil
+}
+
+// GetGroupCollectionName retrieves the name of the database collection the groups are persisted in
+// Returns the group collection name or error if something goes wrong
+func (service *envConfigurationService) GetGroupCollectionName() (string, error) {
+	collectionName := strings.Trim(service.getVariable("USER_GROUP_COLLECTION_NAME"), " ")
+	if collectionName == "" {
+		return "groups", nil
+	}
+
+	return collectionName, nil
+}
+
+// GetInvitationCollectionName retrieves the name of the database collection the invitations are persisted in
+// Returns the invitation collection name or error if something goes wrong
+func (service *envConfigurationService) GetInvitationCollectionName() (string, error) {
+	collectionName := strings.Trim(service.getVariable("USER_INVITATION_COLLECTION_NAME"), " ")
+	if collectionName == "" {
+		return "invitations", nil
+	}
+
+	return collectionName, nil
+}
+
+// GetInvitationTokenTTL retrieves how long the token sent in an invitation is valid for
+// Returns the time the token is valid for or error if something goes wrong
+func (service *envConfigurationService) GetInvitationTokenTTL() (time.Duration, error) {
+	tokenTTLString := strings.Trim(service.getVariable("USER_INVITATION_TOKEN_TTL"), " ")
+	if tokenTTLString == "" {
+		return 7 * 24 * time.Hour, nil
+	}
+
+	tokenTTL, err := time.ParseDuration(tokenTTLString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_INVITATION_TOKEN_TTL to duration", err)
+	}
+
+	if tokenTTL <= 0 {
+		return 0, commonErrors.NewUnknownError("USER_INVITATION_TOKEN_TTL must be greater than zero")
+	}
+
+	return tokenTTL, nil
+}
+
+// GetInvitationURL retrieves the URL of the page the invited users accept their invitation on, the invitation
+// email links to it with the email address and the token as query parameters
+// Returns the invitation URL, empty if not provided, or error if something goes wrong
+func (service *envConfigurationService) GetInvitationURL() (string, error) {
+	invitationURL := strings.Trim(service.getVariable("USER_INVITATION_URL"), " ")
+	if invitationURL == "" {
+		return "", nil
+	}
+
+	parsedURL, err := url.Parse(invitationURL)
+	if err != nil || !parsedURL.IsAbs() {
+		return "", commonErrors.NewUnknownError(fmt.Sprintf("USER_INVITATION_URL must be an absolute URL: %s", invitationURL))
+	}
+
+	return invitationURL, nil
+}
+
+// GetSessionCollectionName retrieves the name of the database collection the sessions are persisted in
+// Returns the session collection name or error if something goes wrong
+func (service *envConfigurationService) GetSessionCollectionName() (string, error) {
+	collectionName := strings.Trim(service.getVariable("USER_SESSION_COLLECTION_NAME"), " ")
+	if collectionName == "" {
+		return "sessions", nil
+	}
+
+	return collectionName, nil
+}
+
+// GetSessionTTL retrieves how long a session stays active after the auth gateway registers it the last time
+// Returns the time the session stays active for or error if something goes wrong
+func (service *envConfigurationService) GetSessionTTL() (time.Duration, error) {
+	sessionTTLString := strings.Trim(service.getVariable("USER_SESSION_TTL"), " ")
+	if sessionTTLString == "" {
+		return 30 * 24 * time.Hour, nil
+	}
+
+	sessionTTL, err := time.ParseDuration(sessionTTLString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_SESSION_TTL to duration", err)
+	}
+
+	if sessionTTL <= 0 {
+		return 0, commonErrors.NewUnknownError("USER_SESSION_TTL must be greater than zero")
+	}
+
+	return sessionTTL, nil
+}
+
+// GetWebhookSubscriptionCollectionName retrieves the name of the database collection the webhook subscriptions
+// are persisted in
+// Returns the webhook subscription collection name or error if something goes wrong
+func (service *envConfigurationService) GetWebhookSubscriptionCollectionName() (string, error) {
+	collectionName := strings.Trim(service.getVariable("USER_WEBHOOK_SUBSCRIPTION_COLLECTION_NAME"), " ")
+	if collectionName == "" {
+		return "webhookSubscriptions", nil
+	}
+
+	return collectionName, nil
+}
+
+// GetWebhookDeliveryCollectionName retrieves the name of the database collection the webhook deliveries are
+// persisted in
+// Returns the webhook delivery collection name or error if something goes wrong
+func (service *envConfigurationService) GetWebhookDeliveryCollectionName() (string, error) {
+	collectionName := strings.Trim(service.getVariable("USER_WEBHOOK_DELIVERY_COLLECTION_NAME"), " ")
+	if collectionName == "" {
+		return "webhookDeliveries", nil
+	}
+
+	return collectionName, nil
+}
+
+// GetWebhookDeliveryRetention retrieves how long the deliveries of the events to the webhooks are kept for
+// Returns the webhook delivery retention or error if something goes wrong
+func (service *envConfigurationService) GetWebhookDeliveryRetention() (time.Duration, error) {
+	retentionString := strings.Trim(service.getVariable("USER_WEBHOOK_DELIVERY_RETENTION"), " ")
+	if retentionString == "" {
+		return 7 * 24 * time.Hour, nil
+	}
+
+	retention, err := time.ParseDuration(retentionString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_WEBHOOK_DELIVERY_RETENTION to duration", err)
+	}
+
+	if retention <= 0 {
+		return 0, commonErrors.NewUnknownError("USER_WEBHOOK_DELIVERY_RETENTION must be greater than zero")
+	}
+
+	return retention, nil
+}
+
+// GetWebhookMaxAttempts retrieves the maximum number of times a webhook call is attempted before its delivery is
+// considered failed
+// Returns the maximum number of attempts or error if something goes wrong
+func (service *envConfigurationService) GetWebhookMaxAttempts() (int, error) {
+	maxAttemptsString := strings.Trim(service.getVariable("USER_WEBHOOK_MAX_ATTEMPTS"), " ")
+	if maxAttemptsString == "" {
+		return 5, nil
+	}
+
+	maxAttempts, err := strconv.Atoi(maxAttemptsString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_WEBHOOK_MAX_ATTEMPTS to integer", err)
+	}
+
+	if maxAttempts < 1 {
+		return 0, commonErrors.NewUnknownError("USER_WEBHOOK_MAX_ATTEMPTS must be at least 1")
+	}
+
+	return maxAttempts, nil
+}
+
+// GetWebhookRetryBackoff retrieves the time to wait before retrying a failed webhook call for the first time. The
+// time is doubled for every subsequent attempt
+// Returns the retry backoff or error if something goes wrong
+func (service *envConfigurationService) GetWebhookRetryBackoff() (time.Duration, error) {
+	retryBackoffString := strings.Trim(service.getVariable("USER_WEBHOOK_RETRY_BACKOFF"), " ")
+	if retryBackoffString == "" {
+		return time.Second, nil
+	}
+
+	retryBackoff, err := time.ParseDuration(retryBackoffString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_WEBHOOK_RETRY_BACKOFF to duration", err)
+	}
+
+	return retryBackoff, nil
+}
+
+// GetWebhookTimeout retrieves how long a single webhook call can take before it is considered failed
+// Returns the webhook call timeout or error if something goes wrong
+func (service *envConfigurationService) GetWebhookTimeout() (time.Duration, error) {
+	timeoutString := strings.Trim(service.getVariable("USER_WEBHOOK_TIMEOUT"), " ")
+	if timeoutString == "" {
+		return 10 * time.Second, nil
+	}
+
+	timeout, err := time.ParseDuration(timeoutString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_WEBHOOK_TIMEOUT to duration", err)
+	}
+
+	if timeout <= 0 {
+		return 0, commonErrors.NewUnknownError("USER_WEBHOOK_TIMEOUT must be greater than zero")
+	}
+
+	return timeout, nil
+}
+
+// GetWebhookPrivateNetworksAllowed retrieves whether the webhooks can be called in the private, loopback and
+// link-local networks, e.g. to test the webhooks locally
+// Returns true if the webhooks can be called in the private networks or error if something goes wrong
+func (service *envConfigurationService) GetWebhookPrivateNetworksAllowed() (bool, error) {
+	allowedString := strings.Trim(service.getVariable("USER_WEBHOOK_PRIVATE_NETWORKS_ALLOWED"), " ")
+	if allowedString == "" {
+		return false, nil
+	}
+
+	allowed, err := strconv.ParseBool(allowedString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert USER_WEBHOOK_PRIVATE_NETWORKS_ALLOWED to boolean", err)
+	}
+
+	return allowed, nil
+}
+
+// GetLockoutThreshold retrieves the number of the failed login attempts the users are locked after
+// Returns the lockout threshold, zero if the users are never locked, or error if something goes wrong
+func (service *envConfigurationService) GetLockoutThreshold() (int, error) {
+	thresholdString := strings.Trim(service.getVariable("USER_LOCKOUT_THRESHOLD"), " ")
+	if thresholdString == "" {
+		return 5, nil
+	}
+
+	threshold, err := strconv.Atoi(thresholdString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_LOCKOUT_THRESHOLD to integer", err)
+	}
+
+	if threshold < 0 {
+		return 0, commonErrors.NewUnknownError("USER_LOCKOUT_THRESHOLD must not be negative")
+	}
+
+	return threshold, nil
+}
+
+// GetBulkUpdateTokenSecret retrieves the secret the bulk update confirmation tokens are signed with
+// Returns the secret, empty if the bulk update is disabled, or error if something goes wrong
+func (service *envConfigurationService) GetBulkUpdateTokenSecret() (string, error) {
+	return strings.Trim(service.getVariable("BULK_UPDATE_TOKEN_SECRET"), " "), nil
+}
+
+// GetBulkUpdatePreviewTTL retrieves how long the bulk update confirmation token of a preview is valid for
+// Returns the time the token is valid for or error if something goes wrong
+func (service *envConfigurationService) GetBulkUpdatePreviewTTL() (time.Duration, error) {
+	previewTTLString := strings.Trim(service.getVariable("BULK_UPDATE_PREVIEW_TTL"), " ")
+	if previewTTLString == "" {
+		return 10 * time.Minute, nil
+	}
+
+	previewTTL, err := time.ParseDuration(previewTTLString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert BULK_UPDATE_PREVIEW_TTL to duration", err)
+	}
+
+	if previewTTL <= 0 {
+		return 0, commonErrors.NewUnknownError("BULK_UPDATE_PREVIEW_TTL must be greater than zero")
+	}
+
+	return previewTTL, nil
+}
+
+// GetBulkUpdateRateLimit retrieves the maximum number of users updated per second by a bulk update
+// Returns the rate limit or error if something goes wrong
+func (service *envConfigurationService) GetBulkUpdateRateLimit() (int, error) {
+	rateLimitString := strings.Trim(service.getVariable("BULK_UPDATE_RATE_LIMIT"), " ")
+	if rateLimitString == "" {
+		return 10, nil
+	}
+
+	rateLimit, err := strconv.Atoi(rateLimitString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert BULK_UPDATE_RATE_LIMIT to integer", err)
+	}
+
+	if rateLimit < 1 {
+		return 0, commonErrors.NewUnknownError("BULK_UPDATE_RATE_LIMIT must be at least 1")
+	}
+
+	return rateLimit, nil
+}
+
+// GetBulkUpdateMaxUsers retrieves the maximum number of users a single bulk update can update
+// Returns the maximum number of users or error if something goes wrong
+func (service *envConfigurationService) GetBulkUpdateMaxUsers() (int, error) {
+	maxUsersString := strings.Trim(service.getVariable("BULK_UPDATE_MAX_USERS"), " ")
+	if maxUsersString == "" {
+		return 1000, nil
+	}
+
+	maxUsers, err := strconv.Atoi(maxUsersString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert BULK_UPDATE_MAX_USERS to integer", err)
+	}
+
+	if maxUsers < 1 {
+		return 0, commonErrors.NewUnknownError("BULK_UPDATE_MAX_USERS must be at least 1")
+	}
+
+	return maxUsers, nil
+}
+
+// GetErasureTokenSecret retrieves the secret the erasure confirmation tokens are signed with
+// Returns the secret, empty if the erasure is disabled, or error if something goes wrong
+func (service *envConfigurationService) GetErasureTokenSecret() (string, error) {
+	return strings.Trim(service.getVariable("ERASURE_TOKEN_SECRET"), " "), nil
+}
+
+// GetErasureGracePeriod retrieves how long after the erasure is requested it can be confirmed
+// Returns the grace period or error if something goes wrong
+func (service *envConfigurationService) GetErasureGracePeriod() (time.Duration, error) {
+	gracePeriodString := strings.Trim(service.getVariable("ERASURE_GRACE_PERIOD"), " ")
+	if gracePeriodString == "" {
+		return 24 * time.Hour, nil
+	}
+
+	gracePeriod, err := time.ParseDuration(gracePeriodString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert ERASURE_GRACE_PERIOD to duration", err)
+	}
+
+	if gracePeriod < 0 {
+		return 0, commonErrors.NewUnknownError("ERASURE_GRACE_PERIOD must not be negative")
+	}
+
+	return gracePeriod, nil
+}
+
+// GetErasureConfirmationWindow retrieves how long after the grace period ends the erasure can still be confirmed
+// Returns the confirmation window or error if something goes wrong
+func (service *envConfigurationService) GetErasureConfirmationWindow() (time.Duration, error) {
+	confirmationWindowString := strings.Trim(service.getVariable("ERASURE_CONFIRMATION_WINDOW"), " ")
+	if confirmationWindowString == "" {
+		return 7 * 24 * time.Hour, nil
+	}
+
+	confirmationWindow, err := time.ParseDuration(confirmationWindowString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert ERASURE_CONFIRMATION_WINDOW to duration", err)
+	}
+
+	if confirmationWindow <= 0 {
+		return 0, commonErrors.NewUnknownError("ERASURE_CONFIRMATION_WINDOW must be greater than zero")
+	}
+
+	return confirmationWindow, nil
+}
+
+// GetEmailChangeTokenSecret retrieves the secret the email address change confirmation tokens are signed with
+// Returns the secret, empty if the email address is changed without a confirmation, or error if something goes wrong
+func (service *envConfigurationService) GetEmailChangeTokenSecret() (string, error) {
+	return strings.Trim(service.getVariable("EMAIL_CHANGE_TOKEN_SECRET"), " "), nil
+}
+
+// GetEmailChangeTokenTTL retrieves how long the token sent to confirm an email address change is valid for
+// Returns the time the token is valid for or error if something goes wrong
+func (service *envConfigurationService) GetEmailChangeTokenTTL() (time.Duration, error) {
+	tokenTTLString := strings.Trim(service.getVariable("EMAIL_CHANGE_TOKEN_TTL"), " ")
+	if tokenTTLString == "" {
+		return 24 * time.Hour, nil
+	}
+
+	tokenTTL, err := time.ParseDuration(tokenTTLString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert EMAIL_CHANGE_TOKEN_TTL to duration", err)
+	}
+
+	if tokenTTL <= 0 {
+		return 0, commonErrors.NewUnknownError("EMAIL_CHANGE_TOKEN_TTL must be greater than zero")
+	}
+
+	return tokenTTL, nil
+}
+
+// GetEmailChangeURL retrieves the URL of the page the users confirm their email address change on, the email sent
+// to the new email address links to it with the current email address and the token as query parameters
+// Returns the email change URL, empty if not provided, or error if something goes wrong
+func (service *envConfigurationService) GetEmailChangeURL() (string, error) {
+	emailChangeURL := strings.Trim(service.getVariable("USER_EMAIL_CHANGE_URL"), " ")
+	if emailChangeURL == "" {
+		return "", nil
+	}
+
+	parsedURL, err := url.Parse(emailChangeURL)
+	if err != nil || !parsedURL.IsAbs() {
+		return "", commonErrors.NewUnknownError(fmt.Sprintf("USER_EMAIL_CHANGE_URL must be an absolute URL: %s", emailChangeURL))
+	}
+
+	return emailChangeURL, nil
+}
+
+// GetSoftDeleteEnabled retrieves whether the deleted users are only marked as deleted so they can be restored
+// Returns true if the users are soft deleted or error if something goes wrong
+func (service *envConfigurationService) GetSoftDeleteEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("USER_SOFT_DELETE_ENABLED"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert USER_SOFT_DELETE_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetSoftDeleteRetentionPeriod retrieves how long the soft deleted users are kept before they are purged
+// Returns the retention period or error if something goes wrong
+func (service *envConfigurationService) GetSoftDeleteRetentionPeriod() (time.Duration, error) {
+	retentionPeriodString := strings.Trim(service.getVariable("USER_SOFT_DELETE_RETENTION_PERIOD"), " ")
+	if retentionPeriodString == "" {
+		return 30 * 24 * time.Hour, nil
+	}
+
+	retentionPeriod, err := time.ParseDuration(retentionPeriodString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_SOFT_DELETE_RETENTION_PERIOD to duration", err)
+	}
+
+	if retentionPeriod < 0 {
+		return 0, commonErrors.NewUnknownError("USER_SOFT_DELETE_RETENTION_PERIOD must not be negative")
+	}
+
+	return retentionPeriod, nil
+}
+
+// GetSoftDeletePurgeInterval retrieves how often the soft deleted users that passed the retention period are purged
+// Returns the purge interval or error if something goes wrong
+func (service *envConfigurationService) GetSoftDeletePurgeInterval() (time.Duration, error) {
+	purgeIntervalString := strings.Trim(service.getVariable("USER_SOFT_DELETE_PURGE_INTERVAL"), " ")
+	if purgeIntervalString == "" {
+		return time.Hour, nil
+	}
+
+	purgeInterval, err := time.ParseDuration(purgeIntervalString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_SOFT_DELETE_PURGE_INTERVAL to duration", err)
+	}
+
+	if purgeInterval <= 0 {
+		return 0, commonErrors.NewUnknownError("USER_SOFT_DELETE_PURGE_INTERVAL must be greater than zero")
+	}
+
+	return purgeInterval, nil
+}
+
+// GetTestDataPurgeEnabled retrieves whether the users labelled as test data can be purged by the PurgeByLabel operation
+// Returns true if purging the test data is enabled or error if something goes wrong
+func (service *envConfigurationService) GetTestDataPurgeEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("TEST_DATA_PURGE_ENABLED"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert TEST_DATA_PURGE_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetMagicLinksEnabled retrieves whether the users can sign in with the single-use links sent to their email
+// addresses instead of a password
+// Returns true if the magic links are enabled or error if something goes wrong
+func (service *envConfigurationService) GetMagicLinksEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("USER_MAGIC_LINKS_ENABLED"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert USER_MAGIC_LINKS_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetMagicLinkCollectionName retrieves the name of the database collection the magic links are persisted in
+// Returns the magic link collection name or error if something goes wrong
+func (service *envConfigurationService) GetMagicLinkCollectionName() (string, error) {
+	collectionName := strings.Trim(service.getVariable("USER_MAGIC_LINK_COLLECTION_NAME"), " ")
+	if collectionName == "" {
+		return "magic_links", nil
+	}
+
+	return collectionName, nil
+}
+
+// GetMagicLinkTokenTTL retrieves how long the token sent in a magic link is valid for
+// Returns the time the token is valid for or error if something goes wrong
+func (service *envConfigurationService) GetMagicLinkTokenTTL() (time.Duration, error) {
+	tokenTTLString := strings.Trim(service.getVariable("USER_MAGIC_LINK_TOKEN_TTL"), " ")
+	if tokenTTLString == "" {
+		return 15 * time.Minute, nil
+	}
+
+	tokenTTL, err := time.ParseDuration(tokenTTLString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert USER_MAGIC_LINK_TOKEN_TTL to duration", err)
+	}
+
+	if tokenTTL <= 0 {
+		return 0, commonErrors.NewUnknownError("USER_MAGIC_LINK_TOKEN_TTL must be greater than zero")
+	}
+
+	return tokenTTL, nil
+}
+
+// GetMagicLinkIPAddressBindingEnabled retrieves whether a magic link can only be redeemed from the IP address it
+// is issued for
+// Returns true if the magic links are bound to the IP address or error if something goes wrong
+func (service *envConfigurationService) GetMagicLinkIPAddressBindingEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("USER_MAGIC_LINK_BIND_IP_ADDRESS"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert USER_MAGIC_LINK_BIND_IP_ADDRESS to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetMagicLinkDeviceBindingEnabled retrieves whether a magic link can only be redeemed from the device it is
+// issued for
+// Returns true if the magic links are bound to the device or error if something goes wrong
+func (service *envConfigurationService) GetMagicLinkDeviceBindingEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("USER_MAGIC_LINK_BIND_DEVICE"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert USER_MAGIC_LINK_BIND_DEVICE to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetSloAvailabilityObjective retrieves the ratio of the calls that must not fail with a server error
+// Returns the availability objective or error if something goes wrong
+func (service *envConfigurationService) GetSloAvailabilityObjective() (float64, error) {
+	return service.getObjective("SLO_AVAILABILITY_OBJECTIVE", 0.999)
+}
+
+// GetSloLatencyObjective retrieves the ratio of the calls that must complete within the latency threshold
+// Returns the latency objective or error if something goes wrong
+func (service *envConfigurationService) GetSloLatencyObjective() (float64, error) {
+	return service.getObjective("SLO_LATENCY_OBJECTIVE", 0.99)
+}
+
+// GetSloLatencyThreshold retrieves the duration a call must complete within to count towards the latency objective
+// Returns the latency threshold or error if something goes wrong
+func (service *envConfigurationService) GetSloLatencyThreshold() (time.Duration, error) {
+	latencyThresholdString := strings.Trim(service.getVariable("SLO_LATENCY_THRESHOLD"), " ")
+	if latencyThresholdString == "" {
+		return 300 * time.Millisecond, nil
+	}
+
+	latencyThreshold, err := time.ParseDuration(latencyThresholdString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert SLO_LATENCY_THRESHOLD to duration", err)
+	}
+
+	if latencyThreshold <= 0 {
+		return 0, commonErrors.NewUnknownError("SLO_LATENCY_THRESHOLD must be greater than zero")
+	}
+
+	return latencyThreshold, nil
+}
+
+// GetSloWindow retrieves the rolling window the error budgets are computed over
+// Returns the SLO window or error if something goes wrong
+func (service *envConfigurationService) GetSloWindow() (time.Duration, error) {
+	windowString := strings.Trim(service.getVariable("SLO_WINDOW"), " ")
+	if windowString == "" {
+		return 30 * 24 * time.Hour, nil
+	}
+
+	window, err := time.ParseDuration(windowString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert SLO_WINDOW to duration", err)
+	}
+
+	if window < time.Hour {
+		return 0, commonErrors.NewUnknownError("SLO_WINDOW must be at least 1h")
+	}
+
+	return window, nil
+}
+
+// GetValidationRuleModes retrieves how the validation rules being rolled out are applied, keyed by the rule name.
+// The modes are provided as comma separated list of rule=mode pairs, where mode is either warn, enforce or off
+// (e.g. "email_lowercase=enforce,email_domain_has_tld=warn").
+// Returns the map of the rule name to its mode or error if something goes wrong
+func (service *envConfigurationService) GetValidationRuleModes() (map[string]string, error) {
+	modes := map[string]string{}
+	modesString := strings.Trim(service.getVariable("VALIDATION_RULE_MODES"), " ")
+
+	if modesString == "" {
+		return modes, nil
+	}
+
+	for _, pair := range strings.Split(modesString, ",") {
+		parts := strings.Split(pair, "=")
+		if len(parts) != 2 || strings.Trim(parts[0], " ") == "" {
+			return nil, commonErrors.NewUnknownError(fmt.Sprintf("VALIDATION_RULE_MODES contains invalid rule=mode pair: %s", pair))
+		}
+
+		mode := strings.ToLower(strings.Trim(parts[1], " "))
+		if mode != "warn" && mode != "enforce" && mode != "off" {
+			return nil, commonErrors.NewUnknownError(fmt.Sprintf("VALIDATION_RULE_MODES contains invalid mode, must be either warn, enforce or off: %s", pair))
+		}
+
+		modes[strings.Trim(parts[0], " ")] = mode
+	}
+
+	return modes, nil
+}
+
+// GetValidationPolicyAllowedEmailDomains retrieves the domains the email addresses of the new users must belong to
+// Returns the list of the allowed domains in lower case, empty if any domain is allowed, or error if something goes wrong
+func (service *envConfigurationService) GetValidationPolicyAllowedEmailDomains() ([]string, error) {
+	domains := []string{}
+
+	for _, domain := range strings.Split(service.getVariable("VALIDATION_POLICY_ALLOWED_EMAIL_DOMAINS"), ",") {
+		if domain = strings.ToLower(strings.Trim(domain, " ")); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+
+	return domains, nil
+}
+
+// GetValidationPolicyFieldPatterns retrieves the regular expressions the request fields must match, keyed by the
+// field name. The patterns are provided as comma separated list of field=pattern pairs (e.g.
+// "apiKeyName=^[a-z-]+$,labels.department=^[A-Z]{2,4}$"). The patterns can contain commas, so a comma only starts
+// the next pair if it is followed by a field name and =.
+// Returns the map of the field name to its pattern or error if something goes wrong
+func (service *envConfigurationService) GetValidationPolicyFieldPatterns() (map[string]string, error) {
+	patterns := map[string]string{}
+	patternsString := strings.Trim(service.getVariable("VALIDATION_POLICY_FIELD_PATTERNS"), " ")
+
+	if patternsString == "" {
+		return patterns, nil
+	}
+
+	field := ""
+	for _, pair := range strings.Split(patternsString, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) == 2 && validationPolicyFieldName.MatchString(strings.Trim(parts[0], " ")) {
+			field = strings.Trim(parts[0], " ")
+			patterns[field] = parts[1]
+
+			continue
+		}
+
+		if field == "" {
+			return nil, commonErrors.NewUnknownError(fmt.Sprintf("VALIDATION_POLICY_FIELD_PATTERNS contains invalid field=pattern pair: %s", pair))
+		}
+
+		patterns[field] += "," + pair
+	}
+
+	return patterns, nil
+}
+
+// GetValidationPolicyRequiredFields retrieves the user fields the users must be created and updated with
+// Returns the list of the required field names or error if something goes wrong
+func (service *envConfigurationService) GetValidationPolicyRequiredFields() ([]string, error) {
+	fields := []string{}
+
+	for _, field := range strings.Split(service.getVariable("VALIDATION_POLICY_REQUIRED_FIELDS"), ",") {
+		if field = strings.Trim(field, " "); field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, nil
+}
+
+// GetAdminEmails retrieves the email addresses of the users that are allowed to call the admin operations
+// Returns the list of the admin email addresses or error if something goes wrong
+func (service *envConfigurationService) GetAdminEmails() ([]string, error) {
+	adminEmails := []string{}
+
+	for _, email := range strings.Split(service.getVariable("ADMIN_EMAILS"), ",") {
+		if email = strings.Trim(email, " "); email != "" {
+			adminEmails = append(adminEmails, email)
+		}
+	}
+
+	return adminEmails, nil
+}
+
+// GetAdminGroups retrieves the names of the groups whose members are allowed to call the admin operations
+// Returns the list of the admin group names or error if something goes wrong
+func (service *envConfigurationService) GetAdminGroups() ([]string, error) {
+	adminGroups := []string{}
+
+	for _, group := range strings.Split(service.getVariable("ADMIN_GROUPS"), ",") {
+		if group = strings.Trim(group, " "); group != "" {
+			adminGroups = append(adminGroups, group)
+		}
+	}
+
+	return adminGroups, nil
+}
+
+// GetJwksURL retrieves the JWKS URL
+// Returns the JWKS URL or error if something goes wrong
+func (service *envConfigurationService) GetJwksURL() (string, error) {
+	jwksURL := service.getVariable("JWKS_URL")
+
+	if strings.Trim(jwksURL, " ") == "" {
+		return "", commonErrors.NewUnknownError("JWKS_URL is required")
+	}
+
+	return jwksURL, nil
+}
+
+// GetJwksRefreshInterval retrieves how often the cached JSON Web Key Set the access tokens are verified with is refreshed
+// Returns the refresh interval or error if something goes wrong
+func (service *envConfigurationService) GetJwksRefreshInterval() (time.Duration, error) {
+	intervalString := strings.Trim(service.getVariable("JWKS_REFRESH_INTERVAL"), " ")
+	if intervalString == "" {
+		return 15 * time.Minute, nil
+	}
+
+	interval, err := time.ParseDuration(intervalString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert JWKS_REFRESH_INTERVAL to duration", err)
+	}
+
+	if interval <= 0 {
+		return 0, commonErrors.NewUnknownError("JWKS_REFRESH_INTERVAL must be greater than zero")
+	}
+
+	return interval, nil
+}
+
+// GetJwtAcceptedIssuers retrieves the issuers the access tokens are accepted from
+// Returns the list of the accepted issuers, empty if the issuer is not checked, or error if something goes wrong
+func (service *envConfigurationService) GetJwtAcceptedIssuers() ([]string, error) {
+	issuers := []string{}
+
+	for _, issuer := range strings.Split(service.getVariable("JWT_ACCEPTED_ISSUERS"), ",") {
+		if issuer = strings.Trim(issuer, " "); issuer != "" {
+			issuers = append(issuers, issuer)
+		}
+	}
+
+	return issuers, nil
+}
+
+// GetJwtAcceptedAudiences retrieves the audiences the access tokens are accepted for
+// Returns the list of the accepted audiences, empty if the audience is not checked, or error if something goes wrong
+func (service *envConfigurationService) GetJwtAcceptedAudiences() ([]string, error) {
+	audiences := []string{}
+
+	for _, audience := range strings.Split(service.getVariable("JWT_ACCEPTED_AUDIENCES"), ",") {
+		if audience = strings.Trim(audience, " "); audience != "" {
+			audiences = append(audiences, audience)
+		}
+	}
+
+	return audiences, nil
+}
+
+// GetJwtClaimMapping retrieves the claims of the access tokens the email address and the subject of the caller are read from
+// Returns the map of the field, either email, subject, service or tenant, to the claim it is read from or error if something goes wrong
+func (service *envConfigurationService) GetJwtClaimMapping() (map[string]string, error) {
+	claimMapping := map[string]string{}
+	claimMappingString := strings.Trim(service.getVariable("JWT_CLAIM_MAPPING"), " ")
+
+	if claimMappingString == "" {
+		return claimMapping, nil
+	}
+
+	for _, pair := range strings.Split(claimMappingString, ",") {
+		parts := strings.Split(pair, "=")
+		if len(parts) != 2 || strings.Trim(parts[1], " ") == "" {
+			return nil, commonErrors.NewUnknownError(fmt.Sprintf("JWT_CLAIM_MAPPING contains invalid field=claim pair: %s", pair))
+		}
+
+		field := strings.ToLower(strings.Trim(parts[0], " "))
+		if field != "email" && field != "subject" && field != "service" && field != "tenant" {
+			return nil, commonErrors.NewUnknownError(fmt.Sprintf("JWT_CLAIM_MAPPING contains invalid field, must be either email, subject, service or tenant: %s", pair))
+		}
+
+		claimMapping[field] = strings.Trim(parts[1], " ")
+	}
+
+	return claimMapping, nil
+}
+
+// GetServiceIdentityAllowlist retrieves the identities of the internal services allowed to call each endpoint with a
+// client credential token
+// Returns the map of the endpoint name to the allowed service identities or error if something goes wrong
+func (service *envConfigurationService) GetServiceIdentityAllowlist() (map[string][]string, error) {
+	allowlist := map[string][]string{}
+	allowlistString := strings.Trim(service.getVariable("SERVICE_IDENTITY_ALLOWLIST"), " ")
+
+	if allowlistString == "" {
+		return allowlist, nil
+	}
+
+	for _, pair := range strings.Split(allowlistString, ",") {
+		parts := strings.Split(pair, "=")
+		if len(parts) != 2 || strings.Trim(parts[0], " ") == "" || strings.Trim(parts[1], " ") == "" {
+			return nil, commonErrors.NewUnknownError(fmt.Sprintf("SERVICE_IDENTITY_ALLOWLIST contains invalid endpoint=services pair: %s", pair))
+		}
+
+		endpoint := strings.Trim(parts[0], " ")
+		for _, identity := range strings.Split(parts[1], "+") {
+			if identity = strings.Trim(identity, " "); identity != "" {
+				allowlist[endpoint] = append(allowlist[endpoint], identity)
+			}
+		}
+	}
+
+	return allowlist, nil
+}
+
+// GetTenancyMode retrieves whether the users are shared by all the callers or scoped to the tenant of the caller
+// Returns either single or multi, or error if something goes wrong
+func (service *envConfigurationService) GetTenancyMode() (string, error) {
+	mode := strings.ToLower(strings.Trim(service.getVariable("TENANCY_MODE"), " "))
+	if mode == "" {
+		return "single", nil
+	}
+
+	if mode != "single" && mode != "multi" {
+		return "", commonErrors.NewUnknownError(fmt.Sprintf("TENANCY_MODE is not supported: %s", mode))
+	}
+
+	return mode, nil
+}
+
+// GetTenancyCrossTenantReadScope retrieves the scope of the access tokens allowed to read the users of all the
+// tenants in the multi-tenant mode
+// Returns the scope or error if something goes wrong
+func (service *envConfigurationService) GetTenancyCrossTenantReadScope() (string, error) {
+	scope := strings.Trim(service.getVariable("TENANCY_CROSS_TENANT_READ_SCOPE"), " ")
+	if scope == "" {
+		return "users:read:all-tenants", nil
+	}
+
+	return scope, nil
+}
+
+// GetAuthorizationDecisionLoggingEnabled retrieves whether the path of the checks that allowed or denied a call is logged
+// Returns true if the authorization decisions are logged or error if something goes wrong
+func (service *envConfigurationService) GetAuthorizationDecisionLoggingEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("AUTHORIZATION_DECISION_LOGGING_ENABLED"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert AUTHORIZATION_DECISION_LOGGING_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetAuthorizationPolicyFile retrieves the path to the policy file the rules of the endpoints are tailored with
+// Returns the path to the policy file or error if something goes wrong
+func (service *envConfigurationService) GetAuthorizationPolicyFile() (string, error) {
+	return strings.Trim(service.getVariable("AUTHORIZATION_POLICY_FILE"), " "), nil
+}
+
+// GetRedactedUserFields retrieves the fields of the user that are removed from the responses sent to the callers
+// that are neither the owner of the user nor an admin
+// Returns the list of the user field names as they are named in the proto files or error if something goes wrong
+func (service *envConfigurationService) GetRedactedUserFields() ([]string, error) {
+	fieldsString := strings.Trim(service.getVariable("USER_REDACTED_USER_FIELDS"), " ")
+	if fieldsString == "" {
+		return []string{"memberships", "emailVerified", "locked", "lockedAt", "failedLoginAttempts", "createdBy", "updatedBy"}, nil
+	}
+
+	// none is accepted so the redaction can be turned off, an empty variable is indistinguishable from an unset one
+	if strings.EqualFold(fieldsString, "none") {
+		return []string{}, nil
+	}
+
+	fields := []string{}
+	for _, field := range strings.Split(fieldsString, ",") {
+		if field = strings.Trim(field, " "); field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, nil
+}
+
+// GetLogLevel retrieves the minimum level of the logged messages
+// Returns the log level or error if something goes wrong
+func (service *envConfigurationService) GetLogLevel() (string, error) {
+	level := strings.ToLower(strings.Trim(service.getVariable("LOG_LEVEL"), " "))
+	if level == "" {
+		return "info", nil
+	}
+
+	if level != "debug" && level != "info" && level != "warn" && level != "error" {
+		return "", commonErrors.NewUnknownError(fmt.Sprintf("LOG_LEVEL is not supported: %s", level))
+	}
+
+	return level, nil
+}
+
+// GetLogFormat retrieves the format the log messages are written in
+// Returns the log format or error if something goes wrong
+func (service *envConfigurationService) GetLogFormat() (string, error) {
+	format := strings.ToLower(strings.Trim(service.getVariable("LOG_FORMAT"), " "))
+	if format == "" {
+		return "json", nil
+	}
+
+	if format != "json" && format != "console" {
+		return "", commonErrors.NewUnknownError(fmt.Sprintf("LOG_FORMAT is not supported: %s", format))
+	}
+
+	return format, nil
+}
+
+// GetLogLevelEndpointEnabled retrieves whether the HTTP server serves the endpoint that reads and changes the minimum
+// level of the logged messages without restarting the service
+// Returns true if the log level endpoint is served or error if something goes wrong
+func (service *envConfigurationService) GetLogLevelEndpointEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("LOG_LEVEL_ENDPOINT_ENABLED"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert LOG_LEVEL_ENDPOINT_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetPayloadLoggingEnabled retrieves whether the request and response payloads of the calls are logged at the debug
+// level
+// Returns true if the payloads are logged or error if something goes wrong
+func (service *envConfigurationService) GetPayloadLoggingEnabled() (bool, error) {
+	enabledString := strings.Trim(service.getVariable("PAYLOAD_LOGGING_ENABLED"), " ")
+	if enabledString == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(enabledString)
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to convert PAYLOAD_LOGGING_ENABLED to boolean", err)
+	}
+
+	return enabled, nil
+}
+
+// GetPayloadLoggingRedactedFields retrieves the fields whose values are replaced in the logged payloads, the email
+// addresses in the rest of the fields are replaced either way
+// Returns the list of the field names as they are named in the proto files or error if something goes wrong
+func (service *envConfigurationService) GetPayloadLoggingRedactedFields() ([]string, error) {
+	fieldsString := strings.Trim(service.getVariable("PAYLOAD_LOGGING_REDACTED_FIELDS"), " ")
+	if fieldsString == "" {
+		return []string{
+			"email", "emails", "actorEmail", "failedEmails", "createdBy", "updatedBy", "labels", "password",
+			"currentPassword", "newPassword", "token", "confirmationToken", "totpSecretRef", "recoveryCodeHashes",
+			"content", "archive"}, nil
+	}
+
+	// none is accepted so only the email addresses are replaced, an empty variable is indistinguishable from an unset one
+	if strings.EqualFold(fieldsString, "none") {
+		return []string{}, nil
+	}
+
+	fields := []string{}
+	for _, field := range strings.Split(fieldsString, ",") {
+		if field = strings.Trim(field, " "); field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, nil
+}
+
+// GetSecretsRefreshInterval retrieves how often the secrets referenced by the secret options are read again
+// Returns the refresh interval or error if something goes wrong
+func (service *envConfigurationService) GetSecretsRefreshInterval() (time.Duration, error) {
+	intervalString := strings.Trim(service.getVariable("SECRETS_REFRESH_INTERVAL"), " ")
+	if intervalString == "" {
+		return 5 * time.Minute, nil
+	}
+
+	interval, err := time.ParseDuration(intervalString)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError("failed to convert SECRETS_REFRESH_INTERVAL to duration", err)
+	}
+
+	if interval <= 0 {
+		return 0, commonErrors.NewUnknownError("SECRETS_REFRESH_INTERVAL must be greater than zero")
+	}
+
+	return interval, nil
+}
+
+// GetVaultAddress retrieves the address of the HashiCorp Vault server the secrets are read from
+// Returns the Vault address or error if something goes wrong
+func (service *envConfigurationService) GetVaultAddress() (string, error) {
+	address := strings.Trim(service.getVariable("VAULT_ADDRESS"), " ")
+	if address == "" {
+		return "", nil
+	}
+
+	parsedAddress, err := url.Parse(address)
+	if err != nil || (parsedAddress.Scheme != "http" && parsedAddress.Scheme != "https") || parsedAddress.Host == "" {
+		return "", commonErrors.NewUnknownError(fmt.Sprintf("VAULT_ADDRESS must be an http or https URL: %s", address))
+	}
+
+	return strings.TrimRight(address, "/"), nil
+}
+
+// GetVaultToken retrieves the token the secrets are read from Vault with
+// Returns the Vault token or error if something goes wrong
+func (service *envConfigurationService) GetVaultToken() (string, error) {
+	return strings.Trim(service.getVariable("VAULT_TOKEN"), " "), nil
+}
+
+// GetVaultKubernetesRole retrieves the Vault role the service logs in as with its Kubernetes service account token
+// Returns the Vault role or error if something goes wrong
+func (service *envConfigurationService) GetVaultKubernetesRole() (string, error) {
+	return strings.Trim(service.getVariable("VAULT_KUBERNETES_ROLE"), " "), nil
+}
+
+// GetVaultKubernetesAuthPath retrieves the path the Kubernetes auth method is enabled at in Vault
+// Returns the path of the auth method or error if something goes wrong
+func (service *envConfigurationService) GetVaultKubernetesAuthPath() (string, error) {
+	authPath := strings.Trim(service.getVariable("VAULT_KUBERNETES_AUTH_PATH"), " /")
+	if authPath == "" {
+		return "kubernetes", nil
+	}
+
+	return authPath, nil
+}
+
+// getHost reads the host name to listen on from the given environment variable. IPv6 literals can be provided
+// with or without brackets (e.g. "::" or "[::]"), and an empty host binds to all interfaces on both IPv4 and IPv6.
+// variableName: Mandatory. The name of the environment variable to read the host name from
+// Returns the host name without brackets or error if the host name is neither a valid IP address nor a valid DNS name
+func (service *envConfigurationService) getHost(variableName string) (string, error) {
+	host := strings.Trim(service.getVariable(variableName), " ")
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		host = host[1 : len(host)-1]
+	}
+
+	if host == "" || net.ParseIP(host) != nil {
+		return host, nil
+	}
+
+	if err := is.Host.Validate(host); err != nil {
+		return "", commonErrors.NewUnknownErrorWithError(fmt.Sprintf("%s must be either a valid IP address or DNS name", variableName), err)
+	}
+
+	return host, nil
+}
+
+// getObjective reads the SLO objective from the given environment variable
+// variableName: Mandatory. The name of the environment variable to read the objective from
+// defaultObjective: Mandatory. The objective to return if the environment variable is not set
+// Returns the objective or error if the objective is not a ratio between 0 and 1 exclusive
+func (service *envConfigurationService) getObjective(variableName string, defaultObjective float64) (float64, error) {
+	objectiveString := strings.Trim(service.getVariable(variableName), " ")
+	if objectiveString == "" {
+		return defaultObjective, nil
+	}
+
+	objective, err := strconv.ParseFloat(objectiveString, 64)
+	if err != nil {
+		return 0, commonErrors.NewUnknownErrorWithError(fmt.Sprintf("failed to convert %s to float", variableName), err)
+	}
+
+	if objective <= 0 || objective >= 1 {
+		return 0, commonErrors.NewUnknownError(fmt.Sprintf("%s must be between 0 and 1 exclusive", variableName))
+	}
+
+	return objective, nil
 }
@@ -0,0 +1,149 @@
+package configuration_test
+
+import (
+	"os"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Env Configuration Service Tests", func() {
+	var (
+		sut configuration.ConfigurationContract
+	)
+
+	// getHosts are the getters of the hosts the listeners bind to, keyed by the environment variables they are read from
+	getHosts := map[string]func() (string, error){
+		"GRPC_HOST":    func() (string, error) { return sut.GetGrpcHost() },
+		"HTTP_HOST":    func() (string, error) { return sut.GetHttpHost() },
+		"GRAPHQL_HOST": func() (string, error) { return sut.GetGraphQLHost() },
+	}
+
+	BeforeEach(func() {
+		for _, option := range configuration.Options() {
+			os.Unsetenv(option.EnvironmentVariable)
+		}
+
+		var err error
+		sut, err = configuration.NewEnvConfigurationService()
+		Ω(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		for _, option := range configuration.Options() {
+			os.Unsetenv(option.EnvironmentVariable)
+		}
+	})
+
+	Context("the host the listener binds to is read", func() {
+		When("the host is not set", func() {
+			It("should return an empty host so the listener binds to all the IPv4 and IPv6 interfaces", func() {
+				for _, getHost := range getHosts {
+					host, err := getHost()
+					Ω(err).Should(BeNil())
+					Ω(host).Should(BeEmpty())
+				}
+			})
+		})
+
+		When("the host is an IPv6 literal", func() {
+			It("should return the address without the brackets", func() {
+				for variableName, getHost := range getHosts {
+					for value, expectedHost := range map[string]string{
+						"::":        "::",
+						"[::]":      "::",
+						"[::1]":     "::1",
+						" fd00::1 ": "fd00::1",
+					} {
+						os.Setenv(variableName, value)
+
+						host, err := getHost()
+						Ω(err).Should(BeNil())
+						Ω(host).Should(Equal(expectedHost))
+					}
+				}
+			})
+		})
+
+		When("the host is an IPv4 address or a DNS name", func() {
+			It("should return the host as it is", func() {
+				for variableName, getHost := range getHosts {
+					for _, value := range []string{"0.0.0.0", "127.0.0.1", "localhost", "user.decentralized-cloud.io"} {
+						os.Setenv(variableName, value)
+
+						host, err := getHost()
+						Ω(err).Should(BeNil())
+						Ω(host).Should(Equal(value))
+					}
+				}
+			})
+		})
+
+		When("the host is neither an IP address nor a DNS name", func() {
+			It("should return UnknownError", func() {
+				for variableName, getHost := range getHosts {
+					for _, value := range []string{"[user service]", "::1]", "user_service!"} {
+						os.Setenv(variableName, value)
+
+						_, err := getHost()
+						Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+					}
+				}
+			})
+		})
+	})
+
+	Context("whether the gRPC server reflection is enabled is read", func() {
+		When("it is not set", func() {
+			It("should enable the reflection", func() {
+				enabled, err := sut.GetGrpcReflectionEnabled()
+				Ω(err).Should(BeNil())
+				Ω(enabled).Should(BeTrue())
+			})
+		})
+
+		When("it is set", func() {
+			It("should return the parsed value", func() {
+				for value, expectedEnabled := range map[string]bool{"false": false, " 0 ": false, "true": true, "1": true} {
+					os.Setenv("GRPC_REFLECTION_ENABLED", value)
+
+					enabled, err := sut.GetGrpcReflectionEnabled()
+					Ω(err).Should(BeNil())
+					Ω(enabled).Should(Equal(expectedEnabled))
+				}
+			})
+		})
+
+		When("it is not a boolean", func() {
+			It("should return UnknownError", func() {
+				os.Setenv("GRPC_REFLECTION_ENABLED", "sometimes")
+
+				_, err := sut.GetGrpcReflectionEnabled()
+				Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("the MongoDB read preference is read", func() {
+		When("it is not set", func() {
+			It("should read from the primary", func() {
+				readPreference, err := sut.GetDatabaseReadPreference()
+				Ω(err).Should(BeNil())
+				Ω(readPreference).Should(Equal("primary"))
+			})
+		})
+
+		When("it is set", func() {
+			It("should return the read preference mode regardless of its case", func() {
+				os.Setenv("USER_DATABASE_READ_PREFERENCE", " SecondaryPreferred ")
+
+				readPreference, err := sut.GetDatabaseReadPreference()
+				Ω(err).Should(BeNil())
+				Ω(readPreference).Should(Equal("secondaryPreferred"))
+			})
+		})
+	})
+})
@@ -0,0 +1,108 @@
+// Package configuration implements configuration service required by the user service
+package configuration
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// NewFileConfigurationService creates new instance of the configuration service that reads the options from a YAML
+// or JSON file, setting up all dependencies and returns the instance. The keys of the file are the environment
+// variables the options are read from, as generated by the config init command. The environment variables take
+// precedence over the file, so an option can be overridden without editing the file.
+// path: Mandatory. The path to the configuration file
+// Returns the new service or error if something goes wrong
+func NewFileConfigurationService(path string) (ConfigurationContract, error) {
+	if strings.Trim(path, " ") == "" {
+		return nil, commonErrors.NewArgumentNilError("path", "path is required")
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError(fmt.Sprintf("failed to read the configuration file %s", path), err)
+	}
+
+	values, err := parseConfigurationFile(content)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError(fmt.Sprintf("failed to parse the configuration file %s", path), err)
+	}
+
+	return newEnvConfigurationService(func(variableName string) string {
+		if value, ok := os.LookupEnv(variableName); ok {
+			return value
+		}
+
+		return values[variableName]
+	})
+}
+
+// parseConfigurationFile parses the YAML or JSON configuration file into the values of the options keyed by their
+// environment variable. The lists are joined by comma and the maps are joined as comma separated key=value pairs, the
+// same way the list and map options are provided as environment variables.
+// content: Mandatory. The content of the configuration file
+// Returns the values of the options or error if the file is invalid or contains an unknown option
+func parseConfigurationFile(content []byte) (map[string]string, error) {
+	document := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+
+	knownVariables := map[string]bool{}
+	for _, option := range Options() {
+		knownVariables[option.EnvironmentVariable] = true
+	}
+
+	values := map[string]string{}
+	unknownVariables := []string{}
+	for variableName, value := range document {
+		if !knownVariables[variableName] {
+			unknownVariables = append(unknownVariables, variableName)
+
+			continue
+		}
+
+		values[variableName] = formatConfigurationFileValue(value)
+	}
+
+	if len(unknownVariables) > 0 {
+		sort.Strings(unknownVariables)
+
+		return nil, fmt.Errorf("unknown options: %s", strings.Join(unknownVariables, ", "))
+	}
+
+	return values, nil
+}
+
+func formatConfigurationFileValue(value interface{}) string {
+	switch castedValue := value.(type) {
+	case nil:
+		return ""
+
+	case []interface{}:
+		items := make([]string, 0, len(castedValue))
+		for _, item := range castedValue {
+			items = append(items, formatConfigurationFileValue(item))
+		}
+
+		return strings.Join(items, ",")
+
+	case map[interface{}]interface{}:
+		pairs := make([]string, 0, len(castedValue))
+		for key, item := range castedValue {
+			pairs = append(pairs, fmt.Sprint(key)+"="+formatConfigurationFileValue(item))
+		}
+
+		sort.Strings(pairs)
+
+		return strings.Join(pairs, ",")
+
+	default:
+		return fmt.Sprint(castedValue)
+	}
+}
@@ -0,0 +1,146 @@
+package configuration_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("File Configuration Service Tests", func() {
+	var (
+		directory string
+	)
+
+	BeforeEach(func() {
+		var err error
+		directory, err = ioutil.TempDir("", "configuration")
+		Ω(err).Should(BeNil())
+
+		for _, option := range configuration.Options() {
+			os.Unsetenv(option.EnvironmentVariable)
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+		os.Unsetenv("GRPC_PORT")
+	})
+
+	writeFile := func(content string) string {
+		path := filepath.Join(directory, "config.yaml")
+		Ω(ioutil.WriteFile(path, []byte(content), 0600)).Should(Succeed())
+
+		return path
+	}
+
+	Context("user tries to instantiate FileConfigurationService", func() {
+		When("path is not provided and NewFileConfigurationService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := configuration.NewFileConfigurationService("")
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("the file does not exist", func() {
+			It("should return error", func() {
+				service, err := configuration.NewFileConfigurationService(filepath.Join(directory, "missing.yaml"))
+				Ω(service).Should(BeNil())
+				Ω(err).ShouldNot(BeNil())
+			})
+		})
+
+		When("the file contains an unknown option", func() {
+			It("should return error naming the unknown option", func() {
+				service, err := configuration.NewFileConfigurationService(writeFile("GRPC_PORTS: 80\n"))
+				Ω(service).Should(BeNil())
+				Ω(err.Error()).Should(ContainSubstring("GRPC_PORTS"))
+			})
+		})
+	})
+
+	Context("FileConfigurationService is instantiated", func() {
+		When("the file is a YAML file", func() {
+			It("should read the options from the file", func() {
+				service, err := configuration.NewFileConfigurationService(writeFile(`
+GRPC_PORT: 8080
+SAGA_RETRY_BACKOFF: "250ms"
+USER_SOFT_DELETE_ENABLED: true
+ADMIN_EMAILS:
+  - admin@test.com
+  - ops@test.com
+VALIDATION_RULE_MODES:
+  email_lowercase: enforce
+  email_domain_has_tld: "off"
+VALIDATION_POLICY_FIELD_PATTERNS:
+  labels.department: "^[A-Z]{2,4}$"
+  apiKeyName: "^[a-z-]+$"
+JWT_CLAIM_MAPPING:
+  email: preferred_username
+SERVICE_IDENTITY_ALLOWLIST:
+  ReadUser: tenant+edge-cluster
+ENDPOINT_TIMEOUTS:
+  ReadUser: 2s
+  BulkUpdateUsers: 5m
+`))
+				Ω(err).Should(BeNil())
+
+				Ω(service.GetGrpcPort()).Should(Equal(8080))
+				Ω(service.GetSagaRetryBackoff()).Should(Equal(250 * time.Millisecond))
+				Ω(service.GetSoftDeleteEnabled()).Should(BeTrue())
+				Ω(service.GetAdminEmails()).Should(Equal([]string{"admin@test.com", "ops@test.com"}))
+				Ω(service.GetValidationRuleModes()).Should(Equal(map[string]string{
+					"email_lowercase":      "enforce",
+					"email_domain_has_tld": "off",
+				}))
+				Ω(service.GetValidationPolicyFieldPatterns()).Should(Equal(map[string]string{
+					"labels.department": "^[A-Z]{2,4}$",
+					"apiKeyName":        "^[a-z-]+$",
+				}))
+				Ω(service.GetJwtClaimMapping()).Should(Equal(map[string]string{"email": "preferred_username"}))
+				Ω(service.GetServiceIdentityAllowlist()).Should(Equal(map[string][]string{"ReadUser": {"tenant", "edge-cluster"}}))
+				Ω(service.GetEndpointTimeouts()).Should(Equal(map[string]time.Duration{
+					"ReadUser":        2 * time.Second,
+					"BulkUpdateUsers": 5 * time.Minute,
+				}))
+			})
+		})
+
+		When("the file is a JSON file", func() {
+			It("should read the options from the file", func() {
+				service, err := configuration.NewFileConfigurationService(writeFile(`{"GRPC_PORT": "8080", "JWKS_URL": "https://test.com/jwks"}`))
+				Ω(err).Should(BeNil())
+
+				Ω(service.GetGrpcPort()).Should(Equal(8080))
+				Ω(service.GetJwksURL()).Should(Equal("https://test.com/jwks"))
+			})
+		})
+
+		When("the option is set both in the file and the environment variable", func() {
+			It("should read the option from the environment variable", func() {
+				os.Setenv("GRPC_PORT", "9090")
+
+				service, err := configuration.NewFileConfigurationService(writeFile("GRPC_PORT: 8080\n"))
+				Ω(err).Should(BeNil())
+
+				Ω(service.GetGrpcPort()).Should(Equal(9090))
+			})
+		})
+
+		When("the option is left empty in the file", func() {
+			It("should use the default value", func() {
+				service, err := configuration.NewFileConfigurationService(writeFile("GRPC_SHUTDOWN_TIMEOUT: \"\"\n"))
+				Ω(err).Should(BeNil())
+
+				Ω(service.GetGrpcShutdownTimeout()).Should(Equal(30 * time.Second))
+			})
+		})
+	})
+})
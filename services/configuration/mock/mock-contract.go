@@ -6,6 +6,7 @@ package mock_configuration
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -33,122 +34,2267 @@ func (m *MockConfigurationContract) EXPECT() *MockConfigurationContractMockRecor
 	return m.recorder
 }
 
+// GetAPIKeyCollectionName mocks base method.
+func (m *MockConfigurationContract) GetAPIKeyCollectionName() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyCollectionName")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyCollectionName indicates an expected call of GetAPIKeyCollectionName.
+func (mr *MockConfigurationContractMockRecorder) GetAPIKeyCollectionName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyCollectionName", reflect.TypeOf((*MockConfigurationContract)(nil).GetAPIKeyCollectionName))
+}
+
+// GetAPIKeyMaxPerUser mocks base method.
+func (m *MockConfigurationContract) GetAPIKeyMaxPerUser() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyMaxPerUser")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyMaxPerUser indicates an expected call of GetAPIKeyMaxPerUser.
+func (mr *MockConfigurationContractMockRecorder) GetAPIKeyMaxPerUser() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyMaxPerUser", reflect.TypeOf((*MockConfigurationContract)(nil).GetAPIKeyMaxPerUser))
+}
+
+// GetAdminEmails mocks base method.
+func (m *MockConfigurationContract) GetAdminEmails() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminEmails")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminEmails indicates an expected call of GetAdminEmails.
+func (mr *MockConfigurationContractMockRecorder) GetAdminEmails() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminEmails", reflect.TypeOf((*MockConfigurationContract)(nil).GetAdminEmails))
+}
+
+// GetAdminGroups mocks base method.
+func (m *MockConfigurationContract) GetAdminGroups() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminGroups")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminGroups indicates an expected call of GetAdminGroups.
+func (mr *MockConfigurationContractMockRecorder) GetAdminGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminGroups", reflect.TypeOf((*MockConfigurationContract)(nil).GetAdminGroups))
+}
+
+// GetAuditCollectionName mocks base method.
+func (m *MockConfigurationContract) GetAuditCollectionName() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditCollectionName")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditCollectionName indicates an expected call of GetAuditCollectionName.
+func (mr *MockConfigurationContractMockRecorder) GetAuditCollectionName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditCollectionName", reflect.TypeOf((*MockConfigurationContract)(nil).GetAuditCollectionName))
+}
+
+// GetAuthorizationDecisionLoggingEnabled mocks base method.
+func (m *MockConfigurationContract) GetAuthorizationDecisionLoggingEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorizationDecisionLoggingEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorizationDecisionLoggingEnabled indicates an expected call of GetAuthorizationDecisionLoggingEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetAuthorizationDecisionLoggingEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorizationDecisionLoggingEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetAuthorizationDecisionLoggingEnabled))
+}
+
+// GetAuthorizationPolicyFile mocks base method.
+func (m *MockConfigurationContract) GetAuthorizationPolicyFile() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorizationPolicyFile")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorizationPolicyFile indicates an expected call of GetAuthorizationPolicyFile.
+func (mr *MockConfigurationContractMockRecorder) GetAuthorizationPolicyFile() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorizationPolicyFile", reflect.TypeOf((*MockConfigurationContract)(nil).GetAuthorizationPolicyFile))
+}
+
+// GetAvatarAllowedContentTypes mocks base method.
+func (m *MockConfigurationContract) GetAvatarAllowedContentTypes() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvatarAllowedContentTypes")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvatarAllowedContentTypes indicates an expected call of GetAvatarAllowedContentTypes.
+func (mr *MockConfigurationContractMockRecorder) GetAvatarAllowedContentTypes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatarAllowedContentTypes", reflect.TypeOf((*MockConfigurationContract)(nil).GetAvatarAllowedContentTypes))
+}
+
+// GetAvatarMaxSize mocks base method.
+func (m *MockConfigurationContract) GetAvatarMaxSize() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvatarMaxSize")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvatarMaxSize indicates an expected call of GetAvatarMaxSize.
+func (mr *MockConfigurationContractMockRecorder) GetAvatarMaxSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatarMaxSize", reflect.TypeOf((*MockConfigurationContract)(nil).GetAvatarMaxSize))
+}
+
+// GetAvatarURLExpiry mocks base method.
+func (m *MockConfigurationContract) GetAvatarURLExpiry() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvatarURLExpiry")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvatarURLExpiry indicates an expected call of GetAvatarURLExpiry.
+func (mr *MockConfigurationContractMockRecorder) GetAvatarURLExpiry() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatarURLExpiry", reflect.TypeOf((*MockConfigurationContract)(nil).GetAvatarURLExpiry))
+}
+
+// GetAvatarsEnabled mocks base method.
+func (m *MockConfigurationContract) GetAvatarsEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvatarsEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvatarsEnabled indicates an expected call of GetAvatarsEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetAvatarsEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatarsEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetAvatarsEnabled))
+}
+
+// GetBulkUpdateMaxUsers mocks base method.
+func (m *MockConfigurationContract) GetBulkUpdateMaxUsers() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBulkUpdateMaxUsers")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBulkUpdateMaxUsers indicates an expected call of GetBulkUpdateMaxUsers.
+func (mr *MockConfigurationContractMockRecorder) GetBulkUpdateMaxUsers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBulkUpdateMaxUsers", reflect.TypeOf((*MockConfigurationContract)(nil).GetBulkUpdateMaxUsers))
+}
+
+// GetBulkUpdatePreviewTTL mocks base method.
+func (m *MockConfigurationContract) GetBulkUpdatePreviewTTL() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBulkUpdatePreviewTTL")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBulkUpdatePreviewTTL indicates an expected call of GetBulkUpdatePreviewTTL.
+func (mr *MockConfigurationContractMockRecorder) GetBulkUpdatePreviewTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBulkUpdatePreviewTTL", reflect.TypeOf((*MockConfigurationContract)(nil).GetBulkUpdatePreviewTTL))
+}
+
+// GetBulkUpdateRateLimit mocks base method.
+func (m *MockConfigurationContract) GetBulkUpdateRateLimit() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBulkUpdateRateLimit")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBulkUpdateRateLimit indicates an expected call of GetBulkUpdateRateLimit.
+func (mr *MockConfigurationContractMockRecorder) GetBulkUpdateRateLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBulkUpdateRateLimit", reflect.TypeOf((*MockConfigurationContract)(nil).GetBulkUpdateRateLimit))
+}
+
+// GetBulkUpdateTokenSecret mocks base method.
+func (m *MockConfigurationContract) GetBulkUpdateTokenSecret() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBulkUpdateTokenSecret")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBulkUpdateTokenSecret indicates an expected call of GetBulkUpdateTokenSecret.
+func (mr *MockConfigurationContractMockRecorder) GetBulkUpdateTokenSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBulkUpdateTokenSecret", reflect.TypeOf((*MockConfigurationContract)(nil).GetBulkUpdateTokenSecret))
+}
+
+// GetCacheEnabled mocks base method.
+func (m *MockConfigurationContract) GetCacheEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCacheEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCacheEnabled indicates an expected call of GetCacheEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetCacheEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCacheEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetCacheEnabled))
+}
+
+// GetCacheKeyPrefix mocks base method.
+func (m *MockConfigurationContract) GetCacheKeyPrefix() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCacheKeyPrefix")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCacheKeyPrefix indicates an expected call of GetCacheKeyPrefix.
+func (mr *MockConfigurationContractMockRecorder) GetCacheKeyPrefix() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCacheKeyPrefix", reflect.TypeOf((*MockConfigurationContract)(nil).GetCacheKeyPrefix))
+}
+
+// GetCacheLocalMaxEntries mocks base method.
+func (m *MockConfigurationContract) GetCacheLocalMaxEntries() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCacheLocalMaxEntries")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCacheLocalMaxEntries indicates an expected call of GetCacheLocalMaxEntries.
+func (mr *MockConfigurationContractMockRecorder) GetCacheLocalMaxEntries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCacheLocalMaxEntries", reflect.TypeOf((*MockConfigurationContract)(nil).GetCacheLocalMaxEntries))
+}
+
+// GetCacheLocalTTL mocks base method.
+func (m *MockConfigurationContract) GetCacheLocalTTL() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCacheLocalTTL")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCacheLocalTTL indicates an expected call of GetCacheLocalTTL.
+func (mr *MockConfigurationContractMockRecorder) GetCacheLocalTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCacheLocalTTL", reflect.TypeOf((*MockConfigurationContract)(nil).GetCacheLocalTTL))
+}
+
+// GetCacheRedisAddress mocks base method.
+func (m *MockConfigurationContract) GetCacheRedisAddress() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCacheRedisAddress")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCacheRedisAddress indicates an expected call of GetCacheRedisAddress.
+func (mr *MockConfigurationContractMockRecorder) GetCacheRedisAddress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCacheRedisAddress", reflect.TypeOf((*MockConfigurationContract)(nil).GetCacheRedisAddress))
+}
+
+// GetCacheRedisDatabase mocks base method.
+func (m *MockConfigurationContract) GetCacheRedisDatabase() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCacheRedisDatabase")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCacheRedisDatabase indicates an expected call of GetCacheRedisDatabase.
+func (mr *MockConfigurationContractMockRecorder) GetCacheRedisDatabase() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCacheRedisDatabase", reflect.TypeOf((*MockConfigurationContract)(nil).GetCacheRedisDatabase))
+}
+
+// GetCacheRedisPassword mocks base method.
+func (m *MockConfigurationContract) GetCacheRedisPassword() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCacheRedisPassword")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCacheRedisPassword indicates an expected call of GetCacheRedisPassword.
+func (mr *MockConfigurationContractMockRecorder) GetCacheRedisPassword() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCacheRedisPassword", reflect.TypeOf((*MockConfigurationContract)(nil).GetCacheRedisPassword))
+}
+
+// GetCacheTTL mocks base method.
+func (m *MockConfigurationContract) GetCacheTTL() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCacheTTL")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCacheTTL indicates an expected call of GetCacheTTL.
+func (mr *MockConfigurationContractMockRecorder) GetCacheTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCacheTTL", reflect.TypeOf((*MockConfigurationContract)(nil).GetCacheTTL))
+}
+
+// GetConnectEnabled mocks base method.
+func (m *MockConfigurationContract) GetConnectEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnectEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConnectEnabled indicates an expected call of GetConnectEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetConnectEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetConnectEnabled))
+}
+
+// GetConsentCollectionName mocks base method.
+func (m *MockConfigurationContract) GetConsentCollectionName() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsentCollectionName")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsentCollectionName indicates an expected call of GetConsentCollectionName.
+func (mr *MockConfigurationContractMockRecorder) GetConsentCollectionName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsentCollectionName", reflect.TypeOf((*MockConfigurationContract)(nil).GetConsentCollectionName))
+}
+
+// GetConsentRequiredOperations mocks base method.
+func (m *MockConfigurationContract) GetConsentRequiredOperations() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsentRequiredOperations")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsentRequiredOperations indicates an expected call of GetConsentRequiredOperations.
+func (mr *MockConfigurationContractMockRecorder) GetConsentRequiredOperations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsentRequiredOperations", reflect.TypeOf((*MockConfigurationContract)(nil).GetConsentRequiredOperations))
+}
+
+// GetConsentRequiredPolicies mocks base method.
+func (m *MockConfigurationContract) GetConsentRequiredPolicies() (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsentRequiredPolicies")
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsentRequiredPolicies indicates an expected call of GetConsentRequiredPolicies.
+func (mr *MockConfigurationContractMockRecorder) GetConsentRequiredPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsentRequiredPolicies", reflect.TypeOf((*MockConfigurationContract)(nil).GetConsentRequiredPolicies))
+}
+
+// GetContentSecurityPolicy mocks base method.
+func (m *MockConfigurationContract) GetContentSecurityPolicy() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContentSecurityPolicy")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContentSecurityPolicy indicates an expected call of GetContentSecurityPolicy.
+func (mr *MockConfigurationContractMockRecorder) GetContentSecurityPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContentSecurityPolicy", reflect.TypeOf((*MockConfigurationContract)(nil).GetContentSecurityPolicy))
+}
+
+// GetCorsAllowedHeaders mocks base method.
+func (m *MockConfigurationContract) GetCorsAllowedHeaders() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCorsAllowedHeaders")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCorsAllowedHeaders indicates an expected call of GetCorsAllowedHeaders.
+func (mr *MockConfigurationContractMockRecorder) GetCorsAllowedHeaders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCorsAllowedHeaders", reflect.TypeOf((*MockConfigurationContract)(nil).GetCorsAllowedHeaders))
+}
+
+// GetCorsAllowedMethods mocks base method.
+func (m *MockConfigurationContract) GetCorsAllowedMethods() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCorsAllowedMethods")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCorsAllowedMethods indicates an expected call of GetCorsAllowedMethods.
+func (mr *MockConfigurationContractMockRecorder) GetCorsAllowedMethods() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCorsAllowedMethods", reflect.TypeOf((*MockConfigurationContract)(nil).GetCorsAllowedMethods))
+}
+
+// GetCorsAllowedOrigins mocks base method.
+func (m *MockConfigurationContract) GetCorsAllowedOrigins() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCorsAllowedOrigins")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCorsAllowedOrigins indicates an expected call of GetCorsAllowedOrigins.
+func (mr *MockConfigurationContractMockRecorder) GetCorsAllowedOrigins() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCorsAllowedOrigins", reflect.TypeOf((*MockConfigurationContract)(nil).GetCorsAllowedOrigins))
+}
+
+// GetCorsMaxAge mocks base method.
+func (m *MockConfigurationContract) GetCorsMaxAge() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCorsMaxAge")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCorsMaxAge indicates an expected call of GetCorsMaxAge.
+func (mr *MockConfigurationContractMockRecorder) GetCorsMaxAge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCorsMaxAge", reflect.TypeOf((*MockConfigurationContract)(nil).GetCorsMaxAge))
+}
+
+// GetCredentialCollectionName mocks base method.
+func (m *MockConfigurationContract) GetCredentialCollectionName() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredentialCollectionName")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredentialCollectionName indicates an expected call of GetCredentialCollectionName.
+func (mr *MockConfigurationContractMockRecorder) GetCredentialCollectionName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentialCollectionName", reflect.TypeOf((*MockConfigurationContract)(nil).GetCredentialCollectionName))
+}
+
+// GetDataResidencyDefaultRegion mocks base method.
+func (m *MockConfigurationContract) GetDataResidencyDefaultRegion() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataResidencyDefaultRegion")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataResidencyDefaultRegion indicates an expected call of GetDataResidencyDefaultRegion.
+func (mr *MockConfigurationContractMockRecorder) GetDataResidencyDefaultRegion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataResidencyDefaultRegion", reflect.TypeOf((*MockConfigurationContract)(nil).GetDataResidencyDefaultRegion))
+}
+
+// GetDataResidencyRoutes mocks base method.
+func (m *MockConfigurationContract) GetDataResidencyRoutes() (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataResidencyRoutes")
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataResidencyRoutes indicates an expected call of GetDataResidencyRoutes.
+func (mr *MockConfigurationContractMockRecorder) GetDataResidencyRoutes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataResidencyRoutes", reflect.TypeOf((*MockConfigurationContract)(nil).GetDataResidencyRoutes))
+}
+
 // GetDatabaseCollectionName mocks base method.
 func (m *MockConfigurationContract) GetDatabaseCollectionName() (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDatabaseCollectionName")
+	ret := m.ctrl.Call(m, "GetDatabaseCollectionName")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatabaseCollectionName indicates an expected call of GetDatabaseCollectionName.
+func (mr *MockConfigurationContractMockRecorder) GetDatabaseCollectionName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseCollectionName", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseCollectionName))
+}
+
+// GetDatabaseConnectionString mocks base method.
+func (m *MockConfigurationContract) GetDatabaseConnectionString() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatabaseConnectionString")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatabaseConnectionString indicates an expected call of GetDatabaseConnectionString.
+func (mr *MockConfigurationContractMockRecorder) GetDatabaseConnectionString() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseConnectionString", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseConnectionString))
+}
+
+// GetDatabaseName mocks base method.
+func (m *MockConfigurationContract) GetDatabaseName() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatabaseName")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatabaseName indicates an expected call of GetDatabaseName.
+func (mr *MockConfigurationContractMockRecorder) GetDatabaseName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseName", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseName))
+}
+
+// GetDatabaseReadConcern mocks base method.
+func (m *MockConfigurationContract) GetDatabaseReadConcern() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatabaseReadConcern")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatabaseReadConcern indicates an expected call of GetDatabaseReadConcern.
+func (mr *MockConfigurationContractMockRecorder) GetDatabaseReadConcern() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseReadConcern", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseReadConcern))
+}
+
+// GetDatabaseReadPreference mocks base method.
+func (m *MockConfigurationContract) GetDatabaseReadPreference() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatabaseReadPreference")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatabaseReadPreference indicates an expected call of GetDatabaseReadPreference.
+func (mr *MockConfigurationContractMockRecorder) GetDatabaseReadPreference() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseReadPreference", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseReadPreference))
+}
+
+// GetDatabaseSearchIndexHints mocks base method.
+func (m *MockConfigurationContract) GetDatabaseSearchIndexHints() (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatabaseSearchIndexHints")
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatabaseSearchIndexHints indicates an expected call of GetDatabaseSearchIndexHints.
+func (mr *MockConfigurationContractMockRecorder) GetDatabaseSearchIndexHints() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseSearchIndexHints", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseSearchIndexHints))
+}
+
+// GetDatabaseSearchQueryPlanStatisticsEnabled mocks base method.
+func (m *MockConfigurationContract) GetDatabaseSearchQueryPlanStatisticsEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatabaseSearchQueryPlanStatisticsEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatabaseSearchQueryPlanStatisticsEnabled indicates an expected call of GetDatabaseSearchQueryPlanStatisticsEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetDatabaseSearchQueryPlanStatisticsEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseSearchQueryPlanStatisticsEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseSearchQueryPlanStatisticsEnabled))
+}
+
+// GetDatabaseSearchSortableFields mocks base method.
+func (m *MockConfigurationContract) GetDatabaseSearchSortableFields() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatabaseSearchSortableFields")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatabaseSearchSortableFields indicates an expected call of GetDatabaseSearchSortableFields.
+func (mr *MockConfigurationContractMockRecorder) GetDatabaseSearchSortableFields() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseSearchSortableFields", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseSearchSortableFields))
+}
+
+// GetDatabaseStartupCheckEnabled mocks base method.
+func (m *MockConfigurationContract) GetDatabaseStartupCheckEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatabaseStartupCheckEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatabaseStartupCheckEnabled indicates an expected call of GetDatabaseStartupCheckEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetDatabaseStartupCheckEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseStartupCheckEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseStartupCheckEnabled))
+}
+
+// GetDatabaseStartupCheckInitialBackoff mocks base method.
+func (m *MockConfigurationContract) GetDatabaseStartupCheckInitialBackoff() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatabaseStartupCheckInitialBackoff")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatabaseStartupCheckInitialBackoff indicates an expected call of GetDatabaseStartupCheckInitialBackoff.
+func (mr *MockConfigurationContractMockRecorder) GetDatabaseStartupCheckInitialBackoff() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseStartupCheckInitialBackoff", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseStartupCheckInitialBackoff))
+}
+
+// GetDatabaseStartupCheckMaxBackoff mocks base method.
+func (m *MockConfigurationContract) GetDatabaseStartupCheckMaxBackoff() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatabaseStartupCheckMaxBackoff")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatabaseStartupCheckMaxBackoff indicates an expected call of GetDatabaseStartupCheckMaxBackoff.
+func (mr *MockConfigurationContractMockRecorder) GetDatabaseStartupCheckMaxBackoff() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseStartupCheckMaxBackoff", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseStartupCheckMaxBackoff))
+}
+
+// GetDatabaseType mocks base method.
+func (m *MockConfigurationContract) GetDatabaseType() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatabaseType")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatabaseType indicates an expected call of GetDatabaseType.
+func (mr *MockConfigurationContractMockRecorder) GetDatabaseType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseType", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseType))
+}
+
+// GetDatabaseWriteConcern mocks base method.
+func (m *MockConfigurationContract) GetDatabaseWriteConcern() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatabaseWriteConcern")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatabaseWriteConcern indicates an expected call of GetDatabaseWriteConcern.
+func (mr *MockConfigurationContractMockRecorder) GetDatabaseWriteConcern() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseWriteConcern", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseWriteConcern))
+}
+
+// GetEmailChangeTokenSecret mocks base method.
+func (m *MockConfigurationContract) GetEmailChangeTokenSecret() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailChangeTokenSecret")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailChangeTokenSecret indicates an expected call of GetEmailChangeTokenSecret.
+func (mr *MockConfigurationContractMockRecorder) GetEmailChangeTokenSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailChangeTokenSecret", reflect.TypeOf((*MockConfigurationContract)(nil).GetEmailChangeTokenSecret))
+}
+
+// GetEmailChangeTokenTTL mocks base method.
+func (m *MockConfigurationContract) GetEmailChangeTokenTTL() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailChangeTokenTTL")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailChangeTokenTTL indicates an expected call of GetEmailChangeTokenTTL.
+func (mr *MockConfigurationContractMockRecorder) GetEmailChangeTokenTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailChangeTokenTTL", reflect.TypeOf((*MockConfigurationContract)(nil).GetEmailChangeTokenTTL))
+}
+
+// GetEmailChangeURL mocks base method.
+func (m *MockConfigurationContract) GetEmailChangeURL() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailChangeURL")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailChangeURL indicates an expected call of GetEmailChangeURL.
+func (mr *MockConfigurationContractMockRecorder) GetEmailChangeURL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailChangeURL", reflect.TypeOf((*MockConfigurationContract)(nil).GetEmailChangeURL))
+}
+
+// GetEmailVerificationProvider mocks base method.
+func (m *MockConfigurationContract) GetEmailVerificationProvider() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailVerificationProvider")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailVerificationProvider indicates an expected call of GetEmailVerificationProvider.
+func (mr *MockConfigurationContractMockRecorder) GetEmailVerificationProvider() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailVerificationProvider", reflect.TypeOf((*MockConfigurationContract)(nil).GetEmailVerificationProvider))
+}
+
+// GetEmailVerificationTokenTTL mocks base method.
+func (m *MockConfigurationContract) GetEmailVerificationTokenTTL() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailVerificationTokenTTL")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailVerificationTokenTTL indicates an expected call of GetEmailVerificationTokenTTL.
+func (mr *MockConfigurationContractMockRecorder) GetEmailVerificationTokenTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailVerificationTokenTTL", reflect.TypeOf((*MockConfigurationContract)(nil).GetEmailVerificationTokenTTL))
+}
+
+// GetEmailVerificationURL mocks base method.
+func (m *MockConfigurationContract) GetEmailVerificationURL() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailVerificationURL")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailVerificationURL indicates an expected call of GetEmailVerificationURL.
+func (mr *MockConfigurationContractMockRecorder) GetEmailVerificationURL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailVerificationURL", reflect.TypeOf((*MockConfigurationContract)(nil).GetEmailVerificationURL))
+}
+
+// GetEndpointDefaultTimeout mocks base method.
+func (m *MockConfigurationContract) GetEndpointDefaultTimeout() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEndpointDefaultTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEndpointDefaultTimeout indicates an expected call of GetEndpointDefaultTimeout.
+func (mr *MockConfigurationContractMockRecorder) GetEndpointDefaultTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndpointDefaultTimeout", reflect.TypeOf((*MockConfigurationContract)(nil).GetEndpointDefaultTimeout))
+}
+
+// GetEndpointTimeouts mocks base method.
+func (m *MockConfigurationContract) GetEndpointTimeouts() (map[string]time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEndpointTimeouts")
+	ret0, _ := ret[0].(map[string]time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEndpointTimeouts indicates an expected call of GetEndpointTimeouts.
+func (mr *MockConfigurationContractMockRecorder) GetEndpointTimeouts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndpointTimeouts", reflect.TypeOf((*MockConfigurationContract)(nil).GetEndpointTimeouts))
+}
+
+// GetErasureConfirmationWindow mocks base method.
+func (m *MockConfigurationContract) GetErasureConfirmationWindow() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetErasureConfirmationWindow")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetErasureConfirmationWindow indicates an expected call of GetErasureConfirmationWindow.
+func (mr *MockConfigurationContractMockRecorder) GetErasureConfirmationWindow() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetErasureConfirmationWindow", reflect.TypeOf((*MockConfigurationContract)(nil).GetErasureConfirmationWindow))
+}
+
+// GetErasureGracePeriod mocks base method.
+func (m *MockConfigurationContract) GetErasureGracePeriod() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetErasureGracePeriod")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetErasureGracePeriod indicates an expected call of GetErasureGracePeriod.
+func (mr *MockConfigurationContractMockRecorder) GetErasureGracePeriod() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetErasureGracePeriod", reflect.TypeOf((*MockConfigurationContract)(nil).GetErasureGracePeriod))
+}
+
+// GetErasureTokenSecret mocks base method.
+func (m *MockConfigurationContract) GetErasureTokenSecret() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetErasureTokenSecret")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetErasureTokenSecret indicates an expected call of GetErasureTokenSecret.
+func (mr *MockConfigurationContractMockRecorder) GetErasureTokenSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetErasureTokenSecret", reflect.TypeOf((*MockConfigurationContract)(nil).GetErasureTokenSecret))
+}
+
+// GetEventingBroker mocks base method.
+func (m *MockConfigurationContract) GetEventingBroker() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventingBroker")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventingBroker indicates an expected call of GetEventingBroker.
+func (mr *MockConfigurationContractMockRecorder) GetEventingBroker() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventingBroker", reflect.TypeOf((*MockConfigurationContract)(nil).GetEventingBroker))
+}
+
+// GetEventingConnectionString mocks base method.
+func (m *MockConfigurationContract) GetEventingConnectionString() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventingConnectionString")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventingConnectionString indicates an expected call of GetEventingConnectionString.
+func (mr *MockConfigurationContractMockRecorder) GetEventingConnectionString() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventingConnectionString", reflect.TypeOf((*MockConfigurationContract)(nil).GetEventingConnectionString))
+}
+
+// GetEventingSubjectPrefix mocks base method.
+func (m *MockConfigurationContract) GetEventingSubjectPrefix() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventingSubjectPrefix")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventingSubjectPrefix indicates an expected call of GetEventingSubjectPrefix.
+func (mr *MockConfigurationContractMockRecorder) GetEventingSubjectPrefix() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventingSubjectPrefix", reflect.TypeOf((*MockConfigurationContract)(nil).GetEventingSubjectPrefix))
+}
+
+// GetGraphQLHost mocks base method.
+func (m *MockConfigurationContract) GetGraphQLHost() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGraphQLHost")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGraphQLHost indicates an expected call of GetGraphQLHost.
+func (mr *MockConfigurationContractMockRecorder) GetGraphQLHost() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGraphQLHost", reflect.TypeOf((*MockConfigurationContract)(nil).GetGraphQLHost))
+}
+
+// GetGraphQLPort mocks base method.
+func (m *MockConfigurationContract) GetGraphQLPort() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGraphQLPort")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGraphQLPort indicates an expected call of GetGraphQLPort.
+func (mr *MockConfigurationContractMockRecorder) GetGraphQLPort() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGraphQLPort", reflect.TypeOf((*MockConfigurationContract)(nil).GetGraphQLPort))
+}
+
+// GetGroupCollectionName mocks base method.
+func (m *MockConfigurationContract) GetGroupCollectionName() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupCollectionName")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupCollectionName indicates an expected call of GetGroupCollectionName.
+func (mr *MockConfigurationContractMockRecorder) GetGroupCollectionName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupCollectionName", reflect.TypeOf((*MockConfigurationContract)(nil).GetGroupCollectionName))
+}
+
+// GetGrpcHost mocks base method.
+func (m *MockConfigurationContract) GetGrpcHost() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrpcHost")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrpcHost indicates an expected call of GetGrpcHost.
+func (mr *MockConfigurationContractMockRecorder) GetGrpcHost() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrpcHost", reflect.TypeOf((*MockConfigurationContract)(nil).GetGrpcHost))
+}
+
+// GetGrpcMaxReceiveMessageSize mocks base method.
+func (m *MockConfigurationContract) GetGrpcMaxReceiveMessageSize() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrpcMaxReceiveMessageSize")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrpcMaxReceiveMessageSize indicates an expected call of GetGrpcMaxReceiveMessageSize.
+func (mr *MockConfigurationContractMockRecorder) GetGrpcMaxReceiveMessageSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrpcMaxReceiveMessageSize", reflect.TypeOf((*MockConfigurationContract)(nil).GetGrpcMaxReceiveMessageSize))
+}
+
+// GetGrpcPort mocks base method.
+func (m *MockConfigurationContract) GetGrpcPort() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrpcPort")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrpcPort indicates an expected call of GetGrpcPort.
+func (mr *MockConfigurationContractMockRecorder) GetGrpcPort() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrpcPort", reflect.TypeOf((*MockConfigurationContract)(nil).GetGrpcPort))
+}
+
+// GetGrpcReflectionEnabled mocks base method.
+func (m *MockConfigurationContract) GetGrpcReflectionEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrpcReflectionEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrpcReflectionEnabled indicates an expected call of GetGrpcReflectionEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetGrpcReflectionEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrpcReflectionEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetGrpcReflectionEnabled))
+}
+
+// GetGrpcShutdownDrainDelay mocks base method.
+func (m *MockConfigurationContract) GetGrpcShutdownDrainDelay() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrpcShutdownDrainDelay")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrpcShutdownDrainDelay indicates an expected call of GetGrpcShutdownDrainDelay.
+func (mr *MockConfigurationContractMockRecorder) GetGrpcShutdownDrainDelay() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrpcShutdownDrainDelay", reflect.TypeOf((*MockConfigurationContract)(nil).GetGrpcShutdownDrainDelay))
+}
+
+// GetGrpcShutdownTimeout mocks base method.
+func (m *MockConfigurationContract) GetGrpcShutdownTimeout() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrpcShutdownTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrpcShutdownTimeout indicates an expected call of GetGrpcShutdownTimeout.
+func (mr *MockConfigurationContractMockRecorder) GetGrpcShutdownTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrpcShutdownTimeout", reflect.TypeOf((*MockConfigurationContract)(nil).GetGrpcShutdownTimeout))
+}
+
+// GetGrpcStrictDecodingEnabled mocks base method.
+func (m *MockConfigurationContract) GetGrpcStrictDecodingEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrpcStrictDecodingEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrpcStrictDecodingEnabled indicates an expected call of GetGrpcStrictDecodingEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetGrpcStrictDecodingEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrpcStrictDecodingEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetGrpcStrictDecodingEnabled))
+}
+
+// GetHstsIncludeSubdomains mocks base method.
+func (m *MockConfigurationContract) GetHstsIncludeSubdomains() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHstsIncludeSubdomains")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHstsIncludeSubdomains indicates an expected call of GetHstsIncludeSubdomains.
+func (mr *MockConfigurationContractMockRecorder) GetHstsIncludeSubdomains() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHstsIncludeSubdomains", reflect.TypeOf((*MockConfigurationContract)(nil).GetHstsIncludeSubdomains))
+}
+
+// GetHstsMaxAge mocks base method.
+func (m *MockConfigurationContract) GetHstsMaxAge() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHstsMaxAge")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHstsMaxAge indicates an expected call of GetHstsMaxAge.
+func (mr *MockConfigurationContractMockRecorder) GetHstsMaxAge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHstsMaxAge", reflect.TypeOf((*MockConfigurationContract)(nil).GetHstsMaxAge))
+}
+
+// GetHttpHost mocks base method.
+func (m *MockConfigurationContract) GetHttpHost() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHttpHost")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHttpHost indicates an expected call of GetHttpHost.
+func (mr *MockConfigurationContractMockRecorder) GetHttpHost() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHttpHost", reflect.TypeOf((*MockConfigurationContract)(nil).GetHttpHost))
+}
+
+// GetHttpPort mocks base method.
+func (m *MockConfigurationContract) GetHttpPort() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHttpPort")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHttpPort indicates an expected call of GetHttpPort.
+func (mr *MockConfigurationContractMockRecorder) GetHttpPort() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHttpPort", reflect.TypeOf((*MockConfigurationContract)(nil).GetHttpPort))
+}
+
+// GetHttpProfilingEnabled mocks base method.
+func (m *MockConfigurationContract) GetHttpProfilingEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHttpProfilingEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHttpProfilingEnabled indicates an expected call of GetHttpProfilingEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetHttpProfilingEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHttpProfilingEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetHttpProfilingEnabled))
+}
+
+// GetInvitationCollectionName mocks base method.
+func (m *MockConfigurationContract) GetInvitationCollectionName() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationCollectionName")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationCollectionName indicates an expected call of GetInvitationCollectionName.
+func (mr *MockConfigurationContractMockRecorder) GetInvitationCollectionName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationCollectionName", reflect.TypeOf((*MockConfigurationContract)(nil).GetInvitationCollectionName))
+}
+
+// GetInvitationTokenTTL mocks base method.
+func (m *MockConfigurationContract) GetInvitationTokenTTL() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationTokenTTL")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationTokenTTL indicates an expected call of GetInvitationTokenTTL.
+func (mr *MockConfigurationContractMockRecorder) GetInvitationTokenTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationTokenTTL", reflect.TypeOf((*MockConfigurationContract)(nil).GetInvitationTokenTTL))
+}
+
+// GetInvitationURL mocks base method.
+func (m *MockConfigurationContract) GetInvitationURL() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationURL")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationURL indicates an expected call of GetInvitationURL.
+func (mr *MockConfigurationContractMockRecorder) GetInvitationURL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationURL", reflect.TypeOf((*MockConfigurationContract)(nil).GetInvitationURL))
+}
+
+// GetJwksRefreshInterval mocks base method.
+func (m *MockConfigurationContract) GetJwksRefreshInterval() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJwksRefreshInterval")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJwksRefreshInterval indicates an expected call of GetJwksRefreshInterval.
+func (mr *MockConfigurationContractMockRecorder) GetJwksRefreshInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJwksRefreshInterval", reflect.TypeOf((*MockConfigurationContract)(nil).GetJwksRefreshInterval))
+}
+
+// GetJwksURL mocks base method.
+func (m *MockConfigurationContract) GetJwksURL() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJwksURL")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJwksURL indicates an expected call of GetJwksURL.
+func (mr *MockConfigurationContractMockRecorder) GetJwksURL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJwksURL", reflect.TypeOf((*MockConfigurationContract)(nil).GetJwksURL))
+}
+
+// GetJwtAcceptedAudiences mocks base method.
+func (m *MockConfigurationContract) GetJwtAcceptedAudiences() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJwtAcceptedAudiences")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJwtAcceptedAudiences indicates an expected call of GetJwtAcceptedAudiences.
+func (mr *MockConfigurationContractMockRecorder) GetJwtAcceptedAudiences() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJwtAcceptedAudiences", reflect.TypeOf((*MockConfigurationContract)(nil).GetJwtAcceptedAudiences))
+}
+
+// GetJwtAcceptedIssuers mocks base method.
+func (m *MockConfigurationContract) GetJwtAcceptedIssuers() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJwtAcceptedIssuers")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJwtAcceptedIssuers indicates an expected call of GetJwtAcceptedIssuers.
+func (mr *MockConfigurationContractMockRecorder) GetJwtAcceptedIssuers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJwtAcceptedIssuers", reflect.TypeOf((*MockConfigurationContract)(nil).GetJwtAcceptedIssuers))
+}
+
+// GetJwtClaimMapping mocks base method.
+func (m *MockConfigurationContract) GetJwtClaimMapping() (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJwtClaimMapping")
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJwtClaimMapping indicates an expected call of GetJwtClaimMapping.
+func (mr *MockConfigurationContractMockRecorder) GetJwtClaimMapping() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJwtClaimMapping", reflect.TypeOf((*MockConfigurationContract)(nil).GetJwtClaimMapping))
+}
+
+// GetLockoutThreshold mocks base method.
+func (m *MockConfigurationContract) GetLockoutThreshold() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLockoutThreshold")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLockoutThreshold indicates an expected call of GetLockoutThreshold.
+func (mr *MockConfigurationContractMockRecorder) GetLockoutThreshold() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLockoutThreshold", reflect.TypeOf((*MockConfigurationContract)(nil).GetLockoutThreshold))
+}
+
+// GetLogFormat mocks base method.
+func (m *MockConfigurationContract) GetLogFormat() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogFormat")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogFormat indicates an expected call of GetLogFormat.
+func (mr *MockConfigurationContractMockRecorder) GetLogFormat() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogFormat", reflect.TypeOf((*MockConfigurationContract)(nil).GetLogFormat))
+}
+
+// GetLogLevel mocks base method.
+func (m *MockConfigurationContract) GetLogLevel() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogLevel")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogLevel indicates an expected call of GetLogLevel.
+func (mr *MockConfigurationContractMockRecorder) GetLogLevel() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogLevel", reflect.TypeOf((*MockConfigurationContract)(nil).GetLogLevel))
+}
+
+// GetLogLevelEndpointEnabled mocks base method.
+func (m *MockConfigurationContract) GetLogLevelEndpointEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogLevelEndpointEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogLevelEndpointEnabled indicates an expected call of GetLogLevelEndpointEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetLogLevelEndpointEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogLevelEndpointEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetLogLevelEndpointEnabled))
+}
+
+// GetMagicLinkCollectionName mocks base method.
+func (m *MockConfigurationContract) GetMagicLinkCollectionName() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMagicLinkCollectionName")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMagicLinkCollectionName indicates an expected call of GetMagicLinkCollectionName.
+func (mr *MockConfigurationContractMockRecorder) GetMagicLinkCollectionName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMagicLinkCollectionName", reflect.TypeOf((*MockConfigurationContract)(nil).GetMagicLinkCollectionName))
+}
+
+// GetMagicLinkDeviceBindingEnabled mocks base method.
+func (m *MockConfigurationContract) GetMagicLinkDeviceBindingEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMagicLinkDeviceBindingEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMagicLinkDeviceBindingEnabled indicates an expected call of GetMagicLinkDeviceBindingEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetMagicLinkDeviceBindingEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMagicLinkDeviceBindingEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetMagicLinkDeviceBindingEnabled))
+}
+
+// GetMagicLinkIPAddressBindingEnabled mocks base method.
+func (m *MockConfigurationContract) GetMagicLinkIPAddressBindingEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMagicLinkIPAddressBindingEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMagicLinkIPAddressBindingEnabled indicates an expected call of GetMagicLinkIPAddressBindingEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetMagicLinkIPAddressBindingEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMagicLinkIPAddressBindingEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetMagicLinkIPAddressBindingEnabled))
+}
+
+// GetMagicLinkTokenTTL mocks base method.
+func (m *MockConfigurationContract) GetMagicLinkTokenTTL() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMagicLinkTokenTTL")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMagicLinkTokenTTL indicates an expected call of GetMagicLinkTokenTTL.
+func (mr *MockConfigurationContractMockRecorder) GetMagicLinkTokenTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMagicLinkTokenTTL", reflect.TypeOf((*MockConfigurationContract)(nil).GetMagicLinkTokenTTL))
+}
+
+// GetMagicLinksEnabled mocks base method.
+func (m *MockConfigurationContract) GetMagicLinksEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMagicLinksEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMagicLinksEnabled indicates an expected call of GetMagicLinksEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetMagicLinksEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMagicLinksEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetMagicLinksEnabled))
+}
+
+// GetObjectStorageAccessKeyID mocks base method.
+func (m *MockConfigurationContract) GetObjectStorageAccessKeyID() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectStorageAccessKeyID")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectStorageAccessKeyID indicates an expected call of GetObjectStorageAccessKeyID.
+func (mr *MockConfigurationContractMockRecorder) GetObjectStorageAccessKeyID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectStorageAccessKeyID", reflect.TypeOf((*MockConfigurationContract)(nil).GetObjectStorageAccessKeyID))
+}
+
+// GetObjectStorageBucket mocks base method.
+func (m *MockConfigurationContract) GetObjectStorageBucket() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectStorageBucket")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectStorageBucket indicates an expected call of GetObjectStorageBucket.
+func (mr *MockConfigurationContractMockRecorder) GetObjectStorageBucket() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectStorageBucket", reflect.TypeOf((*MockConfigurationContract)(nil).GetObjectStorageBucket))
+}
+
+// GetObjectStorageEndpoint mocks base method.
+func (m *MockConfigurationContract) GetObjectStorageEndpoint() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectStorageEndpoint")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectStorageEndpoint indicates an expected call of GetObjectStorageEndpoint.
+func (mr *MockConfigurationContractMockRecorder) GetObjectStorageEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectStorageEndpoint", reflect.TypeOf((*MockConfigurationContract)(nil).GetObjectStorageEndpoint))
+}
+
+// GetObjectStoragePathStyleEnabled mocks base method.
+func (m *MockConfigurationContract) GetObjectStoragePathStyleEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectStoragePathStyleEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectStoragePathStyleEnabled indicates an expected call of GetObjectStoragePathStyleEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetObjectStoragePathStyleEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectStoragePathStyleEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetObjectStoragePathStyleEnabled))
+}
+
+// GetObjectStorageRegion mocks base method.
+func (m *MockConfigurationContract) GetObjectStorageRegion() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectStorageRegion")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectStorageRegion indicates an expected call of GetObjectStorageRegion.
+func (mr *MockConfigurationContractMockRecorder) GetObjectStorageRegion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectStorageRegion", reflect.TypeOf((*MockConfigurationContract)(nil).GetObjectStorageRegion))
+}
+
+// GetObjectStorageSecretAccessKey mocks base method.
+func (m *MockConfigurationContract) GetObjectStorageSecretAccessKey() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectStorageSecretAccessKey")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectStorageSecretAccessKey indicates an expected call of GetObjectStorageSecretAccessKey.
+func (mr *MockConfigurationContractMockRecorder) GetObjectStorageSecretAccessKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectStorageSecretAccessKey", reflect.TypeOf((*MockConfigurationContract)(nil).GetObjectStorageSecretAccessKey))
+}
+
+// GetPasswordCredentialsEnabled mocks base method.
+func (m *MockConfigurationContract) GetPasswordCredentialsEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordCredentialsEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordCredentialsEnabled indicates an expected call of GetPasswordCredentialsEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetPasswordCredentialsEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordCredentialsEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetPasswordCredentialsEnabled))
+}
+
+// GetPasswordHashingAlgorithm mocks base method.
+func (m *MockConfigurationContract) GetPasswordHashingAlgorithm() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordHashingAlgorithm")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordHashingAlgorithm indicates an expected call of GetPasswordHashingAlgorithm.
+func (mr *MockConfigurationContractMockRecorder) GetPasswordHashingAlgorithm() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordHashingAlgorithm", reflect.TypeOf((*MockConfigurationContract)(nil).GetPasswordHashingAlgorithm))
+}
+
+// GetPasswordMinLength mocks base method.
+func (m *MockConfigurationContract) GetPasswordMinLength() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordMinLength")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordMinLength indicates an expected call of GetPasswordMinLength.
+func (mr *MockConfigurationContractMockRecorder) GetPasswordMinLength() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordMinLength", reflect.TypeOf((*MockConfigurationContract)(nil).GetPasswordMinLength))
+}
+
+// GetPasswordRequiredCharacterClasses mocks base method.
+func (m *MockConfigurationContract) GetPasswordRequiredCharacterClasses() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordRequiredCharacterClasses")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordRequiredCharacterClasses indicates an expected call of GetPasswordRequiredCharacterClasses.
+func (mr *MockConfigurationContractMockRecorder) GetPasswordRequiredCharacterClasses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordRequiredCharacterClasses", reflect.TypeOf((*MockConfigurationContract)(nil).GetPasswordRequiredCharacterClasses))
+}
+
+// GetPayloadLoggingEnabled mocks base method.
+func (m *MockConfigurationContract) GetPayloadLoggingEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayloadLoggingEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayloadLoggingEnabled indicates an expected call of GetPayloadLoggingEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetPayloadLoggingEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayloadLoggingEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetPayloadLoggingEnabled))
+}
+
+// GetPayloadLoggingRedactedFields mocks base method.
+func (m *MockConfigurationContract) GetPayloadLoggingRedactedFields() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayloadLoggingRedactedFields")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayloadLoggingRedactedFields indicates an expected call of GetPayloadLoggingRedactedFields.
+func (mr *MockConfigurationContractMockRecorder) GetPayloadLoggingRedactedFields() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayloadLoggingRedactedFields", reflect.TypeOf((*MockConfigurationContract)(nil).GetPayloadLoggingRedactedFields))
+}
+
+// GetReadDeduplicationEnabled mocks base method.
+func (m *MockConfigurationContract) GetReadDeduplicationEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReadDeduplicationEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReadDeduplicationEnabled indicates an expected call of GetReadDeduplicationEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetReadDeduplicationEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReadDeduplicationEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetReadDeduplicationEnabled))
+}
+
+// GetRedactedUserFields mocks base method.
+func (m *MockConfigurationContract) GetRedactedUserFields() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedactedUserFields")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRedactedUserFields indicates an expected call of GetRedactedUserFields.
+func (mr *MockConfigurationContractMockRecorder) GetRedactedUserFields() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedactedUserFields", reflect.TypeOf((*MockConfigurationContract)(nil).GetRedactedUserFields))
+}
+
+// GetReplicationHeartbeatCollectionName mocks base method.
+func (m *MockConfigurationContract) GetReplicationHeartbeatCollectionName() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplicationHeartbeatCollectionName")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplicationHeartbeatCollectionName indicates an expected call of GetReplicationHeartbeatCollectionName.
+func (mr *MockConfigurationContractMockRecorder) GetReplicationHeartbeatCollectionName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicationHeartbeatCollectionName", reflect.TypeOf((*MockConfigurationContract)(nil).GetReplicationHeartbeatCollectionName))
+}
+
+// GetReplicationHeartbeatInterval mocks base method.
+func (m *MockConfigurationContract) GetReplicationHeartbeatInterval() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplicationHeartbeatInterval")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplicationHeartbeatInterval indicates an expected call of GetReplicationHeartbeatInterval.
+func (mr *MockConfigurationContractMockRecorder) GetReplicationHeartbeatInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicationHeartbeatInterval", reflect.TypeOf((*MockConfigurationContract)(nil).GetReplicationHeartbeatInterval))
+}
+
+// GetReplicationMaxLag mocks base method.
+func (m *MockConfigurationContract) GetReplicationMaxLag() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplicationMaxLag")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplicationMaxLag indicates an expected call of GetReplicationMaxLag.
+func (mr *MockConfigurationContractMockRecorder) GetReplicationMaxLag() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicationMaxLag", reflect.TypeOf((*MockConfigurationContract)(nil).GetReplicationMaxLag))
+}
+
+// GetReplicationStandbyConnectionString mocks base method.
+func (m *MockConfigurationContract) GetReplicationStandbyConnectionString() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplicationStandbyConnectionString")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplicationStandbyConnectionString indicates an expected call of GetReplicationStandbyConnectionString.
+func (mr *MockConfigurationContractMockRecorder) GetReplicationStandbyConnectionString() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicationStandbyConnectionString", reflect.TypeOf((*MockConfigurationContract)(nil).GetReplicationStandbyConnectionString))
+}
+
+// GetRepositoryType mocks base method.
+func (m *MockConfigurationContract) GetRepositoryType() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepositoryType")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepositoryType indicates an expected call of GetRepositoryType.
+func (mr *MockConfigurationContractMockRecorder) GetRepositoryType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepositoryType", reflect.TypeOf((*MockConfigurationContract)(nil).GetRepositoryType))
+}
+
+// GetSagaCollectionName mocks base method.
+func (m *MockConfigurationContract) GetSagaCollectionName() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSagaCollectionName")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSagaCollectionName indicates an expected call of GetSagaCollectionName.
+func (mr *MockConfigurationContractMockRecorder) GetSagaCollectionName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSagaCollectionName", reflect.TypeOf((*MockConfigurationContract)(nil).GetSagaCollectionName))
+}
+
+// GetSagaMaxAttempts mocks base method.
+func (m *MockConfigurationContract) GetSagaMaxAttempts() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSagaMaxAttempts")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSagaMaxAttempts indicates an expected call of GetSagaMaxAttempts.
+func (mr *MockConfigurationContractMockRecorder) GetSagaMaxAttempts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSagaMaxAttempts", reflect.TypeOf((*MockConfigurationContract)(nil).GetSagaMaxAttempts))
+}
+
+// GetSagaRetryBackoff mocks base method.
+func (m *MockConfigurationContract) GetSagaRetryBackoff() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSagaRetryBackoff")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSagaRetryBackoff indicates an expected call of GetSagaRetryBackoff.
+func (mr *MockConfigurationContractMockRecorder) GetSagaRetryBackoff() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSagaRetryBackoff", reflect.TypeOf((*MockConfigurationContract)(nil).GetSagaRetryBackoff))
+}
+
+// GetSecretsRefreshInterval mocks base method.
+func (m *MockConfigurationContract) GetSecretsRefreshInterval() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretsRefreshInterval")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretsRefreshInterval indicates an expected call of GetSecretsRefreshInterval.
+func (mr *MockConfigurationContractMockRecorder) GetSecretsRefreshInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretsRefreshInterval", reflect.TypeOf((*MockConfigurationContract)(nil).GetSecretsRefreshInterval))
+}
+
+// GetServiceIdentityAllowlist mocks base method.
+func (m *MockConfigurationContract) GetServiceIdentityAllowlist() (map[string][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceIdentityAllowlist")
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceIdentityAllowlist indicates an expected call of GetServiceIdentityAllowlist.
+func (mr *MockConfigurationContractMockRecorder) GetServiceIdentityAllowlist() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceIdentityAllowlist", reflect.TypeOf((*MockConfigurationContract)(nil).GetServiceIdentityAllowlist))
+}
+
+// GetSessionCollectionName mocks base method.
+func (m *MockConfigurationContract) GetSessionCollectionName() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionCollectionName")
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDatabaseCollectionName indicates an expected call of GetDatabaseCollectionName.
-func (mr *MockConfigurationContractMockRecorder) GetDatabaseCollectionName() *gomock.Call {
+// GetSessionCollectionName indicates an expected call of GetSessionCollectionName.
+func (mr *MockConfigurationContractMockRecorder) GetSessionCollectionName() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseCollectionName", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseCollectionName))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionCollectionName", reflect.TypeOf((*MockConfigurationContract)(nil).GetSessionCollectionName))
 }
 
-// GetDatabaseConnectionString mocks base method.
-func (m *MockConfigurationContract) GetDatabaseConnectionString() (string, error) {
+// GetSessionTTL mocks base method.
+func (m *MockConfigurationContract) GetSessionTTL() (time.Duration, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDatabaseConnectionString")
+	ret := m.ctrl.Call(m, "GetSessionTTL")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionTTL indicates an expected call of GetSessionTTL.
+func (mr *MockConfigurationContractMockRecorder) GetSessionTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionTTL", reflect.TypeOf((*MockConfigurationContract)(nil).GetSessionTTL))
+}
+
+// GetSloAvailabilityObjective mocks base method.
+func (m *MockConfigurationContract) GetSloAvailabilityObjective() (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSloAvailabilityObjective")
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSloAvailabilityObjective indicates an expected call of GetSloAvailabilityObjective.
+func (mr *MockConfigurationContractMockRecorder) GetSloAvailabilityObjective() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSloAvailabilityObjective", reflect.TypeOf((*MockConfigurationContract)(nil).GetSloAvailabilityObjective))
+}
+
+// GetSloLatencyObjective mocks base method.
+func (m *MockConfigurationContract) GetSloLatencyObjective() (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSloLatencyObjective")
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSloLatencyObjective indicates an expected call of GetSloLatencyObjective.
+func (mr *MockConfigurationContractMockRecorder) GetSloLatencyObjective() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSloLatencyObjective", reflect.TypeOf((*MockConfigurationContract)(nil).GetSloLatencyObjective))
+}
+
+// GetSloLatencyThreshold mocks base method.
+func (m *MockConfigurationContract) GetSloLatencyThreshold() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSloLatencyThreshold")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSloLatencyThreshold indicates an expected call of GetSloLatencyThreshold.
+func (mr *MockConfigurationContractMockRecorder) GetSloLatencyThreshold() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSloLatencyThreshold", reflect.TypeOf((*MockConfigurationContract)(nil).GetSloLatencyThreshold))
+}
+
+// GetSloWindow mocks base method.
+func (m *MockConfigurationContract) GetSloWindow() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSloWindow")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSloWindow indicates an expected call of GetSloWindow.
+func (mr *MockConfigurationContractMockRecorder) GetSloWindow() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSloWindow", reflect.TypeOf((*MockConfigurationContract)(nil).GetSloWindow))
+}
+
+// GetSmtpAddress mocks base method.
+func (m *MockConfigurationContract) GetSmtpAddress() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSmtpAddress")
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDatabaseConnectionString indicates an expected call of GetDatabaseConnectionString.
-func (mr *MockConfigurationContractMockRecorder) GetDatabaseConnectionString() *gomock.Call {
+// GetSmtpAddress indicates an expected call of GetSmtpAddress.
+func (mr *MockConfigurationContractMockRecorder) GetSmtpAddress() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseConnectionString", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseConnectionString))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmtpAddress", reflect.TypeOf((*MockConfigurationContract)(nil).GetSmtpAddress))
 }
 
-// GetDatabaseName mocks base method.
-func (m *MockConfigurationContract) GetDatabaseName() (string, error) {
+// GetSmtpFrom mocks base method.
+func (m *MockConfigurationContract) GetSmtpFrom() (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDatabaseName")
+	ret := m.ctrl.Call(m, "GetSmtpFrom")
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDatabaseName indicates an expected call of GetDatabaseName.
-func (mr *MockConfigurationContractMockRecorder) GetDatabaseName() *gomock.Call {
+// GetSmtpFrom indicates an expected call of GetSmtpFrom.
+func (mr *MockConfigurationContractMockRecorder) GetSmtpFrom() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseName", reflect.TypeOf((*MockConfigurationContract)(nil).GetDatabaseName))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmtpFrom", reflect.TypeOf((*MockConfigurationContract)(nil).GetSmtpFrom))
 }
 
-// GetGrpcHost mocks base method.
-func (m *MockConfigurationContract) GetGrpcHost() (string, error) {
+// GetSmtpPassword mocks base method.
+func (m *MockConfigurationContract) GetSmtpPassword() (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGrpcHost")
+	ret := m.ctrl.Call(m, "GetSmtpPassword")
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetGrpcHost indicates an expected call of GetGrpcHost.
-func (mr *MockConfigurationContractMockRecorder) GetGrpcHost() *gomock.Call {
+// GetSmtpPassword indicates an expected call of GetSmtpPassword.
+func (mr *MockConfigurationContractMockRecorder) GetSmtpPassword() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrpcHost", reflect.TypeOf((*MockConfigurationContract)(nil).GetGrpcHost))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmtpPassword", reflect.TypeOf((*MockConfigurationContract)(nil).GetSmtpPassword))
 }
 
-// GetGrpcPort mocks base method.
-func (m *MockConfigurationContract) GetGrpcPort() (int, error) {
+// GetSmtpUsername mocks base method.
+func (m *MockConfigurationContract) GetSmtpUsername() (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGrpcPort")
+	ret := m.ctrl.Call(m, "GetSmtpUsername")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSmtpUsername indicates an expected call of GetSmtpUsername.
+func (mr *MockConfigurationContractMockRecorder) GetSmtpUsername() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmtpUsername", reflect.TypeOf((*MockConfigurationContract)(nil).GetSmtpUsername))
+}
+
+// GetSoftDeleteEnabled mocks base method.
+func (m *MockConfigurationContract) GetSoftDeleteEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSoftDeleteEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSoftDeleteEnabled indicates an expected call of GetSoftDeleteEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetSoftDeleteEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSoftDeleteEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetSoftDeleteEnabled))
+}
+
+// GetSoftDeletePurgeInterval mocks base method.
+func (m *MockConfigurationContract) GetSoftDeletePurgeInterval() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSoftDeletePurgeInterval")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSoftDeletePurgeInterval indicates an expected call of GetSoftDeletePurgeInterval.
+func (mr *MockConfigurationContractMockRecorder) GetSoftDeletePurgeInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSoftDeletePurgeInterval", reflect.TypeOf((*MockConfigurationContract)(nil).GetSoftDeletePurgeInterval))
+}
+
+// GetSoftDeleteRetentionPeriod mocks base method.
+func (m *MockConfigurationContract) GetSoftDeleteRetentionPeriod() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSoftDeleteRetentionPeriod")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSoftDeleteRetentionPeriod indicates an expected call of GetSoftDeleteRetentionPeriod.
+func (mr *MockConfigurationContractMockRecorder) GetSoftDeleteRetentionPeriod() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSoftDeleteRetentionPeriod", reflect.TypeOf((*MockConfigurationContract)(nil).GetSoftDeleteRetentionPeriod))
+}
+
+// GetTenancyCrossTenantReadScope mocks base method.
+func (m *MockConfigurationContract) GetTenancyCrossTenantReadScope() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenancyCrossTenantReadScope")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenancyCrossTenantReadScope indicates an expected call of GetTenancyCrossTenantReadScope.
+func (mr *MockConfigurationContractMockRecorder) GetTenancyCrossTenantReadScope() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenancyCrossTenantReadScope", reflect.TypeOf((*MockConfigurationContract)(nil).GetTenancyCrossTenantReadScope))
+}
+
+// GetTenancyMode mocks base method.
+func (m *MockConfigurationContract) GetTenancyMode() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenancyMode")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenancyMode indicates an expected call of GetTenancyMode.
+func (mr *MockConfigurationContractMockRecorder) GetTenancyMode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenancyMode", reflect.TypeOf((*MockConfigurationContract)(nil).GetTenancyMode))
+}
+
+// GetTestDataPurgeEnabled mocks base method.
+func (m *MockConfigurationContract) GetTestDataPurgeEnabled() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTestDataPurgeEnabled")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTestDataPurgeEnabled indicates an expected call of GetTestDataPurgeEnabled.
+func (mr *MockConfigurationContractMockRecorder) GetTestDataPurgeEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTestDataPurgeEnabled", reflect.TypeOf((*MockConfigurationContract)(nil).GetTestDataPurgeEnabled))
+}
+
+// GetValidationPolicyAllowedEmailDomains mocks base method.
+func (m *MockConfigurationContract) GetValidationPolicyAllowedEmailDomains() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidationPolicyAllowedEmailDomains")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidationPolicyAllowedEmailDomains indicates an expected call of GetValidationPolicyAllowedEmailDomains.
+func (mr *MockConfigurationContractMockRecorder) GetValidationPolicyAllowedEmailDomains() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidationPolicyAllowedEmailDomains", reflect.TypeOf((*MockConfigurationContract)(nil).GetValidationPolicyAllowedEmailDomains))
+}
+
+// GetValidationPolicyFieldPatterns mocks base method.
+func (m *MockConfigurationContract) GetValidationPolicyFieldPatterns() (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidationPolicyFieldPatterns")
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidationPolicyFieldPatterns indicates an expected call of GetValidationPolicyFieldPatterns.
+func (mr *MockConfigurationContractMockRecorder) GetValidationPolicyFieldPatterns() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidationPolicyFieldPatterns", reflect.TypeOf((*MockConfigurationContract)(nil).GetValidationPolicyFieldPatterns))
+}
+
+// GetValidationPolicyRequiredFields mocks base method.
+func (m *MockConfigurationContract) GetValidationPolicyRequiredFields() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidationPolicyRequiredFields")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidationPolicyRequiredFields indicates an expected call of GetValidationPolicyRequiredFields.
+func (mr *MockConfigurationContractMockRecorder) GetValidationPolicyRequiredFields() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidationPolicyRequiredFields", reflect.TypeOf((*MockConfigurationContract)(nil).GetValidationPolicyRequiredFields))
+}
+
+// GetValidationRuleModes mocks base method.
+func (m *MockConfigurationContract) GetValidationRuleModes() (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidationRuleModes")
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidationRuleModes indicates an expected call of GetValidationRuleModes.
+func (mr *MockConfigurationContractMockRecorder) GetValidationRuleModes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidationRuleModes", reflect.TypeOf((*MockConfigurationContract)(nil).GetValidationRuleModes))
+}
+
+// GetVaultAddress mocks base method.
+func (m *MockConfigurationContract) GetVaultAddress() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaultAddress")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVaultAddress indicates an expected call of GetVaultAddress.
+func (mr *MockConfigurationContractMockRecorder) GetVaultAddress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaultAddress", reflect.TypeOf((*MockConfigurationContract)(nil).GetVaultAddress))
+}
+
+// GetVaultKubernetesAuthPath mocks base method.
+func (m *MockConfigurationContract) GetVaultKubernetesAuthPath() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaultKubernetesAuthPath")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVaultKubernetesAuthPath indicates an expected call of GetVaultKubernetesAuthPath.
+func (mr *MockConfigurationContractMockRecorder) GetVaultKubernetesAuthPath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaultKubernetesAuthPath", reflect.TypeOf((*MockConfigurationContract)(nil).GetVaultKubernetesAuthPath))
+}
+
+// GetVaultKubernetesRole mocks base method.
+func (m *MockConfigurationContract) GetVaultKubernetesRole() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaultKubernetesRole")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVaultKubernetesRole indicates an expected call of GetVaultKubernetesRole.
+func (mr *MockConfigurationContractMockRecorder) GetVaultKubernetesRole() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaultKubernetesRole", reflect.TypeOf((*MockConfigurationContract)(nil).GetVaultKubernetesRole))
+}
+
+// GetVaultToken mocks base method.
+func (m *MockConfigurationContract) GetVaultToken() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaultToken")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVaultToken indicates an expected call of GetVaultToken.
+func (mr *MockConfigurationContractMockRecorder) GetVaultToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaultToken", reflect.TypeOf((*MockConfigurationContract)(nil).GetVaultToken))
+}
+
+// GetWatchBufferSize mocks base method.
+func (m *MockConfigurationContract) GetWatchBufferSize() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchBufferSize")
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetGrpcPort indicates an expected call of GetGrpcPort.
-func (mr *MockConfigurationContractMockRecorder) GetGrpcPort() *gomock.Call {
+// GetWatchBufferSize indicates an expected call of GetWatchBufferSize.
+func (mr *MockConfigurationContractMockRecorder) GetWatchBufferSize() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrpcPort", reflect.TypeOf((*MockConfigurationContract)(nil).GetGrpcPort))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchBufferSize", reflect.TypeOf((*MockConfigurationContract)(nil).GetWatchBufferSize))
 }
 
-// GetHttpHost mocks base method.
-func (m *MockConfigurationContract) GetHttpHost() (string, error) {
+// GetWatchSource mocks base method.
+func (m *MockConfigurationContract) GetWatchSource() (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetHttpHost")
+	ret := m.ctrl.Call(m, "GetWatchSource")
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetHttpHost indicates an expected call of GetHttpHost.
-func (mr *MockConfigurationContractMockRecorder) GetHttpHost() *gomock.Call {
+// GetWatchSource indicates an expected call of GetWatchSource.
+func (mr *MockConfigurationContractMockRecorder) GetWatchSource() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHttpHost", reflect.TypeOf((*MockConfigurationContract)(nil).GetHttpHost))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchSource", reflect.TypeOf((*MockConfigurationContract)(nil).GetWatchSource))
 }
 
-// GetHttpPort mocks base method.
-func (m *MockConfigurationContract) GetHttpPort() (int, error) {
+// GetWebhookDeliveryCollectionName mocks base method.
+func (m *MockConfigurationContract) GetWebhookDeliveryCollectionName() (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetHttpPort")
+	ret := m.ctrl.Call(m, "GetWebhookDeliveryCollectionName")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveryCollectionName indicates an expected call of GetWebhookDeliveryCollectionName.
+func (mr *MockConfigurationContractMockRecorder) GetWebhookDeliveryCollectionName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveryCollectionName", reflect.TypeOf((*MockConfigurationContract)(nil).GetWebhookDeliveryCollectionName))
+}
+
+// GetWebhookDeliveryRetention mocks base method.
+func (m *MockConfigurationContract) GetWebhookDeliveryRetention() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveryRetention")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveryRetention indicates an expected call of GetWebhookDeliveryRetention.
+func (mr *MockConfigurationContractMockRecorder) GetWebhookDeliveryRetention() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveryRetention", reflect.TypeOf((*MockConfigurationContract)(nil).GetWebhookDeliveryRetention))
+}
+
+// GetWebhookMaxAttempts mocks base method.
+func (m *MockConfigurationContract) GetWebhookMaxAttempts() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookMaxAttempts")
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetHttpPort indicates an expected call of GetHttpPort.
-func (mr *MockConfigurationContractMockRecorder) GetHttpPort() *gomock.Call {
+// GetWebhookMaxAttempts indicates an expected call of GetWebhookMaxAttempts.
+func (mr *MockConfigurationContractMockRecorder) GetWebhookMaxAttempts() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHttpPort", reflect.TypeOf((*MockConfigurationContract)(nil).GetHttpPort))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookMaxAttempts", reflect.TypeOf((*MockConfigurationContract)(nil).GetWebhookMaxAttempts))
 }
 
-// GetJwksURL mocks base method.
-func (m *MockConfigurationContract) GetJwksURL() (string, error) {
+// GetWebhookPrivateNetworksAllowed mocks base method.
+func (m *MockConfigurationContract) GetWebhookPrivateNetworksAllowed() (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetJwksURL")
+	ret := m.ctrl.Call(m, "GetWebhookPrivateNetworksAllowed")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookPrivateNetworksAllowed indicates an expected call of GetWebhookPrivateNetworksAllowed.
+func (mr *MockConfigurationContractMockRecorder) GetWebhookPrivateNetworksAllowed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookPrivateNetworksAllowed", reflect.TypeOf((*MockConfigurationContract)(nil).GetWebhookPrivateNetworksAllowed))
+}
+
+// GetWebhookRetryBackoff mocks base method.
+func (m *MockConfigurationContract) GetWebhookRetryBackoff() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookRetryBackoff")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookRetryBackoff indicates an expected call of GetWebhookRetryBackoff.
+func (mr *MockConfigurationContractMockRecorder) GetWebhookRetryBackoff() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookRetryBackoff", reflect.TypeOf((*MockConfigurationContract)(nil).GetWebhookRetryBackoff))
+}
+
+// GetWebhookSubscriptionCollectionName mocks base method.
+func (m *MockConfigurationContract) GetWebhookSubscriptionCollectionName() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookSubscriptionCollectionName")
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetJwksURL indicates an expected call of GetJwksURL.
-func (mr *MockConfigurationContractMockRecorder) GetJwksURL() *gomock.Call {
+// GetWebhookSubscriptionCollectionName indicates an expected call of GetWebhookSubscriptionCollectionName.
+func (mr *MockConfigurationContractMockRecorder) GetWebhookSubscriptionCollectionName() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJwksURL", reflect.TypeOf((*MockConfigurationContract)(nil).GetJwksURL))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookSubscriptionCollectionName", reflect.TypeOf((*MockConfigurationContract)(nil).GetWebhookSubscriptionCollectionName))
+}
+
+// GetWebhookTimeout mocks base method.
+func (m *MockConfigurationContract) GetWebhookTimeout() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookTimeout indicates an expected call of GetWebhookTimeout.
+func (mr *MockConfigurationContractMockRecorder) GetWebhookTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookTimeout", reflect.TypeOf((*MockConfigurationContract)(nil).GetWebhookTimeout))
 }
@@ -0,0 +1,1056 @@
+// Package configuration implements configuration service required by the user service
+package configuration
+
+// Option describes a single configuration option exposed by the ConfigurationContract
+type Option struct {
+	// Getter is the name of the ConfigurationContract method that retrieves the option
+	Getter string
+
+	// Section groups the related options together
+	Section string
+
+	// EnvironmentVariable is the name of the environment variable the option is read from
+	EnvironmentVariable string
+
+	// Description explains what the option is used for and the accepted values
+	Description string
+
+	// Default is the value used when the environment variable is not set
+	Default string
+
+	// Required indicates the service fails to start if the option is not set
+	Required bool
+
+	// Secret indicates the value may contain credentials and must never be displayed
+	Secret bool
+}
+
+// Options returns the description of all the options exposed by the ConfigurationContract, in the order they are declared
+// Returns the list of the options
+func Options() []Option {
+	return []Option{
+		{
+			Getter:              "GetGrpcHost",
+			Section:             "gRPC",
+			EnvironmentVariable: "GRPC_HOST",
+			Description:         "The host name or IP address the gRPC server listens on. Listens on all the interfaces if empty",
+		},
+		{
+			Getter:              "GetGrpcPort",
+			Section:             "gRPC",
+			EnvironmentVariable: "GRPC_PORT",
+			Description:         "The port number the gRPC server listens on",
+			Required:            true,
+		},
+		{
+			Getter:              "GetGrpcShutdownTimeout",
+			Section:             "gRPC",
+			EnvironmentVariable: "GRPC_SHUTDOWN_TIMEOUT",
+			Description:         "The time the gRPC server waits for the in-flight calls to finish when stopping, e.g. 30s",
+			Default:             "30s",
+		},
+		{
+			Getter:              "GetGrpcShutdownDrainDelay",
+			Section:             "gRPC",
+			EnvironmentVariable: "GRPC_SHUTDOWN_DRAIN_DELAY",
+			Description:         "The time the gRPC server keeps accepting the calls when stopping after its health checks report it is not serving, so the load balancers stop routing the calls to it first. The in-flight calls are drained after it, e.g. 5s",
+			Default:             "5s",
+		},
+		{
+			Getter:              "GetGrpcReflectionEnabled",
+			Section:             "gRPC",
+			EnvironmentVariable: "GRPC_REFLECTION_ENABLED",
+			Description:         "Whether the gRPC server reflection is registered so tools such as grpcurl can discover the operations. Disable it in production to not advertise the admin operations",
+			Default:             "true",
+		},
+		{
+			Getter:              "GetGrpcStrictDecodingEnabled",
+			Section:             "gRPC",
+			EnvironmentVariable: "GRPC_STRICT_DECODING_ENABLED",
+			Description:         "Whether the UpdateUser requests carrying the fields this service does not know, e.g. sent by the clients built against a newer contract, are rejected with InvalidArgument instead of the fields being silently dropped",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetGrpcMaxReceiveMessageSize",
+			Section:             "gRPC",
+			EnvironmentVariable: "GRPC_MAX_RECEIVE_MESSAGE_SIZE",
+			Description:         "The maximum size in bytes of the messages the gRPC server receives, including the Connect requests served on the HTTP port. The larger requests fail with ResourceExhausted before they are decoded. It must be larger than USER_AVATAR_MAX_SIZE for the avatars to be uploaded",
+			Default:             "4194304",
+		},
+		{
+			Getter:              "GetEndpointDefaultTimeout",
+			Section:             "Endpoints",
+			EnvironmentVariable: "ENDPOINT_DEFAULT_TIMEOUT",
+			Description:         "The time the calls to the endpoints without a timeout in ENDPOINT_TIMEOUTS are cancelled after and fail with DeadlineExceeded, e.g. 10s. The streaming endpoints StreamSearch, ExportUsers and WatchUsers are not cancelled by it. The calls have no timeout if empty",
+		},
+		{
+			Getter:              "GetEndpointTimeouts",
+			Section:             "Endpoints",
+			EnvironmentVariable: "ENDPOINT_TIMEOUTS",
+			Description:         "Comma separated list of endpoint=timeout pairs setting the time the calls to the endpoint are cancelled after, overriding ENDPOINT_DEFAULT_TIMEOUT, e.g. ReadUser=2s,BulkUpdateUsers=5m",
+		},
+		{
+			Getter:              "GetHttpHost",
+			Section:             "HTTP",
+			EnvironmentVariable: "HTTP_HOST",
+			Description:         "The host name or IP address the HTTP server serving the health checks and the metrics listens on. Listens on all the interfaces if empty",
+		},
+		{
+			Getter:              "GetHttpPort",
+			Section:             "HTTP",
+			EnvironmentVariable: "HTTP_PORT",
+			Description:         "The port number the HTTP server serving the health checks and the metrics listens on",
+			Required:            true,
+		},
+		{
+			Getter:              "GetHttpProfilingEnabled",
+			Section:             "HTTP",
+			EnvironmentVariable: "HTTP_PROFILING_ENABLED",
+			Description:         "Whether the HTTP server serves the runtime profiles under /debug/pprof, e.g. for the support bundle to collect the goroutine and heap profiles",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetConnectEnabled",
+			Section:             "HTTP",
+			EnvironmentVariable: "CONNECT_ENABLED",
+			Description:         "Whether the HTTP server serves the unary gRPC operations over the Connect protocol under /user.Service/, e.g. for the web console to call the service directly. The calls go through the same authorization as the gRPC calls",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetCorsAllowedOrigins",
+			Section:             "HTTP",
+			EnvironmentVariable: "CORS_ALLOWED_ORIGINS",
+			Description:         "Comma separated list of the origins the browsers are allowed to call the HTTP endpoints, e.g. the health checks and the operations served over the Connect protocol, from, e.g. https://console.example.com. * allows every origin. Only the same origin calls are allowed if empty",
+		},
+		{
+			Getter:              "GetCorsAllowedMethods",
+			Section:             "HTTP",
+			EnvironmentVariable: "CORS_ALLOWED_METHODS",
+			Description:         "Comma separated list of the methods the browsers are allowed to call the HTTP endpoints with",
+			Default:             "GET,POST",
+		},
+		{
+			Getter:              "GetCorsAllowedHeaders",
+			Section:             "HTTP",
+			EnvironmentVariable: "CORS_ALLOWED_HEADERS",
+			Description:         "Comma separated list of the headers the browsers are allowed to send to the HTTP endpoints on top of Authorization, Content-Type, X-Api-Key, X-Request-ID, X-Client-Name and the Connect protocol headers, e.g. the headers the ingress requires",
+		},
+		{
+			Getter:              "GetCorsMaxAge",
+			Section:             "HTTP",
+			EnvironmentVariable: "CORS_MAX_AGE",
+			Description:         "How long the browsers cache the result of the CORS preflight requests, e.g. 10m",
+			Default:             "10m",
+		},
+		{
+			Getter:              "GetHstsMaxAge",
+			Section:             "HTTP",
+			EnvironmentVariable: "HSTS_MAX_AGE",
+			Description:         "How long the browsers only call the host of the HTTP endpoints over HTTPS, e.g. 8760h. The Strict-Transport-Security header is not sent if zero, e.g. when the ingress sets it or the endpoints are not served over HTTPS",
+			Default:             "0s",
+		},
+		{
+			Getter:              "GetHstsIncludeSubdomains",
+			Section:             "HTTP",
+			EnvironmentVariable: "HSTS_INCLUDE_SUBDOMAINS",
+			Description:         "Whether the Strict-Transport-Security header covers the subdomains of the host too",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetContentSecurityPolicy",
+			Section:             "HTTP",
+			EnvironmentVariable: "CONTENT_SECURITY_POLICY",
+			Description:         "The Content-Security-Policy the responses of the HTTP endpoints are sent with. off does not send the header, e.g. when the ingress sets it",
+			Default:             "default-src 'none'; frame-ancestors 'none'",
+		},
+		{
+			Getter:              "GetGraphQLHost",
+			Section:             "GraphQL",
+			EnvironmentVariable: "GRAPHQL_HOST",
+			Description:         "The host name or IP address the GraphQL server listens on. Listens on all the interfaces if empty",
+		},
+		{
+			Getter:              "GetGraphQLPort",
+			Section:             "GraphQL",
+			EnvironmentVariable: "GRAPHQL_PORT",
+			Description:         "The port number the GraphQL server listens on",
+			Required:            true,
+		},
+		{
+			Getter:              "GetDatabaseType",
+			Section:             "Database",
+			EnvironmentVariable: "DATABASE_TYPE",
+			Description:         "The database the users are stored in. One of: mongodb|postgres",
+			Default:             "mongodb",
+		},
+		{
+			Getter:              "GetRepositoryType",
+			Section:             "Database",
+			EnvironmentVariable: "REPOSITORY_TYPE",
+			Description:         "The repository the users are kept in. One of: mongodb|postgres|memory|cached. The database type is used if empty, cached keeps the users in that database behind the Redis cache, memory keeps them in the memory of the replica and loses them when it restarts",
+		},
+		{
+			Getter:              "GetDatabaseConnectionString",
+			Section:             "Database",
+			EnvironmentVariable: "DATABASE_CONNECTION_STRING",
+			Description:         "The database connection string, e.g. mongodb://mongodb:27017",
+			Required:            true,
+			Secret:              true,
+		},
+		{
+			Getter:              "GetDatabaseName",
+			Section:             "Database",
+			EnvironmentVariable: "USER_DATABASE_NAME",
+			Description:         "The MongoDB database name",
+			Required:            true,
+		},
+		{
+			Getter:              "GetDatabaseCollectionName",
+			Section:             "Database",
+			EnvironmentVariable: "USER_DATABASE_COLLECTION_NAME",
+			Description:         "The MongoDB collection or PostgreSQL table name the users are stored in",
+			Required:            true,
+		},
+		{
+			Getter:              "GetDatabaseSearchIndexHints",
+			Section:             "Database",
+			EnvironmentVariable: "USER_DATABASE_SEARCH_INDEX_HINTS",
+			Description:         "Comma separated list of shape=index pairs used as MongoDB search index hints, e.g. email=email_1",
+		},
+		{
+			Getter:              "GetDatabaseSearchSortableFields",
+			Section:             "Database",
+			EnvironmentVariable: "USER_DATABASE_SEARCH_SORTABLE_FIELDS",
+			Description:         "Comma separated list of the fields the search result can be sorted by, the fields the database has indexes for",
+			Default:             "email,createdAt",
+		},
+		{
+			Getter:              "GetDatabaseSearchQueryPlanStatisticsEnabled",
+			Section:             "Database",
+			EnvironmentVariable: "USER_DATABASE_SEARCH_QUERY_PLAN_STATISTICS",
+			Description:         "Whether the MongoDB query plan statistics of the searches are recorded as metrics",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetDatabaseStartupCheckEnabled",
+			Section:             "Database",
+			EnvironmentVariable: "USER_DATABASE_STARTUP_CHECK_ENABLED",
+			Description:         "Whether the service starts while MongoDB is unavailable and retries it in the background, reporting it is not ready until MongoDB is reached",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetDatabaseStartupCheckInitialBackoff",
+			Section:             "Database",
+			EnvironmentVariable: "USER_DATABASE_STARTUP_CHECK_INITIAL_BACKOFF",
+			Description:         "How long the startup check waits before retrying MongoDB for the first time, the wait doubles after every failed attempt",
+			Default:             "1s",
+		},
+		{
+			Getter:              "GetDatabaseStartupCheckMaxBackoff",
+			Section:             "Database",
+			EnvironmentVariable: "USER_DATABASE_STARTUP_CHECK_MAX_BACKOFF",
+			Description:         "The longest the startup check waits between two attempts to reach MongoDB",
+			Default:             "30s",
+		},
+		{
+			Getter:              "GetDatabaseReadPreference",
+			Section:             "Database",
+			EnvironmentVariable: "USER_DATABASE_READ_PREFERENCE",
+			Description:         "The MongoDB read preference of ReadUser, ReadUsers and the searches, one of primary, primaryPreferred, secondary, secondaryPreferred or nearest. The other operations always read from the primary. A read from a secondary only reflects the writes made through the same replica of the service",
+			Default:             "primary",
+		},
+		{
+			Getter:              "GetDatabaseReadConcern",
+			Section:             "Database",
+			EnvironmentVariable: "USER_DATABASE_READ_CONCERN",
+			Description:         "The MongoDB read concern level, one of local, available, majority or linearizable. The reads are only causally consistent with the writes if both concerns are majority",
+			Default:             "majority",
+		},
+		{
+			Getter:              "GetDatabaseWriteConcern",
+			Section:             "Database",
+			EnvironmentVariable: "USER_DATABASE_WRITE_CONCERN",
+			Description:         "The MongoDB write concern, majority or the number of the replica set members that must acknowledge the writes",
+			Default:             "majority",
+		},
+		{
+			Getter:              "GetDataResidencyDefaultRegion",
+			Section:             "Data Residency",
+			EnvironmentVariable: "USER_DATA_RESIDENCY_DEFAULT_REGION",
+			Description:         "The region the database set by DATABASE_CONNECTION_STRING is in, e.g. us. The users that do not ask for a specific region are persisted in this region",
+		},
+		{
+			Getter:              "GetDataResidencyRoutes",
+			Section:             "Data Residency",
+			EnvironmentVariable: "USER_DATA_RESIDENCY_ROUTES",
+			Description:         "Comma separated list of region=connectionString pairs of the databases the users residing in the other regions are persisted in, e.g. eu=mongodb://mongodb-eu:27017. The connection strings must not contain commas",
+			Secret:              true,
+		},
+		{
+			Getter:              "GetReplicationStandbyConnectionString",
+			Section:             "Replication",
+			EnvironmentVariable: "USER_REPLICATION_STANDBY_CONNECTION_STRING",
+			Description:         "The connection string of the standby database the primary database is replicated to. The replication lag is only reported if provided",
+			Secret:              true,
+		},
+		{
+			Getter:              "GetReplicationHeartbeatCollectionName",
+			Section:             "Replication",
+			EnvironmentVariable: "USER_REPLICATION_HEARTBEAT_COLLECTION_NAME",
+			Description:         "The MongoDB collection or PostgreSQL table name the replication heartbeats are written to",
+			Default:             "replication_heartbeat",
+		},
+		{
+			Getter:              "GetReplicationHeartbeatInterval",
+			Section:             "Replication",
+			EnvironmentVariable: "USER_REPLICATION_HEARTBEAT_INTERVAL",
+			Description:         "How often the replication heartbeat is written to the primary database and read from the standby database, e.g. 10s",
+			Default:             "10s",
+		},
+		{
+			Getter:              "GetReplicationMaxLag",
+			Section:             "Replication",
+			EnvironmentVariable: "USER_REPLICATION_MAX_LAG",
+			Description:         "How far behind the primary database the standby database can be while still being ready to fail over to, e.g. 1m",
+			Default:             "1m",
+		},
+		{
+			Getter:              "GetCacheEnabled",
+			Section:             "Cache",
+			EnvironmentVariable: "USER_CACHE_ENABLED",
+			Description:         "Whether the users read from the database are cached in Redis",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetCacheRedisAddress",
+			Section:             "Cache",
+			EnvironmentVariable: "USER_CACHE_REDIS_ADDRESS",
+			Description:         "The host:port address of the Redis server, required if the cache is enabled, e.g. redis:6379",
+		},
+		{
+			Getter:              "GetCacheRedisPassword",
+			Section:             "Cache",
+			EnvironmentVariable: "USER_CACHE_REDIS_PASSWORD",
+			Description:         "The password used to authenticate with the Redis server. No authentication is made if not provided",
+			Secret:              true,
+		},
+		{
+			Getter:              "GetCacheRedisDatabase",
+			Section:             "Cache",
+			EnvironmentVariable: "USER_CACHE_REDIS_DATABASE",
+			Description:         "The number of the Redis database the users are cached in",
+			Default:             "0",
+		},
+		{
+			Getter:              "GetCacheTTL",
+			Section:             "Cache",
+			EnvironmentVariable: "USER_CACHE_TTL",
+			Description:         "How long a cached user is kept before it is read from the database again, e.g. 5m",
+			Default:             "5m",
+		},
+		{
+			Getter:              "GetCacheKeyPrefix",
+			Section:             "Cache",
+			EnvironmentVariable: "USER_CACHE_KEY_PREFIX",
+			Description:         "The prefix of the Redis keys the users are cached under, so the Redis server can be shared",
+			Default:             "user:",
+		},
+		{
+			Getter:              "GetCacheLocalTTL",
+			Section:             "Cache",
+			EnvironmentVariable: "USER_CACHE_LOCAL_TTL",
+			Description:         "How long a user is kept in the memory of the replica in front of Redis, the replicas remove the changed users from their memory through Redis pub/sub. Zero keeps no user in memory",
+			Default:             "0s",
+		},
+		{
+			Getter:              "GetCacheLocalMaxEntries",
+			Section:             "Cache",
+			EnvironmentVariable: "USER_CACHE_LOCAL_MAX_ENTRIES",
+			Description:         "The maximum number of the users kept in the memory of the replica",
+			Default:             "10000",
+		},
+		{
+			Getter:              "GetReadDeduplicationEnabled",
+			Section:             "Cache",
+			EnvironmentVariable: "USER_READ_DEDUPLICATION_ENABLED",
+			Description:         "Whether the concurrent reads of the same user share a single database query instead of querying the database once per read",
+			Default:             "true",
+		},
+		{
+			Getter:              "GetEventingBroker",
+			Section:             "Eventing",
+			EnvironmentVariable: "EVENTING_BROKER",
+			Description:         "The message broker the user lifecycle events are published to. One of: none|nats",
+			Default:             "none",
+		},
+		{
+			Getter:              "GetEventingConnectionString",
+			Section:             "Eventing",
+			EnvironmentVariable: "EVENTING_CONNECTION_STRING",
+			Description:         "The message broker connection string, required unless the broker is none, e.g. nats://nats:4222",
+			Secret:              true,
+		},
+		{
+			Getter:              "GetEventingSubjectPrefix",
+			Section:             "Eventing",
+			EnvironmentVariable: "USER_EVENTING_SUBJECT_PREFIX",
+			Description:         "The prefix of the subjects the user lifecycle events are published to",
+			Default:             "user",
+		},
+		{
+			Getter:              "GetWatchBufferSize",
+			Section:             "Eventing",
+			EnvironmentVariable: "USER_WATCH_BUFFER_SIZE",
+			Description:         "How many user changes are buffered for a WatchUser or WatchUsers stream before the stream is ended for falling behind",
+			Default:             "64",
+		},
+		{
+			Getter:              "GetWatchSource",
+			Section:             "Eventing",
+			EnvironmentVariable: "USER_WATCH_SOURCE",
+			Description:         "Where the changes sent to the WatchUser and WatchUsers streams are read from. One of: events|change-stream. The change stream requires MongoDB and lets the clients resume a stream with the resume token of the last change they received",
+			Default:             "events",
+		},
+		{
+			Getter:              "GetEmailVerificationProvider",
+			Section:             "Email Verification",
+			EnvironmentVariable: "USER_EMAIL_VERIFICATION_PROVIDER",
+			Description:         "How the verification emails are sent, event publishes the token for another service to send the email through the eventing broker. One of: event|smtp",
+			Default:             "event",
+		},
+		{
+			Getter:              "GetEmailVerificationTokenTTL",
+			Section:             "Email Verification",
+			EnvironmentVariable: "USER_EMAIL_VERIFICATION_TOKEN_TTL",
+			Description:         "How long the token sent in a verification email is valid for, e.g. 24h",
+			Default:             "24h",
+		},
+		{
+			Getter:              "GetEmailVerificationURL",
+			Section:             "Email Verification",
+			EnvironmentVariable: "USER_EMAIL_VERIFICATION_URL",
+			Description:         "The URL of the page the users verify their email address on, required if the provider is smtp. The email and token query parameters are added to it, e.g. https://example.com/verify-email",
+		},
+		{
+			Getter:              "GetSmtpAddress",
+			Section:             "Email Verification",
+			EnvironmentVariable: "SMTP_ADDRESS",
+			Description:         "The host and port of the SMTP server the verification emails are sent through, required if the provider is smtp, e.g. smtp.example.com:587",
+		},
+		{
+			Getter:              "GetSmtpUsername",
+			Section:             "Email Verification",
+			EnvironmentVariable: "SMTP_USERNAME",
+			Description:         "The username used to authenticate with the SMTP server. The emails are sent without authentication if empty",
+		},
+		{
+			Getter:              "GetSmtpPassword",
+			Section:             "Email Verification",
+			EnvironmentVariable: "SMTP_PASSWORD",
+			Description:         "The password used to authenticate with the SMTP server",
+			Secret:              true,
+		},
+		{
+			Getter:              "GetSmtpFrom",
+			Section:             "Email Verification",
+			EnvironmentVariable: "SMTP_FROM",
+			Description:         "The email address the verification emails are sent from, required if the provider is smtp, e.g. no-reply@example.com",
+		},
+		{
+			Getter:              "GetAvatarsEnabled",
+			Section:             "Avatars",
+			EnvironmentVariable: "USER_AVATARS_ENABLED",
+			Description:         "Whether the users can upload an avatar image with SetUserAvatar and download it with GetUserAvatar, the images are stored in the object storage",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetAvatarMaxSize",
+			Section:             "Avatars",
+			EnvironmentVariable: "USER_AVATAR_MAX_SIZE",
+			Description:         "The maximum size of the avatar images in bytes",
+			Default:             "1048576",
+		},
+		{
+			Getter:              "GetAvatarAllowedContentTypes",
+			Section:             "Avatars",
+			EnvironmentVariable: "USER_AVATAR_ALLOWED_CONTENT_TYPES",
+			Description:         "Comma separated list of the MIME types the avatar images are accepted in, the content of the image must match the type",
+			Default:             "image/png,image/jpeg,image/webp",
+		},
+		{
+			Getter:              "GetAvatarURLExpiry",
+			Section:             "Avatars",
+			EnvironmentVariable: "USER_AVATAR_URL_EXPIRY",
+			Description:         "How long the presigned URLs the avatar images are downloaded from are valid for, at most 168h",
+			Default:             "15m",
+		},
+		{
+			Getter:              "GetObjectStorageEndpoint",
+			Section:             "Avatars",
+			EnvironmentVariable: "OBJECT_STORAGE_ENDPOINT",
+			Description:         "The URL of the S3 compatible object storage the avatar images are stored in, e.g. http://minio:9000. Amazon S3 is used if empty",
+		},
+		{
+			Getter:              "GetObjectStorageRegion",
+			Section:             "Avatars",
+			EnvironmentVariable: "OBJECT_STORAGE_REGION",
+			Description:         "The region of the object storage bucket",
+			Default:             "us-east-1",
+		},
+		{
+			Getter:              "GetObjectStorageBucket",
+			Section:             "Avatars",
+			EnvironmentVariable: "OBJECT_STORAGE_BUCKET",
+			Description:         "The name of the bucket the avatar images are stored in, required if the avatars are enabled",
+		},
+		{
+			Getter:              "GetObjectStorageAccessKeyID",
+			Section:             "Avatars",
+			EnvironmentVariable: "OBJECT_STORAGE_ACCESS_KEY_ID",
+			Description:         "The access key ID used to authenticate with the object storage, the default AWS credential chain is used if empty",
+		},
+		{
+			Getter:              "GetObjectStorageSecretAccessKey",
+			Section:             "Avatars",
+			EnvironmentVariable: "OBJECT_STORAGE_SECRET_ACCESS_KEY",
+			Description:         "The secret access key used to authenticate with the object storage",
+			Secret:              true,
+		},
+		{
+			Getter:              "GetObjectStoragePathStyleEnabled",
+			Section:             "Avatars",
+			EnvironmentVariable: "OBJECT_STORAGE_PATH_STYLE_ENABLED",
+			Description:         "Whether the bucket is addressed in the path rather than in the host name of the URLs, as MinIO requires",
+			Default:             "true",
+		},
+		{
+			Getter:              "GetSagaCollectionName",
+			Section:             "Saga",
+			EnvironmentVariable: "USER_SAGA_COLLECTION_NAME",
+			Description:         "The MongoDB collection or PostgreSQL table name the saga states are stored in",
+			Default:             "saga",
+		},
+		{
+			Getter:              "GetSagaMaxAttempts",
+			Section:             "Saga",
+			EnvironmentVariable: "SAGA_MAX_ATTEMPTS",
+			Description:         "The maximum number of attempts made for every saga step and compensation, must be at least 1",
+			Default:             "3",
+		},
+		{
+			Getter:              "GetSagaRetryBackoff",
+			Section:             "Saga",
+			EnvironmentVariable: "SAGA_RETRY_BACKOFF",
+			Description:         "The delay before the first retry of a failed saga step, doubled after every retry",
+			Default:             "100ms",
+		},
+		{
+			Getter:              "GetAuditCollectionName",
+			Section:             "Audit",
+			EnvironmentVariable: "USER_AUDIT_COLLECTION_NAME",
+			Description:         "The MongoDB collection or PostgreSQL table name the audit records of the mutating operations are stored in",
+			Default:             "audit",
+		},
+		{
+			Getter:              "GetPasswordCredentialsEnabled",
+			Section:             "Password Credentials",
+			EnvironmentVariable: "USER_PASSWORD_CREDENTIALS_ENABLED",
+			Description:         "Whether the users can set a password managed by the service, for the deployments that cannot rely only on an external identity provider",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetCredentialCollectionName",
+			Section:             "Password Credentials",
+			EnvironmentVariable: "USER_CREDENTIAL_COLLECTION_NAME",
+			Description:         "The MongoDB collection or PostgreSQL table name the password hashes are stored in, kept apart from the users so they are never read along with them",
+			Default:             "credentials",
+		},
+		{
+			Getter:              "GetPasswordHashingAlgorithm",
+			Section:             "Password Credentials",
+			EnvironmentVariable: "USER_PASSWORD_HASHING_ALGORITHM",
+			Description:         "The algorithm the new passwords are hashed with, the passwords hashed with the other one are rehashed when they are next verified. One of: argon2id|bcrypt|pbkdf2-sha256. The FIPS build only supports pbkdf2-sha256 and defaults to it",
+			Default:             "argon2id",
+		},
+		{
+			Getter:              "GetPasswordMinLength",
+			Section:             "Password Credentials",
+			EnvironmentVariable: "USER_PASSWORD_MIN_LENGTH",
+			Description:         "The minimum number of the characters a password must contain",
+			Default:             "12",
+		},
+		{
+			Getter:              "GetPasswordRequiredCharacterClasses",
+			Section:             "Password Credentials",
+			EnvironmentVariable: "USER_PASSWORD_REQUIRED_CHARACTER_CLASSES",
+			Description:         "How many of the lower case letters, upper case letters, digits and symbols a password must contain, between 0 and 4",
+			Default:             "3",
+		},
+		{
+			Getter:              "GetAPIKeyCollectionName",
+			Section:             "API Keys",
+			EnvironmentVariable: "USER_API_KEY_COLLECTION_NAME",
+			Description:         "The MongoDB collection or PostgreSQL table name the hashes of the API keys are stored in",
+			Default:             "api_keys",
+		},
+		{
+			Getter:              "GetAPIKeyMaxPerUser",
+			Section:             "API Keys",
+			EnvironmentVariable: "USER_API_KEY_MAX_PER_USER",
+			Description:         "The maximum number of the API keys a user can have that are neither revoked nor expired, at least 1",
+			Default:             "10",
+		},
+		{
+			Getter:              "GetConsentCollectionName",
+			Section:             "Consents",
+			EnvironmentVariable: "USER_CONSENT_COLLECTION_NAME",
+			Description:         "The MongoDB collection or PostgreSQL table name the consents of the users are stored in",
+			Default:             "consents",
+		},
+		{
+			Getter:              "GetConsentRequiredPolicies",
+			Section:             "Consents",
+			EnvironmentVariable: "CONSENT_REQUIRED_POLICIES",
+			Description:         "Comma separated policy=version pairs of the current versions of the policies the users must accept, e.g. terms-of-service=2021-01. No policy is required if not provided",
+		},
+		{
+			Getter:              "GetConsentRequiredOperations",
+			Section:             "Consents",
+			EnvironmentVariable: "CONSENT_REQUIRED_OPERATIONS",
+			Description:         "Comma separated operations that require the user to accept the required policies first, any of UpdateUser, UpdateUserPreferences, SetUserAvatar, CreateAPIKey, SetPassword, ChangePassword and EnrollMFA",
+		},
+		{
+			Getter:              "GetGroupCollectionName",
+			Section:             "Groups",
+			EnvironmentVariable: "USER_GROUP_COLLECTION_NAME",
+			Description:         "The MongoDB collection or PostgreSQL table name the groups and their members are stored in",
+			Default:             "groups",
+		},
+		{
+			Getter:              "GetInvitationCollectionName",
+			Section:             "Invitations",
+			EnvironmentVariable: "USER_INVITATION_COLLECTION_NAME",
+			Description:         "The MongoDB collection or PostgreSQL table name the invitations are stored in",
+			Default:             "invitations",
+		},
+		{
+			Getter:              "GetInvitationTokenTTL",
+			Section:             "Invitations",
+			EnvironmentVariable: "USER_INVITATION_TOKEN_TTL",
+			Description:         "How long the token sent in an invitation is valid for, e.g. 168h",
+			Default:             "168h",
+		},
+		{
+			Getter:              "GetInvitationURL",
+			Section:             "Invitations",
+			EnvironmentVariable: "USER_INVITATION_URL",
+			Description:         "The URL of the page the invited users accept their invitation on, required to send the invitations if the email verification provider is smtp. The email and token query parameters are added to it, e.g. https://example.com/accept-invitation",
+		},
+		{
+			Getter:              "GetSessionCollectionName",
+			Section:             "Sessions",
+			EnvironmentVariable: "USER_SESSION_COLLECTION_NAME",
+			Description:         "The MongoDB collection or PostgreSQL table name the sessions are stored in",
+			Default:             "sessions",
+		},
+		{
+			Getter:              "GetSessionTTL",
+			Section:             "Sessions",
+			EnvironmentVariable: "USER_SESSION_TTL",
+			Description:         "How long a session stays active after the auth gateway registers it the last time, e.g. 720h",
+			Default:             "720h",
+		},
+		{
+			Getter:              "GetWebhookSubscriptionCollectionName",
+			Section:             "Webhooks",
+			EnvironmentVariable: "USER_WEBHOOK_SUBSCRIPTION_COLLECTION_NAME",
+			Description:         "The MongoDB collection or PostgreSQL table name the webhook subscriptions are stored in",
+			Default:             "webhookSubscriptions",
+		},
+		{
+			Getter:              "GetWebhookDeliveryCollectionName",
+			Section:             "Webhooks",
+			EnvironmentVariable: "USER_WEBHOOK_DELIVERY_COLLECTION_NAME",
+			Description:         "The MongoDB collection or PostgreSQL table name the webhook deliveries are stored in",
+			Default:             "webhookDeliveries",
+		},
+		{
+			Getter:              "GetWebhookDeliveryRetention",
+			Section:             "Webhooks",
+			EnvironmentVariable: "USER_WEBHOOK_DELIVERY_RETENTION",
+			Description:         "How long the deliveries of the events to the webhooks are kept for, e.g. 168h",
+			Default:             "168h",
+		},
+		{
+			Getter:              "GetWebhookMaxAttempts",
+			Section:             "Webhooks",
+			EnvironmentVariable: "USER_WEBHOOK_MAX_ATTEMPTS",
+			Description:         "The maximum number of attempts made to call a webhook for every event, must be at least 1",
+			Default:             "5",
+		},
+		{
+			Getter:              "GetWebhookRetryBackoff",
+			Section:             "Webhooks",
+			EnvironmentVariable: "USER_WEBHOOK_RETRY_BACKOFF",
+			Description:         "The delay before the first retry of a failed webhook call, doubled after every retry",
+			Default:             "1s",
+		},
+		{
+			Getter:              "GetWebhookTimeout",
+			Section:             "Webhooks",
+			EnvironmentVariable: "USER_WEBHOOK_TIMEOUT",
+			Description:         "How long a single webhook call can take before it is considered failed",
+			Default:             "10s",
+		},
+		{
+			Getter:              "GetWebhookPrivateNetworksAllowed",
+			Section:             "Webhooks",
+			EnvironmentVariable: "USER_WEBHOOK_PRIVATE_NETWORKS_ALLOWED",
+			Description:         "Whether the webhooks can be called in the private, loopback and link-local networks. Keep it disabled in production, so the webhooks can not be used to reach the service itself or the other services in its network",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetLockoutThreshold",
+			Section:             "Account Lockout",
+			EnvironmentVariable: "USER_LOCKOUT_THRESHOLD",
+			Description:         "The number of the failed login attempts the users are locked after, the locked users stay locked until an admin unlocks them. Zero never locks the users",
+			Default:             "5",
+		},
+		{
+			Getter:              "GetBulkUpdateTokenSecret",
+			Section:             "Bulk Update",
+			EnvironmentVariable: "BULK_UPDATE_TOKEN_SECRET",
+			Description:         "The secret the bulk update confirmation tokens are signed with, shared by all the replicas. Bulk update is disabled if not provided",
+			Secret:              true,
+		},
+		{
+			Getter:              "GetBulkUpdatePreviewTTL",
+			Section:             "Bulk Update",
+			EnvironmentVariable: "BULK_UPDATE_PREVIEW_TTL",
+			Description:         "How long the confirmation token returned by a bulk update preview is valid for",
+			Default:             "10m",
+		},
+		{
+			Getter:              "GetBulkUpdateRateLimit",
+			Section:             "Bulk Update",
+			EnvironmentVariable: "BULK_UPDATE_RATE_LIMIT",
+			Description:         "The maximum number of users a bulk update updates per second, must be at least 1",
+			Default:             "10",
+		},
+		{
+			Getter:              "GetBulkUpdateMaxUsers",
+			Section:             "Bulk Update",
+			EnvironmentVariable: "BULK_UPDATE_MAX_USERS",
+			Description:         "The maximum number of users a single bulk update is allowed to update, must be at least 1",
+			Default:             "1000",
+		},
+		{
+			Getter:              "GetErasureTokenSecret",
+			Section:             "Erasure",
+			EnvironmentVariable: "ERASURE_TOKEN_SECRET",
+			Description:         "The secret the erasure confirmation tokens are signed with, shared by all the replicas. Erasing the users on their request is disabled if not provided",
+			Secret:              true,
+		},
+		{
+			Getter:              "GetErasureGracePeriod",
+			Section:             "Erasure",
+			EnvironmentVariable: "ERASURE_GRACE_PERIOD",
+			Description:         "How long after the erasure is requested it can be confirmed, so the users can change their mind. Zero allows confirming it right away",
+			Default:             "24h",
+		},
+		{
+			Getter:              "GetErasureConfirmationWindow",
+			Section:             "Erasure",
+			EnvironmentVariable: "ERASURE_CONFIRMATION_WINDOW",
+			Description:         "How long after the grace period ends the erasure can still be confirmed before it must be requested again",
+			Default:             "168h",
+		},
+		{
+			Getter:              "GetEmailChangeTokenSecret",
+			Section:             "Email Change",
+			EnvironmentVariable: "EMAIL_CHANGE_TOKEN_SECRET",
+			Description:         "The secret the email address change confirmation tokens are signed with, shared by all the replicas. If provided, the email address is only changed once the user confirms the change with the token sent to the new email address, and changing it right away is rejected",
+			Secret:              true,
+		},
+		{
+			Getter:              "GetEmailChangeTokenTTL",
+			Section:             "Email Change",
+			EnvironmentVariable: "EMAIL_CHANGE_TOKEN_TTL",
+			Description:         "How long the token sent to the new email address to confirm the email address change with is valid for, e.g. 24h",
+			Default:             "24h",
+		},
+		{
+			Getter:              "GetEmailChangeURL",
+			Section:             "Email Change",
+			EnvironmentVariable: "USER_EMAIL_CHANGE_URL",
+			Description:         "The URL of the page the users confirm their email address change on, required to confirm the changes if the email verification provider is smtp. The email and token query parameters are added to it, e.g. https://example.com/confirm-email-change",
+		},
+		{
+			Getter:              "GetSoftDeleteEnabled",
+			Section:             "Soft Delete",
+			EnvironmentVariable: "USER_SOFT_DELETE_ENABLED",
+			Description:         "Whether the deleted users are only marked as deleted so they can be restored until the retention period passes",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetSoftDeleteRetentionPeriod",
+			Section:             "Soft Delete",
+			EnvironmentVariable: "USER_SOFT_DELETE_RETENTION_PERIOD",
+			Description:         "How long the soft deleted users are kept before they are permanently deleted, e.g. 720h",
+			Default:             "720h",
+		},
+		{
+			Getter:              "GetSoftDeletePurgeInterval",
+			Section:             "Soft Delete",
+			EnvironmentVariable: "USER_SOFT_DELETE_PURGE_INTERVAL",
+			Description:         "How often the soft deleted users that passed the retention period are permanently deleted, e.g. 1h",
+			Default:             "1h",
+		},
+		{
+			Getter:              "GetTestDataPurgeEnabled",
+			Section:             "Test Data",
+			EnvironmentVariable: "TEST_DATA_PURGE_ENABLED",
+			Description:         "Whether the admins can permanently delete all the users with a test label using PurgeByLabel. Only enable it in the ephemeral test environments",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetMagicLinksEnabled",
+			Section:             "Magic Links",
+			EnvironmentVariable: "USER_MAGIC_LINKS_ENABLED",
+			Description:         "Whether the users can sign in with the single-use links sent to their email addresses instead of a password",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetMagicLinkCollectionName",
+			Section:             "Magic Links",
+			EnvironmentVariable: "USER_MAGIC_LINK_COLLECTION_NAME",
+			Description:         "The MongoDB collection or PostgreSQL table name the magic links are stored in",
+			Default:             "magic_links",
+		},
+		{
+			Getter:              "GetMagicLinkTokenTTL",
+			Section:             "Magic Links",
+			EnvironmentVariable: "USER_MAGIC_LINK_TOKEN_TTL",
+			Description:         "How long the token sent in a magic link is valid for, e.g. 15m",
+			Default:             "15m",
+		},
+		{
+			Getter:              "GetMagicLinkIPAddressBindingEnabled",
+			Section:             "Magic Links",
+			EnvironmentVariable: "USER_MAGIC_LINK_BIND_IP_ADDRESS",
+			Description:         "Whether a magic link can only be redeemed from the IP address it is requested from",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetMagicLinkDeviceBindingEnabled",
+			Section:             "Magic Links",
+			EnvironmentVariable: "USER_MAGIC_LINK_BIND_DEVICE",
+			Description:         "Whether a magic link can only be redeemed from the device it is requested from",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetSloAvailabilityObjective",
+			Section:             "SLO",
+			EnvironmentVariable: "SLO_AVAILABILITY_OBJECTIVE",
+			Description:         "The ratio of the calls that must not fail with a server error, between 0 and 1 exclusive",
+			Default:             "0.999",
+		},
+		{
+			Getter:              "GetSloLatencyObjective",
+			Section:             "SLO",
+			EnvironmentVariable: "SLO_LATENCY_OBJECTIVE",
+			Description:         "The ratio of the calls that must complete within SLO_LATENCY_THRESHOLD, between 0 and 1 exclusive",
+			Default:             "0.99",
+		},
+		{
+			Getter:              "GetSloLatencyThreshold",
+			Section:             "SLO",
+			EnvironmentVariable: "SLO_LATENCY_THRESHOLD",
+			Description:         "The duration a call must complete within to count towards the latency objective, e.g. 300ms",
+			Default:             "300ms",
+		},
+		{
+			Getter:              "GetSloWindow",
+			Section:             "SLO",
+			EnvironmentVariable: "SLO_WINDOW",
+			Description:         "The rolling window the error budgets are computed over, at least 1h, e.g. 720h",
+			Default:             "720h",
+		},
+		{
+			Getter:              "GetValidationRuleModes",
+			Section:             "Validation",
+			EnvironmentVariable: "VALIDATION_RULE_MODES",
+			Description:         "Comma separated list of rule=mode pairs setting how the validation rules being rolled out are applied, the mode is either warn, enforce or off and the rules not listed only warn",
+		},
+		{
+			Getter:              "GetValidationPolicyAllowedEmailDomains",
+			Section:             "Validation",
+			EnvironmentVariable: "VALIDATION_POLICY_ALLOWED_EMAIL_DOMAINS",
+			Description:         "Comma separated list of the domains the email addresses of the new users must belong to, any domain is allowed if empty",
+		},
+		{
+			Getter:              "GetValidationPolicyFieldPatterns",
+			Section:             "Validation",
+			EnvironmentVariable: "VALIDATION_POLICY_FIELD_PATTERNS",
+			Description:         "Comma separated list of field=pattern pairs setting the regular expressions the fields must match, the fields are email, dataResidency, labels.<key>, apiKeyName and mfaMethodName",
+		},
+		{
+			Getter:              "GetValidationPolicyRequiredFields",
+			Section:             "Validation",
+			EnvironmentVariable: "VALIDATION_POLICY_REQUIRED_FIELDS",
+			Description:         "Comma separated list of the fields the users must be created and updated with, the fields are dataResidency and labels.<key>",
+		},
+		{
+			Getter:              "GetAdminEmails",
+			Section:             "Security",
+			EnvironmentVariable: "ADMIN_EMAILS",
+			Description:         "Comma separated list of the email addresses of the users that are allowed to call the admin operations",
+		},
+		{
+			Getter:              "GetAdminGroups",
+			Section:             "Security",
+			EnvironmentVariable: "ADMIN_GROUPS",
+			Description:         "Comma separated list of the names of the groups whose members are allowed to call the admin operations",
+		},
+		{
+			Getter:              "GetJwksURL",
+			Section:             "Security",
+			EnvironmentVariable: "JWKS_URL",
+			Description:         "The URL of the JSON Web Key Set used to verify the access tokens",
+			Required:            true,
+		},
+		{
+			Getter:              "GetJwksRefreshInterval",
+			Section:             "Security",
+			EnvironmentVariable: "JWKS_REFRESH_INTERVAL",
+			Description:         "How often the cached JSON Web Key Set is refreshed, it is refreshed earlier when a token is signed with an unknown key, e.g. 15m",
+			Default:             "15m",
+		},
+		{
+			Getter:              "GetJwtAcceptedIssuers",
+			Section:             "Security",
+			EnvironmentVariable: "JWT_ACCEPTED_ISSUERS",
+			Description:         "Comma separated list of the issuers the access tokens are accepted from, the issuer is not checked if none is listed",
+		},
+		{
+			Getter:              "GetJwtAcceptedAudiences",
+			Section:             "Security",
+			EnvironmentVariable: "JWT_ACCEPTED_AUDIENCES",
+			Description:         "Comma separated list of the audiences the access tokens are accepted for, a token issued for any of them is accepted and the audience is not checked if none is listed",
+		},
+		{
+			Getter:              "GetJwtClaimMapping",
+			Section:             "Security",
+			EnvironmentVariable: "JWT_CLAIM_MAPPING",
+			Description:         "Comma separated list of field=claim pairs setting the claims of the access tokens the caller is read from, the field is either email, subject, service or tenant, e.g. email=preferred_username. The fields not listed are read from the email, sub, service and tenant_id claims",
+		},
+		{
+			Getter:              "GetServiceIdentityAllowlist",
+			Section:             "Security",
+			EnvironmentVariable: "SERVICE_IDENTITY_ALLOWLIST",
+			Description:         "Comma separated list of endpoint=services pairs setting the internal services allowed to call the endpoint with a client credential token carrying the service claim, the services are separated by +, e.g. ReadUser=tenant+edge-cluster. The service tokens are denied on the endpoints not listed",
+		},
+		{
+			Getter:              "GetTenancyMode",
+			Section:             "Security",
+			EnvironmentVariable: "TENANCY_MODE",
+			Description:         "Whether the users are shared by all the callers or every user belongs to the tenant read from the tenant claim of the access token of the caller that created it, and the callers only read and change the users of their tenant. One of: single|multi",
+			Default:             "single",
+		},
+		{
+			Getter:              "GetTenancyCrossTenantReadScope",
+			Section:             "Security",
+			EnvironmentVariable: "TENANCY_CROSS_TENANT_READ_SCOPE",
+			Description:         "The scope, read from the scope claim of the access tokens, allowing the caller to read the users of all the tenants in the multi-tenant mode. The users are still only changed within the tenant of the caller",
+			Default:             "users:read:all-tenants",
+		},
+		{
+			Getter:              "GetAuthorizationDecisionLoggingEnabled",
+			Section:             "Security",
+			EnvironmentVariable: "AUTHORIZATION_DECISION_LOGGING_ENABLED",
+			Description:         "Whether the checks that allowed or denied every call are logged, to find out which check caused a permission denied response",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetAuthorizationPolicyFile",
+			Section:             "Security",
+			EnvironmentVariable: "AUTHORIZATION_POLICY_FILE",
+			Description:         "The YAML file tailoring who may call every endpoint, listing the rule of the endpoints under endpoints, e.g. CreateUser: admin. The rule is one of: authenticated|owner|admin|owner-or-admin|deny, the endpoints not listed keep their built-in rule",
+		},
+		{
+			Getter:              "GetRedactedUserFields",
+			Section:             "Security",
+			EnvironmentVariable: "USER_REDACTED_USER_FIELDS",
+			Description:         "Comma separated list of the user fields, named as in the proto files, removed from the responses sent to the callers that are neither the owner of the user nor an admin, none turns the redaction off",
+			Default:             "memberships,emailVerified,locked,lockedAt,failedLoginAttempts,createdBy,updatedBy",
+		},
+		{
+			Getter:              "GetLogLevel",
+			Section:             "Logging",
+			EnvironmentVariable: "LOG_LEVEL",
+			Description:         "The minimum level of the logged messages. One of: debug|info|warn|error",
+			Default:             "info",
+		},
+		{
+			Getter:              "GetLogFormat",
+			Section:             "Logging",
+			EnvironmentVariable: "LOG_FORMAT",
+			Description:         "The format the log messages are written in, console is easier to read during development. One of: json|console",
+			Default:             "json",
+		},
+		{
+			Getter:              "GetLogLevelEndpointEnabled",
+			Section:             "Logging",
+			EnvironmentVariable: "LOG_LEVEL_ENDPOINT_ENABLED",
+			Description:         "Whether the HTTP server serves /debug/log-level, GET returns the current log level and PUT with {\"level\":\"debug\"} changes it until the service restarts. It is not authenticated like the runtime profiles, so only enable it if the HTTP port is not exposed publicly. SIGUSR1 switches between debug and LOG_LEVEL either way",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetPayloadLoggingEnabled",
+			Section:             "Logging",
+			EnvironmentVariable: "PAYLOAD_LOGGING_ENABLED",
+			Description:         "Whether the request and response payloads of the gRPC and Connect calls are logged. They are logged at the debug level, so LOG_LEVEL must be debug as well, and the values of PAYLOAD_LOGGING_REDACTED_FIELDS and every email address are replaced before they are logged",
+			Default:             "false",
+		},
+		{
+			Getter:              "GetPayloadLoggingRedactedFields",
+			Section:             "Logging",
+			EnvironmentVariable: "PAYLOAD_LOGGING_REDACTED_FIELDS",
+			Description:         "Comma separated list of the fields of any message, named as in the proto files, whose values are replaced in the logged payloads, none only replaces the email addresses",
+			Default:             "email,emails,actorEmail,failedEmails,createdBy,updatedBy,labels,password,currentPassword,newPassword,token,confirmationToken,totpSecretRef,recoveryCodeHashes,content,archive",
+		},
+		{
+			Getter:              "GetSecretsRefreshInterval",
+			Section:             "Secrets",
+			EnvironmentVariable: "SECRETS_REFRESH_INTERVAL",
+			Description:         "How often the secrets are read again. Every secret option can reference a secret instead of containing it, file:/path reads a file such as a mounted Kubernetes secret and vault:path#key reads the key of a HashiCorp Vault secret, e.g. vault:secret/data/user#connectionString. The secrets are read again in the background and the last value read is kept if reading the secret again fails. The database connections made after the connection string is rotated use the rotated one. TLS certificates and keys can not be referenced",
+			Default:             "5m",
+		},
+		{
+			Getter:              "GetVaultAddress",
+			Section:             "Secrets",
+			EnvironmentVariable: "VAULT_ADDRESS",
+			Description:         "The address of the HashiCorp Vault server the vault: references are read from, e.g. https://vault:8200",
+		},
+		{
+			Getter:              "GetVaultToken",
+			Section:             "Secrets",
+			EnvironmentVariable: "VAULT_TOKEN",
+			Description:         "The token the secrets are read from Vault with, file:/path reads it from a file such as the one the Vault agent renews. The service logs in with VAULT_KUBERNETES_ROLE if empty",
+			Secret:              true,
+		},
+		{
+			Getter:              "GetVaultKubernetesRole",
+			Section:             "Secrets",
+			EnvironmentVariable: "VAULT_KUBERNETES_ROLE",
+			Description:         "The Vault role the service logs in as with the token of its Kubernetes service account when VAULT_TOKEN is empty",
+		},
+		{
+			Getter:              "GetVaultKubernetesAuthPath",
+			Section:             "Secrets",
+			EnvironmentVariable: "VAULT_KUBERNETES_AUTH_PATH",
+			Description:         "The path the Kubernetes auth method is enabled at in Vault",
+			Default:             "kubernetes",
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package configuration_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestConfigurationService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Configuration Service Tests")
+}
+
+var _ = Describe("Configuration Options Tests", func() {
+	When("the options are listed", func() {
+		It("should describe every method of the ConfigurationContract exactly once", func() {
+			getters := map[string]int{}
+			for _, option := range configuration.Options() {
+				getters[option.Getter]++
+
+				Ω(option.EnvironmentVariable).ShouldNot(BeEmpty())
+				Ω(option.Description).ShouldNot(BeEmpty())
+				Ω(option.Required && option.Default != "").Should(BeFalse())
+			}
+
+			contractType := reflect.TypeOf((*configuration.ConfigurationContract)(nil)).Elem()
+			Ω(getters).Should(HaveLen(contractType.NumMethod()))
+
+			for index := 0; index < contractType.NumMethod(); index++ {
+				Ω(getters[contractType.Method(index).Name]).Should(Equal(1), contractType.Method(index).Name)
+			}
+		})
+	})
+})
@@ -0,0 +1,62 @@
+// Package postgres connects to the PostgreSQL databases the configuration points at
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Connect creates the connection pool of the database the connection string points at, connecting with the rotated
+// credentials once the secret the connection string is read from is rotated
+// ctx: Mandatory The reference to the context
+// connectionString: Mandatory. The connection string the pool is created with
+// getConnectionString: Mandatory. The function that reads the connection string again
+// Returns either the connection pool or error if something goes wrong
+func Connect(
+	ctx context.Context,
+	connectionString string,
+	getConnectionString func() (string, error)) (*pgxpool.Pool, error) {
+	config, err := NewPoolConfig(connectionString, getConnectionString)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgxpool.ConnectConfig(ctx, config)
+}
+
+// NewPoolConfig creates the configuration of the connection pool of the database the connection string points at.
+// The connection string is read again before every new connection of the pool is made and its user name and password
+// replace the ones the pool was created with, so the new connections are made with the rotated credentials. The
+// connections already made keep working until the pool replaces them once they reach their maximum lifetime.
+// connectionString: Mandatory. The connection string the pool is created with
+// getConnectionString: Mandatory. The function that reads the connection string again
+// Returns either the configuration or error if the connection string is invalid
+func NewPoolConfig(
+	connectionString string,
+	getConnectionString func() (string, error)) (*pgxpool.Config, error) {
+	config, err := pgxpool.ParseConfig(connectionString)
+	if err != nil {
+		return nil, err
+	}
+
+	config.BeforeConnect = func(_ context.Context, connConfig *pgx.ConnConfig) error {
+		connectionString, err := getConnectionString()
+		if err != nil {
+			return err
+		}
+
+		latestConfig, err := pgx.ParseConfig(connectionString)
+		if err != nil {
+			return err
+		}
+
+		connConfig.User = latestConfig.User
+		connConfig.Password = latestConfig.Password
+
+		return nil
+	}
+
+	return config, nil
+}
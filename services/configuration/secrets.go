@@ -0,0 +1,243 @@
+// Package configuration implements configuration service required by the user service
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+const (
+	// fileSecretReferencePrefix marks the secret read from a file, e.g. a mounted Kubernetes secret
+	fileSecretReferencePrefix = "file:"
+
+	// vaultSecretReferencePrefix marks the secret read from Vault, followed by the path of the secret and the key
+	// separated by #
+	vaultSecretReferencePrefix = "vault:"
+
+	// secretReadTimeout bounds reading a single secret, so an unreachable Vault does not hang reading the option
+	secretReadTimeout = 10 * time.Second
+)
+
+// resolvedSecret is the value last read for the reference of a secret option
+type resolvedSecret struct {
+	reference  string
+	value      string
+	readAt     time.Time
+	refreshing bool
+}
+
+// secretResolver replaces the references of the secret options with the secrets they reference, reading the secrets
+// again in the background once the refresh interval passes. Reading the options never waits for the secrets to be
+// read again, so a slow or unavailable Vault does not slow down the callers.
+type secretResolver struct {
+	lookup          func(variableName string) string
+	secretVariables map[string]bool
+	refreshInterval time.Duration
+	vaultClient     *vaultClient
+	now             func() time.Time
+
+	lock    sync.Mutex
+	secrets map[string]*resolvedSecret
+}
+
+// newEnvConfigurationService creates the configuration service that reads the options with the given function and
+// reads the secrets the secret options reference. Every referenced secret is read once, so a missing secret fails
+// creating the service instead of the first use of the option.
+// getVariable: Mandatory. The function that returns the value of the given option
+// Returns the new service or error if something goes wrong
+func newEnvConfigurationService(getVariable func(variableName string) string) (ConfigurationContract, error) {
+	resolver, err := newSecretResolver(&envConfigurationService{getVariable: getVariable})
+	if err != nil {
+		return nil, err
+	}
+
+	return &envConfigurationService{
+		getVariable: resolver.getVariable,
+	}, nil
+}
+
+// newSecretResolver creates the resolver reading its own options from the given configuration service, the options
+// of the resolver can only reference the files
+// unresolvedService: Mandatory. The configuration service reading the references of the secret options as they are
+// Returns the new resolver or error if any option of the resolver is invalid or any referenced secret can not be read
+func newSecretResolver(unresolvedService *envConfigurationService) (*secretResolver, error) {
+	refreshInterval, err := unresolvedService.GetSecretsRefreshInterval()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultAddress, err := unresolvedService.GetVaultAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := &secretResolver{
+		lookup:          unresolvedService.getVariable,
+		secretVariables: map[string]bool{},
+		refreshInterval: refreshInterval,
+		now:             time.Now,
+		secrets:         map[string]*resolvedSecret{},
+	}
+
+	if vaultAddress != "" {
+		vaultToken, _ := unresolvedService.GetVaultToken()
+		kubernetesRole, _ := unresolvedService.GetVaultKubernetesRole()
+		kubernetesAuthPath, _ := unresolvedService.GetVaultKubernetesAuthPath()
+
+		if vaultToken == "" && kubernetesRole == "" {
+			return nil, commonErrors.NewUnknownError("either VAULT_TOKEN or VAULT_KUBERNETES_ROLE is required when VAULT_ADDRESS is provided")
+		}
+
+		resolver.vaultClient = newVaultClient(vaultAddress, vaultToken, kubernetesRole, kubernetesAuthPath)
+	}
+
+	for _, option := range Options() {
+		// The token Vault is read with can not be read from Vault, it is read by the Vault client itself
+		if option.Secret && option.EnvironmentVariable != "VAULT_TOKEN" {
+			resolver.secretVariables[option.EnvironmentVariable] = true
+		}
+	}
+
+	for variableName := range resolver.secretVariables {
+		if reference := resolver.lookup(variableName); isSecretReference(reference) {
+			if _, err := resolver.resolve(variableName, reference); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return resolver, nil
+}
+
+// getVariable returns the value of the given option, replacing the reference of a secret option with the secret. The
+// value last read is returned while the secret is read again in the background once the refresh interval passes, and
+// kept if reading the secret again fails. The secret is only read right away if it was never read for the reference,
+// the option is empty if reading it fails.
+// variableName: Mandatory. The name of the option
+// Returns the value of the option
+func (resolver *secretResolver) getVariable(variableName string) string {
+	value := resolver.lookup(variableName)
+	if !resolver.secretVariables[variableName] || !isSecretReference(value) {
+		return value
+	}
+
+	resolver.lock.Lock()
+	secret, found := resolver.secrets[variableName]
+	if !found || secret.reference != value {
+		resolver.lock.Unlock()
+
+		readValue, _ := resolver.resolve(variableName, value)
+
+		return readValue
+	}
+
+	if !secret.refreshing && resolver.now().Sub(secret.readAt) >= resolver.refreshInterval {
+		secret.refreshing = true
+
+		go resolver.refresh(variableName, secret.reference)
+	}
+
+	cachedValue := secret.value
+	resolver.lock.Unlock()
+
+	return cachedValue
+}
+
+// resolve reads the secret the reference of the given option points at and keeps it for the reference
+// variableName: Mandatory. The name of the option
+// reference: Mandatory. The reference of the option
+// Returns either the secret or error if the secret can not be read
+func (resolver *secretResolver) resolve(variableName string, reference string) (string, error) {
+	readValue, err := resolver.read(reference)
+	if err != nil {
+		return "", commonErrors.NewUnknownErrorWithError(fmt.Sprintf("failed to read the secret %s references", variableName), err)
+	}
+
+	resolver.lock.Lock()
+	defer resolver.lock.Unlock()
+
+	resolver.secrets[variableName] = &resolvedSecret{
+		reference: reference,
+		value:     readValue,
+		readAt:    resolver.now(),
+	}
+
+	return readValue, nil
+}
+
+// refresh reads the secret of the given option again and replaces the value last read with it, unless the reference
+// of the option changed meanwhile. The value last read is kept if the secret can not be read, and the secret is not
+// read again before the refresh interval passes, so an unavailable Vault is not called on every read of the option.
+// variableName: Mandatory. The name of the option
+// reference: Mandatory. The reference the value last read was read for
+func (resolver *secretResolver) refresh(variableName string, reference string) {
+	readValue, err := resolver.read(reference)
+
+	resolver.lock.Lock()
+	defer resolver.lock.Unlock()
+
+	secret, found := resolver.secrets[variableName]
+	if !found || secret.reference != reference {
+		return
+	}
+
+	secret.refreshing = false
+	secret.readAt = resolver.now()
+
+	if err == nil {
+		secret.value = readValue
+	}
+}
+
+// read reads the secret the given reference points at
+// reference: Mandatory. The file: or vault: reference of the secret
+// Returns either the secret or error if the secret can not be read
+func (resolver *secretResolver) read(reference string) (string, error) {
+	if strings.HasPrefix(reference, fileSecretReferencePrefix) {
+		return readSecretFile(strings.TrimPrefix(reference, fileSecretReferencePrefix))
+	}
+
+	if resolver.vaultClient == nil {
+		return "", fmt.Errorf("VAULT_ADDRESS is required to read %s", reference)
+	}
+
+	path, key, found := cutSecretReference(strings.TrimPrefix(reference, vaultSecretReferencePrefix))
+	if !found {
+		return "", fmt.Errorf("the Vault reference must be in the form vault:path#key: %s", reference)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretReadTimeout)
+	defer cancel()
+
+	return resolver.vaultClient.read(ctx, path, key)
+}
+
+func isSecretReference(value string) bool {
+	return strings.HasPrefix(value, fileSecretReferencePrefix) || strings.HasPrefix(value, vaultSecretReferencePrefix)
+}
+
+// readSecretFile reads the secret from the given file. The trailing line break is removed, the secret files created
+// from the files usually end with one.
+func readSecretFile(path string) (string, error) {
+	content, err := ioutil.ReadFile(strings.Trim(path, " "))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+func cutSecretReference(reference string) (string, string, bool) {
+	index := strings.LastIndex(reference, "#")
+	if index <= 0 || index == len(reference)-1 {
+		return "", "", false
+	}
+
+	return strings.Trim(reference[:index], "/ "), reference[index+1:], true
+}
@@ -0,0 +1,255 @@
+package configuration_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Configuration Secrets Tests", func() {
+	var (
+		directory string
+	)
+
+	BeforeEach(func() {
+		var err error
+		directory, err = ioutil.TempDir("", "secrets")
+		Ω(err).Should(BeNil())
+
+		for _, option := range configuration.Options() {
+			os.Unsetenv(option.EnvironmentVariable)
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+
+		for _, option := range configuration.Options() {
+			os.Unsetenv(option.EnvironmentVariable)
+		}
+	})
+
+	writeSecret := func(name string, content string) string {
+		path := filepath.Join(directory, name)
+		Ω(ioutil.WriteFile(path, []byte(content), 0600)).Should(Succeed())
+
+		return path
+	}
+
+	Context("the secret option references a file", func() {
+		When("the file exists", func() {
+			It("should return the content of the file without the trailing line break", func() {
+				os.Setenv("DATABASE_CONNECTION_STRING", "file:"+writeSecret("connection-string", "mongodb://mongodb:27017\n"))
+
+				service, err := configuration.NewEnvConfigurationService()
+				Ω(err).Should(BeNil())
+
+				connectionString, err := service.GetDatabaseConnectionString()
+				Ω(err).Should(BeNil())
+				Ω(connectionString).Should(Equal("mongodb://mongodb:27017"))
+			})
+		})
+
+		When("the file changes", func() {
+			It("should return the new content once the refresh interval passes", func() {
+				path := writeSecret("connection-string", "mongodb://old:27017")
+				os.Setenv("DATABASE_CONNECTION_STRING", "file:"+path)
+				os.Setenv("SECRETS_REFRESH_INTERVAL", "50ms")
+
+				service, err := configuration.NewEnvConfigurationService()
+				Ω(err).Should(BeNil())
+
+				writeSecret("connection-string", "mongodb://new:27017")
+
+				connectionString, _ := service.GetDatabaseConnectionString()
+				Ω(connectionString).Should(Equal("mongodb://old:27017"))
+
+				Eventually(func() string {
+					connectionString, _ := service.GetDatabaseConnectionString()
+
+					return connectionString
+				}, time.Second, 10*time.Millisecond).Should(Equal("mongodb://new:27017"))
+			})
+		})
+
+		When("the file is removed after it is read", func() {
+			It("should keep returning the value read last", func() {
+				path := writeSecret("connection-string", "mongodb://mongodb:27017")
+				os.Setenv("DATABASE_CONNECTION_STRING", "file:"+path)
+				os.Setenv("SECRETS_REFRESH_INTERVAL", "1ms")
+
+				service, err := configuration.NewEnvConfigurationService()
+				Ω(err).Should(BeNil())
+
+				Ω(os.Remove(path)).Should(Succeed())
+				time.Sleep(5 * time.Millisecond)
+
+				connectionString, err := service.GetDatabaseConnectionString()
+				Ω(err).Should(BeNil())
+				Ω(connectionString).Should(Equal("mongodb://mongodb:27017"))
+			})
+		})
+
+		When("the file does not exist", func() {
+			It("should fail creating the service", func() {
+				os.Setenv("DATABASE_CONNECTION_STRING", "file:"+filepath.Join(directory, "missing"))
+
+				service, err := configuration.NewEnvConfigurationService()
+				Ω(service).Should(BeNil())
+				Ω(err).ShouldNot(BeNil())
+				Ω(err.Error()).Should(ContainSubstring("DATABASE_CONNECTION_STRING"))
+			})
+		})
+	})
+
+	Context("the option that is not secret looks like a reference", func() {
+		It("should return the value as it is", func() {
+			os.Setenv("USER_DATABASE_NAME", "file:user")
+
+			service, err := configuration.NewEnvConfigurationService()
+			Ω(err).Should(BeNil())
+
+			databaseName, err := service.GetDatabaseName()
+			Ω(err).Should(BeNil())
+			Ω(databaseName).Should(Equal("file:user"))
+		})
+	})
+
+	Context("the secret option references Vault", func() {
+		var (
+			vaultServer *httptest.Server
+			tokens      []string
+		)
+
+		BeforeEach(func() {
+			tokens = []string{}
+			vaultServer = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				tokens = append(tokens, request.Header.Get("X-Vault-Token"))
+
+				switch request.URL.Path {
+				case "/v1/secret/data/user":
+					_ = json.NewEncoder(writer).Encode(map[string]interface{}{
+						"data": map[string]interface{}{
+							"data":     map[string]interface{}{"connectionString": "postgres://postgres:5432/user"},
+							"metadata": map[string]interface{}{"version": 3},
+						},
+					})
+				case "/v1/kv/user":
+					_ = json.NewEncoder(writer).Encode(map[string]interface{}{
+						"data": map[string]interface{}{"smtpPassword": "secret"},
+					})
+				default:
+					writer.WriteHeader(http.StatusNotFound)
+				}
+			}))
+
+			os.Setenv("VAULT_ADDRESS", vaultServer.URL)
+		})
+
+		AfterEach(func() {
+			vaultServer.Close()
+		})
+
+		When("the secret is in the versioned KV secrets engine", func() {
+			It("should return the key of the latest version", func() {
+				os.Setenv("VAULT_TOKEN", "root-token")
+				os.Setenv("DATABASE_CONNECTION_STRING", "vault:secret/data/user#connectionString")
+
+				service, err := configuration.NewEnvConfigurationService()
+				Ω(err).Should(BeNil())
+
+				connectionString, err := service.GetDatabaseConnectionString()
+				Ω(err).Should(BeNil())
+				Ω(connectionString).Should(Equal("postgres://postgres:5432/user"))
+				Ω(tokens).Should(ConsistOf("root-token"))
+			})
+		})
+
+		When("the secret is in the unversioned KV secrets engine and the token is read from a file", func() {
+			It("should return the key of the secret", func() {
+				os.Setenv("VAULT_TOKEN", "file:"+writeSecret("vault-token", "agent-token\n"))
+				os.Setenv("SMTP_PASSWORD", "vault:kv/user#smtpPassword")
+
+				service, err := configuration.NewEnvConfigurationService()
+				Ω(err).Should(BeNil())
+
+				password, err := service.GetSmtpPassword()
+				Ω(err).Should(BeNil())
+				Ω(password).Should(Equal("secret"))
+				Ω(tokens).Should(ConsistOf("agent-token"))
+			})
+		})
+
+		When("Vault is slow to respond once the refresh interval passes", func() {
+			It("should keep returning the value read last without waiting for Vault", func() {
+				release := make(chan struct{})
+				var reads int32
+				slowVaultServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+					if atomic.AddInt32(&reads, 1) > 1 {
+						<-release
+					}
+
+					_ = json.NewEncoder(writer).Encode(map[string]interface{}{
+						"data": map[string]interface{}{"connectionString": "postgres://postgres:5432/user"},
+					})
+				}))
+				defer slowVaultServer.Close()
+				defer close(release)
+
+				os.Setenv("VAULT_ADDRESS", slowVaultServer.URL)
+				os.Setenv("VAULT_TOKEN", "root-token")
+				os.Setenv("DATABASE_CONNECTION_STRING", "vault:kv/user#connectionString")
+				os.Setenv("SECRETS_REFRESH_INTERVAL", "1ms")
+
+				service, err := configuration.NewEnvConfigurationService()
+				Ω(err).Should(BeNil())
+
+				time.Sleep(5 * time.Millisecond)
+
+				for index := 0; index < 3; index++ {
+					started := time.Now()
+					connectionString, err := service.GetDatabaseConnectionString()
+					Ω(err).Should(BeNil())
+					Ω(connectionString).Should(Equal("postgres://postgres:5432/user"))
+					Ω(time.Since(started)).Should(BeNumerically("<", time.Second))
+				}
+
+				Eventually(func() int32 {
+					return atomic.LoadInt32(&reads)
+				}).Should(Equal(int32(2)))
+				Consistently(func() int32 {
+					return atomic.LoadInt32(&reads)
+				}, 50*time.Millisecond).Should(Equal(int32(2)))
+			})
+		})
+
+		When("the key does not exist", func() {
+			It("should fail creating the service", func() {
+				os.Setenv("VAULT_TOKEN", "root-token")
+				os.Setenv("DATABASE_CONNECTION_STRING", "vault:secret/data/user#password")
+
+				service, err := configuration.NewEnvConfigurationService()
+				Ω(service).Should(BeNil())
+				Ω(err).ShouldNot(BeNil())
+			})
+		})
+
+		When("neither the token nor the Kubernetes role is provided", func() {
+			It("should fail creating the service", func() {
+				service, err := configuration.NewEnvConfigurationService()
+				Ω(service).Should(BeNil())
+				Ω(err).ShouldNot(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,127 @@
+// Package configuration implements configuration service required by the user service
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	// ValidationKindOption indicates the result is about the value of a configuration option
+	ValidationKindOption = "option"
+
+	// ValidationKindReachability indicates the result is about a dependency the configuration points at
+	ValidationKindReachability = "reachability"
+
+	// ValidationStatusOK indicates the option or the dependency is valid
+	ValidationStatusOK = "ok"
+
+	// ValidationStatusFailed indicates the option or the dependency is invalid
+	ValidationStatusFailed = "failed"
+
+	// validationCheckTimeout bounds every reachability check, so an unreachable dependency does not hang the validation
+	validationCheckTimeout = 10 * time.Second
+)
+
+// ValidationCheck verifies a dependency the configuration points at can be reached, e.g. the database
+type ValidationCheck struct {
+	// Name describes the dependency that is checked
+	Name string
+
+	// Check returns error if the dependency can not be reached
+	Check func(ctx context.Context) error
+}
+
+// ValidationResult is the outcome of validating a single option or dependency
+type ValidationResult struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// ValidationReport contains every problem found in the configuration along with the outcome of every reachability
+// check, so all of them can be fixed at once
+type ValidationReport struct {
+	OptionsChecked int                `json:"optionsChecked"`
+	Failures       int                `json:"failures"`
+	Results        []ValidationResult `json:"results"`
+}
+
+// Err returns the error summarizing the failures of the report
+// Returns error listing every failure or nil if nothing failed
+func (report ValidationReport) Err() error {
+	if report.Failures == 0 {
+		return nil
+	}
+
+	failures := make([]string, 0, report.Failures)
+	for _, result := range report.Results {
+		if result.Status == ValidationStatusFailed {
+			failures = append(failures, fmt.Sprintf("%s: %s", result.Name, result.Message))
+		}
+	}
+
+	return fmt.Errorf("the configuration is invalid: %s", strings.Join(failures, "; "))
+}
+
+// Validate reads every option the way the service parses it and runs the given reachability checks, collecting all the
+// failures instead of stopping at the first one. Only the invalid options are listed in the report.
+// ctx: Mandatory The reference to the context
+// configurationService: Mandatory. Reference to the service the options are read from
+// checks: Optional. The reachability checks to run, each of them is given validationCheckTimeout to finish
+// Returns the report of the validation
+func Validate(ctx context.Context, configurationService ConfigurationContract, checks ...ValidationCheck) ValidationReport {
+	configurationValue := reflect.ValueOf(configurationService)
+	report := ValidationReport{Results: []ValidationResult{}}
+
+	for _, option := range Options() {
+		report.OptionsChecked++
+
+		value, err := readOption(configurationValue, option)
+		switch {
+		case err != nil:
+			report.add(option.EnvironmentVariable, ValidationKindOption, err)
+		case option.Required && strings.Trim(value, " ") == "":
+			report.add(option.EnvironmentVariable, ValidationKindOption, fmt.Errorf("%s is required", option.EnvironmentVariable))
+		}
+	}
+
+	for _, check := range checks {
+		report.add(check.Name, ValidationKindReachability, runValidationCheck(ctx, check))
+	}
+
+	return report
+}
+
+func runValidationCheck(ctx context.Context, check ValidationCheck) error {
+	ctx, cancel := context.WithTimeout(ctx, validationCheckTimeout)
+	defer cancel()
+
+	return check.Check(ctx)
+}
+
+// add records the outcome of validating an option or a dependency. The valid options are not recorded, there are too
+// many of them to be useful in the report.
+func (report *ValidationReport) add(name string, kind string, err error) {
+	if err == nil {
+		if kind == ValidationKindOption {
+			return
+		}
+
+		report.Results = append(report.Results, ValidationResult{Name: name, Kind: kind, Status: ValidationStatusOK})
+
+		return
+	}
+
+	report.Failures++
+	report.Results = append(report.Results, ValidationResult{
+		Name:    name,
+		Kind:    kind,
+		Status:  ValidationStatusFailed,
+		Message: err.Error(),
+	})
+}
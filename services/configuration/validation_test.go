@@ -0,0 +1,107 @@
+package configuration_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Configuration Validation Tests", func() {
+	var (
+		directory string
+		ctx       context.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+		directory, err = ioutil.TempDir("", "configuration")
+		Ω(err).Should(BeNil())
+
+		for _, option := range configuration.Options() {
+			os.Unsetenv(option.EnvironmentVariable)
+		}
+
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+	})
+
+	newConfigurationService := func(content string) configuration.ConfigurationContract {
+		path := filepath.Join(directory, "config.yaml")
+		Ω(ioutil.WriteFile(path, []byte(content), 0600)).Should(Succeed())
+
+		service, err := configuration.NewFileConfigurationService(path)
+		Ω(err).Should(BeNil())
+
+		return service
+	}
+
+	findResult := func(report configuration.ValidationReport, name string) *configuration.ValidationResult {
+		for _, result := range report.Results {
+			if result.Name == name {
+				return &result
+			}
+		}
+
+		return nil
+	}
+
+	When("the required options are missing", func() {
+		It("should report every one of them instead of stopping at the first", func() {
+			report := configuration.Validate(ctx, newConfigurationService("GRPC_PORT: 8080\n"))
+
+			Ω(report.OptionsChecked).Should(Equal(len(configuration.Options())))
+			Ω(findResult(report, "GRPC_PORT")).Should(BeNil())
+
+			for _, name := range []string{"DATABASE_CONNECTION_STRING", "JWKS_URL"} {
+				result := findResult(report, name)
+				Ω(result).ShouldNot(BeNil(), name)
+				Ω(result.Kind).Should(Equal(configuration.ValidationKindOption))
+				Ω(result.Status).Should(Equal(configuration.ValidationStatusFailed))
+			}
+
+			Ω(report.Err()).ShouldNot(BeNil())
+			Ω(report.Err().Error()).Should(ContainSubstring("JWKS_URL"))
+		})
+	})
+
+	When("an option can not be parsed", func() {
+		It("should report the option with the parse error", func() {
+			report := configuration.Validate(ctx, newConfigurationService("GRPC_PORT: not-a-port\n"))
+
+			result := findResult(report, "GRPC_PORT")
+			Ω(result).ShouldNot(BeNil())
+			Ω(result.Status).Should(Equal(configuration.ValidationStatusFailed))
+			Ω(result.Message).ShouldNot(BeEmpty())
+		})
+	})
+
+	When("the reachability checks are run", func() {
+		It("should report the outcome of every check", func() {
+			report := configuration.Validate(
+				ctx,
+				newConfigurationService("GRPC_PORT: 8080\n"),
+				configuration.ValidationCheck{Name: "reachable", Check: func(ctx context.Context) error { return nil }},
+				configuration.ValidationCheck{Name: "unreachable", Check: func(ctx context.Context) error { return errors.New("connection refused") }})
+
+			reachable := findResult(report, "reachable")
+			Ω(reachable).ShouldNot(BeNil())
+			Ω(reachable.Kind).Should(Equal(configuration.ValidationKindReachability))
+			Ω(reachable.Status).Should(Equal(configuration.ValidationStatusOK))
+
+			unreachable := findResult(report, "unreachable")
+			Ω(unreachable).ShouldNot(BeNil())
+			Ω(unreachable.Status).Should(Equal(configuration.ValidationStatusFailed))
+			Ω(unreachable.Message).Should(Equal("connection refused"))
+		})
+	})
+})
@@ -0,0 +1,190 @@
+// Package configuration implements configuration service required by the user service
+package configuration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// kubernetesServiceAccountTokenPath is where Kubernetes mounts the token of the service account of the pod
+const kubernetesServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// vaultClient reads the secrets from the KV secrets engine of HashiCorp Vault through its HTTP API
+type vaultClient struct {
+	address            string
+	token              string
+	kubernetesRole     string
+	kubernetesAuthPath string
+	serviceAccountPath string
+	httpClient         *http.Client
+
+	lock       sync.Mutex
+	loginToken string
+}
+
+type vaultSecretResponse struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+type vaultLoginResponse struct {
+	Auth struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+}
+
+// newVaultClient creates the client of the given Vault server. The client reads the secrets with the given token, or
+// logs in with the token of the Kubernetes service account of the pod if no token is provided.
+// address: Mandatory. The address of the Vault server
+// token: Optional. The token to read the secrets with, file:/path reads the token from the file every time it is used
+// kubernetesRole: Optional. The role to log in as when no token is provided
+// kubernetesAuthPath: Optional. The path the Kubernetes auth method is enabled at
+// Returns the new client
+func newVaultClient(address string, token string, kubernetesRole string, kubernetesAuthPath string) *vaultClient {
+	return &vaultClient{
+		address:            address,
+		token:              token,
+		kubernetesRole:     kubernetesRole,
+		kubernetesAuthPath: kubernetesAuthPath,
+		serviceAccountPath: kubernetesServiceAccountTokenPath,
+		httpClient:         &http.Client{},
+	}
+}
+
+// read reads the key of the secret at the given path. Both the versioned and the unversioned KV secrets engines are
+// supported, the path of a versioned secret contains data, e.g. secret/data/user.
+// ctx: Mandatory The reference to the context
+// path: Mandatory. The path of the secret
+// key: Mandatory. The key of the secret to read
+// Returns either the value of the key or error if the secret can not be read
+func (client *vaultClient) read(ctx context.Context, path string, key string) (string, error) {
+	token, err := client.getToken(ctx, false)
+	if err != nil {
+		return "", err
+	}
+
+	statusCode, body, err := client.call(ctx, http.MethodGet, "/v1/"+path, token, nil)
+	if err == nil && statusCode == http.StatusForbidden && client.token == "" {
+		// The token the service logged in with expired, so it logs in again once
+		if token, err = client.getToken(ctx, true); err != nil {
+			return "", err
+		}
+
+		statusCode, body, err = client.call(ctx, http.MethodGet, "/v1/"+path, token, nil)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if statusCode != http.StatusOK {
+		return "", fmt.Errorf("reading %s from Vault failed with status %d", path, statusCode)
+	}
+
+	response := vaultSecretResponse{}
+	if err = json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("failed to parse the secret %s read from Vault: %w", path, err)
+	}
+
+	data := response.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, versioned := data["metadata"]; versioned {
+			data = nested
+		}
+	}
+
+	value, ok := data[key]
+	if !ok || value == nil {
+		return "", fmt.Errorf("the secret %s read from Vault does not contain %s", path, key)
+	}
+
+	if stringValue, ok := value.(string); ok {
+		return stringValue, nil
+	}
+
+	return fmt.Sprint(value), nil
+}
+
+// getToken returns the token to read the secrets with, logging in with the Kubernetes service account if no token is
+// provided
+// ctx: Mandatory The reference to the context
+// renew: Mandatory. Whether to log in again even if the service already logged in
+// Returns either the token or error if logging in fails
+func (client *vaultClient) getToken(ctx context.Context, renew bool) (string, error) {
+	if client.token != "" {
+		if strings.HasPrefix(client.token, fileSecretReferencePrefix) {
+			return readSecretFile(strings.TrimPrefix(client.token, fileSecretReferencePrefix))
+		}
+
+		return client.token, nil
+	}
+
+	client.lock.Lock()
+	defer client.lock.Unlock()
+
+	if client.loginToken != "" && !renew {
+		return client.loginToken, nil
+	}
+
+	serviceAccountToken, err := readSecretFile(client.serviceAccountPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the Kubernetes service account token to log in to Vault: %w", err)
+	}
+
+	request, err := json.Marshal(map[string]string{"role": client.kubernetesRole, "jwt": serviceAccountToken})
+	if err != nil {
+		return "", err
+	}
+
+	statusCode, body, err := client.call(ctx, http.MethodPost, "/v1/auth/"+client.kubernetesAuthPath+"/login", "", request)
+	if err != nil {
+		return "", err
+	}
+
+	if statusCode != http.StatusOK {
+		return "", fmt.Errorf("logging in to Vault as %s failed with status %d", client.kubernetesRole, statusCode)
+	}
+
+	response := vaultLoginResponse{}
+	if err = json.Unmarshal(body, &response); err != nil || response.Auth.ClientToken == "" {
+		return "", fmt.Errorf("logging in to Vault as %s returned no token", client.kubernetesRole)
+	}
+
+	client.loginToken = response.Auth.ClientToken
+
+	return client.loginToken, nil
+}
+
+func (client *vaultClient) call(ctx context.Context, method string, path string, token string, body []byte) (int, []byte, error) {
+	request, err := http.NewRequestWithContext(ctx, method, client.address+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if token != "" {
+		request.Header.Set("X-Vault-Token", token)
+	}
+
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to call Vault: %w", err)
+	}
+
+	defer response.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read the response of Vault: %w", err)
+	}
+
+	return response.StatusCode, responseBody, nil
+}
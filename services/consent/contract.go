@@ -0,0 +1,98 @@
+// Package consent implements the tracking of the policy versions, e.g. the terms of service or the privacy policy,
+// the users accepted
+package consent
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// ConsentContract declares the service that records the consents of the users and reports the required policy
+// versions they have not accepted yet
+type ConsentContract interface {
+	// RecordConsent records that the user accepted the version of the policy
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// policy: Mandatory. The name of the policy
+	// version: Mandatory. The version of the policy, it must be the required version if the policy is required
+	// Returns either the recorded consent or error if something goes wrong.
+	RecordConsent(
+		ctx context.Context,
+		email string,
+		policy string,
+		version string) (*models.Consent, error)
+
+	// ListConsents lists all the consents of the user ordered by the time they are accepted at
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns either the consents of the user or error if something goes wrong.
+	ListConsents(
+		ctx context.Context,
+		email string) ([]models.Consent, error)
+
+	// ListOutstandingPolicies lists the required policy versions the user has not accepted yet ordered by the policy
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns either the outstanding policy versions, empty if the user accepted all of them, or error if something
+	// goes wrong.
+	ListOutstandingPolicies(
+		ctx context.Context,
+		email string) ([]models.PolicyVersion, error)
+
+	// DeleteConsents deletes all the consents of the user
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns error if something goes wrong.
+	DeleteConsents(
+		ctx context.Context,
+		email string) error
+
+	// ChangeEmail moves all the consents of the user to the new email address of the user
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The current email address of the user
+	// newEmail: Mandatory. The new email address of the user
+	// Returns error if something goes wrong.
+	ChangeEmail(
+		ctx context.Context,
+		email string,
+		newEmail string) error
+}
+
+// StoreContract declares the service that persists the consents of the users
+type StoreContract interface {
+	// CreateConsent persists the new consent
+	// ctx: Mandatory The reference to the context
+	// consent: Mandatory. The consent to persist
+	// Returns error if something goes wrong.
+	CreateConsent(
+		ctx context.Context,
+		consent *models.Consent) error
+
+	// ListConsents lists all the persisted consents of the user ordered by the time they are accepted at
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns either the consents of the user or error if something goes wrong.
+	ListConsents(
+		ctx context.Context,
+		email string) ([]models.Consent, error)
+
+	// DeleteConsents deletes all the persisted consents of the user, it does nothing if the user has no consent
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns error if something goes wrong.
+	DeleteConsents(
+		ctx context.Context,
+		email string) error
+
+	// ChangeEmail moves all the persisted consents of the user to the new email address, it does nothing if the user
+	// has no consent
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The current email address of the user
+	// newEmail: Mandatory. The new email address of the user
+	// Returns error if something goes wrong.
+	ChangeEmail(
+		ctx context.Context,
+		email string,
+		newEmail string) error
+}
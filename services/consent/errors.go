@@ -0,0 +1,48 @@
+// Package consent implements the tracking of the policy versions, e.g. the terms of service or the privacy policy,
+// the users accepted
+package consent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// ConsentRequiredError indicates the operation requires the user to accept the required policy versions first
+type ConsentRequiredError struct {
+	Email    string
+	Policies []models.PolicyVersion
+}
+
+// Error returns message for the ConsentRequiredError error type
+// Returns the formatted error message
+func (e ConsentRequiredError) Error() string {
+	policies := make([]string, 0, len(e.Policies))
+	for _, policy := range e.Policies {
+		policies = append(policies, policy.Policy+" "+policy.Version)
+	}
+
+	return fmt.Sprintf("the user %s has not accepted %s yet", e.Email, strings.Join(policies, ", "))
+}
+
+// IsConsentRequiredError indicates whether the error is of type ConsentRequiredError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type ConsentRequiredError
+func IsConsentRequiredError(err error) bool {
+	var consentRequiredError ConsentRequiredError
+
+	return errors.As(err, &consentRequiredError)
+}
+
+// NewConsentRequiredError creates a new ConsentRequiredError error
+// email: Mandatory. The email address of the user
+// policies: Mandatory. The required policy versions the user has not accepted yet
+// Returns the new error
+func NewConsentRequiredError(email string, policies []models.PolicyVersion) error {
+	return ConsentRequiredError{
+		Email:    email,
+		Policies: policies,
+	}
+}
@@ -0,0 +1,189 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/consent/contract.go
+
+// Package mock_consent is a generated GoMock package.
+package mock_consent
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/decentralized-cloud/user/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockConsentContract is a mock of ConsentContract interface.
+type MockConsentContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsentContractMockRecorder
+}
+
+// MockConsentContractMockRecorder is the mock recorder for MockConsentContract.
+type MockConsentContractMockRecorder struct {
+	mock *MockConsentContract
+}
+
+// NewMockConsentContract creates a new mock instance.
+func NewMockConsentContract(ctrl *gomock.Controller) *MockConsentContract {
+	mock := &MockConsentContract{ctrl: ctrl}
+	mock.recorder = &MockConsentContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsentContract) EXPECT() *MockConsentContractMockRecorder {
+	return m.recorder
+}
+
+// ChangeEmail mocks base method.
+func (m *MockConsentContract) ChangeEmail(ctx context.Context, email, newEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmail", ctx, email, newEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeEmail indicates an expected call of ChangeEmail.
+func (mr *MockConsentContractMockRecorder) ChangeEmail(ctx, email, newEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmail", reflect.TypeOf((*MockConsentContract)(nil).ChangeEmail), ctx, email, newEmail)
+}
+
+// DeleteConsents mocks base method.
+func (m *MockConsentContract) DeleteConsents(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteConsents", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteConsents indicates an expected call of DeleteConsents.
+func (mr *MockConsentContractMockRecorder) DeleteConsents(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConsents", reflect.TypeOf((*MockConsentContract)(nil).DeleteConsents), ctx, email)
+}
+
+// ListConsents mocks base method.
+func (m *MockConsentContract) ListConsents(ctx context.Context, email string) ([]models.Consent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConsents", ctx, email)
+	ret0, _ := ret[0].([]models.Consent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConsents indicates an expected call of ListConsents.
+func (mr *MockConsentContractMockRecorder) ListConsents(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConsents", reflect.TypeOf((*MockConsentContract)(nil).ListConsents), ctx, email)
+}
+
+// ListOutstandingPolicies mocks base method.
+func (m *MockConsentContract) ListOutstandingPolicies(ctx context.Context, email string) ([]models.PolicyVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOutstandingPolicies", ctx, email)
+	ret0, _ := ret[0].([]models.PolicyVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOutstandingPolicies indicates an expected call of ListOutstandingPolicies.
+func (mr *MockConsentContractMockRecorder) ListOutstandingPolicies(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOutstandingPolicies", reflect.TypeOf((*MockConsentContract)(nil).ListOutstandingPolicies), ctx, email)
+}
+
+// RecordConsent mocks base method.
+func (m *MockConsentContract) RecordConsent(ctx context.Context, email, policy, version string) (*models.Consent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordConsent", ctx, email, policy, version)
+	ret0, _ := ret[0].(*models.Consent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordConsent indicates an expected call of RecordConsent.
+func (mr *MockConsentContractMockRecorder) RecordConsent(ctx, email, policy, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordConsent", reflect.TypeOf((*MockConsentContract)(nil).RecordConsent), ctx, email, policy, version)
+}
+
+// MockStoreContract is a mock of StoreContract interface.
+type MockStoreContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreContractMockRecorder
+}
+
+// MockStoreContractMockRecorder is the mock recorder for MockStoreContract.
+type MockStoreContractMockRecorder struct {
+	mock *MockStoreContract
+}
+
+// NewMockStoreContract creates a new mock instance.
+func NewMockStoreContract(ctrl *gomock.Controller) *MockStoreContract {
+	mock := &MockStoreContract{ctrl: ctrl}
+	mock.recorder = &MockStoreContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreContract) EXPECT() *MockStoreContractMockRecorder {
+	return m.recorder
+}
+
+// ChangeEmail mocks base method.
+func (m *MockStoreContract) ChangeEmail(ctx context.Context, email, newEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmail", ctx, email, newEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeEmail indicates an expected call of ChangeEmail.
+func (mr *MockStoreContractMockRecorder) ChangeEmail(ctx, email, newEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmail", reflect.TypeOf((*MockStoreContract)(nil).ChangeEmail), ctx, email, newEmail)
+}
+
+// CreateConsent mocks base method.
+func (m *MockStoreContract) CreateConsent(ctx context.Context, consent *models.Consent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConsent", ctx, consent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateConsent indicates an expected call of CreateConsent.
+func (mr *MockStoreContractMockRecorder) CreateConsent(ctx, consent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConsent", reflect.TypeOf((*MockStoreContract)(nil).CreateConsent), ctx, consent)
+}
+
+// DeleteConsents mocks base method.
+func (m *MockStoreContract) DeleteConsents(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteConsents", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteConsents indicates an expected call of DeleteConsents.
+func (mr *MockStoreContractMockRecorder) DeleteConsents(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConsents", reflect.TypeOf((*MockStoreContract)(nil).DeleteConsents), ctx, email)
+}
+
+// ListConsents mocks base method.
+func (m *MockStoreContract) ListConsents(ctx context.Context, email string) ([]models.Consent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConsents", ctx, email)
+	ret0, _ := ret[0].([]models.Consent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConsents indicates an expected call of ListConsents.
+func (mr *MockStoreContractMockRecorder) ListConsents(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConsents", reflect.TypeOf((*MockStoreContract)(nil).ListConsents), ctx, email)
+}
@@ -0,0 +1,168 @@
+// Package mongodb implements the MongoDB store that persists the consents of the users
+package mongodb
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/consent"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongodbStoreService struct {
+	getConnectionString func() (string, error)
+	databaseName        string
+	collectionName      string
+}
+
+// NewMongodbStoreService creates new instance of the mongodbStoreService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewMongodbStoreService(
+	configurationService configuration.ConfigurationContract) (consent.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if _, err := configurationService.GetDatabaseConnectionString(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	databaseName, err := configurationService.GetDatabaseName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database name", err)
+	}
+
+	collectionName, err := configurationService.GetConsentCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the consent collection name", err)
+	}
+
+	return &mongodbStoreService{
+		getConnectionString: configurationService.GetDatabaseConnectionString,
+		databaseName:        databaseName,
+		collectionName:      collectionName,
+	}, nil
+}
+
+// CreateConsent persists the new consent
+// ctx: Mandatory The reference to the context
+// consent: Mandatory. The consent to persist
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) CreateConsent(
+	ctx context.Context,
+	consent *models.Consent) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	if _, err = collection.InsertOne(ctx, consent); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to create consent", err)
+	}
+
+	return nil
+}
+
+// ListConsents lists all the persisted consents of the user ordered by the time they are accepted at
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns either the consents of the user or error if something goes wrong.
+func (service *mongodbStoreService) ListConsents(
+	ctx context.Context,
+	email string) ([]models.Consent, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(ctx, client)
+
+	filter := bson.D{{Key: "email", Value: email}}
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "acceptedat", Value: 1}}))
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list consents", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	consents := []models.Consent{}
+	if err = cursor.All(ctx, &consents); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to decode consents", err)
+	}
+
+	return consents, nil
+}
+
+// DeleteConsents deletes all the persisted consents of the user, it does nothing if the user has no consent
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) DeleteConsents(
+	ctx context.Context,
+	email string) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	if _, err = collection.DeleteMany(ctx, bson.D{{Key: "email", Value: email}}); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to delete consents", err)
+	}
+
+	return nil
+}
+
+// ChangeEmail moves all the persisted consents of the user to the new email address, it does nothing if the user
+// has no consent
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The current email address of the user
+// newEmail: Mandatory. The new email address of the user
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) ChangeEmail(
+	ctx context.Context,
+	email string,
+	newEmail string) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	if _, err = collection.UpdateMany(
+		ctx,
+		bson.D{{Key: "email", Value: email}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "email", Value: newEmail}}}}); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to change the email address of consents", err)
+	}
+
+	return nil
+}
+
+func (service *mongodbStoreService) createClientAndCollection(ctx context.Context) (*mongo.Client, *mongo.Collection, error) {
+	connectionString, err := service.getConnectionString()
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	clientOptions := options.Client().ApplyURI(connectionString)
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("could not connect to mongodb database", err)
+	}
+
+	return client, client.Database(service.databaseName).Collection(service.collectionName), nil
+}
+
+func disconnect(ctx context.Context, client *mongo.Client) {
+	_ = client.Disconnect(ctx)
+}
@@ -0,0 +1,181 @@
+// Package postgres implements the PostgreSQL store that persists the consents of the users
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	configurationPostgres "github.com/decentralized-cloud/user/services/configuration/postgres"
+	"github.com/decentralized-cloud/user/services/consent"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+const consentColumns = "consent_id, email, policy, version, accepted_at"
+
+type postgresStoreService struct {
+	pool      *pgxpool.Pool
+	tableName string
+}
+
+// NewPostgresStoreService creates new instance of the postgresStoreService, setting up all dependencies, creating
+// the consent table if it does not exist yet and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewPostgresStoreService(
+	configurationService configuration.ConfigurationContract) (consent.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	connectionString, err := configurationService.GetDatabaseConnectionString()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to postgres", err)
+	}
+
+	// The consent collection name is used as the name of the table the consents are persisted in
+	tableName, err := configurationService.GetConsentCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the consent table name", err)
+	}
+
+	ctx := context.Background()
+	pool, err := configurationPostgres.Connect(ctx, connectionString, configurationService.GetDatabaseConnectionString)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("could not connect to postgres database", err)
+	}
+
+	service := &postgresStoreService{
+		pool:      pool,
+		tableName: tableName,
+	}
+
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (
+			consent_id TEXT PRIMARY KEY,
+			email TEXT NOT NULL,
+			policy TEXT NOT NULL,
+			version TEXT NOT NULL,
+			accepted_at TIMESTAMPTZ NOT NULL)`,
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the consent table", err)
+	}
+
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		"CREATE INDEX IF NOT EXISTS %s ON %s (email)",
+		pgx.Identifier{tableName + "_email_idx"}.Sanitize(),
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the consent email index", err)
+	}
+
+	return service, nil
+}
+
+// CreateConsent persists the new consent
+// ctx: Mandatory The reference to the context
+// consent: Mandatory. The consent to persist
+// Returns error if something goes wrong.
+func (service *postgresStoreService) CreateConsent(
+	ctx context.Context,
+	consent *models.Consent) error {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("INSERT INTO %s (%s) VALUES ($1, $2, $3, $4, $5)", service.table(), consentColumns),
+		consent.ConsentID,
+		consent.Email,
+		consent.Policy,
+		consent.Version,
+		consent.AcceptedAt); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to create consent", err)
+	}
+
+	return nil
+}
+
+// ListConsents lists all the persisted consents of the user ordered by the time they are accepted at
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns either the consents of the user or error if something goes wrong.
+func (service *postgresStoreService) ListConsents(
+	ctx context.Context,
+	email string) ([]models.Consent, error) {
+	rows, err := service.pool.Query(
+		ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE email = $1 ORDER BY accepted_at", consentColumns, service.table()),
+		email)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list consents", err)
+	}
+
+	defer rows.Close()
+
+	consents := []models.Consent{}
+	for rows.Next() {
+		var consent models.Consent
+		if err = rows.Scan(
+			&consent.ConsentID,
+			&consent.Email,
+			&consent.Policy,
+			&consent.Version,
+			&consent.AcceptedAt); err != nil {
+			return nil, commonErrors.NewUnknownErrorWithError("failed to read consent", err)
+		}
+
+		consents = append(consents, consent)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list consents", err)
+	}
+
+	return consents, nil
+}
+
+// DeleteConsents deletes all the persisted consents of the user, it does nothing if the user has no consent
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns error if something goes wrong.
+func (service *postgresStoreService) DeleteConsents(
+	ctx context.Context,
+	email string) error {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE email = $1", service.table()),
+		email); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to delete consents", err)
+	}
+
+	return nil
+}
+
+// ChangeEmail moves all the persisted consents of the user to the new email address, it does nothing if the user
+// has no consent
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The current email address of the user
+// newEmail: Mandatory. The new email address of the user
+// Returns error if something goes wrong.
+func (service *postgresStoreService) ChangeEmail(
+	ctx context.Context,
+	email string,
+	newEmail string) error {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("UPDATE %s SET email = $2 WHERE email = $1", service.table()),
+		email,
+		newEmail); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to change the email address of consents", err)
+	}
+
+	return nil
+}
+
+func (service *postgresStoreService) table() string {
+	return pgx.Identifier{service.tableName}.Sanitize()
+}
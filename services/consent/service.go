@@ -0,0 +1,174 @@
+// Package consent implements the tracking of the policy versions, e.g. the terms of service or the privacy policy,
+// the users accepted
+package consent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/idgenerator"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+type consentService struct {
+	storeService       StoreContract
+	clockService       clock.ClockContract
+	idGeneratorService idgenerator.IDGeneratorContract
+	requiredPolicies   []models.PolicyVersion
+}
+
+// NewConsentService creates new instance of the consentService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// storeService: Mandatory. Reference to the service that persists the consents of the users
+// clockService: Mandatory. Reference to the service that provides the current time
+// idGeneratorService: Mandatory. Reference to the service that generates the IDs of the consents
+// Returns the new service or error if something goes wrong
+func NewConsentService(
+	configurationService configuration.ConfigurationContract,
+	storeService StoreContract,
+	clockService clock.ClockContract,
+	idGeneratorService idgenerator.IDGeneratorContract) (ConsentContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if storeService == nil {
+		return nil, commonErrors.NewArgumentNilError("storeService", "storeService is required")
+	}
+
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	if idGeneratorService == nil {
+		return nil, commonErrors.NewArgumentNilError("idGeneratorService", "idGeneratorService is required")
+	}
+
+	requiredPolicyVersions, err := configurationService.GetConsentRequiredPolicies()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the required policy versions", err)
+	}
+
+	requiredPolicies := make([]models.PolicyVersion, 0, len(requiredPolicyVersions))
+	for policy, version := range requiredPolicyVersions {
+		requiredPolicies = append(requiredPolicies, models.PolicyVersion{
+			Policy:  policy,
+			Version: version,
+		})
+	}
+
+	sort.Slice(requiredPolicies, func(i, j int) bool {
+		return requiredPolicies[i].Policy < requiredPolicies[j].Policy
+	})
+
+	return &consentService{
+		storeService:       storeService,
+		clockService:       clockService,
+		idGeneratorService: idGeneratorService,
+		requiredPolicies:   requiredPolicies,
+	}, nil
+}
+
+// RecordConsent records that the user accepted the version of the policy
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// policy: Mandatory. The name of the policy
+// version: Mandatory. The version of the policy, it must be the required version if the policy is required
+// Returns either the recorded consent or error if something goes wrong.
+func (service *consentService) RecordConsent(
+	ctx context.Context,
+	email string,
+	policy string,
+	version string) (*models.Consent, error) {
+	// Accepting an outdated version of a required policy would never satisfy the requirement, so it is rejected
+	// rather than recorded
+	for _, requiredPolicy := range service.requiredPolicies {
+		if requiredPolicy.Policy == policy && requiredPolicy.Version != version {
+			return nil, commonErrors.NewArgumentError(
+				"version",
+				fmt.Sprintf("the current version of the policy %s is %s", policy, requiredPolicy.Version))
+		}
+	}
+
+	consent := &models.Consent{
+		ConsentID:  service.idGeneratorService.NewID(),
+		Email:      email,
+		Policy:     policy,
+		Version:    version,
+		AcceptedAt: service.clockService.Now(),
+	}
+
+	if err := service.storeService.CreateConsent(ctx, consent); err != nil {
+		return nil, err
+	}
+
+	return consent, nil
+}
+
+// ListConsents lists all the consents of the user ordered by the time they are accepted at
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns either the consents of the user or error if something goes wrong.
+func (service *consentService) ListConsents(
+	ctx context.Context,
+	email string) ([]models.Consent, error) {
+	return service.storeService.ListConsents(ctx, email)
+}
+
+// ListOutstandingPolicies lists the required policy versions the user has not accepted yet ordered by the policy
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns either the outstanding policy versions, empty if the user accepted all of them, or error if something
+// goes wrong.
+func (service *consentService) ListOutstandingPolicies(
+	ctx context.Context,
+	email string) ([]models.PolicyVersion, error) {
+	outstandingPolicies := []models.PolicyVersion{}
+	if len(service.requiredPolicies) == 0 {
+		return outstandingPolicies, nil
+	}
+
+	consents, err := service.storeService.ListConsents(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	accepted := map[models.PolicyVersion]bool{}
+	for _, consent := range consents {
+		accepted[models.PolicyVersion{Policy: consent.Policy, Version: consent.Version}] = true
+	}
+
+	for _, requiredPolicy := range service.requiredPolicies {
+		if !accepted[requiredPolicy] {
+			outstandingPolicies = append(outstandingPolicies, requiredPolicy)
+		}
+	}
+
+	return outstandingPolicies, nil
+}
+
+// DeleteConsents deletes all the consents of the user
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns error if something goes wrong.
+func (service *consentService) DeleteConsents(
+	ctx context.Context,
+	email string) error {
+	return service.storeService.DeleteConsents(ctx, email)
+}
+
+// ChangeEmail moves all the consents of the user to the new email address of the user
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The current email address of the user
+// newEmail: Mandatory. The new email address of the user
+// Returns error if something goes wrong.
+func (service *consentService) ChangeEmail(
+	ctx context.Context,
+	email string,
+	newEmail string) error {
+	return service.storeService.ChangeEmail(ctx, email, newEmail)
+}
@@ -0,0 +1,212 @@
+package consent_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/consent"
+	consentMock "github.com/decentralized-cloud/user/services/consent/mock"
+	idgeneratorMock "github.com/decentralized-cloud/user/services/idgenerator/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestConsentService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Consent Service Tests")
+}
+
+var _ = Describe("Consent Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      consent.ConsentContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		mockStoreService         *consentMock.MockStoreContract
+		mockClockService         *clockMock.MockClockContract
+		mockIDGeneratorService   *idgeneratorMock.MockIDGeneratorContract
+		consents                 []models.Consent
+		now                      time.Time
+		ctx                      context.Context
+		email                    string
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetConsentRequiredPolicies().
+			Return(map[string]string{"terms-of-service": "2", "privacy-policy": "1"}, nil).
+			AnyTimes()
+
+		// The store keeps the consents in memory, so the recorded consents can be listed back
+		consents = []models.Consent{}
+		mockStoreService = consentMock.NewMockStoreContract(mockCtrl)
+		mockStoreService.
+			EXPECT().
+			CreateConsent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, consent *models.Consent) error {
+				consents = append(consents, *consent)
+
+				return nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			ListConsents(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, email string) ([]models.Consent, error) {
+				result := []models.Consent{}
+				for _, consent := range consents {
+					if consent.Email == email {
+						result = append(result, consent)
+					}
+				}
+
+				return result, nil
+			}).
+			AnyTimes()
+
+		now = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			DoAndReturn(func() time.Time { return now }).
+			AnyTimes()
+
+		mockIDGeneratorService = idgeneratorMock.NewMockIDGeneratorContract(mockCtrl)
+		mockIDGeneratorService.
+			EXPECT().
+			NewID().
+			DoAndReturn(cuid.New).
+			AnyTimes()
+
+		ctx = context.Background()
+		email = cuid.New() + "@test.com"
+
+		var err error
+		sut, err = consent.NewConsentService(mockConfigurationService, mockStoreService, mockClockService, mockIDGeneratorService)
+		Ω(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate ConsentService", func() {
+		When("configuration service is not provided and NewConsentService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := consent.NewConsentService(nil, mockStoreService, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("store service is not provided and NewConsentService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := consent.NewConsentService(mockConfigurationService, nil, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("clock service is not provided and NewConsentService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := consent.NewConsentService(mockConfigurationService, mockStoreService, nil, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("ID generator service is not provided and NewConsentService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := consent.NewConsentService(mockConfigurationService, mockStoreService, mockClockService, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("ConsentService is instantiated", func() {
+		When("RecordConsent is called", func() {
+			It("should persist the consent accepted now", func() {
+				recordedConsent, err := sut.RecordConsent(ctx, email, "terms-of-service", "2")
+				Ω(err).Should(BeNil())
+				Ω(recordedConsent.ConsentID).ShouldNot(BeEmpty())
+				Ω(recordedConsent.AcceptedAt).Should(Equal(now))
+				Ω(consents).Should(Equal([]models.Consent{*recordedConsent}))
+			})
+
+			It("should record any version of a policy that is not required", func() {
+				_, err := sut.RecordConsent(ctx, email, "marketing", cuid.New())
+				Ω(err).Should(BeNil())
+				Ω(consents).Should(HaveLen(1))
+			})
+
+			It("should return ArgumentError if the version of a required policy is not its current version", func() {
+				_, err := sut.RecordConsent(ctx, email, "terms-of-service", "1")
+				Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+				Ω(consents).Should(BeEmpty())
+			})
+		})
+
+		When("ListOutstandingPolicies is called", func() {
+			It("should return the required policy versions the user has not accepted yet ordered by the policy", func() {
+				outstandingPolicies, err := sut.ListOutstandingPolicies(ctx, email)
+				Ω(err).Should(BeNil())
+				Ω(outstandingPolicies).Should(Equal([]models.PolicyVersion{
+					{Policy: "privacy-policy", Version: "1"},
+					{Policy: "terms-of-service", Version: "2"},
+				}))
+
+				_, err = sut.RecordConsent(ctx, email, "terms-of-service", "2")
+				Ω(err).Should(BeNil())
+
+				outstandingPolicies, err = sut.ListOutstandingPolicies(ctx, email)
+				Ω(err).Should(BeNil())
+				Ω(outstandingPolicies).Should(Equal([]models.PolicyVersion{{Policy: "privacy-policy", Version: "1"}}))
+			})
+
+			It("should not count the consents of the other users", func() {
+				_, err := sut.RecordConsent(ctx, cuid.New()+"@test.com", "privacy-policy", "1")
+				Ω(err).Should(BeNil())
+
+				outstandingPolicies, err := sut.ListOutstandingPolicies(ctx, email)
+				Ω(err).Should(BeNil())
+				Ω(outstandingPolicies).Should(HaveLen(2))
+			})
+		})
+
+		When("a new version of a required policy is published", func() {
+			It("should require the user to accept it again", func() {
+				_, err := sut.RecordConsent(ctx, email, "terms-of-service", "2")
+				Ω(err).Should(BeNil())
+				_, err = sut.RecordConsent(ctx, email, "privacy-policy", "1")
+				Ω(err).Should(BeNil())
+
+				newConfigurationService := configurationMock.NewMockConfigurationContract(mockCtrl)
+				newConfigurationService.
+					EXPECT().
+					GetConsentRequiredPolicies().
+					Return(map[string]string{"terms-of-service": "3", "privacy-policy": "1"}, nil)
+
+				sut, err = consent.NewConsentService(newConfigurationService, mockStoreService, mockClockService, mockIDGeneratorService)
+				Ω(err).Should(BeNil())
+
+				outstandingPolicies, err := sut.ListOutstandingPolicies(ctx, email)
+				Ω(err).Should(BeNil())
+				Ω(outstandingPolicies).Should(Equal([]models.PolicyVersion{{Policy: "terms-of-service", Version: "3"}}))
+			})
+		})
+	})
+})
@@ -0,0 +1,28 @@
+// Package correlation implements the request correlation ids that tie the log messages and the error responses
+// of a single request together, across the services the request passes through
+package correlation
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc"
+)
+
+// CorrelationContract declares the service that assigns the correlation id to the received requests
+type CorrelationContract interface {
+	// CreateUnaryServerInterceptor creates the interceptor that assigns the correlation id to the unary calls and
+	// returns it in the response header and the error responses
+	// Returns the new interceptor
+	CreateUnaryServerInterceptor() grpc.UnaryServerInterceptor
+
+	// CreateStreamServerInterceptor creates the interceptor that assigns the correlation id to the streaming calls
+	// and returns it in the response header and the error status
+	// Returns the new interceptor
+	CreateStreamServerInterceptor() grpc.StreamServerInterceptor
+
+	// CreateHTTPMiddleware creates the middleware that assigns the correlation id to the HTTP requests and returns
+	// it in the response header
+	// next: Mandatory. The handler to call with the correlation id assigned
+	// Returns the new handler
+	CreateHTTPMiddleware(next http.Handler) http.Handler
+}
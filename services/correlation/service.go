@@ -0,0 +1,203 @@
+// Package correlation implements the request correlation ids that tie the log messages and the error responses
+// of a single request together, across the services the request passes through
+package correlation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// RequestIDHeader is the header the correlation id is received and returned in, it is also the gRPC metadata key
+	RequestIDHeader = "X-Request-ID"
+
+	// RequestIDLogField is the name of the field the correlation id is logged in
+	RequestIDLogField = "requestId"
+
+	errorMessageFieldName = "errorMessage"
+)
+
+type contextKey string
+
+var (
+	requestIDContextKey = contextKey("RequestID")
+	loggerContextKey    = contextKey("Logger")
+
+	// validRequestID limits the received correlation ids to the characters that are safe to log and to send in a header
+	validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:\-]{1,128}$`)
+)
+
+type correlationService struct {
+	logger *zap.Logger
+}
+
+// NewCorrelationService creates new instance of the correlationService, setting up all dependencies and returns the instance
+// logger: Mandatory. Reference to the logger service the correlation id is attached to
+// Returns the new service or error if something goes wrong
+func NewCorrelationService(logger *zap.Logger) (CorrelationContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	return &correlationService{
+		logger: logger,
+	}, nil
+}
+
+// GetRequestID returns the correlation id assigned to the request
+// ctx: Mandatory The reference to the context
+// Returns the correlation id or an empty string if none is assigned
+func GetRequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	requestID, _ := ctx.Value(requestIDContextKey).(string)
+
+	return requestID
+}
+
+// GetLogger returns the logger the correlation id of the request is attached to, so the log messages written while
+// handling a request can be found by its correlation id
+// ctx: Mandatory The reference to the context
+// logger: Mandatory. The logger to use if no correlation id is assigned to the request
+// Returns the logger to use while handling the request
+func GetLogger(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	if ctx == nil {
+		return logger
+	}
+
+	if requestLogger, ok := ctx.Value(loggerContextKey).(*zap.Logger); ok {
+		return requestLogger
+	}
+
+	return logger
+}
+
+// CreateUnaryServerInterceptor creates the interceptor that assigns the correlation id to the unary calls and
+// returns it in the response header and the error responses
+// Returns the new interceptor
+func (service *correlationService) CreateUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		request interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, requestID := service.newContext(ctx, getIncomingRequestID(ctx))
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+
+		response, err := handler(ctx, request)
+		if err != nil {
+			return response, withRequestID(err, requestID)
+		}
+
+		if responseMessage, ok := response.(proto.Message); ok && responseMessage.ProtoReflect().IsValid() {
+			appendToErrorMessage(responseMessage.ProtoReflect(), requestID)
+		}
+
+		return response, nil
+	}
+}
+
+// CreateStreamServerInterceptor creates the interceptor that assigns the correlation id to the streaming calls
+// and returns it in the response header and the error status
+// Returns the new interceptor
+func (service *correlationService) CreateStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		server interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		ctx, requestID := service.newContext(stream.Context(), getIncomingRequestID(stream.Context()))
+		_ = stream.SetHeader(metadata.Pairs(RequestIDHeader, requestID))
+
+		if err := handler(server, &serverStream{ServerStream: stream, ctx: ctx}); err != nil {
+			return withRequestID(err, requestID)
+		}
+
+		return nil
+	}
+}
+
+// CreateHTTPMiddleware creates the middleware that assigns the correlation id to the HTTP requests and returns
+// it in the response header
+// next: Mandatory. The handler to call with the correlation id assigned
+// Returns the new handler
+func (service *correlationService) CreateHTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		ctx, requestID := service.newContext(request.Context(), request.Header.Get(RequestIDHeader))
+		writer.Header().Set(RequestIDHeader, requestID)
+
+		next.ServeHTTP(writer, request.WithContext(ctx))
+	})
+}
+
+// newContext returns the context the correlation id and the logger it is attached to are stored in. The received
+// correlation id is propagated if it is valid, a new one is generated otherwise.
+func (service *correlationService) newContext(ctx context.Context, requestID string) (context.Context, string) {
+	if !validRequestID.MatchString(requestID) {
+		requestID = cuid.New()
+	}
+
+	ctx = context.WithValue(ctx, requestIDContextKey, requestID)
+	ctx = context.WithValue(ctx, loggerContextKey, service.logger.With(zap.String(RequestIDLogField, requestID)))
+
+	return ctx, requestID
+}
+
+func getIncomingRequestID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(RequestIDHeader); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// withRequestID adds the correlation id to the message of the gRPC status the call failed with
+func withRequestID(err error, requestID string) error {
+	grpcStatus := status.Convert(err)
+
+	return status.Error(grpcStatus.Code(), fmt.Sprintf("%s (request id: %s)", grpcStatus.Message(), requestID))
+}
+
+// appendToErrorMessage adds the correlation id to the errorMessage field of the response if it is set
+func appendToErrorMessage(response protoreflect.Message, requestID string) {
+	field := response.Descriptor().Fields().ByName(errorMessageFieldName)
+	if field == nil || field.Kind() != protoreflect.StringKind || field.IsList() {
+		return
+	}
+
+	errorMessage := response.Get(field).String()
+	if errorMessage == "" {
+		return
+	}
+
+	response.Set(field, protoreflect.ValueOfString(fmt.Sprintf("%s (request id: %s)", errorMessage, requestID)))
+}
+
+// serverStream replaces the context of the stream with the one the correlation id is stored in
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context the correlation id is stored in
+func (stream *serverStream) Context() context.Context {
+	return stream.ctx
+}
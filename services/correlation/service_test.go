@@ -0,0 +1,215 @@
+package correlation_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/services/correlation"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestCorrelationService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Correlation Service Tests")
+}
+
+var _ = Describe("Correlation Service Tests", func() {
+	var (
+		logs *observer.ObservedLogs
+		sut  correlation.CorrelationContract
+		info *grpc.UnaryServerInfo
+	)
+
+	BeforeEach(func() {
+		var core zapcore.Core
+		core, logs = observer.New(zap.InfoLevel)
+		info = &grpc.UnaryServerInfo{FullMethod: "/user.Service/ReadUser"}
+
+		var err error
+		sut, err = correlation.NewCorrelationService(zap.New(core))
+		Ω(err).Should(BeNil())
+	})
+
+	callUnary := func(ctx context.Context, handler grpc.UnaryHandler) (interface{}, error) {
+		return sut.CreateUnaryServerInterceptor()(ctx, &userGRPCContract.ReadUserRequest{}, info, handler)
+	}
+
+	Context("user tries to instantiate CorrelationService", func() {
+		When("logger is not provided and NewCorrelationService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := correlation.NewCorrelationService(nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("CorrelationService is instantiated", func() {
+		When("a call is received with a correlation id", func() {
+			It("should propagate the received correlation id", func() {
+				requestID := cuid.New()
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(correlation.RequestIDHeader, requestID))
+
+				_, _ = callUnary(ctx, func(ctx context.Context, request interface{}) (interface{}, error) {
+					Ω(correlation.GetRequestID(ctx)).Should(Equal(requestID))
+
+					return &userGRPCContract.ReadUserResponse{}, nil
+				})
+			})
+
+			It("should attach the correlation id to the logger of the request", func() {
+				requestID := cuid.New()
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(correlation.RequestIDHeader, requestID))
+
+				_, _ = callUnary(ctx, func(ctx context.Context, request interface{}) (interface{}, error) {
+					correlation.GetLogger(ctx, zap.NewNop()).Info(cuid.New())
+
+					return &userGRPCContract.ReadUserResponse{}, nil
+				})
+
+				Ω(logs.All()).Should(HaveLen(1))
+				Ω(logs.All()[0].ContextMap()[correlation.RequestIDLogField]).Should(Equal(requestID))
+			})
+		})
+
+		When("a call is received without a correlation id", func() {
+			It("should generate a new correlation id", func() {
+				_, _ = callUnary(context.Background(), func(ctx context.Context, request interface{}) (interface{}, error) {
+					Ω(correlation.GetRequestID(ctx)).ShouldNot(BeEmpty())
+
+					return &userGRPCContract.ReadUserResponse{}, nil
+				})
+			})
+		})
+
+		When("a call is received with an invalid correlation id", func() {
+			It("should replace it with a new correlation id", func() {
+				requestID := cuid.New() + "\n" + cuid.New()
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(correlation.RequestIDHeader, requestID))
+
+				_, _ = callUnary(ctx, func(ctx context.Context, request interface{}) (interface{}, error) {
+					Ω(correlation.GetRequestID(ctx)).ShouldNot(BeEmpty())
+					Ω(correlation.GetRequestID(ctx)).ShouldNot(Equal(requestID))
+
+					return &userGRPCContract.ReadUserResponse{}, nil
+				})
+			})
+		})
+
+		When("the call returns an error response", func() {
+			It("should add the correlation id to the error message", func() {
+				requestID := cuid.New()
+				errorMessage := cuid.New()
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(correlation.RequestIDHeader, requestID))
+
+				response, err := callUnary(ctx, func(ctx context.Context, request interface{}) (interface{}, error) {
+					return &userGRPCContract.ReadUserResponse{
+						Error:        userGRPCContract.Error_USER_NOT_FOUND,
+						ErrorMessage: errorMessage,
+					}, nil
+				})
+
+				Ω(err).Should(BeNil())
+				castedResponse := response.(*userGRPCContract.ReadUserResponse)
+				Ω(castedResponse.ErrorMessage).Should(HavePrefix(errorMessage))
+				Ω(castedResponse.ErrorMessage).Should(ContainSubstring(requestID))
+			})
+		})
+
+		When("the call returns a successful response", func() {
+			It("should leave the error message empty", func() {
+				response, err := callUnary(context.Background(), func(ctx context.Context, request interface{}) (interface{}, error) {
+					return &userGRPCContract.ReadUserResponse{}, nil
+				})
+
+				Ω(err).Should(BeNil())
+				Ω(response.(*userGRPCContract.ReadUserResponse).ErrorMessage).Should(BeEmpty())
+			})
+		})
+
+		When("the call fails", func() {
+			It("should add the correlation id to the status message and keep the status code", func() {
+				requestID := cuid.New()
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(correlation.RequestIDHeader, requestID))
+
+				_, err := callUnary(ctx, func(ctx context.Context, request interface{}) (interface{}, error) {
+					return nil, status.Error(codes.PermissionDenied, cuid.New())
+				})
+
+				Ω(status.Code(err)).Should(Equal(codes.PermissionDenied))
+				Ω(status.Convert(err).Message()).Should(ContainSubstring(requestID))
+			})
+		})
+
+		When("a streaming call is received", func() {
+			It("should propagate the received correlation id and return it in the response header", func() {
+				requestID := cuid.New()
+				stream := &testServerStream{
+					ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(correlation.RequestIDHeader, requestID)),
+				}
+
+				err := sut.CreateStreamServerInterceptor()(nil, stream, &grpc.StreamServerInfo{}, func(server interface{}, stream grpc.ServerStream) error {
+					Ω(correlation.GetRequestID(stream.Context())).Should(Equal(requestID))
+
+					return nil
+				})
+
+				Ω(err).Should(BeNil())
+				Ω(stream.header.Get(correlation.RequestIDHeader)).Should(Equal([]string{requestID}))
+			})
+		})
+
+		When("an HTTP request is received with a correlation id", func() {
+			It("should propagate the received correlation id and return it in the response header", func() {
+				requestID := cuid.New()
+				request := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(""))
+				request.Header.Set(correlation.RequestIDHeader, requestID)
+				recorder := httptest.NewRecorder()
+
+				sut.CreateHTTPMiddleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+					Ω(correlation.GetRequestID(request.Context())).Should(Equal(requestID))
+				})).ServeHTTP(recorder, request)
+
+				Ω(recorder.Header().Get(correlation.RequestIDHeader)).Should(Equal(requestID))
+			})
+		})
+
+		When("GetLogger is called for a context without a correlation id", func() {
+			It("should return the given logger", func() {
+				logger := zap.NewNop()
+				Ω(correlation.GetLogger(context.Background(), logger)).Should(BeIdenticalTo(logger))
+			})
+		})
+	})
+})
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (stream *testServerStream) Context() context.Context {
+	return stream.ctx
+}
+
+func (stream *testServerStream) SetHeader(header metadata.MD) error {
+	stream.header = metadata.Join(stream.header, header)
+
+	return nil
+}
@@ -0,0 +1,106 @@
+// Package credential implements the optional password credentials the users can sign in with when the deployment
+// cannot rely only on an external identity provider
+package credential
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// CredentialContract declares the service that hashes, persists and verifies the passwords of the users
+type CredentialContract interface {
+	// ValidatePassword validates the password against the configured password policy
+	// password: Mandatory. The password to validate
+	// Returns error if the password does not satisfy the password policy
+	ValidatePassword(password string) error
+
+	// SetPassword hashes the password with the configured algorithm and persists the hash, replacing the password the
+	// user had before if any
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// password: Mandatory. The new password of the user
+	// Returns error if something goes wrong.
+	SetPassword(
+		ctx context.Context,
+		email string,
+		password string) error
+
+	// HasPassword indicates whether the user has a password
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns either whether the user has a password or error if something goes wrong.
+	HasPassword(
+		ctx context.Context,
+		email string) (bool, error)
+
+	// VerifyPassword verifies the password matches the password of the user. The password is rehashed with the
+	// configured algorithm if it is hashed with another one.
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// password: Mandatory. The password to verify
+	// Returns either whether the password matches or error if something goes wrong. The password does not match if
+	// the user has no password.
+	VerifyPassword(
+		ctx context.Context,
+		email string,
+		password string) (bool, error)
+
+	// DeletePassword deletes the password of the user, it does nothing if the user has no password
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns error if something goes wrong.
+	DeletePassword(
+		ctx context.Context,
+		email string) error
+
+	// ChangeEmail moves the password of the user to the new email address of the user, it does nothing if the user
+	// has no password
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The current email address of the user
+	// newEmail: Mandatory. The new email address of the user
+	// Returns error if something goes wrong.
+	ChangeEmail(
+		ctx context.Context,
+		email string,
+		newEmail string) error
+}
+
+// StoreContract declares the service that persists the password hashes
+type StoreContract interface {
+	// ReadCredential reads the persisted password credential of the user
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns either the password credential or error if something goes wrong. NotFoundError is returned if the user
+	// has no password.
+	ReadCredential(
+		ctx context.Context,
+		email string) (*models.PasswordCredential, error)
+
+	// SaveCredential creates or replaces the persisted password credential of the user
+	// ctx: Mandatory The reference to the context
+	// credential: Mandatory. The password credential to persist
+	// Returns error if something goes wrong.
+	SaveCredential(
+		ctx context.Context,
+		credential *models.PasswordCredential) error
+
+	// DeleteCredential deletes the persisted password credential of the user, it does nothing if there is none
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns error if something goes wrong.
+	DeleteCredential(
+		ctx context.Context,
+		email string) error
+
+	// ChangeEmail moves the persisted password credential of the user to the new email address, it does nothing if
+	// there is none
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The current email address of the user
+	// newEmail: Mandatory. The new email address of the user
+	// Returns error if something goes wrong.
+	ChangeEmail(
+		ctx context.Context,
+		email string,
+		newEmail string) error
+}
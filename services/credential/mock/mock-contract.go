@@ -0,0 +1,202 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/credential/contract.go
+
+// Package mock_credential is a generated GoMock package.
+package mock_credential
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/decentralized-cloud/user/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCredentialContract is a mock of CredentialContract interface.
+type MockCredentialContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialContractMockRecorder
+}
+
+// MockCredentialContractMockRecorder is the mock recorder for MockCredentialContract.
+type MockCredentialContractMockRecorder struct {
+	mock *MockCredentialContract
+}
+
+// NewMockCredentialContract creates a new mock instance.
+func NewMockCredentialContract(ctrl *gomock.Controller) *MockCredentialContract {
+	mock := &MockCredentialContract{ctrl: ctrl}
+	mock.recorder = &MockCredentialContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialContract) EXPECT() *MockCredentialContractMockRecorder {
+	return m.recorder
+}
+
+// ChangeEmail mocks base method.
+func (m *MockCredentialContract) ChangeEmail(ctx context.Context, email, newEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmail", ctx, email, newEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeEmail indicates an expected call of ChangeEmail.
+func (mr *MockCredentialContractMockRecorder) ChangeEmail(ctx, email, newEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmail", reflect.TypeOf((*MockCredentialContract)(nil).ChangeEmail), ctx, email, newEmail)
+}
+
+// DeletePassword mocks base method.
+func (m *MockCredentialContract) DeletePassword(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePassword", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePassword indicates an expected call of DeletePassword.
+func (mr *MockCredentialContractMockRecorder) DeletePassword(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePassword", reflect.TypeOf((*MockCredentialContract)(nil).DeletePassword), ctx, email)
+}
+
+// HasPassword mocks base method.
+func (m *MockCredentialContract) HasPassword(ctx context.Context, email string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPassword", ctx, email)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPassword indicates an expected call of HasPassword.
+func (mr *MockCredentialContractMockRecorder) HasPassword(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPassword", reflect.TypeOf((*MockCredentialContract)(nil).HasPassword), ctx, email)
+}
+
+// SetPassword mocks base method.
+func (m *MockCredentialContract) SetPassword(ctx context.Context, email, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", ctx, email, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockCredentialContractMockRecorder) SetPassword(ctx, email, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockCredentialContract)(nil).SetPassword), ctx, email, password)
+}
+
+// ValidatePassword mocks base method.
+func (m *MockCredentialContract) ValidatePassword(password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatePassword", password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidatePassword indicates an expected call of ValidatePassword.
+func (mr *MockCredentialContractMockRecorder) ValidatePassword(password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatePassword", reflect.TypeOf((*MockCredentialContract)(nil).ValidatePassword), password)
+}
+
+// VerifyPassword mocks base method.
+func (m *MockCredentialContract) VerifyPassword(ctx context.Context, email, password string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPassword", ctx, email, password)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyPassword indicates an expected call of VerifyPassword.
+func (mr *MockCredentialContractMockRecorder) VerifyPassword(ctx, email, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPassword", reflect.TypeOf((*MockCredentialContract)(nil).VerifyPassword), ctx, email, password)
+}
+
+// MockStoreContract is a mock of StoreContract interface.
+type MockStoreContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreContractMockRecorder
+}
+
+// MockStoreContractMockRecorder is the mock recorder for MockStoreContract.
+type MockStoreContractMockRecorder struct {
+	mock *MockStoreContract
+}
+
+// NewMockStoreContract creates a new mock instance.
+func NewMockStoreContract(ctrl *gomock.Controller) *MockStoreContract {
+	mock := &MockStoreContract{ctrl: ctrl}
+	mock.recorder = &MockStoreContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreContract) EXPECT() *MockStoreContractMockRecorder {
+	return m.recorder
+}
+
+// ChangeEmail mocks base method.
+func (m *MockStoreContract) ChangeEmail(ctx context.Context, email, newEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmail", ctx, email, newEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeEmail indicates an expected call of ChangeEmail.
+func (mr *MockStoreContractMockRecorder) ChangeEmail(ctx, email, newEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmail", reflect.TypeOf((*MockStoreContract)(nil).ChangeEmail), ctx, email, newEmail)
+}
+
+// DeleteCredential mocks base method.
+func (m *MockStoreContract) DeleteCredential(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCredential", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCredential indicates an expected call of DeleteCredential.
+func (mr *MockStoreContractMockRecorder) DeleteCredential(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCredential", reflect.TypeOf((*MockStoreContract)(nil).DeleteCredential), ctx, email)
+}
+
+// ReadCredential mocks base method.
+func (m *MockStoreContract) ReadCredential(ctx context.Context, email string) (*models.PasswordCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadCredential", ctx, email)
+	ret0, _ := ret[0].(*models.PasswordCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadCredential indicates an expected call of ReadCredential.
+func (mr *MockStoreContractMockRecorder) ReadCredential(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadCredential", reflect.TypeOf((*MockStoreContract)(nil).ReadCredential), ctx, email)
+}
+
+// SaveCredential mocks base method.
+func (m *MockStoreContract) SaveCredential(ctx context.Context, credential *models.PasswordCredential) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCredential", ctx, credential)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCredential indicates an expected call of SaveCredential.
+func (mr *MockStoreContractMockRecorder) SaveCredential(ctx, credential interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCredential", reflect.TypeOf((*MockStoreContract)(nil).SaveCredential), ctx, credential)
+}
@@ -0,0 +1,167 @@
+// Package mongodb implements the MongoDB store that persists the password hashes
+package mongodb
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/credential"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongodbStoreService struct {
+	getConnectionString func() (string, error)
+	databaseName        string
+	collectionName      string
+}
+
+// NewMongodbStoreService creates new instance of the mongodbStoreService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewMongodbStoreService(
+	configurationService configuration.ConfigurationContract) (credential.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if _, err := configurationService.GetDatabaseConnectionString(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	databaseName, err := configurationService.GetDatabaseName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database name", err)
+	}
+
+	collectionName, err := configurationService.GetCredentialCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the credential collection name", err)
+	}
+
+	return &mongodbStoreService{
+		getConnectionString: configurationService.GetDatabaseConnectionString,
+		databaseName:        databaseName,
+		collectionName:      collectionName,
+	}, nil
+}
+
+// ReadCredential reads the persisted password credential of the user
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns either the password credential or error if something goes wrong. NotFoundError is returned if the user
+// has no password.
+func (service *mongodbStoreService) ReadCredential(
+	ctx context.Context,
+	email string) (*models.PasswordCredential, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(ctx, client)
+
+	var credential models.PasswordCredential
+
+	filter := bson.D{{Key: "email", Value: email}}
+	err = collection.FindOne(ctx, filter).Decode(&credential)
+	if err == mongo.ErrNoDocuments {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read password credential", err)
+	}
+
+	return &credential, nil
+}
+
+// SaveCredential creates or replaces the persisted password credential of the user
+// ctx: Mandatory The reference to the context
+// credential: Mandatory. The password credential to persist
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) SaveCredential(
+	ctx context.Context,
+	credential *models.PasswordCredential) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	filter := bson.D{{Key: "email", Value: credential.Email}}
+	if _, err = collection.ReplaceOne(ctx, filter, credential, options.Replace().SetUpsert(true)); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to save password credential", err)
+	}
+
+	return nil
+}
+
+// DeleteCredential deletes the persisted password credential of the user, it does nothing if there is none
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) DeleteCredential(
+	ctx context.Context,
+	email string) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	filter := bson.D{{Key: "email", Value: email}}
+	if _, err = collection.DeleteOne(ctx, filter); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to delete password credential", err)
+	}
+
+	return nil
+}
+
+// ChangeEmail moves the persisted password credential of the user to the new email address, it does nothing if
+// there is none
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The current email address of the user
+// newEmail: Mandatory. The new email address of the user
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) ChangeEmail(
+	ctx context.Context,
+	email string,
+	newEmail string) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	filter := bson.D{{Key: "email", Value: email}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "email", Value: newEmail}}}}
+	if _, err = collection.UpdateOne(ctx, filter, update); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to change the email address of password credential", err)
+	}
+
+	return nil
+}
+
+func (service *mongodbStoreService) createClientAndCollection(ctx context.Context) (*mongo.Client, *mongo.Collection, error) {
+	connectionString, err := service.getConnectionString()
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	clientOptions := options.Client().ApplyURI(connectionString)
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("could not connect to mongodb database", err)
+	}
+
+	return client, client.Database(service.databaseName).Collection(service.collectionName), nil
+}
+
+func disconnect(ctx context.Context, client *mongo.Client) {
+	_ = client.Disconnect(ctx)
+}
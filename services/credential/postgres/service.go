@@ -0,0 +1,151 @@
+// Package postgres implements the PostgreSQL store that persists the password hashes
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	configurationPostgres "github.com/decentralized-cloud/user/services/configuration/postgres"
+	"github.com/decentralized-cloud/user/services/credential"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+type postgresStoreService struct {
+	pool      *pgxpool.Pool
+	tableName string
+}
+
+// NewPostgresStoreService creates new instance of the postgresStoreService, setting up all dependencies, creating
+// the credential table if it does not exist yet and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewPostgresStoreService(
+	configurationService configuration.ConfigurationContract) (credential.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	connectionString, err := configurationService.GetDatabaseConnectionString()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to postgres", err)
+	}
+
+	// The credential collection name is used as the name of the table the password hashes are persisted in
+	tableName, err := configurationService.GetCredentialCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the credential table name", err)
+	}
+
+	ctx := context.Background()
+	pool, err := configurationPostgres.Connect(ctx, connectionString, configurationService.GetDatabaseConnectionString)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("could not connect to postgres database", err)
+	}
+
+	service := &postgresStoreService{
+		pool:      pool,
+		tableName: tableName,
+	}
+
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (email TEXT PRIMARY KEY, password_hash TEXT NOT NULL, updated_at TIMESTAMPTZ NOT NULL)",
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the credential table", err)
+	}
+
+	return service, nil
+}
+
+// ReadCredential reads the persisted password credential of the user
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns either the password credential or error if something goes wrong. NotFoundError is returned if the user
+// has no password.
+func (service *postgresStoreService) ReadCredential(
+	ctx context.Context,
+	email string) (*models.PasswordCredential, error) {
+	credential := models.PasswordCredential{Email: email}
+
+	err := service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf("SELECT password_hash, updated_at FROM %s WHERE email = $1", service.table()),
+		email).Scan(&credential.PasswordHash, &credential.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read password credential", err)
+	}
+
+	return &credential, nil
+}
+
+// SaveCredential creates or replaces the persisted password credential of the user
+// ctx: Mandatory The reference to the context
+// credential: Mandatory. The password credential to persist
+// Returns error if something goes wrong.
+func (service *postgresStoreService) SaveCredential(
+	ctx context.Context,
+	credential *models.PasswordCredential) error {
+	_, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf(
+			`INSERT INTO %s (email, password_hash, updated_at) VALUES ($1, $2, $3)
+			ON CONFLICT (email) DO UPDATE SET password_hash = EXCLUDED.password_hash, updated_at = EXCLUDED.updated_at`,
+			service.table()),
+		credential.Email,
+		credential.PasswordHash,
+		credential.UpdatedAt)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to save password credential", err)
+	}
+
+	return nil
+}
+
+// DeleteCredential deletes the persisted password credential of the user, it does nothing if there is none
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns error if something goes wrong.
+func (service *postgresStoreService) DeleteCredential(
+	ctx context.Context,
+	email string) error {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE email = $1", service.table()),
+		email); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to delete password credential", err)
+	}
+
+	return nil
+}
+
+// ChangeEmail moves the persisted password credential of the user to the new email address, it does nothing if
+// there is none
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The current email address of the user
+// newEmail: Mandatory. The new email address of the user
+// Returns error if something goes wrong.
+func (service *postgresStoreService) ChangeEmail(
+	ctx context.Context,
+	email string,
+	newEmail string) error {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("UPDATE %s SET email = $2 WHERE email = $1", service.table()),
+		email,
+		newEmail); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to change the email address of password credential", err)
+	}
+
+	return nil
+}
+
+func (service *postgresStoreService) table() string {
+	return pgx.Identifier{service.tableName}.Sanitize()
+}
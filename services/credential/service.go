@@ -0,0 +1,421 @@
+// Package credential implements the optional password credentials the users can sign in with when the deployment
+// cannot rely only on an external identity provider
+package credential
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/pkg/fips"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// AlgorithmArgon2id hashes the passwords with argon2id, the hashes are encoded in the PHC string format
+	AlgorithmArgon2id = "argon2id"
+
+	// AlgorithmBcrypt hashes the passwords with bcrypt
+	AlgorithmBcrypt = "bcrypt"
+
+	// AlgorithmPBKDF2SHA256 hashes the passwords with PBKDF2 using HMAC-SHA256, the only FIPS approved algorithm and
+	// so the only one supported by the FIPS build. The hashes are encoded in the PHC string format.
+	AlgorithmPBKDF2SHA256 = "pbkdf2-sha256"
+
+	// MaxPasswordLength is the maximum number of the bytes a password can contain, so hashing a password can not be
+	// made arbitrarily expensive
+	MaxPasswordLength = 1024
+
+	// bcryptMaxPasswordLength is the number of the bytes bcrypt hashes, the bytes after it would be silently ignored
+	bcryptMaxPasswordLength = 72
+	bcryptCost              = 12
+
+	argon2Memory      = 64 * 1024
+	argon2Iterations  = 3
+	argon2Parallelism = 2
+	argon2SaltLength  = 16
+	argon2KeyLength   = 32
+
+	pbkdf2Iterations = 600000
+	pbkdf2SaltLength = 16
+	pbkdf2KeyLength  = 32
+)
+
+type credentialService struct {
+	storeService             StoreContract
+	clockService             clock.ClockContract
+	algorithm                string
+	minLength                int
+	requiredCharacterClasses int
+}
+
+// NewCredentialService creates new instance of the credentialService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// storeService: Mandatory. Reference to the service that persists the password hashes
+// clockService: Mandatory. Reference to the service that provides the current time
+// Returns the new service or error if something goes wrong
+func NewCredentialService(
+	configurationService configuration.ConfigurationContract,
+	storeService StoreContract,
+	clockService clock.ClockContract) (CredentialContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if storeService == nil {
+		return nil, commonErrors.NewArgumentNilError("storeService", "storeService is required")
+	}
+
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	algorithm, err := configurationService.GetPasswordHashingAlgorithm()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the password hashing algorithm", err)
+	}
+
+	if fips.Built && algorithm != AlgorithmPBKDF2SHA256 {
+		return nil, commonErrors.NewUnknownError(fmt.Sprintf(
+			"the passwords can only be hashed with %s in the FIPS build, %s is not FIPS approved",
+			AlgorithmPBKDF2SHA256,
+			algorithm))
+	}
+
+	minLength, err := configurationService.GetPasswordMinLength()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the minimum password length", err)
+	}
+
+	requiredCharacterClasses, err := configurationService.GetPasswordRequiredCharacterClasses()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the number of the required password character classes", err)
+	}
+
+	return &credentialService{
+		storeService:             storeService,
+		clockService:             clockService,
+		algorithm:                algorithm,
+		minLength:                minLength,
+		requiredCharacterClasses: requiredCharacterClasses,
+	}, nil
+}
+
+// ValidatePassword validates the password against the configured password policy
+// password: Mandatory. The password to validate
+// Returns error if the password does not satisfy the password policy
+func (service *credentialService) ValidatePassword(password string) error {
+	if !utf8.ValidString(password) {
+		return commonErrors.NewArgumentError("password", "password must be a valid UTF-8 string")
+	}
+
+	if utf8.RuneCountInString(password) < service.minLength {
+		return commonErrors.NewArgumentError("password", fmt.Sprintf("password must contain at least %d characters", service.minLength))
+	}
+
+	maxLength := MaxPasswordLength
+	if service.algorithm == AlgorithmBcrypt {
+		maxLength = bcryptMaxPasswordLength
+	}
+
+	if len(password) > maxLength {
+		return commonErrors.NewArgumentError("password", fmt.Sprintf("password must not be longer than %d bytes", maxLength))
+	}
+
+	if characterClasses := countCharacterClasses(password); characterClasses < service.requiredCharacterClasses {
+		return commonErrors.NewArgumentError(
+			"password",
+			fmt.Sprintf(
+				"password must contain at least %d of lower case letters, upper case letters, digits and symbols",
+				service.requiredCharacterClasses))
+	}
+
+	return nil
+}
+
+// SetPassword hashes the password with the configured algorithm and persists the hash, replacing the password the
+// user had before if any
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// password: Mandatory. The new password of the user
+// Returns error if something goes wrong.
+func (service *credentialService) SetPassword(
+	ctx context.Context,
+	email string,
+	password string) error {
+	if err := service.ValidatePassword(password); err != nil {
+		return err
+	}
+
+	return service.savePassword(ctx, email, password)
+}
+
+// HasPassword indicates whether the user has a password
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns either whether the user has a password or error if something goes wrong.
+func (service *credentialService) HasPassword(
+	ctx context.Context,
+	email string) (bool, error) {
+	if _, err := service.storeService.ReadCredential(ctx, email); err != nil {
+		if commonErrors.IsNotFoundError(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// VerifyPassword verifies the password matches the password of the user. The password is rehashed with the
+// configured algorithm if it is hashed with another one.
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// password: Mandatory. The password to verify
+// Returns either whether the password matches or error if something goes wrong. The password does not match if
+// the user has no password.
+func (service *credentialService) VerifyPassword(
+	ctx context.Context,
+	email string,
+	password string) (bool, error) {
+	if len(password) > MaxPasswordLength {
+		return false, nil
+	}
+
+	credential, err := service.storeService.ReadCredential(ctx, email)
+	if err != nil {
+		if commonErrors.IsNotFoundError(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	matched, err := comparePassword(credential.PasswordHash, password)
+	if err != nil || !matched {
+		return false, err
+	}
+
+	// Rehashing is best effort, the password still matches if the new hash could not be persisted
+	if getAlgorithm(credential.PasswordHash) != service.algorithm {
+		_ = service.savePassword(ctx, email, password)
+	}
+
+	return true, nil
+}
+
+// DeletePassword deletes the password of the user, it does nothing if the user has no password
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns error if something goes wrong.
+func (service *credentialService) DeletePassword(
+	ctx context.Context,
+	email string) error {
+	return service.storeService.DeleteCredential(ctx, email)
+}
+
+// ChangeEmail moves the password of the user to the new email address of the user, it does nothing if the user
+// has no password
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The current email address of the user
+// newEmail: Mandatory. The new email address of the user
+// Returns error if something goes wrong.
+func (service *credentialService) ChangeEmail(
+	ctx context.Context,
+	email string,
+	newEmail string) error {
+	return service.storeService.ChangeEmail(ctx, email, newEmail)
+}
+
+func (service *credentialService) savePassword(ctx context.Context, email string, password string) error {
+	passwordHash, err := hashPassword(service.algorithm, password)
+	if err != nil {
+		return err
+	}
+
+	return service.storeService.SaveCredential(ctx, &models.PasswordCredential{
+		Email:        email,
+		PasswordHash: passwordHash,
+		UpdatedAt:    service.clockService.Now(),
+	})
+}
+
+func countCharacterClasses(password string) int {
+	var lower, upper, digit, symbol bool
+
+	for _, character := range password {
+		switch {
+		case unicode.IsLower(character):
+			lower = true
+		case unicode.IsUpper(character):
+			upper = true
+		case unicode.IsDigit(character):
+			digit = true
+		case !unicode.IsLetter(character):
+			symbol = true
+		}
+	}
+
+	count := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			count++
+		}
+	}
+
+	return count
+}
+
+func hashPassword(algorithm string, password string) (string, error) {
+	switch algorithm {
+	case AlgorithmBcrypt:
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+		if err != nil {
+			return "", commonErrors.NewUnknownErrorWithError("failed to hash the password", err)
+		}
+
+		return string(hash), nil
+
+	case AlgorithmPBKDF2SHA256:
+		salt := make([]byte, pbkdf2SaltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return "", commonErrors.NewUnknownErrorWithError("failed to generate the password salt", err)
+		}
+
+		key := pbkdf2.Key([]byte(password), salt, pbkdf2Iterations, pbkdf2KeyLength, sha256.New)
+
+		return fmt.Sprintf(
+			"$%s$i=%d$%s$%s",
+			AlgorithmPBKDF2SHA256,
+			pbkdf2Iterations,
+			base64.RawStdEncoding.EncodeToString(salt),
+			base64.RawStdEncoding.EncodeToString(key)), nil
+	}
+
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", commonErrors.NewUnknownErrorWithError("failed to generate the password salt", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, argon2Iterations, argon2Memory, argon2Parallelism, argon2KeyLength)
+
+	return fmt.Sprintf(
+		"$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		AlgorithmArgon2id,
+		argon2.Version,
+		argon2Memory,
+		argon2Iterations,
+		argon2Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func getAlgorithm(passwordHash string) string {
+	if strings.HasPrefix(passwordHash, "$"+AlgorithmArgon2id+"$") {
+		return AlgorithmArgon2id
+	}
+
+	if strings.HasPrefix(passwordHash, "$"+AlgorithmPBKDF2SHA256+"$") {
+		return AlgorithmPBKDF2SHA256
+	}
+
+	return AlgorithmBcrypt
+}
+
+// comparePassword compares the password with its hash. The FIPS build only compares the passwords with their
+// PBKDF2-SHA256 hashes, the passwords hashed with the other algorithms must be set again.
+func comparePassword(passwordHash string, password string) (bool, error) {
+	algorithm := getAlgorithm(passwordHash)
+	if fips.Built && algorithm != AlgorithmPBKDF2SHA256 {
+		return false, commonErrors.NewUnknownError(fmt.Sprintf(
+			"the password hashed with %s can not be verified in the FIPS build, the password must be set again",
+			algorithm))
+	}
+
+	if algorithm == AlgorithmPBKDF2SHA256 {
+		return comparePBKDF2Password(passwordHash, password)
+	}
+
+	if algorithm == AlgorithmBcrypt {
+		if err := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(password)); err != nil {
+			if err == bcrypt.ErrMismatchedHashAndPassword {
+				return false, nil
+			}
+
+			return false, commonErrors.NewUnknownErrorWithError("failed to compare the password with its bcrypt hash", err)
+		}
+
+		return true, nil
+	}
+
+	// $argon2id$v=19$m=65536,t=3,p=2$salt$key splits into an empty string followed by the five parts
+	parts := strings.Split(passwordHash, "$")
+	if len(parts) != 6 {
+		return false, commonErrors.NewUnknownError("the argon2id password hash is malformed")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, commonErrors.NewUnknownError("the argon2id version of the password hash is not supported")
+	}
+
+	var memory, iterations uint32
+	var parallelism uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism); err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to parse the argon2id parameters of the password hash", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to decode the argon2id salt of the password hash", err)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to decode the argon2id key of the password hash", err)
+	}
+
+	candidateKey := argon2.IDKey([]byte(password), salt, iterations, memory, parallelism, uint32(len(key)))
+
+	return subtle.ConstantTimeCompare(key, candidateKey) == 1, nil
+}
+
+func comparePBKDF2Password(passwordHash string, password string) (bool, error) {
+	// $pbkdf2-sha256$i=600000$salt$key splits into an empty string followed by the four parts
+	parts := strings.Split(passwordHash, "$")
+	if len(parts) != 5 {
+		return false, commonErrors.NewUnknownError("the pbkdf2-sha256 password hash is malformed")
+	}
+
+	var iterations int
+	if _, err := fmt.Sscanf(parts[2], "i=%d", &iterations); err != nil || iterations < 1 {
+		return false, commonErrors.NewUnknownError("failed to parse the pbkdf2-sha256 iterations of the password hash")
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to decode the pbkdf2-sha256 salt of the password hash", err)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to decode the pbkdf2-sha256 key of the password hash", err)
+	}
+
+	candidateKey := pbkdf2.Key([]byte(password), salt, iterations, len(key), sha256.New)
+
+	return subtle.ConstantTimeCompare(key, candidateKey) == 1, nil
+}
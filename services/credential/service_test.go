@@ -0,0 +1,281 @@
+package credential_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/credential"
+	credentialMock "github.com/decentralized-cloud/user/services/credential/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestCredentialService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Credential Service Tests")
+}
+
+var _ = Describe("Credential Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      credential.CredentialContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		mockStoreService         *credentialMock.MockStoreContract
+		mockClockService         *clockMock.MockClockContract
+		algorithm                string
+		credentials              map[string]models.PasswordCredential
+		now                      time.Time
+		ctx                      context.Context
+		email                    string
+		password                 string
+	)
+
+	createService := func() credential.CredentialContract {
+		service, err := credential.NewCredentialService(mockConfigurationService, mockStoreService, mockClockService)
+		Ω(err).Should(BeNil())
+
+		return service
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		algorithm = credential.AlgorithmArgon2id
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetPasswordHashingAlgorithm().
+			DoAndReturn(func() (string, error) { return algorithm, nil }).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetPasswordMinLength().
+			Return(12, nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetPasswordRequiredCharacterClasses().
+			Return(3, nil).
+			AnyTimes()
+
+		// The store keeps the credentials in memory, so the hashes the service persists can be verified back
+		credentials = map[string]models.PasswordCredential{}
+		mockStoreService = credentialMock.NewMockStoreContract(mockCtrl)
+		mockStoreService.
+			EXPECT().
+			ReadCredential(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, email string) (*models.PasswordCredential, error) {
+				credential, ok := credentials[email]
+				if !ok {
+					return nil, commonErrors.NewNotFoundError()
+				}
+
+				return &credential, nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			SaveCredential(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, credential *models.PasswordCredential) error {
+				credentials[credential.Email] = *credential
+
+				return nil
+			}).
+			AnyTimes()
+
+		now = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			DoAndReturn(func() time.Time { return now }).
+			AnyTimes()
+
+		ctx = context.Background()
+		email = cuid.New() + "@test.com"
+		password = "Correct-Horse-" + cuid.New()
+		sut = createService()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate CredentialService", func() {
+		When("configuration service is not provided and NewCredentialService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := credential.NewCredentialService(nil, mockStoreService, mockClockService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("store service is not provided and NewCredentialService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := credential.NewCredentialService(mockConfigurationService, nil, mockClockService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("clock service is not provided and NewCredentialService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := credential.NewCredentialService(mockConfigurationService, mockStoreService, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("CredentialService is instantiated", func() {
+		When("ValidatePassword is called with a password that satisfies the password policy", func() {
+			It("should return no error", func() {
+				Ω(sut.ValidatePassword(password)).Should(BeNil())
+			})
+		})
+
+		When("ValidatePassword is called with a password shorter than the minimum length", func() {
+			It("should return ArgumentError", func() {
+				Ω(commonErrors.IsArgumentError(sut.ValidatePassword("Sh0rt-pass"))).Should(BeTrue())
+			})
+		})
+
+		When("ValidatePassword is called with a password that has too few character classes", func() {
+			It("should return ArgumentError", func() {
+				Ω(commonErrors.IsArgumentError(sut.ValidatePassword("onlylowercaseletters"))).Should(BeTrue())
+			})
+		})
+
+		When("ValidatePassword is called with a password longer than bcrypt hashes", func() {
+			It("should only return ArgumentError if the passwords are hashed with bcrypt", func() {
+				longPassword := "Aa1-" + strings.Repeat("x", 80)
+				Ω(sut.ValidatePassword(longPassword)).Should(BeNil())
+
+				algorithm = credential.AlgorithmBcrypt
+				Ω(commonErrors.IsArgumentError(createService().ValidatePassword(longPassword))).Should(BeTrue())
+			})
+		})
+
+		When("SetPassword is called", func() {
+			It("should only persist the argon2id hash of the password", func() {
+				Ω(sut.SetPassword(ctx, email, password)).Should(BeNil())
+
+				Ω(credentials[email].PasswordHash).Should(HavePrefix("$argon2id$v=19$"))
+				Ω(credentials[email].PasswordHash).ShouldNot(ContainSubstring(password))
+				Ω(credentials[email].UpdatedAt).Should(Equal(now))
+			})
+
+			It("should reject the password that does not satisfy the password policy", func() {
+				Ω(commonErrors.IsArgumentError(sut.SetPassword(ctx, email, "weak"))).Should(BeTrue())
+				Ω(credentials).Should(BeEmpty())
+			})
+		})
+
+		When("HasPassword is called", func() {
+			It("should return whether the user has a password", func() {
+				hasPassword, err := sut.HasPassword(ctx, email)
+				Ω(err).Should(BeNil())
+				Ω(hasPassword).Should(BeFalse())
+
+				Ω(sut.SetPassword(ctx, email, password)).Should(BeNil())
+
+				hasPassword, err = sut.HasPassword(ctx, email)
+				Ω(err).Should(BeNil())
+				Ω(hasPassword).Should(BeTrue())
+			})
+		})
+
+		When("VerifyPassword is called", func() {
+			BeforeEach(func() {
+				Ω(sut.SetPassword(ctx, email, password)).Should(BeNil())
+			})
+
+			It("should match the password that is set", func() {
+				matched, err := sut.VerifyPassword(ctx, email, password)
+				Ω(err).Should(BeNil())
+				Ω(matched).Should(BeTrue())
+			})
+
+			It("should not match another password", func() {
+				matched, err := sut.VerifyPassword(ctx, email, password+"x")
+				Ω(err).Should(BeNil())
+				Ω(matched).Should(BeFalse())
+			})
+
+			It("should not match if the user has no password", func() {
+				matched, err := sut.VerifyPassword(ctx, cuid.New()+"@test.com", password)
+				Ω(err).Should(BeNil())
+				Ω(matched).Should(BeFalse())
+			})
+		})
+
+		When("VerifyPassword is called for a password hashed with another algorithm", func() {
+			It("should match the password and rehash it with the configured algorithm", func() {
+				algorithm = credential.AlgorithmBcrypt
+				Ω(createService().SetPassword(ctx, email, password)).Should(BeNil())
+				Ω(credentials[email].PasswordHash).Should(HavePrefix("$2a$"))
+
+				algorithm = credential.AlgorithmArgon2id
+				matched, err := createService().VerifyPassword(ctx, email, password)
+				Ω(err).Should(BeNil())
+				Ω(matched).Should(BeTrue())
+				Ω(credentials[email].PasswordHash).Should(HavePrefix("$argon2id$"))
+
+				matched, err = sut.VerifyPassword(ctx, email, password)
+				Ω(err).Should(BeNil())
+				Ω(matched).Should(BeTrue())
+			})
+		})
+
+		When("the passwords are hashed with PBKDF2-SHA256", func() {
+			It("should persist the PBKDF2-SHA256 hash of the password and match it", func() {
+				algorithm = credential.AlgorithmPBKDF2SHA256
+				service := createService()
+				Ω(service.SetPassword(ctx, email, password)).Should(BeNil())
+				Ω(credentials[email].PasswordHash).Should(HavePrefix("$pbkdf2-sha256$i=600000$"))
+				Ω(credentials[email].PasswordHash).ShouldNot(ContainSubstring(password))
+
+				matched, err := service.VerifyPassword(ctx, email, password)
+				Ω(err).Should(BeNil())
+				Ω(matched).Should(BeTrue())
+
+				matched, err = service.VerifyPassword(ctx, email, password+"x")
+				Ω(err).Should(BeNil())
+				Ω(matched).Should(BeFalse())
+			})
+
+			It("should rehash the password hashed with argon2id with PBKDF2-SHA256", func() {
+				Ω(sut.SetPassword(ctx, email, password)).Should(BeNil())
+
+				algorithm = credential.AlgorithmPBKDF2SHA256
+				matched, err := createService().VerifyPassword(ctx, email, password)
+				Ω(err).Should(BeNil())
+				Ω(matched).Should(BeTrue())
+				Ω(credentials[email].PasswordHash).Should(HavePrefix("$pbkdf2-sha256$"))
+			})
+		})
+
+		When("DeletePassword is called", func() {
+			It("should delete the persisted credential", func() {
+				mockStoreService.
+					EXPECT().
+					DeleteCredential(ctx, email).
+					Return(nil)
+
+				Ω(sut.DeletePassword(ctx, email)).Should(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,24 @@
+// Package deprecation implements the warnings sent to the clients that call deprecated operations or send
+// deprecated fields, so they can be tracked down and migrated before the deprecated API is removed
+package deprecation
+
+import (
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// DeprecationContract declares the service that detects and reports the use of the deprecated operations and fields
+type DeprecationContract interface {
+	// GetWarnings returns the warnings about the deprecated operation and fields the given call uses. The operations
+	// and fields are deprecated by setting the deprecated option on them in the proto files.
+	// fullMethod: Mandatory. The full name of the called method, e.g. /user.Service/ReadUser
+	// request: Optional. The received request message
+	// Returns the warnings or an empty list if the call does not use anything deprecated
+	GetWarnings(fullMethod string, request proto.Message) []*userGRPCContract.DeprecationWarning
+
+	// CreateUnaryServerInterceptor creates the interceptor that attaches the deprecation warnings to the calls as
+	// both the response header and the deprecationWarnings response field, and counts them per client
+	// Returns the new interceptor
+	CreateUnaryServerInterceptor() grpc.UnaryServerInterceptor
+}
@@ -0,0 +1,219 @@
+// Package deprecation implements the warnings sent to the clients that call deprecated operations or send
+// deprecated fields, so they can be tracked down and migrated before the deprecated API is removed
+package deprecation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	// DeprecationHeader is set to true on the calls that use a deprecated operation or field
+	DeprecationHeader = "deprecation"
+
+	// WarningHeader contains a "<subject>: <message>" value for every deprecated operation or field the call uses
+	WarningHeader = "x-deprecation-warning"
+
+	// ClientNameHeader is the optional header the clients identify themselves with in the deprecation metrics.
+	// The user agent is used instead if it is not provided.
+	ClientNameHeader = "x-client-name"
+
+	warningsFieldName = "deprecationWarnings"
+	unknownClient     = "unknown"
+)
+
+// Guidance contains what the clients should use instead of the deprecated operations and fields, keyed by their
+// full name, e.g. user.Service.ReadUser or user.ReadUserRequest.email. Add the guidance alongside setting the
+// deprecated option in the proto files.
+var Guidance = map[string]string{
+	"user.ReadUserRequest.email": "Read the user by its userID, or call ReadUserByEmail to read it by its email address",
+}
+
+var deprecatedUsageCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "user_deprecated_usage_total",
+		Help: "The number of the calls that used a deprecated operation or field grouped by the operation, the deprecated subject and the client",
+	},
+	[]string{"operation", "subject", "client"})
+
+type deprecationService struct {
+	files    *protoregistry.Files
+	guidance map[string]string
+}
+
+// NewDeprecationService creates new instance of the deprecationService, setting up all dependencies and returns the instance
+// files: Mandatory. The registry to look up the descriptors of the called operations in
+// guidance: Optional. What the clients should use instead, keyed by the full name of the deprecated operation or field
+// Returns the new service or error if something goes wrong
+func NewDeprecationService(
+	files *protoregistry.Files,
+	guidance map[string]string) (DeprecationContract, error) {
+	if files == nil {
+		return nil, commonErrors.NewArgumentNilError("files", "files is required")
+	}
+
+	if guidance == nil {
+		guidance = map[string]string{}
+	}
+
+	return &deprecationService{
+		files:    files,
+		guidance: guidance,
+	}, nil
+}
+
+// GetWarnings returns the warnings about the deprecated operation and fields the given call uses. The operations
+// and fields are deprecated by setting the deprecated option on them in the proto files.
+// fullMethod: Mandatory. The full name of the called method, e.g. /user.Service/ReadUser
+// request: Optional. The received request message
+// Returns the warnings or an empty list if the call does not use anything deprecated
+func (service *deprecationService) GetWarnings(fullMethod string, request proto.Message) []*userGRPCContract.DeprecationWarning {
+	warnings := []*userGRPCContract.DeprecationWarning{}
+
+	if method := service.findMethod(fullMethod); method != nil {
+		if options, ok := method.Options().(*descriptorpb.MethodOptions); ok && options.GetDeprecated() {
+			warnings = append(warnings, service.newWarning(method.FullName()))
+		}
+	}
+
+	if request != nil {
+		warnings = service.appendFieldWarnings(warnings, request.ProtoReflect())
+	}
+
+	return warnings
+}
+
+// CreateUnaryServerInterceptor creates the interceptor that attaches the deprecation warnings to the calls as
+// both the response header and the deprecationWarnings response field, and counts them per client
+// Returns the new interceptor
+func (service *deprecationService) CreateUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		request interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		requestMessage, _ := request.(proto.Message)
+		warnings := service.GetWarnings(info.FullMethod, requestMessage)
+		if len(warnings) == 0 {
+			return handler(ctx, request)
+		}
+
+		operation := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+		client := getClientName(ctx)
+		header := metadata.Pairs(DeprecationHeader, "true")
+		for _, warning := range warnings {
+			header.Append(WarningHeader, warning.Subject+": "+warning.Message)
+			deprecatedUsageCounter.WithLabelValues(operation, warning.Subject, client).Inc()
+		}
+
+		// The header is sent along with the trailer if the call fails before the response header is sent
+		_ = grpc.SetHeader(ctx, header)
+
+		response, err := handler(ctx, request)
+		if responseMessage, ok := response.(proto.Message); ok && err == nil && responseMessage.ProtoReflect().IsValid() {
+			setWarnings(responseMessage.ProtoReflect(), warnings)
+		}
+
+		return response, err
+	}
+}
+
+func (service *deprecationService) findMethod(fullMethod string) protoreflect.MethodDescriptor {
+	separatorIndex := strings.LastIndex(fullMethod, "/")
+	if separatorIndex <= 0 {
+		return nil
+	}
+
+	serviceName := protoreflect.FullName(strings.TrimPrefix(fullMethod[:separatorIndex], "/"))
+	descriptor, err := service.files.FindDescriptorByName(serviceName)
+	if err != nil {
+		return nil
+	}
+
+	serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil
+	}
+
+	return serviceDescriptor.Methods().ByName(protoreflect.Name(fullMethod[separatorIndex+1:]))
+}
+
+func (service *deprecationService) appendFieldWarnings(
+	warnings []*userGRPCContract.DeprecationWarning,
+	message protoreflect.Message) []*userGRPCContract.DeprecationWarning {
+	// Range only visits the populated fields, so the clients are only warned about the fields they actually send
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if options, ok := field.Options().(*descriptorpb.FieldOptions); ok && options.GetDeprecated() {
+			warnings = append(warnings, service.newWarning(field.FullName()))
+		}
+
+		if field.Message() == nil || field.IsMap() {
+			return true
+		}
+
+		if field.IsList() {
+			for index := 0; index < value.List().Len(); index++ {
+				warnings = service.appendFieldWarnings(warnings, value.List().Get(index).Message())
+			}
+		} else {
+			warnings = service.appendFieldWarnings(warnings, value.Message())
+		}
+
+		return true
+	})
+
+	return warnings
+}
+
+func (service *deprecationService) newWarning(subject protoreflect.FullName) *userGRPCContract.DeprecationWarning {
+	message, ok := service.guidance[string(subject)]
+	if !ok {
+		message = fmt.Sprintf("%s is deprecated and will be removed in a future version", subject)
+	}
+
+	return &userGRPCContract.DeprecationWarning{
+		Subject: string(subject),
+		Message: message,
+	}
+}
+
+// setWarnings sets the deprecationWarnings field of the response if the response message declares it
+func setWarnings(response protoreflect.Message, warnings []*userGRPCContract.DeprecationWarning) {
+	field := response.Descriptor().Fields().ByName(warningsFieldName)
+	if field == nil || !field.IsList() || field.Message() == nil ||
+		field.Message().FullName() != (&userGRPCContract.DeprecationWarning{}).ProtoReflect().Descriptor().FullName() {
+		return
+	}
+
+	list := response.Mutable(field).List()
+	for _, warning := range warnings {
+		list.Append(protoreflect.ValueOfMessage(warning.ProtoReflect()))
+	}
+}
+
+func getClientName(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return unknownClient
+	}
+
+	for _, header := range []string{ClientNameHeader, "user-agent"} {
+		if values := md.Get(header); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+
+	return unknownClient
+}
@@ -0,0 +1,286 @@
+package deprecation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/services/deprecation"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestDeprecationService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Deprecation Service Tests")
+}
+
+var _ = Describe("Deprecation Service Tests", func() {
+	var (
+		files               *protoregistry.Files
+		requestDescriptor   protoreflect.MessageDescriptor
+		responseDescriptor  protoreflect.MessageDescriptor
+		sut                 deprecation.DeprecationContract
+		guidance            map[string]string
+		deprecatedMethod    string
+		notDeprecatedMethod string
+	)
+
+	BeforeEach(func() {
+		files, requestDescriptor, responseDescriptor = createTestDescriptors()
+		deprecatedMethod = "/test.Service/Deprecated"
+		notDeprecatedMethod = "/test.Service/NotDeprecated"
+		guidance = map[string]string{"test.Request.email": cuid.New()}
+
+		var err error
+		sut, err = deprecation.NewDeprecationService(files, guidance)
+		Ω(err).Should(BeNil())
+	})
+
+	Context("user tries to instantiate DeprecationService", func() {
+		When("files is not provided and NewDeprecationService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := deprecation.NewDeprecationService(nil, guidance)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("guidance is not provided and NewDeprecationService is called", func() {
+			It("should instantiate the new DeprecationService", func() {
+				service, err := deprecation.NewDeprecationService(files, nil)
+				Ω(err).Should(BeNil())
+				Ω(service).ShouldNot(BeNil())
+			})
+		})
+	})
+
+	Context("DeprecationService is instantiated", func() {
+		When("a call uses nothing deprecated", func() {
+			It("should return no warning", func() {
+				request := dynamicpb.NewMessage(requestDescriptor)
+				request.Set(requestDescriptor.Fields().ByName("id"), protoreflect.ValueOfString(cuid.New()))
+
+				Ω(sut.GetWarnings(notDeprecatedMethod, request)).Should(BeEmpty())
+			})
+		})
+
+		When("a deprecated operation is called", func() {
+			It("should warn about the operation using the default message", func() {
+				warnings := sut.GetWarnings(deprecatedMethod, dynamicpb.NewMessage(requestDescriptor))
+
+				Ω(warnings).Should(HaveLen(1))
+				Ω(warnings[0].Subject).Should(Equal("test.Service.Deprecated"))
+				Ω(warnings[0].Message).Should(ContainSubstring("test.Service.Deprecated is deprecated"))
+			})
+		})
+
+		When("a deprecated field is sent", func() {
+			It("should warn about the field using the guidance", func() {
+				request := dynamicpb.NewMessage(requestDescriptor)
+				request.Set(requestDescriptor.Fields().ByName("email"), protoreflect.ValueOfString(cuid.New()))
+
+				warnings := sut.GetWarnings(notDeprecatedMethod, request)
+
+				Ω(warnings).Should(HaveLen(1))
+				Ω(warnings[0].Subject).Should(Equal("test.Request.email"))
+				Ω(warnings[0].Message).Should(Equal(guidance["test.Request.email"]))
+			})
+		})
+
+		When("a deprecated field is not sent", func() {
+			It("should not warn about the field", func() {
+				request := dynamicpb.NewMessage(requestDescriptor)
+				request.Set(requestDescriptor.Fields().ByName("email"), protoreflect.ValueOfString(""))
+
+				Ω(sut.GetWarnings(notDeprecatedMethod, request)).Should(BeEmpty())
+			})
+		})
+
+		When("a deprecated field is sent in a nested message", func() {
+			It("should warn about the nested field", func() {
+				nested := dynamicpb.NewMessage(requestDescriptor)
+				nested.Set(requestDescriptor.Fields().ByName("email"), protoreflect.ValueOfString(cuid.New()))
+				request := dynamicpb.NewMessage(requestDescriptor)
+				request.Mutable(requestDescriptor.Fields().ByName("children")).List().Append(protoreflect.ValueOfMessage(nested))
+
+				warnings := sut.GetWarnings(notDeprecatedMethod, request)
+
+				Ω(warnings).Should(HaveLen(1))
+				Ω(warnings[0].Subject).Should(Equal("test.Request.email"))
+			})
+		})
+
+		When("the interceptor intercepts a deprecated call", func() {
+			var (
+				client string
+				ctx    context.Context
+				info   *grpc.UnaryServerInfo
+			)
+
+			BeforeEach(func() {
+				client = cuid.New()
+				ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(deprecation.ClientNameHeader, client))
+				info = &grpc.UnaryServerInfo{FullMethod: deprecatedMethod}
+			})
+
+			It("should set the warnings on the response", func() {
+				response := dynamicpb.NewMessage(responseDescriptor)
+				returnedResponse, err := sut.CreateUnaryServerInterceptor()(
+					ctx,
+					dynamicpb.NewMessage(requestDescriptor),
+					info,
+					func(ctx context.Context, request interface{}) (interface{}, error) {
+						return response, nil
+					})
+
+				Ω(err).Should(BeNil())
+				warnings := returnedResponse.(proto.Message).ProtoReflect().Get(responseDescriptor.Fields().ByName("deprecationWarnings")).List()
+				Ω(warnings.Len()).Should(Equal(1))
+				Ω(warnings.Get(0).Message().Interface().(*userGRPCContract.DeprecationWarning).Subject).Should(Equal("test.Service.Deprecated"))
+			})
+
+			It("should count the deprecated usage per client", func() {
+				_, _ = sut.CreateUnaryServerInterceptor()(
+					ctx,
+					dynamicpb.NewMessage(requestDescriptor),
+					info,
+					func(ctx context.Context, request interface{}) (interface{}, error) {
+						return dynamicpb.NewMessage(responseDescriptor), nil
+					})
+
+				Ω(getDeprecatedUsageCount("Deprecated", "test.Service.Deprecated", client)).Should(Equal(float64(1)))
+			})
+
+			It("should return the error the call failed with", func() {
+				expectedErr := errors.New(cuid.New())
+				_, err := sut.CreateUnaryServerInterceptor()(
+					ctx,
+					dynamicpb.NewMessage(requestDescriptor),
+					info,
+					func(ctx context.Context, request interface{}) (interface{}, error) {
+						return nil, expectedErr
+					})
+
+				Ω(err).Should(Equal(expectedErr))
+			})
+		})
+	})
+})
+
+// createTestDescriptors creates a service with a deprecated and a not deprecated method, and a request message with
+// a deprecated field, as the user service does not deprecate anything yet
+func createTestDescriptors() (*protoregistry.Files, protoreflect.MessageDescriptor, protoreflect.MessageDescriptor) {
+	commonsDescriptor := (&userGRPCContract.DeprecationWarning{}).ProtoReflect().Descriptor().ParentFile()
+	fileDescriptor, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{commonsDescriptor.Path()},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Request"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					newField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false, false),
+					newField("email", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false, true),
+					newField("children", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".test.Request", true, false),
+				},
+			},
+			{
+				Name: proto.String("Response"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					newField("deprecationWarnings", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".user.DeprecationWarning", true, false),
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("Service"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{
+						Name:       proto.String("Deprecated"),
+						InputType:  proto.String(".test.Request"),
+						OutputType: proto.String(".test.Response"),
+						Options:    &descriptorpb.MethodOptions{Deprecated: proto.Bool(true)},
+					},
+					{
+						Name:       proto.String("NotDeprecated"),
+						InputType:  proto.String(".test.Request"),
+						OutputType: proto.String(".test.Response"),
+					},
+				},
+			},
+		},
+	}, protoregistry.GlobalFiles)
+	Ω(err).Should(BeNil())
+
+	files := &protoregistry.Files{}
+	Ω(files.RegisterFile(fileDescriptor)).Should(Succeed())
+
+	return files, fileDescriptor.Messages().ByName("Request"), fileDescriptor.Messages().ByName("Response")
+}
+
+func newField(
+	name string,
+	number int32,
+	fieldType descriptorpb.FieldDescriptorProto_Type,
+	typeName string,
+	repeated bool,
+	deprecated bool) *descriptorpb.FieldDescriptorProto {
+	label := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	if repeated {
+		label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	}
+
+	field := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    label.Enum(),
+		Type:     fieldType.Enum(),
+		Options:  &descriptorpb.FieldOptions{Deprecated: proto.Bool(deprecated)},
+	}
+
+	if typeName != "" {
+		field.TypeName = proto.String(typeName)
+	}
+
+	return field
+}
+
+func getDeprecatedUsageCount(operation, subject, client string) float64 {
+	metricFamilies, err := prometheus.DefaultGatherer.Gather()
+	Ω(err).Should(BeNil())
+
+	for _, metricFamily := range metricFamilies {
+		if metricFamily.GetName() != "user_deprecated_usage_total" {
+			continue
+		}
+
+		for _, metric := range metricFamily.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			if labels["operation"] == operation && labels["subject"] == subject && labels["client"] == client {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+
+	return 0
+}
@@ -4,7 +4,7 @@ package endpoint
 import "github.com/go-kit/kit/endpoint"
 
 // EndpointCreatorContract declares the contract that creates endpoints to create new user,
-// read, update and delete existing users.
+// read, update, delete and restore existing users.
 type EndpointCreatorContract interface {
 	// CreateUserEndpoint creates Create User endpoint
 	// Returns the Create User endpoint
@@ -14,11 +14,263 @@ type EndpointCreatorContract interface {
 	// Returns the Read User endpoint
 	ReadUserEndpoint() endpoint.Endpoint
 
+	// ReadUserByEmailEndpoint creates Read User By Email endpoint
+	// Returns the Read User By Email endpoint
+	ReadUserByEmailEndpoint() endpoint.Endpoint
+
+	// ReadUsersEndpoint creates Read Users endpoint
+	// Returns the Read Users endpoint
+	ReadUsersEndpoint() endpoint.Endpoint
+
 	// UpdateUserEndpoint creates Update User endpoint
 	// Returns the Update User endpoint
 	UpdateUserEndpoint() endpoint.Endpoint
 
+	// ChangeEmailEndpoint creates Change Email endpoint
+	// Returns the Change Email endpoint
+	ChangeEmailEndpoint() endpoint.Endpoint
+
+	// RequestEmailChangeEndpoint creates Request Email Change endpoint
+	// Returns the Request Email Change endpoint
+	RequestEmailChangeEndpoint() endpoint.Endpoint
+
+	// ConfirmEmailChangeEndpoint creates Confirm Email Change endpoint
+	// Returns the Confirm Email Change endpoint
+	ConfirmEmailChangeEndpoint() endpoint.Endpoint
+
 	// DeleteUserEndpoint creates Delete User endpoint
 	// Returns the Delete User endpoint
 	DeleteUserEndpoint() endpoint.Endpoint
+
+	// RestoreUserEndpoint creates Restore User endpoint
+	// Returns the Restore User endpoint
+	RestoreUserEndpoint() endpoint.Endpoint
+
+	// SearchEndpoint creates Search User endpoint
+	// Returns the Search User endpoint
+	SearchEndpoint() endpoint.Endpoint
+
+	// StreamSearchEndpoint creates Stream Search User endpoint
+	// Returns the Stream Search User endpoint
+	StreamSearchEndpoint() endpoint.Endpoint
+
+	// GetSagaStatusEndpoint creates Get Saga Status endpoint
+	// Returns the Get Saga Status endpoint
+	GetSagaStatusEndpoint() endpoint.Endpoint
+
+	// ListAuditRecordsEndpoint creates List Audit Records endpoint
+	// Returns the List Audit Records endpoint
+	ListAuditRecordsEndpoint() endpoint.Endpoint
+
+	// GetEffectiveConfigurationEndpoint creates Get Effective Configuration endpoint
+	// Returns the Get Effective Configuration endpoint
+	GetEffectiveConfigurationEndpoint() endpoint.Endpoint
+
+	// GetEnabledFeaturesEndpoint creates Get Enabled Features endpoint
+	// Returns the Get Enabled Features endpoint
+	GetEnabledFeaturesEndpoint() endpoint.Endpoint
+
+	// PreviewBulkUpdateUsersEndpoint creates Preview Bulk Update Users endpoint
+	// Returns the Preview Bulk Update Users endpoint
+	PreviewBulkUpdateUsersEndpoint() endpoint.Endpoint
+
+	// BulkUpdateUsersEndpoint creates Bulk Update Users endpoint
+	// Returns the Bulk Update Users endpoint
+	BulkUpdateUsersEndpoint() endpoint.Endpoint
+
+	// PurgeByLabelEndpoint creates Purge By Label endpoint
+	// Returns the Purge By Label endpoint
+	PurgeByLabelEndpoint() endpoint.Endpoint
+
+	// GetOutboxLagEndpoint creates Get Outbox Lag endpoint
+	// Returns the Get Outbox Lag endpoint
+	GetOutboxLagEndpoint() endpoint.Endpoint
+
+	// ListPendingEventsEndpoint creates List Pending Events endpoint
+	// Returns the List Pending Events endpoint
+	ListPendingEventsEndpoint() endpoint.Endpoint
+
+	// ForceFlushEndpoint creates Force Flush endpoint
+	// Returns the Force Flush endpoint
+	ForceFlushEndpoint() endpoint.Endpoint
+
+	// GetUserPreferencesEndpoint creates Get User Preferences endpoint
+	// Returns the Get User Preferences endpoint
+	GetUserPreferencesEndpoint() endpoint.Endpoint
+
+	// UpdateUserPreferencesEndpoint creates Update User Preferences endpoint
+	// Returns the Update User Preferences endpoint
+	UpdateUserPreferencesEndpoint() endpoint.Endpoint
+
+	// GetUserAvatarEndpoint creates Get User Avatar endpoint
+	// Returns the Get User Avatar endpoint
+	GetUserAvatarEndpoint() endpoint.Endpoint
+
+	// SetUserAvatarEndpoint creates Set User Avatar endpoint
+	// Returns the Set User Avatar endpoint
+	SetUserAvatarEndpoint() endpoint.Endpoint
+
+	// ExportPersonalDataEndpoint creates Export Personal Data endpoint
+	// Returns the Export Personal Data endpoint
+	ExportPersonalDataEndpoint() endpoint.Endpoint
+
+	// EraseUserEndpoint creates Erase User endpoint
+	// Returns the Erase User endpoint
+	EraseUserEndpoint() endpoint.Endpoint
+
+	// AddUserToTenantEndpoint creates Add User To Tenant endpoint
+	// Returns the Add User To Tenant endpoint
+	AddUserToTenantEndpoint() endpoint.Endpoint
+
+	// RemoveUserFromTenantEndpoint creates Remove User From Tenant endpoint
+	// Returns the Remove User From Tenant endpoint
+	RemoveUserFromTenantEndpoint() endpoint.Endpoint
+
+	// ListUserTenantsEndpoint creates List User Tenants endpoint
+	// Returns the List User Tenants endpoint
+	ListUserTenantsEndpoint() endpoint.Endpoint
+
+	// GetReplicationStatusEndpoint creates Get Replication Status endpoint
+	// Returns the Get Replication Status endpoint
+	GetReplicationStatusEndpoint() endpoint.Endpoint
+
+	// ExportUsersEndpoint creates Export Users endpoint
+	// Returns the Export Users endpoint
+	ExportUsersEndpoint() endpoint.Endpoint
+
+	// IssueMagicLinkEndpoint creates Issue Magic Link endpoint
+	// Returns the Issue Magic Link endpoint
+	IssueMagicLinkEndpoint() endpoint.Endpoint
+
+	// RedeemMagicLinkEndpoint creates Redeem Magic Link endpoint
+	// Returns the Redeem Magic Link endpoint
+	RedeemMagicLinkEndpoint() endpoint.Endpoint
+
+	// SendVerificationEmailEndpoint creates Send Verification Email endpoint
+	// Returns the Send Verification Email endpoint
+	SendVerificationEmailEndpoint() endpoint.Endpoint
+
+	// VerifyEmailEndpoint creates Verify Email endpoint
+	// Returns the Verify Email endpoint
+	VerifyEmailEndpoint() endpoint.Endpoint
+
+	// SetPasswordEndpoint creates Set Password endpoint
+	// Returns the Set Password endpoint
+	SetPasswordEndpoint() endpoint.Endpoint
+
+	// ChangePasswordEndpoint creates Change Password endpoint
+	// Returns the Change Password endpoint
+	ChangePasswordEndpoint() endpoint.Endpoint
+
+	// VerifyPasswordEndpoint creates Verify Password endpoint
+	// Returns the Verify Password endpoint
+	VerifyPasswordEndpoint() endpoint.Endpoint
+
+	// WatchUsersEndpoint creates Watch Users endpoint
+	// Returns the Watch Users endpoint
+	WatchUsersEndpoint() endpoint.Endpoint
+
+	// CreateAPIKeyEndpoint creates Create API Key endpoint
+	// Returns the Create API Key endpoint
+	CreateAPIKeyEndpoint() endpoint.Endpoint
+
+	// ListAPIKeysEndpoint creates List API Keys endpoint
+	// Returns the List API Keys endpoint
+	ListAPIKeysEndpoint() endpoint.Endpoint
+
+	// RevokeAPIKeyEndpoint creates Revoke API Key endpoint
+	// Returns the Revoke API Key endpoint
+	RevokeAPIKeyEndpoint() endpoint.Endpoint
+
+	// RecordLoginAttemptEndpoint creates Record Login Attempt endpoint
+	// Returns the Record Login Attempt endpoint
+	RecordLoginAttemptEndpoint() endpoint.Endpoint
+
+	// UnlockUserEndpoint creates Unlock User endpoint
+	// Returns the Unlock User endpoint
+	UnlockUserEndpoint() endpoint.Endpoint
+
+	// EnrollMFAEndpoint creates Enroll MFA endpoint
+	// Returns the Enroll MFA endpoint
+	EnrollMFAEndpoint() endpoint.Endpoint
+
+	// ListMFAMethodsEndpoint creates List MFA Methods endpoint
+	// Returns the List MFA Methods endpoint
+	ListMFAMethodsEndpoint() endpoint.Endpoint
+
+	// RemoveMFAMethodEndpoint creates Remove MFA Method endpoint
+	// Returns the Remove MFA Method endpoint
+	RemoveMFAMethodEndpoint() endpoint.Endpoint
+
+	// RecordConsentEndpoint creates Record Consent endpoint
+	// Returns the Record Consent endpoint
+	RecordConsentEndpoint() endpoint.Endpoint
+
+	// ListConsentsEndpoint creates List Consents endpoint
+	// Returns the List Consents endpoint
+	ListConsentsEndpoint() endpoint.Endpoint
+
+	// CreateGroupEndpoint creates Create Group endpoint
+	// Returns the Create Group endpoint
+	CreateGroupEndpoint() endpoint.Endpoint
+
+	// ReadGroupEndpoint creates Read Group endpoint
+	// Returns the Read Group endpoint
+	ReadGroupEndpoint() endpoint.Endpoint
+
+	// UpdateGroupEndpoint creates Update Group endpoint
+	// Returns the Update Group endpoint
+	UpdateGroupEndpoint() endpoint.Endpoint
+
+	// DeleteGroupEndpoint creates Delete Group endpoint
+	// Returns the Delete Group endpoint
+	DeleteGroupEndpoint() endpoint.Endpoint
+
+	// ListGroupsEndpoint creates List Groups endpoint
+	// Returns the List Groups endpoint
+	ListGroupsEndpoint() endpoint.Endpoint
+
+	// AddUserToGroupEndpoint creates Add User To Group endpoint
+	// Returns the Add User To Group endpoint
+	AddUserToGroupEndpoint() endpoint.Endpoint
+
+	// RemoveUserFromGroupEndpoint creates Remove User From Group endpoint
+	// Returns the Remove User From Group endpoint
+	RemoveUserFromGroupEndpoint() endpoint.Endpoint
+
+	// InviteUserEndpoint creates Invite User endpoint
+	// Returns the Invite User endpoint
+	InviteUserEndpoint() endpoint.Endpoint
+
+	// AcceptInvitationEndpoint creates Accept Invitation endpoint
+	// Returns the Accept Invitation endpoint
+	AcceptInvitationEndpoint() endpoint.Endpoint
+
+	// RegisterSessionEndpoint creates Register Session endpoint
+	// Returns the Register Session endpoint
+	RegisterSessionEndpoint() endpoint.Endpoint
+
+	// ListSessionsEndpoint creates List Sessions endpoint
+	// Returns the List Sessions endpoint
+	ListSessionsEndpoint() endpoint.Endpoint
+
+	// RevokeSessionEndpoint creates Revoke Session endpoint
+	// Returns the Revoke Session endpoint
+	RevokeSessionEndpoint() endpoint.Endpoint
+
+	// CreateWebhookSubscriptionEndpoint creates Create Webhook Subscription endpoint
+	// Returns the Create Webhook Subscription endpoint
+	CreateWebhookSubscriptionEndpoint() endpoint.Endpoint
+
+	// ListWebhookSubscriptionsEndpoint creates List Webhook Subscriptions endpoint
+	// Returns the List Webhook Subscriptions endpoint
+	ListWebhookSubscriptionsEndpoint() endpoint.Endpoint
+
+	// DeleteWebhookSubscriptionEndpoint creates Delete Webhook Subscription endpoint
+	// Returns the Delete Webhook Subscription endpoint
+	DeleteWebhookSubscriptionEndpoint() endpoint.Endpoint
+
+	// ListWebhookDeliveriesEndpoint creates List Webhook Deliveries endpoint
+	// Returns the List Webhook Deliveries endpoint
+	ListWebhookDeliveriesEndpoint() endpoint.Endpoint
 }
@@ -34,6 +34,132 @@ func (m *MockEndpointCreatorContract) EXPECT() *MockEndpointCreatorContractMockR
 	return m.recorder
 }
 
+// AcceptInvitationEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) AcceptInvitationEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitationEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// AcceptInvitationEndpoint indicates an expected call of AcceptInvitationEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) AcceptInvitationEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitationEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).AcceptInvitationEndpoint))
+}
+
+// AddUserToGroupEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) AddUserToGroupEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserToGroupEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// AddUserToGroupEndpoint indicates an expected call of AddUserToGroupEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) AddUserToGroupEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToGroupEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).AddUserToGroupEndpoint))
+}
+
+// AddUserToTenantEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) AddUserToTenantEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserToTenantEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// AddUserToTenantEndpoint indicates an expected call of AddUserToTenantEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) AddUserToTenantEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToTenantEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).AddUserToTenantEndpoint))
+}
+
+// BulkUpdateUsersEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) BulkUpdateUsersEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateUsersEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// BulkUpdateUsersEndpoint indicates an expected call of BulkUpdateUsersEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) BulkUpdateUsersEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateUsersEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).BulkUpdateUsersEndpoint))
+}
+
+// ChangeEmailEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ChangeEmailEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmailEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ChangeEmailEndpoint indicates an expected call of ChangeEmailEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ChangeEmailEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmailEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ChangeEmailEndpoint))
+}
+
+// ChangePasswordEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ChangePasswordEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePasswordEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ChangePasswordEndpoint indicates an expected call of ChangePasswordEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ChangePasswordEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePasswordEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ChangePasswordEndpoint))
+}
+
+// ConfirmEmailChangeEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ConfirmEmailChangeEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmailChangeEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ConfirmEmailChangeEndpoint indicates an expected call of ConfirmEmailChangeEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ConfirmEmailChangeEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailChangeEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ConfirmEmailChangeEndpoint))
+}
+
+// CreateAPIKeyEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) CreateAPIKeyEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKeyEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// CreateAPIKeyEndpoint indicates an expected call of CreateAPIKeyEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) CreateAPIKeyEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKeyEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).CreateAPIKeyEndpoint))
+}
+
+// CreateGroupEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) CreateGroupEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroupEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// CreateGroupEndpoint indicates an expected call of CreateGroupEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) CreateGroupEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroupEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).CreateGroupEndpoint))
+}
+
 // CreateUserEndpoint mocks base method.
 func (m *MockEndpointCreatorContract) CreateUserEndpoint() endpoint.Endpoint {
 	m.ctrl.T.Helper()
@@ -48,6 +174,34 @@ func (mr *MockEndpointCreatorContractMockRecorder) CreateUserEndpoint() *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).CreateUserEndpoint))
 }
 
+// CreateWebhookSubscriptionEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) CreateWebhookSubscriptionEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookSubscriptionEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// CreateWebhookSubscriptionEndpoint indicates an expected call of CreateWebhookSubscriptionEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) CreateWebhookSubscriptionEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookSubscriptionEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).CreateWebhookSubscriptionEndpoint))
+}
+
+// DeleteGroupEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) DeleteGroupEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroupEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// DeleteGroupEndpoint indicates an expected call of DeleteGroupEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) DeleteGroupEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroupEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).DeleteGroupEndpoint))
+}
+
 // DeleteUserEndpoint mocks base method.
 func (m *MockEndpointCreatorContract) DeleteUserEndpoint() endpoint.Endpoint {
 	m.ctrl.T.Helper()
@@ -62,6 +216,412 @@ func (mr *MockEndpointCreatorContractMockRecorder) DeleteUserEndpoint() *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).DeleteUserEndpoint))
 }
 
+// DeleteWebhookSubscriptionEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) DeleteWebhookSubscriptionEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookSubscriptionEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// DeleteWebhookSubscriptionEndpoint indicates an expected call of DeleteWebhookSubscriptionEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) DeleteWebhookSubscriptionEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookSubscriptionEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).DeleteWebhookSubscriptionEndpoint))
+}
+
+// EnrollMFAEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) EnrollMFAEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollMFAEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// EnrollMFAEndpoint indicates an expected call of EnrollMFAEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) EnrollMFAEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollMFAEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).EnrollMFAEndpoint))
+}
+
+// EraseUserEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) EraseUserEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUserEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// EraseUserEndpoint indicates an expected call of EraseUserEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) EraseUserEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUserEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).EraseUserEndpoint))
+}
+
+// ExportPersonalDataEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ExportPersonalDataEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportPersonalDataEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ExportPersonalDataEndpoint indicates an expected call of ExportPersonalDataEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ExportPersonalDataEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportPersonalDataEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ExportPersonalDataEndpoint))
+}
+
+// ExportUsersEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ExportUsersEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUsersEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ExportUsersEndpoint indicates an expected call of ExportUsersEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ExportUsersEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUsersEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ExportUsersEndpoint))
+}
+
+// ForceFlushEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ForceFlushEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceFlushEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ForceFlushEndpoint indicates an expected call of ForceFlushEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ForceFlushEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceFlushEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ForceFlushEndpoint))
+}
+
+// GetEffectiveConfigurationEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) GetEffectiveConfigurationEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectiveConfigurationEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// GetEffectiveConfigurationEndpoint indicates an expected call of GetEffectiveConfigurationEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) GetEffectiveConfigurationEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectiveConfigurationEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).GetEffectiveConfigurationEndpoint))
+}
+
+// GetEnabledFeaturesEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) GetEnabledFeaturesEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledFeaturesEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// GetEnabledFeaturesEndpoint indicates an expected call of GetEnabledFeaturesEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) GetEnabledFeaturesEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledFeaturesEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).GetEnabledFeaturesEndpoint))
+}
+
+// GetOutboxLagEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) GetOutboxLagEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxLagEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// GetOutboxLagEndpoint indicates an expected call of GetOutboxLagEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) GetOutboxLagEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxLagEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).GetOutboxLagEndpoint))
+}
+
+// GetReplicationStatusEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) GetReplicationStatusEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplicationStatusEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// GetReplicationStatusEndpoint indicates an expected call of GetReplicationStatusEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) GetReplicationStatusEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicationStatusEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).GetReplicationStatusEndpoint))
+}
+
+// GetSagaStatusEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) GetSagaStatusEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSagaStatusEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// GetSagaStatusEndpoint indicates an expected call of GetSagaStatusEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) GetSagaStatusEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSagaStatusEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).GetSagaStatusEndpoint))
+}
+
+// GetUserAvatarEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) GetUserAvatarEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAvatarEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// GetUserAvatarEndpoint indicates an expected call of GetUserAvatarEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) GetUserAvatarEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAvatarEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).GetUserAvatarEndpoint))
+}
+
+// GetUserPreferencesEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) GetUserPreferencesEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPreferencesEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// GetUserPreferencesEndpoint indicates an expected call of GetUserPreferencesEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) GetUserPreferencesEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPreferencesEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).GetUserPreferencesEndpoint))
+}
+
+// InviteUserEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) InviteUserEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteUserEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// InviteUserEndpoint indicates an expected call of InviteUserEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) InviteUserEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteUserEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).InviteUserEndpoint))
+}
+
+// IssueMagicLinkEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) IssueMagicLinkEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueMagicLinkEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// IssueMagicLinkEndpoint indicates an expected call of IssueMagicLinkEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) IssueMagicLinkEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueMagicLinkEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).IssueMagicLinkEndpoint))
+}
+
+// ListAPIKeysEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ListAPIKeysEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeysEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ListAPIKeysEndpoint indicates an expected call of ListAPIKeysEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ListAPIKeysEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeysEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ListAPIKeysEndpoint))
+}
+
+// ListAuditRecordsEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ListAuditRecordsEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditRecordsEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ListAuditRecordsEndpoint indicates an expected call of ListAuditRecordsEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ListAuditRecordsEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditRecordsEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ListAuditRecordsEndpoint))
+}
+
+// ListConsentsEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ListConsentsEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConsentsEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ListConsentsEndpoint indicates an expected call of ListConsentsEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ListConsentsEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConsentsEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ListConsentsEndpoint))
+}
+
+// ListGroupsEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ListGroupsEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupsEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ListGroupsEndpoint indicates an expected call of ListGroupsEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ListGroupsEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupsEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ListGroupsEndpoint))
+}
+
+// ListMFAMethodsEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ListMFAMethodsEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMFAMethodsEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ListMFAMethodsEndpoint indicates an expected call of ListMFAMethodsEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ListMFAMethodsEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMFAMethodsEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ListMFAMethodsEndpoint))
+}
+
+// ListPendingEventsEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ListPendingEventsEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingEventsEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ListPendingEventsEndpoint indicates an expected call of ListPendingEventsEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ListPendingEventsEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingEventsEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ListPendingEventsEndpoint))
+}
+
+// ListSessionsEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ListSessionsEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessionsEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ListSessionsEndpoint indicates an expected call of ListSessionsEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ListSessionsEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessionsEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ListSessionsEndpoint))
+}
+
+// ListUserTenantsEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ListUserTenantsEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserTenantsEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ListUserTenantsEndpoint indicates an expected call of ListUserTenantsEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ListUserTenantsEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserTenantsEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ListUserTenantsEndpoint))
+}
+
+// ListWebhookDeliveriesEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ListWebhookDeliveriesEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveriesEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ListWebhookDeliveriesEndpoint indicates an expected call of ListWebhookDeliveriesEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ListWebhookDeliveriesEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveriesEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ListWebhookDeliveriesEndpoint))
+}
+
+// ListWebhookSubscriptionsEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ListWebhookSubscriptionsEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookSubscriptionsEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ListWebhookSubscriptionsEndpoint indicates an expected call of ListWebhookSubscriptionsEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ListWebhookSubscriptionsEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookSubscriptionsEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ListWebhookSubscriptionsEndpoint))
+}
+
+// PreviewBulkUpdateUsersEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) PreviewBulkUpdateUsersEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewBulkUpdateUsersEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// PreviewBulkUpdateUsersEndpoint indicates an expected call of PreviewBulkUpdateUsersEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) PreviewBulkUpdateUsersEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewBulkUpdateUsersEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).PreviewBulkUpdateUsersEndpoint))
+}
+
+// PurgeByLabelEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) PurgeByLabelEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeByLabelEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// PurgeByLabelEndpoint indicates an expected call of PurgeByLabelEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) PurgeByLabelEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeByLabelEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).PurgeByLabelEndpoint))
+}
+
+// ReadGroupEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ReadGroupEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadGroupEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ReadGroupEndpoint indicates an expected call of ReadGroupEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ReadGroupEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadGroupEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ReadGroupEndpoint))
+}
+
+// ReadUserByEmailEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ReadUserByEmailEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUserByEmailEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ReadUserByEmailEndpoint indicates an expected call of ReadUserByEmailEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ReadUserByEmailEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserByEmailEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ReadUserByEmailEndpoint))
+}
+
 // ReadUserEndpoint mocks base method.
 func (m *MockEndpointCreatorContract) ReadUserEndpoint() endpoint.Endpoint {
 	m.ctrl.T.Helper()
@@ -76,6 +636,272 @@ func (mr *MockEndpointCreatorContractMockRecorder) ReadUserEndpoint() *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ReadUserEndpoint))
 }
 
+// ReadUsersEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) ReadUsersEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUsersEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// ReadUsersEndpoint indicates an expected call of ReadUsersEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) ReadUsersEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUsersEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).ReadUsersEndpoint))
+}
+
+// RecordConsentEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) RecordConsentEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordConsentEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// RecordConsentEndpoint indicates an expected call of RecordConsentEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) RecordConsentEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordConsentEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).RecordConsentEndpoint))
+}
+
+// RecordLoginAttemptEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) RecordLoginAttemptEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLoginAttemptEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// RecordLoginAttemptEndpoint indicates an expected call of RecordLoginAttemptEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) RecordLoginAttemptEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoginAttemptEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).RecordLoginAttemptEndpoint))
+}
+
+// RedeemMagicLinkEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) RedeemMagicLinkEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemMagicLinkEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// RedeemMagicLinkEndpoint indicates an expected call of RedeemMagicLinkEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) RedeemMagicLinkEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemMagicLinkEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).RedeemMagicLinkEndpoint))
+}
+
+// RegisterSessionEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) RegisterSessionEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterSessionEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// RegisterSessionEndpoint indicates an expected call of RegisterSessionEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) RegisterSessionEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSessionEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).RegisterSessionEndpoint))
+}
+
+// RemoveMFAMethodEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) RemoveMFAMethodEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMFAMethodEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// RemoveMFAMethodEndpoint indicates an expected call of RemoveMFAMethodEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) RemoveMFAMethodEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMFAMethodEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).RemoveMFAMethodEndpoint))
+}
+
+// RemoveUserFromGroupEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) RemoveUserFromGroupEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserFromGroupEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// RemoveUserFromGroupEndpoint indicates an expected call of RemoveUserFromGroupEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) RemoveUserFromGroupEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromGroupEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).RemoveUserFromGroupEndpoint))
+}
+
+// RemoveUserFromTenantEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) RemoveUserFromTenantEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserFromTenantEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// RemoveUserFromTenantEndpoint indicates an expected call of RemoveUserFromTenantEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) RemoveUserFromTenantEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromTenantEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).RemoveUserFromTenantEndpoint))
+}
+
+// RequestEmailChangeEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) RequestEmailChangeEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestEmailChangeEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// RequestEmailChangeEndpoint indicates an expected call of RequestEmailChangeEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) RequestEmailChangeEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmailChangeEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).RequestEmailChangeEndpoint))
+}
+
+// RestoreUserEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) RestoreUserEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUserEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// RestoreUserEndpoint indicates an expected call of RestoreUserEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) RestoreUserEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUserEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).RestoreUserEndpoint))
+}
+
+// RevokeAPIKeyEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) RevokeAPIKeyEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKeyEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// RevokeAPIKeyEndpoint indicates an expected call of RevokeAPIKeyEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) RevokeAPIKeyEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKeyEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).RevokeAPIKeyEndpoint))
+}
+
+// RevokeSessionEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) RevokeSessionEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessionEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// RevokeSessionEndpoint indicates an expected call of RevokeSessionEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) RevokeSessionEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessionEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).RevokeSessionEndpoint))
+}
+
+// SearchEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) SearchEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// SearchEndpoint indicates an expected call of SearchEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) SearchEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).SearchEndpoint))
+}
+
+// SendVerificationEmailEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) SendVerificationEmailEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerificationEmailEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// SendVerificationEmailEndpoint indicates an expected call of SendVerificationEmailEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) SendVerificationEmailEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerificationEmailEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).SendVerificationEmailEndpoint))
+}
+
+// SetPasswordEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) SetPasswordEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPasswordEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// SetPasswordEndpoint indicates an expected call of SetPasswordEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) SetPasswordEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPasswordEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).SetPasswordEndpoint))
+}
+
+// SetUserAvatarEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) SetUserAvatarEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserAvatarEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// SetUserAvatarEndpoint indicates an expected call of SetUserAvatarEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) SetUserAvatarEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserAvatarEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).SetUserAvatarEndpoint))
+}
+
+// StreamSearchEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) StreamSearchEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamSearchEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// StreamSearchEndpoint indicates an expected call of StreamSearchEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) StreamSearchEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSearchEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).StreamSearchEndpoint))
+}
+
+// UnlockUserEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) UnlockUserEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUserEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// UnlockUserEndpoint indicates an expected call of UnlockUserEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) UnlockUserEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUserEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).UnlockUserEndpoint))
+}
+
+// UpdateGroupEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) UpdateGroupEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroupEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// UpdateGroupEndpoint indicates an expected call of UpdateGroupEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) UpdateGroupEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).UpdateGroupEndpoint))
+}
+
 // UpdateUserEndpoint mocks base method.
 func (m *MockEndpointCreatorContract) UpdateUserEndpoint() endpoint.Endpoint {
 	m.ctrl.T.Helper()
@@ -89,3 +915,59 @@ func (mr *MockEndpointCreatorContractMockRecorder) UpdateUserEndpoint() *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).UpdateUserEndpoint))
 }
+
+// UpdateUserPreferencesEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) UpdateUserPreferencesEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPreferencesEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// UpdateUserPreferencesEndpoint indicates an expected call of UpdateUserPreferencesEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) UpdateUserPreferencesEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPreferencesEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).UpdateUserPreferencesEndpoint))
+}
+
+// VerifyEmailEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) VerifyEmailEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmailEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// VerifyEmailEndpoint indicates an expected call of VerifyEmailEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) VerifyEmailEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmailEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).VerifyEmailEndpoint))
+}
+
+// VerifyPasswordEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) VerifyPasswordEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPasswordEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// VerifyPasswordEndpoint indicates an expected call of VerifyPasswordEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) VerifyPasswordEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPasswordEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).VerifyPasswordEndpoint))
+}
+
+// WatchUsersEndpoint mocks base method.
+func (m *MockEndpointCreatorContract) WatchUsersEndpoint() endpoint.Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchUsersEndpoint")
+	ret0, _ := ret[0].(endpoint.Endpoint)
+	return ret0
+}
+
+// WatchUsersEndpoint indicates an expected call of WatchUsersEndpoint.
+func (mr *MockEndpointCreatorContractMockRecorder) WatchUsersEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchUsersEndpoint", reflect.TypeOf((*MockEndpointCreatorContract)(nil).WatchUsersEndpoint))
+}
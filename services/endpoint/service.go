@@ -3,35 +3,74 @@ package endpoint
 
 import (
 	"context"
+	"time"
 
 	"github.com/decentralized-cloud/user/models"
 	"github.com/decentralized-cloud/user/services/business"
+	"github.com/decentralized-cloud/user/services/canary"
+	"github.com/decentralized-cloud/user/services/configuration"
 	"github.com/go-kit/kit/endpoint"
 	commonErrors "github.com/micro-business/go-core/system/errors"
 )
 
+// streamingEndpoints are the endpoints the calls to run as long as the client reads the stream, so the default
+// timeout does not apply to them and they are only cancelled after the timeout set for them
+var streamingEndpoints = map[string]bool{
+	"StreamSearch": true,
+	"ExportUsers":  true,
+	"WatchUsers":   true,
+}
+
 type endpointCreatorService struct {
-	businessService business.BusinessContract
+	businessService         business.BusinessContract
+	canaryValidationService canary.CanaryValidationContract
+	defaultTimeout          time.Duration
+	timeouts                map[string]time.Duration
 }
 
 // NewEndpointCreatorService creates new instance of the EndpointCreatorService, setting up all dependencies and returns the instance
 // businessService: Mandatory. Reference to the instance of the User  service
+// canaryValidationService: Mandatory. Reference to the service that applies the validation rules being rolled out
+// configurationService: Mandatory. Reference to the service that provides the timeouts of the endpoints
 // Returns the new service or error if something goes wrong
 func NewEndpointCreatorService(
-	businessService business.BusinessContract) (EndpointCreatorContract, error) {
+	businessService business.BusinessContract,
+	canaryValidationService canary.CanaryValidationContract,
+	configurationService configuration.ConfigurationContract) (EndpointCreatorContract, error) {
 	if businessService == nil {
 		return nil, commonErrors.NewArgumentNilError("businessService", "businessService is required")
 	}
 
+	if canaryValidationService == nil {
+		return nil, commonErrors.NewArgumentNilError("canaryValidationService", "canaryValidationService is required")
+	}
+
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	defaultTimeout, err := configurationService.GetEndpointDefaultTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	timeouts, err := configurationService.GetEndpointTimeouts()
+	if err != nil {
+		return nil, err
+	}
+
 	return &endpointCreatorService{
-		businessService: businessService,
+		businessService:         businessService,
+		canaryValidationService: canaryValidationService,
+		defaultTimeout:          defaultTimeout,
+		timeouts:                timeouts,
 	}, nil
 }
 
 // CreateUserEndpoint creates Create User endpoint
 // Returns the Create User endpoint
 func (service *endpointCreatorService) CreateUserEndpoint() endpoint.Endpoint {
-	return func(ctx context.Context, request interface{}) (interface{}, error) {
+	return service.withTimeout("CreateUser", func(ctx context.Context, request interface{}) (interface{}, error) {
 		if ctx == nil {
 			return &business.CreateUserResponse{
 				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
@@ -50,18 +89,24 @@ func (service *endpointCreatorService) CreateUserEndpoint() endpoint.Endpoint {
 
 		if err := castedRequest.Validate(); err != nil {
 			return &business.CreateUserResponse{
-				Err: commonErrors.NewArgumentErrorWithError("request", "", err),
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("CreateUser", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.CreateUserResponse{
+				Err: business.NewRequestValidationError(err),
 			}, nil
 		}
 
 		return service.businessService.CreateUser(ctx, castedRequest)
-	}
+	})
 }
 
 // ReadUserEndpoint creates Read User endpoint
 // Returns the Read User endpoint
 func (service *endpointCreatorService) ReadUserEndpoint() endpoint.Endpoint {
-	return func(ctx context.Context, request interface{}) (interface{}, error) {
+	return service.withTimeout("ReadUser", func(ctx context.Context, request interface{}) (interface{}, error) {
 		if ctx == nil {
 			return &business.ReadUserResponse{
 				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
@@ -77,18 +122,97 @@ func (service *endpointCreatorService) ReadUserEndpoint() endpoint.Endpoint {
 		castedRequest := request.(*business.ReadUserRequest)
 		if err := castedRequest.Validate(); err != nil {
 			return &business.ReadUserResponse{
-				Err: commonErrors.NewArgumentErrorWithError("request", "", err),
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("ReadUser", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.ReadUserResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		response, err := service.businessService.ReadUser(ctx, castedRequest)
+		if err != nil || response.Err != nil {
+			return response, err
+		}
+
+		// The ownership of the users read by their ID could only be verified once they are read, the users of the
+		// others are reported as not found so the callers can not tell which IDs exist
+		if parsedToken, ok := ctx.Value(models.ContextKeyParsedToken).(models.ParsedToken); ok && parsedToken.OwnerOnly && parsedToken.Email != response.Email {
+			return &business.ReadUserResponse{
+				Err: commonErrors.NewNotFoundError(),
+			}, nil
+		}
+
+		return response, nil
+	})
+}
+
+// ReadUserByEmailEndpoint creates Read User By Email endpoint
+// Returns the Read User By Email endpoint
+func (service *endpointCreatorService) ReadUserByEmailEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ReadUserByEmail", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ReadUserResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ReadUserResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ReadUserRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ReadUserResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("ReadUserByEmail", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.ReadUserResponse{
+				Err: business.NewRequestValidationError(err),
 			}, nil
 		}
 
 		return service.businessService.ReadUser(ctx, castedRequest)
-	}
+	})
+}
+
+// ReadUsersEndpoint creates Read Users endpoint
+// Returns the Read Users endpoint
+func (service *endpointCreatorService) ReadUsersEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ReadUsers", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ReadUsersResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ReadUsersResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ReadUsersRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ReadUsersResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ReadUsers(ctx, castedRequest)
+	})
 }
 
 // UpdateUserEndpoint creates Update User endpoint
 // Returns the Update User endpoint
 func (service *endpointCreatorService) UpdateUserEndpoint() endpoint.Endpoint {
-	return func(ctx context.Context, request interface{}) (interface{}, error) {
+	return service.withTimeout("UpdateUser", func(ctx context.Context, request interface{}) (interface{}, error) {
 		if ctx == nil {
 			return &business.UpdateUserResponse{
 				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
@@ -104,18 +228,117 @@ func (service *endpointCreatorService) UpdateUserEndpoint() endpoint.Endpoint {
 		castedRequest := request.(*business.UpdateUserRequest)
 		if err := castedRequest.Validate(); err != nil {
 			return &business.UpdateUserResponse{
-				Err: commonErrors.NewArgumentErrorWithError("request", "", err),
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("UpdateUser", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.UpdateUserResponse{
+				Err: business.NewRequestValidationError(err),
 			}, nil
 		}
 
 		return service.businessService.UpdateUser(ctx, castedRequest)
-	}
+	})
+}
+
+// ChangeEmailEndpoint creates Change Email endpoint
+// Returns the Change Email endpoint
+func (service *endpointCreatorService) ChangeEmailEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ChangeEmail", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ChangeEmailResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ChangeEmailResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ChangeEmailRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ChangeEmailResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("ChangeEmail", map[string]interface{}{"email": castedRequest.NewEmail}); err != nil {
+			return &business.ChangeEmailResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ChangeEmail(ctx, castedRequest)
+	})
+}
+
+// RequestEmailChangeEndpoint creates Request Email Change endpoint
+// Returns the Request Email Change endpoint
+func (service *endpointCreatorService) RequestEmailChangeEndpoint() endpoint.Endpoint {
+	return service.withTimeout("RequestEmailChange", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.RequestEmailChangeResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.RequestEmailChangeResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.RequestEmailChangeRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.RequestEmailChangeResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("RequestEmailChange", map[string]interface{}{"email": castedRequest.NewEmail}); err != nil {
+			return &business.RequestEmailChangeResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.RequestEmailChange(ctx, castedRequest)
+	})
+}
+
+// ConfirmEmailChangeEndpoint creates Confirm Email Change endpoint
+// Returns the Confirm Email Change endpoint
+func (service *endpointCreatorService) ConfirmEmailChangeEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ConfirmEmailChange", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ConfirmEmailChangeResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ConfirmEmailChangeResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ConfirmEmailChangeRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ConfirmEmailChangeResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ConfirmEmailChange(ctx, castedRequest)
+	})
 }
 
 // DeleteUserEndpoint creates Delete User endpoint
 // Returns the Delete User endpoint
 func (service *endpointCreatorService) DeleteUserEndpoint() endpoint.Endpoint {
-	return func(ctx context.Context, request interface{}) (interface{}, error) {
+	return service.withTimeout("DeleteUser", func(ctx context.Context, request interface{}) (interface{}, error) {
 		if ctx == nil {
 			return &business.DeleteUserResponse{
 				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
@@ -131,10 +354,1643 @@ func (service *endpointCreatorService) DeleteUserEndpoint() endpoint.Endpoint {
 		castedRequest := request.(*business.DeleteUserRequest)
 		if err := castedRequest.Validate(); err != nil {
 			return &business.DeleteUserResponse{
-				Err: commonErrors.NewArgumentErrorWithError("request", "", err),
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("DeleteUser", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.DeleteUserResponse{
+				Err: business.NewRequestValidationError(err),
 			}, nil
 		}
 
 		return service.businessService.DeleteUser(ctx, castedRequest)
+	})
+}
+
+// RestoreUserEndpoint creates Restore User endpoint
+// Returns the Restore User endpoint
+func (service *endpointCreatorService) RestoreUserEndpoint() endpoint.Endpoint {
+	return service.withTimeout("RestoreUser", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.RestoreUserResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.RestoreUserResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.RestoreUserRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.RestoreUserResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("RestoreUser", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.RestoreUserResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.RestoreUser(ctx, castedRequest)
+	})
+}
+
+// SearchEndpoint creates Search User endpoint
+// Returns the Search User endpoint
+func (service *endpointCreatorService) SearchEndpoint() endpoint.Endpoint {
+	return service.withTimeout("Search", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.SearchResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.SearchResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.SearchRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.SearchResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.Search(ctx, castedRequest)
+	})
+}
+
+// StreamSearchEndpoint creates Stream Search User endpoint
+// Returns the Stream Search User endpoint
+func (service *endpointCreatorService) StreamSearchEndpoint() endpoint.Endpoint {
+	return service.withTimeout("StreamSearch", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.StreamSearchResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.StreamSearchResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.StreamSearchRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.StreamSearchResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.StreamSearch(ctx, castedRequest)
+	})
+}
+
+// GetSagaStatusEndpoint creates Get Saga Status endpoint
+// Returns the Get Saga Status endpoint
+func (service *endpointCreatorService) GetSagaStatusEndpoint() endpoint.Endpoint {
+	return service.withTimeout("GetSagaStatus", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.GetSagaStatusResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.GetSagaStatusResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.GetSagaStatusRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.GetSagaStatusResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.GetSagaStatus(ctx, castedRequest)
+	})
+}
+
+// ListAuditRecordsEndpoint creates List Audit Records endpoint
+// Returns the List Audit Records endpoint
+func (service *endpointCreatorService) ListAuditRecordsEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ListAuditRecords", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ListAuditRecordsResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ListAuditRecordsResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ListAuditRecordsRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ListAuditRecordsResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ListAuditRecords(ctx, castedRequest)
+	})
+}
+
+// GetEffectiveConfigurationEndpoint creates Get Effective Configuration endpoint
+// Returns the Get Effective Configuration endpoint
+func (service *endpointCreatorService) GetEffectiveConfigurationEndpoint() endpoint.Endpoint {
+	return service.withTimeout("GetEffectiveConfiguration", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.GetEffectiveConfigurationResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.GetEffectiveConfigurationResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		return service.businessService.GetEffectiveConfiguration(ctx, request.(*business.GetEffectiveConfigurationRequest))
+	})
+}
+
+// GetEnabledFeaturesEndpoint creates Get Enabled Features endpoint
+// Returns the Get Enabled Features endpoint
+func (service *endpointCreatorService) GetEnabledFeaturesEndpoint() endpoint.Endpoint {
+	return service.withTimeout("GetEnabledFeatures", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.GetEnabledFeaturesResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.GetEnabledFeaturesResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		return service.businessService.GetEnabledFeatures(ctx, request.(*business.GetEnabledFeaturesRequest))
+	})
+}
+
+// PreviewBulkUpdateUsersEndpoint creates Preview Bulk Update Users endpoint
+// Returns the Preview Bulk Update Users endpoint
+func (service *endpointCreatorService) PreviewBulkUpdateUsersEndpoint() endpoint.Endpoint {
+	return service.withTimeout("PreviewBulkUpdateUsers", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.PreviewBulkUpdateUsersResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.PreviewBulkUpdateUsersResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.PreviewBulkUpdateUsersRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.PreviewBulkUpdateUsersResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.PreviewBulkUpdateUsers(ctx, castedRequest)
+	})
+}
+
+// BulkUpdateUsersEndpoint creates Bulk Update Users endpoint
+// Returns the Bulk Update Users endpoint
+func (service *endpointCreatorService) BulkUpdateUsersEndpoint() endpoint.Endpoint {
+	return service.withTimeout("BulkUpdateUsers", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.BulkUpdateUsersResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.BulkUpdateUsersResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.BulkUpdateUsersRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.BulkUpdateUsersResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.BulkUpdateUsers(ctx, castedRequest)
+	})
+}
+
+// PurgeByLabelEndpoint creates Purge By Label endpoint
+// Returns the Purge By Label endpoint
+func (service *endpointCreatorService) PurgeByLabelEndpoint() endpoint.Endpoint {
+	return service.withTimeout("PurgeByLabel", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.PurgeByLabelResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.PurgeByLabelResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.PurgeByLabelRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.PurgeByLabelResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.PurgeByLabel(ctx, castedRequest)
+	})
+}
+
+// GetOutboxLagEndpoint creates Get Outbox Lag endpoint
+// Returns the Get Outbox Lag endpoint
+func (service *endpointCreatorService) GetOutboxLagEndpoint() endpoint.Endpoint {
+	return service.withTimeout("GetOutboxLag", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.GetOutboxLagResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.GetOutboxLagResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		return service.businessService.GetOutboxLag(ctx, request.(*business.GetOutboxLagRequest))
+	})
+}
+
+// ListPendingEventsEndpoint creates List Pending Events endpoint
+// Returns the List Pending Events endpoint
+func (service *endpointCreatorService) ListPendingEventsEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ListPendingEvents", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ListPendingEventsResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ListPendingEventsResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ListPendingEventsRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ListPendingEventsResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ListPendingEvents(ctx, castedRequest)
+	})
+}
+
+// ForceFlushEndpoint creates Force Flush endpoint
+// Returns the Force Flush endpoint
+func (service *endpointCreatorService) ForceFlushEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ForceFlush", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ForceFlushResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ForceFlushResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		return service.businessService.ForceFlush(ctx, request.(*business.ForceFlushRequest))
+	})
+}
+
+// GetUserPreferencesEndpoint creates Get User Preferences endpoint
+// Returns the Get User Preferences endpoint
+func (service *endpointCreatorService) GetUserPreferencesEndpoint() endpoint.Endpoint {
+	return service.withTimeout("GetUserPreferences", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.GetUserPreferencesResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.GetUserPreferencesResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.GetUserPreferencesRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.GetUserPreferencesResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("GetUserPreferences", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.GetUserPreferencesResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.GetUserPreferences(ctx, castedRequest)
+	})
+}
+
+// UpdateUserPreferencesEndpoint creates Update User Preferences endpoint
+// Returns the Update User Preferences endpoint
+func (service *endpointCreatorService) UpdateUserPreferencesEndpoint() endpoint.Endpoint {
+	return service.withTimeout("UpdateUserPreferences", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.UpdateUserPreferencesResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.UpdateUserPreferencesResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.UpdateUserPreferencesRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.UpdateUserPreferencesResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("UpdateUserPreferences", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.UpdateUserPreferencesResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.UpdateUserPreferences(ctx, castedRequest)
+	})
+}
+
+// GetUserAvatarEndpoint creates Get User Avatar endpoint
+// Returns the Get User Avatar endpoint
+func (service *endpointCreatorService) GetUserAvatarEndpoint() endpoint.Endpoint {
+	return service.withTimeout("GetUserAvatar", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.GetUserAvatarResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.GetUserAvatarResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.GetUserAvatarRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.GetUserAvatarResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("GetUserAvatar", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.GetUserAvatarResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.GetUserAvatar(ctx, castedRequest)
+	})
+}
+
+// SetUserAvatarEndpoint creates Set User Avatar endpoint
+// Returns the Set User Avatar endpoint
+func (service *endpointCreatorService) SetUserAvatarEndpoint() endpoint.Endpoint {
+	return service.withTimeout("SetUserAvatar", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.SetUserAvatarResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.SetUserAvatarResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.SetUserAvatarRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.SetUserAvatarResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("SetUserAvatar", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.SetUserAvatarResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.SetUserAvatar(ctx, castedRequest)
+	})
+}
+
+// ExportPersonalDataEndpoint creates Export Personal Data endpoint
+// Returns the Export Personal Data endpoint
+func (service *endpointCreatorService) ExportPersonalDataEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ExportPersonalData", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ExportPersonalDataResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ExportPersonalDataResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ExportPersonalDataRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ExportPersonalDataResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("ExportPersonalData", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.ExportPersonalDataResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ExportPersonalData(ctx, castedRequest)
+	})
+}
+
+// EraseUserEndpoint creates Erase User endpoint
+// Returns the Erase User endpoint
+func (service *endpointCreatorService) EraseUserEndpoint() endpoint.Endpoint {
+	return service.withTimeout("EraseUser", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.EraseUserResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.EraseUserResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.EraseUserRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.EraseUserResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("EraseUser", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.EraseUserResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.EraseUser(ctx, castedRequest)
+	})
+}
+
+// AddUserToTenantEndpoint creates Add User To Tenant endpoint
+// Returns the Add User To Tenant endpoint
+func (service *endpointCreatorService) AddUserToTenantEndpoint() endpoint.Endpoint {
+	return service.withTimeout("AddUserToTenant", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.AddUserToTenantResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.AddUserToTenantResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.AddUserToTenantRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.AddUserToTenantResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("AddUserToTenant", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.AddUserToTenantResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.AddUserToTenant(ctx, castedRequest)
+	})
+}
+
+// RemoveUserFromTenantEndpoint creates Remove User From Tenant endpoint
+// Returns the Remove User From Tenant endpoint
+func (service *endpointCreatorService) RemoveUserFromTenantEndpoint() endpoint.Endpoint {
+	return service.withTimeout("RemoveUserFromTenant", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.RemoveUserFromTenantResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.RemoveUserFromTenantResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.RemoveUserFromTenantRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.RemoveUserFromTenantResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("RemoveUserFromTenant", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.RemoveUserFromTenantResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.RemoveUserFromTenant(ctx, castedRequest)
+	})
+}
+
+// ListUserTenantsEndpoint creates List User Tenants endpoint
+// Returns the List User Tenants endpoint
+func (service *endpointCreatorService) ListUserTenantsEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ListUserTenants", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ListUserTenantsResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ListUserTenantsResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ListUserTenantsRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ListUserTenantsResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("ListUserTenants", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.ListUserTenantsResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ListUserTenants(ctx, castedRequest)
+	})
+}
+
+// GetReplicationStatusEndpoint creates Get Replication Status endpoint
+// Returns the Get Replication Status endpoint
+func (service *endpointCreatorService) GetReplicationStatusEndpoint() endpoint.Endpoint {
+	return service.withTimeout("GetReplicationStatus", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.GetReplicationStatusResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.GetReplicationStatusResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		return service.businessService.GetReplicationStatus(ctx, request.(*business.GetReplicationStatusRequest))
+	})
+}
+
+// ExportUsersEndpoint creates Export Users endpoint
+// Returns the Export Users endpoint
+func (service *endpointCreatorService) ExportUsersEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ExportUsers", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ExportUsersResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ExportUsersResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ExportUsersRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ExportUsersResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ExportUsers(ctx, castedRequest)
+	})
+}
+
+// IssueMagicLinkEndpoint creates Issue Magic Link endpoint
+// Returns the Issue Magic Link endpoint
+func (service *endpointCreatorService) IssueMagicLinkEndpoint() endpoint.Endpoint {
+	return service.withTimeout("IssueMagicLink", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.IssueMagicLinkResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.IssueMagicLinkResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.IssueMagicLinkRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.IssueMagicLinkResponse{
+				Err: commonErrors.NewArgumentErrorWithError("request", "", err),
+			}, nil
+		}
+
+		return service.businessService.IssueMagicLink(ctx, castedRequest)
+	})
+}
+
+// RedeemMagicLinkEndpoint creates Redeem Magic Link endpoint
+// Returns the Redeem Magic Link endpoint
+func (service *endpointCreatorService) RedeemMagicLinkEndpoint() endpoint.Endpoint {
+	return service.withTimeout("RedeemMagicLink", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.RedeemMagicLinkResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.RedeemMagicLinkResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.RedeemMagicLinkRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.RedeemMagicLinkResponse{
+				Err: commonErrors.NewArgumentErrorWithError("request", "", err),
+			}, nil
+		}
+
+		return service.businessService.RedeemMagicLink(ctx, castedRequest)
+	})
+}
+
+// SendVerificationEmailEndpoint creates Send Verification Email endpoint
+// Returns the Send Verification Email endpoint
+func (service *endpointCreatorService) SendVerificationEmailEndpoint() endpoint.Endpoint {
+	return service.withTimeout("SendVerificationEmail", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.SendVerificationEmailResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.SendVerificationEmailResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.SendVerificationEmailRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.SendVerificationEmailResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.SendVerificationEmail(ctx, castedRequest)
+	})
+}
+
+// VerifyEmailEndpoint creates Verify Email endpoint
+// Returns the Verify Email endpoint
+func (service *endpointCreatorService) VerifyEmailEndpoint() endpoint.Endpoint {
+	return service.withTimeout("VerifyEmail", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.VerifyEmailResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.VerifyEmailResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.VerifyEmailRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.VerifyEmailResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.VerifyEmail(ctx, castedRequest)
+	})
+}
+
+// SetPasswordEndpoint creates Set Password endpoint
+// Returns the Set Password endpoint
+func (service *endpointCreatorService) SetPasswordEndpoint() endpoint.Endpoint {
+	return service.withTimeout("SetPassword", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.SetPasswordResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.SetPasswordResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.SetPasswordRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.SetPasswordResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.SetPassword(ctx, castedRequest)
+	})
+}
+
+// ChangePasswordEndpoint creates Change Password endpoint
+// Returns the Change Password endpoint
+func (service *endpointCreatorService) ChangePasswordEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ChangePassword", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ChangePasswordResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ChangePasswordResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ChangePasswordRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ChangePasswordResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ChangePassword(ctx, castedRequest)
+	})
+}
+
+// VerifyPasswordEndpoint creates Verify Password endpoint
+// Returns the Verify Password endpoint
+func (service *endpointCreatorService) VerifyPasswordEndpoint() endpoint.Endpoint {
+	return service.withTimeout("VerifyPassword", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.VerifyPasswordResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.VerifyPasswordResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.VerifyPasswordRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.VerifyPasswordResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.VerifyPassword(ctx, castedRequest)
+	})
+}
+
+// WatchUsersEndpoint creates Watch Users endpoint
+// Returns the Watch Users endpoint
+func (service *endpointCreatorService) WatchUsersEndpoint() endpoint.Endpoint {
+	return service.withTimeout("WatchUsers", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.WatchUsersResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.WatchUsersResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.WatchUsersRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.WatchUsersResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.WatchUsers(ctx, castedRequest)
+	})
+}
+
+// CreateAPIKeyEndpoint creates Create API Key endpoint
+// Returns the Create API Key endpoint
+func (service *endpointCreatorService) CreateAPIKeyEndpoint() endpoint.Endpoint {
+	return service.withTimeout("CreateAPIKey", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.CreateAPIKeyResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.CreateAPIKeyResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.CreateAPIKeyRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.CreateAPIKeyResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.CreateAPIKey(ctx, castedRequest)
+	})
+}
+
+// ListAPIKeysEndpoint creates List API Keys endpoint
+// Returns the List API Keys endpoint
+func (service *endpointCreatorService) ListAPIKeysEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ListAPIKeys", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ListAPIKeysResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ListAPIKeysResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ListAPIKeysRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ListAPIKeysResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ListAPIKeys(ctx, castedRequest)
+	})
+}
+
+// RevokeAPIKeyEndpoint creates Revoke API Key endpoint
+// Returns the Revoke API Key endpoint
+func (service *endpointCreatorService) RevokeAPIKeyEndpoint() endpoint.Endpoint {
+	return service.withTimeout("RevokeAPIKey", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.RevokeAPIKeyResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.RevokeAPIKeyResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.RevokeAPIKeyRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.RevokeAPIKeyResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.RevokeAPIKey(ctx, castedRequest)
+	})
+}
+
+// RecordLoginAttemptEndpoint creates Record Login Attempt endpoint
+// Returns the Record Login Attempt endpoint
+func (service *endpointCreatorService) RecordLoginAttemptEndpoint() endpoint.Endpoint {
+	return service.withTimeout("RecordLoginAttempt", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.RecordLoginAttemptResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.RecordLoginAttemptResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.RecordLoginAttemptRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.RecordLoginAttemptResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.RecordLoginAttempt(ctx, castedRequest)
+	})
+}
+
+// UnlockUserEndpoint creates Unlock User endpoint
+// Returns the Unlock User endpoint
+func (service *endpointCreatorService) UnlockUserEndpoint() endpoint.Endpoint {
+	return service.withTimeout("UnlockUser", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.UnlockUserResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.UnlockUserResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.UnlockUserRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.UnlockUserResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.UnlockUser(ctx, castedRequest)
+	})
+}
+
+// EnrollMFAEndpoint creates Enroll MFA endpoint
+// Returns the Enroll MFA endpoint
+func (service *endpointCreatorService) EnrollMFAEndpoint() endpoint.Endpoint {
+	return service.withTimeout("EnrollMFA", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.EnrollMFAResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.EnrollMFAResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.EnrollMFARequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.EnrollMFAResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.EnrollMFA(ctx, castedRequest)
+	})
+}
+
+// ListMFAMethodsEndpoint creates List MFA Methods endpoint
+// Returns the List MFA Methods endpoint
+func (service *endpointCreatorService) ListMFAMethodsEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ListMFAMethods", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ListMFAMethodsResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ListMFAMethodsResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ListMFAMethodsRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ListMFAMethodsResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ListMFAMethods(ctx, castedRequest)
+	})
+}
+
+// RemoveMFAMethodEndpoint creates Remove MFA Method endpoint
+// Returns the Remove MFA Method endpoint
+func (service *endpointCreatorService) RemoveMFAMethodEndpoint() endpoint.Endpoint {
+	return service.withTimeout("RemoveMFAMethod", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.RemoveMFAMethodResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.RemoveMFAMethodResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.RemoveMFAMethodRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.RemoveMFAMethodResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.RemoveMFAMethod(ctx, castedRequest)
+	})
+}
+
+// RecordConsentEndpoint creates Record Consent endpoint
+// Returns the Record Consent endpoint
+func (service *endpointCreatorService) RecordConsentEndpoint() endpoint.Endpoint {
+	return service.withTimeout("RecordConsent", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.RecordConsentResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.RecordConsentResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.RecordConsentRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.RecordConsentResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.RecordConsent(ctx, castedRequest)
+	})
+}
+
+// ListConsentsEndpoint creates List Consents endpoint
+// Returns the List Consents endpoint
+func (service *endpointCreatorService) ListConsentsEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ListConsents", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ListConsentsResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ListConsentsResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ListConsentsRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ListConsentsResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ListConsents(ctx, castedRequest)
+	})
+}
+
+// CreateGroupEndpoint creates Create Group endpoint
+// Returns the Create Group endpoint
+func (service *endpointCreatorService) CreateGroupEndpoint() endpoint.Endpoint {
+	return service.withTimeout("CreateGroup", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.CreateGroupResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.CreateGroupResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.CreateGroupRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.CreateGroupResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.CreateGroup(ctx, castedRequest)
+	})
+}
+
+// ReadGroupEndpoint creates Read Group endpoint
+// Returns the Read Group endpoint
+func (service *endpointCreatorService) ReadGroupEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ReadGroup", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ReadGroupResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ReadGroupResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ReadGroupRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ReadGroupResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ReadGroup(ctx, castedRequest)
+	})
+}
+
+// UpdateGroupEndpoint creates Update Group endpoint
+// Returns the Update Group endpoint
+func (service *endpointCreatorService) UpdateGroupEndpoint() endpoint.Endpoint {
+	return service.withTimeout("UpdateGroup", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.UpdateGroupResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.UpdateGroupResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.UpdateGroupRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.UpdateGroupResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.UpdateGroup(ctx, castedRequest)
+	})
+}
+
+// DeleteGroupEndpoint creates Delete Group endpoint
+// Returns the Delete Group endpoint
+func (service *endpointCreatorService) DeleteGroupEndpoint() endpoint.Endpoint {
+	return service.withTimeout("DeleteGroup", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.DeleteGroupResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.DeleteGroupResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.DeleteGroupRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.DeleteGroupResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.DeleteGroup(ctx, castedRequest)
+	})
+}
+
+// ListGroupsEndpoint creates List Groups endpoint
+// Returns the List Groups endpoint
+func (service *endpointCreatorService) ListGroupsEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ListGroups", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ListGroupsResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ListGroupsResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ListGroupsRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ListGroupsResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ListGroups(ctx, castedRequest)
+	})
+}
+
+// AddUserToGroupEndpoint creates Add User To Group endpoint
+// Returns the Add User To Group endpoint
+func (service *endpointCreatorService) AddUserToGroupEndpoint() endpoint.Endpoint {
+	return service.withTimeout("AddUserToGroup", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.AddUserToGroupResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.AddUserToGroupResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.AddUserToGroupRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.AddUserToGroupResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.AddUserToGroup(ctx, castedRequest)
+	})
+}
+
+// RemoveUserFromGroupEndpoint creates Remove User From Group endpoint
+// Returns the Remove User From Group endpoint
+func (service *endpointCreatorService) RemoveUserFromGroupEndpoint() endpoint.Endpoint {
+	return service.withTimeout("RemoveUserFromGroup", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.RemoveUserFromGroupResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.RemoveUserFromGroupResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.RemoveUserFromGroupRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.RemoveUserFromGroupResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.RemoveUserFromGroup(ctx, castedRequest)
+	})
+}
+
+// InviteUserEndpoint creates Invite User endpoint
+// Returns the Invite User endpoint
+func (service *endpointCreatorService) InviteUserEndpoint() endpoint.Endpoint {
+	return service.withTimeout("InviteUser", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.InviteUserResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.InviteUserResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.InviteUserRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.InviteUserResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.InviteUser(ctx, castedRequest)
+	})
+}
+
+// AcceptInvitationEndpoint creates Accept Invitation endpoint
+// Returns the Accept Invitation endpoint
+func (service *endpointCreatorService) AcceptInvitationEndpoint() endpoint.Endpoint {
+	return service.withTimeout("AcceptInvitation", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.AcceptInvitationResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.AcceptInvitationResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.AcceptInvitationRequest)
+		parsedToken := ctx.Value(models.ContextKeyParsedToken).(models.ParsedToken)
+		castedRequest.Email = parsedToken.Email
+
+		if err := castedRequest.Validate(); err != nil {
+			return &business.AcceptInvitationResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		if err := service.canaryValidationService.Validate("AcceptInvitation", map[string]interface{}{"email": castedRequest.Email}); err != nil {
+			return &business.AcceptInvitationResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.AcceptInvitation(ctx, castedRequest)
+	})
+}
+
+// RegisterSessionEndpoint creates Register Session endpoint
+// Returns the Register Session endpoint
+func (service *endpointCreatorService) RegisterSessionEndpoint() endpoint.Endpoint {
+	return service.withTimeout("RegisterSession", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.RegisterSessionResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.RegisterSessionResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.RegisterSessionRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.RegisterSessionResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.RegisterSession(ctx, castedRequest)
+	})
+}
+
+// ListSessionsEndpoint creates List Sessions endpoint
+// Returns the List Sessions endpoint
+func (service *endpointCreatorService) ListSessionsEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ListSessions", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ListSessionsResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ListSessionsResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ListSessionsRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ListSessionsResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ListSessions(ctx, castedRequest)
+	})
+}
+
+// RevokeSessionEndpoint creates Revoke Session endpoint
+// Returns the Revoke Session endpoint
+func (service *endpointCreatorService) RevokeSessionEndpoint() endpoint.Endpoint {
+	return service.withTimeout("RevokeSession", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.RevokeSessionResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.RevokeSessionResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.RevokeSessionRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.RevokeSessionResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.RevokeSession(ctx, castedRequest)
+	})
+}
+
+// CreateWebhookSubscriptionEndpoint creates Create Webhook Subscription endpoint
+// Returns the Create Webhook Subscription endpoint
+func (service *endpointCreatorService) CreateWebhookSubscriptionEndpoint() endpoint.Endpoint {
+	return service.withTimeout("CreateWebhookSubscription", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.CreateWebhookSubscriptionResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.CreateWebhookSubscriptionResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.CreateWebhookSubscriptionRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.CreateWebhookSubscriptionResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.CreateWebhookSubscription(ctx, castedRequest)
+	})
+}
+
+// ListWebhookSubscriptionsEndpoint creates List Webhook Subscriptions endpoint
+// Returns the List Webhook Subscriptions endpoint
+func (service *endpointCreatorService) ListWebhookSubscriptionsEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ListWebhookSubscriptions", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ListWebhookSubscriptionsResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ListWebhookSubscriptionsResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ListWebhookSubscriptionsRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ListWebhookSubscriptionsResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ListWebhookSubscriptions(ctx, castedRequest)
+	})
+}
+
+// DeleteWebhookSubscriptionEndpoint creates Delete Webhook Subscription endpoint
+// Returns the Delete Webhook Subscription endpoint
+func (service *endpointCreatorService) DeleteWebhookSubscriptionEndpoint() endpoint.Endpoint {
+	return service.withTimeout("DeleteWebhookSubscription", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.DeleteWebhookSubscriptionResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.DeleteWebhookSubscriptionResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.DeleteWebhookSubscriptionRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.DeleteWebhookSubscriptionResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.DeleteWebhookSubscription(ctx, castedRequest)
+	})
+}
+
+// ListWebhookDeliveriesEndpoint creates List Webhook Deliveries endpoint
+// Returns the List Webhook Deliveries endpoint
+func (service *endpointCreatorService) ListWebhookDeliveriesEndpoint() endpoint.Endpoint {
+	return service.withTimeout("ListWebhookDeliveries", func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return &business.ListWebhookDeliveriesResponse{
+				Err: commonErrors.NewArgumentNilError("ctx", "ctx is required"),
+			}, nil
+		}
+
+		if request == nil {
+			return &business.ListWebhookDeliveriesResponse{
+				Err: commonErrors.NewArgumentNilError("request", "request is required"),
+			}, nil
+		}
+
+		castedRequest := request.(*business.ListWebhookDeliveriesRequest)
+		if err := castedRequest.Validate(); err != nil {
+			return &business.ListWebhookDeliveriesResponse{
+				Err: business.NewRequestValidationError(err),
+			}, nil
+		}
+
+		return service.businessService.ListWebhookDeliveries(ctx, castedRequest)
+	})
+}
+
+// withTimeout cancels the context of the calls to the endpoint once the timeout of the endpoint elapses, so the
+// operations the call runs fail with DeadlineExceeded. The deadline of the caller is kept if it is earlier.
+// name: Mandatory. The name of the endpoint
+// next: Mandatory. The endpoint to cancel the calls of
+// Returns the endpoint that cancels the calls after the timeout, or the given endpoint if it has no timeout
+func (service *endpointCreatorService) withTimeout(name string, next endpoint.Endpoint) endpoint.Endpoint {
+	timeout, ok := service.timeouts[name]
+	if !ok && !streamingEndpoints[name] {
+		timeout = service.defaultTimeout
+	}
+
+	if timeout == 0 {
+		return next
+	}
+
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		if ctx == nil {
+			return next(ctx, request)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return next(ctx, request)
 	}
 }
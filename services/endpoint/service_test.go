@@ -11,6 +11,8 @@ import (
 	"github.com/decentralized-cloud/user/models"
 	"github.com/decentralized-cloud/user/services/business"
 	businessMock "github.com/decentralized-cloud/user/services/business/mock"
+	canaryMock "github.com/decentralized-cloud/user/services/canary/mock"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
 	"github.com/decentralized-cloud/user/services/endpoint"
 	gokitendpoint "github.com/go-kit/kit/endpoint"
 	"github.com/golang/mock/gomock"
@@ -30,17 +32,39 @@ func TestEndpointCreatorService(t *testing.T) {
 
 var _ = Describe("Endpoint Creator Service Tests", func() {
 	var (
-		mockCtrl            *gomock.Controller
-		sut                 endpoint.EndpointCreatorContract
-		mockBusinessService *businessMock.MockBusinessContract
-		ctx                 context.Context
+		mockCtrl                 *gomock.Controller
+		sut                      endpoint.EndpointCreatorContract
+		mockBusinessService      *businessMock.MockBusinessContract
+		mockCanaryService        *canaryMock.MockCanaryValidationContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		ctx                      context.Context
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 
 		mockBusinessService = businessMock.NewMockBusinessContract(mockCtrl)
-		sut, _ = endpoint.NewEndpointCreatorService(mockBusinessService)
+		mockCanaryService = canaryMock.NewMockCanaryValidationContract(mockCtrl)
+		mockCanaryService.
+			EXPECT().
+			Validate(gomock.Any(), gomock.Any()).
+			Return(nil).
+			AnyTimes()
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetEndpointDefaultTimeout().
+			Return(time.Duration(0), nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetEndpointTimeouts().
+			Return(map[string]time.Duration{}, nil).
+			AnyTimes()
+
+		sut, _ = endpoint.NewEndpointCreatorService(mockBusinessService, mockCanaryService, mockConfigurationService)
 		ctx = context.WithValue(context.Background(), models.ContextKeyParsedToken, models.ParsedToken{Email: cuid.New() + "@test.com"})
 	})
 
@@ -51,19 +75,57 @@ var _ = Describe("Endpoint Creator Service Tests", func() {
 	Context("user tries to instantiate EndpointCreatorService", func() {
 		When("user business service is not provided and NewEndpointCreatorService is called", func() {
 			It("should return ArgumentNilError", func() {
-				service, err := endpoint.NewEndpointCreatorService(nil)
+				service, err := endpoint.NewEndpointCreatorService(nil, mockCanaryService, mockConfigurationService)
 				Ω(service).Should(BeNil())
 				assertArgumentNilError("businessService", "", err)
 			})
 		})
 
+		When("canary validation service is not provided and NewEndpointCreatorService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := endpoint.NewEndpointCreatorService(mockBusinessService, nil, mockConfigurationService)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("canaryValidationService", "", err)
+			})
+		})
+
+		When("configuration service is not provided and NewEndpointCreatorService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := endpoint.NewEndpointCreatorService(mockBusinessService, mockCanaryService, nil)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("configurationService", "", err)
+			})
+		})
+
 		When("all dependencies are resolved and NewEndpointCreatorService is called", func() {
 			It("should instantiate the new EndpointCreatorService", func() {
-				service, err := endpoint.NewEndpointCreatorService(mockBusinessService)
+				service, err := endpoint.NewEndpointCreatorService(mockBusinessService, mockCanaryService, mockConfigurationService)
 				Ω(err).Should(BeNil())
 				Ω(service).ShouldNot(BeNil())
 			})
 		})
+
+		When("the request violates an enforced canary validation rule", func() {
+			It("should return ArgumentError", func() {
+				canaryMockCtrl := gomock.NewController(GinkgoT())
+				defer canaryMockCtrl.Finish()
+
+				request := business.CreateUserRequest{Email: cuid.New() + "@test.com"}
+				expectedErr := errors.New(cuid.New())
+				rejectingCanaryService := canaryMock.NewMockCanaryValidationContract(canaryMockCtrl)
+				rejectingCanaryService.
+					EXPECT().
+					Validate("CreateUser", gomock.Any()).
+					Return(expectedErr)
+
+				service, _ := endpoint.NewEndpointCreatorService(mockBusinessService, rejectingCanaryService, mockConfigurationService)
+				returnedResponse, err := service.CreateUserEndpoint()(ctx, &request)
+
+				Ω(err).Should(BeNil())
+				castedResponse := returnedResponse.(*business.CreateUserResponse)
+				assertArgumentError("request", "", castedResponse.Err, expectedErr)
+			})
+		})
 	})
 
 	Context("EndpointCreatorService is instantiated", func() {
@@ -132,6 +194,33 @@ var _ = Describe("Endpoint Creator Service Tests", func() {
 					})
 				})
 
+				When("endpoint is called with an email address longer than the limit", func() {
+					It("should return ArgumentError", func() {
+						request.Email = strings.Repeat("a", 250) + "@test.com"
+						ctx = context.WithValue(context.Background(), models.ContextKeyParsedToken, models.ParsedToken{Email: request.Email})
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.CreateUserResponse)
+						validationErr := request.Validate()
+						Ω(validationErr).ShouldNot(BeNil())
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with invalid labels", func() {
+					It("should return ArgumentError", func() {
+						request.User.Labels = map[string]string{"region": "eu", "plan.tier": "pro"}
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.CreateUserResponse)
+						validationErr := request.Validate()
+						Ω(validationErr).ShouldNot(BeNil())
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
 				When("endpoint is called with valid request", func() {
 					It("should call business service CreateUser method", func() {
 						mockBusinessService.
@@ -244,6 +333,37 @@ var _ = Describe("Endpoint Creator Service Tests", func() {
 					})
 				})
 
+				When("endpoint is called with invalid email address", func() {
+					It("should return the validation failure of the email address", func() {
+						request.Email = "not-an-email-address"
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.ReadUserResponse)
+
+						var validationErr business.ValidationError
+						Ω(errors.As(castedResponse.Err, &validationErr)).Should(BeTrue())
+						Ω(validationErr.Failures).Should(Equal([]business.ValidationFailure{{
+							Field:   "email",
+							Code:    business.ValidationCodeFormat,
+							Message: "must be a valid email address",
+						}}))
+					})
+				})
+
+				When("endpoint is called with a field that is not a user field", func() {
+					It("should return ArgumentError", func() {
+						request.Fields = []string{"labels", "password"}
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.ReadUserResponse)
+						validationErr := request.Validate()
+						Ω(validationErr).ShouldNot(BeNil())
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
 				When("endpoint is called with valid request", func() {
 					It("should call business service ReadUser method", func() {
 						mockBusinessService.
@@ -290,6 +410,193 @@ var _ = Describe("Endpoint Creator Service Tests", func() {
 						Ω(returnedResponse).Should(Equal(&response))
 					})
 				})
+
+				When("endpoint is called with both the email address and the user ID", func() {
+					It("should return ArgumentError", func() {
+						request.UserID = cuid.New()
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.ReadUserResponse)
+						validationErr := request.Validate()
+						Ω(validationErr).ShouldNot(BeNil())
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with the user ID and only the owner is allowed", func() {
+					var callerEmail string
+
+					BeforeEach(func() {
+						callerEmail = cuid.New() + "@test.com"
+						ctx = context.WithValue(context.Background(), models.ContextKeyParsedToken, models.ParsedToken{Email: callerEmail, OwnerOnly: true})
+						request = business.ReadUserRequest{UserID: cuid.New()}
+					})
+
+					It("should return the user of the caller", func() {
+						response.Email = callerEmail
+						mockBusinessService.
+							EXPECT().
+							ReadUser(ctx, gomock.Any()).
+							Do(func(_ context.Context, mappedRequest *business.ReadUserRequest) {
+								Ω(mappedRequest.UserID).Should(Equal(request.UserID))
+							}).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+
+					It("should report the user of the others as not found", func() {
+						response.Email = cuid.New() + "@test.com"
+						mockBusinessService.
+							EXPECT().
+							ReadUser(ctx, gomock.Any()).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.ReadUserResponse)
+						Ω(commonErrors.IsNotFoundError(castedResponse.Err)).Should(BeTrue())
+						Ω(castedResponse.User).Should(Equal(models.User{}))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ReadUserByEmailEndpoint is called", func() {
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ReadUserRequest
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ReadUserByEmailEndpoint()
+				request = business.ReadUserRequest{
+					Email: cuid.New() + "@test.com",
+				}
+			})
+
+			It("should return ArgumentError if the email address is not provided", func() {
+				invalidRequest := business.ReadUserRequest{}
+				returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+				Ω(err).Should(BeNil())
+				castedResponse := returnedResponse.(*business.ReadUserResponse)
+				validationErr := invalidRequest.Validate()
+				assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+			})
+
+			It("should call business service ReadUser method with the email address", func() {
+				response := business.ReadUserResponse{Email: request.Email}
+				mockBusinessService.
+					EXPECT().
+					ReadUser(ctx, gomock.Any()).
+					Do(func(_ context.Context, mappedRequest *business.ReadUserRequest) {
+						Ω(mappedRequest.Email).Should(Equal(request.Email))
+					}).
+					Return(&response, nil)
+
+				returnedResponse, err := endpoint(ctx, &request)
+
+				Ω(err).Should(BeNil())
+				Ω(returnedResponse).Should(Equal(&response))
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ReadUsersEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ReadUsersEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ReadUsersRequest
+				response business.ReadUsersResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ReadUsersEndpoint()
+				request = business.ReadUsersRequest{
+					Emails: []string{cuid.New() + "@test.com", cuid.New() + "@test.com"},
+				}
+
+				response = business.ReadUsersResponse{
+					Users: []models.UserWithCursor{},
+				}
+			})
+
+			Context("ReadUsersEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.ReadUsersResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.ReadUsersResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without any email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := business.ReadUsersRequest{}
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.ReadUsersResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with an invalid email address", func() {
+					It("should return ArgumentError", func() {
+						request.Emails = append(request.Emails, "not-an-email-address")
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.ReadUsersResponse)
+						validationErr := request.Validate()
+						Ω(validationErr).ShouldNot(BeNil())
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service ReadUsers method", func() {
+						mockBusinessService.
+							EXPECT().
+							ReadUsers(ctx, gomock.Any()).
+							Do(func(_ context.Context, mappedRequest *business.ReadUsersRequest) {
+								Ω(mappedRequest.Emails).Should(Equal(request.Emails))
+							}).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.ReadUsersResponse)
+						Ω(castedResponse.Err).Should(BeNil())
+					})
+				})
 			})
 		})
 	})
@@ -408,35 +715,33 @@ var _ = Describe("Endpoint Creator Service Tests", func() {
 	})
 
 	Context("EndpointCreatorService is instantiated", func() {
-		When("DeleteUserEndpoint is called", func() {
-			It("should return valid function", func() {
-				endpoint := sut.DeleteUserEndpoint()
-				Ω(endpoint).ShouldNot(BeNil())
-			})
-
+		When("ChangeEmailEndpoint is called", func() {
 			var (
 				endpoint gokitendpoint.Endpoint
-				request  business.DeleteUserRequest
-				response business.DeleteUserResponse
+				request  business.ChangeEmailRequest
+				response business.ChangeEmailResponse
 			)
 
 			BeforeEach(func() {
-				endpoint = sut.DeleteUserEndpoint()
-				request = business.DeleteUserRequest{
-					Email: cuid.New() + "@test.com",
+				endpoint = sut.ChangeEmailEndpoint()
+				request = business.ChangeEmailRequest{
+					Email:    cuid.New() + "@test.com",
+					NewEmail: cuid.New() + "@test.com",
 				}
 
-				response = business.DeleteUserResponse{}
+				response = business.ChangeEmailResponse{
+					User:   models.User{ID: cuid.New()},
+					Cursor: cuid.New(),
+				}
 			})
 
-			Context("DeleteUserEndpoint function is returned", func() {
+			Context("ChangeEmailEndpoint function is returned", func() {
 				When("endpoint is called with nil context", func() {
 					It("should return ArgumentNilError", func() {
 						returnedResponse, err := endpoint(nil, &request)
 
 						Ω(err).Should(BeNil())
-						Ω(returnedResponse).ShouldNot(BeNil())
-						castedResponse := returnedResponse.(*business.DeleteUserResponse)
+						castedResponse := returnedResponse.(*business.ChangeEmailResponse)
 						assertArgumentNilError("ctx", "", castedResponse.Err)
 					})
 				})
@@ -446,52 +751,48 @@ var _ = Describe("Endpoint Creator Service Tests", func() {
 						returnedResponse, err := endpoint(ctx, nil)
 
 						Ω(err).Should(BeNil())
-						Ω(returnedResponse).ShouldNot(BeNil())
-						castedResponse := returnedResponse.(*business.DeleteUserResponse)
+						castedResponse := returnedResponse.(*business.ChangeEmailResponse)
 						assertArgumentNilError("request", "", castedResponse.Err)
 					})
 				})
 
-				When("endpoint is called with invalid request", func() {
-					It("should return ArgumentNilError", func() {
-						invalidRequest := business.DeleteUserRequest{
-							Email: "",
-						}
-						returnedResponse, err := endpoint(ctx, &invalidRequest)
+				When("endpoint is called with the current email address as the new one", func() {
+					It("should return ArgumentError", func() {
+						request.NewEmail = request.Email
+						returnedResponse, err := endpoint(ctx, &request)
 
 						Ω(err).Should(BeNil())
-						Ω(response).ShouldNot(BeNil())
-						castedResponse := returnedResponse.(*business.DeleteUserResponse)
-						validationErr := invalidRequest.Validate()
+						castedResponse := returnedResponse.(*business.ChangeEmailResponse)
+						validationErr := request.Validate()
+						Ω(validationErr).ShouldNot(BeNil())
 						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
 					})
 				})
 
 				When("endpoint is called with valid request", func() {
-					It("should call business service DeleteUser method", func() {
+					It("should call business service ChangeEmail method and return its response", func() {
 						mockBusinessService.
 							EXPECT().
-							DeleteUser(ctx, gomock.Any()).
-							Do(func(_ context.Context, mappedRequest *business.DeleteUserRequest) {
+							ChangeEmail(ctx, gomock.Any()).
+							Do(func(_ context.Context, mappedRequest *business.ChangeEmailRequest) {
 								Ω(mappedRequest.Email).Should(Equal(request.Email))
+								Ω(mappedRequest.NewEmail).Should(Equal(request.NewEmail))
 							}).
 							Return(&response, nil)
 
 						returnedResponse, err := endpoint(ctx, &request)
 
 						Ω(err).Should(BeNil())
-						Ω(response).ShouldNot(BeNil())
-						castedResponse := returnedResponse.(*business.DeleteUserResponse)
-						Ω(castedResponse.Err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
 					})
 				})
 
-				When("business service DeleteUser returns error", func() {
+				When("business service ChangeEmail returns error", func() {
 					It("should return the same error", func() {
 						expectedErr := errors.New(cuid.New())
 						mockBusinessService.
 							EXPECT().
-							DeleteUser(gomock.Any(), gomock.Any()).
+							ChangeEmail(gomock.Any(), gomock.Any()).
 							Return(nil, expectedErr)
 
 						_, err := endpoint(ctx, &request)
@@ -499,12 +800,69 @@ var _ = Describe("Endpoint Creator Service Tests", func() {
 						Ω(err).Should(Equal(expectedErr))
 					})
 				})
+			})
+		})
+	})
 
-				When("business service DeleteUser returns response", func() {
-					It("should return the same response", func() {
+	Context("EndpointCreatorService is instantiated", func() {
+		When("RequestEmailChangeEndpoint is called", func() {
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.RequestEmailChangeRequest
+				response business.RequestEmailChangeResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.RequestEmailChangeEndpoint()
+				request = business.RequestEmailChangeRequest{
+					Email:    cuid.New() + "@test.com",
+					NewEmail: cuid.New() + "@test.com",
+				}
+
+				response = business.RequestEmailChangeResponse{
+					ExpiresAt: time.Now(),
+				}
+			})
+
+			Context("RequestEmailChangeEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.RequestEmailChangeResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.RequestEmailChangeResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with the current email address as the new one", func() {
+					It("should return ArgumentError", func() {
+						request.NewEmail = request.Email
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.RequestEmailChangeResponse)
+						validationErr := request.Validate()
+						Ω(validationErr).ShouldNot(BeNil())
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service RequestEmailChange method and return its response", func() {
 						mockBusinessService.
 							EXPECT().
-							DeleteUser(gomock.Any(), gomock.Any()).
+							RequestEmailChange(ctx, &request).
 							Return(&response, nil)
 
 						returnedResponse, err := endpoint(ctx, &request)
@@ -517,6 +875,6036 @@ var _ = Describe("Endpoint Creator Service Tests", func() {
 		})
 	})
 
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ConfirmEmailChangeEndpoint is called", func() {
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ConfirmEmailChangeRequest
+				response business.ConfirmEmailChangeResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ConfirmEmailChangeEndpoint()
+				request = business.ConfirmEmailChangeRequest{
+					Email: cuid.New() + "@test.com",
+					Token: cuid.New(),
+				}
+
+				response = business.ConfirmEmailChangeResponse{
+					User:   models.User{ID: cuid.New()},
+					Cursor: cuid.New(),
+				}
+			})
+
+			Context("ConfirmEmailChangeEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.ConfirmEmailChangeResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without the token", func() {
+					It("should return ArgumentError", func() {
+						request.Token = ""
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.ConfirmEmailChangeResponse)
+						validationErr := request.Validate()
+						Ω(validationErr).ShouldNot(BeNil())
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service ConfirmEmailChange method and return its response", func() {
+						mockBusinessService.
+							EXPECT().
+							ConfirmEmailChange(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("DeleteUserEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.DeleteUserEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.DeleteUserRequest
+				response business.DeleteUserResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.DeleteUserEndpoint()
+				request = business.DeleteUserRequest{
+					Email: cuid.New() + "@test.com",
+				}
+
+				response = business.DeleteUserResponse{}
+			})
+
+			Context("DeleteUserEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.DeleteUserResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.DeleteUserResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid request", func() {
+					It("should return ArgumentNilError", func() {
+						invalidRequest := business.DeleteUserRequest{
+							Email: "",
+						}
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(response).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.DeleteUserResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service DeleteUser method", func() {
+						mockBusinessService.
+							EXPECT().
+							DeleteUser(ctx, gomock.Any()).
+							Do(func(_ context.Context, mappedRequest *business.DeleteUserRequest) {
+								Ω(mappedRequest.Email).Should(Equal(request.Email))
+							}).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(response).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.DeleteUserResponse)
+						Ω(castedResponse.Err).Should(BeNil())
+					})
+				})
+
+				When("business service DeleteUser returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							DeleteUser(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+
+				When("business service DeleteUser returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							DeleteUser(gomock.Any(), gomock.Any()).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("RestoreUserEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.RestoreUserEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.RestoreUserRequest
+				response business.RestoreUserResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.RestoreUserEndpoint()
+				request = business.RestoreUserRequest{
+					Email: cuid.New() + "@test.com",
+				}
+
+				response = business.RestoreUserResponse{}
+			})
+
+			Context("RestoreUserEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RestoreUserResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RestoreUserResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid request", func() {
+					It("should return ArgumentNilError", func() {
+						invalidRequest := business.RestoreUserRequest{
+							Email: "",
+						}
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(response).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RestoreUserResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service RestoreUser method", func() {
+						mockBusinessService.
+							EXPECT().
+							RestoreUser(ctx, gomock.Any()).
+							Do(func(_ context.Context, mappedRequest *business.RestoreUserRequest) {
+								Ω(mappedRequest.Email).Should(Equal(request.Email))
+							}).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(response).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RestoreUserResponse)
+						Ω(castedResponse.Err).Should(BeNil())
+					})
+				})
+
+				When("business service RestoreUser returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							RestoreUser(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+
+				When("business service RestoreUser returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							RestoreUser(gomock.Any(), gomock.Any()).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("SearchEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.SearchEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.SearchRequest
+				response business.SearchResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.SearchEndpoint()
+				first := 10
+				request = business.SearchRequest{
+					Pagination: models.Pagination{First: &first},
+					SortingOptions: []models.SortingOptionPair{
+						{Name: "email", Direction: models.Ascending},
+					},
+					Emails: []string{cuid.New() + "@test.com"},
+				}
+
+				response = business.SearchResponse{
+					HasPreviousPage: false,
+					HasNextPage:     true,
+					TotalCount:      20,
+					Users: []models.UserWithCursor{
+						{
+							UserID: cuid.New(),
+							Email:  request.Emails[0],
+							User:   models.User{},
+							Cursor: cuid.New(),
+						},
+					},
+				}
+			})
+
+			Context("SearchEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.SearchResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.SearchResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid request", func() {
+					It("should return ArgumentError", func() {
+						first := -1
+						invalidRequest := business.SearchRequest{
+							Pagination: models.Pagination{First: &first},
+							Emails:     []string{cuid.New()},
+						}
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(response).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.SearchResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with more email addresses than the limit", func() {
+					It("should return ArgumentError", func() {
+						request.Emails = make([]string, 1001)
+						for index := range request.Emails {
+							request.Emails[index] = cuid.New() + "@test.com"
+						}
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.SearchResponse)
+						validationErr := request.Validate()
+						Ω(validationErr).ShouldNot(BeNil())
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with invalid label selector", func() {
+					It("should return ArgumentError", func() {
+						request.LabelSelector = "region in (eu, us"
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.SearchResponse)
+						validationErr := request.Validate()
+						Ω(validationErr).ShouldNot(BeNil())
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with more than one invalid field", func() {
+					It("should return the validation failure of every invalid field", func() {
+						request.Query = strings.Repeat("a", 255)
+						request.Fields = []string{"labels", "emailVerificationTokenHash"}
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.SearchResponse)
+
+						var validationErr business.ValidationError
+						Ω(errors.As(castedResponse.Err, &validationErr)).Should(BeTrue())
+						Ω(validationErr.Failures).Should(HaveLen(2))
+						Ω(validationErr.Failures[0].Field).Should(Equal("fields.1"))
+						Ω(validationErr.Failures[0].Code).Should(Equal(business.ValidationCodeInvalid))
+						Ω(validationErr.Failures[1].Field).Should(Equal("query"))
+						Ω(validationErr.Failures[1].Code).Should(Equal(business.ValidationCodeLength))
+					})
+				})
+
+				When("endpoint is called with a field that is not a user field", func() {
+					It("should return ArgumentError", func() {
+						request.Fields = []string{"emailVerificationTokenHash"}
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.SearchResponse)
+						validationErr := request.Validate()
+						Ω(validationErr).ShouldNot(BeNil())
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with a query longer than an email address", func() {
+					It("should return ArgumentError", func() {
+						request.Query = strings.Repeat("a", 255)
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						castedResponse := returnedResponse.(*business.SearchResponse)
+						validationErr := request.Validate()
+						Ω(validationErr).ShouldNot(BeNil())
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service Search method", func() {
+						mockBusinessService.
+							EXPECT().
+							Search(ctx, gomock.Any()).
+							Do(func(_ context.Context, mappedRequest *business.SearchRequest) {
+								Ω(mappedRequest.Pagination).Should(Equal(request.Pagination))
+								Ω(mappedRequest.SortingOptions).Should(Equal(request.SortingOptions))
+								Ω(mappedRequest.Emails).Should(Equal(request.Emails))
+							}).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(response).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.SearchResponse)
+						Ω(castedResponse.Err).Should(BeNil())
+					})
+				})
+
+				When("business service Search returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							Search(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+
+				When("business service Search returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							Search(gomock.Any(), gomock.Any()).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("StreamSearchEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.StreamSearchEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.StreamSearchRequest
+				response business.StreamSearchResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.StreamSearchEndpoint()
+				request = business.StreamSearchRequest{
+					SortingOptions: []models.SortingOptionPair{
+						{Name: "email", Direction: models.Ascending},
+					},
+					Emails: []string{cuid.New() + "@test.com"},
+					Send: func(user models.UserWithCursor) error {
+						return nil
+					},
+				}
+
+				response = business.StreamSearchResponse{
+					SentCount: 20,
+				}
+			})
+
+			Context("StreamSearchEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.StreamSearchResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.StreamSearchResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid request", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := business.StreamSearchRequest{
+							Emails: []string{cuid.New()},
+						}
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.StreamSearchResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service StreamSearch method", func() {
+						mockBusinessService.
+							EXPECT().
+							StreamSearch(ctx, gomock.Any()).
+							Do(func(_ context.Context, mappedRequest *business.StreamSearchRequest) {
+								Ω(mappedRequest.SortingOptions).Should(Equal(request.SortingOptions))
+								Ω(mappedRequest.Emails).Should(Equal(request.Emails))
+								Ω(mappedRequest.Send).ShouldNot(BeNil())
+							}).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.StreamSearchResponse)
+						Ω(castedResponse.Err).Should(BeNil())
+					})
+				})
+
+				When("business service StreamSearch returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							StreamSearch(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+
+				When("business service StreamSearch returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							StreamSearch(gomock.Any(), gomock.Any()).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("GetSagaStatusEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.GetSagaStatusEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.GetSagaStatusRequest
+				response business.GetSagaStatusResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.GetSagaStatusEndpoint()
+				request = business.GetSagaStatusRequest{
+					SagaID: cuid.New(),
+				}
+
+				response = business.GetSagaStatusResponse{
+					Saga: models.SagaState{
+						SagaID: request.SagaID,
+						Name:   cuid.New(),
+						Status: models.SagaCompleted,
+					},
+				}
+			})
+
+			Context("GetSagaStatusEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetSagaStatusResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetSagaStatusResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid request", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := business.GetSagaStatusRequest{
+							SagaID: "",
+						}
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(response).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetSagaStatusResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service GetSagaStatus method", func() {
+						mockBusinessService.
+							EXPECT().
+							GetSagaStatus(ctx, gomock.Any()).
+							Do(func(_ context.Context, mappedRequest *business.GetSagaStatusRequest) {
+								Ω(mappedRequest.SagaID).Should(Equal(request.SagaID))
+							}).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(response).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetSagaStatusResponse)
+						Ω(castedResponse.Err).Should(BeNil())
+					})
+				})
+
+				When("business service GetSagaStatus returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							GetSagaStatus(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+
+				When("business service GetSagaStatus returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							GetSagaStatus(gomock.Any(), gomock.Any()).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+
+		When("ListAuditRecordsEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ListAuditRecordsEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ListAuditRecordsRequest
+				response business.ListAuditRecordsResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ListAuditRecordsEndpoint()
+				request = business.ListAuditRecordsRequest{
+					Email:      cuid.New() + "@test.com",
+					Operations: []models.AuditOperation{models.AuditOperationDelete},
+					Limit:      rand.Intn(1000),
+				}
+
+				response = business.ListAuditRecordsResponse{
+					AuditRecords: []models.AuditRecord{
+						{
+							RecordID:  cuid.New(),
+							Operation: models.AuditOperationDelete,
+							Email:     request.Email,
+						},
+					},
+				}
+			})
+
+			Context("ListAuditRecordsEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListAuditRecordsResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListAuditRecordsResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid request", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := business.ListAuditRecordsRequest{
+							Email: cuid.New(),
+							Limit: -1,
+						}
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(response).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListAuditRecordsResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service ListAuditRecords method", func() {
+						mockBusinessService.
+							EXPECT().
+							ListAuditRecords(ctx, gomock.Any()).
+							Do(func(_ context.Context, mappedRequest *business.ListAuditRecordsRequest) {
+								Ω(mappedRequest.Email).Should(Equal(request.Email))
+								Ω(mappedRequest.Operations).Should(Equal(request.Operations))
+								Ω(mappedRequest.Limit).Should(Equal(request.Limit))
+							}).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(response).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListAuditRecordsResponse)
+						Ω(castedResponse.Err).Should(BeNil())
+					})
+				})
+
+				When("business service ListAuditRecords returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							ListAuditRecords(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+
+				When("business service ListAuditRecords returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							ListAuditRecords(gomock.Any(), gomock.Any()).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("GetEffectiveConfigurationEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.GetEffectiveConfigurationEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.GetEffectiveConfigurationRequest
+				response business.GetEffectiveConfigurationResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.GetEffectiveConfigurationEndpoint()
+				request = business.GetEffectiveConfigurationRequest{}
+				response = business.GetEffectiveConfigurationResponse{
+					Options: []models.ConfigurationOption{{Name: cuid.New()}},
+				}
+			})
+
+			Context("GetEffectiveConfigurationEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetEffectiveConfigurationResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetEffectiveConfigurationResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("business service GetEffectiveConfiguration returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							GetEffectiveConfiguration(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+
+				When("business service GetEffectiveConfiguration returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							GetEffectiveConfiguration(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("GetEnabledFeaturesEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.GetEnabledFeaturesEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.GetEnabledFeaturesRequest
+				response business.GetEnabledFeaturesResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.GetEnabledFeaturesEndpoint()
+				request = business.GetEnabledFeaturesRequest{}
+				response = business.GetEnabledFeaturesResponse{
+					Features: []models.Feature{{Name: cuid.New()}},
+				}
+			})
+
+			Context("GetEnabledFeaturesEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetEnabledFeaturesResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetEnabledFeaturesResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("business service GetEnabledFeatures returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							GetEnabledFeatures(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+
+				When("business service GetEnabledFeatures returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							GetEnabledFeatures(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("PreviewBulkUpdateUsersEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.PreviewBulkUpdateUsersEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.PreviewBulkUpdateUsersRequest
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.PreviewBulkUpdateUsersEndpoint()
+				request = business.PreviewBulkUpdateUsersRequest{
+					Emails:     []string{cuid.New() + "@test.com"},
+					UpdateMask: []string{cuid.New()},
+					SampleSize: rand.Intn(100),
+				}
+			})
+
+			Context("PreviewBulkUpdateUsersEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.PreviewBulkUpdateUsersResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.PreviewBulkUpdateUsersResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with an update mask path that is not a user field", func() {
+					It("should return ArgumentError", func() {
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.PreviewBulkUpdateUsersResponse)
+						validationErr := request.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with an update mask path of a user field that can not be bulk updated", func() {
+					It("should return ArgumentError", func() {
+						request.UpdateMask = []string{"labels", "memberships"}
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.PreviewBulkUpdateUsersResponse)
+						validationErr := request.Validate()
+						Ω(validationErr).ShouldNot(BeNil())
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without update mask", func() {
+					It("should return ArgumentError", func() {
+						request.UpdateMask = nil
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.PreviewBulkUpdateUsersResponse)
+						validationErr := request.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("BulkUpdateUsersEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.BulkUpdateUsersEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.BulkUpdateUsersRequest
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.BulkUpdateUsersEndpoint()
+				request = business.BulkUpdateUsersRequest{
+					Emails:            []string{cuid.New() + "@test.com"},
+					UpdateMask:        []string{cuid.New()},
+					ConfirmationToken: cuid.New(),
+				}
+			})
+
+			Context("BulkUpdateUsersEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.BulkUpdateUsersResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.BulkUpdateUsersResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with an update mask path that is not a user field", func() {
+					It("should return ArgumentError", func() {
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.BulkUpdateUsersResponse)
+						validationErr := request.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with an update mask path of a user field that can not be bulk updated", func() {
+					It("should return ArgumentError", func() {
+						request.UpdateMask = []string{"labels", "memberships"}
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.BulkUpdateUsersResponse)
+						validationErr := request.Validate()
+						Ω(validationErr).ShouldNot(BeNil())
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without update mask", func() {
+					It("should return ArgumentError", func() {
+						request.UpdateMask = nil
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.BulkUpdateUsersResponse)
+						validationErr := request.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("PurgeByLabelEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.PurgeByLabelEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.PurgeByLabelRequest
+				response business.PurgeByLabelResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.PurgeByLabelEndpoint()
+				request = business.PurgeByLabelRequest{
+					Label: "loadgen-" + cuid.New(),
+				}
+
+				response = business.PurgeByLabelResponse{
+					PurgedCount: rand.Int63(),
+				}
+			})
+
+			Context("PurgeByLabelEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.PurgeByLabelResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.PurgeByLabelResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with a label that could match the users not tagged with it", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := business.PurgeByLabelRequest{
+							Label: ".*",
+						}
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.PurgeByLabelResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without label", func() {
+					It("should return ArgumentError", func() {
+						request.Label = ""
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.PurgeByLabelResponse)
+						validationErr := request.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service PurgeByLabel method", func() {
+						mockBusinessService.
+							EXPECT().
+							PurgeByLabel(ctx, gomock.Any()).
+							Do(func(_ context.Context, mappedRequest *business.PurgeByLabelRequest) {
+								Ω(mappedRequest.Label).Should(Equal(request.Label))
+							}).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service PurgeByLabel returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							PurgeByLabel(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("GetOutboxLagEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.GetOutboxLagEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.GetOutboxLagRequest
+				response business.GetOutboxLagResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.GetOutboxLagEndpoint()
+				request = business.GetOutboxLagRequest{}
+				response = business.GetOutboxLagResponse{
+					OutboxLag: models.OutboxLag{
+						Broker:        "nats",
+						PendingEvents: rand.Int63(),
+					},
+				}
+			})
+
+			Context("GetOutboxLagEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetOutboxLagResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetOutboxLagResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("business service GetOutboxLag returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							GetOutboxLag(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+
+				When("business service GetOutboxLag returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							GetOutboxLag(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ListPendingEventsEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ListPendingEventsEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ListPendingEventsRequest
+				response business.ListPendingEventsResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ListPendingEventsEndpoint()
+				request = business.ListPendingEventsRequest{
+					Limit: 10,
+				}
+
+				response = business.ListPendingEventsResponse{
+					PendingEvents: []models.PendingEvent{{Sequence: rand.Int63(), Subject: "user.created"}},
+				}
+			})
+
+			Context("ListPendingEventsEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListPendingEventsResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListPendingEventsResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with a limit above the maximum", func() {
+					It("should return ArgumentError", func() {
+						request.Limit = 1001
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListPendingEventsResponse)
+						validationErr := request.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("business service ListPendingEvents returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							ListPendingEvents(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+
+				When("business service ListPendingEvents returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							ListPendingEvents(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ForceFlushEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ForceFlushEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ForceFlushRequest
+				response business.ForceFlushResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ForceFlushEndpoint()
+				request = business.ForceFlushRequest{}
+				response = business.ForceFlushResponse{
+					FlushedCount: rand.Int63(),
+				}
+			})
+
+			Context("ForceFlushEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ForceFlushResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ForceFlushResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("business service ForceFlush returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							ForceFlush(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+
+				When("business service ForceFlush returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							ForceFlush(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("GetUserPreferencesEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.GetUserPreferencesEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.GetUserPreferencesRequest
+				response business.GetUserPreferencesResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.GetUserPreferencesEndpoint()
+				request = business.GetUserPreferencesRequest{
+					Email: cuid.New() + "@test.com",
+				}
+
+				response = business.GetUserPreferencesResponse{
+					Preferences: map[string]string{models.PreferenceTheme: "dark"},
+				}
+			})
+
+			Context("GetUserPreferencesEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetUserPreferencesResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetUserPreferencesResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid request", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := business.GetUserPreferencesRequest{
+							Email: "",
+						}
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetUserPreferencesResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("business service GetUserPreferences returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							GetUserPreferences(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("UpdateUserPreferencesEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.UpdateUserPreferencesEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.UpdateUserPreferencesRequest
+				response business.UpdateUserPreferencesResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.UpdateUserPreferencesEndpoint()
+				request = business.UpdateUserPreferencesRequest{
+					Email: cuid.New() + "@test.com",
+					Preferences: map[string]string{
+						models.PreferenceTheme:              "dark",
+						models.PreferenceLanguage:           "en-AU",
+						models.PreferenceEmailNotifications: "false",
+					},
+					RemovedKeys: []string{models.PreferencePushNotifications},
+				}
+
+				response = business.UpdateUserPreferencesResponse{
+					Preferences: request.Preferences,
+				}
+			})
+
+			Context("UpdateUserPreferencesEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.UpdateUserPreferencesResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.UpdateUserPreferencesResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with an unknown preference", func() {
+					It("should return ArgumentError", func() {
+						request.Preferences["fontSize"] = "12"
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.UpdateUserPreferencesResponse)
+						validationErr := request.Validate()
+						Ω(validationErr.Error()).Should(ContainSubstring("fontSize is not a known preference"))
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with a value the preference does not support", func() {
+					It("should return ArgumentError", func() {
+						request.Preferences[models.PreferenceTheme] = "blue"
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.UpdateUserPreferencesResponse)
+						validationErr := request.Validate()
+						Ω(validationErr.Error()).Should(ContainSubstring(models.PreferenceTheme))
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with an unknown removed preference", func() {
+					It("should return ArgumentError", func() {
+						request.RemovedKeys = []string{"fontSize"}
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.UpdateUserPreferencesResponse)
+						validationErr := request.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with a preference that is both set and removed", func() {
+					It("should return ArgumentError", func() {
+						request.RemovedKeys = []string{models.PreferenceTheme}
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.UpdateUserPreferencesResponse)
+						validationErr := request.Validate()
+						Ω(validationErr.Error()).Should(ContainSubstring("can not be both set and removed"))
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("business service UpdateUserPreferences returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							UpdateUserPreferences(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("GetUserAvatarEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.GetUserAvatarEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.GetUserAvatarRequest
+				response business.GetUserAvatarResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.GetUserAvatarEndpoint()
+				request = business.GetUserAvatarRequest{
+					Email: cuid.New() + "@test.com",
+				}
+
+				response = business.GetUserAvatarResponse{
+					URL:         "https://" + cuid.New(),
+					ContentHash: cuid.New(),
+					ExpiresAt:   time.Now(),
+				}
+			})
+
+			Context("GetUserAvatarEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetUserAvatarResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetUserAvatarResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email", func() {
+					It("should return ArgumentError", func() {
+						request.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetUserAvatarResponse)
+						validationErr := request.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("business service GetUserAvatar returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							GetUserAvatar(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("SetUserAvatarEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.SetUserAvatarEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.SetUserAvatarRequest
+				response business.SetUserAvatarResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.SetUserAvatarEndpoint()
+				request = business.SetUserAvatarRequest{
+					Email:       cuid.New() + "@test.com",
+					ContentType: "image/png",
+					Content:     []byte(cuid.New()),
+				}
+
+				response = business.SetUserAvatarResponse{
+					Cursor:      cuid.New(),
+					URL:         "https://" + cuid.New(),
+					ContentHash: cuid.New(),
+					ExpiresAt:   time.Now(),
+				}
+			})
+
+			Context("SetUserAvatarEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.SetUserAvatarResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.SetUserAvatarResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without the image", func() {
+					It("should return ArgumentError", func() {
+						request.Content = nil
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.SetUserAvatarResponse)
+						validationErr := request.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("business service SetUserAvatar returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							SetUserAvatar(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ExportPersonalDataEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ExportPersonalDataEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ExportPersonalDataRequest
+				response business.ExportPersonalDataResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ExportPersonalDataEndpoint()
+				request = business.ExportPersonalDataRequest{
+					Email: cuid.New() + "@test.com",
+				}
+
+				response = business.ExportPersonalDataResponse{
+					Archive:  []byte(cuid.New()),
+					FileName: cuid.New(),
+				}
+			})
+
+			Context("ExportPersonalDataEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ExportPersonalDataResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ExportPersonalDataResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email", func() {
+					It("should return ArgumentError", func() {
+						request.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ExportPersonalDataResponse)
+						validationErr := request.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("business service ExportPersonalData returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							ExportPersonalData(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("EraseUserEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.EraseUserEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.EraseUserRequest
+				response business.EraseUserResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.EraseUserEndpoint()
+				request = business.EraseUserRequest{
+					Email:             cuid.New() + "@test.com",
+					ConfirmationToken: cuid.New(),
+				}
+
+				response = business.EraseUserResponse{
+					Erased: true,
+				}
+			})
+
+			Context("EraseUserEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.EraseUserResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.EraseUserResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with a too long confirmation token", func() {
+					It("should return ArgumentError", func() {
+						request.ConfirmationToken = strings.Repeat("a", 1025)
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.EraseUserResponse)
+						validationErr := request.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("business service EraseUser returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							EraseUser(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("AddUserToTenantEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.AddUserToTenantEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.AddUserToTenantRequest
+				response business.AddUserToTenantResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.AddUserToTenantEndpoint()
+				request = business.AddUserToTenantRequest{
+					Email:    cuid.New() + "@test.com",
+					TenantID: cuid.New(),
+					Role:     models.TenantRoleMember,
+				}
+
+				response = business.AddUserToTenantResponse{
+					Cursor: cuid.New(),
+				}
+			})
+
+			Context("AddUserToTenantEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.AddUserToTenantResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.AddUserToTenantResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without tenant ID", func() {
+					It("should return ArgumentError", func() {
+						request.TenantID = ""
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.AddUserToTenantResponse)
+						validationErr := request.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with an unknown role", func() {
+					It("should return ArgumentError", func() {
+						request.Role = "guest"
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.AddUserToTenantResponse)
+						validationErr := request.Validate()
+						Ω(validationErr.Error()).Should(ContainSubstring("Role"))
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("business service AddUserToTenant returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							AddUserToTenant(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("RemoveUserFromTenantEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.RemoveUserFromTenantEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.RemoveUserFromTenantRequest
+				response business.RemoveUserFromTenantResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.RemoveUserFromTenantEndpoint()
+				request = business.RemoveUserFromTenantRequest{
+					Email:    cuid.New() + "@test.com",
+					TenantID: cuid.New(),
+				}
+
+				response = business.RemoveUserFromTenantResponse{
+					Cursor: cuid.New(),
+				}
+			})
+
+			Context("RemoveUserFromTenantEndpoint function is returned", func() {
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RemoveUserFromTenantResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without tenant ID", func() {
+					It("should return ArgumentError", func() {
+						request.TenantID = ""
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RemoveUserFromTenantResponse)
+						validationErr := request.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("business service RemoveUserFromTenant returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							RemoveUserFromTenant(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ListUserTenantsEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ListUserTenantsEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ListUserTenantsRequest
+				response business.ListUserTenantsResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ListUserTenantsEndpoint()
+				request = business.ListUserTenantsRequest{
+					Email: cuid.New() + "@test.com",
+				}
+
+				response = business.ListUserTenantsResponse{
+					Memberships: []models.TenantMembership{{TenantID: cuid.New(), Role: models.TenantRoleOwner}},
+				}
+			})
+
+			Context("ListUserTenantsEndpoint function is returned", func() {
+				When("endpoint is called with invalid request", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := business.ListUserTenantsRequest{
+							Email: "",
+						}
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListUserTenantsResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("business service ListUserTenants returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							ListUserTenants(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("GetReplicationStatusEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.GetReplicationStatusEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.GetReplicationStatusRequest
+				response business.GetReplicationStatusResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.GetReplicationStatusEndpoint()
+				request = business.GetReplicationStatusRequest{}
+				response = business.GetReplicationStatusResponse{
+					ReplicationStatus: models.ReplicationStatus{
+						Configured: true,
+						LagChanges: rand.Int63(),
+					},
+				}
+			})
+
+			Context("GetReplicationStatusEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetReplicationStatusResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.GetReplicationStatusResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("business service GetReplicationStatus returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							GetReplicationStatus(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+
+				When("business service GetReplicationStatus returns response", func() {
+					It("should return the same response", func() {
+						mockBusinessService.
+							EXPECT().
+							GetReplicationStatus(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ExportUsersEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ExportUsersEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ExportUsersRequest
+				response business.ExportUsersResponse
+			)
+
+			BeforeEach(func() {
+				createdAfter := time.Now().Add(-time.Hour)
+				endpoint = sut.ExportUsersEndpoint()
+				request = business.ExportUsersRequest{
+					TenantID:     cuid.New(),
+					CreatedAfter: &createdAfter,
+					Send: func(user models.UserWithCursor) error {
+						return nil
+					},
+				}
+
+				response = business.ExportUsersResponse{
+					ExportedCount: 20,
+				}
+			})
+
+			Context("ExportUsersEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ExportUsersResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ExportUsersResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with the creation date range ending before it starts", func() {
+					It("should return ArgumentError", func() {
+						createdBefore := request.CreatedAfter.Add(-time.Minute)
+						invalidRequest := request
+						invalidRequest.CreatedBefore = &createdBefore
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ExportUsersResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without the function to send the users to", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Send = nil
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ExportUsersResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service ExportUsers method", func() {
+						mockBusinessService.
+							EXPECT().
+							ExportUsers(ctx, gomock.Any()).
+							Do(func(_ context.Context, mappedRequest *business.ExportUsersRequest) {
+								Ω(mappedRequest.TenantID).Should(Equal(request.TenantID))
+								Ω(mappedRequest.CreatedAfter).Should(Equal(request.CreatedAfter))
+								Ω(mappedRequest.Send).ShouldNot(BeNil())
+							}).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service ExportUsers returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							ExportUsers(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("IssueMagicLinkEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.IssueMagicLinkEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.IssueMagicLinkRequest
+				response business.IssueMagicLinkResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.IssueMagicLinkEndpoint()
+				request = business.IssueMagicLinkRequest{
+					Email:     cuid.New() + "@test.com",
+					IPAddress: "10.0.0.1",
+					DeviceID:  cuid.New(),
+				}
+
+				response = business.IssueMagicLinkResponse{
+					ExpiresAt: time.Now().Add(15 * time.Minute),
+				}
+			})
+
+			Context("IssueMagicLinkEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.IssueMagicLinkResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.IssueMagicLinkResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.IssueMagicLinkResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with invalid IP address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.IPAddress = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.IssueMagicLinkResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service IssueMagicLink method", func() {
+						mockBusinessService.
+							EXPECT().
+							IssueMagicLink(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service IssueMagicLink returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							IssueMagicLink(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("RedeemMagicLinkEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.RedeemMagicLinkEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.RedeemMagicLinkRequest
+				response business.RedeemMagicLinkResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.RedeemMagicLinkEndpoint()
+				request = business.RedeemMagicLinkRequest{
+					Email:     cuid.New() + "@test.com",
+					Token:     cuid.New(),
+					IPAddress: "10.0.0.1",
+					DeviceID:  cuid.New(),
+				}
+
+				response = business.RedeemMagicLinkResponse{
+					User: models.User{DataResidency: cuid.New()},
+				}
+			})
+
+			Context("RedeemMagicLinkEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RedeemMagicLinkResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RedeemMagicLinkResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RedeemMagicLinkResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with empty token", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Token = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RedeemMagicLinkResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with invalid IP address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.IPAddress = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RedeemMagicLinkResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service RedeemMagicLink method", func() {
+						mockBusinessService.
+							EXPECT().
+							RedeemMagicLink(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service RedeemMagicLink returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							RedeemMagicLink(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("SendVerificationEmailEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.SendVerificationEmailEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.SendVerificationEmailRequest
+				response business.SendVerificationEmailResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.SendVerificationEmailEndpoint()
+				request = business.SendVerificationEmailRequest{
+					Email: cuid.New() + "@test.com",
+				}
+
+				response = business.SendVerificationEmailResponse{
+					ExpiresAt: time.Now().Add(time.Hour),
+				}
+			})
+
+			Context("SendVerificationEmailEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.SendVerificationEmailResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.SendVerificationEmailResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.SendVerificationEmailResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service SendVerificationEmail method", func() {
+						mockBusinessService.
+							EXPECT().
+							SendVerificationEmail(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service SendVerificationEmail returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							SendVerificationEmail(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("VerifyEmailEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.VerifyEmailEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.VerifyEmailRequest
+				response business.VerifyEmailResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.VerifyEmailEndpoint()
+				request = business.VerifyEmailRequest{
+					Email: cuid.New() + "@test.com",
+					Token: cuid.New(),
+				}
+
+				response = business.VerifyEmailResponse{
+					User:   models.User{EmailVerified: true},
+					Cursor: cuid.New(),
+				}
+			})
+
+			Context("VerifyEmailEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.VerifyEmailResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.VerifyEmailResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.VerifyEmailResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without the token", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Token = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.VerifyEmailResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service VerifyEmail method", func() {
+						mockBusinessService.
+							EXPECT().
+							VerifyEmail(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service VerifyEmail returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							VerifyEmail(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("SetPasswordEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.SetPasswordEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.SetPasswordRequest
+				response business.SetPasswordResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.SetPasswordEndpoint()
+				request = business.SetPasswordRequest{
+					Email:    cuid.New() + "@test.com",
+					Password: cuid.New(),
+				}
+
+				response = business.SetPasswordResponse{}
+			})
+
+			Context("SetPasswordEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.SetPasswordResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.SetPasswordResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.SetPasswordResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without the password", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Password = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.SetPasswordResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service SetPassword method", func() {
+						mockBusinessService.
+							EXPECT().
+							SetPassword(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service SetPassword returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							SetPassword(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ChangePasswordEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ChangePasswordEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ChangePasswordRequest
+				response business.ChangePasswordResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ChangePasswordEndpoint()
+				request = business.ChangePasswordRequest{
+					Email:           cuid.New() + "@test.com",
+					CurrentPassword: cuid.New(),
+					NewPassword:     cuid.New(),
+				}
+
+				response = business.ChangePasswordResponse{}
+			})
+
+			Context("ChangePasswordEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ChangePasswordResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ChangePasswordResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ChangePasswordResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without the new password", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.NewPassword = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ChangePasswordResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service ChangePassword method", func() {
+						mockBusinessService.
+							EXPECT().
+							ChangePassword(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service ChangePassword returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							ChangePassword(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("VerifyPasswordEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.VerifyPasswordEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.VerifyPasswordRequest
+				response business.VerifyPasswordResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.VerifyPasswordEndpoint()
+				request = business.VerifyPasswordRequest{
+					Email:    cuid.New() + "@test.com",
+					Password: cuid.New(),
+				}
+
+				response = business.VerifyPasswordResponse{
+					User: models.User{EmailVerified: true},
+				}
+			})
+
+			Context("VerifyPasswordEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.VerifyPasswordResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.VerifyPasswordResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.VerifyPasswordResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without the password", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Password = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.VerifyPasswordResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service VerifyPassword method", func() {
+						mockBusinessService.
+							EXPECT().
+							VerifyPassword(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service VerifyPassword returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							VerifyPassword(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("WatchUsersEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.WatchUsersEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.WatchUsersRequest
+				response business.WatchUsersResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.WatchUsersEndpoint()
+				request = business.WatchUsersRequest{
+					Emails: []string{cuid.New() + "@test.com"},
+					Types:  []models.UserChangeType{models.UserChangeUpdated},
+					Send: func(change models.UserChange) error {
+						return nil
+					},
+				}
+
+				response = business.WatchUsersResponse{
+					SentCount: 20,
+				}
+			})
+
+			Context("WatchUsersEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.WatchUsersResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.WatchUsersResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Emails = []string{cuid.New()}
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.WatchUsersResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with unknown change type", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Types = []models.UserChangeType{models.UserChangeType(cuid.New())}
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.WatchUsersResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without the function to send the changes to", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Send = nil
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.WatchUsersResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service WatchUsers method", func() {
+						mockBusinessService.
+							EXPECT().
+							WatchUsers(ctx, gomock.Any()).
+							Do(func(_ context.Context, mappedRequest *business.WatchUsersRequest) {
+								Ω(mappedRequest.Emails).Should(Equal(request.Emails))
+								Ω(mappedRequest.Types).Should(Equal(request.Types))
+								Ω(mappedRequest.Send).ShouldNot(BeNil())
+							}).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service WatchUsers returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							WatchUsers(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("CreateAPIKeyEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.CreateAPIKeyEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.CreateAPIKeyRequest
+				response business.CreateAPIKeyResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.CreateAPIKeyEndpoint()
+				request = business.CreateAPIKeyRequest{
+					Email:  cuid.New() + "@test.com",
+					Name:   cuid.New(),
+					Scopes: []models.APIKeyScope{models.APIKeyScopeRead},
+				}
+
+				response = business.CreateAPIKeyResponse{
+					APIKey: models.APIKey{KeyID: cuid.New()},
+					Key:    cuid.New(),
+				}
+			})
+
+			Context("CreateAPIKeyEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.CreateAPIKeyResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.CreateAPIKeyResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.CreateAPIKeyResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without the name", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Name = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.CreateAPIKeyResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without the scopes", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Scopes = nil
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.CreateAPIKeyResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with unknown scope", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Scopes = []models.APIKeyScope{models.APIKeyScope(cuid.New())}
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.CreateAPIKeyResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service CreateAPIKey method", func() {
+						mockBusinessService.
+							EXPECT().
+							CreateAPIKey(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service CreateAPIKey returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							CreateAPIKey(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ListAPIKeysEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ListAPIKeysEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ListAPIKeysRequest
+				response business.ListAPIKeysResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ListAPIKeysEndpoint()
+				request = business.ListAPIKeysRequest{
+					Email: cuid.New() + "@test.com",
+				}
+
+				response = business.ListAPIKeysResponse{
+					APIKeys: []models.APIKey{{KeyID: cuid.New()}},
+				}
+			})
+
+			Context("ListAPIKeysEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListAPIKeysResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListAPIKeysResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListAPIKeysResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service ListAPIKeys method", func() {
+						mockBusinessService.
+							EXPECT().
+							ListAPIKeys(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service ListAPIKeys returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							ListAPIKeys(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("RevokeAPIKeyEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.RevokeAPIKeyEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.RevokeAPIKeyRequest
+				response business.RevokeAPIKeyResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.RevokeAPIKeyEndpoint()
+				request = business.RevokeAPIKeyRequest{
+					Email: cuid.New() + "@test.com",
+					KeyID: cuid.New(),
+				}
+
+				response = business.RevokeAPIKeyResponse{}
+			})
+
+			Context("RevokeAPIKeyEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RevokeAPIKeyResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RevokeAPIKeyResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RevokeAPIKeyResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without the ID of the API key", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.KeyID = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RevokeAPIKeyResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service RevokeAPIKey method", func() {
+						mockBusinessService.
+							EXPECT().
+							RevokeAPIKey(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service RevokeAPIKey returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							RevokeAPIKey(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("RecordLoginAttemptEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.RecordLoginAttemptEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.RecordLoginAttemptRequest
+				response business.RecordLoginAttemptResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.RecordLoginAttemptEndpoint()
+				request = business.RecordLoginAttemptRequest{
+					Email:     cuid.New() + "@test.com",
+					Succeeded: true,
+				}
+
+				response = business.RecordLoginAttemptResponse{
+					User:   models.User{},
+					Cursor: cuid.New(),
+				}
+			})
+
+			Context("RecordLoginAttemptEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RecordLoginAttemptResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RecordLoginAttemptResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with empty email", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RecordLoginAttemptResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with invalid email", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RecordLoginAttemptResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service RecordLoginAttempt method", func() {
+						mockBusinessService.
+							EXPECT().
+							RecordLoginAttempt(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service RecordLoginAttempt returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							RecordLoginAttempt(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("UnlockUserEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.UnlockUserEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.UnlockUserRequest
+				response business.UnlockUserResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.UnlockUserEndpoint()
+				request = business.UnlockUserRequest{
+					Email: cuid.New() + "@test.com",
+				}
+
+				response = business.UnlockUserResponse{
+					User:   models.User{},
+					Cursor: cuid.New(),
+				}
+			})
+
+			Context("UnlockUserEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.UnlockUserResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.UnlockUserResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with empty email", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.UnlockUserResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with invalid email", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.UnlockUserResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service UnlockUser method", func() {
+						mockBusinessService.
+							EXPECT().
+							UnlockUser(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service UnlockUser returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							UnlockUser(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("EnrollMFAEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.EnrollMFAEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.EnrollMFARequest
+				response business.EnrollMFAResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.EnrollMFAEndpoint()
+				request = business.EnrollMFARequest{
+					Email:         cuid.New() + "@test.com",
+					Type:          models.MFAMethodTypeTOTP,
+					Name:          cuid.New(),
+					TOTPSecretRef: cuid.New(),
+				}
+
+				response = business.EnrollMFAResponse{
+					Method: models.MFAMethod{MethodID: cuid.New()},
+					User:   models.User{},
+					Cursor: cuid.New(),
+				}
+			})
+
+			Context("EnrollMFAEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.EnrollMFAResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.EnrollMFAResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.EnrollMFAResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with unknown method type", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Type = models.MFAMethodType(cuid.New())
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.EnrollMFAResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without the credential that matches the method type", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.TOTPSecretRef = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.EnrollMFAResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with a credential that does not match the method type", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.WebAuthnCredentialID = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.EnrollMFAResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service EnrollMFA method", func() {
+						mockBusinessService.
+							EXPECT().
+							EnrollMFA(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service EnrollMFA returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							EnrollMFA(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ListMFAMethodsEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ListMFAMethodsEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ListMFAMethodsRequest
+				response business.ListMFAMethodsResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ListMFAMethodsEndpoint()
+				request = business.ListMFAMethodsRequest{
+					Email: cuid.New() + "@test.com",
+				}
+
+				response = business.ListMFAMethodsResponse{
+					Methods: []models.MFAMethod{{MethodID: cuid.New()}},
+				}
+			})
+
+			Context("ListMFAMethodsEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListMFAMethodsResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListMFAMethodsResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListMFAMethodsResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service ListMFAMethods method", func() {
+						mockBusinessService.
+							EXPECT().
+							ListMFAMethods(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service ListMFAMethods returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							ListMFAMethods(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("RemoveMFAMethodEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.RemoveMFAMethodEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.RemoveMFAMethodRequest
+				response business.RemoveMFAMethodResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.RemoveMFAMethodEndpoint()
+				request = business.RemoveMFAMethodRequest{
+					Email:    cuid.New() + "@test.com",
+					MethodID: cuid.New(),
+				}
+
+				response = business.RemoveMFAMethodResponse{
+					User:   models.User{},
+					Cursor: cuid.New(),
+				}
+			})
+
+			Context("RemoveMFAMethodEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RemoveMFAMethodResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RemoveMFAMethodResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RemoveMFAMethodResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without method ID", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.MethodID = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RemoveMFAMethodResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service RemoveMFAMethod method", func() {
+						mockBusinessService.
+							EXPECT().
+							RemoveMFAMethod(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service RemoveMFAMethod returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							RemoveMFAMethod(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("RecordConsentEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.RecordConsentEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.RecordConsentRequest
+				response business.RecordConsentResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.RecordConsentEndpoint()
+				request = business.RecordConsentRequest{
+					Email:   cuid.New() + "@test.com",
+					Policy:  cuid.New(),
+					Version: cuid.New(),
+				}
+
+				response = business.RecordConsentResponse{
+					Consent: models.Consent{ConsentID: cuid.New()},
+				}
+			})
+
+			Context("RecordConsentEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RecordConsentResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RecordConsentResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RecordConsentResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without policy", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Policy = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RecordConsentResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without version", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Version = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RecordConsentResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service RecordConsent method", func() {
+						mockBusinessService.
+							EXPECT().
+							RecordConsent(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service RecordConsent returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							RecordConsent(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ListConsentsEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ListConsentsEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ListConsentsRequest
+				response business.ListConsentsResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ListConsentsEndpoint()
+				request = business.ListConsentsRequest{
+					Email: cuid.New() + "@test.com",
+				}
+
+				response = business.ListConsentsResponse{
+					Consents:            []models.Consent{{ConsentID: cuid.New()}},
+					OutstandingPolicies: []models.PolicyVersion{{Policy: cuid.New(), Version: cuid.New()}},
+				}
+			})
+
+			Context("ListConsentsEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListConsentsResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListConsentsResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListConsentsResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service ListConsents method", func() {
+						mockBusinessService.
+							EXPECT().
+							ListConsents(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service ListConsents returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							ListConsents(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+
+	Context("EndpointCreatorService is instantiated", func() {
+		When("CreateGroupEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.CreateGroupEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.CreateGroupRequest
+				response business.CreateGroupResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.CreateGroupEndpoint()
+				request = business.CreateGroupRequest{
+					Name:        cuid.New(),
+					Description: cuid.New(),
+				}
+
+				response = business.CreateGroupResponse{
+					Group: models.Group{GroupID: cuid.New()},
+				}
+			})
+
+			Context("CreateGroupEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.CreateGroupResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.CreateGroupResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without name", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Name = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.CreateGroupResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service CreateGroup method", func() {
+						mockBusinessService.
+							EXPECT().
+							CreateGroup(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service CreateGroup returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							CreateGroup(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ReadGroupEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ReadGroupEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ReadGroupRequest
+				response business.ReadGroupResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ReadGroupEndpoint()
+				request = business.ReadGroupRequest{
+					GroupID: cuid.New(),
+				}
+
+				response = business.ReadGroupResponse{
+					Group: models.Group{GroupID: cuid.New()},
+				}
+			})
+
+			Context("ReadGroupEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ReadGroupResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ReadGroupResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without group ID", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.GroupID = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ReadGroupResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service ReadGroup method", func() {
+						mockBusinessService.
+							EXPECT().
+							ReadGroup(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service ReadGroup returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							ReadGroup(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("UpdateGroupEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.UpdateGroupEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.UpdateGroupRequest
+				response business.UpdateGroupResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.UpdateGroupEndpoint()
+				request = business.UpdateGroupRequest{
+					GroupID:     cuid.New(),
+					Name:        cuid.New(),
+					Description: cuid.New(),
+				}
+
+				response = business.UpdateGroupResponse{
+					Group: models.Group{GroupID: cuid.New()},
+				}
+			})
+
+			Context("UpdateGroupEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.UpdateGroupResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.UpdateGroupResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without group ID", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.GroupID = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.UpdateGroupResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called without name", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Name = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.UpdateGroupResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service UpdateGroup method", func() {
+						mockBusinessService.
+							EXPECT().
+							UpdateGroup(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service UpdateGroup returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							UpdateGroup(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("DeleteGroupEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.DeleteGroupEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.DeleteGroupRequest
+				response business.DeleteGroupResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.DeleteGroupEndpoint()
+				request = business.DeleteGroupRequest{
+					GroupID: cuid.New(),
+				}
+
+				response = business.DeleteGroupResponse{}
+			})
+
+			Context("DeleteGroupEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.DeleteGroupResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.DeleteGroupResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without group ID", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.GroupID = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.DeleteGroupResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service DeleteGroup method", func() {
+						mockBusinessService.
+							EXPECT().
+							DeleteGroup(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service DeleteGroup returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							DeleteGroup(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ListGroupsEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ListGroupsEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ListGroupsRequest
+				response business.ListGroupsResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ListGroupsEndpoint()
+				request = business.ListGroupsRequest{
+					Email: cuid.New() + "@test.com",
+				}
+
+				response = business.ListGroupsResponse{
+					Groups: []models.Group{{GroupID: cuid.New()}},
+				}
+			})
+
+			Context("ListGroupsEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListGroupsResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListGroupsResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListGroupsResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service ListGroups method", func() {
+						mockBusinessService.
+							EXPECT().
+							ListGroups(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service ListGroups returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							ListGroups(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("AddUserToGroupEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.AddUserToGroupEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.AddUserToGroupRequest
+				response business.AddUserToGroupResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.AddUserToGroupEndpoint()
+				request = business.AddUserToGroupRequest{
+					GroupID: cuid.New(),
+					Email:   cuid.New() + "@test.com",
+				}
+
+				response = business.AddUserToGroupResponse{
+					Group: models.Group{GroupID: cuid.New()},
+				}
+			})
+
+			Context("AddUserToGroupEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.AddUserToGroupResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.AddUserToGroupResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without group ID", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.GroupID = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.AddUserToGroupResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.AddUserToGroupResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service AddUserToGroup method", func() {
+						mockBusinessService.
+							EXPECT().
+							AddUserToGroup(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service AddUserToGroup returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							AddUserToGroup(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("RemoveUserFromGroupEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.RemoveUserFromGroupEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.RemoveUserFromGroupRequest
+				response business.RemoveUserFromGroupResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.RemoveUserFromGroupEndpoint()
+				request = business.RemoveUserFromGroupRequest{
+					GroupID: cuid.New(),
+					Email:   cuid.New() + "@test.com",
+				}
+
+				response = business.RemoveUserFromGroupResponse{
+					Group: models.Group{GroupID: cuid.New()},
+				}
+			})
+
+			Context("RemoveUserFromGroupEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RemoveUserFromGroupResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RemoveUserFromGroupResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without group ID", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.GroupID = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RemoveUserFromGroupResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RemoveUserFromGroupResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service RemoveUserFromGroup method", func() {
+						mockBusinessService.
+							EXPECT().
+							RemoveUserFromGroup(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service RemoveUserFromGroup returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							RemoveUserFromGroup(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+
+		When("InviteUserEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.InviteUserEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.InviteUserRequest
+				response business.InviteUserResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.InviteUserEndpoint()
+				request = business.InviteUserRequest{
+					Email: cuid.New() + "@test.com",
+				}
+
+				response = business.InviteUserResponse{
+					ExpiresAt: time.Now(),
+				}
+			})
+
+			Context("InviteUserEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.InviteUserResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.InviteUserResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.InviteUserResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service InviteUser method", func() {
+						mockBusinessService.
+							EXPECT().
+							InviteUser(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service InviteUser returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							InviteUser(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+
+		When("AcceptInvitationEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.AcceptInvitationEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.AcceptInvitationRequest
+				response business.AcceptInvitationResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.AcceptInvitationEndpoint()
+				request = business.AcceptInvitationRequest{
+					Token: cuid.New(),
+					User:  models.User{},
+				}
+
+				response = business.AcceptInvitationResponse{
+					User:   models.User{},
+					Cursor: cuid.New(),
+				}
+			})
+
+			Context("AcceptInvitationEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.AcceptInvitationResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.AcceptInvitationResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without token", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Token = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.AcceptInvitationResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service AcceptInvitation method with the email address in the token", func() {
+						mockBusinessService.
+							EXPECT().
+							AcceptInvitation(ctx, gomock.Any()).
+							Do(func(_ context.Context, mappedRequest *business.AcceptInvitationRequest) {
+								parsedToken := ctx.Value(models.ContextKeyParsedToken).(models.ParsedToken)
+								Ω(mappedRequest.Email).Should(Equal(parsedToken.Email))
+								Ω(mappedRequest.Token).Should(Equal(request.Token))
+							}).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service AcceptInvitation returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							AcceptInvitation(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("RegisterSessionEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.RegisterSessionEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.RegisterSessionRequest
+				response business.RegisterSessionResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.RegisterSessionEndpoint()
+				request = business.RegisterSessionRequest{
+					Email:      cuid.New() + "@test.com",
+					SessionID:  cuid.New(),
+					DeviceName: "laptop",
+					UserAgent:  "Mozilla/5.0",
+					IPAddress:  "10.0.0.1",
+				}
+
+				response = business.RegisterSessionResponse{
+					Session: models.Session{SessionID: cuid.New()},
+				}
+			})
+
+			Context("RegisterSessionEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RegisterSessionResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RegisterSessionResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without session ID", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.SessionID = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RegisterSessionResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with invalid IP address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.IPAddress = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RegisterSessionResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service RegisterSession method", func() {
+						mockBusinessService.
+							EXPECT().
+							RegisterSession(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service RegisterSession returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							RegisterSession(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ListSessionsEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ListSessionsEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ListSessionsRequest
+				response business.ListSessionsResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ListSessionsEndpoint()
+				request = business.ListSessionsRequest{
+					Email: cuid.New() + "@test.com",
+				}
+
+				response = business.ListSessionsResponse{
+					Sessions: []models.Session{{SessionID: cuid.New()}},
+				}
+			})
+
+			Context("ListSessionsEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListSessionsResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListSessionsResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with invalid email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = cuid.New()
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListSessionsResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service ListSessions method", func() {
+						mockBusinessService.
+							EXPECT().
+							ListSessions(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service ListSessions returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							ListSessions(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("RevokeSessionEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.RevokeSessionEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.RevokeSessionRequest
+				response business.RevokeSessionResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.RevokeSessionEndpoint()
+				request = business.RevokeSessionRequest{
+					Email:     cuid.New() + "@test.com",
+					SessionID: cuid.New(),
+				}
+
+				response = business.RevokeSessionResponse{}
+			})
+
+			Context("RevokeSessionEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RevokeSessionResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RevokeSessionResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without session ID", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.SessionID = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.RevokeSessionResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service RevokeSession method", func() {
+						mockBusinessService.
+							EXPECT().
+							RevokeSession(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service RevokeSession returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							RevokeSession(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("CreateWebhookSubscriptionEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.CreateWebhookSubscriptionEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.CreateWebhookSubscriptionRequest
+				response business.CreateWebhookSubscriptionResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.CreateWebhookSubscriptionEndpoint()
+				request = business.CreateWebhookSubscriptionRequest{
+					Email:      cuid.New() + "@test.com",
+					URL:        "https://example.com/hook",
+					Secret:     "0123456789abcdef",
+					EventTypes: []models.WebhookEventType{models.WebhookEventUserUpdated},
+				}
+
+				response = business.CreateWebhookSubscriptionResponse{}
+			})
+
+			Context("CreateWebhookSubscriptionEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.CreateWebhookSubscriptionResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.CreateWebhookSubscriptionResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with plain HTTP URL", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.URL = "http://example.com/hook"
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.CreateWebhookSubscriptionResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service CreateWebhookSubscription method", func() {
+						mockBusinessService.
+							EXPECT().
+							CreateWebhookSubscription(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service CreateWebhookSubscription returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							CreateWebhookSubscription(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ListWebhookSubscriptionsEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ListWebhookSubscriptionsEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ListWebhookSubscriptionsRequest
+				response business.ListWebhookSubscriptionsResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ListWebhookSubscriptionsEndpoint()
+				request = business.ListWebhookSubscriptionsRequest{
+					Email: cuid.New() + "@test.com",
+				}
+
+				response = business.ListWebhookSubscriptionsResponse{}
+			})
+
+			Context("ListWebhookSubscriptionsEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListWebhookSubscriptionsResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListWebhookSubscriptionsResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without email address", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Email = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListWebhookSubscriptionsResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service ListWebhookSubscriptions method", func() {
+						mockBusinessService.
+							EXPECT().
+							ListWebhookSubscriptions(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service ListWebhookSubscriptions returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							ListWebhookSubscriptions(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("DeleteWebhookSubscriptionEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.DeleteWebhookSubscriptionEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.DeleteWebhookSubscriptionRequest
+				response business.DeleteWebhookSubscriptionResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.DeleteWebhookSubscriptionEndpoint()
+				request = business.DeleteWebhookSubscriptionRequest{
+					Email:          cuid.New() + "@test.com",
+					SubscriptionID: cuid.New(),
+				}
+
+				response = business.DeleteWebhookSubscriptionResponse{}
+			})
+
+			Context("DeleteWebhookSubscriptionEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.DeleteWebhookSubscriptionResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.DeleteWebhookSubscriptionResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called without subscription ID", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.SubscriptionID = ""
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.DeleteWebhookSubscriptionResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service DeleteWebhookSubscription method", func() {
+						mockBusinessService.
+							EXPECT().
+							DeleteWebhookSubscription(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service DeleteWebhookSubscription returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							DeleteWebhookSubscription(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("EndpointCreatorService is instantiated", func() {
+		When("ListWebhookDeliveriesEndpoint is called", func() {
+			It("should return valid function", func() {
+				endpoint := sut.ListWebhookDeliveriesEndpoint()
+				Ω(endpoint).ShouldNot(BeNil())
+			})
+
+			var (
+				endpoint gokitendpoint.Endpoint
+				request  business.ListWebhookDeliveriesRequest
+				response business.ListWebhookDeliveriesResponse
+			)
+
+			BeforeEach(func() {
+				endpoint = sut.ListWebhookDeliveriesEndpoint()
+				request = business.ListWebhookDeliveriesRequest{
+					Email:          cuid.New() + "@test.com",
+					SubscriptionID: cuid.New(),
+					Limit:          10,
+				}
+
+				response = business.ListWebhookDeliveriesResponse{}
+			})
+
+			Context("ListWebhookDeliveriesEndpoint function is returned", func() {
+				When("endpoint is called with nil context", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(nil, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListWebhookDeliveriesResponse)
+						assertArgumentNilError("ctx", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with nil request", func() {
+					It("should return ArgumentNilError", func() {
+						returnedResponse, err := endpoint(ctx, nil)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListWebhookDeliveriesResponse)
+						assertArgumentNilError("request", "", castedResponse.Err)
+					})
+				})
+
+				When("endpoint is called with too large limit", func() {
+					It("should return ArgumentError", func() {
+						invalidRequest := request
+						invalidRequest.Limit = 1000
+						returnedResponse, err := endpoint(ctx, &invalidRequest)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).ShouldNot(BeNil())
+						castedResponse := returnedResponse.(*business.ListWebhookDeliveriesResponse)
+						validationErr := invalidRequest.Validate()
+						assertArgumentError("request", validationErr.Error(), castedResponse.Err, validationErr)
+					})
+				})
+
+				When("endpoint is called with valid request", func() {
+					It("should call business service ListWebhookDeliveries method", func() {
+						mockBusinessService.
+							EXPECT().
+							ListWebhookDeliveries(ctx, &request).
+							Return(&response, nil)
+
+						returnedResponse, err := endpoint(ctx, &request)
+
+						Ω(err).Should(BeNil())
+						Ω(returnedResponse).Should(Equal(&response))
+					})
+				})
+
+				When("business service ListWebhookDeliveries returns error", func() {
+					It("should return the same error", func() {
+						expectedErr := errors.New(cuid.New())
+						mockBusinessService.
+							EXPECT().
+							ListWebhookDeliveries(gomock.Any(), gomock.Any()).
+							Return(nil, expectedErr)
+
+						_, err := endpoint(ctx, &request)
+
+						Ω(err).Should(Equal(expectedErr))
+					})
+				})
+			})
+		})
+	})
+	Context("the timeouts of the endpoints are configured", func() {
+		var timeoutSut endpoint.EndpointCreatorContract
+
+		BeforeEach(func() {
+			timeoutConfigurationService := configurationMock.NewMockConfigurationContract(mockCtrl)
+			timeoutConfigurationService.
+				EXPECT().
+				GetEndpointDefaultTimeout().
+				Return(time.Hour, nil)
+
+			timeoutConfigurationService.
+				EXPECT().
+				GetEndpointTimeouts().
+				Return(map[string]time.Duration{"ReadUser": time.Minute}, nil)
+
+			timeoutSut, _ = endpoint.NewEndpointCreatorService(mockBusinessService, mockCanaryService, timeoutConfigurationService)
+		})
+
+		When("the endpoint has a timeout of its own", func() {
+			It("should call the business service with the deadline of the endpoint", func() {
+				mockBusinessService.
+					EXPECT().
+					ReadUser(gomock.Any(), gomock.Any()).
+					Do(func(callCtx context.Context, _ *business.ReadUserRequest) {
+						deadline, ok := callCtx.Deadline()
+						Ω(ok).Should(BeTrue())
+						Ω(deadline).Should(BeTemporally("~", time.Now().Add(time.Minute), time.Second))
+					}).
+					Return(&business.ReadUserResponse{}, nil)
+
+				_, err := timeoutSut.ReadUserEndpoint()(ctx, &business.ReadUserRequest{Email: cuid.New() + "@test.com"})
+				Ω(err).Should(BeNil())
+			})
+
+			It("should keep the deadline of the caller if it is earlier", func() {
+				callerCtx, cancel := context.WithTimeout(ctx, time.Second)
+				defer cancel()
+
+				callerDeadline, _ := callerCtx.Deadline()
+				mockBusinessService.
+					EXPECT().
+					ReadUser(gomock.Any(), gomock.Any()).
+					Do(func(callCtx context.Context, _ *business.ReadUserRequest) {
+						deadline, _ := callCtx.Deadline()
+						Ω(deadline).Should(Equal(callerDeadline))
+					}).
+					Return(&business.ReadUserResponse{}, nil)
+
+				_, err := timeoutSut.ReadUserEndpoint()(callerCtx, &business.ReadUserRequest{Email: cuid.New() + "@test.com"})
+				Ω(err).Should(BeNil())
+			})
+		})
+
+		When("the endpoint has no timeout of its own", func() {
+			It("should call the business service with the default deadline", func() {
+				mockBusinessService.
+					EXPECT().
+					DeleteUser(gomock.Any(), gomock.Any()).
+					Do(func(callCtx context.Context, _ *business.DeleteUserRequest) {
+						deadline, ok := callCtx.Deadline()
+						Ω(ok).Should(BeTrue())
+						Ω(deadline).Should(BeTemporally("~", time.Now().Add(time.Hour), time.Second))
+					}).
+					Return(&business.DeleteUserResponse{}, nil)
+
+				_, err := timeoutSut.DeleteUserEndpoint()(ctx, &business.DeleteUserRequest{Email: cuid.New() + "@test.com"})
+				Ω(err).Should(BeNil())
+			})
+		})
+
+		When("the endpoint is a streaming endpoint", func() {
+			It("should not apply the default timeout", func() {
+				mockBusinessService.
+					EXPECT().
+					WatchUsers(gomock.Any(), gomock.Any()).
+					Do(func(callCtx context.Context, _ *business.WatchUsersRequest) {
+						_, ok := callCtx.Deadline()
+						Ω(ok).Should(BeFalse())
+					}).
+					Return(&business.WatchUsersResponse{}, nil)
+
+				_, err := timeoutSut.WatchUsersEndpoint()(ctx, &business.WatchUsersRequest{
+					Emails: []string{cuid.New() + "@test.com"},
+					Send: func(change models.UserChange) error {
+						return nil
+					},
+				})
+				Ω(err).Should(BeNil())
+			})
+		})
+	})
 })
 
 func assertArgumentNilError(expectedArgumentName, expectedMessage string, err error) {
@@ -542,5 +6930,13 @@ func assertArgumentError(expectedArgumentName, expectedMessage string, err error
 
 	Ω(argumentErr.ArgumentName).Should(Equal(expectedArgumentName))
 	Ω(strings.Contains(argumentErr.Error(), expectedMessage)).Should(BeTrue())
-	Ω(errors.Unwrap(err)).Should(Equal(nestedErr))
+
+	// The invalid requests carry the validation failures along the validation errors they are created from
+	nested := errors.Unwrap(err)
+	var validationErr business.ValidationError
+	if errors.As(nested, &validationErr) {
+		nested = validationErr.Unwrap()
+	}
+
+	Ω(nested).Should(Equal(nestedErr))
 }
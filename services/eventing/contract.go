@@ -0,0 +1,111 @@
+// Package eventing implements different eventing services required by the user service
+package eventing
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// EventingContract declares the eventing service that publishes the user lifecycle events
+// so other services can react to the user changes.
+type EventingContract interface {
+	// PublishUserCreated publishes the event raised when a new user is created
+	// ctx: Mandatory The reference to the context
+	// event: Mandatory. The event to publish
+	// Returns error if something goes wrong.
+	PublishUserCreated(
+		ctx context.Context,
+		event *UserCreatedEvent) error
+
+	// PublishUserUpdated publishes the event raised when an existing user is updated
+	// ctx: Mandatory The reference to the context
+	// event: Mandatory. The event to publish
+	// Returns error if something goes wrong.
+	PublishUserUpdated(
+		ctx context.Context,
+		event *UserUpdatedEvent) error
+
+	// PublishUserDeleted publishes the event raised when an existing user is deleted
+	// ctx: Mandatory The reference to the context
+	// event: Mandatory. The event to publish
+	// Returns error if something goes wrong.
+	PublishUserDeleted(
+		ctx context.Context,
+		event *UserDeletedEvent) error
+
+	// PublishUserRestored publishes the event raised when a soft deleted user is restored
+	// ctx: Mandatory The reference to the context
+	// event: Mandatory. The event to publish
+	// Returns error if something goes wrong.
+	PublishUserRestored(
+		ctx context.Context,
+		event *UserRestoredEvent) error
+
+	// PublishMagicLinkIssued publishes the event raised when a magic link is issued to a user, so the service that
+	// sends the emails can send the magic link token to the email address of the user
+	// ctx: Mandatory The reference to the context
+	// event: Mandatory. The event to publish
+	// Returns error if something goes wrong.
+	PublishMagicLinkIssued(
+		ctx context.Context,
+		event *MagicLinkIssuedEvent) error
+
+	// PublishEmailVerificationRequested publishes the event raised when a verification email is requested, so the
+	// service that sends the emails can send the token to the user
+	// ctx: Mandatory The reference to the context
+	// event: Mandatory. The event to publish
+	// Returns error if something goes wrong.
+	PublishEmailVerificationRequested(
+		ctx context.Context,
+		event *EmailVerificationRequestedEvent) error
+
+	// PublishUserInvited publishes the event raised when an email address is invited, so the service that sends the
+	// emails can send the invitation token to the invited email address
+	// ctx: Mandatory The reference to the context
+	// event: Mandatory. The event to publish
+	// Returns error if something goes wrong.
+	PublishUserInvited(
+		ctx context.Context,
+		event *UserInvitedEvent) error
+
+	// PublishEmailChangeRequested publishes the event raised when a user requests changing the email address, so the
+	// service that sends the emails can send the confirmation token to the new email address
+	// ctx: Mandatory The reference to the context
+	// event: Mandatory. The event to publish
+	// Returns error if something goes wrong.
+	PublishEmailChangeRequested(
+		ctx context.Context,
+		event *EmailChangeRequestedEvent) error
+
+	// PublishEmailChanged publishes the event raised when the email address change of a user is confirmed, so the
+	// service that sends the emails can notify the previous email address of the change
+	// ctx: Mandatory The reference to the context
+	// event: Mandatory. The event to publish
+	// Returns error if something goes wrong.
+	PublishEmailChanged(
+		ctx context.Context,
+		event *EmailChangedEvent) error
+
+	// GetOutboxLag reads how many published events the message broker has not confirmed receiving yet and for how long
+	// ctx: Mandatory The reference to the context
+	// Returns either the publisher lag or error if something goes wrong.
+	GetOutboxLag(ctx context.Context) (*models.OutboxLag, error)
+
+	// ListPendingEvents lists the published events the message broker has not confirmed receiving yet, the oldest first
+	// ctx: Mandatory The reference to the context
+	// limit: Mandatory. The maximum number of the events to list
+	// Returns either the pending events or error if something goes wrong.
+	ListPendingEvents(
+		ctx context.Context,
+		limit int) ([]models.PendingEvent, error)
+
+	// Flush sends the buffered events and waits for the message broker to confirm receiving all the published events
+	// ctx: Mandatory The reference to the context
+	// Returns either the number of the events confirmed or error if something goes wrong.
+	Flush(ctx context.Context) (int64, error)
+
+	// Close flushes the pending events and closes the connection to the message broker
+	// Returns error if something goes wrong.
+	Close() error
+}
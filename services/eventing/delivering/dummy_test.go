@@ -0,0 +1 @@
+package delivering_test
@@ -0,0 +1,216 @@
+// Package delivering implements the eventing service decorator that delivers the user events it publishes to the
+// webhooks the users subscribe to
+package delivering
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/correlation"
+	"github.com/decentralized-cloud/user/services/eventing"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/webhook"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+)
+
+type deliveringEventingService struct {
+	logger            *zap.Logger
+	eventingService   eventing.EventingContract
+	repositoryService repository.RepositoryContract
+	webhookService    webhook.WebhookContract
+}
+
+// NewDeliveringEventingService creates new instance of the deliveringEventingService, setting up all dependencies and returns the instance
+// logger: Mandatory. Reference to the logger service
+// eventingService: Mandatory. Reference to the eventing service the events are published with
+// repositoryService: Mandatory. Reference to the repository service the preferences of the users are read from
+// webhookService: Mandatory. Reference to the service that delivers the events to the webhooks of the users
+// Returns the new service or error if something goes wrong
+func NewDeliveringEventingService(
+	logger *zap.Logger,
+	eventingService eventing.EventingContract,
+	repositoryService repository.RepositoryContract,
+	webhookService webhook.WebhookContract) (eventing.EventingContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	if eventingService == nil {
+		return nil, commonErrors.NewArgumentNilError("eventingService", "eventingService is required")
+	}
+
+	if repositoryService == nil {
+		return nil, commonErrors.NewArgumentNilError("repositoryService", "repositoryService is required")
+	}
+
+	if webhookService == nil {
+		return nil, commonErrors.NewArgumentNilError("webhookService", "webhookService is required")
+	}
+
+	return &deliveringEventingService{
+		logger:            logger,
+		eventingService:   eventingService,
+		repositoryService: repositoryService,
+		webhookService:    webhookService,
+	}, nil
+}
+
+// PublishUserCreated publishes the event raised when a new user is created and delivers it to the webhooks
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *deliveringEventingService) PublishUserCreated(
+	ctx context.Context,
+	event *eventing.UserCreatedEvent) error {
+	service.deliver(ctx, event.Email, models.WebhookEventUserCreated)
+
+	return service.eventingService.PublishUserCreated(ctx, event)
+}
+
+// PublishUserUpdated publishes the event raised when an existing user is updated and delivers it to the webhooks
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *deliveringEventingService) PublishUserUpdated(
+	ctx context.Context,
+	event *eventing.UserUpdatedEvent) error {
+	service.deliver(ctx, event.Email, models.WebhookEventUserUpdated)
+
+	return service.eventingService.PublishUserUpdated(ctx, event)
+}
+
+// PublishUserDeleted publishes the event raised when an existing user is deleted and delivers it to the webhooks
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *deliveringEventingService) PublishUserDeleted(
+	ctx context.Context,
+	event *eventing.UserDeletedEvent) error {
+	service.deliver(ctx, event.Email, models.WebhookEventUserDeleted)
+
+	return service.eventingService.PublishUserDeleted(ctx, event)
+}
+
+// PublishUserRestored publishes the event raised when a soft deleted user is restored and delivers it to the
+// webhooks
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *deliveringEventingService) PublishUserRestored(
+	ctx context.Context,
+	event *eventing.UserRestoredEvent) error {
+	service.deliver(ctx, event.Email, models.WebhookEventUserRestored)
+
+	return service.eventingService.PublishUserRestored(ctx, event)
+}
+
+// PublishMagicLinkIssued publishes the event raised when a magic link is issued to a user, it is not delivered to
+// the webhooks as it carries the token the user signs in with
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *deliveringEventingService) PublishMagicLinkIssued(
+	ctx context.Context,
+	event *eventing.MagicLinkIssuedEvent) error {
+	return service.eventingService.PublishMagicLinkIssued(ctx, event)
+}
+
+// PublishEmailVerificationRequested publishes the event raised when a verification email is requested, it is not
+// delivered to the webhooks as it carries the verification token
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *deliveringEventingService) PublishEmailVerificationRequested(
+	ctx context.Context,
+	event *eventing.EmailVerificationRequestedEvent) error {
+	return service.eventingService.PublishEmailVerificationRequested(ctx, event)
+}
+
+// PublishUserInvited publishes the event raised when an email address is invited, it is not delivered to the
+// webhooks as the invited email address has no user yet
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *deliveringEventingService) PublishUserInvited(
+	ctx context.Context,
+	event *eventing.UserInvitedEvent) error {
+	return service.eventingService.PublishUserInvited(ctx, event)
+}
+
+// PublishEmailChangeRequested publishes the event raised when a user requests changing the email address, it is not
+// delivered to the webhooks as it carries the confirmation token
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *deliveringEventingService) PublishEmailChangeRequested(
+	ctx context.Context,
+	event *eventing.EmailChangeRequestedEvent) error {
+	return service.eventingService.PublishEmailChangeRequested(ctx, event)
+}
+
+// PublishEmailChanged publishes the event raised when the email address change of a user is confirmed, it is not
+// delivered to the webhooks as they are delivered the user updated event published for the change
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *deliveringEventingService) PublishEmailChanged(
+	ctx context.Context,
+	event *eventing.EmailChangedEvent) error {
+	return service.eventingService.PublishEmailChanged(ctx, event)
+}
+
+// GetOutboxLag reads how many published events the message broker has not confirmed receiving yet and for how long
+// ctx: Mandatory The reference to the context
+// Returns either the publisher lag or error if something goes wrong.
+func (service *deliveringEventingService) GetOutboxLag(ctx context.Context) (*models.OutboxLag, error) {
+	return service.eventingService.GetOutboxLag(ctx)
+}
+
+// ListPendingEvents lists the published events the message broker has not confirmed receiving yet, the oldest first
+// ctx: Mandatory The reference to the context
+// limit: Mandatory. The maximum number of the events to list
+// Returns either the pending events or error if something goes wrong.
+func (service *deliveringEventingService) ListPendingEvents(
+	ctx context.Context,
+	limit int) ([]models.PendingEvent, error) {
+	return service.eventingService.ListPendingEvents(ctx, limit)
+}
+
+// Flush sends the buffered events and waits for the message broker to confirm receiving all the published events
+// ctx: Mandatory The reference to the context
+// Returns either the number of the events confirmed or error if something goes wrong.
+func (service *deliveringEventingService) Flush(ctx context.Context) (int64, error) {
+	return service.eventingService.Flush(ctx)
+}
+
+// Close flushes the pending events and closes the connection to the message broker
+// Returns error if something goes wrong.
+func (service *deliveringEventingService) Close() error {
+	return service.eventingService.Close()
+}
+
+// deliver delivers the event to the webhooks of the user unless the user turned the webhook notifications off. The
+// change the event is raised for is already made, so failing to deliver it does not fail publishing it and is only
+// logged. The users that do not exist anymore are notified of their deletion.
+func (service *deliveringEventingService) deliver(ctx context.Context, email string, eventType models.WebhookEventType) {
+	logger := correlation.GetLogger(ctx, service.logger)
+
+	response, err := service.repositoryService.ReadUserPreferences(ctx, &repository.ReadUserPreferencesRequest{
+		Email: email,
+	})
+
+	if err != nil && !commonErrors.IsNotFoundError(err) {
+		logger.Error("failed to read the webhook notifications preference", zap.String("event", string(eventType)), zap.Error(err))
+
+		return
+	}
+
+	if err == nil && response.Preferences[models.PreferenceWebhookNotifications] == "false" {
+		return
+	}
+
+	if err = service.webhookService.Deliver(ctx, email, eventType); err != nil {
+		logger.Error("failed to deliver the event to the webhooks", zap.String("event", string(eventType)), zap.Error(err))
+	}
+}
@@ -0,0 +1 @@
+package eventing_test
@@ -0,0 +1,76 @@
+// Package eventing implements different eventing services required by the user service
+package eventing
+
+import (
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// UserCreatedEvent contains the details of the user that is created
+type UserCreatedEvent struct {
+	Email  string
+	User   models.User
+	Cursor string
+}
+
+// UserUpdatedEvent contains the details of the user that is updated
+type UserUpdatedEvent struct {
+	Email  string
+	User   models.User
+	Cursor string
+
+	// PreviousEmail is the email address the user had before, it is only set if the email address is changed
+	PreviousEmail string
+}
+
+// UserDeletedEvent contains the details of the user that is deleted
+type UserDeletedEvent struct {
+	Email       string
+	SoftDeleted bool
+}
+
+// UserRestoredEvent contains the details of the soft deleted user that is restored
+type UserRestoredEvent struct {
+	Email  string
+	User   models.User
+	Cursor string
+}
+
+// MagicLinkIssuedEvent contains the token to send to the user to sign in with
+type MagicLinkIssuedEvent struct {
+	Email     string
+	Token     string
+	ExpiresAt time.Time
+}
+
+// EmailVerificationRequestedEvent contains the token to send to the user to verify the email address with
+type EmailVerificationRequestedEvent struct {
+	Email     string
+	Token     string
+	ExpiresAt time.Time
+}
+
+// UserInvitedEvent contains the token to send to the invited email address to accept the invitation with
+type UserInvitedEvent struct {
+	Email     string
+	InvitedBy string
+	Token     string
+	ExpiresAt time.Time
+}
+
+// EmailChangeRequestedEvent contains the token to send to the new email address of the user to confirm the email
+// address change with
+type EmailChangeRequestedEvent struct {
+	Email     string
+	NewEmail  string
+	Token     string
+	ExpiresAt time.Time
+}
+
+// EmailChangedEvent contains the details of the email address change to notify the previous email address of the
+// user of
+type EmailChangedEvent struct {
+	Email    string
+	NewEmail string
+}
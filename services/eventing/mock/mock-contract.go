@@ -0,0 +1,222 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/eventing/contract.go
+
+// Package mock_eventing is a generated GoMock package.
+package mock_eventing
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/decentralized-cloud/user/models"
+	eventing "github.com/decentralized-cloud/user/services/eventing"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEventingContract is a mock of EventingContract interface.
+type MockEventingContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventingContractMockRecorder
+}
+
+// MockEventingContractMockRecorder is the mock recorder for MockEventingContract.
+type MockEventingContractMockRecorder struct {
+	mock *MockEventingContract
+}
+
+// NewMockEventingContract creates a new mock instance.
+func NewMockEventingContract(ctrl *gomock.Controller) *MockEventingContract {
+	mock := &MockEventingContract{ctrl: ctrl}
+	mock.recorder = &MockEventingContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventingContract) EXPECT() *MockEventingContractMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockEventingContract) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockEventingContractMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockEventingContract)(nil).Close))
+}
+
+// Flush mocks base method.
+func (m *MockEventingContract) Flush(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockEventingContractMockRecorder) Flush(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockEventingContract)(nil).Flush), ctx)
+}
+
+// GetOutboxLag mocks base method.
+func (m *MockEventingContract) GetOutboxLag(ctx context.Context) (*models.OutboxLag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxLag", ctx)
+	ret0, _ := ret[0].(*models.OutboxLag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutboxLag indicates an expected call of GetOutboxLag.
+func (mr *MockEventingContractMockRecorder) GetOutboxLag(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxLag", reflect.TypeOf((*MockEventingContract)(nil).GetOutboxLag), ctx)
+}
+
+// ListPendingEvents mocks base method.
+func (m *MockEventingContract) ListPendingEvents(ctx context.Context, limit int) ([]models.PendingEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingEvents", ctx, limit)
+	ret0, _ := ret[0].([]models.PendingEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingEvents indicates an expected call of ListPendingEvents.
+func (mr *MockEventingContractMockRecorder) ListPendingEvents(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingEvents", reflect.TypeOf((*MockEventingContract)(nil).ListPendingEvents), ctx, limit)
+}
+
+// PublishEmailChangeRequested mocks base method.
+func (m *MockEventingContract) PublishEmailChangeRequested(ctx context.Context, event *eventing.EmailChangeRequestedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishEmailChangeRequested", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishEmailChangeRequested indicates an expected call of PublishEmailChangeRequested.
+func (mr *MockEventingContractMockRecorder) PublishEmailChangeRequested(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEmailChangeRequested", reflect.TypeOf((*MockEventingContract)(nil).PublishEmailChangeRequested), ctx, event)
+}
+
+// PublishEmailChanged mocks base method.
+func (m *MockEventingContract) PublishEmailChanged(ctx context.Context, event *eventing.EmailChangedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishEmailChanged", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishEmailChanged indicates an expected call of PublishEmailChanged.
+func (mr *MockEventingContractMockRecorder) PublishEmailChanged(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEmailChanged", reflect.TypeOf((*MockEventingContract)(nil).PublishEmailChanged), ctx, event)
+}
+
+// PublishEmailVerificationRequested mocks base method.
+func (m *MockEventingContract) PublishEmailVerificationRequested(ctx context.Context, event *eventing.EmailVerificationRequestedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishEmailVerificationRequested", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishEmailVerificationRequested indicates an expected call of PublishEmailVerificationRequested.
+func (mr *MockEventingContractMockRecorder) PublishEmailVerificationRequested(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEmailVerificationRequested", reflect.TypeOf((*MockEventingContract)(nil).PublishEmailVerificationRequested), ctx, event)
+}
+
+// PublishMagicLinkIssued mocks base method.
+func (m *MockEventingContract) PublishMagicLinkIssued(ctx context.Context, event *eventing.MagicLinkIssuedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishMagicLinkIssued", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishMagicLinkIssued indicates an expected call of PublishMagicLinkIssued.
+func (mr *MockEventingContractMockRecorder) PublishMagicLinkIssued(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishMagicLinkIssued", reflect.TypeOf((*MockEventingContract)(nil).PublishMagicLinkIssued), ctx, event)
+}
+
+// PublishUserCreated mocks base method.
+func (m *MockEventingContract) PublishUserCreated(ctx context.Context, event *eventing.UserCreatedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishUserCreated", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishUserCreated indicates an expected call of PublishUserCreated.
+func (mr *MockEventingContractMockRecorder) PublishUserCreated(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishUserCreated", reflect.TypeOf((*MockEventingContract)(nil).PublishUserCreated), ctx, event)
+}
+
+// PublishUserDeleted mocks base method.
+func (m *MockEventingContract) PublishUserDeleted(ctx context.Context, event *eventing.UserDeletedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishUserDeleted", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishUserDeleted indicates an expected call of PublishUserDeleted.
+func (mr *MockEventingContractMockRecorder) PublishUserDeleted(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishUserDeleted", reflect.TypeOf((*MockEventingContract)(nil).PublishUserDeleted), ctx, event)
+}
+
+// PublishUserInvited mocks base method.
+func (m *MockEventingContract) PublishUserInvited(ctx context.Context, event *eventing.UserInvitedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishUserInvited", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishUserInvited indicates an expected call of PublishUserInvited.
+func (mr *MockEventingContractMockRecorder) PublishUserInvited(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishUserInvited", reflect.TypeOf((*MockEventingContract)(nil).PublishUserInvited), ctx, event)
+}
+
+// PublishUserRestored mocks base method.
+func (m *MockEventingContract) PublishUserRestored(ctx context.Context, event *eventing.UserRestoredEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishUserRestored", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishUserRestored indicates an expected call of PublishUserRestored.
+func (mr *MockEventingContractMockRecorder) PublishUserRestored(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishUserRestored", reflect.TypeOf((*MockEventingContract)(nil).PublishUserRestored), ctx, event)
+}
+
+// PublishUserUpdated mocks base method.
+func (m *MockEventingContract) PublishUserUpdated(ctx context.Context, event *eventing.UserUpdatedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishUserUpdated", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishUserUpdated indicates an expected call of PublishUserUpdated.
+func (mr *MockEventingContractMockRecorder) PublishUserUpdated(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishUserUpdated", reflect.TypeOf((*MockEventingContract)(nil).PublishUserUpdated), ctx, event)
+}
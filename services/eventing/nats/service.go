@@ -0,0 +1,457 @@
+// Package nats implements the eventing service that publishes the user lifecycle events to NATS
+package nats
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/eventing"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// confirmationInterval is how often the broker is asked to confirm receiving the published events
+	confirmationInterval = time.Second
+
+	// flushTimeout bounds the time waited for the broker to confirm receiving the events if the context has no deadline
+	flushTimeout = 5 * time.Second
+)
+
+var pendingEventsGauge = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "user_eventing_pending_events",
+		Help: "The number of the published user events NATS has not confirmed receiving yet",
+	})
+
+var oldestPendingEventAgeGauge = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "user_eventing_oldest_pending_event_age_seconds",
+		Help: "How long ago the oldest user event NATS has not confirmed receiving yet was published, 0 if there is none",
+	})
+
+var confirmationsCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "user_eventing_confirmations_total",
+		Help: "The number of the times NATS was asked to confirm receiving the published user events grouped by the result (success or error)",
+	},
+	[]string{"result"})
+
+type natsEventingService struct {
+	logger        *zap.Logger
+	connection    *natsgo.Conn
+	subjectPrefix string
+	stop          chan struct{}
+	stopped       chan struct{}
+
+	// mutex guards the fields tracking the pending events. The events are tracked once they are handed to the NATS
+	// client and until NATS confirms receiving them. The list is bounded by the NATS client, as publishing fails once
+	// the reconnect buffer is full.
+	mutex                 sync.Mutex
+	pendingEvents         []models.PendingEvent
+	publishedSequence     int64
+	confirmedSequence     int64
+	lastConfirmedAt       *time.Time
+	lastConfirmationError string
+}
+
+// NewNatsEventingService creates new instance of the natsEventingService, setting up all dependencies and returns the instance
+// logger: Mandatory. Reference to the logger service
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewNatsEventingService(
+	logger *zap.Logger,
+	configurationService configuration.ConfigurationContract) (eventing.EventingContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	connectionString, err := configurationService.GetEventingConnectionString()
+	if err != nil {
+		return nil, err
+	}
+
+	subjectPrefix, err := configurationService.GetEventingSubjectPrefix()
+	if err != nil {
+		return nil, err
+	}
+
+	connection, err := natsgo.Connect(
+		connectionString,
+		natsgo.Name("user"),
+		natsgo.MaxReconnects(-1),
+		natsgo.RetryOnFailedConnect(true))
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to connect to NATS", err)
+	}
+
+	service := &natsEventingService{
+		logger:        logger,
+		connection:    connection,
+		subjectPrefix: subjectPrefix,
+		stop:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+		pendingEvents: []models.PendingEvent{},
+	}
+
+	go service.confirmPeriodically()
+
+	return service, nil
+}
+
+// PublishUserCreated publishes the event raised when a new user is created
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *natsEventingService) PublishUserCreated(
+	ctx context.Context,
+	event *eventing.UserCreatedEvent) error {
+	occurredAt := time.Now()
+
+	return service.publish("created", event.Email, occurredAt, &userGRPCContract.UserCreatedEvent{
+		OccurredAt: timestamppb.New(occurredAt),
+		Email:      event.Email,
+		User:       &userGRPCContract.User{UserID: event.User.ID},
+		Cursor:     event.Cursor,
+	})
+}
+
+// PublishUserUpdated publishes the event raised when an existing user is updated
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *natsEventingService) PublishUserUpdated(
+	ctx context.Context,
+	event *eventing.UserUpdatedEvent) error {
+	occurredAt := time.Now()
+
+	return service.publish("updated", event.Email, occurredAt, &userGRPCContract.UserUpdatedEvent{
+		OccurredAt:    timestamppb.New(occurredAt),
+		Email:         event.Email,
+		User:          &userGRPCContract.User{UserID: event.User.ID},
+		Cursor:        event.Cursor,
+		PreviousEmail: event.PreviousEmail,
+	})
+}
+
+// PublishUserDeleted publishes the event raised when an existing user is deleted
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *natsEventingService) PublishUserDeleted(
+	ctx context.Context,
+	event *eventing.UserDeletedEvent) error {
+	occurredAt := time.Now()
+
+	return service.publish("deleted", event.Email, occurredAt, &userGRPCContract.UserDeletedEvent{
+		OccurredAt:  timestamppb.New(occurredAt),
+		Email:       event.Email,
+		SoftDeleted: event.SoftDeleted,
+	})
+}
+
+// PublishUserRestored publishes the event raised when a soft deleted user is restored
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *natsEventingService) PublishUserRestored(
+	ctx context.Context,
+	event *eventing.UserRestoredEvent) error {
+	occurredAt := time.Now()
+
+	return service.publish("restored", event.Email, occurredAt, &userGRPCContract.UserRestoredEvent{
+		OccurredAt: timestamppb.New(occurredAt),
+		Email:      event.Email,
+		User:       &userGRPCContract.User{UserID: event.User.ID},
+		Cursor:     event.Cursor,
+	})
+}
+
+// PublishMagicLinkIssued publishes the event raised when a magic link is issued to a user. The event carries the
+// token the user signs in with, so only the service that sends the emails must be allowed to subscribe to its subject.
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *natsEventingService) PublishMagicLinkIssued(
+	ctx context.Context,
+	event *eventing.MagicLinkIssuedEvent) error {
+	occurredAt := time.Now()
+
+	return service.publish("magic_link_issued", event.Email, occurredAt, &userGRPCContract.MagicLinkIssuedEvent{
+		OccurredAt: timestamppb.New(occurredAt),
+		Email:      event.Email,
+		Token:      event.Token,
+		ExpiresAt:  timestamppb.New(event.ExpiresAt),
+	})
+}
+
+// PublishEmailVerificationRequested publishes the event raised when a verification email is requested. The event
+// carries the token, so only the service that sends the emails must be allowed to subscribe to its subject.
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *natsEventingService) PublishEmailVerificationRequested(
+	ctx context.Context,
+	event *eventing.EmailVerificationRequestedEvent) error {
+	occurredAt := time.Now()
+
+	return service.publish("email_verification_requested", event.Email, occurredAt, &userGRPCContract.EmailVerificationRequestedEvent{
+		OccurredAt: timestamppb.New(occurredAt),
+		Email:      event.Email,
+		Token:      event.Token,
+		ExpiresAt:  timestamppb.New(event.ExpiresAt),
+	})
+}
+
+// PublishUserInvited publishes the event raised when an email address is invited. The event carries the token, so
+// only the service that sends the emails must be allowed to subscribe to its subject.
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *natsEventingService) PublishUserInvited(
+	ctx context.Context,
+	event *eventing.UserInvitedEvent) error {
+	occurredAt := time.Now()
+
+	return service.publish("invited", event.Email, occurredAt, &userGRPCContract.UserInvitedEvent{
+		OccurredAt: timestamppb.New(occurredAt),
+		Email:      event.Email,
+		InvitedBy:  event.InvitedBy,
+		Token:      event.Token,
+		ExpiresAt:  timestamppb.New(event.ExpiresAt),
+	})
+}
+
+// PublishEmailChangeRequested publishes the event raised when a user requests changing the email address. The event
+// carries the token, so only the service that sends the emails must be allowed to subscribe to its subject.
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *natsEventingService) PublishEmailChangeRequested(
+	ctx context.Context,
+	event *eventing.EmailChangeRequestedEvent) error {
+	occurredAt := time.Now()
+
+	return service.publish("email_change_requested", event.Email, occurredAt, &userGRPCContract.EmailChangeRequestedEvent{
+		OccurredAt: timestamppb.New(occurredAt),
+		Email:      event.Email,
+		NewEmail:   event.NewEmail,
+		Token:      event.Token,
+		ExpiresAt:  timestamppb.New(event.ExpiresAt),
+	})
+}
+
+// PublishEmailChanged publishes the event raised when the email address change of a user is confirmed
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *natsEventingService) PublishEmailChanged(
+	ctx context.Context,
+	event *eventing.EmailChangedEvent) error {
+	occurredAt := time.Now()
+
+	return service.publish("email_changed", event.Email, occurredAt, &userGRPCContract.EmailChangedEvent{
+		OccurredAt: timestamppb.New(occurredAt),
+		Email:      event.Email,
+		NewEmail:   event.NewEmail,
+	})
+}
+
+// GetOutboxLag reads how many published events NATS has not confirmed receiving yet and for how long
+// ctx: Mandatory The reference to the context
+// Returns either the publisher lag or error if something goes wrong.
+func (service *natsEventingService) GetOutboxLag(ctx context.Context) (*models.OutboxLag, error) {
+	pendingBytes, err := service.connection.Buffered()
+	if err != nil && err != natsgo.ErrConnectionClosed {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read the buffered user events", err)
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	lag := &models.OutboxLag{
+		Broker:                "nats",
+		Connected:             service.connection.IsConnected(),
+		PendingEvents:         service.publishedSequence - service.confirmedSequence,
+		PendingBytes:          int64(pendingBytes),
+		LastConfirmedAt:       service.lastConfirmedAt,
+		LastConfirmationError: service.lastConfirmationError,
+	}
+
+	if len(service.pendingEvents) > 0 {
+		oldestPendingEventAt := service.pendingEvents[0].OccurredAt
+		lag.OldestPendingEventAt = &oldestPendingEventAt
+	}
+
+	return lag, nil
+}
+
+// ListPendingEvents lists the published events NATS has not confirmed receiving yet, the oldest first
+// ctx: Mandatory The reference to the context
+// limit: Mandatory. The maximum number of the events to list
+// Returns either the pending events or error if something goes wrong.
+func (service *natsEventingService) ListPendingEvents(
+	ctx context.Context,
+	limit int) ([]models.PendingEvent, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	if limit > len(service.pendingEvents) {
+		limit = len(service.pendingEvents)
+	}
+
+	return append([]models.PendingEvent{}, service.pendingEvents[:limit]...), nil
+}
+
+// Flush sends the buffered events and waits for NATS to confirm receiving all the published events
+// ctx: Mandatory The reference to the context
+// Returns either the number of the events confirmed or error if something goes wrong.
+func (service *natsEventingService) Flush(ctx context.Context) (int64, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, flushTimeout)
+
+		defer cancel()
+	}
+
+	// Only the events published before the flush started are confirmed by it
+	service.mutex.Lock()
+	sequence := service.publishedSequence
+	service.mutex.Unlock()
+
+	err := service.connection.FlushWithContext(ctx)
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	defer service.updateMetrics()
+
+	if err != nil {
+		service.lastConfirmationError = err.Error()
+		confirmationsCounter.WithLabelValues("error").Inc()
+
+		return 0, commonErrors.NewUnknownErrorWithError("failed to flush the user events", err)
+	}
+
+	confirmedAt := time.Now()
+	service.lastConfirmedAt = &confirmedAt
+	service.lastConfirmationError = ""
+	confirmationsCounter.WithLabelValues("success").Inc()
+
+	// A concurrent flush may have confirmed the same events already
+	if sequence <= service.confirmedSequence {
+		return 0, nil
+	}
+
+	confirmedCount := sequence - service.confirmedSequence
+	service.confirmedSequence = sequence
+
+	index := 0
+	for index < len(service.pendingEvents) && service.pendingEvents[index].Sequence <= sequence {
+		index++
+	}
+
+	service.pendingEvents = service.pendingEvents[index:]
+
+	return confirmedCount, nil
+}
+
+// Close flushes the pending events and closes the connection to NATS
+// Returns error if something goes wrong.
+func (service *natsEventingService) Close() error {
+	close(service.stop)
+	<-service.stopped
+
+	return service.connection.Drain()
+}
+
+// confirmPeriodically asks NATS to confirm receiving the pending events until the service is closed, so the pending
+// events only pile up while NATS is not reachable
+func (service *natsEventingService) confirmPeriodically() {
+	defer close(service.stopped)
+
+	ticker := time.NewTicker(confirmationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-service.stop:
+			return
+
+		case <-ticker.C:
+			service.mutex.Lock()
+			hasPendingEvents := service.publishedSequence > service.confirmedSequence
+			service.updateMetrics()
+			service.mutex.Unlock()
+
+			if !hasPendingEvents {
+				continue
+			}
+
+			if _, err := service.Flush(context.Background()); err != nil {
+				service.logger.Warn("NATS has not confirmed receiving the user events", zap.Error(err))
+			}
+		}
+	}
+}
+
+// updateMetrics reports the pending events, it must be called while holding the mutex
+func (service *natsEventingService) updateMetrics() {
+	pendingEventsGauge.Set(float64(service.publishedSequence - service.confirmedSequence))
+
+	if len(service.pendingEvents) == 0 {
+		oldestPendingEventAgeGauge.Set(0)
+
+		return
+	}
+
+	oldestPendingEventAgeGauge.Set(time.Since(service.pendingEvents[0].OccurredAt).Seconds())
+}
+
+func (service *natsEventingService) publish(
+	eventName string,
+	email string,
+	occurredAt time.Time,
+	message proto.Message) error {
+	subject := fmt.Sprintf("%s.%s", service.subjectPrefix, eventName)
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		service.logger.Error("failed to marshal user event", zap.String("subject", subject), zap.Error(err))
+
+		return commonErrors.NewUnknownErrorWithError("failed to marshal user event", err)
+	}
+
+	if err = service.connection.Publish(subject, data); err != nil {
+		service.logger.Error("failed to publish user event", zap.String("subject", subject), zap.Error(err))
+
+		return commonErrors.NewUnknownErrorWithError("failed to publish user event", err)
+	}
+
+	// The event is tracked after it is handed to the NATS client, so a flush never confirms an event it did not send
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	service.publishedSequence++
+	service.pendingEvents = append(service.pendingEvents, models.PendingEvent{
+		Sequence:   service.publishedSequence,
+		Subject:    subject,
+		Email:      email,
+		OccurredAt: occurredAt,
+	})
+
+	return nil
+}
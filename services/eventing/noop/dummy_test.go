@@ -0,0 +1 @@
+package noop_test
@@ -0,0 +1,140 @@
+// Package noop implements the eventing service that discards the user lifecycle events
+package noop
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/eventing"
+)
+
+type noopEventingService struct {
+}
+
+// NewNoopEventingService creates new instance of the noopEventingService, setting up all dependencies and returns the instance
+// Returns the new service or error if something goes wrong
+func NewNoopEventingService() (eventing.EventingContract, error) {
+	return &noopEventingService{}, nil
+}
+
+// PublishUserCreated discards the event raised when a new user is created
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *noopEventingService) PublishUserCreated(
+	ctx context.Context,
+	event *eventing.UserCreatedEvent) error {
+	return nil
+}
+
+// PublishUserUpdated discards the event raised when an existing user is updated
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *noopEventingService) PublishUserUpdated(
+	ctx context.Context,
+	event *eventing.UserUpdatedEvent) error {
+	return nil
+}
+
+// PublishUserDeleted discards the event raised when an existing user is deleted
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *noopEventingService) PublishUserDeleted(
+	ctx context.Context,
+	event *eventing.UserDeletedEvent) error {
+	return nil
+}
+
+// PublishUserRestored discards the event raised when a soft deleted user is restored
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *noopEventingService) PublishUserRestored(
+	ctx context.Context,
+	event *eventing.UserRestoredEvent) error {
+	return nil
+}
+
+// PublishMagicLinkIssued discards the event raised when a magic link is issued to a user
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *noopEventingService) PublishMagicLinkIssued(
+	ctx context.Context,
+	event *eventing.MagicLinkIssuedEvent) error {
+	return nil
+}
+
+// PublishEmailVerificationRequested discards the event raised when a verification email is requested
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *noopEventingService) PublishEmailVerificationRequested(
+	ctx context.Context,
+	event *eventing.EmailVerificationRequestedEvent) error {
+	return nil
+}
+
+// PublishUserInvited discards the event raised when an email address is invited
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *noopEventingService) PublishUserInvited(
+	ctx context.Context,
+	event *eventing.UserInvitedEvent) error {
+	return nil
+}
+
+// PublishEmailChangeRequested discards the event raised when a user requests changing the email address
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *noopEventingService) PublishEmailChangeRequested(
+	ctx context.Context,
+	event *eventing.EmailChangeRequestedEvent) error {
+	return nil
+}
+
+// PublishEmailChanged discards the event raised when the email address change of a user is confirmed
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *noopEventingService) PublishEmailChanged(
+	ctx context.Context,
+	event *eventing.EmailChangedEvent) error {
+	return nil
+}
+
+// GetOutboxLag reports no lag as the events are discarded
+// ctx: Mandatory The reference to the context
+// Returns either the publisher lag or error if something goes wrong.
+func (service *noopEventingService) GetOutboxLag(ctx context.Context) (*models.OutboxLag, error) {
+	return &models.OutboxLag{
+		Broker: "none",
+	}, nil
+}
+
+// ListPendingEvents lists no event as the events are discarded
+// ctx: Mandatory The reference to the context
+// limit: Mandatory. The maximum number of the events to list
+// Returns either the pending events or error if something goes wrong.
+func (service *noopEventingService) ListPendingEvents(
+	ctx context.Context,
+	limit int) ([]models.PendingEvent, error) {
+	return []models.PendingEvent{}, nil
+}
+
+// Flush does nothing as the events are discarded
+// ctx: Mandatory The reference to the context
+// Returns either the number of the events confirmed or error if something goes wrong.
+func (service *noopEventingService) Flush(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+// Close does nothing as there is no connection to the message broker
+// Returns error if something goes wrong.
+func (service *noopEventingService) Close() error {
+	return nil
+}
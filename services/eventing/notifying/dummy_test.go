@@ -0,0 +1 @@
+package notifying_test
@@ -0,0 +1,190 @@
+// Package notifying implements the eventing service decorator that notifies the watchers of the replica of the user
+// changes it publishes, for the deployments without a message broker the watchers could receive the changes from
+package notifying
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/eventing"
+	"github.com/decentralized-cloud/user/services/watch"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+type notifyingEventingService struct {
+	eventingService eventing.EventingContract
+	watchService    watch.WatchContract
+	clockService    clock.ClockContract
+}
+
+// NewNotifyingEventingService creates new instance of the notifyingEventingService, setting up all dependencies and returns the instance
+// eventingService: Mandatory. Reference to the eventing service the events are published with
+// watchService: Mandatory. Reference to the service that notifies the watchers of the user changes
+// clockService: Mandatory. Reference to the service that provides the current time
+// Returns the new service or error if something goes wrong
+func NewNotifyingEventingService(
+	eventingService eventing.EventingContract,
+	watchService watch.WatchContract,
+	clockService clock.ClockContract) (eventing.EventingContract, error) {
+	if eventingService == nil {
+		return nil, commonErrors.NewArgumentNilError("eventingService", "eventingService is required")
+	}
+
+	if watchService == nil {
+		return nil, commonErrors.NewArgumentNilError("watchService", "watchService is required")
+	}
+
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	return &notifyingEventingService{
+		eventingService: eventingService,
+		watchService:    watchService,
+		clockService:    clockService,
+	}, nil
+}
+
+// PublishUserCreated publishes the event raised when a new user is created and notifies the watchers
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *notifyingEventingService) PublishUserCreated(
+	ctx context.Context,
+	event *eventing.UserCreatedEvent) error {
+	service.notify(models.UserChangeCreated, event.Email, event.Cursor, false)
+
+	return service.eventingService.PublishUserCreated(ctx, event)
+}
+
+// PublishUserUpdated publishes the event raised when an existing user is updated and notifies the watchers
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *notifyingEventingService) PublishUserUpdated(
+	ctx context.Context,
+	event *eventing.UserUpdatedEvent) error {
+	service.notify(models.UserChangeUpdated, event.Email, event.Cursor, false)
+
+	return service.eventingService.PublishUserUpdated(ctx, event)
+}
+
+// PublishUserDeleted publishes the event raised when an existing user is deleted and notifies the watchers
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *notifyingEventingService) PublishUserDeleted(
+	ctx context.Context,
+	event *eventing.UserDeletedEvent) error {
+	service.notify(models.UserChangeDeleted, event.Email, "", event.SoftDeleted)
+
+	return service.eventingService.PublishUserDeleted(ctx, event)
+}
+
+// PublishUserRestored publishes the event raised when a soft deleted user is restored and notifies the watchers
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *notifyingEventingService) PublishUserRestored(
+	ctx context.Context,
+	event *eventing.UserRestoredEvent) error {
+	service.notify(models.UserChangeRestored, event.Email, event.Cursor, false)
+
+	return service.eventingService.PublishUserRestored(ctx, event)
+}
+
+// PublishMagicLinkIssued publishes the event raised when a magic link is issued to a user, the watchers are not
+// notified as the user does not change
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *notifyingEventingService) PublishMagicLinkIssued(
+	ctx context.Context,
+	event *eventing.MagicLinkIssuedEvent) error {
+	return service.eventingService.PublishMagicLinkIssued(ctx, event)
+}
+
+// PublishEmailVerificationRequested publishes the event raised when a verification email is requested, the watchers
+// are not notified as the user does not change
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *notifyingEventingService) PublishEmailVerificationRequested(
+	ctx context.Context,
+	event *eventing.EmailVerificationRequestedEvent) error {
+	return service.eventingService.PublishEmailVerificationRequested(ctx, event)
+}
+
+// PublishUserInvited publishes the event raised when an email address is invited, the watchers are not notified as
+// no user is created until the invitation is accepted
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *notifyingEventingService) PublishUserInvited(
+	ctx context.Context,
+	event *eventing.UserInvitedEvent) error {
+	return service.eventingService.PublishUserInvited(ctx, event)
+}
+
+// PublishEmailChangeRequested publishes the event raised when a user requests changing the email address, the
+// watchers are not notified as the user does not change until the change is confirmed
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *notifyingEventingService) PublishEmailChangeRequested(
+	ctx context.Context,
+	event *eventing.EmailChangeRequestedEvent) error {
+	return service.eventingService.PublishEmailChangeRequested(ctx, event)
+}
+
+// PublishEmailChanged publishes the event raised when the email address change of a user is confirmed, the watchers
+// are not notified as they are notified of the user updated event published for the change
+// ctx: Mandatory The reference to the context
+// event: Mandatory. The event to publish
+// Returns error if something goes wrong.
+func (service *notifyingEventingService) PublishEmailChanged(
+	ctx context.Context,
+	event *eventing.EmailChangedEvent) error {
+	return service.eventingService.PublishEmailChanged(ctx, event)
+}
+
+// GetOutboxLag reads how many published events the message broker has not confirmed receiving yet and for how long
+// ctx: Mandatory The reference to the context
+// Returns either the publisher lag or error if something goes wrong.
+func (service *notifyingEventingService) GetOutboxLag(ctx context.Context) (*models.OutboxLag, error) {
+	return service.eventingService.GetOutboxLag(ctx)
+}
+
+// ListPendingEvents lists the published events the message broker has not confirmed receiving yet, the oldest first
+// ctx: Mandatory The reference to the context
+// limit: Mandatory. The maximum number of the events to list
+// Returns either the pending events or error if something goes wrong.
+func (service *notifyingEventingService) ListPendingEvents(
+	ctx context.Context,
+	limit int) ([]models.PendingEvent, error) {
+	return service.eventingService.ListPendingEvents(ctx, limit)
+}
+
+// Flush sends the buffered events and waits for the message broker to confirm receiving all the published events
+// ctx: Mandatory The reference to the context
+// Returns either the number of the events confirmed or error if something goes wrong.
+func (service *notifyingEventingService) Flush(ctx context.Context) (int64, error) {
+	return service.eventingService.Flush(ctx)
+}
+
+// Close flushes the pending events and closes the connection to the message broker
+// Returns error if something goes wrong.
+func (service *notifyingEventingService) Close() error {
+	return service.eventingService.Close()
+}
+
+func (service *notifyingEventingService) notify(changeType models.UserChangeType, email string, cursor string, softDeleted bool) {
+	service.watchService.Notify(models.UserChange{
+		Type:        changeType,
+		Email:       email,
+		Cursor:      cursor,
+		OccurredAt:  service.clockService.Now(),
+		SoftDeleted: softDeleted,
+	})
+}
@@ -0,0 +1,187 @@
+// Package group implements the groups of users the admins can search the users by and grant the admin role to
+package group
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// GroupContract declares the service that manages the groups and their members
+type GroupContract interface {
+	// CreateGroup creates a new group with no member
+	// ctx: Mandatory The reference to the context
+	// name: Mandatory. The name of the group, it must not be the name of another group
+	// description: Optional. The description of the group
+	// Returns either the new group or error if something goes wrong. GroupAlreadyExistsError is returned if another
+	// group has the name.
+	CreateGroup(
+		ctx context.Context,
+		name string,
+		description string) (*models.Group, error)
+
+	// ReadGroup reads an existing group
+	// ctx: Mandatory The reference to the context
+	// groupID: Mandatory. The ID of the group
+	// Returns either the group or error if something goes wrong. GroupNotFoundError is returned if there is no group
+	// with the ID.
+	ReadGroup(
+		ctx context.Context,
+		groupID string) (*models.Group, error)
+
+	// UpdateGroup renames and describes an existing group, its members are kept
+	// ctx: Mandatory The reference to the context
+	// groupID: Mandatory. The ID of the group
+	// name: Mandatory. The new name of the group, it must not be the name of another group
+	// description: Optional. The new description of the group
+	// Returns either the updated group or error if something goes wrong. GroupNotFoundError is returned if there is
+	// no group with the ID and GroupAlreadyExistsError if another group has the name.
+	UpdateGroup(
+		ctx context.Context,
+		groupID string,
+		name string,
+		description string) (*models.Group, error)
+
+	// DeleteGroup deletes an existing group, its members are not affected
+	// ctx: Mandatory The reference to the context
+	// groupID: Mandatory. The ID of the group
+	// Returns error if something goes wrong. GroupNotFoundError is returned if there is no group with the ID.
+	DeleteGroup(
+		ctx context.Context,
+		groupID string) error
+
+	// ListGroups lists all the groups ordered by their names
+	// ctx: Mandatory The reference to the context
+	// Returns either the groups or error if something goes wrong.
+	ListGroups(ctx context.Context) ([]models.Group, error)
+
+	// ListUserGroups lists the groups the user is a member of ordered by their names
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns either the groups of the user or error if something goes wrong.
+	ListUserGroups(
+		ctx context.Context,
+		email string) ([]models.Group, error)
+
+	// ListMembers lists the members of any of the groups, each member is listed once
+	// ctx: Mandatory The reference to the context
+	// groupIDs: Mandatory. The IDs of the groups
+	// Returns either the email addresses of the members ordered by the email address or error if something goes
+	// wrong. GroupNotFoundError is returned if there is no group with any of the IDs.
+	ListMembers(
+		ctx context.Context,
+		groupIDs []string) ([]string, error)
+
+	// AddMember adds the user to an existing group, it does nothing if the user is already a member of the group
+	// ctx: Mandatory The reference to the context
+	// groupID: Mandatory. The ID of the group
+	// email: Mandatory. The email address of the user
+	// Returns either the group after the user is added or error if something goes wrong. GroupNotFoundError is
+	// returned if there is no group with the ID.
+	AddMember(
+		ctx context.Context,
+		groupID string,
+		email string) (*models.Group, error)
+
+	// RemoveMember removes the user from an existing group, it does nothing if the user is not a member of the group
+	// ctx: Mandatory The reference to the context
+	// groupID: Mandatory. The ID of the group
+	// email: Mandatory. The email address of the user
+	// Returns either the group after the user is removed or error if something goes wrong. GroupNotFoundError is
+	// returned if there is no group with the ID.
+	RemoveMember(
+		ctx context.Context,
+		groupID string,
+		email string) (*models.Group, error)
+
+	// RemoveUserFromGroups removes the user from all the groups the user is a member of
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns error if something goes wrong.
+	RemoveUserFromGroups(
+		ctx context.Context,
+		email string) error
+
+	// ChangeEmail moves the user to the new email address of the user in all the groups the user is a member of
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The current email address of the user
+	// newEmail: Mandatory. The new email address of the user
+	// Returns error if something goes wrong.
+	ChangeEmail(
+		ctx context.Context,
+		email string,
+		newEmail string) error
+}
+
+// StoreContract declares the service that persists the groups and their members
+type StoreContract interface {
+	// CreateGroup persists the new group
+	// ctx: Mandatory The reference to the context
+	// group: Mandatory. The group to persist
+	// Returns error if something goes wrong. GroupAlreadyExistsError is returned if another group has the name.
+	CreateGroup(
+		ctx context.Context,
+		group *models.Group) error
+
+	// ReadGroup reads the persisted group
+	// ctx: Mandatory The reference to the context
+	// groupID: Mandatory. The ID of the group
+	// Returns either the group or error if something goes wrong. GroupNotFoundError is returned if there is no group
+	// with the ID.
+	ReadGroup(
+		ctx context.Context,
+		groupID string) (*models.Group, error)
+
+	// UpdateGroup replaces the name, the description and the time the persisted group is updated at, its members are
+	// kept
+	// ctx: Mandatory The reference to the context
+	// group: Mandatory. The group to update
+	// Returns error if something goes wrong. GroupNotFoundError is returned if there is no group with the ID and
+	// GroupAlreadyExistsError if another group has the name.
+	UpdateGroup(
+		ctx context.Context,
+		group *models.Group) error
+
+	// DeleteGroup deletes the persisted group
+	// ctx: Mandatory The reference to the context
+	// groupID: Mandatory. The ID of the group
+	// Returns error if something goes wrong. GroupNotFoundError is returned if there is no group with the ID.
+	DeleteGroup(
+		ctx context.Context,
+		groupID string) error
+
+	// ListGroups lists the persisted groups ordered by their names
+	// ctx: Mandatory The reference to the context
+	// member: Optional. The email address of the user the groups are limited to the groups of, all the groups are
+	// listed if empty
+	// Returns either the groups or error if something goes wrong.
+	ListGroups(
+		ctx context.Context,
+		member string) ([]models.Group, error)
+
+	// AddMember adds the member to the persisted group, it does nothing if the user is already a member of the group
+	// ctx: Mandatory The reference to the context
+	// groupID: Mandatory. The ID of the group
+	// email: Mandatory. The email address of the user
+	// updatedAt: Mandatory. The time the group is updated at
+	// Returns error if something goes wrong. GroupNotFoundError is returned if there is no group with the ID.
+	AddMember(
+		ctx context.Context,
+		groupID string,
+		email string,
+		updatedAt time.Time) error
+
+	// RemoveMember removes the member from the persisted group, it does nothing if the user is not a member of the
+	// group
+	// ctx: Mandatory The reference to the context
+	// groupID: Mandatory. The ID of the group
+	// email: Mandatory. The email address of the user
+	// updatedAt: Mandatory. The time the group is updated at
+	// Returns error if something goes wrong. GroupNotFoundError is returned if there is no group with the ID.
+	RemoveMember(
+		ctx context.Context,
+		groupID string,
+		email string,
+		updatedAt time.Time) error
+}
@@ -0,0 +1,65 @@
+// Package group implements the groups of users the admins can search the users by and grant the admin role to
+package group
+
+import (
+	"errors"
+	"fmt"
+)
+
+// GroupNotFoundError indicates there is no group with the ID
+type GroupNotFoundError struct {
+	GroupID string
+}
+
+// Error returns message for the GroupNotFoundError error type
+// Returns the formatted error message
+func (e GroupNotFoundError) Error() string {
+	return fmt.Sprintf("group not found: %s", e.GroupID)
+}
+
+// IsGroupNotFoundError indicates whether the error is of type GroupNotFoundError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type GroupNotFoundError
+func IsGroupNotFoundError(err error) bool {
+	var groupNotFoundError GroupNotFoundError
+
+	return errors.As(err, &groupNotFoundError)
+}
+
+// NewGroupNotFoundError creates a new GroupNotFoundError error
+// groupID: Mandatory. The ID of the group
+// Returns the new error
+func NewGroupNotFoundError(groupID string) error {
+	return GroupNotFoundError{
+		GroupID: groupID,
+	}
+}
+
+// GroupAlreadyExistsError indicates another group already has the name
+type GroupAlreadyExistsError struct {
+	Name string
+}
+
+// Error returns message for the GroupAlreadyExistsError error type
+// Returns the formatted error message
+func (e GroupAlreadyExistsError) Error() string {
+	return fmt.Sprintf("group already exists: %s", e.Name)
+}
+
+// IsGroupAlreadyExistsError indicates whether the error is of type GroupAlreadyExistsError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type GroupAlreadyExistsError
+func IsGroupAlreadyExistsError(err error) bool {
+	var groupAlreadyExistsError GroupAlreadyExistsError
+
+	return errors.As(err, &groupAlreadyExistsError)
+}
+
+// NewGroupAlreadyExistsError creates a new GroupAlreadyExistsError error
+// name: Mandatory. The name of the group
+// Returns the new error
+func NewGroupAlreadyExistsError(name string) error {
+	return GroupAlreadyExistsError{
+		Name: name,
+	}
+}
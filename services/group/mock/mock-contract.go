@@ -0,0 +1,322 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/group/contract.go
+
+// Package mock_group is a generated GoMock package.
+package mock_group
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/decentralized-cloud/user/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGroupContract is a mock of GroupContract interface.
+type MockGroupContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupContractMockRecorder
+}
+
+// MockGroupContractMockRecorder is the mock recorder for MockGroupContract.
+type MockGroupContractMockRecorder struct {
+	mock *MockGroupContract
+}
+
+// NewMockGroupContract creates a new mock instance.
+func NewMockGroupContract(ctrl *gomock.Controller) *MockGroupContract {
+	mock := &MockGroupContract{ctrl: ctrl}
+	mock.recorder = &MockGroupContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupContract) EXPECT() *MockGroupContractMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockGroupContract) AddMember(ctx context.Context, groupID, email string) (*models.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", ctx, groupID, email)
+	ret0, _ := ret[0].(*models.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockGroupContractMockRecorder) AddMember(ctx, groupID, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockGroupContract)(nil).AddMember), ctx, groupID, email)
+}
+
+// ChangeEmail mocks base method.
+func (m *MockGroupContract) ChangeEmail(ctx context.Context, email, newEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmail", ctx, email, newEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeEmail indicates an expected call of ChangeEmail.
+func (mr *MockGroupContractMockRecorder) ChangeEmail(ctx, email, newEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmail", reflect.TypeOf((*MockGroupContract)(nil).ChangeEmail), ctx, email, newEmail)
+}
+
+// CreateGroup mocks base method.
+func (m *MockGroupContract) CreateGroup(ctx context.Context, name, description string) (*models.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", ctx, name, description)
+	ret0, _ := ret[0].(*models.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockGroupContractMockRecorder) CreateGroup(ctx, name, description interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockGroupContract)(nil).CreateGroup), ctx, name, description)
+}
+
+// DeleteGroup mocks base method.
+func (m *MockGroupContract) DeleteGroup(ctx context.Context, groupID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroup", ctx, groupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroup indicates an expected call of DeleteGroup.
+func (mr *MockGroupContractMockRecorder) DeleteGroup(ctx, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockGroupContract)(nil).DeleteGroup), ctx, groupID)
+}
+
+// ListGroups mocks base method.
+func (m *MockGroupContract) ListGroups(ctx context.Context) ([]models.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroups", ctx)
+	ret0, _ := ret[0].([]models.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroups indicates an expected call of ListGroups.
+func (mr *MockGroupContractMockRecorder) ListGroups(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroups", reflect.TypeOf((*MockGroupContract)(nil).ListGroups), ctx)
+}
+
+// ListMembers mocks base method.
+func (m *MockGroupContract) ListMembers(ctx context.Context, groupIDs []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", ctx, groupIDs)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockGroupContractMockRecorder) ListMembers(ctx, groupIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockGroupContract)(nil).ListMembers), ctx, groupIDs)
+}
+
+// ListUserGroups mocks base method.
+func (m *MockGroupContract) ListUserGroups(ctx context.Context, email string) ([]models.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserGroups", ctx, email)
+	ret0, _ := ret[0].([]models.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserGroups indicates an expected call of ListUserGroups.
+func (mr *MockGroupContractMockRecorder) ListUserGroups(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserGroups", reflect.TypeOf((*MockGroupContract)(nil).ListUserGroups), ctx, email)
+}
+
+// ReadGroup mocks base method.
+func (m *MockGroupContract) ReadGroup(ctx context.Context, groupID string) (*models.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadGroup", ctx, groupID)
+	ret0, _ := ret[0].(*models.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadGroup indicates an expected call of ReadGroup.
+func (mr *MockGroupContractMockRecorder) ReadGroup(ctx, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadGroup", reflect.TypeOf((*MockGroupContract)(nil).ReadGroup), ctx, groupID)
+}
+
+// RemoveMember mocks base method.
+func (m *MockGroupContract) RemoveMember(ctx context.Context, groupID, email string) (*models.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, groupID, email)
+	ret0, _ := ret[0].(*models.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockGroupContractMockRecorder) RemoveMember(ctx, groupID, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockGroupContract)(nil).RemoveMember), ctx, groupID, email)
+}
+
+// RemoveUserFromGroups mocks base method.
+func (m *MockGroupContract) RemoveUserFromGroups(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserFromGroups", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveUserFromGroups indicates an expected call of RemoveUserFromGroups.
+func (mr *MockGroupContractMockRecorder) RemoveUserFromGroups(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromGroups", reflect.TypeOf((*MockGroupContract)(nil).RemoveUserFromGroups), ctx, email)
+}
+
+// UpdateGroup mocks base method.
+func (m *MockGroupContract) UpdateGroup(ctx context.Context, groupID, name, description string) (*models.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroup", ctx, groupID, name, description)
+	ret0, _ := ret[0].(*models.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGroup indicates an expected call of UpdateGroup.
+func (mr *MockGroupContractMockRecorder) UpdateGroup(ctx, groupID, name, description interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockGroupContract)(nil).UpdateGroup), ctx, groupID, name, description)
+}
+
+// MockStoreContract is a mock of StoreContract interface.
+type MockStoreContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreContractMockRecorder
+}
+
+// MockStoreContractMockRecorder is the mock recorder for MockStoreContract.
+type MockStoreContractMockRecorder struct {
+	mock *MockStoreContract
+}
+
+// NewMockStoreContract creates a new mock instance.
+func NewMockStoreContract(ctrl *gomock.Controller) *MockStoreContract {
+	mock := &MockStoreContract{ctrl: ctrl}
+	mock.recorder = &MockStoreContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreContract) EXPECT() *MockStoreContractMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockStoreContract) AddMember(ctx context.Context, groupID, email string, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", ctx, groupID, email, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockStoreContractMockRecorder) AddMember(ctx, groupID, email, updatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockStoreContract)(nil).AddMember), ctx, groupID, email, updatedAt)
+}
+
+// CreateGroup mocks base method.
+func (m *MockStoreContract) CreateGroup(ctx context.Context, group *models.Group) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", ctx, group)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockStoreContractMockRecorder) CreateGroup(ctx, group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockStoreContract)(nil).CreateGroup), ctx, group)
+}
+
+// DeleteGroup mocks base method.
+func (m *MockStoreContract) DeleteGroup(ctx context.Context, groupID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroup", ctx, groupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroup indicates an expected call of DeleteGroup.
+func (mr *MockStoreContractMockRecorder) DeleteGroup(ctx, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockStoreContract)(nil).DeleteGroup), ctx, groupID)
+}
+
+// ListGroups mocks base method.
+func (m *MockStoreContract) ListGroups(ctx context.Context, member string) ([]models.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroups", ctx, member)
+	ret0, _ := ret[0].([]models.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroups indicates an expected call of ListGroups.
+func (mr *MockStoreContractMockRecorder) ListGroups(ctx, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroups", reflect.TypeOf((*MockStoreContract)(nil).ListGroups), ctx, member)
+}
+
+// ReadGroup mocks base method.
+func (m *MockStoreContract) ReadGroup(ctx context.Context, groupID string) (*models.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadGroup", ctx, groupID)
+	ret0, _ := ret[0].(*models.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadGroup indicates an expected call of ReadGroup.
+func (mr *MockStoreContractMockRecorder) ReadGroup(ctx, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadGroup", reflect.TypeOf((*MockStoreContract)(nil).ReadGroup), ctx, groupID)
+}
+
+// RemoveMember mocks base method.
+func (m *MockStoreContract) RemoveMember(ctx context.Context, groupID, email string, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, groupID, email, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockStoreContractMockRecorder) RemoveMember(ctx, groupID, email, updatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockStoreContract)(nil).RemoveMember), ctx, groupID, email, updatedAt)
+}
+
+// UpdateGroup mocks base method.
+func (m *MockStoreContract) UpdateGroup(ctx context.Context, group *models.Group) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroup", ctx, group)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGroup indicates an expected call of UpdateGroup.
+func (mr *MockStoreContractMockRecorder) UpdateGroup(ctx, group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockStoreContract)(nil).UpdateGroup), ctx, group)
+}
@@ -0,0 +1,319 @@
+// Package mongodb implements the MongoDB store that persists the groups and their members
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/group"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongodbStoreService struct {
+	getConnectionString func() (string, error)
+	databaseName        string
+	collectionName      string
+}
+
+// NewMongodbStoreService creates new instance of the mongodbStoreService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewMongodbStoreService(
+	configurationService configuration.ConfigurationContract) (group.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if _, err := configurationService.GetDatabaseConnectionString(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	databaseName, err := configurationService.GetDatabaseName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database name", err)
+	}
+
+	collectionName, err := configurationService.GetGroupCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the group collection name", err)
+	}
+
+	return &mongodbStoreService{
+		getConnectionString: configurationService.GetDatabaseConnectionString,
+		databaseName:        databaseName,
+		collectionName:      collectionName,
+	}, nil
+}
+
+// CreateGroup persists the new group
+// ctx: Mandatory The reference to the context
+// newGroup: Mandatory. The group to persist
+// Returns error if something goes wrong. GroupAlreadyExistsError is returned if another group has the name.
+func (service *mongodbStoreService) CreateGroup(
+	ctx context.Context,
+	newGroup *models.Group) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	if err = ensureNameIsNotTaken(ctx, collection, newGroup); err != nil {
+		return err
+	}
+
+	if _, err = collection.InsertOne(ctx, newGroup); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to create group", err)
+	}
+
+	return nil
+}
+
+// ReadGroup reads the persisted group
+// ctx: Mandatory The reference to the context
+// groupID: Mandatory. The ID of the group
+// Returns either the group or error if something goes wrong. GroupNotFoundError is returned if there is no group
+// with the ID.
+func (service *mongodbStoreService) ReadGroup(
+	ctx context.Context,
+	groupID string) (*models.Group, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(ctx, client)
+
+	var readGroup models.Group
+
+	filter := bson.D{{Key: "groupid", Value: groupID}}
+	err = collection.FindOne(ctx, filter).Decode(&readGroup)
+	if err == mongo.ErrNoDocuments {
+		return nil, group.NewGroupNotFoundError(groupID)
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read group", err)
+	}
+
+	return &readGroup, nil
+}
+
+// UpdateGroup replaces the name, the description and the time the persisted group is updated at, its members are
+// kept
+// ctx: Mandatory The reference to the context
+// updatedGroup: Mandatory. The group to update
+// Returns error if something goes wrong. GroupNotFoundError is returned if there is no group with the ID and
+// GroupAlreadyExistsError if another group has the name.
+func (service *mongodbStoreService) UpdateGroup(
+	ctx context.Context,
+	updatedGroup *models.Group) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	if err = ensureNameIsNotTaken(ctx, collection, updatedGroup); err != nil {
+		return err
+	}
+
+	filter := bson.D{{Key: "groupid", Value: updatedGroup.GroupID}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "name", Value: updatedGroup.Name},
+		{Key: "description", Value: updatedGroup.Description},
+		{Key: "updatedat", Value: updatedGroup.UpdatedAt},
+	}}}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to update group", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return group.NewGroupNotFoundError(updatedGroup.GroupID)
+	}
+
+	return nil
+}
+
+// DeleteGroup deletes the persisted group
+// ctx: Mandatory The reference to the context
+// groupID: Mandatory. The ID of the group
+// Returns error if something goes wrong. GroupNotFoundError is returned if there is no group with the ID.
+func (service *mongodbStoreService) DeleteGroup(
+	ctx context.Context,
+	groupID string) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	result, err := collection.DeleteOne(ctx, bson.D{{Key: "groupid", Value: groupID}})
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to delete group", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return group.NewGroupNotFoundError(groupID)
+	}
+
+	return nil
+}
+
+// ListGroups lists the persisted groups ordered by their names
+// ctx: Mandatory The reference to the context
+// member: Optional. The email address of the user the groups are limited to the groups of, all the groups are
+// listed if empty
+// Returns either the groups or error if something goes wrong.
+func (service *mongodbStoreService) ListGroups(
+	ctx context.Context,
+	member string) ([]models.Group, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(ctx, client)
+
+	filter := bson.D{}
+	if member != "" {
+		filter = bson.D{{Key: "members", Value: member}}
+	}
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list groups", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	groups := []models.Group{}
+	if err = cursor.All(ctx, &groups); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to decode groups", err)
+	}
+
+	return groups, nil
+}
+
+// AddMember adds the member to the persisted group, it does nothing if the user is already a member of the group
+// ctx: Mandatory The reference to the context
+// groupID: Mandatory. The ID of the group
+// email: Mandatory. The email address of the user
+// updatedAt: Mandatory. The time the group is updated at
+// Returns error if something goes wrong. GroupNotFoundError is returned if there is no group with the ID.
+func (service *mongodbStoreService) AddMember(
+	ctx context.Context,
+	groupID string,
+	email string,
+	updatedAt time.Time) error {
+	// Only the group the user is not a member of yet is updated, so adding the user again keeps the time it is
+	// updated at
+	filter := bson.D{{Key: "groupid", Value: groupID}, {Key: "members", Value: bson.D{{Key: "$ne", Value: email}}}}
+	update := bson.D{
+		{Key: "$push", Value: bson.D{{Key: "members", Value: email}}},
+		{Key: "$set", Value: bson.D{{Key: "updatedat", Value: updatedAt}}},
+	}
+
+	return service.updateMembers(ctx, groupID, filter, update)
+}
+
+// RemoveMember removes the member from the persisted group, it does nothing if the user is not a member of the
+// group
+// ctx: Mandatory The reference to the context
+// groupID: Mandatory. The ID of the group
+// email: Mandatory. The email address of the user
+// updatedAt: Mandatory. The time the group is updated at
+// Returns error if something goes wrong. GroupNotFoundError is returned if there is no group with the ID.
+func (service *mongodbStoreService) RemoveMember(
+	ctx context.Context,
+	groupID string,
+	email string,
+	updatedAt time.Time) error {
+	filter := bson.D{{Key: "groupid", Value: groupID}, {Key: "members", Value: email}}
+	update := bson.D{
+		{Key: "$pull", Value: bson.D{{Key: "members", Value: email}}},
+		{Key: "$set", Value: bson.D{{Key: "updatedat", Value: updatedAt}}},
+	}
+
+	return service.updateMembers(ctx, groupID, filter, update)
+}
+
+// updateMembers applies the update to the group matching the filter, and fails with GroupNotFoundError if no group
+// matched it as the group does not exist rather than it already has the expected members
+func (service *mongodbStoreService) updateMembers(
+	ctx context.Context,
+	groupID string,
+	filter bson.D,
+	update bson.D) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to update group members", err)
+	}
+
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	count, err := collection.CountDocuments(ctx, bson.D{{Key: "groupid", Value: groupID}})
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to read group", err)
+	}
+
+	if count == 0 {
+		return group.NewGroupNotFoundError(groupID)
+	}
+
+	return nil
+}
+
+func (service *mongodbStoreService) createClientAndCollection(ctx context.Context) (*mongo.Client, *mongo.Collection, error) {
+	connectionString, err := service.getConnectionString()
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	clientOptions := options.Client().ApplyURI(connectionString)
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("could not connect to mongodb database", err)
+	}
+
+	return client, client.Database(service.databaseName).Collection(service.collectionName), nil
+}
+
+// ensureNameIsNotTaken fails with GroupAlreadyExistsError if a group other than the given one has its name
+func ensureNameIsNotTaken(
+	ctx context.Context,
+	collection *mongo.Collection,
+	namedGroup *models.Group) error {
+	filter := bson.D{{Key: "name", Value: namedGroup.Name}, {Key: "groupid", Value: bson.D{{Key: "$ne", Value: namedGroup.GroupID}}}}
+	count, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to read group", err)
+	}
+
+	if count > 0 {
+		return group.NewGroupAlreadyExistsError(namedGroup.Name)
+	}
+
+	return nil
+}
+
+func disconnect(ctx context.Context, client *mongo.Client) {
+	_ = client.Disconnect(ctx)
+}
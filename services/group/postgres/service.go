@@ -0,0 +1,329 @@
+// Package postgres implements the PostgreSQL store that persists the groups and their members
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	configurationPostgres "github.com/decentralized-cloud/user/services/configuration/postgres"
+	"github.com/decentralized-cloud/user/services/group"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+const (
+	groupColumns = "group_id, name, description, members, created_at, updated_at"
+
+	uniqueViolationErrorCode = "23505"
+)
+
+type postgresStoreService struct {
+	pool      *pgxpool.Pool
+	tableName string
+}
+
+// NewPostgresStoreService creates new instance of the postgresStoreService, setting up all dependencies, creating
+// the group table if it does not exist yet and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewPostgresStoreService(
+	configurationService configuration.ConfigurationContract) (group.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	connectionString, err := configurationService.GetDatabaseConnectionString()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to postgres", err)
+	}
+
+	// The group collection name is used as the name of the table the groups are persisted in
+	tableName, err := configurationService.GetGroupCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the group table name", err)
+	}
+
+	ctx := context.Background()
+	pool, err := configurationPostgres.Connect(ctx, connectionString, configurationService.GetDatabaseConnectionString)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("could not connect to postgres database", err)
+	}
+
+	service := &postgresStoreService{
+		pool:      pool,
+		tableName: tableName,
+	}
+
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (
+			group_id TEXT PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE,
+			description TEXT NOT NULL,
+			members TEXT[] NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL)`,
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the group table", err)
+	}
+
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		"CREATE INDEX IF NOT EXISTS %s ON %s USING GIN (members)",
+		pgx.Identifier{tableName + "_members_idx"}.Sanitize(),
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the group members index", err)
+	}
+
+	return service, nil
+}
+
+// CreateGroup persists the new group
+// ctx: Mandatory The reference to the context
+// newGroup: Mandatory. The group to persist
+// Returns error if something goes wrong. GroupAlreadyExistsError is returned if another group has the name.
+func (service *postgresStoreService) CreateGroup(
+	ctx context.Context,
+	newGroup *models.Group) error {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("INSERT INTO %s (%s) VALUES ($1, $2, $3, $4, $5, $6)", service.table(), groupColumns),
+		newGroup.GroupID,
+		newGroup.Name,
+		newGroup.Description,
+		newGroup.Members,
+		newGroup.CreatedAt,
+		newGroup.UpdatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return group.NewGroupAlreadyExistsError(newGroup.Name)
+		}
+
+		return commonErrors.NewUnknownErrorWithError("failed to create group", err)
+	}
+
+	return nil
+}
+
+// ReadGroup reads the persisted group
+// ctx: Mandatory The reference to the context
+// groupID: Mandatory. The ID of the group
+// Returns either the group or error if something goes wrong. GroupNotFoundError is returned if there is no group
+// with the ID.
+func (service *postgresStoreService) ReadGroup(
+	ctx context.Context,
+	groupID string) (*models.Group, error) {
+	readGroup, err := scanGroup(service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE group_id = $1", groupColumns, service.table()),
+		groupID))
+	if err == pgx.ErrNoRows {
+		return nil, group.NewGroupNotFoundError(groupID)
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read group", err)
+	}
+
+	return readGroup, nil
+}
+
+// UpdateGroup replaces the name, the description and the time the persisted group is updated at, its members are
+// kept
+// ctx: Mandatory The reference to the context
+// updatedGroup: Mandatory. The group to update
+// Returns error if something goes wrong. GroupNotFoundError is returned if there is no group with the ID and
+// GroupAlreadyExistsError if another group has the name.
+func (service *postgresStoreService) UpdateGroup(
+	ctx context.Context,
+	updatedGroup *models.Group) error {
+	commandTag, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("UPDATE %s SET name = $2, description = $3, updated_at = $4 WHERE group_id = $1", service.table()),
+		updatedGroup.GroupID,
+		updatedGroup.Name,
+		updatedGroup.Description,
+		updatedGroup.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return group.NewGroupAlreadyExistsError(updatedGroup.Name)
+		}
+
+		return commonErrors.NewUnknownErrorWithError("failed to update group", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return group.NewGroupNotFoundError(updatedGroup.GroupID)
+	}
+
+	return nil
+}
+
+// DeleteGroup deletes the persisted group
+// ctx: Mandatory The reference to the context
+// groupID: Mandatory. The ID of the group
+// Returns error if something goes wrong. GroupNotFoundError is returned if there is no group with the ID.
+func (service *postgresStoreService) DeleteGroup(
+	ctx context.Context,
+	groupID string) error {
+	commandTag, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE group_id = $1", service.table()),
+		groupID)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to delete group", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return group.NewGroupNotFoundError(groupID)
+	}
+
+	return nil
+}
+
+// ListGroups lists the persisted groups ordered by their names
+// ctx: Mandatory The reference to the context
+// member: Optional. The email address of the user the groups are limited to the groups of, all the groups are
+// listed if empty
+// Returns either the groups or error if something goes wrong.
+func (service *postgresStoreService) ListGroups(
+	ctx context.Context,
+	member string) ([]models.Group, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s ORDER BY name", groupColumns, service.table())
+	args := []interface{}{}
+	if member != "" {
+		query = fmt.Sprintf("SELECT %s FROM %s WHERE members @> ARRAY[$1] ORDER BY name", groupColumns, service.table())
+		args = append(args, member)
+	}
+
+	rows, err := service.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list groups", err)
+	}
+
+	defer rows.Close()
+
+	groups := []models.Group{}
+	for rows.Next() {
+		readGroup, err := scanGroup(rows)
+		if err != nil {
+			return nil, commonErrors.NewUnknownErrorWithError("failed to read group", err)
+		}
+
+		groups = append(groups, *readGroup)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list groups", err)
+	}
+
+	return groups, nil
+}
+
+// AddMember adds the member to the persisted group, it does nothing if the user is already a member of the group
+// ctx: Mandatory The reference to the context
+// groupID: Mandatory. The ID of the group
+// email: Mandatory. The email address of the user
+// updatedAt: Mandatory. The time the group is updated at
+// Returns error if something goes wrong. GroupNotFoundError is returned if there is no group with the ID.
+func (service *postgresStoreService) AddMember(
+	ctx context.Context,
+	groupID string,
+	email string,
+	updatedAt time.Time) error {
+	// Only the group the user is not a member of yet is updated, so adding the user again keeps the time it is
+	// updated at
+	return service.updateMembers(
+		ctx,
+		groupID,
+		fmt.Sprintf(
+			"UPDATE %s SET members = array_append(members, $2), updated_at = $3 WHERE group_id = $1 AND NOT members @> ARRAY[$2]",
+			service.table()),
+		email,
+		updatedAt)
+}
+
+// RemoveMember removes the member from the persisted group, it does nothing if the user is not a member of the
+// group
+// ctx: Mandatory The reference to the context
+// groupID: Mandatory. The ID of the group
+// email: Mandatory. The email address of the user
+// updatedAt: Mandatory. The time the group is updated at
+// Returns error if something goes wrong. GroupNotFoundError is returned if there is no group with the ID.
+func (service *postgresStoreService) RemoveMember(
+	ctx context.Context,
+	groupID string,
+	email string,
+	updatedAt time.Time) error {
+	return service.updateMembers(
+		ctx,
+		groupID,
+		fmt.Sprintf(
+			"UPDATE %s SET members = array_remove(members, $2), updated_at = $3 WHERE group_id = $1 AND members @> ARRAY[$2]",
+			service.table()),
+		email,
+		updatedAt)
+}
+
+// updateMembers runs the statement updating the members of the group, and fails with GroupNotFoundError if it
+// updated no group as the group does not exist rather than it already has the expected members
+func (service *postgresStoreService) updateMembers(
+	ctx context.Context,
+	groupID string,
+	statement string,
+	email string,
+	updatedAt time.Time) error {
+	commandTag, err := service.pool.Exec(ctx, statement, groupID, email, updatedAt)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to update group members", err)
+	}
+
+	if commandTag.RowsAffected() > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err = service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE group_id = $1)", service.table()),
+		groupID).Scan(&exists); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to read group", err)
+	}
+
+	if !exists {
+		return group.NewGroupNotFoundError(groupID)
+	}
+
+	return nil
+}
+
+func (service *postgresStoreService) table() string {
+	return pgx.Identifier{service.tableName}.Sanitize()
+}
+
+func scanGroup(row pgx.Row) (*models.Group, error) {
+	var scannedGroup models.Group
+
+	if err := row.Scan(
+		&scannedGroup.GroupID,
+		&scannedGroup.Name,
+		&scannedGroup.Description,
+		&scannedGroup.Members,
+		&scannedGroup.CreatedAt,
+		&scannedGroup.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	return &scannedGroup, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgError *pgconn.PgError
+
+	return errors.As(err, &pgError) && pgError.Code == uniqueViolationErrorCode
+}
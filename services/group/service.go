@@ -0,0 +1,257 @@
+// Package group implements the groups of users the admins can search the users by and grant the admin role to
+package group
+
+import (
+	"context"
+	"sort"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/idgenerator"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+type groupService struct {
+	storeService       StoreContract
+	clockService       clock.ClockContract
+	idGeneratorService idgenerator.IDGeneratorContract
+}
+
+// NewGroupService creates new instance of the groupService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// storeService: Mandatory. Reference to the service that persists the groups
+// clockService: Mandatory. Reference to the service that provides the current time
+// idGeneratorService: Mandatory. Reference to the service that generates the IDs of the groups
+// Returns the new service or error if something goes wrong
+func NewGroupService(
+	configurationService configuration.ConfigurationContract,
+	storeService StoreContract,
+	clockService clock.ClockContract,
+	idGeneratorService idgenerator.IDGeneratorContract) (GroupContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if storeService == nil {
+		return nil, commonErrors.NewArgumentNilError("storeService", "storeService is required")
+	}
+
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	if idGeneratorService == nil {
+		return nil, commonErrors.NewArgumentNilError("idGeneratorService", "idGeneratorService is required")
+	}
+
+	return &groupService{
+		storeService:       storeService,
+		clockService:       clockService,
+		idGeneratorService: idGeneratorService,
+	}, nil
+}
+
+// CreateGroup creates a new group with no member
+// ctx: Mandatory The reference to the context
+// name: Mandatory. The name of the group, it must not be the name of another group
+// description: Optional. The description of the group
+// Returns either the new group or error if something goes wrong. GroupAlreadyExistsError is returned if another
+// group has the name.
+func (service *groupService) CreateGroup(
+	ctx context.Context,
+	name string,
+	description string) (*models.Group, error) {
+	now := service.clockService.Now()
+	group := &models.Group{
+		GroupID:     service.idGeneratorService.NewID(),
+		Name:        name,
+		Description: description,
+		Members:     []string{},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := service.storeService.CreateGroup(ctx, group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// ReadGroup reads an existing group
+// ctx: Mandatory The reference to the context
+// groupID: Mandatory. The ID of the group
+// Returns either the group or error if something goes wrong. GroupNotFoundError is returned if there is no group
+// with the ID.
+func (service *groupService) ReadGroup(
+	ctx context.Context,
+	groupID string) (*models.Group, error) {
+	return service.storeService.ReadGroup(ctx, groupID)
+}
+
+// UpdateGroup renames and describes an existing group, its members are kept
+// ctx: Mandatory The reference to the context
+// groupID: Mandatory. The ID of the group
+// name: Mandatory. The new name of the group, it must not be the name of another group
+// description: Optional. The new description of the group
+// Returns either the updated group or error if something goes wrong. GroupNotFoundError is returned if there is
+// no group with the ID and GroupAlreadyExistsError if another group has the name.
+func (service *groupService) UpdateGroup(
+	ctx context.Context,
+	groupID string,
+	name string,
+	description string) (*models.Group, error) {
+	if err := service.storeService.UpdateGroup(ctx, &models.Group{
+		GroupID:     groupID,
+		Name:        name,
+		Description: description,
+		UpdatedAt:   service.clockService.Now(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return service.storeService.ReadGroup(ctx, groupID)
+}
+
+// DeleteGroup deletes an existing group, its members are not affected
+// ctx: Mandatory The reference to the context
+// groupID: Mandatory. The ID of the group
+// Returns error if something goes wrong. GroupNotFoundError is returned if there is no group with the ID.
+func (service *groupService) DeleteGroup(
+	ctx context.Context,
+	groupID string) error {
+	return service.storeService.DeleteGroup(ctx, groupID)
+}
+
+// ListGroups lists all the groups ordered by their names
+// ctx: Mandatory The reference to the context
+// Returns either the groups or error if something goes wrong.
+func (service *groupService) ListGroups(ctx context.Context) ([]models.Group, error) {
+	return service.storeService.ListGroups(ctx, "")
+}
+
+// ListUserGroups lists the groups the user is a member of ordered by their names
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns either the groups of the user or error if something goes wrong.
+func (service *groupService) ListUserGroups(
+	ctx context.Context,
+	email string) ([]models.Group, error) {
+	return service.storeService.ListGroups(ctx, email)
+}
+
+// ListMembers lists the members of any of the groups, each member is listed once
+// ctx: Mandatory The reference to the context
+// groupIDs: Mandatory. The IDs of the groups
+// Returns either the email addresses of the members ordered by the email address or error if something goes
+// wrong. GroupNotFoundError is returned if there is no group with any of the IDs.
+func (service *groupService) ListMembers(
+	ctx context.Context,
+	groupIDs []string) ([]string, error) {
+	members := map[string]bool{}
+	for _, groupID := range groupIDs {
+		group, err := service.storeService.ReadGroup(ctx, groupID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range group.Members {
+			members[member] = true
+		}
+	}
+
+	emails := make([]string, 0, len(members))
+	for member := range members {
+		emails = append(emails, member)
+	}
+
+	sort.Strings(emails)
+
+	return emails, nil
+}
+
+// AddMember adds the user to an existing group, it does nothing if the user is already a member of the group
+// ctx: Mandatory The reference to the context
+// groupID: Mandatory. The ID of the group
+// email: Mandatory. The email address of the user
+// Returns either the group after the user is added or error if something goes wrong. GroupNotFoundError is
+// returned if there is no group with the ID.
+func (service *groupService) AddMember(
+	ctx context.Context,
+	groupID string,
+	email string) (*models.Group, error) {
+	if err := service.storeService.AddMember(ctx, groupID, email, service.clockService.Now()); err != nil {
+		return nil, err
+	}
+
+	return service.storeService.ReadGroup(ctx, groupID)
+}
+
+// RemoveMember removes the user from an existing group, it does nothing if the user is not a member of the group
+// ctx: Mandatory The reference to the context
+// groupID: Mandatory. The ID of the group
+// email: Mandatory. The email address of the user
+// Returns either the group after the user is removed or error if something goes wrong. GroupNotFoundError is
+// returned if there is no group with the ID.
+func (service *groupService) RemoveMember(
+	ctx context.Context,
+	groupID string,
+	email string) (*models.Group, error) {
+	if err := service.storeService.RemoveMember(ctx, groupID, email, service.clockService.Now()); err != nil {
+		return nil, err
+	}
+
+	return service.storeService.ReadGroup(ctx, groupID)
+}
+
+// RemoveUserFromGroups removes the user from all the groups the user is a member of
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns error if something goes wrong.
+func (service *groupService) RemoveUserFromGroups(
+	ctx context.Context,
+	email string) error {
+	groups, err := service.storeService.ListGroups(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		// A group deleted in the meantime no longer has the user as a member either
+		if err = service.storeService.RemoveMember(ctx, group.GroupID, email, service.clockService.Now()); err != nil && !IsGroupNotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ChangeEmail moves the user to the new email address of the user in all the groups the user is a member of
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The current email address of the user
+// newEmail: Mandatory. The new email address of the user
+// Returns error if something goes wrong.
+func (service *groupService) ChangeEmail(
+	ctx context.Context,
+	email string,
+	newEmail string) error {
+	groups, err := service.storeService.ListGroups(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	// The new email address is added before the current one is removed, so the user never loses a group, e.g. an
+	// admin group, half way through
+	for _, group := range groups {
+		if err = service.storeService.AddMember(ctx, group.GroupID, newEmail, service.clockService.Now()); err != nil && !IsGroupNotFoundError(err) {
+			return err
+		}
+
+		if err = service.storeService.RemoveMember(ctx, group.GroupID, email, service.clockService.Now()); err != nil && !IsGroupNotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
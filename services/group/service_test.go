@@ -0,0 +1,356 @@
+package group_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/group"
+	groupMock "github.com/decentralized-cloud/user/services/group/mock"
+	idgeneratorMock "github.com/decentralized-cloud/user/services/idgenerator/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestGroupService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Group Service Tests")
+}
+
+var _ = Describe("Group Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      group.GroupContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		mockStoreService         *groupMock.MockStoreContract
+		mockClockService         *clockMock.MockClockContract
+		mockIDGeneratorService   *idgeneratorMock.MockIDGeneratorContract
+		groups                   map[string]*models.Group
+		now                      time.Time
+		ctx                      context.Context
+		email                    string
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+
+		// The store keeps the groups in memory, so the changes to the groups can be read back
+		groups = map[string]*models.Group{}
+		mockStoreService = groupMock.NewMockStoreContract(mockCtrl)
+		mockStoreService.
+			EXPECT().
+			CreateGroup(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, newGroup *models.Group) error {
+				for _, existingGroup := range groups {
+					if existingGroup.Name == newGroup.Name {
+						return group.NewGroupAlreadyExistsError(newGroup.Name)
+					}
+				}
+
+				storedGroup := *newGroup
+				groups[newGroup.GroupID] = &storedGroup
+
+				return nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			ReadGroup(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, groupID string) (*models.Group, error) {
+				storedGroup, ok := groups[groupID]
+				if !ok {
+					return nil, group.NewGroupNotFoundError(groupID)
+				}
+
+				readGroup := *storedGroup
+				readGroup.Members = append([]string{}, storedGroup.Members...)
+
+				return &readGroup, nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			UpdateGroup(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, updatedGroup *models.Group) error {
+				storedGroup, ok := groups[updatedGroup.GroupID]
+				if !ok {
+					return group.NewGroupNotFoundError(updatedGroup.GroupID)
+				}
+
+				storedGroup.Name = updatedGroup.Name
+				storedGroup.Description = updatedGroup.Description
+				storedGroup.UpdatedAt = updatedGroup.UpdatedAt
+
+				return nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			ListGroups(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, member string) ([]models.Group, error) {
+				result := []models.Group{}
+				for _, storedGroup := range groups {
+					for _, storedMember := range storedGroup.Members {
+						if storedMember == member {
+							result = append(result, *storedGroup)
+						}
+					}
+				}
+
+				return result, nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			AddMember(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, groupID string, email string, updatedAt time.Time) error {
+				storedGroup, ok := groups[groupID]
+				if !ok {
+					return group.NewGroupNotFoundError(groupID)
+				}
+
+				for _, storedMember := range storedGroup.Members {
+					if storedMember == email {
+						return nil
+					}
+				}
+
+				storedGroup.Members = append(storedGroup.Members, email)
+				storedGroup.UpdatedAt = updatedAt
+
+				return nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			RemoveMember(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, groupID string, email string, updatedAt time.Time) error {
+				storedGroup, ok := groups[groupID]
+				if !ok {
+					return group.NewGroupNotFoundError(groupID)
+				}
+
+				members := []string{}
+				for _, storedMember := range storedGroup.Members {
+					if storedMember != email {
+						members = append(members, storedMember)
+					}
+				}
+
+				storedGroup.Members = members
+				storedGroup.UpdatedAt = updatedAt
+
+				return nil
+			}).
+			AnyTimes()
+
+		now = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			DoAndReturn(func() time.Time { return now }).
+			AnyTimes()
+
+		mockIDGeneratorService = idgeneratorMock.NewMockIDGeneratorContract(mockCtrl)
+		mockIDGeneratorService.
+			EXPECT().
+			NewID().
+			DoAndReturn(cuid.New).
+			AnyTimes()
+
+		ctx = context.Background()
+		email = cuid.New() + "@test.com"
+
+		var err error
+		sut, err = group.NewGroupService(mockConfigurationService, mockStoreService, mockClockService, mockIDGeneratorService)
+		Ω(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate GroupService", func() {
+		When("configuration service is not provided and NewGroupService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := group.NewGroupService(nil, mockStoreService, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("store service is not provided and NewGroupService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := group.NewGroupService(mockConfigurationService, nil, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("clock service is not provided and NewGroupService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := group.NewGroupService(mockConfigurationService, mockStoreService, nil, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("ID generator service is not provided and NewGroupService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := group.NewGroupService(mockConfigurationService, mockStoreService, mockClockService, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("GroupService is instantiated", func() {
+		When("CreateGroup is called", func() {
+			It("should persist the group created now with no member", func() {
+				createdGroup, err := sut.CreateGroup(ctx, "engineering", cuid.New())
+				Ω(err).Should(BeNil())
+				Ω(createdGroup.GroupID).ShouldNot(BeEmpty())
+				Ω(createdGroup.Members).Should(BeEmpty())
+				Ω(createdGroup.CreatedAt).Should(Equal(now))
+				Ω(*groups[createdGroup.GroupID]).Should(Equal(*createdGroup))
+			})
+
+			It("should return GroupAlreadyExistsError if another group has the name", func() {
+				_, err := sut.CreateGroup(ctx, "engineering", "")
+				Ω(err).Should(BeNil())
+
+				_, err = sut.CreateGroup(ctx, "engineering", "")
+				Ω(group.IsGroupAlreadyExistsError(err)).Should(BeTrue())
+			})
+		})
+
+		When("UpdateGroup is called", func() {
+			It("should rename the group and keep its members", func() {
+				createdGroup, err := sut.CreateGroup(ctx, "engineering", "")
+				Ω(err).Should(BeNil())
+				_, err = sut.AddMember(ctx, createdGroup.GroupID, email)
+				Ω(err).Should(BeNil())
+
+				now = now.Add(time.Hour)
+				updatedGroup, err := sut.UpdateGroup(ctx, createdGroup.GroupID, "platform", "The platform team")
+				Ω(err).Should(BeNil())
+				Ω(updatedGroup.Name).Should(Equal("platform"))
+				Ω(updatedGroup.Description).Should(Equal("The platform team"))
+				Ω(updatedGroup.Members).Should(Equal([]string{email}))
+				Ω(updatedGroup.UpdatedAt).Should(Equal(now))
+			})
+
+			It("should return GroupNotFoundError if there is no group with the ID", func() {
+				_, err := sut.UpdateGroup(ctx, cuid.New(), "platform", "")
+				Ω(group.IsGroupNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("AddMember and RemoveMember are called", func() {
+			It("should add the user to the group once and remove the user from it", func() {
+				createdGroup, err := sut.CreateGroup(ctx, "engineering", "")
+				Ω(err).Should(BeNil())
+
+				_, err = sut.AddMember(ctx, createdGroup.GroupID, email)
+				Ω(err).Should(BeNil())
+				readGroup, err := sut.AddMember(ctx, createdGroup.GroupID, email)
+				Ω(err).Should(BeNil())
+				Ω(readGroup.Members).Should(Equal([]string{email}))
+
+				readGroup, err = sut.RemoveMember(ctx, createdGroup.GroupID, email)
+				Ω(err).Should(BeNil())
+				Ω(readGroup.Members).Should(BeEmpty())
+			})
+
+			It("should return GroupNotFoundError if there is no group with the ID", func() {
+				_, err := sut.AddMember(ctx, cuid.New(), email)
+				Ω(group.IsGroupNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("ListMembers is called", func() {
+			It("should return the members of any of the groups once ordered by the email address", func() {
+				firstGroup, err := sut.CreateGroup(ctx, "engineering", "")
+				Ω(err).Should(BeNil())
+				secondGroup, err := sut.CreateGroup(ctx, "platform", "")
+				Ω(err).Should(BeNil())
+
+				_, err = sut.AddMember(ctx, firstGroup.GroupID, "b@test.com")
+				Ω(err).Should(BeNil())
+				_, err = sut.AddMember(ctx, firstGroup.GroupID, "a@test.com")
+				Ω(err).Should(BeNil())
+				_, err = sut.AddMember(ctx, secondGroup.GroupID, "a@test.com")
+				Ω(err).Should(BeNil())
+				_, err = sut.AddMember(ctx, secondGroup.GroupID, "c@test.com")
+				Ω(err).Should(BeNil())
+
+				members, err := sut.ListMembers(ctx, []string{firstGroup.GroupID, secondGroup.GroupID})
+				Ω(err).Should(BeNil())
+				Ω(members).Should(Equal([]string{"a@test.com", "b@test.com", "c@test.com"}))
+			})
+
+			It("should return GroupNotFoundError if there is no group with any of the IDs", func() {
+				_, err := sut.ListMembers(ctx, []string{cuid.New()})
+				Ω(group.IsGroupNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("RemoveUserFromGroups is called", func() {
+			It("should remove the user from every group the user is a member of", func() {
+				firstGroup, err := sut.CreateGroup(ctx, "engineering", "")
+				Ω(err).Should(BeNil())
+				secondGroup, err := sut.CreateGroup(ctx, "platform", "")
+				Ω(err).Should(BeNil())
+
+				_, err = sut.AddMember(ctx, firstGroup.GroupID, email)
+				Ω(err).Should(BeNil())
+				_, err = sut.AddMember(ctx, secondGroup.GroupID, email)
+				Ω(err).Should(BeNil())
+
+				Ω(sut.RemoveUserFromGroups(ctx, email)).Should(BeNil())
+
+				userGroups, err := sut.ListUserGroups(ctx, email)
+				Ω(err).Should(BeNil())
+				Ω(userGroups).Should(BeEmpty())
+			})
+		})
+
+		When("ChangeEmail is called", func() {
+			It("should move the user to the new email address in every group the user is a member of", func() {
+				newEmail := cuid.New() + "@test.com"
+
+				group, err := sut.CreateGroup(ctx, "engineering", "")
+				Ω(err).Should(BeNil())
+
+				_, err = sut.AddMember(ctx, group.GroupID, email)
+				Ω(err).Should(BeNil())
+
+				Ω(sut.ChangeEmail(ctx, email, newEmail)).Should(BeNil())
+
+				userGroups, err := sut.ListUserGroups(ctx, email)
+				Ω(err).Should(BeNil())
+				Ω(userGroups).Should(BeEmpty())
+
+				userGroups, err = sut.ListUserGroups(ctx, newEmail)
+				Ω(err).Should(BeNil())
+				Ω(userGroups).Should(HaveLen(1))
+				Ω(userGroups[0].GroupID).Should(Equal(group.GroupID))
+			})
+		})
+	})
+})
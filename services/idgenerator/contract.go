@@ -0,0 +1,17 @@
+// Package idgenerator implements the source of the identifiers assigned to the persisted objects, so the cursor
+// logic can be tested deterministically
+package idgenerator
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// IDGeneratorContract declares the service that generates the identifiers of the persisted objects
+type IDGeneratorContract interface {
+	// NewID generates a new collision resistant identifier, e.g. for the sagas and the audit records
+	// Returns the new identifier
+	NewID() string
+
+	// NewObjectID generates a new MongoDB ObjectID. The users are paginated in the order of their ObjectID, so the
+	// ObjectIDs generated later must be greater than the ones generated earlier.
+	// Returns the new ObjectID
+	NewObjectID() primitive.ObjectID
+}
@@ -0,0 +1 @@
+package idgenerator_test
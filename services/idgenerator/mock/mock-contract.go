@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/idgenerator/contract.go
+
+// Package mock_idgenerator is a generated GoMock package.
+package mock_idgenerator
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockIDGeneratorContract is a mock of IDGeneratorContract interface.
+type MockIDGeneratorContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDGeneratorContractMockRecorder
+}
+
+// MockIDGeneratorContractMockRecorder is the mock recorder for MockIDGeneratorContract.
+type MockIDGeneratorContractMockRecorder struct {
+	mock *MockIDGeneratorContract
+}
+
+// NewMockIDGeneratorContract creates a new mock instance.
+func NewMockIDGeneratorContract(ctrl *gomock.Controller) *MockIDGeneratorContract {
+	mock := &MockIDGeneratorContract{ctrl: ctrl}
+	mock.recorder = &MockIDGeneratorContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDGeneratorContract) EXPECT() *MockIDGeneratorContractMockRecorder {
+	return m.recorder
+}
+
+// NewID mocks base method.
+func (m *MockIDGeneratorContract) NewID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// NewID indicates an expected call of NewID.
+func (mr *MockIDGeneratorContractMockRecorder) NewID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewID", reflect.TypeOf((*MockIDGeneratorContract)(nil).NewID))
+}
+
+// NewObjectID mocks base method.
+func (m *MockIDGeneratorContract) NewObjectID() primitive.ObjectID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewObjectID")
+	ret0, _ := ret[0].(primitive.ObjectID)
+	return ret0
+}
+
+// NewObjectID indicates an expected call of NewObjectID.
+func (mr *MockIDGeneratorContractMockRecorder) NewObjectID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewObjectID", reflect.TypeOf((*MockIDGeneratorContract)(nil).NewObjectID))
+}
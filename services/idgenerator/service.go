@@ -0,0 +1,30 @@
+// Package idgenerator implements the source of the identifiers assigned to the persisted objects, so the cursor
+// logic can be tested deterministically
+package idgenerator
+
+import (
+	"github.com/lucsky/cuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type idGeneratorService struct {
+}
+
+// NewIDGeneratorService creates new instance of the idGeneratorService, setting up all dependencies and returns the instance
+// Returns the new service or error if something goes wrong
+func NewIDGeneratorService() (IDGeneratorContract, error) {
+	return &idGeneratorService{}, nil
+}
+
+// NewID generates a new collision resistant identifier, e.g. for the sagas and the audit records
+// Returns the new identifier
+func (service *idGeneratorService) NewID() string {
+	return cuid.New()
+}
+
+// NewObjectID generates a new MongoDB ObjectID. The users are paginated in the order of their ObjectID, so the
+// ObjectIDs generated later must be greater than the ones generated earlier.
+// Returns the new ObjectID
+func (service *idGeneratorService) NewObjectID() primitive.ObjectID {
+	return primitive.NewObjectID()
+}
@@ -0,0 +1,94 @@
+// Package instrumentation records the metrics of the operations the user service makes on its dependencies, e.g. the
+// repository, so the failures and the slowness of a dependency can be told apart from the transport issues
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// ResultSuccess is the result of the operations that completed successfully
+	ResultSuccess = "success"
+
+	// ResultError is the result of the operations that failed
+	ResultError = "error"
+)
+
+var operationsCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "user_dependency_operations_total",
+		Help: "The number of the operations made on the dependencies grouped by the dependency, the operation and the result (success or error)",
+	},
+	[]string{"dependency", "operation", "result"})
+
+var operationErrorsCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "user_dependency_operation_errors_total",
+		Help: "The number of the failed operations made on the dependencies grouped by the dependency, the operation and the error type",
+	},
+	[]string{"dependency", "operation", "error_type"})
+
+var operationsDuration = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "user_dependency_operation_duration_seconds",
+		Help:    "The duration of the operations made on the dependencies grouped by the dependency and the operation",
+		Buckets: prometheus.DefBuckets,
+	},
+	[]string{"dependency", "operation"})
+
+// Observe records the result and the duration of an operation made on a dependency. It is meant to be deferred
+// at the start of the operation, e.g. defer instrumentation.Observe("repository", "ReadUser", time.Now(), &err)
+// dependency: Mandatory. The name of the dependency the operation is made on
+// operation: Mandatory. The name of the operation
+// startedAt: Mandatory. The time the operation started at
+// err: Mandatory. Reference to the error the operation returned, read once the operation completed
+func Observe(dependency, operation string, startedAt time.Time, err *error) {
+	operationsDuration.WithLabelValues(dependency, operation).Observe(time.Since(startedAt).Seconds())
+
+	if *err == nil {
+		operationsCounter.WithLabelValues(dependency, operation, ResultSuccess).Inc()
+
+		return
+	}
+
+	operationsCounter.WithLabelValues(dependency, operation, ResultError).Inc()
+	operationErrorsCounter.WithLabelValues(dependency, operation, GetErrorType(*err)).Inc()
+}
+
+// GetErrorType classifies the error an operation failed with
+// err: Mandatory. The error the operation failed with
+// Returns the type of the error
+func GetErrorType(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded"
+
+	case commonErrors.IsArgumentNilError(err), commonErrors.IsArgumentError(err):
+		return "argument"
+
+	case commonErrors.IsNotFoundError(err):
+		return "not_found"
+
+	case commonErrors.IsAlreadyExistsError(err):
+		return "already_exists"
+
+	case repository.IsDataResidencyViolationError(err):
+		return "data_residency_violation"
+
+	case commonErrors.IsUnknownError(err):
+		return "unknown"
+
+	default:
+		return "other"
+	}
+}
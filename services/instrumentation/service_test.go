@@ -0,0 +1,110 @@
+package instrumentation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/instrumentation"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestInstrumentation(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Instrumentation Tests")
+}
+
+var _ = Describe("Instrumentation Tests", func() {
+	var (
+		operation string
+	)
+
+	BeforeEach(func() {
+		// A unique operation name keeps the metrics of the tests apart as they are registered globally
+		operation = cuid.New()
+	})
+
+	getCounterValue := func(name string, labels prometheus.Labels) float64 {
+		families, err := prometheus.DefaultGatherer.Gather()
+		Ω(err).Should(BeNil())
+
+		for _, family := range families {
+			if family.GetName() != name {
+				continue
+			}
+
+			for _, metric := range family.GetMetric() {
+				matched := true
+				for _, label := range metric.GetLabel() {
+					if value, ok := labels[label.GetName()]; ok && value != label.GetValue() {
+						matched = false
+					}
+				}
+
+				if matched {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+
+		return 0
+	}
+
+	When("the operation succeeds", func() {
+		It("should count the operation as success", func() {
+			var err error
+			instrumentation.Observe("repository", operation, time.Now(), &err)
+
+			Ω(getCounterValue("user_dependency_operations_total", prometheus.Labels{"operation": operation, "result": "success"})).Should(Equal(1.0))
+			Ω(getCounterValue("user_dependency_operations_total", prometheus.Labels{"operation": operation, "result": "error"})).Should(Equal(0.0))
+		})
+	})
+
+	When("the operation fails", func() {
+		It("should count the operation as error along with the error type", func() {
+			err := commonErrors.NewNotFoundError()
+			instrumentation.Observe("repository", operation, time.Now(), &err)
+
+			Ω(getCounterValue("user_dependency_operations_total", prometheus.Labels{"operation": operation, "result": "error"})).Should(Equal(1.0))
+			Ω(getCounterValue("user_dependency_operation_errors_total", prometheus.Labels{"operation": operation, "error_type": "not_found"})).Should(Equal(1.0))
+		})
+	})
+
+	When("the error is only known once the deferred call runs", func() {
+		It("should read the error the operation returned", func() {
+			operationFunc := func() (err error) {
+				defer instrumentation.Observe("repository", operation, time.Now(), &err)
+
+				return commonErrors.NewUnknownError(cuid.New())
+			}
+
+			Ω(operationFunc()).ShouldNot(BeNil())
+			Ω(getCounterValue("user_dependency_operation_errors_total", prometheus.Labels{"operation": operation, "error_type": "unknown"})).Should(Equal(1.0))
+		})
+	})
+
+	When("GetErrorType is called", func() {
+		It("should classify the error", func() {
+			errorTypes := map[error]string{
+				context.Canceled:         "canceled",
+				context.DeadlineExceeded: "deadline_exceeded",
+				commonErrors.NewArgumentNilError("email", "email is required"): "argument",
+				commonErrors.NewArgumentError("email", "email is invalid"):     "argument",
+				commonErrors.NewNotFoundError():                                "not_found",
+				commonErrors.NewAlreadyExistsError():                           "already_exists",
+				commonErrors.NewUnknownError(cuid.New()):                       "unknown",
+				errors.New(cuid.New()):                                         "other",
+			}
+
+			for err, expectedErrorType := range errorTypes {
+				Ω(instrumentation.GetErrorType(err)).Should(Equal(expectedErrorType))
+			}
+		})
+	})
+})
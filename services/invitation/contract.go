@@ -0,0 +1,87 @@
+// Package invitation implements the invitations the admins send to the email addresses to join as new users
+package invitation
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// InvitationContract declares the service that issues the invitations and accepts them with the token sent to the
+// invited email address
+type InvitationContract interface {
+	// CreateInvitation issues a new invitation to the email address and persists the hash of its token. The new
+	// invitation replaces the invitation sent to the email address before, so only the latest token can be accepted.
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address to invite
+	// invitedBy: Mandatory. The email address of the admin that sends the invitation
+	// Returns either the persisted invitation and the token itself or error if something goes wrong. The token is
+	// not persisted, so it can not be returned again.
+	CreateInvitation(
+		ctx context.Context,
+		email string,
+		invitedBy string) (*models.Invitation, string, error)
+
+	// AcceptInvitation accepts the invitation sent to the email address with its token. The invitation is only
+	// accepted once, even if it is accepted with the same token concurrently.
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The invited email address
+	// token: Mandatory. The token sent in the invitation
+	// Returns either the accepted invitation or error if something goes wrong. InvitationTokenError is returned if
+	// the token is not the token of the invitation or the invitation has expired and InvitationAlreadyAcceptedError
+	// if the invitation is already accepted.
+	AcceptInvitation(
+		ctx context.Context,
+		email string,
+		token string) (*models.Invitation, error)
+
+	// ReleaseInvitation undoes accepting the invitation, so it can be accepted again, e.g. when the user could not be
+	// created
+	// ctx: Mandatory The reference to the context
+	// invitation: Mandatory. The accepted invitation
+	// Returns error if something goes wrong.
+	ReleaseInvitation(
+		ctx context.Context,
+		invitation *models.Invitation) error
+}
+
+// StoreContract declares the service that persists the invitations
+type StoreContract interface {
+	// SaveInvitation creates the invitation or replaces the invitation persisted for the same email address
+	// ctx: Mandatory The reference to the context
+	// invitation: Mandatory. The invitation to persist
+	// Returns error if something goes wrong.
+	SaveInvitation(
+		ctx context.Context,
+		invitation *models.Invitation) error
+
+	// ReadInvitation reads the invitation persisted for the email address
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The invited email address
+	// Returns either the invitation or error if something goes wrong. NotFoundError is returned if the email address
+	// is not invited.
+	ReadInvitation(
+		ctx context.Context,
+		email string) (*models.Invitation, error)
+
+	// AcceptInvitation records the time the invitation is accepted at if it is not accepted yet
+	// ctx: Mandatory The reference to the context
+	// invitationID: Mandatory. The ID of the invitation
+	// acceptedAt: Mandatory. The time the invitation is accepted at
+	// Returns error if something goes wrong. NotFoundError is returned if there is no invitation with the ID that
+	// is not accepted yet.
+	AcceptInvitation(
+		ctx context.Context,
+		invitationID string,
+		acceptedAt time.Time) error
+
+	// ReleaseInvitation clears the time the invitation is accepted at, it does nothing if there is no invitation
+	// with the ID
+	// ctx: Mandatory The reference to the context
+	// invitationID: Mandatory. The ID of the invitation
+	// Returns error if something goes wrong.
+	ReleaseInvitation(
+		ctx context.Context,
+		invitationID string) error
+}
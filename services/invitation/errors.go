@@ -0,0 +1,84 @@
+// Package invitation implements the invitations the admins send to the email addresses to join as new users
+package invitation
+
+import (
+	"errors"
+	"fmt"
+)
+
+// InvitationTokenError indicates the token the invitation is accepted with is not the token of the invitation sent to
+// the email address, or the invitation has expired
+type InvitationTokenError struct {
+	Email   string
+	Expired bool
+}
+
+// Error returns message for the InvitationTokenError error type
+// Returns the formatted error message
+func (e InvitationTokenError) Error() string {
+	if e.Expired {
+		return fmt.Sprintf("the invitation of %s has expired", e.Email)
+	}
+
+	return fmt.Sprintf("the invitation token of %s is invalid", e.Email)
+}
+
+// IsInvitationTokenError indicates whether the error is of type InvitationTokenError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type InvitationTokenError
+func IsInvitationTokenError(err error) bool {
+	var invitationTokenError InvitationTokenError
+
+	return errors.As(err, &invitationTokenError)
+}
+
+// IsInvitationTokenExpiredError indicates whether the error is of type InvitationTokenError raised because the
+// invitation has expired
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type InvitationTokenError and the invitation has expired
+func IsInvitationTokenExpiredError(err error) bool {
+	var invitationTokenError InvitationTokenError
+
+	return errors.As(err, &invitationTokenError) && invitationTokenError.Expired
+}
+
+// NewInvitationTokenError creates a new InvitationTokenError error
+// email: Mandatory. The invited email address
+// expired: Mandatory. Whether the token is rejected because the invitation has expired
+// Returns the new error
+func NewInvitationTokenError(email string, expired bool) error {
+	return InvitationTokenError{
+		Email:   email,
+		Expired: expired,
+	}
+}
+
+// InvitationAlreadyAcceptedError indicates the invitation sent to the email address is already accepted, so its
+// token can not be used again
+type InvitationAlreadyAcceptedError struct {
+	Email string
+}
+
+// Error returns message for the InvitationAlreadyAcceptedError error type
+// Returns the formatted error message
+func (e InvitationAlreadyAcceptedError) Error() string {
+	return fmt.Sprintf("the invitation of %s is already accepted", e.Email)
+}
+
+// IsInvitationAlreadyAcceptedError indicates whether the error is of type InvitationAlreadyAcceptedError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type InvitationAlreadyAcceptedError
+func IsInvitationAlreadyAcceptedError(err error) bool {
+	var invitationAlreadyAcceptedError InvitationAlreadyAcceptedError
+
+	return errors.As(err, &invitationAlreadyAcceptedError)
+}
+
+// NewInvitationAlreadyAcceptedError creates a new InvitationAlreadyAcceptedError error
+// email: Mandatory. The invited email address
+// Returns the new error
+func NewInvitationAlreadyAcceptedError(email string) error {
+	return InvitationAlreadyAcceptedError{
+		Email: email,
+	}
+}
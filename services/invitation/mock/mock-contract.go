@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/invitation/contract.go
+
+// Package mock_invitation is a generated GoMock package.
+package mock_invitation
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/decentralized-cloud/user/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInvitationContract is a mock of InvitationContract interface.
+type MockInvitationContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvitationContractMockRecorder
+}
+
+// MockInvitationContractMockRecorder is the mock recorder for MockInvitationContract.
+type MockInvitationContractMockRecorder struct {
+	mock *MockInvitationContract
+}
+
+// NewMockInvitationContract creates a new mock instance.
+func NewMockInvitationContract(ctrl *gomock.Controller) *MockInvitationContract {
+	mock := &MockInvitationContract{ctrl: ctrl}
+	mock.recorder = &MockInvitationContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvitationContract) EXPECT() *MockInvitationContractMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockInvitationContract) AcceptInvitation(ctx context.Context, email, token string) (*models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, email, token)
+	ret0, _ := ret[0].(*models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockInvitationContractMockRecorder) AcceptInvitation(ctx, email, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockInvitationContract)(nil).AcceptInvitation), ctx, email, token)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockInvitationContract) CreateInvitation(ctx context.Context, email, invitedBy string) (*models.Invitation, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", ctx, email, invitedBy)
+	ret0, _ := ret[0].(*models.Invitation)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockInvitationContractMockRecorder) CreateInvitation(ctx, email, invitedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockInvitationContract)(nil).CreateInvitation), ctx, email, invitedBy)
+}
+
+// ReleaseInvitation mocks base method.
+func (m *MockInvitationContract) ReleaseInvitation(ctx context.Context, invitation *models.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseInvitation", ctx, invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseInvitation indicates an expected call of ReleaseInvitation.
+func (mr *MockInvitationContractMockRecorder) ReleaseInvitation(ctx, invitation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseInvitation", reflect.TypeOf((*MockInvitationContract)(nil).ReleaseInvitation), ctx, invitation)
+}
+
+// MockStoreContract is a mock of StoreContract interface.
+type MockStoreContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreContractMockRecorder
+}
+
+// MockStoreContractMockRecorder is the mock recorder for MockStoreContract.
+type MockStoreContractMockRecorder struct {
+	mock *MockStoreContract
+}
+
+// NewMockStoreContract creates a new mock instance.
+func NewMockStoreContract(ctrl *gomock.Controller) *MockStoreContract {
+	mock := &MockStoreContract{ctrl: ctrl}
+	mock.recorder = &MockStoreContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreContract) EXPECT() *MockStoreContractMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockStoreContract) AcceptInvitation(ctx context.Context, invitationID string, acceptedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, invitationID, acceptedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockStoreContractMockRecorder) AcceptInvitation(ctx, invitationID, acceptedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockStoreContract)(nil).AcceptInvitation), ctx, invitationID, acceptedAt)
+}
+
+// ReadInvitation mocks base method.
+func (m *MockStoreContract) ReadInvitation(ctx context.Context, email string) (*models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadInvitation", ctx, email)
+	ret0, _ := ret[0].(*models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadInvitation indicates an expected call of ReadInvitation.
+func (mr *MockStoreContractMockRecorder) ReadInvitation(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadInvitation", reflect.TypeOf((*MockStoreContract)(nil).ReadInvitation), ctx, email)
+}
+
+// ReleaseInvitation mocks base method.
+func (m *MockStoreContract) ReleaseInvitation(ctx context.Context, invitationID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseInvitation", ctx, invitationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseInvitation indicates an expected call of ReleaseInvitation.
+func (mr *MockStoreContractMockRecorder) ReleaseInvitation(ctx, invitationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseInvitation", reflect.TypeOf((*MockStoreContract)(nil).ReleaseInvitation), ctx, invitationID)
+}
+
+// SaveInvitation mocks base method.
+func (m *MockStoreContract) SaveInvitation(ctx context.Context, invitation *models.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInvitation", ctx, invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveInvitation indicates an expected call of SaveInvitation.
+func (mr *MockStoreContractMockRecorder) SaveInvitation(ctx, invitation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInvitation", reflect.TypeOf((*MockStoreContract)(nil).SaveInvitation), ctx, invitation)
+}
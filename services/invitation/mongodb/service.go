@@ -0,0 +1,177 @@
+// Package mongodb implements the MongoDB store that persists the invitations
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/invitation"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongodbStoreService struct {
+	getConnectionString func() (string, error)
+	databaseName        string
+	collectionName      string
+}
+
+// NewMongodbStoreService creates new instance of the mongodbStoreService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewMongodbStoreService(
+	configurationService configuration.ConfigurationContract) (invitation.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if _, err := configurationService.GetDatabaseConnectionString(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	databaseName, err := configurationService.GetDatabaseName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database name", err)
+	}
+
+	collectionName, err := configurationService.GetInvitationCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the invitation collection name", err)
+	}
+
+	return &mongodbStoreService{
+		getConnectionString: configurationService.GetDatabaseConnectionString,
+		databaseName:        databaseName,
+		collectionName:      collectionName,
+	}, nil
+}
+
+// SaveInvitation creates the invitation or replaces the invitation persisted for the same email address
+// ctx: Mandatory The reference to the context
+// newInvitation: Mandatory. The invitation to persist
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) SaveInvitation(
+	ctx context.Context,
+	newInvitation *models.Invitation) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	filter := bson.D{{Key: "email", Value: newInvitation.Email}}
+	if _, err = collection.ReplaceOne(ctx, filter, newInvitation, options.Replace().SetUpsert(true)); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to save invitation", err)
+	}
+
+	return nil
+}
+
+// ReadInvitation reads the invitation persisted for the email address
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The invited email address
+// Returns either the invitation or error if something goes wrong. NotFoundError is returned if the email address
+// is not invited.
+func (service *mongodbStoreService) ReadInvitation(
+	ctx context.Context,
+	email string) (*models.Invitation, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(ctx, client)
+
+	var readInvitation models.Invitation
+
+	err = collection.FindOne(ctx, bson.D{{Key: "email", Value: email}}).Decode(&readInvitation)
+	if err == mongo.ErrNoDocuments {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read invitation", err)
+	}
+
+	return &readInvitation, nil
+}
+
+// AcceptInvitation records the time the invitation is accepted at if it is not accepted yet
+// ctx: Mandatory The reference to the context
+// invitationID: Mandatory. The ID of the invitation
+// acceptedAt: Mandatory. The time the invitation is accepted at
+// Returns error if something goes wrong. NotFoundError is returned if there is no invitation with the ID that
+// is not accepted yet.
+func (service *mongodbStoreService) AcceptInvitation(
+	ctx context.Context,
+	invitationID string,
+	acceptedAt time.Time) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	// A null value matches the invitations that are not accepted yet, so the invitation is only accepted once
+	filter := bson.D{{Key: "invitationid", Value: invitationID}, {Key: "acceptedat", Value: nil}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "acceptedat", Value: acceptedAt}}}}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to accept invitation", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return commonErrors.NewNotFoundError()
+	}
+
+	return nil
+}
+
+// ReleaseInvitation clears the time the invitation is accepted at, it does nothing if there is no invitation
+// with the ID
+// ctx: Mandatory The reference to the context
+// invitationID: Mandatory. The ID of the invitation
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) ReleaseInvitation(
+	ctx context.Context,
+	invitationID string) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	filter := bson.D{{Key: "invitationid", Value: invitationID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "acceptedat", Value: nil}}}}
+
+	if _, err = collection.UpdateOne(ctx, filter, update); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to release invitation", err)
+	}
+
+	return nil
+}
+
+func (service *mongodbStoreService) createClientAndCollection(ctx context.Context) (*mongo.Client, *mongo.Collection, error) {
+	connectionString, err := service.getConnectionString()
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	clientOptions := options.Client().ApplyURI(connectionString)
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("could not connect to mongodb database", err)
+	}
+
+	return client, client.Database(service.databaseName).Collection(service.collectionName), nil
+}
+
+func disconnect(ctx context.Context, client *mongo.Client) {
+	_ = client.Disconnect(ctx)
+}
@@ -0,0 +1,185 @@
+// Package postgres implements the PostgreSQL store that persists the invitations
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	configurationPostgres "github.com/decentralized-cloud/user/services/configuration/postgres"
+	"github.com/decentralized-cloud/user/services/invitation"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+const invitationColumns = "invitation_id, email, invited_by, token_hash, created_at, expires_at, accepted_at"
+
+type postgresStoreService struct {
+	pool      *pgxpool.Pool
+	tableName string
+}
+
+// NewPostgresStoreService creates new instance of the postgresStoreService, setting up all dependencies, creating
+// the invitation table if it does not exist yet and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewPostgresStoreService(
+	configurationService configuration.ConfigurationContract) (invitation.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	connectionString, err := configurationService.GetDatabaseConnectionString()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to postgres", err)
+	}
+
+	// The invitation collection name is used as the name of the table the invitations are persisted in
+	tableName, err := configurationService.GetInvitationCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the invitation table name", err)
+	}
+
+	ctx := context.Background()
+	pool, err := configurationPostgres.Connect(ctx, connectionString, configurationService.GetDatabaseConnectionString)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("could not connect to postgres database", err)
+	}
+
+	service := &postgresStoreService{
+		pool:      pool,
+		tableName: tableName,
+	}
+
+	// An email address has a single invitation at a time, so the email address is the primary key
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (
+			invitation_id TEXT NOT NULL UNIQUE,
+			email TEXT PRIMARY KEY,
+			invited_by TEXT NOT NULL,
+			token_hash TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			expires_at TIMESTAMPTZ NOT NULL,
+			accepted_at TIMESTAMPTZ)`,
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the invitation table", err)
+	}
+
+	return service, nil
+}
+
+// SaveInvitation creates the invitation or replaces the invitation persisted for the same email address
+// ctx: Mandatory The reference to the context
+// newInvitation: Mandatory. The invitation to persist
+// Returns error if something goes wrong.
+func (service *postgresStoreService) SaveInvitation(
+	ctx context.Context,
+	newInvitation *models.Invitation) error {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf(
+			`INSERT INTO %s (%s) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (email) DO UPDATE SET
+				invitation_id = EXCLUDED.invitation_id,
+				invited_by = EXCLUDED.invited_by,
+				token_hash = EXCLUDED.token_hash,
+				created_at = EXCLUDED.created_at,
+				expires_at = EXCLUDED.expires_at,
+				accepted_at = EXCLUDED.accepted_at`,
+			service.table(),
+			invitationColumns),
+		newInvitation.InvitationID,
+		newInvitation.Email,
+		newInvitation.InvitedBy,
+		newInvitation.TokenHash,
+		newInvitation.CreatedAt,
+		newInvitation.ExpiresAt,
+		newInvitation.AcceptedAt); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to save invitation", err)
+	}
+
+	return nil
+}
+
+// ReadInvitation reads the invitation persisted for the email address
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The invited email address
+// Returns either the invitation or error if something goes wrong. NotFoundError is returned if the email address
+// is not invited.
+func (service *postgresStoreService) ReadInvitation(
+	ctx context.Context,
+	email string) (*models.Invitation, error) {
+	var readInvitation models.Invitation
+
+	err := service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE email = $1", invitationColumns, service.table()),
+		email).Scan(
+		&readInvitation.InvitationID,
+		&readInvitation.Email,
+		&readInvitation.InvitedBy,
+		&readInvitation.TokenHash,
+		&readInvitation.CreatedAt,
+		&readInvitation.ExpiresAt,
+		&readInvitation.AcceptedAt)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read invitation", err)
+	}
+
+	return &readInvitation, nil
+}
+
+// AcceptInvitation records the time the invitation is accepted at if it is not accepted yet
+// ctx: Mandatory The reference to the context
+// invitationID: Mandatory. The ID of the invitation
+// acceptedAt: Mandatory. The time the invitation is accepted at
+// Returns error if something goes wrong. NotFoundError is returned if there is no invitation with the ID that
+// is not accepted yet.
+func (service *postgresStoreService) AcceptInvitation(
+	ctx context.Context,
+	invitationID string,
+	acceptedAt time.Time) error {
+	commandTag, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("UPDATE %s SET accepted_at = $2 WHERE invitation_id = $1 AND accepted_at IS NULL", service.table()),
+		invitationID,
+		acceptedAt)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to accept invitation", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return commonErrors.NewNotFoundError()
+	}
+
+	return nil
+}
+
+// ReleaseInvitation clears the time the invitation is accepted at, it does nothing if there is no invitation
+// with the ID
+// ctx: Mandatory The reference to the context
+// invitationID: Mandatory. The ID of the invitation
+// Returns error if something goes wrong.
+func (service *postgresStoreService) ReleaseInvitation(
+	ctx context.Context,
+	invitationID string) error {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("UPDATE %s SET accepted_at = NULL WHERE invitation_id = $1", service.table()),
+		invitationID); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to release invitation", err)
+	}
+
+	return nil
+}
+
+func (service *postgresStoreService) table() string {
+	return pgx.Identifier{service.tableName}.Sanitize()
+}
@@ -0,0 +1,168 @@
+// Package invitation implements the invitations the admins send to the email addresses to join as new users
+package invitation
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/idgenerator"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// tokenLength is the number of the random bytes the invitation token is generated from
+const tokenLength = 32
+
+type invitationService struct {
+	storeService       StoreContract
+	clockService       clock.ClockContract
+	idGeneratorService idgenerator.IDGeneratorContract
+	tokenTTL           time.Duration
+}
+
+// NewInvitationService creates new instance of the invitationService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// storeService: Mandatory. Reference to the service that persists the invitations
+// clockService: Mandatory. Reference to the service that provides the current time
+// idGeneratorService: Mandatory. Reference to the service that generates the IDs of the invitations
+// Returns the new service or error if something goes wrong
+func NewInvitationService(
+	configurationService configuration.ConfigurationContract,
+	storeService StoreContract,
+	clockService clock.ClockContract,
+	idGeneratorService idgenerator.IDGeneratorContract) (InvitationContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if storeService == nil {
+		return nil, commonErrors.NewArgumentNilError("storeService", "storeService is required")
+	}
+
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	if idGeneratorService == nil {
+		return nil, commonErrors.NewArgumentNilError("idGeneratorService", "idGeneratorService is required")
+	}
+
+	tokenTTL, err := configurationService.GetInvitationTokenTTL()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the invitation token TTL", err)
+	}
+
+	return &invitationService{
+		storeService:       storeService,
+		clockService:       clockService,
+		idGeneratorService: idGeneratorService,
+		tokenTTL:           tokenTTL,
+	}, nil
+}
+
+// CreateInvitation issues a new invitation to the email address and persists the hash of its token. The new
+// invitation replaces the invitation sent to the email address before, so only the latest token can be accepted.
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address to invite
+// invitedBy: Mandatory. The email address of the admin that sends the invitation
+// Returns either the persisted invitation and the token itself or error if something goes wrong. The token is
+// not persisted, so it can not be returned again.
+func (service *invitationService) CreateInvitation(
+	ctx context.Context,
+	email string,
+	invitedBy string) (*models.Invitation, string, error) {
+	secret := make([]byte, tokenLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", commonErrors.NewUnknownErrorWithError("failed to generate the invitation token", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(secret)
+	now := service.clockService.Now()
+	invitation := &models.Invitation{
+		InvitationID: service.idGeneratorService.NewID(),
+		Email:        email,
+		InvitedBy:    invitedBy,
+		TokenHash:    hashToken(token),
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(service.tokenTTL),
+	}
+
+	if err := service.storeService.SaveInvitation(ctx, invitation); err != nil {
+		return nil, "", err
+	}
+
+	return invitation, token, nil
+}
+
+// AcceptInvitation accepts the invitation sent to the email address with its token. The invitation is only
+// accepted once, even if it is accepted with the same token concurrently.
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The invited email address
+// token: Mandatory. The token sent in the invitation
+// Returns either the accepted invitation or error if something goes wrong. InvitationTokenError is returned if
+// the token is not the token of the invitation or the invitation has expired and InvitationAlreadyAcceptedError
+// if the invitation is already accepted.
+func (service *invitationService) AcceptInvitation(
+	ctx context.Context,
+	email string,
+	token string) (*models.Invitation, error) {
+	invitation, err := service.storeService.ReadInvitation(ctx, email)
+	if err != nil {
+		if commonErrors.IsNotFoundError(err) {
+			return nil, NewInvitationTokenError(email, false)
+		}
+
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashToken(token)), []byte(invitation.TokenHash)) != 1 {
+		return nil, NewInvitationTokenError(email, false)
+	}
+
+	if invitation.AcceptedAt != nil {
+		return nil, NewInvitationAlreadyAcceptedError(email)
+	}
+
+	now := service.clockService.Now()
+	if !now.Before(invitation.ExpiresAt) {
+		return nil, NewInvitationTokenError(email, true)
+	}
+
+	// The store only accepts the invitation if it is not accepted yet, so of the concurrent calls accepting the same
+	// invitation only one succeeds
+	if err = service.storeService.AcceptInvitation(ctx, invitation.InvitationID, now); err != nil {
+		if commonErrors.IsNotFoundError(err) {
+			return nil, NewInvitationAlreadyAcceptedError(email)
+		}
+
+		return nil, err
+	}
+
+	invitation.AcceptedAt = &now
+
+	return invitation, nil
+}
+
+// ReleaseInvitation undoes accepting the invitation, so it can be accepted again, e.g. when the user could not be
+// created
+// ctx: Mandatory The reference to the context
+// invitation: Mandatory. The accepted invitation
+// Returns error if something goes wrong.
+func (service *invitationService) ReleaseInvitation(
+	ctx context.Context,
+	invitation *models.Invitation) error {
+	return service.storeService.ReleaseInvitation(ctx, invitation.InvitationID)
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,289 @@
+package invitation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	idgeneratorMock "github.com/decentralized-cloud/user/services/idgenerator/mock"
+	"github.com/decentralized-cloud/user/services/invitation"
+	invitationMock "github.com/decentralized-cloud/user/services/invitation/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestInvitationService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Invitation Service Tests")
+}
+
+var _ = Describe("Invitation Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      invitation.InvitationContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		mockStoreService         *invitationMock.MockStoreContract
+		mockClockService         *clockMock.MockClockContract
+		mockIDGeneratorService   *idgeneratorMock.MockIDGeneratorContract
+		invitations              map[string]*models.Invitation
+		now                      time.Time
+		ctx                      context.Context
+		email                    string
+		invitedBy                string
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetInvitationTokenTTL().
+			Return(24*time.Hour, nil).
+			AnyTimes()
+
+		// The store keeps the invitations in memory by their email addresses, so the changes to the invitations can
+		// be read back
+		invitations = map[string]*models.Invitation{}
+		mockStoreService = invitationMock.NewMockStoreContract(mockCtrl)
+		mockStoreService.
+			EXPECT().
+			SaveInvitation(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, newInvitation *models.Invitation) error {
+				storedInvitation := *newInvitation
+				invitations[newInvitation.Email] = &storedInvitation
+
+				return nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			ReadInvitation(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, email string) (*models.Invitation, error) {
+				storedInvitation, ok := invitations[email]
+				if !ok {
+					return nil, commonErrors.NewNotFoundError()
+				}
+
+				readInvitation := *storedInvitation
+
+				return &readInvitation, nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			AcceptInvitation(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, invitationID string, acceptedAt time.Time) error {
+				for _, storedInvitation := range invitations {
+					if storedInvitation.InvitationID == invitationID && storedInvitation.AcceptedAt == nil {
+						storedInvitation.AcceptedAt = &acceptedAt
+
+						return nil
+					}
+				}
+
+				return commonErrors.NewNotFoundError()
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			ReleaseInvitation(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, invitationID string) error {
+				for _, storedInvitation := range invitations {
+					if storedInvitation.InvitationID == invitationID {
+						storedInvitation.AcceptedAt = nil
+					}
+				}
+
+				return nil
+			}).
+			AnyTimes()
+
+		now = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			DoAndReturn(func() time.Time { return now }).
+			AnyTimes()
+
+		mockIDGeneratorService = idgeneratorMock.NewMockIDGeneratorContract(mockCtrl)
+		mockIDGeneratorService.
+			EXPECT().
+			NewID().
+			DoAndReturn(cuid.New).
+			AnyTimes()
+
+		ctx = context.Background()
+		email = cuid.New() + "@test.com"
+		invitedBy = cuid.New() + "@test.com"
+
+		var err error
+		sut, err = invitation.NewInvitationService(mockConfigurationService, mockStoreService, mockClockService, mockIDGeneratorService)
+		Ω(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate InvitationService", func() {
+		When("configuration service is not provided and NewInvitationService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := invitation.NewInvitationService(nil, mockStoreService, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("store service is not provided and NewInvitationService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := invitation.NewInvitationService(mockConfigurationService, nil, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("clock service is not provided and NewInvitationService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := invitation.NewInvitationService(mockConfigurationService, mockStoreService, nil, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("ID generator service is not provided and NewInvitationService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := invitation.NewInvitationService(mockConfigurationService, mockStoreService, mockClockService, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("InvitationService is instantiated", func() {
+		When("CreateInvitation is called", func() {
+			It("should persist the hash of the token of the invitation that expires after the TTL", func() {
+				createdInvitation, token, err := sut.CreateInvitation(ctx, email, invitedBy)
+				Ω(err).Should(BeNil())
+				Ω(token).ShouldNot(BeEmpty())
+				Ω(createdInvitation.InvitationID).ShouldNot(BeEmpty())
+				Ω(createdInvitation.InvitedBy).Should(Equal(invitedBy))
+				Ω(createdInvitation.TokenHash).ShouldNot(BeEmpty())
+				Ω(createdInvitation.TokenHash).ShouldNot(ContainSubstring(token))
+				Ω(createdInvitation.CreatedAt).Should(Equal(now))
+				Ω(createdInvitation.ExpiresAt).Should(Equal(now.Add(24 * time.Hour)))
+				Ω(createdInvitation.AcceptedAt).Should(BeNil())
+				Ω(*invitations[email]).Should(Equal(*createdInvitation))
+			})
+
+			It("should replace the invitation sent before", func() {
+				_, previousToken, err := sut.CreateInvitation(ctx, email, invitedBy)
+				Ω(err).Should(BeNil())
+				_, _, err = sut.CreateInvitation(ctx, email, invitedBy)
+				Ω(err).Should(BeNil())
+
+				_, err = sut.AcceptInvitation(ctx, email, previousToken)
+				Ω(invitation.IsInvitationTokenError(err)).Should(BeTrue())
+				Ω(invitation.IsInvitationTokenExpiredError(err)).Should(BeFalse())
+			})
+		})
+
+		When("AcceptInvitation is called", func() {
+			It("should accept the invitation with its token", func() {
+				_, token, err := sut.CreateInvitation(ctx, email, invitedBy)
+				Ω(err).Should(BeNil())
+
+				acceptedInvitation, err := sut.AcceptInvitation(ctx, email, token)
+				Ω(err).Should(BeNil())
+				Ω(*acceptedInvitation.AcceptedAt).Should(Equal(now))
+				Ω(invitations[email].AcceptedAt).ShouldNot(BeNil())
+			})
+
+			It("should return InvitationTokenError if the email address is not invited", func() {
+				_, err := sut.AcceptInvitation(ctx, email, cuid.New())
+				Ω(invitation.IsInvitationTokenError(err)).Should(BeTrue())
+				Ω(invitation.IsInvitationTokenExpiredError(err)).Should(BeFalse())
+			})
+
+			It("should return InvitationTokenError if the token is not the token of the invitation", func() {
+				_, _, err := sut.CreateInvitation(ctx, email, invitedBy)
+				Ω(err).Should(BeNil())
+
+				_, err = sut.AcceptInvitation(ctx, email, cuid.New())
+				Ω(invitation.IsInvitationTokenError(err)).Should(BeTrue())
+				Ω(invitation.IsInvitationTokenExpiredError(err)).Should(BeFalse())
+			})
+
+			It("should return the expired InvitationTokenError if the invitation has expired", func() {
+				_, token, err := sut.CreateInvitation(ctx, email, invitedBy)
+				Ω(err).Should(BeNil())
+
+				now = now.Add(24 * time.Hour)
+				_, err = sut.AcceptInvitation(ctx, email, token)
+				Ω(invitation.IsInvitationTokenExpiredError(err)).Should(BeTrue())
+				Ω(invitations[email].AcceptedAt).Should(BeNil())
+			})
+
+			It("should return InvitationAlreadyAcceptedError if the invitation is already accepted", func() {
+				_, token, err := sut.CreateInvitation(ctx, email, invitedBy)
+				Ω(err).Should(BeNil())
+				_, err = sut.AcceptInvitation(ctx, email, token)
+				Ω(err).Should(BeNil())
+
+				_, err = sut.AcceptInvitation(ctx, email, token)
+				Ω(invitation.IsInvitationAlreadyAcceptedError(err)).Should(BeTrue())
+			})
+
+			It("should return InvitationAlreadyAcceptedError if the invitation is accepted after it is read", func() {
+				_, token, err := sut.CreateInvitation(ctx, email, invitedBy)
+				Ω(err).Should(BeNil())
+
+				// The invitation read by the call is still pending, but another call accepts it before this call does
+				concurrentCtrl := gomock.NewController(GinkgoT())
+				defer concurrentCtrl.Finish()
+
+				pendingInvitation := *invitations[email]
+				concurrentStoreService := invitationMock.NewMockStoreContract(concurrentCtrl)
+				concurrentStoreService.
+					EXPECT().
+					ReadInvitation(gomock.Any(), email).
+					Return(&pendingInvitation, nil)
+				concurrentStoreService.
+					EXPECT().
+					AcceptInvitation(gomock.Any(), pendingInvitation.InvitationID, now).
+					Return(commonErrors.NewNotFoundError())
+
+				service, err := invitation.NewInvitationService(mockConfigurationService, concurrentStoreService, mockClockService, mockIDGeneratorService)
+				Ω(err).Should(BeNil())
+
+				_, err = service.AcceptInvitation(ctx, email, token)
+				Ω(invitation.IsInvitationAlreadyAcceptedError(err)).Should(BeTrue())
+			})
+		})
+
+		When("ReleaseInvitation is called", func() {
+			It("should let the invitation be accepted again", func() {
+				_, token, err := sut.CreateInvitation(ctx, email, invitedBy)
+				Ω(err).Should(BeNil())
+				acceptedInvitation, err := sut.AcceptInvitation(ctx, email, token)
+				Ω(err).Should(BeNil())
+
+				Ω(sut.ReleaseInvitation(ctx, acceptedInvitation)).Should(BeNil())
+
+				_, err = sut.AcceptInvitation(ctx, email, token)
+				Ω(err).Should(BeNil())
+			})
+		})
+	})
+})
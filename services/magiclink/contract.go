@@ -0,0 +1,77 @@
+// Package magiclink implements the single-use links the users sign in with instead of a password
+package magiclink
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// MagicLinkContract declares the service that issues the magic links and redeems them with the token sent to the
+// email address
+type MagicLinkContract interface {
+	// IssueMagicLink issues a new magic link to the email address and persists the hash of its token. The new magic
+	// link replaces the magic link issued to the email address before, so only the latest token can be redeemed. The
+	// magic link is bound to the IP address and the device if the binding to them is enabled.
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address to issue the magic link to
+	// ipAddress: Optional. The IP address the magic link is requested from, mandatory if the IP address binding is enabled
+	// deviceID: Optional. The ID of the device the magic link is requested from, mandatory if the device binding is enabled
+	// Returns either the persisted magic link and the token itself or error if something goes wrong. The token is not
+	// persisted, so it can not be returned again.
+	IssueMagicLink(
+		ctx context.Context,
+		email string,
+		ipAddress string,
+		deviceID string) (*models.MagicLink, string, error)
+
+	// RedeemMagicLink redeems the magic link issued to the email address with its token. The magic link is only
+	// redeemed once, even if it is redeemed with the same token concurrently.
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address the magic link is issued to
+	// token: Mandatory. The token sent in the magic link
+	// ipAddress: Optional. The IP address the magic link is redeemed from
+	// deviceID: Optional. The ID of the device the magic link is redeemed from
+	// Returns either the redeemed magic link or error if something goes wrong. MagicLinkTokenError is returned if the
+	// token is not the token of the magic link, the magic link is redeemed from another IP address or device than
+	// the one it is bound to or the magic link has expired, and MagicLinkAlreadyRedeemedError if the magic link is
+	// already redeemed.
+	RedeemMagicLink(
+		ctx context.Context,
+		email string,
+		token string,
+		ipAddress string,
+		deviceID string) (*models.MagicLink, error)
+}
+
+// StoreContract declares the service that persists the magic links
+type StoreContract interface {
+	// SaveMagicLink creates the magic link or replaces the magic link persisted for the same email address
+	// ctx: Mandatory The reference to the context
+	// magicLink: Mandatory. The magic link to persist
+	// Returns error if something goes wrong.
+	SaveMagicLink(
+		ctx context.Context,
+		magicLink *models.MagicLink) error
+
+	// ReadMagicLink reads the magic link persisted for the email address
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address the magic link is issued to
+	// Returns either the magic link or error if something goes wrong. NotFoundError is returned if no magic link is
+	// issued to the email address.
+	ReadMagicLink(
+		ctx context.Context,
+		email string) (*models.MagicLink, error)
+
+	// RedeemMagicLink records the time the magic link is redeemed at if it is not redeemed yet
+	// ctx: Mandatory The reference to the context
+	// magicLinkID: Mandatory. The ID of the magic link
+	// redeemedAt: Mandatory. The time the magic link is redeemed at
+	// Returns error if something goes wrong. NotFoundError is returned if there is no magic link with the ID that is
+	// not redeemed yet.
+	RedeemMagicLink(
+		ctx context.Context,
+		magicLinkID string,
+		redeemedAt time.Time) error
+}
@@ -0,0 +1,85 @@
+// Package magiclink implements the single-use links the users sign in with instead of a password
+package magiclink
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MagicLinkTokenError indicates the token the magic link is redeemed with is not the token of the magic link issued to
+// the email address, the magic link is redeemed from another IP address or device than the one it is bound to, or the
+// magic link has expired
+type MagicLinkTokenError struct {
+	Email   string
+	Expired bool
+}
+
+// Error returns message for the MagicLinkTokenError error type
+// Returns the formatted error message
+func (e MagicLinkTokenError) Error() string {
+	if e.Expired {
+		return fmt.Sprintf("the magic link of %s has expired", e.Email)
+	}
+
+	return fmt.Sprintf("the magic link token of %s is invalid", e.Email)
+}
+
+// IsMagicLinkTokenError indicates whether the error is of type MagicLinkTokenError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type MagicLinkTokenError
+func IsMagicLinkTokenError(err error) bool {
+	var magicLinkTokenError MagicLinkTokenError
+
+	return errors.As(err, &magicLinkTokenError)
+}
+
+// IsMagicLinkTokenExpiredError indicates whether the error is of type MagicLinkTokenError raised because the magic
+// link has expired
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type MagicLinkTokenError and the magic link has expired
+func IsMagicLinkTokenExpiredError(err error) bool {
+	var magicLinkTokenError MagicLinkTokenError
+
+	return errors.As(err, &magicLinkTokenError) && magicLinkTokenError.Expired
+}
+
+// NewMagicLinkTokenError creates a new MagicLinkTokenError error
+// email: Mandatory. The email address the magic link is issued to
+// expired: Mandatory. Whether the token is rejected because the magic link has expired
+// Returns the new error
+func NewMagicLinkTokenError(email string, expired bool) error {
+	return MagicLinkTokenError{
+		Email:   email,
+		Expired: expired,
+	}
+}
+
+// MagicLinkAlreadyRedeemedError indicates the magic link issued to the email address is already redeemed, so its
+// token can not be used again
+type MagicLinkAlreadyRedeemedError struct {
+	Email string
+}
+
+// Error returns message for the MagicLinkAlreadyRedeemedError error type
+// Returns the formatted error message
+func (e MagicLinkAlreadyRedeemedError) Error() string {
+	return fmt.Sprintf("the magic link of %s is already redeemed", e.Email)
+}
+
+// IsMagicLinkAlreadyRedeemedError indicates whether the error is of type MagicLinkAlreadyRedeemedError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type MagicLinkAlreadyRedeemedError
+func IsMagicLinkAlreadyRedeemedError(err error) bool {
+	var magicLinkAlreadyRedeemedError MagicLinkAlreadyRedeemedError
+
+	return errors.As(err, &magicLinkAlreadyRedeemedError)
+}
+
+// NewMagicLinkAlreadyRedeemedError creates a new MagicLinkAlreadyRedeemedError error
+// email: Mandatory. The email address the magic link is issued to
+// Returns the new error
+func NewMagicLinkAlreadyRedeemedError(email string) error {
+	return MagicLinkAlreadyRedeemedError{
+		Email: email,
+	}
+}
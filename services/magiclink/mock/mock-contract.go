@@ -0,0 +1,134 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/magiclink/contract.go
+
+// Package mock_magiclink is a generated GoMock package.
+package mock_magiclink
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/decentralized-cloud/user/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMagicLinkContract is a mock of MagicLinkContract interface.
+type MockMagicLinkContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockMagicLinkContractMockRecorder
+}
+
+// MockMagicLinkContractMockRecorder is the mock recorder for MockMagicLinkContract.
+type MockMagicLinkContractMockRecorder struct {
+	mock *MockMagicLinkContract
+}
+
+// NewMockMagicLinkContract creates a new mock instance.
+func NewMockMagicLinkContract(ctrl *gomock.Controller) *MockMagicLinkContract {
+	mock := &MockMagicLinkContract{ctrl: ctrl}
+	mock.recorder = &MockMagicLinkContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMagicLinkContract) EXPECT() *MockMagicLinkContractMockRecorder {
+	return m.recorder
+}
+
+// IssueMagicLink mocks base method.
+func (m *MockMagicLinkContract) IssueMagicLink(ctx context.Context, email, ipAddress, deviceID string) (*models.MagicLink, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueMagicLink", ctx, email, ipAddress, deviceID)
+	ret0, _ := ret[0].(*models.MagicLink)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// IssueMagicLink indicates an expected call of IssueMagicLink.
+func (mr *MockMagicLinkContractMockRecorder) IssueMagicLink(ctx, email, ipAddress, deviceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueMagicLink", reflect.TypeOf((*MockMagicLinkContract)(nil).IssueMagicLink), ctx, email, ipAddress, deviceID)
+}
+
+// RedeemMagicLink mocks base method.
+func (m *MockMagicLinkContract) RedeemMagicLink(ctx context.Context, email, token, ipAddress, deviceID string) (*models.MagicLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemMagicLink", ctx, email, token, ipAddress, deviceID)
+	ret0, _ := ret[0].(*models.MagicLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeemMagicLink indicates an expected call of RedeemMagicLink.
+func (mr *MockMagicLinkContractMockRecorder) RedeemMagicLink(ctx, email, token, ipAddress, deviceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemMagicLink", reflect.TypeOf((*MockMagicLinkContract)(nil).RedeemMagicLink), ctx, email, token, ipAddress, deviceID)
+}
+
+// MockStoreContract is a mock of StoreContract interface.
+type MockStoreContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreContractMockRecorder
+}
+
+// MockStoreContractMockRecorder is the mock recorder for MockStoreContract.
+type MockStoreContractMockRecorder struct {
+	mock *MockStoreContract
+}
+
+// NewMockStoreContract creates a new mock instance.
+func NewMockStoreContract(ctrl *gomock.Controller) *MockStoreContract {
+	mock := &MockStoreContract{ctrl: ctrl}
+	mock.recorder = &MockStoreContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreContract) EXPECT() *MockStoreContractMockRecorder {
+	return m.recorder
+}
+
+// ReadMagicLink mocks base method.
+func (m *MockStoreContract) ReadMagicLink(ctx context.Context, email string) (*models.MagicLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadMagicLink", ctx, email)
+	ret0, _ := ret[0].(*models.MagicLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadMagicLink indicates an expected call of ReadMagicLink.
+func (mr *MockStoreContractMockRecorder) ReadMagicLink(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMagicLink", reflect.TypeOf((*MockStoreContract)(nil).ReadMagicLink), ctx, email)
+}
+
+// RedeemMagicLink mocks base method.
+func (m *MockStoreContract) RedeemMagicLink(ctx context.Context, magicLinkID string, redeemedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemMagicLink", ctx, magicLinkID, redeemedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RedeemMagicLink indicates an expected call of RedeemMagicLink.
+func (mr *MockStoreContractMockRecorder) RedeemMagicLink(ctx, magicLinkID, redeemedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemMagicLink", reflect.TypeOf((*MockStoreContract)(nil).RedeemMagicLink), ctx, magicLinkID, redeemedAt)
+}
+
+// SaveMagicLink mocks base method.
+func (m *MockStoreContract) SaveMagicLink(ctx context.Context, magicLink *models.MagicLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMagicLink", ctx, magicLink)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMagicLink indicates an expected call of SaveMagicLink.
+func (mr *MockStoreContractMockRecorder) SaveMagicLink(ctx, magicLink interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMagicLink", reflect.TypeOf((*MockStoreContract)(nil).SaveMagicLink), ctx, magicLink)
+}
@@ -0,0 +1,152 @@
+// Package mongodb implements the MongoDB store that persists the magic links
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/magiclink"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongodbStoreService struct {
+	getConnectionString func() (string, error)
+	databaseName        string
+	collectionName      string
+}
+
+// NewMongodbStoreService creates new instance of the mongodbStoreService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewMongodbStoreService(
+	configurationService configuration.ConfigurationContract) (magiclink.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if _, err := configurationService.GetDatabaseConnectionString(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	databaseName, err := configurationService.GetDatabaseName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database name", err)
+	}
+
+	collectionName, err := configurationService.GetMagicLinkCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the magic link collection name", err)
+	}
+
+	return &mongodbStoreService{
+		getConnectionString: configurationService.GetDatabaseConnectionString,
+		databaseName:        databaseName,
+		collectionName:      collectionName,
+	}, nil
+}
+
+// SaveMagicLink creates the magic link or replaces the magic link persisted for the same email address
+// ctx: Mandatory The reference to the context
+// magicLink: Mandatory. The magic link to persist
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) SaveMagicLink(
+	ctx context.Context,
+	magicLink *models.MagicLink) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	filter := bson.D{{Key: "email", Value: magicLink.Email}}
+	if _, err = collection.ReplaceOne(ctx, filter, magicLink, options.Replace().SetUpsert(true)); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to save magic link", err)
+	}
+
+	return nil
+}
+
+// ReadMagicLink reads the magic link persisted for the email address
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address the magic link is issued to
+// Returns either the magic link or error if something goes wrong. NotFoundError is returned if no magic link is
+// issued to the email address.
+func (service *mongodbStoreService) ReadMagicLink(
+	ctx context.Context,
+	email string) (*models.MagicLink, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(ctx, client)
+
+	var magicLink models.MagicLink
+
+	err = collection.FindOne(ctx, bson.D{{Key: "email", Value: email}}).Decode(&magicLink)
+	if err == mongo.ErrNoDocuments {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read magic link", err)
+	}
+
+	return &magicLink, nil
+}
+
+// RedeemMagicLink records the time the magic link is redeemed at if it is not redeemed yet
+// ctx: Mandatory The reference to the context
+// magicLinkID: Mandatory. The ID of the magic link
+// redeemedAt: Mandatory. The time the magic link is redeemed at
+// Returns error if something goes wrong. NotFoundError is returned if there is no magic link with the ID that is
+// not redeemed yet.
+func (service *mongodbStoreService) RedeemMagicLink(
+	ctx context.Context,
+	magicLinkID string,
+	redeemedAt time.Time) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	// A null value matches the magic links that are not redeemed yet, so the magic link is only redeemed once
+	filter := bson.D{{Key: "magiclinkid", Value: magicLinkID}, {Key: "redeemedat", Value: nil}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "redeemedat", Value: redeemedAt}}}}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to redeem magic link", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return commonErrors.NewNotFoundError()
+	}
+
+	return nil
+}
+
+func (service *mongodbStoreService) createClientAndCollection(ctx context.Context) (*mongo.Client, *mongo.Collection, error) {
+	connectionString, err := service.getConnectionString()
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	clientOptions := options.Client().ApplyURI(connectionString)
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("could not connect to mongodb database", err)
+	}
+
+	return client, client.Database(service.databaseName).Collection(service.collectionName), nil
+}
+
+func disconnect(ctx context.Context, client *mongo.Client) {
+	_ = client.Disconnect(ctx)
+}
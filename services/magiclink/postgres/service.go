@@ -0,0 +1,171 @@
+// Package postgres implements the PostgreSQL store that persists the magic links
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	configurationPostgres "github.com/decentralized-cloud/user/services/configuration/postgres"
+	"github.com/decentralized-cloud/user/services/magiclink"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+const magicLinkColumns = "magic_link_id, email, token_hash, ip_address_hash, device_id_hash, created_at, expires_at, redeemed_at"
+
+type postgresStoreService struct {
+	pool      *pgxpool.Pool
+	tableName string
+}
+
+// NewPostgresStoreService creates new instance of the postgresStoreService, setting up all dependencies, creating
+// the magic link table if it does not exist yet and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewPostgresStoreService(
+	configurationService configuration.ConfigurationContract) (magiclink.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	connectionString, err := configurationService.GetDatabaseConnectionString()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to postgres", err)
+	}
+
+	// The magic link collection name is used as the name of the table the magic links are persisted in
+	tableName, err := configurationService.GetMagicLinkCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the magic link table name", err)
+	}
+
+	ctx := context.Background()
+	pool, err := configurationPostgres.Connect(ctx, connectionString, configurationService.GetDatabaseConnectionString)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("could not connect to postgres database", err)
+	}
+
+	service := &postgresStoreService{
+		pool:      pool,
+		tableName: tableName,
+	}
+
+	// An email address has a single magic link at a time, so the email address is the primary key
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (
+			magic_link_id TEXT NOT NULL UNIQUE,
+			email TEXT PRIMARY KEY,
+			token_hash TEXT NOT NULL,
+			ip_address_hash TEXT NOT NULL,
+			device_id_hash TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			expires_at TIMESTAMPTZ NOT NULL,
+			redeemed_at TIMESTAMPTZ)`,
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the magic link table", err)
+	}
+
+	return service, nil
+}
+
+// SaveMagicLink creates the magic link or replaces the magic link persisted for the same email address
+// ctx: Mandatory The reference to the context
+// magicLink: Mandatory. The magic link to persist
+// Returns error if something goes wrong.
+func (service *postgresStoreService) SaveMagicLink(
+	ctx context.Context,
+	magicLink *models.MagicLink) error {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf(
+			`INSERT INTO %s (%s) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (email) DO UPDATE SET
+				magic_link_id = EXCLUDED.magic_link_id,
+				token_hash = EXCLUDED.token_hash,
+				ip_address_hash = EXCLUDED.ip_address_hash,
+				device_id_hash = EXCLUDED.device_id_hash,
+				created_at = EXCLUDED.created_at,
+				expires_at = EXCLUDED.expires_at,
+				redeemed_at = EXCLUDED.redeemed_at`,
+			service.table(),
+			magicLinkColumns),
+		magicLink.MagicLinkID,
+		magicLink.Email,
+		magicLink.TokenHash,
+		magicLink.IPAddressHash,
+		magicLink.DeviceIDHash,
+		magicLink.CreatedAt,
+		magicLink.ExpiresAt,
+		magicLink.RedeemedAt); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to save magic link", err)
+	}
+
+	return nil
+}
+
+// ReadMagicLink reads the magic link persisted for the email address
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address the magic link is issued to
+// Returns either the magic link or error if something goes wrong. NotFoundError is returned if no magic link is
+// issued to the email address.
+func (service *postgresStoreService) ReadMagicLink(
+	ctx context.Context,
+	email string) (*models.MagicLink, error) {
+	var magicLink models.MagicLink
+
+	err := service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE email = $1", magicLinkColumns, service.table()),
+		email).Scan(
+		&magicLink.MagicLinkID,
+		&magicLink.Email,
+		&magicLink.TokenHash,
+		&magicLink.IPAddressHash,
+		&magicLink.DeviceIDHash,
+		&magicLink.CreatedAt,
+		&magicLink.ExpiresAt,
+		&magicLink.RedeemedAt)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read magic link", err)
+	}
+
+	return &magicLink, nil
+}
+
+// RedeemMagicLink records the time the magic link is redeemed at if it is not redeemed yet
+// ctx: Mandatory The reference to the context
+// magicLinkID: Mandatory. The ID of the magic link
+// redeemedAt: Mandatory. The time the magic link is redeemed at
+// Returns error if something goes wrong. NotFoundError is returned if there is no magic link with the ID that is
+// not redeemed yet.
+func (service *postgresStoreService) RedeemMagicLink(
+	ctx context.Context,
+	magicLinkID string,
+	redeemedAt time.Time) error {
+	commandTag, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("UPDATE %s SET redeemed_at = $2 WHERE magic_link_id = $1 AND redeemed_at IS NULL", service.table()),
+		magicLinkID,
+		redeemedAt)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to redeem magic link", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return commonErrors.NewNotFoundError()
+	}
+
+	return nil
+}
+
+func (service *postgresStoreService) table() string {
+	return pgx.Identifier{service.tableName}.Sanitize()
+}
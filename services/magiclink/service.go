@@ -0,0 +1,210 @@
+// Package magiclink implements the single-use links the users sign in with instead of a password
+package magiclink
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/idgenerator"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// tokenLength is the number of the random bytes the magic link token is generated from
+const tokenLength = 32
+
+type magicLinkService struct {
+	storeService            StoreContract
+	clockService            clock.ClockContract
+	idGeneratorService      idgenerator.IDGeneratorContract
+	tokenTTL                time.Duration
+	ipAddressBindingEnabled bool
+	deviceBindingEnabled    bool
+}
+
+// NewMagicLinkService creates new instance of the magicLinkService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// storeService: Mandatory. Reference to the service that persists the magic links
+// clockService: Mandatory. Reference to the service that provides the current time
+// idGeneratorService: Mandatory. Reference to the service that generates the IDs of the magic links
+// Returns the new service or error if something goes wrong
+func NewMagicLinkService(
+	configurationService configuration.ConfigurationContract,
+	storeService StoreContract,
+	clockService clock.ClockContract,
+	idGeneratorService idgenerator.IDGeneratorContract) (MagicLinkContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if storeService == nil {
+		return nil, commonErrors.NewArgumentNilError("storeService", "storeService is required")
+	}
+
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	if idGeneratorService == nil {
+		return nil, commonErrors.NewArgumentNilError("idGeneratorService", "idGeneratorService is required")
+	}
+
+	tokenTTL, err := configurationService.GetMagicLinkTokenTTL()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the magic link token TTL", err)
+	}
+
+	ipAddressBindingEnabled, err := configurationService.GetMagicLinkIPAddressBindingEnabled()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get whether the magic links are bound to the IP address", err)
+	}
+
+	deviceBindingEnabled, err := configurationService.GetMagicLinkDeviceBindingEnabled()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get whether the magic links are bound to the device", err)
+	}
+
+	return &magicLinkService{
+		storeService:            storeService,
+		clockService:            clockService,
+		idGeneratorService:      idGeneratorService,
+		tokenTTL:                tokenTTL,
+		ipAddressBindingEnabled: ipAddressBindingEnabled,
+		deviceBindingEnabled:    deviceBindingEnabled,
+	}, nil
+}
+
+// IssueMagicLink issues a new magic link to the email address and persists the hash of its token. The new magic
+// link replaces the magic link issued to the email address before, so only the latest token can be redeemed. The
+// magic link is bound to the IP address and the device if the binding to them is enabled.
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address to issue the magic link to
+// ipAddress: Optional. The IP address the magic link is requested from, mandatory if the IP address binding is enabled
+// deviceID: Optional. The ID of the device the magic link is requested from, mandatory if the device binding is enabled
+// Returns either the persisted magic link and the token itself or error if something goes wrong. The token is not
+// persisted, so it can not be returned again.
+func (service *magicLinkService) IssueMagicLink(
+	ctx context.Context,
+	email string,
+	ipAddress string,
+	deviceID string) (*models.MagicLink, string, error) {
+	if service.ipAddressBindingEnabled && ipAddress == "" {
+		return nil, "", commonErrors.NewArgumentError("ipAddress", "ipAddress is required as the magic links are bound to the IP address")
+	}
+
+	if service.deviceBindingEnabled && deviceID == "" {
+		return nil, "", commonErrors.NewArgumentError("deviceID", "deviceID is required as the magic links are bound to the device")
+	}
+
+	secret := make([]byte, tokenLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", commonErrors.NewUnknownErrorWithError("failed to generate the magic link token", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(secret)
+	now := service.clockService.Now()
+	magicLink := &models.MagicLink{
+		MagicLinkID: service.idGeneratorService.NewID(),
+		Email:       email,
+		TokenHash:   hash(token),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(service.tokenTTL),
+	}
+
+	// Only the hashes are persisted, so the IP addresses and the devices of the users are not kept in plain text
+	if service.ipAddressBindingEnabled {
+		magicLink.IPAddressHash = hash(ipAddress)
+	}
+
+	if service.deviceBindingEnabled {
+		magicLink.DeviceIDHash = hash(deviceID)
+	}
+
+	if err := service.storeService.SaveMagicLink(ctx, magicLink); err != nil {
+		return nil, "", err
+	}
+
+	return magicLink, token, nil
+}
+
+// RedeemMagicLink redeems the magic link issued to the email address with its token. The magic link is only
+// redeemed once, even if it is redeemed with the same token concurrently.
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address the magic link is issued to
+// token: Mandatory. The token sent in the magic link
+// ipAddress: Optional. The IP address the magic link is redeemed from
+// deviceID: Optional. The ID of the device the magic link is redeemed from
+// Returns either the redeemed magic link or error if something goes wrong. MagicLinkTokenError is returned if the
+// token is not the token of the magic link, the magic link is redeemed from another IP address or device than
+// the one it is bound to or the magic link has expired, and MagicLinkAlreadyRedeemedError if the magic link is
+// already redeemed.
+func (service *magicLinkService) RedeemMagicLink(
+	ctx context.Context,
+	email string,
+	token string,
+	ipAddress string,
+	deviceID string) (*models.MagicLink, error) {
+	magicLink, err := service.storeService.ReadMagicLink(ctx, email)
+	if err != nil {
+		if commonErrors.IsNotFoundError(err) {
+			return nil, NewMagicLinkTokenError(email, false)
+		}
+
+		return nil, err
+	}
+
+	if !matchesHash(token, magicLink.TokenHash) {
+		return nil, NewMagicLinkTokenError(email, false)
+	}
+
+	if magicLink.RedeemedAt != nil {
+		return nil, NewMagicLinkAlreadyRedeemedError(email)
+	}
+
+	now := service.clockService.Now()
+	if !now.Before(magicLink.ExpiresAt) {
+		return nil, NewMagicLinkTokenError(email, true)
+	}
+
+	// The binding is checked against what the magic link is bound to when it is issued, so changing the configuration
+	// does not affect the magic links issued before. A mismatch does not redeem the magic link, so a link leaked to
+	// another device can not be used to stop the user from signing in with it.
+	if magicLink.IPAddressHash != "" && !matchesHash(ipAddress, magicLink.IPAddressHash) {
+		return nil, NewMagicLinkTokenError(email, false)
+	}
+
+	if magicLink.DeviceIDHash != "" && !matchesHash(deviceID, magicLink.DeviceIDHash) {
+		return nil, NewMagicLinkTokenError(email, false)
+	}
+
+	// The store only redeems the magic link if it is not redeemed yet, so of the concurrent calls redeeming the same
+	// magic link only one succeeds
+	if err = service.storeService.RedeemMagicLink(ctx, magicLink.MagicLinkID, now); err != nil {
+		if commonErrors.IsNotFoundError(err) {
+			return nil, NewMagicLinkAlreadyRedeemedError(email)
+		}
+
+		return nil, err
+	}
+
+	magicLink.RedeemedAt = &now
+
+	return magicLink, nil
+}
+
+func hash(value string) string {
+	hash := sha256.Sum256([]byte(value))
+
+	return hex.EncodeToString(hash[:])
+}
+
+func matchesHash(value string, expectedHash string) bool {
+	return subtle.ConstantTimeCompare([]byte(hash(value)), []byte(expectedHash)) == 1
+}
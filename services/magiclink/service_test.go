@@ -0,0 +1,352 @@
+package magiclink_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	idgeneratorMock "github.com/decentralized-cloud/user/services/idgenerator/mock"
+	"github.com/decentralized-cloud/user/services/magiclink"
+	magiclinkMock "github.com/decentralized-cloud/user/services/magiclink/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestMagicLinkService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Magic Link Service Tests")
+}
+
+var _ = Describe("Magic Link Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      magiclink.MagicLinkContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		mockStoreService         *magiclinkMock.MockStoreContract
+		mockClockService         *clockMock.MockClockContract
+		mockIDGeneratorService   *idgeneratorMock.MockIDGeneratorContract
+		magicLinks               map[string]*models.MagicLink
+		ipAddressBindingEnabled  bool
+		deviceBindingEnabled     bool
+		now                      time.Time
+		ctx                      context.Context
+		email                    string
+		ipAddress                string
+		deviceID                 string
+	)
+
+	createService := func() magiclink.MagicLinkContract {
+		service, err := magiclink.NewMagicLinkService(mockConfigurationService, mockStoreService, mockClockService, mockIDGeneratorService)
+		Ω(err).Should(BeNil())
+
+		return service
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		ipAddressBindingEnabled = false
+		deviceBindingEnabled = false
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetMagicLinkTokenTTL().
+			Return(15*time.Minute, nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetMagicLinkIPAddressBindingEnabled().
+			DoAndReturn(func() (bool, error) { return ipAddressBindingEnabled, nil }).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetMagicLinkDeviceBindingEnabled().
+			DoAndReturn(func() (bool, error) { return deviceBindingEnabled, nil }).
+			AnyTimes()
+
+		// The store keeps the magic links in memory by their email addresses, so the changes to the magic links can be
+		// read back
+		magicLinks = map[string]*models.MagicLink{}
+		mockStoreService = magiclinkMock.NewMockStoreContract(mockCtrl)
+		mockStoreService.
+			EXPECT().
+			SaveMagicLink(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, magicLink *models.MagicLink) error {
+				storedMagicLink := *magicLink
+				magicLinks[magicLink.Email] = &storedMagicLink
+
+				return nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			ReadMagicLink(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, email string) (*models.MagicLink, error) {
+				storedMagicLink, ok := magicLinks[email]
+				if !ok {
+					return nil, commonErrors.NewNotFoundError()
+				}
+
+				readMagicLink := *storedMagicLink
+
+				return &readMagicLink, nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			RedeemMagicLink(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, magicLinkID string, redeemedAt time.Time) error {
+				for _, storedMagicLink := range magicLinks {
+					if storedMagicLink.MagicLinkID == magicLinkID && storedMagicLink.RedeemedAt == nil {
+						storedMagicLink.RedeemedAt = &redeemedAt
+
+						return nil
+					}
+				}
+
+				return commonErrors.NewNotFoundError()
+			}).
+			AnyTimes()
+
+		now = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			DoAndReturn(func() time.Time { return now }).
+			AnyTimes()
+
+		mockIDGeneratorService = idgeneratorMock.NewMockIDGeneratorContract(mockCtrl)
+		mockIDGeneratorService.
+			EXPECT().
+			NewID().
+			DoAndReturn(cuid.New).
+			AnyTimes()
+
+		ctx = context.Background()
+		email = cuid.New() + "@test.com"
+		ipAddress = "192.0.2.1"
+		deviceID = cuid.New()
+		sut = createService()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate MagicLinkService", func() {
+		When("configuration service is not provided and NewMagicLinkService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := magiclink.NewMagicLinkService(nil, mockStoreService, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("store service is not provided and NewMagicLinkService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := magiclink.NewMagicLinkService(mockConfigurationService, nil, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("clock service is not provided and NewMagicLinkService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := magiclink.NewMagicLinkService(mockConfigurationService, mockStoreService, nil, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("ID generator service is not provided and NewMagicLinkService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := magiclink.NewMagicLinkService(mockConfigurationService, mockStoreService, mockClockService, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("MagicLinkService is instantiated", func() {
+		When("IssueMagicLink is called", func() {
+			It("should persist the hash of the token of the magic link that expires after the TTL", func() {
+				issuedMagicLink, token, err := sut.IssueMagicLink(ctx, email, ipAddress, deviceID)
+				Ω(err).Should(BeNil())
+				Ω(token).ShouldNot(BeEmpty())
+				Ω(issuedMagicLink.MagicLinkID).ShouldNot(BeEmpty())
+				Ω(issuedMagicLink.TokenHash).ShouldNot(BeEmpty())
+				Ω(issuedMagicLink.TokenHash).ShouldNot(ContainSubstring(token))
+				Ω(issuedMagicLink.IPAddressHash).Should(BeEmpty())
+				Ω(issuedMagicLink.DeviceIDHash).Should(BeEmpty())
+				Ω(issuedMagicLink.CreatedAt).Should(Equal(now))
+				Ω(issuedMagicLink.ExpiresAt).Should(Equal(now.Add(15 * time.Minute)))
+				Ω(issuedMagicLink.RedeemedAt).Should(BeNil())
+				Ω(*magicLinks[email]).Should(Equal(*issuedMagicLink))
+			})
+
+			It("should persist the hashes of the IP address and the device if the binding is enabled", func() {
+				ipAddressBindingEnabled = true
+				deviceBindingEnabled = true
+
+				issuedMagicLink, _, err := createService().IssueMagicLink(ctx, email, ipAddress, deviceID)
+				Ω(err).Should(BeNil())
+				Ω(issuedMagicLink.IPAddressHash).ShouldNot(BeEmpty())
+				Ω(issuedMagicLink.IPAddressHash).ShouldNot(ContainSubstring(ipAddress))
+				Ω(issuedMagicLink.DeviceIDHash).ShouldNot(BeEmpty())
+				Ω(issuedMagicLink.DeviceIDHash).ShouldNot(ContainSubstring(deviceID))
+			})
+
+			It("should return ArgumentError if the IP address binding is enabled and the IP address is not provided", func() {
+				ipAddressBindingEnabled = true
+
+				_, _, err := createService().IssueMagicLink(ctx, email, "", deviceID)
+				Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+				Ω(magicLinks).Should(BeEmpty())
+			})
+
+			It("should return ArgumentError if the device binding is enabled and the device is not provided", func() {
+				deviceBindingEnabled = true
+
+				_, _, err := createService().IssueMagicLink(ctx, email, ipAddress, "")
+				Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+				Ω(magicLinks).Should(BeEmpty())
+			})
+
+			It("should replace the magic link issued before", func() {
+				_, previousToken, err := sut.IssueMagicLink(ctx, email, ipAddress, deviceID)
+				Ω(err).Should(BeNil())
+				_, _, err = sut.IssueMagicLink(ctx, email, ipAddress, deviceID)
+				Ω(err).Should(BeNil())
+
+				_, err = sut.RedeemMagicLink(ctx, email, previousToken, ipAddress, deviceID)
+				Ω(magiclink.IsMagicLinkTokenError(err)).Should(BeTrue())
+				Ω(magiclink.IsMagicLinkTokenExpiredError(err)).Should(BeFalse())
+			})
+		})
+
+		When("RedeemMagicLink is called", func() {
+			It("should redeem the magic link with its token", func() {
+				_, token, err := sut.IssueMagicLink(ctx, email, ipAddress, deviceID)
+				Ω(err).Should(BeNil())
+
+				redeemedMagicLink, err := sut.RedeemMagicLink(ctx, email, token, "", "")
+				Ω(err).Should(BeNil())
+				Ω(*redeemedMagicLink.RedeemedAt).Should(Equal(now))
+				Ω(magicLinks[email].RedeemedAt).ShouldNot(BeNil())
+			})
+
+			It("should return MagicLinkTokenError if no magic link is issued to the email address", func() {
+				_, err := sut.RedeemMagicLink(ctx, email, cuid.New(), ipAddress, deviceID)
+				Ω(magiclink.IsMagicLinkTokenError(err)).Should(BeTrue())
+				Ω(magiclink.IsMagicLinkTokenExpiredError(err)).Should(BeFalse())
+			})
+
+			It("should return MagicLinkTokenError if the token is not the token of the magic link", func() {
+				_, _, err := sut.IssueMagicLink(ctx, email, ipAddress, deviceID)
+				Ω(err).Should(BeNil())
+
+				_, err = sut.RedeemMagicLink(ctx, email, cuid.New(), ipAddress, deviceID)
+				Ω(magiclink.IsMagicLinkTokenError(err)).Should(BeTrue())
+				Ω(magiclink.IsMagicLinkTokenExpiredError(err)).Should(BeFalse())
+			})
+
+			It("should return the expired MagicLinkTokenError if the magic link has expired", func() {
+				_, token, err := sut.IssueMagicLink(ctx, email, ipAddress, deviceID)
+				Ω(err).Should(BeNil())
+
+				now = now.Add(15 * time.Minute)
+				_, err = sut.RedeemMagicLink(ctx, email, token, ipAddress, deviceID)
+				Ω(magiclink.IsMagicLinkTokenExpiredError(err)).Should(BeTrue())
+				Ω(magicLinks[email].RedeemedAt).Should(BeNil())
+			})
+
+			It("should return MagicLinkTokenError without redeeming the magic link if the IP address does not match", func() {
+				ipAddressBindingEnabled = true
+				service := createService()
+
+				_, token, err := service.IssueMagicLink(ctx, email, ipAddress, deviceID)
+				Ω(err).Should(BeNil())
+
+				_, err = service.RedeemMagicLink(ctx, email, token, "198.51.100.1", deviceID)
+				Ω(magiclink.IsMagicLinkTokenError(err)).Should(BeTrue())
+				Ω(magicLinks[email].RedeemedAt).Should(BeNil())
+
+				_, err = service.RedeemMagicLink(ctx, email, token, ipAddress, deviceID)
+				Ω(err).Should(BeNil())
+			})
+
+			It("should return MagicLinkTokenError without redeeming the magic link if the device does not match", func() {
+				deviceBindingEnabled = true
+				service := createService()
+
+				_, token, err := service.IssueMagicLink(ctx, email, ipAddress, deviceID)
+				Ω(err).Should(BeNil())
+
+				_, err = service.RedeemMagicLink(ctx, email, token, ipAddress, cuid.New())
+				Ω(magiclink.IsMagicLinkTokenError(err)).Should(BeTrue())
+				Ω(magicLinks[email].RedeemedAt).Should(BeNil())
+
+				_, err = service.RedeemMagicLink(ctx, email, token, ipAddress, deviceID)
+				Ω(err).Should(BeNil())
+			})
+
+			It("should keep checking the binding of the magic links issued before the binding is disabled", func() {
+				ipAddressBindingEnabled = true
+				_, token, err := createService().IssueMagicLink(ctx, email, ipAddress, deviceID)
+				Ω(err).Should(BeNil())
+
+				ipAddressBindingEnabled = false
+				_, err = createService().RedeemMagicLink(ctx, email, token, "198.51.100.1", deviceID)
+				Ω(magiclink.IsMagicLinkTokenError(err)).Should(BeTrue())
+			})
+
+			It("should return MagicLinkAlreadyRedeemedError if the magic link is already redeemed", func() {
+				_, token, err := sut.IssueMagicLink(ctx, email, ipAddress, deviceID)
+				Ω(err).Should(BeNil())
+				_, err = sut.RedeemMagicLink(ctx, email, token, ipAddress, deviceID)
+				Ω(err).Should(BeNil())
+
+				_, err = sut.RedeemMagicLink(ctx, email, token, ipAddress, deviceID)
+				Ω(magiclink.IsMagicLinkAlreadyRedeemedError(err)).Should(BeTrue())
+			})
+
+			It("should return MagicLinkAlreadyRedeemedError if the magic link is redeemed after it is read", func() {
+				_, token, err := sut.IssueMagicLink(ctx, email, ipAddress, deviceID)
+				Ω(err).Should(BeNil())
+
+				// The magic link read by the call is not redeemed yet, but another call redeems it before this call does
+				concurrentCtrl := gomock.NewController(GinkgoT())
+				defer concurrentCtrl.Finish()
+
+				pendingMagicLink := *magicLinks[email]
+				concurrentStoreService := magiclinkMock.NewMockStoreContract(concurrentCtrl)
+				concurrentStoreService.
+					EXPECT().
+					ReadMagicLink(gomock.Any(), email).
+					Return(&pendingMagicLink, nil)
+				concurrentStoreService.
+					EXPECT().
+					RedeemMagicLink(gomock.Any(), pendingMagicLink.MagicLinkID, now).
+					Return(commonErrors.NewNotFoundError())
+
+				service, err := magiclink.NewMagicLinkService(mockConfigurationService, concurrentStoreService, mockClockService, mockIDGeneratorService)
+				Ω(err).Should(BeNil())
+
+				_, err = service.RedeemMagicLink(ctx, email, token, ipAddress, deviceID)
+				Ω(magiclink.IsMagicLinkAlreadyRedeemedError(err)).Should(BeTrue())
+			})
+		})
+	})
+})
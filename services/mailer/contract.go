@@ -0,0 +1,39 @@
+// Package mailer implements different services that send the emails to the users
+package mailer
+
+import "context"
+
+// MailerContract declares the service that sends the emails to the users
+type MailerContract interface {
+	// SendVerificationEmail sends the token the user verifies the email address with to the email address
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The verification email to send
+	// Returns error if something goes wrong.
+	SendVerificationEmail(
+		ctx context.Context,
+		email *VerificationEmail) error
+
+	// SendInvitationEmail sends the token the invited user accepts the invitation with to the invited email address
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The invitation email to send
+	// Returns error if something goes wrong.
+	SendInvitationEmail(
+		ctx context.Context,
+		email *InvitationEmail) error
+
+	// SendEmailChangeEmail sends the token the user confirms the email address change with to the new email address
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address change email to send
+	// Returns error if something goes wrong.
+	SendEmailChangeEmail(
+		ctx context.Context,
+		email *EmailChangeEmail) error
+
+	// SendEmailChangedNotification notifies the previous email address of the user that the email address is changed
+	// ctx: Mandatory The reference to the context
+	// notification: Mandatory. The notification to send
+	// Returns error if something goes wrong.
+	SendEmailChangedNotification(
+		ctx context.Context,
+		notification *EmailChangedNotification) error
+}
@@ -0,0 +1 @@
+package mailer_test
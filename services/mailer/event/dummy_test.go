@@ -0,0 +1 @@
+package event_test
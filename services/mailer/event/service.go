@@ -0,0 +1,85 @@
+// Package event implements the mailer service that publishes the emails as events for another service to send them
+package event
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/services/eventing"
+	"github.com/decentralized-cloud/user/services/mailer"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+type eventMailerService struct {
+	eventingService eventing.EventingContract
+}
+
+// NewEventMailerService creates new instance of the eventMailerService, setting up all dependencies and returns the instance
+// eventingService: Mandatory. Reference to the service that publishes the events
+// Returns the new service or error if something goes wrong
+func NewEventMailerService(eventingService eventing.EventingContract) (mailer.MailerContract, error) {
+	if eventingService == nil {
+		return nil, commonErrors.NewArgumentNilError("eventingService", "eventingService is required")
+	}
+
+	return &eventMailerService{
+		eventingService: eventingService,
+	}, nil
+}
+
+// SendVerificationEmail publishes the event the service that sends the emails sends the verification email for
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The verification email to send
+// Returns error if something goes wrong.
+func (service *eventMailerService) SendVerificationEmail(
+	ctx context.Context,
+	email *mailer.VerificationEmail) error {
+	return service.eventingService.PublishEmailVerificationRequested(ctx, &eventing.EmailVerificationRequestedEvent{
+		Email:     email.Email,
+		Token:     email.Token,
+		ExpiresAt: email.ExpiresAt,
+	})
+}
+
+// SendInvitationEmail publishes the event the service that sends the emails sends the invitation email for
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The invitation email to send
+// Returns error if something goes wrong.
+func (service *eventMailerService) SendInvitationEmail(
+	ctx context.Context,
+	email *mailer.InvitationEmail) error {
+	return service.eventingService.PublishUserInvited(ctx, &eventing.UserInvitedEvent{
+		Email:     email.Email,
+		InvitedBy: email.InvitedBy,
+		Token:     email.Token,
+		ExpiresAt: email.ExpiresAt,
+	})
+}
+
+// SendEmailChangeEmail publishes the event the service that sends the emails sends the email address change email for
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address change email to send
+// Returns error if something goes wrong.
+func (service *eventMailerService) SendEmailChangeEmail(
+	ctx context.Context,
+	email *mailer.EmailChangeEmail) error {
+	return service.eventingService.PublishEmailChangeRequested(ctx, &eventing.EmailChangeRequestedEvent{
+		Email:     email.Email,
+		NewEmail:  email.NewEmail,
+		Token:     email.Token,
+		ExpiresAt: email.ExpiresAt,
+	})
+}
+
+// SendEmailChangedNotification publishes the event the service that sends the emails notifies the previous email
+// address for
+// ctx: Mandatory The reference to the context
+// notification: Mandatory. The notification to send
+// Returns error if something goes wrong.
+func (service *eventMailerService) SendEmailChangedNotification(
+	ctx context.Context,
+	notification *mailer.EmailChangedNotification) error {
+	return service.eventingService.PublishEmailChanged(ctx, &eventing.EmailChangedEvent{
+		Email:    notification.Email,
+		NewEmail: notification.NewEmail,
+	})
+}
@@ -0,0 +1,35 @@
+// Package mailer implements different services that send the emails to the users
+package mailer
+
+import "time"
+
+// VerificationEmail contains the token to send to the user to verify the email address with
+type VerificationEmail struct {
+	Email     string
+	Token     string
+	ExpiresAt time.Time
+}
+
+// InvitationEmail contains the token to send to the invited email address to accept the invitation with
+type InvitationEmail struct {
+	Email     string
+	InvitedBy string
+	Token     string
+	ExpiresAt time.Time
+}
+
+// EmailChangeEmail contains the token to send to the new email address of the user to confirm the email address
+// change with
+type EmailChangeEmail struct {
+	Email     string
+	NewEmail  string
+	Token     string
+	ExpiresAt time.Time
+}
+
+// EmailChangedNotification contains the details of the email address change to send to the previous email address
+// of the user
+type EmailChangedNotification struct {
+	Email    string
+	NewEmail string
+}
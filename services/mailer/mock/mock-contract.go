@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/mailer/contract.go
+
+// Package mock_mailer is a generated GoMock package.
+package mock_mailer
+
+import (
+	context "context"
+	reflect "reflect"
+
+	mailer "github.com/decentralized-cloud/user/services/mailer"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMailerContract is a mock of MailerContract interface.
+type MockMailerContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailerContractMockRecorder
+}
+
+// MockMailerContractMockRecorder is the mock recorder for MockMailerContract.
+type MockMailerContractMockRecorder struct {
+	mock *MockMailerContract
+}
+
+// NewMockMailerContract creates a new mock instance.
+func NewMockMailerContract(ctrl *gomock.Controller) *MockMailerContract {
+	mock := &MockMailerContract{ctrl: ctrl}
+	mock.recorder = &MockMailerContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailerContract) EXPECT() *MockMailerContractMockRecorder {
+	return m.recorder
+}
+
+// SendEmailChangeEmail mocks base method.
+func (m *MockMailerContract) SendEmailChangeEmail(ctx context.Context, email *mailer.EmailChangeEmail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmailChangeEmail", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmailChangeEmail indicates an expected call of SendEmailChangeEmail.
+func (mr *MockMailerContractMockRecorder) SendEmailChangeEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmailChangeEmail", reflect.TypeOf((*MockMailerContract)(nil).SendEmailChangeEmail), ctx, email)
+}
+
+// SendEmailChangedNotification mocks base method.
+func (m *MockMailerContract) SendEmailChangedNotification(ctx context.Context, notification *mailer.EmailChangedNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmailChangedNotification", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmailChangedNotification indicates an expected call of SendEmailChangedNotification.
+func (mr *MockMailerContractMockRecorder) SendEmailChangedNotification(ctx, notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmailChangedNotification", reflect.TypeOf((*MockMailerContract)(nil).SendEmailChangedNotification), ctx, notification)
+}
+
+// SendInvitationEmail mocks base method.
+func (m *MockMailerContract) SendInvitationEmail(ctx context.Context, email *mailer.InvitationEmail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendInvitationEmail", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendInvitationEmail indicates an expected call of SendInvitationEmail.
+func (mr *MockMailerContractMockRecorder) SendInvitationEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendInvitationEmail", reflect.TypeOf((*MockMailerContract)(nil).SendInvitationEmail), ctx, email)
+}
+
+// SendVerificationEmail mocks base method.
+func (m *MockMailerContract) SendVerificationEmail(ctx context.Context, email *mailer.VerificationEmail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerificationEmail", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendVerificationEmail indicates an expected call of SendVerificationEmail.
+func (mr *MockMailerContractMockRecorder) SendVerificationEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerificationEmail", reflect.TypeOf((*MockMailerContract)(nil).SendVerificationEmail), ctx, email)
+}
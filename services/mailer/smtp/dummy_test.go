@@ -0,0 +1 @@
+package smtp_test
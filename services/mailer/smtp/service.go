@@ -0,0 +1,249 @@
+// Package smtp implements the mailer service that sends the emails through an SMTP server
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/url"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/mailer"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+type smtpMailerService struct {
+	address         string
+	auth            smtp.Auth
+	from            string
+	verificationURL string
+	invitationURL   string
+	emailChangeURL  string
+}
+
+// NewSmtpMailerService creates new instance of the smtpMailerService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewSmtpMailerService(configurationService configuration.ConfigurationContract) (mailer.MailerContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	address, err := configurationService.GetSmtpAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	username, err := configurationService.GetSmtpUsername()
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := configurationService.GetSmtpPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := configurationService.GetSmtpFrom()
+	if err != nil {
+		return nil, err
+	}
+
+	verificationURL, err := configurationService.GetEmailVerificationURL()
+	if err != nil {
+		return nil, err
+	}
+
+	invitationURL, err := configurationService.GetInvitationURL()
+	if err != nil {
+		return nil, err
+	}
+
+	emailChangeURL, err := configurationService.GetEmailChangeURL()
+	if err != nil {
+		return nil, err
+	}
+
+	service := &smtpMailerService{
+		address:         address,
+		from:            from,
+		verificationURL: verificationURL,
+		invitationURL:   invitationURL,
+		emailChangeURL:  emailChangeURL,
+	}
+
+	// The SMTP server is only authenticated with if the username is provided, PlainAuth refuses to send the
+	// credentials unless the connection is encrypted or to localhost
+	if username != "" {
+		host, _, _ := net.SplitHostPort(address)
+		service.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return service, nil
+}
+
+// SendVerificationEmail sends the verification email linking to the email verification page through the SMTP server
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The verification email to send
+// Returns error if something goes wrong.
+func (service *smtpMailerService) SendVerificationEmail(
+	ctx context.Context,
+	email *mailer.VerificationEmail) error {
+	link, err := createLink(service.verificationURL, email.Email, email.Token)
+	if err != nil {
+		return err
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", service.from)
+	fmt.Fprintf(&message, "To: %s\r\n", email.Email)
+	fmt.Fprintf(&message, "Subject: Verify your email address\r\n")
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: text/plain; charset=UTF-8\r\n")
+	fmt.Fprintf(&message, "\r\n")
+	fmt.Fprintf(&message, "Open the link below to verify your email address:\r\n\r\n%s\r\n\r\n", link)
+	fmt.Fprintf(&message, "The link expires at %s.\r\n", email.ExpiresAt.UTC().Format(time.RFC1123))
+
+	if err = service.send(ctx, email.Email, message.Bytes()); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to send the verification email", err)
+	}
+
+	return nil
+}
+
+// SendInvitationEmail sends the invitation email linking to the invitation page through the SMTP server
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The invitation email to send
+// Returns error if something goes wrong.
+func (service *smtpMailerService) SendInvitationEmail(
+	ctx context.Context,
+	email *mailer.InvitationEmail) error {
+	if service.invitationURL == "" {
+		return commonErrors.NewUnknownError("USER_INVITATION_URL is required to send the invitation emails")
+	}
+
+	link, err := createLink(service.invitationURL, email.Email, email.Token)
+	if err != nil {
+		return err
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", service.from)
+	fmt.Fprintf(&message, "To: %s\r\n", email.Email)
+	fmt.Fprintf(&message, "Subject: You are invited\r\n")
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: text/plain; charset=UTF-8\r\n")
+	fmt.Fprintf(&message, "\r\n")
+	fmt.Fprintf(&message, "%s invited you. Open the link below to accept the invitation:\r\n\r\n%s\r\n\r\n", email.InvitedBy, link)
+	fmt.Fprintf(&message, "The link expires at %s.\r\n", email.ExpiresAt.UTC().Format(time.RFC1123))
+
+	if err = service.send(ctx, email.Email, message.Bytes()); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to send the invitation email", err)
+	}
+
+	return nil
+}
+
+// SendEmailChangeEmail sends the email linking to the email address change confirmation page to the new email address
+// through the SMTP server
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address change email to send
+// Returns error if something goes wrong.
+func (service *smtpMailerService) SendEmailChangeEmail(
+	ctx context.Context,
+	email *mailer.EmailChangeEmail) error {
+	if service.emailChangeURL == "" {
+		return commonErrors.NewUnknownError("USER_EMAIL_CHANGE_URL is required to send the email address change emails")
+	}
+
+	// The link carries the current email address, as the change is confirmed by the user signed in with it
+	link, err := createLink(service.emailChangeURL, email.Email, email.Token)
+	if err != nil {
+		return err
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", service.from)
+	fmt.Fprintf(&message, "To: %s\r\n", email.NewEmail)
+	fmt.Fprintf(&message, "Subject: Confirm your new email address\r\n")
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: text/plain; charset=UTF-8\r\n")
+	fmt.Fprintf(&message, "\r\n")
+	fmt.Fprintf(&message, "Open the link below to change your email address from %s to this email address:\r\n\r\n%s\r\n\r\n", email.Email, link)
+	fmt.Fprintf(&message, "The link expires at %s.\r\n", email.ExpiresAt.UTC().Format(time.RFC1123))
+
+	if err = service.send(ctx, email.NewEmail, message.Bytes()); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to send the email address change email", err)
+	}
+
+	return nil
+}
+
+// SendEmailChangedNotification notifies the previous email address of the user of the email address change through
+// the SMTP server
+// ctx: Mandatory The reference to the context
+// notification: Mandatory. The notification to send
+// Returns error if something goes wrong.
+func (service *smtpMailerService) SendEmailChangedNotification(
+	ctx context.Context,
+	notification *mailer.EmailChangedNotification) error {
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", service.from)
+	fmt.Fprintf(&message, "To: %s\r\n", notification.Email)
+	fmt.Fprintf(&message, "Subject: Your email address is changed\r\n")
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: text/plain; charset=UTF-8\r\n")
+	fmt.Fprintf(&message, "\r\n")
+	fmt.Fprintf(&message, "The email address of your account is changed from %s to %s.\r\n\r\n", notification.Email, notification.NewEmail)
+	fmt.Fprintf(&message, "Contact the support right away if you did not make this change.\r\n")
+
+	if err := service.send(ctx, notification.Email, message.Bytes()); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to send the email address changed notification", err)
+	}
+
+	return nil
+}
+
+// send sends the message to the email address through the SMTP server
+// ctx: Mandatory The reference to the context
+// to: Mandatory. The email address to send the message to
+// message: Mandatory. The message including its headers
+// Returns error if something goes wrong
+func (service *smtpMailerService) send(ctx context.Context, to string, message []byte) error {
+	// net/smtp does not support a context, so the email is sent in the background and abandoned once the context is
+	// done. The connection is closed by the SMTP server timing out in that case.
+	result := make(chan error, 1)
+	go func() {
+		result <- smtp.SendMail(service.address, service.auth, service.from, []string{to}, message)
+	}()
+
+	select {
+	case err := <-result:
+		return err
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// createLink adds the email address and the token to the query of the URL
+// rawURL: Mandatory. The URL of the page the email links to
+// email: Mandatory. The email address the email is sent to
+// token: Mandatory. The token sent in the email
+// Returns either the link or error if something goes wrong
+func createLink(rawURL string, email string, token string) (string, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", commonErrors.NewUnknownErrorWithError("failed to parse the URL the email links to", err)
+	}
+
+	query := parsedURL.Query()
+	query.Set("email", email)
+	query.Set("token", token)
+	parsedURL.RawQuery = query.Encode()
+
+	return parsedURL.String(), nil
+}
@@ -0,0 +1,37 @@
+// Package objectstorage implements different services that store the files of the users in an object storage
+package objectstorage
+
+import (
+	"context"
+	"time"
+)
+
+// ObjectStorageContract declares the service that stores the files of the users, e.g. the avatar images, in a bucket
+// of an object storage
+type ObjectStorageContract interface {
+	// PutObject stores the object, replacing the object stored with the same key before if any
+	// ctx: Mandatory The reference to the context
+	// object: Mandatory. The object to store
+	// Returns error if something goes wrong.
+	PutObject(
+		ctx context.Context,
+		object *Object) error
+
+	// DeleteObject deletes the object, deleting an object that does not exist succeeds
+	// ctx: Mandatory The reference to the context
+	// key: Mandatory. The key of the object to delete
+	// Returns error if something goes wrong.
+	DeleteObject(
+		ctx context.Context,
+		key string) error
+
+	// PresignGetObject creates the URL the object can be downloaded from without any credentials until it expires
+	// ctx: Mandatory The reference to the context
+	// key: Mandatory. The key of the object to download
+	// expiry: Mandatory. How long the URL is valid for
+	// Returns either the URL or error if something goes wrong.
+	PresignGetObject(
+		ctx context.Context,
+		key string,
+		expiry time.Duration) (string, error)
+}
@@ -0,0 +1,55 @@
+// Package disabled implements the object storage service used when no object storage is configured
+package disabled
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/objectstorage"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+type disabledObjectStorageService struct {
+}
+
+// NewDisabledObjectStorageService creates new instance of the disabledObjectStorageService, setting up all dependencies and returns the instance
+// Returns the new service or error if something goes wrong
+func NewDisabledObjectStorageService() (objectstorage.ObjectStorageContract, error) {
+	return &disabledObjectStorageService{}, nil
+}
+
+// PutObject fails as no object storage is configured
+// ctx: Mandatory The reference to the context
+// object: Mandatory. The object to store
+// Returns error as the object can not be stored
+func (service *disabledObjectStorageService) PutObject(
+	ctx context.Context,
+	object *objectstorage.Object) error {
+	return newNotConfiguredError()
+}
+
+// DeleteObject fails as no object storage is configured
+// ctx: Mandatory The reference to the context
+// key: Mandatory. The key of the object to delete
+// Returns error as the object can not be deleted
+func (service *disabledObjectStorageService) DeleteObject(
+	ctx context.Context,
+	key string) error {
+	return newNotConfiguredError()
+}
+
+// PresignGetObject fails as no object storage is configured
+// ctx: Mandatory The reference to the context
+// key: Mandatory. The key of the object to download
+// expiry: Mandatory. How long the URL is valid for
+// Returns error as the object can not be downloaded
+func (service *disabledObjectStorageService) PresignGetObject(
+	ctx context.Context,
+	key string,
+	expiry time.Duration) (string, error) {
+	return "", newNotConfiguredError()
+}
+
+func newNotConfiguredError() error {
+	return commonErrors.NewUnknownError("no object storage is configured")
+}
@@ -0,0 +1 @@
+package objectstorage_test
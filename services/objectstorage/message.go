@@ -0,0 +1,9 @@
+// Package objectstorage implements different services that store the files of the users in an object storage
+package objectstorage
+
+// Object contains the content of a file to store and the key it is stored with
+type Object struct {
+	Key         string
+	ContentType string
+	Content     []byte
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/objectstorage/contract.go
+
+// Package mock_objectstorage is a generated GoMock package.
+package mock_objectstorage
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	objectstorage "github.com/decentralized-cloud/user/services/objectstorage"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockObjectStorageContract is a mock of ObjectStorageContract interface.
+type MockObjectStorageContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockObjectStorageContractMockRecorder
+}
+
+// MockObjectStorageContractMockRecorder is the mock recorder for MockObjectStorageContract.
+type MockObjectStorageContractMockRecorder struct {
+	mock *MockObjectStorageContract
+}
+
+// NewMockObjectStorageContract creates a new mock instance.
+func NewMockObjectStorageContract(ctrl *gomock.Controller) *MockObjectStorageContract {
+	mock := &MockObjectStorageContract{ctrl: ctrl}
+	mock.recorder = &MockObjectStorageContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObjectStorageContract) EXPECT() *MockObjectStorageContractMockRecorder {
+	return m.recorder
+}
+
+// DeleteObject mocks base method.
+func (m *MockObjectStorageContract) DeleteObject(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteObject", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteObject indicates an expected call of DeleteObject.
+func (mr *MockObjectStorageContractMockRecorder) DeleteObject(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteObject", reflect.TypeOf((*MockObjectStorageContract)(nil).DeleteObject), ctx, key)
+}
+
+// PresignGetObject mocks base method.
+func (m *MockObjectStorageContract) PresignGetObject(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignGetObject", ctx, key, expiry)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignGetObject indicates an expected call of PresignGetObject.
+func (mr *MockObjectStorageContractMockRecorder) PresignGetObject(ctx, key, expiry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignGetObject", reflect.TypeOf((*MockObjectStorageContract)(nil).PresignGetObject), ctx, key, expiry)
+}
+
+// PutObject mocks base method.
+func (m *MockObjectStorageContract) PutObject(ctx context.Context, object *objectstorage.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutObject", ctx, object)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutObject indicates an expected call of PutObject.
+func (mr *MockObjectStorageContractMockRecorder) PutObject(ctx, object interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObject", reflect.TypeOf((*MockObjectStorageContract)(nil).PutObject), ctx, object)
+}
@@ -0,0 +1 @@
+package s3_test
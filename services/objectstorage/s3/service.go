@@ -0,0 +1,147 @@
+// Package s3 implements the object storage service that stores the files in Amazon S3 or an S3 compatible object
+// storage such as MinIO
+package s3
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/objectstorage"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+type s3ObjectStorageService struct {
+	client *s3.S3
+	bucket string
+}
+
+// NewS3ObjectStorageService creates new instance of the s3ObjectStorageService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewS3ObjectStorageService(configurationService configuration.ConfigurationContract) (objectstorage.ObjectStorageContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	endpoint, err := configurationService.GetObjectStorageEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	region, err := configurationService.GetObjectStorageRegion()
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, err := configurationService.GetObjectStorageBucket()
+	if err != nil {
+		return nil, err
+	}
+
+	accessKeyID, err := configurationService.GetObjectStorageAccessKeyID()
+	if err != nil {
+		return nil, err
+	}
+
+	secretAccessKey, err := configurationService.GetObjectStorageSecretAccessKey()
+	if err != nil {
+		return nil, err
+	}
+
+	pathStyleEnabled, err := configurationService.GetObjectStoragePathStyleEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	config := aws.NewConfig().
+		WithRegion(region).
+		WithS3ForcePathStyle(pathStyleEnabled)
+
+	if endpoint != "" {
+		config = config.WithEndpoint(endpoint)
+	}
+
+	// The default credential chain, e.g. the environment variables or the role of the pod, is used unless the
+	// access key is provided
+	if accessKeyID != "" {
+		config = config.WithCredentials(credentials.NewStaticCredentials(accessKeyID, secretAccessKey, ""))
+	}
+
+	awsSession, err := session.NewSession(config)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the object storage session", err)
+	}
+
+	return &s3ObjectStorageService{
+		client: s3.New(awsSession),
+		bucket: bucket,
+	}, nil
+}
+
+// PutObject stores the object in the bucket, replacing the object stored with the same key before if any
+// ctx: Mandatory The reference to the context
+// object: Mandatory. The object to store
+// Returns error if something goes wrong.
+func (service *s3ObjectStorageService) PutObject(
+	ctx context.Context,
+	object *objectstorage.Object) error {
+	if _, err := service.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(service.bucket),
+		Key:           aws.String(object.Key),
+		Body:          bytes.NewReader(object.Content),
+		ContentType:   aws.String(object.ContentType),
+		ContentLength: aws.Int64(int64(len(object.Content))),
+	}); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to store the object", err)
+	}
+
+	return nil
+}
+
+// DeleteObject deletes the object from the bucket, deleting an object that does not exist succeeds
+// ctx: Mandatory The reference to the context
+// key: Mandatory. The key of the object to delete
+// Returns error if something goes wrong.
+func (service *s3ObjectStorageService) DeleteObject(
+	ctx context.Context,
+	key string) error {
+	if _, err := service.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(service.bucket),
+		Key:    aws.String(key),
+	}); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to delete the object", err)
+	}
+
+	return nil
+}
+
+// PresignGetObject creates the URL the object can be downloaded from without any credentials until it expires. The
+// URL is signed locally, so it is created even if the object does not exist.
+// ctx: Mandatory The reference to the context
+// key: Mandatory. The key of the object to download
+// expiry: Mandatory. How long the URL is valid for
+// Returns either the URL or error if something goes wrong.
+func (service *s3ObjectStorageService) PresignGetObject(
+	ctx context.Context,
+	key string,
+	expiry time.Duration) (string, error) {
+	request, _ := service.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(service.bucket),
+		Key:    aws.String(key),
+	})
+
+	request.SetContext(ctx)
+
+	url, err := request.Presign(expiry)
+	if err != nil {
+		return "", commonErrors.NewUnknownErrorWithError("failed to presign the object URL", err)
+	}
+
+	return url, nil
+}
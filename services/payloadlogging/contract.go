@@ -0,0 +1,25 @@
+// Package payloadlogging implements the logging of the request and response payloads of the calls at the debug level,
+// replacing the personal data in the payloads before they are logged
+package payloadlogging
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// PayloadLoggingContract declares the service that logs the request and response payloads of the calls
+type PayloadLoggingContract interface {
+	// Redact returns the copy of the message the values of the configured fields and the email addresses are replaced
+	// in, the given message is not changed
+	// message: Mandatory. The message to redact
+	// Returns the redacted copy of the message
+	Redact(message proto.Message) proto.Message
+
+	// CreateUnaryServerInterceptor creates the interceptor that logs the request and the response of the unary calls
+	// Returns the new interceptor
+	CreateUnaryServerInterceptor() grpc.UnaryServerInterceptor
+
+	// CreateStreamServerInterceptor creates the interceptor that logs every message the streaming calls receive and send
+	// Returns the new interceptor
+	CreateStreamServerInterceptor() grpc.StreamServerInterceptor
+}
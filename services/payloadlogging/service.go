@@ -0,0 +1,250 @@
+// Package payloadlogging implements the logging of the request and response payloads of the calls at the debug level,
+// replacing the personal data in the payloads before they are logged
+package payloadlogging
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/decentralized-cloud/user/services/correlation"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// RedactedValue is the value the redacted strings are replaced with in the logged payloads
+const RedactedValue = "[REDACTED]"
+
+// emailAddress matches the email addresses wherever they appear in the strings of the payloads, e.g. in a search
+// filter or in an error message
+var emailAddress = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+
+type payloadLoggingService struct {
+	logger  *zap.Logger
+	enabled bool
+	fields  map[protoreflect.Name]bool
+}
+
+// NewPayloadLoggingService creates new instance of the payloadLoggingService, setting up all dependencies and returns the instance
+// logger: Mandatory. Reference to the logger service the payloads are logged with
+// enabled: Mandatory. Whether the payloads are logged, the interceptors only pass the calls on if not
+// fieldNames: Optional. The names of the fields of any message, as they are named in the proto files, whose values are
+// replaced in the logged payloads
+// Returns the new service or error if something goes wrong
+func NewPayloadLoggingService(logger *zap.Logger, enabled bool, fieldNames []string) (PayloadLoggingContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	fields := map[protoreflect.Name]bool{}
+	for _, fieldName := range fieldNames {
+		fields[protoreflect.Name(fieldName)] = true
+	}
+
+	return &payloadLoggingService{
+		logger:  logger,
+		enabled: enabled,
+		fields:  fields,
+	}, nil
+}
+
+// Redact returns the copy of the message the values of the configured fields and the email addresses are replaced
+// in, the given message is not changed
+// message: Mandatory. The message to redact
+// Returns the redacted copy of the message
+func (service *payloadLoggingService) Redact(message proto.Message) proto.Message {
+	if message == nil || !message.ProtoReflect().IsValid() {
+		return message
+	}
+
+	redacted := proto.Clone(message)
+	service.redactMessage(redacted.ProtoReflect())
+
+	return redacted
+}
+
+// CreateUnaryServerInterceptor creates the interceptor that logs the request and the response of the unary calls
+// Returns the new interceptor
+func (service *payloadLoggingService) CreateUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		request interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if !service.enabled {
+			return handler(ctx, request)
+		}
+
+		service.logPayload(ctx, "gRPC request payload", info.FullMethod, request)
+
+		response, err := handler(ctx, request)
+		if err == nil {
+			service.logPayload(ctx, "gRPC response payload", info.FullMethod, response)
+		}
+
+		return response, err
+	}
+}
+
+// CreateStreamServerInterceptor creates the interceptor that logs every message the streaming calls receive and send
+// Returns the new interceptor
+func (service *payloadLoggingService) CreateStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		server interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		if !service.enabled {
+			return handler(server, stream)
+		}
+
+		return handler(server, &loggingServerStream{
+			ServerStream: stream,
+			service:      service,
+			method:       info.FullMethod,
+		})
+	}
+}
+
+// logPayload logs the redacted payload at the debug level. The payload is only redacted and marshalled if the logger
+// logs at the debug level, so the payloads cost nothing while the level is higher.
+// ctx: Mandatory The reference to the context
+// message: Mandatory. The message the payload is logged with
+// method: Mandatory. The full name of the method the payload is received or sent by
+// payload: Optional. The payload to log, nothing is logged if it is not a proto message
+func (service *payloadLoggingService) logPayload(ctx context.Context, message, method string, payload interface{}) {
+	payloadMessage, ok := payload.(proto.Message)
+	if !ok || payloadMessage == nil {
+		return
+	}
+
+	checkedEntry := correlation.GetLogger(ctx, service.logger).Check(zap.DebugLevel, message)
+	if checkedEntry == nil {
+		return
+	}
+
+	payloadJSON, err := protojson.Marshal(service.Redact(payloadMessage))
+	if err != nil {
+		checkedEntry.Write(zap.String("method", method), zap.NamedError("payloadError", err))
+
+		return
+	}
+
+	checkedEntry.Write(zap.String("method", method), zap.String("payload", string(payloadJSON)))
+}
+
+func (service *payloadLoggingService) redactMessage(message protoreflect.Message) {
+	type populatedField struct {
+		field protoreflect.FieldDescriptor
+		value protoreflect.Value
+	}
+
+	// The fields are collected first, as the message must not be changed while its fields are ranged over
+	populatedFields := []populatedField{}
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		populatedFields = append(populatedFields, populatedField{field: field, value: value})
+
+		return true
+	})
+
+	for _, populated := range populatedFields {
+		field := populated.field
+		value := populated.value
+		redactField := service.fields[field.Name()]
+
+		switch {
+		case field.IsMap():
+			service.redactMap(field, value.Map(), redactField)
+
+		case field.IsList():
+			service.redactList(field, value.List(), redactField)
+
+		case field.Kind() == protoreflect.StringKind:
+			message.Set(field, protoreflect.ValueOfString(redactString(value.String(), redactField)))
+
+		case redactField:
+			message.Clear(field)
+
+		case field.Message() != nil:
+			service.redactMessage(value.Message())
+		}
+	}
+}
+
+func (service *payloadLoggingService) redactMap(field protoreflect.FieldDescriptor, mapValue protoreflect.Map, redactField bool) {
+	keys := []protoreflect.MapKey{}
+	mapValue.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, key)
+
+		return true
+	})
+
+	mapField := field.MapValue()
+	for _, key := range keys {
+		switch {
+		case mapField.Kind() == protoreflect.StringKind:
+			mapValue.Set(key, protoreflect.ValueOfString(redactString(mapValue.Get(key).String(), redactField)))
+
+		case redactField:
+			mapValue.Clear(key)
+
+		case mapField.Message() != nil:
+			service.redactMessage(mapValue.Get(key).Message())
+		}
+	}
+}
+
+func (service *payloadLoggingService) redactList(field protoreflect.FieldDescriptor, list protoreflect.List, redactField bool) {
+	switch {
+	case field.Kind() == protoreflect.StringKind:
+		for index := 0; index < list.Len(); index++ {
+			list.Set(index, protoreflect.ValueOfString(redactString(list.Get(index).String(), redactField)))
+		}
+
+	case redactField:
+		list.Truncate(0)
+
+	case field.Message() != nil:
+		for index := 0; index < list.Len(); index++ {
+			service.redactMessage(list.Get(index).Message())
+		}
+	}
+}
+
+// redactString replaces the whole value if the field it is the value of is redacted, otherwise only the email
+// addresses in the value
+func redactString(value string, redactField bool) string {
+	if redactField {
+		return RedactedValue
+	}
+
+	return emailAddress.ReplaceAllString(value, RedactedValue)
+}
+
+// loggingServerStream logs every message the streaming call receives and sends
+type loggingServerStream struct {
+	grpc.ServerStream
+	service *payloadLoggingService
+	method  string
+}
+
+// RecvMsg receives the message and logs it
+func (stream *loggingServerStream) RecvMsg(message interface{}) error {
+	if err := stream.ServerStream.RecvMsg(message); err != nil {
+		return err
+	}
+
+	stream.service.logPayload(stream.Context(), "gRPC stream message received", stream.method, message)
+
+	return nil
+}
+
+// SendMsg logs the message and sends it
+func (stream *loggingServerStream) SendMsg(message interface{}) error {
+	stream.service.logPayload(stream.Context(), "gRPC stream message sent", stream.method, message)
+
+	return stream.ServerStream.SendMsg(message)
+}
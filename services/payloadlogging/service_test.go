@@ -0,0 +1,156 @@
+package payloadlogging_test
+
+import (
+	"context"
+	"testing"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/services/payloadlogging"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestPayloadLoggingService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Payload Logging Service Tests")
+}
+
+var _ = Describe("Payload Logging Service Tests", func() {
+	var (
+		sut    payloadlogging.PayloadLoggingContract
+		logs   *observer.ObservedLogs
+		logger *zap.Logger
+		email  string
+		info   *grpc.UnaryServerInfo
+	)
+
+	BeforeEach(func() {
+		var core zapcore.Core
+		core, logs = observer.New(zapcore.DebugLevel)
+		logger = zap.New(core)
+		email = cuid.New() + "@test.com"
+		info = &grpc.UnaryServerInfo{FullMethod: "/user.Service/ReadUser"}
+
+		var err error
+		sut, err = payloadlogging.NewPayloadLoggingService(logger, true, []string{"email", "labels", "createdBy"})
+		Ω(err).Should(BeNil())
+	})
+
+	Context("user tries to instantiate PayloadLoggingService", func() {
+		When("logger is not provided and NewPayloadLoggingService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := payloadlogging.NewPayloadLoggingService(nil, true, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("PayloadLoggingService is instantiated", func() {
+		When("Redact is called", func() {
+			It("should replace the values of the configured fields in the copy of the message", func() {
+				response := &userGRPCContract.ReadUserResponse{
+					User: &userGRPCContract.User{
+						DataResidency: "eu",
+						Labels:        map[string]string{"plan": "pro"},
+						CreatedBy:     email,
+					},
+				}
+
+				redacted := sut.Redact(response).(*userGRPCContract.ReadUserResponse)
+				Ω(redacted.User.DataResidency).Should(Equal("eu"))
+				Ω(redacted.User.Labels).Should(Equal(map[string]string{"plan": payloadlogging.RedactedValue}))
+				Ω(redacted.User.CreatedBy).Should(Equal(payloadlogging.RedactedValue))
+
+				Ω(response.User.Labels).Should(Equal(map[string]string{"plan": "pro"}))
+				Ω(response.User.CreatedBy).Should(Equal(email))
+			})
+
+			It("should replace the email addresses in the fields that are not configured", func() {
+				response := &userGRPCContract.ReadUserResponse{ErrorMessage: "user not found: " + email}
+
+				redacted := sut.Redact(response).(*userGRPCContract.ReadUserResponse)
+				Ω(redacted.ErrorMessage).Should(Equal("user not found: " + payloadlogging.RedactedValue))
+			})
+
+			It("should replace the configured fields of the messages in the lists", func() {
+				response := &userGRPCContract.SearchResponse{
+					Users: []*userGRPCContract.UserWithCursor{{Email: email, Cursor: "cursor"}},
+				}
+
+				redacted := sut.Redact(response).(*userGRPCContract.SearchResponse)
+				Ω(redacted.Users[0].Email).Should(Equal(payloadlogging.RedactedValue))
+				Ω(redacted.Users[0].Cursor).Should(Equal("cursor"))
+			})
+		})
+
+		When("the unary interceptor intercepts a call", func() {
+			It("should log the redacted request and response at the debug level", func() {
+				request := &userGRPCContract.ReadUserRequest{Email: email}
+				expectedResponse := &userGRPCContract.ReadUserResponse{User: &userGRPCContract.User{DataResidency: "eu"}}
+
+				response, err := sut.CreateUnaryServerInterceptor()(
+					context.Background(),
+					request,
+					info,
+					func(ctx context.Context, request interface{}) (interface{}, error) {
+						return expectedResponse, nil
+					})
+				Ω(err).Should(BeNil())
+				Ω(response).Should(Equal(expectedResponse))
+
+				entries := logs.FilterLevelExact(zapcore.DebugLevel).All()
+				Ω(entries).Should(HaveLen(2))
+				Ω(entries[0].Message).Should(Equal("gRPC request payload"))
+				Ω(entries[0].ContextMap()["method"]).Should(Equal(info.FullMethod))
+				Ω(entries[0].ContextMap()["payload"]).Should(ContainSubstring(payloadlogging.RedactedValue))
+				Ω(entries[0].ContextMap()["payload"]).ShouldNot(ContainSubstring(email))
+				Ω(entries[1].Message).Should(Equal("gRPC response payload"))
+				Ω(entries[1].ContextMap()["payload"]).Should(ContainSubstring("eu"))
+				Ω(proto.Equal(request, &userGRPCContract.ReadUserRequest{Email: email})).Should(BeTrue())
+			})
+		})
+
+		When("the unary interceptor intercepts a call and the logger does not log at the debug level", func() {
+			It("should not log the payloads", func() {
+				var core zapcore.Core
+				core, logs = observer.New(zapcore.InfoLevel)
+				service, _ := payloadlogging.NewPayloadLoggingService(zap.New(core), true, nil)
+
+				_, err := service.CreateUnaryServerInterceptor()(
+					context.Background(),
+					&userGRPCContract.ReadUserRequest{Email: email},
+					info,
+					func(ctx context.Context, request interface{}) (interface{}, error) {
+						return &userGRPCContract.ReadUserResponse{}, nil
+					})
+				Ω(err).Should(BeNil())
+				Ω(logs.Len()).Should(Equal(0))
+			})
+		})
+
+		When("the unary interceptor intercepts a call and the payload logging is disabled", func() {
+			It("should not log the payloads", func() {
+				service, _ := payloadlogging.NewPayloadLoggingService(logger, false, nil)
+
+				_, err := service.CreateUnaryServerInterceptor()(
+					context.Background(),
+					&userGRPCContract.ReadUserRequest{Email: email},
+					info,
+					func(ctx context.Context, request interface{}) (interface{}, error) {
+						return &userGRPCContract.ReadUserResponse{}, nil
+					})
+				Ω(err).Should(BeNil())
+				Ω(logs.Len()).Should(Equal(0))
+			})
+		})
+	})
+})
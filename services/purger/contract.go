@@ -0,0 +1,14 @@
+// Package purger implements the background service that permanently deletes the soft deleted users
+package purger
+
+// PurgerContract declares the service that periodically and permanently deletes the soft deleted users
+// that passed the retention period.
+type PurgerContract interface {
+	// Start the purger service. Blocks until the service is stopped.
+	// Returns error if something goes wrong.
+	Start() error
+
+	// Stop the purger service, cancelling the in-flight purge if there is any.
+	// Returns error if something goes wrong.
+	Stop() error
+}
@@ -0,0 +1,127 @@
+// Package purger implements the background service that permanently deletes the soft deleted users
+package purger
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+)
+
+type purgerService struct {
+	logger            *zap.Logger
+	repositoryService repository.RepositoryContract
+	clockService      clock.ClockContract
+	retentionPeriod   time.Duration
+	purgeInterval     time.Duration
+	ctx               context.Context
+	cancel            context.CancelFunc
+}
+
+// NewPurgerService creates new instance of the purgerService, setting up all dependencies and returns the instance
+// logger: Mandatory. Reference to the logger service
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// repositoryService: Mandatory. Reference to the repository service that persists the users
+// clockService: Mandatory. Reference to the service that provides the current time
+// Returns the new service or error if something goes wrong
+func NewPurgerService(
+	logger *zap.Logger,
+	configurationService configuration.ConfigurationContract,
+	repositoryService repository.RepositoryContract,
+	clockService clock.ClockContract) (PurgerContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if repositoryService == nil {
+		return nil, commonErrors.NewArgumentNilError("repositoryService", "repositoryService is required")
+	}
+
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	retentionPeriod, err := configurationService.GetSoftDeleteRetentionPeriod()
+	if err != nil {
+		return nil, err
+	}
+
+	purgeInterval, err := configurationService.GetSoftDeletePurgeInterval()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &purgerService{
+		logger:            logger,
+		repositoryService: repositoryService,
+		clockService:      clockService,
+		retentionPeriod:   retentionPeriod,
+		purgeInterval:     purgeInterval,
+		ctx:               ctx,
+		cancel:            cancel,
+	}, nil
+}
+
+// Start the purger service. The users are purged right away and then once every purge interval until the
+// service is stopped. The purger runs even if soft delete is disabled, so the users soft deleted before it
+// got disabled are still purged once they pass the retention period.
+// Returns error if something goes wrong.
+func (service *purgerService) Start() error {
+	service.logger.Info(
+		"Purger service started",
+		zap.Duration("retentionPeriod", service.retentionPeriod),
+		zap.Duration("purgeInterval", service.purgeInterval))
+
+	ticker := time.NewTicker(service.purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		service.purge()
+
+		select {
+		case <-service.ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop the purger service, cancelling the in-flight purge if there is any.
+// Returns error if something goes wrong.
+func (service *purgerService) Stop() error {
+	service.cancel()
+
+	return nil
+}
+
+func (service *purgerService) purge() {
+	deletedBefore := service.clockService.Now().Add(-service.retentionPeriod)
+	response, err := service.repositoryService.PurgeDeletedUsers(service.ctx, &repository.PurgeDeletedUsersRequest{
+		DeletedBefore: deletedBefore,
+	})
+	if err != nil {
+		// Stopping the service cancels the in-flight purge, which is not worth reporting
+		if service.ctx.Err() == nil {
+			service.logger.Error("failed to purge the soft deleted users", zap.Error(err))
+		}
+
+		return
+	}
+
+	if response.PurgedCount > 0 {
+		service.logger.Info(
+			"Purged the soft deleted users",
+			zap.Int64("purgedCount", response.PurgedCount),
+			zap.Time("deletedBefore", deletedBefore))
+	}
+}
@@ -0,0 +1,169 @@
+package purger_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/purger"
+	"github.com/decentralized-cloud/user/services/repository"
+	repositoryMock "github.com/decentralized-cloud/user/services/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestPurgerService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Purger Service Tests")
+}
+
+var _ = Describe("Purger Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      purger.PurgerContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		mockRepositoryService    *repositoryMock.MockRepositoryContract
+		mockClockService         *clockMock.MockClockContract
+		now                      time.Time
+		retentionPeriod          time.Duration
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		retentionPeriod = 24 * time.Hour
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetSoftDeleteRetentionPeriod().
+			Return(retentionPeriod, nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetSoftDeletePurgeInterval().
+			Return(10*time.Millisecond, nil).
+			AnyTimes()
+
+		now = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			Return(now).
+			AnyTimes()
+
+		mockRepositoryService = repositoryMock.NewMockRepositoryContract(mockCtrl)
+		sut, _ = purger.NewPurgerService(zap.NewNop(), mockConfigurationService, mockRepositoryService, mockClockService)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate PurgerService", func() {
+		When("logger is not provided and NewPurgerService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := purger.NewPurgerService(nil, mockConfigurationService, mockRepositoryService, mockClockService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("configuration service is not provided and NewPurgerService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := purger.NewPurgerService(zap.NewNop(), nil, mockRepositoryService, mockClockService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("repository service is not provided and NewPurgerService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := purger.NewPurgerService(zap.NewNop(), mockConfigurationService, nil, mockClockService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("clock service is not provided and NewPurgerService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := purger.NewPurgerService(zap.NewNop(), mockConfigurationService, mockRepositoryService, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("all dependencies are resolved and NewPurgerService is called", func() {
+			It("should instantiate the new PurgerService", func() {
+				service, err := purger.NewPurgerService(zap.NewNop(), mockConfigurationService, mockRepositoryService, mockClockService)
+				Ω(err).Should(BeNil())
+				Ω(service).ShouldNot(BeNil())
+			})
+		})
+	})
+
+	Context("purger service is started", func() {
+		When("the purge interval passes", func() {
+			It("should purge the users deleted before the retention period until it is stopped", func() {
+				purged := make(chan time.Time, 100)
+				mockRepositoryService.
+					EXPECT().
+					PurgeDeletedUsers(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, request *repository.PurgeDeletedUsersRequest) (*repository.PurgeDeletedUsersResponse, error) {
+						purged <- request.DeletedBefore
+
+						return &repository.PurgeDeletedUsersResponse{PurgedCount: 1}, nil
+					}).
+					MinTimes(2)
+
+				stopped := make(chan error)
+				go func() {
+					stopped <- sut.Start()
+				}()
+
+				var deletedBefore time.Time
+				Eventually(purged).Should(Receive(&deletedBefore))
+				Ω(deletedBefore).Should(Equal(now.Add(-retentionPeriod)))
+				Eventually(purged).Should(Receive())
+
+				Ω(sut.Stop()).Should(Succeed())
+				Eventually(stopped).Should(Receive(BeNil()))
+			})
+		})
+
+		When("repository service fails to purge the users", func() {
+			It("should keep purging until it is stopped", func() {
+				purged := make(chan struct{}, 100)
+				mockRepositoryService.
+					EXPECT().
+					PurgeDeletedUsers(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *repository.PurgeDeletedUsersRequest) (*repository.PurgeDeletedUsersResponse, error) {
+						purged <- struct{}{}
+
+						return nil, errors.New(cuid.New())
+					}).
+					MinTimes(2)
+
+				stopped := make(chan error)
+				go func() {
+					stopped <- sut.Start()
+				}()
+
+				Eventually(purged).Should(Receive())
+				Eventually(purged).Should(Receive())
+
+				Ω(sut.Stop()).Should(Succeed())
+				Eventually(stopped).Should(Receive(BeNil()))
+			})
+		})
+	})
+})
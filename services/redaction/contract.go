@@ -0,0 +1,29 @@
+// Package redaction implements the removal of the sensitive user fields from the responses sent to the callers that
+// are neither the owner of the user nor an admin, so every operation returning users is filtered the same way
+package redaction
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// RedactionContract declares the service that removes the sensitive user fields from the responses
+type RedactionContract interface {
+	// Redact clears the fields listed in the policy from every user in the response the caller is not allowed to see
+	// them for. The owner of a user is the email address of the closest message containing the user, or the given
+	// owner email address if none of them has one.
+	// caller: Optional. The authenticated caller the response is sent to, every user is redacted if not provided
+	// ownerEmail: Optional. The email address of the owner of the users the response does not state the owner of
+	// response: Mandatory. The response message to redact in place
+	Redact(caller *Caller, ownerEmail string, response proto.Message)
+
+	// CreateUnaryServerInterceptor creates the interceptor that redacts the responses of the unary calls based on the
+	// caller the authorization records with SetCaller and the email address in the request
+	// Returns the new interceptor
+	CreateUnaryServerInterceptor() grpc.UnaryServerInterceptor
+
+	// CreateStreamServerInterceptor creates the interceptor that redacts every message the streaming calls send based
+	// on the caller the authorization records with SetCaller and the email address in the request
+	// Returns the new interceptor
+	CreateStreamServerInterceptor() grpc.StreamServerInterceptor
+}
@@ -0,0 +1,214 @@
+// Package redaction implements the removal of the sensitive user fields from the responses sent to the callers that
+// are neither the owner of the user nor an admin, so every operation returning users is filtered the same way
+package redaction
+
+import (
+	"context"
+	"fmt"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// emailFieldName is the field the messages containing a user state the email address of the user in
+const emailFieldName = "email"
+
+type callerContextKey struct{}
+
+// Caller contains the authenticated caller the response is redacted for
+type Caller struct {
+	Email string
+	Admin bool
+}
+
+// callerHolder is attached to the context by the interceptors before the call is handled, so the authorization can
+// record the caller it authenticated for the interceptors to read once the response is ready
+type callerHolder struct {
+	caller *Caller
+}
+
+type redactionService struct {
+	userDescriptor protoreflect.MessageDescriptor
+	fields         []protoreflect.FieldDescriptor
+}
+
+// NewRedactionService creates new instance of the redactionService, setting up all dependencies and returns the instance
+// fieldNames: Optional. The names of the user fields to redact as they are named in the proto files
+// Returns the new service or error if something goes wrong
+func NewRedactionService(fieldNames []string) (RedactionContract, error) {
+	userDescriptor := (&userGRPCContract.User{}).ProtoReflect().Descriptor()
+	fields := make([]protoreflect.FieldDescriptor, 0, len(fieldNames))
+
+	for _, fieldName := range fieldNames {
+		field := userDescriptor.Fields().ByName(protoreflect.Name(fieldName))
+		if field == nil {
+			return nil, commonErrors.NewArgumentError(
+				"fieldNames",
+				fmt.Sprintf("%s is not a field of %s", fieldName, userDescriptor.FullName()))
+		}
+
+		fields = append(fields, field)
+	}
+
+	return &redactionService{
+		userDescriptor: userDescriptor,
+		fields:         fields,
+	}, nil
+}
+
+// SetCaller records the authenticated caller of the call, so the response is redacted for it. The call is treated as
+// made by an unknown caller if it is never recorded, and SetCaller does nothing if the call is not intercepted.
+// ctx: Mandatory. The context of the call
+// caller: Mandatory. The authenticated caller
+func SetCaller(ctx context.Context, caller Caller) {
+	if holder, ok := ctx.Value(callerContextKey{}).(*callerHolder); ok {
+		holder.caller = &caller
+	}
+}
+
+// Redact clears the fields listed in the policy from every user in the response the caller is not allowed to see
+// them for. The owner of a user is the email address of the closest message containing the user, or the given
+// owner email address if none of them has one.
+// caller: Optional. The authenticated caller the response is sent to, every user is redacted if not provided
+// ownerEmail: Optional. The email address of the owner of the users the response does not state the owner of
+// response: Mandatory. The response message to redact in place
+func (service *redactionService) Redact(caller *Caller, ownerEmail string, response proto.Message) {
+	if len(service.fields) == 0 || (caller != nil && caller.Admin) || response == nil || !response.ProtoReflect().IsValid() {
+		return
+	}
+
+	service.redactMessage(caller, ownerEmail, response.ProtoReflect())
+}
+
+// CreateUnaryServerInterceptor creates the interceptor that redacts the responses of the unary calls based on the
+// caller the authorization records with SetCaller and the email address in the request
+// Returns the new interceptor
+func (service *redactionService) CreateUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		request interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		holder := &callerHolder{}
+		response, err := handler(context.WithValue(ctx, callerContextKey{}, holder), request)
+
+		if responseMessage, ok := response.(proto.Message); ok && err == nil {
+			service.Redact(holder.caller, getRequestEmail(request), responseMessage)
+		}
+
+		return response, err
+	}
+}
+
+// CreateStreamServerInterceptor creates the interceptor that redacts every message the streaming calls send based
+// on the caller the authorization records with SetCaller and the email address in the request
+// Returns the new interceptor
+func (service *redactionService) CreateStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		server interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		holder := &callerHolder{}
+
+		return handler(server, &redactingServerStream{
+			ServerStream: stream,
+			ctx:          context.WithValue(stream.Context(), callerContextKey{}, holder),
+			holder:       holder,
+			service:      service,
+		})
+	}
+}
+
+func (service *redactionService) redactMessage(caller *Caller, ownerEmail string, message protoreflect.Message) {
+	if field := message.Descriptor().Fields().ByName(emailFieldName); field != nil &&
+		field.Kind() == protoreflect.StringKind && !field.IsList() && message.Has(field) {
+		ownerEmail = message.Get(field).String()
+	}
+
+	if message.Descriptor().FullName() == service.userDescriptor.FullName() {
+		if caller == nil || caller.Email == "" || caller.Email != ownerEmail {
+			for _, field := range service.fields {
+				message.Clear(field)
+			}
+		}
+
+		return
+	}
+
+	// Range only visits the populated fields, so only the users the response actually contains are visited
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if field.IsMap() {
+			if field.MapValue().Message() != nil {
+				value.Map().Range(func(_ protoreflect.MapKey, mapValue protoreflect.Value) bool {
+					service.redactMessage(caller, ownerEmail, mapValue.Message())
+
+					return true
+				})
+			}
+
+			return true
+		}
+
+		if field.Message() == nil {
+			return true
+		}
+
+		if field.IsList() {
+			for index := 0; index < value.List().Len(); index++ {
+				service.redactMessage(caller, ownerEmail, value.List().Get(index).Message())
+			}
+		} else {
+			service.redactMessage(caller, ownerEmail, value.Message())
+		}
+
+		return true
+	})
+}
+
+// redactingServerStream redacts the messages the streaming call sends, the request is received before the handler
+// sends anything, so the owner email address is known by then
+type redactingServerStream struct {
+	grpc.ServerStream
+	ctx          context.Context
+	holder       *callerHolder
+	service      *redactionService
+	requestEmail string
+}
+
+// Context returns the context the caller is recorded in
+func (stream *redactingServerStream) Context() context.Context {
+	return stream.ctx
+}
+
+// RecvMsg receives the request and keeps its email address as the owner of the users the responses do not state the
+// owner of
+func (stream *redactingServerStream) RecvMsg(message interface{}) error {
+	if err := stream.ServerStream.RecvMsg(message); err != nil {
+		return err
+	}
+
+	stream.requestEmail = getRequestEmail(message)
+
+	return nil
+}
+
+// SendMsg redacts the message before it is sent
+func (stream *redactingServerStream) SendMsg(message interface{}) error {
+	if responseMessage, ok := message.(proto.Message); ok {
+		stream.service.Redact(stream.holder.caller, stream.requestEmail, responseMessage)
+	}
+
+	return stream.ServerStream.SendMsg(message)
+}
+
+func getRequestEmail(request interface{}) string {
+	if requestWithEmail, ok := request.(interface{ GetEmail() string }); ok {
+		return requestWithEmail.GetEmail()
+	}
+
+	return ""
+}
@@ -0,0 +1,169 @@
+package redaction_test
+
+import (
+	"context"
+	"testing"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/services/redaction"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestRedactionService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Redaction Service Tests")
+}
+
+var _ = Describe("Redaction Service Tests", func() {
+	var (
+		sut      redaction.RedactionContract
+		email    string
+		tenantID string
+	)
+
+	newUser := func() *userGRPCContract.User {
+		return &userGRPCContract.User{
+			Memberships:   []*userGRPCContract.TenantMembership{{TenantID: tenantID, Role: "owner"}},
+			DataResidency: "eu",
+			EmailVerified: true,
+		}
+	}
+
+	redactedUser := &userGRPCContract.User{DataResidency: "eu"}
+
+	BeforeEach(func() {
+		email = cuid.New() + "@test.com"
+		tenantID = cuid.New()
+
+		var err error
+		sut, err = redaction.NewRedactionService([]string{"memberships", "emailVerified"})
+		Ω(err).Should(BeNil())
+	})
+
+	Context("user tries to instantiate RedactionService", func() {
+		When("a field that the user does not have is provided and NewRedactionService is called", func() {
+			It("should return ArgumentError", func() {
+				service, err := redaction.NewRedactionService([]string{"memberships", cuid.New()})
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+			})
+		})
+
+		When("no field is provided and NewRedactionService is called", func() {
+			It("should instantiate the new RedactionService that redacts nothing", func() {
+				service, err := redaction.NewRedactionService(nil)
+				Ω(err).Should(BeNil())
+
+				response := &userGRPCContract.ReadUserResponse{User: newUser()}
+				service.Redact(nil, email, response)
+				Ω(proto.Equal(response.User, newUser())).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("RedactionService is instantiated", func() {
+		When("Redact is called for the owner of the user", func() {
+			It("should leave the user as it is", func() {
+				response := &userGRPCContract.ReadUserResponse{User: newUser()}
+				sut.Redact(&redaction.Caller{Email: email}, email, response)
+				Ω(proto.Equal(response.User, newUser())).Should(BeTrue())
+			})
+		})
+
+		When("Redact is called for an admin", func() {
+			It("should leave the user as it is", func() {
+				response := &userGRPCContract.ReadUserResponse{User: newUser()}
+				sut.Redact(&redaction.Caller{Email: cuid.New() + "@test.com", Admin: true}, email, response)
+				Ω(proto.Equal(response.User, newUser())).Should(BeTrue())
+			})
+		})
+
+		When("Redact is called for a caller that is neither the owner nor an admin", func() {
+			It("should clear the fields in the policy", func() {
+				response := &userGRPCContract.ReadUserResponse{User: newUser()}
+				sut.Redact(&redaction.Caller{Email: cuid.New() + "@test.com"}, email, response)
+				Ω(proto.Equal(response.User, redactedUser)).Should(BeTrue())
+			})
+		})
+
+		When("Redact is called without the caller", func() {
+			It("should clear the fields in the policy", func() {
+				response := &userGRPCContract.ReadUserResponse{User: newUser()}
+				sut.Redact(nil, email, response)
+				Ω(proto.Equal(response.User, redactedUser)).Should(BeTrue())
+			})
+		})
+
+		When("Redact is called for a response that states the owner of every user", func() {
+			It("should only clear the fields of the users the caller does not own", func() {
+				response := &userGRPCContract.SearchResponse{
+					Users: []*userGRPCContract.UserWithCursor{
+						{Email: email, User: newUser()},
+						{Email: cuid.New() + "@test.com", User: newUser()},
+					},
+				}
+
+				sut.Redact(&redaction.Caller{Email: email}, "", response)
+				Ω(proto.Equal(response.Users[0].User, newUser())).Should(BeTrue())
+				Ω(proto.Equal(response.Users[1].User, redactedUser)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("the unary server interceptor is created", func() {
+		var (
+			interceptor grpc.UnaryServerInterceptor
+			info        *grpc.UnaryServerInfo
+			request     *userGRPCContract.ReadUserRequest
+		)
+
+		BeforeEach(func() {
+			interceptor = sut.CreateUnaryServerInterceptor()
+			info = &grpc.UnaryServerInfo{FullMethod: "/user.Service/ReadUser"}
+			request = &userGRPCContract.ReadUserRequest{Email: email}
+		})
+
+		When("the owner of the user in the request calls the operation", func() {
+			It("should leave the response as it is", func() {
+				response, err := interceptor(context.Background(), request, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+					redaction.SetCaller(ctx, redaction.Caller{Email: email})
+
+					return &userGRPCContract.ReadUserResponse{User: newUser()}, nil
+				})
+
+				Ω(err).Should(BeNil())
+				Ω(proto.Equal(response.(*userGRPCContract.ReadUserResponse).User, newUser())).Should(BeTrue())
+			})
+		})
+
+		When("a caller that is not the owner of the user in the request calls the operation", func() {
+			It("should redact the response", func() {
+				response, err := interceptor(context.Background(), request, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+					redaction.SetCaller(ctx, redaction.Caller{Email: cuid.New() + "@test.com"})
+
+					return &userGRPCContract.ReadUserResponse{User: newUser()}, nil
+				})
+
+				Ω(err).Should(BeNil())
+				Ω(proto.Equal(response.(*userGRPCContract.ReadUserResponse).User, redactedUser)).Should(BeTrue())
+			})
+		})
+
+		When("the caller is never recorded", func() {
+			It("should redact the response", func() {
+				response, err := interceptor(context.Background(), request, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+					return &userGRPCContract.ReadUserResponse{User: newUser()}, nil
+				})
+
+				Ω(err).Should(BeNil())
+				Ω(proto.Equal(response.(*userGRPCContract.ReadUserResponse).User, redactedUser)).Should(BeTrue())
+			})
+		})
+	})
+})
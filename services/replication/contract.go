@@ -0,0 +1,43 @@
+// Package replication implements the service that measures how far behind the primary database the standby database is
+package replication
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// ReplicationContract declares the service that periodically writes the replication heartbeat to the primary database
+// and reports how far behind the primary database the standby database is, so the readiness to fail over to the
+// standby database can be measured.
+type ReplicationContract interface {
+	// Start the replication service. Blocks until the service is stopped.
+	// Returns error if something goes wrong.
+	Start() error
+
+	// Stop the replication service.
+	// Returns error if something goes wrong.
+	Stop() error
+
+	// GetReplicationStatus reads the latest heartbeats of the primary and the standby databases and compares them
+	// ctx: Mandatory The reference to the context
+	// Returns either the replication status or error if something goes wrong.
+	GetReplicationStatus(ctx context.Context) (*models.ReplicationStatus, error)
+}
+
+// HeartbeatStoreContract declares the service that writes and reads the replication heartbeat of a database
+type HeartbeatStoreContract interface {
+	// WriteHeartbeat increments the sequence of the replication heartbeat
+	// ctx: Mandatory The reference to the context
+	// writtenAt: Mandatory. The time the heartbeat is written at
+	// Returns either the written heartbeat or error if something goes wrong.
+	WriteHeartbeat(
+		ctx context.Context,
+		writtenAt time.Time) (*models.ReplicationHeartbeat, error)
+
+	// ReadHeartbeat reads the latest replication heartbeat
+	// ctx: Mandatory The reference to the context
+	// Returns either the latest heartbeat or NotFoundError if no heartbeat is written yet.
+	ReadHeartbeat(ctx context.Context) (*models.ReplicationHeartbeat, error)
+}
@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/replication/contract.go
+
+// Package mock_replication is a generated GoMock package.
+package mock_replication
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/decentralized-cloud/user/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReplicationContract is a mock of ReplicationContract interface.
+type MockReplicationContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockReplicationContractMockRecorder
+}
+
+// MockReplicationContractMockRecorder is the mock recorder for MockReplicationContract.
+type MockReplicationContractMockRecorder struct {
+	mock *MockReplicationContract
+}
+
+// NewMockReplicationContract creates a new mock instance.
+func NewMockReplicationContract(ctrl *gomock.Controller) *MockReplicationContract {
+	mock := &MockReplicationContract{ctrl: ctrl}
+	mock.recorder = &MockReplicationContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReplicationContract) EXPECT() *MockReplicationContractMockRecorder {
+	return m.recorder
+}
+
+// GetReplicationStatus mocks base method.
+func (m *MockReplicationContract) GetReplicationStatus(ctx context.Context) (*models.ReplicationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplicationStatus", ctx)
+	ret0, _ := ret[0].(*models.ReplicationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplicationStatus indicates an expected call of GetReplicationStatus.
+func (mr *MockReplicationContractMockRecorder) GetReplicationStatus(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicationStatus", reflect.TypeOf((*MockReplicationContract)(nil).GetReplicationStatus), ctx)
+}
+
+// Start mocks base method.
+func (m *MockReplicationContract) Start() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockReplicationContractMockRecorder) Start() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockReplicationContract)(nil).Start))
+}
+
+// Stop mocks base method.
+func (m *MockReplicationContract) Stop() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockReplicationContractMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockReplicationContract)(nil).Stop))
+}
+
+// MockHeartbeatStoreContract is a mock of HeartbeatStoreContract interface.
+type MockHeartbeatStoreContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockHeartbeatStoreContractMockRecorder
+}
+
+// MockHeartbeatStoreContractMockRecorder is the mock recorder for MockHeartbeatStoreContract.
+type MockHeartbeatStoreContractMockRecorder struct {
+	mock *MockHeartbeatStoreContract
+}
+
+// NewMockHeartbeatStoreContract creates a new mock instance.
+func NewMockHeartbeatStoreContract(ctrl *gomock.Controller) *MockHeartbeatStoreContract {
+	mock := &MockHeartbeatStoreContract{ctrl: ctrl}
+	mock.recorder = &MockHeartbeatStoreContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHeartbeatStoreContract) EXPECT() *MockHeartbeatStoreContractMockRecorder {
+	return m.recorder
+}
+
+// ReadHeartbeat mocks base method.
+func (m *MockHeartbeatStoreContract) ReadHeartbeat(ctx context.Context) (*models.ReplicationHeartbeat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadHeartbeat", ctx)
+	ret0, _ := ret[0].(*models.ReplicationHeartbeat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadHeartbeat indicates an expected call of ReadHeartbeat.
+func (mr *MockHeartbeatStoreContractMockRecorder) ReadHeartbeat(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadHeartbeat", reflect.TypeOf((*MockHeartbeatStoreContract)(nil).ReadHeartbeat), ctx)
+}
+
+// WriteHeartbeat mocks base method.
+func (m *MockHeartbeatStoreContract) WriteHeartbeat(ctx context.Context, writtenAt time.Time) (*models.ReplicationHeartbeat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteHeartbeat", ctx, writtenAt)
+	ret0, _ := ret[0].(*models.ReplicationHeartbeat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteHeartbeat indicates an expected call of WriteHeartbeat.
+func (mr *MockHeartbeatStoreContractMockRecorder) WriteHeartbeat(ctx, writtenAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteHeartbeat", reflect.TypeOf((*MockHeartbeatStoreContract)(nil).WriteHeartbeat), ctx, writtenAt)
+}
@@ -0,0 +1,139 @@
+// Package mongodb implements the MongoDB store of the replication heartbeat
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/replication"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// heartbeatID is the identifier of the single document the heartbeat is written to
+const heartbeatID = "heartbeat"
+
+type heartbeat struct {
+	ID        string    `bson:"_id"`
+	Sequence  int64     `bson:"sequence"`
+	WrittenAt time.Time `bson:"writtenAt"`
+}
+
+type mongodbHeartbeatStoreService struct {
+	connectionString string
+	databaseName     string
+	collectionName   string
+}
+
+// NewMongodbHeartbeatStoreService creates new instance of the mongodbHeartbeatStoreService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// connectionString: Mandatory. The connection string of the database the heartbeat is written to or read from
+// Returns the new service or error if something goes wrong
+func NewMongodbHeartbeatStoreService(
+	configurationService configuration.ConfigurationContract,
+	connectionString string) (replication.HeartbeatStoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if connectionString == "" {
+		return nil, commonErrors.NewArgumentError("connectionString", "connectionString is required")
+	}
+
+	databaseName, err := configurationService.GetDatabaseName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database name", err)
+	}
+
+	collectionName, err := configurationService.GetReplicationHeartbeatCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the replication heartbeat collection name", err)
+	}
+
+	return &mongodbHeartbeatStoreService{
+		connectionString: connectionString,
+		databaseName:     databaseName,
+		collectionName:   collectionName,
+	}, nil
+}
+
+// WriteHeartbeat increments the sequence of the replication heartbeat
+// ctx: Mandatory The reference to the context
+// writtenAt: Mandatory. The time the heartbeat is written at
+// Returns either the written heartbeat or error if something goes wrong.
+func (service *mongodbHeartbeatStoreService) WriteHeartbeat(
+	ctx context.Context,
+	writtenAt time.Time) (*models.ReplicationHeartbeat, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(ctx, client)
+
+	update := bson.M{
+		"$inc": bson.M{"sequence": 1},
+		"$set": bson.M{"writtenAt": writtenAt},
+	}
+
+	var written heartbeat
+
+	err = collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": heartbeatID},
+		update,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)).Decode(&written)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to write the replication heartbeat", err)
+	}
+
+	return mapHeartbeat(written), nil
+}
+
+// ReadHeartbeat reads the latest replication heartbeat
+// ctx: Mandatory The reference to the context
+// Returns either the latest heartbeat or NotFoundError if no heartbeat is written yet.
+func (service *mongodbHeartbeatStoreService) ReadHeartbeat(ctx context.Context) (*models.ReplicationHeartbeat, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(ctx, client)
+
+	var latest heartbeat
+
+	err = collection.FindOne(ctx, bson.M{"_id": heartbeatID}).Decode(&latest)
+	if err == mongo.ErrNoDocuments {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read the replication heartbeat", err)
+	}
+
+	return mapHeartbeat(latest), nil
+}
+
+func (service *mongodbHeartbeatStoreService) createClientAndCollection(ctx context.Context) (*mongo.Client, *mongo.Collection, error) {
+	clientOptions := options.Client().ApplyURI(service.connectionString)
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("could not connect to mongodb database", err)
+	}
+
+	return client, client.Database(service.databaseName).Collection(service.collectionName), nil
+}
+
+func mapHeartbeat(heartbeat heartbeat) *models.ReplicationHeartbeat {
+	return &models.ReplicationHeartbeat{
+		Sequence:  heartbeat.Sequence,
+		WrittenAt: heartbeat.WrittenAt,
+	}
+}
+
+func disconnect(ctx context.Context, client *mongo.Client) {
+	_ = client.Disconnect(ctx)
+}
@@ -0,0 +1,37 @@
+// Package noop implements the replication service used when there is no standby database
+package noop
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/replication"
+)
+
+type noopReplicationService struct {
+}
+
+// NewNoopReplicationService creates new instance of the noopReplicationService, setting up all dependencies and returns the instance
+// Returns the new service or error if something goes wrong
+func NewNoopReplicationService() (replication.ReplicationContract, error) {
+	return &noopReplicationService{}, nil
+}
+
+// Start returns right away as there is no heartbeat to write
+// Returns error if something goes wrong.
+func (service *noopReplicationService) Start() error {
+	return nil
+}
+
+// Stop returns right away as there is nothing to stop
+// Returns error if something goes wrong.
+func (service *noopReplicationService) Stop() error {
+	return nil
+}
+
+// GetReplicationStatus reports the standby database is not configured
+// ctx: Mandatory The reference to the context
+// Returns either the replication status or error if something goes wrong.
+func (service *noopReplicationService) GetReplicationStatus(ctx context.Context) (*models.ReplicationStatus, error) {
+	return &models.ReplicationStatus{}, nil
+}
@@ -0,0 +1,122 @@
+// Package postgres implements the PostgreSQL store of the replication heartbeat
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/replication"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// heartbeatID is the identifier of the single row the heartbeat is written to
+const heartbeatID = 1
+
+type postgresHeartbeatStoreService struct {
+	pool      *pgxpool.Pool
+	tableName string
+}
+
+// NewPostgresHeartbeatStoreService creates new instance of the postgresHeartbeatStoreService, setting up all
+// dependencies, creating the heartbeat table if it does not exist yet and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// connectionString: Mandatory. The connection string of the database the heartbeat is written to or read from
+// readOnly: Mandatory. Whether the database is a read only standby, the heartbeat table is only created if it is not,
+// as the table is received from the primary database through the replication
+// Returns the new service or error if something goes wrong
+func NewPostgresHeartbeatStoreService(
+	configurationService configuration.ConfigurationContract,
+	connectionString string,
+	readOnly bool) (replication.HeartbeatStoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if connectionString == "" {
+		return nil, commonErrors.NewArgumentError("connectionString", "connectionString is required")
+	}
+
+	// The replication heartbeat collection name is used as the name of the table the heartbeat is written to
+	tableName, err := configurationService.GetReplicationHeartbeatCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the replication heartbeat table name", err)
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.Connect(ctx, connectionString)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("could not connect to postgres database", err)
+	}
+
+	service := &postgresHeartbeatStoreService{
+		pool:      pool,
+		tableName: tableName,
+	}
+
+	if readOnly {
+		return service, nil
+	}
+
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (id INTEGER PRIMARY KEY, sequence BIGINT NOT NULL, written_at TIMESTAMPTZ NOT NULL)",
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the replication heartbeat table", err)
+	}
+
+	return service, nil
+}
+
+// WriteHeartbeat increments the sequence of the replication heartbeat
+// ctx: Mandatory The reference to the context
+// writtenAt: Mandatory. The time the heartbeat is written at
+// Returns either the written heartbeat or error if something goes wrong.
+func (service *postgresHeartbeatStoreService) WriteHeartbeat(
+	ctx context.Context,
+	writtenAt time.Time) (*models.ReplicationHeartbeat, error) {
+	var heartbeat models.ReplicationHeartbeat
+
+	err := service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf(
+			`INSERT INTO %[1]s (id, sequence, written_at) VALUES ($1, 1, $2)
+			ON CONFLICT (id) DO UPDATE SET sequence = %[1]s.sequence + 1, written_at = EXCLUDED.written_at
+			RETURNING sequence, written_at`,
+			service.table()),
+		heartbeatID,
+		writtenAt).Scan(&heartbeat.Sequence, &heartbeat.WrittenAt)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to write the replication heartbeat", err)
+	}
+
+	return &heartbeat, nil
+}
+
+// ReadHeartbeat reads the latest replication heartbeat
+// ctx: Mandatory The reference to the context
+// Returns either the latest heartbeat or NotFoundError if no heartbeat is written yet.
+func (service *postgresHeartbeatStoreService) ReadHeartbeat(ctx context.Context) (*models.ReplicationHeartbeat, error) {
+	var heartbeat models.ReplicationHeartbeat
+
+	err := service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf("SELECT sequence, written_at FROM %s WHERE id = $1", service.table()),
+		heartbeatID).Scan(&heartbeat.Sequence, &heartbeat.WrittenAt)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read the replication heartbeat", err)
+	}
+
+	return &heartbeat, nil
+}
+
+func (service *postgresHeartbeatStoreService) table() string {
+	return pgx.Identifier{service.tableName}.Sanitize()
+}
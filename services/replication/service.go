@@ -0,0 +1,206 @@
+// Package replication implements the service that measures how far behind the primary database the standby database is
+package replication
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var (
+	lagGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "user_replication_lag_seconds",
+		Help: "How long ago the latest replication heartbeat the standby database received was written, zero if it is up to date",
+	})
+
+	lagChangesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "user_replication_lag_changes",
+		Help: "The number of the replication heartbeats the standby database has not received yet",
+	})
+
+	failoverReadyGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "user_replication_failover_ready",
+		Help: "Whether the standby database is reachable and not further behind than the maximum lag, 1 if it is ready to fail over to",
+	})
+)
+
+type replicationService struct {
+	logger              *zap.Logger
+	clockService        clock.ClockContract
+	primaryStoreService HeartbeatStoreContract
+	standbyStoreService HeartbeatStoreContract
+	heartbeatInterval   time.Duration
+	maxLag              time.Duration
+	ctx                 context.Context
+	cancel              context.CancelFunc
+}
+
+// NewReplicationService creates new instance of the replicationService, setting up all dependencies and returns the instance
+// logger: Mandatory. Reference to the logger service
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// clockService: Mandatory. Reference to the service that provides the current time
+// primaryStoreService: Mandatory. Reference to the heartbeat store of the primary database
+// standbyStoreService: Mandatory. Reference to the heartbeat store of the standby database
+// Returns the new service or error if something goes wrong
+func NewReplicationService(
+	logger *zap.Logger,
+	configurationService configuration.ConfigurationContract,
+	clockService clock.ClockContract,
+	primaryStoreService HeartbeatStoreContract,
+	standbyStoreService HeartbeatStoreContract) (ReplicationContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	if primaryStoreService == nil {
+		return nil, commonErrors.NewArgumentNilError("primaryStoreService", "primaryStoreService is required")
+	}
+
+	if standbyStoreService == nil {
+		return nil, commonErrors.NewArgumentNilError("standbyStoreService", "standbyStoreService is required")
+	}
+
+	heartbeatInterval, err := configurationService.GetReplicationHeartbeatInterval()
+	if err != nil {
+		return nil, err
+	}
+
+	maxLag, err := configurationService.GetReplicationMaxLag()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &replicationService{
+		logger:              logger,
+		clockService:        clockService,
+		primaryStoreService: primaryStoreService,
+		standbyStoreService: standbyStoreService,
+		heartbeatInterval:   heartbeatInterval,
+		maxLag:              maxLag,
+		ctx:                 ctx,
+		cancel:              cancel,
+	}, nil
+}
+
+// Start the replication service. The heartbeat is written to the primary database and the replication lag is
+// recorded as metrics right away and then once every heartbeat interval until the service is stopped.
+// Returns error if something goes wrong.
+func (service *replicationService) Start() error {
+	service.logger.Info(
+		"Replication service started",
+		zap.Duration("heartbeatInterval", service.heartbeatInterval),
+		zap.Duration("maxLag", service.maxLag))
+
+	ticker := time.NewTicker(service.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		service.beat()
+
+		select {
+		case <-service.ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop the replication service.
+// Returns error if something goes wrong.
+func (service *replicationService) Stop() error {
+	service.cancel()
+
+	return nil
+}
+
+// GetReplicationStatus reads the latest heartbeats of the primary and the standby databases and compares them. The
+// databases being unreachable is reported in the status rather than as an error, as it makes the standby database
+// not ready to fail over to.
+// ctx: Mandatory The reference to the context
+// Returns either the replication status or error if something goes wrong.
+func (service *replicationService) GetReplicationStatus(ctx context.Context) (*models.ReplicationStatus, error) {
+	status := &models.ReplicationStatus{
+		Configured: true,
+		CheckedAt:  service.clockService.Now(),
+	}
+
+	primary, err := service.primaryStoreService.ReadHeartbeat(ctx)
+	if err != nil {
+		status.Error = describeReadError("primary", err)
+
+		return status, nil
+	}
+
+	status.Primary = primary
+
+	standby, err := service.standbyStoreService.ReadHeartbeat(ctx)
+	if err != nil {
+		status.Error = describeReadError("standby", err)
+
+		return status, nil
+	}
+
+	status.Standby = standby
+
+	if standby.Sequence < primary.Sequence {
+		status.LagChanges = primary.Sequence - standby.Sequence
+		status.Lag = status.CheckedAt.Sub(standby.WrittenAt)
+	}
+
+	status.FailoverReady = status.Lag <= service.maxLag
+
+	return status, nil
+}
+
+func (service *replicationService) beat() {
+	if _, err := service.primaryStoreService.WriteHeartbeat(service.ctx, service.clockService.Now()); err != nil {
+		// Stopping the service cancels the in-flight heartbeat, which is not worth reporting
+		if service.ctx.Err() == nil {
+			service.logger.Error("failed to write the replication heartbeat", zap.Error(err))
+		}
+
+		return
+	}
+
+	status, _ := service.GetReplicationStatus(service.ctx)
+	if status.Error != "" && service.ctx.Err() == nil {
+		service.logger.Warn("failed to measure the replication lag", zap.String("error", status.Error))
+	}
+
+	if status.Standby != nil {
+		lagGauge.Set(status.Lag.Seconds())
+		lagChangesGauge.Set(float64(status.LagChanges))
+	}
+
+	if status.FailoverReady {
+		failoverReadyGauge.Set(1)
+	} else {
+		failoverReadyGauge.Set(0)
+	}
+}
+
+func describeReadError(database string, err error) string {
+	if commonErrors.IsNotFoundError(err) {
+		return fmt.Sprintf("the %s database has not received any heartbeat yet", database)
+	}
+
+	return fmt.Sprintf("failed to read the heartbeat of the %s database: %v", database, err)
+}
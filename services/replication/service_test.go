@@ -0,0 +1,236 @@
+package replication_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/replication"
+	replicationMock "github.com/decentralized-cloud/user/services/replication/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestReplicationService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Replication Service Tests")
+}
+
+var _ = Describe("Replication Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      replication.ReplicationContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		mockClockService         *clockMock.MockClockContract
+		mockPrimaryStoreService  *replicationMock.MockHeartbeatStoreContract
+		mockStandbyStoreService  *replicationMock.MockHeartbeatStoreContract
+		ctx                      context.Context
+		now                      time.Time
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		ctx = context.Background()
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetReplicationHeartbeatInterval().
+			Return(10*time.Millisecond, nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetReplicationMaxLag().
+			Return(time.Minute, nil).
+			AnyTimes()
+
+		now = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			Return(now).
+			AnyTimes()
+
+		mockPrimaryStoreService = replicationMock.NewMockHeartbeatStoreContract(mockCtrl)
+		mockStandbyStoreService = replicationMock.NewMockHeartbeatStoreContract(mockCtrl)
+		sut, _ = replication.NewReplicationService(zap.NewNop(), mockConfigurationService, mockClockService, mockPrimaryStoreService, mockStandbyStoreService)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate ReplicationService", func() {
+		When("logger is not provided and NewReplicationService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := replication.NewReplicationService(nil, mockConfigurationService, mockClockService, mockPrimaryStoreService, mockStandbyStoreService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("configuration service is not provided and NewReplicationService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := replication.NewReplicationService(zap.NewNop(), nil, mockClockService, mockPrimaryStoreService, mockStandbyStoreService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("clock service is not provided and NewReplicationService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := replication.NewReplicationService(zap.NewNop(), mockConfigurationService, nil, mockPrimaryStoreService, mockStandbyStoreService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("primary store service is not provided and NewReplicationService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := replication.NewReplicationService(zap.NewNop(), mockConfigurationService, mockClockService, nil, mockStandbyStoreService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("standby store service is not provided and NewReplicationService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := replication.NewReplicationService(zap.NewNop(), mockConfigurationService, mockClockService, mockPrimaryStoreService, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("all dependencies are resolved and NewReplicationService is called", func() {
+			It("should instantiate the new ReplicationService", func() {
+				service, err := replication.NewReplicationService(zap.NewNop(), mockConfigurationService, mockClockService, mockPrimaryStoreService, mockStandbyStoreService)
+				Ω(err).Should(BeNil())
+				Ω(service).ShouldNot(BeNil())
+			})
+		})
+	})
+
+	Context("ReplicationService is instantiated", func() {
+		When("GetReplicationStatus is called and the standby database received the latest heartbeat", func() {
+			It("should return no lag and ready to fail over", func() {
+				heartbeat := models.ReplicationHeartbeat{Sequence: 10, WrittenAt: now.Add(-5 * time.Second)}
+				mockPrimaryStoreService.EXPECT().ReadHeartbeat(ctx).Return(&heartbeat, nil)
+				mockStandbyStoreService.EXPECT().ReadHeartbeat(ctx).Return(&heartbeat, nil)
+
+				status, err := sut.GetReplicationStatus(ctx)
+				Ω(err).Should(BeNil())
+				Ω(status.Configured).Should(BeTrue())
+				Ω(status.LagChanges).Should(BeZero())
+				Ω(status.Lag).Should(BeZero())
+				Ω(status.FailoverReady).Should(BeTrue())
+				Ω(status.CheckedAt).Should(Equal(now))
+			})
+		})
+
+		When("GetReplicationStatus is called and the standby database is behind within the maximum lag", func() {
+			It("should return the lag and ready to fail over", func() {
+				mockPrimaryStoreService.EXPECT().ReadHeartbeat(ctx).Return(&models.ReplicationHeartbeat{Sequence: 10, WrittenAt: now}, nil)
+				mockStandbyStoreService.EXPECT().ReadHeartbeat(ctx).Return(&models.ReplicationHeartbeat{Sequence: 7, WrittenAt: now.Add(-30 * time.Second)}, nil)
+
+				status, err := sut.GetReplicationStatus(ctx)
+				Ω(err).Should(BeNil())
+				Ω(status.LagChanges).Should(Equal(int64(3)))
+				Ω(status.Lag).Should(Equal(30 * time.Second))
+				Ω(status.FailoverReady).Should(BeTrue())
+			})
+		})
+
+		When("GetReplicationStatus is called and the standby database is further behind than the maximum lag", func() {
+			It("should return the lag and not ready to fail over", func() {
+				mockPrimaryStoreService.EXPECT().ReadHeartbeat(ctx).Return(&models.ReplicationHeartbeat{Sequence: 20, WrittenAt: now}, nil)
+				mockStandbyStoreService.EXPECT().ReadHeartbeat(ctx).Return(&models.ReplicationHeartbeat{Sequence: 5, WrittenAt: now.Add(-2 * time.Minute)}, nil)
+
+				status, err := sut.GetReplicationStatus(ctx)
+				Ω(err).Should(BeNil())
+				Ω(status.LagChanges).Should(Equal(int64(15)))
+				Ω(status.Lag).Should(Equal(2 * time.Minute))
+				Ω(status.FailoverReady).Should(BeFalse())
+			})
+		})
+
+		When("GetReplicationStatus is called and the standby database has not received any heartbeat yet", func() {
+			It("should report the error in the status and not ready to fail over", func() {
+				primary := models.ReplicationHeartbeat{Sequence: 1, WrittenAt: now}
+				mockPrimaryStoreService.EXPECT().ReadHeartbeat(ctx).Return(&primary, nil)
+				mockStandbyStoreService.EXPECT().ReadHeartbeat(ctx).Return(nil, commonErrors.NewNotFoundError())
+
+				status, err := sut.GetReplicationStatus(ctx)
+				Ω(err).Should(BeNil())
+				Ω(status.Primary).Should(Equal(&primary))
+				Ω(status.Standby).Should(BeNil())
+				Ω(status.Error).Should(ContainSubstring("standby"))
+				Ω(status.FailoverReady).Should(BeFalse())
+			})
+		})
+
+		When("GetReplicationStatus is called and the primary database is unreachable", func() {
+			It("should report the error in the status without reading the standby database", func() {
+				expectedError := errors.New(cuid.New())
+				mockPrimaryStoreService.EXPECT().ReadHeartbeat(ctx).Return(nil, expectedError)
+
+				status, err := sut.GetReplicationStatus(ctx)
+				Ω(err).Should(BeNil())
+				Ω(status.Error).Should(ContainSubstring(expectedError.Error()))
+				Ω(status.FailoverReady).Should(BeFalse())
+			})
+		})
+	})
+
+	Context("replication service is started", func() {
+		When("the heartbeat interval passes", func() {
+			It("should write the heartbeat to the primary database until it is stopped", func() {
+				written := make(chan time.Time, 100)
+				mockPrimaryStoreService.
+					EXPECT().
+					WriteHeartbeat(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, writtenAt time.Time) (*models.ReplicationHeartbeat, error) {
+						written <- writtenAt
+
+						return &models.ReplicationHeartbeat{Sequence: 1, WrittenAt: writtenAt}, nil
+					}).
+					MinTimes(2)
+
+				mockPrimaryStoreService.
+					EXPECT().
+					ReadHeartbeat(gomock.Any()).
+					Return(&models.ReplicationHeartbeat{Sequence: 1, WrittenAt: now}, nil).
+					AnyTimes()
+
+				mockStandbyStoreService.
+					EXPECT().
+					ReadHeartbeat(gomock.Any()).
+					Return(&models.ReplicationHeartbeat{Sequence: 1, WrittenAt: now}, nil).
+					AnyTimes()
+
+				stopped := make(chan error)
+				go func() {
+					stopped <- sut.Start()
+				}()
+
+				var writtenAt time.Time
+				Eventually(written).Should(Receive(&writtenAt))
+				Ω(writtenAt).Should(Equal(now))
+				Eventually(written).Should(Receive())
+
+				Ω(sut.Stop()).Should(Succeed())
+				Eventually(stopped).Should(Receive(BeNil()))
+			})
+		})
+	})
+})
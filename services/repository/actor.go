@@ -0,0 +1,23 @@
+// Package repository implements different repository services required by the user service
+package repository
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// GetActor returns the caller the operation is made on behalf of, recorded as the caller that created or last
+// updated the user. It is the email address of the user the token is issued to, or the identity of the internal
+// service the client credential token is issued to.
+// ctx: Mandatory The reference to the context
+// Returns the caller, or empty if the operation is not made on behalf of an authenticated caller, e.g. the saga
+// compensations
+func GetActor(ctx context.Context) string {
+	parsedToken, _ := ctx.Value(models.ContextKeyParsedToken).(models.ParsedToken)
+	if parsedToken.Email != "" {
+		return parsedToken.Email
+	}
+
+	return parsedToken.Service
+}
@@ -0,0 +1,69 @@
+// Package cached implements the repository service that caches the users read from the underlying repository
+package cached
+
+import (
+	"context"
+	"time"
+)
+
+// CacheStoreContract declares the service that stores the cached values
+type CacheStoreContract interface {
+	// Get reads the value cached under the given key
+	// ctx: Mandatory The reference to the context
+	// key: Mandatory. The key the value is cached under
+	// Returns the cached value and true if found, false if the value is not cached or has expired, or error if something goes wrong.
+	Get(
+		ctx context.Context,
+		key string) ([]byte, bool, error)
+
+	// Set caches the value under the given key, replacing the existing value if any
+	// ctx: Mandatory The reference to the context
+	// key: Mandatory. The key to cache the value under
+	// value: Mandatory. The value to cache
+	// ttl: Mandatory. How long the value is kept before it expires
+	// Returns error if something goes wrong.
+	Set(
+		ctx context.Context,
+		key string,
+		value []byte,
+		ttl time.Duration) error
+
+	// Delete removes the values cached under the given keys, the keys that are not cached are ignored
+	// ctx: Mandatory The reference to the context
+	// keys: Mandatory. The keys to remove
+	// Returns error if something goes wrong.
+	Delete(
+		ctx context.Context,
+		keys ...string) error
+
+	// DeleteMatching removes the values cached under the keys that match the given glob pattern
+	// ctx: Mandatory The reference to the context
+	// pattern: Mandatory. The glob pattern of the keys to remove, e.g. user:*
+	// Returns error if something goes wrong.
+	DeleteMatching(
+		ctx context.Context,
+		pattern string) error
+}
+
+// InvalidationBusContract declares the service that broadcasts the users removed from the cache to all the replicas,
+// so they remove the users from their memory too
+type InvalidationBusContract interface {
+	// Publish broadcasts the invalidation to all the replicas, including the one publishing it
+	// ctx: Mandatory The reference to the context
+	// invalidation: Mandatory. The invalidation to broadcast
+	// Returns error if something goes wrong.
+	Publish(
+		ctx context.Context,
+		invalidation Invalidation) error
+
+	// Subscribe starts receiving the invalidations broadcast by the replicas in the background until the bus is closed.
+	// As the invalidations broadcast while the replica is not subscribed are lost, the handler is also called with an
+	// invalidation of all the users every time the subscription is established.
+	// handler: Mandatory. The function called with every received invalidation
+	// Returns error if something goes wrong.
+	Subscribe(handler func(invalidation Invalidation)) error
+
+	// Close stops receiving the invalidations
+	// Returns error if something goes wrong.
+	Close() error
+}
@@ -0,0 +1,96 @@
+package cached
+
+import (
+	"sync"
+	"time"
+)
+
+type localEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// localCache keeps the cached values in the memory of the replica for a short time, so the users read over and over
+// again are not read from Redis every time
+type localCache struct {
+	mutex      sync.Mutex
+	entries    map[string]localEntry
+	ttl        time.Duration
+	maxEntries int
+}
+
+func newLocalCache(ttl time.Duration, maxEntries int) *localCache {
+	return &localCache{
+		entries:    map[string]localEntry{},
+		ttl:        ttl,
+		maxEntries: maxEntries,
+	}
+}
+
+func (cache *localCache) get(key string, now time.Time) ([]byte, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entry, ok := cache.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if !now.Before(entry.expiresAt) {
+		delete(cache.entries, key)
+
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+func (cache *localCache) set(key string, value []byte, now time.Time) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if _, ok := cache.entries[key]; !ok && len(cache.entries) >= cache.maxEntries {
+		cache.evict(now)
+	}
+
+	cache.entries[key] = localEntry{
+		value:     value,
+		expiresAt: now.Add(cache.ttl),
+	}
+}
+
+func (cache *localCache) delete(keys ...string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	for _, key := range keys {
+		delete(cache.entries, key)
+	}
+}
+
+func (cache *localCache) clear() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.entries = map[string]localEntry{}
+}
+
+// evict removes the expired entries, or an arbitrary entry if none has expired, to make room for a new entry. The
+// entries live for a short time, so keeping track of the least recently used entry is not worth it.
+func (cache *localCache) evict(now time.Time) {
+	for key, entry := range cache.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(cache.entries, key)
+		}
+	}
+
+	if len(cache.entries) < cache.maxEntries {
+		return
+	}
+
+	for key := range cache.entries {
+		delete(cache.entries, key)
+
+		return
+	}
+}
@@ -0,0 +1,7 @@
+package cached
+
+// Invalidation contains the keys of the users removed from the cache
+type Invalidation struct {
+	Keys []string `json:"keys,omitempty"`
+	All  bool     `json:"all,omitempty"`
+}
@@ -0,0 +1,165 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/repository/cached/contract.go
+
+// Package mock_cached is a generated GoMock package.
+package mock_cached
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	cached "github.com/decentralized-cloud/user/services/repository/cached"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCacheStoreContract is a mock of CacheStoreContract interface.
+type MockCacheStoreContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheStoreContractMockRecorder
+}
+
+// MockCacheStoreContractMockRecorder is the mock recorder for MockCacheStoreContract.
+type MockCacheStoreContractMockRecorder struct {
+	mock *MockCacheStoreContract
+}
+
+// NewMockCacheStoreContract creates a new mock instance.
+func NewMockCacheStoreContract(ctrl *gomock.Controller) *MockCacheStoreContract {
+	mock := &MockCacheStoreContract{ctrl: ctrl}
+	mock.recorder = &MockCacheStoreContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCacheStoreContract) EXPECT() *MockCacheStoreContractMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCacheStoreContract) Delete(ctx context.Context, keys ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCacheStoreContractMockRecorder) Delete(ctx interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCacheStoreContract)(nil).Delete), varargs...)
+}
+
+// DeleteMatching mocks base method.
+func (m *MockCacheStoreContract) DeleteMatching(ctx context.Context, pattern string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMatching", ctx, pattern)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMatching indicates an expected call of DeleteMatching.
+func (mr *MockCacheStoreContractMockRecorder) DeleteMatching(ctx, pattern interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMatching", reflect.TypeOf((*MockCacheStoreContract)(nil).DeleteMatching), ctx, pattern)
+}
+
+// Get mocks base method.
+func (m *MockCacheStoreContract) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCacheStoreContractMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCacheStoreContract)(nil).Get), ctx, key)
+}
+
+// Set mocks base method.
+func (m *MockCacheStoreContract) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, key, value, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockCacheStoreContractMockRecorder) Set(ctx, key, value, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCacheStoreContract)(nil).Set), ctx, key, value, ttl)
+}
+
+// MockInvalidationBusContract is a mock of InvalidationBusContract interface.
+type MockInvalidationBusContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvalidationBusContractMockRecorder
+}
+
+// MockInvalidationBusContractMockRecorder is the mock recorder for MockInvalidationBusContract.
+type MockInvalidationBusContractMockRecorder struct {
+	mock *MockInvalidationBusContract
+}
+
+// NewMockInvalidationBusContract creates a new mock instance.
+func NewMockInvalidationBusContract(ctrl *gomock.Controller) *MockInvalidationBusContract {
+	mock := &MockInvalidationBusContract{ctrl: ctrl}
+	mock.recorder = &MockInvalidationBusContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvalidationBusContract) EXPECT() *MockInvalidationBusContractMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockInvalidationBusContract) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockInvalidationBusContractMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockInvalidationBusContract)(nil).Close))
+}
+
+// Publish mocks base method.
+func (m *MockInvalidationBusContract) Publish(ctx context.Context, invalidation cached.Invalidation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, invalidation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockInvalidationBusContractMockRecorder) Publish(ctx, invalidation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockInvalidationBusContract)(nil).Publish), ctx, invalidation)
+}
+
+// Subscribe mocks base method.
+func (m *MockInvalidationBusContract) Subscribe(handler func(cached.Invalidation)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockInvalidationBusContractMockRecorder) Subscribe(handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockInvalidationBusContract)(nil).Subscribe), handler)
+}
@@ -0,0 +1,275 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/repository/cached"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// resubscribeDelay is the time waited before subscribing again after the subscription connection failed
+	resubscribeDelay = time.Second
+
+	// pingInterval is the interval the subscription connection is pinged at, so a connection that silently stopped
+	// receiving the invalidations is detected and replaced
+	pingInterval = 5 * time.Second
+)
+
+type redisInvalidationBus struct {
+	logger     *zap.Logger
+	client     *client
+	channel    string
+	mutex      sync.Mutex
+	conn       *connection
+	subscribed bool
+	closed     bool
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+// NewRedisInvalidationBus creates new instance of the redisInvalidationBus, setting up all dependencies and returns the instance.
+// The invalidations are broadcast through the Redis pub/sub channel named after the cache key prefix, so the deployments
+// sharing the Redis server do not receive each other's invalidations.
+// logger: Mandatory. Reference to the logger service
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewRedisInvalidationBus(
+	logger *zap.Logger,
+	configurationService configuration.ConfigurationContract) (cached.InvalidationBusContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	address, err := configurationService.GetCacheRedisAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := configurationService.GetCacheRedisPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := configurationService.GetCacheRedisDatabase()
+	if err != nil {
+		return nil, err
+	}
+
+	keyPrefix, err := configurationService.GetCacheKeyPrefix()
+	if err != nil {
+		return nil, err
+	}
+
+	return &redisInvalidationBus{
+		logger:  logger,
+		client:  newClient(address, password, database),
+		channel: keyPrefix + "invalidations",
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}, nil
+}
+
+// Publish broadcasts the invalidation to all the replicas, including the one publishing it
+// ctx: Mandatory The reference to the context
+// invalidation: Mandatory. The invalidation to broadcast
+// Returns error if something goes wrong.
+func (service *redisInvalidationBus) Publish(
+	ctx context.Context,
+	invalidation cached.Invalidation) error {
+	payload, err := json.Marshal(invalidation)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to encode the invalidation", err)
+	}
+
+	if _, err = service.client.execute(ctx, "PUBLISH", service.channel, string(payload)); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to broadcast the invalidation", err)
+	}
+
+	return nil
+}
+
+// Subscribe starts receiving the invalidations broadcast by the replicas in the background until the bus is closed.
+// The subscription is retried until it succeeds, so the replica starts while the Redis server is not reachable.
+// handler: Mandatory. The function called with every received invalidation
+// Returns error if something goes wrong.
+func (service *redisInvalidationBus) Subscribe(handler func(invalidation cached.Invalidation)) error {
+	if handler == nil {
+		return commonErrors.NewArgumentNilError("handler", "handler is required")
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	if service.closed {
+		return commonErrors.NewUnknownError("the invalidation bus is closed")
+	}
+
+	if service.subscribed {
+		return commonErrors.NewUnknownError("the invalidation bus is already subscribed to")
+	}
+
+	service.subscribed = true
+	go service.receive(handler)
+
+	return nil
+}
+
+// Close stops receiving the invalidations
+// Returns error if something goes wrong.
+func (service *redisInvalidationBus) Close() error {
+	service.mutex.Lock()
+	if service.closed {
+		service.mutex.Unlock()
+
+		return nil
+	}
+
+	service.closed = true
+	close(service.stop)
+
+	if service.conn != nil {
+		_ = service.conn.conn.Close()
+	}
+
+	subscribed := service.subscribed
+	service.mutex.Unlock()
+
+	service.client.close()
+
+	if subscribed {
+		<-service.done
+	}
+
+	return nil
+}
+
+func (service *redisInvalidationBus) receive(handler func(invalidation cached.Invalidation)) {
+	defer close(service.done)
+
+	for {
+		err := service.receiveUntilFailed(handler)
+
+		select {
+		case <-service.stop:
+			return
+		default:
+		}
+
+		service.logger.Warn(
+			"Lost the subscription to the cache invalidations, the users kept in memory may be stale until it is restored",
+			zap.Error(err))
+
+		select {
+		case <-service.stop:
+			return
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+// receiveUntilFailed subscribes to the invalidations and hands them to the handler until the connection fails
+func (service *redisInvalidationBus) receiveUntilFailed(handler func(invalidation cached.Invalidation)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	conn, err := service.client.dial(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = conn.conn.Close()
+	}()
+
+	if !service.setConnection(conn) {
+		return nil
+	}
+
+	defer service.setConnection(nil)
+
+	if _, err = conn.execute(ctx, "SUBSCRIBE", service.channel); err != nil {
+		return err
+	}
+
+	// The invalidations broadcast while this replica was not subscribed are lost
+	handler(cached.Invalidation{All: true})
+
+	stopPinging := make(chan struct{})
+	defer close(stopPinging)
+
+	go service.ping(conn, stopPinging)
+
+	for {
+		if err = conn.conn.SetReadDeadline(time.Now().Add(2 * pingInterval)); err != nil {
+			return err
+		}
+
+		reply, err := conn.readReply()
+		if err != nil {
+			return err
+		}
+
+		items, ok := reply.([]interface{})
+		if !ok || len(items) != 3 {
+			continue
+		}
+
+		if kind, _ := items[0].([]byte); string(kind) != "message" {
+			continue
+		}
+
+		payload, _ := items[2].([]byte)
+		invalidation := cached.Invalidation{}
+		if err = json.Unmarshal(payload, &invalidation); err != nil {
+			return fmt.Errorf("failed to decode the invalidation: %w", err)
+		}
+
+		handler(invalidation)
+	}
+}
+
+// ping pings the subscription connection periodically, the replies are read along with the invalidations
+func (service *redisInvalidationBus) ping(conn *connection, stop chan struct{}) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := conn.conn.SetWriteDeadline(time.Now().Add(commandTimeout)); err != nil {
+				return
+			}
+
+			if err := conn.write("PING"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// setConnection keeps the subscription connection so it is closed along with the bus. Returns false if the bus is closed.
+func (service *redisInvalidationBus) setConnection(conn *connection) bool {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	if service.closed {
+		return false
+	}
+
+	service.conn = conn
+
+	return true
+}
@@ -0,0 +1,128 @@
+package redis_test
+
+import (
+	"context"
+	"time"
+
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/repository/cached"
+	"github.com/decentralized-cloud/user/services/repository/cached/redis"
+	"github.com/golang/mock/gomock"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redis Invalidation Bus Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      cached.InvalidationBusContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		server                   *fakeRedisServer
+		ctx                      context.Context
+		invalidations            chan cached.Invalidation
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		ctx = context.Background()
+		server = newFakeRedisServer()
+		invalidations = make(chan cached.Invalidation, 10)
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetCacheRedisAddress().
+			Return(server.address(), nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetCacheRedisPassword().
+			Return("", nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetCacheRedisDatabase().
+			Return(0, nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetCacheKeyPrefix().
+			Return("user:", nil).
+			AnyTimes()
+
+		sut, _ = redis.NewRedisInvalidationBus(zap.NewNop(), mockConfigurationService)
+	})
+
+	AfterEach(func() {
+		Ω(sut.Close()).Should(Succeed())
+		server.close()
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate RedisInvalidationBus", func() {
+		When("logger is not provided and NewRedisInvalidationBus is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := redis.NewRedisInvalidationBus(nil, mockConfigurationService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("configuration service is not provided and NewRedisInvalidationBus is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := redis.NewRedisInvalidationBus(zap.NewNop(), nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("RedisInvalidationBus is instantiated", func() {
+		When("the bus is subscribed to", func() {
+			It("should invalidate all the users as the earlier invalidations are lost", func() {
+				Ω(sut.Subscribe(func(invalidation cached.Invalidation) { invalidations <- invalidation })).Should(Succeed())
+
+				Eventually(invalidations).Should(Receive(Equal(cached.Invalidation{All: true})))
+				Ω(server.commands()).Should(ContainElement("SUBSCRIBE user:invalidations"))
+			})
+		})
+
+		When("an invalidation is published", func() {
+			It("should be received by the subscribers", func() {
+				Ω(sut.Subscribe(func(invalidation cached.Invalidation) { invalidations <- invalidation })).Should(Succeed())
+				Eventually(invalidations).Should(Receive())
+
+				Ω(sut.Publish(ctx, cached.Invalidation{Keys: []string{"user:a@test.com"}})).Should(Succeed())
+
+				Eventually(invalidations).Should(Receive(Equal(cached.Invalidation{Keys: []string{"user:a@test.com"}})))
+			})
+		})
+
+		When("the subscription connection is lost", func() {
+			It("should subscribe again and invalidate all the users", func() {
+				Ω(sut.Subscribe(func(invalidation cached.Invalidation) { invalidations <- invalidation })).Should(Succeed())
+				Eventually(invalidations).Should(Receive())
+
+				server.disconnectSubscribers()
+
+				Eventually(invalidations, 5*time.Second).Should(Receive(Equal(cached.Invalidation{All: true})))
+				Eventually(server.subscriberCount).Should(Equal(1))
+			})
+		})
+
+		When("the Redis server is not reachable", func() {
+			It("should return error from Publish", func() {
+				server.close()
+
+				err := sut.Publish(ctx, cached.Invalidation{All: true})
+				Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+			})
+		})
+	})
+})
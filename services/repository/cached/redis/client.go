@@ -0,0 +1,231 @@
+// Package redis implements the cache store that keeps the cached values in Redis
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxIdleConnections is the number of the connections kept open between the commands
+	maxIdleConnections = 16
+
+	// commandTimeout bounds the time a command can take if the context has no deadline, the cache must never be
+	// slower than reading from the database
+	commandTimeout = time.Second
+)
+
+// redisError is the error reply returned by the Redis server for a command
+type redisError string
+
+func (err redisError) Error() string {
+	return string(err)
+}
+
+type connection struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// client sends the commands to the Redis server over a pool of connections
+type client struct {
+	address         string
+	password        string
+	database        int
+	idleConnections chan *connection
+}
+
+func newClient(address string, password string, database int) *client {
+	return &client{
+		address:         address,
+		password:        password,
+		database:        database,
+		idleConnections: make(chan *connection, maxIdleConnections),
+	}
+}
+
+// execute sends the command to the Redis server and reads its reply. The connection is returned to the idle
+// connections unless it failed, as the position in the reply stream of a failed connection is unknown.
+func (service *client) execute(ctx context.Context, args ...string) (interface{}, error) {
+	conn, err := service.getConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.execute(ctx, args...)
+	if _, ok := err.(redisError); err != nil && !ok {
+		_ = conn.conn.Close()
+
+		return nil, err
+	}
+
+	service.releaseConnection(conn)
+
+	return reply, err
+}
+
+func (service *client) getConnection(ctx context.Context) (*connection, error) {
+	select {
+	case conn := <-service.idleConnections:
+		return conn, nil
+	default:
+	}
+
+	return service.dial(ctx)
+}
+
+// dial opens a new connection to the Redis server, authenticates and selects the configured database
+func (service *client) dial(ctx context.Context) (*connection, error) {
+	dialer := net.Dialer{Timeout: commandTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", service.address)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &connection{
+		conn:   netConn,
+		reader: bufio.NewReader(netConn),
+	}
+
+	if service.password != "" {
+		if _, err = conn.execute(ctx, "AUTH", service.password); err != nil {
+			_ = netConn.Close()
+
+			return nil, err
+		}
+	}
+
+	if service.database != 0 {
+		if _, err = conn.execute(ctx, "SELECT", strconv.Itoa(service.database)); err != nil {
+			_ = netConn.Close()
+
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+func (service *client) releaseConnection(conn *connection) {
+	select {
+	case service.idleConnections <- conn:
+	default:
+		_ = conn.conn.Close()
+	}
+}
+
+// close closes the idle connections, the connections in use are closed once they are released
+func (service *client) close() {
+	for {
+		select {
+		case conn := <-service.idleConnections:
+			_ = conn.conn.Close()
+		default:
+			return
+		}
+	}
+}
+
+// execute writes the command as a RESP array of bulk strings and reads the reply
+func (conn *connection) execute(ctx context.Context, args ...string) (interface{}, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(commandTimeout)
+	}
+
+	if err := conn.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	if err := conn.write(args...); err != nil {
+		return nil, err
+	}
+
+	return conn.readReply()
+}
+
+// write writes the command as a RESP array of bulk strings
+func (conn *connection) write(args ...string) error {
+	var command strings.Builder
+	command.WriteString("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		command.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n" + arg + "\r\n")
+	}
+
+	_, err := io.WriteString(conn.conn, command.String())
+
+	return err
+}
+
+// readReply reads a single RESP reply. The bulk strings are returned as []byte, the integers as int64, the arrays as
+// []interface{} and the null replies as nil.
+func (conn *connection) readReply() (interface{}, error) {
+	line, err := conn.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+
+	case '-':
+		return nil, redisError(line[1:])
+
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+
+	case '$':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		if length < 0 {
+			return nil, nil
+		}
+
+		value := make([]byte, length+2)
+		if _, err = io.ReadFull(conn.reader, value); err != nil {
+			return nil, err
+		}
+
+		return value[:length], nil
+
+	case '*':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		if length < 0 {
+			return nil, nil
+		}
+
+		items := make([]interface{}, 0, length)
+		for i := 0; i < length; i++ {
+			item, err := conn.readReply()
+			if _, ok := err.(redisError); err != nil && !ok {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		return items, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected reply: %s", line)
+	}
+}
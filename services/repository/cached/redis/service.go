@@ -0,0 +1,149 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/repository/cached"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// scanCount is the number of the keys SCAN is hinted to return per iteration
+const scanCount = 100
+
+type redisCacheStore struct {
+	client *client
+}
+
+// NewRedisCacheStore creates new instance of the redisCacheStore, setting up all dependencies and returns the instance.
+// The connections to the Redis server are opened when the first command is sent.
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewRedisCacheStore(configurationService configuration.ConfigurationContract) (cached.CacheStoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	address, err := configurationService.GetCacheRedisAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := configurationService.GetCacheRedisPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := configurationService.GetCacheRedisDatabase()
+	if err != nil {
+		return nil, err
+	}
+
+	return &redisCacheStore{
+		client: newClient(address, password, database),
+	}, nil
+}
+
+// Get reads the value cached under the given key
+// ctx: Mandatory The reference to the context
+// key: Mandatory. The key the value is cached under
+// Returns the cached value and true if found, false if the value is not cached or has expired, or error if something goes wrong.
+func (service *redisCacheStore) Get(
+	ctx context.Context,
+	key string) ([]byte, bool, error) {
+	reply, err := service.client.execute(ctx, "GET", key)
+	if err != nil {
+		return nil, false, commonErrors.NewUnknownErrorWithError("failed to read the cached value", err)
+	}
+
+	if reply == nil {
+		return nil, false, nil
+	}
+
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, commonErrors.NewUnknownError(fmt.Sprintf("unexpected reply to GET: %v", reply))
+	}
+
+	return value, true, nil
+}
+
+// Set caches the value under the given key, replacing the existing value if any
+// ctx: Mandatory The reference to the context
+// key: Mandatory. The key to cache the value under
+// value: Mandatory. The value to cache
+// ttl: Mandatory. How long the value is kept before it expires
+// Returns error if something goes wrong.
+func (service *redisCacheStore) Set(
+	ctx context.Context,
+	key string,
+	value []byte,
+	ttl time.Duration) error {
+	if _, err := service.client.execute(ctx, "SET", key, string(value), "PX", strconv.FormatInt(ttl.Milliseconds(), 10)); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to cache the value", err)
+	}
+
+	return nil
+}
+
+// Delete removes the values cached under the given keys, the keys that are not cached are ignored
+// ctx: Mandatory The reference to the context
+// keys: Mandatory. The keys to remove
+// Returns error if something goes wrong.
+func (service *redisCacheStore) Delete(
+	ctx context.Context,
+	keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if _, err := service.client.execute(ctx, append([]string{"DEL"}, keys...)...); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to remove the cached values", err)
+	}
+
+	return nil
+}
+
+// DeleteMatching removes the values cached under the keys that match the given glob pattern
+// ctx: Mandatory The reference to the context
+// pattern: Mandatory. The glob pattern of the keys to remove, e.g. user:*
+// Returns error if something goes wrong.
+func (service *redisCacheStore) DeleteMatching(
+	ctx context.Context,
+	pattern string) error {
+	// SCAN is used instead of KEYS so a large cache does not block the Redis server
+	cursor := "0"
+	for {
+		reply, err := service.client.execute(ctx, "SCAN", cursor, "MATCH", pattern, "COUNT", strconv.Itoa(scanCount))
+		if err != nil {
+			return commonErrors.NewUnknownErrorWithError("failed to find the cached values", err)
+		}
+
+		items, ok := reply.([]interface{})
+		if !ok || len(items) != 2 {
+			return commonErrors.NewUnknownError(fmt.Sprintf("unexpected reply to SCAN: %v", reply))
+		}
+
+		nextCursor, _ := items[0].([]byte)
+		keyReplies, _ := items[1].([]interface{})
+
+		keys := make([]string, 0, len(keyReplies))
+		for _, keyReply := range keyReplies {
+			if key, ok := keyReply.([]byte); ok {
+				keys = append(keys, string(key))
+			}
+		}
+
+		if err := service.Delete(ctx, keys...); err != nil {
+			return err
+		}
+
+		cursor = string(nextCursor)
+		if cursor == "0" || cursor == "" {
+			return nil
+		}
+	}
+}
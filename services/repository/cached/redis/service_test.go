@@ -0,0 +1,369 @@
+package redis_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/repository/cached"
+	"github.com/decentralized-cloud/user/services/repository/cached/redis"
+	"github.com/golang/mock/gomock"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestRedisCacheStore(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Redis Cache Store Tests")
+}
+
+var _ = Describe("Redis Cache Store Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      cached.CacheStoreContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		server                   *fakeRedisServer
+		ctx                      context.Context
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		ctx = context.Background()
+		server = newFakeRedisServer()
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetCacheRedisAddress().
+			Return(server.address(), nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetCacheRedisPassword().
+			Return("secret", nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetCacheRedisDatabase().
+			Return(2, nil).
+			AnyTimes()
+
+		sut, _ = redis.NewRedisCacheStore(mockConfigurationService)
+	})
+
+	AfterEach(func() {
+		server.close()
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate RedisCacheStore", func() {
+		When("configuration service is not provided and NewRedisCacheStore is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := redis.NewRedisCacheStore(nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("RedisCacheStore is instantiated", func() {
+		When("the value is cached", func() {
+			It("should read the cached value", func() {
+				Ω(sut.Set(ctx, "user:a@test.com", []byte("{}"), time.Minute)).Should(Succeed())
+
+				value, found, err := sut.Get(ctx, "user:a@test.com")
+				Ω(err).Should(BeNil())
+				Ω(found).Should(BeTrue())
+				Ω(value).Should(Equal([]byte("{}")))
+				Ω(server.commands()).Should(ContainElement("AUTH secret"))
+				Ω(server.commands()).Should(ContainElement("SELECT 2"))
+				Ω(server.commands()).Should(ContainElement("SET user:a@test.com {} PX 60000"))
+			})
+		})
+
+		When("the value is not cached", func() {
+			It("should report the value is not found", func() {
+				value, found, err := sut.Get(ctx, "user:a@test.com")
+				Ω(err).Should(BeNil())
+				Ω(found).Should(BeFalse())
+				Ω(value).Should(BeNil())
+			})
+		})
+
+		When("the value is deleted", func() {
+			It("should not be found any more", func() {
+				Ω(sut.Set(ctx, "user:a@test.com", []byte("{}"), time.Minute)).Should(Succeed())
+				Ω(sut.Delete(ctx, "user:a@test.com")).Should(Succeed())
+
+				_, found, err := sut.Get(ctx, "user:a@test.com")
+				Ω(err).Should(BeNil())
+				Ω(found).Should(BeFalse())
+			})
+		})
+
+		When("the values matching a pattern are deleted", func() {
+			It("should only delete the matching values", func() {
+				Ω(sut.Set(ctx, "user:a+load-1@test.com", []byte("{}"), time.Minute)).Should(Succeed())
+				Ω(sut.Set(ctx, "user:b+load-2@test.com", []byte("{}"), time.Minute)).Should(Succeed())
+				Ω(sut.Set(ctx, "user:c@test.com", []byte("{}"), time.Minute)).Should(Succeed())
+
+				Ω(sut.DeleteMatching(ctx, "user:*+load-*")).Should(Succeed())
+
+				Ω(server.keys()).Should(ConsistOf("user:c@test.com"))
+			})
+		})
+
+		When("the Redis server returns an error reply", func() {
+			It("should return error", func() {
+				server.fail("ERR unexpected")
+
+				_, _, err := sut.Get(ctx, "user:a@test.com")
+				Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+			})
+		})
+
+		When("the Redis server is not reachable", func() {
+			It("should return error", func() {
+				server.close()
+
+				_, _, err := sut.Get(ctx, "user:a@test.com")
+				Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+			})
+		})
+	})
+})
+
+// fakeRedisServer implements the subset of the Redis protocol and commands the cache store uses
+type fakeRedisServer struct {
+	listener    net.Listener
+	mutex       sync.Mutex
+	values      map[string]string
+	subscribers map[string][]net.Conn
+	received    []string
+	failure     string
+}
+
+func newFakeRedisServer() *fakeRedisServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Ω(err).Should(BeNil())
+
+	server := &fakeRedisServer{
+		listener:    listener,
+		values:      map[string]string{},
+		subscribers: map[string][]net.Conn{},
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go server.serve(conn)
+		}
+	}()
+
+	return server
+}
+
+func (server *fakeRedisServer) address() string {
+	return server.listener.Addr().String()
+}
+
+func (server *fakeRedisServer) close() {
+	_ = server.listener.Close()
+}
+
+// disconnectSubscribers drops the subscription connections as if the Redis server restarted
+func (server *fakeRedisServer) disconnectSubscribers() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	for _, conns := range server.subscribers {
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	}
+
+	server.subscribers = map[string][]net.Conn{}
+}
+
+func (server *fakeRedisServer) subscriberCount() int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	count := 0
+	for _, conns := range server.subscribers {
+		count += len(conns)
+	}
+
+	return count
+}
+
+func (server *fakeRedisServer) fail(message string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.failure = message
+}
+
+func (server *fakeRedisServer) commands() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return append([]string{}, server.received...)
+}
+
+func (server *fakeRedisServer) keys() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	keys := []string{}
+	for key := range server.values {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+func (server *fakeRedisServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		// The replies are written while holding the lock so they do not interleave with the published messages
+		server.mutex.Lock()
+		_, err = io.WriteString(conn, server.execute(conn, args))
+		server.mutex.Unlock()
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (server *fakeRedisServer) execute(conn net.Conn, args []string) string {
+	server.received = append(server.received, strings.Join(args, " "))
+	if server.failure != "" {
+		return "-" + server.failure + "\r\n"
+	}
+
+	switch strings.ToUpper(args[0]) {
+	case "AUTH", "SELECT":
+		return "+OK\r\n"
+
+	case "GET":
+		value, ok := server.values[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+
+		return bulkString(value)
+
+	case "SET":
+		server.values[args[1]] = args[2]
+
+		return "+OK\r\n"
+
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := server.values[key]; ok {
+				delete(server.values, key)
+				deleted++
+			}
+		}
+
+		return ":" + strconv.Itoa(deleted) + "\r\n"
+
+	case "SUBSCRIBE":
+		server.subscribers[args[1]] = append(server.subscribers[args[1]], conn)
+
+		return "*3\r\n" + bulkString("subscribe") + bulkString(args[1]) + ":1\r\n"
+
+	case "PUBLISH":
+		for _, subscriber := range server.subscribers[args[1]] {
+			_, _ = io.WriteString(subscriber, "*3\r\n"+bulkString("message")+bulkString(args[1])+bulkString(args[2]))
+		}
+
+		return ":" + strconv.Itoa(len(server.subscribers[args[1]])) + "\r\n"
+
+	case "PING":
+		return "*2\r\n" + bulkString("pong") + bulkString("")
+
+	case "SCAN":
+		keys := []string{}
+		for key := range server.values {
+			// The test patterns do not contain the characters path.Match treats differently from Redis
+			if matched, _ := path.Match(args[3], key); matched {
+				keys = append(keys, key)
+			}
+		}
+
+		reply := "*2\r\n" + bulkString("0") + "*" + strconv.Itoa(len(keys)) + "\r\n"
+		for _, key := range keys {
+			reply += bulkString(key)
+		}
+
+		return reply
+
+	default:
+		return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := strconv.Atoi(strings.TrimSuffix(line[1:], "\r\n"))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		if line, err = reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+
+		length, err := strconv.Atoi(strings.TrimSuffix(line[1:], "\r\n"))
+		if err != nil {
+			return nil, err
+		}
+
+		value := make([]byte, length+2)
+		if _, err = io.ReadFull(reader, value); err != nil {
+			return nil, err
+		}
+
+		args = append(args, string(value[:length]))
+	}
+
+	return args, nil
+}
+
+func bulkString(value string) string {
+	return "$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n"
+}
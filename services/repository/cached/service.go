@@ -0,0 +1,588 @@
+// Package cached implements the repository service that caches the users read from the underlying repository
+package cached
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/correlation"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var lookupsCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "user_repository_cache_lookups_total",
+		Help: "The number of the users looked up in the cache grouped by the result (local_hit, hit, miss or error)",
+	},
+	[]string{"result"})
+
+var invalidationFailuresCounter = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Name: "user_repository_cache_invalidation_failures_total",
+		Help: "The number of the cached users that could not be removed after they were changed, they are served until they expire",
+	})
+
+var invalidationsReceivedCounter = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Name: "user_repository_cache_invalidations_received_total",
+		Help: "The number of the invalidations received from the replicas, including this one, and applied to the users kept in memory",
+	})
+
+type cachedRepositoryService struct {
+	logger            *zap.Logger
+	clockService      clock.ClockContract
+	repositoryService repository.RepositoryContract
+	cacheStore        CacheStoreContract
+	invalidationBus   InvalidationBusContract
+	localCache        *localCache
+	ttl               time.Duration
+	keyPrefix         string
+	multiTenant       bool
+}
+
+// NewCachedRepositoryService creates new instance of the cachedRepositoryService, setting up all dependencies and returns the instance.
+// The users read from the decorated repository are cached until they expire or are changed through this service, so every
+// replica must use it for the changes to be seen by the other replicas. If the users are also kept in the memory of
+// the replica, the changes are broadcast through the invalidation bus so the other replicas remove the changed users
+// from their memory.
+// logger: Mandatory. Reference to the logger service
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// clockService: Mandatory. Reference to the service that provides the current time
+// repositoryService: Mandatory. Reference to the repository service the users are read from and changed in
+// cacheStore: Mandatory. Reference to the store the users are cached in
+// invalidationBus: Optional. Reference to the bus the changes are broadcast through, required if the users are kept in memory
+// Returns the new service or error if something goes wrong
+func NewCachedRepositoryService(
+	logger *zap.Logger,
+	configurationService configuration.ConfigurationContract,
+	clockService clock.ClockContract,
+	repositoryService repository.RepositoryContract,
+	cacheStore CacheStoreContract,
+	invalidationBus InvalidationBusContract) (repository.RepositoryContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	if repositoryService == nil {
+		return nil, commonErrors.NewArgumentNilError("repositoryService", "repositoryService is required")
+	}
+
+	if cacheStore == nil {
+		return nil, commonErrors.NewArgumentNilError("cacheStore", "cacheStore is required")
+	}
+
+	ttl, err := configurationService.GetCacheTTL()
+	if err != nil {
+		return nil, err
+	}
+
+	keyPrefix, err := configurationService.GetCacheKeyPrefix()
+	if err != nil {
+		return nil, err
+	}
+
+	tenancyMode, err := configurationService.GetTenancyMode()
+	if err != nil {
+		return nil, err
+	}
+
+	service := &cachedRepositoryService{
+		logger:            logger,
+		clockService:      clockService,
+		repositoryService: repositoryService,
+		cacheStore:        cacheStore,
+		ttl:               ttl,
+		keyPrefix:         keyPrefix,
+		multiTenant:       tenancyMode == "multi",
+	}
+
+	localTTL, err := configurationService.GetCacheLocalTTL()
+	if err != nil {
+		return nil, err
+	}
+
+	if localTTL == 0 {
+		return service, nil
+	}
+
+	if invalidationBus == nil {
+		return nil, commonErrors.NewArgumentNilError("invalidationBus", "invalidationBus is required if the users are kept in memory")
+	}
+
+	localMaxEntries, err := configurationService.GetCacheLocalMaxEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	service.invalidationBus = invalidationBus
+	service.localCache = newLocalCache(localTTL, localMaxEntries)
+
+	if err = invalidationBus.Subscribe(service.applyInvalidation); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+// CreateUser creates a new user.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to create a new user
+// Returns either the result of creating new user or error if something goes wrong.
+func (service *cachedRepositoryService) CreateUser(
+	ctx context.Context,
+	request *repository.CreateUserRequest) (*repository.CreateUserResponse, error) {
+	return service.repositoryService.CreateUser(ctx, request)
+}
+
+// ReadUser read an existing user from the memory of the replica or the cache, falling back to the decorated repository
+// if the user is not cached or the cache is not reachable
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read an existing user
+// Returns either the result of reading an existing user or error if something goes wrong.
+func (service *cachedRepositoryService) ReadUser(
+	ctx context.Context,
+	request *repository.ReadUserRequest) (*repository.ReadUserResponse, error) {
+	// The users are cached by their email address, so the users read by their ID are read from the decorated
+	// repository
+	if request.UserID != "" {
+		return service.repositoryService.ReadUser(ctx, request)
+	}
+
+	key := service.getKey(repository.GetReadTenant(ctx), request.Email)
+
+	if service.localCache != nil {
+		if value, found := service.localCache.get(key, service.clockService.Now()); found {
+			user := models.User{}
+			if err := json.Unmarshal(value, &user); err == nil {
+				lookupsCounter.WithLabelValues("local_hit").Inc()
+
+				return readCachedUser(ctx, request.Email, user)
+			}
+		}
+	}
+
+	value, found, err := service.cacheStore.Get(ctx, key)
+	if err != nil {
+		lookupsCounter.WithLabelValues("error").Inc()
+		correlation.GetLogger(ctx, service.logger).Warn("Failed to read the user from the cache", zap.Error(err))
+	} else if found {
+		user := models.User{}
+		if err = json.Unmarshal(value, &user); err == nil {
+			lookupsCounter.WithLabelValues("hit").Inc()
+			service.setLocal(key, value)
+
+			return readCachedUser(ctx, request.Email, user)
+		}
+
+		lookupsCounter.WithLabelValues("error").Inc()
+		correlation.GetLogger(ctx, service.logger).Warn("Failed to decode the cached user", zap.Error(err))
+	} else {
+		lookupsCounter.WithLabelValues("miss").Inc()
+	}
+
+	// The whole user is read whatever fields are requested, as the cached user is read by every caller
+	response, err := service.repositoryService.ReadUser(ctx, &repository.ReadUserRequest{Email: request.Email})
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the existing users are cached, so a new user is never hidden by a cached not found
+	if value, err = json.Marshal(response.User); err == nil {
+		service.setLocal(key, value)
+		err = service.cacheStore.Set(ctx, key, value, service.ttl)
+	}
+
+	if err != nil {
+		correlation.GetLogger(ctx, service.logger).Warn("Failed to cache the user", zap.Error(err))
+	}
+
+	return response, nil
+}
+
+// ReadUsers reads the existing users with the given email addresses or IDs from the decorated repository with a single
+// query. The cache is bypassed, as it does not hold the cursors of the users nor is it keyed by the IDs of the users.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the existing users
+// Returns either the users found or error if something goes wrong.
+func (service *cachedRepositoryService) ReadUsers(
+	ctx context.Context,
+	request *repository.ReadUsersRequest) (*repository.ReadUsersResponse, error) {
+	return service.repositoryService.ReadUsers(ctx, request)
+}
+
+// UpdateUser update an existing user and removes it from the cache
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to update an existing user
+// Returns either the result of updateing an existing user or error if something goes wrong.
+func (service *cachedRepositoryService) UpdateUser(
+	ctx context.Context,
+	request *repository.UpdateUserRequest) (*repository.UpdateUserResponse, error) {
+	defer service.invalidate(ctx, request.Email)
+
+	return service.repositoryService.UpdateUser(ctx, request)
+}
+
+// ChangeEmail changes the email address of an existing user and removes the user from the cache by both its
+// current and new email addresses
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the current and the new email addresses of the user
+// Returns either the user after its email address is changed or error if something goes wrong.
+func (service *cachedRepositoryService) ChangeEmail(
+	ctx context.Context,
+	request *repository.ChangeEmailRequest) (*repository.ChangeEmailResponse, error) {
+	defer service.invalidate(ctx, request.Email)
+	defer service.invalidate(ctx, request.NewEmail)
+
+	return service.repositoryService.ChangeEmail(ctx, request)
+}
+
+// ReadUserPreferences reads the preferences of an existing user, the preferences are not part of the cached user so
+// they are never cached
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the preferences of an existing user
+// Returns either the preferences of the user or error if something goes wrong.
+func (service *cachedRepositoryService) ReadUserPreferences(
+	ctx context.Context,
+	request *repository.ReadUserPreferencesRequest) (*repository.ReadUserPreferencesResponse, error) {
+	return service.repositoryService.ReadUserPreferences(ctx, request)
+}
+
+// UpdateUserPreferences merges the preferences into the preferences of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the preferences to set and the keys of the preferences to remove
+// Returns either the preferences of the user after they are merged or error if something goes wrong.
+func (service *cachedRepositoryService) UpdateUserPreferences(
+	ctx context.Context,
+	request *repository.UpdateUserPreferencesRequest) (*repository.UpdateUserPreferencesResponse, error) {
+	return service.repositoryService.UpdateUserPreferences(ctx, request)
+}
+
+// AddUserToTenant adds the membership in the tenant to an existing user and removes the user from the cache
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the membership to add
+// Returns either the user after the membership is added or error if something goes wrong.
+func (service *cachedRepositoryService) AddUserToTenant(
+	ctx context.Context,
+	request *repository.AddUserToTenantRequest) (*repository.AddUserToTenantResponse, error) {
+	defer service.invalidate(ctx, request.Email)
+
+	return service.repositoryService.AddUserToTenant(ctx, request)
+}
+
+// RemoveUserFromTenant removes the membership in the tenant from an existing user and removes the user from the cache
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the tenant to remove the membership of
+// Returns either the user after the membership is removed or error if something goes wrong.
+func (service *cachedRepositoryService) RemoveUserFromTenant(
+	ctx context.Context,
+	request *repository.RemoveUserFromTenantRequest) (*repository.RemoveUserFromTenantResponse, error) {
+	defer service.invalidate(ctx, request.Email)
+
+	return service.repositoryService.RemoveUserFromTenant(ctx, request)
+}
+
+// SetEmailVerificationToken sets the pending email verification token of an existing user and removes the user from
+// the cache
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the hash of the token and the time it expires at
+// Returns either the user after the token is set or error if something goes wrong.
+func (service *cachedRepositoryService) SetEmailVerificationToken(
+	ctx context.Context,
+	request *repository.SetEmailVerificationTokenRequest) (*repository.SetEmailVerificationTokenResponse, error) {
+	defer service.invalidate(ctx, request.Email)
+
+	return service.repositoryService.SetEmailVerificationToken(ctx, request)
+}
+
+// VerifyEmail marks the email address of an existing user as verified and removes the user from the cache
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the hash of the pending verification token
+// Returns either the user after the email address is verified or error if something goes wrong.
+func (service *cachedRepositoryService) VerifyEmail(
+	ctx context.Context,
+	request *repository.VerifyEmailRequest) (*repository.VerifyEmailResponse, error) {
+	defer service.invalidate(ctx, request.Email)
+
+	return service.repositoryService.VerifyEmail(ctx, request)
+}
+
+// RecordLoginAttempt records the outcome of a login attempt of an existing user and removes the user from the cache
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the outcome of the attempt and the lockout threshold
+// Returns either the user after the attempt is recorded or error if something goes wrong.
+func (service *cachedRepositoryService) RecordLoginAttempt(
+	ctx context.Context,
+	request *repository.RecordLoginAttemptRequest) (*repository.RecordLoginAttemptResponse, error) {
+	defer service.invalidate(ctx, request.Email)
+
+	return service.repositoryService.RecordLoginAttempt(ctx, request)
+}
+
+// UnlockUser unlocks an existing user and removes the user from the cache
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to unlock an existing user
+// Returns either the user after it is unlocked or error if something goes wrong.
+func (service *cachedRepositoryService) UnlockUser(
+	ctx context.Context,
+	request *repository.UnlockUserRequest) (*repository.UnlockUserResponse, error) {
+	defer service.invalidate(ctx, request.Email)
+
+	return service.repositoryService.UnlockUser(ctx, request)
+}
+
+// AddMFAMethod adds the MFA method to an existing user and removes the user from the cache
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the method to add
+// Returns either the user after the method is added or error if something goes wrong.
+func (service *cachedRepositoryService) AddMFAMethod(
+	ctx context.Context,
+	request *repository.AddMFAMethodRequest) (*repository.AddMFAMethodResponse, error) {
+	defer service.invalidate(ctx, request.Email)
+
+	return service.repositoryService.AddMFAMethod(ctx, request)
+}
+
+// RemoveMFAMethod removes the MFA method from an existing user and removes the user from the cache
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the ID of the method to remove
+// Returns either the user after the method is removed or error if something goes wrong.
+func (service *cachedRepositoryService) RemoveMFAMethod(
+	ctx context.Context,
+	request *repository.RemoveMFAMethodRequest) (*repository.RemoveMFAMethodResponse, error) {
+	defer service.invalidate(ctx, request.Email)
+
+	return service.repositoryService.RemoveMFAMethod(ctx, request)
+}
+
+// SetUserAvatar sets the avatar of an existing user and removes the user from the cache
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the key and the hash of the stored avatar image
+// Returns either the user after the avatar is set or error if something goes wrong.
+func (service *cachedRepositoryService) SetUserAvatar(
+	ctx context.Context,
+	request *repository.SetUserAvatarRequest) (*repository.SetUserAvatarResponse, error) {
+	defer service.invalidate(ctx, request.Email)
+
+	return service.repositoryService.SetUserAvatar(ctx, request)
+}
+
+// DeleteUser delete an existing user and removes it from the cache
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to delete an existing user
+// Returns either the result of deleting an existing user or error if something goes wrong.
+func (service *cachedRepositoryService) DeleteUser(
+	ctx context.Context,
+	request *repository.DeleteUserRequest) (*repository.DeleteUserResponse, error) {
+	defer service.invalidate(ctx, request.Email)
+
+	return service.repositoryService.DeleteUser(ctx, request)
+}
+
+// RestoreUser restores an existing soft deleted user and removes it from the cache
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to restore an existing soft deleted user
+// Returns either the result of restoring the user or error if something goes wrong.
+func (service *cachedRepositoryService) RestoreUser(
+	ctx context.Context,
+	request *repository.RestoreUserRequest) (*repository.RestoreUserResponse, error) {
+	defer service.invalidate(ctx, request.Email)
+
+	return service.repositoryService.RestoreUser(ctx, request)
+}
+
+// PurgeDeletedUsers permanently deletes the soft deleted users that are deleted before the given time. The soft
+// deleted users are removed from the cache when they are deleted, so the cache is left as is.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to purge the soft deleted users
+// Returns either the result of purging the users or error if something goes wrong.
+func (service *cachedRepositoryService) PurgeDeletedUsers(
+	ctx context.Context,
+	request *repository.PurgeDeletedUsersRequest) (*repository.PurgeDeletedUsersResponse, error) {
+	return service.repositoryService.PurgeDeletedUsers(ctx, request)
+}
+
+// ListUsersByLabel lists all the users tagged with the given test label, the users are never read from the cache
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to list the users tagged with the test label
+// Returns either the users tagged with the test label or error if something goes wrong.
+func (service *cachedRepositoryService) ListUsersByLabel(
+	ctx context.Context,
+	request *repository.ListUsersByLabelRequest) (*repository.ListUsersByLabelResponse, error) {
+	return service.repositoryService.ListUsersByLabel(ctx, request)
+}
+
+// PurgeUsersByLabel permanently deletes all the users tagged with the given test label and removes them from the cache
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to purge the users tagged with the test label
+// Returns either the result of purging the users or error if something goes wrong.
+func (service *cachedRepositoryService) PurgeUsersByLabel(
+	ctx context.Context,
+	request *repository.PurgeUsersByLabelRequest) (*repository.PurgeUsersByLabelResponse, error) {
+	response, err := service.repositoryService.PurgeUsersByLabel(ctx, request)
+
+	// The patterns match the same email addresses as models.GetTestLabelEmailPattern
+	for _, separator := range []string{"-", "@"} {
+		pattern := escapeGlob(service.keyPrefix) + "*+" + escapeGlob(request.Label) + separator + "*"
+		if invalidateErr := service.cacheStore.DeleteMatching(ctx, pattern); invalidateErr != nil {
+			service.reportInvalidationFailure(ctx, invalidateErr)
+		}
+	}
+
+	// The purged users can not be told apart from the keys kept in memory without reading them, and the purges are
+	// rare, so all the users kept in memory are removed
+	service.broadcast(ctx, Invalidation{All: true})
+
+	return response, err
+}
+
+// Search returns the list of users that matched the criteria, the search results are never cached
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria
+// Returns the list of users that matched the criteria
+func (service *cachedRepositoryService) Search(
+	ctx context.Context,
+	request *repository.SearchRequest) (*repository.SearchResponse, error) {
+	return service.repositoryService.Search(ctx, request)
+}
+
+// StreamSearch sends the users that matched the criteria one by one without loading all of them in memory
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria and the function to send the users to
+// Returns either the number of the sent users or error if something goes wrong.
+func (service *cachedRepositoryService) StreamSearch(
+	ctx context.Context,
+	request *repository.StreamSearchRequest) (*repository.StreamSearchResponse, error) {
+	return service.repositoryService.StreamSearch(ctx, request)
+}
+
+// Ping verifies the repository can reach the underlying database. The cache is not checked as the users are read
+// from the database while the cache is not reachable.
+// ctx: Mandatory The reference to the context
+// Returns error if the database is not reachable
+func (service *cachedRepositoryService) Ping(ctx context.Context) error {
+	return service.repositoryService.Ping(ctx)
+}
+
+// invalidate removes the user from the cache. It is called whether or not the change succeeded, as a failed change
+// may still have been applied, e.g. if the connection dropped before the database replied. The user is only cached
+// by the key of its own tenant and by the key of the reads that are not scoped to a tenant, so a change scoped to a
+// tenant only removes these two keys.
+func (service *cachedRepositoryService) invalidate(ctx context.Context, email string) {
+	tenantID := repository.GetTenant(ctx)
+	if tenantID == "" && service.multiTenant {
+		// The change is not scoped to a tenant, e.g. it is made by a background job, so the tenant of the user is not
+		// known and the user is removed whatever tenant it is cached by. These changes are rare, so all the users
+		// kept in memory are removed.
+		if err := service.cacheStore.DeleteMatching(ctx, escapeGlob(service.keyPrefix)+"*/"+escapeGlob(email)); err != nil {
+			service.reportInvalidationFailure(ctx, err)
+		}
+
+		service.broadcast(ctx, Invalidation{All: true})
+
+		return
+	}
+
+	keys := []string{service.getKey("", email)}
+	if tenantID != "" {
+		keys = append(keys, service.getKey(tenantID, email))
+	}
+
+	if err := service.cacheStore.Delete(ctx, keys...); err != nil {
+		service.reportInvalidationFailure(ctx, err)
+	}
+
+	service.broadcast(ctx, Invalidation{Keys: keys})
+}
+
+// broadcast removes the users from the memory of this replica and broadcasts the invalidation to the other replicas.
+// The other replicas serve the stale users they keep in memory until they expire if the broadcast fails.
+func (service *cachedRepositoryService) broadcast(ctx context.Context, invalidation Invalidation) {
+	if service.localCache == nil {
+		return
+	}
+
+	service.removeLocal(invalidation)
+
+	if err := service.invalidationBus.Publish(ctx, invalidation); err != nil {
+		invalidationFailuresCounter.Inc()
+		correlation.GetLogger(ctx, service.logger).Error(
+			"Failed to broadcast the changed user to the replicas, the stale user is served until it expires",
+			zap.Duration("ttl", service.localCache.ttl),
+			zap.Error(err))
+	}
+}
+
+// applyInvalidation removes the users broadcast by a replica, including this one, from the memory of this replica
+func (service *cachedRepositoryService) applyInvalidation(invalidation Invalidation) {
+	invalidationsReceivedCounter.Inc()
+	service.removeLocal(invalidation)
+}
+
+func (service *cachedRepositoryService) removeLocal(invalidation Invalidation) {
+	if invalidation.All {
+		service.localCache.clear()
+
+		return
+	}
+
+	service.localCache.delete(invalidation.Keys...)
+}
+
+func (service *cachedRepositoryService) setLocal(key string, value []byte) {
+	if service.localCache != nil {
+		service.localCache.set(key, value, service.clockService.Now())
+	}
+}
+
+func (service *cachedRepositoryService) reportInvalidationFailure(ctx context.Context, err error) {
+	invalidationFailuresCounter.Inc()
+	correlation.GetLogger(ctx, service.logger).Error(
+		"Failed to remove the changed user from the cache, the stale user is served until it expires",
+		zap.Duration("ttl", service.ttl),
+		zap.Error(err))
+}
+
+// readCachedUser returns the user found in the cache. The users are cached by the tenant the reads are scoped to, so
+// the user of another tenant is never expected, but it is still reported as not found, as the repository would.
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address the user is cached by
+// user: Mandatory. The user found in the cache
+// Returns either the result of reading the user or error if the caller is not allowed to read the user
+func readCachedUser(ctx context.Context, email string, user models.User) (*repository.ReadUserResponse, error) {
+	if !repository.IsReadable(ctx, user) {
+		return nil, commonErrors.NewNotFoundError()
+	}
+
+	return &repository.ReadUserResponse{Email: email, User: user}, nil
+}
+
+// getKey returns the key the user is cached under for the reads scoped to the given tenant. The tenant is escaped, so
+// the first slash after the prefix always ends the tenant and the keys of different tenants never collide.
+// tenantID: Optional. The tenant the reads are scoped to, empty for the reads that are not scoped to a tenant
+// email: Mandatory. The email address of the user
+func (service *cachedRepositoryService) getKey(tenantID string, email string) string {
+	return service.keyPrefix + url.PathEscape(tenantID) + "/" + email
+}
+
+// escapeGlob escapes the characters that have a special meaning in the Redis glob patterns
+func escapeGlob(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`).Replace(value)
+}
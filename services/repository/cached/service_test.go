@@ -0,0 +1,669 @@
+package cached_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/repository/cached"
+	cachedMock "github.com/decentralized-cloud/user/services/repository/cached/mock"
+	repositoryMock "github.com/decentralized-cloud/user/services/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestCachedRepositoryService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cached Repository Service Tests")
+}
+
+var _ = Describe("Cached Repository Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      repository.RepositoryContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		mockClockService         *clockMock.MockClockContract
+		mockRepositoryService    *repositoryMock.MockRepositoryContract
+		mockCacheStore           *cachedMock.MockCacheStoreContract
+		mockInvalidationBus      *cachedMock.MockInvalidationBusContract
+		ctx                      context.Context
+		email                    string
+		key                      string
+		ttl                      time.Duration
+		localTTL                 time.Duration
+		tenancyMode              string
+		now                      time.Time
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		ctx = context.Background()
+		email = cuid.New() + "@test.com"
+		key = "user:/" + email
+		ttl = 5 * time.Minute
+		localTTL = 0
+		tenancyMode = "single"
+		now = time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetCacheTTL().
+			Return(ttl, nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetCacheKeyPrefix().
+			Return("user:", nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetTenancyMode().
+			DoAndReturn(func() (string, error) { return tenancyMode, nil }).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetCacheLocalTTL().
+			DoAndReturn(func() (time.Duration, error) { return localTTL, nil }).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetCacheLocalMaxEntries().
+			Return(10000, nil).
+			AnyTimes()
+
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			DoAndReturn(func() time.Time { return now }).
+			AnyTimes()
+
+		mockRepositoryService = repositoryMock.NewMockRepositoryContract(mockCtrl)
+		mockCacheStore = cachedMock.NewMockCacheStoreContract(mockCtrl)
+		mockInvalidationBus = cachedMock.NewMockInvalidationBusContract(mockCtrl)
+		sut, _ = cached.NewCachedRepositoryService(zap.NewNop(), mockConfigurationService, mockClockService, mockRepositoryService, mockCacheStore, nil)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate CachedRepositoryService", func() {
+		When("logger is not provided and NewCachedRepositoryService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := cached.NewCachedRepositoryService(nil, mockConfigurationService, mockClockService, mockRepositoryService, mockCacheStore, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("configuration service is not provided and NewCachedRepositoryService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := cached.NewCachedRepositoryService(zap.NewNop(), nil, mockClockService, mockRepositoryService, mockCacheStore, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("clock service is not provided and NewCachedRepositoryService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := cached.NewCachedRepositoryService(zap.NewNop(), mockConfigurationService, nil, mockRepositoryService, mockCacheStore, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("repository service is not provided and NewCachedRepositoryService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := cached.NewCachedRepositoryService(zap.NewNop(), mockConfigurationService, mockClockService, nil, mockCacheStore, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("cache store is not provided and NewCachedRepositoryService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := cached.NewCachedRepositoryService(zap.NewNop(), mockConfigurationService, mockClockService, mockRepositoryService, nil, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("the users are kept in memory, invalidation bus is not provided and NewCachedRepositoryService is called", func() {
+			It("should return ArgumentNilError", func() {
+				localTTL = time.Minute
+
+				service, err := cached.NewCachedRepositoryService(zap.NewNop(), mockConfigurationService, mockClockService, mockRepositoryService, mockCacheStore, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("all dependencies are resolved and NewCachedRepositoryService is called", func() {
+			It("should instantiate the new CachedRepositoryService", func() {
+				service, err := cached.NewCachedRepositoryService(zap.NewNop(), mockConfigurationService, mockClockService, mockRepositoryService, mockCacheStore, nil)
+				Ω(err).Should(BeNil())
+				Ω(service).ShouldNot(BeNil())
+			})
+		})
+	})
+
+	Describe("ReadUser is called", func() {
+		var (
+			request repository.ReadUserRequest
+		)
+
+		BeforeEach(func() {
+			request = repository.ReadUserRequest{Email: email}
+		})
+
+		When("the user is cached", func() {
+			It("should return the cached user without reading the repository", func() {
+				mockCacheStore.
+					EXPECT().
+					Get(ctx, key).
+					Return([]byte("{}"), true, nil)
+
+				response, err := sut.ReadUser(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response).ShouldNot(BeNil())
+			})
+		})
+
+		When("the user is not cached", func() {
+			It("should read the user from the repository and cache it", func() {
+				mockCacheStore.
+					EXPECT().
+					Get(ctx, key).
+					Return(nil, false, nil)
+
+				user := models.User{
+					Memberships: []models.TenantMembership{{TenantID: cuid.New(), Role: models.TenantRoleMember, JoinedAt: time.Now().UTC()}},
+				}
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &request).
+					Return(&repository.ReadUserResponse{User: user}, nil)
+
+				encodedUser, _ := json.Marshal(user)
+				mockCacheStore.
+					EXPECT().
+					Set(ctx, key, encodedUser, ttl).
+					Return(nil)
+
+				response, err := sut.ReadUser(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.User).Should(Equal(user))
+			})
+		})
+
+		When("the cache store is not reachable", func() {
+			It("should read the user from the repository", func() {
+				mockCacheStore.
+					EXPECT().
+					Get(ctx, key).
+					Return(nil, false, errors.New(cuid.New()))
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &request).
+					Return(&repository.ReadUserResponse{}, nil)
+
+				mockCacheStore.
+					EXPECT().
+					Set(ctx, key, gomock.Any(), ttl).
+					Return(errors.New(cuid.New()))
+
+				response, err := sut.ReadUser(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response).ShouldNot(BeNil())
+			})
+		})
+
+		When("the repository returns error", func() {
+			It("should return the same error without caching it", func() {
+				expectedError := commonErrors.NewNotFoundError()
+				mockCacheStore.
+					EXPECT().
+					Get(ctx, key).
+					Return(nil, false, nil)
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &request).
+					Return(nil, expectedError)
+
+				response, err := sut.ReadUser(ctx, &request)
+				Ω(response).Should(BeNil())
+				Ω(err).Should(Equal(expectedError))
+			})
+		})
+	})
+
+	Describe("the tenant memberships are changed", func() {
+		When("the repository adds the user to a tenant", func() {
+			It("should remove the user from the cache", func() {
+				request := repository.AddUserToTenantRequest{
+					Email:      email,
+					Membership: models.TenantMembership{TenantID: cuid.New(), Role: models.TenantRoleMember},
+				}
+				expectedResponse := &repository.AddUserToTenantResponse{Cursor: cuid.New()}
+
+				gomock.InOrder(
+					mockRepositoryService.
+						EXPECT().
+						AddUserToTenant(ctx, &request).
+						Return(expectedResponse, nil),
+					mockCacheStore.
+						EXPECT().
+						Delete(ctx, key).
+						Return(nil))
+
+				response, err := sut.AddUserToTenant(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response).Should(Equal(expectedResponse))
+			})
+		})
+
+		When("the repository removes the user from a tenant", func() {
+			It("should remove the user from the cache", func() {
+				request := repository.RemoveUserFromTenantRequest{Email: email, TenantID: cuid.New()}
+				expectedResponse := &repository.RemoveUserFromTenantResponse{Cursor: cuid.New()}
+
+				gomock.InOrder(
+					mockRepositoryService.
+						EXPECT().
+						RemoveUserFromTenant(ctx, &request).
+						Return(expectedResponse, nil),
+					mockCacheStore.
+						EXPECT().
+						Delete(ctx, key).
+						Return(nil))
+
+				response, err := sut.RemoveUserFromTenant(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response).Should(Equal(expectedResponse))
+			})
+		})
+	})
+
+	Describe("UpdateUser is called", func() {
+		When("the repository updates the user", func() {
+			It("should remove the user from the cache", func() {
+				request := repository.UpdateUserRequest{Email: email}
+				expectedResponse := &repository.UpdateUserResponse{Cursor: cuid.New()}
+
+				gomock.InOrder(
+					mockRepositoryService.
+						EXPECT().
+						UpdateUser(ctx, &request).
+						Return(expectedResponse, nil),
+					mockCacheStore.
+						EXPECT().
+						Delete(ctx, key).
+						Return(nil))
+
+				response, err := sut.UpdateUser(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response).Should(Equal(expectedResponse))
+			})
+		})
+
+		When("the repository returns error", func() {
+			It("should still remove the user from the cache and return the same error", func() {
+				request := repository.UpdateUserRequest{Email: email}
+				expectedError := errors.New(cuid.New())
+
+				mockRepositoryService.
+					EXPECT().
+					UpdateUser(ctx, &request).
+					Return(nil, expectedError)
+
+				mockCacheStore.
+					EXPECT().
+					Delete(ctx, key).
+					Return(nil)
+
+				response, err := sut.UpdateUser(ctx, &request)
+				Ω(response).Should(BeNil())
+				Ω(err).Should(Equal(expectedError))
+			})
+		})
+
+		When("the cache store fails to remove the user", func() {
+			It("should return the result of the repository", func() {
+				request := repository.UpdateUserRequest{Email: email}
+				expectedResponse := &repository.UpdateUserResponse{Cursor: cuid.New()}
+
+				mockRepositoryService.
+					EXPECT().
+					UpdateUser(ctx, &request).
+					Return(expectedResponse, nil)
+
+				mockCacheStore.
+					EXPECT().
+					Delete(ctx, key).
+					Return(errors.New(cuid.New()))
+
+				response, err := sut.UpdateUser(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response).Should(Equal(expectedResponse))
+			})
+		})
+	})
+
+	Describe("DeleteUser is called", func() {
+		It("should remove the user from the cache", func() {
+			request := repository.DeleteUserRequest{Email: email, SoftDelete: true}
+
+			gomock.InOrder(
+				mockRepositoryService.
+					EXPECT().
+					DeleteUser(ctx, &request).
+					Return(&repository.DeleteUserResponse{}, nil),
+				mockCacheStore.
+					EXPECT().
+					Delete(ctx, key).
+					Return(nil))
+
+			response, err := sut.DeleteUser(ctx, &request)
+			Ω(err).Should(BeNil())
+			Ω(response).ShouldNot(BeNil())
+		})
+	})
+
+	Describe("RestoreUser is called", func() {
+		It("should remove the user from the cache", func() {
+			request := repository.RestoreUserRequest{Email: email}
+
+			gomock.InOrder(
+				mockRepositoryService.
+					EXPECT().
+					RestoreUser(ctx, &request).
+					Return(&repository.RestoreUserResponse{}, nil),
+				mockCacheStore.
+					EXPECT().
+					Delete(ctx, key).
+					Return(nil))
+
+			response, err := sut.RestoreUser(ctx, &request)
+			Ω(err).Should(BeNil())
+			Ω(response).ShouldNot(BeNil())
+		})
+	})
+
+	Describe("the users of the tenants are cached", func() {
+		var (
+			tenantID  string
+			tenantCtx context.Context
+		)
+
+		BeforeEach(func() {
+			tenantID = cuid.New()
+			tenantCtx = context.WithValue(ctx, models.ContextKeyParsedToken, models.ParsedToken{TenantID: tenantID})
+		})
+
+		When("the read is scoped to a tenant", func() {
+			It("should read the user cached by the key of the tenant", func() {
+				mockCacheStore.
+					EXPECT().
+					Get(tenantCtx, "user:"+tenantID+"/"+email).
+					Return([]byte(`{"TenantID":"`+tenantID+`"}`), true, nil)
+
+				response, err := sut.ReadUser(tenantCtx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(response.User.TenantID).Should(Equal(tenantID))
+			})
+		})
+
+		When("the change is scoped to a tenant", func() {
+			It("should remove the user cached by the key of the tenant and by the key of the reads not scoped to a tenant", func() {
+				request := repository.UpdateUserRequest{Email: email}
+
+				mockRepositoryService.
+					EXPECT().
+					UpdateUser(tenantCtx, &request).
+					Return(&repository.UpdateUserResponse{}, nil)
+
+				mockCacheStore.
+					EXPECT().
+					Delete(tenantCtx, key, "user:"+tenantID+"/"+email).
+					Return(nil)
+
+				_, err := sut.UpdateUser(tenantCtx, &request)
+				Ω(err).Should(BeNil())
+			})
+		})
+
+		When("the change is not scoped to a tenant in the multi-tenant mode", func() {
+			It("should remove the user cached by the key of any tenant", func() {
+				tenancyMode = "multi"
+				sut, _ = cached.NewCachedRepositoryService(zap.NewNop(), mockConfigurationService, mockClockService, mockRepositoryService, mockCacheStore, nil)
+				request := repository.UpdateUserRequest{Email: email}
+
+				mockRepositoryService.
+					EXPECT().
+					UpdateUser(ctx, &request).
+					Return(&repository.UpdateUserResponse{}, nil)
+
+				mockCacheStore.
+					EXPECT().
+					DeleteMatching(ctx, "user:*/"+email).
+					Return(nil)
+
+				_, err := sut.UpdateUser(ctx, &request)
+				Ω(err).Should(BeNil())
+			})
+		})
+	})
+
+	Describe("PurgeUsersByLabel is called", func() {
+		It("should remove the users tagged with the label from the cache", func() {
+			request := repository.PurgeUsersByLabelRequest{Label: "load-test"}
+
+			mockRepositoryService.
+				EXPECT().
+				PurgeUsersByLabel(ctx, &request).
+				Return(&repository.PurgeUsersByLabelResponse{PurgedCount: 2}, nil)
+
+			mockCacheStore.
+				EXPECT().
+				DeleteMatching(ctx, "user:*+load-test-*").
+				Return(nil)
+
+			mockCacheStore.
+				EXPECT().
+				DeleteMatching(ctx, "user:*+load-test@*").
+				Return(nil)
+
+			response, err := sut.PurgeUsersByLabel(ctx, &request)
+			Ω(err).Should(BeNil())
+			Ω(response.PurgedCount).Should(Equal(int64(2)))
+		})
+	})
+
+	Describe("the users are kept in memory", func() {
+		var (
+			handler func(invalidation cached.Invalidation)
+		)
+
+		BeforeEach(func() {
+			localTTL = time.Minute
+
+			mockInvalidationBus.
+				EXPECT().
+				Subscribe(gomock.Any()).
+				DoAndReturn(func(subscribedHandler func(invalidation cached.Invalidation)) error {
+					handler = subscribedHandler
+
+					return nil
+				})
+
+			sut, _ = cached.NewCachedRepositoryService(zap.NewNop(), mockConfigurationService, mockClockService, mockRepositoryService, mockCacheStore, mockInvalidationBus)
+		})
+
+		When("the user is read again", func() {
+			It("should return the user kept in memory without reading the cache store", func() {
+				request := repository.ReadUserRequest{Email: email}
+
+				mockCacheStore.
+					EXPECT().
+					Get(ctx, key).
+					Return([]byte("{}"), true, nil).
+					Times(1)
+
+				_, err := sut.ReadUser(ctx, &request)
+				Ω(err).Should(BeNil())
+
+				response, err := sut.ReadUser(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response).ShouldNot(BeNil())
+			})
+		})
+
+		When("the user kept in memory has expired", func() {
+			It("should read the user from the cache store again", func() {
+				request := repository.ReadUserRequest{Email: email}
+
+				mockCacheStore.
+					EXPECT().
+					Get(ctx, key).
+					Return([]byte("{}"), true, nil).
+					Times(2)
+
+				_, err := sut.ReadUser(ctx, &request)
+				Ω(err).Should(BeNil())
+
+				now = now.Add(localTTL)
+
+				_, err = sut.ReadUser(ctx, &request)
+				Ω(err).Should(BeNil())
+			})
+		})
+
+		When("a replica broadcasts the user is changed", func() {
+			It("should read the user from the cache store again", func() {
+				request := repository.ReadUserRequest{Email: email}
+
+				mockCacheStore.
+					EXPECT().
+					Get(ctx, key).
+					Return([]byte("{}"), true, nil).
+					Times(2)
+
+				_, err := sut.ReadUser(ctx, &request)
+				Ω(err).Should(BeNil())
+
+				handler(cached.Invalidation{Keys: []string{key}})
+
+				_, err = sut.ReadUser(ctx, &request)
+				Ω(err).Should(BeNil())
+			})
+		})
+
+		When("the user is changed", func() {
+			It("should remove the user from memory and broadcast the change to the replicas", func() {
+				readRequest := repository.ReadUserRequest{Email: email}
+				updateRequest := repository.UpdateUserRequest{Email: email}
+
+				mockCacheStore.
+					EXPECT().
+					Get(ctx, key).
+					Return([]byte("{}"), true, nil).
+					Times(2)
+
+				mockRepositoryService.
+					EXPECT().
+					UpdateUser(ctx, &updateRequest).
+					Return(&repository.UpdateUserResponse{}, nil)
+
+				gomock.InOrder(
+					mockCacheStore.
+						EXPECT().
+						Delete(ctx, key).
+						Return(nil),
+					mockInvalidationBus.
+						EXPECT().
+						Publish(ctx, cached.Invalidation{Keys: []string{key}}).
+						Return(nil))
+
+				_, err := sut.ReadUser(ctx, &readRequest)
+				Ω(err).Should(BeNil())
+
+				_, err = sut.UpdateUser(ctx, &updateRequest)
+				Ω(err).Should(BeNil())
+
+				_, err = sut.ReadUser(ctx, &readRequest)
+				Ω(err).Should(BeNil())
+			})
+		})
+
+		When("the users are purged by label", func() {
+			It("should broadcast the invalidation of all the users", func() {
+				request := repository.PurgeUsersByLabelRequest{Label: "load-test"}
+
+				mockRepositoryService.
+					EXPECT().
+					PurgeUsersByLabel(ctx, &request).
+					Return(&repository.PurgeUsersByLabelResponse{}, nil)
+
+				mockCacheStore.
+					EXPECT().
+					DeleteMatching(ctx, gomock.Any()).
+					Return(nil).
+					Times(2)
+
+				mockInvalidationBus.
+					EXPECT().
+					Publish(ctx, cached.Invalidation{All: true}).
+					Return(nil)
+
+				_, err := sut.PurgeUsersByLabel(ctx, &request)
+				Ω(err).Should(BeNil())
+			})
+		})
+
+		When("the broadcast fails", func() {
+			It("should return the result of the repository", func() {
+				request := repository.DeleteUserRequest{Email: email}
+				expectedResponse := &repository.DeleteUserResponse{}
+
+				mockRepositoryService.
+					EXPECT().
+					DeleteUser(ctx, &request).
+					Return(expectedResponse, nil)
+
+				mockCacheStore.
+					EXPECT().
+					Delete(ctx, key).
+					Return(nil)
+
+				mockInvalidationBus.
+					EXPECT().
+					Publish(ctx, gomock.Any()).
+					Return(errors.New(cuid.New()))
+
+				response, err := sut.DeleteUser(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response).Should(Equal(expectedResponse))
+			})
+		})
+	})
+})
@@ -0,0 +1,659 @@
+// Package conformance implements the specs every implementation of the repository contract has to pass, so the
+// repositories keep the same semantics for the errors, the cursors, the ordering and the concurrent updates no matter
+// which database they persist the users in.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// concurrentCalls is the number of the calls the concurrency specs make at the same time
+const concurrentCalls = 10
+
+// NewRepositoryFunc creates the repository the specs run against. The repositories that persist the users in a
+// shared database do not need to empty it, the specs only create the users with unique email addresses and only
+// search for the users they created.
+type NewRepositoryFunc func() repository.RepositoryContract
+
+// DescribeRepositoryContract registers the conformance specs in the test suite of the repository
+// name: Mandatory. The name of the repository the specs are described with
+// newRepository: Mandatory. Creates the repository before each spec
+// Returns true, so the specs can be registered at the package level with var _ =
+func DescribeRepositoryContract(name string, newRepository NewRepositoryFunc) bool {
+	return Describe(name+" Repository Contract Conformance Tests", func() {
+		var (
+			sut repository.RepositoryContract
+			ctx context.Context
+		)
+
+		BeforeEach(func() {
+			sut = newRepository()
+			ctx = context.Background()
+		})
+
+		createUsers := func(count int) []models.UserWithCursor {
+			users := []models.UserWithCursor{}
+			for index := 0; index < count; index++ {
+				email := cuid.New() + "@test.com"
+				response, err := sut.CreateUser(ctx, &repository.CreateUserRequest{Email: email, User: models.User{}})
+				Ω(err).Should(BeNil())
+
+				users = append(users, models.UserWithCursor{UserID: response.User.ID, Email: email, Cursor: response.Cursor})
+			}
+
+			return users
+		}
+
+		emailsOf := func(users []models.UserWithCursor) []string {
+			emails := []string{}
+			for _, user := range users {
+				emails = append(emails, user.Email)
+			}
+
+			return emails
+		}
+
+		Context("errors", func() {
+			When("the user does not exist", func() {
+				It("should return NotFoundError from every operation on the user", func() {
+					email := cuid.New() + "@test.com"
+
+					_, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+					_, err = sut.ReadUser(ctx, &repository.ReadUserRequest{UserID: cuid.New()})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+					_, err = sut.UpdateUser(ctx, &repository.UpdateUserRequest{Email: email, User: models.User{}})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+					_, err = sut.ChangeEmail(ctx, &repository.ChangeEmailRequest{Email: email, NewEmail: cuid.New() + "@test.com"})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+					_, err = sut.ReadUserPreferences(ctx, &repository.ReadUserPreferencesRequest{Email: email})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+					_, err = sut.UpdateUserPreferences(ctx, &repository.UpdateUserPreferencesRequest{
+						Email:       email,
+						Preferences: map[string]string{models.PreferenceTheme: "dark"},
+					})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+					_, err = sut.AddUserToTenant(ctx, &repository.AddUserToTenantRequest{
+						Email:      email,
+						Membership: models.TenantMembership{TenantID: cuid.New(), Role: models.TenantRoleMember},
+					})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+					_, err = sut.RecordLoginAttempt(ctx, &repository.RecordLoginAttemptRequest{Email: email, LockoutThreshold: 1})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+					_, err = sut.UnlockUser(ctx, &repository.UnlockUserRequest{Email: email})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+					_, err = sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: email})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+					_, err = sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: email, SoftDelete: true})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+					_, err = sut.RestoreUser(ctx, &repository.RestoreUserRequest{Email: email})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+				})
+			})
+
+			When("the user already exists", func() {
+				It("should return AlreadyExistsError from creating it again and NotFoundError from restoring it", func() {
+					email := createUsers(1)[0].Email
+
+					response, err := sut.CreateUser(ctx, &repository.CreateUserRequest{Email: email, User: models.User{}})
+					Ω(response).Should(BeNil())
+					Ω(commonErrors.IsAlreadyExistsError(err)).Should(BeTrue())
+
+					_, err = sut.RestoreUser(ctx, &repository.RestoreUserRequest{Email: email})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+				})
+			})
+
+			When("the user is soft deleted", func() {
+				It("should return NotFoundError until the user is restored and AlreadyExistsError from creating it again", func() {
+					email := createUsers(1)[0].Email
+
+					_, err := sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: email, SoftDelete: true})
+					Ω(err).Should(BeNil())
+
+					_, err = sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+					_, err = sut.UpdateUser(ctx, &repository.UpdateUserRequest{Email: email, User: models.User{}})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+					_, err = sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: email, SoftDelete: true})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+					_, err = sut.CreateUser(ctx, &repository.CreateUserRequest{Email: email, User: models.User{}})
+					Ω(commonErrors.IsAlreadyExistsError(err)).Should(BeTrue())
+
+					_, err = sut.RestoreUser(ctx, &repository.RestoreUserRequest{Email: email})
+					Ω(err).Should(BeNil())
+
+					_, err = sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+					Ω(err).Should(BeNil())
+				})
+			})
+
+			When("the search result is sorted by an unsupported field", func() {
+				It("should return ArgumentError from both searching and streaming", func() {
+					sortingOptions := []models.SortingOptionPair{{Name: cuid.New(), Direction: models.Ascending}}
+
+					_, err := sut.Search(ctx, &repository.SearchRequest{SortingOptions: sortingOptions})
+					Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+
+					_, err = sut.StreamSearch(ctx, &repository.StreamSearchRequest{
+						SortingOptions: sortingOptions,
+						Send:           func(models.UserWithCursor) error { return nil },
+					})
+					Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+				})
+			})
+
+			When("the users are streamed after an invalid cursor", func() {
+				It("should return ArgumentError", func() {
+					_, err := sut.StreamSearch(ctx, &repository.StreamSearchRequest{
+						After: cuid.New(),
+						Send:  func(models.UserWithCursor) error { return nil },
+					})
+					Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+				})
+			})
+
+			When("sending a streamed user fails", func() {
+				It("should stop streaming and return UnknownError", func() {
+					users := createUsers(2)
+
+					sentCount := 0
+					_, err := sut.StreamSearch(ctx, &repository.StreamSearchRequest{
+						Emails: emailsOf(users),
+						Send: func(models.UserWithCursor) error {
+							sentCount++
+
+							return errors.New(cuid.New())
+						},
+					})
+					Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+					Ω(sentCount).Should(Equal(1))
+				})
+			})
+		})
+
+		Context("batch reads", func() {
+			When("some of the users do not exist or are soft deleted", func() {
+				It("should only return the existing users with the cursors they are searched with", func() {
+					users := createUsers(3)
+
+					_, err := sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: users[2].Email, SoftDelete: true})
+					Ω(err).Should(BeNil())
+
+					response, err := sut.ReadUsers(ctx, &repository.ReadUsersRequest{
+						Emails: append(emailsOf(users), cuid.New()+"@test.com"),
+					})
+					Ω(err).Should(BeNil())
+					Ω(emailsOf(response.Users)).Should(ConsistOf(users[0].Email, users[1].Email))
+
+					for _, user := range response.Users {
+						if user.Email == users[0].Email {
+							Ω(user.Cursor).Should(Equal(users[0].Cursor))
+						} else {
+							Ω(user.Cursor).Should(Equal(users[1].Cursor))
+						}
+					}
+				})
+			})
+
+			When("some of the users are named by their IDs", func() {
+				It("should return the users named by either their email addresses or their IDs once, along with their IDs", func() {
+					users := createUsers(3)
+
+					_, err := sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: users[2].Email, SoftDelete: true})
+					Ω(err).Should(BeNil())
+
+					response, err := sut.ReadUsers(ctx, &repository.ReadUsersRequest{
+						Emails:  []string{users[0].Email},
+						UserIDs: []string{users[0].UserID, users[1].UserID, users[2].UserID, cuid.New()},
+					})
+					Ω(err).Should(BeNil())
+					Ω(emailsOf(response.Users)).Should(ConsistOf(users[0].Email, users[1].Email))
+
+					for _, user := range response.Users {
+						if user.Email == users[0].Email {
+							Ω(user.User.ID).Should(Equal(users[0].UserID))
+						} else {
+							Ω(user.User.ID).Should(Equal(users[1].UserID))
+						}
+					}
+				})
+			})
+
+			When("no email address is given", func() {
+				It("should return no user", func() {
+					createUsers(1)
+
+					response, err := sut.ReadUsers(ctx, &repository.ReadUsersRequest{})
+					Ω(err).Should(BeNil())
+					Ω(response.Users).Should(BeEmpty())
+				})
+			})
+		})
+
+		Context("identity", func() {
+			When("the user is read by its ID", func() {
+				It("should return the user along with its email address until the user is soft deleted", func() {
+					user := createUsers(1)[0]
+					Ω(user.UserID).ShouldNot(BeEmpty())
+
+					response, err := sut.ReadUser(ctx, &repository.ReadUserRequest{UserID: user.UserID})
+					Ω(err).Should(BeNil())
+					Ω(response.Email).Should(Equal(user.Email))
+					Ω(response.User.ID).Should(Equal(user.UserID))
+
+					response, err = sut.ReadUser(ctx, &repository.ReadUserRequest{Email: user.Email})
+					Ω(err).Should(BeNil())
+					Ω(response.Email).Should(Equal(user.Email))
+					Ω(response.User.ID).Should(Equal(user.UserID))
+
+					_, err = sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: user.Email, SoftDelete: true})
+					Ω(err).Should(BeNil())
+
+					_, err = sut.ReadUser(ctx, &repository.ReadUserRequest{UserID: user.UserID})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+				})
+			})
+
+			When("the email address of the user is changed", func() {
+				It("should keep the ID and the cursor of the user and clear the verification", func() {
+					user := createUsers(1)[0]
+					newEmail := cuid.New() + "@test.com"
+
+					_, err := sut.SetEmailVerificationToken(ctx, &repository.SetEmailVerificationTokenRequest{
+						Email:     user.Email,
+						TokenHash: cuid.New(),
+						ExpiresAt: time.Now().Add(time.Hour),
+					})
+					Ω(err).Should(BeNil())
+
+					response, err := sut.ChangeEmail(ctx, &repository.ChangeEmailRequest{Email: user.Email, NewEmail: newEmail})
+					Ω(err).Should(BeNil())
+					Ω(response.User.ID).Should(Equal(user.UserID))
+					Ω(response.Cursor).Should(Equal(user.Cursor))
+					Ω(response.User.EmailVerified).Should(BeFalse())
+					Ω(response.User.EmailVerificationTokenHash).Should(BeEmpty())
+					Ω(response.User.EmailVerificationExpiresAt).Should(BeNil())
+
+					_, err = sut.ReadUser(ctx, &repository.ReadUserRequest{Email: user.Email})
+					Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+					readResponse, err := sut.ReadUser(ctx, &repository.ReadUserRequest{UserID: user.UserID})
+					Ω(err).Should(BeNil())
+					Ω(readResponse.Email).Should(Equal(newEmail))
+				})
+			})
+
+			When("another user has the new email address", func() {
+				It("should return AlreadyExistsError and leave the email address as it is", func() {
+					users := createUsers(2)
+
+					response, err := sut.ChangeEmail(ctx, &repository.ChangeEmailRequest{Email: users[0].Email, NewEmail: users[1].Email})
+					Ω(response).Should(BeNil())
+					Ω(commonErrors.IsAlreadyExistsError(err)).Should(BeTrue())
+
+					readResponse, err := sut.ReadUser(ctx, &repository.ReadUserRequest{UserID: users[0].UserID})
+					Ω(err).Should(BeNil())
+					Ω(readResponse.Email).Should(Equal(users[0].Email))
+				})
+			})
+		})
+
+		Context("cursors", func() {
+			When("the user is created, updated and restored", func() {
+				It("should keep returning the cursor the user is searched with", func() {
+					user := createUsers(1)[0]
+
+					updateResponse, err := sut.UpdateUser(ctx, &repository.UpdateUserRequest{
+						Email: user.Email,
+						User:  models.User{Labels: map[string]string{"plan": "pro"}},
+					})
+					Ω(err).Should(BeNil())
+					Ω(updateResponse.Cursor).Should(Equal(user.Cursor))
+
+					_, err = sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: user.Email, SoftDelete: true})
+					Ω(err).Should(BeNil())
+
+					restoreResponse, err := sut.RestoreUser(ctx, &repository.RestoreUserRequest{Email: user.Email})
+					Ω(err).Should(BeNil())
+					Ω(restoreResponse.Cursor).Should(Equal(user.Cursor))
+
+					searchResponse, err := sut.Search(ctx, &repository.SearchRequest{Emails: []string{user.Email}})
+					Ω(err).Should(BeNil())
+					Ω(searchResponse.Users).Should(HaveLen(1))
+					Ω(searchResponse.Users[0].Cursor).Should(Equal(user.Cursor))
+				})
+			})
+
+			When("the users are paged forward", func() {
+				It("should return every user exactly once", func() {
+					users := createUsers(5)
+					first := 2
+
+					pagedEmails := []string{}
+					request := repository.SearchRequest{Pagination: models.Pagination{First: &first}, Emails: emailsOf(users)}
+					for {
+						response, err := sut.Search(ctx, &request)
+						Ω(err).Should(BeNil())
+						Ω(response.TotalCount).Should(Equal(int64(len(users))))
+						Ω(response.HasPreviousPage).Should(Equal(request.Pagination.After != nil))
+
+						pagedEmails = append(pagedEmails, emailsOf(response.Users)...)
+						if !response.HasNextPage {
+							break
+						}
+
+						Ω(len(pagedEmails)).Should(BeNumerically("<", len(users)))
+						request.Pagination.After = &response.Users[len(response.Users)-1].Cursor
+					}
+
+					Ω(pagedEmails).Should(Equal(emailsOf(users)))
+				})
+			})
+
+			When("the users are paged backward", func() {
+				It("should return the users before the cursor", func() {
+					users := createUsers(4)
+					last := 2
+
+					response, err := sut.Search(ctx, &repository.SearchRequest{
+						Pagination: models.Pagination{Last: &last, Before: &users[3].Cursor},
+						Emails:     emailsOf(users),
+					})
+					Ω(err).Should(BeNil())
+					Ω(emailsOf(response.Users)).Should(Equal(emailsOf(users[1:3])))
+					Ω(response.HasPreviousPage).Should(BeTrue())
+					Ω(response.HasNextPage).Should(BeTrue())
+				})
+			})
+
+			When("the users are paged forward in the sorted order", func() {
+				It("should return every user exactly once in the requested order", func() {
+					users := createUsers(5)
+					descending := emailsOf(users)
+					sort.Sort(sort.Reverse(sort.StringSlice(descending)))
+					first := 2
+
+					pagedEmails := []string{}
+					request := repository.SearchRequest{
+						Pagination:     models.Pagination{First: &first},
+						SortingOptions: []models.SortingOptionPair{{Name: "email", Direction: models.Descending}},
+						Emails:         emailsOf(users),
+					}
+
+					for {
+						response, err := sut.Search(ctx, &request)
+						Ω(err).Should(BeNil())
+
+						pagedEmails = append(pagedEmails, emailsOf(response.Users)...)
+						if !response.HasNextPage {
+							break
+						}
+
+						Ω(len(pagedEmails)).Should(BeNumerically("<", len(users)))
+						request.Pagination.After = &response.Users[len(response.Users)-1].Cursor
+					}
+
+					Ω(pagedEmails).Should(Equal(descending))
+				})
+			})
+
+			When("the users are searched after or before an invalid cursor", func() {
+				It("should return ArgumentError", func() {
+					users := createUsers(1)
+					invalidCursor := cuid.New()
+
+					_, err := sut.Search(ctx, &repository.SearchRequest{
+						Pagination: models.Pagination{After: &invalidCursor},
+						Emails:     emailsOf(users),
+					})
+					Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+
+					_, err = sut.Search(ctx, &repository.SearchRequest{
+						Pagination: models.Pagination{Before: &invalidCursor},
+						Emails:     emailsOf(users),
+					})
+					Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+				})
+			})
+
+			When("the users are streamed after a cursor", func() {
+				It("should resume streaming after the user of the cursor", func() {
+					users := createUsers(3)
+
+					sentEmails := []string{}
+					response, err := sut.StreamSearch(ctx, &repository.StreamSearchRequest{
+						Emails: emailsOf(users),
+						After:  users[0].Cursor,
+						Send: func(user models.UserWithCursor) error {
+							sentEmails = append(sentEmails, user.Email)
+
+							return nil
+						},
+					})
+					Ω(err).Should(BeNil())
+					Ω(response.SentCount).Should(Equal(int64(2)))
+					Ω(sentEmails).Should(Equal(emailsOf(users[1:])))
+				})
+			})
+		})
+
+		Context("ordering", func() {
+			When("the search result is not sorted", func() {
+				It("should return the users in the order they are created", func() {
+					users := createUsers(3)
+
+					response, err := sut.Search(ctx, &repository.SearchRequest{Emails: emailsOf(users)})
+					Ω(err).Should(BeNil())
+					Ω(emailsOf(response.Users)).Should(Equal(emailsOf(users)))
+
+					sentEmails := []string{}
+					_, err = sut.StreamSearch(ctx, &repository.StreamSearchRequest{
+						Emails: emailsOf(users),
+						Send: func(user models.UserWithCursor) error {
+							sentEmails = append(sentEmails, user.Email)
+
+							return nil
+						},
+					})
+					Ω(err).Should(BeNil())
+					Ω(sentEmails).Should(Equal(emailsOf(users)))
+				})
+			})
+
+			When("the search result is sorted by email", func() {
+				It("should return the users in the requested order", func() {
+					users := createUsers(3)
+					ascending := emailsOf(users)
+					sort.Strings(ascending)
+
+					response, err := sut.Search(ctx, &repository.SearchRequest{
+						SortingOptions: []models.SortingOptionPair{{Name: "email", Direction: models.Ascending}},
+						Emails:         emailsOf(users),
+					})
+					Ω(err).Should(BeNil())
+					Ω(emailsOf(response.Users)).Should(Equal(ascending))
+
+					response, err = sut.Search(ctx, &repository.SearchRequest{
+						SortingOptions: []models.SortingOptionPair{{Name: "email", Direction: models.Descending}},
+						Emails:         emailsOf(users),
+					})
+					Ω(err).Should(BeNil())
+					Ω(emailsOf(response.Users)).Should(Equal([]string{ascending[2], ascending[1], ascending[0]}))
+				})
+			})
+
+			When("the soft deleted users are included", func() {
+				It("should keep the position of the soft deleted users", func() {
+					users := createUsers(3)
+
+					_, err := sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: users[1].Email, SoftDelete: true})
+					Ω(err).Should(BeNil())
+
+					response, err := sut.Search(ctx, &repository.SearchRequest{Emails: emailsOf(users)})
+					Ω(err).Should(BeNil())
+					Ω(emailsOf(response.Users)).Should(Equal([]string{users[0].Email, users[2].Email}))
+
+					response, err = sut.Search(ctx, &repository.SearchRequest{Emails: emailsOf(users), IncludeDeleted: true})
+					Ω(err).Should(BeNil())
+					Ω(emailsOf(response.Users)).Should(Equal(emailsOf(users)))
+					Ω(response.Users[1].DeletedAt).ShouldNot(BeNil())
+				})
+			})
+		})
+
+		Context("concurrency", func() {
+			When("the same user is created concurrently", func() {
+				It("should only create the user once", func() {
+					email := cuid.New() + "@test.com"
+
+					errs := callConcurrently(func() error {
+						_, err := sut.CreateUser(ctx, &repository.CreateUserRequest{Email: email, User: models.User{}})
+
+						return err
+					})
+
+					Ω(countErrors(errs, nil)).Should(Equal(1))
+					Ω(countErrors(errs, commonErrors.IsAlreadyExistsError)).Should(Equal(concurrentCalls - 1))
+				})
+			})
+
+			When("the user is added to the same tenant concurrently", func() {
+				It("should only add the membership once", func() {
+					email := createUsers(1)[0].Email
+					membership := models.TenantMembership{TenantID: cuid.New(), Role: models.TenantRoleMember}
+
+					errs := callConcurrently(func() error {
+						_, err := sut.AddUserToTenant(ctx, &repository.AddUserToTenantRequest{Email: email, Membership: membership})
+
+						return err
+					})
+
+					Ω(countErrors(errs, nil)).Should(Equal(1))
+					Ω(countErrors(errs, commonErrors.IsAlreadyExistsError)).Should(Equal(concurrentCalls - 1))
+
+					response, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.User.Memberships).Should(HaveLen(1))
+				})
+			})
+
+			When("the failed login attempts of the user are recorded concurrently", func() {
+				It("should count every attempt and lock the user once", func() {
+					email := createUsers(1)[0].Email
+
+					errs := callConcurrently(func() error {
+						_, err := sut.RecordLoginAttempt(ctx, &repository.RecordLoginAttemptRequest{
+							Email:            email,
+							LockoutThreshold: concurrentCalls / 2,
+						})
+
+						return err
+					})
+
+					Ω(countErrors(errs, nil)).Should(Equal(concurrentCalls))
+
+					response, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(response.User.FailedLoginAttempts).Should(Equal(concurrentCalls))
+					Ω(response.User.LockedAt).ShouldNot(BeNil())
+				})
+			})
+
+			When("different preferences of the user are updated concurrently", func() {
+				It("should merge all the preferences", func() {
+					email := createUsers(1)[0].Email
+
+					keys := make(chan string, concurrentCalls)
+					errs := callConcurrently(func() error {
+						key := cuid.New()
+						keys <- key
+
+						_, err := sut.UpdateUserPreferences(ctx, &repository.UpdateUserPreferencesRequest{
+							Email:       email,
+							Preferences: map[string]string{key: key},
+						})
+
+						return err
+					})
+					close(keys)
+
+					Ω(countErrors(errs, nil)).Should(Equal(concurrentCalls))
+
+					response, err := sut.ReadUserPreferences(ctx, &repository.ReadUserPreferencesRequest{Email: email})
+					Ω(err).Should(BeNil())
+
+					for key := range keys {
+						Ω(response.Preferences).Should(HaveKeyWithValue(key, key))
+					}
+				})
+			})
+		})
+	})
+}
+
+// callConcurrently calls the function concurrentCalls times at the same time
+// call: Mandatory. The function to call
+// Returns the errors the calls returned
+func callConcurrently(call func() error) []error {
+	errs := make([]error, concurrentCalls)
+	start := make(chan struct{})
+
+	var waitGroup sync.WaitGroup
+	for index := range errs {
+		waitGroup.Add(1)
+
+		go func(index int) {
+			defer GinkgoRecover()
+			defer waitGroup.Done()
+
+			<-start
+			errs[index] = call()
+		}(index)
+	}
+
+	close(start)
+	waitGroup.Wait()
+
+	return errs
+}
+
+// countErrors counts the errors that matched the predicate
+// errs: Mandatory. The errors to count
+// matches: Optional. The predicate the errors are matched with, the nil errors are counted if not provided
+// Returns the number of the matched errors
+func countErrors(errs []error, matches func(err error) bool) int {
+	count := 0
+	for _, err := range errs {
+		if (matches == nil && err == nil) || (matches != nil && matches(err)) {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1 @@
+package conformance_test
@@ -3,8 +3,8 @@ package repository
 
 import "context"
 
-// RepositoryContract declares the repository service that can create new user, read, update
-// and delete existing users.
+// RepositoryContract declares the repository service that can create new user, read, update,
+// delete and restore existing users.
 type RepositoryContract interface {
 	// CreateUser creates a new user.
 	// ctx: Mandatory The reference to the context
@@ -22,6 +22,14 @@ type RepositoryContract interface {
 		ctx context.Context,
 		request *ReadUserRequest) (*ReadUserResponse, error)
 
+	// ReadUsers reads the existing users with the given email addresses or IDs at once
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to read the existing users
+	// Returns either the users found or error if something goes wrong. The users that are not found are left out.
+	ReadUsers(
+		ctx context.Context,
+		request *ReadUsersRequest) (*ReadUsersResponse, error)
+
 	// UpdateUser update an existing user
 	// ctx: Mandatory The reference to the context
 	// request: Mandatory. The request to update an existing user
@@ -30,11 +38,171 @@ type RepositoryContract interface {
 		ctx context.Context,
 		request *UpdateUserRequest) (*UpdateUserResponse, error)
 
-	// DeleteUser delete an existing user
+	// ChangeEmail changes the email address of an existing user, the user keeps its ID. The new email address is not
+	// verified, so the verification of the previous one is cleared.
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the current and the new email addresses of the user
+	// Returns either the user after its email address is changed or error if something goes wrong. It fails with
+	// AlreadyExistsError if another user, even a soft deleted one, has the new email address.
+	ChangeEmail(
+		ctx context.Context,
+		request *ChangeEmailRequest) (*ChangeEmailResponse, error)
+
+	// ReadUserPreferences reads the preferences of an existing user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to read the preferences of an existing user
+	// Returns either the preferences of the user or error if something goes wrong.
+	ReadUserPreferences(
+		ctx context.Context,
+		request *ReadUserPreferencesRequest) (*ReadUserPreferencesResponse, error)
+
+	// UpdateUserPreferences merges the preferences into the preferences of an existing user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the preferences to set and the keys of the preferences to remove
+	// Returns either the preferences of the user after they are merged or error if something goes wrong.
+	UpdateUserPreferences(
+		ctx context.Context,
+		request *UpdateUserPreferencesRequest) (*UpdateUserPreferencesResponse, error)
+
+	// AddUserToTenant adds the membership in the tenant to an existing user. The membership is added atomically only if
+	// the user is not a member of the tenant yet.
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the membership to add
+	// Returns either the user after the membership is added or error if something goes wrong.
+	AddUserToTenant(
+		ctx context.Context,
+		request *AddUserToTenantRequest) (*AddUserToTenantResponse, error)
+
+	// RemoveUserFromTenant removes the membership in the tenant from an existing user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the tenant to remove the membership of
+	// Returns either the user after the membership is removed or error if something goes wrong.
+	RemoveUserFromTenant(
+		ctx context.Context,
+		request *RemoveUserFromTenantRequest) (*RemoveUserFromTenantResponse, error)
+
+	// SetEmailVerificationToken sets the pending email verification token of an existing user, replacing the token
+	// sent before if any
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the hash of the token and the time it expires at
+	// Returns either the user after the token is set or error if something goes wrong.
+	SetEmailVerificationToken(
+		ctx context.Context,
+		request *SetEmailVerificationTokenRequest) (*SetEmailVerificationTokenResponse, error)
+
+	// VerifyEmail marks the email address of an existing user as verified and clears the pending verification token.
+	// The user is updated atomically only if the token is still the pending verification token of the user.
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the hash of the pending verification token
+	// Returns either the user after the email address is verified or error if something goes wrong. NotFoundError is
+	// returned if the user does not exist or the token is not its pending verification token.
+	VerifyEmail(
+		ctx context.Context,
+		request *VerifyEmailRequest) (*VerifyEmailResponse, error)
+
+	// RecordLoginAttempt records the outcome of a login attempt of an existing user. A failed attempt increments the
+	// number of the failed attempts and locks the user atomically once it reaches the lockout threshold, a successful
+	// attempt resets it unless the user is locked.
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the outcome of the attempt and the lockout threshold
+	// Returns either the user after the attempt is recorded or error if something goes wrong.
+	RecordLoginAttempt(
+		ctx context.Context,
+		request *RecordLoginAttemptRequest) (*RecordLoginAttemptResponse, error)
+
+	// UnlockUser unlocks an existing user and resets the number of its failed login attempts
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to unlock an existing user
+	// Returns either the user after it is unlocked or error if something goes wrong.
+	UnlockUser(
+		ctx context.Context,
+		request *UnlockUserRequest) (*UnlockUserResponse, error)
+
+	// AddMFAMethod adds the MFA method to an existing user. The method is added atomically only if the user has not
+	// enrolled the same credential yet.
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the method to add
+	// Returns either the user after the method is added or error if something goes wrong.
+	AddMFAMethod(
+		ctx context.Context,
+		request *AddMFAMethodRequest) (*AddMFAMethodResponse, error)
+
+	// RemoveMFAMethod removes the MFA method from an existing user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the ID of the method to remove
+	// Returns either the user after the method is removed or error if something goes wrong.
+	RemoveMFAMethod(
+		ctx context.Context,
+		request *RemoveMFAMethodRequest) (*RemoveMFAMethodResponse, error)
+
+	// SetUserAvatar sets the avatar of an existing user, replacing the avatar set before if any
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the key and the hash of the stored avatar image
+	// Returns either the user after the avatar is set or error if something goes wrong.
+	SetUserAvatar(
+		ctx context.Context,
+		request *SetUserAvatarRequest) (*SetUserAvatarResponse, error)
+
+	// DeleteUser delete an existing user. The user is only marked as deleted if the request asks for a soft delete
 	// ctx: Mandatory The reference to the context
 	// request: Mandatory. The request to delete an existing user
 	// Returns either the result of deleting an existing user or error if something goes wrong.
 	DeleteUser(
 		ctx context.Context,
 		request *DeleteUserRequest) (*DeleteUserResponse, error)
+
+	// RestoreUser restores an existing soft deleted user
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to restore an existing soft deleted user
+	// Returns either the result of restoring the user or error if something goes wrong.
+	RestoreUser(
+		ctx context.Context,
+		request *RestoreUserRequest) (*RestoreUserResponse, error)
+
+	// PurgeDeletedUsers permanently deletes the soft deleted users that are deleted before the given time
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to purge the soft deleted users
+	// Returns either the result of purging the users or error if something goes wrong.
+	PurgeDeletedUsers(
+		ctx context.Context,
+		request *PurgeDeletedUsersRequest) (*PurgeDeletedUsersResponse, error)
+
+	// ListUsersByLabel lists all the users tagged with the given test label, including the soft deleted ones, so the
+	// data stored for them elsewhere can be deleted before they are purged
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to list the users tagged with the test label
+	// Returns either the users tagged with the test label or error if something goes wrong.
+	ListUsersByLabel(
+		ctx context.Context,
+		request *ListUsersByLabelRequest) (*ListUsersByLabelResponse, error)
+
+	// PurgeUsersByLabel permanently deletes all the users tagged with the given test label, including the soft deleted ones
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request to purge the users tagged with the test label
+	// Returns either the result of purging the users or error if something goes wrong.
+	PurgeUsersByLabel(
+		ctx context.Context,
+		request *PurgeUsersByLabelRequest) (*PurgeUsersByLabelResponse, error)
+
+	// Search returns the list of users that matched the criteria
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the search criteria
+	// Returns either the requested page of the users that matched the criteria or error if something goes wrong.
+	// ArgumentError is returned if the After or Before cursor does not identify a user.
+	Search(
+		ctx context.Context,
+		request *SearchRequest) (*SearchResponse, error)
+
+	// StreamSearch sends the users that matched the criteria one by one without loading all of them in memory
+	// ctx: Mandatory The reference to the context
+	// request: Mandatory. The request contains the search criteria and the function to send the users to
+	// Returns either the number of the sent users or error if something goes wrong.
+	StreamSearch(
+		ctx context.Context,
+		request *StreamSearchRequest) (*StreamSearchResponse, error)
+
+	// Ping verifies the repository can reach the underlying database
+	// ctx: Mandatory The reference to the context
+	// Returns error if the database is not reachable
+	Ping(ctx context.Context) error
 }
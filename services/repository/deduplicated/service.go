@@ -0,0 +1,462 @@
+// Package deduplicated implements the repository service that collapses the concurrent identical reads made on the
+// underlying repository into a single query, e.g. when the gateway retries the reads of a hot user all at once
+package deduplicated
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// resultQueried is the result of the reads that made the query on the underlying repository
+	resultQueried = "queried"
+
+	// resultShared is the result of the reads that shared the query made by a concurrent identical read
+	resultShared = "shared"
+)
+
+var readsCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "user_repository_deduplicated_reads_total",
+		Help: "The number of the reads grouped by the operation and the result (queried or shared), the shared reads are the queries saved",
+	},
+	[]string{"operation", "result"})
+
+type deduplicatedRepositoryService struct {
+	repositoryService repository.RepositoryContract
+	group             singleflight.Group
+}
+
+// NewDeduplicatedRepositoryService creates new instance of the deduplicatedRepositoryService, setting up all dependencies and returns the instance.
+// The concurrent reads of the same user made on the decorated repository share the query made by the first of them,
+// the other operations are made on the decorated repository as they are.
+// repositoryService: Mandatory. Reference to the repository service the operations are made on
+// Returns the new service or error if something goes wrong
+func NewDeduplicatedRepositoryService(repositoryService repository.RepositoryContract) (repository.RepositoryContract, error) {
+	if repositoryService == nil {
+		return nil, commonErrors.NewArgumentNilError("repositoryService", "repositoryService is required")
+	}
+
+	return &deduplicatedRepositoryService{
+		repositoryService: repositoryService,
+	}, nil
+}
+
+// CreateUser creates a new user.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to create a new user
+// Returns either the result of creating new user or error if something goes wrong.
+func (service *deduplicatedRepositoryService) CreateUser(
+	ctx context.Context,
+	request *repository.CreateUserRequest) (*repository.CreateUserResponse, error) {
+
+	return service.repositoryService.CreateUser(ctx, request)
+}
+
+// ReadUser read an existing user. The concurrent reads of the same user share the query made by the first of them,
+// unless only some of the fields are read, as the reads of the whole user must never share a projection of it.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read an existing user
+// Returns either the result of reading an existing user or error if something goes wrong.
+func (service *deduplicatedRepositoryService) ReadUser(
+	ctx context.Context,
+	request *repository.ReadUserRequest) (*repository.ReadUserResponse, error) {
+	if len(request.Fields) > 0 {
+		return service.repositoryService.ReadUser(ctx, request)
+	}
+
+	// The reads by ID and by email address are shared separately, as they are keyed differently
+	key := request.Email
+	if request.UserID != "" {
+		key = "id:" + request.UserID
+		if request.IncludeDeleted {
+			key = "deleted-id:" + request.UserID
+		}
+	}
+
+	result, err := service.do(ctx, "ReadUser", key, func(ctx context.Context) (interface{}, error) {
+		return service.repositoryService.ReadUser(ctx, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Every caller gets its own deep copy of the response, so a caller changing it, e.g. adding a label to the user,
+	// does not change it for the others
+	response := *result.(*repository.ReadUserResponse)
+	response.User = copyUser(response.User)
+
+	return &response, nil
+}
+
+// ReadUsers reads the existing users with the given email addresses or IDs at once. The reads are not shared, the
+// concurrent reads of the same set of users are unlikely.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the existing users
+// Returns either the users found or error if something goes wrong.
+func (service *deduplicatedRepositoryService) ReadUsers(
+	ctx context.Context,
+	request *repository.ReadUsersRequest) (*repository.ReadUsersResponse, error) {
+	return service.repositoryService.ReadUsers(ctx, request)
+}
+
+// UpdateUser update an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to update an existing user
+// Returns either the result of updateing an existing user or error if something goes wrong.
+func (service *deduplicatedRepositoryService) UpdateUser(
+	ctx context.Context,
+	request *repository.UpdateUserRequest) (*repository.UpdateUserResponse, error) {
+
+	return service.repositoryService.UpdateUser(ctx, request)
+}
+
+// ChangeEmail changes the email address of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the current and the new email addresses of the user
+// Returns either the user after its email address is changed or error if something goes wrong.
+func (service *deduplicatedRepositoryService) ChangeEmail(
+	ctx context.Context,
+	request *repository.ChangeEmailRequest) (*repository.ChangeEmailResponse, error) {
+
+	return service.repositoryService.ChangeEmail(ctx, request)
+}
+
+// ReadUserPreferences reads the preferences of an existing user. The concurrent reads of the preferences of the same
+// user share the query made by the first of them.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the preferences of an existing user
+// Returns either the preferences of the user or error if something goes wrong.
+func (service *deduplicatedRepositoryService) ReadUserPreferences(
+	ctx context.Context,
+	request *repository.ReadUserPreferencesRequest) (*repository.ReadUserPreferencesResponse, error) {
+	result, err := service.do(ctx, "ReadUserPreferences", request.Email, func(ctx context.Context) (interface{}, error) {
+		return service.repositoryService.ReadUserPreferences(ctx, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := *result.(*repository.ReadUserPreferencesResponse)
+	response.Preferences = copyStrings(response.Preferences)
+
+	return &response, nil
+}
+
+// UpdateUserPreferences merges the preferences into the preferences of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the preferences to set and the keys of the preferences to remove
+// Returns either the preferences of the user after they are merged or error if something goes wrong.
+func (service *deduplicatedRepositoryService) UpdateUserPreferences(
+	ctx context.Context,
+	request *repository.UpdateUserPreferencesRequest) (*repository.UpdateUserPreferencesResponse, error) {
+
+	return service.repositoryService.UpdateUserPreferences(ctx, request)
+}
+
+// AddUserToTenant adds the membership in the tenant to an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the membership to add
+// Returns either the user after the membership is added or error if something goes wrong.
+func (service *deduplicatedRepositoryService) AddUserToTenant(
+	ctx context.Context,
+	request *repository.AddUserToTenantRequest) (*repository.AddUserToTenantResponse, error) {
+
+	return service.repositoryService.AddUserToTenant(ctx, request)
+}
+
+// RemoveUserFromTenant removes the membership in the tenant from an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the tenant to remove the membership of
+// Returns either the user after the membership is removed or error if something goes wrong.
+func (service *deduplicatedRepositoryService) RemoveUserFromTenant(
+	ctx context.Context,
+	request *repository.RemoveUserFromTenantRequest) (*repository.RemoveUserFromTenantResponse, error) {
+
+	return service.repositoryService.RemoveUserFromTenant(ctx, request)
+}
+
+// SetEmailVerificationToken sets the pending email verification token of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the hash of the token and the time it expires at
+// Returns either the user after the token is set or error if something goes wrong.
+func (service *deduplicatedRepositoryService) SetEmailVerificationToken(
+	ctx context.Context,
+	request *repository.SetEmailVerificationTokenRequest) (*repository.SetEmailVerificationTokenResponse, error) {
+
+	return service.repositoryService.SetEmailVerificationToken(ctx, request)
+}
+
+// VerifyEmail marks the email address of an existing user as verified
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the hash of the pending verification token
+// Returns either the user after the email address is verified or error if something goes wrong.
+func (service *deduplicatedRepositoryService) VerifyEmail(
+	ctx context.Context,
+	request *repository.VerifyEmailRequest) (*repository.VerifyEmailResponse, error) {
+
+	return service.repositoryService.VerifyEmail(ctx, request)
+}
+
+// RecordLoginAttempt records the outcome of a login attempt of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the outcome of the attempt and the lockout threshold
+// Returns either the user after the attempt is recorded or error if something goes wrong.
+func (service *deduplicatedRepositoryService) RecordLoginAttempt(
+	ctx context.Context,
+	request *repository.RecordLoginAttemptRequest) (*repository.RecordLoginAttemptResponse, error) {
+
+	return service.repositoryService.RecordLoginAttempt(ctx, request)
+}
+
+// UnlockUser unlocks an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to unlock an existing user
+// Returns either the user after it is unlocked or error if something goes wrong.
+func (service *deduplicatedRepositoryService) UnlockUser(
+	ctx context.Context,
+	request *repository.UnlockUserRequest) (*repository.UnlockUserResponse, error) {
+
+	return service.repositoryService.UnlockUser(ctx, request)
+}
+
+// AddMFAMethod adds the MFA method to an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the method to add
+// Returns either the user after the method is added or error if something goes wrong.
+func (service *deduplicatedRepositoryService) AddMFAMethod(
+	ctx context.Context,
+	request *repository.AddMFAMethodRequest) (*repository.AddMFAMethodResponse, error) {
+
+	return service.repositoryService.AddMFAMethod(ctx, request)
+}
+
+// RemoveMFAMethod removes the MFA method from an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the ID of the method to remove
+// Returns either the user after the method is removed or error if something goes wrong.
+func (service *deduplicatedRepositoryService) RemoveMFAMethod(
+	ctx context.Context,
+	request *repository.RemoveMFAMethodRequest) (*repository.RemoveMFAMethodResponse, error) {
+
+	return service.repositoryService.RemoveMFAMethod(ctx, request)
+}
+
+// SetUserAvatar sets the avatar of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the key and the hash of the stored avatar image
+// Returns either the user after the avatar is set or error if something goes wrong.
+func (service *deduplicatedRepositoryService) SetUserAvatar(
+	ctx context.Context,
+	request *repository.SetUserAvatarRequest) (*repository.SetUserAvatarResponse, error) {
+
+	return service.repositoryService.SetUserAvatar(ctx, request)
+}
+
+// DeleteUser delete an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to delete an existing user
+// Returns either the result of deleting an existing user or error if something goes wrong.
+func (service *deduplicatedRepositoryService) DeleteUser(
+	ctx context.Context,
+	request *repository.DeleteUserRequest) (*repository.DeleteUserResponse, error) {
+
+	return service.repositoryService.DeleteUser(ctx, request)
+}
+
+// RestoreUser restores an existing soft deleted user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to restore an existing soft deleted user
+// Returns either the result of restoring the user or error if something goes wrong.
+func (service *deduplicatedRepositoryService) RestoreUser(
+	ctx context.Context,
+	request *repository.RestoreUserRequest) (*repository.RestoreUserResponse, error) {
+
+	return service.repositoryService.RestoreUser(ctx, request)
+}
+
+// PurgeDeletedUsers permanently deletes the soft deleted users that are deleted before the given time
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to purge the soft deleted users
+// Returns either the result of purging the users or error if something goes wrong.
+func (service *deduplicatedRepositoryService) PurgeDeletedUsers(
+	ctx context.Context,
+	request *repository.PurgeDeletedUsersRequest) (*repository.PurgeDeletedUsersResponse, error) {
+
+	return service.repositoryService.PurgeDeletedUsers(ctx, request)
+}
+
+// ListUsersByLabel lists all the users tagged with the given test label, including the soft deleted ones
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to list the users tagged with the test label
+// Returns either the users tagged with the test label or error if something goes wrong.
+func (service *deduplicatedRepositoryService) ListUsersByLabel(
+	ctx context.Context,
+	request *repository.ListUsersByLabelRequest) (*repository.ListUsersByLabelResponse, error) {
+
+	return service.repositoryService.ListUsersByLabel(ctx, request)
+}
+
+// PurgeUsersByLabel permanently deletes all the users tagged with the given test label, including the soft deleted ones
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to purge the users tagged with the test label
+// Returns either the result of purging the users or error if something goes wrong.
+func (service *deduplicatedRepositoryService) PurgeUsersByLabel(
+	ctx context.Context,
+	request *repository.PurgeUsersByLabelRequest) (*repository.PurgeUsersByLabelResponse, error) {
+
+	return service.repositoryService.PurgeUsersByLabel(ctx, request)
+}
+
+// Search returns the list of users that matched the criteria
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria
+// Returns the list of users that matched the criteria
+func (service *deduplicatedRepositoryService) Search(
+	ctx context.Context,
+	request *repository.SearchRequest) (*repository.SearchResponse, error) {
+
+	return service.repositoryService.Search(ctx, request)
+}
+
+// StreamSearch sends the users that matched the criteria one by one without loading all of them in memory
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria and the function to send the users to
+// Returns either the number of the sent users or error if something goes wrong.
+func (service *deduplicatedRepositoryService) StreamSearch(
+	ctx context.Context,
+	request *repository.StreamSearchRequest) (*repository.StreamSearchResponse, error) {
+
+	return service.repositoryService.StreamSearch(ctx, request)
+}
+
+// Ping verifies the repository can reach the underlying database
+// ctx: Mandatory The reference to the context
+// Returns error if the database is not reachable
+func (service *deduplicatedRepositoryService) Ping(ctx context.Context) error {
+
+	return service.repositoryService.Ping(ctx)
+}
+
+// do makes the read once for all the concurrent callers with the same key. The query is made with the context of the
+// first caller detached from its cancellation but bound by its deadline, so the other callers do not fail when the
+// first one gives up, while every caller still stops waiting as soon as its own context is done.
+func (service *deduplicatedRepositoryService) do(
+	ctx context.Context,
+	operation string,
+	key string,
+	read func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	// The function only runs for the caller that makes the query, every caller waiting for it reports the result as shared
+	queried := false
+
+	// The reads scoped to different tenants see different users, so they are only shared within the same tenant
+	resultChannel := service.group.DoChan(operation+"/"+repository.GetReadTenant(ctx)+"/"+key, func() (interface{}, error) {
+		queried = true
+		readCtx := context.Context(detachedContext{parent: ctx})
+		if deadline, ok := ctx.Deadline(); ok {
+			var cancel context.CancelFunc
+			readCtx, cancel = context.WithDeadline(readCtx, deadline)
+			defer cancel()
+		}
+
+		return read(readCtx)
+	})
+
+	select {
+	case result := <-resultChannel:
+		if queried {
+			readsCounter.WithLabelValues(operation, resultQueried).Inc()
+		} else {
+			readsCounter.WithLabelValues(operation, resultShared).Inc()
+		}
+
+		return result.Val, result.Err
+
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// detachedContext keeps the values of its parent, e.g. the tracing span and the correlation ID, but is never canceled
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (ctx detachedContext) Value(key interface{}) interface{} {
+	return ctx.parent.Value(key)
+}
+
+// copyUser copies the user deeply, so the callers sharing a read can not change the user through each other's copies
+func copyUser(user models.User) models.User {
+	copied := user
+	copied.Labels = copyStrings(user.Labels)
+	copied.EmailVerificationExpiresAt = copyTime(user.EmailVerificationExpiresAt)
+	copied.LockedAt = copyTime(user.LockedAt)
+	copied.CreatedAt = copyTime(user.CreatedAt)
+	copied.UpdatedAt = copyTime(user.UpdatedAt)
+
+	if user.Memberships != nil {
+		copied.Memberships = append([]models.TenantMembership{}, user.Memberships...)
+	}
+
+	if user.MFAMethods != nil {
+		copied.MFAMethods = make([]models.MFAMethod, 0, len(user.MFAMethods))
+		for _, method := range user.MFAMethods {
+			if method.RecoveryCodeHashes != nil {
+				method.RecoveryCodeHashes = append([]string{}, method.RecoveryCodeHashes...)
+			}
+
+			copied.MFAMethods = append(copied.MFAMethods, method)
+		}
+	}
+
+	if user.Avatar != nil {
+		avatar := *user.Avatar
+		copied.Avatar = &avatar
+	}
+
+	return copied
+}
+
+// copyStrings copies the map, keeping the nil maps nil
+func copyStrings(values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+
+	copied := make(map[string]string, len(values))
+	for key, value := range values {
+		copied[key] = value
+	}
+
+	return copied
+}
+
+func copyTime(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+
+	copied := *value
+
+	return &copied
+}
@@ -0,0 +1,234 @@
+package deduplicated_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/repository/deduplicated"
+	repositoryMock "github.com/decentralized-cloud/user/services/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestDeduplicatedRepositoryService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Deduplicated Repository Service Tests")
+}
+
+var _ = Describe("Deduplicated Repository Service Tests", func() {
+	var (
+		mockCtrl              *gomock.Controller
+		sut                   repository.RepositoryContract
+		mockRepositoryService *repositoryMock.MockRepositoryContract
+		ctx                   context.Context
+		request               repository.ReadUserRequest
+	)
+
+	// readConcurrently starts the other reads once the first one reached the repository, so they wait for its query
+	readConcurrently := func(readCtx context.Context, count int, started chan struct{}) []*repository.ReadUserResponse {
+		responses := make([]*repository.ReadUserResponse, count)
+		var waitGroup sync.WaitGroup
+
+		for index := 0; index < count; index++ {
+			waitGroup.Add(1)
+
+			go func(index int) {
+				defer GinkgoRecover()
+				defer waitGroup.Done()
+
+				response, err := sut.ReadUser(readCtx, &request)
+				Ω(err).Should(BeNil())
+				responses[index] = response
+			}(index)
+
+			if index == 0 {
+				<-started
+			}
+		}
+
+		waitGroup.Wait()
+
+		return responses
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		ctx = context.Background()
+		request = repository.ReadUserRequest{Email: cuid.New() + "@test.com"}
+
+		mockRepositoryService = repositoryMock.NewMockRepositoryContract(mockCtrl)
+		sut, _ = deduplicated.NewDeduplicatedRepositoryService(mockRepositoryService)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate DeduplicatedRepositoryService", func() {
+		When("repository service is not provided and NewDeduplicatedRepositoryService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := deduplicated.NewDeduplicatedRepositoryService(nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("all dependencies are resolved and NewDeduplicatedRepositoryService is called", func() {
+			It("should instantiate the new DeduplicatedRepositoryService", func() {
+				service, err := deduplicated.NewDeduplicatedRepositoryService(mockRepositoryService)
+				Ω(err).Should(BeNil())
+				Ω(service).ShouldNot(BeNil())
+			})
+		})
+	})
+
+	Context("DeduplicatedRepositoryService is instantiated", func() {
+		When("the same user is read concurrently", func() {
+			It("should query the repository once and return a copy of the user to every read", func() {
+				started := make(chan struct{})
+				lockedAt := time.Now()
+				user := models.User{
+					DataResidency: cuid.New(),
+					Labels:        map[string]string{"region": "eu"},
+					Memberships:   []models.TenantMembership{{TenantID: cuid.New(), Role: "member"}},
+					MFAMethods:    []models.MFAMethod{{MethodID: cuid.New(), RecoveryCodeHashes: []string{cuid.New()}}},
+					LockedAt:      &lockedAt,
+					Avatar:        &models.Avatar{ObjectKey: cuid.New()},
+				}
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(gomock.Any(), &request).
+					DoAndReturn(func(_ context.Context, _ *repository.ReadUserRequest) (*repository.ReadUserResponse, error) {
+						close(started)
+
+						// The reads can not be observed joining the query, so they are given the time to do so
+						time.Sleep(100 * time.Millisecond)
+
+						return &repository.ReadUserResponse{User: user}, nil
+					})
+
+				responses := readConcurrently(ctx, 5, started)
+				for _, response := range responses {
+					Ω(response.User).Should(Equal(user))
+				}
+
+				// The users are copied deeply, so changing the collections of one of them does not change the others
+				responses[0].User.DataResidency = cuid.New()
+				responses[0].User.Labels["region"] = "us"
+				responses[0].User.Memberships[0].Role = "admin"
+				responses[0].User.MFAMethods[0].Name = cuid.New()
+				responses[0].User.MFAMethods[0].RecoveryCodeHashes[0] = cuid.New()
+				*responses[0].User.LockedAt = lockedAt.Add(time.Hour)
+				responses[0].User.Avatar.ObjectKey = cuid.New()
+
+				Ω(responses[1].User).Should(Equal(models.User{
+					DataResidency: user.DataResidency,
+					Labels:        map[string]string{"region": "eu"},
+					Memberships:   []models.TenantMembership{{TenantID: user.Memberships[0].TenantID, Role: "member"}},
+					MFAMethods:    []models.MFAMethod{{MethodID: user.MFAMethods[0].MethodID, RecoveryCodeHashes: []string{user.MFAMethods[0].RecoveryCodeHashes[0]}}},
+					LockedAt:      &lockedAt,
+					Avatar:        &models.Avatar{ObjectKey: user.Avatar.ObjectKey},
+				}))
+			})
+		})
+
+		When("the read that made the query is canceled", func() {
+			It("should still return the user to the other reads", func() {
+				started := make(chan struct{})
+				proceed := make(chan struct{})
+				user := models.User{DataResidency: cuid.New()}
+				firstCtx, cancelFirst := context.WithCancel(ctx)
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(gomock.Any(), &request).
+					DoAndReturn(func(readCtx context.Context, _ *repository.ReadUserRequest) (*repository.ReadUserResponse, error) {
+						close(started)
+						<-proceed
+
+						return &repository.ReadUserResponse{User: user}, readCtx.Err()
+					})
+
+				firstDone := make(chan error, 1)
+				go func() {
+					_, err := sut.ReadUser(firstCtx, &request)
+					firstDone <- err
+				}()
+
+				<-started
+				otherDone := make(chan *repository.ReadUserResponse, 1)
+				go func() {
+					defer GinkgoRecover()
+
+					response, err := sut.ReadUser(ctx, &request)
+					Ω(err).Should(BeNil())
+					otherDone <- response
+				}()
+
+				// The other read can not be observed joining the query, so it is given the time to do so
+				time.Sleep(50 * time.Millisecond)
+				cancelFirst()
+				Ω(<-firstDone).Should(Equal(context.Canceled))
+
+				close(proceed)
+				Ω((<-otherDone).User).Should(Equal(user))
+			})
+		})
+
+		When("the same user is read one after the other", func() {
+			It("should query the repository for every read", func() {
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(gomock.Any(), &request).
+					Return(&repository.ReadUserResponse{}, nil).
+					Times(2)
+
+				_, err := sut.ReadUser(ctx, &request)
+				Ω(err).Should(BeNil())
+
+				_, err = sut.ReadUser(ctx, &request)
+				Ω(err).Should(BeNil())
+			})
+		})
+
+		When("the repository returns error", func() {
+			It("should return the same error", func() {
+				expectedError := commonErrors.NewNotFoundError()
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(gomock.Any(), &request).
+					Return(nil, expectedError)
+
+				response, err := sut.ReadUser(ctx, &request)
+				Ω(response).Should(BeNil())
+				Ω(err).Should(Equal(expectedError))
+			})
+		})
+
+		When("an operation other than a read is called", func() {
+			It("should make the operation on the repository as it is", func() {
+				updateRequest := repository.UpdateUserRequest{Email: request.Email}
+				expectedResponse := &repository.UpdateUserResponse{Cursor: cuid.New()}
+
+				mockRepositoryService.
+					EXPECT().
+					UpdateUser(ctx, &updateRequest).
+					Return(expectedResponse, nil)
+
+				response, err := sut.UpdateUser(ctx, &updateRequest)
+				Ω(err).Should(BeNil())
+				Ω(response).Should(Equal(expectedResponse))
+			})
+		})
+	})
+})
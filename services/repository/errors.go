@@ -0,0 +1,132 @@
+// Package repository implements different repository services required by the user service
+package repository
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DataResidencyViolationError indicates the operation would persist the user in a region other than the region the
+// user resides in
+type DataResidencyViolationError struct {
+	Email           string
+	Region          string
+	RequestedRegion string
+}
+
+// Error returns message for the DataResidencyViolationError error type
+// Returns the formatted error message
+func (e DataResidencyViolationError) Error() string {
+	return fmt.Sprintf(
+		"the user %s resides in the region %s and cannot be moved to the region %s",
+		e.Email,
+		e.Region,
+		e.RequestedRegion)
+}
+
+// IsDataResidencyViolationError indicates whether the error is of type DataResidencyViolationError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type DataResidencyViolationError
+func IsDataResidencyViolationError(err error) bool {
+	var dataResidencyViolationError DataResidencyViolationError
+
+	return errors.As(err, &dataResidencyViolationError)
+}
+
+// NewDataResidencyViolationError creates a new DataResidencyViolationError error
+// email: Mandatory. The email address of the user
+// region: Mandatory. The region the user resides in
+// requestedRegion: Mandatory. The region the operation would persist the user in
+// Returns the new error
+func NewDataResidencyViolationError(email, region, requestedRegion string) error {
+	return DataResidencyViolationError{
+		Email:           email,
+		Region:          region,
+		RequestedRegion: requestedRegion,
+	}
+}
+
+// DependencyUnavailableError indicates the repository could not reach the database it depends on yet, so the
+// service reports it is not ready instead of failing to start
+type DependencyUnavailableError struct {
+	Dependency string
+	Attempts   int
+	Err        error
+}
+
+// Error returns message for the DependencyUnavailableError error type
+// Returns the formatted error message
+func (e DependencyUnavailableError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("the dependency %s is not checked yet", e.Dependency)
+	}
+
+	return fmt.Sprintf("the dependency %s is unavailable after %d attempts: %v", e.Dependency, e.Attempts, e.Err)
+}
+
+// Unwrap returns the error the last attempt to reach the dependency failed with
+// Returns the wrapped error
+func (e DependencyUnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// IsDependencyUnavailableError indicates whether the error is of type DependencyUnavailableError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type DependencyUnavailableError
+func IsDependencyUnavailableError(err error) bool {
+	var dependencyUnavailableError DependencyUnavailableError
+
+	return errors.As(err, &dependencyUnavailableError)
+}
+
+// NewDependencyUnavailableError creates a new DependencyUnavailableError error
+// dependency: Mandatory. The name of the dependency that is unavailable
+// attempts: Mandatory. The number of the attempts made to reach the dependency so far
+// err: Optional. The error the last attempt failed with, not provided if no attempt is made yet
+// Returns the new error
+func NewDependencyUnavailableError(dependency string, attempts int, err error) error {
+	return DependencyUnavailableError{
+		Dependency: dependency,
+		Attempts:   attempts,
+		Err:        err,
+	}
+}
+
+// DeadlineExceededError indicates the deadline of the context passed before the operation against the database
+// completed, so the caller can tell a slow database apart from a failing one
+type DeadlineExceededError struct {
+	Operation string
+	Err       error
+}
+
+// Error returns message for the DeadlineExceededError error type
+// Returns the formatted error message
+func (e DeadlineExceededError) Error() string {
+	return fmt.Sprintf("the deadline exceeded before the operation completed: %s: %v", e.Operation, e.Err)
+}
+
+// Unwrap returns the error the operation failed with once the deadline passed
+// Returns the wrapped error
+func (e DeadlineExceededError) Unwrap() error {
+	return e.Err
+}
+
+// IsDeadlineExceededError indicates whether the error is of type DeadlineExceededError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type DeadlineExceededError
+func IsDeadlineExceededError(err error) bool {
+	var deadlineExceededError DeadlineExceededError
+
+	return errors.As(err, &deadlineExceededError)
+}
+
+// NewDeadlineExceededError creates a new DeadlineExceededError error
+// operation: Mandatory. The description of the operation that did not complete in time
+// err: Optional. The error the operation failed with
+// Returns the new error
+func NewDeadlineExceededError(operation string, err error) error {
+	return DeadlineExceededError{
+		Operation: operation,
+		Err:       err,
+	}
+}
@@ -0,0 +1,280 @@
+// Package factory creates the repository service the configuration selects
+package factory
+
+import (
+	"fmt"
+
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/idgenerator"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/repository/cached"
+	"github.com/decentralized-cloud/user/services/repository/cached/redis"
+	"github.com/decentralized-cloud/user/services/repository/deduplicated"
+	"github.com/decentralized-cloud/user/services/repository/instrumented"
+	"github.com/decentralized-cloud/user/services/repository/memory"
+	"github.com/decentralized-cloud/user/services/repository/mongodb"
+	"github.com/decentralized-cloud/user/services/repository/postgres"
+	"github.com/decentralized-cloud/user/services/repository/regional"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+)
+
+// Dependencies contains the services the repositories are created with
+type Dependencies struct {
+	// Logger is mandatory for the repository service and logs the operations made on the repository
+	Logger *zap.Logger
+
+	// ConfigurationService is mandatory and provides the configurations the repository is selected with
+	ConfigurationService configuration.ConfigurationContract
+
+	// ClockService is mandatory and provides the time the users are created, updated and soft deleted at
+	ClockService clock.ClockContract
+
+	// IDGeneratorService is mandatory if the users are persisted in MongoDB
+	IDGeneratorService idgenerator.IDGeneratorContract
+}
+
+// storeConstructor creates the repository that persists the users of a region
+type storeConstructor func(
+	dependencies Dependencies,
+	databaseConfigurationService configuration.ConfigurationContract) (repository.RepositoryContract, error)
+
+// storeConstructors are the constructors of the supported stores keyed by their names
+var storeConstructors = map[string]storeConstructor{
+	"mongodb": func(
+		dependencies Dependencies,
+		databaseConfigurationService configuration.ConfigurationContract) (repository.RepositoryContract, error) {
+		return mongodb.NewMongodbRepositoryService(databaseConfigurationService, dependencies.ClockService, dependencies.IDGeneratorService)
+	},
+	"postgres": func(
+		dependencies Dependencies,
+		databaseConfigurationService configuration.ConfigurationContract) (repository.RepositoryContract, error) {
+		return postgres.NewPostgresRepositoryService(databaseConfigurationService, dependencies.ClockService)
+	},
+	"memory": func(
+		dependencies Dependencies,
+		databaseConfigurationService configuration.ConfigurationContract) (repository.RepositoryContract, error) {
+		return memory.NewMemoryRepositoryService(dependencies.ClockService)
+	},
+}
+
+// selection is the repository the configuration selects
+type selection struct {
+	// store is the name of the store the users are persisted in
+	store string
+
+	// cacheEnabled indicates the store is put behind the Redis cache
+	cacheEnabled bool
+}
+
+// NewRepositoryService creates the repository service the configuration selects. The users are persisted in the
+// store of the region they reside in, and the store is instrumented, its reads deduplicated and put behind the Redis
+// cache as configured.
+// dependencies: Mandatory. The services the repository is created with
+// Returns either the repository service and the bus that removes the changed users from the memory of the other
+// replicas, nil if the users are not kept in the memory of the replicas, or error if something goes wrong
+func NewRepositoryService(dependencies Dependencies) (repository.RepositoryContract, cached.InvalidationBusContract, error) {
+	if err := validateDependencies(dependencies); err != nil {
+		return nil, nil, err
+	}
+
+	if dependencies.Logger == nil {
+		return nil, nil, commonErrors.NewArgumentNilError("Logger", "Logger is required")
+	}
+
+	selected, err := selectRepository(dependencies.ConfigurationService)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repositoryService, err := newRegionalRepositoryService(dependencies, selected.store)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The store is instrumented beneath the cache so the metrics reflect the store rather than the cache hits
+	if repositoryService, err = instrumented.NewInstrumentedRepositoryService(dependencies.Logger, repositoryService); err != nil {
+		return nil, nil, err
+	}
+
+	// The reads are deduplicated beneath the cache so the concurrent cache misses of a hot user share a single query
+	readDeduplicationEnabled, err := dependencies.ConfigurationService.GetReadDeduplicationEnabled()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if readDeduplicationEnabled {
+		if repositoryService, err = deduplicated.NewDeduplicatedRepositoryService(repositoryService); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if !selected.cacheEnabled {
+		return repositoryService, nil, nil
+	}
+
+	cacheStore, err := redis.NewRedisCacheStore(dependencies.ConfigurationService)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	localTTL, err := dependencies.ConfigurationService.GetCacheLocalTTL()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The invalidation bus is only needed to remove the changed users from the memory of the other replicas
+	var invalidationBus cached.InvalidationBusContract
+	if localTTL > 0 {
+		if invalidationBus, err = redis.NewRedisInvalidationBus(dependencies.Logger, dependencies.ConfigurationService); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	cachedRepositoryService, err := cached.NewCachedRepositoryService(
+		dependencies.Logger,
+		dependencies.ConfigurationService,
+		dependencies.ClockService,
+		repositoryService,
+		cacheStore,
+		invalidationBus)
+	if err != nil {
+		if invalidationBus != nil {
+			_ = invalidationBus.Close()
+		}
+
+		return nil, nil, err
+	}
+
+	return cachedRepositoryService, invalidationBus, nil
+}
+
+// NewDatabaseRepositoryService creates the repository service that persists every user straight into the database of
+// the region the user resides in, bypassing the cache. It is used by the commands that manage the users without
+// starting the service, so the memory store is rejected as the users would be lost when the command exits.
+// dependencies: Mandatory. The services the repository is created with
+// Returns either the repository service or error if something goes wrong
+func NewDatabaseRepositoryService(dependencies Dependencies) (repository.RepositoryContract, error) {
+	if err := validateDependencies(dependencies); err != nil {
+		return nil, err
+	}
+
+	selected, err := selectRepository(dependencies.ConfigurationService)
+	if err != nil {
+		return nil, err
+	}
+
+	if selected.store == "memory" {
+		return nil, commonErrors.NewUnknownError("REPOSITORY_TYPE memory keeps the users in the memory of the service and can not be managed by the commands")
+	}
+
+	return newRegionalRepositoryService(dependencies, selected.store)
+}
+
+// selectRepository selects the store and the layers of the repository from the configurations and validates their
+// combination
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns either the selected repository or error if the configurations can not be combined
+func selectRepository(configurationService configuration.ConfigurationContract) (selection, error) {
+	repositoryType, err := configurationService.GetRepositoryType()
+	if err != nil {
+		return selection{}, err
+	}
+
+	databaseType, err := configurationService.GetDatabaseType()
+	if err != nil {
+		return selection{}, err
+	}
+
+	cacheEnabled, err := configurationService.GetCacheEnabled()
+	if err != nil {
+		return selection{}, err
+	}
+
+	routes, err := configurationService.GetDataResidencyRoutes()
+	if err != nil {
+		return selection{}, err
+	}
+
+	switch repositoryType {
+	case "cached":
+		// The cached repository keeps the users in the database set by the database type
+		return selection{store: databaseType, cacheEnabled: true}, nil
+
+	case "memory":
+		// Every replica keeps its own users, so caching them in Redis shared by the replicas would serve the users of
+		// another replica, and there is no database to route the users of the other regions to
+		if cacheEnabled {
+			return selection{}, commonErrors.NewUnknownError("REPOSITORY_TYPE memory can not be combined with USER_CACHE_ENABLED")
+		}
+
+		if len(routes) > 0 {
+			return selection{}, commonErrors.NewUnknownError("REPOSITORY_TYPE memory can not be combined with USER_DATA_RESIDENCY_ROUTES")
+		}
+
+	default:
+		// The repository connects to the database set by the database connection string, which is also where the
+		// other stores of the service are persisted
+		if repositoryType != databaseType {
+			return selection{}, commonErrors.NewUnknownError(
+				fmt.Sprintf("REPOSITORY_TYPE %s does not match DATABASE_TYPE %s", repositoryType, databaseType))
+		}
+	}
+
+	if _, ok := storeConstructors[repositoryType]; !ok {
+		return selection{}, commonErrors.NewUnknownError(fmt.Sprintf("REPOSITORY_TYPE is not supported: %s", repositoryType))
+	}
+
+	return selection{store: repositoryType, cacheEnabled: cacheEnabled}, nil
+}
+
+// newRegionalRepositoryService creates the store of every region and the repository that routes the users to the
+// store of the region they reside in. The default region uses the database set by the database connection string.
+// dependencies: Mandatory. The services the repository is created with
+// store: Mandatory. The name of the store the users are persisted in
+// Returns either the repository service or error if something goes wrong
+func newRegionalRepositoryService(dependencies Dependencies, store string) (repository.RepositoryContract, error) {
+	configurationService := dependencies.ConfigurationService
+	newStore := storeConstructors[store]
+
+	defaultRegion, err := configurationService.GetDataResidencyDefaultRegion()
+	if err != nil {
+		return nil, err
+	}
+
+	routes, err := configurationService.GetDataResidencyRoutes()
+	if err != nil {
+		return nil, err
+	}
+
+	regionRepositoryServices := map[string]repository.RepositoryContract{}
+	if regionRepositoryServices[defaultRegion], err = newStore(dependencies, configurationService); err != nil {
+		return nil, err
+	}
+
+	for region, connectionString := range routes {
+		regionConfigurationService := regional.NewRegionConfigurationService(configurationService, connectionString)
+		if regionRepositoryServices[region], err = newStore(dependencies, regionConfigurationService); err != nil {
+			return nil, err
+		}
+	}
+
+	return regional.NewRegionalRepositoryService(configurationService, regionRepositoryServices)
+}
+
+func validateDependencies(dependencies Dependencies) error {
+	if dependencies.ConfigurationService == nil {
+		return commonErrors.NewArgumentNilError("ConfigurationService", "ConfigurationService is required")
+	}
+
+	if dependencies.ClockService == nil {
+		return commonErrors.NewArgumentNilError("ClockService", "ClockService is required")
+	}
+
+	if dependencies.IDGeneratorService == nil {
+		return commonErrors.NewArgumentNilError("IDGeneratorService", "IDGeneratorService is required")
+	}
+
+	return nil
+}
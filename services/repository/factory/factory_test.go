@@ -0,0 +1,169 @@
+package factory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	idgeneratorMock "github.com/decentralized-cloud/user/services/idgenerator/mock"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/repository/factory"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+func TestFactory(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Repository Factory Tests")
+}
+
+var _ = Describe("Repository Factory Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		dependencies             factory.Dependencies
+		repositoryType           string
+		databaseType             string
+		cacheEnabled             bool
+		routes                   map[string]string
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		repositoryType = "memory"
+		databaseType = "mongodb"
+		cacheEnabled = false
+		routes = map[string]string{}
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetRepositoryType().
+			DoAndReturn(func() (string, error) { return repositoryType, nil }).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetDatabaseType().
+			DoAndReturn(func() (string, error) { return databaseType, nil }).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetCacheEnabled().
+			DoAndReturn(func() (bool, error) { return cacheEnabled, nil }).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetDataResidencyRoutes().
+			DoAndReturn(func() (map[string]string, error) { return routes, nil }).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetDataResidencyDefaultRegion().
+			Return("", nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetReadDeduplicationEnabled().
+			Return(true, nil).
+			AnyTimes()
+
+		mockClockService := clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			Return(time.Now()).
+			AnyTimes()
+
+		dependencies = factory.Dependencies{
+			Logger:               zap.NewNop(),
+			ConfigurationService: mockConfigurationService,
+			ClockService:         mockClockService,
+			IDGeneratorService:   idgeneratorMock.NewMockIDGeneratorContract(mockCtrl),
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to create the repository service", func() {
+		When("configuration service is not provided", func() {
+			It("should return ArgumentNilError", func() {
+				dependencies.ConfigurationService = nil
+
+				service, _, err := factory.NewRepositoryService(dependencies)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("the memory repository is selected", func() {
+			It("should create the repository that keeps the users in memory", func() {
+				service, invalidationBus, err := factory.NewRepositoryService(dependencies)
+				Ω(err).Should(BeNil())
+				Ω(invalidationBus).Should(BeNil())
+
+				ctx := context.Background()
+				email := cuid.New() + "@test.com"
+				_, err = service.CreateUser(ctx, &repository.CreateUserRequest{Email: email, User: models.User{}})
+				Ω(err).Should(BeNil())
+
+				response, err := service.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(response.User.CreatedAt).ShouldNot(BeNil())
+			})
+		})
+
+		When("the memory repository is combined with the cache", func() {
+			It("should return UnknownError", func() {
+				cacheEnabled = true
+
+				service, _, err := factory.NewRepositoryService(dependencies)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+			})
+		})
+
+		When("the memory repository is combined with the data residency routes", func() {
+			It("should return UnknownError", func() {
+				routes = map[string]string{"eu": "mongodb://mongodb-eu:27017"}
+
+				service, _, err := factory.NewRepositoryService(dependencies)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+			})
+		})
+
+		When("the database repository does not match the database type", func() {
+			It("should return UnknownError", func() {
+				repositoryType = "postgres"
+
+				service, _, err := factory.NewRepositoryService(dependencies)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("user tries to create the database repository service", func() {
+		When("the memory repository is selected", func() {
+			It("should return UnknownError", func() {
+				service, err := factory.NewDatabaseRepositoryService(dependencies)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,365 @@
+// Package instrumented implements the repository service that records the metrics of the operations made on the
+// underlying repository
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/instrumentation"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+)
+
+// dependency is the name the operations on the repository are recorded under
+const dependency = "repository"
+
+type instrumentedRepositoryService struct {
+	logger            *zap.Logger
+	repositoryService repository.RepositoryContract
+}
+
+// NewInstrumentedRepositoryService creates new instance of the instrumentedRepositoryService, setting up all dependencies and returns the instance.
+// The number, the errors and the duration of the operations made on the decorated repository are recorded as metrics,
+// and the operations are logged.
+// logger: Mandatory. Reference to the logger service
+// repositoryService: Mandatory. Reference to the repository service the operations are made on
+// Returns the new service or error if something goes wrong
+func NewInstrumentedRepositoryService(
+	logger *zap.Logger,
+	repositoryService repository.RepositoryContract) (repository.RepositoryContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	if repositoryService == nil {
+		return nil, commonErrors.NewArgumentNilError("repositoryService", "repositoryService is required")
+	}
+
+	return &instrumentedRepositoryService{
+		logger:            logger,
+		repositoryService: repositoryService,
+	}, nil
+}
+
+// CreateUser creates a new user.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to create a new user
+// Returns either the result of creating new user or error if something goes wrong.
+func (service *instrumentedRepositoryService) CreateUser(
+	ctx context.Context,
+	request *repository.CreateUserRequest) (response *repository.CreateUserResponse, err error) {
+	defer service.observe("CreateUser", time.Now(), &err)
+
+	return service.repositoryService.CreateUser(ctx, request)
+}
+
+// ReadUser read an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read an existing user
+// Returns either the result of reading an existing user or error if something goes wrong.
+func (service *instrumentedRepositoryService) ReadUser(
+	ctx context.Context,
+	request *repository.ReadUserRequest) (response *repository.ReadUserResponse, err error) {
+	defer service.observe("ReadUser", time.Now(), &err)
+
+	return service.repositoryService.ReadUser(ctx, request)
+}
+
+// ReadUsers reads the existing users with the given email addresses or IDs at once
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the existing users
+// Returns either the users found or error if something goes wrong.
+func (service *instrumentedRepositoryService) ReadUsers(
+	ctx context.Context,
+	request *repository.ReadUsersRequest) (response *repository.ReadUsersResponse, err error) {
+	defer service.observe("ReadUsers", time.Now(), &err)
+
+	return service.repositoryService.ReadUsers(ctx, request)
+}
+
+// UpdateUser update an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to update an existing user
+// Returns either the result of updateing an existing user or error if something goes wrong.
+func (service *instrumentedRepositoryService) UpdateUser(
+	ctx context.Context,
+	request *repository.UpdateUserRequest) (response *repository.UpdateUserResponse, err error) {
+	defer service.observe("UpdateUser", time.Now(), &err)
+
+	return service.repositoryService.UpdateUser(ctx, request)
+}
+
+// ChangeEmail changes the email address of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the current and the new email addresses of the user
+// Returns either the user after its email address is changed or error if something goes wrong.
+func (service *instrumentedRepositoryService) ChangeEmail(
+	ctx context.Context,
+	request *repository.ChangeEmailRequest) (response *repository.ChangeEmailResponse, err error) {
+	defer service.observe("ChangeEmail", time.Now(), &err)
+
+	return service.repositoryService.ChangeEmail(ctx, request)
+}
+
+// ReadUserPreferences reads the preferences of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the preferences of an existing user
+// Returns either the preferences of the user or error if something goes wrong.
+func (service *instrumentedRepositoryService) ReadUserPreferences(
+	ctx context.Context,
+	request *repository.ReadUserPreferencesRequest) (response *repository.ReadUserPreferencesResponse, err error) {
+	defer service.observe("ReadUserPreferences", time.Now(), &err)
+
+	return service.repositoryService.ReadUserPreferences(ctx, request)
+}
+
+// UpdateUserPreferences merges the preferences into the preferences of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the preferences to set and the keys of the preferences to remove
+// Returns either the preferences of the user after they are merged or error if something goes wrong.
+func (service *instrumentedRepositoryService) UpdateUserPreferences(
+	ctx context.Context,
+	request *repository.UpdateUserPreferencesRequest) (response *repository.UpdateUserPreferencesResponse, err error) {
+	defer service.observe("UpdateUserPreferences", time.Now(), &err)
+
+	return service.repositoryService.UpdateUserPreferences(ctx, request)
+}
+
+// AddUserToTenant adds the membership in the tenant to an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the membership to add
+// Returns either the user after the membership is added or error if something goes wrong.
+func (service *instrumentedRepositoryService) AddUserToTenant(
+	ctx context.Context,
+	request *repository.AddUserToTenantRequest) (response *repository.AddUserToTenantResponse, err error) {
+	defer service.observe("AddUserToTenant", time.Now(), &err)
+
+	return service.repositoryService.AddUserToTenant(ctx, request)
+}
+
+// RemoveUserFromTenant removes the membership in the tenant from an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the tenant to remove the membership of
+// Returns either the user after the membership is removed or error if something goes wrong.
+func (service *instrumentedRepositoryService) RemoveUserFromTenant(
+	ctx context.Context,
+	request *repository.RemoveUserFromTenantRequest) (response *repository.RemoveUserFromTenantResponse, err error) {
+	defer service.observe("RemoveUserFromTenant", time.Now(), &err)
+
+	return service.repositoryService.RemoveUserFromTenant(ctx, request)
+}
+
+// SetEmailVerificationToken sets the pending email verification token of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the hash of the token and the time it expires at
+// Returns either the user after the token is set or error if something goes wrong.
+func (service *instrumentedRepositoryService) SetEmailVerificationToken(
+	ctx context.Context,
+	request *repository.SetEmailVerificationTokenRequest) (response *repository.SetEmailVerificationTokenResponse, err error) {
+	defer service.observe("SetEmailVerificationToken", time.Now(), &err)
+
+	return service.repositoryService.SetEmailVerificationToken(ctx, request)
+}
+
+// VerifyEmail marks the email address of an existing user as verified
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the hash of the pending verification token
+// Returns either the user after the email address is verified or error if something goes wrong.
+func (service *instrumentedRepositoryService) VerifyEmail(
+	ctx context.Context,
+	request *repository.VerifyEmailRequest) (response *repository.VerifyEmailResponse, err error) {
+	defer service.observe("VerifyEmail", time.Now(), &err)
+
+	return service.repositoryService.VerifyEmail(ctx, request)
+}
+
+// RecordLoginAttempt records the outcome of a login attempt of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the outcome of the attempt and the lockout threshold
+// Returns either the user after the attempt is recorded or error if something goes wrong.
+func (service *instrumentedRepositoryService) RecordLoginAttempt(
+	ctx context.Context,
+	request *repository.RecordLoginAttemptRequest) (response *repository.RecordLoginAttemptResponse, err error) {
+	defer service.observe("RecordLoginAttempt", time.Now(), &err)
+
+	return service.repositoryService.RecordLoginAttempt(ctx, request)
+}
+
+// UnlockUser unlocks an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to unlock an existing user
+// Returns either the user after it is unlocked or error if something goes wrong.
+func (service *instrumentedRepositoryService) UnlockUser(
+	ctx context.Context,
+	request *repository.UnlockUserRequest) (response *repository.UnlockUserResponse, err error) {
+	defer service.observe("UnlockUser", time.Now(), &err)
+
+	return service.repositoryService.UnlockUser(ctx, request)
+}
+
+// AddMFAMethod adds the MFA method to an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the method to add
+// Returns either the user after the method is added or error if something goes wrong.
+func (service *instrumentedRepositoryService) AddMFAMethod(
+	ctx context.Context,
+	request *repository.AddMFAMethodRequest) (response *repository.AddMFAMethodResponse, err error) {
+	defer service.observe("AddMFAMethod", time.Now(), &err)
+
+	return service.repositoryService.AddMFAMethod(ctx, request)
+}
+
+// RemoveMFAMethod removes the MFA method from an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the ID of the method to remove
+// Returns either the user after the method is removed or error if something goes wrong.
+func (service *instrumentedRepositoryService) RemoveMFAMethod(
+	ctx context.Context,
+	request *repository.RemoveMFAMethodRequest) (response *repository.RemoveMFAMethodResponse, err error) {
+	defer service.observe("RemoveMFAMethod", time.Now(), &err)
+
+	return service.repositoryService.RemoveMFAMethod(ctx, request)
+}
+
+// SetUserAvatar sets the avatar of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the key and the hash of the stored avatar image
+// Returns either the user after the avatar is set or error if something goes wrong.
+func (service *instrumentedRepositoryService) SetUserAvatar(
+	ctx context.Context,
+	request *repository.SetUserAvatarRequest) (response *repository.SetUserAvatarResponse, err error) {
+	defer service.observe("SetUserAvatar", time.Now(), &err)
+
+	return service.repositoryService.SetUserAvatar(ctx, request)
+}
+
+// DeleteUser delete an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to delete an existing user
+// Returns either the result of deleting an existing user or error if something goes wrong.
+func (service *instrumentedRepositoryService) DeleteUser(
+	ctx context.Context,
+	request *repository.DeleteUserRequest) (response *repository.DeleteUserResponse, err error) {
+	defer service.observe("DeleteUser", time.Now(), &err)
+
+	return service.repositoryService.DeleteUser(ctx, request)
+}
+
+// RestoreUser restores an existing soft deleted user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to restore an existing soft deleted user
+// Returns either the result of restoring the user or error if something goes wrong.
+func (service *instrumentedRepositoryService) RestoreUser(
+	ctx context.Context,
+	request *repository.RestoreUserRequest) (response *repository.RestoreUserResponse, err error) {
+	defer service.observe("RestoreUser", time.Now(), &err)
+
+	return service.repositoryService.RestoreUser(ctx, request)
+}
+
+// PurgeDeletedUsers permanently deletes the soft deleted users that are deleted before the given time
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to purge the soft deleted users
+// Returns either the result of purging the users or error if something goes wrong.
+func (service *instrumentedRepositoryService) PurgeDeletedUsers(
+	ctx context.Context,
+	request *repository.PurgeDeletedUsersRequest) (response *repository.PurgeDeletedUsersResponse, err error) {
+	defer service.observe("PurgeDeletedUsers", time.Now(), &err)
+
+	return service.repositoryService.PurgeDeletedUsers(ctx, request)
+}
+
+// ListUsersByLabel lists all the users tagged with the given test label, including the soft deleted ones
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to list the users tagged with the test label
+// Returns either the users tagged with the test label or error if something goes wrong.
+func (service *instrumentedRepositoryService) ListUsersByLabel(
+	ctx context.Context,
+	request *repository.ListUsersByLabelRequest) (response *repository.ListUsersByLabelResponse, err error) {
+	defer service.observe("ListUsersByLabel", time.Now(), &err)
+
+	return service.repositoryService.ListUsersByLabel(ctx, request)
+}
+
+// PurgeUsersByLabel permanently deletes all the users tagged with the given test label, including the soft deleted ones
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to purge the users tagged with the test label
+// Returns either the result of purging the users or error if something goes wrong.
+func (service *instrumentedRepositoryService) PurgeUsersByLabel(
+	ctx context.Context,
+	request *repository.PurgeUsersByLabelRequest) (response *repository.PurgeUsersByLabelResponse, err error) {
+	defer service.observe("PurgeUsersByLabel", time.Now(), &err)
+
+	return service.repositoryService.PurgeUsersByLabel(ctx, request)
+}
+
+// Search returns the list of users that matched the criteria
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria
+// Returns the list of users that matched the criteria
+func (service *instrumentedRepositoryService) Search(
+	ctx context.Context,
+	request *repository.SearchRequest) (response *repository.SearchResponse, err error) {
+	defer service.observe("Search", time.Now(), &err)
+
+	return service.repositoryService.Search(ctx, request)
+}
+
+// StreamSearch sends the users that matched the criteria one by one without loading all of them in memory. The recorded
+// duration includes the time taken to send the users
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria and the function to send the users to
+// Returns either the number of the sent users or error if something goes wrong.
+func (service *instrumentedRepositoryService) StreamSearch(
+	ctx context.Context,
+	request *repository.StreamSearchRequest) (response *repository.StreamSearchResponse, err error) {
+	defer service.observe("StreamSearch", time.Now(), &err)
+
+	return service.repositoryService.StreamSearch(ctx, request)
+}
+
+// Ping verifies the repository can reach the underlying database
+// ctx: Mandatory The reference to the context
+// Returns error if the database is not reachable
+func (service *instrumentedRepositoryService) Ping(ctx context.Context) (err error) {
+	defer service.observe("Ping", time.Now(), &err)
+
+	return service.repositoryService.Ping(ctx)
+}
+
+// observe records the metrics of the operation made on the repository and logs the operation. The errors the callers
+// expect, e.g. the user is not found, are logged at the debug level, the rest of the errors at the error level.
+// operation: Mandatory. The name of the operation
+// startedAt: Mandatory. The time the operation started at
+// err: Mandatory. Reference to the error the operation returned
+func (service *instrumentedRepositoryService) observe(operation string, startedAt time.Time, err *error) {
+	instrumentation.Observe(dependency, operation, startedAt, err)
+
+	duration := zap.Duration("duration", time.Since(startedAt))
+	if *err == nil {
+		service.logger.Debug("repository operation succeeded", zap.String("operation", operation), duration)
+
+		return
+	}
+
+	errorType := instrumentation.GetErrorType(*err)
+	switch errorType {
+	case "not_found", "already_exists", "argument", "canceled":
+		service.logger.Debug(
+			"repository operation failed",
+			zap.String("operation", operation),
+			zap.String("errorType", errorType),
+			duration,
+			zap.Error(*err))
+
+	default:
+		service.logger.Error(
+			"repository operation failed",
+			zap.String("operation", operation),
+			zap.String("errorType", errorType),
+			duration,
+			zap.Error(*err))
+	}
+}
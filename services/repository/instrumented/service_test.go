@@ -0,0 +1,117 @@
+package instrumented_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/repository/instrumented"
+	repositoryMock "github.com/decentralized-cloud/user/services/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+func TestInstrumentedRepositoryService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Instrumented Repository Service Tests")
+}
+
+var _ = Describe("Instrumented Repository Service Tests", func() {
+	var (
+		mockCtrl              *gomock.Controller
+		sut                   repository.RepositoryContract
+		mockRepositoryService *repositoryMock.MockRepositoryContract
+		ctx                   context.Context
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		ctx = context.Background()
+
+		mockRepositoryService = repositoryMock.NewMockRepositoryContract(mockCtrl)
+		sut, _ = instrumented.NewInstrumentedRepositoryService(zap.NewNop(), mockRepositoryService)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate InstrumentedRepositoryService", func() {
+		When("logger is not provided and NewInstrumentedRepositoryService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := instrumented.NewInstrumentedRepositoryService(nil, mockRepositoryService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("repository service is not provided and NewInstrumentedRepositoryService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := instrumented.NewInstrumentedRepositoryService(zap.NewNop(), nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("all dependencies are resolved and NewInstrumentedRepositoryService is called", func() {
+			It("should instantiate the new InstrumentedRepositoryService", func() {
+				service, err := instrumented.NewInstrumentedRepositoryService(zap.NewNop(), mockRepositoryService)
+				Ω(err).Should(BeNil())
+				Ω(service).ShouldNot(BeNil())
+			})
+		})
+	})
+
+	Context("InstrumentedRepositoryService is instantiated", func() {
+		When("the repository returns the result", func() {
+			It("should return the same result", func() {
+				request := repository.UpdateUserRequest{Email: cuid.New() + "@test.com"}
+				expectedResponse := &repository.UpdateUserResponse{Cursor: cuid.New()}
+
+				mockRepositoryService.
+					EXPECT().
+					UpdateUser(ctx, &request).
+					Return(expectedResponse, nil)
+
+				response, err := sut.UpdateUser(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response).Should(Equal(expectedResponse))
+			})
+		})
+
+		When("the repository returns error", func() {
+			It("should return the same error", func() {
+				request := repository.ReadUserRequest{Email: cuid.New() + "@test.com"}
+				expectedError := commonErrors.NewNotFoundError()
+
+				mockRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &request).
+					Return(nil, expectedError)
+
+				response, err := sut.ReadUser(ctx, &request)
+				Ω(response).Should(BeNil())
+				Ω(err).Should(Equal(expectedError))
+			})
+		})
+
+		When("the database is not reachable", func() {
+			It("should return the same error from Ping", func() {
+				expectedError := errors.New(cuid.New())
+
+				mockRepositoryService.
+					EXPECT().
+					Ping(ctx).
+					Return(expectedError)
+
+				Ω(sut.Ping(ctx)).Should(Equal(expectedError))
+			})
+		})
+	})
+})
@@ -0,0 +1,19 @@
+package memory_test
+
+import (
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/repository/conformance"
+	"github.com/decentralized-cloud/user/services/repository/memory"
+	. "github.com/onsi/gomega"
+)
+
+var _ = conformance.DescribeRepositoryContract("Memory", func() repository.RepositoryContract {
+	clockService, err := clock.NewClockService()
+	Ω(err).Should(BeNil())
+
+	sut, err := memory.NewMemoryRepositoryService(clockService)
+	Ω(err).Should(BeNil())
+
+	return sut
+})
@@ -0,0 +1,1092 @@
+// Package memory implements the in-memory repository service
+package memory
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// sortableFields are the name of the fields the search result can be sorted by, the same fields the database
+// repositories support
+var sortableFields = map[string]bool{
+	"email":     true,
+	"createdAt": true,
+	"updatedAt": true,
+	"createdBy": true,
+	"updatedBy": true,
+}
+
+// storedUser is the user kept in memory along the fields that are not part of the user model
+type storedUser struct {
+	id          int64
+	email       string
+	user        models.User
+	preferences map[string]string
+	deletedAt   *time.Time
+}
+
+// searchFilter contains the search criteria only some of the searches support
+type searchFilter struct {
+	userIDs       []string
+	tenantID      string
+	readTenantID  string
+	createdAfter  *time.Time
+	createdBefore *time.Time
+	after         string
+	labelSelector []models.LabelSelectorRequirement
+	query         string
+}
+
+type memoryRepositoryService struct {
+	clockService clock.ClockContract
+	lock         sync.RWMutex
+	users        map[string]*storedUser
+	lastID       int64
+}
+
+// NewMemoryRepositoryService creates new instance of the memoryRepositoryService, setting up all dependencies and
+// returns the instance. The users are only kept in the memory of the process, so they are lost when it exits and are
+// not shared between the replicas. It is meant for the local development and the tests that do not need a database.
+// clockService: Mandatory. Reference to the service that provides the time the users are created, updated and soft
+// deleted at
+// Returns the new service or error if something goes wrong
+func NewMemoryRepositoryService(clockService clock.ClockContract) (repository.RepositoryContract, error) {
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	return &memoryRepositoryService{
+		clockService: clockService,
+		users:        map[string]*storedUser{},
+	}, nil
+}
+
+// CreateUser creates a new user.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to create a new user
+// Returns either the result of creating new user or error if something goes wrong.
+func (service *memoryRepositoryService) CreateUser(
+	ctx context.Context,
+	request *repository.CreateUserRequest) (*repository.CreateUserResponse, error) {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	// The soft deleted users keep their email address, like they do in the databases
+	if _, ok := service.users[request.Email]; ok {
+		return nil, commonErrors.NewAlreadyExistsError()
+	}
+
+	now := service.clockService.Now()
+	actor := repository.GetActor(ctx)
+	tenantID := repository.GetTenant(ctx)
+
+	service.lastID++
+	userID := strconv.FormatInt(service.lastID, 10)
+	service.users[request.Email] = &storedUser{
+		id:    service.lastID,
+		email: request.Email,
+		user: models.User{
+			ID:        userID,
+			Labels:    copyLabels(request.User.Labels),
+			CreatedAt: &now,
+			UpdatedAt: &now,
+			CreatedBy: actor,
+			UpdatedBy: actor,
+			TenantID:  tenantID,
+		},
+		preferences: map[string]string{},
+	}
+
+	createdUser := request.User
+	createdUser.ID = userID
+	createdUser.CreatedAt = &now
+	createdUser.UpdatedAt = &now
+	createdUser.CreatedBy = actor
+	createdUser.UpdatedBy = actor
+	createdUser.TenantID = tenantID
+
+	return &repository.CreateUserResponse{
+		User:   createdUser,
+		Cursor: userID,
+	}, nil
+}
+
+// ReadUser read an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read an existing user
+// Returns either the result of reading an existing user or error if something goes wrong.
+func (service *memoryRepositoryService) ReadUser(
+	ctx context.Context,
+	request *repository.ReadUserRequest) (*repository.ReadUserResponse, error) {
+	service.lock.RLock()
+	defer service.lock.RUnlock()
+
+	var stored *storedUser
+	var err error
+	if request.UserID != "" {
+		stored, err = service.findUserByID(request.UserID, repository.GetReadTenant(ctx), request.IncludeDeleted)
+	} else {
+		stored, err = service.findUser(request.Email, repository.GetReadTenant(ctx))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.ReadUserResponse{
+		Email: stored.email,
+		User:  copyUser(stored.user),
+	}, nil
+}
+
+// ReadUsers reads the existing users with the given email addresses or IDs at once
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the existing users
+// Returns either the users found or error if something goes wrong. The users that are not found are left out.
+func (service *memoryRepositoryService) ReadUsers(
+	ctx context.Context,
+	request *repository.ReadUsersRequest) (*repository.ReadUsersResponse, error) {
+	if len(request.Emails) == 0 && len(request.UserIDs) == 0 {
+		return &repository.ReadUsersResponse{Users: []models.UserWithCursor{}}, nil
+	}
+
+	users, err := service.search(request.Emails, nil, false, searchFilter{userIDs: request.UserIDs, readTenantID: repository.GetReadTenant(ctx)})
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.ReadUsersResponse{
+		Users: users,
+	}, nil
+}
+
+// UpdateUser update an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to update an existing user
+// Returns either the result of updateing an existing user or error if something goes wrong.
+func (service *memoryRepositoryService) UpdateUser(
+	ctx context.Context,
+	request *repository.UpdateUserRequest) (*repository.UpdateUserResponse, error) {
+	user, cursor, err := service.updateUser(ctx, request.Email, true, func(stored *storedUser) error {
+		// The labels are replaced as a whole
+		stored.user.Labels = copyLabels(request.User.Labels)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.UpdateUserResponse{
+		User:   user,
+		Cursor: cursor,
+	}, nil
+}
+
+// ChangeEmail changes the email address of an existing user, the user keeps its ID. The new email address is not
+// verified, so the verification of the previous one is cleared.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the current and the new email addresses of the user
+// Returns either the user after its email address is changed or error if something goes wrong.
+func (service *memoryRepositoryService) ChangeEmail(
+	ctx context.Context,
+	request *repository.ChangeEmailRequest) (*repository.ChangeEmailResponse, error) {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	stored, err := service.findUser(request.Email, repository.GetTenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	// The soft deleted users keep their email address, like they do in the databases
+	if _, ok := service.users[request.NewEmail]; ok {
+		return nil, commonErrors.NewAlreadyExistsError()
+	}
+
+	changed := *stored
+	changed.email = request.NewEmail
+	changed.user = copyUser(stored.user)
+	changed.user.EmailVerified = false
+	changed.user.EmailVerificationTokenHash = ""
+	changed.user.EmailVerificationExpiresAt = nil
+	service.touch(ctx, &changed)
+
+	delete(service.users, request.Email)
+	service.users[request.NewEmail] = &changed
+
+	return &repository.ChangeEmailResponse{
+		User:   copyUser(changed.user),
+		Cursor: strconv.FormatInt(changed.id, 10),
+	}, nil
+}
+
+// ReadUserPreferences reads the preferences of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the preferences of an existing user
+// Returns either the preferences of the user or error if something goes wrong.
+func (service *memoryRepositoryService) ReadUserPreferences(
+	ctx context.Context,
+	request *repository.ReadUserPreferencesRequest) (*repository.ReadUserPreferencesResponse, error) {
+	service.lock.RLock()
+	defer service.lock.RUnlock()
+
+	stored, err := service.findUser(request.Email, repository.GetReadTenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.ReadUserPreferencesResponse{
+		Preferences: copyPreferences(stored.preferences),
+	}, nil
+}
+
+// UpdateUserPreferences merges the preferences into the preferences of an existing user. The preferences are merged
+// while the lock is held, so the concurrent updates of the different preferences do not overwrite each other.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the preferences to set and the keys of the preferences to remove
+// Returns either the preferences of the user after they are merged or error if something goes wrong.
+func (service *memoryRepositoryService) UpdateUserPreferences(
+	ctx context.Context,
+	request *repository.UpdateUserPreferencesRequest) (*repository.UpdateUserPreferencesResponse, error) {
+	var preferences map[string]string
+
+	_, _, err := service.updateUser(ctx, request.Email, true, func(stored *storedUser) error {
+		for key, value := range request.Preferences {
+			stored.preferences[key] = value
+		}
+
+		for _, key := range request.RemovedKeys {
+			delete(stored.preferences, key)
+		}
+
+		preferences = copyPreferences(stored.preferences)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.UpdateUserPreferencesResponse{
+		Preferences: preferences,
+	}, nil
+}
+
+// AddUserToTenant adds the membership in the tenant to an existing user. The membership is added while the lock is
+// held, so the concurrent requests to add the user to the same tenant can not both succeed.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the membership to add
+// Returns either the user after the membership is added or error if something goes wrong.
+func (service *memoryRepositoryService) AddUserToTenant(
+	ctx context.Context,
+	request *repository.AddUserToTenantRequest) (*repository.AddUserToTenantResponse, error) {
+	user, cursor, err := service.updateUser(ctx, request.Email, true, func(stored *storedUser) error {
+		if indexOfMembership(stored.user.Memberships, request.Membership.TenantID) >= 0 {
+			return commonErrors.NewAlreadyExistsError()
+		}
+
+		stored.user.Memberships = append(stored.user.Memberships, request.Membership)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.AddUserToTenantResponse{
+		User:   user,
+		Cursor: cursor,
+	}, nil
+}
+
+// RemoveUserFromTenant removes the membership in the tenant from an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the tenant to remove the membership of
+// Returns either the user after the membership is removed or error if something goes wrong.
+func (service *memoryRepositoryService) RemoveUserFromTenant(
+	ctx context.Context,
+	request *repository.RemoveUserFromTenantRequest) (*repository.RemoveUserFromTenantResponse, error) {
+	user, cursor, err := service.updateUser(ctx, request.Email, true, func(stored *storedUser) error {
+		index := indexOfMembership(stored.user.Memberships, request.TenantID)
+		if index < 0 {
+			return commonErrors.NewNotFoundError()
+		}
+
+		memberships := append([]models.TenantMembership{}, stored.user.Memberships[:index]...)
+		stored.user.Memberships = append(memberships, stored.user.Memberships[index+1:]...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.RemoveUserFromTenantResponse{
+		User:   user,
+		Cursor: cursor,
+	}, nil
+}
+
+// SetEmailVerificationToken sets the pending email verification token of an existing user, replacing the token sent
+// before if any
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the hash of the token and the time it expires at
+// Returns either the user after the token is set or error if something goes wrong.
+func (service *memoryRepositoryService) SetEmailVerificationToken(
+	ctx context.Context,
+	request *repository.SetEmailVerificationTokenRequest) (*repository.SetEmailVerificationTokenResponse, error) {
+	user, cursor, err := service.updateUser(ctx, request.Email, true, func(stored *storedUser) error {
+		expiresAt := request.ExpiresAt
+		stored.user.EmailVerificationTokenHash = request.TokenHash
+		stored.user.EmailVerificationExpiresAt = &expiresAt
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.SetEmailVerificationTokenResponse{
+		User:   user,
+		Cursor: cursor,
+	}, nil
+}
+
+// VerifyEmail marks the email address of an existing user as verified and clears the pending verification token.
+// The user is only updated if the token is still the pending verification token, so a token replaced by a newer
+// verification email can not be used anymore.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the hash of the pending verification token
+// Returns either the user after the email address is verified or error if something goes wrong.
+func (service *memoryRepositoryService) VerifyEmail(
+	ctx context.Context,
+	request *repository.VerifyEmailRequest) (*repository.VerifyEmailResponse, error) {
+	user, cursor, err := service.updateUser(ctx, request.Email, true, func(stored *storedUser) error {
+		if stored.user.EmailVerificationTokenHash == "" || stored.user.EmailVerificationTokenHash != request.TokenHash {
+			return commonErrors.NewNotFoundError()
+		}
+
+		stored.user.EmailVerified = true
+		stored.user.EmailVerificationTokenHash = ""
+		stored.user.EmailVerificationExpiresAt = nil
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.VerifyEmailResponse{
+		User:   user,
+		Cursor: cursor,
+	}, nil
+}
+
+// RecordLoginAttempt records the outcome of a login attempt of an existing user. The login attempts are not
+// considered changes, so the update time of the user is left as it is.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the outcome of the attempt and the lockout threshold
+// Returns either the user after the attempt is recorded or error if something goes wrong.
+func (service *memoryRepositoryService) RecordLoginAttempt(
+	ctx context.Context,
+	request *repository.RecordLoginAttemptRequest) (*repository.RecordLoginAttemptResponse, error) {
+	user, cursor, err := service.updateUser(ctx, request.Email, false, func(stored *storedUser) error {
+		if request.Succeeded {
+			if stored.user.LockedAt == nil {
+				stored.user.FailedLoginAttempts = 0
+			}
+
+			return nil
+		}
+
+		// A zero threshold disables the lockout, so the failed attempts are only counted
+		stored.user.FailedLoginAttempts++
+		if stored.user.LockedAt == nil && request.LockoutThreshold > 0 && stored.user.FailedLoginAttempts >= request.LockoutThreshold {
+			attemptedAt := request.AttemptedAt
+			stored.user.LockedAt = &attemptedAt
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.RecordLoginAttemptResponse{
+		User:   user,
+		Cursor: cursor,
+	}, nil
+}
+
+// UnlockUser unlocks an existing user and resets the number of its failed login attempts
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to unlock an existing user
+// Returns either the user after it is unlocked or error if something goes wrong.
+func (service *memoryRepositoryService) UnlockUser(
+	ctx context.Context,
+	request *repository.UnlockUserRequest) (*repository.UnlockUserResponse, error) {
+	user, cursor, err := service.updateUser(ctx, request.Email, true, func(stored *storedUser) error {
+		stored.user.FailedLoginAttempts = 0
+		stored.user.LockedAt = nil
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.UnlockUserResponse{
+		User:   user,
+		Cursor: cursor,
+	}, nil
+}
+
+// AddMFAMethod adds the MFA method to an existing user. The method is added while the lock is held, so the
+// concurrent requests to enroll the same credential can not both succeed.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the method to add
+// Returns either the user after the method is added or error if something goes wrong.
+func (service *memoryRepositoryService) AddMFAMethod(
+	ctx context.Context,
+	request *repository.AddMFAMethodRequest) (*repository.AddMFAMethodResponse, error) {
+	user, cursor, err := service.updateUser(ctx, request.Email, true, func(stored *storedUser) error {
+		credentialKey := request.Method.CredentialKey()
+		for _, method := range stored.user.MFAMethods {
+			if method.CredentialKey() == credentialKey {
+				return commonErrors.NewAlreadyExistsError()
+			}
+		}
+
+		stored.user.MFAMethods = append(stored.user.MFAMethods, copyMFAMethod(request.Method))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.AddMFAMethodResponse{
+		User:   user,
+		Cursor: cursor,
+	}, nil
+}
+
+// RemoveMFAMethod removes the MFA method from an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the ID of the method to remove
+// Returns either the user after the method is removed or error if something goes wrong.
+func (service *memoryRepositoryService) RemoveMFAMethod(
+	ctx context.Context,
+	request *repository.RemoveMFAMethodRequest) (*repository.RemoveMFAMethodResponse, error) {
+	user, cursor, err := service.updateUser(ctx, request.Email, true, func(stored *storedUser) error {
+		for index, method := range stored.user.MFAMethods {
+			if method.MethodID != request.MethodID {
+				continue
+			}
+
+			// The users that removed all their methods have no MFA methods rather than an empty list
+			var methods []models.MFAMethod
+			methods = append(methods, stored.user.MFAMethods[:index]...)
+			stored.user.MFAMethods = append(methods, stored.user.MFAMethods[index+1:]...)
+
+			return nil
+		}
+
+		return commonErrors.NewNotFoundError()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.RemoveMFAMethodResponse{
+		User:   user,
+		Cursor: cursor,
+	}, nil
+}
+
+// SetUserAvatar sets the avatar of an existing user, replacing the avatar set before if any
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the key and the hash of the stored avatar image
+// Returns either the user after the avatar is set or error if something goes wrong.
+func (service *memoryRepositoryService) SetUserAvatar(
+	ctx context.Context,
+	request *repository.SetUserAvatarRequest) (*repository.SetUserAvatarResponse, error) {
+	user, cursor, err := service.updateUser(ctx, request.Email, true, func(stored *storedUser) error {
+		avatar := request.Avatar
+		stored.user.Avatar = &avatar
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.SetUserAvatarResponse{
+		User:   user,
+		Cursor: cursor,
+	}, nil
+}
+
+// DeleteUser delete an existing user. The user is only marked as deleted if the request asks for a soft delete
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to delete an existing user
+// Returns either the result of deleting an existing user or error if something goes wrong.
+func (service *memoryRepositoryService) DeleteUser(
+	ctx context.Context,
+	request *repository.DeleteUserRequest) (*repository.DeleteUserResponse, error) {
+	if request.SoftDelete {
+		_, _, err := service.updateUser(ctx, request.Email, true, func(stored *storedUser) error {
+			deletedAt := service.clockService.Now()
+			stored.deletedAt = &deletedAt
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &repository.DeleteUserResponse{}, nil
+	}
+
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	if _, err := service.findUser(request.Email, repository.GetTenant(ctx)); err != nil {
+		return nil, err
+	}
+
+	delete(service.users, request.Email)
+
+	return &repository.DeleteUserResponse{}, nil
+}
+
+// RestoreUser restores an existing soft deleted user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to restore an existing soft deleted user
+// Returns either the result of restoring the user or error if something goes wrong.
+func (service *memoryRepositoryService) RestoreUser(
+	ctx context.Context,
+	request *repository.RestoreUserRequest) (*repository.RestoreUserResponse, error) {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	stored, ok := service.users[request.Email]
+	if !ok || stored.deletedAt == nil || !isInTenant(stored, repository.GetTenant(ctx)) {
+		return nil, commonErrors.NewNotFoundError()
+	}
+
+	stored.deletedAt = nil
+	service.touch(ctx, stored)
+
+	return &repository.RestoreUserResponse{
+		User:   copyUser(stored.user),
+		Cursor: strconv.FormatInt(stored.id, 10),
+	}, nil
+}
+
+// PurgeDeletedUsers permanently deletes the soft deleted users that are deleted before the given time
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to purge the soft deleted users
+// Returns either the result of purging the users or error if something goes wrong.
+func (service *memoryRepositoryService) PurgeDeletedUsers(
+	ctx context.Context,
+	request *repository.PurgeDeletedUsersRequest) (*repository.PurgeDeletedUsersResponse, error) {
+	purgedCount := service.purgeUsers(repository.GetTenant(ctx), func(stored *storedUser) bool {
+		return stored.deletedAt != nil && stored.deletedAt.Before(request.DeletedBefore)
+	})
+
+	return &repository.PurgeDeletedUsersResponse{
+		PurgedCount: purgedCount,
+	}, nil
+}
+
+// ListUsersByLabel lists all the users tagged with the given test label, including the soft deleted ones
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to list the users tagged with the test label
+// Returns either the users tagged with the test label or error if something goes wrong.
+func (service *memoryRepositoryService) ListUsersByLabel(
+	ctx context.Context,
+	request *repository.ListUsersByLabelRequest) (*repository.ListUsersByLabelResponse, error) {
+	pattern, err := regexp.Compile(models.GetTestLabelEmailPattern(request.Label))
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list users by label", err)
+	}
+
+	service.lock.RLock()
+	defer service.lock.RUnlock()
+
+	tenantID := repository.GetTenant(ctx)
+	users := []models.UserWithCursor{}
+	for _, stored := range service.users {
+		if !isInTenant(stored, tenantID) || !pattern.MatchString(stored.email) {
+			continue
+		}
+
+		cursor := strconv.FormatInt(stored.id, 10)
+		users = append(users, models.UserWithCursor{
+			UserID:    cursor,
+			Email:     stored.email,
+			User:      copyUser(stored.user),
+			Cursor:    cursor,
+			CreatedAt: *stored.user.CreatedAt,
+			DeletedAt: copyTime(stored.deletedAt),
+		})
+	}
+
+	return &repository.ListUsersByLabelResponse{
+		Users: users,
+	}, nil
+}
+
+// PurgeUsersByLabel permanently deletes all the users tagged with the given test label, including the soft deleted ones
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to purge the users tagged with the test label
+// Returns either the result of purging the users or error if something goes wrong.
+func (service *memoryRepositoryService) PurgeUsersByLabel(
+	ctx context.Context,
+	request *repository.PurgeUsersByLabelRequest) (*repository.PurgeUsersByLabelResponse, error) {
+	pattern, err := regexp.Compile(models.GetTestLabelEmailPattern(request.Label))
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to purge users by label", err)
+	}
+
+	purgedCount := service.purgeUsers(repository.GetTenant(ctx), func(stored *storedUser) bool {
+		return pattern.MatchString(stored.email)
+	})
+
+	return &repository.PurgeUsersByLabelResponse{
+		PurgedCount: purgedCount,
+	}, nil
+}
+
+// Search returns the list of users that matched the criteria
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria
+// Returns the list of users that matched the criteria
+func (service *memoryRepositoryService) Search(
+	ctx context.Context,
+	request *repository.SearchRequest) (*repository.SearchResponse, error) {
+	users, err := service.search(
+		request.Emails,
+		request.SortingOptions,
+		request.IncludeDeleted,
+		searchFilter{readTenantID: repository.GetReadTenant(ctx), labelSelector: request.LabelSelector, query: request.Query})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(request.SortingOptions) == 0 {
+		repository.SortByRelevance(users, request.Query)
+	}
+
+	return repository.Paginate(users, request.Pagination)
+}
+
+// StreamSearch sends the users that matched the criteria one by one. The matched users are copied before the first
+// one is sent, so Send is called without holding the lock.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria and the function to send the users to
+// Returns either the number of the sent users or error if something goes wrong.
+func (service *memoryRepositoryService) StreamSearch(
+	ctx context.Context,
+	request *repository.StreamSearchRequest) (*repository.StreamSearchResponse, error) {
+	users, err := service.search(request.Emails, request.SortingOptions, request.IncludeDeleted, searchFilter{
+		tenantID:      request.TenantID,
+		readTenantID:  repository.GetReadTenant(ctx),
+		createdAfter:  request.CreatedAfter,
+		createdBefore: request.CreatedBefore,
+		after:         request.After,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var sentCount int64
+	for _, user := range users {
+		if err = ctx.Err(); err != nil {
+			return nil, commonErrors.NewUnknownErrorWithError("failed to search users", err)
+		}
+
+		if err = request.Send(user); err != nil {
+			return nil, commonErrors.NewUnknownErrorWithError("failed to send user", err)
+		}
+
+		sentCount++
+	}
+
+	return &repository.StreamSearchResponse{
+		SentCount: sentCount,
+	}, nil
+}
+
+// Ping verifies the repository can reach the underlying database, the memory is always reachable
+// ctx: Mandatory The reference to the context
+// Returns nil
+func (service *memoryRepositoryService) Ping(ctx context.Context) error {
+	return nil
+}
+
+// updateUser changes the user while the lock is held, so the changes are atomic like the conditional updates of the
+// database repositories
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// touch: Mandatory. Whether the change is recorded as the last update of the user
+// change: Mandatory. The function that changes the user, the user is left as it is if it returns error
+// Returns either the user after the change and its cursor or error if something goes wrong
+func (service *memoryRepositoryService) updateUser(
+	ctx context.Context,
+	email string,
+	touch bool,
+	change func(stored *storedUser) error) (models.User, string, error) {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	stored, err := service.findUser(email, repository.GetTenant(ctx))
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	// The change is made to a copy, so a change that fails half way through does not leave the user changed
+	changed := *stored
+	changed.user = copyUser(stored.user)
+	changed.preferences = copyPreferences(stored.preferences)
+	if err = change(&changed); err != nil {
+		return models.User{}, "", err
+	}
+
+	if touch {
+		service.touch(ctx, &changed)
+	}
+
+	service.users[email] = &changed
+
+	return copyUser(changed.user), strconv.FormatInt(changed.id, 10), nil
+}
+
+// touch records the change as the last update of the user
+// ctx: Mandatory The reference to the context
+// stored: Mandatory. The user that is changed
+func (service *memoryRepositoryService) touch(ctx context.Context, stored *storedUser) {
+	now := service.clockService.Now()
+	stored.user.UpdatedAt = &now
+	stored.user.UpdatedBy = repository.GetActor(ctx)
+}
+
+// findUser finds the user unless it is soft deleted or belongs to another tenant. The lock must be held by the caller.
+// email: Mandatory. The email address of the user
+// tenantID: Optional. The tenant the user must belong to, the user is matched whatever its tenant is if empty
+// Returns either the user or error if something goes wrong
+func (service *memoryRepositoryService) findUser(email string, tenantID string) (*storedUser, error) {
+	stored, ok := service.users[email]
+	if !ok || stored.deletedAt != nil || !isInTenant(stored, tenantID) {
+		return nil, commonErrors.NewNotFoundError()
+	}
+
+	return stored, nil
+}
+
+// findUserByID finds the user with the given ID unless it belongs to another tenant, or it is soft deleted and the
+// soft deleted users are not included. The lock must be held by the caller.
+// userID: Mandatory. The ID of the user
+// tenantID: Optional. The tenant the user must belong to, the user is matched whatever its tenant is if empty
+// includeDeleted: Mandatory. Whether the soft deleted user is matched as well
+// Returns either the user or error if something goes wrong
+func (service *memoryRepositoryService) findUserByID(userID string, tenantID string, includeDeleted bool) (*storedUser, error) {
+	for _, stored := range service.users {
+		if stored.user.ID == userID {
+			if includeDeleted && stored.deletedAt != nil && isInTenant(stored, tenantID) {
+				return stored, nil
+			}
+
+			return service.findUser(stored.email, tenantID)
+		}
+	}
+
+	return nil, commonErrors.NewNotFoundError()
+}
+
+// purgeUsers permanently deletes the users that matched the criteria
+// tenantID: Optional. The tenant the users must belong to, the users are matched whatever their tenant is if empty
+// matches: Mandatory. The function that decides whether the user is deleted
+// Returns the number of the deleted users
+func (service *memoryRepositoryService) purgeUsers(tenantID string, matches func(stored *storedUser) bool) int64 {
+	service.lock.Lock()
+	defer service.lock.Unlock()
+
+	var purgedCount int64
+	for email, stored := range service.users {
+		if isInTenant(stored, tenantID) && matches(stored) {
+			delete(service.users, email)
+			purgedCount++
+		}
+	}
+
+	return purgedCount
+}
+
+// search finds the users that matched the criteria and sorts them
+// emails: Optional. The email addresses to filter the users by
+// sortingOptions: Optional. The sorting options to apply
+// includeDeleted: Mandatory. Whether the soft deleted users should be matched as well
+// filter: Optional. The additional criteria only some of the searches support
+// Returns either the sorted users or error if sorting by any of the given fields is not supported
+func (service *memoryRepositoryService) search(
+	emails []string,
+	sortingOptions []models.SortingOptionPair,
+	includeDeleted bool,
+	filter searchFilter) ([]models.UserWithCursor, error) {
+	for _, sortingOption := range sortingOptions {
+		if !sortableFields[sortingOption.Name] {
+			return nil, commonErrors.NewArgumentError("request", fmt.Sprintf("sorting by %s is not supported", sortingOption.Name))
+		}
+	}
+
+	var afterID int64
+	if filter.after != "" {
+		var err error
+		if afterID, err = strconv.ParseInt(filter.after, 10, 64); err != nil {
+			return nil, commonErrors.NewArgumentError("request", fmt.Sprintf("invalid cursor %s", filter.after))
+		}
+	}
+
+	emailSet := map[string]bool{}
+	for _, email := range emails {
+		emailSet[email] = true
+	}
+
+	// The users named by either their email addresses or their IDs are matched
+	userIDSet := map[string]bool{}
+	for _, userID := range filter.userIDs {
+		userIDSet[userID] = true
+	}
+
+	service.lock.RLock()
+
+	matched := []*storedUser{}
+	for _, stored := range service.users {
+		switch {
+		case (len(emailSet) > 0 || len(userIDSet) > 0) && !emailSet[stored.email] && !userIDSet[stored.user.ID]:
+		case !includeDeleted && stored.deletedAt != nil:
+		case filter.tenantID != "" && indexOfMembership(stored.user.Memberships, filter.tenantID) < 0:
+		case !isInTenant(stored, filter.readTenantID):
+		case filter.createdAfter != nil && stored.user.CreatedAt.Before(*filter.createdAfter):
+		case filter.createdBefore != nil && !stored.user.CreatedAt.Before(*filter.createdBefore):
+		case stored.id <= afterID:
+		case !matchesLabelSelector(stored.user.Labels, filter.labelSelector):
+		case !repository.MatchesQuery(stored.email, filter.query):
+		default:
+			matched = append(matched, stored)
+		}
+	}
+
+	users := make([]models.UserWithCursor, 0, len(matched))
+	for _, stored := range matched {
+		cursor := strconv.FormatInt(stored.id, 10)
+		users = append(users, models.UserWithCursor{
+			UserID:    cursor,
+			Email:     stored.email,
+			User:      copyUser(stored.user),
+			Cursor:    cursor,
+			CreatedAt: *stored.user.CreatedAt,
+			DeletedAt: copyTime(stored.deletedAt),
+		})
+	}
+
+	service.lock.RUnlock()
+
+	// Always sort by the ID last so the position of the users, hence the cursors, are stable
+	sort.SliceStable(users, func(i, j int) bool {
+		for _, sortingOption := range sortingOptions {
+			if comparison := compareField(users[i], users[j], sortingOption.Name); comparison != 0 {
+				if sortingOption.Direction == models.Descending {
+					return comparison > 0
+				}
+
+				return comparison < 0
+			}
+		}
+
+		return cursorID(users[i]) < cursorID(users[j])
+	})
+
+	return users, nil
+}
+
+// compareField compares the field of the users, the users without an update time are sorted before the others as
+// they are in the databases
+// Returns a negative number if the field of the first user sorts first, a positive number if it sorts last, otherwise 0
+func compareField(first models.UserWithCursor, second models.UserWithCursor, field string) int {
+	switch field {
+	case "email":
+		return strings.Compare(first.Email, second.Email)
+	case "createdAt":
+		return compareTimes(&first.CreatedAt, &second.CreatedAt)
+	case "updatedAt":
+		return compareTimes(first.User.UpdatedAt, second.User.UpdatedAt)
+	case "createdBy":
+		return strings.Compare(first.User.CreatedBy, second.User.CreatedBy)
+	default:
+		return strings.Compare(first.User.UpdatedBy, second.User.UpdatedBy)
+	}
+}
+
+func compareTimes(first *time.Time, second *time.Time) int {
+	switch {
+	case first == nil && second == nil:
+		return 0
+	case first == nil:
+		return -1
+	case second == nil:
+		return 1
+	case first.Before(*second):
+		return -1
+	case second.Before(*first):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func cursorID(user models.UserWithCursor) int64 {
+	id, _ := strconv.ParseInt(user.Cursor, 10, 64)
+
+	return id
+}
+
+// matchesLabelSelector indicates whether the labels match all the requirements of the label selector. The users
+// without the label match the notin requirements, like they do in the databases.
+func matchesLabelSelector(labels map[string]string, labelSelector []models.LabelSelectorRequirement) bool {
+	for _, requirement := range labelSelector {
+		value, ok := labels[requirement.Key]
+
+		switch requirement.Operator {
+		case models.LabelSelectorOperatorIn:
+			if !ok || !containsValue(requirement.Values, value) {
+				return false
+			}
+
+		case models.LabelSelectorOperatorNotIn:
+			if ok && containsValue(requirement.Values, value) {
+				return false
+			}
+
+		case models.LabelSelectorOperatorDoesNotExist:
+			if ok {
+				return false
+			}
+
+		default:
+			if !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func containsValue(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isInTenant(stored *storedUser, tenantID string) bool {
+	return tenantID == "" || stored.user.TenantID == tenantID
+}
+
+func indexOfMembership(memberships []models.TenantMembership, tenantID string) int {
+	for index, membership := range memberships {
+		if membership.TenantID == tenantID {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// copyUser copies the user deeply, so the callers can not change the users kept in memory through the returned ones
+func copyUser(user models.User) models.User {
+	copied := user
+	copied.Labels = copyLabels(user.Labels)
+	copied.EmailVerificationExpiresAt = copyTime(user.EmailVerificationExpiresAt)
+	copied.LockedAt = copyTime(user.LockedAt)
+	copied.CreatedAt = copyTime(user.CreatedAt)
+	copied.UpdatedAt = copyTime(user.UpdatedAt)
+
+	if user.Memberships != nil {
+		copied.Memberships = append([]models.TenantMembership{}, user.Memberships...)
+	}
+
+	// The users that never enrolled have no MFA methods rather than an empty list
+	copied.MFAMethods = nil
+	for _, method := range user.MFAMethods {
+		copied.MFAMethods = append(copied.MFAMethods, copyMFAMethod(method))
+	}
+
+	if user.Avatar != nil {
+		avatar := *user.Avatar
+		copied.Avatar = &avatar
+	}
+
+	return copied
+}
+
+func copyMFAMethod(method models.MFAMethod) models.MFAMethod {
+	copied := method
+	if method.RecoveryCodeHashes != nil {
+		copied.RecoveryCodeHashes = append([]string{}, method.RecoveryCodeHashes...)
+	}
+
+	return copied
+}
+
+// copyLabels copies the labels, the users without labels have no labels rather than an empty map like they do in the
+// databases
+func copyLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	copied := make(map[string]string, len(labels))
+	for key, value := range labels {
+		copied[key] = value
+	}
+
+	return copied
+}
+
+func copyPreferences(preferences map[string]string) map[string]string {
+	copied := make(map[string]string, len(preferences))
+	for key, value := range preferences {
+		copied[key] = value
+	}
+
+	return copied
+}
+
+func copyTime(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+
+	copied := *value
+
+	return &copied
+}
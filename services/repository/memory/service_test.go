@@ -0,0 +1,619 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/repository/memory"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestMemoryRepositoryService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Memory Repository Service Tests")
+}
+
+var _ = Describe("Memory Repository Service Tests", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		sut              repository.RepositoryContract
+		ctx              context.Context
+		createRequest    repository.CreateUserRequest
+		mockClockService *clockMock.MockClockContract
+		now              time.Time
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		now = time.Now().UTC()
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			DoAndReturn(func() time.Time { return now }).
+			AnyTimes()
+
+		var err error
+		sut, err = memory.NewMemoryRepositoryService(mockClockService)
+		Ω(err).Should(BeNil())
+
+		ctx = context.Background()
+		createRequest = repository.CreateUserRequest{
+			Email: cuid.New() + "@test.com",
+			User:  models.User{}}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate RepositoryService", func() {
+		When("clock service is not provided and NewMemoryRepositoryService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := memory.NewMemoryRepositoryService(nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("user going to create a new user", func() {
+		When("create user is called", func() {
+			It("should create the new user", func() {
+				response, err := sut.CreateUser(ctx, &createRequest)
+				Ω(err).Should(BeNil())
+				Ω(response.Cursor).ShouldNot(BeEmpty())
+			})
+		})
+	})
+
+	Context("user already exists", func() {
+		var (
+			email string
+		)
+
+		BeforeEach(func() {
+			_, _ = sut.CreateUser(ctx, &createRequest)
+			email = createRequest.Email
+		})
+
+		When("user creates another user with the same email address", func() {
+			It("should return AlreadyExistsError", func() {
+				response, err := sut.CreateUser(ctx, &createRequest)
+				Ω(err).Should(HaveOccurred())
+				Ω(response).Should(BeNil())
+				Ω(commonErrors.IsAlreadyExistsError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user reads the user", func() {
+			It("should return the user", func() {
+				response, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(response).ShouldNot(BeNil())
+			})
+		})
+
+		When("user updates the existing user", func() {
+			It("should update the user information", func() {
+				response, err := sut.UpdateUser(ctx, &repository.UpdateUserRequest{Email: email, User: models.User{}})
+				Ω(err).Should(BeNil())
+				Ω(response.Cursor).ShouldNot(BeEmpty())
+			})
+		})
+
+		When("user updates the existing user on behalf of a caller", func() {
+			It("should record the time and the caller that created and last updated the user", func() {
+				actor := cuid.New() + "@test.com"
+				actorCtx := context.WithValue(ctx, models.ContextKeyParsedToken, models.ParsedToken{Email: actor})
+
+				response, err := sut.UpdateUser(actorCtx, &repository.UpdateUserRequest{Email: email, User: models.User{}})
+				Ω(err).Should(BeNil())
+				Ω(*response.User.CreatedAt).Should(BeTemporally("==", now))
+				Ω(*response.User.UpdatedAt).Should(BeTemporally("==", now))
+				Ω(response.User.CreatedBy).Should(BeEmpty())
+				Ω(response.User.UpdatedBy).Should(Equal(actor))
+			})
+		})
+
+		When("user adds the existing user to a tenant", func() {
+			It("should add the membership once and remove it once", func() {
+				membership := models.TenantMembership{
+					TenantID: cuid.New(),
+					Role:     models.TenantRoleMember,
+					JoinedAt: time.Now().UTC().Truncate(time.Millisecond),
+				}
+
+				addResponse, err := sut.AddUserToTenant(ctx, &repository.AddUserToTenantRequest{Email: email, Membership: membership})
+				Ω(err).Should(BeNil())
+				Ω(addResponse.User.Memberships).Should(HaveLen(1))
+				Ω(addResponse.User.Memberships[0].TenantID).Should(Equal(membership.TenantID))
+				Ω(addResponse.User.Memberships[0].Role).Should(Equal(membership.Role))
+				Ω(addResponse.User.Memberships[0].JoinedAt.Equal(membership.JoinedAt)).Should(BeTrue())
+
+				_, err = sut.AddUserToTenant(ctx, &repository.AddUserToTenantRequest{Email: email, Membership: membership})
+				Ω(commonErrors.IsAlreadyExistsError(err)).Should(BeTrue())
+
+				readResponse, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(readResponse.User.Memberships).Should(HaveLen(1))
+
+				removeResponse, err := sut.RemoveUserFromTenant(ctx, &repository.RemoveUserFromTenantRequest{Email: email, TenantID: membership.TenantID})
+				Ω(err).Should(BeNil())
+				Ω(removeResponse.User.Memberships).Should(BeEmpty())
+
+				_, err = sut.RemoveUserFromTenant(ctx, &repository.RemoveUserFromTenantRequest{Email: email, TenantID: membership.TenantID})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user verifies the email address of the existing user", func() {
+			It("should only verify the email address with the pending verification token", func() {
+				expiresAt := time.Now().UTC().Truncate(time.Millisecond).Add(time.Hour)
+
+				setResponse, err := sut.SetEmailVerificationToken(ctx, &repository.SetEmailVerificationTokenRequest{
+					Email:     email,
+					TokenHash: "first",
+					ExpiresAt: expiresAt,
+				})
+				Ω(err).Should(BeNil())
+				Ω(setResponse.User.EmailVerified).Should(BeFalse())
+				Ω(setResponse.User.EmailVerificationTokenHash).Should(Equal("first"))
+				Ω(setResponse.User.EmailVerificationExpiresAt.Equal(expiresAt)).Should(BeTrue())
+
+				_, err = sut.SetEmailVerificationToken(ctx, &repository.SetEmailVerificationTokenRequest{
+					Email:     email,
+					TokenHash: "second",
+					ExpiresAt: expiresAt,
+				})
+				Ω(err).Should(BeNil())
+
+				_, err = sut.VerifyEmail(ctx, &repository.VerifyEmailRequest{Email: email, TokenHash: "first"})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+				verifyResponse, err := sut.VerifyEmail(ctx, &repository.VerifyEmailRequest{Email: email, TokenHash: "second"})
+				Ω(err).Should(BeNil())
+				Ω(verifyResponse.User.EmailVerified).Should(BeTrue())
+				Ω(verifyResponse.User.EmailVerificationTokenHash).Should(BeEmpty())
+				Ω(verifyResponse.User.EmailVerificationExpiresAt).Should(BeNil())
+
+				readResponse, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(readResponse.User.EmailVerified).Should(BeTrue())
+			})
+		})
+
+		When("user records the login attempts of the existing user", func() {
+			It("should lock the user once the failed attempts reach the threshold until it is unlocked", func() {
+				attemptedAt := time.Now().UTC().Truncate(time.Millisecond)
+				request := repository.RecordLoginAttemptRequest{Email: email, LockoutThreshold: 2, AttemptedAt: attemptedAt}
+
+				attemptResponse, err := sut.RecordLoginAttempt(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(attemptResponse.User.FailedLoginAttempts).Should(Equal(1))
+				Ω(attemptResponse.User.LockedAt).Should(BeNil())
+
+				attemptResponse, err = sut.RecordLoginAttempt(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(attemptResponse.User.FailedLoginAttempts).Should(Equal(2))
+				Ω(attemptResponse.User.LockedAt.Equal(attemptedAt)).Should(BeTrue())
+
+				request.Succeeded = true
+				attemptResponse, err = sut.RecordLoginAttempt(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(attemptResponse.User.FailedLoginAttempts).Should(Equal(2))
+				Ω(attemptResponse.User.LockedAt).ShouldNot(BeNil())
+
+				unlockResponse, err := sut.UnlockUser(ctx, &repository.UnlockUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(unlockResponse.User.FailedLoginAttempts).Should(BeZero())
+				Ω(unlockResponse.User.LockedAt).Should(BeNil())
+
+				readResponse, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(readResponse.User.LockedAt).Should(BeNil())
+			})
+		})
+
+		When("user enrolls the existing user in the MFA methods", func() {
+			It("should add the method only once and remove it by its ID", func() {
+				method := models.MFAMethod{
+					MethodID:      cuid.New(),
+					Type:          models.MFAMethodTypeTOTP,
+					Name:          cuid.New(),
+					TOTPSecretRef: cuid.New(),
+					EnrolledAt:    time.Now().UTC().Truncate(time.Millisecond),
+				}
+
+				addResponse, err := sut.AddMFAMethod(ctx, &repository.AddMFAMethodRequest{Email: email, Method: method})
+				Ω(err).Should(BeNil())
+				Ω(addResponse.User.MFAMethods).Should(HaveLen(1))
+				Ω(addResponse.User.MFAMethods[0].MethodID).Should(Equal(method.MethodID))
+				Ω(addResponse.User.MFAMethods[0].TOTPSecretRef).Should(Equal(method.TOTPSecretRef))
+				Ω(addResponse.User.MFAMethods[0].EnrolledAt.Equal(method.EnrolledAt)).Should(BeTrue())
+
+				duplicateMethod := method
+				duplicateMethod.MethodID = cuid.New()
+				_, err = sut.AddMFAMethod(ctx, &repository.AddMFAMethodRequest{Email: email, Method: duplicateMethod})
+				Ω(commonErrors.IsAlreadyExistsError(err)).Should(BeTrue())
+
+				removeResponse, err := sut.RemoveMFAMethod(ctx, &repository.RemoveMFAMethodRequest{Email: email, MethodID: method.MethodID})
+				Ω(err).Should(BeNil())
+				Ω(removeResponse.User.MFAMethods).Should(BeEmpty())
+
+				_, err = sut.RemoveMFAMethod(ctx, &repository.RemoveMFAMethodRequest{Email: email, MethodID: method.MethodID})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user updates the preferences of the existing user", func() {
+			It("should merge the preferences into the existing preferences", func() {
+				_, err := sut.UpdateUserPreferences(ctx, &repository.UpdateUserPreferencesRequest{
+					Email: email,
+					Preferences: map[string]string{
+						models.PreferenceTheme:    "dark",
+						models.PreferenceLanguage: "en",
+					},
+				})
+				Ω(err).Should(BeNil())
+
+				response, err := sut.UpdateUserPreferences(ctx, &repository.UpdateUserPreferencesRequest{
+					Email:       email,
+					Preferences: map[string]string{models.PreferenceLanguage: "en-AU"},
+					RemovedKeys: []string{models.PreferenceTheme},
+				})
+				Ω(err).Should(BeNil())
+				Ω(response.Preferences).Should(Equal(map[string]string{models.PreferenceLanguage: "en-AU"}))
+
+				readResponse, err := sut.ReadUserPreferences(ctx, &repository.ReadUserPreferencesRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(readResponse.Preferences).Should(Equal(response.Preferences))
+			})
+		})
+
+		When("user changes the user returned by the repository", func() {
+			It("should not change the user kept in memory", func() {
+				_, err := sut.UpdateUser(ctx, &repository.UpdateUserRequest{Email: email, User: models.User{Labels: map[string]string{"plan": "pro"}}})
+				Ω(err).Should(BeNil())
+
+				response, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				response.User.Labels["plan"] = "free"
+
+				response, err = sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(response.User.Labels).Should(Equal(map[string]string{"plan": "pro"}))
+			})
+		})
+
+		When("user reads the user on behalf of a caller of another tenant", func() {
+			It("should return NotFoundError", func() {
+				tenantCtx := context.WithValue(ctx, models.ContextKeyParsedToken, models.ParsedToken{TenantID: cuid.New()})
+
+				_, err := sut.ReadUser(tenantCtx, &repository.ReadUserRequest{Email: email})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+				_, err = sut.UpdateUser(tenantCtx, &repository.UpdateUserRequest{Email: email, User: models.User{}})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user deletes the user", func() {
+			It("should delete the user", func() {
+				_, err := sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+
+				response, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(HaveOccurred())
+				Ω(response).Should(BeNil())
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("user is soft deleted", func() {
+		var (
+			email string
+		)
+
+		BeforeEach(func() {
+			_, _ = sut.CreateUser(ctx, &createRequest)
+			email = createRequest.Email
+
+			_, err := sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: email, SoftDelete: true})
+			Ω(err).Should(BeNil())
+		})
+
+		When("user reads the user", func() {
+			It("should return NotFoundError", func() {
+				_, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user deletes the user again", func() {
+			It("should return NotFoundError", func() {
+				_, err := sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: email, SoftDelete: true})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user searches for the user", func() {
+			It("should only return the user if the deleted users are included", func() {
+				response, err := sut.Search(ctx, &repository.SearchRequest{Emails: []string{email}})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(BeEmpty())
+
+				response, err = sut.Search(ctx, &repository.SearchRequest{Emails: []string{email}, IncludeDeleted: true})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(1))
+				Ω(response.Users[0].DeletedAt).ShouldNot(BeNil())
+			})
+		})
+
+		When("user restores the user", func() {
+			It("should make the user readable again", func() {
+				response, err := sut.RestoreUser(ctx, &repository.RestoreUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(response.Cursor).ShouldNot(BeEmpty())
+
+				_, err = sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+
+				_, err = sut.RestoreUser(ctx, &repository.RestoreUserRequest{Email: email})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("the soft deleted users are purged", func() {
+			It("should only purge the users deleted before the given time", func() {
+				response, err := sut.PurgeDeletedUsers(ctx, &repository.PurgeDeletedUsersRequest{DeletedBefore: now.Add(-time.Hour)})
+				Ω(err).Should(BeNil())
+
+				_, err = sut.RestoreUser(ctx, &repository.RestoreUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+
+				_, err = sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: email, SoftDelete: true})
+				Ω(err).Should(BeNil())
+
+				response, err = sut.PurgeDeletedUsers(ctx, &repository.PurgeDeletedUsersRequest{DeletedBefore: now.Add(time.Hour)})
+				Ω(err).Should(BeNil())
+				Ω(response.PurgedCount).Should(BeNumerically(">=", 1))
+
+				_, err = sut.RestoreUser(ctx, &repository.RestoreUserRequest{Email: email})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("user does not exist", func() {
+		var (
+			email string
+		)
+
+		BeforeEach(func() {
+			email = cuid.New() + "@test.com"
+		})
+
+		When("user reads the user", func() {
+			It("should return NotFoundError", func() {
+				_, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user tries to update the user", func() {
+			It("should return NotFoundError", func() {
+				_, err := sut.UpdateUser(ctx, &repository.UpdateUserRequest{Email: email, User: models.User{}})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user tries to add the user to a tenant", func() {
+			It("should return NotFoundError", func() {
+				_, err := sut.AddUserToTenant(ctx, &repository.AddUserToTenantRequest{
+					Email:      email,
+					Membership: models.TenantMembership{TenantID: cuid.New(), Role: models.TenantRoleMember},
+				})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user tries to update the preferences of the user", func() {
+			It("should return NotFoundError", func() {
+				_, err := sut.UpdateUserPreferences(ctx, &repository.UpdateUserPreferencesRequest{
+					Email:       email,
+					Preferences: map[string]string{models.PreferenceTheme: "dark"},
+				})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user tries to delete the user", func() {
+			It("should return NotFoundError", func() {
+				_, err := sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: email})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("users already exist", func() {
+		var (
+			emails []string
+		)
+
+		BeforeEach(func() {
+			emails = []string{cuid.New() + "@test.com", cuid.New() + "@test.com", cuid.New() + "@test.com"}
+			for _, email := range emails {
+				_, _ = sut.CreateUser(ctx, &repository.CreateUserRequest{Email: email, User: models.User{}})
+			}
+		})
+
+		When("user searches for the users using pagination", func() {
+			It("should return the requested page", func() {
+				first := 1
+				firstPageResponse, err := sut.Search(ctx, &repository.SearchRequest{
+					Pagination: models.Pagination{First: &first},
+					Emails:     emails,
+				})
+				Ω(err).Should(BeNil())
+				Ω(firstPageResponse.TotalCount).Should(Equal(int64(len(emails))))
+				Ω(firstPageResponse.HasNextPage).Should(BeTrue())
+				Ω(firstPageResponse.Users).Should(HaveLen(1))
+				Ω(firstPageResponse.Users[0].Email).Should(Equal(emails[0]))
+
+				secondPageResponse, err := sut.Search(ctx, &repository.SearchRequest{
+					Pagination: models.Pagination{First: &first, After: &firstPageResponse.Users[0].Cursor},
+					Emails:     emails,
+				})
+				Ω(err).Should(BeNil())
+				Ω(secondPageResponse.HasPreviousPage).Should(BeTrue())
+				Ω(secondPageResponse.Users).Should(HaveLen(1))
+				Ω(secondPageResponse.Users[0].Email).Should(Equal(emails[1]))
+			})
+		})
+
+		When("user searches for the users sorted by an unsupported field", func() {
+			It("should return ArgumentError", func() {
+				_, err := sut.Search(ctx, &repository.SearchRequest{
+					SortingOptions: []models.SortingOptionPair{{Name: cuid.New(), Direction: models.Ascending}},
+					Emails:         emails,
+				})
+				Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user streams the users by email", func() {
+			It("should send all the matched users one by one", func() {
+				sentUsers := []models.UserWithCursor{}
+				response, err := sut.StreamSearch(ctx, &repository.StreamSearchRequest{
+					Emails: emails,
+					Send: func(user models.UserWithCursor) error {
+						sentUsers = append(sentUsers, user)
+
+						return nil
+					},
+				})
+				Ω(err).Should(BeNil())
+				Ω(response.SentCount).Should(Equal(int64(len(emails))))
+				Ω(sentUsers).Should(HaveLen(len(emails)))
+
+				for index, user := range sentUsers {
+					Ω(user.Email).Should(Equal(emails[index]))
+					Ω(user.Cursor).Should(Equal(user.UserID))
+				}
+			})
+		})
+
+		When("user searches for the users by a label selector", func() {
+			It("should only return the users that match all the requirements", func() {
+				_, err := sut.UpdateUser(ctx, &repository.UpdateUserRequest{Email: emails[0], User: models.User{Labels: map[string]string{"plan": "pro"}}})
+				Ω(err).Should(BeNil())
+
+				_, err = sut.UpdateUser(ctx, &repository.UpdateUserRequest{Email: emails[1], User: models.User{Labels: map[string]string{"plan": "free"}}})
+				Ω(err).Should(BeNil())
+
+				labelSelector, err := models.ParseLabelSelector("plan notin (free)")
+				Ω(err).Should(BeNil())
+
+				response, err := sut.Search(ctx, &repository.SearchRequest{Emails: emails, LabelSelector: labelSelector})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(2))
+				Ω(response.Users[0].Email).Should(Equal(emails[0]))
+				Ω(response.Users[1].Email).Should(Equal(emails[2]))
+
+				labelSelector, err = models.ParseLabelSelector("plan")
+				Ω(err).Should(BeNil())
+
+				response, err = sut.Search(ctx, &repository.SearchRequest{Emails: emails, LabelSelector: labelSelector})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(2))
+			})
+		})
+
+		When("user searches for the users by a free-text query", func() {
+			It("should only return the users whose email address starts with the query sorted by relevance", func() {
+				token := cuid.New()
+				queryEmails := []string{
+					token + "@test.com.au",
+					token + "@test.com",
+					"x" + token + "@test.com",
+					"a." + token + "@test.com",
+				}
+				for _, email := range queryEmails {
+					_, err := sut.CreateUser(ctx, &repository.CreateUserRequest{Email: email, User: models.User{}})
+					Ω(err).Should(BeNil())
+				}
+
+				response, err := sut.Search(ctx, &repository.SearchRequest{Query: strings.ToUpper(token)})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(2))
+				Ω(response.Users[0].Email).Should(Equal(queryEmails[0]))
+				Ω(response.Users[1].Email).Should(Equal(queryEmails[1]))
+
+				response, err = sut.Search(ctx, &repository.SearchRequest{Query: strings.ToUpper(queryEmails[1])})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(2))
+				Ω(response.Users[0].Email).Should(Equal(queryEmails[1]))
+				Ω(response.Users[1].Email).Should(Equal(queryEmails[0]))
+
+				response, err = sut.Search(ctx, &repository.SearchRequest{
+					Query:          queryEmails[1],
+					SortingOptions: []models.SortingOptionPair{{Name: "email", Direction: models.Descending}},
+				})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(2))
+				Ω(response.Users[0].Email).Should(Equal(queryEmails[0]))
+
+				// Only the query is matched regardless of the case, the email addresses are still matched as they are
+				response, err = sut.Search(ctx, &repository.SearchRequest{
+					Emails: []string{strings.ToUpper(queryEmails[1])},
+					Query:  token,
+				})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(BeEmpty())
+			})
+		})
+
+		When("user searches for the users sorted by email in descending order", func() {
+			It("should return the users in the requested order", func() {
+				response, err := sut.Search(ctx, &repository.SearchRequest{
+					SortingOptions: []models.SortingOptionPair{{Name: "email", Direction: models.Descending}},
+					Emails:         emails,
+				})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(len(emails)))
+
+				for index := 1; index < len(response.Users); index++ {
+					Ω(response.Users[index-1].Email > response.Users[index].Email).Should(BeTrue())
+				}
+			})
+		})
+
+		When("sending a streamed user fails", func() {
+			It("should stop streaming and return UnknownError", func() {
+				sentCount := 0
+				_, err := sut.StreamSearch(ctx, &repository.StreamSearchRequest{
+					Emails: emails,
+					Send: func(user models.UserWithCursor) error {
+						sentCount++
+
+						return errors.New(cuid.New())
+					},
+				})
+				Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+				Ω(sentCount).Should(Equal(1))
+			})
+		})
+	})
+})
@@ -2,6 +2,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/decentralized-cloud/user/models"
 )
 
@@ -20,11 +22,43 @@ type CreateUserResponse struct {
 // ReadUserRequest contains the request to read an existing user
 type ReadUserRequest struct {
 	Email string
+
+	// UserID is the ID of the user to read, the user is read by its email address if empty
+	UserID string
+
+	// IncludeDeleted reads the user even if it is soft deleted, e.g. to restore it. It only applies to the users read
+	// by their ID.
+	IncludeDeleted bool
+
+	// Fields are the names of the user fields the caller needs as they are named in the GRPC contract, the repository
+	// may leave the other fields out to read less. All the fields are read if empty, so the users read to be changed
+	// must never be projected.
+	Fields []string
 }
 
 // ReadUserResponse contains the result of reading an existing user
 type ReadUserResponse struct {
-	User models.User
+	// Email is the email address of the user, so the callers reading the user by its ID learn it
+	Email string
+	User  models.User
+}
+
+// ReadUsersRequest contains the request to read the existing users with the given email addresses or IDs at once
+type ReadUsersRequest struct {
+	Emails []string
+
+	// UserIDs are the stable IDs of the users to read along with the users named by their email addresses
+	UserIDs []string
+
+	// Fields are the names of the user fields the caller needs as they are named in the GRPC contract, the repository
+	// may leave the other fields out to read less. All the fields are read if empty.
+	Fields []string
+}
+
+// ReadUsersResponse contains the result of reading the existing users. The users are in no particular order, the
+// ones that are not found or soft deleted are left out.
+type ReadUsersResponse struct {
+	Users []models.UserWithCursor
 }
 
 // UpdateUserRequest contains the request to update an existing user
@@ -39,11 +73,254 @@ type UpdateUserResponse struct {
 	Cursor string
 }
 
-// DeleteUserRequest contains the request to delete an existing user
-type DeleteUserRequest struct {
+// ChangeEmailRequest contains the request to change the email address of an existing user
+type ChangeEmailRequest struct {
+	Email    string
+	NewEmail string
+}
+
+// ChangeEmailResponse contains the result of changing the email address of an existing user
+type ChangeEmailResponse struct {
+	User   models.User
+	Cursor string
+}
+
+// ReadUserPreferencesRequest contains the request to read the preferences of an existing user
+type ReadUserPreferencesRequest struct {
+	Email string
+}
+
+// ReadUserPreferencesResponse contains the result of reading the preferences of an existing user
+type ReadUserPreferencesResponse struct {
+	Preferences map[string]string
+}
+
+// UpdateUserPreferencesRequest contains the request to merge the preferences into the preferences of an existing
+// user. The preferences not in the request are left as they are, except the ones in RemovedKeys that are removed.
+type UpdateUserPreferencesRequest struct {
+	Email       string
+	Preferences map[string]string
+	RemovedKeys []string
+}
+
+// UpdateUserPreferencesResponse contains the result of updating the preferences of an existing user
+type UpdateUserPreferencesResponse struct {
+	Preferences map[string]string
+}
+
+// AddUserToTenantRequest contains the request to add the membership in a tenant to an existing user
+type AddUserToTenantRequest struct {
+	Email      string
+	Membership models.TenantMembership
+}
+
+// AddUserToTenantResponse contains the result of adding the membership in a tenant to an existing user
+type AddUserToTenantResponse struct {
+	User   models.User
+	Cursor string
+}
+
+// RemoveUserFromTenantRequest contains the request to remove the membership in a tenant from an existing user
+type RemoveUserFromTenantRequest struct {
+	Email    string
+	TenantID string
+}
+
+// RemoveUserFromTenantResponse contains the result of removing the membership in a tenant from an existing user
+type RemoveUserFromTenantResponse struct {
+	User   models.User
+	Cursor string
+}
+
+// SetEmailVerificationTokenRequest contains the request to set the pending email verification token of an existing user
+type SetEmailVerificationTokenRequest struct {
+	Email     string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+// SetEmailVerificationTokenResponse contains the result of setting the pending email verification token of an existing user
+type SetEmailVerificationTokenResponse struct {
+	User   models.User
+	Cursor string
+}
+
+// VerifyEmailRequest contains the request to mark the email address of an existing user as verified
+type VerifyEmailRequest struct {
+	Email     string
+	TokenHash string
+}
+
+// VerifyEmailResponse contains the result of marking the email address of an existing user as verified
+type VerifyEmailResponse struct {
+	User   models.User
+	Cursor string
+}
+
+// RecordLoginAttemptRequest contains the request to record the outcome of a login attempt of an existing user
+type RecordLoginAttemptRequest struct {
+	Email            string
+	Succeeded        bool
+	LockoutThreshold int
+	AttemptedAt      time.Time
+}
+
+// RecordLoginAttemptResponse contains the result of recording the outcome of a login attempt of an existing user
+type RecordLoginAttemptResponse struct {
+	User   models.User
+	Cursor string
+}
+
+// UnlockUserRequest contains the request to unlock an existing user
+type UnlockUserRequest struct {
 	Email string
 }
 
+// UnlockUserResponse contains the result of unlocking an existing user
+type UnlockUserResponse struct {
+	User   models.User
+	Cursor string
+}
+
+// AddMFAMethodRequest contains the request to add an MFA method to an existing user
+type AddMFAMethodRequest struct {
+	Email  string
+	Method models.MFAMethod
+}
+
+// AddMFAMethodResponse contains the result of adding an MFA method to an existing user
+type AddMFAMethodResponse struct {
+	User   models.User
+	Cursor string
+}
+
+// RemoveMFAMethodRequest contains the request to remove an MFA method from an existing user
+type RemoveMFAMethodRequest struct {
+	Email    string
+	MethodID string
+}
+
+// RemoveMFAMethodResponse contains the result of removing an MFA method from an existing user
+type RemoveMFAMethodResponse struct {
+	User   models.User
+	Cursor string
+}
+
+// SetUserAvatarRequest contains the request to set the avatar of an existing user
+type SetUserAvatarRequest struct {
+	Email  string
+	Avatar models.Avatar
+}
+
+// SetUserAvatarResponse contains the result of setting the avatar of an existing user
+type SetUserAvatarResponse struct {
+	User   models.User
+	Cursor string
+}
+
+// DeleteUserRequest contains the request to delete an existing user. The user is only marked as deleted
+// if SoftDelete is set
+type DeleteUserRequest struct {
+	Email      string
+	SoftDelete bool
+}
+
 // DeleteUserResponse contains the result of deleting an existing user
 type DeleteUserResponse struct {
 }
+
+// RestoreUserRequest contains the request to restore an existing soft deleted user
+type RestoreUserRequest struct {
+	Email string
+}
+
+// RestoreUserResponse contains the result of restoring an existing soft deleted user
+type RestoreUserResponse struct {
+	User   models.User
+	Cursor string
+}
+
+// PurgeDeletedUsersRequest contains the request to permanently delete the soft deleted users
+type PurgeDeletedUsersRequest struct {
+	DeletedBefore time.Time
+}
+
+// PurgeDeletedUsersResponse contains the result of permanently deleting the soft deleted users
+type PurgeDeletedUsersResponse struct {
+	PurgedCount int64
+}
+
+// ListUsersByLabelRequest contains the request to list the users tagged with a test label
+type ListUsersByLabelRequest struct {
+	Label string
+}
+
+// ListUsersByLabelResponse contains the users tagged with a test label
+type ListUsersByLabelResponse struct {
+	Users []models.UserWithCursor
+}
+
+// PurgeUsersByLabelRequest contains the request to permanently delete the users tagged with a test label
+type PurgeUsersByLabelRequest struct {
+	Label string
+}
+
+// PurgeUsersByLabelResponse contains the result of permanently deleting the users tagged with a test label
+type PurgeUsersByLabelResponse struct {
+	PurgedCount int64
+}
+
+// SearchRequest defines the request to search for users. The soft deleted users are only returned if
+// IncludeDeleted is set
+type SearchRequest struct {
+	Pagination     models.Pagination
+	SortingOptions []models.SortingOptionPair
+	Emails         []string
+	IncludeDeleted bool
+
+	// LabelSelector are the requirements the labels of the users must all match, the users are not filtered by their
+	// labels if empty
+	LabelSelector []models.LabelSelectorRequirement
+
+	// Query is the free-text query the email address of the users must start with regardless of the case, the users
+	// are not filtered by it if empty. Unless sorting options are given, the users are sorted by how well they match it.
+	Query string
+
+	// Fields are the names of the user fields the caller needs as they are named in the GRPC contract, the repository
+	// may leave the other fields out to read less. All the fields are read if empty.
+	Fields []string
+}
+
+// SearchResponse defines the result of searching for users
+type SearchResponse struct {
+	HasPreviousPage bool
+	HasNextPage     bool
+	TotalCount      int64
+	Users           []models.UserWithCursor
+}
+
+// StreamSearchRequest defines the request to stream the users that matched the criteria. Every matched user is
+// passed to Send as soon as it is read, so Send blocking slows down reading the users from the database
+type StreamSearchRequest struct {
+	SortingOptions []models.SortingOptionPair
+	Emails         []string
+	IncludeDeleted bool
+	Send           func(user models.UserWithCursor) error
+
+	// TenantID limits the users to the members of the tenant, the users are not filtered by tenant if empty
+	TenantID string
+
+	// CreatedAfter and CreatedBefore limit the users to the ones created at or after CreatedAfter and before
+	// CreatedBefore, the users are not filtered by the creation time if they are not set
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+
+	// After resumes streaming after the user with the given cursor. Without sorting options the users are streamed in
+	// the order of their cursors, so the users streamed before the cursor are skipped.
+	After string
+}
+
+// StreamSearchResponse defines the result of streaming the users that matched the criteria
+type StreamSearchResponse struct {
+	SentCount int64
+}
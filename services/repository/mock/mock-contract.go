@@ -35,6 +35,51 @@ func (m *MockRepositoryContract) EXPECT() *MockRepositoryContractMockRecorder {
 	return m.recorder
 }
 
+// AddMFAMethod mocks base method.
+func (m *MockRepositoryContract) AddMFAMethod(ctx context.Context, request *repository.AddMFAMethodRequest) (*repository.AddMFAMethodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMFAMethod", ctx, request)
+	ret0, _ := ret[0].(*repository.AddMFAMethodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMFAMethod indicates an expected call of AddMFAMethod.
+func (mr *MockRepositoryContractMockRecorder) AddMFAMethod(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMFAMethod", reflect.TypeOf((*MockRepositoryContract)(nil).AddMFAMethod), ctx, request)
+}
+
+// AddUserToTenant mocks base method.
+func (m *MockRepositoryContract) AddUserToTenant(ctx context.Context, request *repository.AddUserToTenantRequest) (*repository.AddUserToTenantResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserToTenant", ctx, request)
+	ret0, _ := ret[0].(*repository.AddUserToTenantResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddUserToTenant indicates an expected call of AddUserToTenant.
+func (mr *MockRepositoryContractMockRecorder) AddUserToTenant(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToTenant", reflect.TypeOf((*MockRepositoryContract)(nil).AddUserToTenant), ctx, request)
+}
+
+// ChangeEmail mocks base method.
+func (m *MockRepositoryContract) ChangeEmail(ctx context.Context, request *repository.ChangeEmailRequest) (*repository.ChangeEmailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmail", ctx, request)
+	ret0, _ := ret[0].(*repository.ChangeEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeEmail indicates an expected call of ChangeEmail.
+func (mr *MockRepositoryContractMockRecorder) ChangeEmail(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmail", reflect.TypeOf((*MockRepositoryContract)(nil).ChangeEmail), ctx, request)
+}
+
 // CreateUser mocks base method.
 func (m *MockRepositoryContract) CreateUser(ctx context.Context, request *repository.CreateUserRequest) (*repository.CreateUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +110,65 @@ func (mr *MockRepositoryContractMockRecorder) DeleteUser(ctx, request interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockRepositoryContract)(nil).DeleteUser), ctx, request)
 }
 
+// ListUsersByLabel mocks base method.
+func (m *MockRepositoryContract) ListUsersByLabel(ctx context.Context, request *repository.ListUsersByLabelRequest) (*repository.ListUsersByLabelResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersByLabel", ctx, request)
+	ret0, _ := ret[0].(*repository.ListUsersByLabelResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersByLabel indicates an expected call of ListUsersByLabel.
+func (mr *MockRepositoryContractMockRecorder) ListUsersByLabel(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersByLabel", reflect.TypeOf((*MockRepositoryContract)(nil).ListUsersByLabel), ctx, request)
+}
+
+// Ping mocks base method.
+func (m *MockRepositoryContract) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockRepositoryContractMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockRepositoryContract)(nil).Ping), ctx)
+}
+
+// PurgeDeletedUsers mocks base method.
+func (m *MockRepositoryContract) PurgeDeletedUsers(ctx context.Context, request *repository.PurgeDeletedUsersRequest) (*repository.PurgeDeletedUsersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedUsers", ctx, request)
+	ret0, _ := ret[0].(*repository.PurgeDeletedUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedUsers indicates an expected call of PurgeDeletedUsers.
+func (mr *MockRepositoryContractMockRecorder) PurgeDeletedUsers(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedUsers", reflect.TypeOf((*MockRepositoryContract)(nil).PurgeDeletedUsers), ctx, request)
+}
+
+// PurgeUsersByLabel mocks base method.
+func (m *MockRepositoryContract) PurgeUsersByLabel(ctx context.Context, request *repository.PurgeUsersByLabelRequest) (*repository.PurgeUsersByLabelResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUsersByLabel", ctx, request)
+	ret0, _ := ret[0].(*repository.PurgeUsersByLabelResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeUsersByLabel indicates an expected call of PurgeUsersByLabel.
+func (mr *MockRepositoryContractMockRecorder) PurgeUsersByLabel(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUsersByLabel", reflect.TypeOf((*MockRepositoryContract)(nil).PurgeUsersByLabel), ctx, request)
+}
+
 // ReadUser mocks base method.
 func (m *MockRepositoryContract) ReadUser(ctx context.Context, request *repository.ReadUserRequest) (*repository.ReadUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +184,171 @@ func (mr *MockRepositoryContractMockRecorder) ReadUser(ctx, request interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUser", reflect.TypeOf((*MockRepositoryContract)(nil).ReadUser), ctx, request)
 }
 
+// ReadUserPreferences mocks base method.
+func (m *MockRepositoryContract) ReadUserPreferences(ctx context.Context, request *repository.ReadUserPreferencesRequest) (*repository.ReadUserPreferencesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUserPreferences", ctx, request)
+	ret0, _ := ret[0].(*repository.ReadUserPreferencesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUserPreferences indicates an expected call of ReadUserPreferences.
+func (mr *MockRepositoryContractMockRecorder) ReadUserPreferences(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserPreferences", reflect.TypeOf((*MockRepositoryContract)(nil).ReadUserPreferences), ctx, request)
+}
+
+// ReadUsers mocks base method.
+func (m *MockRepositoryContract) ReadUsers(ctx context.Context, request *repository.ReadUsersRequest) (*repository.ReadUsersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUsers", ctx, request)
+	ret0, _ := ret[0].(*repository.ReadUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUsers indicates an expected call of ReadUsers.
+func (mr *MockRepositoryContractMockRecorder) ReadUsers(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUsers", reflect.TypeOf((*MockRepositoryContract)(nil).ReadUsers), ctx, request)
+}
+
+// RecordLoginAttempt mocks base method.
+func (m *MockRepositoryContract) RecordLoginAttempt(ctx context.Context, request *repository.RecordLoginAttemptRequest) (*repository.RecordLoginAttemptResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLoginAttempt", ctx, request)
+	ret0, _ := ret[0].(*repository.RecordLoginAttemptResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordLoginAttempt indicates an expected call of RecordLoginAttempt.
+func (mr *MockRepositoryContractMockRecorder) RecordLoginAttempt(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoginAttempt", reflect.TypeOf((*MockRepositoryContract)(nil).RecordLoginAttempt), ctx, request)
+}
+
+// RemoveMFAMethod mocks base method.
+func (m *MockRepositoryContract) RemoveMFAMethod(ctx context.Context, request *repository.RemoveMFAMethodRequest) (*repository.RemoveMFAMethodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMFAMethod", ctx, request)
+	ret0, _ := ret[0].(*repository.RemoveMFAMethodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveMFAMethod indicates an expected call of RemoveMFAMethod.
+func (mr *MockRepositoryContractMockRecorder) RemoveMFAMethod(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMFAMethod", reflect.TypeOf((*MockRepositoryContract)(nil).RemoveMFAMethod), ctx, request)
+}
+
+// RemoveUserFromTenant mocks base method.
+func (m *MockRepositoryContract) RemoveUserFromTenant(ctx context.Context, request *repository.RemoveUserFromTenantRequest) (*repository.RemoveUserFromTenantResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserFromTenant", ctx, request)
+	ret0, _ := ret[0].(*repository.RemoveUserFromTenantResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveUserFromTenant indicates an expected call of RemoveUserFromTenant.
+func (mr *MockRepositoryContractMockRecorder) RemoveUserFromTenant(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromTenant", reflect.TypeOf((*MockRepositoryContract)(nil).RemoveUserFromTenant), ctx, request)
+}
+
+// RestoreUser mocks base method.
+func (m *MockRepositoryContract) RestoreUser(ctx context.Context, request *repository.RestoreUserRequest) (*repository.RestoreUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, request)
+	ret0, _ := ret[0].(*repository.RestoreUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockRepositoryContractMockRecorder) RestoreUser(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockRepositoryContract)(nil).RestoreUser), ctx, request)
+}
+
+// Search mocks base method.
+func (m *MockRepositoryContract) Search(ctx context.Context, request *repository.SearchRequest) (*repository.SearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, request)
+	ret0, _ := ret[0].(*repository.SearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockRepositoryContractMockRecorder) Search(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockRepositoryContract)(nil).Search), ctx, request)
+}
+
+// SetEmailVerificationToken mocks base method.
+func (m *MockRepositoryContract) SetEmailVerificationToken(ctx context.Context, request *repository.SetEmailVerificationTokenRequest) (*repository.SetEmailVerificationTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailVerificationToken", ctx, request)
+	ret0, _ := ret[0].(*repository.SetEmailVerificationTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetEmailVerificationToken indicates an expected call of SetEmailVerificationToken.
+func (mr *MockRepositoryContractMockRecorder) SetEmailVerificationToken(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailVerificationToken", reflect.TypeOf((*MockRepositoryContract)(nil).SetEmailVerificationToken), ctx, request)
+}
+
+// SetUserAvatar mocks base method.
+func (m *MockRepositoryContract) SetUserAvatar(ctx context.Context, request *repository.SetUserAvatarRequest) (*repository.SetUserAvatarResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserAvatar", ctx, request)
+	ret0, _ := ret[0].(*repository.SetUserAvatarResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserAvatar indicates an expected call of SetUserAvatar.
+func (mr *MockRepositoryContractMockRecorder) SetUserAvatar(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserAvatar", reflect.TypeOf((*MockRepositoryContract)(nil).SetUserAvatar), ctx, request)
+}
+
+// StreamSearch mocks base method.
+func (m *MockRepositoryContract) StreamSearch(ctx context.Context, request *repository.StreamSearchRequest) (*repository.StreamSearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamSearch", ctx, request)
+	ret0, _ := ret[0].(*repository.StreamSearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamSearch indicates an expected call of StreamSearch.
+func (mr *MockRepositoryContractMockRecorder) StreamSearch(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSearch", reflect.TypeOf((*MockRepositoryContract)(nil).StreamSearch), ctx, request)
+}
+
+// UnlockUser mocks base method.
+func (m *MockRepositoryContract) UnlockUser(ctx context.Context, request *repository.UnlockUserRequest) (*repository.UnlockUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, request)
+	ret0, _ := ret[0].(*repository.UnlockUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockRepositoryContractMockRecorder) UnlockUser(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockRepositoryContract)(nil).UnlockUser), ctx, request)
+}
+
 // UpdateUser mocks base method.
 func (m *MockRepositoryContract) UpdateUser(ctx context.Context, request *repository.UpdateUserRequest) (*repository.UpdateUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +363,33 @@ func (mr *MockRepositoryContractMockRecorder) UpdateUser(ctx, request interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockRepositoryContract)(nil).UpdateUser), ctx, request)
 }
+
+// UpdateUserPreferences mocks base method.
+func (m *MockRepositoryContract) UpdateUserPreferences(ctx context.Context, request *repository.UpdateUserPreferencesRequest) (*repository.UpdateUserPreferencesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPreferences", ctx, request)
+	ret0, _ := ret[0].(*repository.UpdateUserPreferencesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserPreferences indicates an expected call of UpdateUserPreferences.
+func (mr *MockRepositoryContractMockRecorder) UpdateUserPreferences(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPreferences", reflect.TypeOf((*MockRepositoryContract)(nil).UpdateUserPreferences), ctx, request)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockRepositoryContract) VerifyEmail(ctx context.Context, request *repository.VerifyEmailRequest) (*repository.VerifyEmailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, request)
+	ret0, _ := ret[0].(*repository.VerifyEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockRepositoryContractMockRecorder) VerifyEmail(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockRepositoryContract)(nil).VerifyEmail), ctx, request)
+}
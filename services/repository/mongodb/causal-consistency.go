@@ -0,0 +1,121 @@
+// Package mongodb implements MongoDB repository services
+package mongodb
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// causalConsistencyTracker keeps track of the latest cluster and operation time observed by the repository, so
+// every new session can be advanced to it before running any operation. As the repository creates a new client
+// per request, this is what guarantees a read that follows a mutation reflects that mutation even if the read is
+// served by a secondary. The tracker is kept in memory, so it only covers the mutations made by the same process.
+type causalConsistencyTracker struct {
+	mutex         sync.Mutex
+	clusterTime   bson.Raw
+	operationTime *primitive.Timestamp
+}
+
+// advance advances the given session to the latest cluster and operation time observed so far
+// session: Mandatory. The session to advance
+// Returns error if something goes wrong
+func (tracker *causalConsistencyTracker) advance(session mongo.Session) error {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if tracker.clusterTime != nil {
+		if err := session.AdvanceClusterTime(tracker.clusterTime); err != nil {
+			return err
+		}
+	}
+
+	if tracker.operationTime != nil {
+		if err := session.AdvanceOperationTime(tracker.operationTime); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// observe records the cluster and operation time of the given session if they are later than the ones observed so far
+// session: Mandatory. The session that ran the operation
+func (tracker *causalConsistencyTracker) observe(session mongo.Session) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if clusterTime := session.ClusterTime(); clusterTime != nil &&
+		isLaterTimestamp(getClusterTimestamp(clusterTime), getClusterTimestamp(tracker.clusterTime)) {
+		tracker.clusterTime = clusterTime
+	}
+
+	if operationTime := session.OperationTime(); operationTime != nil &&
+		isLaterTimestamp(operationTime, tracker.operationTime) {
+		tracker.operationTime = operationTime
+	}
+}
+
+// withCausallyConsistentSession runs the given function within a causally consistent session that is advanced to the
+// latest cluster and operation time observed by the repository
+// ctx: Mandatory The reference to the context
+// client: Mandatory. The client to start the session from
+// fn: Mandatory. The function to run within the session
+// Returns error if something goes wrong
+func (service *mongodbRepositoryService) withCausallyConsistentSession(
+	ctx context.Context,
+	client *mongo.Client,
+	fn func(sessionCtx mongo.SessionContext) error) error {
+	session, err := client.StartSession(options.Session().SetCausalConsistency(true))
+	if err != nil {
+		return err
+	}
+
+	defer session.EndSession(ctx)
+
+	if err = service.causalConsistency.advance(session); err != nil {
+		return err
+	}
+
+	if err = mongo.WithSession(ctx, session, fn); err != nil {
+		return err
+	}
+
+	service.causalConsistency.observe(session)
+
+	return nil
+}
+
+func getClusterTimestamp(clusterTime bson.Raw) *primitive.Timestamp {
+	if clusterTime == nil {
+		return nil
+	}
+
+	value, err := clusterTime.LookupErr("$clusterTime", "clusterTime")
+	if err != nil {
+		return nil
+	}
+
+	t, i, ok := value.TimestampOK()
+	if !ok {
+		return nil
+	}
+
+	return &primitive.Timestamp{T: t, I: i}
+}
+
+func isLaterTimestamp(timestamp, than *primitive.Timestamp) bool {
+	if timestamp == nil {
+		return false
+	}
+
+	if than == nil {
+		return true
+	}
+
+	return timestamp.T > than.T || (timestamp.T == than.T && timestamp.I > than.I)
+}
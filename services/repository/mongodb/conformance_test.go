@@ -0,0 +1,45 @@
+package mongodb_test
+
+import (
+	"os"
+	"strings"
+
+	"github.com/decentralized-cloud/user/services/clock"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/idgenerator"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/repository/conformance"
+	"github.com/decentralized-cloud/user/services/repository/mongodb"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = conformance.DescribeRepositoryContract("Mongodb", func() repository.RepositoryContract {
+	connectionString := os.Getenv("DATABASE_CONNECTION_STRING")
+	if strings.Trim(connectionString, " ") == "" {
+		connectionString = "mongodb://mongodb:27017"
+	}
+
+	mockConfigurationService := configurationMock.NewMockConfigurationContract(gomock.NewController(GinkgoT()))
+	mockConfigurationService.EXPECT().GetDatabaseConnectionString().Return(connectionString, nil).AnyTimes()
+	mockConfigurationService.EXPECT().GetDatabaseName().Return("user", nil).AnyTimes()
+	mockConfigurationService.EXPECT().GetDatabaseCollectionName().Return("user", nil).AnyTimes()
+	mockConfigurationService.EXPECT().GetDatabaseSearchIndexHints().Return(map[string]string{}, nil).AnyTimes()
+	mockConfigurationService.EXPECT().GetDatabaseSearchQueryPlanStatisticsEnabled().Return(false, nil).AnyTimes()
+	mockConfigurationService.EXPECT().GetDatabaseReadPreference().Return("secondaryPreferred", nil).AnyTimes()
+	mockConfigurationService.EXPECT().GetDatabaseReadConcern().Return("majority", nil).AnyTimes()
+	mockConfigurationService.EXPECT().GetDatabaseWriteConcern().Return("majority", nil).AnyTimes()
+	mockConfigurationService.EXPECT().GetDatabaseStartupCheckEnabled().Return(false, nil).AnyTimes()
+
+	clockService, err := clock.NewClockService()
+	Ω(err).Should(BeNil())
+
+	idGeneratorService, err := idgenerator.NewIDGeneratorService()
+	Ω(err).Should(BeNil())
+
+	sut, err := mongodb.NewMongodbRepositoryService(mockConfigurationService, clockService, idGeneratorService)
+	Ω(err).Should(BeNil())
+
+	return sut
+})
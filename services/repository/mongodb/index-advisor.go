@@ -0,0 +1,181 @@
+// Package mongodb implements MongoDB repository services
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SearchIndexAdvice describes whether the users collection has an index that serves the searches filtered by the
+// fields of a filter shape and sorted by a field
+type SearchIndexAdvice struct {
+	// FilterShape is the list of the filtered field names sorted and joined by "+", empty if the search is not filtered
+	FilterShape string
+
+	// SortField is the name of the field the search result is sorted by
+	SortField string
+
+	// Index is the name of the index that serves the searches, empty if the collection has no such index
+	Index string
+
+	// SuggestedIndex is the keys of the index that would serve the searches, empty if the collection already has one
+	SuggestedIndex string
+}
+
+type indexSpecification struct {
+	Name   string `bson:"name"`
+	Key    bson.D `bson:"key"`
+	Unique bool   `bson:"unique"`
+	Sparse bool   `bson:"sparse"`
+}
+
+// AdviseSearchIndexes connects to the database and reports whether the users collection has an index that serves
+// every combination of the configured filter shapes and sortable fields, so the searches the service allows never
+// make the database scan and sort the whole collection. The filter shapes are the unfiltered search, the search by
+// the email addresses and the shapes the index hints are configured for.
+// ctx: Mandatory The reference to the context
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns either the advice of every combination or error if something goes wrong
+func AdviseSearchIndexes(
+	ctx context.Context,
+	configurationService configuration.ConfigurationContract) ([]SearchIndexAdvice, error) {
+	service, err := newConnectingService(configurationService)
+	if err != nil {
+		return nil, err
+	}
+
+	indexHints, err := configurationService.GetDatabaseSearchIndexHints()
+	if err != nil {
+		return nil, err
+	}
+
+	sortableFields, err := configurationService.GetDatabaseSearchSortableFields()
+	if err != nil {
+		return nil, err
+	}
+
+	client, collection, err := service.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(client)
+
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return nil, newOperationError(ctx, "failed to list the indexes of the users collection", err)
+	}
+
+	indexes := []indexSpecification{}
+	if err = cursor.All(ctx, &indexes); err != nil {
+		return nil, newOperationError(ctx, "failed to read the indexes of the users collection", err)
+	}
+
+	filterShapes := map[string]bool{"": true, "email": true}
+	for filterShape := range indexHints {
+		filterShapes[filterShape] = true
+	}
+
+	sortedFilterShapes := make([]string, 0, len(filterShapes))
+	for filterShape := range filterShapes {
+		sortedFilterShapes = append(sortedFilterShapes, filterShape)
+	}
+
+	sort.Strings(sortedFilterShapes)
+
+	advice := []SearchIndexAdvice{}
+	for _, filterShape := range sortedFilterShapes {
+		for _, sortField := range sortableFields {
+			advice = append(advice, adviseSearchIndex(indexes, filterShape, sortField))
+		}
+	}
+
+	return advice, nil
+}
+
+// adviseSearchIndex finds the index that serves the searches filtered by the fields of the filter shape and sorted
+// by the field. An index serves them if it starts with the filtered fields followed by the sorted field, or if it is
+// a unique index on the filtered fields, as the filter then matches too few users for sorting them to matter. The
+// sparse indexes never serve them, as they leave out the users that do not have the indexed fields.
+func adviseSearchIndex(indexes []indexSpecification, filterShape string, sortField string) SearchIndexAdvice {
+	filterFields := []string{}
+	if filterShape != "" {
+		filterFields = strings.Split(filterShape, "+")
+	}
+
+	sortDocumentField := sortField
+	if mappedField, ok := sortFields[sortField]; ok {
+		sortDocumentField = mappedField
+	}
+
+	requiredKeys := append([]string{}, filterFields...)
+	if !containsField(filterFields, sortDocumentField) {
+		requiredKeys = append(requiredKeys, sortDocumentField)
+	}
+
+	for _, index := range indexes {
+		if index.Sparse {
+			continue
+		}
+
+		keys := make([]string, 0, len(index.Key))
+		for _, key := range index.Key {
+			keys = append(keys, key.Key)
+		}
+
+		if servesSearch(keys, index.Unique, filterFields, requiredKeys) {
+			return SearchIndexAdvice{FilterShape: filterShape, SortField: sortField, Index: index.Name}
+		}
+	}
+
+	suggestedKeys := make([]string, 0, len(requiredKeys))
+	for _, key := range requiredKeys {
+		suggestedKeys = append(suggestedKeys, fmt.Sprintf("%s: 1", key))
+	}
+
+	return SearchIndexAdvice{
+		FilterShape:    filterShape,
+		SortField:      sortField,
+		SuggestedIndex: "{" + strings.Join(suggestedKeys, ", ") + "}",
+	}
+}
+
+func servesSearch(keys []string, unique bool, filterFields []string, requiredKeys []string) bool {
+	if unique && len(filterFields) > 0 && len(keys) <= len(filterFields) {
+		for _, key := range keys {
+			if !containsField(filterFields, key) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if len(keys) < len(requiredKeys) {
+		return false
+	}
+
+	// The filtered fields can be in any order as long as they prefix the index, the sorted field must follow them
+	for position, key := range keys[:len(filterFields)] {
+		if !containsField(filterFields, key) || containsField(keys[:position], key) {
+			return false
+		}
+	}
+
+	return len(requiredKeys) == len(filterFields) || keys[len(filterFields)] == requiredKeys[len(requiredKeys)-1]
+}
+
+func containsField(fields []string, field string) bool {
+	for _, item := range fields {
+		if item == field {
+			return true
+		}
+	}
+
+	return false
+}
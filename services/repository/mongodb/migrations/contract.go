@@ -0,0 +1,34 @@
+// Package migrations implements the versioned migrations of the MongoDB users collection
+package migrations
+
+import (
+	"context"
+)
+
+// MigratorContract declares the service that applies and reverts the migrations of the users collection and records
+// the versions applied in the migrations collection
+type MigratorContract interface {
+	// Up applies the migrations that are not applied yet in the order of their versions
+	// ctx: Mandatory The reference to the context
+	// targetVersion: Optional. The version to migrate up to, every pending migration is applied if 0
+	// Returns either the versions of the applied migrations or error if something goes wrong. The versions applied
+	// before the error are returned along with it.
+	Up(
+		ctx context.Context,
+		targetVersion int) ([]int, error)
+
+	// Down reverts the applied migrations newer than the target version, the newest first
+	// ctx: Mandatory The reference to the context
+	// targetVersion: Mandatory. The version to migrate down to, every applied migration is reverted if 0
+	// Returns either the versions of the reverted migrations or error if something goes wrong. The versions reverted
+	// before the error are returned along with it.
+	Down(
+		ctx context.Context,
+		targetVersion int) ([]int, error)
+
+	// Status lists the known migrations and whether they are applied, along with the migrations applied by a newer
+	// version of the service
+	// ctx: Mandatory The reference to the context
+	// Returns either the status of the migrations ordered by their versions or error if something goes wrong
+	Status(ctx context.Context) ([]MigrationStatus, error)
+}
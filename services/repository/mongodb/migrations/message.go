@@ -0,0 +1,39 @@
+// Package migrations implements the versioned migrations of the MongoDB users collection
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MigrationFunc changes the users collection, e.g. creates an index, backfills a field or renames a key. The instances
+// of the service starting at the same time may run the same migration, so it must be safe to run more than once.
+type MigrationFunc func(ctx context.Context, collection *mongo.Collection) error
+
+// Migration is a single versioned change of the users collection
+type Migration struct {
+	// Version orders the migrations, the migrations are applied in the ascending order of their versions
+	Version int
+
+	// Description explains what the migration changes
+	Description string
+
+	// Up applies the change
+	Up MigrationFunc
+
+	// Down reverts the change, nil if the change can not be reverted
+	Down MigrationFunc
+}
+
+// MigrationStatus contains whether a migration is applied and when
+type MigrationStatus struct {
+	Version     int
+	Description string
+	Applied     bool
+	AppliedAt   *time.Time
+
+	// Unknown indicates the migration is applied by a newer version of the service, so it can not be reverted
+	Unknown bool
+}
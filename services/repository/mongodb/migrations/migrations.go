@@ -0,0 +1,160 @@
+// Package migrations implements the versioned migrations of the MongoDB users collection
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// indexMissingErrorCodes are the codes of the errors dropping an index that does not exist fails with, either the
+// index or the whole collection is missing
+var indexMissingErrorCodes = []int{26, 27}
+
+// All returns the migrations of the users collection ordered by their versions. The migrations that are already
+// applied are recorded in the migrations collection, so new migrations must only ever be appended to the end of the
+// list with a greater version.
+func All() []Migration {
+	return []Migration{
+		// The unique index on email is what makes CreateUser reject duplicate users
+		NewIndexMigration(1, "Create the unique email index", mongo.IndexModel{
+			Keys:    bson.D{{Key: "email", Value: 1}},
+			Options: options.Index().SetName("email_1").SetUnique(true),
+		}),
+		// The sparse index on deletedAt only contains the soft deleted users and keeps purging them cheap
+		NewIndexMigration(2, "Create the deletedAt index", mongo.IndexModel{
+			Keys:    bson.D{{Key: "deletedAt", Value: 1}},
+			Options: options.Index().SetName("deletedAt_1").SetSparse(true),
+		}),
+		// The sparse index on tenantID only contains the users created in the multi-tenant mode and serves the
+		// searches scoped to a tenant
+		NewIndexMigration(3, "Create the tenantID index", mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenantID", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("tenantID_1__id_1").SetSparse(true),
+		}),
+		// The wildcard index on the labels serves the label selectors whatever label keys they match
+		NewIndexMigration(4, "Create the labels index", mongo.IndexModel{
+			Keys:    bson.D{{Key: "labels.$**", Value: 1}},
+			Options: options.Index().SetName("labels.$**_1"),
+		}),
+		// The free-text queries are matched as a prefix of the email addresses in lower case, which the users
+		// created before are given here and the index on them serves
+		NewDerivedFieldMigration(5, "Set the normalized email address of the users", "normalizedEmail", bson.M{"$toLower": "$email"}),
+		NewIndexMigration(6, "Create the normalizedEmail index", mongo.IndexModel{
+			Keys:    bson.D{{Key: "normalizedEmail", Value: 1}},
+			Options: options.Index().SetName("normalizedEmail_1"),
+		}),
+	}
+}
+
+// NewIndexMigration creates the migration that creates an index and drops it when reverted. The index must be named,
+// the indexes created before the migrations are versioned use the names MongoDB gives them by default, so creating
+// them again is a no-op.
+// version: Mandatory. The version of the migration
+// description: Mandatory. What the migration changes
+// model: Mandatory. The index to create, its options must set the name of the index
+// Returns the new migration
+func NewIndexMigration(version int, description string, model mongo.IndexModel) Migration {
+	return Migration{
+		Version:     version,
+		Description: description,
+		Up: func(ctx context.Context, collection *mongo.Collection) error {
+			_, err := collection.Indexes().CreateOne(ctx, model)
+
+			return err
+		},
+		Down: func(ctx context.Context, collection *mongo.Collection) error {
+			_, err := collection.Indexes().DropOne(ctx, *model.Options.Name)
+			if commandErr, ok := err.(mongo.CommandError); ok {
+				for _, code := range indexMissingErrorCodes {
+					if commandErr.HasErrorCode(code) {
+						return nil
+					}
+				}
+			}
+
+			return err
+		},
+	}
+}
+
+// NewBackfillMigration creates the migration that sets a field of the users that do not have it yet. The field is
+// left as it is when the migration is reverted, as the older versions of the service ignore the fields they do not
+// know.
+// version: Mandatory. The version of the migration
+// description: Mandatory. What the migration changes
+// field: Mandatory. The field to set
+// value: Mandatory. The value to set the field to
+// Returns the new migration
+func NewBackfillMigration(version int, description string, field string, value interface{}) Migration {
+	return Migration{
+		Version:     version,
+		Description: description,
+		Up: func(ctx context.Context, collection *mongo.Collection) error {
+			_, err := collection.UpdateMany(
+				ctx,
+				bson.M{field: bson.M{"$exists": false}},
+				bson.M{"$set": bson.M{field: value}})
+
+			return err
+		},
+		Down: func(ctx context.Context, collection *mongo.Collection) error {
+			return nil
+		},
+	}
+}
+
+// NewDerivedFieldMigration creates the migration that sets a field of the users that do not have it yet to the value
+// derived from their other fields. The field is left as it is when the migration is reverted, like the backfilled
+// fields.
+// version: Mandatory. The version of the migration
+// description: Mandatory. What the migration changes
+// field: Mandatory. The field to set
+// expression: Mandatory. The aggregation expression the value of the field is derived with, e.g. {"$toLower": "$email"}
+// Returns the new migration
+func NewDerivedFieldMigration(version int, description string, field string, expression interface{}) Migration {
+	return Migration{
+		Version:     version,
+		Description: description,
+		Up: func(ctx context.Context, collection *mongo.Collection) error {
+			_, err := collection.UpdateMany(
+				ctx,
+				bson.M{field: bson.M{"$exists": false}},
+				bson.A{bson.M{"$set": bson.M{field: expression}}})
+
+			return err
+		},
+		Down: func(ctx context.Context, collection *mongo.Collection) error {
+			return nil
+		},
+	}
+}
+
+// NewRenameMigration creates the migration that renames a field of the users and renames it back when reverted
+// version: Mandatory. The version of the migration
+// description: Mandatory. What the migration changes
+// from: Mandatory. The field to rename
+// to: Mandatory. The new name of the field
+// Returns the new migration
+func NewRenameMigration(version int, description string, from string, to string) Migration {
+	return Migration{
+		Version:     version,
+		Description: description,
+		Up:          renameField(from, to),
+		Down:        renameField(to, from),
+	}
+}
+
+// renameField returns the migration function that renames the field of the users that have it
+func renameField(from string, to string) MigrationFunc {
+	return func(ctx context.Context, collection *mongo.Collection) error {
+		_, err := collection.UpdateMany(
+			ctx,
+			bson.M{from: bson.M{"$exists": true}},
+			bson.M{"$rename": bson.M{from: to}})
+
+		return err
+	}
+}
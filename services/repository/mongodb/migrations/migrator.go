@@ -0,0 +1,225 @@
+// Package migrations implements the versioned migrations of the MongoDB users collection
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// migrationsCollectionSuffix is appended to the name of the users collection to name the collection the applied
+// versions are recorded in
+const migrationsCollectionSuffix = "_migrations"
+
+// appliedMigration is the record of an applied migration
+type appliedMigration struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"appliedAt"`
+}
+
+type migrator struct {
+	collection           *mongo.Collection
+	migrationsCollection *mongo.Collection
+	migrations           []Migration
+}
+
+// NewMigrator creates new instance of the migrator, setting up all dependencies and returns the instance
+// collection: Mandatory. The users collection to migrate
+// migrations: Mandatory. The migrations to apply, ordered by their versions
+// Returns the new migrator or error if something goes wrong
+func NewMigrator(
+	collection *mongo.Collection,
+	migrations []Migration) (MigratorContract, error) {
+	if collection == nil {
+		return nil, commonErrors.NewArgumentNilError("collection", "collection is required")
+	}
+
+	previousVersion := 0
+	for _, migration := range migrations {
+		if migration.Version <= previousVersion {
+			return nil, commonErrors.NewArgumentError(
+				"migrations",
+				fmt.Sprintf("migration %d must have a greater version than migration %d", migration.Version, previousVersion))
+		}
+
+		if migration.Up == nil {
+			return nil, commonErrors.NewArgumentError("migrations", fmt.Sprintf("migration %d must have the Up function", migration.Version))
+		}
+
+		previousVersion = migration.Version
+	}
+
+	return &migrator{
+		collection:           collection,
+		migrationsCollection: collection.Database().Collection(collection.Name() + migrationsCollectionSuffix),
+		migrations:           migrations,
+	}, nil
+}
+
+// Up applies the migrations that are not applied yet in the order of their versions
+// ctx: Mandatory The reference to the context
+// targetVersion: Optional. The version to migrate up to, every pending migration is applied if 0
+// Returns either the versions of the applied migrations or error if something goes wrong. The versions applied
+// before the error are returned along with it.
+func (migrator *migrator) Up(
+	ctx context.Context,
+	targetVersion int) ([]int, error) {
+	applied, err := migrator.readAppliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	appliedVersions := []int{}
+
+	for _, migration := range migrator.migrations {
+		if targetVersion > 0 && migration.Version > targetVersion {
+			break
+		}
+
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		if err := migration.Up(ctx, migrator.collection); err != nil {
+			return appliedVersions, fmt.Errorf("failed to apply migration %d: %w", migration.Version, err)
+		}
+
+		// Another instance starting at the same time may have recorded the same migration already
+		if _, err := migrator.migrationsCollection.InsertOne(ctx, appliedMigration{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now().UTC(),
+		}); err != nil && !mongo.IsDuplicateKeyError(err) {
+			return appliedVersions, fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+		}
+
+		appliedVersions = append(appliedVersions, migration.Version)
+	}
+
+	return appliedVersions, nil
+}
+
+// Down reverts the applied migrations newer than the target version, the newest first
+// ctx: Mandatory The reference to the context
+// targetVersion: Mandatory. The version to migrate down to, every applied migration is reverted if 0
+// Returns either the versions of the reverted migrations or error if something goes wrong. The versions reverted
+// before the error are returned along with it.
+func (migrator *migrator) Down(
+	ctx context.Context,
+	targetVersion int) ([]int, error) {
+	applied, err := migrator.readAppliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []int{}
+	for version := range applied {
+		if version > targetVersion {
+			versions = append(versions, version)
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+	known := map[int]Migration{}
+	for _, migration := range migrator.migrations {
+		known[migration.Version] = migration
+	}
+
+	revertedVersions := []int{}
+
+	for _, version := range versions {
+		migration, ok := known[version]
+		if !ok {
+			return revertedVersions, fmt.Errorf("migration %d is applied by a newer version of the service and can not be reverted by this version", version)
+		}
+
+		if migration.Down == nil {
+			return revertedVersions, fmt.Errorf("migration %d can not be reverted", version)
+		}
+
+		if err := migration.Down(ctx, migrator.collection); err != nil {
+			return revertedVersions, fmt.Errorf("failed to revert migration %d: %w", version, err)
+		}
+
+		if _, err := migrator.migrationsCollection.DeleteOne(ctx, bson.M{"_id": version}); err != nil {
+			return revertedVersions, fmt.Errorf("failed to remove the record of migration %d: %w", version, err)
+		}
+
+		revertedVersions = append(revertedVersions, version)
+	}
+
+	return revertedVersions, nil
+}
+
+// Status lists the known migrations and whether they are applied, along with the migrations applied by a newer
+// version of the service
+// ctx: Mandatory The reference to the context
+// Returns either the status of the migrations ordered by their versions or error if something goes wrong
+func (migrator *migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := migrator.readAppliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrator.migrations))
+	for _, migration := range migrator.migrations {
+		status := MigrationStatus{
+			Version:     migration.Version,
+			Description: migration.Description,
+		}
+
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			delete(applied, migration.Version)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	// The migrations left are only known to the newer version of the service that applied them
+	for _, record := range applied {
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, MigrationStatus{
+			Version:     record.Version,
+			Description: record.Description,
+			Applied:     true,
+			AppliedAt:   &appliedAt,
+			Unknown:     true,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses, nil
+}
+
+// readAppliedMigrations reads the records of the applied migrations
+// ctx: Mandatory The reference to the context
+// Returns either the records by their versions or error if something goes wrong
+func (migrator *migrator) readAppliedMigrations(ctx context.Context) (map[int]appliedMigration, error) {
+	cursor, err := migrator.migrationsCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the applied migrations: %w", err)
+	}
+
+	var records []appliedMigration
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("failed to read the applied migrations: %w", err)
+	}
+
+	applied := make(map[int]appliedMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,234 @@
+package migrations_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/decentralized-cloud/user/services/repository/mongodb/migrations"
+	"github.com/lucsky/cuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMigrations(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Migrations Tests")
+}
+
+var _ = Describe("Migrator Tests", func() {
+	var (
+		ctx        context.Context
+		client     *mongo.Client
+		collection *mongo.Collection
+		applied    []int
+		reverted   []int
+		migration  func(version int) migrations.Migration
+	)
+
+	BeforeEach(func() {
+		connectionString := os.Getenv("DATABASE_CONNECTION_STRING")
+		if strings.Trim(connectionString, " ") == "" {
+			connectionString = "mongodb://mongodb:27017"
+		}
+
+		ctx = context.Background()
+
+		var err error
+		client, err = mongo.Connect(ctx, options.Client().ApplyURI(connectionString))
+		Ω(err).Should(BeNil())
+
+		collection = client.Database("user").Collection(cuid.New())
+		applied = []int{}
+		reverted = []int{}
+
+		migration = func(version int) migrations.Migration {
+			return migrations.Migration{
+				Version:     version,
+				Description: cuid.New(),
+				Up: func(ctx context.Context, collection *mongo.Collection) error {
+					applied = append(applied, version)
+
+					return nil
+				},
+				Down: func(ctx context.Context, collection *mongo.Collection) error {
+					reverted = append(reverted, version)
+
+					return nil
+				},
+			}
+		}
+	})
+
+	AfterEach(func() {
+		_ = collection.Database().Collection(collection.Name() + "_migrations").Drop(ctx)
+		_ = collection.Drop(ctx)
+		_ = client.Disconnect(ctx)
+	})
+
+	Context("user tries to instantiate Migrator", func() {
+		When("the migrations are not ordered by their versions", func() {
+			It("should return ArgumentError", func() {
+				migrator, err := migrations.NewMigrator(collection, []migrations.Migration{migration(2), migration(1)})
+				Ω(migrator).Should(BeNil())
+				Ω(err).ShouldNot(BeNil())
+			})
+		})
+
+		When("the built-in migrations are used", func() {
+			It("should instantiate the new Migrator", func() {
+				migrator, err := migrations.NewMigrator(collection, migrations.All())
+				Ω(err).Should(BeNil())
+				Ω(migrator).ShouldNot(BeNil())
+			})
+		})
+	})
+
+	Context("Migrator is instantiated", func() {
+		var migrator migrations.MigratorContract
+
+		BeforeEach(func() {
+			migrator, _ = migrations.NewMigrator(collection, []migrations.Migration{migration(1), migration(2), migration(3)})
+		})
+
+		When("Up is called", func() {
+			It("should apply the pending migrations in order and only once", func() {
+				versions, err := migrator.Up(ctx, 2)
+				Ω(err).Should(BeNil())
+				Ω(versions).Should(Equal([]int{1, 2}))
+
+				versions, err = migrator.Up(ctx, 0)
+				Ω(err).Should(BeNil())
+				Ω(versions).Should(Equal([]int{3}))
+				Ω(applied).Should(Equal([]int{1, 2, 3}))
+
+				statuses, err := migrator.Status(ctx)
+				Ω(err).Should(BeNil())
+				Ω(statuses).Should(HaveLen(3))
+
+				for _, status := range statuses {
+					Ω(status.Applied).Should(BeTrue())
+					Ω(status.AppliedAt).ShouldNot(BeNil())
+				}
+			})
+		})
+
+		When("a migration fails", func() {
+			It("should stop and return the migrations applied before", func() {
+				expectedError := errors.New(cuid.New())
+				failing := migration(2)
+				failing.Up = func(ctx context.Context, collection *mongo.Collection) error { return expectedError }
+				migrator, _ = migrations.NewMigrator(collection, []migrations.Migration{migration(1), failing, migration(3)})
+
+				versions, err := migrator.Up(ctx, 0)
+				Ω(errors.Is(err, expectedError)).Should(BeTrue())
+				Ω(versions).Should(Equal([]int{1}))
+
+				statuses, err := migrator.Status(ctx)
+				Ω(err).Should(BeNil())
+				Ω(statuses[0].Applied).Should(BeTrue())
+				Ω(statuses[1].Applied).Should(BeFalse())
+			})
+		})
+
+		When("Down is called", func() {
+			It("should revert the migrations newer than the target version, the newest first", func() {
+				_, err := migrator.Up(ctx, 0)
+				Ω(err).Should(BeNil())
+
+				versions, err := migrator.Down(ctx, 1)
+				Ω(err).Should(BeNil())
+				Ω(versions).Should(Equal([]int{3, 2}))
+				Ω(reverted).Should(Equal([]int{3, 2}))
+
+				statuses, err := migrator.Status(ctx)
+				Ω(err).Should(BeNil())
+				Ω(statuses[0].Applied).Should(BeTrue())
+				Ω(statuses[1].Applied).Should(BeFalse())
+				Ω(statuses[2].Applied).Should(BeFalse())
+			})
+		})
+
+		When("a migration is applied by a newer version of the service", func() {
+			It("should report it as unknown and refuse to revert it", func() {
+				newerMigrator, _ := migrations.NewMigrator(collection, []migrations.Migration{migration(1), migration(2), migration(3), migration(4)})
+				_, err := newerMigrator.Up(ctx, 0)
+				Ω(err).Should(BeNil())
+
+				statuses, err := migrator.Status(ctx)
+				Ω(err).Should(BeNil())
+				Ω(statuses).Should(HaveLen(4))
+				Ω(statuses[3].Unknown).Should(BeTrue())
+
+				_, err = migrator.Down(ctx, 0)
+				Ω(err).ShouldNot(BeNil())
+				Ω(reverted).Should(BeEmpty())
+			})
+		})
+	})
+
+	Context("the built-in migration constructors are used", func() {
+		When("the index migration is applied and reverted", func() {
+			It("should create and drop the index", func() {
+				migrator, _ := migrations.NewMigrator(collection, []migrations.Migration{
+					migrations.NewIndexMigration(1, cuid.New(), mongo.IndexModel{
+						Keys:    bson.D{{Key: "email", Value: 1}},
+						Options: options.Index().SetName("email_1").SetUnique(true),
+					}),
+				})
+
+				_, err := migrator.Up(ctx, 0)
+				Ω(err).Should(BeNil())
+
+				_, err = collection.InsertOne(ctx, bson.M{"email": "a@test.com"})
+				Ω(err).Should(BeNil())
+
+				_, err = collection.InsertOne(ctx, bson.M{"email": "a@test.com"})
+				Ω(mongo.IsDuplicateKeyError(err)).Should(BeTrue())
+
+				_, err = migrator.Down(ctx, 0)
+				Ω(err).Should(BeNil())
+
+				_, err = collection.InsertOne(ctx, bson.M{"email": "a@test.com"})
+				Ω(err).Should(BeNil())
+			})
+		})
+
+		When("the backfill and rename migrations are applied and reverted", func() {
+			It("should set the missing field and rename it back and forth", func() {
+				_, err := collection.InsertMany(ctx, []interface{}{
+					bson.M{"email": "a@test.com"},
+					bson.M{"email": "b@test.com", "plan": "pro"},
+				})
+				Ω(err).Should(BeNil())
+
+				migrator, _ := migrations.NewMigrator(collection, []migrations.Migration{
+					migrations.NewBackfillMigration(1, cuid.New(), "plan", "free"),
+					migrations.NewRenameMigration(2, cuid.New(), "plan", "tier"),
+				})
+
+				_, err = migrator.Up(ctx, 0)
+				Ω(err).Should(BeNil())
+				Ω(readField(ctx, collection, "a@test.com", "tier")).Should(Equal("free"))
+				Ω(readField(ctx, collection, "b@test.com", "tier")).Should(Equal("pro"))
+
+				_, err = migrator.Down(ctx, 0)
+				Ω(err).Should(BeNil())
+				Ω(readField(ctx, collection, "a@test.com", "plan")).Should(Equal("free"))
+				Ω(readField(ctx, collection, "b@test.com", "tier")).Should(BeNil())
+			})
+		})
+	})
+})
+
+func readField(ctx context.Context, collection *mongo.Collection, email string, field string) interface{} {
+	document := bson.M{}
+	Ω(collection.FindOne(ctx, bson.M{"email": email}).Decode(&document)).Should(BeNil())
+
+	return document[field]
+}
@@ -0,0 +1,69 @@
+// Package mongodb implements MongoDB repository services
+package mongodb
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/repository/mongodb/migrations"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// NewMigrator connects to the database and creates the migrator of the users collection, so the collection can be
+// migrated without starting the service
+// ctx: Mandatory The reference to the context
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns either the migrator and the function that disconnects from the database once the migrator is no longer
+// used or error if something goes wrong
+func NewMigrator(
+	ctx context.Context,
+	configurationService configuration.ConfigurationContract) (migrations.MigratorContract, func(), error) {
+	service, err := newConnectingService(configurationService)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, collection, err := service.connect(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	migrator, err := migrations.NewMigrator(collection, migrations.All())
+	if err != nil {
+		disconnect(client)
+
+		return nil, nil, err
+	}
+
+	return migrator, func() { disconnect(client) }, nil
+}
+
+// newConnectingService creates the repository service that only connects to the database, so the users collection can
+// be operated on without starting the service
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns either the repository service or error if something goes wrong
+func newConnectingService(configurationService configuration.ConfigurationContract) (*mongodbRepositoryService, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if _, err := configurationService.GetDatabaseConnectionString(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	databaseName, err := configurationService.GetDatabaseName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database name", err)
+	}
+
+	databaseCollectionName, err := configurationService.GetDatabaseCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database collection name", err)
+	}
+
+	return &mongodbRepositoryService{
+		getConnectionString:    configurationService.GetDatabaseConnectionString,
+		databaseName:           databaseName,
+		databaseCollectionName: databaseCollectionName,
+	}, nil
+}
@@ -0,0 +1,316 @@
+// Package mongodb implements MongoDB repository services
+package mongodb
+
+import (
+	"strings"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// relevanceField is the field the relevance of the users to the free-text query is computed into, so the search result
+// can be sorted and paged by it
+const relevanceField = "relevance"
+
+// searchPage reads a page of the search result with the cursors, the limit and the count pushed into the queries, so
+// only the users on the page are read. The cursors are the document IDs, the page continues after or before the user
+// of the cursor using the values the user is sorted by.
+type searchPage struct {
+	collection *mongo.Collection
+	filter     bson.M
+	sort       bson.D
+	projection interface{}
+	hint       interface{}
+
+	// query is the lower case free-text query the users are ranked by before they are sorted, empty if the users are
+	// only sorted by the sorting options
+	query string
+}
+
+// newSearchPage creates the search page reading the users that matched the filter
+// collection: Mandatory. The collection the users are stored in
+// filter: Mandatory. The filter the users must match
+// findOptions: Mandatory. The sort, the projection and the index hint the users are read with
+// query: Optional. The free-text query the users are ranked by, the users are not ranked if empty
+// Returns the search page
+func newSearchPage(
+	collection *mongo.Collection,
+	filter bson.M,
+	findOptions *options.FindOptions,
+	query string) *searchPage {
+	sort, _ := findOptions.Sort.(bson.D)
+	if query != "" {
+		sort = append(bson.D{{Key: relevanceField, Value: 1}}, sort...)
+	}
+
+	return &searchPage{
+		collection: collection,
+		filter:     filter,
+		sort:       sort,
+		projection: findOptions.Projection,
+		hint:       findOptions.Hint,
+		query:      strings.ToLower(query),
+	}
+}
+
+// read reads the requested page of the search result
+// sessionCtx: Mandatory. The session the users are read in
+// pagination: Mandatory. The pagination details to apply
+// Returns either the requested page or error if something goes wrong. ArgumentError is returned if the After or
+// Before cursor does not identify a user.
+func (page *searchPage) read(
+	sessionCtx mongo.SessionContext,
+	pagination models.Pagination) (*repository.SearchResponse, error) {
+	pageQuery := repository.NewPageQuery(pagination)
+
+	after, err := page.readCursor(sessionCtx, pageQuery.After)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := page.readCursor(sessionCtx, pageQuery.Before)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := bson.A{}
+	if after != nil {
+		conditions = append(conditions, createKeysetFilter(after, page.sort))
+	}
+
+	if before != nil {
+		conditions = append(conditions, createKeysetFilter(before, reverseSort(page.sort)))
+	}
+
+	sort := page.sort
+	if pageQuery.Backward {
+		sort = reverseSort(sort)
+	}
+
+	aggregateOptions := options.Aggregate()
+	if page.hint != nil {
+		aggregateOptions.SetHint(page.hint)
+	}
+
+	cursor, err := page.collection.Aggregate(sessionCtx, page.createPipeline(conditions, sort, pageQuery.Limit), aggregateOptions)
+	if err != nil {
+		return nil, newOperationError(sessionCtx, "failed to search users", err)
+	}
+
+	defer func() {
+		_ = cursor.Close(sessionCtx)
+	}()
+
+	users := []models.UserWithCursor{}
+	for cursor.Next(sessionCtx) {
+		var user user
+		if err = cursor.Decode(&user); err != nil {
+			return nil, newOperationError(sessionCtx, "failed to decode user", err)
+		}
+
+		users = append(users, mapUserWithCursor(user))
+	}
+
+	if err = cursor.Err(); err != nil {
+		return nil, newOperationError(sessionCtx, "failed to search users", err)
+	}
+
+	countOptions := options.Count()
+	if page.hint != nil {
+		countOptions.SetHint(page.hint)
+	}
+
+	totalCount, err := page.collection.CountDocuments(sessionCtx, page.filter, countOptions)
+	if err != nil {
+		return nil, newOperationError(sessionCtx, "failed to count users", err)
+	}
+
+	usersBeforeAfter, err := page.exists(sessionCtx, after, reverseSort(page.sort))
+	if err != nil {
+		return nil, err
+	}
+
+	usersAfterBefore, err := page.exists(sessionCtx, before, page.sort)
+	if err != nil {
+		return nil, err
+	}
+
+	return repository.NewPageResponse(pageQuery, pagination, users, totalCount, usersBeforeAfter, usersAfterBefore), nil
+}
+
+// readCursor reads the values the user of the cursor is sorted by
+// sessionCtx: Mandatory. The session the user is read in
+// cursor: Optional. The cursor to read
+// Returns either the values the user is sorted by, nil if the cursor is not given, or error if something goes wrong.
+// ArgumentError is returned if the cursor does not identify a user.
+func (page *searchPage) readCursor(sessionCtx mongo.SessionContext, cursor *string) (bson.M, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+
+	id, err := primitive.ObjectIDFromHex(*cursor)
+	if err != nil {
+		return nil, repository.NewInvalidCursorError(*cursor)
+	}
+
+	filter := bson.M{"_id": id}
+	if tenantID, ok := page.filter["tenantID"]; ok {
+		filter["tenantID"] = tenantID
+	}
+
+	projection := bson.M{"email": 1}
+	for _, key := range page.sort {
+		if key.Key != relevanceField {
+			projection[key.Key] = 1
+		}
+	}
+
+	var values bson.M
+	if err = page.collection.FindOne(sessionCtx, filter, options.FindOne().SetProjection(projection)).Decode(&values); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.NewInvalidCursorError(*cursor)
+		}
+
+		return nil, newOperationError(sessionCtx, "failed to read the cursor", err)
+	}
+
+	if page.query != "" {
+		email, _ := values["email"].(string)
+		values[relevanceField] = repository.GetRelevance(email, page.query)
+	}
+
+	return values, nil
+}
+
+// exists indicates whether any user that matched the filter is sorted at or after the user of the cursor
+// sessionCtx: Mandatory. The session the users are read in
+// cursor: Optional. The values the user of the cursor is sorted by
+// sort: Mandatory. The order the users are sorted in
+// Returns either true if such user exists, false if the cursor is not given, or error if something goes wrong
+func (page *searchPage) exists(sessionCtx mongo.SessionContext, cursor bson.M, sort bson.D) (bool, error) {
+	if cursor == nil {
+		return false, nil
+	}
+
+	conditions := bson.A{bson.M{"$or": bson.A{
+		bson.M{"_id": cursor["_id"]},
+		createKeysetFilter(cursor, sort),
+	}}}
+
+	result, err := page.collection.Aggregate(sessionCtx, page.createPipeline(conditions, nil, 1))
+	if err != nil {
+		return false, newOperationError(sessionCtx, "failed to search users", err)
+	}
+
+	defer func() {
+		_ = result.Close(sessionCtx)
+	}()
+
+	found := result.Next(sessionCtx)
+	if err = result.Err(); err != nil {
+		return false, newOperationError(sessionCtx, "failed to search users", err)
+	}
+
+	return found, nil
+}
+
+// createPipeline creates the aggregation pipeline reading the users that matched the filter and the given conditions
+// conditions: Optional. The additional conditions the users must match, e.g. the keyset filters of the cursors
+// sort: Optional. The order the users are read in
+// limit: Optional. The number of the users to read, the users are not limited if zero
+// Returns the aggregation pipeline
+func (page *searchPage) createPipeline(conditions bson.A, sort bson.D, limit int) mongo.Pipeline {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: page.filter}}}
+	if page.query != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{relevanceField: createRelevanceExpression(page.query)}}})
+	}
+
+	if len(conditions) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"$and": conditions}}})
+	}
+
+	if len(sort) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$sort", Value: sort}})
+	}
+
+	if limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+
+	if page.projection != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$project", Value: page.projection}})
+	}
+
+	return pipeline
+}
+
+// createKeysetFilter creates the filter matching the users sorted after the user of the cursor. The users sorted after
+// it are the ones sorted after it by the first field, or equal to it by the first field and sorted after it by the
+// second field and so on. The document ID is always sorted by last, so no other user is equal to it.
+// cursor: Mandatory. The values the user of the cursor is sorted by
+// sort: Mandatory. The order the users are sorted in
+// Returns the filter
+func createKeysetFilter(cursor bson.M, sort bson.D) bson.M {
+	branches := bson.A{}
+	equal := bson.A{}
+
+	for _, key := range sort {
+		value := cursor[key.Key]
+		if condition := createAfterCondition(key.Key, key.Value.(int), value); condition != nil {
+			branch := append(append(bson.A{}, equal...), condition)
+			branches = append(branches, bson.M{"$and": branch})
+		}
+
+		equal = append(equal, bson.M{key.Key: value})
+	}
+
+	return bson.M{"$or": branches}
+}
+
+// createAfterCondition creates the condition matching the field values sorted after the given value. The missing
+// values are sorted first in ascending order, and the comparison operators only match the values of the same type,
+// so they are matched explicitly.
+// field: Mandatory. The document field
+// direction: Mandatory. The sort direction, 1 for ascending and -1 for descending
+// value: Optional. The value of the field of the user of the cursor, nil if missing
+// Returns the condition or nil if no value is sorted after the given value
+func createAfterCondition(field string, direction int, value interface{}) bson.M {
+	switch {
+	case direction > 0 && value == nil:
+		return bson.M{field: bson.M{"$ne": nil}}
+
+	case direction > 0:
+		return bson.M{field: bson.M{"$gt": value}}
+
+	case value == nil:
+		return nil
+
+	default:
+		return bson.M{"$or": bson.A{
+			bson.M{field: bson.M{"$lt": value}},
+			bson.M{field: nil},
+		}}
+	}
+}
+
+// reverseSort reverses the sort direction of every field
+func reverseSort(sort bson.D) bson.D {
+	reversed := make(bson.D, 0, len(sort))
+	for _, key := range sort {
+		reversed = append(reversed, bson.E{Key: key.Key, Value: -key.Value.(int)})
+	}
+
+	return reversed
+}
+
+// createRelevanceExpression creates the expression ranking how well the email address of the user matches the free-text
+// query, the same way repository.GetRelevance ranks it
+// query: Mandatory. The lower case free-text query
+// Returns the expression
+func createRelevanceExpression(query string) bson.M {
+	return bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{bson.M{"$toLower": "$email"}, query}}, 0, 1}}
+}
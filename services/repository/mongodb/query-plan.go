@@ -0,0 +1,96 @@
+// Package mongodb implements MongoDB repository services
+package mongodb
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type queryPlanStage struct {
+	Stage      string          `bson:"stage"`
+	InputStage *queryPlanStage `bson:"inputStage"`
+}
+
+type explainResult struct {
+	QueryPlanner struct {
+		WinningPlan queryPlanStage `bson:"winningPlan"`
+	} `bson:"queryPlanner"`
+}
+
+var searchQueryPlansCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "user_repository_search_query_plans_total",
+		Help: "The number of searches grouped by the filter shape, the index hint and the stage the winning query plan reads the documents with (e.g. IXSCAN or COLLSCAN)",
+	},
+	[]string{"filter_shape", "index_hint", "stage"})
+
+// getFilterShape returns the shape of the given filter, that is the list of the filtered field names sorted and joined by "+"
+// filter: Mandatory. The search filter
+// Returns the shape of the filter
+func getFilterShape(filter bson.M) string {
+	fields := make([]string, 0, len(filter))
+	for field := range filter {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	return strings.Join(fields, "+")
+}
+
+// recordQueryPlanStatistics explains the search query and records the stage the winning query plan reads the documents with.
+// Failing to explain the query is recorded as UNKNOWN stage and never fails the search itself.
+// ctx: Mandatory The reference to the context
+// collection: Mandatory. The collection the search runs against
+// filter: Mandatory. The search filter
+// sort: Mandatory. The search sort order
+// filterShape: Mandatory. The shape of the search filter
+// indexHint: Optional. The index hint supplied to the search
+func recordQueryPlanStatistics(
+	ctx context.Context,
+	collection *mongo.Collection,
+	filter bson.M,
+	sort bson.D,
+	filterShape string,
+	indexHint string) {
+	findCommand := bson.D{
+		{Key: "find", Value: collection.Name()},
+		{Key: "filter", Value: filter},
+		{Key: "sort", Value: sort},
+	}
+
+	if indexHint != "" {
+		findCommand = append(findCommand, bson.E{Key: "hint", Value: indexHint})
+	}
+
+	stage := "UNKNOWN"
+
+	var result explainResult
+	err := collection.Database().RunCommand(ctx, bson.D{
+		{Key: "explain", Value: findCommand},
+		{Key: "verbosity", Value: "queryPlanner"},
+	}).Decode(&result)
+	if err == nil {
+		stage = getLeafStage(result.QueryPlanner.WinningPlan)
+	}
+
+	searchQueryPlansCounter.WithLabelValues(filterShape, indexHint, stage).Inc()
+}
+
+func getLeafStage(plan queryPlanStage) string {
+	for plan.InputStage != nil {
+		plan = *plan.InputStage
+	}
+
+	if plan.Stage == "" {
+		return "UNKNOWN"
+	}
+
+	return plan.Stage
+}
@@ -0,0 +1,99 @@
+// Package mongodb implements MongoDB repository services
+package mongodb
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// replicaSetOptions defines which members of the replica set the users are read from and how many of them must
+// acknowledge the reads and the writes
+type replicaSetOptions struct {
+	readPreference *readpref.ReadPref
+	readConcern    *readconcern.ReadConcern
+	writeConcern   *writeconcern.WriteConcern
+}
+
+// getReplicaSetOptions reads the read preference, the read concern and the write concern from the configuration
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns either the replica set options or error if something goes wrong
+func getReplicaSetOptions(configurationService configuration.ConfigurationContract) (replicaSetOptions, error) {
+	readPreferenceMode, err := configurationService.GetDatabaseReadPreference()
+	if err != nil {
+		return replicaSetOptions{}, commonErrors.NewUnknownErrorWithError("failed to get the database read preference", err)
+	}
+
+	readConcernLevel, err := configurationService.GetDatabaseReadConcern()
+	if err != nil {
+		return replicaSetOptions{}, commonErrors.NewUnknownErrorWithError("failed to get the database read concern", err)
+	}
+
+	writeConcernString, err := configurationService.GetDatabaseWriteConcern()
+	if err != nil {
+		return replicaSetOptions{}, commonErrors.NewUnknownErrorWithError("failed to get the database write concern", err)
+	}
+
+	mode, err := readpref.ModeFromString(readPreferenceMode)
+	if err != nil {
+		return replicaSetOptions{}, commonErrors.NewUnknownErrorWithError("failed to parse the database read preference", err)
+	}
+
+	readPreference, err := readpref.New(mode)
+	if err != nil {
+		return replicaSetOptions{}, commonErrors.NewUnknownErrorWithError("failed to create the database read preference", err)
+	}
+
+	// Only the primary can serve the linearizable reads
+	if readConcernLevel == "linearizable" && mode != readpref.PrimaryMode {
+		return replicaSetOptions{}, commonErrors.NewUnknownError("the linearizable database read concern requires the primary read preference")
+	}
+
+	writeConcern := writeconcern.New(writeconcern.WMajority())
+	if writeConcernString != "majority" {
+		members, err := strconv.Atoi(writeConcernString)
+		if err != nil {
+			return replicaSetOptions{}, commonErrors.NewUnknownErrorWithError("failed to parse the database write concern", err)
+		}
+
+		writeConcern = writeconcern.New(writeconcern.W(members))
+	}
+
+	return replicaSetOptions{
+		readPreference: readPreference,
+		readConcern:    readconcern.New(readconcern.Level(readConcernLevel)),
+		writeConcern:   writeConcern,
+	}, nil
+}
+
+// createClientAndReadCollection creates the client and the collection the users are read from with the configured
+// read preference. Only the reads that are not part of a mutation use it, so they can be served by the secondaries
+// while the mutations keep reading the users from the primary.
+// ctx: Mandatory The reference to the context
+// Returns either the client and the collection or error if something goes wrong
+func (service *mongodbRepositoryService) createClientAndReadCollection(ctx context.Context) (*mongo.Client, *mongo.Collection, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if service.replicaSet.readPreference == nil {
+		return client, collection, nil
+	}
+
+	readCollection, err := collection.Clone(options.Collection().SetReadPreference(service.replicaSet.readPreference))
+	if err != nil {
+		disconnect(client)
+
+		return nil, nil, newOperationError(ctx, "failed to set the read preference of the users collection", err)
+	}
+
+	return client, readCollection, nil
+}
@@ -3,37 +3,164 @@ package mongodb
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/clock"
 	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/idgenerator"
 	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/repository/mongodb/migrations"
 	commonErrors "github.com/micro-business/go-core/system/errors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 type user struct {
-	Email string `bson:"email" json:"email"`
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	Email       string             `bson:"email" json:"email"`
+	Preferences map[string]string  `bson:"preferences,omitempty" json:"preferences,omitempty"`
+	Memberships []membership       `bson:"memberships,omitempty" json:"memberships,omitempty"`
+	DeletedAt   *time.Time         `bson:"deletedAt,omitempty" json:"deletedAt,omitempty"`
+
+	// NormalizedEmail is the email address in lower case the free-text queries are matched against, so they match
+	// regardless of the case through a plain index
+	NormalizedEmail string `bson:"normalizedEmail,omitempty" json:"normalizedEmail,omitempty"`
+
+	EmailVerified              bool       `bson:"emailVerified,omitempty" json:"emailVerified,omitempty"`
+	EmailVerificationTokenHash string     `bson:"emailVerificationTokenHash,omitempty" json:"emailVerificationTokenHash,omitempty"`
+	EmailVerificationExpiresAt *time.Time `bson:"emailVerificationExpiresAt,omitempty" json:"emailVerificationExpiresAt,omitempty"`
+
+	FailedLoginAttempts int        `bson:"failedLoginAttempts,omitempty" json:"failedLoginAttempts,omitempty"`
+	LockedAt            *time.Time `bson:"lockedAt,omitempty" json:"lockedAt,omitempty"`
+
+	MFAMethods []mfaMethod `bson:"mfaMethods,omitempty" json:"mfaMethods,omitempty"`
+
+	Labels map[string]string `bson:"labels,omitempty" json:"labels,omitempty"`
+
+	Avatar *avatar `bson:"avatar,omitempty" json:"avatar,omitempty"`
+
+	CreatedAt *time.Time `bson:"createdAt,omitempty" json:"createdAt,omitempty"`
+	UpdatedAt *time.Time `bson:"updatedAt,omitempty" json:"updatedAt,omitempty"`
+	CreatedBy string     `bson:"createdBy,omitempty" json:"createdBy,omitempty"`
+	UpdatedBy string     `bson:"updatedBy,omitempty" json:"updatedBy,omitempty"`
+
+	TenantID string `bson:"tenantID,omitempty" json:"tenantID,omitempty"`
+}
+
+// sortableFields are the name of the fields the search result can be sorted by, the same fields the other
+// repositories support
+var sortableFields = map[string]bool{
+	"email":     true,
+	"createdAt": true,
+	"updatedAt": true,
+	"createdBy": true,
+	"updatedBy": true,
+}
+
+// sortFields maps the name of the fields the search result is sorted by to the document fields they are stored in
+// if they differ. The document IDs start with the time the user is created at, so the users created before the
+// creation time is stored are sorted by it as well.
+var sortFields = map[string]string{
+	"createdAt": "_id",
+}
+
+// projectionFields maps the name of the user fields the callers can request to the document fields they are read
+// from. The fields that are not stored in the documents, e.g. the data residency, are not mapped.
+var projectionFields = map[string][]string{
+	"memberships":         {"memberships"},
+	"emailVerified":       {"emailVerified"},
+	"locked":              {"lockedAt"},
+	"lockedAt":            {"lockedAt"},
+	"failedLoginAttempts": {"failedLoginAttempts"},
+	"labels":              {"labels"},
+	"createdAt":           {"createdAt"},
+	"updatedAt":           {"updatedAt"},
+	"createdBy":           {"createdBy"},
+	"updatedBy":           {"updatedBy"},
+}
+
+// searchFilter contains the search criteria only some of the searches support
+type searchFilter struct {
+	tenantID      string
+	createdAfter  *time.Time
+	createdBefore *time.Time
+	after         *primitive.ObjectID
+	labelSelector []models.LabelSelectorRequirement
+	query         string
+	fields        []string
+}
+
+type membership struct {
+	TenantID string    `bson:"tenantID" json:"tenantID"`
+	Role     string    `bson:"role" json:"role"`
+	JoinedAt time.Time `bson:"joinedAt" json:"joinedAt"`
+}
+
+// mfaMethod is the stored MFA method. The credential key is stored along the method, so enrolling the same credential
+// twice can be rejected by the filter of a single update.
+type mfaMethod struct {
+	MethodID             string    `bson:"methodID" json:"methodID"`
+	Type                 string    `bson:"type" json:"type"`
+	Name                 string    `bson:"name,omitempty" json:"name,omitempty"`
+	CredentialKey        string    `bson:"credentialKey" json:"credentialKey"`
+	TOTPSecretRef        string    `bson:"totpSecretRef,omitempty" json:"totpSecretRef,omitempty"`
+	WebAuthnCredentialID string    `bson:"webAuthnCredentialID,omitempty" json:"webAuthnCredentialID,omitempty"`
+	RecoveryCodeHashes   []string  `bson:"recoveryCodeHashes,omitempty" json:"recoveryCodeHashes,omitempty"`
+	EnrolledAt           time.Time `bson:"enrolledAt" json:"enrolledAt"`
+}
+
+// avatar is the stored location of the avatar image of the user
+type avatar struct {
+	ObjectKey   string `bson:"objectKey" json:"objectKey"`
+	ContentHash string `bson:"contentHash" json:"contentHash"`
 }
 
 type mongodbRepositoryService struct {
-	connectionString       string
-	databaseName           string
-	databaseCollectionName string
+	getConnectionString              func() (string, error)
+	databaseName                     string
+	databaseCollectionName           string
+	searchIndexHints                 map[string]string
+	searchQueryPlanStatisticsEnabled bool
+	replicaSet                       replicaSetOptions
+	causalConsistency                *causalConsistencyTracker
+	startupCheck                     *startupCheck
+	clockService                     clock.ClockContract
+	idGeneratorService               idgenerator.IDGeneratorContract
 }
 
 // NewMongodbRepositoryService creates new instance of the mongodbRepositoryService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// clockService: Mandatory. Reference to the service that provides the time the users are created, updated and soft
+// deleted at
+// idGeneratorService: Mandatory. Reference to the service that generates the ObjectIDs of the users
 // Returns the new service or error if something goes wrong
 func NewMongodbRepositoryService(
-	configurationService configuration.ConfigurationContract) (repository.RepositoryContract, error) {
+	configurationService configuration.ConfigurationContract,
+	clockService clock.ClockContract,
+	idGeneratorService idgenerator.IDGeneratorContract) (repository.RepositoryContract, error) {
 	if configurationService == nil {
 		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
 	}
 
-	connectionString, err := configurationService.GetDatabaseConnectionString()
-	if err != nil {
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	if idGeneratorService == nil {
+		return nil, commonErrors.NewArgumentNilError("idGeneratorService", "idGeneratorService is required")
+	}
+
+	if _, err := configurationService.GetDatabaseConnectionString(); err != nil {
 		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
 	}
 
@@ -47,11 +174,60 @@ func NewMongodbRepositoryService(
 		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database collection name", err)
 	}
 
-	return &mongodbRepositoryService{
-		connectionString:       connectionString,
-		databaseName:           databaseName,
-		databaseCollectionName: databaseCollectionName,
-	}, nil
+	searchIndexHints, err := configurationService.GetDatabaseSearchIndexHints()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database search index hints", err)
+	}
+
+	searchQueryPlanStatisticsEnabled, err := configurationService.GetDatabaseSearchQueryPlanStatisticsEnabled()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get whether the database search query plan statistics is enabled", err)
+	}
+
+	replicaSet, err := getReplicaSetOptions(configurationService)
+	if err != nil {
+		return nil, err
+	}
+
+	startupCheckEnabled, err := configurationService.GetDatabaseStartupCheckEnabled()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get whether the database startup check is enabled", err)
+	}
+
+	service := &mongodbRepositoryService{
+		getConnectionString:              configurationService.GetDatabaseConnectionString,
+		databaseName:                     databaseName,
+		databaseCollectionName:           databaseCollectionName,
+		searchIndexHints:                 searchIndexHints,
+		searchQueryPlanStatisticsEnabled: searchQueryPlanStatisticsEnabled,
+		replicaSet:                       replicaSet,
+		causalConsistency:                &causalConsistencyTracker{},
+		clockService:                     clockService,
+		idGeneratorService:               idGeneratorService,
+	}
+
+	if !startupCheckEnabled {
+		if err = service.migrate(context.Background()); err != nil {
+			return nil, err
+		}
+
+		return service, nil
+	}
+
+	initialBackoff, err := configurationService.GetDatabaseStartupCheckInitialBackoff()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the initial backoff of the database startup check", err)
+	}
+
+	maxBackoff, err := configurationService.GetDatabaseStartupCheckMaxBackoff()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the maximum backoff of the database startup check", err)
+	}
+
+	service.startupCheck = newStartupCheck()
+	go service.startupCheck.run(service.migrate, initialBackoff, maxBackoff)
+
+	return service, nil
 }
 
 // CreateUser creates a new user.
@@ -66,17 +242,47 @@ func (service *mongodbRepositoryService) CreateUser(
 		return nil, err
 	}
 
-	defer disconnect(ctx, client)
+	defer disconnect(client)
+
+	now := service.clockService.Now()
+	actor := repository.GetActor(ctx)
+	tenantID := repository.GetTenant(ctx)
 
-	insertResult, err := collection.InsertOne(ctx, user{request.Email})
+	var insertResult *mongo.InsertOneResult
+	err = service.withCausallyConsistentSession(ctx, client, func(sessionCtx mongo.SessionContext) (err error) {
+		insertResult, err = collection.InsertOne(sessionCtx, user{
+			ID:              service.idGeneratorService.NewObjectID(),
+			Email:           request.Email,
+			NormalizedEmail: strings.ToLower(request.Email),
+			Labels:          request.User.Labels,
+			CreatedAt:       &now,
+			UpdatedAt:       &now,
+			CreatedBy:       actor,
+			UpdatedBy:       actor,
+			TenantID:        tenantID,
+		})
+
+		return
+	})
 	if err != nil {
-		return nil, commonErrors.NewUnknownErrorWithError("failed to create user", err)
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, commonErrors.NewAlreadyExistsErrorWithError(err)
+		}
+
+		return nil, newOperationError(ctx, "failed to create user", err)
 	}
 
 	userID := insertResult.InsertedID.(primitive.ObjectID).Hex()
+	createdUser := request.User
+	createdUser.ID = userID
+	createdUser.CreatedAt = &now
+	createdUser.UpdatedAt = &now
+	createdUser.CreatedBy = actor
+	createdUser.UpdatedBy = actor
+	createdUser.TenantID = tenantID
 
 	return &repository.CreateUserResponse{
-		User:   request.User,
+		User:   createdUser,
 		Cursor: userID,
 	}, nil
 }
@@ -88,11 +294,100 @@ func (service *mongodbRepositoryService) CreateUser(
 func (service *mongodbRepositoryService) ReadUser(
 	ctx context.Context,
 	request *repository.ReadUserRequest) (response *repository.ReadUserResponse, err error) {
-	response, _, err = service.readUser(ctx, request)
+	response, _, err = service.readUser(ctx, request, repository.GetReadTenant(ctx), true)
 
 	return
 }
 
+// ReadUsers reads the existing users with the given email addresses or IDs at once, with a single query
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the existing users
+// Returns either the users found or error if something goes wrong. The users that are not found are left out.
+func (service *mongodbRepositoryService) ReadUsers(
+	ctx context.Context,
+	request *repository.ReadUsersRequest) (*repository.ReadUsersResponse, error) {
+	// The users named by either their email addresses or their IDs are matched
+	conditions := bson.A{}
+	if len(request.Emails) > 0 {
+		conditions = append(conditions, bson.M{"email": bson.M{"$in": request.Emails}})
+	}
+
+	// The IDs that are not object IDs can not be the ID of any user, so they are left out
+	objectIDs := bson.A{}
+	for _, userID := range request.UserIDs {
+		if objectID, err := primitive.ObjectIDFromHex(userID); err == nil {
+			objectIDs = append(objectIDs, objectID)
+		}
+	}
+
+	if len(objectIDs) > 0 {
+		conditions = append(conditions, bson.M{"_id": bson.M{"$in": objectIDs}})
+	}
+
+	users := []models.UserWithCursor{}
+	if len(conditions) == 0 {
+		return &repository.ReadUsersResponse{Users: users}, nil
+	}
+
+	client, collection, err := service.createClientAndReadCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(client)
+
+	filter := bson.M{
+		"$or":       conditions,
+		"deletedAt": nil,
+	}
+
+	if tenantID := repository.GetReadTenant(ctx); tenantID != "" {
+		filter["tenantID"] = tenantID
+	}
+
+	findOptions := options.Find()
+	if projection := createProjection(request.Fields); projection != nil {
+		findOptions.SetProjection(projection)
+	}
+
+	err = service.withCausallyConsistentSession(ctx, client, func(sessionCtx mongo.SessionContext) error {
+		cursor, err := collection.Find(sessionCtx, filter, findOptions)
+		if err != nil {
+			return newOperationError(ctx, "failed to retrieve users", err)
+		}
+
+		defer func() {
+			_ = cursor.Close(sessionCtx)
+		}()
+
+		for cursor.Next(sessionCtx) {
+			var user user
+			if err = cursor.Decode(&user); err != nil {
+				return newOperationError(ctx, "failed to decode user", err)
+			}
+
+			users = append(users, mapUserWithCursor(user))
+		}
+
+		if err = cursor.Err(); err != nil {
+			return newOperationError(ctx, "failed to retrieve users", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		if commonErrors.IsUnknownError(err) || repository.IsDeadlineExceededError(err) {
+			return nil, err
+		}
+
+		return nil, newOperationError(ctx, "failed to retrieve users", err)
+	}
+
+	return &repository.ReadUsersResponse{
+		Users: users,
+	}, nil
+}
+
 // UpdateUser update an existing user
 // context: Optional The reference to the context
 // request: Mandatory. The request to update an existing user
@@ -105,108 +400,1345 @@ func (service *mongodbRepositoryService) UpdateUser(
 		return nil, err
 	}
 
-	defer disconnect(ctx, client)
+	defer disconnect(client)
+
+	filter := notDeletedUserFilter(request.Email, repository.GetTenant(ctx))
 
-	filter := bson.D{{Key: "email", Value: request.Email}}
+	// The labels are replaced as a whole, the users without labels have no labels field
+	newUser := bson.M{"$set": bson.M{"email": request.Email, "normalizedEmail": strings.ToLower(request.Email)}}
+	if len(request.User.Labels) > 0 {
+		newUser["$set"].(bson.M)["labels"] = request.User.Labels
+	} else {
+		newUser["$unset"] = bson.M{"labels": ""}
+	}
 
-	newUser := bson.M{"$set": bson.M{"email": request.Email}}
-	response, err := collection.UpdateOne(ctx, filter, newUser)
+	var response *mongo.UpdateResult
+	err = service.withCausallyConsistentSession(ctx, client, func(sessionCtx mongo.SessionContext) (err error) {
+		response, err = collection.UpdateOne(sessionCtx, filter, service.withUpdateMetadata(ctx, newUser))
 
+		return
+	})
 	if err != nil {
-		return nil, commonErrors.NewUnknownErrorWithError("failed to update user", err)
+		return nil, newOperationError(ctx, "failed to update user", err)
 	}
 
 	if response.MatchedCount == 0 {
 		return nil, commonErrors.NewNotFoundError()
 	}
 
-	_, userID, err := service.readUser(ctx, &repository.ReadUserRequest{Email: request.Email})
+	readUserResponse, userID, err := service.readUser(ctx, &repository.ReadUserRequest{Email: request.Email}, repository.GetTenant(ctx), false)
 	if err != nil {
 		return nil, err
 	}
 
 	return &repository.UpdateUserResponse{
-		User:   request.User,
+		User:   readUserResponse.User,
 		Cursor: userID,
 	}, nil
 }
 
-// DeleteUser delete an existing user
-// context: Optional The reference to the context
-// request: Mandatory. The request to delete an existing user
-// Returns either the result of deleting an existing user or error if something goes wrong.
-func (service *mongodbRepositoryService) DeleteUser(
+// ChangeEmail changes the email address of an existing user, the user keeps its ID. The new email address is not
+// verified, so the verification of the previous one is cleared.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the current and the new email addresses of the user
+// Returns either the user after its email address is changed or error if something goes wrong.
+func (service *mongodbRepositoryService) ChangeEmail(
 	ctx context.Context,
-	request *repository.DeleteUserRequest) (*repository.DeleteUserResponse, error) {
-	client, collection, err := service.createClientAndCollection(ctx)
+	request *repository.ChangeEmailRequest) (*repository.ChangeEmailResponse, error) {
+	update := bson.M{
+		"$set":   bson.M{"email": request.NewEmail, "normalizedEmail": strings.ToLower(request.NewEmail)},
+		"$unset": bson.M{"emailVerified": "", "emailVerificationTokenHash": "", "emailVerificationExpiresAt": ""},
+	}
+
+	user, err := service.findAndUpdateUser(
+		ctx,
+		request.Email,
+		notDeletedUserFilter(request.Email, repository.GetTenant(ctx)),
+		service.withUpdateMetadata(ctx, update),
+		commonErrors.NewNotFoundError())
 	if err != nil {
 		return nil, err
 	}
 
-	defer disconnect(ctx, client)
+	return &repository.ChangeEmailResponse{
+		User:   mapUser(user),
+		Cursor: user.ID.Hex(),
+	}, nil
+}
 
-	filter := bson.D{{Key: "email", Value: request.Email}}
-	response, err := collection.DeleteOne(ctx, filter)
+// ReadUserPreferences reads the preferences of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the preferences of an existing user
+// Returns either the preferences of the user or error if something goes wrong.
+func (service *mongodbRepositoryService) ReadUserPreferences(
+	ctx context.Context,
+	request *repository.ReadUserPreferencesRequest) (*repository.ReadUserPreferencesResponse, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
 	if err != nil {
-		return nil, commonErrors.NewUnknownErrorWithError("failed to delete user", err)
+		return nil, err
 	}
 
-	if response.DeletedCount == 0 {
+	defer disconnect(client)
+
+	var user user
+	err = service.withCausallyConsistentSession(ctx, client, func(sessionCtx mongo.SessionContext) error {
+		return collection.
+			FindOne(sessionCtx, notDeletedUserFilter(request.Email, repository.GetReadTenant(ctx)), options.FindOne().SetProjection(bson.M{"preferences": 1})).
+			Decode(&user)
+	})
+	if err == mongo.ErrNoDocuments {
 		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, newOperationError(ctx, "failed to retrieve user preferences", err)
 	}
 
-	return &repository.DeleteUserResponse{}, nil
+	return &repository.ReadUserPreferencesResponse{
+		Preferences: getPreferences(user),
+	}, nil
 }
 
-// ReadUser read an existing user
+// UpdateUserPreferences merges the preferences into the preferences of an existing user. The preferences are set
+// and removed field by field, so the concurrent updates of the different preferences do not overwrite each other.
 // ctx: Mandatory The reference to the context
-// request: Mandatory. The request to read an existing user
-// Returns either the result of reading an existing user or error if something goes wrong.
-func (service *mongodbRepositoryService) readUser(
+// request: Mandatory. The request contains the preferences to set and the keys of the preferences to remove
+// Returns either the preferences of the user after they are merged or error if something goes wrong.
+func (service *mongodbRepositoryService) UpdateUserPreferences(
 	ctx context.Context,
-	request *repository.ReadUserRequest) (*repository.ReadUserResponse, string, error) {
+	request *repository.UpdateUserPreferencesRequest) (*repository.UpdateUserPreferencesResponse, error) {
+	if len(request.Preferences) == 0 && len(request.RemovedKeys) == 0 {
+		response, err := service.ReadUserPreferences(ctx, &repository.ReadUserPreferencesRequest{Email: request.Email})
+		if err != nil {
+			return nil, err
+		}
+
+		return &repository.UpdateUserPreferencesResponse{Preferences: response.Preferences}, nil
+	}
+
 	client, collection, err := service.createClientAndCollection(ctx)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
-	defer disconnect(ctx, client)
+	defer disconnect(client)
 
-	filter := bson.D{{Key: "email", Value: request.Email}}
-	var user user
+	update := bson.M{}
+	if len(request.Preferences) > 0 {
+		set := bson.M{}
+		for key, value := range request.Preferences {
+			set["preferences."+key] = value
+		}
+
+		update["$set"] = set
+	}
+
+	if len(request.RemovedKeys) > 0 {
+		unset := bson.M{}
+		for _, key := range request.RemovedKeys {
+			unset["preferences."+key] = ""
+		}
+
+		update["$unset"] = unset
+	}
 
-	result := collection.FindOne(ctx, filter)
-	err = result.Decode(&user)
+	var user user
+	err = service.withCausallyConsistentSession(ctx, client, func(sessionCtx mongo.SessionContext) error {
+		return collection.
+			FindOneAndUpdate(
+				sessionCtx,
+				notDeletedUserFilter(request.Email, repository.GetTenant(ctx)),
+				service.withUpdateMetadata(ctx, update),
+				options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(bson.M{"preferences": 1})).
+			Decode(&user)
+	})
 	if err == mongo.ErrNoDocuments {
-		return nil, "", commonErrors.NewNotFoundError()
+		return nil, commonErrors.NewNotFoundError()
 	} else if err != nil {
-		return nil, "", commonErrors.NewUnknownErrorWithError("failed to retrieve user", err)
+		return nil, newOperationError(ctx, "failed to update user preferences", err)
 	}
 
-	var userBson bson.M
+	return &repository.UpdateUserPreferencesResponse{
+		Preferences: getPreferences(user),
+	}, nil
+}
 
-	err = result.Decode(&userBson)
+// AddUserToTenant adds the membership in the tenant to an existing user. The user is only matched if it is not a
+// member of the tenant yet, so the concurrent requests to add the user to the same tenant can not both succeed.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the membership to add
+// Returns either the user after the membership is added or error if something goes wrong.
+func (service *mongodbRepositoryService) AddUserToTenant(
+	ctx context.Context,
+	request *repository.AddUserToTenantRequest) (*repository.AddUserToTenantResponse, error) {
+	filter := append(
+		notDeletedUserFilter(request.Email, repository.GetTenant(ctx)),
+		bson.E{Key: "memberships.tenantID", Value: bson.M{"$ne": request.Membership.TenantID}})
+
+	update := bson.M{"$push": bson.M{"memberships": membership{
+		TenantID: request.Membership.TenantID,
+		Role:     request.Membership.Role,
+		JoinedAt: request.Membership.JoinedAt,
+	}}}
+
+	user, err := service.findAndUpdateUser(ctx, request.Email, filter, service.withUpdateMetadata(ctx, update), commonErrors.NewAlreadyExistsError())
 	if err != nil {
-		return nil, "", commonErrors.NewUnknownErrorWithError("failed to load user bson data", err)
+		return nil, err
 	}
 
-	userID := userBson["_id"].(primitive.ObjectID).Hex()
+	return &repository.AddUserToTenantResponse{
+		User:   mapUser(user),
+		Cursor: user.ID.Hex(),
+	}, nil
+}
 
-	return &repository.ReadUserResponse{
-		User: models.User{},
-	}, userID, nil
+// RemoveUserFromTenant removes the membership in the tenant from an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the tenant to remove the membership of
+// Returns either the user after the membership is removed or error if something goes wrong.
+func (service *mongodbRepositoryService) RemoveUserFromTenant(
+	ctx context.Context,
+	request *repository.RemoveUserFromTenantRequest) (*repository.RemoveUserFromTenantResponse, error) {
+	filter := append(
+		notDeletedUserFilter(request.Email, repository.GetTenant(ctx)),
+		bson.E{Key: "memberships.tenantID", Value: request.TenantID})
+
+	update := bson.M{"$pull": bson.M{"memberships": bson.M{"tenantID": request.TenantID}}}
+
+	user, err := service.findAndUpdateUser(ctx, request.Email, filter, service.withUpdateMetadata(ctx, update), commonErrors.NewNotFoundError())
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.RemoveUserFromTenantResponse{
+		User:   mapUser(user),
+		Cursor: user.ID.Hex(),
+	}, nil
 }
 
-func (service *mongodbRepositoryService) createClientAndCollection(ctx context.Context) (*mongo.Client, *mongo.Collection, error) {
-	clientOptions := options.Client().ApplyURI(service.connectionString)
-	client, err := mongo.Connect(ctx, clientOptions)
+// SetEmailVerificationToken sets the pending email verification token of an existing user, replacing the token sent
+// before if any
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the hash of the token and the time it expires at
+// Returns either the user after the token is set or error if something goes wrong.
+func (service *mongodbRepositoryService) SetEmailVerificationToken(
+	ctx context.Context,
+	request *repository.SetEmailVerificationTokenRequest) (*repository.SetEmailVerificationTokenResponse, error) {
+	update := bson.M{"$set": bson.M{
+		"emailVerificationTokenHash": request.TokenHash,
+		"emailVerificationExpiresAt": request.ExpiresAt,
+	}}
+
+	user, err := service.findAndUpdateUser(
+		ctx,
+		request.Email,
+		notDeletedUserFilter(request.Email, repository.GetTenant(ctx)),
+		service.withUpdateMetadata(ctx, update),
+		commonErrors.NewNotFoundError())
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.SetEmailVerificationTokenResponse{
+		User:   mapUser(user),
+		Cursor: user.ID.Hex(),
+	}, nil
+}
+
+// VerifyEmail marks the email address of an existing user as verified and clears the pending verification token.
+// The user is only matched if the token is still the pending verification token, so a token replaced by a newer
+// verification email can not be used anymore.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the hash of the pending verification token
+// Returns either the user after the email address is verified or error if something goes wrong.
+func (service *mongodbRepositoryService) VerifyEmail(
+	ctx context.Context,
+	request *repository.VerifyEmailRequest) (*repository.VerifyEmailResponse, error) {
+	filter := append(
+		notDeletedUserFilter(request.Email, repository.GetTenant(ctx)),
+		bson.E{Key: "emailVerificationTokenHash", Value: request.TokenHash})
+
+	update := bson.M{
+		"$set":   bson.M{"emailVerified": true},
+		"$unset": bson.M{"emailVerificationTokenHash": "", "emailVerificationExpiresAt": ""},
+	}
+
+	user, err := service.findAndUpdateUser(ctx, request.Email, filter, service.withUpdateMetadata(ctx, update), commonErrors.NewNotFoundError())
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.VerifyEmailResponse{
+		User:   mapUser(user),
+		Cursor: user.ID.Hex(),
+	}, nil
+}
+
+// RecordLoginAttempt records the outcome of a login attempt of an existing user. The update is an aggregation
+// pipeline, so the user is locked based on the number of the failed attempts in the same atomic update that
+// increments it, and concurrent failed attempts can not skip the lockout threshold.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the outcome of the attempt and the lockout threshold
+// Returns either the user after the attempt is recorded or error if something goes wrong.
+func (service *mongodbRepositoryService) RecordLoginAttempt(
+	ctx context.Context,
+	request *repository.RecordLoginAttemptRequest) (*repository.RecordLoginAttemptResponse, error) {
+	locked := bson.M{"$ne": bson.A{bson.M{"$ifNull": bson.A{"$lockedAt", nil}}, nil}}
+
+	var update bson.A
+	if request.Succeeded {
+		update = bson.A{
+			bson.M{"$set": bson.M{"failedLoginAttempts": bson.M{"$cond": bson.A{locked, "$failedLoginAttempts", 0}}}},
+		}
+	} else {
+		update = bson.A{
+			bson.M{"$set": bson.M{"failedLoginAttempts": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$failedLoginAttempts", 0}}, 1}}}},
+		}
+
+		// A zero threshold disables the lockout, so the failed attempts are only counted
+		if request.LockoutThreshold > 0 {
+			update = append(update, bson.M{"$set": bson.M{"lockedAt": bson.M{"$cond": bson.A{
+				bson.M{"$and": bson.A{
+					bson.M{"$not": bson.A{locked}},
+					bson.M{"$gte": bson.A{"$failedLoginAttempts", request.LockoutThreshold}},
+				}},
+				request.AttemptedAt,
+				"$lockedAt",
+			}}}})
+		}
+	}
+
+	user, err := service.findAndUpdateUser(
+		ctx,
+		request.Email,
+		notDeletedUserFilter(request.Email, repository.GetTenant(ctx)),
+		update,
+		commonErrors.NewNotFoundError())
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.RecordLoginAttemptResponse{
+		User:   mapUser(user),
+		Cursor: user.ID.Hex(),
+	}, nil
+}
+
+// UnlockUser unlocks an existing user and resets the number of its failed login attempts
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to unlock an existing user
+// Returns either the user after it is unlocked or error if something goes wrong.
+func (service *mongodbRepositoryService) UnlockUser(
+	ctx context.Context,
+	request *repository.UnlockUserRequest) (*repository.UnlockUserResponse, error) {
+	update := bson.M{"$unset": bson.M{"failedLoginAttempts": "", "lockedAt": ""}}
+
+	user, err := service.findAndUpdateUser(
+		ctx,
+		request.Email,
+		notDeletedUserFilter(request.Email, repository.GetTenant(ctx)),
+		service.withUpdateMetadata(ctx, update),
+		commonErrors.NewNotFoundError())
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.UnlockUserResponse{
+		User:   mapUser(user),
+		Cursor: user.ID.Hex(),
+	}, nil
+}
+
+// AddMFAMethod adds the MFA method to an existing user. The user is only matched if it has not enrolled the same
+// credential yet, so the concurrent requests to enroll the same credential can not both succeed.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the method to add
+// Returns either the user after the method is added or error if something goes wrong.
+func (service *mongodbRepositoryService) AddMFAMethod(
+	ctx context.Context,
+	request *repository.AddMFAMethodRequest) (*repository.AddMFAMethodResponse, error) {
+	credentialKey := request.Method.CredentialKey()
+	filter := append(
+		notDeletedUserFilter(request.Email, repository.GetTenant(ctx)),
+		bson.E{Key: "mfaMethods.credentialKey", Value: bson.M{"$ne": credentialKey}})
+
+	update := bson.M{"$push": bson.M{"mfaMethods": mfaMethod{
+		MethodID:             request.Method.MethodID,
+		Type:                 string(request.Method.Type),
+		Name:                 request.Method.Name,
+		CredentialKey:        credentialKey,
+		TOTPSecretRef:        request.Method.TOTPSecretRef,
+		WebAuthnCredentialID: request.Method.WebAuthnCredentialID,
+		RecoveryCodeHashes:   request.Method.RecoveryCodeHashes,
+		EnrolledAt:           request.Method.EnrolledAt,
+	}}}
+
+	user, err := service.findAndUpdateUser(ctx, request.Email, filter, service.withUpdateMetadata(ctx, update), commonErrors.NewAlreadyExistsError())
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.AddMFAMethodResponse{
+		User:   mapUser(user),
+		Cursor: user.ID.Hex(),
+	}, nil
+}
+
+// RemoveMFAMethod removes the MFA method from an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the ID of the method to remove
+// Returns either the user after the method is removed or error if something goes wrong.
+func (service *mongodbRepositoryService) RemoveMFAMethod(
+	ctx context.Context,
+	request *repository.RemoveMFAMethodRequest) (*repository.RemoveMFAMethodResponse, error) {
+	filter := append(
+		notDeletedUserFilter(request.Email, repository.GetTenant(ctx)),
+		bson.E{Key: "mfaMethods.methodID", Value: request.MethodID})
+
+	update := bson.M{"$pull": bson.M{"mfaMethods": bson.M{"methodID": request.MethodID}}}
+
+	user, err := service.findAndUpdateUser(ctx, request.Email, filter, service.withUpdateMetadata(ctx, update), commonErrors.NewNotFoundError())
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.RemoveMFAMethodResponse{
+		User:   mapUser(user),
+		Cursor: user.ID.Hex(),
+	}, nil
+}
+
+// SetUserAvatar sets the avatar of an existing user, replacing the avatar set before if any
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the key and the hash of the stored avatar image
+// Returns either the user after the avatar is set or error if something goes wrong.
+func (service *mongodbRepositoryService) SetUserAvatar(
+	ctx context.Context,
+	request *repository.SetUserAvatarRequest) (*repository.SetUserAvatarResponse, error) {
+	update := bson.M{"$set": bson.M{"avatar": avatar{
+		ObjectKey:   request.Avatar.ObjectKey,
+		ContentHash: request.Avatar.ContentHash,
+	}}}
+
+	user, err := service.findAndUpdateUser(
+		ctx,
+		request.Email,
+		notDeletedUserFilter(request.Email, repository.GetTenant(ctx)),
+		service.withUpdateMetadata(ctx, update),
+		commonErrors.NewNotFoundError())
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.SetUserAvatarResponse{
+		User:   mapUser(user),
+		Cursor: user.ID.Hex(),
+	}, nil
+}
+
+// DeleteUser delete an existing user
+// context: Optional The reference to the context
+// request: Mandatory. The request to delete an existing user
+// Returns either the result of deleting an existing user or error if something goes wrong.
+func (service *mongodbRepositoryService) DeleteUser(
+	ctx context.Context,
+	request *repository.DeleteUserRequest) (*repository.DeleteUserResponse, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(client)
+
+	filter := notDeletedUserFilter(request.Email, repository.GetTenant(ctx))
+
+	var affectedCount int64
+	err = service.withCausallyConsistentSession(ctx, client, func(sessionCtx mongo.SessionContext) error {
+		if request.SoftDelete {
+			response, err := collection.UpdateOne(
+				sessionCtx,
+				filter,
+				service.withUpdateMetadata(ctx, bson.M{"$set": bson.M{"deletedAt": service.clockService.Now()}}))
+			if err != nil {
+				return err
+			}
+
+			affectedCount = response.MatchedCount
+
+			return nil
+		}
+
+		response, err := collection.DeleteOne(sessionCtx, filter)
+		if err != nil {
+			return err
+		}
+
+		affectedCount = response.DeletedCount
+
+		return nil
+	})
 	if err != nil {
-		return nil, nil, commonErrors.NewUnknownErrorWithError("could not connect to mongodb database", err)
+		return nil, newOperationError(ctx, "failed to delete user", err)
+	}
+
+	if affectedCount == 0 {
+		return nil, commonErrors.NewNotFoundError()
+	}
+
+	return &repository.DeleteUserResponse{}, nil
+}
+
+// RestoreUser restores an existing soft deleted user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to restore an existing soft deleted user
+// Returns either the result of restoring the user or error if something goes wrong.
+func (service *mongodbRepositoryService) RestoreUser(
+	ctx context.Context,
+	request *repository.RestoreUserRequest) (*repository.RestoreUserResponse, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(client)
+
+	filter := bson.D{
+		{Key: "email", Value: request.Email},
+		{Key: "deletedAt", Value: bson.M{"$ne": nil}},
+	}
+
+	if tenantID := repository.GetTenant(ctx); tenantID != "" {
+		filter = append(filter, bson.E{Key: "tenantID", Value: tenantID})
+	}
+
+	var response *mongo.UpdateResult
+	err = service.withCausallyConsistentSession(ctx, client, func(sessionCtx mongo.SessionContext) (err error) {
+		response, err = collection.UpdateOne(sessionCtx, filter, service.withUpdateMetadata(ctx, bson.M{"$unset": bson.M{"deletedAt": ""}}))
+
+		return
+	})
+	if err != nil {
+		return nil, newOperationError(ctx, "failed to restore user", err)
+	}
+
+	if response.MatchedCount == 0 {
+		return nil, commonErrors.NewNotFoundError()
+	}
+
+	readUserResponse, userID, err := service.readUser(ctx, &repository.ReadUserRequest{Email: request.Email}, repository.GetTenant(ctx), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.RestoreUserResponse{
+		User:   readUserResponse.User,
+		Cursor: userID,
+	}, nil
+}
+
+// PurgeDeletedUsers permanently deletes the soft deleted users that are deleted before the given time
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to purge the soft deleted users
+// Returns either the result of purging the users or error if something goes wrong.
+func (service *mongodbRepositoryService) PurgeDeletedUsers(
+	ctx context.Context,
+	request *repository.PurgeDeletedUsersRequest) (*repository.PurgeDeletedUsersResponse, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(client)
+
+	filter := bson.M{"deletedAt": bson.M{"$lt": request.DeletedBefore}}
+	if tenantID := repository.GetTenant(ctx); tenantID != "" {
+		filter["tenantID"] = tenantID
+	}
+
+	var response *mongo.DeleteResult
+	err = service.withCausallyConsistentSession(ctx, client, func(sessionCtx mongo.SessionContext) (err error) {
+		response, err = collection.DeleteMany(sessionCtx, filter)
+
+		return
+	})
+	if err != nil {
+		return nil, newOperationError(ctx, "failed to purge deleted users", err)
+	}
+
+	return &repository.PurgeDeletedUsersResponse{
+		PurgedCount: response.DeletedCount,
+	}, nil
+}
+
+// ListUsersByLabel lists all the users tagged with the given test label, including the soft deleted ones
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to list the users tagged with the test label
+// Returns either the users tagged with the test label or error if something goes wrong.
+func (service *mongodbRepositoryService) ListUsersByLabel(
+	ctx context.Context,
+	request *repository.ListUsersByLabelRequest) (*repository.ListUsersByLabelResponse, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(client)
+
+	filter := bson.M{"email": bson.M{"$regex": models.GetTestLabelEmailPattern(request.Label)}}
+	if tenantID := repository.GetTenant(ctx); tenantID != "" {
+		filter["tenantID"] = tenantID
+	}
+
+	users := []models.UserWithCursor{}
+	err = service.withCausallyConsistentSession(ctx, client, func(sessionCtx mongo.SessionContext) error {
+		cursor, err := collection.Find(sessionCtx, filter)
+		if err != nil {
+			return newOperationError(ctx, "failed to list users by label", err)
+		}
+
+		defer func() {
+			_ = cursor.Close(sessionCtx)
+		}()
+
+		for cursor.Next(sessionCtx) {
+			var user user
+			if err = cursor.Decode(&user); err != nil {
+				return newOperationError(ctx, "failed to decode user", err)
+			}
+
+			users = append(users, mapUserWithCursor(user))
+		}
+
+		if err = cursor.Err(); err != nil {
+			return newOperationError(ctx, "failed to list users by label", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.ListUsersByLabelResponse{
+		Users: users,
+	}, nil
+}
+
+// PurgeUsersByLabel permanently deletes all the users tagged with the given test label, including the soft deleted ones
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to purge the users tagged with the test label
+// Returns either the result of purging the users or error if something goes wrong.
+func (service *mongodbRepositoryService) PurgeUsersByLabel(
+	ctx context.Context,
+	request *repository.PurgeUsersByLabelRequest) (*repository.PurgeUsersByLabelResponse, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(client)
+
+	filter := bson.M{"email": bson.M{"$regex": models.GetTestLabelEmailPattern(request.Label)}}
+	if tenantID := repository.GetTenant(ctx); tenantID != "" {
+		filter["tenantID"] = tenantID
+	}
+
+	var response *mongo.DeleteResult
+	err = service.withCausallyConsistentSession(ctx, client, func(sessionCtx mongo.SessionContext) (err error) {
+		response, err = collection.DeleteMany(sessionCtx, filter)
+
+		return
+	})
+	if err != nil {
+		return nil, newOperationError(ctx, "failed to purge users by label", err)
+	}
+
+	return &repository.PurgeUsersByLabelResponse{
+		PurgedCount: response.DeletedCount,
+	}, nil
+}
+
+// Search returns the list of users that matched the criteria. Only the users on the requested page are read, the
+// pagination is pushed into the queries.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria
+// Returns either the requested page of the users that matched the criteria or error if something goes wrong.
+// ArgumentError is returned if the After or Before cursor does not identify a user.
+func (service *mongodbRepositoryService) Search(
+	ctx context.Context,
+	request *repository.SearchRequest) (*repository.SearchResponse, error) {
+	if err := validateSortingOptions(request.SortingOptions); err != nil {
+		return nil, err
+	}
+
+	client, collection, err := service.createClientAndReadCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(client)
+
+	filter, findOptions := service.createSearchQuery(
+		ctx,
+		collection,
+		request.Emails,
+		request.SortingOptions,
+		request.IncludeDeleted,
+		searchFilter{labelSelector: request.LabelSelector, query: request.Query, fields: request.Fields})
+
+	// The users are ranked by how well they match the free-text query unless they are explicitly sorted
+	rankingQuery := ""
+	if len(request.SortingOptions) == 0 {
+		rankingQuery = request.Query
+	}
+
+	page := newSearchPage(collection, filter, findOptions, rankingQuery)
+
+	var response *repository.SearchResponse
+	err = service.withCausallyConsistentSession(ctx, client, func(sessionCtx mongo.SessionContext) (err error) {
+		response, err = page.read(sessionCtx, request.Pagination)
+
+		return
+	})
+	if err != nil {
+		if commonErrors.IsUnknownError(err) || commonErrors.IsArgumentError(err) || repository.IsDeadlineExceededError(err) {
+			return nil, err
+		}
+
+		return nil, newOperationError(ctx, "failed to search users", err)
+	}
+
+	return response, nil
+}
+
+// StreamSearch sends the users that matched the criteria one by one without loading all of them in memory
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria and the function to send the users to
+// Returns either the number of the sent users or error if something goes wrong.
+func (service *mongodbRepositoryService) StreamSearch(
+	ctx context.Context,
+	request *repository.StreamSearchRequest) (*repository.StreamSearchResponse, error) {
+	streamFilter := searchFilter{
+		tenantID:      request.TenantID,
+		createdAfter:  request.CreatedAfter,
+		createdBefore: request.CreatedBefore,
+	}
+
+	if request.After != "" {
+		after, err := primitive.ObjectIDFromHex(request.After)
+		if err != nil {
+			return nil, commonErrors.NewArgumentError("request", fmt.Sprintf("invalid cursor %s", request.After))
+		}
+
+		streamFilter.after = &after
+	}
+
+	if err := validateSortingOptions(request.SortingOptions); err != nil {
+		return nil, err
+	}
+
+	client, collection, err := service.createClientAndReadCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(client)
+
+	filter, findOptions := service.createSearchQuery(ctx, collection, request.Emails, request.SortingOptions, request.IncludeDeleted, streamFilter)
+
+	var sentCount int64
+	err = service.withCausallyConsistentSession(ctx, client, func(sessionCtx mongo.SessionContext) error {
+		cursor, err := collection.Find(sessionCtx, filter, findOptions)
+		if err != nil {
+			return newOperationError(ctx, "failed to search users", err)
+		}
+
+		defer func() {
+			_ = cursor.Close(sessionCtx)
+		}()
+
+		// The cursor only fetches the next batch once the current one is consumed, so a slow receiver holds
+		// the reading back instead of the users piling up in memory
+		for cursor.Next(sessionCtx) {
+			var user user
+			if err = cursor.Decode(&user); err != nil {
+				return newOperationError(ctx, "failed to decode user", err)
+			}
+
+			if err = request.Send(mapUserWithCursor(user)); err != nil {
+				return newOperationError(ctx, "failed to send user", err)
+			}
+
+			sentCount++
+		}
+
+		if err = cursor.Err(); err != nil {
+			return newOperationError(ctx, "failed to search users", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		if commonErrors.IsUnknownError(err) || repository.IsDeadlineExceededError(err) {
+			return nil, err
+		}
+
+		return nil, newOperationError(ctx, "failed to search users", err)
+	}
+
+	return &repository.StreamSearchResponse{
+		SentCount: sentCount,
+	}, nil
+}
+
+// ReadUser read an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read an existing user
+// tenantID: Optional. The tenant the user must belong to, the user is matched whatever its tenant is if empty
+// readFromReplicas: Mandatory. Whether the user is read with the configured read preference, false if the read is
+// part of a mutation
+// Returns either the result of reading an existing user or error if something goes wrong.
+func (service *mongodbRepositoryService) readUser(
+	ctx context.Context,
+	request *repository.ReadUserRequest,
+	tenantID string,
+	readFromReplicas bool) (*repository.ReadUserResponse, string, error) {
+	filter := notDeletedUserFilter(request.Email, tenantID)
+	if request.UserID != "" {
+		objectID, err := primitive.ObjectIDFromHex(request.UserID)
+		if err != nil {
+			// The IDs that are not document IDs can not be the ID of any user
+			return nil, "", commonErrors.NewNotFoundError()
+		}
+
+		filter = notDeletedUserIDFilter(objectID, tenantID)
+		if request.IncludeDeleted {
+			filter = userIDFilter(objectID, tenantID)
+		}
+	}
+
+	createClientAndCollection := service.createClientAndCollection
+	if readFromReplicas {
+		createClientAndCollection = service.createClientAndReadCollection
+	}
+
+	client, collection, err := createClientAndCollection(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer disconnect(client)
+
+	var user user
+
+	var result *mongo.SingleResult
+	err = service.withCausallyConsistentSession(ctx, client, func(sessionCtx mongo.SessionContext) error {
+		findOneOptions := options.FindOne()
+		if projection := createProjection(request.Fields); projection != nil {
+			findOneOptions.SetProjection(projection)
+		}
+
+		result = collection.FindOne(sessionCtx, filter, findOneOptions)
+
+		return result.Err()
+	})
+	if err == nil {
+		err = result.Decode(&user)
+	}
+
+	if err == mongo.ErrNoDocuments {
+		return nil, "", commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, "", newOperationError(ctx, "failed to retrieve user", err)
+	}
+
+	var userBson bson.M
+
+	err = result.Decode(&userBson)
+	if err != nil {
+		return nil, "", newOperationError(ctx, "failed to load user bson data", err)
+	}
+
+	userID := userBson["_id"].(primitive.ObjectID).Hex()
+
+	return &repository.ReadUserResponse{
+		Email: user.Email,
+		User:  mapUser(user),
+	}, userID, nil
+}
+
+// findAndUpdateUser applies the update to the user that matched the filter in a single operation
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// filter: Mandatory. The filter that matches the user only if the update can be applied to it
+// update: Mandatory. The update to apply to the user
+// conditionErr: Mandatory. The error to return if the user exists but the filter did not match it
+// Returns either the user after the update or error if something goes wrong
+func (service *mongodbRepositoryService) findAndUpdateUser(
+	ctx context.Context,
+	email string,
+	filter bson.D,
+	update interface{},
+	conditionErr error) (user, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return user{}, err
+	}
+
+	defer disconnect(client)
+
+	var updatedUser user
+	err = service.withCausallyConsistentSession(ctx, client, func(sessionCtx mongo.SessionContext) error {
+		return collection.
+			FindOneAndUpdate(sessionCtx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).
+			Decode(&updatedUser)
+	})
+	if err == mongo.ErrNoDocuments {
+		// The filter did not match either because the user does not exist or because of the condition on the user
+		if _, _, err = service.readUser(ctx, &repository.ReadUserRequest{Email: email}, repository.GetTenant(ctx), false); err != nil {
+			return user{}, err
+		}
+
+		return user{}, conditionErr
+	} else if mongo.IsDuplicateKeyError(err) {
+		// Only changing the email address of the user can make it collide with another user
+		return user{}, commonErrors.NewAlreadyExistsErrorWithError(err)
+	} else if err != nil {
+		return user{}, newOperationError(ctx, "failed to update user", err)
+	}
+
+	return updatedUser, nil
+}
+
+// withUpdateMetadata adds setting the time the user is updated at and the caller that updated it to the update
+// ctx: Mandatory The reference to the context
+// update: Mandatory. The update to apply to the user
+// Returns the update
+func (service *mongodbRepositoryService) withUpdateMetadata(ctx context.Context, update bson.M) bson.M {
+	set, ok := update["$set"].(bson.M)
+	if !ok {
+		set = bson.M{}
+		update["$set"] = set
+	}
+
+	set["updatedAt"] = service.clockService.Now()
+	set["updatedBy"] = repository.GetActor(ctx)
+
+	return update
+}
+
+// Ping verifies the repository can reach the underlying database
+// ctx: Mandatory The reference to the context
+// Returns error if the database is not reachable
+func (service *mongodbRepositoryService) Ping(ctx context.Context) error {
+	client, _, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(client)
+
+	if err = client.Ping(ctx, readpref.Primary()); err != nil {
+		return newOperationError(ctx, "could not ping mongodb database", err)
+	}
+
+	return nil
+}
+
+// migrate applies the migrations of the users collection that are not applied yet, e.g. creates the indexes the
+// repository relies on
+// ctx: Mandatory The reference to the context
+// Returns error if something goes wrong
+func (service *mongodbRepositoryService) migrate(ctx context.Context) error {
+	client, collection, err := service.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(client)
+
+	migrator, err := migrations.NewMigrator(collection, migrations.All())
+	if err != nil {
+		return err
+	}
+
+	if _, err = migrator.Up(ctx, 0); err != nil {
+		return newOperationError(ctx, "failed to migrate the users collection", err)
+	}
+
+	return nil
+}
+
+func (service *mongodbRepositoryService) createClientAndCollection(ctx context.Context) (*mongo.Client, *mongo.Collection, error) {
+	// No client is created for the calls whose deadline already passed, e.g. while waiting for an earlier operation
+	if err := ctx.Err(); err == context.DeadlineExceeded {
+		return nil, nil, repository.NewDeadlineExceededError("could not connect to mongodb database", err)
+	}
+
+	if service.startupCheck != nil {
+		if err := service.startupCheck.getError(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return service.connect(ctx)
+}
+
+// connect creates the client and the collection regardless of the startup check, so the startup check can migrate
+// the collection with it
+// ctx: Mandatory The reference to the context
+// Returns either the client and the collection or error if something goes wrong
+func (service *mongodbRepositoryService) connect(ctx context.Context) (*mongo.Client, *mongo.Collection, error) {
+	connectionString, err := service.getConnectionString()
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	clientOptions := options.Client().ApplyURI(connectionString)
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, nil, newOperationError(ctx, "could not connect to mongodb database", err)
+	}
+
+	// Causal consistency guarantees only hold if both the reads and the writes are majority acknowledged, which is
+	// the default unless the replica set options are configured otherwise
+	readConcern := service.replicaSet.readConcern
+	if readConcern == nil {
+		readConcern = readconcern.Majority()
+	}
+
+	writeConcern := service.replicaSet.writeConcern
+	if writeConcern == nil {
+		writeConcern = writeconcern.New(writeconcern.WMajority())
+	}
+
+	collectionOptions := options.Collection().
+		SetReadConcern(readConcern).
+		SetWriteConcern(writeConcern)
+
+	return client, client.Database(service.databaseName).Collection(service.databaseCollectionName, collectionOptions), nil
+}
+
+// validateSortingOptions verifies the search result can be sorted by all the given fields
+// sortingOptions: Optional. The sorting options to verify
+// Returns ArgumentError if sorting by any of the given fields is not supported
+func validateSortingOptions(sortingOptions []models.SortingOptionPair) error {
+	for _, sortingOption := range sortingOptions {
+		if !sortableFields[sortingOption.Name] {
+			return commonErrors.NewArgumentError("request", fmt.Sprintf("sorting by %s is not supported", sortingOption.Name))
+		}
+	}
+
+	return nil
+}
+
+// createSearchQuery creates the filter and the options to find the users that matched the criteria with
+// ctx: Mandatory The reference to the context
+// collection: Mandatory. The collection the users are stored in
+// emails: Optional. The email addresses to filter the users by
+// sortingOptions: Optional. The sorting options to apply
+// includeDeleted: Mandatory. Whether the soft deleted users should be matched as well
+// streamFilter: Optional. The additional criteria only some of the searches support
+// Returns the filter and the find options
+func (service *mongodbRepositoryService) createSearchQuery(
+	ctx context.Context,
+	collection *mongo.Collection,
+	emails []string,
+	sortingOptions []models.SortingOptionPair,
+	includeDeleted bool,
+	streamFilter searchFilter) (bson.M, *options.FindOptions) {
+	filter := bson.M{}
+	if len(emails) > 0 {
+		filter["email"] = bson.M{"$in": emails}
+	}
+
+	sort := bson.D{}
+	for _, sortingOption := range sortingOptions {
+		direction := 1
+		if sortingOption.Direction == models.Descending {
+			direction = -1
+		}
+
+		field := sortingOption.Name
+		if mappedField, ok := sortFields[field]; ok {
+			field = mappedField
+		}
+
+		sort = append(sort, bson.E{Key: field, Value: direction})
+	}
+
+	// Always sort by the document ID last so the position of the users, hence the cursors, are stable
+	sort = append(sort, bson.E{Key: "_id", Value: 1})
+
+	findOptions := options.Find().SetSort(sort)
+	if projection := createProjection(streamFilter.fields); projection != nil {
+		findOptions.SetProjection(projection)
+	}
+
+	filterShape := getFilterShape(filter)
+	indexHint := service.searchIndexHints[filterShape]
+	if indexHint != "" {
+		findOptions.SetHint(indexHint)
+	}
+
+	// The soft deleted users are excluded after the filter shape is taken, so the configured index hints
+	// keep matching the filters the callers supplied
+	if !includeDeleted {
+		filter["deletedAt"] = nil
+	}
+
+	if streamFilter.tenantID != "" {
+		filter["memberships.tenantID"] = streamFilter.tenantID
+	}
+
+	// The users of the other tenants are excluded after the filter shape is taken as well, as every search of the
+	// caller is scoped to the same tenant
+	if tenantID := repository.GetReadTenant(ctx); tenantID != "" {
+		filter["tenantID"] = tenantID
+	}
+
+	// The document IDs start with the time the user is created at, so the users are filtered by the creation time
+	// through the document ID, which also resumes streaming after the given cursor
+	idFilter := bson.M{}
+	if streamFilter.createdAfter != nil {
+		idFilter["$gte"] = primitive.NewObjectIDFromTimestamp(*streamFilter.createdAfter)
+	}
+
+	if streamFilter.createdBefore != nil {
+		idFilter["$lt"] = primitive.NewObjectIDFromTimestamp(*streamFilter.createdBefore)
+	}
+
+	if streamFilter.after != nil {
+		idFilter["$gt"] = *streamFilter.after
+	}
+
+	if len(idFilter) > 0 {
+		filter["_id"] = idFilter
+	}
+
+	// The requirements are combined with $and, as more than one requirement can match the same label
+	if len(streamFilter.labelSelector) > 0 {
+		labelFilters := bson.A{}
+		for _, requirement := range streamFilter.labelSelector {
+			labelFilters = append(labelFilters, createLabelFilter(requirement))
+		}
+
+		filter["$and"] = labelFilters
+	}
+
+	// The query is matched as a prefix of the email addresses in lower case, so it matches regardless of the case
+	// while the rest of the search still compares the strings as they are. The anchored regular expression is served
+	// by the normalized email index instead of scanning every email address.
+	if streamFilter.query != "" {
+		filter["normalizedEmail"] = bson.M{"$regex": "^" + regexp.QuoteMeta(strings.ToLower(streamFilter.query))}
+	}
+
+	if service.searchQueryPlanStatisticsEnabled {
+		recordQueryPlanStatistics(ctx, collection, filter, sort, filterShape, indexHint)
+	}
+
+	return filter, findOptions
+}
+
+// createProjection creates the projection that only reads the document fields the requested user fields are read from,
+// along the fields the users are identified and mapped by
+// fields: Optional. The names of the requested user fields
+// Returns the projection or nil if the whole documents are read
+func createProjection(fields []string) bson.M {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	projection := bson.M{"_id": 1, "email": 1, "deletedAt": 1, "createdAt": 1, "tenantID": 1}
+	for _, field := range fields {
+		for _, documentField := range projectionFields[field] {
+			projection[documentField] = 1
+		}
+	}
+
+	return projection
+}
+
+// createLabelFilter creates the filter the labels of the users must match to match the requirement of the label
+// selector
+// requirement: Mandatory. The requirement of the label selector
+// Returns the filter
+func createLabelFilter(requirement models.LabelSelectorRequirement) bson.M {
+	field := "labels." + requirement.Key
+
+	switch requirement.Operator {
+	case models.LabelSelectorOperatorIn:
+		return bson.M{field: bson.M{"$in": requirement.Values}}
+
+	case models.LabelSelectorOperatorNotIn:
+		return bson.M{field: bson.M{"$nin": requirement.Values}}
+
+	case models.LabelSelectorOperatorDoesNotExist:
+		return bson.M{field: bson.M{"$exists": false}}
+
+	default:
+		return bson.M{field: bson.M{"$exists": true}}
+	}
+}
+
+// mapUserWithCursor maps the stored user to the user with cursor returned by the search, the document ID is used as the cursor
+// user: Mandatory. The stored user
+// Returns the user with cursor
+func mapUserWithCursor(user user) models.UserWithCursor {
+	userID := user.ID.Hex()
+	mappedUser := mapUser(user)
+
+	return models.UserWithCursor{
+		UserID:    userID,
+		Email:     user.Email,
+		User:      mappedUser,
+		Cursor:    userID,
+		CreatedAt: *mappedUser.CreatedAt,
+		DeletedAt: user.DeletedAt,
+	}
+}
+
+// mapUser maps the stored user to the user model
+// user: Mandatory. The stored user
+// Returns the mapped user
+func mapUser(user user) models.User {
+	memberships := make([]models.TenantMembership, 0, len(user.Memberships))
+	for _, membership := range user.Memberships {
+		memberships = append(memberships, models.TenantMembership{
+			TenantID: membership.TenantID,
+			Role:     membership.Role,
+			JoinedAt: membership.JoinedAt,
+		})
+	}
+
+	// The users that never enrolled have no MFA methods rather than an empty list, like the users that are not locked
+	// have no lock time
+	var mfaMethods []models.MFAMethod
+	for _, method := range user.MFAMethods {
+		mfaMethods = append(mfaMethods, models.MFAMethod{
+			MethodID:             method.MethodID,
+			Type:                 models.MFAMethodType(method.Type),
+			Name:                 method.Name,
+			TOTPSecretRef:        method.TOTPSecretRef,
+			WebAuthnCredentialID: method.WebAuthnCredentialID,
+			RecoveryCodeHashes:   method.RecoveryCodeHashes,
+			EnrolledAt:           method.EnrolledAt,
+		})
+	}
+
+	var mappedAvatar *models.Avatar
+	if user.Avatar != nil {
+		mappedAvatar = &models.Avatar{
+			ObjectKey:   user.Avatar.ObjectKey,
+			ContentHash: user.Avatar.ContentHash,
+		}
+	}
+
+	// The users created before the creation time is stored were created at the time their document ID starts with
+	createdAt := user.CreatedAt
+	if createdAt == nil {
+		idTimestamp := user.ID.Timestamp()
+		createdAt = &idTimestamp
+	}
+
+	return models.User{
+		ID:                         user.ID.Hex(),
+		Memberships:                memberships,
+		EmailVerified:              user.EmailVerified,
+		EmailVerificationTokenHash: user.EmailVerificationTokenHash,
+		EmailVerificationExpiresAt: user.EmailVerificationExpiresAt,
+		FailedLoginAttempts:        user.FailedLoginAttempts,
+		LockedAt:                   user.LockedAt,
+		MFAMethods:                 mfaMethods,
+		Labels:                     user.Labels,
+		Avatar:                     mappedAvatar,
+		CreatedAt:                  createdAt,
+		UpdatedAt:                  user.UpdatedAt,
+		CreatedBy:                  user.CreatedBy,
+		UpdatedBy:                  user.UpdatedBy,
+		TenantID:                   user.TenantID,
+	}
+}
+
+// getPreferences returns the preferences of the user, the users that never set a preference have no preferences field
+// user: Mandatory. The stored user
+// Returns the preferences of the user
+func getPreferences(user user) map[string]string {
+	if user.Preferences == nil {
+		return map[string]string{}
+	}
+
+	return user.Preferences
+}
+
+// notDeletedUserFilter returns the filter that matches the user with the given email address unless it is soft deleted
+// or belongs to another tenant
+// email: Mandatory. The user email address
+// tenantID: Optional. The tenant the user must belong to, the user is matched whatever its tenant is if empty
+// Returns the filter
+func notDeletedUserFilter(email string, tenantID string) bson.D {
+	// Matching nil matches both the users that never got deleted and the ones restored since
+	filter := bson.D{
+		{Key: "email", Value: email},
+		{Key: "deletedAt", Value: nil},
+	}
+
+	if tenantID != "" {
+		filter = append(filter, bson.E{Key: "tenantID", Value: tenantID})
+	}
+
+	return filter
+}
+
+// notDeletedUserIDFilter returns the filter that matches the user with the given ID unless it is soft deleted or
+// belongs to another tenant
+// userID: Mandatory. The document ID of the user
+// tenantID: Optional. The tenant the user must belong to, the user is matched whatever its tenant is if empty
+// Returns the filter
+func notDeletedUserIDFilter(userID primitive.ObjectID, tenantID string) bson.D {
+	filter := bson.D{
+		{Key: "_id", Value: userID},
+		{Key: "deletedAt", Value: nil},
+	}
+
+	if tenantID != "" {
+		filter = append(filter, bson.E{Key: "tenantID", Value: tenantID})
+	}
+
+	return filter
+}
+
+// userIDFilter returns the filter that matches the user with the given ID, including the soft deleted one, unless it
+// belongs to another tenant
+// userID: Mandatory. The document ID of the user
+// tenantID: Optional. The tenant the user must belong to, the user is matched whatever its tenant is if empty
+// Returns the filter
+func userIDFilter(userID primitive.ObjectID, tenantID string) bson.D {
+	filter := bson.D{
+		{Key: "_id", Value: userID},
+	}
+
+	if tenantID != "" {
+		filter = append(filter, bson.E{Key: "tenantID", Value: tenantID})
+	}
+
+	return filter
+}
+
+// newOperationError creates the error the operation failed with. The operations fail with DeadlineExceededError once
+// the deadline of the context passed, whatever error the driver reported for it, and with UnknownError otherwise.
+// ctx: Mandatory The reference to the context the operation ran with
+// message: Mandatory. The description of the failure
+// err: Mandatory. The error the operation failed with
+// Returns the new error
+func newOperationError(ctx context.Context, message string, err error) error {
+	if ctx.Err() == context.DeadlineExceeded || errors.Is(err, context.DeadlineExceeded) {
+		return repository.NewDeadlineExceededError(message, err)
 	}
 
-	return client, client.Database(service.databaseName).Collection(service.databaseCollectionName), nil
+	return commonErrors.NewUnknownErrorWithError(message, err)
 }
 
-func disconnect(ctx context.Context, client *mongo.Client) {
-	_ = client.Disconnect(ctx)
+// disconnect disconnects the client without the context of the operation, so the connections are released even if
+// the deadline of the operation passed
+func disconnect(client *mongo.Client) {
+	_ = client.Disconnect(context.Background())
 }
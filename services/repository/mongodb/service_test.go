@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/decentralized-cloud/user/models"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
 	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	idGeneratorMock "github.com/decentralized-cloud/user/services/idgenerator/mock"
 	"github.com/decentralized-cloud/user/services/repository"
 	"github.com/decentralized-cloud/user/services/repository/mongodb"
 	"github.com/golang/mock/gomock"
@@ -16,6 +20,7 @@ import (
 	commonErrors "github.com/micro-business/go-core/system/errors"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func TestMongodbRepositoryService(t *testing.T) {
@@ -25,14 +30,19 @@ func TestMongodbRepositoryService(t *testing.T) {
 
 var _ = Describe("Mongodb Repository Service Tests", func() {
 	var (
-		mockCtrl      *gomock.Controller
-		sut           repository.RepositoryContract
-		ctx           context.Context
-		createRequest repository.CreateUserRequest
+		mockCtrl               *gomock.Controller
+		sut                    repository.RepositoryContract
+		ctx                    context.Context
+		createRequest          repository.CreateUserRequest
+		connectionString       string
+		mockClockService       *clockMock.MockClockContract
+		mockIDGeneratorService *idGeneratorMock.MockIDGeneratorContract
+		now                    time.Time
+		objectID               primitive.ObjectID
 	)
 
 	BeforeEach(func() {
-		connectionString := os.Getenv("DATABASE_CONNECTION_STRING")
+		connectionString = os.Getenv("DATABASE_CONNECTION_STRING")
 		if strings.Trim(connectionString, " ") == "" {
 			connectionString = "mongodb://mongodb:27017"
 		}
@@ -42,7 +52,8 @@ var _ = Describe("Mongodb Repository Service Tests", func() {
 		mockConfigurationService.
 			EXPECT().
 			GetDatabaseConnectionString().
-			Return(connectionString, nil)
+			Return(connectionString, nil).
+			AnyTimes()
 
 		mockConfigurationService.
 			EXPECT().
@@ -54,7 +65,57 @@ var _ = Describe("Mongodb Repository Service Tests", func() {
 			GetDatabaseCollectionName().
 			Return("user", nil)
 
-		sut, _ = mongodb.NewMongodbRepositoryService(mockConfigurationService)
+		mockConfigurationService.
+			EXPECT().
+			GetDatabaseSearchIndexHints().
+			Return(map[string]string{}, nil)
+
+		mockConfigurationService.
+			EXPECT().
+			GetDatabaseSearchQueryPlanStatisticsEnabled().
+			Return(true, nil)
+
+		mockConfigurationService.
+			EXPECT().
+			GetDatabaseReadPreference().
+			Return("secondaryPreferred", nil)
+
+		mockConfigurationService.
+			EXPECT().
+			GetDatabaseReadConcern().
+			Return("majority", nil)
+
+		mockConfigurationService.
+			EXPECT().
+			GetDatabaseWriteConcern().
+			Return("majority", nil)
+
+		mockConfigurationService.
+			EXPECT().
+			GetDatabaseStartupCheckEnabled().
+			Return(false, nil)
+
+		// The time is truncated to the precision the database persists it with
+		now = time.Now().UTC().Truncate(time.Millisecond)
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			DoAndReturn(func() time.Time { return now }).
+			AnyTimes()
+
+		mockIDGeneratorService = idGeneratorMock.NewMockIDGeneratorContract(mockCtrl)
+		mockIDGeneratorService.
+			EXPECT().
+			NewObjectID().
+			DoAndReturn(func() primitive.ObjectID {
+				objectID = primitive.NewObjectID()
+
+				return objectID
+			}).
+			AnyTimes()
+
+		sut, _ = mongodb.NewMongodbRepositoryService(mockConfigurationService, mockClockService, mockIDGeneratorService)
 		ctx = context.Background()
 		createRequest = repository.CreateUserRequest{
 			Email: cuid.New() + "@test.com",
@@ -72,7 +133,8 @@ var _ = Describe("Mongodb Repository Service Tests", func() {
 				mockConfigurationService.
 					EXPECT().
 					GetDatabaseConnectionString().
-					Return(cuid.New(), nil)
+					Return(connectionString, nil).
+					AnyTimes()
 
 				mockConfigurationService.
 					EXPECT().
@@ -84,11 +146,166 @@ var _ = Describe("Mongodb Repository Service Tests", func() {
 					GetDatabaseCollectionName().
 					Return(cuid.New(), nil)
 
-				service, err := mongodb.NewMongodbRepositoryService(mockConfigurationService)
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseSearchIndexHints().
+					Return(map[string]string{"email": cuid.New()}, nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseSearchQueryPlanStatisticsEnabled().
+					Return(false, nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseReadPreference().
+					Return("secondaryPreferred", nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseReadConcern().
+					Return("majority", nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseWriteConcern().
+					Return("majority", nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseStartupCheckEnabled().
+					Return(false, nil)
+
+				service, err := mongodb.NewMongodbRepositoryService(mockConfigurationService, mockClockService, mockIDGeneratorService)
 				Ω(err).Should(BeNil())
 				Ω(service).ShouldNot(BeNil())
 			})
 		})
+
+		When("the linearizable read concern is configured along with a read preference other than primary", func() {
+			It("should return error", func() {
+				mockConfigurationService := configurationMock.NewMockConfigurationContract(mockCtrl)
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseConnectionString().
+					Return(connectionString, nil).
+					AnyTimes()
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseName().
+					Return(cuid.New(), nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseCollectionName().
+					Return(cuid.New(), nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseSearchIndexHints().
+					Return(map[string]string{}, nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseSearchQueryPlanStatisticsEnabled().
+					Return(false, nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseReadPreference().
+					Return("secondaryPreferred", nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseReadConcern().
+					Return("linearizable", nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseWriteConcern().
+					Return("majority", nil)
+
+				service, err := mongodb.NewMongodbRepositoryService(mockConfigurationService, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+			})
+		})
+
+		When("the startup check is enabled and the database is unreachable", func() {
+			It("should instantiate the new RepositoryService that reports DependencyUnavailableError", func() {
+				mockConfigurationService := configurationMock.NewMockConfigurationContract(mockCtrl)
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseConnectionString().
+					Return("mongodb://127.0.0.1:1/?connectTimeoutMS=100&serverSelectionTimeoutMS=100", nil).
+					AnyTimes()
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseName().
+					Return(cuid.New(), nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseCollectionName().
+					Return(cuid.New(), nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseSearchIndexHints().
+					Return(map[string]string{}, nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseSearchQueryPlanStatisticsEnabled().
+					Return(false, nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseReadPreference().
+					Return("secondaryPreferred", nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseReadConcern().
+					Return("majority", nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseWriteConcern().
+					Return("majority", nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseStartupCheckEnabled().
+					Return(true, nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseStartupCheckInitialBackoff().
+					Return(10*time.Millisecond, nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseStartupCheckMaxBackoff().
+					Return(10*time.Millisecond, nil)
+
+				service, err := mongodb.NewMongodbRepositoryService(mockConfigurationService, mockClockService, mockIDGeneratorService)
+				Ω(err).Should(BeNil())
+
+				// The state reports the attempts made so far and the error the last attempt failed with
+				Eventually(func() int {
+					var dependencyUnavailableError repository.DependencyUnavailableError
+					Ω(errors.As(service.Ping(ctx), &dependencyUnavailableError)).Should(BeTrue())
+
+					return dependencyUnavailableError.Attempts
+				}).Should(BeNumerically(">", 0))
+
+				_, err = service.CreateUser(ctx, &createRequest)
+				Ω(repository.IsDependencyUnavailableError(err)).Should(BeTrue())
+			})
+		})
 	})
 
 	Context("user going to create a new user", func() {
@@ -96,7 +313,7 @@ var _ = Describe("Mongodb Repository Service Tests", func() {
 			It("should create the new user", func() {
 				response, err := sut.CreateUser(ctx, &createRequest)
 				Ω(err).Should(BeNil())
-				Ω(response.Cursor).ShouldNot(BeNil())
+				Ω(response.Cursor).Should(Equal(objectID.Hex()))
 				assertUser(response.User, createRequest.User)
 			})
 		})
@@ -112,6 +329,16 @@ var _ = Describe("Mongodb Repository Service Tests", func() {
 			email = createRequest.Email
 		})
 
+		When("user creates another user with the same email address", func() {
+			It("should return AlreadyExistsError", func() {
+				response, err := sut.CreateUser(ctx, &createRequest)
+				Ω(err).Should(HaveOccurred())
+				Ω(response).Should(BeNil())
+
+				Ω(commonErrors.IsAlreadyExistsError(err)).Should(BeTrue())
+			})
+		})
+
 		When("user reads a user by Id", func() {
 			It("should return a user", func() {
 				response, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
@@ -137,6 +364,194 @@ var _ = Describe("Mongodb Repository Service Tests", func() {
 			})
 		})
 
+		When("user reads the existing user right after every update while the reads prefer the secondaries", func() {
+			It("should always return the latest update", func() {
+				for revision := 0; revision < 20; revision++ {
+					label := strconv.Itoa(revision)
+					_, err := sut.UpdateUser(ctx, &repository.UpdateUserRequest{
+						Email: email,
+						User:  models.User{Labels: map[string]string{"revision": label}}})
+					Ω(err).Should(BeNil())
+
+					readResponse, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+					Ω(err).Should(BeNil())
+					Ω(readResponse.User.Labels).Should(HaveKeyWithValue("revision", label))
+				}
+			})
+		})
+
+		When("user updates the existing user on behalf of a caller", func() {
+			It("should record the time and the caller that created and last updated the user", func() {
+				actor := cuid.New() + "@test.com"
+				actorCtx := context.WithValue(ctx, models.ContextKeyParsedToken, models.ParsedToken{Email: actor})
+
+				_, err := sut.UpdateUser(actorCtx, &repository.UpdateUserRequest{Email: email, User: models.User{}})
+				Ω(err).Should(BeNil())
+
+				response, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(*response.User.CreatedAt).Should(BeTemporally("==", now))
+				Ω(*response.User.UpdatedAt).Should(BeTemporally("==", now))
+				Ω(response.User.CreatedBy).Should(BeEmpty())
+				Ω(response.User.UpdatedBy).Should(Equal(actor))
+			})
+		})
+
+		When("user adds the existing user to a tenant", func() {
+			It("should add the membership once and remove it once", func() {
+				membership := models.TenantMembership{
+					TenantID: cuid.New(),
+					Role:     models.TenantRoleMember,
+					JoinedAt: time.Now().UTC().Truncate(time.Millisecond),
+				}
+
+				addResponse, err := sut.AddUserToTenant(ctx, &repository.AddUserToTenantRequest{Email: email, Membership: membership})
+				Ω(err).Should(BeNil())
+				Ω(addResponse.User.Memberships).Should(HaveLen(1))
+				Ω(addResponse.User.Memberships[0].TenantID).Should(Equal(membership.TenantID))
+				Ω(addResponse.User.Memberships[0].Role).Should(Equal(membership.Role))
+				Ω(addResponse.User.Memberships[0].JoinedAt.Equal(membership.JoinedAt)).Should(BeTrue())
+
+				_, err = sut.AddUserToTenant(ctx, &repository.AddUserToTenantRequest{Email: email, Membership: membership})
+				Ω(commonErrors.IsAlreadyExistsError(err)).Should(BeTrue())
+
+				readResponse, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(readResponse.User.Memberships).Should(HaveLen(1))
+
+				removeResponse, err := sut.RemoveUserFromTenant(ctx, &repository.RemoveUserFromTenantRequest{Email: email, TenantID: membership.TenantID})
+				Ω(err).Should(BeNil())
+				Ω(removeResponse.User.Memberships).Should(BeEmpty())
+
+				_, err = sut.RemoveUserFromTenant(ctx, &repository.RemoveUserFromTenantRequest{Email: email, TenantID: membership.TenantID})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user verifies the email address of the existing user", func() {
+			It("should only verify the email address with the pending verification token", func() {
+				expiresAt := time.Now().UTC().Truncate(time.Millisecond).Add(time.Hour)
+
+				setResponse, err := sut.SetEmailVerificationToken(ctx, &repository.SetEmailVerificationTokenRequest{
+					Email:     email,
+					TokenHash: "first",
+					ExpiresAt: expiresAt,
+				})
+				Ω(err).Should(BeNil())
+				Ω(setResponse.User.EmailVerified).Should(BeFalse())
+				Ω(setResponse.User.EmailVerificationTokenHash).Should(Equal("first"))
+				Ω(setResponse.User.EmailVerificationExpiresAt.Equal(expiresAt)).Should(BeTrue())
+
+				_, err = sut.SetEmailVerificationToken(ctx, &repository.SetEmailVerificationTokenRequest{
+					Email:     email,
+					TokenHash: "second",
+					ExpiresAt: expiresAt,
+				})
+				Ω(err).Should(BeNil())
+
+				_, err = sut.VerifyEmail(ctx, &repository.VerifyEmailRequest{Email: email, TokenHash: "first"})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+				verifyResponse, err := sut.VerifyEmail(ctx, &repository.VerifyEmailRequest{Email: email, TokenHash: "second"})
+				Ω(err).Should(BeNil())
+				Ω(verifyResponse.User.EmailVerified).Should(BeTrue())
+				Ω(verifyResponse.User.EmailVerificationTokenHash).Should(BeEmpty())
+				Ω(verifyResponse.User.EmailVerificationExpiresAt).Should(BeNil())
+
+				readResponse, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(readResponse.User.EmailVerified).Should(BeTrue())
+			})
+		})
+
+		When("user records the login attempts of the existing user", func() {
+			It("should lock the user once the failed attempts reach the threshold until it is unlocked", func() {
+				attemptedAt := time.Now().UTC().Truncate(time.Millisecond)
+				request := repository.RecordLoginAttemptRequest{Email: email, LockoutThreshold: 2, AttemptedAt: attemptedAt}
+
+				attemptResponse, err := sut.RecordLoginAttempt(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(attemptResponse.User.FailedLoginAttempts).Should(Equal(1))
+				Ω(attemptResponse.User.LockedAt).Should(BeNil())
+
+				attemptResponse, err = sut.RecordLoginAttempt(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(attemptResponse.User.FailedLoginAttempts).Should(Equal(2))
+				Ω(attemptResponse.User.LockedAt.Equal(attemptedAt)).Should(BeTrue())
+
+				request.Succeeded = true
+				attemptResponse, err = sut.RecordLoginAttempt(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(attemptResponse.User.FailedLoginAttempts).Should(Equal(2))
+				Ω(attemptResponse.User.LockedAt).ShouldNot(BeNil())
+
+				unlockResponse, err := sut.UnlockUser(ctx, &repository.UnlockUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(unlockResponse.User.FailedLoginAttempts).Should(BeZero())
+				Ω(unlockResponse.User.LockedAt).Should(BeNil())
+
+				readResponse, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(readResponse.User.LockedAt).Should(BeNil())
+			})
+		})
+
+		When("user enrolls the existing user in the MFA methods", func() {
+			It("should add the method only once and remove it by its ID", func() {
+				method := models.MFAMethod{
+					MethodID:      cuid.New(),
+					Type:          models.MFAMethodTypeTOTP,
+					Name:          cuid.New(),
+					TOTPSecretRef: cuid.New(),
+					EnrolledAt:    time.Now().UTC().Truncate(time.Millisecond),
+				}
+
+				addResponse, err := sut.AddMFAMethod(ctx, &repository.AddMFAMethodRequest{Email: email, Method: method})
+				Ω(err).Should(BeNil())
+				Ω(addResponse.User.MFAMethods).Should(HaveLen(1))
+				Ω(addResponse.User.MFAMethods[0].MethodID).Should(Equal(method.MethodID))
+				Ω(addResponse.User.MFAMethods[0].TOTPSecretRef).Should(Equal(method.TOTPSecretRef))
+				Ω(addResponse.User.MFAMethods[0].EnrolledAt.Equal(method.EnrolledAt)).Should(BeTrue())
+
+				duplicateMethod := method
+				duplicateMethod.MethodID = cuid.New()
+				_, err = sut.AddMFAMethod(ctx, &repository.AddMFAMethodRequest{Email: email, Method: duplicateMethod})
+				Ω(commonErrors.IsAlreadyExistsError(err)).Should(BeTrue())
+
+				removeResponse, err := sut.RemoveMFAMethod(ctx, &repository.RemoveMFAMethodRequest{Email: email, MethodID: method.MethodID})
+				Ω(err).Should(BeNil())
+				Ω(removeResponse.User.MFAMethods).Should(BeEmpty())
+
+				_, err = sut.RemoveMFAMethod(ctx, &repository.RemoveMFAMethodRequest{Email: email, MethodID: method.MethodID})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user updates the preferences of the existing user", func() {
+			It("should merge the preferences into the existing preferences", func() {
+				_, err := sut.UpdateUserPreferences(ctx, &repository.UpdateUserPreferencesRequest{
+					Email: email,
+					Preferences: map[string]string{
+						models.PreferenceTheme:    "dark",
+						models.PreferenceLanguage: "en",
+					},
+				})
+				Ω(err).Should(BeNil())
+
+				response, err := sut.UpdateUserPreferences(ctx, &repository.UpdateUserPreferencesRequest{
+					Email:       email,
+					Preferences: map[string]string{models.PreferenceLanguage: "en-AU"},
+					RemovedKeys: []string{models.PreferenceTheme},
+				})
+				Ω(err).Should(BeNil())
+				Ω(response.Preferences).Should(Equal(map[string]string{models.PreferenceLanguage: "en-AU"}))
+
+				readResponse, err := sut.ReadUserPreferences(ctx, &repository.ReadUserPreferencesRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(readResponse.Preferences).Should(Equal(response.Preferences))
+			})
+		})
+
 		When("user deletes the user", func() {
 			It("should delete the user", func() {
 				_, err := sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: email})
@@ -154,6 +569,144 @@ var _ = Describe("Mongodb Repository Service Tests", func() {
 		})
 	})
 
+	Context("user is soft deleted", func() {
+		var (
+			email string
+		)
+
+		BeforeEach(func() {
+			_, _ = sut.CreateUser(ctx, &createRequest)
+			email = createRequest.Email
+
+			_, err := sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: email, SoftDelete: true})
+			Ω(err).Should(BeNil())
+		})
+
+		When("user reads the user", func() {
+			It("should return NotFoundError", func() {
+				_, err := sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user deletes the user again", func() {
+			It("should return NotFoundError", func() {
+				_, err := sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: email, SoftDelete: true})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user searches for the user", func() {
+			It("should only return the user if the deleted users are included", func() {
+				response, err := sut.Search(ctx, &repository.SearchRequest{Emails: []string{email}})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(BeEmpty())
+
+				response, err = sut.Search(ctx, &repository.SearchRequest{Emails: []string{email}, IncludeDeleted: true})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(1))
+				Ω(response.Users[0].DeletedAt).ShouldNot(BeNil())
+			})
+		})
+
+		When("user restores the user", func() {
+			It("should make the user readable again", func() {
+				response, err := sut.RestoreUser(ctx, &repository.RestoreUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(response.Cursor).ShouldNot(BeEmpty())
+
+				_, err = sut.ReadUser(ctx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+
+				_, err = sut.RestoreUser(ctx, &repository.RestoreUserRequest{Email: email})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("the soft deleted users are purged", func() {
+			It("should only purge the users deleted before the given time", func() {
+				response, err := sut.PurgeDeletedUsers(ctx, &repository.PurgeDeletedUsersRequest{DeletedBefore: now.Add(-time.Hour)})
+				Ω(err).Should(BeNil())
+
+				_, err = sut.RestoreUser(ctx, &repository.RestoreUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+
+				_, err = sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: email, SoftDelete: true})
+				Ω(err).Should(BeNil())
+
+				response, err = sut.PurgeDeletedUsers(ctx, &repository.PurgeDeletedUsersRequest{DeletedBefore: now.Add(time.Hour)})
+				Ω(err).Should(BeNil())
+				Ω(response.PurgedCount).Should(BeNumerically(">=", 1))
+
+				_, err = sut.RestoreUser(ctx, &repository.RestoreUserRequest{Email: email})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("user belongs to a tenant", func() {
+		var (
+			email                 string
+			tenantCtx             context.Context
+			otherTenantCtx        context.Context
+			crossTenantReadersCtx context.Context
+		)
+
+		BeforeEach(func() {
+			tenantID := cuid.New()
+			tenantCtx = context.WithValue(ctx, models.ContextKeyParsedToken, models.ParsedToken{TenantID: tenantID})
+			otherTenantCtx = context.WithValue(ctx, models.ContextKeyParsedToken, models.ParsedToken{TenantID: cuid.New()})
+			crossTenantReadersCtx = context.WithValue(
+				ctx,
+				models.ContextKeyParsedToken,
+				models.ParsedToken{TenantID: cuid.New(), CrossTenantReads: true})
+
+			response, err := sut.CreateUser(tenantCtx, &createRequest)
+			Ω(err).Should(BeNil())
+			Ω(response.User.TenantID).Should(Equal(tenantID))
+			email = createRequest.Email
+		})
+
+		When("a caller of the same tenant reads the user", func() {
+			It("should return the user", func() {
+				_, err := sut.ReadUser(tenantCtx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+
+				response, err := sut.Search(tenantCtx, &repository.SearchRequest{Emails: []string{email}})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(1))
+			})
+		})
+
+		When("a caller of another tenant reads the user", func() {
+			It("should return NotFoundError", func() {
+				_, err := sut.ReadUser(otherTenantCtx, &repository.ReadUserRequest{Email: email})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+				response, err := sut.Search(otherTenantCtx, &repository.SearchRequest{Emails: []string{email}})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(BeEmpty())
+			})
+		})
+
+		When("a caller of another tenant allowed to read across the tenants reads the user", func() {
+			It("should return the user but not allow changing it", func() {
+				_, err := sut.ReadUser(crossTenantReadersCtx, &repository.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+
+				response, err := sut.Search(crossTenantReadersCtx, &repository.SearchRequest{Emails: []string{email}})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(1))
+
+				_, err = sut.UpdateUser(crossTenantReadersCtx, &repository.UpdateUserRequest{Email: email, User: models.User{}})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+
+				_, err = sut.DeleteUser(crossTenantReadersCtx, &repository.DeleteUserRequest{Email: email})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+	})
+
 	Context("user does not exist", func() {
 		var (
 			email string
@@ -193,6 +746,26 @@ var _ = Describe("Mongodb Repository Service Tests", func() {
 			})
 		})
 
+		When("user tries to add the user to a tenant", func() {
+			It("should return NotFoundError", func() {
+				_, err := sut.AddUserToTenant(ctx, &repository.AddUserToTenantRequest{
+					Email:      email,
+					Membership: models.TenantMembership{TenantID: cuid.New(), Role: models.TenantRoleMember},
+				})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("user tries to update the preferences of the user", func() {
+			It("should return NotFoundError", func() {
+				_, err := sut.UpdateUserPreferences(ctx, &repository.UpdateUserPreferencesRequest{
+					Email:       email,
+					Preferences: map[string]string{models.PreferenceTheme: "dark"},
+				})
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
 		When("user tries to delete the user", func() {
 			It("should return NotFoundError", func() {
 				response, err := sut.DeleteUser(ctx, &repository.DeleteUserRequest{Email: email})
@@ -207,6 +780,256 @@ var _ = Describe("Mongodb Repository Service Tests", func() {
 		})
 	})
 
+	Context("users already exist", func() {
+		var (
+			emails []string
+		)
+
+		BeforeEach(func() {
+			emails = []string{cuid.New() + "@test.com", cuid.New() + "@test.com", cuid.New() + "@test.com"}
+			for _, email := range emails {
+				_, _ = sut.CreateUser(ctx, &repository.CreateUserRequest{Email: email, User: models.User{}})
+			}
+		})
+
+		When("user searches for the users by email", func() {
+			It("should return all the matched users", func() {
+				response, err := sut.Search(ctx, &repository.SearchRequest{Emails: emails})
+				Ω(err).Should(BeNil())
+				Ω(response.TotalCount).Should(Equal(int64(len(emails))))
+				Ω(response.HasPreviousPage).Should(BeFalse())
+				Ω(response.HasNextPage).Should(BeFalse())
+				Ω(response.Users).Should(HaveLen(len(emails)))
+
+				for index, user := range response.Users {
+					Ω(user.Email).Should(Equal(emails[index]))
+					Ω(user.Cursor).Should(Equal(user.UserID))
+				}
+			})
+		})
+
+		When("user searches for the users using pagination", func() {
+			It("should return the requested page", func() {
+				first := 1
+				firstPageResponse, err := sut.Search(ctx, &repository.SearchRequest{
+					Pagination: models.Pagination{First: &first},
+					Emails:     emails,
+				})
+				Ω(err).Should(BeNil())
+				Ω(firstPageResponse.HasPreviousPage).Should(BeFalse())
+				Ω(firstPageResponse.HasNextPage).Should(BeTrue())
+				Ω(firstPageResponse.Users).Should(HaveLen(1))
+				Ω(firstPageResponse.Users[0].Email).Should(Equal(emails[0]))
+
+				secondPageResponse, err := sut.Search(ctx, &repository.SearchRequest{
+					Pagination: models.Pagination{First: &first, After: &firstPageResponse.Users[0].Cursor},
+					Emails:     emails,
+				})
+				Ω(err).Should(BeNil())
+				Ω(secondPageResponse.HasPreviousPage).Should(BeTrue())
+				Ω(secondPageResponse.HasNextPage).Should(BeTrue())
+				Ω(secondPageResponse.Users).Should(HaveLen(1))
+				Ω(secondPageResponse.Users[0].Email).Should(Equal(emails[1]))
+			})
+		})
+
+		When("user searches for the users by a label selector", func() {
+			It("should only return the users whose labels matched all the requirements", func() {
+				labels := []map[string]string{{"region": "eu", "plan": "pro"}, {"region": "us", "plan": "free"}, {"region": "eu"}}
+				for index, email := range emails {
+					_, err := sut.UpdateUser(ctx, &repository.UpdateUserRequest{Email: email, User: models.User{Labels: labels[index]}})
+					Ω(err).Should(BeNil())
+				}
+
+				labelSelector, err := models.ParseLabelSelector("region in (eu, ca),plan!=free")
+				Ω(err).Should(BeNil())
+
+				response, err := sut.Search(ctx, &repository.SearchRequest{Emails: emails, LabelSelector: labelSelector})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(2))
+				Ω(response.Users[0].Email).Should(Equal(emails[0]))
+				Ω(response.Users[0].User.Labels).Should(Equal(labels[0]))
+				Ω(response.Users[1].Email).Should(Equal(emails[2]))
+
+				labelSelector, err = models.ParseLabelSelector("region=eu,!plan")
+				Ω(err).Should(BeNil())
+
+				response, err = sut.Search(ctx, &repository.SearchRequest{Emails: emails, LabelSelector: labelSelector})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(1))
+				Ω(response.Users[0].Email).Should(Equal(emails[2]))
+			})
+		})
+
+		When("user searches for the users by a free-text query", func() {
+			It("should only return the users whose email address starts with the query sorted by relevance", func() {
+				token := cuid.New()
+				queryEmails := []string{
+					token + "@test.com.au",
+					token + "@test.com",
+					"x" + token + "@test.com",
+					"a." + token + "@test.com",
+				}
+				for _, email := range queryEmails {
+					_, err := sut.CreateUser(ctx, &repository.CreateUserRequest{Email: email, User: models.User{}})
+					Ω(err).Should(BeNil())
+				}
+
+				response, err := sut.Search(ctx, &repository.SearchRequest{Query: strings.ToUpper(token)})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(2))
+				Ω(response.Users[0].Email).Should(Equal(queryEmails[0]))
+				Ω(response.Users[1].Email).Should(Equal(queryEmails[1]))
+
+				response, err = sut.Search(ctx, &repository.SearchRequest{Query: strings.ToUpper(queryEmails[1])})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(2))
+				Ω(response.Users[0].Email).Should(Equal(queryEmails[1]))
+				Ω(response.Users[1].Email).Should(Equal(queryEmails[0]))
+
+				response, err = sut.Search(ctx, &repository.SearchRequest{
+					Query:          queryEmails[1],
+					SortingOptions: []models.SortingOptionPair{{Name: "email", Direction: models.Descending}},
+				})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(2))
+				Ω(response.Users[0].Email).Should(Equal(queryEmails[0]))
+
+				// Only the query is matched regardless of the case, the email addresses are still matched as they are
+				response, err = sut.Search(ctx, &repository.SearchRequest{
+					Emails: []string{strings.ToUpper(queryEmails[1])},
+					Query:  token,
+				})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(BeEmpty())
+			})
+		})
+
+		When("user searches for the users sorted by email in descending order", func() {
+			It("should return the users in descending order", func() {
+				response, err := sut.Search(ctx, &repository.SearchRequest{
+					SortingOptions: []models.SortingOptionPair{{Name: "email", Direction: models.Descending}},
+					Emails:         emails,
+				})
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(len(emails)))
+
+				for index := 1; index < len(response.Users); index++ {
+					Ω(response.Users[index-1].Email >= response.Users[index].Email).Should(BeTrue())
+				}
+			})
+		})
+
+		When("user streams the users by email", func() {
+			It("should send all the matched users one by one", func() {
+				sentUsers := []models.UserWithCursor{}
+				response, err := sut.StreamSearch(ctx, &repository.StreamSearchRequest{
+					Emails: emails,
+					Send: func(user models.UserWithCursor) error {
+						sentUsers = append(sentUsers, user)
+
+						return nil
+					},
+				})
+				Ω(err).Should(BeNil())
+				Ω(response.SentCount).Should(Equal(int64(len(emails))))
+				Ω(sentUsers).Should(HaveLen(len(emails)))
+
+				for index, user := range sentUsers {
+					Ω(user.Email).Should(Equal(emails[index]))
+					Ω(user.Cursor).Should(Equal(user.UserID))
+				}
+			})
+		})
+
+		When("sending a streamed user fails", func() {
+			It("should stop streaming and return UnknownError", func() {
+				sentCount := 0
+				_, err := sut.StreamSearch(ctx, &repository.StreamSearchRequest{
+					Emails: emails,
+					Send: func(user models.UserWithCursor) error {
+						sentCount++
+
+						return errors.New(cuid.New())
+					},
+				})
+				Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+				Ω(sentCount).Should(Equal(1))
+			})
+		})
+	})
+
+	Context("user asks which searches the indexes of the users collection serve", func() {
+		When("AdviseSearchIndexes is called", func() {
+			It("should report the index of every search and suggest the missing ones", func() {
+				mockConfigurationService := configurationMock.NewMockConfigurationContract(mockCtrl)
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseConnectionString().
+					Return(connectionString, nil).
+					AnyTimes()
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseName().
+					Return("user", nil).
+					AnyTimes()
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseCollectionName().
+					Return(cuid.New(), nil).
+					AnyTimes()
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseSearchIndexHints().
+					Return(map[string]string{}, nil)
+
+				mockConfigurationService.
+					EXPECT().
+					GetDatabaseSearchSortableFields().
+					Return([]string{"email", "createdAt", "updatedAt"}, nil)
+
+				migrator, disconnect, err := mongodb.NewMigrator(ctx, mockConfigurationService)
+				Ω(err).Should(BeNil())
+				defer disconnect()
+
+				_, err = migrator.Up(ctx, 0)
+				Ω(err).Should(BeNil())
+
+				advice, err := mongodb.AdviseSearchIndexes(ctx, mockConfigurationService)
+				Ω(err).Should(BeNil())
+				Ω(advice).Should(Equal([]mongodb.SearchIndexAdvice{
+					{FilterShape: "", SortField: "email", Index: "email_1"},
+					{FilterShape: "", SortField: "createdAt", Index: "_id_"},
+					{FilterShape: "", SortField: "updatedAt", SuggestedIndex: "{updatedAt: 1}"},
+					{FilterShape: "email", SortField: "email", Index: "email_1"},
+					{FilterShape: "email", SortField: "createdAt", Index: "email_1"},
+					{FilterShape: "email", SortField: "updatedAt", Index: "email_1"},
+				}))
+			})
+		})
+	})
+
+	Context("the deadline of the context passed", func() {
+		When("an operation is called", func() {
+			It("should return DeadlineExceededError", func() {
+				expiredCtx, cancel := context.WithTimeout(ctx, -time.Second)
+				defer cancel()
+
+				_, err := sut.CreateUser(expiredCtx, &createRequest)
+				Ω(repository.IsDeadlineExceededError(err)).Should(BeTrue())
+
+				_, err = sut.ReadUser(expiredCtx, &repository.ReadUserRequest{Email: createRequest.Email})
+				Ω(repository.IsDeadlineExceededError(err)).Should(BeTrue())
+
+				_, err = sut.Search(expiredCtx, &repository.SearchRequest{})
+				Ω(repository.IsDeadlineExceededError(err)).Should(BeTrue())
+			})
+		})
+	})
+
 })
 
 func assertUser(user, expectedUser models.User) {
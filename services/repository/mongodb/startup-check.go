@@ -0,0 +1,84 @@
+// Package mongodb implements MongoDB repository services
+package mongodb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/repository"
+)
+
+const (
+	// startupCheckDependency is the name the database is reported by while it is unavailable
+	startupCheckDependency = "mongodb"
+
+	// startupCheckAttemptTimeout bounds a single attempt, so an unreachable database is retried rather than waited for
+	startupCheckAttemptTimeout = 10 * time.Second
+)
+
+// startupCheck keeps the state of the database while the repository retries it in the background at startup. Every
+// operation fails with DependencyUnavailableError until the database is reached and the collection is migrated, so
+// the users are never persisted before the unique email index exists.
+type startupCheck struct {
+	mutex sync.RWMutex
+	err   error
+}
+
+// newStartupCheck creates the startup check in the DependencyUnavailable state, as no attempt is made yet
+// Returns the new startup check
+func newStartupCheck() *startupCheck {
+	return &startupCheck{
+		err: repository.NewDependencyUnavailableError(startupCheckDependency, 0, nil),
+	}
+}
+
+// getError returns the DependencyUnavailableError the operations fail with until the database is reached
+// Returns the error or nil if the database is reached
+func (check *startupCheck) getError() error {
+	check.mutex.RLock()
+	defer check.mutex.RUnlock()
+
+	return check.err
+}
+
+// setError records the state of the database after an attempt
+// err: Optional. The error the attempt failed with, nil if the database is reached
+func (check *startupCheck) setError(err error) {
+	check.mutex.Lock()
+	defer check.mutex.Unlock()
+
+	check.err = err
+}
+
+// run retries migrating the collection until it succeeds, doubling the wait after every failed attempt up to the maximum
+// backoff. The database is retried for as long as it is unavailable, so the pod stays alive but not ready instead of
+// crash looping.
+// migrate: Mandatory. The function that reaches the database and migrates the collection
+// initialBackoff: Mandatory. The wait before the first retry
+// maxBackoff: Mandatory. The longest wait between two attempts
+func (check *startupCheck) run(
+	migrate func(ctx context.Context) error,
+	initialBackoff time.Duration,
+	maxBackoff time.Duration) {
+	backoff := initialBackoff
+
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), startupCheckAttemptTimeout)
+		err := migrate(ctx)
+		cancel()
+
+		if err == nil {
+			check.setError(nil)
+
+			return
+		}
+
+		check.setError(repository.NewDependencyUnavailableError(startupCheckDependency, attempt, err))
+		time.Sleep(backoff)
+
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
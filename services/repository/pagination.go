@@ -0,0 +1,160 @@
+// Package repository implements different repository services required by the user service
+package repository
+
+import (
+	"fmt"
+
+	"github.com/decentralized-cloud/user/models"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// PageQuery is the part of the Relay cursor connection pagination the repositories push into their queries. The users
+// sorted after the After cursor and before the Before cursor are read in the sort order, or in the reverse order if the
+// page is read backward, and at most Limit users are read.
+type PageQuery struct {
+	After    *string
+	Before   *string
+	Backward bool
+
+	// Limit is the number of the users to read, one more than the page holds, so whether the users continue past the
+	// page is known without counting them. The users are not limited if zero.
+	Limit int
+}
+
+// NewPageQuery creates the query the requested page of the search result is read with. The page is read backward if
+// only the last users are requested.
+// pagination: Mandatory. The pagination details to apply
+// Returns the page query
+func NewPageQuery(pagination models.Pagination) PageQuery {
+	query := PageQuery{
+		After:  pagination.After,
+		Before: pagination.Before,
+	}
+
+	switch {
+	case pagination.First != nil:
+		query.Limit = *pagination.First + 1
+
+	case pagination.Last != nil:
+		query.Backward = true
+		query.Limit = *pagination.Last + 1
+	}
+
+	return query
+}
+
+// NewPageResponse applies the rest of the Relay cursor connection pagination algorithm to the users read with the page
+// query and returns the requested page
+// query: Mandatory. The page query the users are read with
+// pagination: Mandatory. The pagination details to apply
+// users: Mandatory. The users read with the page query in the order they are read
+// totalCount: Mandatory. The number of the users that matched the search criteria regardless of the pagination
+// usersBeforeAfter: Mandatory. Whether any user that matched the search criteria is sorted before or at the After
+// cursor, false if the After cursor is not given
+// usersAfterBefore: Mandatory. Whether any user that matched the search criteria is sorted after or at the Before
+// cursor, false if the Before cursor is not given
+// Returns the requested page of the users
+func NewPageResponse(
+	query PageQuery,
+	pagination models.Pagination,
+	users []models.UserWithCursor,
+	totalCount int64,
+	usersBeforeAfter bool,
+	usersAfterBefore bool) *SearchResponse {
+	hasPreviousPage, hasNextPage := usersBeforeAfter, usersAfterBefore
+
+	if query.Backward {
+		if len(users) > *pagination.Last {
+			users = users[:*pagination.Last]
+			hasPreviousPage = true
+		}
+
+		reversed := make([]models.UserWithCursor, 0, len(users))
+		for index := len(users) - 1; index >= 0; index-- {
+			reversed = append(reversed, users[index])
+		}
+
+		users = reversed
+	} else if pagination.First != nil {
+		if len(users) > *pagination.First {
+			users = users[:*pagination.First]
+			hasNextPage = true
+		}
+
+		if pagination.Last != nil && len(users) > *pagination.Last {
+			users = users[len(users)-*pagination.Last:]
+			hasPreviousPage = true
+		}
+	}
+
+	return &SearchResponse{
+		HasPreviousPage: hasPreviousPage,
+		HasNextPage:     hasNextPage,
+		TotalCount:      totalCount,
+		Users:           users,
+	}
+}
+
+// NewInvalidCursorError creates the error the search is rejected with if the given cursor does not identify a user
+// cursor: Mandatory. The invalid cursor
+// Returns the ArgumentError
+func NewInvalidCursorError(cursor string) error {
+	return commonErrors.NewArgumentError("request", fmt.Sprintf("invalid cursor %s", cursor))
+}
+
+// Paginate applies the Relay cursor connection pagination algorithm to the sorted list of users. It is used by the
+// repositories that already hold every user that matched the search criteria in memory, the rest push the pagination
+// into their queries using NewPageQuery and NewPageResponse.
+// users: Mandatory. The complete sorted list of the users that matched the search criteria
+// pagination: Mandatory. The pagination details to apply
+// Returns either the requested page of the users or ArgumentError if the After or Before cursor is not one of the users
+func Paginate(users []models.UserWithCursor, pagination models.Pagination) (*SearchResponse, error) {
+	start, end := 0, len(users)
+
+	if pagination.After != nil {
+		index := indexOfCursor(users, *pagination.After)
+		if index < 0 {
+			return nil, NewInvalidCursorError(*pagination.After)
+		}
+
+		start = index + 1
+	}
+
+	if pagination.Before != nil {
+		index := indexOfCursor(users, *pagination.Before)
+		if index < 0 {
+			return nil, NewInvalidCursorError(*pagination.Before)
+		}
+
+		end = index
+	}
+
+	if end < start {
+		end = start
+	}
+
+	if pagination.First != nil && end-start > *pagination.First {
+		end = start + *pagination.First
+	}
+
+	if pagination.Last != nil && end-start > *pagination.Last {
+		start = end - *pagination.Last
+	}
+
+	return &SearchResponse{
+		HasPreviousPage: start > 0,
+		HasNextPage:     end < len(users),
+		TotalCount:      int64(len(users)),
+		Users:           users[start:end],
+	}, nil
+}
+
+func indexOfCursor(users []models.UserWithCursor, cursor string) int {
+	for index, user := range users {
+		if user.Cursor == cursor {
+			return index
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,90 @@
+// Package postgres implements PostgreSQL repository services
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// migrations contains the ordered list of the schema migrations. The migrations that are already applied are
+// recorded in the schema migrations table, so new migrations must only ever be appended to the end of the list.
+// %[1]s is replaced by the users table name, %[2]s by the name of the unique email constraint and %[3]s by the name
+// of the index on the soft deleted users.
+var migrations = []string{
+	`CREATE TABLE IF NOT EXISTS %[1]s (
+		id BIGSERIAL PRIMARY KEY,
+		email TEXT NOT NULL,
+		CONSTRAINT %[2]s UNIQUE (email)
+	)`,
+	`ALTER TABLE %[1]s ADD COLUMN deleted_at TIMESTAMPTZ;
+	CREATE INDEX %[3]s ON %[1]s (deleted_at) WHERE deleted_at IS NOT NULL`,
+	`ALTER TABLE %[1]s ADD COLUMN preferences JSONB NOT NULL DEFAULT '{}'::jsonb`,
+	`ALTER TABLE %[1]s ADD COLUMN memberships JSONB NOT NULL DEFAULT '[]'::jsonb`,
+	// The users created before the column is added are considered created when the migration is applied
+	`ALTER TABLE %[1]s ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now()`,
+	`ALTER TABLE %[1]s ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT false,
+		ADD COLUMN email_verification_token_hash TEXT,
+		ADD COLUMN email_verification_expires_at TIMESTAMPTZ`,
+	`ALTER TABLE %[1]s ADD COLUMN failed_login_attempts INTEGER NOT NULL DEFAULT 0,
+		ADD COLUMN locked_at TIMESTAMPTZ`,
+	`ALTER TABLE %[1]s ADD COLUMN mfa_methods JSONB NOT NULL DEFAULT '[]'::jsonb`,
+	// The GIN index serves the label selectors whatever label keys they match
+	`ALTER TABLE %[1]s ADD COLUMN labels JSONB NOT NULL DEFAULT '{}'::jsonb;
+	CREATE INDEX ON %[1]s USING GIN (labels)`,
+	// The users changed before the columns are added have no update time and no known creator or updater
+	`ALTER TABLE %[1]s ADD COLUMN updated_at TIMESTAMPTZ,
+		ADD COLUMN created_by TEXT NOT NULL DEFAULT '',
+		ADD COLUMN updated_by TEXT NOT NULL DEFAULT ''`,
+	// The users created in the single-tenant mode belong to no tenant, the partial index serves the searches scoped to
+	// a tenant
+	`ALTER TABLE %[1]s ADD COLUMN tenant_id TEXT NOT NULL DEFAULT '';
+	CREATE INDEX ON %[1]s (tenant_id, id) WHERE tenant_id <> ''`,
+	`ALTER TABLE %[1]s ADD COLUMN avatar_object_key TEXT,
+		ADD COLUMN avatar_content_hash TEXT`,
+	// The pattern index on the lower case email addresses serves the free-text queries, which are matched as their
+	// prefix, whatever the collation of the database is
+	`CREATE INDEX ON %[1]s (lower(email) text_pattern_ops)`,
+}
+
+// migrate applies the schema migrations that are not applied yet. The migrations run within a single
+// transaction holding an advisory lock so multiple instances of the service can start at the same time.
+// ctx: Mandatory The reference to the context
+// pool: Mandatory. The connection pool to run the migrations on
+// tableName: Mandatory. The name of the users table
+// Returns error if something goes wrong
+func migrate(ctx context.Context, pool *pgxpool.Pool, tableName string) error {
+	migrationsTable := pgx.Identifier{tableName + "_schema_migrations"}.Sanitize()
+	usersTable := pgx.Identifier{tableName}.Sanitize()
+	uniqueEmailConstraint := pgx.Identifier{tableName + uniqueEmailConstraintSuffix}.Sanitize()
+	deletedAtIndex := pgx.Identifier{tableName + "_deleted_at_idx"}.Sanitize()
+
+	return pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", tableName); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (version INTEGER PRIMARY KEY)", migrationsTable)); err != nil {
+			return err
+		}
+
+		var currentVersion int
+		if err := tx.QueryRow(ctx, fmt.Sprintf("SELECT COALESCE(MAX(version), 0) FROM %s", migrationsTable)).Scan(&currentVersion); err != nil {
+			return err
+		}
+
+		for version := currentVersion + 1; version <= len(migrations); version++ {
+			if _, err := tx.Exec(ctx, fmt.Sprintf(migrations[version-1], usersTable, uniqueEmailConstraint, deletedAtIndex)); err != nil {
+				return fmt.Errorf("failed to apply migration %d: %w", version, err)
+			}
+
+			if _, err := tx.Exec(ctx, fmt.Sprintf("INSERT INTO %s (version) VALUES ($1)", migrationsTable), version); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,350 @@
+// Package postgres implements PostgreSQL repository services
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/jackc/pgx/v4"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// sortKey is the column or the expression the search result is sorted by
+type sortKey struct {
+	// expression is the column or the expression the users are sorted by
+	expression string
+
+	// column is the column the value of the user of a cursor is read from, empty if the value is computed from the
+	// email address of the user instead
+	column string
+
+	descending bool
+}
+
+// createSortKeys creates the keys the search result is sorted by. The row ID is always sorted by last, so the position
+// of the users, hence the cursors, are stable.
+// sortingOptions: Optional. The sorting options to apply
+// Returns either the sort keys or error if sorting by any of the given fields is not supported
+func createSortKeys(sortingOptions []models.SortingOptionPair) ([]sortKey, error) {
+	sortKeys := []sortKey{}
+	for _, sortingOption := range sortingOptions {
+		column, ok := sortableColumns[sortingOption.Name]
+		if !ok {
+			return nil, commonErrors.NewArgumentError("request", fmt.Sprintf("sorting by %s is not supported", sortingOption.Name))
+		}
+
+		sortKeys = append(sortKeys, sortKey{
+			expression: column,
+			column:     column,
+			descending: sortingOption.Direction == models.Descending,
+		})
+	}
+
+	return append(sortKeys, sortKey{expression: "id", column: "id"}), nil
+}
+
+// createSelectQuery creates the query selecting the users that meet the conditions
+// conditions: Optional. The conditions the users must meet
+// sortKeys: Mandatory. The keys the users are sorted by
+// reverse: Mandatory. Whether the users are read in the reverse order
+// limit: Optional. The number of the users to read, the users are not limited if zero
+// Returns the query
+func (service *postgresRepositoryService) createSelectQuery(
+	conditions []string,
+	sortKeys []sortKey,
+	reverse bool,
+	limit int) string {
+	query := fmt.Sprintf("SELECT id, email, created_at, deleted_at, %s FROM %s", userColumns, service.table())
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// The users without an update time are sorted before the others, as they are in MongoDB
+	orderBy := []string{}
+	for _, key := range sortKeys {
+		if key.descending != reverse {
+			orderBy = append(orderBy, key.expression+" DESC NULLS LAST")
+		} else {
+			orderBy = append(orderBy, key.expression+" ASC NULLS FIRST")
+		}
+	}
+
+	query += " ORDER BY " + strings.Join(orderBy, ", ")
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	return query
+}
+
+// searchPage reads a page of the search result with the cursors, the limit and the count pushed into the queries, so
+// only the users on the page are read. The cursors are the row IDs, the page continues after or before the user of
+// the cursor using the values the user is sorted by.
+type searchPage struct {
+	service      *postgresRepositoryService
+	conditions   []string
+	arguments    []interface{}
+	sortKeys     []sortKey
+	readTenantID string
+
+	// query is the lower case free-text query the users are ranked by before they are sorted, empty if the users are
+	// only sorted by the sorting options
+	query string
+}
+
+// newSearchPage creates the search page reading the users that meet the conditions
+// service: Mandatory. The repository the users are read from
+// conditions: Optional. The conditions the users must meet
+// arguments: Optional. The arguments of the conditions
+// sortKeys: Mandatory. The keys the users are sorted by
+// readTenantID: Optional. The tenant the users of the cursors must belong to
+// query: Optional. The free-text query the users are ranked by, the users are not ranked if empty
+// Returns the search page
+func newSearchPage(
+	service *postgresRepositoryService,
+	conditions []string,
+	arguments []interface{},
+	sortKeys []sortKey,
+	readTenantID string,
+	query string) *searchPage {
+	page := &searchPage{
+		service:      service,
+		conditions:   conditions,
+		arguments:    arguments,
+		readTenantID: readTenantID,
+		query:        strings.ToLower(query),
+	}
+
+	if page.query != "" {
+		page.arguments = append(page.arguments, page.query)
+
+		// Ranks the users the same way repository.GetRelevance does
+		sortKeys = append([]sortKey{{expression: fmt.Sprintf(
+			"(CASE WHEN lower(email) = $%d::text THEN 0 ELSE 1 END)",
+			len(page.arguments))}}, sortKeys...)
+	}
+
+	page.sortKeys = sortKeys
+
+	return page
+}
+
+// read reads the requested page of the search result
+// ctx: Mandatory The reference to the context
+// pagination: Mandatory. The pagination details to apply
+// Returns either the requested page or error if something goes wrong. ArgumentError is returned if the After or
+// Before cursor does not identify a user.
+func (page *searchPage) read(ctx context.Context, pagination models.Pagination) (*repository.SearchResponse, error) {
+	pageQuery := repository.NewPageQuery(pagination)
+
+	after, err := page.readCursor(ctx, pageQuery.After)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := page.readCursor(ctx, pageQuery.Before)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := append([]string{}, page.conditions...)
+	arguments := append([]interface{}{}, page.arguments...)
+	if after != nil {
+		conditions = append(conditions, createKeysetCondition(after, page.sortKeys, false, &arguments))
+	}
+
+	if before != nil {
+		conditions = append(conditions, createKeysetCondition(before, page.sortKeys, true, &arguments))
+	}
+
+	rows, err := page.service.pool.Query(
+		ctx,
+		page.service.createSelectQuery(conditions, page.sortKeys, pageQuery.Backward, pageQuery.Limit),
+		arguments...)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to search users", err)
+	}
+
+	defer rows.Close()
+
+	users := []models.UserWithCursor{}
+	for rows.Next() {
+		user, err := scanUserWithCursor(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to search users", err)
+	}
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", page.service.table())
+	if len(page.conditions) > 0 {
+		countQuery += " WHERE " + strings.Join(page.conditions, " AND ")
+	}
+
+	var totalCount int64
+	if err = page.service.pool.QueryRow(ctx, countQuery, page.countArguments()...).Scan(&totalCount); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to count users", err)
+	}
+
+	usersBeforeAfter, err := page.exists(ctx, after, true)
+	if err != nil {
+		return nil, err
+	}
+
+	usersAfterBefore, err := page.exists(ctx, before, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return repository.NewPageResponse(pageQuery, pagination, users, totalCount, usersBeforeAfter, usersAfterBefore), nil
+}
+
+// countArguments returns the arguments of the conditions, without the arguments of the relevance ranking the count
+// query does not reference
+func (page *searchPage) countArguments() []interface{} {
+	if page.query == "" {
+		return page.arguments
+	}
+
+	return page.arguments[:len(page.arguments)-1]
+}
+
+// readCursor reads the values the user of the cursor is sorted by
+// ctx: Mandatory The reference to the context
+// cursor: Optional. The cursor to read
+// Returns either the values the user is sorted by in the order of the sort keys, nil if the cursor is not given, or
+// error if something goes wrong. ArgumentError is returned if the cursor does not identify a user.
+func (page *searchPage) readCursor(ctx context.Context, cursor *string) ([]interface{}, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseInt(*cursor, 10, 64)
+	if err != nil {
+		return nil, repository.NewInvalidCursorError(*cursor)
+	}
+
+	columns := []string{"email"}
+	for _, key := range page.sortKeys {
+		if key.column != "" {
+			columns = append(columns, key.column)
+		}
+	}
+
+	arguments := []interface{}{id}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1", strings.Join(columns, ", "), page.service.table())
+	if page.readTenantID != "" {
+		arguments = append(arguments, page.readTenantID)
+		query += " AND tenant_id = $2"
+	}
+
+	columnValues := make([]interface{}, len(columns))
+	destinations := make([]interface{}, len(columns))
+	for index := range columnValues {
+		destinations[index] = &columnValues[index]
+	}
+
+	if err = page.service.pool.QueryRow(ctx, query, arguments...).Scan(destinations...); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.NewInvalidCursorError(*cursor)
+		}
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read the cursor", err)
+	}
+
+	email, _ := columnValues[0].(string)
+	values := []interface{}{}
+	columnValues = columnValues[1:]
+	for _, key := range page.sortKeys {
+		if key.column == "" {
+			values = append(values, repository.GetRelevance(email, page.query))
+
+			continue
+		}
+
+		values = append(values, columnValues[0])
+		columnValues = columnValues[1:]
+	}
+
+	return values, nil
+}
+
+// exists indicates whether any user that meets the conditions is sorted at or after the user of the cursor
+// ctx: Mandatory The reference to the context
+// cursor: Optional. The values the user of the cursor is sorted by
+// reverse: Mandatory. Whether the users are sorted in the reverse order
+// Returns either true if such user exists, false if the cursor is not given, or error if something goes wrong
+func (page *searchPage) exists(ctx context.Context, cursor []interface{}, reverse bool) (bool, error) {
+	if cursor == nil {
+		return false, nil
+	}
+
+	arguments := append([]interface{}{}, page.arguments...)
+	arguments = append(arguments, cursor[len(cursor)-1])
+	cursorCondition := fmt.Sprintf("(id = $%d OR %s)", len(arguments), createKeysetCondition(cursor, page.sortKeys, reverse, &arguments))
+	conditions := append(append([]string{}, page.conditions...), cursorCondition)
+
+	var found bool
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE %s)", page.service.table(), strings.Join(conditions, " AND "))
+	if err := page.service.pool.QueryRow(ctx, query, arguments...).Scan(&found); err != nil {
+		return false, commonErrors.NewUnknownErrorWithError("failed to search users", err)
+	}
+
+	return found, nil
+}
+
+// createKeysetCondition creates the condition matching the users sorted after the user of the cursor, i.e. the users
+// sorted after it by the first key, or equal to it by the first key and sorted after it by the second key and so on.
+// The row ID is always sorted by last, so no other user is equal to it. The missing values are sorted first in
+// ascending order, like they are in MongoDB.
+// cursor: Mandatory. The values the user of the cursor is sorted by in the order of the sort keys
+// sortKeys: Mandatory. The keys the users are sorted by
+// reverse: Mandatory. Whether the users are sorted in the reverse order
+// arguments: Mandatory. The arguments of the query, the values of the cursor are appended to it
+// Returns the condition
+func createKeysetCondition(cursor []interface{}, sortKeys []sortKey, reverse bool, arguments *[]interface{}) string {
+	branches := []string{}
+	equal := []string{}
+
+	for index, key := range sortKeys {
+		value := cursor[index]
+		argument := ""
+		if value != nil {
+			*arguments = append(*arguments, value)
+			argument = fmt.Sprintf("$%d", len(*arguments))
+		}
+
+		condition := ""
+		switch descending := key.descending != reverse; {
+		case !descending && value == nil:
+			condition = key.expression + " IS NOT NULL"
+
+		case !descending:
+			condition = key.expression + " > " + argument
+
+		case value != nil:
+			condition = "(" + key.expression + " < " + argument + " OR " + key.expression + " IS NULL)"
+		}
+
+		if condition != "" {
+			branches = append(branches, "("+strings.Join(append(append([]string{}, equal...), condition), " AND ")+")")
+		}
+
+		if value == nil {
+			equal = append(equal, key.expression+" IS NULL")
+		} else {
+			equal = append(equal, key.expression+" = "+argument)
+		}
+	}
+
+	return "(" + strings.Join(branches, " OR ") + ")"
+}
@@ -0,0 +1,1497 @@
+// Package postgres implements PostgreSQL repository services
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	configurationPostgres "github.com/decentralized-cloud/user/services/configuration/postgres"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// uniqueViolationErrorCode is the PostgreSQL error code raised when a unique constraint is violated
+const uniqueViolationErrorCode = "23505"
+
+const uniqueEmailConstraintSuffix = "_email_key"
+
+// userColumns are the columns the user model is decoded from, in the order userRow reads them
+const userColumns = "memberships, email_verified, email_verification_token_hash, email_verification_expires_at, " +
+	"failed_login_attempts, locked_at, mfa_methods, labels, created_at, updated_at, created_by, updated_by, tenant_id, " +
+	"avatar_object_key, avatar_content_hash"
+
+// searchFilter contains the search criteria only some of the searches support
+type searchFilter struct {
+	userIDs       []int64
+	tenantID      string
+	readTenantID  string
+	createdAfter  *time.Time
+	createdBefore *time.Time
+	after         string
+	labelSelector []models.LabelSelectorRequirement
+	query         string
+}
+
+// likeEscaper escapes the wildcards and the escape character of the LIKE patterns
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// sortableColumns maps the name of the fields the search result can be sorted by to the table columns
+var sortableColumns = map[string]string{
+	"email":     "email",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+	"createdBy": "created_by",
+	"updatedBy": "updated_by",
+}
+
+// membership is the JSON encoded tenant membership stored in the memberships column
+type membership struct {
+	TenantID string    `json:"tenantID"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+// mfaMethod is the JSON encoded MFA method stored in the mfa_methods column. The credential key is stored along the
+// method, so enrolling the same credential twice can be rejected by the condition of a single statement.
+type mfaMethod struct {
+	MethodID             string    `json:"methodID"`
+	Type                 string    `json:"type"`
+	Name                 string    `json:"name,omitempty"`
+	CredentialKey        string    `json:"credentialKey"`
+	TOTPSecretRef        string    `json:"totpSecretRef,omitempty"`
+	WebAuthnCredentialID string    `json:"webAuthnCredentialID,omitempty"`
+	RecoveryCodeHashes   []string  `json:"recoveryCodeHashes,omitempty"`
+	EnrolledAt           time.Time `json:"enrolledAt"`
+}
+
+// userRow contains the columns the user model is decoded from
+type userRow struct {
+	membershipsJSON            []byte
+	emailVerified              bool
+	emailVerificationTokenHash *string
+	emailVerificationExpiresAt *time.Time
+	failedLoginAttempts        int
+	lockedAt                   *time.Time
+	mfaMethodsJSON             []byte
+	labelsJSON                 []byte
+	createdAt                  time.Time
+	updatedAt                  *time.Time
+	createdBy                  string
+	updatedBy                  string
+	tenantID                   string
+	avatarObjectKey            *string
+	avatarContentHash          *string
+}
+
+type postgresRepositoryService struct {
+	pool         *pgxpool.Pool
+	tableName    string
+	clockService clock.ClockContract
+}
+
+// NewPostgresRepositoryService creates new instance of the postgresRepositoryService, setting up all dependencies,
+// applying the pending schema migrations and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// clockService: Mandatory. Reference to the service that provides the time the users are created, updated and soft
+// deleted at
+// Returns the new service or error if something goes wrong
+func NewPostgresRepositoryService(
+	configurationService configuration.ConfigurationContract,
+	clockService clock.ClockContract) (repository.RepositoryContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	connectionString, err := configurationService.GetDatabaseConnectionString()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to postgres", err)
+	}
+
+	// The collection name is used as the name of the table the users are persisted in
+	tableName, err := configurationService.GetDatabaseCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database table name", err)
+	}
+
+	ctx := context.Background()
+	pool, err := configurationPostgres.Connect(ctx, connectionString, configurationService.GetDatabaseConnectionString)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("could not connect to postgres database", err)
+	}
+
+	if err = migrate(ctx, pool, tableName); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to migrate the database schema", err)
+	}
+
+	return &postgresRepositoryService{
+		pool:         pool,
+		tableName:    tableName,
+		clockService: clockService,
+	}, nil
+}
+
+// CreateUser creates a new user.
+// context: Optional The reference to the context
+// request: Mandatory. The request to create a new user
+// Returns either the result of creating new user or error if something goes wrong.
+func (service *postgresRepositoryService) CreateUser(
+	ctx context.Context,
+	request *repository.CreateUserRequest) (*repository.CreateUserResponse, error) {
+	var userID int64
+
+	labelsJSON, err := encodeLabels(request.User.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	now := service.clockService.Now()
+	actor := repository.GetActor(ctx)
+	tenantID := repository.GetTenant(ctx)
+
+	err = service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf(
+			`INSERT INTO %s (email, labels, created_at, updated_at, created_by, updated_by, tenant_id)
+			VALUES ($1, $2::jsonb, $3, $3, $4, $4, $5) RETURNING id`,
+			service.table()),
+		request.Email,
+		labelsJSON,
+		now,
+		actor,
+		tenantID).Scan(&userID)
+	if err != nil {
+		var pgError *pgconn.PgError
+		if errors.As(err, &pgError) && pgError.Code == uniqueViolationErrorCode {
+			return nil, commonErrors.NewAlreadyExistsErrorWithError(err)
+		}
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create user", err)
+	}
+
+	createdUser := request.User
+	createdUser.ID = strconv.FormatInt(userID, 10)
+	createdUser.CreatedAt = &now
+	createdUser.UpdatedAt = &now
+	createdUser.CreatedBy = actor
+	createdUser.UpdatedBy = actor
+	createdUser.TenantID = tenantID
+
+	return &repository.CreateUserResponse{
+		User:   createdUser,
+		Cursor: strconv.FormatInt(userID, 10),
+	}, nil
+}
+
+// ReadUser read an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read an existing user
+// Returns either the result of reading an existing user or error if something goes wrong.
+func (service *postgresRepositoryService) ReadUser(
+	ctx context.Context,
+	request *repository.ReadUserRequest) (*repository.ReadUserResponse, error) {
+	if request.UserID != "" {
+		return service.readUserByID(ctx, request.UserID, repository.GetReadTenant(ctx), request.IncludeDeleted)
+	}
+
+	user, err := service.readUser(ctx, request.Email, repository.GetReadTenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.ReadUserResponse{
+		Email: request.Email,
+		User:  user,
+	}, nil
+}
+
+// ReadUsers reads the existing users with the given email addresses or IDs at once, with a single query
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the existing users
+// Returns either the users found or error if something goes wrong. The users that are not found are left out.
+func (service *postgresRepositoryService) ReadUsers(
+	ctx context.Context,
+	request *repository.ReadUsersRequest) (*repository.ReadUsersResponse, error) {
+	// The IDs that are not row IDs can not be the ID of any user, so they are left out
+	userIDs := []int64{}
+	for _, userID := range request.UserIDs {
+		if id, err := strconv.ParseInt(userID, 10, 64); err == nil {
+			userIDs = append(userIDs, id)
+		}
+	}
+
+	users := []models.UserWithCursor{}
+	if len(request.Emails) == 0 && len(userIDs) == 0 {
+		return &repository.ReadUsersResponse{Users: users}, nil
+	}
+
+	query, arguments, err := service.createSearchQuery(request.Emails, nil, false, searchFilter{userIDs: userIDs, readTenantID: repository.GetReadTenant(ctx)})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := service.pool.Query(ctx, query, arguments...)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to retrieve users", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		user, err := scanUserWithCursor(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to retrieve users", err)
+	}
+
+	return &repository.ReadUsersResponse{
+		Users: users,
+	}, nil
+}
+
+// UpdateUser update an existing user
+// context: Optional The reference to the context
+// request: Mandatory. The request to update an existing user
+// Returns either the result of updateing an existing user or error if something goes wrong.
+func (service *postgresRepositoryService) UpdateUser(
+	ctx context.Context,
+	request *repository.UpdateUserRequest) (*repository.UpdateUserResponse, error) {
+	var userID int64
+	var row userRow
+
+	// The labels are replaced as a whole
+	labelsJSON, err := encodeLabels(request.User.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf(
+			`UPDATE %s SET email = $1, labels = $2::jsonb, updated_at = $3, updated_by = $4
+			WHERE email = $1 AND deleted_at IS NULL AND %s RETURNING id, %s`,
+			service.table(),
+			tenantCondition(5),
+			userColumns),
+		request.Email,
+		labelsJSON,
+		service.clockService.Now(),
+		repository.GetActor(ctx),
+		repository.GetTenant(ctx)).Scan(row.fields(&userID)...)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to update user", err)
+	}
+
+	user, err := row.decode(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.UpdateUserResponse{
+		User:   user,
+		Cursor: strconv.FormatInt(userID, 10),
+	}, nil
+}
+
+// ChangeEmail changes the email address of an existing user, the user keeps its ID. The new email address is not
+// verified, so the verification of the previous one is cleared.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the current and the new email addresses of the user
+// Returns either the user after its email address is changed or error if something goes wrong.
+func (service *postgresRepositoryService) ChangeEmail(
+	ctx context.Context,
+	request *repository.ChangeEmailRequest) (*repository.ChangeEmailResponse, error) {
+	var userID int64
+	var row userRow
+
+	err := service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf(
+			`UPDATE %s SET email = $2, email_verified = false, email_verification_token_hash = NULL,
+			email_verification_expires_at = NULL, updated_at = $3, updated_by = $4
+			WHERE email = $1 AND deleted_at IS NULL AND %s RETURNING id, %s`,
+			service.table(),
+			tenantCondition(5),
+			userColumns),
+		request.Email,
+		request.NewEmail,
+		service.clockService.Now(),
+		repository.GetActor(ctx),
+		repository.GetTenant(ctx)).Scan(row.fields(&userID)...)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		var pgError *pgconn.PgError
+		if errors.As(err, &pgError) && pgError.Code == uniqueViolationErrorCode {
+			return nil, commonErrors.NewAlreadyExistsErrorWithError(err)
+		}
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to change user email", err)
+	}
+
+	user, err := row.decode(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.ChangeEmailResponse{
+		User:   user,
+		Cursor: strconv.FormatInt(userID, 10),
+	}, nil
+}
+
+// ReadUserPreferences reads the preferences of an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the preferences of an existing user
+// Returns either the preferences of the user or error if something goes wrong.
+func (service *postgresRepositoryService) ReadUserPreferences(
+	ctx context.Context,
+	request *repository.ReadUserPreferencesRequest) (*repository.ReadUserPreferencesResponse, error) {
+	var preferencesJSON []byte
+
+	err := service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf("SELECT preferences FROM %s WHERE email = $1 AND deleted_at IS NULL AND %s", service.table(), tenantCondition(2)),
+		request.Email,
+		repository.GetReadTenant(ctx)).Scan(&preferencesJSON)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to retrieve user preferences", err)
+	}
+
+	preferences := map[string]string{}
+	if err = json.Unmarshal(preferencesJSON, &preferences); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to decode user preferences", err)
+	}
+
+	return &repository.ReadUserPreferencesResponse{
+		Preferences: preferences,
+	}, nil
+}
+
+// UpdateUserPreferences merges the preferences into the preferences of an existing user. The preferences are merged
+// by the database, so the concurrent updates of the different preferences do not overwrite each other.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the preferences to set and the keys of the preferences to remove
+// Returns either the preferences of the user after they are merged or error if something goes wrong.
+func (service *postgresRepositoryService) UpdateUserPreferences(
+	ctx context.Context,
+	request *repository.UpdateUserPreferencesRequest) (*repository.UpdateUserPreferencesResponse, error) {
+	setPreferences := request.Preferences
+	if setPreferences == nil {
+		setPreferences = map[string]string{}
+	}
+
+	setPreferencesJSON, err := json.Marshal(setPreferences)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to encode user preferences", err)
+	}
+
+	removedKeys := request.RemovedKeys
+	if removedKeys == nil {
+		removedKeys = []string{}
+	}
+
+	var preferencesJSON []byte
+
+	err = service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf(
+			`UPDATE %s SET preferences = (preferences || $2::jsonb) - $3::text[], updated_at = $4, updated_by = $5
+			WHERE email = $1 AND deleted_at IS NULL AND %s RETURNING preferences`,
+			service.table(),
+			tenantCondition(6)),
+		request.Email,
+		string(setPreferencesJSON),
+		removedKeys,
+		service.clockService.Now(),
+		repository.GetActor(ctx),
+		repository.GetTenant(ctx)).Scan(&preferencesJSON)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to update user preferences", err)
+	}
+
+	preferences := map[string]string{}
+	if err = json.Unmarshal(preferencesJSON, &preferences); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to decode user preferences", err)
+	}
+
+	return &repository.UpdateUserPreferencesResponse{
+		Preferences: preferences,
+	}, nil
+}
+
+// AddUserToTenant adds the membership in the tenant to an existing user. The row is only updated if the user is not
+// a member of the tenant yet, and the condition is checked again against the latest row if a concurrent update
+// changed it, so the concurrent requests to add the user to the same tenant can not both succeed.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the membership to add
+// Returns either the user after the membership is added or error if something goes wrong.
+func (service *postgresRepositoryService) AddUserToTenant(
+	ctx context.Context,
+	request *repository.AddUserToTenantRequest) (*repository.AddUserToTenantResponse, error) {
+	addedMembershipJSON, err := json.Marshal([]membership{{
+		TenantID: request.Membership.TenantID,
+		Role:     request.Membership.Role,
+		JoinedAt: request.Membership.JoinedAt,
+	}})
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to encode user membership", err)
+	}
+
+	user, userID, err := service.updateMemberships(
+		ctx,
+		request.Email,
+		"memberships || $3::jsonb",
+		"NOT memberships @> $2::jsonb",
+		request.Membership.TenantID,
+		commonErrors.NewAlreadyExistsError(),
+		string(addedMembershipJSON))
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.AddUserToTenantResponse{
+		User:   user,
+		Cursor: userID,
+	}, nil
+}
+
+// RemoveUserFromTenant removes the membership in the tenant from an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the tenant to remove the membership of
+// Returns either the user after the membership is removed or error if something goes wrong.
+func (service *postgresRepositoryService) RemoveUserFromTenant(
+	ctx context.Context,
+	request *repository.RemoveUserFromTenantRequest) (*repository.RemoveUserFromTenantResponse, error) {
+	user, userID, err := service.updateMemberships(
+		ctx,
+		request.Email,
+		`(SELECT COALESCE(jsonb_agg(element ORDER BY position), '[]'::jsonb)
+			FROM jsonb_array_elements(memberships) WITH ORDINALITY AS elements(element, position)
+			WHERE element->>'tenantID' <> $3)`,
+		"memberships @> $2::jsonb",
+		request.TenantID,
+		commonErrors.NewNotFoundError(),
+		request.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.RemoveUserFromTenantResponse{
+		User:   user,
+		Cursor: userID,
+	}, nil
+}
+
+// SetEmailVerificationToken sets the pending email verification token of an existing user, replacing the token sent
+// before if any
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the hash of the token and the time it expires at
+// Returns either the user after the token is set or error if something goes wrong.
+func (service *postgresRepositoryService) SetEmailVerificationToken(
+	ctx context.Context,
+	request *repository.SetEmailVerificationTokenRequest) (*repository.SetEmailVerificationTokenResponse, error) {
+	var userID int64
+	var row userRow
+
+	err := service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf(
+			`UPDATE %s SET email_verification_token_hash = $2, email_verification_expires_at = $3, updated_at = $4,
+			updated_by = $5 WHERE email = $1 AND deleted_at IS NULL AND %s RETURNING id, %s`,
+			service.table(),
+			tenantCondition(6),
+			userColumns),
+		request.Email,
+		request.TokenHash,
+		request.ExpiresAt,
+		service.clockService.Now(),
+		repository.GetActor(ctx),
+		repository.GetTenant(ctx)).Scan(row.fields(&userID)...)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to set user email verification token", err)
+	}
+
+	user, err := row.decode(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.SetEmailVerificationTokenResponse{
+		User:   user,
+		Cursor: strconv.FormatInt(userID, 10),
+	}, nil
+}
+
+// VerifyEmail marks the email address of an existing user as verified and clears the pending verification token.
+// The row is only updated if the token is still the pending verification token, so a token replaced by a newer
+// verification email can not be used anymore.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the hash of the pending verification token
+// Returns either the user after the email address is verified or error if something goes wrong.
+func (service *postgresRepositoryService) VerifyEmail(
+	ctx context.Context,
+	request *repository.VerifyEmailRequest) (*repository.VerifyEmailResponse, error) {
+	var userID int64
+	var row userRow
+
+	err := service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf(
+			`UPDATE %s SET email_verified = true, email_verification_token_hash = NULL, email_verification_expires_at = NULL,
+			updated_at = $3, updated_by = $4
+			WHERE email = $1 AND deleted_at IS NULL AND email_verification_token_hash = $2 AND %s RETURNING id, %s`,
+			service.table(),
+			tenantCondition(5),
+			userColumns),
+		request.Email,
+		request.TokenHash,
+		service.clockService.Now(),
+		repository.GetActor(ctx),
+		repository.GetTenant(ctx)).Scan(row.fields(&userID)...)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to verify user email", err)
+	}
+
+	user, err := row.decode(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.VerifyEmailResponse{
+		User:   user,
+		Cursor: strconv.FormatInt(userID, 10),
+	}, nil
+}
+
+// RecordLoginAttempt records the outcome of a login attempt of an existing user. The SET expressions read the
+// columns as they were before the update, so the user is locked based on the incremented number of the failed
+// attempts in the same atomic update.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the outcome of the attempt and the lockout threshold
+// Returns either the user after the attempt is recorded or error if something goes wrong.
+func (service *postgresRepositoryService) RecordLoginAttempt(
+	ctx context.Context,
+	request *repository.RecordLoginAttemptRequest) (*repository.RecordLoginAttemptResponse, error) {
+	var userID int64
+	var row userRow
+
+	// A zero threshold disables the lockout, so the failed attempts are only counted
+	query := fmt.Sprintf(
+		`UPDATE %s SET failed_login_attempts = failed_login_attempts + 1,
+		locked_at = CASE WHEN locked_at IS NULL AND $2 > 0 AND failed_login_attempts + 1 >= $2 THEN $3 ELSE locked_at END
+		WHERE email = $1 AND deleted_at IS NULL AND %s RETURNING id, %s`,
+		service.table(),
+		tenantCondition(4),
+		userColumns)
+	args := []interface{}{request.Email, request.LockoutThreshold, request.AttemptedAt, repository.GetTenant(ctx)}
+
+	if request.Succeeded {
+		query = fmt.Sprintf(
+			`UPDATE %s SET failed_login_attempts = CASE WHEN locked_at IS NULL THEN 0 ELSE failed_login_attempts END
+			WHERE email = $1 AND deleted_at IS NULL AND %s RETURNING id, %s`,
+			service.table(),
+			tenantCondition(2),
+			userColumns)
+		args = []interface{}{request.Email, repository.GetTenant(ctx)}
+	}
+
+	err := service.pool.QueryRow(ctx, query, args...).Scan(row.fields(&userID)...)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to record user login attempt", err)
+	}
+
+	user, err := row.decode(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.RecordLoginAttemptResponse{
+		User:   user,
+		Cursor: strconv.FormatInt(userID, 10),
+	}, nil
+}
+
+// SetUserAvatar sets the avatar of an existing user, replacing the avatar set before if any
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the key and the hash of the stored avatar image
+// Returns either the user after the avatar is set or error if something goes wrong.
+func (service *postgresRepositoryService) SetUserAvatar(
+	ctx context.Context,
+	request *repository.SetUserAvatarRequest) (*repository.SetUserAvatarResponse, error) {
+	var userID int64
+	var row userRow
+
+	err := service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf(
+			`UPDATE %s SET avatar_object_key = $2, avatar_content_hash = $3, updated_at = $4, updated_by = $5
+			WHERE email = $1 AND deleted_at IS NULL AND %s RETURNING id, %s`,
+			service.table(),
+			tenantCondition(6),
+			userColumns),
+		request.Email,
+		request.Avatar.ObjectKey,
+		request.Avatar.ContentHash,
+		service.clockService.Now(),
+		repository.GetActor(ctx),
+		repository.GetTenant(ctx)).Scan(row.fields(&userID)...)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to set user avatar", err)
+	}
+
+	user, err := row.decode(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.SetUserAvatarResponse{
+		User:   user,
+		Cursor: strconv.FormatInt(userID, 10),
+	}, nil
+}
+
+// UnlockUser unlocks an existing user and resets the number of its failed login attempts
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to unlock an existing user
+// Returns either the user after it is unlocked or error if something goes wrong.
+func (service *postgresRepositoryService) UnlockUser(
+	ctx context.Context,
+	request *repository.UnlockUserRequest) (*repository.UnlockUserResponse, error) {
+	var userID int64
+	var row userRow
+
+	err := service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf(
+			`UPDATE %s SET failed_login_attempts = 0, locked_at = NULL, updated_at = $2, updated_by = $3
+			WHERE email = $1 AND deleted_at IS NULL AND %s RETURNING id, %s`,
+			service.table(),
+			tenantCondition(4),
+			userColumns),
+		request.Email,
+		service.clockService.Now(),
+		repository.GetActor(ctx),
+		repository.GetTenant(ctx)).Scan(row.fields(&userID)...)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to unlock user", err)
+	}
+
+	user, err := row.decode(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.UnlockUserResponse{
+		User:   user,
+		Cursor: strconv.FormatInt(userID, 10),
+	}, nil
+}
+
+// AddMFAMethod adds the MFA method to an existing user. The row is only updated if the user has not enrolled the same
+// credential yet, and the condition is checked again against the latest row if a concurrent update changed it, so
+// the concurrent requests to enroll the same credential can not both succeed.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the method to add
+// Returns either the user after the method is added or error if something goes wrong.
+func (service *postgresRepositoryService) AddMFAMethod(
+	ctx context.Context,
+	request *repository.AddMFAMethodRequest) (*repository.AddMFAMethodResponse, error) {
+	credentialKey := request.Method.CredentialKey()
+	addedMethodJSON, err := json.Marshal([]mfaMethod{{
+		MethodID:             request.Method.MethodID,
+		Type:                 string(request.Method.Type),
+		Name:                 request.Method.Name,
+		CredentialKey:        credentialKey,
+		TOTPSecretRef:        request.Method.TOTPSecretRef,
+		WebAuthnCredentialID: request.Method.WebAuthnCredentialID,
+		RecoveryCodeHashes:   request.Method.RecoveryCodeHashes,
+		EnrolledAt:           request.Method.EnrolledAt,
+	}})
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to encode user MFA method", err)
+	}
+
+	user, userID, err := service.updateMFAMethods(
+		ctx,
+		request.Email,
+		"mfa_methods || $3::jsonb",
+		"NOT mfa_methods @> $2::jsonb",
+		map[string]string{"credentialKey": credentialKey},
+		commonErrors.NewAlreadyExistsError(),
+		string(addedMethodJSON))
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.AddMFAMethodResponse{
+		User:   user,
+		Cursor: userID,
+	}, nil
+}
+
+// RemoveMFAMethod removes the MFA method from an existing user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the ID of the method to remove
+// Returns either the user after the method is removed or error if something goes wrong.
+func (service *postgresRepositoryService) RemoveMFAMethod(
+	ctx context.Context,
+	request *repository.RemoveMFAMethodRequest) (*repository.RemoveMFAMethodResponse, error) {
+	user, userID, err := service.updateMFAMethods(
+		ctx,
+		request.Email,
+		`(SELECT COALESCE(jsonb_agg(element ORDER BY position), '[]'::jsonb)
+			FROM jsonb_array_elements(mfa_methods) WITH ORDINALITY AS elements(element, position)
+			WHERE element->>'methodID' <> $3)`,
+		"mfa_methods @> $2::jsonb",
+		map[string]string{"methodID": request.MethodID},
+		commonErrors.NewNotFoundError(),
+		request.MethodID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.RemoveMFAMethodResponse{
+		User:   user,
+		Cursor: userID,
+	}, nil
+}
+
+// DeleteUser delete an existing user
+// context: Optional The reference to the context
+// request: Mandatory. The request to delete an existing user
+// Returns either the result of deleting an existing user or error if something goes wrong.
+func (service *postgresRepositoryService) DeleteUser(
+	ctx context.Context,
+	request *repository.DeleteUserRequest) (*repository.DeleteUserResponse, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE email = $1 AND deleted_at IS NULL AND %s", service.table(), tenantCondition(2))
+	arguments := []interface{}{request.Email, repository.GetTenant(ctx)}
+	if request.SoftDelete {
+		query = fmt.Sprintf(
+			"UPDATE %s SET deleted_at = $3, updated_at = $3, updated_by = $4 WHERE email = $1 AND deleted_at IS NULL AND %s",
+			service.table(),
+			tenantCondition(2))
+		arguments = append(arguments, service.clockService.Now(), repository.GetActor(ctx))
+	}
+
+	commandTag, err := service.pool.Exec(ctx, query, arguments...)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to delete user", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return nil, commonErrors.NewNotFoundError()
+	}
+
+	return &repository.DeleteUserResponse{}, nil
+}
+
+// RestoreUser restores an existing soft deleted user
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to restore an existing soft deleted user
+// Returns either the result of restoring the user or error if something goes wrong.
+func (service *postgresRepositoryService) RestoreUser(
+	ctx context.Context,
+	request *repository.RestoreUserRequest) (*repository.RestoreUserResponse, error) {
+	var userID int64
+	var row userRow
+
+	err := service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf(
+			`UPDATE %s SET deleted_at = NULL, updated_at = $2, updated_by = $3
+			WHERE email = $1 AND deleted_at IS NOT NULL AND %s RETURNING id, %s`,
+			service.table(),
+			tenantCondition(4),
+			userColumns),
+		request.Email,
+		service.clockService.Now(),
+		repository.GetActor(ctx),
+		repository.GetTenant(ctx)).Scan(row.fields(&userID)...)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to restore user", err)
+	}
+
+	user, err := row.decode(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.RestoreUserResponse{
+		User:   user,
+		Cursor: strconv.FormatInt(userID, 10),
+	}, nil
+}
+
+// PurgeDeletedUsers permanently deletes the soft deleted users that are deleted before the given time
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to purge the soft deleted users
+// Returns either the result of purging the users or error if something goes wrong.
+func (service *postgresRepositoryService) PurgeDeletedUsers(
+	ctx context.Context,
+	request *repository.PurgeDeletedUsersRequest) (*repository.PurgeDeletedUsersResponse, error) {
+	commandTag, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE deleted_at < $1 AND %s", service.table(), tenantCondition(2)),
+		request.DeletedBefore,
+		repository.GetTenant(ctx))
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to purge deleted users", err)
+	}
+
+	return &repository.PurgeDeletedUsersResponse{
+		PurgedCount: commandTag.RowsAffected(),
+	}, nil
+}
+
+// ListUsersByLabel lists all the users tagged with the given test label, including the soft deleted ones
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to list the users tagged with the test label
+// Returns either the users tagged with the test label or error if something goes wrong.
+func (service *postgresRepositoryService) ListUsersByLabel(
+	ctx context.Context,
+	request *repository.ListUsersByLabelRequest) (*repository.ListUsersByLabelResponse, error) {
+	query := service.createSelectQuery([]string{"email ~ $1", tenantCondition(2)}, []sortKey{{expression: "id", column: "id"}}, false, 0)
+	rows, err := service.pool.Query(ctx, query, models.GetTestLabelEmailPattern(request.Label), repository.GetTenant(ctx))
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list users by label", err)
+	}
+
+	defer rows.Close()
+
+	users := []models.UserWithCursor{}
+	for rows.Next() {
+		user, err := scanUserWithCursor(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list users by label", err)
+	}
+
+	return &repository.ListUsersByLabelResponse{
+		Users: users,
+	}, nil
+}
+
+// PurgeUsersByLabel permanently deletes all the users tagged with the given test label, including the soft deleted ones
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to purge the users tagged with the test label
+// Returns either the result of purging the users or error if something goes wrong.
+func (service *postgresRepositoryService) PurgeUsersByLabel(
+	ctx context.Context,
+	request *repository.PurgeUsersByLabelRequest) (*repository.PurgeUsersByLabelResponse, error) {
+	commandTag, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE email ~ $1 AND %s", service.table(), tenantCondition(2)),
+		models.GetTestLabelEmailPattern(request.Label),
+		repository.GetTenant(ctx))
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to purge users by label", err)
+	}
+
+	return &repository.PurgeUsersByLabelResponse{
+		PurgedCount: commandTag.RowsAffected(),
+	}, nil
+}
+
+// Search returns the list of users that matched the criteria. Only the users on the requested page are read, the
+// pagination is pushed into the queries.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria
+// Returns either the requested page of the users that matched the criteria or error if something goes wrong.
+// ArgumentError is returned if the After or Before cursor does not identify a user.
+func (service *postgresRepositoryService) Search(
+	ctx context.Context,
+	request *repository.SearchRequest) (*repository.SearchResponse, error) {
+	sortKeys, err := createSortKeys(request.SortingOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	readTenantID := repository.GetReadTenant(ctx)
+	conditions, arguments, err := createSearchConditions(
+		request.Emails,
+		request.IncludeDeleted,
+		searchFilter{readTenantID: readTenantID, labelSelector: request.LabelSelector, query: request.Query})
+	if err != nil {
+		return nil, err
+	}
+
+	// The users are ranked by how well they match the free-text query unless they are explicitly sorted
+	rankingQuery := ""
+	if len(request.SortingOptions) == 0 {
+		rankingQuery = request.Query
+	}
+
+	return newSearchPage(service, conditions, arguments, sortKeys, readTenantID, rankingQuery).read(ctx, request.Pagination)
+}
+
+// StreamSearch sends the users that matched the criteria one by one without loading all of them in memory
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria and the function to send the users to
+// Returns either the number of the sent users or error if something goes wrong.
+func (service *postgresRepositoryService) StreamSearch(
+	ctx context.Context,
+	request *repository.StreamSearchRequest) (*repository.StreamSearchResponse, error) {
+	query, arguments, err := service.createSearchQuery(request.Emails, request.SortingOptions, request.IncludeDeleted, searchFilter{
+		tenantID:      request.TenantID,
+		readTenantID:  repository.GetReadTenant(ctx),
+		createdAfter:  request.CreatedAfter,
+		createdBefore: request.CreatedBefore,
+		after:         request.After,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := service.pool.Query(ctx, query, arguments...)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to search users", err)
+	}
+
+	defer rows.Close()
+
+	// The rows are read from the connection as they are iterated, so a slow receiver holds the reading back
+	// instead of the users piling up in memory
+	var sentCount int64
+	for rows.Next() {
+		user, err := scanUserWithCursor(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = request.Send(user); err != nil {
+			return nil, commonErrors.NewUnknownErrorWithError("failed to send user", err)
+		}
+
+		sentCount++
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to search users", err)
+	}
+
+	return &repository.StreamSearchResponse{
+		SentCount: sentCount,
+	}, nil
+}
+
+// Ping verifies the repository can reach the underlying database
+// ctx: Mandatory The reference to the context
+// Returns error if the database is not reachable
+func (service *postgresRepositoryService) Ping(ctx context.Context) error {
+	if err := service.pool.Ping(ctx); err != nil {
+		return commonErrors.NewUnknownErrorWithError("could not ping postgres database", err)
+	}
+
+	return nil
+}
+
+// updateMemberships sets the memberships of the user in a single statement if the condition on its memberships holds
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// memberships: Mandatory. The expression the memberships are set to, $3 refers to the argument
+// condition: Mandatory. The condition on the memberships, $2 refers to the membership in the tenant
+// tenantID: Mandatory. The ID of the tenant the membership is updated in
+// membershipErr: Mandatory. The error to return if the user exists but the condition does not hold
+// argument: Mandatory. The argument of the memberships expression
+// Returns either the user after the update and its cursor or error if something goes wrong
+func (service *postgresRepositoryService) updateMemberships(
+	ctx context.Context,
+	email string,
+	memberships string,
+	condition string,
+	tenantID string,
+	membershipErr error,
+	argument interface{}) (models.User, string, error) {
+	tenantMembershipJSON, err := json.Marshal([]map[string]string{{"tenantID": tenantID}})
+	if err != nil {
+		return models.User{}, "", commonErrors.NewUnknownErrorWithError("failed to encode user membership", err)
+	}
+
+	var userID int64
+	var row userRow
+
+	err = service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf(
+			`UPDATE %s SET memberships = %s, updated_at = $4, updated_by = $5
+			WHERE email = $1 AND deleted_at IS NULL AND %s AND %s RETURNING id, %s`,
+			service.table(),
+			memberships,
+			condition,
+			tenantCondition(6),
+			userColumns),
+		email,
+		string(tenantMembershipJSON),
+		argument,
+		service.clockService.Now(),
+		repository.GetActor(ctx),
+		repository.GetTenant(ctx)).Scan(row.fields(&userID)...)
+	if err == pgx.ErrNoRows {
+		// No row is updated either because the user does not exist or because of its memberships
+		if _, err = service.readUser(ctx, email, repository.GetTenant(ctx)); err != nil {
+			return models.User{}, "", err
+		}
+
+		return models.User{}, "", membershipErr
+	} else if err != nil {
+		return models.User{}, "", commonErrors.NewUnknownErrorWithError("failed to update user memberships", err)
+	}
+
+	user, err := row.decode(userID)
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	return user, strconv.FormatInt(userID, 10), nil
+}
+
+// updateMFAMethods sets the MFA methods of the user in a single statement if the condition on its MFA methods holds
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// mfaMethods: Mandatory. The expression the MFA methods are set to, $3 refers to the argument
+// condition: Mandatory. The condition on the MFA methods, $2 refers to the method that matches the fields
+// fields: Mandatory. The fields of the method the condition refers to
+// mfaMethodErr: Mandatory. The error to return if the user exists but the condition does not hold
+// argument: Mandatory. The argument of the MFA methods expression
+// Returns either the user after the update and its cursor or error if something goes wrong
+func (service *postgresRepositoryService) updateMFAMethods(
+	ctx context.Context,
+	email string,
+	mfaMethods string,
+	condition string,
+	fields map[string]string,
+	mfaMethodErr error,
+	argument interface{}) (models.User, string, error) {
+	matchingMethodJSON, err := json.Marshal([]map[string]string{fields})
+	if err != nil {
+		return models.User{}, "", commonErrors.NewUnknownErrorWithError("failed to encode user MFA method", err)
+	}
+
+	var userID int64
+	var row userRow
+
+	err = service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf(
+			`UPDATE %s SET mfa_methods = %s, updated_at = $4, updated_by = $5
+			WHERE email = $1 AND deleted_at IS NULL AND %s AND %s RETURNING id, %s`,
+			service.table(),
+			mfaMethods,
+			condition,
+			tenantCondition(6),
+			userColumns),
+		email,
+		string(matchingMethodJSON),
+		argument,
+		service.clockService.Now(),
+		repository.GetActor(ctx),
+		repository.GetTenant(ctx)).Scan(row.fields(&userID)...)
+	if err == pgx.ErrNoRows {
+		// No row is updated either because the user does not exist or because of its MFA methods
+		if _, err = service.readUser(ctx, email, repository.GetTenant(ctx)); err != nil {
+			return models.User{}, "", err
+		}
+
+		return models.User{}, "", mfaMethodErr
+	} else if err != nil {
+		return models.User{}, "", commonErrors.NewUnknownErrorWithError("failed to update user MFA methods", err)
+	}
+
+	user, err := row.decode(userID)
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	return user, strconv.FormatInt(userID, 10), nil
+}
+
+// readUser reads the user unless it is soft deleted or belongs to another tenant
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// tenantID: Optional. The tenant the user must belong to, the user is matched whatever its tenant is if empty
+// Returns either the user or error if something goes wrong
+func (service *postgresRepositoryService) readUser(ctx context.Context, email string, tenantID string) (models.User, error) {
+	var userID int64
+	var row userRow
+
+	err := service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf("SELECT id, %s FROM %s WHERE email = $1 AND deleted_at IS NULL AND %s", userColumns, service.table(), tenantCondition(2)),
+		email,
+		tenantID).Scan(row.fields(&userID)...)
+	if err == pgx.ErrNoRows {
+		return models.User{}, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return models.User{}, commonErrors.NewUnknownErrorWithError("failed to retrieve user", err)
+	}
+
+	return row.decode(userID)
+}
+
+// readUserByID reads the user with the given ID unless it belongs to another tenant, or it is soft deleted and the soft
+// deleted users are not included
+// ctx: Mandatory The reference to the context
+// userID: Mandatory. The ID of the user
+// tenantID: Optional. The tenant the user must belong to, the user is matched whatever its tenant is if empty
+// includeDeleted: Mandatory. Whether the soft deleted user is matched as well
+// Returns either the user along with its email address or error if something goes wrong
+func (service *postgresRepositoryService) readUserByID(
+	ctx context.Context,
+	userID string,
+	tenantID string,
+	includeDeleted bool) (*repository.ReadUserResponse, error) {
+	id, err := strconv.ParseInt(userID, 10, 64)
+	if err != nil {
+		// The IDs that are not row IDs can not be the ID of any user
+		return nil, commonErrors.NewNotFoundError()
+	}
+
+	var email string
+	var row userRow
+
+	deletedCondition := "deleted_at IS NULL"
+	if includeDeleted {
+		deletedCondition = "TRUE"
+	}
+
+	err = service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf("SELECT id, email, %s FROM %s WHERE id = $1 AND %s AND %s", userColumns, service.table(), deletedCondition, tenantCondition(2)),
+		id,
+		tenantID).Scan(row.fields(&id, &email)...)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to retrieve user", err)
+	}
+
+	user, err := row.decode(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.ReadUserResponse{
+		Email: email,
+		User:  user,
+	}, nil
+}
+
+// createSearchQuery creates the query to find the users that matched the criteria with
+// emails: Optional. The email addresses to filter the users by
+// sortingOptions: Optional. The sorting options to apply
+// includeDeleted: Mandatory. Whether the soft deleted users should be matched as well
+// filter: Optional. The additional criteria only some of the searches support
+// Returns either the query and its arguments or error if sorting by any of the given fields is not supported
+func (service *postgresRepositoryService) createSearchQuery(
+	emails []string,
+	sortingOptions []models.SortingOptionPair,
+	includeDeleted bool,
+	filter searchFilter) (string, []interface{}, error) {
+	sortKeys, err := createSortKeys(sortingOptions)
+	if err != nil {
+		return "", nil, err
+	}
+
+	conditions, arguments, err := createSearchConditions(emails, includeDeleted, filter)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return service.createSelectQuery(conditions, sortKeys, false, 0), arguments, nil
+}
+
+// createSearchConditions creates the conditions the users that matched the criteria must meet
+// emails: Optional. The email addresses to filter the users by
+// includeDeleted: Mandatory. Whether the soft deleted users should be matched as well
+// filter: Optional. The additional criteria only some of the searches support
+// Returns either the conditions and their arguments or error if something goes wrong
+func createSearchConditions(
+	emails []string,
+	includeDeleted bool,
+	filter searchFilter) ([]string, []interface{}, error) {
+	arguments := []interface{}{}
+	conditions := []string{}
+
+	if len(filter.userIDs) > 0 {
+		// The users named by either their email addresses or their IDs are matched
+		arguments = append(arguments, emails, filter.userIDs)
+		conditions = append(conditions, fmt.Sprintf("(email = ANY($%d) OR id = ANY($%d))", len(arguments)-1, len(arguments)))
+	} else if len(emails) > 0 {
+		arguments = append(arguments, emails)
+		conditions = append(conditions, fmt.Sprintf("email = ANY($%d)", len(arguments)))
+	}
+
+	if filter.tenantID != "" {
+		tenantMembershipJSON, err := json.Marshal([]map[string]string{{"tenantID": filter.tenantID}})
+		if err != nil {
+			return nil, nil, commonErrors.NewUnknownErrorWithError("failed to encode user membership", err)
+		}
+
+		arguments = append(arguments, string(tenantMembershipJSON))
+		conditions = append(conditions, fmt.Sprintf("memberships @> $%d::jsonb", len(arguments)))
+	}
+
+	if filter.readTenantID != "" {
+		arguments = append(arguments, filter.readTenantID)
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", len(arguments)))
+	}
+
+	if filter.createdAfter != nil {
+		arguments = append(arguments, *filter.createdAfter)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(arguments)))
+	}
+
+	if filter.createdBefore != nil {
+		arguments = append(arguments, *filter.createdBefore)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(arguments)))
+	}
+
+	if filter.after != "" {
+		afterID, err := strconv.ParseInt(filter.after, 10, 64)
+		if err != nil {
+			return nil, nil, repository.NewInvalidCursorError(filter.after)
+		}
+
+		arguments = append(arguments, afterID)
+		conditions = append(conditions, fmt.Sprintf("id > $%d", len(arguments)))
+	}
+
+	for _, requirement := range filter.labelSelector {
+		arguments = append(arguments, requirement.Key)
+		keyArgument := len(arguments)
+
+		switch requirement.Operator {
+		case models.LabelSelectorOperatorIn:
+			arguments = append(arguments, requirement.Values)
+			conditions = append(conditions, fmt.Sprintf("labels->>$%d::text = ANY($%d::text[])", keyArgument, len(arguments)))
+
+		case models.LabelSelectorOperatorNotIn:
+			// The users without the label match as well, like they do in MongoDB
+			arguments = append(arguments, requirement.Values)
+			conditions = append(conditions, fmt.Sprintf("COALESCE(labels->>$%d::text <> ALL($%d::text[]), true)", keyArgument, len(arguments)))
+
+		case models.LabelSelectorOperatorDoesNotExist:
+			conditions = append(conditions, fmt.Sprintf("NOT labels ? $%d::text", keyArgument))
+
+		default:
+			conditions = append(conditions, fmt.Sprintf("labels ? $%d::text", keyArgument))
+		}
+	}
+
+	// The wildcards in the query are escaped, so the query only matches the email addresses starting with it as it is
+	// regardless of the case. The prefix is matched through the pattern index on the lower case email addresses.
+	if filter.query != "" {
+		arguments = append(arguments, likeEscaper.Replace(strings.ToLower(filter.query))+"%")
+		conditions = append(conditions, fmt.Sprintf("lower(email) LIKE $%d", len(arguments)))
+	}
+
+	if !includeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
+	return conditions, arguments, nil
+}
+
+// scanUserWithCursor reads the user from the current row of the search query, the row ID is used as the cursor
+// rows: Mandatory. The rows returned by the search query
+// Returns either the user with cursor or error if something goes wrong
+func scanUserWithCursor(rows pgx.Rows) (models.UserWithCursor, error) {
+	var userID int64
+	var email string
+	var createdAt time.Time
+	var deletedAt *time.Time
+	var row userRow
+	if err := rows.Scan(row.fields(&userID, &email, &createdAt, &deletedAt)...); err != nil {
+		return models.UserWithCursor{}, commonErrors.NewUnknownErrorWithError("failed to decode user", err)
+	}
+
+	user, err := row.decode(userID)
+	if err != nil {
+		return models.UserWithCursor{}, err
+	}
+
+	cursor := strconv.FormatInt(userID, 10)
+
+	return models.UserWithCursor{
+		UserID:    cursor,
+		Email:     email,
+		User:      user,
+		Cursor:    cursor,
+		CreatedAt: createdAt,
+		DeletedAt: deletedAt,
+	}, nil
+}
+
+// fields returns the destinations the columns of the user are scanned into, after the given leading destinations
+// leading: Optional. The destinations of the columns selected before the user columns
+// Returns the destinations to scan the row into
+func (row *userRow) fields(leading ...interface{}) []interface{} {
+	return append(
+		leading,
+		&row.membershipsJSON,
+		&row.emailVerified,
+		&row.emailVerificationTokenHash,
+		&row.emailVerificationExpiresAt,
+		&row.failedLoginAttempts,
+		&row.lockedAt,
+		&row.mfaMethodsJSON,
+		&row.labelsJSON,
+		&row.createdAt,
+		&row.updatedAt,
+		&row.createdBy,
+		&row.updatedBy,
+		&row.tenantID,
+		&row.avatarObjectKey,
+		&row.avatarContentHash)
+}
+
+// decode decodes the user from the scanned columns
+// userID: Mandatory. The ID of the row the user is scanned from
+// Returns either the user or error if something goes wrong
+func (row *userRow) decode(userID int64) (models.User, error) {
+	storedMemberships := []membership{}
+	if err := json.Unmarshal(row.membershipsJSON, &storedMemberships); err != nil {
+		return models.User{}, commonErrors.NewUnknownErrorWithError("failed to decode user memberships", err)
+	}
+
+	memberships := make([]models.TenantMembership, 0, len(storedMemberships))
+	for _, membership := range storedMemberships {
+		memberships = append(memberships, models.TenantMembership{
+			TenantID: membership.TenantID,
+			Role:     membership.Role,
+			JoinedAt: membership.JoinedAt,
+		})
+	}
+
+	storedMFAMethods := []mfaMethod{}
+	if err := json.Unmarshal(row.mfaMethodsJSON, &storedMFAMethods); err != nil {
+		return models.User{}, commonErrors.NewUnknownErrorWithError("failed to decode user MFA methods", err)
+	}
+
+	// The users that never enrolled have no MFA methods rather than an empty list, like the users that are not locked
+	// have no lock time
+	var mfaMethods []models.MFAMethod
+	for _, method := range storedMFAMethods {
+		mfaMethods = append(mfaMethods, models.MFAMethod{
+			MethodID:             method.MethodID,
+			Type:                 models.MFAMethodType(method.Type),
+			Name:                 method.Name,
+			TOTPSecretRef:        method.TOTPSecretRef,
+			WebAuthnCredentialID: method.WebAuthnCredentialID,
+			RecoveryCodeHashes:   method.RecoveryCodeHashes,
+			EnrolledAt:           method.EnrolledAt,
+		})
+	}
+
+	user := models.User{
+		ID:                         strconv.FormatInt(userID, 10),
+		Memberships:                memberships,
+		EmailVerified:              row.emailVerified,
+		EmailVerificationExpiresAt: row.emailVerificationExpiresAt,
+		FailedLoginAttempts:        row.failedLoginAttempts,
+		LockedAt:                   row.lockedAt,
+		MFAMethods:                 mfaMethods,
+		CreatedAt:                  &row.createdAt,
+		UpdatedAt:                  row.updatedAt,
+		CreatedBy:                  row.createdBy,
+		UpdatedBy:                  row.updatedBy,
+		TenantID:                   row.tenantID,
+	}
+
+	if err := json.Unmarshal(row.labelsJSON, &user.Labels); err != nil {
+		return models.User{}, commonErrors.NewUnknownErrorWithError("failed to decode user labels", err)
+	}
+
+	// The users without labels have no labels rather than an empty map, like they do in MongoDB
+	if len(user.Labels) == 0 {
+		user.Labels = nil
+	}
+
+	if row.emailVerificationTokenHash != nil {
+		user.EmailVerificationTokenHash = *row.emailVerificationTokenHash
+	}
+
+	if row.avatarObjectKey != nil {
+		user.Avatar = &models.Avatar{ObjectKey: *row.avatarObjectKey}
+		if row.avatarContentHash != nil {
+			user.Avatar.ContentHash = *row.avatarContentHash
+		}
+	}
+
+	return user, nil
+}
+
+// encodeLabels encodes the labels stored in the labels column
+// labels: Optional. The labels of the user
+// Returns either the JSON encoded labels or error if something goes wrong
+func encodeLabels(labels map[string]string) (string, error) {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	labelsJSON, err := json.Marshal(labels)
+	if err != nil {
+		return "", commonErrors.NewUnknownErrorWithError("failed to encode user labels", err)
+	}
+
+	return string(labelsJSON), nil
+}
+
+// tenantCondition returns the condition that matches the users of the tenant the argument refers to, or every user
+// if the argument is empty, e.g. in the single-tenant mode
+// argument: Mandatory. The position of the argument the tenant is passed in
+// Returns the condition
+func tenantCondition(argument int) string {
+	return fmt.Sprintf("($%[1]d = '' OR tenant_id = $%[1]d)", argument)
+}
+
+func (service *postgresRepositoryService) table() string {
+	return pgx.Identifier{service.tableName}.Sanitize()
+}
@@ -0,0 +1,34 @@
+// Package regional implements the repository service that persists every user in the database of the region the user
+// resides in
+package regional
+
+import (
+	"github.com/decentralized-cloud/user/services/configuration"
+)
+
+type regionConfigurationService struct {
+	configuration.ConfigurationContract
+
+	connectionString string
+}
+
+// NewRegionConfigurationService creates new instance of the configuration service the repository of a region is
+// created with. The database connection string is the one routed to the region, all the other options are read from
+// the given configuration service.
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// connectionString: Mandatory. The connection string of the database of the region
+// Returns the new service
+func NewRegionConfigurationService(
+	configurationService configuration.ConfigurationContract,
+	connectionString string) configuration.ConfigurationContract {
+	return &regionConfigurationService{
+		ConfigurationContract: configurationService,
+		connectionString:      connectionString,
+	}
+}
+
+// GetDatabaseConnectionString retrieves the connection string of the database of the region
+// Returns the database connection string or error if something goes wrong
+func (service *regionConfigurationService) GetDatabaseConnectionString() (string, error) {
+	return service.connectionString, nil
+}
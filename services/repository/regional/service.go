@@ -0,0 +1,892 @@
+// Package regional implements the repository service that persists every user in the database of the region the user
+// resides in
+package regional
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/repository"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+// cursorSeparator separates the region from the cursor of the users persisted in the regions other than the default
+// region, so the cursors stay unique across the regions
+const cursorSeparator = ":"
+
+type regionalRepositoryService struct {
+	defaultRegion      string
+	regions            []string
+	repositoryServices map[string]repository.RepositoryContract
+}
+
+// NewRegionalRepositoryService creates new instance of the regionalRepositoryService, setting up all dependencies and returns the instance.
+// Every user is persisted in the repository of the region set as the data residency of the user, or the repository of
+// the default region if the user does not ask for a specific region. The existing users are looked up in the
+// repository of the default region first, then in the repositories of the other regions.
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// repositoryServices: Mandatory. The repository of every configured region keyed by the region name. It must contain the repository of the default region
+// Returns the new service or error if something goes wrong
+func NewRegionalRepositoryService(
+	configurationService configuration.ConfigurationContract,
+	repositoryServices map[string]repository.RepositoryContract) (repository.RepositoryContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if len(repositoryServices) == 0 {
+		return nil, commonErrors.NewArgumentNilError("repositoryServices", "repositoryServices is required")
+	}
+
+	defaultRegion, err := configurationService.GetDataResidencyDefaultRegion()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := repositoryServices[defaultRegion]; !ok {
+		return nil, commonErrors.NewArgumentError("repositoryServices", fmt.Sprintf("the repository of the default region %s is required", defaultRegion))
+	}
+
+	regions := make([]string, 0, len(repositoryServices))
+	for region, repositoryService := range repositoryServices {
+		if repositoryService == nil {
+			return nil, commonErrors.NewArgumentNilError("repositoryServices", fmt.Sprintf("the repository of the region %s is required", region))
+		}
+
+		if region != defaultRegion {
+			regions = append(regions, region)
+		}
+	}
+
+	sort.Strings(regions)
+
+	return &regionalRepositoryService{
+		defaultRegion:      defaultRegion,
+		regions:            append([]string{defaultRegion}, regions...),
+		repositoryServices: repositoryServices,
+	}, nil
+}
+
+// CreateUser creates a new user in the repository of the region the user resides in. The user is not created if a user
+// with the same email address exists in any other region.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to create a new user
+// Returns either the result of creating new user or error if something goes wrong.
+func (service *regionalRepositoryService) CreateUser(
+	ctx context.Context,
+	request *repository.CreateUserRequest) (*repository.CreateUserResponse, error) {
+	region := request.User.DataResidency
+	if region == "" {
+		region = service.defaultRegion
+	}
+
+	repositoryService, ok := service.repositoryServices[region]
+	if !ok {
+		return nil, commonErrors.NewArgumentError("User.DataResidency", fmt.Sprintf("the region %s is not configured", region))
+	}
+
+	if err := service.ensureEmailIsFree(ctx, region, request.Email); err != nil {
+		return nil, err
+	}
+
+	response, err := repositoryService.CreateUser(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	service.qualifyUserModel(region, &response.User)
+	response.Cursor = service.qualifyCursor(region, response.Cursor)
+
+	return response, nil
+}
+
+// ReadUser read an existing user from the region the user resides in
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read an existing user
+// Returns either the result of reading an existing user or error if something goes wrong.
+func (service *regionalRepositoryService) ReadUser(
+	ctx context.Context,
+	request *repository.ReadUserRequest) (*repository.ReadUserResponse, error) {
+	if request.UserID != "" {
+		// The IDs of the users are qualified with their region, so the user is only read from that region
+		region, userID := service.unqualifyCursor(request.UserID)
+		response, err := service.repositoryServices[region].ReadUser(ctx, &repository.ReadUserRequest{
+			UserID:         userID,
+			Fields:         request.Fields,
+			IncludeDeleted: request.IncludeDeleted,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		service.qualifyUserModel(region, &response.User)
+
+		return response, nil
+	}
+
+	region, response, err := service.readUser(ctx, request.Email, request.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	service.qualifyUserModel(region, &response.User)
+
+	return response, nil
+}
+
+// ReadUsers reads the existing users with the given email addresses from all the regions, and the users with the given
+// IDs from the regions their IDs are qualified with, with a single query per region
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the existing users
+// Returns either the users found or error if something goes wrong. The users that are not found are left out.
+func (service *regionalRepositoryService) ReadUsers(
+	ctx context.Context,
+	request *repository.ReadUsersRequest) (*repository.ReadUsersResponse, error) {
+	userIDs := map[string][]string{}
+	for _, qualifiedUserID := range request.UserIDs {
+		region, userID := service.unqualifyCursor(qualifiedUserID)
+		userIDs[region] = append(userIDs[region], userID)
+	}
+
+	users := []models.UserWithCursor{}
+	for _, region := range service.regions {
+		if len(request.Emails) == 0 && len(userIDs[region]) == 0 {
+			continue
+		}
+
+		response, err := service.repositoryServices[region].ReadUsers(ctx, &repository.ReadUsersRequest{
+			Emails:  request.Emails,
+			UserIDs: userIDs[region],
+			Fields:  request.Fields,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range response.Users {
+			users = append(users, service.qualifyUser(region, user))
+		}
+	}
+
+	return &repository.ReadUsersResponse{
+		Users: users,
+	}, nil
+}
+
+// UpdateUser update an existing user in the region the user resides in. The update is rejected if it asks for the user
+// to reside in another region, as the user cannot be moved across the regions.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to update an existing user
+// Returns either the result of updateing an existing user or error if something goes wrong.
+func (service *regionalRepositoryService) UpdateUser(
+	ctx context.Context,
+	request *repository.UpdateUserRequest) (*repository.UpdateUserResponse, error) {
+	region, _, err := service.readUser(ctx, request.Email, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.User.DataResidency != "" && request.User.DataResidency != region {
+		return nil, repository.NewDataResidencyViolationError(request.Email, region, request.User.DataResidency)
+	}
+
+	response, err := service.repositoryServices[region].UpdateUser(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	service.qualifyUserModel(region, &response.User)
+	response.Cursor = service.qualifyCursor(region, response.Cursor)
+
+	return response, nil
+}
+
+// ChangeEmail changes the email address of an existing user in the region the user resides in. The email address is
+// not changed if a user with the new email address exists in any other region.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the current and the new email addresses of the user
+// Returns either the user after its email address is changed or error if something goes wrong.
+func (service *regionalRepositoryService) ChangeEmail(
+	ctx context.Context,
+	request *repository.ChangeEmailRequest) (*repository.ChangeEmailResponse, error) {
+	region, _, err := service.readUser(ctx, request.Email, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = service.ensureEmailIsFree(ctx, region, request.NewEmail); err != nil {
+		return nil, err
+	}
+
+	response, err := service.repositoryServices[region].ChangeEmail(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	service.qualifyUserModel(region, &response.User)
+	response.Cursor = service.qualifyCursor(region, response.Cursor)
+
+	return response, nil
+}
+
+// ReadUserPreferences reads the preferences of an existing user from the region the user resides in
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to read the preferences of an existing user
+// Returns either the preferences of the user or error if something goes wrong.
+func (service *regionalRepositoryService) ReadUserPreferences(
+	ctx context.Context,
+	request *repository.ReadUserPreferencesRequest) (response *repository.ReadUserPreferencesResponse, err error) {
+	_, err = service.findRegion(func(repositoryService repository.RepositoryContract) error {
+		response, err = repositoryService.ReadUserPreferences(ctx, request)
+
+		return err
+	})
+
+	return
+}
+
+// UpdateUserPreferences merges the preferences into the preferences of an existing user in the region the user resides in
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the preferences to set and the keys of the preferences to remove
+// Returns either the preferences of the user after they are merged or error if something goes wrong.
+func (service *regionalRepositoryService) UpdateUserPreferences(
+	ctx context.Context,
+	request *repository.UpdateUserPreferencesRequest) (response *repository.UpdateUserPreferencesResponse, err error) {
+	_, err = service.findRegion(func(repositoryService repository.RepositoryContract) error {
+		response, err = repositoryService.UpdateUserPreferences(ctx, request)
+
+		return err
+	})
+
+	return
+}
+
+// AddUserToTenant adds the membership in the tenant to an existing user in the region the user resides in
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the membership to add
+// Returns either the user after the membership is added or error if something goes wrong.
+func (service *regionalRepositoryService) AddUserToTenant(
+	ctx context.Context,
+	request *repository.AddUserToTenantRequest) (*repository.AddUserToTenantResponse, error) {
+	var response *repository.AddUserToTenantResponse
+
+	region, err := service.findRegion(func(repositoryService repository.RepositoryContract) (err error) {
+		response, err = repositoryService.AddUserToTenant(ctx, request)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	service.qualifyUserModel(region, &response.User)
+	response.Cursor = service.qualifyCursor(region, response.Cursor)
+
+	return response, nil
+}
+
+// RemoveUserFromTenant removes the membership in the tenant from an existing user in the region the user resides in
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the tenant to remove the membership of
+// Returns either the user after the membership is removed or error if something goes wrong.
+func (service *regionalRepositoryService) RemoveUserFromTenant(
+	ctx context.Context,
+	request *repository.RemoveUserFromTenantRequest) (*repository.RemoveUserFromTenantResponse, error) {
+	var response *repository.RemoveUserFromTenantResponse
+
+	region, err := service.findRegion(func(repositoryService repository.RepositoryContract) (err error) {
+		response, err = repositoryService.RemoveUserFromTenant(ctx, request)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	service.qualifyUserModel(region, &response.User)
+	response.Cursor = service.qualifyCursor(region, response.Cursor)
+
+	return response, nil
+}
+
+// SetEmailVerificationToken sets the pending email verification token of an existing user in the region the user
+// resides in
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the hash of the token and the time it expires at
+// Returns either the user after the token is set or error if something goes wrong.
+func (service *regionalRepositoryService) SetEmailVerificationToken(
+	ctx context.Context,
+	request *repository.SetEmailVerificationTokenRequest) (*repository.SetEmailVerificationTokenResponse, error) {
+	var response *repository.SetEmailVerificationTokenResponse
+
+	region, err := service.findRegion(func(repositoryService repository.RepositoryContract) (err error) {
+		response, err = repositoryService.SetEmailVerificationToken(ctx, request)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	service.qualifyUserModel(region, &response.User)
+	response.Cursor = service.qualifyCursor(region, response.Cursor)
+
+	return response, nil
+}
+
+// VerifyEmail marks the email address of an existing user as verified in the region the user resides in
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the hash of the pending verification token
+// Returns either the user after the email address is verified or error if something goes wrong.
+func (service *regionalRepositoryService) VerifyEmail(
+	ctx context.Context,
+	request *repository.VerifyEmailRequest) (*repository.VerifyEmailResponse, error) {
+	var response *repository.VerifyEmailResponse
+
+	region, err := service.findRegion(func(repositoryService repository.RepositoryContract) (err error) {
+		response, err = repositoryService.VerifyEmail(ctx, request)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	service.qualifyUserModel(region, &response.User)
+	response.Cursor = service.qualifyCursor(region, response.Cursor)
+
+	return response, nil
+}
+
+// RecordLoginAttempt records the outcome of a login attempt of an existing user in the region the user resides in
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the outcome of the attempt and the lockout threshold
+// Returns either the user after the attempt is recorded or error if something goes wrong.
+func (service *regionalRepositoryService) RecordLoginAttempt(
+	ctx context.Context,
+	request *repository.RecordLoginAttemptRequest) (*repository.RecordLoginAttemptResponse, error) {
+	var response *repository.RecordLoginAttemptResponse
+
+	region, err := service.findRegion(func(repositoryService repository.RepositoryContract) (err error) {
+		response, err = repositoryService.RecordLoginAttempt(ctx, request)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	service.qualifyUserModel(region, &response.User)
+	response.Cursor = service.qualifyCursor(region, response.Cursor)
+
+	return response, nil
+}
+
+// UnlockUser unlocks an existing user in the region the user resides in
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to unlock an existing user
+// Returns either the user after it is unlocked or error if something goes wrong.
+func (service *regionalRepositoryService) UnlockUser(
+	ctx context.Context,
+	request *repository.UnlockUserRequest) (*repository.UnlockUserResponse, error) {
+	var response *repository.UnlockUserResponse
+
+	region, err := service.findRegion(func(repositoryService repository.RepositoryContract) (err error) {
+		response, err = repositoryService.UnlockUser(ctx, request)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	service.qualifyUserModel(region, &response.User)
+	response.Cursor = service.qualifyCursor(region, response.Cursor)
+
+	return response, nil
+}
+
+// AddMFAMethod adds the MFA method to an existing user in the region the user resides in
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the method to add
+// Returns either the user after the method is added or error if something goes wrong.
+func (service *regionalRepositoryService) AddMFAMethod(
+	ctx context.Context,
+	request *repository.AddMFAMethodRequest) (*repository.AddMFAMethodResponse, error) {
+	var response *repository.AddMFAMethodResponse
+
+	region, err := service.findRegion(func(repositoryService repository.RepositoryContract) (err error) {
+		response, err = repositoryService.AddMFAMethod(ctx, request)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	service.qualifyUserModel(region, &response.User)
+	response.Cursor = service.qualifyCursor(region, response.Cursor)
+
+	return response, nil
+}
+
+// RemoveMFAMethod removes the MFA method from an existing user in the region the user resides in
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the ID of the method to remove
+// Returns either the user after the method is removed or error if something goes wrong.
+func (service *regionalRepositoryService) RemoveMFAMethod(
+	ctx context.Context,
+	request *repository.RemoveMFAMethodRequest) (*repository.RemoveMFAMethodResponse, error) {
+	var response *repository.RemoveMFAMethodResponse
+
+	region, err := service.findRegion(func(repositoryService repository.RepositoryContract) (err error) {
+		response, err = repositoryService.RemoveMFAMethod(ctx, request)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	service.qualifyUserModel(region, &response.User)
+	response.Cursor = service.qualifyCursor(region, response.Cursor)
+
+	return response, nil
+}
+
+// SetUserAvatar sets the avatar of an existing user in the region the user resides in
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the key and the hash of the stored avatar image
+// Returns either the user after the avatar is set or error if something goes wrong.
+func (service *regionalRepositoryService) SetUserAvatar(
+	ctx context.Context,
+	request *repository.SetUserAvatarRequest) (*repository.SetUserAvatarResponse, error) {
+	var response *repository.SetUserAvatarResponse
+
+	region, err := service.findRegion(func(repositoryService repository.RepositoryContract) (err error) {
+		response, err = repositoryService.SetUserAvatar(ctx, request)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	service.qualifyUserModel(region, &response.User)
+	response.Cursor = service.qualifyCursor(region, response.Cursor)
+
+	return response, nil
+}
+
+// DeleteUser delete an existing user from the region the user resides in
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to delete an existing user
+// Returns either the result of deleting an existing user or error if something goes wrong.
+func (service *regionalRepositoryService) DeleteUser(
+	ctx context.Context,
+	request *repository.DeleteUserRequest) (response *repository.DeleteUserResponse, err error) {
+	_, err = service.findRegion(func(repositoryService repository.RepositoryContract) error {
+		response, err = repositoryService.DeleteUser(ctx, request)
+
+		return err
+	})
+
+	return
+}
+
+// RestoreUser restores an existing soft deleted user in the region the user resides in
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to restore an existing soft deleted user
+// Returns either the result of restoring the user or error if something goes wrong.
+func (service *regionalRepositoryService) RestoreUser(
+	ctx context.Context,
+	request *repository.RestoreUserRequest) (*repository.RestoreUserResponse, error) {
+	var response *repository.RestoreUserResponse
+
+	region, err := service.findRegion(func(repositoryService repository.RepositoryContract) (err error) {
+		response, err = repositoryService.RestoreUser(ctx, request)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	service.qualifyUserModel(region, &response.User)
+	response.Cursor = service.qualifyCursor(region, response.Cursor)
+
+	return response, nil
+}
+
+// PurgeDeletedUsers permanently deletes the soft deleted users that are deleted before the given time from all the regions
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to purge the soft deleted users
+// Returns either the result of purging the users or error if something goes wrong.
+func (service *regionalRepositoryService) PurgeDeletedUsers(
+	ctx context.Context,
+	request *repository.PurgeDeletedUsersRequest) (*repository.PurgeDeletedUsersResponse, error) {
+	response := &repository.PurgeDeletedUsersResponse{}
+
+	for _, region := range service.regions {
+		regionResponse, err := service.repositoryServices[region].PurgeDeletedUsers(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		response.PurgedCount += regionResponse.PurgedCount
+	}
+
+	return response, nil
+}
+
+// ListUsersByLabel lists all the users tagged with the given test label in all the regions
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to list the users tagged with the test label
+// Returns either the users tagged with the test label or error if something goes wrong.
+func (service *regionalRepositoryService) ListUsersByLabel(
+	ctx context.Context,
+	request *repository.ListUsersByLabelRequest) (*repository.ListUsersByLabelResponse, error) {
+	response := &repository.ListUsersByLabelResponse{Users: []models.UserWithCursor{}}
+
+	for _, region := range service.regions {
+		regionResponse, err := service.repositoryServices[region].ListUsersByLabel(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range regionResponse.Users {
+			user.User.DataResidency = region
+			response.Users = append(response.Users, user)
+		}
+	}
+
+	return response, nil
+}
+
+// PurgeUsersByLabel permanently deletes all the users tagged with the given test label from all the regions
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request to purge the users tagged with the test label
+// Returns either the result of purging the users or error if something goes wrong.
+func (service *regionalRepositoryService) PurgeUsersByLabel(
+	ctx context.Context,
+	request *repository.PurgeUsersByLabelRequest) (*repository.PurgeUsersByLabelResponse, error) {
+	response := &repository.PurgeUsersByLabelResponse{}
+
+	for _, region := range service.regions {
+		regionResponse, err := service.repositoryServices[region].PurgeUsersByLabel(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		response.PurgedCount += regionResponse.PurgedCount
+	}
+
+	return response, nil
+}
+
+// Search returns the list of users that matched the criteria in all the regions. If more than one region is
+// configured, all the matched users are read from every region and merged before the pagination is applied.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria
+// Returns the list of users that matched the criteria
+func (service *regionalRepositoryService) Search(
+	ctx context.Context,
+	request *repository.SearchRequest) (*repository.SearchResponse, error) {
+	if len(service.regions) == 1 {
+		response, err := service.repositoryServices[service.defaultRegion].Search(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		for index := range response.Users {
+			response.Users[index].User.DataResidency = service.defaultRegion
+		}
+
+		return response, nil
+	}
+
+	users := []models.UserWithCursor{}
+	for _, region := range service.regions {
+		response, err := service.repositoryServices[region].Search(ctx, &repository.SearchRequest{
+			SortingOptions: request.SortingOptions,
+			Emails:         request.Emails,
+			IncludeDeleted: request.IncludeDeleted,
+			LabelSelector:  request.LabelSelector,
+			Query:          request.Query,
+			Fields:         request.Fields,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range response.Users {
+			users = append(users, service.qualifyUser(region, user))
+		}
+	}
+
+	sortUsers(users, request.SortingOptions)
+	if len(request.SortingOptions) == 0 {
+		repository.SortByRelevance(users, request.Query)
+	}
+
+	return repository.Paginate(users, request.Pagination)
+}
+
+// StreamSearch sends the users that matched the criteria in all the regions one by one without loading all of them in
+// memory. The users are sent region by region, so they are only sorted within each region. Resuming after a cursor
+// skips the regions the users before the cursor are sent from.
+// ctx: Mandatory The reference to the context
+// request: Mandatory. The request contains the search criteria and the function to send the users to
+// Returns either the number of the sent users or error if something goes wrong.
+func (service *regionalRepositoryService) StreamSearch(
+	ctx context.Context,
+	request *repository.StreamSearchRequest) (*repository.StreamSearchResponse, error) {
+	response := &repository.StreamSearchResponse{}
+	afterRegion, after := service.unqualifyCursor(request.After)
+	afterRegionReached := request.After == ""
+
+	for _, region := range service.regions {
+		region := region
+
+		regionAfter := ""
+		if !afterRegionReached {
+			if region != afterRegion {
+				continue
+			}
+
+			afterRegionReached = true
+			regionAfter = after
+		}
+
+		regionResponse, err := service.repositoryServices[region].StreamSearch(ctx, &repository.StreamSearchRequest{
+			SortingOptions: request.SortingOptions,
+			Emails:         request.Emails,
+			IncludeDeleted: request.IncludeDeleted,
+			TenantID:       request.TenantID,
+			CreatedAfter:   request.CreatedAfter,
+			CreatedBefore:  request.CreatedBefore,
+			After:          regionAfter,
+			Send: func(user models.UserWithCursor) error {
+				return request.Send(service.qualifyUser(region, user))
+			},
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		response.SentCount += regionResponse.SentCount
+	}
+
+	return response, nil
+}
+
+// Ping verifies the repository can reach the databases of all the regions
+// ctx: Mandatory The reference to the context
+// Returns error if the database of any region is not reachable
+func (service *regionalRepositoryService) Ping(ctx context.Context) error {
+	for _, region := range service.regions {
+		if err := service.repositoryServices[region].Ping(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readUser reads the user from the region the user resides in
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// fields: Optional. The names of the user fields the caller needs, all the fields are read if empty
+// Returns either the region the user resides in and the user or error if something goes wrong
+func (service *regionalRepositoryService) readUser(
+	ctx context.Context,
+	email string,
+	fields []string) (string, *repository.ReadUserResponse, error) {
+	var response *repository.ReadUserResponse
+
+	region, err := service.findRegion(func(repositoryService repository.RepositoryContract) (err error) {
+		response, err = repositoryService.ReadUser(ctx, &repository.ReadUserRequest{
+			Email:  email,
+			Fields: fields,
+		})
+
+		return err
+	})
+
+	return region, response, err
+}
+
+// ensureEmailIsFree fails with AlreadyExistsError if a user with the email address exists in any region other than
+// the given one, the repository of the given region detects the users with the same email address by itself
+// ctx: Mandatory The reference to the context
+// region: Mandatory. The region the user with the email address is persisted in
+// email: Mandatory. The email address
+// Returns error if the email address is taken or something goes wrong
+func (service *regionalRepositoryService) ensureEmailIsFree(ctx context.Context, region string, email string) error {
+	for _, otherRegion := range service.regions {
+		if otherRegion == region {
+			continue
+		}
+
+		_, err := service.repositoryServices[otherRegion].ReadUser(ctx, &repository.ReadUserRequest{
+			Email: email,
+		})
+
+		if err == nil {
+			return commonErrors.NewAlreadyExistsError()
+		}
+
+		if !commonErrors.IsNotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findRegion makes the operation on the repository of every region, starting from the default region, until the
+// operation does not fail because the user is not found in the region
+// operation: Mandatory. The operation to make on the repository of the region
+// Returns either the region the operation succeeded in or error if the operation failed in all the regions
+func (service *regionalRepositoryService) findRegion(operation func(repositoryService repository.RepositoryContract) error) (string, error) {
+	var err error
+
+	for _, region := range service.regions {
+		if err = operation(service.repositoryServices[region]); !commonErrors.IsNotFoundError(err) {
+			return region, err
+		}
+	}
+
+	return "", err
+}
+
+// qualifyUser sets the region the user resides in and qualifies the user ID and the cursor of the user with the region
+// region: Mandatory. The region the user is read from
+// user: Mandatory. The user read from the repository of the region
+// Returns the qualified user
+func (service *regionalRepositoryService) qualifyUser(region string, user models.UserWithCursor) models.UserWithCursor {
+	service.qualifyUserModel(region, &user.User)
+	user.UserID = service.qualifyCursor(region, user.UserID)
+	user.Cursor = service.qualifyCursor(region, user.Cursor)
+
+	return user
+}
+
+// qualifyUserModel sets the region the user resides in and qualifies the ID of the user with the region, so the IDs
+// stay unique across the regions and the users read by their ID are read from their region
+// region: Mandatory. The region the user is read from
+// user: Mandatory. The user read from the repository of the region
+func (service *regionalRepositoryService) qualifyUserModel(region string, user *models.User) {
+	user.DataResidency = region
+	if user.ID != "" {
+		user.ID = service.qualifyCursor(region, user.ID)
+	}
+}
+
+// qualifyCursor prefixes the cursor read from the repository of the region other than the default region with the
+// region. The cursors of the default region are left as they are, so they do not change when the regions are added.
+// region: Mandatory. The region the cursor is read from
+// cursor: Mandatory. The cursor read from the repository of the region
+// Returns the qualified cursor
+func (service *regionalRepositoryService) qualifyCursor(region, cursor string) string {
+	if region == service.defaultRegion {
+		return cursor
+	}
+
+	return region + cursorSeparator + cursor
+}
+
+// unqualifyCursor splits the qualified cursor into the region the cursor is read from and the cursor read from the
+// repository of the region. The cursors without a configured region prefix are read from the default region.
+// cursor: Mandatory. The qualified cursor
+// Returns the region and the cursor read from the repository of the region
+func (service *regionalRepositoryService) unqualifyCursor(cursor string) (string, string) {
+	parts := strings.SplitN(cursor, cursorSeparator, 2)
+	if len(parts) == 2 {
+		if _, ok := service.repositoryServices[parts[0]]; ok {
+			return parts[0], parts[1]
+		}
+	}
+
+	return service.defaultRegion, cursor
+}
+
+// sortUsers sorts the users merged from all the regions by the email address, the creation and update times and
+// the callers that created and updated them if the sorting options ask for it. The users are kept in the region order
+// otherwise.
+// users: Mandatory. The users merged from all the regions
+// sortingOptions: Optional. The sorting options of the search
+func sortUsers(users []models.UserWithCursor, sortingOptions []models.SortingOptionPair) {
+	sort.SliceStable(users, func(i, j int) bool {
+		for _, sortingOption := range sortingOptions {
+			if comparison := compareUsers(users[i], users[j], sortingOption.Name); comparison != 0 {
+				return (comparison < 0) == (sortingOption.Direction == models.Ascending)
+			}
+		}
+
+		return false
+	})
+}
+
+// compareUsers compares the users by the field, the users are equal if they can not be sorted by the field. The users
+// that were not updated since the update time is recorded are sorted before the updated ones, like the repositories sort them.
+func compareUsers(first models.UserWithCursor, second models.UserWithCursor, field string) int {
+	switch field {
+	case "email":
+		return strings.Compare(first.Email, second.Email)
+
+	case "createdAt":
+		return compareTimes(&first.CreatedAt, &second.CreatedAt)
+
+	case "updatedAt":
+		return compareTimes(first.User.UpdatedAt, second.User.UpdatedAt)
+
+	case "createdBy":
+		return strings.Compare(first.User.CreatedBy, second.User.CreatedBy)
+
+	case "updatedBy":
+		return strings.Compare(first.User.UpdatedBy, second.User.UpdatedBy)
+
+	default:
+		return 0
+	}
+}
+
+func compareTimes(first *time.Time, second *time.Time) int {
+	switch {
+	case first == nil && second == nil:
+		return 0
+
+	case first == nil:
+		return -1
+
+	case second == nil:
+		return 1
+
+	case first.Before(*second):
+		return -1
+
+	case first.After(*second):
+		return 1
+
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,405 @@
+package regional_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/repository"
+	repositoryMock "github.com/decentralized-cloud/user/services/repository/mock"
+	"github.com/decentralized-cloud/user/services/repository/regional"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestRegionalRepositoryService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Regional Repository Service Tests")
+}
+
+var _ = Describe("Regional Repository Service Tests", func() {
+	var (
+		mockCtrl                     *gomock.Controller
+		sut                          repository.RepositoryContract
+		mockConfigurationService     *configurationMock.MockConfigurationContract
+		mockDefaultRepositoryService *repositoryMock.MockRepositoryContract
+		mockEURepositoryService      *repositoryMock.MockRepositoryContract
+		ctx                          context.Context
+		email                        string
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		ctx = context.Background()
+		email = cuid.New() + "@test.com"
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetDataResidencyDefaultRegion().
+			Return("us", nil).
+			AnyTimes()
+
+		mockDefaultRepositoryService = repositoryMock.NewMockRepositoryContract(mockCtrl)
+		mockEURepositoryService = repositoryMock.NewMockRepositoryContract(mockCtrl)
+		sut, _ = regional.NewRegionalRepositoryService(mockConfigurationService, map[string]repository.RepositoryContract{
+			"us": mockDefaultRepositoryService,
+			"eu": mockEURepositoryService,
+		})
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate RegionalRepositoryService", func() {
+		When("configuration service is not provided and NewRegionalRepositoryService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := regional.NewRegionalRepositoryService(nil, map[string]repository.RepositoryContract{
+					"us": mockDefaultRepositoryService,
+				})
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("repository services are not provided and NewRegionalRepositoryService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := regional.NewRegionalRepositoryService(mockConfigurationService, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("the repository of the default region is not provided and NewRegionalRepositoryService is called", func() {
+			It("should return ArgumentError", func() {
+				service, err := regional.NewRegionalRepositoryService(mockConfigurationService, map[string]repository.RepositoryContract{
+					"eu": mockEURepositoryService,
+				})
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+			})
+		})
+
+		When("all dependencies are resolved and NewRegionalRepositoryService is called", func() {
+			It("should instantiate the new RegionalRepositoryService", func() {
+				service, err := regional.NewRegionalRepositoryService(mockConfigurationService, map[string]repository.RepositoryContract{
+					"us": mockDefaultRepositoryService,
+					"eu": mockEURepositoryService,
+				})
+				Ω(err).Should(BeNil())
+				Ω(service).ShouldNot(BeNil())
+			})
+		})
+	})
+
+	Context("RegionalRepositoryService is instantiated", func() {
+		When("CreateUser is called for a user residing in a region other than the default region", func() {
+			It("should create the user in the repository of the region and qualify the cursor with the region", func() {
+				request := repository.CreateUserRequest{Email: email, User: models.User{DataResidency: "eu"}}
+				cursor := cuid.New()
+
+				gomock.InOrder(
+					mockDefaultRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(nil, commonErrors.NewNotFoundError()),
+					mockEURepositoryService.
+						EXPECT().
+						CreateUser(ctx, &request).
+						Return(&repository.CreateUserResponse{Cursor: cursor}, nil),
+				)
+
+				response, err := sut.CreateUser(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.User.DataResidency).Should(Equal("eu"))
+				Ω(response.Cursor).Should(Equal("eu:" + cursor))
+			})
+		})
+
+		When("CreateUser is called for a user that exists in another region", func() {
+			It("should return AlreadyExistsError", func() {
+				request := repository.CreateUserRequest{Email: email}
+
+				mockEURepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+					Return(&repository.ReadUserResponse{}, nil)
+
+				response, err := sut.CreateUser(ctx, &request)
+				Ω(response).Should(BeNil())
+				Ω(commonErrors.IsAlreadyExistsError(err)).Should(BeTrue())
+			})
+		})
+
+		When("CreateUser is called for a user residing in a region that is not configured", func() {
+			It("should return ArgumentError", func() {
+				request := repository.CreateUserRequest{Email: email, User: models.User{DataResidency: "ap"}}
+
+				response, err := sut.CreateUser(ctx, &request)
+				Ω(response).Should(BeNil())
+				Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+			})
+		})
+
+		When("ReadUser is called for a user residing in a region other than the default region", func() {
+			It("should read the user from the repository of the region", func() {
+				request := repository.ReadUserRequest{Email: email}
+
+				gomock.InOrder(
+					mockDefaultRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &request).
+						Return(nil, commonErrors.NewNotFoundError()),
+					mockEURepositoryService.
+						EXPECT().
+						ReadUser(ctx, &request).
+						Return(&repository.ReadUserResponse{}, nil),
+				)
+
+				response, err := sut.ReadUser(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.User.DataResidency).Should(Equal("eu"))
+			})
+		})
+
+		When("ReadUser is called for a user that does not exist in any region", func() {
+			It("should return NotFoundError", func() {
+				request := repository.ReadUserRequest{Email: email}
+
+				mockDefaultRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &request).
+					Return(nil, commonErrors.NewNotFoundError())
+				mockEURepositoryService.
+					EXPECT().
+					ReadUser(ctx, &request).
+					Return(nil, commonErrors.NewNotFoundError())
+
+				response, err := sut.ReadUser(ctx, &request)
+				Ω(response).Should(BeNil())
+				Ω(commonErrors.IsNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("ReadUsers is called for the users residing in different regions", func() {
+			It("should read the users from every region and qualify the cursors with the region", func() {
+				euEmail := cuid.New() + "@test.com"
+				request := repository.ReadUsersRequest{Emails: []string{email, euEmail}}
+
+				mockDefaultRepositoryService.
+					EXPECT().
+					ReadUsers(ctx, &request).
+					Return(&repository.ReadUsersResponse{Users: []models.UserWithCursor{{UserID: "1", Email: email, Cursor: "1"}}}, nil)
+				mockEURepositoryService.
+					EXPECT().
+					ReadUsers(ctx, &request).
+					Return(&repository.ReadUsersResponse{Users: []models.UserWithCursor{{UserID: "2", Email: euEmail, Cursor: "2"}}}, nil)
+
+				response, err := sut.ReadUsers(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(ConsistOf(
+					models.UserWithCursor{UserID: "1", Email: email, Cursor: "1", User: models.User{DataResidency: "us"}},
+					models.UserWithCursor{UserID: "eu:2", Email: euEmail, Cursor: "eu:2", User: models.User{DataResidency: "eu"}},
+				))
+			})
+		})
+
+		When("ReadUser is called with the ID of a user residing in a region other than the default region", func() {
+			It("should only read the user from that region and qualify the ID with the region", func() {
+				mockEURepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{UserID: "2"}).
+					Return(&repository.ReadUserResponse{Email: email, User: models.User{ID: "2"}}, nil)
+
+				response, err := sut.ReadUser(ctx, &repository.ReadUserRequest{UserID: "eu:2"})
+				Ω(err).Should(BeNil())
+				Ω(response.Email).Should(Equal(email))
+				Ω(response.User).Should(Equal(models.User{ID: "eu:2", DataResidency: "eu"}))
+			})
+		})
+
+		When("ChangeEmail is called with the email address of a user residing in another region", func() {
+			It("should return AlreadyExistsError without changing the email address", func() {
+				newEmail := cuid.New() + "@test.com"
+
+				mockDefaultRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+					Return(&repository.ReadUserResponse{Email: email}, nil)
+				mockEURepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{Email: newEmail}).
+					Return(&repository.ReadUserResponse{Email: newEmail}, nil)
+
+				response, err := sut.ChangeEmail(ctx, &repository.ChangeEmailRequest{Email: email, NewEmail: newEmail})
+				Ω(response).Should(BeNil())
+				Ω(commonErrors.IsAlreadyExistsError(err)).Should(BeTrue())
+			})
+		})
+
+		When("UpdateUser is called asking to move the user to another region", func() {
+			It("should return DataResidencyViolationError without updating the user", func() {
+				request := repository.UpdateUserRequest{Email: email, User: models.User{DataResidency: "us"}}
+
+				mockDefaultRepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+					Return(nil, commonErrors.NewNotFoundError())
+				mockEURepositoryService.
+					EXPECT().
+					ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+					Return(&repository.ReadUserResponse{}, nil)
+
+				response, err := sut.UpdateUser(ctx, &request)
+				Ω(response).Should(BeNil())
+				Ω(repository.IsDataResidencyViolationError(err)).Should(BeTrue())
+				Ω(err).Should(Equal(repository.NewDataResidencyViolationError(email, "eu", "us")))
+			})
+		})
+
+		When("UpdateUser is called for a user residing in the default region", func() {
+			It("should update the user in the repository of the default region", func() {
+				request := repository.UpdateUserRequest{Email: email}
+				cursor := cuid.New()
+
+				gomock.InOrder(
+					mockDefaultRepositoryService.
+						EXPECT().
+						ReadUser(ctx, &repository.ReadUserRequest{Email: email}).
+						Return(&repository.ReadUserResponse{}, nil),
+					mockDefaultRepositoryService.
+						EXPECT().
+						UpdateUser(ctx, &request).
+						Return(&repository.UpdateUserResponse{Cursor: cursor}, nil),
+				)
+
+				response, err := sut.UpdateUser(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.User.DataResidency).Should(Equal("us"))
+				Ω(response.Cursor).Should(Equal(cursor))
+			})
+		})
+
+		When("Search is called", func() {
+			It("should merge the users of all the regions sorted by the email address", func() {
+				first := "a" + email
+				second := "b" + email
+				request := repository.SearchRequest{
+					SortingOptions: []models.SortingOptionPair{{Name: "email", Direction: models.Ascending}},
+				}
+				regionRequest := repository.SearchRequest{SortingOptions: request.SortingOptions}
+
+				mockDefaultRepositoryService.
+					EXPECT().
+					Search(ctx, &regionRequest).
+					Return(&repository.SearchResponse{
+						Users: []models.UserWithCursor{{UserID: "1", Email: second, Cursor: "1"}},
+					}, nil)
+				mockEURepositoryService.
+					EXPECT().
+					Search(ctx, &regionRequest).
+					Return(&repository.SearchResponse{
+						Users: []models.UserWithCursor{{UserID: "1", Email: first, Cursor: "1"}},
+					}, nil)
+
+				response, err := sut.Search(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.TotalCount).Should(Equal(int64(2)))
+				Ω(response.Users).Should(Equal([]models.UserWithCursor{
+					{UserID: "eu:1", Email: first, Cursor: "eu:1", User: models.User{DataResidency: "eu"}},
+					{UserID: "1", Email: second, Cursor: "1", User: models.User{DataResidency: "us"}},
+				}))
+			})
+		})
+
+		When("Search is called sorting by the update time", func() {
+			It("should merge the users of all the regions sorted by the update time, the users never updated first", func() {
+				updatedAt := time.Now()
+				earlierUpdatedAt := updatedAt.Add(-time.Hour)
+				request := repository.SearchRequest{
+					SortingOptions: []models.SortingOptionPair{{Name: "updatedAt", Direction: models.Ascending}},
+				}
+
+				mockDefaultRepositoryService.
+					EXPECT().
+					Search(ctx, gomock.Any()).
+					Return(&repository.SearchResponse{
+						Users: []models.UserWithCursor{
+							{UserID: "1", Email: email, Cursor: "1", User: models.User{UpdatedAt: &updatedAt}},
+							{UserID: "2", Email: email, Cursor: "2"},
+						},
+					}, nil)
+				mockEURepositoryService.
+					EXPECT().
+					Search(ctx, gomock.Any()).
+					Return(&repository.SearchResponse{
+						Users: []models.UserWithCursor{{UserID: "1", Email: email, Cursor: "1", User: models.User{UpdatedAt: &earlierUpdatedAt}}},
+					}, nil)
+
+				response, err := sut.Search(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.Users).Should(HaveLen(3))
+				Ω(response.Users[0].Cursor).Should(Equal("2"))
+				Ω(response.Users[1].Cursor).Should(Equal("eu:1"))
+				Ω(response.Users[2].Cursor).Should(Equal("1"))
+			})
+		})
+
+		When("StreamSearch is called resuming after the cursor of a user residing in a region other than the default region", func() {
+			It("should skip the default region and resume the region after the unqualified cursor", func() {
+				users := []models.UserWithCursor{}
+				request := repository.StreamSearchRequest{
+					After: "eu:1",
+					Send: func(user models.UserWithCursor) error {
+						users = append(users, user)
+
+						return nil
+					},
+				}
+
+				mockEURepositoryService.
+					EXPECT().
+					StreamSearch(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, regionRequest *repository.StreamSearchRequest) (*repository.StreamSearchResponse, error) {
+						Ω(regionRequest.After).Should(Equal("1"))
+						Ω(regionRequest.Send(models.UserWithCursor{UserID: "2", Email: email, Cursor: "2"})).Should(BeNil())
+
+						return &repository.StreamSearchResponse{SentCount: 1}, nil
+					})
+
+				response, err := sut.StreamSearch(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.SentCount).Should(Equal(int64(1)))
+				Ω(users).Should(Equal([]models.UserWithCursor{
+					{UserID: "eu:2", Email: email, Cursor: "eu:2", User: models.User{DataResidency: "eu"}},
+				}))
+			})
+		})
+
+		When("PurgeDeletedUsers is called", func() {
+			It("should purge the users of all the regions", func() {
+				request := repository.PurgeDeletedUsersRequest{}
+
+				mockDefaultRepositoryService.
+					EXPECT().
+					PurgeDeletedUsers(ctx, &request).
+					Return(&repository.PurgeDeletedUsersResponse{PurgedCount: 2}, nil)
+				mockEURepositoryService.
+					EXPECT().
+					PurgeDeletedUsers(ctx, &request).
+					Return(&repository.PurgeDeletedUsersResponse{PurgedCount: 3}, nil)
+
+				response, err := sut.PurgeDeletedUsers(ctx, &request)
+				Ω(err).Should(BeNil())
+				Ω(response.PurgedCount).Should(Equal(int64(5)))
+			})
+		})
+	})
+})
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// MatchesQuery indicates whether the email address starts with the free-text query regardless of the case. The query
+// is only matched as a prefix, so the database repositories find the users through their email index.
+// email: Mandatory. The email address of the user
+// query: Optional. The free-text query, every email address matches an empty query
+// Returns true if the email address matches the query
+func MatchesQuery(email string, query string) bool {
+	return strings.HasPrefix(strings.ToLower(email), strings.ToLower(query))
+}
+
+// SortByRelevance sorts the users that matched the free-text query by how well their email address matches it. The
+// users whose email address equals the query come first, then the rest. The users that match the query equally well
+// keep their order, so the cursors stay stable.
+// users: Mandatory. The users that matched the query, sorted in place
+// query: Optional. The free-text query, the users are left as they are if empty
+func SortByRelevance(users []models.UserWithCursor, query string) {
+	if query == "" {
+		return
+	}
+
+	query = strings.ToLower(query)
+	sort.SliceStable(users, func(i, j int) bool {
+		return GetRelevance(users[i].Email, query) < GetRelevance(users[j].Email, query)
+	})
+}
+
+// GetRelevance ranks how well the email address that matched the lower case query matches it, the lower the better.
+// The users are sorted by relevance in ascending order.
+// email: Mandatory. The email address of the user
+// query: Mandatory. The lower case free-text query
+// Returns 0 if the email address equals the query and 1 otherwise
+func GetRelevance(email string, query string) int {
+	if strings.ToLower(email) == query {
+		return 0
+	}
+
+	return 1
+}
@@ -0,0 +1,43 @@
+// Package repository implements different repository services required by the user service
+package repository
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// GetTenant returns the tenant the operation is scoped to when it creates or changes the users. The users the caller
+// creates belong to the tenant, and the caller only changes the users of the tenant.
+// ctx: Mandatory The reference to the context
+// Returns the tenant, or empty if the operation is not scoped to a tenant, e.g. in the single-tenant mode or for the
+// operations not made on behalf of an authenticated caller such as the purger
+func GetTenant(ctx context.Context) string {
+	parsedToken, _ := ctx.Value(models.ContextKeyParsedToken).(models.ParsedToken)
+
+	return parsedToken.TenantID
+}
+
+// GetReadTenant returns the tenant the operation is scoped to when it only reads the users. The callers allowed to
+// read across the tenants are not scoped to their tenant.
+// ctx: Mandatory The reference to the context
+// Returns the tenant, or empty if the operation reads the users of all the tenants
+func GetReadTenant(ctx context.Context) string {
+	parsedToken, _ := ctx.Value(models.ContextKeyParsedToken).(models.ParsedToken)
+	if parsedToken.CrossTenantReads {
+		return ""
+	}
+
+	return parsedToken.TenantID
+}
+
+// IsReadable indicates whether the operation is allowed to read the user, e.g. the user served from a cache rather
+// than read with the tenant filter of the repository
+// ctx: Mandatory The reference to the context
+// user: Mandatory. The user to read
+// Returns true if the user belongs to the tenant the read is scoped to or the read is not scoped, otherwise false
+func IsReadable(ctx context.Context, user models.User) bool {
+	tenantID := GetReadTenant(ctx)
+
+	return tenantID == "" || user.TenantID == tenantID
+}
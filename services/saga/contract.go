@@ -0,0 +1,49 @@
+// Package saga implements the orchestrator of the operations spanning multiple services
+package saga
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// SagaContract declares the service that executes sagas, the operations that span multiple services and are
+// made of steps that can be compensated if any of the later steps fails.
+type SagaContract interface {
+	// Execute executes the saga steps in order. A failed step is retried and if it still fails after exhausting
+	// all the attempts, the completed steps are compensated in the reverse order. The progress of the saga is
+	// persisted so it can be read while and after the saga is executed.
+	// ctx: Mandatory The reference to the context
+	// definition: Mandatory. The saga to execute
+	// Returns the final state of the saga and the error of the failed step if the saga could not be completed.
+	Execute(
+		ctx context.Context,
+		definition *Definition) (*models.SagaState, error)
+
+	// ReadSagaState reads the progress of an existing saga
+	// ctx: Mandatory The reference to the context
+	// sagaID: Mandatory. The unique identifier of the saga
+	// Returns either the state of the saga or error if something goes wrong.
+	ReadSagaState(
+		ctx context.Context,
+		sagaID string) (*models.SagaState, error)
+}
+
+// StoreContract declares the service that persists the progress of the sagas
+type StoreContract interface {
+	// SaveSagaState creates or replaces the persisted state of a saga
+	// ctx: Mandatory The reference to the context
+	// state: Mandatory. The state of the saga to persist
+	// Returns error if something goes wrong.
+	SaveSagaState(
+		ctx context.Context,
+		state *models.SagaState) error
+
+	// ReadSagaState reads the persisted state of an existing saga
+	// ctx: Mandatory The reference to the context
+	// sagaID: Mandatory. The unique identifier of the saga
+	// Returns either the state of the saga or error if something goes wrong.
+	ReadSagaState(
+		ctx context.Context,
+		sagaID string) (*models.SagaState, error)
+}
@@ -0,0 +1,22 @@
+// Package saga implements the orchestrator of the operations spanning multiple services
+package saga
+
+import "context"
+
+// Step defines a single step of a saga
+type Step struct {
+	// Name is the name of the step that is persisted as part of the saga progress
+	Name string
+
+	// Action executes the step
+	Action func(ctx context.Context) error
+
+	// Compensation undoes the step once any of the later steps fails. Optional, the step is not compensated if not provided.
+	Compensation func(ctx context.Context) error
+}
+
+// Definition defines a saga as an ordered list of steps
+type Definition struct {
+	Name  string
+	Steps []Step
+}
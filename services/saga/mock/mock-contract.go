@@ -0,0 +1,119 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/saga/contract.go
+
+// Package mock_saga is a generated GoMock package.
+package mock_saga
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/decentralized-cloud/user/models"
+	saga "github.com/decentralized-cloud/user/services/saga"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSagaContract is a mock of SagaContract interface.
+type MockSagaContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockSagaContractMockRecorder
+}
+
+// MockSagaContractMockRecorder is the mock recorder for MockSagaContract.
+type MockSagaContractMockRecorder struct {
+	mock *MockSagaContract
+}
+
+// NewMockSagaContract creates a new mock instance.
+func NewMockSagaContract(ctrl *gomock.Controller) *MockSagaContract {
+	mock := &MockSagaContract{ctrl: ctrl}
+	mock.recorder = &MockSagaContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSagaContract) EXPECT() *MockSagaContractMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockSagaContract) Execute(ctx context.Context, definition *saga.Definition) (*models.SagaState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, definition)
+	ret0, _ := ret[0].(*models.SagaState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockSagaContractMockRecorder) Execute(ctx, definition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockSagaContract)(nil).Execute), ctx, definition)
+}
+
+// ReadSagaState mocks base method.
+func (m *MockSagaContract) ReadSagaState(ctx context.Context, sagaID string) (*models.SagaState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSagaState", ctx, sagaID)
+	ret0, _ := ret[0].(*models.SagaState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSagaState indicates an expected call of ReadSagaState.
+func (mr *MockSagaContractMockRecorder) ReadSagaState(ctx, sagaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSagaState", reflect.TypeOf((*MockSagaContract)(nil).ReadSagaState), ctx, sagaID)
+}
+
+// MockStoreContract is a mock of StoreContract interface.
+type MockStoreContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreContractMockRecorder
+}
+
+// MockStoreContractMockRecorder is the mock recorder for MockStoreContract.
+type MockStoreContractMockRecorder struct {
+	mock *MockStoreContract
+}
+
+// NewMockStoreContract creates a new mock instance.
+func NewMockStoreContract(ctrl *gomock.Controller) *MockStoreContract {
+	mock := &MockStoreContract{ctrl: ctrl}
+	mock.recorder = &MockStoreContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreContract) EXPECT() *MockStoreContractMockRecorder {
+	return m.recorder
+}
+
+// ReadSagaState mocks base method.
+func (m *MockStoreContract) ReadSagaState(ctx context.Context, sagaID string) (*models.SagaState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSagaState", ctx, sagaID)
+	ret0, _ := ret[0].(*models.SagaState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSagaState indicates an expected call of ReadSagaState.
+func (mr *MockStoreContractMockRecorder) ReadSagaState(ctx, sagaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSagaState", reflect.TypeOf((*MockStoreContract)(nil).ReadSagaState), ctx, sagaID)
+}
+
+// SaveSagaState mocks base method.
+func (m *MockStoreContract) SaveSagaState(ctx context.Context, state *models.SagaState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSagaState", ctx, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSagaState indicates an expected call of SaveSagaState.
+func (mr *MockStoreContractMockRecorder) SaveSagaState(ctx, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSagaState", reflect.TypeOf((*MockStoreContract)(nil).SaveSagaState), ctx, state)
+}
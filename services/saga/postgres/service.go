@@ -0,0 +1,110 @@
+// Package postgres implements the PostgreSQL store that persists the progress of the sagas
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	configurationPostgres "github.com/decentralized-cloud/user/services/configuration/postgres"
+	"github.com/decentralized-cloud/user/services/saga"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+type postgresStoreService struct {
+	pool      *pgxpool.Pool
+	tableName string
+}
+
+// NewPostgresStoreService creates new instance of the postgresStoreService, setting up all dependencies, creating
+// the saga table if it does not exist yet and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewPostgresStoreService(
+	configurationService configuration.ConfigurationContract) (saga.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	connectionString, err := configurationService.GetDatabaseConnectionString()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to postgres", err)
+	}
+
+	// The saga collection name is used as the name of the table the saga states are persisted in
+	tableName, err := configurationService.GetSagaCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the saga table name", err)
+	}
+
+	ctx := context.Background()
+	pool, err := configurationPostgres.Connect(ctx, connectionString, configurationService.GetDatabaseConnectionString)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("could not connect to postgres database", err)
+	}
+
+	service := &postgresStoreService{
+		pool:      pool,
+		tableName: tableName,
+	}
+
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (saga_id TEXT PRIMARY KEY, state JSONB NOT NULL)",
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the saga table", err)
+	}
+
+	return service, nil
+}
+
+// SaveSagaState creates or replaces the persisted state of a saga
+// ctx: Mandatory The reference to the context
+// state: Mandatory. The state of the saga to persist
+// Returns error if something goes wrong.
+func (service *postgresStoreService) SaveSagaState(
+	ctx context.Context,
+	state *models.SagaState) error {
+	_, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf(
+			"INSERT INTO %s (saga_id, state) VALUES ($1, $2) ON CONFLICT (saga_id) DO UPDATE SET state = EXCLUDED.state",
+			service.table()),
+		state.SagaID,
+		state)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to save saga state", err)
+	}
+
+	return nil
+}
+
+// ReadSagaState reads the persisted state of an existing saga
+// ctx: Mandatory The reference to the context
+// sagaID: Mandatory. The unique identifier of the saga
+// Returns either the state of the saga or error if something goes wrong.
+func (service *postgresStoreService) ReadSagaState(
+	ctx context.Context,
+	sagaID string) (*models.SagaState, error) {
+	var state models.SagaState
+
+	err := service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf("SELECT state FROM %s WHERE saga_id = $1", service.table()),
+		sagaID).Scan(&state)
+	if err == pgx.ErrNoRows {
+		return nil, commonErrors.NewNotFoundError()
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read saga state", err)
+	}
+
+	return &state, nil
+}
+
+func (service *postgresStoreService) table() string {
+	return pgx.Identifier{service.tableName}.Sanitize()
+}
@@ -0,0 +1,237 @@
+// Package saga implements the orchestrator of the operations spanning multiple services
+package saga
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/correlation"
+	"github.com/decentralized-cloud/user/services/idgenerator"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+)
+
+type sagaService struct {
+	logger             *zap.Logger
+	storeService       StoreContract
+	clockService       clock.ClockContract
+	idGeneratorService idgenerator.IDGeneratorContract
+	maxAttempts        int
+	retryBackoff       time.Duration
+}
+
+// NewSagaService creates new instance of the sagaService, setting up all dependencies and returns the instance
+// logger: Mandatory. Reference to the logger service
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// storeService: Mandatory. Reference to the service that persists the progress of the sagas
+// clockService: Mandatory. Reference to the service that provides the current time
+// idGeneratorService: Mandatory. Reference to the service that generates the saga ids
+// Returns the new service or error if something goes wrong
+func NewSagaService(
+	logger *zap.Logger,
+	configurationService configuration.ConfigurationContract,
+	storeService StoreContract,
+	clockService clock.ClockContract,
+	idGeneratorService idgenerator.IDGeneratorContract) (SagaContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if storeService == nil {
+		return nil, commonErrors.NewArgumentNilError("storeService", "storeService is required")
+	}
+
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	if idGeneratorService == nil {
+		return nil, commonErrors.NewArgumentNilError("idGeneratorService", "idGeneratorService is required")
+	}
+
+	maxAttempts, err := configurationService.GetSagaMaxAttempts()
+	if err != nil {
+		return nil, err
+	}
+
+	retryBackoff, err := configurationService.GetSagaRetryBackoff()
+	if err != nil {
+		return nil, err
+	}
+
+	return &sagaService{
+		logger:             logger,
+		storeService:       storeService,
+		clockService:       clockService,
+		idGeneratorService: idGeneratorService,
+		maxAttempts:        maxAttempts,
+		retryBackoff:       retryBackoff,
+	}, nil
+}
+
+// Execute executes the saga steps in order. A failed step is retried and if it still fails after exhausting
+// all the attempts, the completed steps are compensated in the reverse order. The progress of the saga is
+// persisted so it can be read while and after the saga is executed.
+// ctx: Mandatory The reference to the context
+// definition: Mandatory. The saga to execute
+// Returns the final state of the saga and the error of the failed step if the saga could not be completed.
+func (service *sagaService) Execute(
+	ctx context.Context,
+	definition *Definition) (*models.SagaState, error) {
+	now := service.clockService.Now()
+	state := &models.SagaState{
+		SagaID:    service.idGeneratorService.NewID(),
+		Name:      definition.Name,
+		Status:    models.SagaRunning,
+		Steps:     make([]models.SagaStepState, len(definition.Steps)),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	for index, step := range definition.Steps {
+		state.Steps[index] = models.SagaStepState{
+			Name:   step.Name,
+			Status: models.SagaStepPending,
+		}
+	}
+
+	// Nothing is executed unless the saga can be tracked
+	if err := service.storeService.SaveSagaState(ctx, state); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to persist the saga state", err)
+	}
+
+	for index, step := range definition.Steps {
+		stepState := &state.Steps[index]
+
+		if err := service.runWithRetries(ctx, step.Action, &stepState.Attempts); err != nil {
+			stepState.Status = models.SagaStepFailed
+			stepState.Error = err.Error()
+			state.Status = models.SagaCompensating
+			state.Error = err.Error()
+			service.saveSagaState(ctx, state)
+
+			service.compensate(definition, state, index)
+
+			return state, err
+		}
+
+		stepState.Status = models.SagaStepCompleted
+		service.saveSagaState(ctx, state)
+	}
+
+	state.Status = models.SagaCompleted
+	service.saveSagaState(ctx, state)
+
+	return state, nil
+}
+
+// ReadSagaState reads the progress of an existing saga
+// ctx: Mandatory The reference to the context
+// sagaID: Mandatory. The unique identifier of the saga
+// Returns either the state of the saga or error if something goes wrong.
+func (service *sagaService) ReadSagaState(
+	ctx context.Context,
+	sagaID string) (*models.SagaState, error) {
+	return service.storeService.ReadSagaState(ctx, sagaID)
+}
+
+// compensate compensates the completed steps that come before the failed step in the reverse order
+// definition: Mandatory. The saga that is being executed
+// state: Mandatory. The current state of the saga
+// failedStepIndex: Mandatory. The index of the step that failed
+func (service *sagaService) compensate(
+	definition *Definition,
+	state *models.SagaState,
+	failedStepIndex int) {
+	// The compensation must run to the end even if the operation that started the saga is cancelled
+	ctx := context.Background()
+	state.Status = models.SagaCompensated
+
+	for index := failedStepIndex - 1; index >= 0; index-- {
+		step := definition.Steps[index]
+		stepState := &state.Steps[index]
+
+		if step.Compensation == nil {
+			continue
+		}
+
+		attempts := 0
+		if err := service.runWithRetries(ctx, step.Compensation, &attempts); err != nil {
+			service.logger.Error(
+				"failed to compensate saga step",
+				zap.String("sagaID", state.SagaID),
+				zap.String("saga", state.Name),
+				zap.String("step", step.Name),
+				zap.Error(err))
+
+			stepState.Status = models.SagaStepCompensationFailed
+			stepState.Error = err.Error()
+			state.Status = models.SagaFailed
+		} else {
+			stepState.Status = models.SagaStepCompensated
+		}
+
+		service.saveSagaState(ctx, state)
+	}
+
+	service.saveSagaState(ctx, state)
+}
+
+// runWithRetries runs the given function until it succeeds, it returns an error that is not worth retrying or the
+// maximum number of attempts is reached. The time to wait between the attempts is doubled after every attempt.
+// ctx: Mandatory The reference to the context
+// fn: Mandatory. The function to run
+// attempts: Mandatory. The number of attempts made so far, increased by every attempt
+// Returns the error of the last attempt if the function did not succeed
+func (service *sagaService) runWithRetries(
+	ctx context.Context,
+	fn func(ctx context.Context) error,
+	attempts *int) error {
+	backoff := service.retryBackoff
+
+	for {
+		*attempts++
+
+		err := fn(ctx)
+		if err == nil || !isRetryable(err) || *attempts >= service.maxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// saveSagaState persists the progress of the saga. The saga has already made changes at this point, so failing
+// to persist its progress does not stop it and is only logged.
+func (service *sagaService) saveSagaState(ctx context.Context, state *models.SagaState) {
+	state.UpdatedAt = service.clockService.Now()
+
+	if err := service.storeService.SaveSagaState(ctx, state); err != nil {
+		correlation.GetLogger(ctx, service.logger).Error(
+			"failed to persist the saga state",
+			zap.String("sagaID", state.SagaID),
+			zap.String("saga", state.Name),
+			zap.Error(err))
+	}
+}
+
+// isRetryable returns false for the errors that are caused by the request itself, hence retrying would not help
+func isRetryable(err error) bool {
+	return !commonErrors.IsArgumentNilError(err) &&
+		!commonErrors.IsArgumentError(err) &&
+		!commonErrors.IsNotFoundError(err) &&
+		!commonErrors.IsAlreadyExistsError(err)
+}
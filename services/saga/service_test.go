@@ -0,0 +1,342 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	idGeneratorMock "github.com/decentralized-cloud/user/services/idgenerator/mock"
+	"github.com/decentralized-cloud/user/services/saga"
+	sagaMock "github.com/decentralized-cloud/user/services/saga/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSagaService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Saga Service Tests")
+}
+
+var _ = Describe("Saga Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      saga.SagaContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		mockStoreService         *sagaMock.MockStoreContract
+		mockClockService         *clockMock.MockClockContract
+		mockIDGeneratorService   *idGeneratorMock.MockIDGeneratorContract
+		now                      time.Time
+		sagaID                   string
+		savedStates              []models.SagaState
+		ctx                      context.Context
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetSagaMaxAttempts().
+			Return(3, nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetSagaRetryBackoff().
+			Return(time.Duration(0), nil).
+			AnyTimes()
+
+		savedStates = []models.SagaState{}
+		mockStoreService = sagaMock.NewMockStoreContract(mockCtrl)
+		mockStoreService.
+			EXPECT().
+			SaveSagaState(gomock.Any(), gomock.Any()).
+			Do(func(_ context.Context, state *models.SagaState) {
+				savedState := *state
+				savedState.Steps = append([]models.SagaStepState{}, state.Steps...)
+				savedStates = append(savedStates, savedState)
+			}).
+			Return(nil).
+			AnyTimes()
+
+		now = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			DoAndReturn(func() time.Time { return now }).
+			AnyTimes()
+
+		sagaID = cuid.New()
+		mockIDGeneratorService = idGeneratorMock.NewMockIDGeneratorContract(mockCtrl)
+		mockIDGeneratorService.
+			EXPECT().
+			NewID().
+			Return(sagaID).
+			AnyTimes()
+
+		sut, _ = saga.NewSagaService(zap.NewNop(), mockConfigurationService, mockStoreService, mockClockService, mockIDGeneratorService)
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate SagaService", func() {
+		When("logger is not provided and NewSagaService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := saga.NewSagaService(nil, mockConfigurationService, mockStoreService, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("logger", "", err)
+			})
+		})
+
+		When("configuration service is not provided and NewSagaService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := saga.NewSagaService(zap.NewNop(), nil, mockStoreService, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("configurationService", "", err)
+			})
+		})
+
+		When("store service is not provided and NewSagaService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := saga.NewSagaService(zap.NewNop(), mockConfigurationService, nil, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("storeService", "", err)
+			})
+		})
+
+		When("clock service is not provided and NewSagaService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := saga.NewSagaService(zap.NewNop(), mockConfigurationService, mockStoreService, nil, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("clockService", "", err)
+			})
+		})
+
+		When("id generator service is not provided and NewSagaService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := saga.NewSagaService(zap.NewNop(), mockConfigurationService, mockStoreService, mockClockService, nil)
+				Ω(service).Should(BeNil())
+				assertArgumentNilError("idGeneratorService", "", err)
+			})
+		})
+
+		When("all dependencies are resolved and NewSagaService is called", func() {
+			It("should instantiate the new SagaService", func() {
+				service, err := saga.NewSagaService(zap.NewNop(), mockConfigurationService, mockStoreService, mockClockService, mockIDGeneratorService)
+				Ω(err).Should(BeNil())
+				Ω(service).ShouldNot(BeNil())
+			})
+		})
+	})
+
+	Describe("Execute", func() {
+		var (
+			executedSteps   []string
+			compensatedStep []string
+		)
+
+		BeforeEach(func() {
+			executedSteps = []string{}
+			compensatedStep = []string{}
+		})
+
+		createStep := func(name string, actionErrors ...error) saga.Step {
+			attempt := 0
+
+			return saga.Step{
+				Name: name,
+				Action: func(ctx context.Context) error {
+					executedSteps = append(executedSteps, name)
+
+					if attempt < len(actionErrors) {
+						attempt++
+
+						return actionErrors[attempt-1]
+					}
+
+					return nil
+				},
+				Compensation: func(ctx context.Context) error {
+					compensatedStep = append(compensatedStep, name)
+
+					return nil
+				},
+			}
+		}
+
+		When("all the steps succeed", func() {
+			It("should execute the steps in order and complete the saga", func() {
+				state, err := sut.Execute(ctx, &saga.Definition{
+					Name:  cuid.New(),
+					Steps: []saga.Step{createStep("first"), createStep("second")},
+				})
+
+				Ω(err).Should(BeNil())
+				Ω(state.SagaID).Should(Equal(sagaID))
+				Ω(state.CreatedAt).Should(Equal(now))
+				Ω(state.Status).Should(Equal(models.SagaCompleted))
+				Ω(executedSteps).Should(Equal([]string{"first", "second"}))
+				Ω(compensatedStep).Should(BeEmpty())
+
+				for _, step := range state.Steps {
+					Ω(step.Status).Should(Equal(models.SagaStepCompleted))
+					Ω(step.Attempts).Should(Equal(1))
+				}
+
+				Ω(savedStates[0].Status).Should(Equal(models.SagaRunning))
+				Ω(savedStates[len(savedStates)-1].Status).Should(Equal(models.SagaCompleted))
+			})
+		})
+
+		When("a step fails and then succeeds", func() {
+			It("should retry the step and complete the saga", func() {
+				state, err := sut.Execute(ctx, &saga.Definition{
+					Name:  cuid.New(),
+					Steps: []saga.Step{createStep("first", errors.New(cuid.New())), createStep("second")},
+				})
+
+				Ω(err).Should(BeNil())
+				Ω(state.Status).Should(Equal(models.SagaCompleted))
+				Ω(state.Steps[0].Attempts).Should(Equal(2))
+				Ω(executedSteps).Should(Equal([]string{"first", "first", "second"}))
+			})
+		})
+
+		When("a step keeps failing", func() {
+			It("should compensate the completed steps in the reverse order and return the step error", func() {
+				expectedError := errors.New(cuid.New())
+				state, err := sut.Execute(ctx, &saga.Definition{
+					Name: cuid.New(),
+					Steps: []saga.Step{
+						createStep("first"),
+						createStep("second"),
+						createStep("third", expectedError, expectedError, expectedError),
+					},
+				})
+
+				Ω(err).Should(Equal(expectedError))
+				Ω(state.Status).Should(Equal(models.SagaCompensated))
+				Ω(state.Error).Should(Equal(expectedError.Error()))
+				Ω(state.Steps[2].Status).Should(Equal(models.SagaStepFailed))
+				Ω(state.Steps[2].Attempts).Should(Equal(3))
+				Ω(state.Steps[1].Status).Should(Equal(models.SagaStepCompensated))
+				Ω(state.Steps[0].Status).Should(Equal(models.SagaStepCompensated))
+				Ω(compensatedStep).Should(Equal([]string{"second", "first"}))
+			})
+		})
+
+		When("a step fails with an error that is not worth retrying", func() {
+			It("should not retry the step", func() {
+				expectedError := commonErrors.NewNotFoundError()
+				state, err := sut.Execute(ctx, &saga.Definition{
+					Name:  cuid.New(),
+					Steps: []saga.Step{createStep("first", expectedError)},
+				})
+
+				Ω(err).Should(Equal(expectedError))
+				Ω(state.Status).Should(Equal(models.SagaCompensated))
+				Ω(state.Steps[0].Attempts).Should(Equal(1))
+			})
+		})
+
+		When("a compensation keeps failing", func() {
+			It("should fail the saga", func() {
+				compensationError := errors.New(cuid.New())
+				firstStep := createStep("first")
+				firstStep.Compensation = func(ctx context.Context) error {
+					return compensationError
+				}
+
+				state, err := sut.Execute(ctx, &saga.Definition{
+					Name:  cuid.New(),
+					Steps: []saga.Step{firstStep, createStep("second", commonErrors.NewNotFoundError())},
+				})
+
+				Ω(err).Should(HaveOccurred())
+				Ω(state.Status).Should(Equal(models.SagaFailed))
+				Ω(state.Steps[0].Status).Should(Equal(models.SagaStepCompensationFailed))
+				Ω(state.Steps[0].Error).Should(Equal(compensationError.Error()))
+			})
+		})
+
+		When("the saga state cannot be persisted", func() {
+			It("should not execute any step", func() {
+				failingStoreService := sagaMock.NewMockStoreContract(mockCtrl)
+				failingStoreService.
+					EXPECT().
+					SaveSagaState(gomock.Any(), gomock.Any()).
+					Return(errors.New(cuid.New()))
+
+				service, _ := saga.NewSagaService(zap.NewNop(), mockConfigurationService, failingStoreService, mockClockService, mockIDGeneratorService)
+				state, err := service.Execute(ctx, &saga.Definition{
+					Name:  cuid.New(),
+					Steps: []saga.Step{createStep("first")},
+				})
+
+				Ω(state).Should(BeNil())
+				Ω(commonErrors.IsUnknownError(err)).Should(BeTrue())
+				Ω(executedSteps).Should(BeEmpty())
+			})
+		})
+	})
+
+	Describe("ReadSagaState", func() {
+		When("store service returns the saga state", func() {
+			It("should return the same saga state", func() {
+				expectedState := models.SagaState{SagaID: cuid.New(), Status: models.SagaCompleted}
+				mockStoreService.
+					EXPECT().
+					ReadSagaState(ctx, expectedState.SagaID).
+					Return(&expectedState, nil)
+
+				state, err := sut.ReadSagaState(ctx, expectedState.SagaID)
+				Ω(err).Should(BeNil())
+				Ω(state).Should(Equal(&expectedState))
+			})
+		})
+
+		When("store service returns error", func() {
+			It("should return the same error", func() {
+				expectedError := commonErrors.NewNotFoundError()
+				mockStoreService.
+					EXPECT().
+					ReadSagaState(gomock.Any(), gomock.Any()).
+					Return(nil, expectedError)
+
+				state, err := sut.ReadSagaState(ctx, cuid.New())
+				Ω(state).Should(BeNil())
+				Ω(err).Should(Equal(expectedError))
+			})
+		})
+	})
+})
+
+func assertArgumentNilError(expectedArgumentName, expectedMessage string, err error) {
+	Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+
+	var argumentNilErr commonErrors.ArgumentNilError
+	_ = errors.As(err, &argumentNilErr)
+
+	if expectedArgumentName != "" {
+		Ω(argumentNilErr.ArgumentName).Should(Equal(expectedArgumentName))
+	}
+
+	if expectedMessage != "" {
+		Ω(strings.Contains(argumentNilErr.Error(), expectedMessage)).Should(BeTrue())
+	}
+}
@@ -0,0 +1,103 @@
+// Package session implements the registry of the sessions, the logins of the users on their devices
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// SessionContract declares the service that registers the sessions of the users and lets the users list and revoke
+// their active sessions
+type SessionContract interface {
+	// RegisterSession registers the new session or extends the existing session of the user, so it expires the
+	// session TTL after now
+	// ctx: Mandatory The reference to the context
+	// session: Mandatory. The session containing its ID, the email address of the user and the device
+	// Returns either the registered session or error if something goes wrong. SessionRevokedError is returned if the
+	// session is revoked.
+	RegisterSession(
+		ctx context.Context,
+		session *models.Session) (*models.Session, error)
+
+	// ListSessions lists the active sessions of the user, the most recently seen first
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns either the active sessions of the user or error if something goes wrong.
+	ListSessions(
+		ctx context.Context,
+		email string) ([]models.Session, error)
+
+	// RevokeSession revokes the active session of the user
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// sessionID: Mandatory. The ID of the session
+	// Returns error if something goes wrong. SessionNotFoundError is returned if the user has no active session with
+	// the ID.
+	RevokeSession(
+		ctx context.Context,
+		email string,
+		sessionID string) error
+
+	// DeleteSessions deletes all the sessions of the user
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns error if something goes wrong.
+	DeleteSessions(
+		ctx context.Context,
+		email string) error
+}
+
+// StoreContract declares the service that persists the sessions of the users
+type StoreContract interface {
+	// ReadSession reads the persisted session
+	// ctx: Mandatory The reference to the context
+	// sessionID: Mandatory. The ID of the session
+	// Returns either the session or error if something goes wrong. SessionNotFoundError is returned if there is no
+	// session with the ID.
+	ReadSession(
+		ctx context.Context,
+		sessionID string) (*models.Session, error)
+
+	// SaveSession creates the session or replaces the persisted session with the same ID, the time the persisted
+	// session is revoked at is kept, so a session revoked concurrently stays revoked
+	// ctx: Mandatory The reference to the context
+	// session: Mandatory. The session to persist
+	// Returns error if something goes wrong.
+	SaveSession(
+		ctx context.Context,
+		session *models.Session) error
+
+	// ListSessions lists the persisted sessions of the user that are neither revoked nor expired, the most recently
+	// seen first
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// now: Mandatory. The current time the sessions expired before are left out
+	// Returns either the active sessions of the user or error if something goes wrong.
+	ListSessions(
+		ctx context.Context,
+		email string,
+		now time.Time) ([]models.Session, error)
+
+	// RevokeSession records the time the active session of the user is revoked at
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// sessionID: Mandatory. The ID of the session
+	// revokedAt: Mandatory. The time the session is revoked at, the sessions expired before are not revoked
+	// Returns error if something goes wrong. SessionNotFoundError is returned if the user has no active session with
+	// the ID.
+	RevokeSession(
+		ctx context.Context,
+		email string,
+		sessionID string,
+		revokedAt time.Time) error
+
+	// DeleteSessions deletes all the persisted sessions of the user, it does nothing if the user has no session
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns error if something goes wrong.
+	DeleteSessions(
+		ctx context.Context,
+		email string) error
+}
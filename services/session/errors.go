@@ -0,0 +1,65 @@
+// Package session implements the registry of the sessions, the logins of the users on their devices
+package session
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SessionNotFoundError indicates there is no active session with the ID
+type SessionNotFoundError struct {
+	SessionID string
+}
+
+// Error returns message for the SessionNotFoundError error type
+// Returns the formatted error message
+func (e SessionNotFoundError) Error() string {
+	return fmt.Sprintf("session not found: %s", e.SessionID)
+}
+
+// IsSessionNotFoundError indicates whether the error is of type SessionNotFoundError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type SessionNotFoundError
+func IsSessionNotFoundError(err error) bool {
+	var sessionNotFoundError SessionNotFoundError
+
+	return errors.As(err, &sessionNotFoundError)
+}
+
+// NewSessionNotFoundError creates a new SessionNotFoundError error
+// sessionID: Mandatory. The ID of the session
+// Returns the new error
+func NewSessionNotFoundError(sessionID string) error {
+	return SessionNotFoundError{
+		SessionID: sessionID,
+	}
+}
+
+// SessionRevokedError indicates the session is revoked, so the login it belongs to must not be used anymore
+type SessionRevokedError struct {
+	SessionID string
+}
+
+// Error returns message for the SessionRevokedError error type
+// Returns the formatted error message
+func (e SessionRevokedError) Error() string {
+	return fmt.Sprintf("session is revoked: %s", e.SessionID)
+}
+
+// IsSessionRevokedError indicates whether the error is of type SessionRevokedError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type SessionRevokedError
+func IsSessionRevokedError(err error) bool {
+	var sessionRevokedError SessionRevokedError
+
+	return errors.As(err, &sessionRevokedError)
+}
+
+// NewSessionRevokedError creates a new SessionRevokedError error
+// sessionID: Mandatory. The ID of the session
+// Returns the new error
+func NewSessionRevokedError(sessionID string) error {
+	return SessionRevokedError{
+		SessionID: sessionID,
+	}
+}
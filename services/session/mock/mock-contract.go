@@ -0,0 +1,190 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/session/contract.go
+
+// Package mock_session is a generated GoMock package.
+package mock_session
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/decentralized-cloud/user/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSessionContract is a mock of SessionContract interface.
+type MockSessionContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionContractMockRecorder
+}
+
+// MockSessionContractMockRecorder is the mock recorder for MockSessionContract.
+type MockSessionContractMockRecorder struct {
+	mock *MockSessionContract
+}
+
+// NewMockSessionContract creates a new mock instance.
+func NewMockSessionContract(ctrl *gomock.Controller) *MockSessionContract {
+	mock := &MockSessionContract{ctrl: ctrl}
+	mock.recorder = &MockSessionContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionContract) EXPECT() *MockSessionContractMockRecorder {
+	return m.recorder
+}
+
+// DeleteSessions mocks base method.
+func (m *MockSessionContract) DeleteSessions(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessions", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessions indicates an expected call of DeleteSessions.
+func (mr *MockSessionContractMockRecorder) DeleteSessions(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessions", reflect.TypeOf((*MockSessionContract)(nil).DeleteSessions), ctx, email)
+}
+
+// ListSessions mocks base method.
+func (m *MockSessionContract) ListSessions(ctx context.Context, email string) ([]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, email)
+	ret0, _ := ret[0].([]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockSessionContractMockRecorder) ListSessions(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockSessionContract)(nil).ListSessions), ctx, email)
+}
+
+// RegisterSession mocks base method.
+func (m *MockSessionContract) RegisterSession(ctx context.Context, session *models.Session) (*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterSession", ctx, session)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterSession indicates an expected call of RegisterSession.
+func (mr *MockSessionContractMockRecorder) RegisterSession(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSession", reflect.TypeOf((*MockSessionContract)(nil).RegisterSession), ctx, session)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSessionContract) RevokeSession(ctx context.Context, email, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, email, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionContractMockRecorder) RevokeSession(ctx, email, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSessionContract)(nil).RevokeSession), ctx, email, sessionID)
+}
+
+// MockStoreContract is a mock of StoreContract interface.
+type MockStoreContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreContractMockRecorder
+}
+
+// MockStoreContractMockRecorder is the mock recorder for MockStoreContract.
+type MockStoreContractMockRecorder struct {
+	mock *MockStoreContract
+}
+
+// NewMockStoreContract creates a new mock instance.
+func NewMockStoreContract(ctrl *gomock.Controller) *MockStoreContract {
+	mock := &MockStoreContract{ctrl: ctrl}
+	mock.recorder = &MockStoreContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreContract) EXPECT() *MockStoreContractMockRecorder {
+	return m.recorder
+}
+
+// DeleteSessions mocks base method.
+func (m *MockStoreContract) DeleteSessions(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessions", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessions indicates an expected call of DeleteSessions.
+func (mr *MockStoreContractMockRecorder) DeleteSessions(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessions", reflect.TypeOf((*MockStoreContract)(nil).DeleteSessions), ctx, email)
+}
+
+// ListSessions mocks base method.
+func (m *MockStoreContract) ListSessions(ctx context.Context, email string, now time.Time) ([]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, email, now)
+	ret0, _ := ret[0].([]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockStoreContractMockRecorder) ListSessions(ctx, email, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockStoreContract)(nil).ListSessions), ctx, email, now)
+}
+
+// ReadSession mocks base method.
+func (m *MockStoreContract) ReadSession(ctx context.Context, sessionID string) (*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSession", ctx, sessionID)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSession indicates an expected call of ReadSession.
+func (mr *MockStoreContractMockRecorder) ReadSession(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSession", reflect.TypeOf((*MockStoreContract)(nil).ReadSession), ctx, sessionID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockStoreContract) RevokeSession(ctx context.Context, email, sessionID string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, email, sessionID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockStoreContractMockRecorder) RevokeSession(ctx, email, sessionID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockStoreContract)(nil).RevokeSession), ctx, email, sessionID, revokedAt)
+}
+
+// SaveSession mocks base method.
+func (m *MockStoreContract) SaveSession(ctx context.Context, session *models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSession indicates an expected call of SaveSession.
+func (mr *MockStoreContractMockRecorder) SaveSession(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSession", reflect.TypeOf((*MockStoreContract)(nil).SaveSession), ctx, session)
+}
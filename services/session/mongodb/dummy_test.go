@@ -0,0 +1 @@
+package mongodb_test
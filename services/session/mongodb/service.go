@@ -0,0 +1,267 @@
+// Package mongodb implements the MongoDB store that persists the sessions of the users
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/session"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongodbStoreService struct {
+	getConnectionString func() (string, error)
+	databaseName        string
+	collectionName      string
+}
+
+// NewMongodbStoreService creates new instance of the mongodbStoreService, setting up all dependencies, creating the
+// indexes of the session collection if they do not exist yet and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewMongodbStoreService(
+	configurationService configuration.ConfigurationContract) (session.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if _, err := configurationService.GetDatabaseConnectionString(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	databaseName, err := configurationService.GetDatabaseName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database name", err)
+	}
+
+	collectionName, err := configurationService.GetSessionCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the session collection name", err)
+	}
+
+	service := &mongodbStoreService{
+		getConnectionString: configurationService.GetDatabaseConnectionString,
+		databaseName:        databaseName,
+		collectionName:      collectionName,
+	}
+
+	if err = service.createIndexes(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+// ReadSession reads the persisted session
+// ctx: Mandatory The reference to the context
+// sessionID: Mandatory. The ID of the session
+// Returns either the session or error if something goes wrong. SessionNotFoundError is returned if there is no
+// session with the ID.
+func (service *mongodbStoreService) ReadSession(
+	ctx context.Context,
+	sessionID string) (*models.Session, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(ctx, client)
+
+	var readSession models.Session
+
+	filter := bson.D{{Key: "sessionid", Value: sessionID}}
+	err = collection.FindOne(ctx, filter).Decode(&readSession)
+	if err == mongo.ErrNoDocuments {
+		return nil, session.NewSessionNotFoundError(sessionID)
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read session", err)
+	}
+
+	return &readSession, nil
+}
+
+// SaveSession creates the session or replaces the persisted session with the same ID, the time the persisted
+// session is revoked at is kept, so a session revoked concurrently stays revoked
+// ctx: Mandatory The reference to the context
+// savedSession: Mandatory. The session to persist
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) SaveSession(
+	ctx context.Context,
+	savedSession *models.Session) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	filter := bson.D{{Key: "sessionid", Value: savedSession.SessionID}}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "email", Value: savedSession.Email},
+			{Key: "devicename", Value: savedSession.DeviceName},
+			{Key: "useragent", Value: savedSession.UserAgent},
+			{Key: "ipaddress", Value: savedSession.IPAddress},
+			{Key: "createdat", Value: savedSession.CreatedAt},
+			{Key: "lastseenat", Value: savedSession.LastSeenAt},
+			{Key: "expiresat", Value: savedSession.ExpiresAt},
+		}},
+		{Key: "$setOnInsert", Value: bson.D{{Key: "revokedat", Value: nil}}},
+	}
+
+	if _, err = collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to save session", err)
+	}
+
+	return nil
+}
+
+// ListSessions lists the persisted sessions of the user that are neither revoked nor expired, the most recently
+// seen first
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// now: Mandatory. The current time the sessions expired before are left out
+// Returns either the active sessions of the user or error if something goes wrong.
+func (service *mongodbStoreService) ListSessions(
+	ctx context.Context,
+	email string,
+	now time.Time) ([]models.Session, error) {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(ctx, client)
+
+	cursor, err := collection.Find(ctx, activeSessionFilter(email, now), options.Find().SetSort(bson.D{{Key: "lastseenat", Value: -1}}))
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list sessions", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	sessions := []models.Session{}
+	if err = cursor.All(ctx, &sessions); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to decode sessions", err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession records the time the active session of the user is revoked at
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// sessionID: Mandatory. The ID of the session
+// revokedAt: Mandatory. The time the session is revoked at, the sessions expired before are not revoked
+// Returns error if something goes wrong. SessionNotFoundError is returned if the user has no active session with
+// the ID.
+func (service *mongodbStoreService) RevokeSession(
+	ctx context.Context,
+	email string,
+	sessionID string,
+	revokedAt time.Time) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	filter := append(activeSessionFilter(email, revokedAt), bson.E{Key: "sessionid", Value: sessionID})
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "revokedat", Value: revokedAt}}}}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to revoke session", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return session.NewSessionNotFoundError(sessionID)
+	}
+
+	return nil
+}
+
+// DeleteSessions deletes all the persisted sessions of the user, it does nothing if the user has no session
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) DeleteSessions(
+	ctx context.Context,
+	email string) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	if _, err = collection.DeleteMany(ctx, bson.D{{Key: "email", Value: email}}); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to delete sessions", err)
+	}
+
+	return nil
+}
+
+// createIndexes creates the unique index on the session ID, the index the sessions of a user are listed with and
+// the TTL index MongoDB removes the expired sessions, including the revoked ones, with
+func (service *mongodbStoreService) createIndexes(ctx context.Context) error {
+	client, collection, err := service.createClientAndCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	if _, err = collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "sessionid", Value: 1}},
+			Options: options.Index().SetName("sessionid_1").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "email", Value: 1}, {Key: "lastseenat", Value: -1}},
+			Options: options.Index().SetName("email_1_lastseenat_-1"),
+		},
+		{
+			Keys:    bson.D{{Key: "expiresat", Value: 1}},
+			Options: options.Index().SetName("expiresat_1").SetExpireAfterSeconds(0),
+		},
+	}); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to create the session indexes", err)
+	}
+
+	return nil
+}
+
+func (service *mongodbStoreService) createClientAndCollection(ctx context.Context) (*mongo.Client, *mongo.Collection, error) {
+	connectionString, err := service.getConnectionString()
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	clientOptions := options.Client().ApplyURI(connectionString)
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, nil, commonErrors.NewUnknownErrorWithError("could not connect to mongodb database", err)
+	}
+
+	return client, client.Database(service.databaseName).Collection(service.collectionName), nil
+}
+
+// activeSessionFilter matches the sessions of the user that are neither revoked nor expired at the time
+func activeSessionFilter(email string, now time.Time) bson.D {
+	return bson.D{
+		{Key: "email", Value: email},
+		{Key: "revokedat", Value: nil},
+		{Key: "expiresat", Value: bson.D{{Key: "$gt", Value: now}}},
+	}
+}
+
+func disconnect(ctx context.Context, client *mongo.Client) {
+	_ = client.Disconnect(ctx)
+}
@@ -0,0 +1,261 @@
+// Package postgres implements the PostgreSQL store that persists the sessions of the users
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	configurationPostgres "github.com/decentralized-cloud/user/services/configuration/postgres"
+	"github.com/decentralized-cloud/user/services/session"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+const sessionColumns = "session_id, email, device_name, user_agent, ip_address, created_at, last_seen_at, expires_at, revoked_at"
+
+type postgresStoreService struct {
+	pool      *pgxpool.Pool
+	tableName string
+}
+
+// NewPostgresStoreService creates new instance of the postgresStoreService, setting up all dependencies, creating
+// the session table if it does not exist yet and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewPostgresStoreService(
+	configurationService configuration.ConfigurationContract) (session.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	connectionString, err := configurationService.GetDatabaseConnectionString()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to postgres", err)
+	}
+
+	// The session collection name is used as the name of the table the sessions are persisted in
+	tableName, err := configurationService.GetSessionCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the session table name", err)
+	}
+
+	ctx := context.Background()
+	pool, err := configurationPostgres.Connect(ctx, connectionString, configurationService.GetDatabaseConnectionString)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("could not connect to postgres database", err)
+	}
+
+	service := &postgresStoreService{
+		pool:      pool,
+		tableName: tableName,
+	}
+
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (
+			session_id TEXT PRIMARY KEY,
+			email TEXT NOT NULL,
+			device_name TEXT NOT NULL,
+			user_agent TEXT NOT NULL,
+			ip_address TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			last_seen_at TIMESTAMPTZ NOT NULL,
+			expires_at TIMESTAMPTZ NOT NULL,
+			revoked_at TIMESTAMPTZ)`,
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the session table", err)
+	}
+
+	if _, err = pool.Exec(ctx, fmt.Sprintf(
+		"CREATE INDEX IF NOT EXISTS %s ON %s (email, last_seen_at DESC)",
+		pgx.Identifier{tableName + "_email_idx"}.Sanitize(),
+		service.table())); err != nil {
+		pool.Close()
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to create the session email index", err)
+	}
+
+	return service, nil
+}
+
+// ReadSession reads the persisted session
+// ctx: Mandatory The reference to the context
+// sessionID: Mandatory. The ID of the session
+// Returns either the session or error if something goes wrong. SessionNotFoundError is returned if there is no
+// session with the ID.
+func (service *postgresStoreService) ReadSession(
+	ctx context.Context,
+	sessionID string) (*models.Session, error) {
+	readSession, err := scanSession(service.pool.QueryRow(
+		ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE session_id = $1", sessionColumns, service.table()),
+		sessionID))
+	if err == pgx.ErrNoRows {
+		return nil, session.NewSessionNotFoundError(sessionID)
+	} else if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read session", err)
+	}
+
+	return readSession, nil
+}
+
+// SaveSession creates the session or replaces the persisted session with the same ID, the time the persisted
+// session is revoked at is kept, so a session revoked concurrently stays revoked
+// ctx: Mandatory The reference to the context
+// savedSession: Mandatory. The session to persist
+// Returns error if something goes wrong.
+func (service *postgresStoreService) SaveSession(
+	ctx context.Context,
+	savedSession *models.Session) error {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf(
+			`INSERT INTO %s (%s) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULL)
+			ON CONFLICT (session_id) DO UPDATE SET
+				email = EXCLUDED.email,
+				device_name = EXCLUDED.device_name,
+				user_agent = EXCLUDED.user_agent,
+				ip_address = EXCLUDED.ip_address,
+				created_at = EXCLUDED.created_at,
+				last_seen_at = EXCLUDED.last_seen_at,
+				expires_at = EXCLUDED.expires_at`,
+			service.table(),
+			sessionColumns),
+		savedSession.SessionID,
+		savedSession.Email,
+		savedSession.DeviceName,
+		savedSession.UserAgent,
+		savedSession.IPAddress,
+		savedSession.CreatedAt,
+		savedSession.LastSeenAt,
+		savedSession.ExpiresAt); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to save session", err)
+	}
+
+	return nil
+}
+
+// ListSessions lists the persisted sessions of the user that are neither revoked nor expired, the most recently
+// seen first. PostgreSQL has no TTL index, so the expired sessions of the user are deleted before they are listed.
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// now: Mandatory. The current time the sessions expired before are left out
+// Returns either the active sessions of the user or error if something goes wrong.
+func (service *postgresStoreService) ListSessions(
+	ctx context.Context,
+	email string,
+	now time.Time) ([]models.Session, error) {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE email = $1 AND expires_at <= $2", service.table()),
+		email,
+		now); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to delete expired sessions", err)
+	}
+
+	rows, err := service.pool.Query(
+		ctx,
+		fmt.Sprintf(
+			"SELECT %s FROM %s WHERE email = $1 AND revoked_at IS NULL AND expires_at > $2 ORDER BY last_seen_at DESC",
+			sessionColumns,
+			service.table()),
+		email,
+		now)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list sessions", err)
+	}
+
+	defer rows.Close()
+
+	sessions := []models.Session{}
+	for rows.Next() {
+		scannedSession, err := scanSession(rows)
+		if err != nil {
+			return nil, commonErrors.NewUnknownErrorWithError("failed to decode sessions", err)
+		}
+
+		sessions = append(sessions, *scannedSession)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list sessions", err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession records the time the active session of the user is revoked at
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// sessionID: Mandatory. The ID of the session
+// revokedAt: Mandatory. The time the session is revoked at, the sessions expired before are not revoked
+// Returns error if something goes wrong. SessionNotFoundError is returned if the user has no active session with
+// the ID.
+func (service *postgresStoreService) RevokeSession(
+	ctx context.Context,
+	email string,
+	sessionID string,
+	revokedAt time.Time) error {
+	result, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf(
+			"UPDATE %s SET revoked_at = $3 WHERE session_id = $1 AND email = $2 AND revoked_at IS NULL AND expires_at > $3",
+			service.table()),
+		sessionID,
+		email,
+		revokedAt)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to revoke session", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return session.NewSessionNotFoundError(sessionID)
+	}
+
+	return nil
+}
+
+// DeleteSessions deletes all the persisted sessions of the user, it does nothing if the user has no session
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns error if something goes wrong.
+func (service *postgresStoreService) DeleteSessions(
+	ctx context.Context,
+	email string) error {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE email = $1", service.table()),
+		email); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to delete sessions", err)
+	}
+
+	return nil
+}
+
+func (service *postgresStoreService) table() string {
+	return pgx.Identifier{service.tableName}.Sanitize()
+}
+
+func scanSession(row pgx.Row) (*models.Session, error) {
+	var scannedSession models.Session
+
+	if err := row.Scan(
+		&scannedSession.SessionID,
+		&scannedSession.Email,
+		&scannedSession.DeviceName,
+		&scannedSession.UserAgent,
+		&scannedSession.IPAddress,
+		&scannedSession.CreatedAt,
+		&scannedSession.LastSeenAt,
+		&scannedSession.ExpiresAt,
+		&scannedSession.RevokedAt); err != nil {
+		return nil, err
+	}
+
+	return &scannedSession, nil
+}
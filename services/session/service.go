@@ -0,0 +1,127 @@
+// Package session implements the registry of the sessions, the logins of the users on their devices
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+type sessionService struct {
+	storeService StoreContract
+	clockService clock.ClockContract
+	sessionTTL   time.Duration
+}
+
+// NewSessionService creates new instance of the sessionService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// storeService: Mandatory. Reference to the service that persists the sessions of the users
+// clockService: Mandatory. Reference to the service that provides the current time
+// Returns the new service or error if something goes wrong
+func NewSessionService(
+	configurationService configuration.ConfigurationContract,
+	storeService StoreContract,
+	clockService clock.ClockContract) (SessionContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if storeService == nil {
+		return nil, commonErrors.NewArgumentNilError("storeService", "storeService is required")
+	}
+
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	sessionTTL, err := configurationService.GetSessionTTL()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the session TTL", err)
+	}
+
+	return &sessionService{
+		storeService: storeService,
+		clockService: clockService,
+		sessionTTL:   sessionTTL,
+	}, nil
+}
+
+// RegisterSession registers the new session or extends the existing session of the user, so it expires the
+// session TTL after now
+// ctx: Mandatory The reference to the context
+// session: Mandatory. The session containing its ID, the email address of the user and the device
+// Returns either the registered session or error if something goes wrong. SessionRevokedError is returned if the
+// session is revoked.
+func (service *sessionService) RegisterSession(
+	ctx context.Context,
+	session *models.Session) (*models.Session, error) {
+	now := service.clockService.Now()
+	registeredSession := *session
+	registeredSession.CreatedAt = now
+	registeredSession.LastSeenAt = now
+	registeredSession.ExpiresAt = now.Add(service.sessionTTL)
+	registeredSession.RevokedAt = nil
+
+	existingSession, err := service.storeService.ReadSession(ctx, session.SessionID)
+	if err != nil && !IsSessionNotFoundError(err) {
+		return nil, err
+	}
+
+	if err == nil {
+		if existingSession.Email != session.Email {
+			return nil, commonErrors.NewArgumentError("sessionID", "sessionID is registered for another user")
+		}
+
+		if existingSession.RevokedAt != nil {
+			return nil, NewSessionRevokedError(session.SessionID)
+		}
+
+		// The expired sessions are only kept until the store removes them, registering them again is a new login
+		if now.Before(existingSession.ExpiresAt) {
+			registeredSession.CreatedAt = existingSession.CreatedAt
+		}
+	}
+
+	if err = service.storeService.SaveSession(ctx, &registeredSession); err != nil {
+		return nil, err
+	}
+
+	return &registeredSession, nil
+}
+
+// ListSessions lists the active sessions of the user, the most recently seen first
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns either the active sessions of the user or error if something goes wrong.
+func (service *sessionService) ListSessions(
+	ctx context.Context,
+	email string) ([]models.Session, error) {
+	return service.storeService.ListSessions(ctx, email, service.clockService.Now())
+}
+
+// RevokeSession revokes the active session of the user
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// sessionID: Mandatory. The ID of the session
+// Returns error if something goes wrong. SessionNotFoundError is returned if the user has no active session with
+// the ID.
+func (service *sessionService) RevokeSession(
+	ctx context.Context,
+	email string,
+	sessionID string) error {
+	return service.storeService.RevokeSession(ctx, email, sessionID, service.clockService.Now())
+}
+
+// DeleteSessions deletes all the sessions of the user
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns error if something goes wrong.
+func (service *sessionService) DeleteSessions(
+	ctx context.Context,
+	email string) error {
+	return service.storeService.DeleteSessions(ctx, email)
+}
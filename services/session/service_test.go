@@ -0,0 +1,304 @@
+package session_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/session"
+	sessionMock "github.com/decentralized-cloud/user/services/session/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSessionService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Session Service Tests")
+}
+
+var _ = Describe("Session Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      session.SessionContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		mockStoreService         *sessionMock.MockStoreContract
+		mockClockService         *clockMock.MockClockContract
+		sessions                 map[string]*models.Session
+		sessionTTL               time.Duration
+		now                      time.Time
+		ctx                      context.Context
+		email                    string
+		newSession               func() *models.Session
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		sessionTTL = 24 * time.Hour
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetSessionTTL().
+			Return(sessionTTL, nil).
+			AnyTimes()
+
+		// The store keeps the sessions in memory by their IDs, so the changes to the sessions can be read back
+		sessions = map[string]*models.Session{}
+		mockStoreService = sessionMock.NewMockStoreContract(mockCtrl)
+		mockStoreService.
+			EXPECT().
+			ReadSession(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, sessionID string) (*models.Session, error) {
+				storedSession, ok := sessions[sessionID]
+				if !ok {
+					return nil, session.NewSessionNotFoundError(sessionID)
+				}
+
+				readSession := *storedSession
+
+				return &readSession, nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			SaveSession(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, savedSession *models.Session) error {
+				storedSession := *savedSession
+				if existingSession, ok := sessions[savedSession.SessionID]; ok {
+					storedSession.RevokedAt = existingSession.RevokedAt
+				}
+
+				sessions[savedSession.SessionID] = &storedSession
+
+				return nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			ListSessions(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, email string, now time.Time) ([]models.Session, error) {
+				result := []models.Session{}
+				for _, storedSession := range sessions {
+					if storedSession.Email == email && storedSession.RevokedAt == nil && now.Before(storedSession.ExpiresAt) {
+						result = append(result, *storedSession)
+					}
+				}
+
+				sort.Slice(result, func(i, j int) bool {
+					return result[i].LastSeenAt.After(result[j].LastSeenAt)
+				})
+
+				return result, nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			RevokeSession(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, email string, sessionID string, revokedAt time.Time) error {
+				storedSession, ok := sessions[sessionID]
+				if !ok || storedSession.Email != email || storedSession.RevokedAt != nil || !revokedAt.Before(storedSession.ExpiresAt) {
+					return session.NewSessionNotFoundError(sessionID)
+				}
+
+				storedSession.RevokedAt = &revokedAt
+
+				return nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			DeleteSessions(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, email string) error {
+				for sessionID, storedSession := range sessions {
+					if storedSession.Email == email {
+						delete(sessions, sessionID)
+					}
+				}
+
+				return nil
+			}).
+			AnyTimes()
+
+		now = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.
+			EXPECT().
+			Now().
+			DoAndReturn(func() time.Time { return now }).
+			AnyTimes()
+
+		ctx = context.Background()
+		email = cuid.New() + "@test.com"
+		newSession = func() *models.Session {
+			return &models.Session{
+				SessionID:  cuid.New(),
+				Email:      email,
+				DeviceName: "laptop",
+				UserAgent:  "Mozilla/5.0",
+				IPAddress:  "10.0.0.1",
+			}
+		}
+
+		var err error
+		sut, err = session.NewSessionService(mockConfigurationService, mockStoreService, mockClockService)
+		Ω(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate SessionService", func() {
+		When("configuration service is not provided and NewSessionService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := session.NewSessionService(nil, mockStoreService, mockClockService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("store service is not provided and NewSessionService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := session.NewSessionService(mockConfigurationService, nil, mockClockService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("clock service is not provided and NewSessionService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := session.NewSessionService(mockConfigurationService, mockStoreService, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("SessionService is instantiated", func() {
+		When("RegisterSession is called", func() {
+			It("should persist the new session expiring the session TTL after now", func() {
+				registeredSession, err := sut.RegisterSession(ctx, newSession())
+				Ω(err).Should(BeNil())
+				Ω(registeredSession.CreatedAt).Should(Equal(now))
+				Ω(registeredSession.LastSeenAt).Should(Equal(now))
+				Ω(registeredSession.ExpiresAt).Should(Equal(now.Add(sessionTTL)))
+				Ω(*sessions[registeredSession.SessionID]).Should(Equal(*registeredSession))
+			})
+
+			It("should extend the existing session and keep the time it is created at", func() {
+				userSession := newSession()
+				createdAt := now
+				_, err := sut.RegisterSession(ctx, userSession)
+				Ω(err).Should(BeNil())
+
+				now = now.Add(time.Hour)
+				registeredSession, err := sut.RegisterSession(ctx, userSession)
+				Ω(err).Should(BeNil())
+				Ω(registeredSession.CreatedAt).Should(Equal(createdAt))
+				Ω(registeredSession.LastSeenAt).Should(Equal(now))
+				Ω(registeredSession.ExpiresAt).Should(Equal(now.Add(sessionTTL)))
+			})
+
+			It("should register the expired session as a new session", func() {
+				userSession := newSession()
+				_, err := sut.RegisterSession(ctx, userSession)
+				Ω(err).Should(BeNil())
+
+				now = now.Add(sessionTTL)
+				registeredSession, err := sut.RegisterSession(ctx, userSession)
+				Ω(err).Should(BeNil())
+				Ω(registeredSession.CreatedAt).Should(Equal(now))
+			})
+
+			It("should return SessionRevokedError if the session is revoked", func() {
+				userSession := newSession()
+				_, err := sut.RegisterSession(ctx, userSession)
+				Ω(err).Should(BeNil())
+				Ω(sut.RevokeSession(ctx, email, userSession.SessionID)).Should(BeNil())
+
+				_, err = sut.RegisterSession(ctx, userSession)
+				Ω(session.IsSessionRevokedError(err)).Should(BeTrue())
+			})
+
+			It("should return ArgumentError if the session is registered for another user", func() {
+				userSession := newSession()
+				_, err := sut.RegisterSession(ctx, userSession)
+				Ω(err).Should(BeNil())
+
+				userSession.Email = cuid.New() + "@test.com"
+				_, err = sut.RegisterSession(ctx, userSession)
+				Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+			})
+		})
+
+		When("ListSessions is called", func() {
+			It("should return the active sessions of the user, the most recently seen first", func() {
+				firstSession, err := sut.RegisterSession(ctx, newSession())
+				Ω(err).Should(BeNil())
+
+				now = now.Add(time.Hour)
+				secondSession, err := sut.RegisterSession(ctx, newSession())
+				Ω(err).Should(BeNil())
+
+				revokedSession, err := sut.RegisterSession(ctx, newSession())
+				Ω(err).Should(BeNil())
+				Ω(sut.RevokeSession(ctx, email, revokedSession.SessionID)).Should(BeNil())
+
+				userSessions, err := sut.ListSessions(ctx, email)
+				Ω(err).Should(BeNil())
+				Ω(userSessions).Should(Equal([]models.Session{*secondSession, *firstSession}))
+			})
+
+			It("should not return the expired sessions", func() {
+				_, err := sut.RegisterSession(ctx, newSession())
+				Ω(err).Should(BeNil())
+
+				now = now.Add(sessionTTL)
+				userSessions, err := sut.ListSessions(ctx, email)
+				Ω(err).Should(BeNil())
+				Ω(userSessions).Should(BeEmpty())
+			})
+		})
+
+		When("RevokeSession is called", func() {
+			It("should return SessionNotFoundError if the session is of another user", func() {
+				userSession, err := sut.RegisterSession(ctx, newSession())
+				Ω(err).Should(BeNil())
+
+				err = sut.RevokeSession(ctx, cuid.New()+"@test.com", userSession.SessionID)
+				Ω(session.IsSessionNotFoundError(err)).Should(BeTrue())
+			})
+
+			It("should return SessionNotFoundError if the session is already revoked", func() {
+				userSession, err := sut.RegisterSession(ctx, newSession())
+				Ω(err).Should(BeNil())
+				Ω(sut.RevokeSession(ctx, email, userSession.SessionID)).Should(BeNil())
+
+				err = sut.RevokeSession(ctx, email, userSession.SessionID)
+				Ω(session.IsSessionNotFoundError(err)).Should(BeTrue())
+			})
+		})
+
+		When("DeleteSessions is called", func() {
+			It("should delete all the sessions of the user", func() {
+				_, err := sut.RegisterSession(ctx, newSession())
+				Ω(err).Should(BeNil())
+
+				Ω(sut.DeleteSessions(ctx, email)).Should(BeNil())
+				Ω(sessions).Should(BeEmpty())
+			})
+		})
+	})
+})
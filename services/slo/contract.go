@@ -0,0 +1,14 @@
+// Package slo implements the service level indicators of the user service and the Prometheus rules that track
+// them against the service level objectives
+package slo
+
+import "github.com/go-kit/kit/endpoint"
+
+// SloContract declares the service that measures the service level indicators of the operations
+type SloContract interface {
+	// CreateSLIMiddleware creates the middleware that records the availability and the latency of the endpoint calls
+	// transport: Mandatory. The name of the transport the endpoint is exposed through
+	// operation: Mandatory. The name of the operation the endpoint implements
+	// Returns the new middleware
+	CreateSLIMiddleware(transport, operation string) endpoint.Middleware
+}
@@ -0,0 +1,201 @@
+// Package slo implements the service level indicators of the user service and the Prometheus rules that track
+// them against the service level objectives
+package slo
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// RuleFile defines the Prometheus rule file
+type RuleFile struct {
+	Groups []RuleGroup `yaml:"groups" json:"groups"`
+}
+
+// RuleGroup defines a group of Prometheus rules that are evaluated together
+type RuleGroup struct {
+	Name  string `yaml:"name" json:"name"`
+	Rules []Rule `yaml:"rules" json:"rules"`
+}
+
+// Rule defines either a Prometheus recording rule or alerting rule
+type Rule struct {
+	Record      string            `yaml:"record,omitempty" json:"record,omitempty"`
+	Alert       string            `yaml:"alert,omitempty" json:"alert,omitempty"`
+	Expr        string            `yaml:"expr" json:"expr"`
+	For         string            `yaml:"for,omitempty" json:"for,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+}
+
+type sli struct {
+	name        string
+	title       string
+	objective   float64
+	errorsRatio func(window string) string
+}
+
+type burnRateAlert struct {
+	severity    string
+	longWindow  time.Duration
+	shortWindow time.Duration
+	burnRate    float64
+}
+
+// burnRateAlerts are the multiwindow, multi-burn-rate alerts recommended by the Google SRE workbook. The page
+// alerts fire when 2% of a 30 days error budget is burnt within 1 hour or 5% within 6 hours, the ticket alerts
+// when 10% is burnt within 3 days or the budget is being burnt at the same rate it is earned.
+var burnRateAlerts = []burnRateAlert{
+	{severity: "page", longWindow: time.Hour, shortWindow: 5 * time.Minute, burnRate: 14.4},
+	{severity: "page", longWindow: 6 * time.Hour, shortWindow: 30 * time.Minute, burnRate: 6},
+	{severity: "ticket", longWindow: 24 * time.Hour, shortWindow: 2 * time.Hour, burnRate: 3},
+	{severity: "ticket", longWindow: 72 * time.Hour, shortWindow: 6 * time.Hour, burnRate: 1},
+}
+
+// GenerateRules generates the Prometheus recording rules that compute the error ratios of the service level
+// indicators and the remaining error budgets, and the alerting rules that fire when the error budgets are burnt
+// too fast
+// objectives: Mandatory. The service level objectives to generate the rules for
+// Returns the Prometheus rule file
+func GenerateRules(objectives Objectives) RuleFile {
+	slis := []sli{
+		{
+			name:      "availability",
+			title:     "Availability",
+			objective: objectives.Availability,
+			errorsRatio: func(window string) string {
+				return fmt.Sprintf(
+					`sum(rate(user_sli_requests_total{outcome="%s"}[%s])) / sum(rate(user_sli_requests_total[%s]))`,
+					OutcomeServerError,
+					window,
+					window)
+			},
+		},
+		{
+			name:      "latency",
+			title:     "Latency",
+			objective: objectives.Latency,
+			errorsRatio: func(window string) string {
+				return fmt.Sprintf(
+					`1 - (sum(rate(user_sli_requests_within_latency_threshold_total[%s])) / sum(rate(user_sli_requests_total[%s])))`,
+					window,
+					window)
+			},
+		},
+	}
+
+	windows := getRecordedWindows(objectives.Window)
+	recordingRules := []Rule{}
+	alertingRules := []Rule{}
+
+	for _, sli := range slis {
+		for _, window := range windows {
+			recordingRules = append(recordingRules, Rule{
+				Record: getErrorRatioRecordName(sli.name, window),
+				Expr:   sli.errorsRatio(formatPrometheusDuration(window)),
+			})
+		}
+
+		errorBudget := fmt.Sprintf("(1 - %s)", formatFloat(sli.objective))
+		recordingRules = append(recordingRules, Rule{
+			Record: fmt.Sprintf("user:slo_%s:error_budget_remaining", sli.name),
+			Expr:   fmt.Sprintf("1 - (%s / %s)", getErrorRatioRecordName(sli.name, objectives.Window), errorBudget),
+		})
+
+		for _, severity := range []string{"page", "ticket"} {
+			conditions := []string{}
+			for _, alert := range burnRateAlerts {
+				if alert.severity != severity {
+					continue
+				}
+
+				conditions = append(conditions, fmt.Sprintf(
+					"(%s > (%s * %s) and %s > (%s * %s))",
+					getErrorRatioRecordName(sli.name, alert.longWindow),
+					formatFloat(alert.burnRate),
+					errorBudget,
+					getErrorRatioRecordName(sli.name, alert.shortWindow),
+					formatFloat(alert.burnRate),
+					errorBudget))
+			}
+
+			expr := conditions[0]
+			for _, condition := range conditions[1:] {
+				expr += " or " + condition
+			}
+
+			alertingRules = append(alertingRules, Rule{
+				Alert:  fmt.Sprintf("User%sErrorBudgetBurn", sli.title),
+				Expr:   expr,
+				Labels: map[string]string{"severity": severity, "sli": sli.name},
+				Annotations: map[string]string{
+					"summary": fmt.Sprintf("The user service is burning its %s error budget too fast", sli.name),
+					"description": fmt.Sprintf(
+						"The %s objective is %s over %s, at the current error rate the error budget will be exhausted before the window ends.",
+						sli.name,
+						formatFloat(sli.objective),
+						formatPrometheusDuration(objectives.Window)),
+				},
+			})
+		}
+	}
+
+	return RuleFile{
+		Groups: []RuleGroup{
+			{Name: "user-slo-recording-rules", Rules: recordingRules},
+			{Name: "user-slo-alerting-rules", Rules: alertingRules},
+		},
+	}
+}
+
+// getRecordedWindows returns the windows the error ratios are recorded for, that is the windows of the burn rate
+// alerts followed by the SLO window
+// sloWindow: Mandatory. The rolling window the error budgets are computed over
+// Returns the windows the error ratios are recorded for
+func getRecordedWindows(sloWindow time.Duration) []time.Duration {
+	windows := []time.Duration{}
+	seen := map[time.Duration]bool{}
+
+	for _, alert := range burnRateAlerts {
+		for _, window := range []time.Duration{alert.shortWindow, alert.longWindow} {
+			if !seen[window] {
+				seen[window] = true
+				windows = append(windows, window)
+			}
+		}
+	}
+
+	sort.Slice(windows, func(i, j int) bool { return windows[i] < windows[j] })
+
+	if !seen[sloWindow] {
+		windows = append(windows, sloWindow)
+	}
+
+	return windows
+}
+
+func getErrorRatioRecordName(sliName string, window time.Duration) string {
+	return fmt.Sprintf("user:sli_%s:error_ratio_rate%s", sliName, formatPrometheusDuration(window))
+}
+
+// formatPrometheusDuration formats the duration using the largest Prometheus time unit the duration is a multiple of
+// duration: Mandatory. The duration to format
+// Returns the formatted duration, e.g. 30d for 720h
+func formatPrometheusDuration(duration time.Duration) string {
+	switch {
+	case duration%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", duration/(24*time.Hour))
+	case duration%time.Hour == 0:
+		return fmt.Sprintf("%dh", duration/time.Hour)
+	case duration%time.Minute == 0:
+		return fmt.Sprintf("%dm", duration/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", duration/time.Second)
+	}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
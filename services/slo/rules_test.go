@@ -0,0 +1,79 @@
+package slo_test
+
+import (
+	"time"
+
+	"github.com/decentralized-cloud/user/services/slo"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SLO Rules Tests", func() {
+	var (
+		objectives slo.Objectives
+	)
+
+	BeforeEach(func() {
+		objectives = slo.Objectives{
+			Availability:     0.999,
+			Latency:          0.99,
+			LatencyThreshold: 300 * time.Millisecond,
+			Window:           30 * 24 * time.Hour,
+		}
+	})
+
+	When("the rules are generated", func() {
+		It("should record the error ratios for every burn rate window and the SLO window", func() {
+			ruleFile := slo.GenerateRules(objectives)
+			Ω(ruleFile.Groups).Should(HaveLen(2))
+
+			records := map[string]string{}
+			for _, rule := range ruleFile.Groups[0].Rules {
+				Ω(rule.Record).ShouldNot(BeEmpty())
+				records[rule.Record] = rule.Expr
+			}
+
+			for _, sli := range []string{"availability", "latency"} {
+				for _, window := range []string{"5m", "30m", "1h", "2h", "6h", "1d", "3d", "30d"} {
+					Ω(records).Should(HaveKey("user:sli_" + sli + ":error_ratio_rate" + window))
+				}
+
+				Ω(records).Should(HaveKey("user:slo_" + sli + ":error_budget_remaining"))
+			}
+
+			Ω(records["user:sli_availability:error_ratio_rate5m"]).Should(Equal(
+				`sum(rate(user_sli_requests_total{outcome="server_error"}[5m])) / sum(rate(user_sli_requests_total[5m]))`))
+			Ω(records["user:slo_availability:error_budget_remaining"]).Should(Equal(
+				"1 - (user:sli_availability:error_ratio_rate30d / (1 - 0.999))"))
+		})
+
+		It("should alert on the fast and the slow burn rates of every error budget", func() {
+			ruleFile := slo.GenerateRules(objectives)
+
+			alerts := map[string]string{}
+			for _, rule := range ruleFile.Groups[1].Rules {
+				Ω(rule.Alert).ShouldNot(BeEmpty())
+				alerts[rule.Alert+"/"+rule.Labels["sli"]+"/"+rule.Labels["severity"]] = rule.Expr
+			}
+
+			Ω(alerts).Should(HaveLen(4))
+			Ω(alerts["UserAvailabilityErrorBudgetBurn/availability/page"]).Should(Equal(
+				"(user:sli_availability:error_ratio_rate1h > (14.4 * (1 - 0.999)) and user:sli_availability:error_ratio_rate5m > (14.4 * (1 - 0.999))) or " +
+					"(user:sli_availability:error_ratio_rate6h > (6 * (1 - 0.999)) and user:sli_availability:error_ratio_rate30m > (6 * (1 - 0.999)))"))
+			Ω(alerts["UserLatencyErrorBudgetBurn/latency/ticket"]).Should(ContainSubstring("user:sli_latency:error_ratio_rate3d > (1 * (1 - 0.99))"))
+		})
+
+		It("should not record the SLO window twice if it is one of the burn rate windows", func() {
+			objectives.Window = 72 * time.Hour
+			ruleFile := slo.GenerateRules(objectives)
+
+			records := map[string]int{}
+			for _, rule := range ruleFile.Groups[0].Rules {
+				records[rule.Record]++
+			}
+
+			Ω(records["user:sli_availability:error_ratio_rate3d"]).Should(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,179 @@
+// Package slo implements the service level indicators of the user service and the Prometheus rules that track
+// them against the service level objectives
+package slo
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/go-kit/kit/endpoint"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// OutcomeSuccess is the outcome of the calls that completed successfully
+	OutcomeSuccess = "success"
+
+	// OutcomeClientError is the outcome of the calls that failed because of the caller, e.g. an invalid request.
+	// These calls do not consume the availability error budget.
+	OutcomeClientError = "client_error"
+
+	// OutcomeServerError is the outcome of the calls that failed because of the service or its dependencies
+	OutcomeServerError = "server_error"
+)
+
+var requestsCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "user_sli_requests_total",
+		Help: "The number of the calls grouped by the transport, the operation and the outcome (success, client_error or server_error)",
+	},
+	[]string{"transport", "operation", "outcome"})
+
+var requestsWithinLatencyThresholdCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "user_sli_requests_within_latency_threshold_total",
+		Help: "The number of the calls completed within the SLO latency threshold grouped by the transport and the operation",
+	},
+	[]string{"transport", "operation"})
+
+var requestsDuration = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "user_sli_request_duration_seconds",
+		Help:    "The duration of the calls grouped by the transport and the operation",
+		Buckets: prometheus.DefBuckets,
+	},
+	[]string{"transport", "operation"})
+
+var objectiveGauge = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "user_slo_objective_ratio",
+		Help: "The service level objective grouped by the service level indicator (availability or latency)",
+	},
+	[]string{"sli"})
+
+var latencyThresholdGauge = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "user_slo_latency_threshold_seconds",
+		Help: "The duration a call must complete within to count towards the latency objective",
+	})
+
+// Objectives contains the service level objectives of the user service
+type Objectives struct {
+	Availability     float64
+	Latency          float64
+	LatencyThreshold time.Duration
+	Window           time.Duration
+}
+
+type sloService struct {
+	objectives Objectives
+}
+
+// NewSloService creates new instance of the sloService, setting up all dependencies and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewSloService(configurationService configuration.ConfigurationContract) (SloContract, error) {
+	objectives, err := ReadObjectives(configurationService)
+	if err != nil {
+		return nil, err
+	}
+
+	objectiveGauge.WithLabelValues("availability").Set(objectives.Availability)
+	objectiveGauge.WithLabelValues("latency").Set(objectives.Latency)
+	latencyThresholdGauge.Set(objectives.LatencyThreshold.Seconds())
+
+	return &sloService{
+		objectives: *objectives,
+	}, nil
+}
+
+// ReadObjectives reads the service level objectives from the configuration
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the objectives or error if something goes wrong
+func ReadObjectives(configurationService configuration.ConfigurationContract) (*Objectives, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	availability, err := configurationService.GetSloAvailabilityObjective()
+	if err != nil {
+		return nil, err
+	}
+
+	latency, err := configurationService.GetSloLatencyObjective()
+	if err != nil {
+		return nil, err
+	}
+
+	latencyThreshold, err := configurationService.GetSloLatencyThreshold()
+	if err != nil {
+		return nil, err
+	}
+
+	window, err := configurationService.GetSloWindow()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Objectives{
+		Availability:     availability,
+		Latency:          latency,
+		LatencyThreshold: latencyThreshold,
+		Window:           window,
+	}, nil
+}
+
+// CreateSLIMiddleware creates the middleware that records the availability and the latency of the endpoint calls.
+// The failed calls are detected using both the returned error and the go-kit endpoint.Failer implemented by the response.
+// transport: Mandatory. The name of the transport the endpoint is exposed through
+// operation: Mandatory. The name of the operation the endpoint implements
+// Returns the new middleware
+func (service *sloService) CreateSLIMiddleware(transport, operation string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			startedAt := time.Now()
+
+			defer func() {
+				duration := time.Since(startedAt)
+
+				callErr := err
+				if failer, ok := response.(endpoint.Failer); ok && callErr == nil {
+					callErr = failer.Failed()
+				}
+
+				requestsCounter.WithLabelValues(transport, operation, GetOutcome(callErr)).Inc()
+				requestsDuration.WithLabelValues(transport, operation).Observe(duration.Seconds())
+
+				if duration <= service.objectives.LatencyThreshold {
+					requestsWithinLatencyThresholdCounter.WithLabelValues(transport, operation).Inc()
+				}
+			}()
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// GetOutcome classifies the error a call failed with
+// err: Optional. The error the call failed with
+// Returns the outcome of the call
+func GetOutcome(err error) string {
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+
+	case commonErrors.IsArgumentNilError(err),
+		commonErrors.IsArgumentError(err),
+		commonErrors.IsNotFoundError(err),
+		commonErrors.IsAlreadyExistsError(err),
+		repository.IsDataResidencyViolationError(err):
+		return OutcomeClientError
+
+	default:
+		return OutcomeServerError
+	}
+}
@@ -0,0 +1,195 @@
+package slo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/business"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/slo"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSloService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SLO Service Tests")
+}
+
+var _ = Describe("SLO Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      slo.SloContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		operation                string
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetSloAvailabilityObjective().
+			Return(0.999, nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetSloLatencyObjective().
+			Return(0.99, nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetSloLatencyThreshold().
+			Return(50*time.Millisecond, nil).
+			AnyTimes()
+
+		mockConfigurationService.
+			EXPECT().
+			GetSloWindow().
+			Return(30*24*time.Hour, nil).
+			AnyTimes()
+
+		sut, _ = slo.NewSloService(mockConfigurationService)
+		operation = cuid.New()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate SloService", func() {
+		When("configuration service is not provided and NewSloService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := slo.NewSloService(nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("configuration service fails to return the objectives", func() {
+			It("should return the same error", func() {
+				expectedError := errors.New(cuid.New())
+				failingConfigurationService := configurationMock.NewMockConfigurationContract(mockCtrl)
+				failingConfigurationService.
+					EXPECT().
+					GetSloAvailabilityObjective().
+					Return(0.0, expectedError)
+
+				service, err := slo.NewSloService(failingConfigurationService)
+				Ω(service).Should(BeNil())
+				Ω(err).Should(Equal(expectedError))
+			})
+		})
+	})
+
+	Context("SLI middleware wraps an endpoint", func() {
+		When("the endpoint completes successfully within the latency threshold", func() {
+			It("should record a successful call within the latency threshold", func() {
+				endpoint := sut.CreateSLIMiddleware("grpc", operation)(func(ctx context.Context, request interface{}) (interface{}, error) {
+					return &business.ReadUserResponse{}, nil
+				})
+
+				_, _ = endpoint(context.Background(), nil)
+
+				Ω(getRequestsCount(operation, slo.OutcomeSuccess)).Should(Equal(1.0))
+				Ω(getRequestsWithinLatencyThresholdCount(operation)).Should(Equal(1.0))
+			})
+		})
+
+		When("the endpoint takes longer than the latency threshold", func() {
+			It("should not count the call as within the latency threshold", func() {
+				endpoint := sut.CreateSLIMiddleware("grpc", operation)(func(ctx context.Context, request interface{}) (interface{}, error) {
+					time.Sleep(100 * time.Millisecond)
+
+					return &business.ReadUserResponse{}, nil
+				})
+
+				_, _ = endpoint(context.Background(), nil)
+
+				Ω(getRequestsCount(operation, slo.OutcomeSuccess)).Should(Equal(1.0))
+				Ω(getRequestsWithinLatencyThresholdCount(operation)).Should(Equal(0.0))
+			})
+		})
+
+		When("the response carries a business error", func() {
+			It("should classify the call using the business error", func() {
+				endpoint := sut.CreateSLIMiddleware("grpc", operation)(func(ctx context.Context, request interface{}) (interface{}, error) {
+					return &business.ReadUserResponse{Err: commonErrors.NewNotFoundError()}, nil
+				})
+
+				_, _ = endpoint(context.Background(), nil)
+
+				Ω(getRequestsCount(operation, slo.OutcomeClientError)).Should(Equal(1.0))
+				Ω(getRequestsCount(operation, slo.OutcomeSuccess)).Should(Equal(0.0))
+			})
+		})
+
+		When("the endpoint returns an error", func() {
+			It("should count the call as a server error", func() {
+				endpoint := sut.CreateSLIMiddleware("grpc", operation)(func(ctx context.Context, request interface{}) (interface{}, error) {
+					return nil, errors.New(cuid.New())
+				})
+
+				_, _ = endpoint(context.Background(), nil)
+
+				Ω(getRequestsCount(operation, slo.OutcomeServerError)).Should(Equal(1.0))
+			})
+		})
+	})
+
+	Context("a call outcome is classified", func() {
+		It("should tell the client errors apart from the server errors", func() {
+			Ω(slo.GetOutcome(nil)).Should(Equal(slo.OutcomeSuccess))
+			Ω(slo.GetOutcome(commonErrors.NewArgumentError("request", cuid.New()))).Should(Equal(slo.OutcomeClientError))
+			Ω(slo.GetOutcome(commonErrors.NewArgumentNilError("request", cuid.New()))).Should(Equal(slo.OutcomeClientError))
+			Ω(slo.GetOutcome(commonErrors.NewNotFoundError())).Should(Equal(slo.OutcomeClientError))
+			Ω(slo.GetOutcome(commonErrors.NewAlreadyExistsError())).Should(Equal(slo.OutcomeClientError))
+			Ω(slo.GetOutcome(commonErrors.NewUnknownError(cuid.New()))).Should(Equal(slo.OutcomeServerError))
+			Ω(slo.GetOutcome(errors.New(cuid.New()))).Should(Equal(slo.OutcomeServerError))
+		})
+	})
+})
+
+func getRequestsCount(operation, outcome string) float64 {
+	return getCounterValue("user_sli_requests_total", map[string]string{"transport": "grpc", "operation": operation, "outcome": outcome})
+}
+
+func getRequestsWithinLatencyThresholdCount(operation string) float64 {
+	return getCounterValue("user_sli_requests_within_latency_threshold_total", map[string]string{"transport": "grpc", "operation": operation})
+}
+
+func getCounterValue(name string, labels map[string]string) float64 {
+	metricFamilies, err := prometheus.DefaultGatherer.Gather()
+	Ω(err).Should(BeNil())
+
+	for _, metricFamily := range metricFamilies {
+		if metricFamily.GetName() != name {
+			continue
+		}
+
+		for _, metric := range metricFamily.GetMetric() {
+			matched := 0
+			for _, label := range metric.GetLabel() {
+				if labels[label.GetName()] == label.GetValue() {
+					matched++
+				}
+			}
+
+			if matched == len(labels) {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+
+	return 0
+}
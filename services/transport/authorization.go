@@ -0,0 +1,143 @@
+// Package transport implements different transport services required by the user service
+package transport
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/services/correlation"
+	"github.com/opentracing/opentracing-go"
+	"go.uber.org/zap"
+)
+
+const (
+	// AuthorizationCheckPassed indicates the authorization check allowed the call to proceed
+	AuthorizationCheckPassed = "passed"
+
+	// AuthorizationCheckFailed indicates the authorization check denied the call
+	AuthorizationCheckFailed = "failed"
+)
+
+// AuthorizationCheck contains the result of a single check evaluated while authorizing a call
+type AuthorizationCheck struct {
+	Name   string
+	Result string
+	Reason string
+}
+
+// AuthorizationDecision records the path of the checks evaluated to allow or deny a call so a denied call can
+// be traced back to the check that failed
+type AuthorizationDecision struct {
+	Transport        string
+	Endpoint         string
+	Subject          string
+	Email            string
+	Service          string
+	TenantID         string
+	CrossTenantReads bool
+	Checks           []AuthorizationCheck
+
+	// OwnerOnly indicates the call is allowed for the user of the caller only, while the request names the user by
+	// its ID so the ownership is verified by the endpoint once the user is read
+	OwnerOnly bool
+}
+
+// NewAuthorizationDecision creates new instance of the AuthorizationDecision with no check evaluated yet
+// transport: Mandatory. The name of the transport that received the call, e.g. grpc
+// endpoint: Mandatory. The name of the endpoint being authorized
+// Returns the new authorization decision
+func NewAuthorizationDecision(transport string, endpoint string) *AuthorizationDecision {
+	return &AuthorizationDecision{
+		Transport: transport,
+		Endpoint:  endpoint,
+		Checks:    []AuthorizationCheck{},
+	}
+}
+
+// Pass records the given check allowed the call to proceed
+// name: Mandatory. The name of the check, e.g. the rule, role or scope evaluated
+// reason: Mandatory. Why the check passed
+func (decision *AuthorizationDecision) Pass(name string, reason string) {
+	decision.Checks = append(decision.Checks, AuthorizationCheck{Name: name, Result: AuthorizationCheckPassed, Reason: reason})
+}
+
+// Fail records the given check denied the call
+// name: Mandatory. The name of the check, e.g. the rule, role or scope evaluated
+// err: Mandatory. The error the call is denied with
+// Returns the given error so the caller can return it straight away
+func (decision *AuthorizationDecision) Fail(name string, err error) error {
+	decision.Checks = append(decision.Checks, AuthorizationCheck{Name: name, Result: AuthorizationCheckFailed, Reason: err.Error()})
+
+	return err
+}
+
+// Allowed returns true if none of the evaluated checks denied the call
+func (decision *AuthorizationDecision) Allowed() bool {
+	for _, check := range decision.Checks {
+		if check.Result == AuthorizationCheckFailed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Record reports the decision as span events on a child span of the span found in the context and, if requested,
+// as log fields
+// ctx: Mandatory The reference to the context
+// logger: Mandatory. Reference to the logger service
+// logEnabled: Mandatory. Whether the decision should be logged as well
+func (decision *AuthorizationDecision) Record(ctx context.Context, logger *zap.Logger, logEnabled bool) {
+	span, _ := opentracing.StartSpanFromContext(ctx, "authorize "+decision.Endpoint)
+	defer span.Finish()
+
+	outcome := decision.outcome()
+	span.SetTag("authorization.transport", decision.Transport)
+	span.SetTag("authorization.endpoint", decision.Endpoint)
+	span.SetTag("authorization.decision", outcome)
+
+	path := make([]string, 0, len(decision.Checks))
+	for _, check := range decision.Checks {
+		span.LogKV(
+			"event", "authorization.check",
+			"check", check.Name,
+			"result", check.Result,
+			"reason", check.Reason)
+
+		path = append(path, check.Name+":"+check.Result)
+	}
+
+	if !logEnabled {
+		return
+	}
+
+	fields := []zap.Field{
+		zap.String("transport", decision.Transport),
+		zap.String("endpoint", decision.Endpoint),
+		zap.String("email", decision.Email),
+		zap.String("decision", outcome),
+		zap.Strings("path", path),
+	}
+
+	if decision.Service != "" {
+		fields = append(fields, zap.String("service", decision.Service))
+	}
+
+	if decision.TenantID != "" {
+		fields = append(fields, zap.String("tenant", decision.TenantID))
+	}
+
+	if len(decision.Checks) > 0 {
+		lastCheck := decision.Checks[len(decision.Checks)-1]
+		fields = append(fields, zap.String("check", lastCheck.Name), zap.String("reason", lastCheck.Reason))
+	}
+
+	correlation.GetLogger(ctx, logger).Info("Authorization decision", fields...)
+}
+
+func (decision *AuthorizationDecision) outcome() string {
+	if decision.Allowed() {
+		return "allowed"
+	}
+
+	return "denied"
+}
@@ -0,0 +1,127 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/decentralized-cloud/user/services/transport"
+	"github.com/lucsky/cuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestAuthorizationDecision(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Authorization Decision Tests")
+}
+
+var _ = Describe("Authorization Decision Tests", func() {
+	var (
+		tracer     *mocktracer.MockTracer
+		logs       *observer.ObservedLogs
+		logger     *zap.Logger
+		ctx        context.Context
+		endpoint   string
+		email      string
+		decision   *transport.AuthorizationDecision
+		denyReason error
+	)
+
+	BeforeEach(func() {
+		tracer = mocktracer.New()
+		opentracing.SetGlobalTracer(tracer)
+
+		var core zapcore.Core
+		core, logs = observer.New(zapcore.InfoLevel)
+		logger = zap.New(core)
+
+		ctx = context.Background()
+		endpoint = cuid.New()
+		email = cuid.New()
+		denyReason = errors.New(cuid.New())
+		decision = transport.NewAuthorizationDecision("grpc", endpoint)
+		decision.Email = email
+	})
+
+	AfterEach(func() {
+		opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+	})
+
+	Context("all checks passed", func() {
+		BeforeEach(func() {
+			decision.Pass("token", "The token is verified")
+			decision.Pass("email-ownership", "The email address matches the received one in the request")
+		})
+
+		It("should allow the call", func() {
+			Ω(decision.Allowed()).Should(BeTrue())
+		})
+
+		It("should record every check as a span event", func() {
+			decision.Record(ctx, logger, false)
+
+			spans := tracer.FinishedSpans()
+			Ω(spans).Should(HaveLen(1))
+			Ω(spans[0].OperationName).Should(Equal("authorize " + endpoint))
+			Ω(spans[0].Tag("authorization.decision")).Should(Equal("allowed"))
+			Ω(spans[0].Tag("authorization.endpoint")).Should(Equal(endpoint))
+			Ω(spans[0].Logs()).Should(HaveLen(2))
+			Ω(spans[0].Logs()[0].Fields).Should(ContainElement(mocktracer.MockKeyValue{Key: "check", ValueKind: reflect.String, ValueString: "token"}))
+			Ω(spans[0].Logs()[1].Fields).Should(ContainElement(mocktracer.MockKeyValue{Key: "check", ValueKind: reflect.String, ValueString: "email-ownership"}))
+		})
+
+		It("should not log the decision when logging is disabled", func() {
+			decision.Record(ctx, logger, false)
+
+			Ω(logs.Len()).Should(Equal(0))
+		})
+	})
+
+	Context("a check failed", func() {
+		var returnedErr error
+
+		BeforeEach(func() {
+			decision.Pass("token", "The token is verified")
+			returnedErr = decision.Fail("admin-role", denyReason)
+		})
+
+		It("should return the error the call is denied with", func() {
+			Ω(returnedErr).Should(Equal(denyReason))
+		})
+
+		It("should deny the call", func() {
+			Ω(decision.Allowed()).Should(BeFalse())
+		})
+
+		It("should tag the span with the denied decision", func() {
+			decision.Record(ctx, logger, false)
+
+			spans := tracer.FinishedSpans()
+			Ω(spans).Should(HaveLen(1))
+			Ω(spans[0].Tag("authorization.decision")).Should(Equal("denied"))
+			Ω(spans[0].Logs()[1].Fields).Should(ContainElement(mocktracer.MockKeyValue{Key: "reason", ValueKind: reflect.String, ValueString: denyReason.Error()}))
+		})
+
+		It("should log the path and the failed check when logging is enabled", func() {
+			decision.Record(ctx, logger, true)
+
+			Ω(logs.Len()).Should(Equal(1))
+
+			fields := logs.All()[0].ContextMap()
+			Ω(fields["endpoint"]).Should(Equal(endpoint))
+			Ω(fields["email"]).Should(Equal(email))
+			Ω(fields["decision"]).Should(Equal("denied"))
+			Ω(fields["check"]).Should(Equal("admin-role"))
+			Ω(fields["reason"]).Should(Equal(denyReason.Error()))
+			Ω(fields["path"]).Should(Equal([]interface{}{"token:passed", "admin-role:failed"}))
+		})
+	})
+})
@@ -1,6 +1,8 @@
 // Package transport implements different transport services required by the user service
 package transport
 
+import "net/http"
+
 // TransportContract declares the methods to be implemented by the transport service
 type TransportContract interface {
 	// Start the transport service.
@@ -11,3 +13,14 @@ type TransportContract interface {
 	// Returns error if something goes wrong.
 	Stop() error
 }
+
+// ConnectContract declares the methods to be implemented by the transport service that serves its operations over
+// the Connect protocol too, so the browsers can call them over HTTP/1.1
+type ConnectContract interface {
+	TransportContract
+
+	// CreateConnectHandler creates the handler that serves the unary operations over the Connect protocol, going
+	// through the same endpoints and middlewares the operations go through when called over gRPC.
+	// Returns the new handler.
+	CreateConnectHandler() http.Handler
+}
@@ -1 +0,0 @@
-package transport_test
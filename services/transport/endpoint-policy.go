@@ -0,0 +1,322 @@
+// Package transport implements different transport services required by the user service
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// EndpointRuleAuthenticated allows every authenticated caller to call the endpoint
+	EndpointRuleAuthenticated = "authenticated"
+
+	// EndpointRuleOwner allows the caller to call the endpoint for its own user only
+	EndpointRuleOwner = "owner"
+
+	// EndpointRuleAdmin allows the admins to call the endpoint
+	EndpointRuleAdmin = "admin"
+
+	// EndpointRuleOwnerOrAdmin allows the caller to call the endpoint for its own user and the admins for every user
+	EndpointRuleOwnerOrAdmin = "owner-or-admin"
+
+	// EndpointRuleDeny denies every caller, e.g. to turn off an endpoint that is not used
+	EndpointRuleDeny = "deny"
+)
+
+var endpointRules = []string{
+	EndpointRuleAuthenticated,
+	EndpointRuleOwner,
+	EndpointRuleAdmin,
+	EndpointRuleOwnerOrAdmin,
+	EndpointRuleDeny,
+}
+
+var (
+	// ErrEndpointDenied is returned when the rule of the endpoint denies every caller
+	ErrEndpointDenied = errors.New("the endpoint is denied by the authorization policy")
+
+	// ErrNotOwner is returned when the caller calls the endpoint for a user other than its own
+	ErrNotOwner = errors.New("email address does not match the received one in the request")
+
+	// ErrNotAdmin is returned when the caller is not an admin while the endpoint only allows the admins
+	ErrNotAdmin = errors.New("only the admins are allowed to call the endpoint")
+)
+
+// AdminResolver finds out whether the caller is an admin
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the caller
+// Returns why the caller is an admin, an empty string if the caller is not an admin, or error if something goes wrong
+type AdminResolver func(ctx context.Context, email string) (string, error)
+
+// endpointPolicyFile is the declarative policy file the operators tailor the rules of the endpoints with
+type endpointPolicyFile struct {
+	Endpoints map[string]string `yaml:"endpoints"`
+}
+
+// EndpointPolicy contains the rule every endpoint of a transport is authorized with
+type EndpointPolicy struct {
+	rules map[string]string
+}
+
+// NewEndpointPolicy creates new instance of the EndpointPolicy. The rules of the transport are overridden by the
+// given rules, the endpoints not overridden keep the rules of the transport.
+// defaultRules: Mandatory. The rule of every endpoint served by the transport
+// overrides: Optional. The rules replacing the rules of the transport, e.g. read from the policy file
+// Returns either the new policy or error if any override names an unknown endpoint or rule
+func NewEndpointPolicy(defaultRules map[string]string, overrides map[string]string) (*EndpointPolicy, error) {
+	policy := &EndpointPolicy{rules: map[string]string{}}
+	for endpointName, rule := range defaultRules {
+		policy.rules[endpointName] = rule
+	}
+
+	unknownEndpoints := []string{}
+	for endpointName, rule := range overrides {
+		if _, ok := defaultRules[endpointName]; !ok {
+			unknownEndpoints = append(unknownEndpoints, endpointName)
+
+			continue
+		}
+
+		if !isEndpointRule(rule) {
+			return nil, fmt.Errorf("the rule %q of %s is not supported, must be one of: %s", rule, endpointName, strings.Join(endpointRules, "|"))
+		}
+
+		policy.rules[endpointName] = rule
+	}
+
+	if len(unknownEndpoints) > 0 {
+		sort.Strings(unknownEndpoints)
+
+		return nil, fmt.Errorf("the authorization policy contains unknown endpoints: %s", strings.Join(unknownEndpoints, ", "))
+	}
+
+	return policy, nil
+}
+
+// ReadEndpointPolicyFile reads the rules of the endpoints from the YAML policy file, e.g.
+//
+//	endpoints:
+//	  CreateUser: admin
+//	  DeleteUser: owner-or-admin
+//
+// path: Optional. The path to the policy file, no rule is overridden if empty
+// Returns either the rules keyed by the endpoint names or error if the file can not be read or parsed
+func ReadEndpointPolicyFile(path string) (map[string]string, error) {
+	if strings.Trim(path, " ") == "" {
+		return map[string]string{}, nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the authorization policy file %s: %w", path, err)
+	}
+
+	policyFile := endpointPolicyFile{}
+	if err = yaml.UnmarshalStrict(content, &policyFile); err != nil {
+		return nil, fmt.Errorf("failed to parse the authorization policy file %s: %w", path, err)
+	}
+
+	if policyFile.Endpoints == nil {
+		return map[string]string{}, nil
+	}
+
+	return policyFile.Endpoints, nil
+}
+
+// Rule returns the rule the given endpoint is authorized with
+// endpointName: Mandatory. The name of the endpoint
+// Returns the rule and true if the endpoint is known by the policy
+func (policy *EndpointPolicy) Rule(endpointName string) (string, bool) {
+	rule, ok := policy.rules[endpointName]
+
+	return rule, ok
+}
+
+// Apply evaluates the rule of the endpoint for the caller recorded in the decision
+// ctx: Mandatory The reference to the context
+// decision: Mandatory. The decision the caller is read from and the checks are recorded to
+// request: Mandatory. The request of the call, the user it is made for is read from its Email, Emails or UserID field
+// resolveAdmin: Optional. Finds out whether the caller is an admin, nobody is an admin if not provided
+// Returns error if the call is denied, ErrEndpointDenied, ErrNotOwner or ErrNotAdmin if denied by the rule
+func (policy *EndpointPolicy) Apply(
+	ctx context.Context,
+	decision *AuthorizationDecision,
+	request interface{},
+	resolveAdmin AdminResolver) error {
+	switch rule := policy.rules[decision.Endpoint]; rule {
+	case EndpointRuleAuthenticated:
+		decision.Pass("any-authenticated-user", "The endpoint is allowed for every authenticated user")
+
+		return nil
+
+	case EndpointRuleOwner:
+		if isUserIDRequest(request) && decision.Email != "" {
+			policy.deferOwnership(decision)
+
+			return nil
+		}
+
+		if !isRequestOwner(request, decision.Email) {
+			return decision.Fail("email-ownership", ErrNotOwner)
+		}
+
+		decision.Pass("email-ownership", "The email address matches the received one in the request")
+
+		return nil
+
+	case EndpointRuleAdmin:
+		return policy.applyAdmin(ctx, decision, resolveAdmin)
+
+	case EndpointRuleOwnerOrAdmin:
+		if isRequestOwner(request, decision.Email) {
+			decision.Pass("email-ownership", "The email address matches the received one in the request")
+
+			return nil
+		}
+
+		if isUserIDRequest(request) && decision.Email != "" {
+			return policy.applyAdminOrDeferOwnership(ctx, decision, resolveAdmin)
+		}
+
+		return policy.applyAdmin(ctx, decision, resolveAdmin)
+
+	default:
+		// An endpoint without a rule is denied, so an endpoint added without a rule is never open by mistake
+		return decision.Fail("endpoint-policy", ErrEndpointDenied)
+	}
+}
+
+func (policy *EndpointPolicy) applyAdmin(ctx context.Context, decision *AuthorizationDecision, resolveAdmin AdminResolver) error {
+	if resolveAdmin == nil {
+		return decision.Fail("admin-role", ErrNotAdmin)
+	}
+
+	reason, err := resolveAdmin(ctx, decision.Email)
+	if err != nil {
+		return decision.Fail("admin-role", err)
+	}
+
+	if reason == "" {
+		return decision.Fail("admin-role", ErrNotAdmin)
+	}
+
+	decision.Pass("admin-role", reason)
+
+	return nil
+}
+
+// applyAdminOrDeferOwnership passes the admins, and defers the ownership check of the others until the user named by
+// its ID is read
+func (policy *EndpointPolicy) applyAdminOrDeferOwnership(ctx context.Context, decision *AuthorizationDecision, resolveAdmin AdminResolver) error {
+	if resolveAdmin != nil {
+		reason, err := resolveAdmin(ctx, decision.Email)
+		if err != nil {
+			return decision.Fail("admin-role", err)
+		}
+
+		if reason != "" {
+			decision.Pass("admin-role", reason)
+
+			return nil
+		}
+	}
+
+	policy.deferOwnership(decision)
+
+	return nil
+}
+
+// deferOwnership lets the call for a user named by its ID proceed, the endpoint reports the users of the others as
+// not found once the user is read
+func (policy *EndpointPolicy) deferOwnership(decision *AuthorizationDecision) {
+	decision.OwnerOnly = true
+	decision.Pass("user-id-ownership", "The user is named by its ID, the endpoint only returns the user of the caller")
+}
+
+func isEndpointRule(rule string) bool {
+	for _, endpointRule := range endpointRules {
+		if rule == endpointRule {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isUserIDRequest returns true if the request names the user by its UserID field instead of its email address, or
+// names the users only by their UserIDs field instead of their Emails field
+func isUserIDRequest(request interface{}) bool {
+	value, ok := requestStruct(request)
+	if !ok {
+		return false
+	}
+
+	if field := value.FieldByName("Email"); field.IsValid() && field.Kind() == reflect.String && field.String() != "" {
+		return false
+	}
+
+	if field := value.FieldByName("Emails"); isStringSlice(field) && field.Len() > 0 {
+		return false
+	}
+
+	if field := value.FieldByName("UserIDs"); isStringSlice(field) && field.Len() > 0 {
+		return true
+	}
+
+	field := value.FieldByName("UserID")
+
+	return field.IsValid() && field.Kind() == reflect.String && field.String() != ""
+}
+
+// requestStruct returns the struct the request points to, and false if the request is not a struct
+func requestStruct(request interface{}) (reflect.Value, bool) {
+	value := reflect.ValueOf(request)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return value, false
+		}
+
+		value = value.Elem()
+	}
+
+	return value, value.Kind() == reflect.Struct
+}
+
+// isRequestOwner returns true if the request is made for the user of the given email address. The user is read from
+// the Email field of the request, or the Emails field if it names a single user and no user is named by its ID.
+func isRequestOwner(request interface{}, email string) bool {
+	if email == "" {
+		return false
+	}
+
+	value, ok := requestStruct(request)
+	if !ok {
+		return false
+	}
+
+	if field := value.FieldByName("Email"); field.IsValid() && field.Kind() == reflect.String {
+		return field.String() == email
+	}
+
+	if field := value.FieldByName("UserIDs"); isStringSlice(field) && field.Len() > 0 {
+		return false
+	}
+
+	if field := value.FieldByName("Emails"); isStringSlice(field) {
+		return field.Len() == 1 && field.Index(0).String() == email
+	}
+
+	return false
+}
+
+// isStringSlice returns true if the field of the request is a slice of strings
+func isStringSlice(field reflect.Value) bool {
+	return field.IsValid() && field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String
+}
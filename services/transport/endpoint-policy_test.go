@@ -0,0 +1,261 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/decentralized-cloud/user/services/transport"
+	"github.com/lucsky/cuid"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type endpointPolicyRequest struct {
+	Email string
+}
+
+type endpointPolicyUserIDRequest struct {
+	Email  string
+	UserID string
+}
+
+type endpointPolicyBatchRequest struct {
+	Emails  []string
+	UserIDs []string
+}
+
+var _ = Describe("Endpoint Policy Tests", func() {
+	var (
+		ctx          context.Context
+		decision     *transport.AuthorizationDecision
+		email        string
+		defaultRules map[string]string
+		resolveAdmin transport.AdminResolver
+		adminEmail   string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		email = cuid.New() + "@test.com"
+		adminEmail = cuid.New() + "@test.com"
+
+		defaultRules = map[string]string{
+			"CreateUser":  transport.EndpointRuleAuthenticated,
+			"ReadUser":    transport.EndpointRuleOwner,
+			"ListUsers":   transport.EndpointRuleAdmin,
+			"DeleteUser":  transport.EndpointRuleOwnerOrAdmin,
+			"ExportUsers": transport.EndpointRuleDeny,
+		}
+
+		resolveAdmin = func(ctx context.Context, email string) (string, error) {
+			if email == adminEmail {
+				return "The email address is listed in ADMIN_EMAILS", nil
+			}
+
+			return "", nil
+		}
+	})
+
+	newDecision := func(endpointName string, callerEmail string) *transport.AuthorizationDecision {
+		decision := transport.NewAuthorizationDecision("grpc", endpointName)
+		decision.Email = callerEmail
+
+		return decision
+	}
+
+	Context("the policy is created", func() {
+		It("should keep the default rules of the endpoints not overridden", func() {
+			policy, err := transport.NewEndpointPolicy(defaultRules, map[string]string{"CreateUser": transport.EndpointRuleAdmin})
+			Ω(err).Should(BeNil())
+
+			rule, ok := policy.Rule("CreateUser")
+			Ω(ok).Should(BeTrue())
+			Ω(rule).Should(Equal(transport.EndpointRuleAdmin))
+
+			rule, ok = policy.Rule("ReadUser")
+			Ω(ok).Should(BeTrue())
+			Ω(rule).Should(Equal(transport.EndpointRuleOwner))
+		})
+
+		It("should return error if an override names an unknown endpoint", func() {
+			_, err := transport.NewEndpointPolicy(defaultRules, map[string]string{"ReadUsr": transport.EndpointRuleAdmin})
+			Ω(err).ShouldNot(BeNil())
+			Ω(err.Error()).Should(ContainSubstring("ReadUsr"))
+		})
+
+		It("should return error if an override names an unknown rule", func() {
+			_, err := transport.NewEndpointPolicy(defaultRules, map[string]string{"ReadUser": "everyone"})
+			Ω(err).ShouldNot(BeNil())
+			Ω(err.Error()).Should(ContainSubstring("everyone"))
+		})
+	})
+
+	Context("the policy file is read", func() {
+		var directory string
+
+		BeforeEach(func() {
+			var err error
+			directory, err = ioutil.TempDir("", "endpoint-policy")
+			Ω(err).Should(BeNil())
+		})
+
+		AfterEach(func() {
+			_ = os.RemoveAll(directory)
+		})
+
+		It("should not override any rule if no path is provided", func() {
+			rules, err := transport.ReadEndpointPolicyFile("")
+			Ω(err).Should(BeNil())
+			Ω(rules).Should(BeEmpty())
+		})
+
+		It("should read the rules of the endpoints", func() {
+			path := filepath.Join(directory, "policy.yaml")
+			Ω(ioutil.WriteFile(path, []byte("endpoints:\n  CreateUser: admin\n  DeleteUser: owner-or-admin\n"), 0600)).Should(BeNil())
+
+			rules, err := transport.ReadEndpointPolicyFile(path)
+			Ω(err).Should(BeNil())
+			Ω(rules).Should(Equal(map[string]string{
+				"CreateUser": transport.EndpointRuleAdmin,
+				"DeleteUser": transport.EndpointRuleOwnerOrAdmin,
+			}))
+		})
+
+		It("should return error if the file contains unknown fields", func() {
+			path := filepath.Join(directory, "policy.yaml")
+			Ω(ioutil.WriteFile(path, []byte("endpoint:\n  CreateUser: admin\n"), 0600)).Should(BeNil())
+
+			_, err := transport.ReadEndpointPolicyFile(path)
+			Ω(err).ShouldNot(BeNil())
+		})
+
+		It("should return error if the file does not exist", func() {
+			_, err := transport.ReadEndpointPolicyFile(filepath.Join(directory, "missing.yaml"))
+			Ω(err).ShouldNot(BeNil())
+		})
+	})
+
+	Context("the policy is applied", func() {
+		var policy *transport.EndpointPolicy
+
+		BeforeEach(func() {
+			var err error
+			policy, err = transport.NewEndpointPolicy(defaultRules, nil)
+			Ω(err).Should(BeNil())
+		})
+
+		It("should allow every authenticated caller to call the authenticated endpoints", func() {
+			decision = newDecision("CreateUser", email)
+
+			Ω(policy.Apply(ctx, decision, &endpointPolicyRequest{Email: cuid.New()}, resolveAdmin)).Should(BeNil())
+			Ω(decision.Checks[0].Name).Should(Equal("any-authenticated-user"))
+			Ω(decision.Allowed()).Should(BeTrue())
+		})
+
+		It("should allow the owner to call the owner endpoints", func() {
+			decision = newDecision("ReadUser", email)
+
+			Ω(policy.Apply(ctx, decision, &endpointPolicyRequest{Email: email}, resolveAdmin)).Should(BeNil())
+			Ω(decision.Checks[0].Name).Should(Equal("email-ownership"))
+			Ω(decision.Allowed()).Should(BeTrue())
+		})
+
+		It("should read the owner of the batch requests naming a single user", func() {
+			Ω(policy.Apply(ctx, newDecision("ReadUser", email), &endpointPolicyBatchRequest{Emails: []string{email}}, resolveAdmin)).Should(BeNil())
+
+			err := policy.Apply(ctx, newDecision("ReadUser", email), &endpointPolicyBatchRequest{Emails: []string{email, cuid.New()}}, resolveAdmin)
+			Ω(errors.Is(err, transport.ErrNotOwner)).Should(BeTrue())
+		})
+
+		It("should defer the ownership of the batch requests naming the users only by their IDs to the endpoint", func() {
+			decision = newDecision("ReadUser", email)
+
+			Ω(policy.Apply(ctx, decision, &endpointPolicyBatchRequest{UserIDs: []string{cuid.New()}}, resolveAdmin)).Should(BeNil())
+			Ω(decision.Checks[0].Name).Should(Equal("user-id-ownership"))
+			Ω(decision.OwnerOnly).Should(BeTrue())
+
+			err := policy.Apply(ctx, newDecision("ReadUser", email), &endpointPolicyBatchRequest{Emails: []string{email}, UserIDs: []string{cuid.New()}}, resolveAdmin)
+			Ω(errors.Is(err, transport.ErrNotOwner)).Should(BeTrue())
+		})
+
+		It("should deny the other callers to call the owner endpoints, even the admins", func() {
+			decision = newDecision("ReadUser", adminEmail)
+
+			err := policy.Apply(ctx, decision, &endpointPolicyRequest{Email: email}, resolveAdmin)
+			Ω(errors.Is(err, transport.ErrNotOwner)).Should(BeTrue())
+			Ω(decision.Allowed()).Should(BeFalse())
+		})
+
+		It("should defer the ownership of the users named by their ID to the endpoint", func() {
+			decision = newDecision("ReadUser", email)
+
+			Ω(policy.Apply(ctx, decision, &endpointPolicyUserIDRequest{UserID: cuid.New()}, resolveAdmin)).Should(BeNil())
+			Ω(decision.Checks[0].Name).Should(Equal("user-id-ownership"))
+			Ω(decision.OwnerOnly).Should(BeTrue())
+
+			err := policy.Apply(ctx, newDecision("ReadUser", email), &endpointPolicyUserIDRequest{Email: cuid.New(), UserID: cuid.New()}, resolveAdmin)
+			Ω(errors.Is(err, transport.ErrNotOwner)).Should(BeTrue())
+		})
+
+		It("should only defer the ownership of the users named by their ID for the callers that are not admins", func() {
+			decision = newDecision("DeleteUser", adminEmail)
+			Ω(policy.Apply(ctx, decision, &endpointPolicyUserIDRequest{UserID: cuid.New()}, resolveAdmin)).Should(BeNil())
+			Ω(decision.Checks[0].Name).Should(Equal("admin-role"))
+			Ω(decision.OwnerOnly).Should(BeFalse())
+
+			decision = newDecision("DeleteUser", email)
+			Ω(policy.Apply(ctx, decision, &endpointPolicyUserIDRequest{UserID: cuid.New()}, resolveAdmin)).Should(BeNil())
+			Ω(decision.Checks[0].Name).Should(Equal("user-id-ownership"))
+			Ω(decision.OwnerOnly).Should(BeTrue())
+		})
+
+		It("should allow only the admins to call the admin endpoints", func() {
+			decision = newDecision("ListUsers", adminEmail)
+			Ω(policy.Apply(ctx, decision, &endpointPolicyRequest{}, resolveAdmin)).Should(BeNil())
+			Ω(decision.Checks[0].Name).Should(Equal("admin-role"))
+			Ω(decision.Checks[0].Reason).Should(Equal("The email address is listed in ADMIN_EMAILS"))
+
+			err := policy.Apply(ctx, newDecision("ListUsers", email), &endpointPolicyRequest{}, resolveAdmin)
+			Ω(errors.Is(err, transport.ErrNotAdmin)).Should(BeTrue())
+		})
+
+		It("should deny the admin endpoints if the admins can not be resolved", func() {
+			err := policy.Apply(ctx, newDecision("ListUsers", adminEmail), &endpointPolicyRequest{}, nil)
+			Ω(errors.Is(err, transport.ErrNotAdmin)).Should(BeTrue())
+		})
+
+		It("should return the error resolving the admins", func() {
+			resolveErr := errors.New(cuid.New())
+
+			err := policy.Apply(ctx, newDecision("ListUsers", adminEmail), &endpointPolicyRequest{}, func(ctx context.Context, email string) (string, error) {
+				return "", resolveErr
+			})
+			Ω(err).Should(Equal(resolveErr))
+		})
+
+		It("should allow both the owner and the admins to call the owner-or-admin endpoints", func() {
+			Ω(policy.Apply(ctx, newDecision("DeleteUser", email), &endpointPolicyRequest{Email: email}, resolveAdmin)).Should(BeNil())
+			Ω(policy.Apply(ctx, newDecision("DeleteUser", adminEmail), &endpointPolicyRequest{Email: email}, resolveAdmin)).Should(BeNil())
+
+			err := policy.Apply(ctx, newDecision("DeleteUser", cuid.New()+"@test.com"), &endpointPolicyRequest{Email: email}, resolveAdmin)
+			Ω(errors.Is(err, transport.ErrNotAdmin)).Should(BeTrue())
+		})
+
+		It("should deny every caller to call the denied endpoints", func() {
+			err := policy.Apply(ctx, newDecision("ExportUsers", adminEmail), &endpointPolicyRequest{Email: adminEmail}, resolveAdmin)
+			Ω(errors.Is(err, transport.ErrEndpointDenied)).Should(BeTrue())
+		})
+
+		It("should deny the endpoints without a rule", func() {
+			decision = newDecision(cuid.New(), email)
+
+			err := policy.Apply(ctx, decision, &endpointPolicyRequest{Email: email}, resolveAdmin)
+			Ω(errors.Is(err, transport.ErrEndpointDenied)).Should(BeTrue())
+			Ω(decision.Checks[0].Name).Should(Equal("endpoint-policy"))
+		})
+	})
+})
@@ -0,0 +1,95 @@
+// Package graphql implements functions to expose user service endpoint using GraphQL protocol.
+package graphql
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/transport"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+type contextKey string
+
+var contextKeyAuthorizationHeader = contextKey("AuthorizationHeader")
+
+// defaultEndpointRules contains the rule every endpoint served by the GraphQL transport is authorized with, unless the
+// authorization policy file overrides it. The admins are not resolved by the GraphQL transport, so the admin rules deny
+// every caller. The callers can only search for their own user, by naming only their own email address.
+var defaultEndpointRules = map[string]string{
+	"CreateUser": transport.EndpointRuleAuthenticated,
+	"ReadUser":   transport.EndpointRuleOwner,
+	"UpdateUser": transport.EndpointRuleOwner,
+	"DeleteUser": transport.EndpointRuleOwner,
+	"Search":     transport.EndpointRuleOwner,
+}
+
+// withAuthorizationHeader stores the received authorization header in the request context so the
+// auth middleware can verify it once the GraphQL resolvers call into the endpoints
+func withAuthorizationHeader(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		ctx := context.WithValue(request.Context(), contextKeyAuthorizationHeader, request.Header.Get("Authorization"))
+		next.ServeHTTP(writer, request.WithContext(ctx))
+	})
+}
+
+func (service *transportService) createAuthMiddleware(endpointName string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			decision := transport.NewAuthorizationDecision("graphql", endpointName)
+			err = service.isAuthorized(ctx, decision, endpointName, request)
+			decision.Record(ctx, service.logger, service.logAuthorizationDecisions)
+
+			if err != nil {
+				return nil, err
+			}
+
+			parsedToken := models.ParsedToken{
+				Subject:          decision.Subject,
+				Email:            decision.Email,
+				TenantID:         decision.TenantID,
+				CrossTenantReads: decision.CrossTenantReads,
+				OwnerOnly:        decision.OwnerOnly,
+			}
+			ctx = context.WithValue(ctx, models.ContextKeyParsedToken, parsedToken)
+
+			return next(ctx, request)
+		}
+	}
+}
+
+func (service *transportService) isAuthorized(ctx context.Context, decision *transport.AuthorizationDecision, endpointName string, request interface{}) error {
+	token, err := service.parseAndVerifyToken(ctx)
+	if err != nil {
+		return decision.Fail("token", err)
+	}
+
+	decision.Pass("token", "The token is verified")
+
+	if err = service.tokenPolicy.Apply(decision, token); err != nil {
+		return err
+	}
+
+	if err = service.tenancyPolicy.Apply(decision, token); err != nil {
+		return err
+	}
+
+	if decision.Service != "" {
+		return decision.Fail("service-identity", errors.New("service identities are only allowed to call the gRPC endpoints"))
+	}
+
+	return service.endpointPolicy.Apply(ctx, decision, request, nil)
+}
+
+func (service *transportService) parseAndVerifyToken(ctx context.Context) (jwt.Token, error) {
+	authorizationHeader, _ := ctx.Value(contextKeyAuthorizationHeader).(string)
+	if !strings.HasPrefix(authorizationHeader, "Bearer ") {
+		return nil, errors.New("bearer token is not included in the authorization header")
+	}
+
+	return service.jwksProvider.ParseAndVerifyToken(strings.TrimPrefix(authorizationHeader, "Bearer "))
+}
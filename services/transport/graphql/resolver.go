@@ -0,0 +1,416 @@
+// Package graphql implements functions to expose user service endpoint using GraphQL protocol.
+package graphql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/business"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+type rootResolver struct {
+	service *transportService
+}
+
+type userResolver struct {
+	email string
+	user  models.User
+}
+
+type labelResolver struct {
+	key   string
+	value string
+}
+
+type userEdgeResolver struct {
+	node   *userResolver
+	cursor string
+}
+
+type pageInfoResolver struct {
+	hasNextPage     bool
+	hasPreviousPage bool
+	startCursor     *string
+	endCursor       *string
+}
+
+type userConnectionResolver struct {
+	pageInfo   *pageInfoResolver
+	edges      []*userEdgeResolver
+	totalCount int32
+}
+
+type createUserPayloadResolver struct {
+	user             *userEdgeResolver
+	clientMutationID *string
+}
+
+type updateUserPayloadResolver struct {
+	user             *userEdgeResolver
+	clientMutationID *string
+}
+
+type deleteUserPayloadResolver struct {
+	deletedUserEmail string
+	clientMutationID *string
+}
+
+type sortingOptionPairInput struct {
+	Name      string
+	Direction string
+}
+
+type createUserInput struct {
+	ClientMutationID *string
+}
+
+type labelInput struct {
+	Key   string
+	Value string
+}
+
+type updateUserInput struct {
+	Email            *string
+	ID               *graphqlgo.ID
+	Labels           *[]labelInput
+	ClientMutationID *string
+}
+
+type deleteUserInput struct {
+	Email            string
+	ClientMutationID *string
+}
+
+// User reads an existing user
+// ctx: Mandatory. The reference to the context
+// args: Mandatory. The email address of the user to read
+// Returns the user or error if something goes wrong
+func (r *rootResolver) User(
+	ctx context.Context,
+	args struct {
+		Email string
+	}) (*userResolver, error) {
+	response, err := r.service.readUserEndpoint(ctx, &business.ReadUserRequest{Email: args.Email})
+	if err != nil {
+		return nil, err
+	}
+
+	castedResponse := response.(*business.ReadUserResponse)
+	if castedResponse.Err != nil {
+		return nil, castedResponse.Err
+	}
+
+	return &userResolver{
+		email: args.Email,
+		user:  castedResponse.User,
+	}, nil
+}
+
+// Users searches for users that matched the criteria using Relay style pagination
+// ctx: Mandatory. The reference to the context
+// args: Mandatory. The pagination, filtering and sorting details
+// Returns the users connection or error if something goes wrong
+func (r *rootResolver) Users(
+	ctx context.Context,
+	args struct {
+		After          *string
+		First          *int32
+		Before         *string
+		Last           *int32
+		Emails         *[]string
+		SortingOptions *[]sortingOptionPairInput
+		LabelSelector  *string
+		Query          *string
+	}) (*userConnectionResolver, error) {
+	request := business.SearchRequest{
+		Pagination: models.Pagination{
+			After:  args.After,
+			Before: args.Before,
+		},
+	}
+
+	if args.First != nil {
+		first := int(*args.First)
+		request.Pagination.First = &first
+	}
+
+	if args.Last != nil {
+		last := int(*args.Last)
+		request.Pagination.Last = &last
+	}
+
+	if args.Emails != nil {
+		request.Emails = *args.Emails
+	}
+
+	if args.LabelSelector != nil {
+		request.LabelSelector = *args.LabelSelector
+	}
+
+	if args.Query != nil {
+		request.Query = *args.Query
+	}
+
+	if args.SortingOptions != nil {
+		for _, sortingOption := range *args.SortingOptions {
+			direction := models.Ascending
+			if sortingOption.Direction == "DESCENDING" {
+				direction = models.Descending
+			}
+
+			request.SortingOptions = append(request.SortingOptions, models.SortingOptionPair{
+				Name:      sortingOption.Name,
+				Direction: direction,
+			})
+		}
+	}
+
+	response, err := r.service.searchEndpoint(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	castedResponse := response.(*business.SearchResponse)
+	if castedResponse.Err != nil {
+		return nil, castedResponse.Err
+	}
+
+	edges := make([]*userEdgeResolver, 0, len(castedResponse.Users))
+	for _, user := range castedResponse.Users {
+		edges = append(edges, &userEdgeResolver{
+			node: &userResolver{
+				email: user.Email,
+				user:  user.User,
+			},
+			cursor: user.Cursor,
+		})
+	}
+
+	pageInfo := &pageInfoResolver{
+		hasNextPage:     castedResponse.HasNextPage,
+		hasPreviousPage: castedResponse.HasPreviousPage,
+	}
+
+	if len(edges) > 0 {
+		pageInfo.startCursor = &edges[0].cursor
+		pageInfo.endCursor = &edges[len(edges)-1].cursor
+	}
+
+	return &userConnectionResolver{
+		pageInfo:   pageInfo,
+		edges:      edges,
+		totalCount: int32(castedResponse.TotalCount),
+	}, nil
+}
+
+// CreateUser creates a new user for the authenticated caller
+// ctx: Mandatory. The reference to the context
+// args: Mandatory. The input to create a new user
+// Returns the created user or error if something goes wrong
+func (r *rootResolver) CreateUser(
+	ctx context.Context,
+	args struct {
+		Input createUserInput
+	}) (*createUserPayloadResolver, error) {
+	request := business.CreateUserRequest{User: models.User{}}
+	response, err := r.service.createUserEndpoint(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	castedResponse := response.(*business.CreateUserResponse)
+	if castedResponse.Err != nil {
+		return nil, castedResponse.Err
+	}
+
+	return &createUserPayloadResolver{
+		user: &userEdgeResolver{
+			node: &userResolver{
+				email: request.Email,
+				user:  castedResponse.User,
+			},
+			cursor: castedResponse.Cursor,
+		},
+		clientMutationID: args.Input.ClientMutationID,
+	}, nil
+}
+
+// UpdateUser updates an existing user, named either by its email address or by its ID. The user is read first, so
+// its labels are kept as they are unless the input sets them, and the user named by its ID is updated by its email
+// address.
+// ctx: Mandatory. The reference to the context
+// args: Mandatory. The input to update an existing user
+// Returns the updated user or error if something goes wrong
+func (r *rootResolver) UpdateUser(
+	ctx context.Context,
+	args struct {
+		Input updateUserInput
+	}) (*updateUserPayloadResolver, error) {
+	readRequest := business.ReadUserRequest{Fields: []string{"labels"}}
+	if args.Input.Email != nil {
+		readRequest.Email = *args.Input.Email
+	}
+
+	if args.Input.ID != nil {
+		readRequest.UserID = string(*args.Input.ID)
+	}
+
+	readResponse, err := r.service.readUserEndpoint(ctx, &readRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	castedReadResponse := readResponse.(*business.ReadUserResponse)
+	if castedReadResponse.Err != nil {
+		return nil, castedReadResponse.Err
+	}
+
+	user := models.User{Labels: castedReadResponse.User.Labels}
+	if args.Input.Labels != nil {
+		user.Labels = make(map[string]string, len(*args.Input.Labels))
+		for _, label := range *args.Input.Labels {
+			user.Labels[label.Key] = label.Value
+		}
+	}
+
+	response, err := r.service.updateUserEndpoint(ctx, &business.UpdateUserRequest{
+		Email: castedReadResponse.Email,
+		User:  user,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	castedResponse := response.(*business.UpdateUserResponse)
+	if castedResponse.Err != nil {
+		return nil, castedResponse.Err
+	}
+
+	return &updateUserPayloadResolver{
+		user: &userEdgeResolver{
+			node: &userResolver{
+				email: castedReadResponse.Email,
+				user:  castedResponse.User,
+			},
+			cursor: castedResponse.Cursor,
+		},
+		clientMutationID: args.Input.ClientMutationID,
+	}, nil
+}
+
+// DeleteUser deletes an existing user
+// ctx: Mandatory. The reference to the context
+// args: Mandatory. The input to delete an existing user
+// Returns the email address of the deleted user or error if something goes wrong
+func (r *rootResolver) DeleteUser(
+	ctx context.Context,
+	args struct {
+		Input deleteUserInput
+	}) (*deleteUserPayloadResolver, error) {
+	response, err := r.service.deleteUserEndpoint(ctx, &business.DeleteUserRequest{Email: args.Input.Email})
+	if err != nil {
+		return nil, err
+	}
+
+	castedResponse := response.(*business.DeleteUserResponse)
+	if castedResponse.Err != nil {
+		return nil, castedResponse.Err
+	}
+
+	return &deleteUserPayloadResolver{
+		deletedUserEmail: args.Input.Email,
+		clientMutationID: args.Input.ClientMutationID,
+	}, nil
+}
+
+func (r *userResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(r.user.ID)
+}
+
+func (r *userResolver) Email() string {
+	return r.email
+}
+
+// Labels returns the labels of the user sorted by their keys, as GraphQL has no map type
+func (r *userResolver) Labels() []*labelResolver {
+	labels := make([]*labelResolver, 0, len(r.user.Labels))
+	for key, value := range r.user.Labels {
+		labels = append(labels, &labelResolver{key: key, value: value})
+	}
+
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].key < labels[j].key
+	})
+
+	return labels
+}
+
+func (r *labelResolver) Key() string {
+	return r.key
+}
+
+func (r *labelResolver) Value() string {
+	return r.value
+}
+
+func (r *userEdgeResolver) Node() *userResolver {
+	return r.node
+}
+
+func (r *userEdgeResolver) Cursor() string {
+	return r.cursor
+}
+
+func (r *pageInfoResolver) HasNextPage() bool {
+	return r.hasNextPage
+}
+
+func (r *pageInfoResolver) HasPreviousPage() bool {
+	return r.hasPreviousPage
+}
+
+func (r *pageInfoResolver) StartCursor() *string {
+	return r.startCursor
+}
+
+func (r *pageInfoResolver) EndCursor() *string {
+	return r.endCursor
+}
+
+func (r *userConnectionResolver) PageInfo() *pageInfoResolver {
+	return r.pageInfo
+}
+
+func (r *userConnectionResolver) Edges() []*userEdgeResolver {
+	return r.edges
+}
+
+func (r *userConnectionResolver) TotalCount() int32 {
+	return r.totalCount
+}
+
+func (r *createUserPayloadResolver) User() *userEdgeResolver {
+	return r.user
+}
+
+func (r *createUserPayloadResolver) ClientMutationID() *string {
+	return r.clientMutationID
+}
+
+func (r *updateUserPayloadResolver) User() *userEdgeResolver {
+	return r.user
+}
+
+func (r *updateUserPayloadResolver) ClientMutationID() *string {
+	return r.clientMutationID
+}
+
+func (r *deleteUserPayloadResolver) DeletedUserEmail() string {
+	return r.deletedUserEmail
+}
+
+func (r *deleteUserPayloadResolver) ClientMutationID() *string {
+	return r.clientMutationID
+}
@@ -0,0 +1,104 @@
+// Package graphql implements functions to expose user service endpoint using GraphQL protocol.
+package graphql
+
+const schema = `
+schema {
+  query: Query
+  mutation: Mutation
+}
+
+type Query {
+  user(email: String!): User!
+  users(
+    after: String
+    first: Int
+    before: String
+    last: Int
+    emails: [String!]
+    sortingOptions: [SortingOptionPair!]
+    labelSelector: String
+    query: String
+  ): UserConnection!
+}
+
+type Mutation {
+  createUser(input: CreateUserInput!): CreateUserPayload!
+  updateUser(input: UpdateUserInput!): UpdateUserPayload!
+  deleteUser(input: DeleteUserInput!): DeleteUserPayload!
+}
+
+enum SortingDirection {
+  ASCENDING
+  DESCENDING
+}
+
+input SortingOptionPair {
+  name: String!
+  direction: SortingDirection!
+}
+
+type PageInfo {
+  hasNextPage: Boolean!
+  hasPreviousPage: Boolean!
+  startCursor: String
+  endCursor: String
+}
+
+type User {
+  id: ID!
+  email: String!
+  labels: [Label!]!
+}
+
+type Label {
+  key: String!
+  value: String!
+}
+
+input LabelInput {
+  key: String!
+  value: String!
+}
+
+type UserEdge {
+  node: User!
+  cursor: String!
+}
+
+type UserConnection {
+  pageInfo: PageInfo!
+  edges: [UserEdge!]!
+  totalCount: Int!
+}
+
+input CreateUserInput {
+  clientMutationId: String
+}
+
+type CreateUserPayload {
+  user: UserEdge!
+  clientMutationId: String
+}
+
+input UpdateUserInput {
+  email: String
+  id: ID
+  labels: [LabelInput!]
+  clientMutationId: String
+}
+
+type UpdateUserPayload {
+  user: UserEdge!
+  clientMutationId: String
+}
+
+input DeleteUserInput {
+  email: String!
+  clientMutationId: String
+}
+
+type DeleteUserPayload {
+  deletedUserEmail: String!
+  clientMutationId: String
+}
+`
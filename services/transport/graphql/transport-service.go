@@ -0,0 +1,233 @@
+// Package graphql implements functions to expose user service endpoint using GraphQL protocol.
+package graphql
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/correlation"
+	"github.com/decentralized-cloud/user/services/endpoint"
+	"github.com/decentralized-cloud/user/services/slo"
+	"github.com/decentralized-cloud/user/services/transport"
+	gokitendpoint "github.com/go-kit/kit/endpoint"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/micro-business/go-core/gokit/middleware"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+)
+
+type transportService struct {
+	logger                    *zap.Logger
+	configurationService      configuration.ConfigurationContract
+	endpointCreatorService    endpoint.EndpointCreatorContract
+	middlewareProviderService middleware.MiddlewareProviderContract
+	sloService                slo.SloContract
+	correlationService        correlation.CorrelationContract
+	jwksProvider              *transport.JWKSProvider
+	tokenPolicy               *transport.TokenPolicy
+	tenancyPolicy             *transport.TenancyPolicy
+	endpointPolicy            *transport.EndpointPolicy
+	logAuthorizationDecisions bool
+	server                    *http.Server
+	createUserEndpoint        gokitendpoint.Endpoint
+	readUserEndpoint          gokitendpoint.Endpoint
+	updateUserEndpoint        gokitendpoint.Endpoint
+	deleteUserEndpoint        gokitendpoint.Endpoint
+	searchEndpoint            gokitendpoint.Endpoint
+}
+
+// NewTransportService creates new instance of the transportService, setting up all dependencies and returns the instance
+// logger: Mandatory. Reference to the logger service
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// endpointCreatorService: Mandatory. Reference to the service that creates go-kit compatible endpoints
+// middlewareProviderService: Mandatory. Reference to the service that provides different go-kit middlewares
+// sloService: Mandatory. Reference to the service that measures the service level indicators
+// correlationService: Mandatory. Reference to the service that assigns the correlation id to the received requests
+// Returns the new service or error if something goes wrong
+func NewTransportService(
+	logger *zap.Logger,
+	configurationService configuration.ConfigurationContract,
+	endpointCreatorService endpoint.EndpointCreatorContract,
+	middlewareProviderService middleware.MiddlewareProviderContract,
+	sloService slo.SloContract,
+	correlationService correlation.CorrelationContract) (transport.TransportContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if endpointCreatorService == nil {
+		return nil, commonErrors.NewArgumentNilError("endpointCreatorService", "endpointCreatorService is required")
+	}
+
+	if middlewareProviderService == nil {
+		return nil, commonErrors.NewArgumentNilError("middlewareProviderService", "middlewareProviderService is required")
+	}
+
+	if sloService == nil {
+		return nil, commonErrors.NewArgumentNilError("sloService", "sloService is required")
+	}
+
+	if correlationService == nil {
+		return nil, commonErrors.NewArgumentNilError("correlationService", "correlationService is required")
+	}
+
+	jwksURL, err := configurationService.GetJwksURL()
+	if err != nil {
+		return nil, err
+	}
+
+	jwksRefreshInterval, err := configurationService.GetJwksRefreshInterval()
+	if err != nil {
+		return nil, err
+	}
+
+	acceptedIssuers, err := configurationService.GetJwtAcceptedIssuers()
+	if err != nil {
+		return nil, err
+	}
+
+	acceptedAudiences, err := configurationService.GetJwtAcceptedAudiences()
+	if err != nil {
+		return nil, err
+	}
+
+	claimMapping, err := configurationService.GetJwtClaimMapping()
+	if err != nil {
+		return nil, err
+	}
+
+	tenancyMode, err := configurationService.GetTenancyMode()
+	if err != nil {
+		return nil, err
+	}
+
+	crossTenantReadScope, err := configurationService.GetTenancyCrossTenantReadScope()
+	if err != nil {
+		return nil, err
+	}
+
+	logAuthorizationDecisions, err := configurationService.GetAuthorizationDecisionLoggingEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	authorizationPolicyFile, err := configurationService.GetAuthorizationPolicyFile()
+	if err != nil {
+		return nil, err
+	}
+
+	policyFileRules, err := transport.ReadEndpointPolicyFile(authorizationPolicyFile)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read the authorization policy", err)
+	}
+
+	// The policy file is shared with the gRPC transport, which validates the endpoints only it serves
+	endpointRules := map[string]string{}
+	for endpointName, rule := range policyFileRules {
+		if _, ok := defaultEndpointRules[endpointName]; ok {
+			endpointRules[endpointName] = rule
+		}
+	}
+
+	endpointPolicy, err := transport.NewEndpointPolicy(defaultEndpointRules, endpointRules)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("the authorization policy is invalid", err)
+	}
+
+	return &transportService{
+		logger:                    logger,
+		configurationService:      configurationService,
+		endpointCreatorService:    endpointCreatorService,
+		middlewareProviderService: middlewareProviderService,
+		sloService:                sloService,
+		correlationService:        correlationService,
+		jwksProvider:              transport.NewJWKSProvider(jwksURL, jwksRefreshInterval),
+		tokenPolicy:               transport.NewTokenPolicy(acceptedIssuers, acceptedAudiences, claimMapping),
+		tenancyPolicy:             transport.NewTenancyPolicy(tenancyMode, claimMapping, crossTenantReadScope),
+		endpointPolicy:            endpointPolicy,
+		logAuthorizationDecisions: logAuthorizationDecisions,
+	}, nil
+}
+
+// Start starts the GraphQL transport service
+// Returns error if something goes wrong
+func (service *transportService) Start() error {
+	service.setupEndpoints()
+
+	host, err := service.configurationService.GetGraphQLHost()
+	if err != nil {
+		return err
+	}
+
+	port, err := service.configurationService.GetGraphQLPort()
+	if err != nil {
+		return err
+	}
+
+	parsedSchema, err := graphqlgo.ParseSchema(schema, &rootResolver{service: service})
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", service.correlationService.CreateHTTPMiddleware(withAuthorizationHeader(&relay.Handler{Schema: parsedSchema})))
+
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+	service.server = &http.Server{
+		Addr:    address,
+		Handler: mux,
+	}
+
+	service.logger.Info("GraphQL service started", zap.String("address", address))
+
+	if err = service.server.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+
+	return nil
+}
+
+// Stop stops the GraphQL transport service
+// Returns error if something goes wrong
+func (service *transportService) Stop() error {
+	if service.server == nil {
+		return nil
+	}
+
+	return service.server.Shutdown(context.Background())
+}
+
+func (service *transportService) setupEndpoints() {
+	service.createUserEndpoint = service.endpointCreatorService.CreateUserEndpoint()
+	service.createUserEndpoint = service.middlewareProviderService.CreateLoggingMiddleware("CreateUser")(service.createUserEndpoint)
+	service.createUserEndpoint = service.sloService.CreateSLIMiddleware("graphql", "CreateUser")(service.createUserEndpoint)
+	service.createUserEndpoint = service.createAuthMiddleware("CreateUser")(service.createUserEndpoint)
+
+	service.readUserEndpoint = service.endpointCreatorService.ReadUserEndpoint()
+	service.readUserEndpoint = service.middlewareProviderService.CreateLoggingMiddleware("ReadUser")(service.readUserEndpoint)
+	service.readUserEndpoint = service.sloService.CreateSLIMiddleware("graphql", "ReadUser")(service.readUserEndpoint)
+	service.readUserEndpoint = service.createAuthMiddleware("ReadUser")(service.readUserEndpoint)
+
+	service.updateUserEndpoint = service.endpointCreatorService.UpdateUserEndpoint()
+	service.updateUserEndpoint = service.middlewareProviderService.CreateLoggingMiddleware("UpdateUser")(service.updateUserEndpoint)
+	service.updateUserEndpoint = service.sloService.CreateSLIMiddleware("graphql", "UpdateUser")(service.updateUserEndpoint)
+	service.updateUserEndpoint = service.createAuthMiddleware("UpdateUser")(service.updateUserEndpoint)
+
+	service.deleteUserEndpoint = service.endpointCreatorService.DeleteUserEndpoint()
+	service.deleteUserEndpoint = service.middlewareProviderService.CreateLoggingMiddleware("DeleteUser")(service.deleteUserEndpoint)
+	service.deleteUserEndpoint = service.sloService.CreateSLIMiddleware("graphql", "DeleteUser")(service.deleteUserEndpoint)
+	service.deleteUserEndpoint = service.createAuthMiddleware("DeleteUser")(service.deleteUserEndpoint)
+
+	service.searchEndpoint = service.endpointCreatorService.SearchEndpoint()
+	service.searchEndpoint = service.middlewareProviderService.CreateLoggingMiddleware("Search")(service.searchEndpoint)
+	service.searchEndpoint = service.sloService.CreateSLIMiddleware("graphql", "Search")(service.searchEndpoint)
+	service.searchEndpoint = service.createAuthMiddleware("Search")(service.searchEndpoint)
+}
@@ -0,0 +1,421 @@
+package graphql_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/business"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/correlation"
+	endpointMock "github.com/decentralized-cloud/user/services/endpoint/mock"
+	"github.com/decentralized-cloud/user/services/slo"
+	"github.com/decentralized-cloud/user/services/transport"
+	"github.com/decentralized-cloud/user/services/transport/graphql"
+	gokitendpoint "github.com/go-kit/kit/endpoint"
+	"github.com/golang/mock/gomock"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/lucsky/cuid"
+	"github.com/micro-business/go-core/gokit/middleware"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestGraphQLTransportService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GraphQL Transport Service Tests")
+}
+
+type graphQLResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+var _ = Describe("GraphQL Transport Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      transport.TransportContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		mockEndpointCreator      *endpointMock.MockEndpointCreatorContract
+		jwksServer               *httptest.Server
+		jwksFetchCount           int32
+		signingKey               jwk.Key
+		graphQLURL               string
+		email                    string
+		accessToken              string
+		receivedRequests         chan interface{}
+		endpointResponse         interface{}
+		readUserResponse         interface{}
+	)
+
+	recordingEndpoint := func(response *interface{}) gokitendpoint.Endpoint {
+		return func(_ context.Context, request interface{}) (interface{}, error) {
+			receivedRequests <- request
+
+			return *response, nil
+		}
+	}
+
+	signToken := func(email string) string {
+		token := jwt.New()
+		_ = token.Set(jwt.SubjectKey, email)
+		_ = token.Set(jwt.IssuedAtKey, time.Now())
+		_ = token.Set(jwt.ExpirationKey, time.Now().Add(time.Hour))
+		_ = token.Set("email", email)
+
+		signed, err := jwt.Sign(token, jwa.RS256, signingKey)
+		Ω(err).Should(BeNil())
+
+		return string(signed)
+	}
+
+	execute := func(query string, variables map[string]interface{}) graphQLResponse {
+		body, err := json.Marshal(map[string]interface{}{
+			"query":     query,
+			"variables": variables,
+		})
+		Ω(err).Should(BeNil())
+
+		request, err := http.NewRequest(http.MethodPost, graphQLURL, bytes.NewReader(body))
+		Ω(err).Should(BeNil())
+
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Authorization", "Bearer "+accessToken)
+
+		response, err := http.DefaultClient.Do(request)
+		Ω(err).Should(BeNil())
+
+		defer response.Body.Close()
+
+		var result graphQLResponse
+		Ω(json.NewDecoder(response.Body).Decode(&result)).Should(BeNil())
+
+		return result
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Ω(err).Should(BeNil())
+
+		signingKey, err = jwk.New(privateKey)
+		Ω(err).Should(BeNil())
+
+		keyID := cuid.New()
+		_ = signingKey.Set(jwk.KeyIDKey, keyID)
+		_ = signingKey.Set(jwk.AlgorithmKey, jwa.RS256)
+
+		publicKey, err := jwk.PublicKeyOf(signingKey)
+		Ω(err).Should(BeNil())
+
+		_ = publicKey.Set(jwk.KeyIDKey, keyID)
+		_ = publicKey.Set(jwk.AlgorithmKey, jwa.RS256)
+
+		keySet := jwk.NewSet()
+		keySet.Add(publicKey)
+		content, err := json.Marshal(keySet)
+		Ω(err).Should(BeNil())
+
+		atomic.StoreInt32(&jwksFetchCount, 0)
+		jwksServer = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			atomic.AddInt32(&jwksFetchCount, 1)
+			writer.Header().Set("Content-Type", "application/json")
+			_, _ = writer.Write(content)
+		}))
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Ω(err).Should(BeNil())
+
+		address := listener.Addr().String()
+		port := listener.Addr().(*net.TCPAddr).Port
+		Ω(listener.Close()).Should(BeNil())
+
+		email = cuid.New() + "@test.com"
+		accessToken = signToken(email)
+		receivedRequests = make(chan interface{}, 10)
+		endpointResponse = nil
+		readUserResponse = nil
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.EXPECT().GetJwksURL().Return(jwksServer.URL, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetJwksRefreshInterval().Return(time.Hour, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetJwtAcceptedIssuers().Return([]string{}, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetJwtAcceptedAudiences().Return([]string{}, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetJwtClaimMapping().Return(map[string]string{}, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetTenancyMode().Return(transport.TenancyModeSingle, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetTenancyCrossTenantReadScope().Return("", nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetAuthorizationDecisionLoggingEnabled().Return(false, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetAuthorizationPolicyFile().Return("", nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetGraphQLHost().Return("127.0.0.1", nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetGraphQLPort().Return(port, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetSloAvailabilityObjective().Return(0.999, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetSloLatencyObjective().Return(0.99, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetSloLatencyThreshold().Return(50*time.Millisecond, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetSloWindow().Return(30*24*time.Hour, nil).AnyTimes()
+
+		mockEndpointCreator = endpointMock.NewMockEndpointCreatorContract(mockCtrl)
+		mockEndpointCreator.EXPECT().CreateUserEndpoint().Return(recordingEndpoint(&endpointResponse)).AnyTimes()
+		mockEndpointCreator.EXPECT().ReadUserEndpoint().Return(recordingEndpoint(&readUserResponse)).AnyTimes()
+		mockEndpointCreator.EXPECT().UpdateUserEndpoint().Return(recordingEndpoint(&endpointResponse)).AnyTimes()
+		mockEndpointCreator.EXPECT().DeleteUserEndpoint().Return(recordingEndpoint(&endpointResponse)).AnyTimes()
+		mockEndpointCreator.EXPECT().SearchEndpoint().Return(recordingEndpoint(&endpointResponse)).AnyTimes()
+
+		middlewareProviderService, err := middleware.NewMiddlewareProviderService(zap.NewNop(), true, "")
+		Ω(err).Should(BeNil())
+
+		sloService, err := slo.NewSloService(mockConfigurationService)
+		Ω(err).Should(BeNil())
+
+		correlationService, err := correlation.NewCorrelationService(zap.NewNop())
+		Ω(err).Should(BeNil())
+
+		sut, err = graphql.NewTransportService(
+			zap.NewNop(),
+			mockConfigurationService,
+			mockEndpointCreator,
+			middlewareProviderService,
+			sloService,
+			correlationService)
+		Ω(err).Should(BeNil())
+
+		go func() {
+			defer GinkgoRecover()
+			Ω(sut.Start()).Should(BeNil())
+		}()
+
+		graphQLURL = "http://" + address + "/graphql"
+		Eventually(func() error {
+			connection, err := net.Dial("tcp", address)
+			if err == nil {
+				_ = connection.Close()
+			}
+
+			return err
+		}, 5*time.Second, 10*time.Millisecond).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		Ω(sut.Stop()).Should(BeNil())
+		jwksServer.Close()
+		mockCtrl.Finish()
+	})
+
+	Context("user queries a user", func() {
+		It("should call the read user endpoint with the email address and return the user", func() {
+			readUserResponse = &business.ReadUserResponse{User: models.User{}}
+
+			response := execute(`query($email: String!) { user(email: $email) { email } }`, map[string]interface{}{"email": email})
+
+			Ω(response.Errors).Should(BeEmpty())
+			Ω(response.Data["user"]).Should(Equal(map[string]interface{}{"email": email}))
+			Ω(receivedRequests).Should(Receive(Equal(&business.ReadUserRequest{Email: email})))
+		})
+
+		It("should return the error when another user is queried", func() {
+			response := execute(`query($email: String!) { user(email: $email) { email } }`, map[string]interface{}{"email": cuid.New()})
+
+			Ω(response.Errors).ShouldNot(BeEmpty())
+			Ω(receivedRequests).ShouldNot(Receive())
+		})
+
+		It("should return the error returned by the endpoint", func() {
+			readUserResponse = &business.ReadUserResponse{Err: commonErrors.NewNotFoundError()}
+
+			response := execute(`query($email: String!) { user(email: $email) { email } }`, map[string]interface{}{"email": email})
+
+			Ω(response.Errors).Should(HaveLen(1))
+		})
+	})
+
+	Context("user searches for users", func() {
+		const searchQuery = `query($emails: [String!], $first: Int, $after: String, $query: String) {
+  users(emails: $emails, first: $first, after: $after, query: $query, labelSelector: "plan=pro", sortingOptions: [{name: "email", direction: DESCENDING}]) {
+    totalCount
+    pageInfo { hasNextPage hasPreviousPage startCursor endCursor }
+    edges { cursor node { email } }
+  }
+}`
+
+		It("should map the arguments to the search request and the response to the connection", func() {
+			after := cuid.New()
+			first := 10
+			endpointResponse = &business.SearchResponse{
+				HasNextPage: true,
+				TotalCount:  3,
+				Users: []models.UserWithCursor{
+					{Email: email, Cursor: "first-cursor"},
+					{Email: email, Cursor: "last-cursor"},
+				},
+			}
+
+			response := execute(searchQuery, map[string]interface{}{
+				"emails": []string{email},
+				"first":  first,
+				"after":  after,
+				"query":  "te",
+			})
+
+			Ω(response.Errors).Should(BeEmpty())
+			Ω(receivedRequests).Should(Receive(Equal(&business.SearchRequest{
+				Pagination: models.Pagination{
+					After: &after,
+					First: &first,
+				},
+				SortingOptions: []models.SortingOptionPair{{Name: "email", Direction: models.Descending}},
+				Emails:         []string{email},
+				LabelSelector:  "plan=pro",
+				Query:          "te",
+			})))
+
+			Ω(response.Data["users"]).Should(Equal(map[string]interface{}{
+				"totalCount": float64(3),
+				"pageInfo": map[string]interface{}{
+					"hasNextPage":     true,
+					"hasPreviousPage": false,
+					"startCursor":     "first-cursor",
+					"endCursor":       "last-cursor",
+				},
+				"edges": []interface{}{
+					map[string]interface{}{"cursor": "first-cursor", "node": map[string]interface{}{"email": email}},
+					map[string]interface{}{"cursor": "last-cursor", "node": map[string]interface{}{"email": email}},
+				},
+			}))
+		})
+
+		It("should deny the search that does not name only the email address of the caller", func() {
+			for _, emails := range [][]string{nil, {cuid.New()}, {email, cuid.New()}} {
+				response := execute(searchQuery, map[string]interface{}{"emails": emails})
+
+				Ω(response.Errors).ShouldNot(BeEmpty())
+			}
+
+			Ω(receivedRequests).ShouldNot(Receive())
+		})
+	})
+
+	Context("user calls the mutations", func() {
+		It("should map the create user mutation", func() {
+			clientMutationID := cuid.New()
+			endpointResponse = &business.CreateUserResponse{Cursor: "cursor"}
+
+			response := execute(`mutation($id: String) { createUser(input: {clientMutationId: $id}) { clientMutationId user { cursor } } }`,
+				map[string]interface{}{"id": clientMutationID})
+
+			Ω(response.Errors).Should(BeEmpty())
+			Ω(response.Data["createUser"]).Should(Equal(map[string]interface{}{
+				"clientMutationId": clientMutationID,
+				"user":             map[string]interface{}{"cursor": "cursor"},
+			}))
+			Ω(receivedRequests).Should(Receive(BeAssignableToTypeOf(&business.CreateUserRequest{})))
+		})
+
+		It("should map the update user mutation and keep the labels of the user if the input does not set them", func() {
+			userID := cuid.New()
+			labels := map[string]string{"plan": "pro"}
+			readUserResponse = &business.ReadUserResponse{Email: email, User: models.User{Labels: labels}}
+			endpointResponse = &business.UpdateUserResponse{Cursor: "cursor", User: models.User{ID: userID, Labels: labels}}
+
+			response := execute(`mutation($email: String!) { updateUser(input: {email: $email}) { user { cursor node { id email labels { key value } } } } }`,
+				map[string]interface{}{"email": email})
+
+			Ω(response.Errors).Should(BeEmpty())
+			Ω(response.Data["updateUser"]).Should(Equal(map[string]interface{}{
+				"user": map[string]interface{}{"cursor": "cursor", "node": map[string]interface{}{
+					"id":     userID,
+					"email":  email,
+					"labels": []interface{}{map[string]interface{}{"key": "plan", "value": "pro"}},
+				}},
+			}))
+			Ω(receivedRequests).Should(Receive(Equal(&business.ReadUserRequest{Email: email, Fields: []string{"labels"}})))
+			Ω(receivedRequests).Should(Receive(Equal(&business.UpdateUserRequest{Email: email, User: models.User{Labels: labels}})))
+		})
+
+		It("should update the user named by its ID with the labels the input sets", func() {
+			userID := cuid.New()
+			readUserResponse = &business.ReadUserResponse{Email: email, User: models.User{Labels: map[string]string{"plan": "pro"}}}
+			endpointResponse = &business.UpdateUserResponse{Cursor: "cursor"}
+
+			response := execute(`mutation($id: ID!) { updateUser(input: {id: $id, labels: [{key: "plan", value: "free"}]}) { user { node { email } } } }`,
+				map[string]interface{}{"id": userID})
+
+			Ω(response.Errors).Should(BeEmpty())
+			Ω(response.Data["updateUser"]).Should(Equal(map[string]interface{}{
+				"user": map[string]interface{}{"node": map[string]interface{}{"email": email}},
+			}))
+			Ω(receivedRequests).Should(Receive(Equal(&business.ReadUserRequest{UserID: userID, Fields: []string{"labels"}})))
+			Ω(receivedRequests).Should(Receive(Equal(&business.UpdateUserRequest{
+				Email: email,
+				User:  models.User{Labels: map[string]string{"plan": "free"}},
+			})))
+		})
+
+		It("should map the delete user mutation", func() {
+			endpointResponse = &business.DeleteUserResponse{}
+
+			response := execute(`mutation($email: String!) { deleteUser(input: {email: $email}) { deletedUserEmail } }`,
+				map[string]interface{}{"email": email})
+
+			Ω(response.Errors).Should(BeEmpty())
+			Ω(response.Data["deleteUser"]).Should(Equal(map[string]interface{}{"deletedUserEmail": email}))
+			Ω(receivedRequests).Should(Receive(Equal(&business.DeleteUserRequest{Email: email})))
+		})
+	})
+
+	Context("user calls the service without a valid token", func() {
+		It("should reject the call without a bearer token", func() {
+			accessToken = ""
+
+			response := execute(`query($email: String!) { user(email: $email) { email } }`, map[string]interface{}{"email": email})
+
+			Ω(response.Errors).ShouldNot(BeEmpty())
+			Ω(receivedRequests).ShouldNot(Receive())
+		})
+
+		It("should reject the token signed with a key that is not in the key set", func() {
+			privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Ω(err).Should(BeNil())
+
+			signingKey, err = jwk.New(privateKey)
+			Ω(err).Should(BeNil())
+
+			_ = signingKey.Set(jwk.KeyIDKey, cuid.New())
+			accessToken = signToken(email)
+
+			response := execute(`query($email: String!) { user(email: $email) { email } }`, map[string]interface{}{"email": email})
+
+			Ω(response.Errors).ShouldNot(BeEmpty())
+			Ω(receivedRequests).ShouldNot(Receive())
+		})
+	})
+
+	Context("user calls the service several times", func() {
+		It("should fetch the key set only once", func() {
+			readUserResponse = &business.ReadUserResponse{User: models.User{}}
+
+			for i := 0; i < 3; i++ {
+				response := execute(`query($email: String!) { user(email: $email) { email } }`, map[string]interface{}{"email": email})
+
+				Ω(response.Errors).Should(BeEmpty())
+			}
+
+			Ω(atomic.LoadInt32(&jwksFetchCount)).Should(Equal(int32(1)))
+		})
+	})
+})
@@ -3,38 +3,146 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
-	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
 	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/redaction"
+	"github.com/decentralized-cloud/user/services/transport"
 	"github.com/go-kit/kit/endpoint"
-	"github.com/lestrrat-go/jwx/jwt"
-	"github.com/micro-business/go-core/jwt/grpc"
+	commonErrors "github.com/micro-business/go-core/system/errors"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
-type authorizeFunc func(email string, request interface{}) error
+// defaultEndpointRules contains the rule every endpoint is authorized with unless the authorization policy file
+// overrides it
+var defaultEndpointRules = map[string]string{
+	"CreateUser":                transport.EndpointRuleAuthenticated,
+	"ReadUser":                  transport.EndpointRuleOwner,
+	"ReadUserByEmail":           transport.EndpointRuleOwner,
+	"ReadUsers":                 transport.EndpointRuleOwnerOrAdmin,
+	"UpdateUser":                transport.EndpointRuleOwner,
+	"ChangeEmail":               transport.EndpointRuleOwnerOrAdmin,
+	"RequestEmailChange":        transport.EndpointRuleOwner,
+	"ConfirmEmailChange":        transport.EndpointRuleOwner,
+	"DeleteUser":                transport.EndpointRuleOwner,
+	"RestoreUser":               transport.EndpointRuleOwner,
+	"GetSagaStatus":             transport.EndpointRuleAdmin,
+	"Search":                    transport.EndpointRuleAdmin,
+	"ListAuditRecords":          transport.EndpointRuleAdmin,
+	"StreamSearchUsers":         transport.EndpointRuleAdmin,
+	"GetEffectiveConfiguration": transport.EndpointRuleAdmin,
+	"GetEnabledFeatures":        transport.EndpointRuleAdmin,
+	"PreviewBulkUpdateUsers":    transport.EndpointRuleAdmin,
+	"BulkUpdateUsers":           transport.EndpointRuleAdmin,
+	"PurgeByLabel":              transport.EndpointRuleAdmin,
+	"GetOutboxLag":              transport.EndpointRuleAdmin,
+	"ListPendingEvents":         transport.EndpointRuleAdmin,
+	"ForceFlush":                transport.EndpointRuleAdmin,
+	"GetUserPreferences":        transport.EndpointRuleOwner,
+	"UpdateUserPreferences":     transport.EndpointRuleOwner,
+	"GetUserAvatar":             transport.EndpointRuleOwner,
+	"SetUserAvatar":             transport.EndpointRuleOwner,
+	"ExportPersonalData":        transport.EndpointRuleOwner,
+	"EraseUser":                 transport.EndpointRuleOwner,
+	"AddUserToTenant":           transport.EndpointRuleAdmin,
+	"RemoveUserFromTenant":      transport.EndpointRuleAdmin,
+	"ListUserTenants":           transport.EndpointRuleAdmin,
+	"GetReplicationStatus":      transport.EndpointRuleAdmin,
+	"ExportUsers":               transport.EndpointRuleAdmin,
+	"SendVerificationEmail":     transport.EndpointRuleOwner,
+	"VerifyEmail":               transport.EndpointRuleOwner,
+	"SetPassword":               transport.EndpointRuleOwner,
+	"ChangePassword":            transport.EndpointRuleOwner,
+	"VerifyPassword":            transport.EndpointRuleAdmin,
+	"WatchUser":                 transport.EndpointRuleOwner,
+	"WatchUsers":                transport.EndpointRuleAdmin,
+	"CreateAPIKey":              transport.EndpointRuleOwner,
+	"ListAPIKeys":               transport.EndpointRuleOwner,
+	"RevokeAPIKey":              transport.EndpointRuleOwner,
+	"RecordLoginAttempt":        transport.EndpointRuleAdmin,
+	"UnlockUser":                transport.EndpointRuleAdmin,
+	"IssueMagicLink":            transport.EndpointRuleAdmin,
+	"RedeemMagicLink":           transport.EndpointRuleAdmin,
+	"EnrollMFA":                 transport.EndpointRuleAdmin,
+	"ListMFAMethods":            transport.EndpointRuleAdmin,
+	"RemoveMFAMethod":           transport.EndpointRuleAdmin,
+	"RecordConsent":             transport.EndpointRuleOwner,
+	"ListConsents":              transport.EndpointRuleOwner,
+	"CreateGroup":               transport.EndpointRuleAdmin,
+	"ReadGroup":                 transport.EndpointRuleAdmin,
+	"UpdateGroup":               transport.EndpointRuleAdmin,
+	"DeleteGroup":               transport.EndpointRuleAdmin,
+	"ListGroups":                transport.EndpointRuleAdmin,
+	"AddUserToGroup":            transport.EndpointRuleAdmin,
+	"RemoveUserFromGroup":       transport.EndpointRuleAdmin,
+	"InviteUser":                transport.EndpointRuleAdmin,
+	"AcceptInvitation":          transport.EndpointRuleAuthenticated,
+	"RegisterSession":           transport.EndpointRuleAdmin,
+	"ListSessions":              transport.EndpointRuleOwner,
+	"RevokeSession":             transport.EndpointRuleOwner,
+	"CreateWebhookSubscription": transport.EndpointRuleOwner,
+	"ListWebhookSubscriptions":  transport.EndpointRuleOwner,
+	"DeleteWebhookSubscription": transport.EndpointRuleOwner,
+	"ListWebhookDeliveries":     transport.EndpointRuleOwner,
+}
+
+// apiKeyMetadataKey is the metadata the service accounts send their API key in instead of a token
+const apiKeyMetadataKey = "x-api-key"
 
-var authorizedFuncs = map[string]authorizeFunc{
-	"CreateUser": isAuthorizedToCallCreateUser,
-	"ReadUser":   isAuthorizedToCallReadUser,
-	"UpdateUser": isAuthorizedToCallUpdateUser,
-	"DeleteUser": isAuthorizedToCallDeleteUser,
+// apiKeyScopes contains the endpoints the API keys are allowed to call and the scope each of them requires. The admin,
+// password, API key, session and webhook management endpoints are not listed, so they can only be called with a token.
+// The consents are only recorded with a token too, so a service account can not accept a policy on behalf of the user.
+var apiKeyScopes = map[string]models.APIKeyScope{
+	"ReadUser":              models.APIKeyScopeRead,
+	"ReadUserByEmail":       models.APIKeyScopeRead,
+	"ReadUsers":             models.APIKeyScopeRead,
+	"GetUserPreferences":    models.APIKeyScopeRead,
+	"GetUserAvatar":         models.APIKeyScopeRead,
+	"ListConsents":          models.APIKeyScopeRead,
+	"WatchUser":             models.APIKeyScopeRead,
+	"UpdateUser":            models.APIKeyScopeWrite,
+	"DeleteUser":            models.APIKeyScopeWrite,
+	"RestoreUser":           models.APIKeyScopeWrite,
+	"UpdateUserPreferences": models.APIKeyScopeWrite,
+	"SetUserAvatar":         models.APIKeyScopeWrite,
+	"SendVerificationEmail": models.APIKeyScopeWrite,
 }
 
 func (service *transportService) createAuthMiddleware(endpointName string) endpoint.Middleware {
 	return func(next endpoint.Endpoint) endpoint.Endpoint {
 		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
-			token, err := grpc.ParseAndVerifyToken(ctx, service.jwksURL, true)
+			decision := transport.NewAuthorizationDecision("grpc", endpointName)
+			err = service.isAuthorized(ctx, decision, endpointName, request)
+			decision.Record(ctx, service.logger, service.logAuthorizationDecisions)
+
 			if err != nil {
 				return nil, err
 			}
 
-			if err = service.isAuthorized(token, endpointName, request); err != nil {
-				return nil, err
+			// The caller is recorded for the redaction interceptors, which redact the response once it is encoded. The
+			// internal services call on behalf of every user, so they are not redacted for just as the admins. The
+			// response is redacted if the groups of the caller can not be listed.
+			admin := decision.Service != ""
+			if !admin && decision.Email != "" {
+				admin, _ = service.isAdmin(ctx, decision.Email)
 			}
 
-			parsedToken := models.ParsedToken{Email: token.PrivateClaims()["email"].(string)}
+			redaction.SetCaller(ctx, redaction.Caller{
+				Email: decision.Email,
+				Admin: admin,
+			})
+
+			parsedToken := models.ParsedToken{
+				Subject:          decision.Subject,
+				Email:            decision.Email,
+				Service:          decision.Service,
+				TenantID:         decision.TenantID,
+				CrossTenantReads: decision.CrossTenantReads,
+				OwnerOnly:        decision.OwnerOnly,
+			}
 			ctx = context.WithValue(ctx, models.ContextKeyParsedToken, parsedToken)
 
 			return next(ctx, request)
@@ -42,46 +150,147 @@ func (service *transportService) createAuthMiddleware(endpointName string) endpo
 	}
 }
 
-func (service *transportService) isAuthorized(token jwt.Token, endpointName string, request interface{}) error {
-	email := token.PrivateClaims()["email"].(string)
+func (service *transportService) isAuthorized(ctx context.Context, decision *transport.AuthorizationDecision, endpointName string, request interface{}) error {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(apiKeyMetadataKey)) > 0 {
+		return service.isAuthorizedWithAPIKey(ctx, decision, endpointName, md.Get(apiKeyMetadataKey)[0], request)
+	}
 
-	if len(email) == 0 {
-		return status.Errorf(codes.Unauthenticated, "Email address is not included in the claims")
+	token, err := parseAndVerifyToken(ctx, service.jwksProvider)
+	if err != nil {
+		return decision.Fail("token", err)
 	}
 
-	return authorizedFuncs[endpointName](email, request)
-}
+	decision.Pass("token", "The token is verified")
 
-func isAuthorizedToCallCreateUser(email string, request interface{}) error {
-	return nil
+	if err = service.tokenPolicy.Apply(decision, token); err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if err = service.tenancyPolicy.Apply(decision, token); err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if decision.Service != "" {
+		return service.isAuthorizedService(decision, endpointName)
+	}
+
+	return service.applyEndpointPolicy(ctx, decision, request)
 }
 
-func isAuthorizedToCallReadUser(email string, request interface{}) error {
-	castedRequest := request.(*userGRPCContract.ReadUserRequest)
+// applyEndpointPolicy evaluates the rule of the endpoint, mapping the denied calls to the status codes the callers
+// expect: PermissionDenied if the caller is not an admin, Unauthenticated if the request is not for the user of the caller
+func (service *transportService) applyEndpointPolicy(ctx context.Context, decision *transport.AuthorizationDecision, request interface{}) error {
+	err := service.endpointPolicy.Apply(ctx, decision, request, service.resolveAdmin)
 
-	if castedRequest.Email != email {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, transport.ErrNotOwner):
 		return status.Errorf(codes.Unauthenticated, "Email address does not match the received one in the request")
+	case errors.Is(err, transport.ErrNotAdmin):
+		return status.Errorf(codes.PermissionDenied, "Only the admins are allowed to call %s", decision.Endpoint)
+	case errors.Is(err, transport.ErrEndpointDenied):
+		return status.Errorf(codes.PermissionDenied, "%s is denied by the authorization policy", decision.Endpoint)
+	default:
+		return status.Error(mapErrorToCode(err), err.Error())
 	}
+}
 
-	return nil
+// resolveAdmin returns why the caller is an admin, or an empty string if the caller is not an admin
+func (service *transportService) resolveAdmin(ctx context.Context, email string) (string, error) {
+	if service.adminEmails[email] {
+		return "The email address is listed in ADMIN_EMAILS", nil
+	}
+
+	adminGroup, err := service.findAdminGroup(ctx, email)
+	if err != nil || adminGroup == "" {
+		return "", err
+	}
+
+	return fmt.Sprintf("The user is a member of %s listed in ADMIN_GROUPS", adminGroup), nil
 }
 
-func isAuthorizedToCallUpdateUser(email string, request interface{}) error {
-	castedRequest := request.(*userGRPCContract.UpdateUserRequest)
+// isAdmin returns true if the email address is listed in ADMIN_EMAILS or the user is a member of any of the groups
+// listed in ADMIN_GROUPS
+func (service *transportService) isAdmin(ctx context.Context, email string) (bool, error) {
+	if service.adminEmails[email] {
+		return true, nil
+	}
 
-	if castedRequest.Email != email {
-		return status.Errorf(codes.Unauthenticated, "Email address does not match the received one in the request")
+	adminGroup, err := service.findAdminGroup(ctx, email)
+	if err != nil {
+		return false, err
 	}
 
-	return nil
+	return adminGroup != "", nil
 }
 
-func isAuthorizedToCallDeleteUser(email string, request interface{}) error {
-	castedRequest := request.(*userGRPCContract.DeleteUserRequest)
+// findAdminGroup returns the name of the first group listed in ADMIN_GROUPS the user is a member of, or an empty
+// string if the user is not a member of any of them. The groups are not listed if ADMIN_GROUPS is empty.
+func (service *transportService) findAdminGroup(ctx context.Context, email string) (string, error) {
+	if len(service.adminGroups) == 0 {
+		return "", nil
+	}
 
-	if castedRequest.Email != email {
-		return status.Errorf(codes.Unauthenticated, "Email address does not match the received one in the request")
+	groups, err := service.groupService.ListUserGroups(ctx, email)
+	if err != nil {
+		return "", err
+	}
+
+	for _, group := range groups {
+		if service.adminGroups[group.Name] {
+			return group.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+// isAuthorizedService allows the internal services to call the endpoints they are listed for in
+// SERVICE_IDENTITY_ALLOWLIST. The ownership checks do not apply as the services call on behalf of every user.
+func (service *transportService) isAuthorizedService(decision *transport.AuthorizationDecision, endpointName string) error {
+	if !service.serviceIdentities[endpointName][decision.Service] {
+		return decision.Fail("service-identity", status.Errorf(codes.PermissionDenied, "Service %s is not allowed to call %s", decision.Service, endpointName))
 	}
 
+	decision.Pass("service-identity", "The service identity is listed in SERVICE_IDENTITY_ALLOWLIST for the endpoint")
+
 	return nil
 }
+
+func (service *transportService) isAuthorizedWithAPIKey(
+	ctx context.Context,
+	decision *transport.AuthorizationDecision,
+	endpointName string,
+	key string,
+	request interface{}) error {
+	scope, ok := apiKeyScopes[endpointName]
+	if !ok {
+		return decision.Fail("api-key-endpoint", status.Errorf(codes.PermissionDenied, "%s can not be called with an API key", endpointName))
+	}
+
+	decision.Pass("api-key-endpoint", "The endpoint can be called with an API key")
+
+	apiKey, email, err := service.apiKeyService.ResolveAPIKey(ctx, key)
+	if err != nil {
+		if commonErrors.IsNotFoundError(err) {
+			return decision.Fail("api-key", status.Errorf(codes.Unauthenticated, "API key is invalid, revoked or expired"))
+		}
+
+		return decision.Fail("api-key", status.Error(mapErrorToCode(err), err.Error()))
+	}
+
+	decision.Pass("api-key", "The API key is verified")
+
+	// The API keys are not scoped to a tenant as they are only allowed to call on behalf of the user that owns them
+	decision.Subject = "apikey:" + apiKey.KeyID
+	decision.Email = email
+
+	if !apiKey.HasScope(scope) {
+		return decision.Fail("api-key-scope", status.Errorf(codes.PermissionDenied, "API key does not have the %s scope %s requires", scope, endpointName))
+	}
+
+	decision.Pass("api-key-scope", "The API key has the scope the endpoint requires")
+
+	return service.applyEndpointPolicy(ctx, decision, request)
+}
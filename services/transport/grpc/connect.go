@@ -0,0 +1,365 @@
+// Package grpc implements functions to expose user service endpoint using GRPC protocol.
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// ConnectPathPrefix is the path the operations are served under over the Connect protocol, followed by the name of
+	// the operation, e.g. /user.Service/ReadUser
+	ConnectPathPrefix = "/user.Service/"
+
+	connectContentTypeJSON  = "application/json"
+	connectContentTypeProto = "application/proto"
+	connectTimeoutHeader    = "Connect-Timeout-Ms"
+	connectTrailerPrefix    = "Trailer-"
+)
+
+type connectMethod struct {
+	requestType reflect.Type
+	call        reflect.Value
+}
+
+type connectCode struct {
+	name       string
+	httpStatus int
+}
+
+type connectErrorDetail struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type connectError struct {
+	Code    string               `json:"code"`
+	Message string               `json:"message,omitempty"`
+	Details []connectErrorDetail `json:"details,omitempty"`
+}
+
+type connectHandler struct {
+	methods      map[string]connectMethod
+	interceptors []grpc.UnaryServerInterceptor
+
+	// maxRequestSize matches the maximum message size the gRPC server receives
+	maxRequestSize int
+}
+
+// connectServerTransportStream collects the headers and the trailers the interceptors and the handlers set on the call
+// so they are sent back as HTTP headers
+type connectServerTransportStream struct {
+	method  string
+	lock    sync.Mutex
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+var connectCodes = map[codes.Code]connectCode{
+	codes.Canceled:           {name: "canceled", httpStatus: 499},
+	codes.Unknown:            {name: "unknown", httpStatus: http.StatusInternalServerError},
+	codes.InvalidArgument:    {name: "invalid_argument", httpStatus: http.StatusBadRequest},
+	codes.DeadlineExceeded:   {name: "deadline_exceeded", httpStatus: http.StatusGatewayTimeout},
+	codes.NotFound:           {name: "not_found", httpStatus: http.StatusNotFound},
+	codes.AlreadyExists:      {name: "already_exists", httpStatus: http.StatusConflict},
+	codes.PermissionDenied:   {name: "permission_denied", httpStatus: http.StatusForbidden},
+	codes.ResourceExhausted:  {name: "resource_exhausted", httpStatus: http.StatusTooManyRequests},
+	codes.FailedPrecondition: {name: "failed_precondition", httpStatus: http.StatusBadRequest},
+	codes.Aborted:            {name: "aborted", httpStatus: http.StatusConflict},
+	codes.OutOfRange:         {name: "out_of_range", httpStatus: http.StatusBadRequest},
+	codes.Unimplemented:      {name: "unimplemented", httpStatus: http.StatusNotImplemented},
+	codes.Internal:           {name: "internal", httpStatus: http.StatusInternalServerError},
+	codes.Unavailable:        {name: "unavailable", httpStatus: http.StatusServiceUnavailable},
+	codes.DataLoss:           {name: "data_loss", httpStatus: http.StatusInternalServerError},
+	codes.Unauthenticated:    {name: "unauthenticated", httpStatus: http.StatusUnauthorized},
+}
+
+// CreateConnectHandler creates the handler that serves the unary operations over the Connect protocol, going through
+// the same interceptors, endpoints and authorization the operations go through when called over gRPC. The requests and
+// the responses are encoded either as JSON or as binary protobuf, the streaming operations are not served.
+// Returns the new handler
+func (service *transportService) CreateConnectHandler() http.Handler {
+	service.setupHandlersOnce.Do(service.setupHandlers)
+
+	methods := map[string]connectMethod{}
+	serverValue := reflect.ValueOf(service)
+	serverType := reflect.TypeOf((*userGRPCContract.ServiceServer)(nil)).Elem()
+
+	for index := 0; index < serverType.NumMethod(); index++ {
+		method := serverType.Method(index)
+
+		// The streaming operations receive the stream instead of returning the response
+		if method.Type.NumIn() != 2 || method.Type.NumOut() != 2 {
+			continue
+		}
+
+		methods[method.Name] = connectMethod{
+			requestType: method.Type.In(1).Elem(),
+			call:        serverValue.MethodByName(method.Name),
+		}
+	}
+
+	return &connectHandler{
+		methods:        methods,
+		interceptors:   service.createUnaryServerInterceptors(),
+		maxRequestSize: service.maxReceiveMessageSize,
+	}
+}
+
+// ServeHTTP serves the Connect unary call
+// writer: Mandatory. The writer the response is written to
+// request: Mandatory. The received request
+func (handler *connectHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writer.Header().Set("Allow", http.MethodPost)
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	contentType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	if contentType != connectContentTypeJSON && contentType != connectContentTypeProto {
+		writer.Header().Set("Accept-Post", connectContentTypeJSON+", "+connectContentTypeProto)
+		writer.WriteHeader(http.StatusUnsupportedMediaType)
+
+		return
+	}
+
+	methodName := strings.TrimPrefix(request.URL.Path, ConnectPathPrefix)
+	method, ok := handler.methods[methodName]
+	if !ok {
+		writeConnectError(writer, status.Errorf(codes.Unimplemented, "operation %s is not served over the Connect protocol", methodName))
+
+		return
+	}
+
+	if encoding := request.Header.Get("Content-Encoding"); encoding != "" && encoding != "identity" {
+		writeConnectError(writer, status.Errorf(codes.Unimplemented, "content encoding %s is not supported", encoding))
+
+		return
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(request.Body, int64(handler.maxRequestSize)+1))
+	if err != nil {
+		writeConnectError(writer, status.Errorf(codes.Unknown, "failed to read the request: %v", err))
+
+		return
+	}
+
+	if len(body) > handler.maxRequestSize {
+		writeConnectError(writer, status.Errorf(codes.ResourceExhausted, "the request is larger than %d bytes", handler.maxRequestSize))
+
+		return
+	}
+
+	message := reflect.New(method.requestType).Interface().(proto.Message)
+	if contentType == connectContentTypeJSON {
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(body, message)
+	} else {
+		err = proto.Unmarshal(body, message)
+	}
+
+	if err != nil {
+		writeConnectError(writer, status.Errorf(codes.InvalidArgument, "failed to decode the request: %v", err))
+
+		return
+	}
+
+	ctx := request.Context()
+	if timeoutString := request.Header.Get(connectTimeoutHeader); timeoutString != "" {
+		timeout, err := strconv.ParseInt(timeoutString, 10, 64)
+		if err != nil || timeout < 0 {
+			writeConnectError(writer, status.Errorf(codes.InvalidArgument, "%s must be a non negative integer", connectTimeoutHeader))
+
+			return
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	fullMethod := ConnectPathPrefix + methodName
+	stream := &connectServerTransportStream{method: fullMethod}
+	ctx = metadata.NewIncomingContext(ctx, createIncomingMetadata(request.Header))
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+
+	response, err := handler.invoke(ctx, fullMethod, method, message)
+
+	stream.writeHeaders(writer)
+
+	if err != nil {
+		writeConnectError(writer, err)
+
+		return
+	}
+
+	var encodedResponse []byte
+	if contentType == connectContentTypeJSON {
+		encodedResponse, err = protojson.Marshal(response.(proto.Message))
+	} else {
+		encodedResponse, err = proto.Marshal(response.(proto.Message))
+	}
+
+	if err != nil {
+		writeConnectError(writer, status.Errorf(codes.Internal, "failed to encode the response: %v", err))
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", contentType)
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write(encodedResponse)
+}
+
+// invoke calls the operation through the same interceptors the gRPC server calls it through
+func (handler *connectHandler) invoke(
+	ctx context.Context,
+	fullMethod string,
+	method connectMethod,
+	request proto.Message) (interface{}, error) {
+	info := &grpc.UnaryServerInfo{FullMethod: fullMethod}
+	call := func(ctx context.Context, request interface{}) (interface{}, error) {
+		results := method.call.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(request)})
+		if err, _ := results[1].Interface().(error); err != nil {
+			return nil, err
+		}
+
+		return results[0].Interface(), nil
+	}
+
+	for index := len(handler.interceptors) - 1; index >= 0; index-- {
+		interceptor, next := handler.interceptors[index], call
+		call = func(ctx context.Context, request interface{}) (interface{}, error) {
+			return interceptor(ctx, request, info, next)
+		}
+	}
+
+	return call(ctx, request)
+}
+
+// Method returns the full name of the operation the call is made to
+func (stream *connectServerTransportStream) Method() string {
+	return stream.method
+}
+
+// SetHeader sets the header metadata sent back as the HTTP headers
+func (stream *connectServerTransportStream) SetHeader(md metadata.MD) error {
+	stream.lock.Lock()
+	defer stream.lock.Unlock()
+
+	stream.header = metadata.Join(stream.header, md)
+
+	return nil
+}
+
+// SendHeader sets the header metadata, the headers are sent with the response
+func (stream *connectServerTransportStream) SendHeader(md metadata.MD) error {
+	return stream.SetHeader(md)
+}
+
+// SetTrailer sets the trailer metadata sent back as the HTTP headers prefixed with Trailer-
+func (stream *connectServerTransportStream) SetTrailer(md metadata.MD) error {
+	stream.lock.Lock()
+	defer stream.lock.Unlock()
+
+	stream.trailer = metadata.Join(stream.trailer, md)
+
+	return nil
+}
+
+func (stream *connectServerTransportStream) writeHeaders(writer http.ResponseWriter) {
+	stream.lock.Lock()
+	defer stream.lock.Unlock()
+
+	for key, values := range stream.header {
+		for _, value := range values {
+			writer.Header().Add(key, encodeMetadataValue(key, value))
+		}
+	}
+
+	for key, values := range stream.trailer {
+		for _, value := range values {
+			writer.Header().Add(connectTrailerPrefix+key, encodeMetadataValue(key, value))
+		}
+	}
+}
+
+// createIncomingMetadata maps the HTTP headers to the metadata the interceptors and the authorization read, e.g.
+// the authorization and the request id headers
+func createIncomingMetadata(header http.Header) metadata.MD {
+	md := metadata.MD{}
+
+	for key, values := range header {
+		key = strings.ToLower(key)
+
+		for _, value := range values {
+			// The binary values are sent base64 encoded, padded or not
+			if strings.HasSuffix(key, "-bin") {
+				decodedValue, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(value, "="))
+				if err != nil {
+					continue
+				}
+
+				value = string(decodedValue)
+			}
+
+			md.Append(key, value)
+		}
+	}
+
+	return md
+}
+
+func encodeMetadataValue(key string, value string) string {
+	if strings.HasSuffix(key, "-bin") {
+		return base64.RawStdEncoding.EncodeToString([]byte(value))
+	}
+
+	return value
+}
+
+// writeConnectError writes the error as the Connect error, the google.rpc error details are kept so the clients can
+// read the field violations and the localized messages the same way the gRPC clients do
+func writeConnectError(writer http.ResponseWriter, err error) {
+	grpcStatus := status.Convert(err)
+
+	code, ok := connectCodes[grpcStatus.Code()]
+	if !ok {
+		code = connectCodes[codes.Unknown]
+	}
+
+	response := connectError{
+		Code:    code.name,
+		Message: grpcStatus.Message(),
+	}
+
+	for _, detail := range grpcStatus.Proto().Details {
+		response.Details = append(response.Details, connectErrorDetail{
+			Type:  detail.TypeUrl[strings.LastIndex(detail.TypeUrl, "/")+1:],
+			Value: base64.RawStdEncoding.EncodeToString(detail.Value),
+		})
+	}
+
+	writer.Header().Set("Content-Type", connectContentTypeJSON)
+	writer.WriteHeader(code.httpStatus)
+	_ = json.NewEncoder(writer).Encode(response)
+}
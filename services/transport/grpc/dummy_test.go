@@ -1 +0,0 @@
-package grpc_test
@@ -6,8 +6,18 @@ import (
 
 	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
 	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/apikey"
 	"github.com/decentralized-cloud/user/services/business"
+	"github.com/decentralized-cloud/user/services/consent"
+	"github.com/decentralized-cloud/user/services/group"
+	"github.com/decentralized-cloud/user/services/invitation"
+	"github.com/decentralized-cloud/user/services/magiclink"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/session"
+	"github.com/decentralized-cloud/user/services/webhook"
 	commonErrors "github.com/micro-business/go-core/system/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // decodeCreateUserRequest decodes CreateUser request message from GRPC object to business object
@@ -17,8 +27,10 @@ import (
 func decodeCreateUserRequest(
 	ctx context.Context,
 	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.CreateUserRequest)
+
 	return &business.CreateUserRequest{
-		User: models.User{}}, nil
+		User: mapUserFromGRPC(castedRequest.User)}, nil
 }
 
 // encodeCreateUserResponse encodes CreateUser response from business object to GRPC object
@@ -33,7 +45,7 @@ func encodeCreateUserResponse(
 	if castedResponse.Err == nil {
 		return &userGRPCContract.CreateUserResponse{
 			Error:  userGRPCContract.Error_NO_ERROR,
-			User:   &userGRPCContract.User{},
+			User:   mapUserToGRPC(castedResponse.User),
 			Cursor: castedResponse.Cursor,
 		}, nil
 	}
@@ -41,6 +53,7 @@ func encodeCreateUserResponse(
 	return &userGRPCContract.CreateUserResponse{
 		Error:        mapError(castedResponse.Err),
 		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
 	}, nil
 }
 
@@ -54,7 +67,9 @@ func decodeReadUserRequest(
 	castedRequest := request.(*userGRPCContract.ReadUserRequest)
 
 	return &business.ReadUserRequest{
-		Email: castedRequest.Email,
+		Email:  castedRequest.Email,
+		UserID: castedRequest.UserID,
+		Fields: castedRequest.Fields,
 	}, nil
 }
 
@@ -70,13 +85,99 @@ func encodeReadUserResponse(
 	if castedResponse.Err == nil {
 		return &userGRPCContract.ReadUserResponse{
 			Error: userGRPCContract.Error_NO_ERROR,
-			User:  &userGRPCContract.User{},
+			Email: castedResponse.Email,
+			User:  mapUserToGRPC(castedResponse.User),
 		}, nil
 	}
 
 	return &userGRPCContract.ReadUserResponse{
 		Error:        mapError(castedResponse.Err),
 		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeReadUserByEmailRequest decodes ReadUserByEmail request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeReadUserByEmailRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ReadUserByEmailRequest)
+
+	return &business.ReadUserRequest{
+		Email:  castedRequest.Email,
+		Fields: castedRequest.Fields,
+	}, nil
+}
+
+// encodeReadUserByEmailResponse encodes ReadUserByEmail response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeReadUserByEmailResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ReadUserResponse)
+
+	if castedResponse.Err == nil {
+		return &userGRPCContract.ReadUserByEmailResponse{
+			Error: userGRPCContract.Error_NO_ERROR,
+			User:  mapUserToGRPC(castedResponse.User),
+		}, nil
+	}
+
+	return &userGRPCContract.ReadUserByEmailResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeReadUsersRequest decodes ReadUsers request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeReadUsersRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ReadUsersRequest)
+
+	return &business.ReadUsersRequest{
+		Emails:  castedRequest.Emails,
+		Fields:  castedRequest.Fields,
+		UserIDs: castedRequest.UserIDs,
+	}, nil
+}
+
+// encodeReadUsersResponse encodes ReadUsers response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeReadUsersResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ReadUsersResponse)
+
+	if castedResponse.Err == nil {
+		users := make([]*userGRPCContract.UserWithCursor, 0, len(castedResponse.Users))
+		for _, user := range castedResponse.Users {
+			users = append(users, mapUserWithCursorToGRPC(user))
+		}
+
+		return &userGRPCContract.ReadUsersResponse{
+			Error:          userGRPCContract.Error_NO_ERROR,
+			Users:          users,
+			MissingEmails:  castedResponse.MissingEmails,
+			MissingUserIDs: castedResponse.MissingUserIDs,
+		}, nil
+	}
+
+	return &userGRPCContract.ReadUsersResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
 	}, nil
 }
 
@@ -90,8 +191,9 @@ func decodeUpdateUserRequest(
 	castedRequest := request.(*userGRPCContract.UpdateUserRequest)
 
 	return &business.UpdateUserRequest{
-		Email: castedRequest.Email,
-		User:  models.User{}}, nil
+		Email:  castedRequest.Email,
+		UserID: castedRequest.UserID,
+		User:   mapUserFromGRPC(castedRequest.User)}, nil
 }
 
 // encodeUpdateUserResponse encodes UpdateUser response from business object to GRPC object
@@ -106,7 +208,7 @@ func encodeUpdateUserResponse(
 	if castedResponse.Err == nil {
 		return &userGRPCContract.UpdateUserResponse{
 			Error:  userGRPCContract.Error_NO_ERROR,
-			User:   &userGRPCContract.User{},
+			User:   mapUserToGRPC(castedResponse.User),
 			Cursor: castedResponse.Cursor,
 		}, nil
 	}
@@ -114,6 +216,124 @@ func encodeUpdateUserResponse(
 	return &userGRPCContract.UpdateUserResponse{
 		Error:        mapError(castedResponse.Err),
 		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeChangeEmailRequest decodes ChangeEmail request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeChangeEmailRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ChangeEmailRequest)
+
+	return &business.ChangeEmailRequest{
+		Email:    castedRequest.Email,
+		UserID:   castedRequest.UserID,
+		NewEmail: castedRequest.NewEmail,
+	}, nil
+}
+
+// encodeChangeEmailResponse encodes ChangeEmail response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeChangeEmailResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ChangeEmailResponse)
+
+	if castedResponse.Err == nil {
+		return &userGRPCContract.ChangeEmailResponse{
+			Error:  userGRPCContract.Error_NO_ERROR,
+			User:   mapUserToGRPC(castedResponse.User),
+			Cursor: castedResponse.Cursor,
+		}, nil
+	}
+
+	return &userGRPCContract.ChangeEmailResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeRequestEmailChangeRequest decodes RequestEmailChange request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeRequestEmailChangeRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.RequestEmailChangeRequest)
+
+	return &business.RequestEmailChangeRequest{
+		Email:    castedRequest.Email,
+		NewEmail: castedRequest.NewEmail,
+	}, nil
+}
+
+// encodeRequestEmailChangeResponse encodes RequestEmailChange response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeRequestEmailChangeResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.RequestEmailChangeResponse)
+
+	if castedResponse.Err == nil {
+		return &userGRPCContract.RequestEmailChangeResponse{
+			Error:     userGRPCContract.Error_NO_ERROR,
+			ExpiresAt: timestamppb.New(castedResponse.ExpiresAt),
+		}, nil
+	}
+
+	return &userGRPCContract.RequestEmailChangeResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeConfirmEmailChangeRequest decodes ConfirmEmailChange request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeConfirmEmailChangeRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ConfirmEmailChangeRequest)
+
+	return &business.ConfirmEmailChangeRequest{
+		Email: castedRequest.Email,
+		Token: castedRequest.Token,
+	}, nil
+}
+
+// encodeConfirmEmailChangeResponse encodes ConfirmEmailChange response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeConfirmEmailChangeResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ConfirmEmailChangeResponse)
+
+	if castedResponse.Err == nil {
+		return &userGRPCContract.ConfirmEmailChangeResponse{
+			Error:  userGRPCContract.Error_NO_ERROR,
+			User:   mapUserToGRPC(castedResponse.User),
+			Cursor: castedResponse.Cursor,
+		}, nil
+	}
+
+	return &userGRPCContract.ConfirmEmailChangeResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
 	}, nil
 }
 
@@ -127,7 +347,8 @@ func decodeDeleteUserRequest(
 	castedRequest := request.(*userGRPCContract.DeleteUserRequest)
 
 	return &business.DeleteUserRequest{
-		Email: castedRequest.Email,
+		Email:  castedRequest.Email,
+		UserID: castedRequest.UserID,
 	}, nil
 }
 
@@ -141,32 +362,3115 @@ func encodeDeleteUserResponse(
 	castedResponse := response.(*business.DeleteUserResponse)
 	if castedResponse.Err == nil {
 		return &userGRPCContract.DeleteUserResponse{
-			Error: userGRPCContract.Error_NO_ERROR,
+			Error:  userGRPCContract.Error_NO_ERROR,
+			SagaID: castedResponse.SagaID,
 		}, nil
 	}
 
 	return &userGRPCContract.DeleteUserResponse{
 		Error:        mapError(castedResponse.Err),
 		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+		SagaID:       castedResponse.SagaID,
 	}, nil
 }
 
-func mapError(err error) userGRPCContract.Error {
-	if commonErrors.IsUnknownError(err) {
-		return userGRPCContract.Error_UNKNOWN
+// decodeRestoreUserRequest decodes RestoreUser request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeRestoreUserRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.RestoreUserRequest)
+
+	return &business.RestoreUserRequest{
+		Email:  castedRequest.Email,
+		UserID: castedRequest.UserID,
+	}, nil
+}
+
+// encodeRestoreUserResponse encodes RestoreUser response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeRestoreUserResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.RestoreUserResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.RestoreUserResponse{
+			Error:  userGRPCContract.Error_NO_ERROR,
+			User:   mapUserToGRPC(castedResponse.User),
+			Cursor: castedResponse.Cursor,
+		}, nil
 	}
 
-	if commonErrors.IsAlreadyExistsError(err) {
-		return userGRPCContract.Error_USER_ALREADY_EXISTS
+	return &userGRPCContract.RestoreUserResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeGetSagaStatusRequest decodes GetSagaStatus request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeGetSagaStatusRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.GetSagaStatusRequest)
+
+	return &business.GetSagaStatusRequest{
+		SagaID: castedRequest.SagaID,
+	}, nil
+}
+
+// encodeGetSagaStatusResponse encodes GetSagaStatus response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeGetSagaStatusResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.GetSagaStatusResponse)
+	if castedResponse.Err == nil {
+		steps := make([]*userGRPCContract.SagaStep, 0, len(castedResponse.Saga.Steps))
+		for _, step := range castedResponse.Saga.Steps {
+			steps = append(steps, &userGRPCContract.SagaStep{
+				Name:     step.Name,
+				Status:   mapSagaStepStatus(step.Status),
+				Attempts: int32(step.Attempts),
+				Error:    step.Error,
+			})
+		}
+
+		return &userGRPCContract.GetSagaStatusResponse{
+			Error: userGRPCContract.Error_NO_ERROR,
+			Saga: &userGRPCContract.Saga{
+				SagaID:    castedResponse.Saga.SagaID,
+				Name:      castedResponse.Saga.Name,
+				Status:    mapSagaStatus(castedResponse.Saga.Status),
+				Steps:     steps,
+				Error:     castedResponse.Saga.Error,
+				CreatedAt: timestamppb.New(castedResponse.Saga.CreatedAt),
+				UpdatedAt: timestamppb.New(castedResponse.Saga.UpdatedAt),
+			},
+		}, nil
 	}
 
-	if commonErrors.IsNotFoundError(err) {
-		return userGRPCContract.Error_USER_NOT_FOUND
+	grpcError := mapError(castedResponse.Err)
+	if grpcError == userGRPCContract.Error_USER_NOT_FOUND {
+		grpcError = userGRPCContract.Error_SAGA_NOT_FOUND
 	}
 
-	if commonErrors.IsArgumentNilError(err) || commonErrors.IsArgumentError(err) {
-		return userGRPCContract.Error_BAD_REQUEST
+	return &userGRPCContract.GetSagaStatusResponse{
+		Error:        grpcError,
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeListAuditRecordsRequest decodes ListAuditRecords request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeListAuditRecordsRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ListAuditRecordsRequest)
+	operations := make([]models.AuditOperation, 0, len(castedRequest.Operations))
+	for _, operation := range castedRequest.Operations {
+		operations = append(operations, mapAuditOperationFromGRPC(operation))
 	}
 
-	return userGRPCContract.Error_UNKNOWN
+	decodedRequest := &business.ListAuditRecordsRequest{
+		Email:      castedRequest.Email,
+		ActorEmail: castedRequest.ActorEmail,
+		Operations: operations,
+		Limit:      int(castedRequest.Limit),
+	}
+
+	if castedRequest.CreatedAfter != nil {
+		createdAfter := castedRequest.CreatedAfter.AsTime()
+		decodedRequest.CreatedAfter = &createdAfter
+	}
+
+	if castedRequest.CreatedBefore != nil {
+		createdBefore := castedRequest.CreatedBefore.AsTime()
+		decodedRequest.CreatedBefore = &createdBefore
+	}
+
+	return decodedRequest, nil
+}
+
+// encodeListAuditRecordsResponse encodes ListAuditRecords response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeListAuditRecordsResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ListAuditRecordsResponse)
+	if castedResponse.Err == nil {
+		auditRecords := make([]*userGRPCContract.AuditRecord, 0, len(castedResponse.AuditRecords))
+		for _, record := range castedResponse.AuditRecords {
+			auditRecords = append(auditRecords, mapAuditRecordToGRPC(record))
+		}
+
+		return &userGRPCContract.ListAuditRecordsResponse{
+			Error:        userGRPCContract.Error_NO_ERROR,
+			AuditRecords: auditRecords,
+		}, nil
+	}
+
+	return &userGRPCContract.ListAuditRecordsResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeSearchRequest decodes Search request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeSearchRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.SearchRequest)
+	businessRequest := business.SearchRequest{
+		Emails:         castedRequest.Emails,
+		IncludeDeleted: castedRequest.IncludeDeleted,
+		LabelSelector:  castedRequest.LabelSelector,
+		Query:          castedRequest.Query,
+		Fields:         castedRequest.Fields,
+		GroupIDs:       castedRequest.GroupIDs,
+	}
+
+	if castedRequest.After != "" {
+		after := castedRequest.After
+		businessRequest.Pagination.After = &after
+	}
+
+	if castedRequest.First != 0 {
+		first := int(castedRequest.First)
+		businessRequest.Pagination.First = &first
+	}
+
+	if castedRequest.Before != "" {
+		before := castedRequest.Before
+		businessRequest.Pagination.Before = &before
+	}
+
+	if castedRequest.Last != 0 {
+		last := int(castedRequest.Last)
+		businessRequest.Pagination.Last = &last
+	}
+
+	for _, sortingOption := range castedRequest.SortingOptions {
+		direction := models.Ascending
+		if sortingOption.Direction == userGRPCContract.SortingDirection_DESCENDING {
+			direction = models.Descending
+		}
+
+		businessRequest.SortingOptions = append(businessRequest.SortingOptions, models.SortingOptionPair{
+			Name:      sortingOption.Name,
+			Direction: direction,
+		})
+	}
+
+	return &businessRequest, nil
+}
+
+// encodeSearchResponse encodes Search response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeSearchResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.SearchResponse)
+	if castedResponse.Err == nil {
+		users := make([]*userGRPCContract.UserWithCursor, 0, len(castedResponse.Users))
+		for _, user := range castedResponse.Users {
+			users = append(users, mapUserWithCursorToGRPC(user))
+		}
+
+		return &userGRPCContract.SearchResponse{
+			Error:           userGRPCContract.Error_NO_ERROR,
+			HasPreviousPage: castedResponse.HasPreviousPage,
+			HasNextPage:     castedResponse.HasNextPage,
+			TotalCount:      castedResponse.TotalCount,
+			Users:           users,
+		}, nil
+	}
+
+	return &userGRPCContract.SearchResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeStreamSearchUsersRequest decodes StreamSearchUsers request message from GRPC object to business object
+// request: Mandatory. The reference to the GRPC request
+// stream: Mandatory. The stream to send the users that matched the search criteria to
+// Returns the decoded request
+func decodeStreamSearchUsersRequest(
+	request *userGRPCContract.StreamSearchUsersRequest,
+	stream userGRPCContract.Service_StreamSearchUsersServer) *business.StreamSearchRequest {
+	businessRequest := business.StreamSearchRequest{
+		Emails:         request.Emails,
+		IncludeDeleted: request.IncludeDeleted,
+		// Send blocks once the flow control window of the stream is full, which in turn holds back reading the
+		// users from the repository until the client catches up
+		Send: func(user models.UserWithCursor) error {
+			return stream.Send(mapUserWithCursorToGRPC(user))
+		},
+	}
+
+	for _, sortingOption := range request.SortingOptions {
+		direction := models.Ascending
+		if sortingOption.Direction == userGRPCContract.SortingDirection_DESCENDING {
+			direction = models.Descending
+		}
+
+		businessRequest.SortingOptions = append(businessRequest.SortingOptions, models.SortingOptionPair{
+			Name:      sortingOption.Name,
+			Direction: direction,
+		})
+	}
+
+	return &businessRequest
+}
+
+// encodeStreamSearchUsersResponse encodes StreamSearchUsers response from business object to the status the stream ends with
+// response: Mandatory. The reference to the business response
+// Returns nil if all the users are sent or the status error of the failure
+func encodeStreamSearchUsersResponse(response interface{}) error {
+	castedResponse := response.(*business.StreamSearchResponse)
+	if castedResponse.Err == nil {
+		return nil
+	}
+
+	return newStatusError(castedResponse.Err)
+}
+
+// decodeGetEffectiveConfigurationRequest decodes GetEffectiveConfiguration request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeGetEffectiveConfigurationRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	return &business.GetEffectiveConfigurationRequest{}, nil
+}
+
+// encodeGetEffectiveConfigurationResponse encodes GetEffectiveConfiguration response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeGetEffectiveConfigurationResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.GetEffectiveConfigurationResponse)
+	if castedResponse.Err == nil {
+		options := make([]*userGRPCContract.ConfigurationOption, 0, len(castedResponse.Options))
+		for _, option := range castedResponse.Options {
+			options = append(options, &userGRPCContract.ConfigurationOption{
+				Name:     option.Name,
+				Value:    option.Value,
+				Default:  option.Default,
+				Redacted: option.Redacted,
+				Error:    option.Error,
+			})
+		}
+
+		return &userGRPCContract.GetEffectiveConfigurationResponse{
+			Error:   userGRPCContract.Error_NO_ERROR,
+			Options: options,
+		}, nil
+	}
+
+	return &userGRPCContract.GetEffectiveConfigurationResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeGetEnabledFeaturesRequest decodes GetEnabledFeatures request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeGetEnabledFeaturesRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	return &business.GetEnabledFeaturesRequest{}, nil
+}
+
+// encodeGetEnabledFeaturesResponse encodes GetEnabledFeatures response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeGetEnabledFeaturesResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.GetEnabledFeaturesResponse)
+	if castedResponse.Err == nil {
+		features := make([]*userGRPCContract.Feature, 0, len(castedResponse.Features))
+		for _, feature := range castedResponse.Features {
+			features = append(features, &userGRPCContract.Feature{
+				Name:    feature.Name,
+				Enabled: feature.Enabled,
+				Detail:  feature.Detail,
+			})
+		}
+
+		return &userGRPCContract.GetEnabledFeaturesResponse{
+			Error:    userGRPCContract.Error_NO_ERROR,
+			Features: features,
+		}, nil
+	}
+
+	return &userGRPCContract.GetEnabledFeaturesResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodePreviewBulkUpdateUsersRequest decodes PreviewBulkUpdateUsers request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodePreviewBulkUpdateUsersRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.PreviewBulkUpdateUsersRequest)
+
+	return &business.PreviewBulkUpdateUsersRequest{
+		Emails:     castedRequest.Emails,
+		User:       mapUserFromGRPC(castedRequest.User),
+		UpdateMask: castedRequest.UpdateMask,
+		SampleSize: int(castedRequest.SampleSize),
+	}, nil
+}
+
+// encodePreviewBulkUpdateUsersResponse encodes PreviewBulkUpdateUsers response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodePreviewBulkUpdateUsersResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.PreviewBulkUpdateUsersResponse)
+	if castedResponse.Err == nil {
+		sample := make([]*userGRPCContract.UserWithCursor, 0, len(castedResponse.Sample))
+		for _, user := range castedResponse.Sample {
+			sample = append(sample, mapUserWithCursorToGRPC(user))
+		}
+
+		return &userGRPCContract.PreviewBulkUpdateUsersResponse{
+			Error:             userGRPCContract.Error_NO_ERROR,
+			MatchedCount:      castedResponse.MatchedCount,
+			Sample:            sample,
+			ConfirmationToken: castedResponse.ConfirmationToken,
+			ExpiresAt:         timestamppb.New(castedResponse.ExpiresAt),
+		}, nil
+	}
+
+	return &userGRPCContract.PreviewBulkUpdateUsersResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeBulkUpdateUsersRequest decodes BulkUpdateUsers request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeBulkUpdateUsersRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.BulkUpdateUsersRequest)
+
+	return &business.BulkUpdateUsersRequest{
+		Emails:            castedRequest.Emails,
+		User:              mapUserFromGRPC(castedRequest.User),
+		UpdateMask:        castedRequest.UpdateMask,
+		ConfirmationToken: castedRequest.ConfirmationToken,
+	}, nil
+}
+
+// encodeBulkUpdateUsersResponse encodes BulkUpdateUsers response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeBulkUpdateUsersResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.BulkUpdateUsersResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.BulkUpdateUsersResponse{
+			Error:        userGRPCContract.Error_NO_ERROR,
+			UpdatedCount: castedResponse.UpdatedCount,
+			FailedEmails: castedResponse.FailedEmails,
+		}, nil
+	}
+
+	// The users updated before the bulk update failed are reported along with the error
+	return &userGRPCContract.BulkUpdateUsersResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+		UpdatedCount: castedResponse.UpdatedCount,
+		FailedEmails: castedResponse.FailedEmails,
+	}, nil
+}
+
+// decodePurgeByLabelRequest decodes PurgeByLabel request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodePurgeByLabelRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.PurgeByLabelRequest)
+
+	return &business.PurgeByLabelRequest{
+		Label: castedRequest.Label,
+	}, nil
+}
+
+// encodePurgeByLabelResponse encodes PurgeByLabel response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodePurgeByLabelResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.PurgeByLabelResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.PurgeByLabelResponse{
+			Error:       userGRPCContract.Error_NO_ERROR,
+			PurgedCount: castedResponse.PurgedCount,
+		}, nil
+	}
+
+	return &userGRPCContract.PurgeByLabelResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeGetOutboxLagRequest decodes GetOutboxLag request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeGetOutboxLagRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	return &business.GetOutboxLagRequest{}, nil
+}
+
+// encodeGetOutboxLagResponse encodes GetOutboxLag response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeGetOutboxLagResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.GetOutboxLagResponse)
+	if castedResponse.Err != nil {
+		return &userGRPCContract.GetOutboxLagResponse{
+			Error:        mapError(castedResponse.Err),
+			ErrorMessage: castedResponse.Err.Error(),
+			ErrorDetails: mapErrorDetails(castedResponse.Err),
+		}, nil
+	}
+
+	outboxLag := castedResponse.OutboxLag
+	encodedResponse := &userGRPCContract.GetOutboxLagResponse{
+		Error:                             userGRPCContract.Error_NO_ERROR,
+		Broker:                            outboxLag.Broker,
+		Connected:                         outboxLag.Connected,
+		PendingEvents:                     outboxLag.PendingEvents,
+		PendingBytes:                      outboxLag.PendingBytes,
+		OldestPendingEventAgeMilliseconds: castedResponse.OldestPendingEventAge.Milliseconds(),
+		LastConfirmationError:             outboxLag.LastConfirmationError,
+	}
+
+	if outboxLag.OldestPendingEventAt != nil {
+		encodedResponse.OldestPendingEventAt = timestamppb.New(*outboxLag.OldestPendingEventAt)
+	}
+
+	if outboxLag.LastConfirmedAt != nil {
+		encodedResponse.LastConfirmedAt = timestamppb.New(*outboxLag.LastConfirmedAt)
+	}
+
+	return encodedResponse, nil
+}
+
+// decodeListPendingEventsRequest decodes ListPendingEvents request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeListPendingEventsRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ListPendingEventsRequest)
+
+	return &business.ListPendingEventsRequest{
+		Limit: int(castedRequest.Limit),
+	}, nil
+}
+
+// encodeListPendingEventsResponse encodes ListPendingEvents response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeListPendingEventsResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ListPendingEventsResponse)
+	if castedResponse.Err != nil {
+		return &userGRPCContract.ListPendingEventsResponse{
+			Error:        mapError(castedResponse.Err),
+			ErrorMessage: castedResponse.Err.Error(),
+			ErrorDetails: mapErrorDetails(castedResponse.Err),
+		}, nil
+	}
+
+	pendingEvents := make([]*userGRPCContract.PendingEvent, 0, len(castedResponse.PendingEvents))
+	for _, pendingEvent := range castedResponse.PendingEvents {
+		pendingEvents = append(pendingEvents, &userGRPCContract.PendingEvent{
+			Sequence:   pendingEvent.Sequence,
+			Subject:    pendingEvent.Subject,
+			Email:      pendingEvent.Email,
+			OccurredAt: timestamppb.New(pendingEvent.OccurredAt),
+		})
+	}
+
+	return &userGRPCContract.ListPendingEventsResponse{
+		Error:         userGRPCContract.Error_NO_ERROR,
+		PendingEvents: pendingEvents,
+	}, nil
+}
+
+// decodeForceFlushRequest decodes ForceFlush request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeForceFlushRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	return &business.ForceFlushRequest{}, nil
+}
+
+// encodeForceFlushResponse encodes ForceFlush response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeForceFlushResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ForceFlushResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.ForceFlushResponse{
+			Error:         userGRPCContract.Error_NO_ERROR,
+			FlushedCount:  castedResponse.FlushedCount,
+			PendingEvents: castedResponse.PendingEvents,
+		}, nil
+	}
+
+	return &userGRPCContract.ForceFlushResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeGetUserPreferencesRequest decodes GetUserPreferences request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeGetUserPreferencesRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.GetUserPreferencesRequest)
+
+	return &business.GetUserPreferencesRequest{
+		Email:  castedRequest.Email,
+		UserID: castedRequest.UserID,
+	}, nil
+}
+
+// encodeGetUserPreferencesResponse encodes GetUserPreferences response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeGetUserPreferencesResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.GetUserPreferencesResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.GetUserPreferencesResponse{
+			Error:       userGRPCContract.Error_NO_ERROR,
+			Preferences: castedResponse.Preferences,
+		}, nil
+	}
+
+	return &userGRPCContract.GetUserPreferencesResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeUpdateUserPreferencesRequest decodes UpdateUserPreferences request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeUpdateUserPreferencesRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.UpdateUserPreferencesRequest)
+
+	return &business.UpdateUserPreferencesRequest{
+		Email:       castedRequest.Email,
+		UserID:      castedRequest.UserID,
+		Preferences: castedRequest.Preferences,
+		RemovedKeys: castedRequest.RemovedKeys,
+	}, nil
+}
+
+// encodeUpdateUserPreferencesResponse encodes UpdateUserPreferences response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeUpdateUserPreferencesResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.UpdateUserPreferencesResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.UpdateUserPreferencesResponse{
+			Error:       userGRPCContract.Error_NO_ERROR,
+			Preferences: castedResponse.Preferences,
+		}, nil
+	}
+
+	return &userGRPCContract.UpdateUserPreferencesResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeGetUserAvatarRequest decodes GetUserAvatar request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeGetUserAvatarRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.GetUserAvatarRequest)
+
+	return &business.GetUserAvatarRequest{
+		Email:  castedRequest.Email,
+		UserID: castedRequest.UserID,
+	}, nil
+}
+
+// encodeGetUserAvatarResponse encodes GetUserAvatar response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeGetUserAvatarResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.GetUserAvatarResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.GetUserAvatarResponse{
+			Error:       userGRPCContract.Error_NO_ERROR,
+			Url:         castedResponse.URL,
+			ContentHash: castedResponse.ContentHash,
+			ExpiresAt:   timestamppb.New(castedResponse.ExpiresAt),
+		}, nil
+	}
+
+	return &userGRPCContract.GetUserAvatarResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeSetUserAvatarRequest decodes SetUserAvatar request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeSetUserAvatarRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.SetUserAvatarRequest)
+
+	return &business.SetUserAvatarRequest{
+		Email:       castedRequest.Email,
+		UserID:      castedRequest.UserID,
+		ContentType: castedRequest.ContentType,
+		Content:     castedRequest.Content,
+	}, nil
+}
+
+// encodeSetUserAvatarResponse encodes SetUserAvatar response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeSetUserAvatarResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.SetUserAvatarResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.SetUserAvatarResponse{
+			Error:       userGRPCContract.Error_NO_ERROR,
+			User:        mapUserToGRPC(castedResponse.User),
+			Cursor:      castedResponse.Cursor,
+			Url:         castedResponse.URL,
+			ContentHash: castedResponse.ContentHash,
+			ExpiresAt:   timestamppb.New(castedResponse.ExpiresAt),
+		}, nil
+	}
+
+	return &userGRPCContract.SetUserAvatarResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeExportPersonalDataRequest decodes ExportPersonalData request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeExportPersonalDataRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ExportPersonalDataRequest)
+
+	return &business.ExportPersonalDataRequest{
+		Email: castedRequest.Email,
+	}, nil
+}
+
+// encodeExportPersonalDataResponse encodes ExportPersonalData response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeExportPersonalDataResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ExportPersonalDataResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.ExportPersonalDataResponse{
+			Error:    userGRPCContract.Error_NO_ERROR,
+			Archive:  castedResponse.Archive,
+			FileName: castedResponse.FileName,
+		}, nil
+	}
+
+	return &userGRPCContract.ExportPersonalDataResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeEraseUserRequest decodes EraseUser request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeEraseUserRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.EraseUserRequest)
+
+	return &business.EraseUserRequest{
+		Email:             castedRequest.Email,
+		ConfirmationToken: castedRequest.ConfirmationToken,
+	}, nil
+}
+
+// encodeEraseUserResponse encodes EraseUser response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeEraseUserResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.EraseUserResponse)
+	if castedResponse.Err != nil {
+		return &userGRPCContract.EraseUserResponse{
+			Error:        mapError(castedResponse.Err),
+			ErrorMessage: castedResponse.Err.Error(),
+			ErrorDetails: mapErrorDetails(castedResponse.Err),
+		}, nil
+	}
+
+	if castedResponse.Erased {
+		return &userGRPCContract.EraseUserResponse{
+			Error:  userGRPCContract.Error_NO_ERROR,
+			Erased: true,
+		}, nil
+	}
+
+	return &userGRPCContract.EraseUserResponse{
+		Error:             userGRPCContract.Error_NO_ERROR,
+		ConfirmationToken: castedResponse.ConfirmationToken,
+		ConfirmableAt:     timestamppb.New(castedResponse.ConfirmableAt),
+		ExpiresAt:         timestamppb.New(castedResponse.ExpiresAt),
+	}, nil
+}
+
+// decodeAddUserToTenantRequest decodes AddUserToTenant request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeAddUserToTenantRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.AddUserToTenantRequest)
+
+	return &business.AddUserToTenantRequest{
+		Email:    castedRequest.Email,
+		TenantID: castedRequest.TenantID,
+		Role:     castedRequest.Role,
+	}, nil
+}
+
+// encodeAddUserToTenantResponse encodes AddUserToTenant response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeAddUserToTenantResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.AddUserToTenantResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.AddUserToTenantResponse{
+			Error:  userGRPCContract.Error_NO_ERROR,
+			User:   mapUserToGRPC(castedResponse.User),
+			Cursor: castedResponse.Cursor,
+		}, nil
+	}
+
+	return &userGRPCContract.AddUserToTenantResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeRemoveUserFromTenantRequest decodes RemoveUserFromTenant request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeRemoveUserFromTenantRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.RemoveUserFromTenantRequest)
+
+	return &business.RemoveUserFromTenantRequest{
+		Email:    castedRequest.Email,
+		TenantID: castedRequest.TenantID,
+	}, nil
+}
+
+// encodeRemoveUserFromTenantResponse encodes RemoveUserFromTenant response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeRemoveUserFromTenantResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.RemoveUserFromTenantResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.RemoveUserFromTenantResponse{
+			Error:  userGRPCContract.Error_NO_ERROR,
+			User:   mapUserToGRPC(castedResponse.User),
+			Cursor: castedResponse.Cursor,
+		}, nil
+	}
+
+	return &userGRPCContract.RemoveUserFromTenantResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeListUserTenantsRequest decodes ListUserTenants request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeListUserTenantsRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ListUserTenantsRequest)
+
+	return &business.ListUserTenantsRequest{
+		Email: castedRequest.Email,
+	}, nil
+}
+
+// encodeListUserTenantsResponse encodes ListUserTenants response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeListUserTenantsResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ListUserTenantsResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.ListUserTenantsResponse{
+			Error:       userGRPCContract.Error_NO_ERROR,
+			Memberships: mapTenantMembershipsToGRPC(castedResponse.Memberships),
+		}, nil
+	}
+
+	return &userGRPCContract.ListUserTenantsResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeGetReplicationStatusRequest decodes GetReplicationStatus request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeGetReplicationStatusRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	return &business.GetReplicationStatusRequest{}, nil
+}
+
+// encodeGetReplicationStatusResponse encodes GetReplicationStatus response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeGetReplicationStatusResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.GetReplicationStatusResponse)
+	if castedResponse.Err != nil {
+		return &userGRPCContract.GetReplicationStatusResponse{
+			Error:        mapError(castedResponse.Err),
+			ErrorMessage: castedResponse.Err.Error(),
+			ErrorDetails: mapErrorDetails(castedResponse.Err),
+		}, nil
+	}
+
+	replicationStatus := castedResponse.ReplicationStatus
+	encodedResponse := &userGRPCContract.GetReplicationStatusResponse{
+		Error:            userGRPCContract.Error_NO_ERROR,
+		Configured:       replicationStatus.Configured,
+		Primary:          mapReplicationHeartbeatToGRPC(replicationStatus.Primary),
+		Standby:          mapReplicationHeartbeatToGRPC(replicationStatus.Standby),
+		LagChanges:       replicationStatus.LagChanges,
+		LagMilliseconds:  replicationStatus.Lag.Milliseconds(),
+		FailoverReady:    replicationStatus.FailoverReady,
+		ReplicationError: replicationStatus.Error,
+	}
+
+	if !replicationStatus.CheckedAt.IsZero() {
+		encodedResponse.CheckedAt = timestamppb.New(replicationStatus.CheckedAt)
+	}
+
+	return encodedResponse, nil
+}
+
+// mapReplicationHeartbeatToGRPC maps the replication heartbeat from business object to GRPC object
+// heartbeat: Optional. The heartbeat to map
+// Returns the mapped heartbeat or nil if the heartbeat is not provided
+func mapReplicationHeartbeatToGRPC(heartbeat *models.ReplicationHeartbeat) *userGRPCContract.ReplicationHeartbeat {
+	if heartbeat == nil {
+		return nil
+	}
+
+	return &userGRPCContract.ReplicationHeartbeat{
+		Sequence:  heartbeat.Sequence,
+		WrittenAt: timestamppb.New(heartbeat.WrittenAt),
+	}
+}
+
+// decodeExportUsersRequest decodes ExportUsers request message from GRPC object to business object
+// request: Mandatory. The reference to the GRPC request
+// stream: Mandatory. The stream to send the exported users to
+// Returns the decoded request
+func decodeExportUsersRequest(
+	request *userGRPCContract.ExportUsersRequest,
+	stream userGRPCContract.Service_ExportUsersServer) *business.ExportUsersRequest {
+	businessRequest := business.ExportUsersRequest{
+		TenantID:       request.TenantID,
+		IncludeDeleted: request.IncludeDeleted,
+		After:          request.After,
+		Send: func(user models.UserWithCursor) error {
+			return stream.Send(mapUserWithCursorToGRPC(user))
+		},
+	}
+
+	if request.CreatedAfter != nil {
+		createdAfter := request.CreatedAfter.AsTime()
+		businessRequest.CreatedAfter = &createdAfter
+	}
+
+	if request.CreatedBefore != nil {
+		createdBefore := request.CreatedBefore.AsTime()
+		businessRequest.CreatedBefore = &createdBefore
+	}
+
+	return &businessRequest
+}
+
+// encodeExportUsersResponse encodes ExportUsers response from business object to the status the stream ends with
+// response: Mandatory. The reference to the business response
+// Returns nil if all the users are exported or the status error of the failure
+func encodeExportUsersResponse(response interface{}) error {
+	castedResponse := response.(*business.ExportUsersResponse)
+	if castedResponse.Err == nil {
+		return nil
+	}
+
+	return newStatusError(castedResponse.Err)
+}
+
+// decodeIssueMagicLinkRequest decodes IssueMagicLink request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeIssueMagicLinkRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.IssueMagicLinkRequest)
+
+	return &business.IssueMagicLinkRequest{
+		Email:     castedRequest.Email,
+		IPAddress: castedRequest.IpAddress,
+		DeviceID:  castedRequest.DeviceID,
+	}, nil
+}
+
+// encodeIssueMagicLinkResponse encodes IssueMagicLink response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeIssueMagicLinkResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.IssueMagicLinkResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.IssueMagicLinkResponse{
+			Error:     userGRPCContract.Error_NO_ERROR,
+			ExpiresAt: timestamppb.New(castedResponse.ExpiresAt),
+		}, nil
+	}
+
+	return &userGRPCContract.IssueMagicLinkResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeRedeemMagicLinkRequest decodes RedeemMagicLink request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeRedeemMagicLinkRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.RedeemMagicLinkRequest)
+
+	return &business.RedeemMagicLinkRequest{
+		Email:     castedRequest.Email,
+		Token:     castedRequest.Token,
+		IPAddress: castedRequest.IpAddress,
+		DeviceID:  castedRequest.DeviceID,
+	}, nil
+}
+
+// encodeRedeemMagicLinkResponse encodes RedeemMagicLink response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeRedeemMagicLinkResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.RedeemMagicLinkResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.RedeemMagicLinkResponse{
+			Error: userGRPCContract.Error_NO_ERROR,
+			User:  mapUserToGRPC(castedResponse.User),
+		}, nil
+	}
+
+	return &userGRPCContract.RedeemMagicLinkResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeCreateAPIKeyRequest decodes CreateAPIKey request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeCreateAPIKeyRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.CreateAPIKeyRequest)
+
+	businessRequest := business.CreateAPIKeyRequest{
+		Email:  castedRequest.Email,
+		UserID: castedRequest.UserID,
+		Name:   castedRequest.Name,
+		Scopes: mapAPIKeyScopesFromGRPC(castedRequest.Scopes),
+	}
+
+	if castedRequest.ExpiresAt != nil {
+		expiresAt := castedRequest.ExpiresAt.AsTime()
+		businessRequest.ExpiresAt = &expiresAt
+	}
+
+	return &businessRequest, nil
+}
+
+// encodeCreateAPIKeyResponse encodes CreateAPIKey response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeCreateAPIKeyResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.CreateAPIKeyResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.CreateAPIKeyResponse{
+			Error:  userGRPCContract.Error_NO_ERROR,
+			ApiKey: mapAPIKeyToGRPC(castedResponse.APIKey),
+			Key:    castedResponse.Key,
+		}, nil
+	}
+
+	return &userGRPCContract.CreateAPIKeyResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeListAPIKeysRequest decodes ListAPIKeys request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeListAPIKeysRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ListAPIKeysRequest)
+
+	return &business.ListAPIKeysRequest{
+		Email:  castedRequest.Email,
+		UserID: castedRequest.UserID,
+	}, nil
+}
+
+// encodeListAPIKeysResponse encodes ListAPIKeys response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeListAPIKeysResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ListAPIKeysResponse)
+	if castedResponse.Err == nil {
+		apiKeys := make([]*userGRPCContract.APIKey, 0, len(castedResponse.APIKeys))
+		for _, apiKey := range castedResponse.APIKeys {
+			apiKeys = append(apiKeys, mapAPIKeyToGRPC(apiKey))
+		}
+
+		return &userGRPCContract.ListAPIKeysResponse{
+			Error:   userGRPCContract.Error_NO_ERROR,
+			ApiKeys: apiKeys,
+		}, nil
+	}
+
+	return &userGRPCContract.ListAPIKeysResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeRevokeAPIKeyRequest decodes RevokeAPIKey request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeRevokeAPIKeyRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.RevokeAPIKeyRequest)
+
+	return &business.RevokeAPIKeyRequest{
+		Email:  castedRequest.Email,
+		UserID: castedRequest.UserID,
+		KeyID:  castedRequest.KeyID,
+	}, nil
+}
+
+// encodeRevokeAPIKeyResponse encodes RevokeAPIKey response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeRevokeAPIKeyResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.RevokeAPIKeyResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.RevokeAPIKeyResponse{
+			Error: userGRPCContract.Error_NO_ERROR,
+		}, nil
+	}
+
+	return &userGRPCContract.RevokeAPIKeyResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeRecordLoginAttemptRequest decodes RecordLoginAttempt request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeRecordLoginAttemptRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.RecordLoginAttemptRequest)
+
+	return &business.RecordLoginAttemptRequest{
+		Email:     castedRequest.Email,
+		Succeeded: castedRequest.Succeeded,
+	}, nil
+}
+
+// encodeRecordLoginAttemptResponse encodes RecordLoginAttempt response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeRecordLoginAttemptResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.RecordLoginAttemptResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.RecordLoginAttemptResponse{
+			Error:  userGRPCContract.Error_NO_ERROR,
+			User:   mapUserToGRPC(castedResponse.User),
+			Cursor: castedResponse.Cursor,
+		}, nil
+	}
+
+	return &userGRPCContract.RecordLoginAttemptResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeUnlockUserRequest decodes UnlockUser request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeUnlockUserRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.UnlockUserRequest)
+
+	return &business.UnlockUserRequest{
+		Email: castedRequest.Email,
+	}, nil
+}
+
+// encodeUnlockUserResponse encodes UnlockUser response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeUnlockUserResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.UnlockUserResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.UnlockUserResponse{
+			Error:  userGRPCContract.Error_NO_ERROR,
+			User:   mapUserToGRPC(castedResponse.User),
+			Cursor: castedResponse.Cursor,
+		}, nil
+	}
+
+	return &userGRPCContract.UnlockUserResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeEnrollMFARequest decodes EnrollMFA request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeEnrollMFARequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.EnrollMFARequest)
+
+	return &business.EnrollMFARequest{
+		Email:                castedRequest.Email,
+		UserID:               castedRequest.UserID,
+		Type:                 mapMFAMethodTypeFromGRPC(castedRequest.Type),
+		Name:                 castedRequest.Name,
+		TOTPSecretRef:        castedRequest.TotpSecretRef,
+		WebAuthnCredentialID: castedRequest.WebAuthnCredentialID,
+		RecoveryCodeHashes:   castedRequest.RecoveryCodeHashes,
+	}, nil
+}
+
+// encodeEnrollMFAResponse encodes EnrollMFA response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeEnrollMFAResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.EnrollMFAResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.EnrollMFAResponse{
+			Error:  userGRPCContract.Error_NO_ERROR,
+			Method: mapMFAMethodToGRPC(castedResponse.Method),
+			User:   mapUserToGRPC(castedResponse.User),
+			Cursor: castedResponse.Cursor,
+		}, nil
+	}
+
+	return &userGRPCContract.EnrollMFAResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeListMFAMethodsRequest decodes ListMFAMethods request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeListMFAMethodsRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ListMFAMethodsRequest)
+
+	return &business.ListMFAMethodsRequest{
+		Email:  castedRequest.Email,
+		UserID: castedRequest.UserID,
+	}, nil
+}
+
+// encodeListMFAMethodsResponse encodes ListMFAMethods response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeListMFAMethodsResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ListMFAMethodsResponse)
+	if castedResponse.Err == nil {
+		methods := make([]*userGRPCContract.MFAMethod, 0, len(castedResponse.Methods))
+		for _, method := range castedResponse.Methods {
+			methods = append(methods, mapMFAMethodToGRPC(method))
+		}
+
+		return &userGRPCContract.ListMFAMethodsResponse{
+			Error:   userGRPCContract.Error_NO_ERROR,
+			Methods: methods,
+		}, nil
+	}
+
+	return &userGRPCContract.ListMFAMethodsResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeRemoveMFAMethodRequest decodes RemoveMFAMethod request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeRemoveMFAMethodRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.RemoveMFAMethodRequest)
+
+	return &business.RemoveMFAMethodRequest{
+		Email:    castedRequest.Email,
+		UserID:   castedRequest.UserID,
+		MethodID: castedRequest.MethodID,
+	}, nil
+}
+
+// encodeRemoveMFAMethodResponse encodes RemoveMFAMethod response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeRemoveMFAMethodResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.RemoveMFAMethodResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.RemoveMFAMethodResponse{
+			Error:  userGRPCContract.Error_NO_ERROR,
+			User:   mapUserToGRPC(castedResponse.User),
+			Cursor: castedResponse.Cursor,
+		}, nil
+	}
+
+	return &userGRPCContract.RemoveMFAMethodResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeRecordConsentRequest decodes RecordConsent request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeRecordConsentRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.RecordConsentRequest)
+
+	return &business.RecordConsentRequest{
+		Email:   castedRequest.Email,
+		Policy:  castedRequest.Policy,
+		Version: castedRequest.Version,
+	}, nil
+}
+
+// encodeRecordConsentResponse encodes RecordConsent response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeRecordConsentResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.RecordConsentResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.RecordConsentResponse{
+			Error:   userGRPCContract.Error_NO_ERROR,
+			Consent: mapConsentToGRPC(castedResponse.Consent),
+		}, nil
+	}
+
+	return &userGRPCContract.RecordConsentResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeListConsentsRequest decodes ListConsents request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeListConsentsRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ListConsentsRequest)
+
+	return &business.ListConsentsRequest{
+		Email: castedRequest.Email,
+	}, nil
+}
+
+// encodeListConsentsResponse encodes ListConsents response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeListConsentsResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ListConsentsResponse)
+	if castedResponse.Err == nil {
+		consents := make([]*userGRPCContract.Consent, 0, len(castedResponse.Consents))
+		for _, acceptedConsent := range castedResponse.Consents {
+			consents = append(consents, mapConsentToGRPC(acceptedConsent))
+		}
+
+		return &userGRPCContract.ListConsentsResponse{
+			Error:               userGRPCContract.Error_NO_ERROR,
+			Consents:            consents,
+			OutstandingPolicies: mapPolicyVersionsToGRPC(castedResponse.OutstandingPolicies),
+		}, nil
+	}
+
+	return &userGRPCContract.ListConsentsResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeCreateGroupRequest decodes CreateGroup request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeCreateGroupRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.CreateGroupRequest)
+
+	return &business.CreateGroupRequest{
+		Name:        castedRequest.Name,
+		Description: castedRequest.Description,
+	}, nil
+}
+
+// encodeCreateGroupResponse encodes CreateGroup response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeCreateGroupResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.CreateGroupResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.CreateGroupResponse{
+			Error: userGRPCContract.Error_NO_ERROR,
+			Group: mapGroupToGRPC(castedResponse.Group),
+		}, nil
+	}
+
+	return &userGRPCContract.CreateGroupResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeReadGroupRequest decodes ReadGroup request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeReadGroupRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ReadGroupRequest)
+
+	return &business.ReadGroupRequest{
+		GroupID: castedRequest.GroupID,
+	}, nil
+}
+
+// encodeReadGroupResponse encodes ReadGroup response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeReadGroupResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ReadGroupResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.ReadGroupResponse{
+			Error: userGRPCContract.Error_NO_ERROR,
+			Group: mapGroupToGRPC(castedResponse.Group),
+		}, nil
+	}
+
+	return &userGRPCContract.ReadGroupResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeUpdateGroupRequest decodes UpdateGroup request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeUpdateGroupRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.UpdateGroupRequest)
+
+	return &business.UpdateGroupRequest{
+		GroupID:     castedRequest.GroupID,
+		Name:        castedRequest.Name,
+		Description: castedRequest.Description,
+	}, nil
+}
+
+// encodeUpdateGroupResponse encodes UpdateGroup response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeUpdateGroupResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.UpdateGroupResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.UpdateGroupResponse{
+			Error: userGRPCContract.Error_NO_ERROR,
+			Group: mapGroupToGRPC(castedResponse.Group),
+		}, nil
+	}
+
+	return &userGRPCContract.UpdateGroupResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeDeleteGroupRequest decodes DeleteGroup request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeDeleteGroupRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.DeleteGroupRequest)
+
+	return &business.DeleteGroupRequest{
+		GroupID: castedRequest.GroupID,
+	}, nil
+}
+
+// encodeDeleteGroupResponse encodes DeleteGroup response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeDeleteGroupResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.DeleteGroupResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.DeleteGroupResponse{
+			Error: userGRPCContract.Error_NO_ERROR,
+		}, nil
+	}
+
+	return &userGRPCContract.DeleteGroupResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeListGroupsRequest decodes ListGroups request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeListGroupsRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ListGroupsRequest)
+
+	return &business.ListGroupsRequest{
+		Email: castedRequest.Email,
+	}, nil
+}
+
+// encodeListGroupsResponse encodes ListGroups response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeListGroupsResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ListGroupsResponse)
+	if castedResponse.Err == nil {
+		groups := make([]*userGRPCContract.Group, 0, len(castedResponse.Groups))
+		for _, group := range castedResponse.Groups {
+			groups = append(groups, mapGroupToGRPC(group))
+		}
+
+		return &userGRPCContract.ListGroupsResponse{
+			Error:  userGRPCContract.Error_NO_ERROR,
+			Groups: groups,
+		}, nil
+	}
+
+	return &userGRPCContract.ListGroupsResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeAddUserToGroupRequest decodes AddUserToGroup request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeAddUserToGroupRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.AddUserToGroupRequest)
+
+	return &business.AddUserToGroupRequest{
+		GroupID: castedRequest.GroupID,
+		Email:   castedRequest.Email,
+	}, nil
+}
+
+// encodeAddUserToGroupResponse encodes AddUserToGroup response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeAddUserToGroupResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.AddUserToGroupResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.AddUserToGroupResponse{
+			Error: userGRPCContract.Error_NO_ERROR,
+			Group: mapGroupToGRPC(castedResponse.Group),
+		}, nil
+	}
+
+	return &userGRPCContract.AddUserToGroupResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeRemoveUserFromGroupRequest decodes RemoveUserFromGroup request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeRemoveUserFromGroupRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.RemoveUserFromGroupRequest)
+
+	return &business.RemoveUserFromGroupRequest{
+		GroupID: castedRequest.GroupID,
+		Email:   castedRequest.Email,
+	}, nil
+}
+
+// encodeRemoveUserFromGroupResponse encodes RemoveUserFromGroup response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeRemoveUserFromGroupResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.RemoveUserFromGroupResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.RemoveUserFromGroupResponse{
+			Error: userGRPCContract.Error_NO_ERROR,
+			Group: mapGroupToGRPC(castedResponse.Group),
+		}, nil
+	}
+
+	return &userGRPCContract.RemoveUserFromGroupResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeInviteUserRequest decodes InviteUser request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeInviteUserRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.InviteUserRequest)
+
+	return &business.InviteUserRequest{
+		Email: castedRequest.Email,
+	}, nil
+}
+
+// encodeInviteUserResponse encodes InviteUser response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeInviteUserResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.InviteUserResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.InviteUserResponse{
+			Error:     userGRPCContract.Error_NO_ERROR,
+			ExpiresAt: timestamppb.New(castedResponse.ExpiresAt),
+		}, nil
+	}
+
+	return &userGRPCContract.InviteUserResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeAcceptInvitationRequest decodes AcceptInvitation request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeAcceptInvitationRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.AcceptInvitationRequest)
+
+	return &business.AcceptInvitationRequest{
+		Token: castedRequest.Token,
+		User:  mapUserFromGRPC(castedRequest.User),
+	}, nil
+}
+
+// encodeAcceptInvitationResponse encodes AcceptInvitation response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeAcceptInvitationResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.AcceptInvitationResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.AcceptInvitationResponse{
+			Error:  userGRPCContract.Error_NO_ERROR,
+			User:   mapUserToGRPC(castedResponse.User),
+			Cursor: castedResponse.Cursor,
+		}, nil
+	}
+
+	return &userGRPCContract.AcceptInvitationResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeRegisterSessionRequest decodes RegisterSession request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeRegisterSessionRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.RegisterSessionRequest)
+
+	return &business.RegisterSessionRequest{
+		Email:      castedRequest.Email,
+		SessionID:  castedRequest.SessionID,
+		DeviceName: castedRequest.DeviceName,
+		UserAgent:  castedRequest.UserAgent,
+		IPAddress:  castedRequest.IpAddress,
+	}, nil
+}
+
+// encodeRegisterSessionResponse encodes RegisterSession response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeRegisterSessionResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.RegisterSessionResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.RegisterSessionResponse{
+			Error:   userGRPCContract.Error_NO_ERROR,
+			Session: mapSessionToGRPC(castedResponse.Session),
+		}, nil
+	}
+
+	return &userGRPCContract.RegisterSessionResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeListSessionsRequest decodes ListSessions request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeListSessionsRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ListSessionsRequest)
+
+	return &business.ListSessionsRequest{
+		Email: castedRequest.Email,
+	}, nil
+}
+
+// encodeListSessionsResponse encodes ListSessions response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeListSessionsResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ListSessionsResponse)
+	if castedResponse.Err == nil {
+		sessions := make([]*userGRPCContract.Session, 0, len(castedResponse.Sessions))
+		for _, session := range castedResponse.Sessions {
+			sessions = append(sessions, mapSessionToGRPC(session))
+		}
+
+		return &userGRPCContract.ListSessionsResponse{
+			Error:    userGRPCContract.Error_NO_ERROR,
+			Sessions: sessions,
+		}, nil
+	}
+
+	return &userGRPCContract.ListSessionsResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeRevokeSessionRequest decodes RevokeSession request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeRevokeSessionRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.RevokeSessionRequest)
+
+	return &business.RevokeSessionRequest{
+		Email:     castedRequest.Email,
+		SessionID: castedRequest.SessionID,
+	}, nil
+}
+
+// encodeRevokeSessionResponse encodes RevokeSession response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeRevokeSessionResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.RevokeSessionResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.RevokeSessionResponse{
+			Error: userGRPCContract.Error_NO_ERROR,
+		}, nil
+	}
+
+	return &userGRPCContract.RevokeSessionResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeCreateWebhookSubscriptionRequest decodes CreateWebhookSubscription request message from GRPC object to
+// business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeCreateWebhookSubscriptionRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.CreateWebhookSubscriptionRequest)
+
+	return &business.CreateWebhookSubscriptionRequest{
+		Email:      castedRequest.Email,
+		URL:        castedRequest.Url,
+		Secret:     castedRequest.Secret,
+		EventTypes: mapWebhookEventTypesFromGRPC(castedRequest.EventTypes),
+	}, nil
+}
+
+// encodeCreateWebhookSubscriptionResponse encodes CreateWebhookSubscription response from business object to GRPC
+// object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeCreateWebhookSubscriptionResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.CreateWebhookSubscriptionResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.CreateWebhookSubscriptionResponse{
+			Error:        userGRPCContract.Error_NO_ERROR,
+			Subscription: mapWebhookSubscriptionToGRPC(castedResponse.Subscription),
+		}, nil
+	}
+
+	return &userGRPCContract.CreateWebhookSubscriptionResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeListWebhookSubscriptionsRequest decodes ListWebhookSubscriptions request message from GRPC object to
+// business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeListWebhookSubscriptionsRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ListWebhookSubscriptionsRequest)
+
+	return &business.ListWebhookSubscriptionsRequest{
+		Email: castedRequest.Email,
+	}, nil
+}
+
+// encodeListWebhookSubscriptionsResponse encodes ListWebhookSubscriptions response from business object to GRPC
+// object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeListWebhookSubscriptionsResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ListWebhookSubscriptionsResponse)
+	if castedResponse.Err == nil {
+		subscriptions := make([]*userGRPCContract.WebhookSubscription, 0, len(castedResponse.Subscriptions))
+		for _, subscription := range castedResponse.Subscriptions {
+			subscriptions = append(subscriptions, mapWebhookSubscriptionToGRPC(subscription))
+		}
+
+		return &userGRPCContract.ListWebhookSubscriptionsResponse{
+			Error:         userGRPCContract.Error_NO_ERROR,
+			Subscriptions: subscriptions,
+		}, nil
+	}
+
+	return &userGRPCContract.ListWebhookSubscriptionsResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeDeleteWebhookSubscriptionRequest decodes DeleteWebhookSubscription request message from GRPC object to
+// business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeDeleteWebhookSubscriptionRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.DeleteWebhookSubscriptionRequest)
+
+	return &business.DeleteWebhookSubscriptionRequest{
+		Email:          castedRequest.Email,
+		SubscriptionID: castedRequest.SubscriptionID,
+	}, nil
+}
+
+// encodeDeleteWebhookSubscriptionResponse encodes DeleteWebhookSubscription response from business object to GRPC
+// object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeDeleteWebhookSubscriptionResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.DeleteWebhookSubscriptionResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.DeleteWebhookSubscriptionResponse{
+			Error: userGRPCContract.Error_NO_ERROR,
+		}, nil
+	}
+
+	return &userGRPCContract.DeleteWebhookSubscriptionResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeListWebhookDeliveriesRequest decodes ListWebhookDeliveries request message from GRPC object to business
+// object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeListWebhookDeliveriesRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ListWebhookDeliveriesRequest)
+
+	return &business.ListWebhookDeliveriesRequest{
+		Email:          castedRequest.Email,
+		SubscriptionID: castedRequest.SubscriptionID,
+		Limit:          int(castedRequest.Limit),
+	}, nil
+}
+
+// encodeListWebhookDeliveriesResponse encodes ListWebhookDeliveries response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeListWebhookDeliveriesResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ListWebhookDeliveriesResponse)
+	if castedResponse.Err == nil {
+		deliveries := make([]*userGRPCContract.WebhookDelivery, 0, len(castedResponse.Deliveries))
+		for _, delivery := range castedResponse.Deliveries {
+			deliveries = append(deliveries, mapWebhookDeliveryToGRPC(delivery))
+		}
+
+		return &userGRPCContract.ListWebhookDeliveriesResponse{
+			Error:      userGRPCContract.Error_NO_ERROR,
+			Deliveries: deliveries,
+		}, nil
+	}
+
+	return &userGRPCContract.ListWebhookDeliveriesResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeWatchUserRequest decodes WatchUser request message from GRPC object to business object
+// request: Mandatory. The reference to the GRPC request
+// stream: Mandatory. The stream to send the changes made to the user to
+// Returns the decoded request
+func decodeWatchUserRequest(
+	request *userGRPCContract.WatchUserRequest,
+	stream userGRPCContract.Service_WatchUserServer) *business.WatchUsersRequest {
+	return &business.WatchUsersRequest{
+		Emails: []string{request.Email},
+		Types:  mapUserChangeTypesFromGRPC(request.Types),
+		Send: func(change models.UserChange) error {
+			return stream.Send(mapUserChangeToGRPC(change))
+		},
+	}
+}
+
+// decodeWatchUsersRequest decodes WatchUsers request message from GRPC object to business object
+// request: Mandatory. The reference to the GRPC request
+// stream: Mandatory. The stream to send the changes made to the users to
+// Returns the decoded request
+func decodeWatchUsersRequest(
+	request *userGRPCContract.WatchUsersRequest,
+	stream userGRPCContract.Service_WatchUsersServer) *business.WatchUsersRequest {
+	return &business.WatchUsersRequest{
+		Emails:      request.Emails,
+		Types:       mapUserChangeTypesFromGRPC(request.Types),
+		ResumeToken: request.ResumeToken,
+		Send: func(change models.UserChange) error {
+			return stream.Send(mapUserChangeToGRPC(change))
+		},
+	}
+}
+
+// encodeWatchUsersResponse encodes WatchUser and WatchUsers responses from business object to the status the stream
+// ends with
+// response: Mandatory. The reference to the business response
+// Returns nil if the watch is cancelled by the client or the status error of the failure
+func encodeWatchUsersResponse(response interface{}) error {
+	castedResponse := response.(*business.WatchUsersResponse)
+	if castedResponse.Err == nil {
+		return nil
+	}
+
+	return newStatusError(castedResponse.Err)
+}
+
+// decodeSendVerificationEmailRequest decodes SendVerificationEmail request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeSendVerificationEmailRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.SendVerificationEmailRequest)
+
+	return &business.SendVerificationEmailRequest{
+		Email: castedRequest.Email,
+	}, nil
+}
+
+// encodeSendVerificationEmailResponse encodes SendVerificationEmail response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeSendVerificationEmailResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.SendVerificationEmailResponse)
+	if castedResponse.Err == nil {
+		grpcResponse := &userGRPCContract.SendVerificationEmailResponse{
+			Error:           userGRPCContract.Error_NO_ERROR,
+			AlreadyVerified: castedResponse.AlreadyVerified,
+		}
+
+		if !castedResponse.AlreadyVerified {
+			grpcResponse.ExpiresAt = timestamppb.New(castedResponse.ExpiresAt)
+		}
+
+		return grpcResponse, nil
+	}
+
+	return &userGRPCContract.SendVerificationEmailResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeVerifyEmailRequest decodes VerifyEmail request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeVerifyEmailRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.VerifyEmailRequest)
+
+	return &business.VerifyEmailRequest{
+		Email: castedRequest.Email,
+		Token: castedRequest.Token,
+	}, nil
+}
+
+// encodeVerifyEmailResponse encodes VerifyEmail response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeVerifyEmailResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.VerifyEmailResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.VerifyEmailResponse{
+			Error:  userGRPCContract.Error_NO_ERROR,
+			User:   mapUserToGRPC(castedResponse.User),
+			Cursor: castedResponse.Cursor,
+		}, nil
+	}
+
+	return &userGRPCContract.VerifyEmailResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeSetPasswordRequest decodes SetPassword request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeSetPasswordRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.SetPasswordRequest)
+
+	return &business.SetPasswordRequest{
+		Email:    castedRequest.Email,
+		Password: castedRequest.Password,
+	}, nil
+}
+
+// encodeSetPasswordResponse encodes SetPassword response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeSetPasswordResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.SetPasswordResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.SetPasswordResponse{
+			Error: userGRPCContract.Error_NO_ERROR,
+		}, nil
+	}
+
+	return &userGRPCContract.SetPasswordResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeChangePasswordRequest decodes ChangePassword request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeChangePasswordRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.ChangePasswordRequest)
+
+	return &business.ChangePasswordRequest{
+		Email:           castedRequest.Email,
+		CurrentPassword: castedRequest.CurrentPassword,
+		NewPassword:     castedRequest.NewPassword,
+	}, nil
+}
+
+// encodeChangePasswordResponse encodes ChangePassword response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeChangePasswordResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.ChangePasswordResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.ChangePasswordResponse{
+			Error: userGRPCContract.Error_NO_ERROR,
+		}, nil
+	}
+
+	return &userGRPCContract.ChangePasswordResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// decodeVerifyPasswordRequest decodes VerifyPassword request message from GRPC object to business object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the GRPC request
+// Returns either the decoded request or error if something goes wrong
+func decodeVerifyPasswordRequest(
+	ctx context.Context,
+	request interface{}) (interface{}, error) {
+	castedRequest := request.(*userGRPCContract.VerifyPasswordRequest)
+
+	return &business.VerifyPasswordRequest{
+		Email:    castedRequest.Email,
+		Password: castedRequest.Password,
+	}, nil
+}
+
+// encodeVerifyPasswordResponse encodes VerifyPassword response from business object to GRPC object
+// context: Optional The reference to the context
+// request: Mandatory. The reference to the business response
+// Returns either the decoded response or error if something goes wrong
+func encodeVerifyPasswordResponse(
+	ctx context.Context,
+	response interface{}) (interface{}, error) {
+	castedResponse := response.(*business.VerifyPasswordResponse)
+	if castedResponse.Err == nil {
+		return &userGRPCContract.VerifyPasswordResponse{
+			Error: userGRPCContract.Error_NO_ERROR,
+			User:  mapUserToGRPC(castedResponse.User),
+		}, nil
+	}
+
+	return &userGRPCContract.VerifyPasswordResponse{
+		Error:        mapError(castedResponse.Err),
+		ErrorMessage: castedResponse.Err.Error(),
+		ErrorDetails: mapErrorDetails(castedResponse.Err),
+	}, nil
+}
+
+// mapUserFromGRPC maps the user from GRPC object to business object. Fields must be read using
+// the generated getters as the user is optional in the GRPC requests. The read only fields are mapped
+// as well, so the user maps back to the same GRPC user, the business service ignores their values.
+// user: Optional. The reference to the GRPC user
+// Returns the mapped user
+func mapUserFromGRPC(user *userGRPCContract.User) models.User {
+	mappedUser := models.User{
+		ID:                  user.GetUserID(),
+		Memberships:         mapTenantMembershipsFromGRPC(user.GetMemberships()),
+		DataResidency:       user.GetDataResidency(),
+		EmailVerified:       user.GetEmailVerified(),
+		FailedLoginAttempts: int(user.GetFailedLoginAttempts()),
+		Labels:              user.GetLabels(),
+		CreatedBy:           user.GetCreatedBy(),
+		UpdatedBy:           user.GetUpdatedBy(),
+	}
+
+	if user.GetLockedAt() != nil {
+		lockedAt := user.GetLockedAt().AsTime()
+		mappedUser.LockedAt = &lockedAt
+	}
+
+	if user.GetCreatedAt() != nil {
+		createdAt := user.GetCreatedAt().AsTime()
+		mappedUser.CreatedAt = &createdAt
+	}
+
+	if user.GetUpdatedAt() != nil {
+		updatedAt := user.GetUpdatedAt().AsTime()
+		mappedUser.UpdatedAt = &updatedAt
+	}
+
+	return mappedUser
+}
+
+// mapUserToGRPC maps the user from business object to GRPC object
+// user: Mandatory. The business user
+// Returns the mapped user
+func mapUserToGRPC(user models.User) *userGRPCContract.User {
+	mappedUser := &userGRPCContract.User{
+		UserID:              user.ID,
+		Memberships:         mapTenantMembershipsToGRPC(user.Memberships),
+		DataResidency:       user.DataResidency,
+		EmailVerified:       user.EmailVerified,
+		Locked:              user.LockedAt != nil,
+		FailedLoginAttempts: int32(user.FailedLoginAttempts),
+		Labels:              user.Labels,
+		CreatedBy:           user.CreatedBy,
+		UpdatedBy:           user.UpdatedBy,
+	}
+
+	if user.LockedAt != nil {
+		mappedUser.LockedAt = timestamppb.New(*user.LockedAt)
+	}
+
+	if user.CreatedAt != nil {
+		mappedUser.CreatedAt = timestamppb.New(*user.CreatedAt)
+	}
+
+	if user.UpdatedAt != nil {
+		mappedUser.UpdatedAt = timestamppb.New(*user.UpdatedAt)
+	}
+
+	return mappedUser
+}
+
+// mapTenantMembershipsFromGRPC maps the tenant memberships from GRPC objects to business objects
+// memberships: Optional. The GRPC tenant memberships
+// Returns the mapped tenant memberships or nil if there is none
+func mapTenantMembershipsFromGRPC(memberships []*userGRPCContract.TenantMembership) []models.TenantMembership {
+	if len(memberships) == 0 {
+		return nil
+	}
+
+	mappedMemberships := make([]models.TenantMembership, 0, len(memberships))
+	for _, membership := range memberships {
+		mappedMemberships = append(mappedMemberships, models.TenantMembership{
+			TenantID: membership.GetTenantID(),
+			Role:     membership.GetRole(),
+			JoinedAt: membership.GetJoinedAt().AsTime(),
+		})
+	}
+
+	return mappedMemberships
+}
+
+// mapTenantMembershipsToGRPC maps the tenant memberships from business objects to GRPC objects
+// memberships: Optional. The business tenant memberships
+// Returns the mapped tenant memberships
+func mapTenantMembershipsToGRPC(memberships []models.TenantMembership) []*userGRPCContract.TenantMembership {
+	mappedMemberships := make([]*userGRPCContract.TenantMembership, 0, len(memberships))
+	for _, membership := range memberships {
+		mappedMemberships = append(mappedMemberships, &userGRPCContract.TenantMembership{
+			TenantID: membership.TenantID,
+			Role:     membership.Role,
+			JoinedAt: timestamppb.New(membership.JoinedAt),
+		})
+	}
+
+	return mappedMemberships
+}
+
+func mapSagaStatus(status models.SagaStatus) userGRPCContract.SagaStatus {
+	switch status {
+	case models.SagaCompleted:
+		return userGRPCContract.SagaStatus_COMPLETED
+	case models.SagaCompensating:
+		return userGRPCContract.SagaStatus_COMPENSATING
+	case models.SagaCompensated:
+		return userGRPCContract.SagaStatus_COMPENSATED
+	case models.SagaFailed:
+		return userGRPCContract.SagaStatus_FAILED
+	default:
+		return userGRPCContract.SagaStatus_RUNNING
+	}
+}
+
+func mapSagaStepStatus(status models.SagaStepStatus) userGRPCContract.SagaStepStatus {
+	switch status {
+	case models.SagaStepCompleted:
+		return userGRPCContract.SagaStepStatus_STEP_COMPLETED
+	case models.SagaStepFailed:
+		return userGRPCContract.SagaStepStatus_STEP_FAILED
+	case models.SagaStepCompensated:
+		return userGRPCContract.SagaStepStatus_STEP_COMPENSATED
+	case models.SagaStepCompensationFailed:
+		return userGRPCContract.SagaStepStatus_STEP_COMPENSATION_FAILED
+	default:
+		return userGRPCContract.SagaStepStatus_STEP_PENDING
+	}
+}
+
+func mapAuditOperationFromGRPC(operation userGRPCContract.AuditOperation) models.AuditOperation {
+	switch operation {
+	case userGRPCContract.AuditOperation_AUDIT_UPDATE:
+		return models.AuditOperationUpdate
+	case userGRPCContract.AuditOperation_AUDIT_DELETE:
+		return models.AuditOperationDelete
+	case userGRPCContract.AuditOperation_AUDIT_RESTORE:
+		return models.AuditOperationRestore
+	case userGRPCContract.AuditOperation_AUDIT_ERASE:
+		return models.AuditOperationErase
+	case userGRPCContract.AuditOperation_AUDIT_CHANGE_EMAIL:
+		return models.AuditOperationChangeEmail
+	default:
+		return models.AuditOperationCreate
+	}
+}
+
+func mapAuditOperationToGRPC(operation models.AuditOperation) userGRPCContract.AuditOperation {
+	switch operation {
+	case models.AuditOperationUpdate:
+		return userGRPCContract.AuditOperation_AUDIT_UPDATE
+	case models.AuditOperationDelete:
+		return userGRPCContract.AuditOperation_AUDIT_DELETE
+	case models.AuditOperationRestore:
+		return userGRPCContract.AuditOperation_AUDIT_RESTORE
+	case models.AuditOperationErase:
+		return userGRPCContract.AuditOperation_AUDIT_ERASE
+	case models.AuditOperationChangeEmail:
+		return userGRPCContract.AuditOperation_AUDIT_CHANGE_EMAIL
+	default:
+		return userGRPCContract.AuditOperation_AUDIT_CREATE
+	}
+}
+
+func mapAPIKeyScopesFromGRPC(scopes []userGRPCContract.APIKeyScope) []models.APIKeyScope {
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	mappedScopes := make([]models.APIKeyScope, 0, len(scopes))
+	for _, scope := range scopes {
+		if scope == userGRPCContract.APIKeyScope_API_KEY_WRITE {
+			mappedScopes = append(mappedScopes, models.APIKeyScopeWrite)
+		} else {
+			mappedScopes = append(mappedScopes, models.APIKeyScopeRead)
+		}
+	}
+
+	return mappedScopes
+}
+
+func mapAPIKeyToGRPC(apiKey models.APIKey) *userGRPCContract.APIKey {
+	scopes := make([]userGRPCContract.APIKeyScope, 0, len(apiKey.Scopes))
+	for _, scope := range apiKey.Scopes {
+		if scope == models.APIKeyScopeWrite {
+			scopes = append(scopes, userGRPCContract.APIKeyScope_API_KEY_WRITE)
+		} else {
+			scopes = append(scopes, userGRPCContract.APIKeyScope_API_KEY_READ)
+		}
+	}
+
+	mappedAPIKey := &userGRPCContract.APIKey{
+		KeyID:     apiKey.KeyID,
+		Name:      apiKey.Name,
+		Scopes:    scopes,
+		CreatedAt: timestamppb.New(apiKey.CreatedAt),
+	}
+
+	if apiKey.ExpiresAt != nil {
+		mappedAPIKey.ExpiresAt = timestamppb.New(*apiKey.ExpiresAt)
+	}
+
+	if apiKey.RevokedAt != nil {
+		mappedAPIKey.RevokedAt = timestamppb.New(*apiKey.RevokedAt)
+	}
+
+	return mappedAPIKey
+}
+
+func mapMFAMethodTypeFromGRPC(methodType userGRPCContract.MFAMethodType) models.MFAMethodType {
+	switch methodType {
+	case userGRPCContract.MFAMethodType_MFA_TOTP:
+		return models.MFAMethodTypeTOTP
+	case userGRPCContract.MFAMethodType_MFA_WEBAUTHN:
+		return models.MFAMethodTypeWebAuthn
+	case userGRPCContract.MFAMethodType_MFA_RECOVERY_CODES:
+		return models.MFAMethodTypeRecoveryCodes
+	default:
+		// The unknown types are passed on as they are, so the validation rejects them
+		return models.MFAMethodType(methodType.String())
+	}
+}
+
+func mapMFAMethodToGRPC(method models.MFAMethod) *userGRPCContract.MFAMethod {
+	methodType := userGRPCContract.MFAMethodType_MFA_TOTP
+	switch method.Type {
+	case models.MFAMethodTypeWebAuthn:
+		methodType = userGRPCContract.MFAMethodType_MFA_WEBAUTHN
+	case models.MFAMethodTypeRecoveryCodes:
+		methodType = userGRPCContract.MFAMethodType_MFA_RECOVERY_CODES
+	}
+
+	return &userGRPCContract.MFAMethod{
+		MethodID:             method.MethodID,
+		Type:                 methodType,
+		Name:                 method.Name,
+		TotpSecretRef:        method.TOTPSecretRef,
+		WebAuthnCredentialID: method.WebAuthnCredentialID,
+		RecoveryCodeHashes:   method.RecoveryCodeHashes,
+		EnrolledAt:           timestamppb.New(method.EnrolledAt),
+	}
+}
+
+func mapConsentToGRPC(acceptedConsent models.Consent) *userGRPCContract.Consent {
+	return &userGRPCContract.Consent{
+		ConsentID:  acceptedConsent.ConsentID,
+		Policy:     acceptedConsent.Policy,
+		Version:    acceptedConsent.Version,
+		AcceptedAt: timestamppb.New(acceptedConsent.AcceptedAt),
+	}
+}
+
+func mapGroupToGRPC(group models.Group) *userGRPCContract.Group {
+	return &userGRPCContract.Group{
+		GroupID:     group.GroupID,
+		Name:        group.Name,
+		Description: group.Description,
+		Members:     group.Members,
+		CreatedAt:   timestamppb.New(group.CreatedAt),
+		UpdatedAt:   timestamppb.New(group.UpdatedAt),
+	}
+}
+
+func mapSessionToGRPC(session models.Session) *userGRPCContract.Session {
+	return &userGRPCContract.Session{
+		SessionID:  session.SessionID,
+		Email:      session.Email,
+		DeviceName: session.DeviceName,
+		UserAgent:  session.UserAgent,
+		IpAddress:  session.IPAddress,
+		CreatedAt:  timestamppb.New(session.CreatedAt),
+		LastSeenAt: timestamppb.New(session.LastSeenAt),
+		ExpiresAt:  timestamppb.New(session.ExpiresAt),
+	}
+}
+
+// webhookEventTypes maps the GRPC webhook event types to the business ones
+var webhookEventTypes = map[userGRPCContract.WebhookEventType]models.WebhookEventType{
+	userGRPCContract.WebhookEventType_WEBHOOK_USER_CREATED:  models.WebhookEventUserCreated,
+	userGRPCContract.WebhookEventType_WEBHOOK_USER_UPDATED:  models.WebhookEventUserUpdated,
+	userGRPCContract.WebhookEventType_WEBHOOK_USER_DELETED:  models.WebhookEventUserDeleted,
+	userGRPCContract.WebhookEventType_WEBHOOK_USER_RESTORED: models.WebhookEventUserRestored,
+}
+
+// mapWebhookEventTypesFromGRPC maps the GRPC webhook event types to the business ones. The unknown event types are
+// kept by their numbers, so the request validation rejects them.
+func mapWebhookEventTypesFromGRPC(eventTypes []userGRPCContract.WebhookEventType) []models.WebhookEventType {
+	if len(eventTypes) == 0 {
+		return nil
+	}
+
+	mappedEventTypes := make([]models.WebhookEventType, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		mappedEventType, ok := webhookEventTypes[eventType]
+		if !ok {
+			mappedEventType = models.WebhookEventType(eventType.String())
+		}
+
+		mappedEventTypes = append(mappedEventTypes, mappedEventType)
+	}
+
+	return mappedEventTypes
+}
+
+func mapWebhookEventTypeToGRPC(eventType models.WebhookEventType) userGRPCContract.WebhookEventType {
+	for mappedEventType, businessEventType := range webhookEventTypes {
+		if businessEventType == eventType {
+			return mappedEventType
+		}
+	}
+
+	return userGRPCContract.WebhookEventType_WEBHOOK_USER_CREATED
+}
+
+func mapWebhookSubscriptionToGRPC(subscription models.WebhookSubscription) *userGRPCContract.WebhookSubscription {
+	eventTypes := make([]userGRPCContract.WebhookEventType, 0, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		eventTypes = append(eventTypes, mapWebhookEventTypeToGRPC(eventType))
+	}
+
+	return &userGRPCContract.WebhookSubscription{
+		SubscriptionID: subscription.SubscriptionID,
+		Url:            subscription.URL,
+		EventTypes:     eventTypes,
+		CreatedAt:      timestamppb.New(subscription.CreatedAt),
+	}
+}
+
+func mapWebhookDeliveryToGRPC(delivery models.WebhookDelivery) *userGRPCContract.WebhookDelivery {
+	status := userGRPCContract.WebhookDeliveryStatus_DELIVERY_PENDING
+	switch delivery.Status {
+	case models.WebhookDeliverySucceeded:
+		status = userGRPCContract.WebhookDeliveryStatus_DELIVERY_SUCCEEDED
+	case models.WebhookDeliveryFailed:
+		status = userGRPCContract.WebhookDeliveryStatus_DELIVERY_FAILED
+	}
+
+	return &userGRPCContract.WebhookDelivery{
+		DeliveryID:     delivery.DeliveryID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventType:      mapWebhookEventTypeToGRPC(delivery.EventType),
+		Status:         status,
+		Attempts:       int32(delivery.Attempts),
+		StatusCode:     int32(delivery.StatusCode),
+		Error:          delivery.Error,
+		CreatedAt:      timestamppb.New(delivery.CreatedAt),
+		UpdatedAt:      timestamppb.New(delivery.UpdatedAt),
+	}
+}
+
+func mapPolicyVersionsToGRPC(policies []models.PolicyVersion) []*userGRPCContract.PolicyVersion {
+	mappedPolicies := make([]*userGRPCContract.PolicyVersion, 0, len(policies))
+	for _, policy := range policies {
+		mappedPolicies = append(mappedPolicies, &userGRPCContract.PolicyVersion{
+			Policy:  policy.Policy,
+			Version: policy.Version,
+		})
+	}
+
+	return mappedPolicies
+}
+
+func mapUserChangeTypesFromGRPC(changeTypes []userGRPCContract.UserChangeType) []models.UserChangeType {
+	if len(changeTypes) == 0 {
+		return nil
+	}
+
+	mappedChangeTypes := make([]models.UserChangeType, 0, len(changeTypes))
+	for _, changeType := range changeTypes {
+		switch changeType {
+		case userGRPCContract.UserChangeType_USER_UPDATED:
+			mappedChangeTypes = append(mappedChangeTypes, models.UserChangeUpdated)
+		case userGRPCContract.UserChangeType_USER_DELETED:
+			mappedChangeTypes = append(mappedChangeTypes, models.UserChangeDeleted)
+		case userGRPCContract.UserChangeType_USER_RESTORED:
+			mappedChangeTypes = append(mappedChangeTypes, models.UserChangeRestored)
+		default:
+			mappedChangeTypes = append(mappedChangeTypes, models.UserChangeCreated)
+		}
+	}
+
+	return mappedChangeTypes
+}
+
+func mapUserChangeTypeToGRPC(changeType models.UserChangeType) userGRPCContract.UserChangeType {
+	switch changeType {
+	case models.UserChangeUpdated:
+		return userGRPCContract.UserChangeType_USER_UPDATED
+	case models.UserChangeDeleted:
+		return userGRPCContract.UserChangeType_USER_DELETED
+	case models.UserChangeRestored:
+		return userGRPCContract.UserChangeType_USER_RESTORED
+	default:
+		return userGRPCContract.UserChangeType_USER_CREATED
+	}
+}
+
+func mapUserChangeToGRPC(change models.UserChange) *userGRPCContract.UserChange {
+	mappedChange := &userGRPCContract.UserChange{
+		Type:        mapUserChangeTypeToGRPC(change.Type),
+		Email:       change.Email,
+		Cursor:      change.Cursor,
+		OccurredAt:  timestamppb.New(change.OccurredAt),
+		SoftDeleted: change.SoftDeleted,
+		ResumeToken: change.ResumeToken,
+	}
+
+	if change.User != nil {
+		mappedChange.User = mapUserToGRPC(*change.User)
+	}
+
+	return mappedChange
+}
+
+func mapAuditRecordToGRPC(record models.AuditRecord) *userGRPCContract.AuditRecord {
+	changes := make([]*userGRPCContract.AuditChange, 0, len(record.Changes))
+	for _, change := range record.Changes {
+		changes = append(changes, &userGRPCContract.AuditChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+
+	mappedRecord := &userGRPCContract.AuditRecord{
+		RecordID:     record.RecordID,
+		Operation:    mapAuditOperationToGRPC(record.Operation),
+		ActorSubject: record.ActorSubject,
+		ActorEmail:   record.ActorEmail,
+		Email:        record.Email,
+		Changes:      changes,
+		CreatedAt:    timestamppb.New(record.CreatedAt),
+	}
+
+	if record.Before != nil {
+		mappedRecord.Before = mapUserToGRPC(*record.Before)
+	}
+
+	if record.After != nil {
+		mappedRecord.After = mapUserToGRPC(*record.After)
+	}
+
+	return mappedRecord
+}
+
+func mapError(err error) userGRPCContract.Error {
+	// The dependency is checked first as the error wraps the unknown error the last attempt to reach it failed with
+	if repository.IsDependencyUnavailableError(err) {
+		return userGRPCContract.Error_DEPENDENCY_UNAVAILABLE
+	}
+
+	// The deadline is checked before the unknown error as well, as the error wraps the error of the database driver
+	if repository.IsDeadlineExceededError(err) {
+		return userGRPCContract.Error_DEADLINE_EXCEEDED
+	}
+
+	if commonErrors.IsUnknownError(err) {
+		return userGRPCContract.Error_UNKNOWN
+	}
+
+	if commonErrors.IsAlreadyExistsError(err) {
+		return userGRPCContract.Error_USER_ALREADY_EXISTS
+	}
+
+	if commonErrors.IsNotFoundError(err) {
+		return userGRPCContract.Error_USER_NOT_FOUND
+	}
+
+	if commonErrors.IsArgumentNilError(err) || commonErrors.IsArgumentError(err) {
+		return userGRPCContract.Error_BAD_REQUEST
+	}
+
+	if repository.IsDataResidencyViolationError(err) {
+		return userGRPCContract.Error_DATA_RESIDENCY_VIOLATION
+	}
+
+	if magiclink.IsMagicLinkTokenExpiredError(err) {
+		return userGRPCContract.Error_MAGIC_LINK_TOKEN_EXPIRED
+	}
+
+	if magiclink.IsMagicLinkTokenError(err) {
+		return userGRPCContract.Error_MAGIC_LINK_TOKEN_INVALID
+	}
+
+	if magiclink.IsMagicLinkAlreadyRedeemedError(err) {
+		return userGRPCContract.Error_MAGIC_LINK_ALREADY_REDEEMED
+	}
+
+	if business.IsEmailVerificationTokenExpiredError(err) {
+		return userGRPCContract.Error_EMAIL_VERIFICATION_TOKEN_EXPIRED
+	}
+
+	if business.IsEmailVerificationTokenError(err) {
+		return userGRPCContract.Error_EMAIL_VERIFICATION_TOKEN_INVALID
+	}
+
+	if business.IsFeatureDisabledError(err) {
+		return userGRPCContract.Error_FEATURE_DISABLED
+	}
+
+	if business.IsPasswordAlreadySetError(err) {
+		return userGRPCContract.Error_PASSWORD_ALREADY_SET
+	}
+
+	if business.IsPasswordMismatchError(err) {
+		return userGRPCContract.Error_PASSWORD_MISMATCH
+	}
+
+	if business.IsUserLockedError(err) {
+		return userGRPCContract.Error_USER_LOCKED
+	}
+
+	if apikey.IsLimitExceededError(err) {
+		return userGRPCContract.Error_API_KEY_LIMIT_EXCEEDED
+	}
+
+	if consent.IsConsentRequiredError(err) {
+		return userGRPCContract.Error_CONSENT_REQUIRED
+	}
+
+	if group.IsGroupNotFoundError(err) {
+		return userGRPCContract.Error_GROUP_NOT_FOUND
+	}
+
+	if group.IsGroupAlreadyExistsError(err) {
+		return userGRPCContract.Error_GROUP_ALREADY_EXISTS
+	}
+
+	if invitation.IsInvitationTokenExpiredError(err) {
+		return userGRPCContract.Error_INVITATION_TOKEN_EXPIRED
+	}
+
+	if invitation.IsInvitationTokenError(err) {
+		return userGRPCContract.Error_INVITATION_TOKEN_INVALID
+	}
+
+	if invitation.IsInvitationAlreadyAcceptedError(err) {
+		return userGRPCContract.Error_INVITATION_ALREADY_ACCEPTED
+	}
+
+	if session.IsSessionNotFoundError(err) {
+		return userGRPCContract.Error_SESSION_NOT_FOUND
+	}
+
+	if session.IsSessionRevokedError(err) {
+		return userGRPCContract.Error_SESSION_REVOKED
+	}
+
+	if webhook.IsSubscriptionNotFoundError(err) {
+		return userGRPCContract.Error_WEBHOOK_SUBSCRIPTION_NOT_FOUND
+	}
+
+	return userGRPCContract.Error_UNKNOWN
+}
+
+func mapUserWithCursorToGRPC(user models.UserWithCursor) *userGRPCContract.UserWithCursor {
+	userWithCursor := &userGRPCContract.UserWithCursor{
+		Email:  user.Email,
+		User:   mapUserToGRPC(user.User),
+		Cursor: user.Cursor,
+	}
+
+	if user.DeletedAt != nil {
+		userWithCursor.DeletedAt = timestamppb.New(*user.DeletedAt)
+	}
+
+	if !user.CreatedAt.IsZero() {
+		userWithCursor.CreatedAt = timestamppb.New(user.CreatedAt)
+	}
+
+	return userWithCursor
+}
+
+func mapErrorToCode(err error) codes.Code {
+	// The dependency is checked first as the error wraps the unknown error the last attempt to reach it failed with
+	if repository.IsDependencyUnavailableError(err) {
+		return codes.Unavailable
+	}
+
+	// The deadline is checked before the unknown error as well, as the error wraps the error of the database driver
+	if repository.IsDeadlineExceededError(err) {
+		return codes.DeadlineExceeded
+	}
+
+	if commonErrors.IsUnknownError(err) {
+		return codes.Unknown
+	}
+
+	if commonErrors.IsAlreadyExistsError(err) {
+		return codes.AlreadyExists
+	}
+
+	if commonErrors.IsNotFoundError(err) {
+		return codes.NotFound
+	}
+
+	if commonErrors.IsArgumentNilError(err) || commonErrors.IsArgumentError(err) {
+		return codes.InvalidArgument
+	}
+
+	if repository.IsDataResidencyViolationError(err) {
+		return codes.FailedPrecondition
+	}
+
+	if magiclink.IsMagicLinkTokenExpiredError(err) {
+		return codes.FailedPrecondition
+	}
+
+	if magiclink.IsMagicLinkTokenError(err) {
+		return codes.InvalidArgument
+	}
+
+	if magiclink.IsMagicLinkAlreadyRedeemedError(err) {
+		return codes.FailedPrecondition
+	}
+
+	if business.IsEmailVerificationTokenExpiredError(err) {
+		return codes.FailedPrecondition
+	}
+
+	if business.IsEmailVerificationTokenError(err) {
+		return codes.InvalidArgument
+	}
+
+	if business.IsFeatureDisabledError(err) {
+		return codes.Unimplemented
+	}
+
+	if business.IsPasswordAlreadySetError(err) {
+		return codes.AlreadyExists
+	}
+
+	if business.IsPasswordMismatchError(err) {
+		return codes.Unauthenticated
+	}
+
+	if business.IsUserLockedError(err) {
+		return codes.FailedPrecondition
+	}
+
+	if business.IsWatchLaggedError(err) {
+		return codes.ResourceExhausted
+	}
+
+	if apikey.IsLimitExceededError(err) {
+		return codes.ResourceExhausted
+	}
+
+	if consent.IsConsentRequiredError(err) {
+		return codes.FailedPrecondition
+	}
+
+	if group.IsGroupNotFoundError(err) {
+		return codes.NotFound
+	}
+
+	if group.IsGroupAlreadyExistsError(err) {
+		return codes.AlreadyExists
+	}
+
+	if invitation.IsInvitationTokenExpiredError(err) {
+		return codes.FailedPrecondition
+	}
+
+	if invitation.IsInvitationTokenError(err) {
+		return codes.InvalidArgument
+	}
+
+	if invitation.IsInvitationAlreadyAcceptedError(err) {
+		return codes.FailedPrecondition
+	}
+
+	if session.IsSessionNotFoundError(err) {
+		return codes.NotFound
+	}
+
+	if session.IsSessionRevokedError(err) {
+		return codes.FailedPrecondition
+	}
+
+	if webhook.IsSubscriptionNotFoundError(err) {
+		return codes.NotFound
+	}
+
+	return codes.Unknown
 }
@@ -0,0 +1,225 @@
+// Package grpc implements functions to expose user service endpoint using GRPC protocol.
+package grpc
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/services/apikey"
+	"github.com/decentralized-cloud/user/services/business"
+	"github.com/decentralized-cloud/user/services/consent"
+	"github.com/decentralized-cloud/user/services/repository"
+	validation "github.com/go-ozzo/ozzo-validation"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	rpcStatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	// errorDomain is the domain of the google.rpc.ErrorInfo details, their reason is the name of the Error enum value
+	errorDomain = "user.decentralized-cloud"
+
+	// errorMessageLocale is the locale of the google.rpc.LocalizedMessage details
+	errorMessageLocale = "en-US"
+
+	// dependencyUnavailableRetryDelay is how long the clients are asked to wait before retrying the operations that
+	// failed as a dependency of the service is not reachable
+	dependencyUnavailableRetryDelay = 5 * time.Second
+)
+
+// localizedErrorMessages are the messages of the errors that are safe to show to the end users, unlike the error
+// message of the response that can contain the details only the operators need
+var localizedErrorMessages = map[userGRPCContract.Error]string{
+	userGRPCContract.Error_UNKNOWN:                          "Something went wrong. Please try again later.",
+	userGRPCContract.Error_USER_ALREADY_EXISTS:              "The user already exists.",
+	userGRPCContract.Error_USER_NOT_FOUND:                   "The user does not exist.",
+	userGRPCContract.Error_BAD_REQUEST:                      "The request is invalid. Please correct the invalid fields and try again.",
+	userGRPCContract.Error_SAGA_NOT_FOUND:                   "The operation does not exist.",
+	userGRPCContract.Error_DATA_RESIDENCY_VIOLATION:         "The user can not be moved to another region.",
+	userGRPCContract.Error_MAGIC_LINK_TOKEN_INVALID:         "The sign in link is invalid.",
+	userGRPCContract.Error_MAGIC_LINK_TOKEN_EXPIRED:         "The sign in link has expired. Please request a new one.",
+	userGRPCContract.Error_MAGIC_LINK_ALREADY_REDEEMED:      "The sign in link is already used. Please request a new one.",
+	userGRPCContract.Error_EMAIL_VERIFICATION_TOKEN_INVALID: "The email verification link is invalid.",
+	userGRPCContract.Error_EMAIL_VERIFICATION_TOKEN_EXPIRED: "The email verification link has expired. Please request a new one.",
+	userGRPCContract.Error_FEATURE_DISABLED:                 "This feature is not available.",
+	userGRPCContract.Error_PASSWORD_ALREADY_SET:             "The password is already set. Please change the password instead.",
+	userGRPCContract.Error_PASSWORD_MISMATCH:                "The email address or the password is incorrect.",
+	userGRPCContract.Error_API_KEY_LIMIT_EXCEEDED:           "The maximum number of API keys is reached. Please revoke an API key first.",
+	userGRPCContract.Error_DEPENDENCY_UNAVAILABLE:           "The service is temporarily unavailable. Please try again later.",
+	userGRPCContract.Error_USER_LOCKED:                      "The account is locked after too many failed sign in attempts. Please contact an administrator.",
+	userGRPCContract.Error_DEADLINE_EXCEEDED:                "The operation took too long. Please try again later.",
+	userGRPCContract.Error_CONSENT_REQUIRED:                 "Please accept the latest terms before continuing.",
+	userGRPCContract.Error_GROUP_NOT_FOUND:                  "The group does not exist.",
+	userGRPCContract.Error_GROUP_ALREADY_EXISTS:             "A group with this name already exists.",
+	userGRPCContract.Error_INVITATION_TOKEN_INVALID:         "The invitation link is invalid.",
+	userGRPCContract.Error_INVITATION_TOKEN_EXPIRED:         "The invitation has expired. Please ask an administrator to invite you again.",
+	userGRPCContract.Error_INVITATION_ALREADY_ACCEPTED:      "The invitation is already accepted.",
+	userGRPCContract.Error_SESSION_NOT_FOUND:                "The session does not exist or has expired.",
+	userGRPCContract.Error_SESSION_REVOKED:                  "The session is revoked. Please sign in again.",
+	userGRPCContract.Error_WEBHOOK_SUBSCRIPTION_NOT_FOUND:   "The webhook subscription does not exist.",
+}
+
+// mapErrorDetails maps the error to the google.rpc error details the clients can react to without parsing the error
+// message. Every error carries the ErrorInfo with the name of the Error enum value as the reason and the
+// LocalizedMessage that is safe to show to the end users. The invalid requests carry the BadRequest with the field
+// violations and the ValidationErrors with the code of every failure, the exceeded limits the QuotaFailure, the
+// missing consents the PreconditionFailure with the policies to accept and the errors worth retrying the RetryInfo.
+// err: Mandatory. The error the operation failed with
+// Returns the error details packed in Any
+func mapErrorDetails(err error) []*anypb.Any {
+	details := []*anypb.Any{}
+
+	for _, detail := range createErrorDetails(err) {
+		if packedDetail, packErr := anypb.New(detail); packErr == nil {
+			details = append(details, packedDetail)
+		}
+	}
+
+	return details
+}
+
+// newStatusError creates the status error the streams end with, carrying the same error details the responses of
+// the unary operations carry
+// err: Mandatory. The error the stream failed with
+// Returns the status error
+func newStatusError(err error) error {
+	return status.FromProto(&rpcStatus.Status{
+		Code:    int32(mapErrorToCode(err)),
+		Message: err.Error(),
+		Details: mapErrorDetails(err),
+	}).Err()
+}
+
+func createErrorDetails(err error) []proto.Message {
+	grpcError := mapError(err)
+	details := []proto.Message{
+		&errdetails.ErrorInfo{
+			Reason: grpcError.String(),
+			Domain: errorDomain,
+		},
+		&errdetails.LocalizedMessage{
+			Locale:  errorMessageLocale,
+			Message: localizedErrorMessages[grpcError],
+		},
+	}
+
+	if fieldViolations := mapFieldViolations(err); len(fieldViolations) > 0 {
+		details = append(details, &errdetails.BadRequest{FieldViolations: fieldViolations})
+	}
+
+	var validationError business.ValidationError
+	if errors.As(err, &validationError) {
+		validationErrors := make([]*userGRPCContract.ValidationError, 0, len(validationError.Failures))
+		for _, failure := range validationError.Failures {
+			validationErrors = append(validationErrors, &userGRPCContract.ValidationError{
+				Field:   failure.Field,
+				Code:    failure.Code,
+				Message: failure.Message,
+			})
+		}
+
+		details = append(details, &userGRPCContract.ValidationErrors{Errors: validationErrors})
+	}
+
+	var limitExceededError apikey.LimitExceededError
+	if errors.As(err, &limitExceededError) {
+		details = append(details, &errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{
+				Subject:     "apiKeys",
+				Description: limitExceededError.Error(),
+			}},
+		})
+	}
+
+	var consentRequiredError consent.ConsentRequiredError
+	if errors.As(err, &consentRequiredError) {
+		violations := make([]*errdetails.PreconditionFailure_Violation, 0, len(consentRequiredError.Policies))
+		for _, policy := range consentRequiredError.Policies {
+			violations = append(violations, &errdetails.PreconditionFailure_Violation{
+				Type:        "CONSENT",
+				Subject:     policy.Policy,
+				Description: "version " + policy.Version + " of the policy must be accepted",
+			})
+		}
+
+		details = append(details, &errdetails.PreconditionFailure{Violations: violations})
+	}
+
+	if repository.IsDependencyUnavailableError(err) {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(dependencyUnavailableRetryDelay)})
+	}
+
+	return details
+}
+
+// mapFieldViolations maps the invalid arguments to the field violations. The validation errors of the request are
+// reported per field, the nested fields and the items of the lists are reported by their path, e.g. user.emails.0
+// err: Mandatory. The error the operation failed with
+// Returns the field violations, empty if the error is not caused by invalid arguments
+func mapFieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	var validationErrors validation.Errors
+	if errors.As(err, &validationErrors) {
+		return appendFieldViolations(nil, "", validationErrors)
+	}
+
+	var argumentNilError commonErrors.ArgumentNilError
+	if errors.As(err, &argumentNilError) {
+		return []*errdetails.BadRequest_FieldViolation{{
+			Field:       argumentNilError.ArgumentName,
+			Description: argumentNilError.Error(),
+		}}
+	}
+
+	var argumentError commonErrors.ArgumentError
+	if errors.As(err, &argumentError) {
+		description := argumentError.Message
+		if description == "" {
+			description = argumentError.Error()
+		}
+
+		return []*errdetails.BadRequest_FieldViolation{{
+			Field:       argumentError.ArgumentName,
+			Description: description,
+		}}
+	}
+
+	return nil
+}
+
+func appendFieldViolations(
+	fieldViolations []*errdetails.BadRequest_FieldViolation,
+	path string,
+	validationErrors validation.Errors) []*errdetails.BadRequest_FieldViolation {
+	// The fields are sorted, so the same invalid request is always reported the same way
+	fields := make([]string, 0, len(validationErrors))
+	for field := range validationErrors {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		fieldPath := strings.TrimPrefix(path+"."+field, ".")
+
+		var nestedErrors validation.Errors
+		if errors.As(validationErrors[field], &nestedErrors) {
+			fieldViolations = appendFieldViolations(fieldViolations, fieldPath, nestedErrors)
+
+			continue
+		}
+
+		fieldViolations = append(fieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fieldPath,
+			Description: validationErrors[field].Error(),
+		})
+	}
+
+	return fieldViolations
+}
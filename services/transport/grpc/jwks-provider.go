@@ -0,0 +1,43 @@
+// Package grpc implements functions to expose user service endpoint using GRPC protocol.
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/decentralized-cloud/user/services/transport"
+	"github.com/lestrrat-go/jwx/jwt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// bearerTokenPrefix is the prefix of the authorization metadata carrying the access token
+const bearerTokenPrefix = "Bearer "
+
+// parseAndVerifyToken reads the bearer token from the authorization metadata and verifies it with the cached key set
+// ctx: Mandatory. The reference to the context
+// jwksProvider: Mandatory. The provider of the key set the token is verified with
+// Returns either the verified token or Unauthenticated error if the token can not be verified
+func parseAndVerifyToken(ctx context.Context, jwksProvider *transport.JWKSProvider) (jwt.Token, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "metadata is not provided")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 || len(values[0]) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "authorization token is not provided")
+	}
+
+	if !strings.HasPrefix(values[0], bearerTokenPrefix) {
+		return nil, status.Errorf(codes.Unauthenticated, "authorization token format is not Bearer")
+	}
+
+	token, err := jwksProvider.ParseAndVerifyToken(values[0][len(bearerTokenPrefix):])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return token, nil
+}
@@ -0,0 +1,123 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gokitgrpc "github.com/go-kit/kit/transport/grpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var unknownFieldsCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "user_grpc_requests_with_unknown_fields_total",
+		Help: "The number of the gRPC requests carrying fields this service does not know grouped by the operation and the action taken (rejected or dropped)",
+	},
+	[]string{"operation", "action"})
+
+// rejectUnknownFields wraps the decoder so the requests carrying the fields this service does not know are counted and,
+// if the strict decoding is enabled, rejected with InvalidArgument instead of the fields being silently dropped
+// operation: Mandatory. The name of the operation the decoder decodes the requests of
+// decoder: Mandatory. The decoder that decodes the request once it is checked
+// Returns the wrapped decoder
+func (service *transportService) rejectUnknownFields(
+	operation string,
+	decoder gokitgrpc.DecodeRequestFunc) gokitgrpc.DecodeRequestFunc {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		unknownFields := findUnknownFields(request.(proto.Message).ProtoReflect(), "")
+		if len(unknownFields) == 0 {
+			return decoder(ctx, request)
+		}
+
+		if !service.strictDecodingEnabled {
+			unknownFieldsCounter.WithLabelValues(operation, "dropped").Inc()
+
+			return decoder(ctx, request)
+		}
+
+		unknownFieldsCounter.WithLabelValues(operation, "rejected").Inc()
+
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"%s request carries fields this service does not support, the client may be built against a newer contract: %s",
+			operation,
+			strings.Join(unknownFields, ", "))
+	}
+}
+
+// findUnknownFields returns the paths of the unknown fields carried by the message and its nested messages. As the
+// names of the unknown fields are not known, they are named after their field numbers, e.g. user.#17
+func findUnknownFields(message protoreflect.Message, path string) []string {
+	unknownFields := []string{}
+	found := map[protowire.Number]bool{}
+
+	for unknown := message.GetUnknown(); len(unknown) > 0; {
+		number, wireType, tagLength := protowire.ConsumeTag(unknown)
+		if tagLength < 0 {
+			break
+		}
+
+		valueLength := protowire.ConsumeFieldValue(number, wireType, unknown[tagLength:])
+		if valueLength < 0 {
+			break
+		}
+
+		// The repeated fields are reported once
+		if !found[number] {
+			found[number] = true
+			unknownFields = append(unknownFields, joinFieldPath(path, "#"+strconv.Itoa(int(number))))
+		}
+
+		unknown = unknown[tagLength+valueLength:]
+	}
+
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		fieldPath := joinFieldPath(path, string(field.Name()))
+
+		switch {
+		case field.IsList():
+			if field.Message() == nil {
+				break
+			}
+
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				unknownFields = append(unknownFields, findUnknownFields(list.Get(i).Message(), fmt.Sprintf("%s[%d]", fieldPath, i))...)
+			}
+
+		case field.IsMap():
+			if field.MapValue().Message() == nil {
+				break
+			}
+
+			value.Map().Range(func(key protoreflect.MapKey, mapValue protoreflect.Value) bool {
+				unknownFields = append(unknownFields, findUnknownFields(mapValue.Message(), fmt.Sprintf("%s[%v]", fieldPath, key.Interface()))...)
+
+				return true
+			})
+
+		case field.Message() != nil:
+			unknownFields = append(unknownFields, findUnknownFields(value.Message(), fieldPath)...)
+		}
+
+		return true
+	})
+
+	return unknownFields
+}
+
+func joinFieldPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
@@ -5,16 +5,30 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strconv"
+	"sync"
+	"time"
 
 	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/services/apikey"
 	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/correlation"
+	"github.com/decentralized-cloud/user/services/deprecation"
 	"github.com/decentralized-cloud/user/services/endpoint"
+	"github.com/decentralized-cloud/user/services/group"
+	"github.com/decentralized-cloud/user/services/payloadlogging"
+	"github.com/decentralized-cloud/user/services/redaction"
+	"github.com/decentralized-cloud/user/services/slo"
 	"github.com/decentralized-cloud/user/services/transport"
+	gokitendpoint "github.com/go-kit/kit/endpoint"
 	gokitgrpc "github.com/go-kit/kit/transport/grpc"
 	"github.com/micro-business/go-core/gokit/middleware"
 	commonErrors "github.com/micro-business/go-core/system/errors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 type transportService struct {
@@ -22,11 +36,99 @@ type transportService struct {
 	configurationService      configuration.ConfigurationContract
 	endpointCreatorService    endpoint.EndpointCreatorContract
 	middlewareProviderService middleware.MiddlewareProviderContract
-	jwksURL                   string
+	sloService                slo.SloContract
+	deprecationService        deprecation.DeprecationContract
+	redactionService          redaction.RedactionContract
+	payloadLoggingService     payloadlogging.PayloadLoggingContract
+	correlationService        correlation.CorrelationContract
+	apiKeyService             apikey.APIKeyContract
+	groupService              group.GroupContract
+	jwksProvider              *transport.JWKSProvider
+	adminEmails               map[string]bool
+	adminGroups               map[string]bool
+	serviceIdentities         map[string]map[string]bool
+	tokenPolicy               *transport.TokenPolicy
+	tenancyPolicy             *transport.TenancyPolicy
+	endpointPolicy            *transport.EndpointPolicy
+	logAuthorizationDecisions bool
+	shutdownTimeout           time.Duration
+	shutdownDrainDelay        time.Duration
+	reflectionEnabled         bool
+	strictDecodingEnabled     bool
+	maxReceiveMessageSize     int
+	setupHandlersOnce         sync.Once
+	serverLock                sync.Mutex
+	server                    *grpc.Server
+	healthServer              *health.Server
 	createUserHandler         gokitgrpc.Handler
 	readUserHandler           gokitgrpc.Handler
+	readUserByEmailHandler    gokitgrpc.Handler
+	readUsersHandler          gokitgrpc.Handler
 	updateUserHandler         gokitgrpc.Handler
+	changeEmailHandler        gokitgrpc.Handler
+	requestEmailChangeHandler gokitgrpc.Handler
+	confirmEmailChangeHandler gokitgrpc.Handler
 	deleteUserHandler         gokitgrpc.Handler
+	restoreUserHandler        gokitgrpc.Handler
+	getSagaStatusHandler      gokitgrpc.Handler
+	listAuditRecordsHandler   gokitgrpc.Handler
+	searchHandler             gokitgrpc.Handler
+	streamSearchUsersEndpoint gokitendpoint.Endpoint
+
+	getEffectiveConfigurationHandler gokitgrpc.Handler
+	getEnabledFeaturesHandler        gokitgrpc.Handler
+	previewBulkUpdateUsersHandler    gokitgrpc.Handler
+	bulkUpdateUsersHandler           gokitgrpc.Handler
+	purgeByLabelHandler              gokitgrpc.Handler
+	getOutboxLagHandler              gokitgrpc.Handler
+	listPendingEventsHandler         gokitgrpc.Handler
+	forceFlushHandler                gokitgrpc.Handler
+	getUserPreferencesHandler        gokitgrpc.Handler
+	updateUserPreferencesHandler     gokitgrpc.Handler
+	getUserAvatarHandler             gokitgrpc.Handler
+	setUserAvatarHandler             gokitgrpc.Handler
+	exportPersonalDataHandler        gokitgrpc.Handler
+	eraseUserHandler                 gokitgrpc.Handler
+	addUserToTenantHandler           gokitgrpc.Handler
+	removeUserFromTenantHandler      gokitgrpc.Handler
+	listUserTenantsHandler           gokitgrpc.Handler
+	getReplicationStatusHandler      gokitgrpc.Handler
+	exportUsersEndpoint              gokitendpoint.Endpoint
+	issueMagicLinkHandler            gokitgrpc.Handler
+	redeemMagicLinkHandler           gokitgrpc.Handler
+	watchUserEndpoint                gokitendpoint.Endpoint
+	watchUsersEndpoint               gokitendpoint.Endpoint
+	sendVerificationEmailHandler     gokitgrpc.Handler
+	verifyEmailHandler               gokitgrpc.Handler
+	setPasswordHandler               gokitgrpc.Handler
+	changePasswordHandler            gokitgrpc.Handler
+	verifyPasswordHandler            gokitgrpc.Handler
+	createAPIKeyHandler              gokitgrpc.Handler
+	listAPIKeysHandler               gokitgrpc.Handler
+	revokeAPIKeyHandler              gokitgrpc.Handler
+	recordLoginAttemptHandler        gokitgrpc.Handler
+	unlockUserHandler                gokitgrpc.Handler
+	enrollMFAHandler                 gokitgrpc.Handler
+	listMFAMethodsHandler            gokitgrpc.Handler
+	removeMFAMethodHandler           gokitgrpc.Handler
+	recordConsentHandler             gokitgrpc.Handler
+	listConsentsHandler              gokitgrpc.Handler
+	createGroupHandler               gokitgrpc.Handler
+	readGroupHandler                 gokitgrpc.Handler
+	updateGroupHandler               gokitgrpc.Handler
+	deleteGroupHandler               gokitgrpc.Handler
+	listGroupsHandler                gokitgrpc.Handler
+	addUserToGroupHandler            gokitgrpc.Handler
+	removeUserFromGroupHandler       gokitgrpc.Handler
+	inviteUserHandler                gokitgrpc.Handler
+	acceptInvitationHandler          gokitgrpc.Handler
+	registerSessionHandler           gokitgrpc.Handler
+	listSessionsHandler              gokitgrpc.Handler
+	revokeSessionHandler             gokitgrpc.Handler
+	createWebhookSubscriptionHandler gokitgrpc.Handler
+	listWebhookSubscriptionsHandler  gokitgrpc.Handler
+	deleteWebhookSubscriptionHandler gokitgrpc.Handler
+	listWebhookDeliveriesHandler     gokitgrpc.Handler
 }
 
 var Live bool
@@ -42,12 +144,26 @@ func init() {
 // configurationService: Mandatory. Reference to the service that provides required configurations
 // endpointCreatorService: Mandatory. Reference to the service that creates go-kit compatible endpoints
 // middlewareProviderService: Mandatory. Reference to the service that provides different go-kit middlewares
+// sloService: Mandatory. Reference to the service that measures the service level indicators
+// deprecationService: Mandatory. Reference to the service that warns the clients about the deprecated operations and fields
+// correlationService: Mandatory. Reference to the service that assigns the correlation id to the received requests
+// redactionService: Mandatory. Reference to the service that removes the sensitive user fields from the responses
+// payloadLoggingService: Mandatory. Reference to the service that logs the request and response payloads
+// apiKeyService: Mandatory. Reference to the service that resolves the API keys the service accounts call the service with
+// groupService: Mandatory. Reference to the service that lists the groups the admin role is granted through
 // Returns the new service or error if something goes wrong
 func NewTransportService(
 	logger *zap.Logger,
 	configurationService configuration.ConfigurationContract,
 	endpointCreatorService endpoint.EndpointCreatorContract,
-	middlewareProviderService middleware.MiddlewareProviderContract) (transport.TransportContract, error) {
+	middlewareProviderService middleware.MiddlewareProviderContract,
+	sloService slo.SloContract,
+	deprecationService deprecation.DeprecationContract,
+	correlationService correlation.CorrelationContract,
+	redactionService redaction.RedactionContract,
+	payloadLoggingService payloadlogging.PayloadLoggingContract,
+	apiKeyService apikey.APIKeyContract,
+	groupService group.GroupContract) (transport.ConnectContract, error) {
 	if logger == nil {
 		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
 	}
@@ -64,24 +180,183 @@ func NewTransportService(
 		return nil, commonErrors.NewArgumentNilError("middlewareProviderService", "middlewareProviderService is required")
 	}
 
+	if sloService == nil {
+		return nil, commonErrors.NewArgumentNilError("sloService", "sloService is required")
+	}
+
+	if deprecationService == nil {
+		return nil, commonErrors.NewArgumentNilError("deprecationService", "deprecationService is required")
+	}
+
+	if correlationService == nil {
+		return nil, commonErrors.NewArgumentNilError("correlationService", "correlationService is required")
+	}
+
+	if redactionService == nil {
+		return nil, commonErrors.NewArgumentNilError("redactionService", "redactionService is required")
+	}
+
+	if payloadLoggingService == nil {
+		return nil, commonErrors.NewArgumentNilError("payloadLoggingService", "payloadLoggingService is required")
+	}
+
+	if apiKeyService == nil {
+		return nil, commonErrors.NewArgumentNilError("apiKeyService", "apiKeyService is required")
+	}
+
+	if groupService == nil {
+		return nil, commonErrors.NewArgumentNilError("groupService", "groupService is required")
+	}
+
 	jwksURL, err := configurationService.GetJwksURL()
 	if err != nil {
 		return nil, err
 	}
 
+	jwksRefreshInterval, err := configurationService.GetJwksRefreshInterval()
+	if err != nil {
+		return nil, err
+	}
+
+	adminEmailList, err := configurationService.GetAdminEmails()
+	if err != nil {
+		return nil, err
+	}
+
+	adminGroupList, err := configurationService.GetAdminGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	acceptedIssuers, err := configurationService.GetJwtAcceptedIssuers()
+	if err != nil {
+		return nil, err
+	}
+
+	acceptedAudiences, err := configurationService.GetJwtAcceptedAudiences()
+	if err != nil {
+		return nil, err
+	}
+
+	claimMapping, err := configurationService.GetJwtClaimMapping()
+	if err != nil {
+		return nil, err
+	}
+
+	serviceIdentityAllowlist, err := configurationService.GetServiceIdentityAllowlist()
+	if err != nil {
+		return nil, err
+	}
+
+	shutdownTimeout, err := configurationService.GetGrpcShutdownTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	shutdownDrainDelay, err := configurationService.GetGrpcShutdownDrainDelay()
+	if err != nil {
+		return nil, err
+	}
+
+	tenancyMode, err := configurationService.GetTenancyMode()
+	if err != nil {
+		return nil, err
+	}
+
+	crossTenantReadScope, err := configurationService.GetTenancyCrossTenantReadScope()
+	if err != nil {
+		return nil, err
+	}
+
+	logAuthorizationDecisions, err := configurationService.GetAuthorizationDecisionLoggingEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	authorizationPolicyFile, err := configurationService.GetAuthorizationPolicyFile()
+	if err != nil {
+		return nil, err
+	}
+
+	endpointRules, err := transport.ReadEndpointPolicyFile(authorizationPolicyFile)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read the authorization policy", err)
+	}
+
+	endpointPolicy, err := transport.NewEndpointPolicy(defaultEndpointRules, endpointRules)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("the authorization policy is invalid", err)
+	}
+
+	reflectionEnabled, err := configurationService.GetGrpcReflectionEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	strictDecodingEnabled, err := configurationService.GetGrpcStrictDecodingEnabled()
+	if err != nil {
+		return nil, err
+	}
+
+	maxReceiveMessageSize, err := configurationService.GetGrpcMaxReceiveMessageSize()
+	if err != nil {
+		return nil, err
+	}
+
+	adminEmails := map[string]bool{}
+	for _, email := range adminEmailList {
+		adminEmails[email] = true
+	}
+
+	adminGroups := map[string]bool{}
+	for _, name := range adminGroupList {
+		adminGroups[name] = true
+	}
+
+	serviceIdentities := map[string]map[string]bool{}
+	for endpointName, identities := range serviceIdentityAllowlist {
+		if _, ok := defaultEndpointRules[endpointName]; !ok {
+			return nil, commonErrors.NewUnknownError(fmt.Sprintf("SERVICE_IDENTITY_ALLOWLIST contains unknown endpoint: %s", endpointName))
+		}
+
+		serviceIdentities[endpointName] = map[string]bool{}
+		for _, identity := range identities {
+			serviceIdentities[endpointName][identity] = true
+		}
+	}
+
 	return &transportService{
 		logger:                    logger,
 		configurationService:      configurationService,
 		endpointCreatorService:    endpointCreatorService,
 		middlewareProviderService: middlewareProviderService,
-		jwksURL:                   jwksURL,
+		sloService:                sloService,
+		deprecationService:        deprecationService,
+		correlationService:        correlationService,
+		redactionService:          redactionService,
+		payloadLoggingService:     payloadLoggingService,
+		apiKeyService:             apiKeyService,
+		groupService:              groupService,
+		jwksProvider:              transport.NewJWKSProvider(jwksURL, jwksRefreshInterval),
+		adminEmails:               adminEmails,
+		adminGroups:               adminGroups,
+		serviceIdentities:         serviceIdentities,
+		tokenPolicy:               transport.NewTokenPolicy(acceptedIssuers, acceptedAudiences, claimMapping),
+		tenancyPolicy:             transport.NewTenancyPolicy(tenancyMode, claimMapping, crossTenantReadScope),
+		endpointPolicy:            endpointPolicy,
+		logAuthorizationDecisions: logAuthorizationDecisions,
+		shutdownTimeout:           shutdownTimeout,
+		shutdownDrainDelay:        shutdownDrainDelay,
+		reflectionEnabled:         reflectionEnabled,
+		strictDecodingEnabled:     strictDecodingEnabled,
+		maxReceiveMessageSize:     maxReceiveMessageSize,
 	}, nil
 }
 
 // Start starts the GRPC transport service
 // Returns error if something goes wrong
 func (service *transportService) Start() error {
-	service.setupHandlers()
+	service.setupHandlersOnce.Do(service.setupHandlers)
 
 	host, err := service.configurationService.GetGrpcHost()
 	if err != nil {
@@ -93,15 +368,42 @@ func (service *transportService) Start() error {
 		return err
 	}
 
-	address := fmt.Sprintf("%s:%d", host, port)
+	address := net.JoinHostPort(host, strconv.Itoa(port))
 	listener, err := net.Listen("tcp", address)
 	if err != nil {
 		return err
 	}
 
-	gRPCServer := grpc.NewServer()
+	// The correlation id is assigned first so everything the call does after can be correlated with it, including the
+	// logged payloads. The payloads are logged as the caller sends and receives them. The responses are redacted for the
+	// caller the authorization middleware records, so every operation returning users is covered.
+	gRPCServer := grpc.NewServer(
+		grpc.MaxRecvMsgSize(service.maxReceiveMessageSize),
+		grpc.ChainUnaryInterceptor(service.createUnaryServerInterceptors()...),
+		grpc.ChainStreamInterceptor(
+			service.correlationService.CreateStreamServerInterceptor(),
+			service.payloadLoggingService.CreateStreamServerInterceptor(),
+			service.redactionService.CreateStreamServerInterceptor()))
 	userGRPCContract.RegisterServiceServer(gRPCServer, service)
-	service.logger.Info("gRPC service started", zap.String("address", address))
+
+	// The health service is served without authentication so the Kubernetes gRPC probes can call it
+	healthServer := health.NewServer()
+	for serviceName := range gRPCServer.GetServiceInfo() {
+		healthServer.SetServingStatus(serviceName, healthpb.HealthCheckResponse_SERVING)
+	}
+
+	healthpb.RegisterHealthServer(gRPCServer, healthServer)
+
+	if service.reflectionEnabled {
+		reflection.Register(gRPCServer)
+	}
+
+	service.serverLock.Lock()
+	service.server = gRPCServer
+	service.healthServer = healthServer
+	service.serverLock.Unlock()
+
+	service.logger.Info("gRPC service started", zap.String("address", address), zap.Bool("reflection", service.reflectionEnabled))
 
 	Live = true
 	Ready = true
@@ -114,15 +416,62 @@ func (service *transportService) Start() error {
 	return err
 }
 
-// Stop stops the GRPC transport service
+// Stop stops the GRPC transport service. The service reports it is not ready and keeps accepting the calls for the
+// drain delay, so the load balancers stop routing the calls to it first, then it stops accepting the calls and waits
+// for the in-flight ones to finish until the shutdown timeout passes.
 // Returns error if something goes wrong
 func (service *transportService) Stop() error {
+	service.serverLock.Lock()
+	gRPCServer := service.server
+	healthServer := service.healthServer
+	service.serverLock.Unlock()
+
+	if gRPCServer == nil {
+		return nil
+	}
+
+	// Stop advertising the service before the listener closes so no new traffic is routed to it
+	Ready = false
+	Live = false
+	healthServer.Shutdown()
+
+	if service.shutdownDrainDelay > 0 {
+		service.logger.Info("gRPC service is draining", zap.Duration("delay", service.shutdownDrainDelay))
+		time.Sleep(service.shutdownDrainDelay)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		gRPCServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		service.logger.Info("gRPC service stopped")
+	case <-time.After(service.shutdownTimeout):
+		service.logger.Warn("gRPC service did not drain the in-flight calls in time, stopping forcefully", zap.Duration("timeout", service.shutdownTimeout))
+		gRPCServer.Stop()
+	}
+
 	return nil
 }
 
+// createUnaryServerInterceptors creates the interceptors every unary call goes through, whether it is received over
+// gRPC or the Connect protocol
+func (service *transportService) createUnaryServerInterceptors() []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{
+		service.correlationService.CreateUnaryServerInterceptor(),
+		service.payloadLoggingService.CreateUnaryServerInterceptor(),
+		service.deprecationService.CreateUnaryServerInterceptor(),
+		service.redactionService.CreateUnaryServerInterceptor(),
+	}
+}
+
 func (service *transportService) setupHandlers() {
 	endpoint := service.endpointCreatorService.CreateUserEndpoint()
 	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("CreateUser")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "CreateUser")(endpoint)
 	endpoint = service.createAuthMiddleware("CreateUser")(endpoint)
 	service.createUserHandler = gokitgrpc.NewServer(
 		endpoint,
@@ -132,6 +481,7 @@ func (service *transportService) setupHandlers() {
 
 	endpoint = service.endpointCreatorService.ReadUserEndpoint()
 	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ReadUser")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ReadUser")(endpoint)
 	endpoint = service.createAuthMiddleware("ReadUser")(endpoint)
 	service.readUserHandler = gokitgrpc.NewServer(
 		endpoint,
@@ -139,84 +489,1676 @@ func (service *transportService) setupHandlers() {
 		encodeReadUserResponse,
 	)
 
+	endpoint = service.endpointCreatorService.ReadUserByEmailEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ReadUserByEmail")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ReadUserByEmail")(endpoint)
+	endpoint = service.createAuthMiddleware("ReadUserByEmail")(endpoint)
+	service.readUserByEmailHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeReadUserByEmailRequest,
+		encodeReadUserByEmailResponse,
+	)
+
+	endpoint = service.endpointCreatorService.ReadUsersEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ReadUsers")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ReadUsers")(endpoint)
+	endpoint = service.createAuthMiddleware("ReadUsers")(endpoint)
+	service.readUsersHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeReadUsersRequest,
+		encodeReadUsersResponse,
+	)
+
 	endpoint = service.endpointCreatorService.UpdateUserEndpoint()
 	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("UpdateUser")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "UpdateUser")(endpoint)
 	endpoint = service.createAuthMiddleware("UpdateUser")(endpoint)
 	service.updateUserHandler = gokitgrpc.NewServer(
 		endpoint,
-		decodeUpdateUserRequest,
+		service.rejectUnknownFields("UpdateUser", decodeUpdateUserRequest),
 		encodeUpdateUserResponse,
 	)
 
+	endpoint = service.endpointCreatorService.ChangeEmailEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ChangeEmail")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ChangeEmail")(endpoint)
+	endpoint = service.createAuthMiddleware("ChangeEmail")(endpoint)
+	service.changeEmailHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeChangeEmailRequest,
+		encodeChangeEmailResponse,
+	)
+
+	endpoint = service.endpointCreatorService.RequestEmailChangeEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("RequestEmailChange")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "RequestEmailChange")(endpoint)
+	endpoint = service.createAuthMiddleware("RequestEmailChange")(endpoint)
+	service.requestEmailChangeHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeRequestEmailChangeRequest,
+		encodeRequestEmailChangeResponse,
+	)
+
+	endpoint = service.endpointCreatorService.ConfirmEmailChangeEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ConfirmEmailChange")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ConfirmEmailChange")(endpoint)
+	endpoint = service.createAuthMiddleware("ConfirmEmailChange")(endpoint)
+	service.confirmEmailChangeHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeConfirmEmailChangeRequest,
+		encodeConfirmEmailChangeResponse,
+	)
+
 	endpoint = service.endpointCreatorService.DeleteUserEndpoint()
 	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("DeleteUser")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "DeleteUser")(endpoint)
 	endpoint = service.createAuthMiddleware("DeleteUser")(endpoint)
 	service.deleteUserHandler = gokitgrpc.NewServer(
 		endpoint,
 		decodeDeleteUserRequest,
 		encodeDeleteUserResponse,
 	)
-}
 
-// CreateUser creates a new user
-// context: Mandatory. The reference to the context
-// request: mandatory. The request to create a new user
-// Returns the result of creating new user
-func (service *transportService) CreateUser(
-	ctx context.Context,
-	request *userGRPCContract.CreateUserRequest) (*userGRPCContract.CreateUserResponse, error) {
-	_, response, err := service.createUserHandler.ServeGRPC(ctx, request)
-	if err != nil {
-		return nil, err
-	}
+	endpoint = service.endpointCreatorService.RestoreUserEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("RestoreUser")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "RestoreUser")(endpoint)
+	endpoint = service.createAuthMiddleware("RestoreUser")(endpoint)
+	service.restoreUserHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeRestoreUserRequest,
+		encodeRestoreUserResponse,
+	)
 
-	return response.(*userGRPCContract.CreateUserResponse), nil
-}
+	endpoint = service.endpointCreatorService.GetSagaStatusEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("GetSagaStatus")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "GetSagaStatus")(endpoint)
+	endpoint = service.createAuthMiddleware("GetSagaStatus")(endpoint)
+	service.getSagaStatusHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeGetSagaStatusRequest,
+		encodeGetSagaStatusResponse,
+	)
 
-// ReadUser read an existing user
-// context: Mandatory. The reference to the context
-// request: Mandatory. The request to read an existing user
-// Returns the result of reading an existing user
-func (service *transportService) ReadUser(
-	ctx context.Context,
-	request *userGRPCContract.ReadUserRequest) (*userGRPCContract.ReadUserResponse, error) {
-	_, response, err := service.readUserHandler.ServeGRPC(ctx, request)
-	if err != nil {
-		return nil, err
-	}
+	endpoint = service.endpointCreatorService.ListAuditRecordsEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ListAuditRecords")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ListAuditRecords")(endpoint)
+	endpoint = service.createAuthMiddleware("ListAuditRecords")(endpoint)
+	service.listAuditRecordsHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeListAuditRecordsRequest,
+		encodeListAuditRecordsResponse,
+	)
 
-	return response.(*userGRPCContract.ReadUserResponse), nil
+	endpoint = service.endpointCreatorService.SearchEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("Search")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "Search")(endpoint)
+	endpoint = service.createAuthMiddleware("Search")(endpoint)
+	service.searchHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeSearchRequest,
+		encodeSearchResponse,
+	)
 
-}
+	// go-kit does not support the streaming calls, the endpoint is called directly by StreamSearchUsers instead.
+	// The stream takes as long as sending all the matched users does, so it is not measured against the SLOs
+	endpoint = service.endpointCreatorService.StreamSearchEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("StreamSearchUsers")(endpoint)
+	endpoint = service.createAuthMiddleware("StreamSearchUsers")(endpoint)
+	service.streamSearchUsersEndpoint = endpoint
 
-// UpdateUser update an existing user
-// context: Mandatory. The reference to the context
-// request: Mandatory. The request to update an existing user
-// Returns the result of updateing an existing user
-func (service *transportService) UpdateUser(
-	ctx context.Context,
-	request *userGRPCContract.UpdateUserRequest) (*userGRPCContract.UpdateUserResponse, error) {
-	_, response, err := service.updateUserHandler.ServeGRPC(ctx, request)
-	if err != nil {
-		return nil, err
-	}
+	endpoint = service.endpointCreatorService.GetEffectiveConfigurationEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("GetEffectiveConfiguration")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "GetEffectiveConfiguration")(endpoint)
+	endpoint = service.createAuthMiddleware("GetEffectiveConfiguration")(endpoint)
+	service.getEffectiveConfigurationHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeGetEffectiveConfigurationRequest,
+		encodeGetEffectiveConfigurationResponse,
+	)
 
-	return response.(*userGRPCContract.UpdateUserResponse), nil
+	endpoint = service.endpointCreatorService.GetEnabledFeaturesEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("GetEnabledFeatures")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "GetEnabledFeatures")(endpoint)
+	endpoint = service.createAuthMiddleware("GetEnabledFeatures")(endpoint)
+	service.getEnabledFeaturesHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeGetEnabledFeaturesRequest,
+		encodeGetEnabledFeaturesResponse,
+	)
 
-}
+	endpoint = service.endpointCreatorService.PreviewBulkUpdateUsersEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("PreviewBulkUpdateUsers")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "PreviewBulkUpdateUsers")(endpoint)
+	endpoint = service.createAuthMiddleware("PreviewBulkUpdateUsers")(endpoint)
+	service.previewBulkUpdateUsersHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodePreviewBulkUpdateUsersRequest,
+		encodePreviewBulkUpdateUsersResponse,
+	)
 
-// DeleteUser delete an existing user
-// context: Mandatory. The reference to the context
-// request: Mandatory. The request to delete an existing user
-// Returns the result of deleting an existing user
-func (service *transportService) DeleteUser(
-	ctx context.Context,
-	request *userGRPCContract.DeleteUserRequest) (*userGRPCContract.DeleteUserResponse, error) {
-	_, response, err := service.deleteUserHandler.ServeGRPC(ctx, request)
-	if err != nil {
-		return nil, err
-	}
+	// The bulk update takes as long as updating all the matched users at the configured rate does, so it is not
+	// measured against the SLOs
+	endpoint = service.endpointCreatorService.BulkUpdateUsersEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("BulkUpdateUsers")(endpoint)
+	endpoint = service.createAuthMiddleware("BulkUpdateUsers")(endpoint)
+	service.bulkUpdateUsersHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeBulkUpdateUsersRequest,
+		encodeBulkUpdateUsersResponse,
+	)
 
-	return response.(*userGRPCContract.DeleteUserResponse), nil
+	endpoint = service.endpointCreatorService.PurgeByLabelEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("PurgeByLabel")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "PurgeByLabel")(endpoint)
+	endpoint = service.createAuthMiddleware("PurgeByLabel")(endpoint)
+	service.purgeByLabelHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodePurgeByLabelRequest,
+		encodePurgeByLabelResponse,
+	)
+
+	endpoint = service.endpointCreatorService.GetOutboxLagEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("GetOutboxLag")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "GetOutboxLag")(endpoint)
+	endpoint = service.createAuthMiddleware("GetOutboxLag")(endpoint)
+	service.getOutboxLagHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeGetOutboxLagRequest,
+		encodeGetOutboxLagResponse,
+	)
+
+	endpoint = service.endpointCreatorService.ListPendingEventsEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ListPendingEvents")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ListPendingEvents")(endpoint)
+	endpoint = service.createAuthMiddleware("ListPendingEvents")(endpoint)
+	service.listPendingEventsHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeListPendingEventsRequest,
+		encodeListPendingEventsResponse,
+	)
+
+	endpoint = service.endpointCreatorService.ForceFlushEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ForceFlush")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ForceFlush")(endpoint)
+	endpoint = service.createAuthMiddleware("ForceFlush")(endpoint)
+	service.forceFlushHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeForceFlushRequest,
+		encodeForceFlushResponse,
+	)
+
+	endpoint = service.endpointCreatorService.GetUserPreferencesEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("GetUserPreferences")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "GetUserPreferences")(endpoint)
+	endpoint = service.createAuthMiddleware("GetUserPreferences")(endpoint)
+	service.getUserPreferencesHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeGetUserPreferencesRequest,
+		encodeGetUserPreferencesResponse,
+	)
+
+	endpoint = service.endpointCreatorService.UpdateUserPreferencesEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("UpdateUserPreferences")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "UpdateUserPreferences")(endpoint)
+	endpoint = service.createAuthMiddleware("UpdateUserPreferences")(endpoint)
+	service.updateUserPreferencesHandler = gokitgrpc.NewServer(
+		endpoint,
+		service.rejectUnknownFields("UpdateUserPreferences", decodeUpdateUserPreferencesRequest),
+		encodeUpdateUserPreferencesResponse,
+	)
+
+	endpoint = service.endpointCreatorService.GetUserAvatarEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("GetUserAvatar")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "GetUserAvatar")(endpoint)
+	endpoint = service.createAuthMiddleware("GetUserAvatar")(endpoint)
+	service.getUserAvatarHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeGetUserAvatarRequest,
+		encodeGetUserAvatarResponse,
+	)
+
+	endpoint = service.endpointCreatorService.SetUserAvatarEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("SetUserAvatar")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "SetUserAvatar")(endpoint)
+	endpoint = service.createAuthMiddleware("SetUserAvatar")(endpoint)
+	service.setUserAvatarHandler = gokitgrpc.NewServer(
+		endpoint,
+		service.rejectUnknownFields("SetUserAvatar", decodeSetUserAvatarRequest),
+		encodeSetUserAvatarResponse,
+	)
+
+	endpoint = service.endpointCreatorService.ExportPersonalDataEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ExportPersonalData")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ExportPersonalData")(endpoint)
+	endpoint = service.createAuthMiddleware("ExportPersonalData")(endpoint)
+	service.exportPersonalDataHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeExportPersonalDataRequest,
+		encodeExportPersonalDataResponse,
+	)
+
+	endpoint = service.endpointCreatorService.EraseUserEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("EraseUser")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "EraseUser")(endpoint)
+	endpoint = service.createAuthMiddleware("EraseUser")(endpoint)
+	service.eraseUserHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeEraseUserRequest,
+		encodeEraseUserResponse,
+	)
+
+	endpoint = service.endpointCreatorService.AddUserToTenantEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("AddUserToTenant")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "AddUserToTenant")(endpoint)
+	endpoint = service.createAuthMiddleware("AddUserToTenant")(endpoint)
+	service.addUserToTenantHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeAddUserToTenantRequest,
+		encodeAddUserToTenantResponse,
+	)
+
+	endpoint = service.endpointCreatorService.RemoveUserFromTenantEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("RemoveUserFromTenant")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "RemoveUserFromTenant")(endpoint)
+	endpoint = service.createAuthMiddleware("RemoveUserFromTenant")(endpoint)
+	service.removeUserFromTenantHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeRemoveUserFromTenantRequest,
+		encodeRemoveUserFromTenantResponse,
+	)
+
+	endpoint = service.endpointCreatorService.ListUserTenantsEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ListUserTenants")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ListUserTenants")(endpoint)
+	endpoint = service.createAuthMiddleware("ListUserTenants")(endpoint)
+	service.listUserTenantsHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeListUserTenantsRequest,
+		encodeListUserTenantsResponse,
+	)
+
+	endpoint = service.endpointCreatorService.GetReplicationStatusEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("GetReplicationStatus")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "GetReplicationStatus")(endpoint)
+	endpoint = service.createAuthMiddleware("GetReplicationStatus")(endpoint)
+	service.getReplicationStatusHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeGetReplicationStatusRequest,
+		encodeGetReplicationStatusResponse,
+	)
+
+	// The export streams all the users like StreamSearchUsers does, so its endpoint is called directly by ExportUsers
+	// and it is not measured against the SLOs either
+	endpoint = service.endpointCreatorService.ExportUsersEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ExportUsers")(endpoint)
+	endpoint = service.createAuthMiddleware("ExportUsers")(endpoint)
+	service.exportUsersEndpoint = endpoint
+
+	endpoint = service.endpointCreatorService.IssueMagicLinkEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("IssueMagicLink")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "IssueMagicLink")(endpoint)
+	endpoint = service.createAuthMiddleware("IssueMagicLink")(endpoint)
+	service.issueMagicLinkHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeIssueMagicLinkRequest,
+		encodeIssueMagicLinkResponse,
+	)
+
+	endpoint = service.endpointCreatorService.RedeemMagicLinkEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("RedeemMagicLink")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "RedeemMagicLink")(endpoint)
+	endpoint = service.createAuthMiddleware("RedeemMagicLink")(endpoint)
+	service.redeemMagicLinkHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeRedeemMagicLinkRequest,
+		encodeRedeemMagicLinkResponse,
+	)
+
+	// The watches stream the changes until the client cancels them, so their endpoints are called directly as well
+	endpoint = service.endpointCreatorService.WatchUsersEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("WatchUser")(endpoint)
+	endpoint = service.createAuthMiddleware("WatchUser")(endpoint)
+	service.watchUserEndpoint = endpoint
+
+	endpoint = service.endpointCreatorService.WatchUsersEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("WatchUsers")(endpoint)
+	endpoint = service.createAuthMiddleware("WatchUsers")(endpoint)
+	service.watchUsersEndpoint = endpoint
+
+	endpoint = service.endpointCreatorService.SendVerificationEmailEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("SendVerificationEmail")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "SendVerificationEmail")(endpoint)
+	endpoint = service.createAuthMiddleware("SendVerificationEmail")(endpoint)
+	service.sendVerificationEmailHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeSendVerificationEmailRequest,
+		encodeSendVerificationEmailResponse,
+	)
+
+	endpoint = service.endpointCreatorService.VerifyEmailEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("VerifyEmail")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "VerifyEmail")(endpoint)
+	endpoint = service.createAuthMiddleware("VerifyEmail")(endpoint)
+	service.verifyEmailHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeVerifyEmailRequest,
+		encodeVerifyEmailResponse,
+	)
+
+	endpoint = service.endpointCreatorService.SetPasswordEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("SetPassword")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "SetPassword")(endpoint)
+	endpoint = service.createAuthMiddleware("SetPassword")(endpoint)
+	service.setPasswordHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeSetPasswordRequest,
+		encodeSetPasswordResponse,
+	)
+
+	endpoint = service.endpointCreatorService.ChangePasswordEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ChangePassword")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ChangePassword")(endpoint)
+	endpoint = service.createAuthMiddleware("ChangePassword")(endpoint)
+	service.changePasswordHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeChangePasswordRequest,
+		encodeChangePasswordResponse,
+	)
+
+	endpoint = service.endpointCreatorService.VerifyPasswordEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("VerifyPassword")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "VerifyPassword")(endpoint)
+	endpoint = service.createAuthMiddleware("VerifyPassword")(endpoint)
+	service.verifyPasswordHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeVerifyPasswordRequest,
+		encodeVerifyPasswordResponse,
+	)
+
+	endpoint = service.endpointCreatorService.CreateAPIKeyEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("CreateAPIKey")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "CreateAPIKey")(endpoint)
+	endpoint = service.createAuthMiddleware("CreateAPIKey")(endpoint)
+	service.createAPIKeyHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeCreateAPIKeyRequest,
+		encodeCreateAPIKeyResponse,
+	)
+
+	endpoint = service.endpointCreatorService.ListAPIKeysEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ListAPIKeys")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ListAPIKeys")(endpoint)
+	endpoint = service.createAuthMiddleware("ListAPIKeys")(endpoint)
+	service.listAPIKeysHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeListAPIKeysRequest,
+		encodeListAPIKeysResponse,
+	)
+
+	endpoint = service.endpointCreatorService.RevokeAPIKeyEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("RevokeAPIKey")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "RevokeAPIKey")(endpoint)
+	endpoint = service.createAuthMiddleware("RevokeAPIKey")(endpoint)
+	service.revokeAPIKeyHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeRevokeAPIKeyRequest,
+		encodeRevokeAPIKeyResponse,
+	)
+
+	endpoint = service.endpointCreatorService.RecordLoginAttemptEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("RecordLoginAttempt")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "RecordLoginAttempt")(endpoint)
+	endpoint = service.createAuthMiddleware("RecordLoginAttempt")(endpoint)
+	service.recordLoginAttemptHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeRecordLoginAttemptRequest,
+		encodeRecordLoginAttemptResponse,
+	)
+
+	endpoint = service.endpointCreatorService.UnlockUserEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("UnlockUser")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "UnlockUser")(endpoint)
+	endpoint = service.createAuthMiddleware("UnlockUser")(endpoint)
+	service.unlockUserHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeUnlockUserRequest,
+		encodeUnlockUserResponse,
+	)
+
+	endpoint = service.endpointCreatorService.EnrollMFAEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("EnrollMFA")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "EnrollMFA")(endpoint)
+	endpoint = service.createAuthMiddleware("EnrollMFA")(endpoint)
+	service.enrollMFAHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeEnrollMFARequest,
+		encodeEnrollMFAResponse,
+	)
+
+	endpoint = service.endpointCreatorService.ListMFAMethodsEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ListMFAMethods")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ListMFAMethods")(endpoint)
+	endpoint = service.createAuthMiddleware("ListMFAMethods")(endpoint)
+	service.listMFAMethodsHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeListMFAMethodsRequest,
+		encodeListMFAMethodsResponse,
+	)
+
+	endpoint = service.endpointCreatorService.RemoveMFAMethodEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("RemoveMFAMethod")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "RemoveMFAMethod")(endpoint)
+	endpoint = service.createAuthMiddleware("RemoveMFAMethod")(endpoint)
+	service.removeMFAMethodHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeRemoveMFAMethodRequest,
+		encodeRemoveMFAMethodResponse,
+	)
+
+	endpoint = service.endpointCreatorService.RecordConsentEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("RecordConsent")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "RecordConsent")(endpoint)
+	endpoint = service.createAuthMiddleware("RecordConsent")(endpoint)
+	service.recordConsentHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeRecordConsentRequest,
+		encodeRecordConsentResponse,
+	)
+
+	endpoint = service.endpointCreatorService.ListConsentsEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ListConsents")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ListConsents")(endpoint)
+	endpoint = service.createAuthMiddleware("ListConsents")(endpoint)
+	service.listConsentsHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeListConsentsRequest,
+		encodeListConsentsResponse,
+	)
+
+	endpoint = service.endpointCreatorService.CreateGroupEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("CreateGroup")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "CreateGroup")(endpoint)
+	endpoint = service.createAuthMiddleware("CreateGroup")(endpoint)
+	service.createGroupHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeCreateGroupRequest,
+		encodeCreateGroupResponse,
+	)
+
+	endpoint = service.endpointCreatorService.ReadGroupEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ReadGroup")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ReadGroup")(endpoint)
+	endpoint = service.createAuthMiddleware("ReadGroup")(endpoint)
+	service.readGroupHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeReadGroupRequest,
+		encodeReadGroupResponse,
+	)
+
+	endpoint = service.endpointCreatorService.UpdateGroupEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("UpdateGroup")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "UpdateGroup")(endpoint)
+	endpoint = service.createAuthMiddleware("UpdateGroup")(endpoint)
+	service.updateGroupHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeUpdateGroupRequest,
+		encodeUpdateGroupResponse,
+	)
+
+	endpoint = service.endpointCreatorService.DeleteGroupEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("DeleteGroup")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "DeleteGroup")(endpoint)
+	endpoint = service.createAuthMiddleware("DeleteGroup")(endpoint)
+	service.deleteGroupHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeDeleteGroupRequest,
+		encodeDeleteGroupResponse,
+	)
+
+	endpoint = service.endpointCreatorService.ListGroupsEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ListGroups")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ListGroups")(endpoint)
+	endpoint = service.createAuthMiddleware("ListGroups")(endpoint)
+	service.listGroupsHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeListGroupsRequest,
+		encodeListGroupsResponse,
+	)
+
+	endpoint = service.endpointCreatorService.AddUserToGroupEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("AddUserToGroup")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "AddUserToGroup")(endpoint)
+	endpoint = service.createAuthMiddleware("AddUserToGroup")(endpoint)
+	service.addUserToGroupHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeAddUserToGroupRequest,
+		encodeAddUserToGroupResponse,
+	)
+
+	endpoint = service.endpointCreatorService.RemoveUserFromGroupEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("RemoveUserFromGroup")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "RemoveUserFromGroup")(endpoint)
+	endpoint = service.createAuthMiddleware("RemoveUserFromGroup")(endpoint)
+	service.removeUserFromGroupHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeRemoveUserFromGroupRequest,
+		encodeRemoveUserFromGroupResponse,
+	)
+
+	endpoint = service.endpointCreatorService.InviteUserEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("InviteUser")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "InviteUser")(endpoint)
+	endpoint = service.createAuthMiddleware("InviteUser")(endpoint)
+	service.inviteUserHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeInviteUserRequest,
+		encodeInviteUserResponse,
+	)
+
+	endpoint = service.endpointCreatorService.AcceptInvitationEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("AcceptInvitation")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "AcceptInvitation")(endpoint)
+	endpoint = service.createAuthMiddleware("AcceptInvitation")(endpoint)
+	service.acceptInvitationHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeAcceptInvitationRequest,
+		encodeAcceptInvitationResponse,
+	)
+
+	endpoint = service.endpointCreatorService.RegisterSessionEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("RegisterSession")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "RegisterSession")(endpoint)
+	endpoint = service.createAuthMiddleware("RegisterSession")(endpoint)
+	service.registerSessionHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeRegisterSessionRequest,
+		encodeRegisterSessionResponse,
+	)
+
+	endpoint = service.endpointCreatorService.ListSessionsEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ListSessions")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ListSessions")(endpoint)
+	endpoint = service.createAuthMiddleware("ListSessions")(endpoint)
+	service.listSessionsHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeListSessionsRequest,
+		encodeListSessionsResponse,
+	)
+
+	endpoint = service.endpointCreatorService.RevokeSessionEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("RevokeSession")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "RevokeSession")(endpoint)
+	endpoint = service.createAuthMiddleware("RevokeSession")(endpoint)
+	service.revokeSessionHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeRevokeSessionRequest,
+		encodeRevokeSessionResponse,
+	)
+
+	endpoint = service.endpointCreatorService.CreateWebhookSubscriptionEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("CreateWebhookSubscription")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "CreateWebhookSubscription")(endpoint)
+	endpoint = service.createAuthMiddleware("CreateWebhookSubscription")(endpoint)
+	service.createWebhookSubscriptionHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeCreateWebhookSubscriptionRequest,
+		encodeCreateWebhookSubscriptionResponse,
+	)
+
+	endpoint = service.endpointCreatorService.ListWebhookSubscriptionsEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ListWebhookSubscriptions")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ListWebhookSubscriptions")(endpoint)
+	endpoint = service.createAuthMiddleware("ListWebhookSubscriptions")(endpoint)
+	service.listWebhookSubscriptionsHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeListWebhookSubscriptionsRequest,
+		encodeListWebhookSubscriptionsResponse,
+	)
+
+	endpoint = service.endpointCreatorService.DeleteWebhookSubscriptionEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("DeleteWebhookSubscription")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "DeleteWebhookSubscription")(endpoint)
+	endpoint = service.createAuthMiddleware("DeleteWebhookSubscription")(endpoint)
+	service.deleteWebhookSubscriptionHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeDeleteWebhookSubscriptionRequest,
+		encodeDeleteWebhookSubscriptionResponse,
+	)
+
+	endpoint = service.endpointCreatorService.ListWebhookDeliveriesEndpoint()
+	endpoint = service.middlewareProviderService.CreateLoggingMiddleware("ListWebhookDeliveries")(endpoint)
+	endpoint = service.sloService.CreateSLIMiddleware("grpc", "ListWebhookDeliveries")(endpoint)
+	endpoint = service.createAuthMiddleware("ListWebhookDeliveries")(endpoint)
+	service.listWebhookDeliveriesHandler = gokitgrpc.NewServer(
+		endpoint,
+		decodeListWebhookDeliveriesRequest,
+		encodeListWebhookDeliveriesResponse,
+	)
+}
+
+// CreateUser creates a new user
+// context: Mandatory. The reference to the context
+// request: mandatory. The request to create a new user
+// Returns the result of creating new user
+func (service *transportService) CreateUser(
+	ctx context.Context,
+	request *userGRPCContract.CreateUserRequest) (*userGRPCContract.CreateUserResponse, error) {
+	_, response, err := service.createUserHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.CreateUserResponse), nil
+}
+
+// ReadUser read an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to read an existing user
+// Returns the result of reading an existing user
+func (service *transportService) ReadUser(
+	ctx context.Context,
+	request *userGRPCContract.ReadUserRequest) (*userGRPCContract.ReadUserResponse, error) {
+	_, response, err := service.readUserHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ReadUserResponse), nil
+
+}
+
+// ReadUserByEmail reads an existing user by its email address
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to read an existing user by its email address
+// Returns the result of reading an existing user
+func (service *transportService) ReadUserByEmail(
+	ctx context.Context,
+	request *userGRPCContract.ReadUserByEmailRequest) (*userGRPCContract.ReadUserByEmailResponse, error) {
+	_, response, err := service.readUserByEmailHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ReadUserByEmailResponse), nil
+}
+
+// ReadUsers reads the existing users with the given email addresses or IDs at once
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to read the existing users
+// Returns the users found and the email addresses of the users not found
+func (service *transportService) ReadUsers(
+	ctx context.Context,
+	request *userGRPCContract.ReadUsersRequest) (*userGRPCContract.ReadUsersResponse, error) {
+	_, response, err := service.readUsersHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ReadUsersResponse), nil
+}
+
+// UpdateUser update an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to update an existing user
+// Returns the result of updateing an existing user
+func (service *transportService) UpdateUser(
+	ctx context.Context,
+	request *userGRPCContract.UpdateUserRequest) (*userGRPCContract.UpdateUserResponse, error) {
+	_, response, err := service.updateUserHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.UpdateUserResponse), nil
+
+}
+
+// ChangeEmail changes the email address of an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to change the email address of an existing user
+// Returns the result of changing the email address of an existing user
+func (service *transportService) ChangeEmail(
+	ctx context.Context,
+	request *userGRPCContract.ChangeEmailRequest) (*userGRPCContract.ChangeEmailResponse, error) {
+	_, response, err := service.changeEmailHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ChangeEmailResponse), nil
+}
+
+// RequestEmailChange sends the token that confirms changing the email address of an existing user to the new email
+// address
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to change the email address of an existing user
+// Returns the time the token expires at
+func (service *transportService) RequestEmailChange(
+	ctx context.Context,
+	request *userGRPCContract.RequestEmailChangeRequest) (*userGRPCContract.RequestEmailChangeResponse, error) {
+	_, response, err := service.requestEmailChangeHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.RequestEmailChangeResponse), nil
+}
+
+// ConfirmEmailChange changes the email address of an existing user to the one the token is sent to
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the token sent to the new email address
+// Returns the user with the new email address
+func (service *transportService) ConfirmEmailChange(
+	ctx context.Context,
+	request *userGRPCContract.ConfirmEmailChangeRequest) (*userGRPCContract.ConfirmEmailChangeResponse, error) {
+	_, response, err := service.confirmEmailChangeHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ConfirmEmailChangeResponse), nil
+}
+
+// DeleteUser delete an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to delete an existing user
+// Returns the result of deleting an existing user
+func (service *transportService) DeleteUser(
+	ctx context.Context,
+	request *userGRPCContract.DeleteUserRequest) (*userGRPCContract.DeleteUserResponse, error) {
+	_, response, err := service.deleteUserHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.DeleteUserResponse), nil
+
+}
+
+// RestoreUser restores an existing soft deleted user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to restore an existing soft deleted user
+// Returns the result of restoring an existing soft deleted user
+func (service *transportService) RestoreUser(
+	ctx context.Context,
+	request *userGRPCContract.RestoreUserRequest) (*userGRPCContract.RestoreUserResponse, error) {
+	_, response, err := service.restoreUserHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.RestoreUserResponse), nil
+}
+
+// GetSagaStatus reads the progress of an existing saga
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to read the progress of an existing saga
+// Returns the progress of the saga
+func (service *transportService) GetSagaStatus(
+	ctx context.Context,
+	request *userGRPCContract.GetSagaStatusRequest) (*userGRPCContract.GetSagaStatusResponse, error) {
+	_, response, err := service.getSagaStatusHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.GetSagaStatusResponse), nil
+}
+
+// ListAuditRecords lists the audit records of the mutating operations that matched the criteria
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the criteria the audit records are listed by
+// Returns the audit records that matched the criteria, the latest first
+func (service *transportService) ListAuditRecords(
+	ctx context.Context,
+	request *userGRPCContract.ListAuditRecordsRequest) (*userGRPCContract.ListAuditRecordsResponse, error) {
+	_, response, err := service.listAuditRecordsHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ListAuditRecordsResponse), nil
+}
+
+// Search returns the list of users that matched the criteria
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the search criteria
+// Returns the list of users that matched the criteria
+func (service *transportService) Search(
+	ctx context.Context,
+	request *userGRPCContract.SearchRequest) (*userGRPCContract.SearchResponse, error) {
+	_, response, err := service.searchHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.SearchResponse), nil
+}
+
+// StreamSearchUsers streams the users that matched the criteria one by one
+// request: Mandatory. The request contains the search criteria
+// stream: Mandatory. The stream to send the users that matched the criteria to
+// Returns error if something goes wrong
+func (service *transportService) StreamSearchUsers(
+	request *userGRPCContract.StreamSearchUsersRequest,
+	stream userGRPCContract.Service_StreamSearchUsersServer) error {
+	response, err := service.streamSearchUsersEndpoint(stream.Context(), decodeStreamSearchUsersRequest(request, stream))
+	if err != nil {
+		return err
+	}
+
+	return encodeStreamSearchUsersResponse(response)
+}
+
+// GetEffectiveConfiguration reads the configuration the replica is running with, the secrets are redacted
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to read the effective configuration
+// Returns the effective configuration
+func (service *transportService) GetEffectiveConfiguration(
+	ctx context.Context,
+	request *userGRPCContract.GetEffectiveConfigurationRequest) (*userGRPCContract.GetEffectiveConfigurationResponse, error) {
+	_, response, err := service.getEffectiveConfigurationHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.GetEffectiveConfigurationResponse), nil
+}
+
+// GetEnabledFeatures reads the optional features the replica is running with
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to read the enabled features
+// Returns the optional features and whether they are enabled
+func (service *transportService) GetEnabledFeatures(
+	ctx context.Context,
+	request *userGRPCContract.GetEnabledFeaturesRequest) (*userGRPCContract.GetEnabledFeaturesResponse, error) {
+	_, response, err := service.getEnabledFeaturesHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.GetEnabledFeaturesResponse), nil
+}
+
+// PreviewBulkUpdateUsers counts the users that match the filter and returns a sample of them along with the token
+// that confirms applying the update to them
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the filter and the update to preview
+// Returns the number and a sample of the matched users, and the confirmation token
+func (service *transportService) PreviewBulkUpdateUsers(
+	ctx context.Context,
+	request *userGRPCContract.PreviewBulkUpdateUsersRequest) (*userGRPCContract.PreviewBulkUpdateUsersResponse, error) {
+	_, response, err := service.previewBulkUpdateUsersHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.PreviewBulkUpdateUsersResponse), nil
+}
+
+// BulkUpdateUsers applies a previewed update to all the users that match the filter
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the filter, the update and the confirmation token of the preview
+// Returns the number of the updated users and the users that failed to update
+func (service *transportService) BulkUpdateUsers(
+	ctx context.Context,
+	request *userGRPCContract.BulkUpdateUsersRequest) (*userGRPCContract.BulkUpdateUsersResponse, error) {
+	_, response, err := service.bulkUpdateUsersHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.BulkUpdateUsersResponse), nil
+}
+
+// PurgeByLabel permanently deletes all the users tagged with the given test label
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the test label of the users to purge
+// Returns the number of the purged users
+func (service *transportService) PurgeByLabel(
+	ctx context.Context,
+	request *userGRPCContract.PurgeByLabelRequest) (*userGRPCContract.PurgeByLabelResponse, error) {
+	_, response, err := service.purgeByLabelHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.PurgeByLabelResponse), nil
+}
+
+// GetOutboxLag reads how many user lifecycle events the message broker has not confirmed receiving yet
+// context: Mandatory. The reference to the context
+// request: Mandatory. Empty request
+// Returns the publisher lag of the replica
+func (service *transportService) GetOutboxLag(
+	ctx context.Context,
+	request *userGRPCContract.GetOutboxLagRequest) (*userGRPCContract.GetOutboxLagResponse, error) {
+	_, response, err := service.getOutboxLagHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.GetOutboxLagResponse), nil
+}
+
+// ListPendingEvents lists the user lifecycle events the message broker has not confirmed receiving yet
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the maximum number of the events to list
+// Returns the pending events of the replica, the oldest first
+func (service *transportService) ListPendingEvents(
+	ctx context.Context,
+	request *userGRPCContract.ListPendingEventsRequest) (*userGRPCContract.ListPendingEventsResponse, error) {
+	_, response, err := service.listPendingEventsHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ListPendingEventsResponse), nil
+}
+
+// ForceFlush sends the buffered user lifecycle events and waits for the message broker to confirm receiving them
+// context: Mandatory. The reference to the context
+// request: Mandatory. Empty request
+// Returns the number of the confirmed events
+func (service *transportService) ForceFlush(
+	ctx context.Context,
+	request *userGRPCContract.ForceFlushRequest) (*userGRPCContract.ForceFlushResponse, error) {
+	_, response, err := service.forceFlushHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ForceFlushResponse), nil
+}
+
+// GetUserPreferences returns the preferences of an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to get the preferences of an existing user
+// Returns the preferences of the user
+func (service *transportService) GetUserPreferences(
+	ctx context.Context,
+	request *userGRPCContract.GetUserPreferencesRequest) (*userGRPCContract.GetUserPreferencesResponse, error) {
+	_, response, err := service.getUserPreferencesHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.GetUserPreferencesResponse), nil
+}
+
+// UpdateUserPreferences merges the given preferences into the preferences of an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to update the preferences of an existing user
+// Returns the preferences of the user after the update
+func (service *transportService) UpdateUserPreferences(
+	ctx context.Context,
+	request *userGRPCContract.UpdateUserPreferencesRequest) (*userGRPCContract.UpdateUserPreferencesResponse, error) {
+	_, response, err := service.updateUserPreferencesHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.UpdateUserPreferencesResponse), nil
+}
+
+// GetUserAvatar creates the URL the avatar image of an existing user is downloaded from
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to read the avatar of an existing user
+// Returns the URL of the avatar image
+func (service *transportService) GetUserAvatar(
+	ctx context.Context,
+	request *userGRPCContract.GetUserAvatarRequest) (*userGRPCContract.GetUserAvatarResponse, error) {
+	_, response, err := service.getUserAvatarHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.GetUserAvatarResponse), nil
+}
+
+// SetUserAvatar stores the avatar image of an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the avatar image
+// Returns the user after the avatar is set and the URL of the avatar image
+func (service *transportService) SetUserAvatar(
+	ctx context.Context,
+	request *userGRPCContract.SetUserAvatarRequest) (*userGRPCContract.SetUserAvatarResponse, error) {
+	_, response, err := service.setUserAvatarHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.SetUserAvatarResponse), nil
+}
+
+// ExportPersonalData assembles everything stored about an existing user into a JSON archive
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to export the personal data of an existing user
+// Returns the JSON archive of the personal data of the user
+func (service *transportService) ExportPersonalData(
+	ctx context.Context,
+	request *userGRPCContract.ExportPersonalDataRequest) (*userGRPCContract.ExportPersonalDataResponse, error) {
+	_, response, err := service.exportPersonalDataHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ExportPersonalDataResponse), nil
+}
+
+// EraseUser requests or confirms the erasure of an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the user email address and the confirmation token if the erasure is confirmed
+// Returns either the confirmation token or whether the user is erased
+func (service *transportService) EraseUser(
+	ctx context.Context,
+	request *userGRPCContract.EraseUserRequest) (*userGRPCContract.EraseUserResponse, error) {
+	_, response, err := service.eraseUserHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.EraseUserResponse), nil
+}
+
+// AddUserToTenant adds an existing user to a tenant
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the tenant and the role to add the user with
+// Returns the user after it is added to the tenant
+func (service *transportService) AddUserToTenant(
+	ctx context.Context,
+	request *userGRPCContract.AddUserToTenantRequest) (*userGRPCContract.AddUserToTenantResponse, error) {
+	_, response, err := service.addUserToTenantHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.AddUserToTenantResponse), nil
+}
+
+// RemoveUserFromTenant removes an existing user from a tenant
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the tenant to remove the user from
+// Returns the user after it is removed from the tenant
+func (service *transportService) RemoveUserFromTenant(
+	ctx context.Context,
+	request *userGRPCContract.RemoveUserFromTenantRequest) (*userGRPCContract.RemoveUserFromTenantResponse, error) {
+	_, response, err := service.removeUserFromTenantHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.RemoveUserFromTenantResponse), nil
+}
+
+// ListUserTenants lists the tenants an existing user is a member of
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to list the tenants of an existing user
+// Returns the memberships of the user in the tenants
+func (service *transportService) ListUserTenants(
+	ctx context.Context,
+	request *userGRPCContract.ListUserTenantsRequest) (*userGRPCContract.ListUserTenantsResponse, error) {
+	_, response, err := service.listUserTenantsHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ListUserTenantsResponse), nil
+}
+
+// GetReplicationStatus reads how far behind the primary database the standby database is
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to read the replication status
+// Returns the latest heartbeats of the primary and the standby databases and the lag between them
+func (service *transportService) GetReplicationStatus(
+	ctx context.Context,
+	request *userGRPCContract.GetReplicationStatusRequest) (*userGRPCContract.GetReplicationStatusResponse, error) {
+	_, response, err := service.getReplicationStatusHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.GetReplicationStatusResponse), nil
+}
+
+// ExportUsers streams all the users that matched the filter one by one
+// request: Mandatory. The request contains the filter
+// stream: Mandatory. The stream to send the users that matched the filter to
+// Returns error if something goes wrong
+func (service *transportService) ExportUsers(
+	request *userGRPCContract.ExportUsersRequest,
+	stream userGRPCContract.Service_ExportUsersServer) error {
+	response, err := service.exportUsersEndpoint(stream.Context(), decodeExportUsersRequest(request, stream))
+	if err != nil {
+		return err
+	}
+
+	return encodeExportUsersResponse(response)
+}
+
+// IssueMagicLink issues a single-use magic link to an existing user and publishes it to be emailed to the user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the user email address and the IP address and the device the link is
+// requested from
+// Returns the time the magic link expires at
+func (service *transportService) IssueMagicLink(
+	ctx context.Context,
+	request *userGRPCContract.IssueMagicLinkRequest) (*userGRPCContract.IssueMagicLinkResponse, error) {
+	_, response, err := service.issueMagicLinkHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.IssueMagicLinkResponse), nil
+}
+
+// RedeemMagicLink redeems the magic link of a user, the magic link can not be redeemed again
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the user email address, the token and the IP address and the device the
+// link is redeemed from
+// Returns the user the magic link signs in
+func (service *transportService) RedeemMagicLink(
+	ctx context.Context,
+	request *userGRPCContract.RedeemMagicLinkRequest) (*userGRPCContract.RedeemMagicLinkResponse, error) {
+	_, response, err := service.redeemMagicLinkHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.RedeemMagicLinkResponse), nil
+}
+
+// SendVerificationEmail sends a new email verification token to the email address of an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request to send the verification email
+// Returns the time the token expires at
+func (service *transportService) SendVerificationEmail(
+	ctx context.Context,
+	request *userGRPCContract.SendVerificationEmailRequest) (*userGRPCContract.SendVerificationEmailResponse, error) {
+	_, response, err := service.sendVerificationEmailHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.SendVerificationEmailResponse), nil
+}
+
+// VerifyEmail verifies the email address of an existing user with the token sent in the verification email
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the token sent in the verification email
+// Returns the user after its email address is verified
+func (service *transportService) VerifyEmail(
+	ctx context.Context,
+	request *userGRPCContract.VerifyEmailRequest) (*userGRPCContract.VerifyEmailResponse, error) {
+	_, response, err := service.verifyEmailHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.VerifyEmailResponse), nil
+}
+
+// SetPassword sets the first password of an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the password to set
+// Returns the result of setting the password
+func (service *transportService) SetPassword(
+	ctx context.Context,
+	request *userGRPCContract.SetPasswordRequest) (*userGRPCContract.SetPasswordResponse, error) {
+	_, response, err := service.setPasswordHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.SetPasswordResponse), nil
+}
+
+// ChangePassword replaces the password of an existing user after verifying the current password
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the current and the new password
+// Returns the result of replacing the password
+func (service *transportService) ChangePassword(
+	ctx context.Context,
+	request *userGRPCContract.ChangePasswordRequest) (*userGRPCContract.ChangePasswordResponse, error) {
+	_, response, err := service.changePasswordHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ChangePasswordResponse), nil
+}
+
+// VerifyPassword verifies the password of an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the password to verify
+// Returns the user the password matched
+func (service *transportService) VerifyPassword(
+	ctx context.Context,
+	request *userGRPCContract.VerifyPasswordRequest) (*userGRPCContract.VerifyPasswordResponse, error) {
+	_, response, err := service.verifyPasswordHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.VerifyPasswordResponse), nil
+}
+
+// WatchUser streams the changes made to the user as they happen
+// request: Mandatory. The request contains the user email address and the types of the changes to watch
+// stream: Mandatory. The stream to send the changes made to the user to
+// Returns error if something goes wrong
+func (service *transportService) WatchUser(
+	request *userGRPCContract.WatchUserRequest,
+	stream userGRPCContract.Service_WatchUserServer) error {
+	response, err := service.watchUserEndpoint(stream.Context(), decodeWatchUserRequest(request, stream))
+	if err != nil {
+		return err
+	}
+
+	return encodeWatchUsersResponse(response)
+}
+
+// WatchUsers streams the changes made to the users that matched the filter as they happen
+// request: Mandatory. The request contains the filter
+// stream: Mandatory. The stream to send the changes made to the users to
+// Returns error if something goes wrong
+func (service *transportService) WatchUsers(
+	request *userGRPCContract.WatchUsersRequest,
+	stream userGRPCContract.Service_WatchUsersServer) error {
+	response, err := service.watchUsersEndpoint(stream.Context(), decodeWatchUsersRequest(request, stream))
+	if err != nil {
+		return err
+	}
+
+	return encodeWatchUsersResponse(response)
+}
+
+// CreateAPIKey mints a new API key for an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the name, the scopes and the expiry of the API key
+// Returns the new API key and the key itself
+func (service *transportService) CreateAPIKey(
+	ctx context.Context,
+	request *userGRPCContract.CreateAPIKeyRequest) (*userGRPCContract.CreateAPIKeyResponse, error) {
+	_, response, err := service.createAPIKeyHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.CreateAPIKeyResponse), nil
+}
+
+// ListAPIKeys lists all the API keys of an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the user email address
+// Returns the API keys of the user
+func (service *transportService) ListAPIKeys(
+	ctx context.Context,
+	request *userGRPCContract.ListAPIKeysRequest) (*userGRPCContract.ListAPIKeysResponse, error) {
+	_, response, err := service.listAPIKeysHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ListAPIKeysResponse), nil
+}
+
+// RevokeAPIKey revokes an API key of an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the ID of the API key
+// Returns the result of revoking the API key
+func (service *transportService) RevokeAPIKey(
+	ctx context.Context,
+	request *userGRPCContract.RevokeAPIKeyRequest) (*userGRPCContract.RevokeAPIKeyResponse, error) {
+	_, response, err := service.revokeAPIKeyHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.RevokeAPIKeyResponse), nil
+}
+
+// RecordLoginAttempt records the outcome of a login attempt of an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the outcome of the login attempt
+// Returns the user after the login attempt is recorded
+func (service *transportService) RecordLoginAttempt(
+	ctx context.Context,
+	request *userGRPCContract.RecordLoginAttemptRequest) (*userGRPCContract.RecordLoginAttemptResponse, error) {
+	_, response, err := service.recordLoginAttemptHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.RecordLoginAttemptResponse), nil
+}
+
+// UnlockUser unlocks an existing user and resets the number of its failed login attempts
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the user email address
+// Returns the user after it is unlocked
+func (service *transportService) UnlockUser(
+	ctx context.Context,
+	request *userGRPCContract.UnlockUserRequest) (*userGRPCContract.UnlockUserResponse, error) {
+	_, response, err := service.unlockUserHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.UnlockUserResponse), nil
+}
+
+// EnrollMFA enrolls an existing user in an MFA method
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the method to enroll the user in
+// Returns the method the user is enrolled in
+func (service *transportService) EnrollMFA(
+	ctx context.Context,
+	request *userGRPCContract.EnrollMFARequest) (*userGRPCContract.EnrollMFAResponse, error) {
+	_, response, err := service.enrollMFAHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.EnrollMFAResponse), nil
+}
+
+// ListMFAMethods lists the MFA methods an existing user is enrolled in
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the user email address
+// Returns the MFA methods of the user
+func (service *transportService) ListMFAMethods(
+	ctx context.Context,
+	request *userGRPCContract.ListMFAMethodsRequest) (*userGRPCContract.ListMFAMethodsResponse, error) {
+	_, response, err := service.listMFAMethodsHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ListMFAMethodsResponse), nil
+}
+
+// RemoveMFAMethod removes an MFA method from an existing user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the ID of the method to remove
+// Returns the user after the method is removed
+func (service *transportService) RemoveMFAMethod(
+	ctx context.Context,
+	request *userGRPCContract.RemoveMFAMethodRequest) (*userGRPCContract.RemoveMFAMethodResponse, error) {
+	_, response, err := service.removeMFAMethodHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.RemoveMFAMethodResponse), nil
+}
+
+// RecordConsent records that an existing user accepted a version of a policy
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the policy and the version the user accepted
+// Returns the recorded consent
+func (service *transportService) RecordConsent(
+	ctx context.Context,
+	request *userGRPCContract.RecordConsentRequest) (*userGRPCContract.RecordConsentResponse, error) {
+	_, response, err := service.recordConsentHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.RecordConsentResponse), nil
+}
+
+// ListConsents lists the consents of an existing user and the required policy versions the user has not accepted yet
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the user email address
+// Returns the consents and the outstanding policy versions of the user
+func (service *transportService) ListConsents(
+	ctx context.Context,
+	request *userGRPCContract.ListConsentsRequest) (*userGRPCContract.ListConsentsResponse, error) {
+	_, response, err := service.listConsentsHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ListConsentsResponse), nil
+}
+
+// CreateGroup creates a new group with no member
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the name and the description of the group
+// Returns the new group
+func (service *transportService) CreateGroup(
+	ctx context.Context,
+	request *userGRPCContract.CreateGroupRequest) (*userGRPCContract.CreateGroupResponse, error) {
+	_, response, err := service.createGroupHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.CreateGroupResponse), nil
+}
+
+// ReadGroup reads an existing group along its members
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the ID of the group
+// Returns the group
+func (service *transportService) ReadGroup(
+	ctx context.Context,
+	request *userGRPCContract.ReadGroupRequest) (*userGRPCContract.ReadGroupResponse, error) {
+	_, response, err := service.readGroupHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ReadGroupResponse), nil
+}
+
+// UpdateGroup renames and describes an existing group, its members are kept
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the new name and description of the group
+// Returns the updated group
+func (service *transportService) UpdateGroup(
+	ctx context.Context,
+	request *userGRPCContract.UpdateGroupRequest) (*userGRPCContract.UpdateGroupResponse, error) {
+	_, response, err := service.updateGroupHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.UpdateGroupResponse), nil
+}
+
+// DeleteGroup deletes an existing group, its members are not affected
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the ID of the group
+// Returns the result of deleting the group
+func (service *transportService) DeleteGroup(
+	ctx context.Context,
+	request *userGRPCContract.DeleteGroupRequest) (*userGRPCContract.DeleteGroupResponse, error) {
+	_, response, err := service.deleteGroupHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.DeleteGroupResponse), nil
+}
+
+// ListGroups lists all the groups, or the groups of a user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the optional email address of the user
+// Returns the groups ordered by their names
+func (service *transportService) ListGroups(
+	ctx context.Context,
+	request *userGRPCContract.ListGroupsRequest) (*userGRPCContract.ListGroupsResponse, error) {
+	_, response, err := service.listGroupsHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ListGroupsResponse), nil
+}
+
+// AddUserToGroup adds an existing user to an existing group
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the ID of the group and the user email address
+// Returns the group after the user is added
+func (service *transportService) AddUserToGroup(
+	ctx context.Context,
+	request *userGRPCContract.AddUserToGroupRequest) (*userGRPCContract.AddUserToGroupResponse, error) {
+	_, response, err := service.addUserToGroupHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.AddUserToGroupResponse), nil
+}
+
+// RemoveUserFromGroup removes a user from an existing group
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the ID of the group and the user email address
+// Returns the group after the user is removed
+func (service *transportService) RemoveUserFromGroup(
+	ctx context.Context,
+	request *userGRPCContract.RemoveUserFromGroupRequest) (*userGRPCContract.RemoveUserFromGroupResponse, error) {
+	_, response, err := service.removeUserFromGroupHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.RemoveUserFromGroupResponse), nil
+}
+
+// InviteUser invites an email address to join as a new user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the email address to invite
+// Returns the time the invitation expires at
+func (service *transportService) InviteUser(
+	ctx context.Context,
+	request *userGRPCContract.InviteUserRequest) (*userGRPCContract.InviteUserResponse, error) {
+	_, response, err := service.inviteUserHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.InviteUserResponse), nil
+}
+
+// AcceptInvitation accepts the invitation sent to the email address in the token and creates its user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the invitation token and the user object
+// Returns the created user object
+func (service *transportService) AcceptInvitation(
+	ctx context.Context,
+	request *userGRPCContract.AcceptInvitationRequest) (*userGRPCContract.AcceptInvitationResponse, error) {
+	_, response, err := service.acceptInvitationHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.AcceptInvitationResponse), nil
+}
+
+// RegisterSession registers a new session of a user or extends an existing one
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the ID of the session, the user email address and the device
+// Returns the registered session
+func (service *transportService) RegisterSession(
+	ctx context.Context,
+	request *userGRPCContract.RegisterSessionRequest) (*userGRPCContract.RegisterSessionResponse, error) {
+	_, response, err := service.registerSessionHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.RegisterSessionResponse), nil
+}
+
+// ListSessions lists the active sessions of a user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the user email address
+// Returns the active sessions, the most recently seen first
+func (service *transportService) ListSessions(
+	ctx context.Context,
+	request *userGRPCContract.ListSessionsRequest) (*userGRPCContract.ListSessionsResponse, error) {
+	_, response, err := service.listSessionsHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ListSessionsResponse), nil
+}
+
+// RevokeSession revokes an active session of a user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the user email address and the ID of the session
+// Returns the result of revoking the session
+func (service *transportService) RevokeSession(
+	ctx context.Context,
+	request *userGRPCContract.RevokeSessionRequest) (*userGRPCContract.RevokeSessionResponse, error) {
+	_, response, err := service.revokeSessionHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.RevokeSessionResponse), nil
+}
+
+// CreateWebhookSubscription subscribes a webhook to the events of a user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the user email address, the URL, the secret and the event types
+// Returns the created subscription
+func (service *transportService) CreateWebhookSubscription(
+	ctx context.Context,
+	request *userGRPCContract.CreateWebhookSubscriptionRequest) (*userGRPCContract.CreateWebhookSubscriptionResponse, error) {
+	_, response, err := service.createWebhookSubscriptionHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.CreateWebhookSubscriptionResponse), nil
+}
+
+// ListWebhookSubscriptions lists the webhook subscriptions of a user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the user email address
+// Returns the subscriptions, the oldest first
+func (service *transportService) ListWebhookSubscriptions(
+	ctx context.Context,
+	request *userGRPCContract.ListWebhookSubscriptionsRequest) (*userGRPCContract.ListWebhookSubscriptionsResponse, error) {
+	_, response, err := service.listWebhookSubscriptionsHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.ListWebhookSubscriptionsResponse), nil
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription of a user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the user email address and the ID of the subscription
+// Returns the result of deleting the subscription
+func (service *transportService) DeleteWebhookSubscription(
+	ctx context.Context,
+	request *userGRPCContract.DeleteWebhookSubscriptionRequest) (*userGRPCContract.DeleteWebhookSubscriptionResponse, error) {
+	_, response, err := service.deleteWebhookSubscriptionHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*userGRPCContract.DeleteWebhookSubscriptionResponse), nil
+}
+
+// ListWebhookDeliveries lists the recent deliveries of the events to the webhooks of a user
+// context: Mandatory. The reference to the context
+// request: Mandatory. The request contains the user email address, the optional ID of the subscription and the limit
+// Returns the deliveries, the most recent first
+func (service *transportService) ListWebhookDeliveries(
+	ctx context.Context,
+	request *userGRPCContract.ListWebhookDeliveriesRequest) (*userGRPCContract.ListWebhookDeliveriesResponse, error) {
+	_, response, err := service.listWebhookDeliveriesHandler.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
 
+	return response.(*userGRPCContract.ListWebhookDeliveriesResponse), nil
 }
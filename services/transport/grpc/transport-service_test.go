@@ -0,0 +1,691 @@
+package grpc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/apikey"
+	apiKeyMock "github.com/decentralized-cloud/user/services/apikey/mock"
+	"github.com/decentralized-cloud/user/services/business"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/consent"
+	"github.com/decentralized-cloud/user/services/correlation"
+	"github.com/decentralized-cloud/user/services/deprecation"
+	"github.com/decentralized-cloud/user/services/endpoint"
+	endpointMock "github.com/decentralized-cloud/user/services/endpoint/mock"
+	groupMock "github.com/decentralized-cloud/user/services/group/mock"
+	"github.com/decentralized-cloud/user/services/payloadlogging"
+	"github.com/decentralized-cloud/user/services/redaction"
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/slo"
+	"github.com/decentralized-cloud/user/services/transport"
+	transportGRPC "github.com/decentralized-cloud/user/services/transport/grpc"
+	gokitendpoint "github.com/go-kit/kit/endpoint"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/golang/mock/gomock"
+	"github.com/micro-business/go-core/gokit/middleware"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestGRPCTransportService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "gRPC Transport Service Tests")
+}
+
+var _ = Describe("gRPC Transport Service Tests", func() {
+	const drainDelay = 500 * time.Millisecond
+
+	var (
+		mockCtrl          *gomock.Controller
+		sut               transport.TransportContract
+		address           string
+		started           chan error
+		reflectionEnabled bool
+		strictDecoding    bool
+		readUserErr       error
+	)
+
+	// checkServiceHealth calls the health service over a new connection, so it fails once the listener is closed
+	checkServiceHealth := func(serviceName string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		connection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+		if err != nil {
+			return healthpb.HealthCheckResponse_UNKNOWN, err
+		}
+
+		defer connection.Close()
+
+		response, err := healthpb.NewHealthClient(connection).Check(ctx, &healthpb.HealthCheckRequest{Service: serviceName})
+		if err != nil {
+			return healthpb.HealthCheckResponse_UNKNOWN, err
+		}
+
+		return response.Status, nil
+	}
+
+	// checkHealth checks the overall health of the server
+	checkHealth := func() (healthpb.HealthCheckResponse_ServingStatus, error) {
+		return checkServiceHealth("")
+	}
+
+	// listServices lists the services the server serves using the server reflection
+	listServices := func() ([]string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		connection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+		Ω(err).Should(BeNil())
+
+		defer connection.Close()
+
+		stream, err := reflectionpb.NewServerReflectionClient(connection).ServerReflectionInfo(ctx)
+		Ω(err).Should(BeNil())
+
+		err = stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		})
+		Ω(err).Should(BeNil())
+
+		response, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		serviceNames := []string{}
+		for _, serviceResponse := range response.GetListServicesResponse().GetService() {
+			serviceNames = append(serviceNames, serviceResponse.Name)
+		}
+
+		return serviceNames, nil
+	}
+
+	// updateUser sends the UpdateUser request carrying the given unknown fields, numbered 99, in the request and the user
+	updateUser := func(unknownInRequest bool, unknownInUser bool) error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		connection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+		Ω(err).Should(BeNil())
+
+		defer connection.Close()
+
+		unknownField := protowire.AppendVarint(protowire.AppendTag(nil, 99, protowire.VarintType), 1)
+		request := &userGRPCContract.UpdateUserRequest{User: &userGRPCContract.User{}}
+		if unknownInRequest {
+			request.ProtoReflect().SetUnknown(unknownField)
+		}
+
+		if unknownInUser {
+			request.User.ProtoReflect().SetUnknown(unknownField)
+		}
+
+		_, err = userGRPCContract.NewServiceClient(connection).UpdateUser(ctx, request)
+
+		return err
+	}
+
+	// getUnknownFieldsCount returns the number of the UpdateUser requests carrying unknown fields the action is taken on
+	getUnknownFieldsCount := func(action string) float64 {
+		metricFamilies, err := prometheus.DefaultGatherer.Gather()
+		Ω(err).Should(BeNil())
+
+		for _, metricFamily := range metricFamilies {
+			if metricFamily.GetName() != "user_grpc_requests_with_unknown_fields_total" {
+				continue
+			}
+
+			for _, metric := range metricFamily.GetMetric() {
+				labels := map[string]string{}
+				for _, label := range metric.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+
+				if labels["operation"] == "UpdateUser" && labels["action"] == action {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+
+		return 0
+	}
+
+	// readUser reads the user with the API key of the user, so the request reaches the endpoint that fails with readUserErr
+	readUser := func() *userGRPCContract.ReadUserResponse {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		connection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+		Ω(err).Should(BeNil())
+
+		defer connection.Close()
+
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", "test-key")
+		response, err := userGRPCContract.NewServiceClient(connection).ReadUser(ctx, &userGRPCContract.ReadUserRequest{Email: "user@test.com"})
+		Ω(err).Should(BeNil())
+
+		return response
+	}
+
+	// getErrorDetail unpacks the error detail of the same type as the given detail into it
+	// Returns true if the response carries the error detail of the type
+	getErrorDetail := func(response *userGRPCContract.ReadUserResponse, detail proto.Message) bool {
+		for _, packedDetail := range response.ErrorDetails {
+			if packedDetail.MessageIs(detail) {
+				Ω(packedDetail.UnmarshalTo(detail)).Should(Succeed())
+
+				return true
+			}
+		}
+
+		return false
+	}
+
+	// callConnect calls the operation over the Connect protocol with the API key of the user
+	callConnect := func(httpMethod string, operation string, contentType string, body []byte) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(httpMethod, transportGRPC.ConnectPathPrefix+operation, bytes.NewReader(body))
+		request.Header.Set("Content-Type", contentType)
+		request.Header.Set("X-Api-Key", "test-key")
+		request.Header.Set("X-Request-ID", "test-request")
+
+		recorder := httptest.NewRecorder()
+		sut.(transport.ConnectContract).CreateConnectHandler().ServeHTTP(recorder, request)
+
+		return recorder
+	}
+
+	// decodeConnectError decodes the error the Connect call failed with
+	// Returns the code and the message of the error
+	decodeConnectError := func(recorder *httptest.ResponseRecorder) (string, string) {
+		var connectError struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		}
+
+		Ω(recorder.Header().Get("Content-Type")).Should(Equal("application/json"))
+		Ω(json.Unmarshal(recorder.Body.Bytes(), &connectError)).Should(Succeed())
+
+		return connectError.Code, connectError.Message
+	}
+
+	BeforeEach(func() {
+		reflectionEnabled = false
+		strictDecoding = false
+		readUserErr = nil
+	})
+
+	JustBeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Ω(err).Should(BeNil())
+
+		address = listener.Addr().String()
+		port := listener.Addr().(*net.TCPAddr).Port
+		Ω(listener.Close()).Should(BeNil())
+
+		mockConfigurationService := configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.EXPECT().GetJwksURL().Return("http://127.0.0.1:1/jwks", nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetJwksRefreshInterval().Return(time.Hour, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetAdminEmails().Return([]string{}, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetAdminGroups().Return([]string{}, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetJwtAcceptedIssuers().Return([]string{}, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetJwtAcceptedAudiences().Return([]string{}, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetJwtClaimMapping().Return(map[string]string{}, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetServiceIdentityAllowlist().Return(map[string][]string{}, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetTenancyMode().Return("single", nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetTenancyCrossTenantReadScope().Return("", nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetAuthorizationDecisionLoggingEnabled().Return(false, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetAuthorizationPolicyFile().Return("", nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetGrpcHost().Return("127.0.0.1", nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetGrpcPort().Return(port, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetGrpcShutdownTimeout().Return(5*time.Second, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetGrpcShutdownDrainDelay().Return(drainDelay, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetGrpcReflectionEnabled().Return(reflectionEnabled, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetGrpcStrictDecodingEnabled().Return(strictDecoding, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetGrpcMaxReceiveMessageSize().Return(4*1024*1024, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetSloAvailabilityObjective().Return(0.999, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetSloLatencyObjective().Return(0.99, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetSloLatencyThreshold().Return(50*time.Millisecond, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetSloWindow().Return(30*24*time.Hour, nil).AnyTimes()
+
+		// Every operation is served by the same endpoint, the calls are not made by these tests
+		nopEndpoint := gokitendpoint.Nop
+		mockEndpointCreator := endpointMock.NewMockEndpointCreatorContract(mockCtrl)
+		mockEndpointCreator.EXPECT().ReadUserEndpoint().Return(func(ctx context.Context, request interface{}) (interface{}, error) {
+			return &business.ReadUserResponse{Err: readUserErr}, nil
+		}).AnyTimes()
+
+		mockEndpointCreator.EXPECT().UpdateUserEndpoint().Return(func(ctx context.Context, request interface{}) (interface{}, error) {
+			return &business.UpdateUserResponse{User: request.(*business.UpdateUserRequest).User}, nil
+		}).AnyTimes()
+
+		endpointCreatorType := reflect.TypeOf((*endpoint.EndpointCreatorContract)(nil)).Elem()
+		for index := 0; index < endpointCreatorType.NumMethod(); index++ {
+			mockCtrl.RecordCall(mockEndpointCreator, endpointCreatorType.Method(index).Name).Return(nopEndpoint).AnyTimes()
+		}
+
+		middlewareProviderService, err := middleware.NewMiddlewareProviderService(zap.NewNop(), true, "")
+		Ω(err).Should(BeNil())
+
+		sloService, err := slo.NewSloService(mockConfigurationService)
+		Ω(err).Should(BeNil())
+
+		deprecationService, err := deprecation.NewDeprecationService(protoregistry.GlobalFiles, map[string]string{})
+		Ω(err).Should(BeNil())
+
+		correlationService, err := correlation.NewCorrelationService(zap.NewNop())
+		Ω(err).Should(BeNil())
+
+		redactionService, err := redaction.NewRedactionService([]string{})
+		Ω(err).Should(BeNil())
+
+		mockAPIKeyService := apiKeyMock.NewMockAPIKeyContract(mockCtrl)
+		mockAPIKeyService.EXPECT().ResolveAPIKey(gomock.Any(), "test-key").Return(&models.APIKey{
+			KeyID:  "test",
+			UserID: "test-user",
+			Scopes: []models.APIKeyScope{models.APIKeyScopeRead},
+		}, "user@test.com", nil).AnyTimes()
+
+		mockAPIKeyService.EXPECT().ResolveAPIKey(gomock.Any(), "write-key").Return(&models.APIKey{
+			KeyID:  "write",
+			UserID: "test-user",
+			Scopes: []models.APIKeyScope{models.APIKeyScopeRead, models.APIKeyScopeWrite},
+		}, "user@test.com", nil).AnyTimes()
+
+		payloadLoggingService, err := payloadlogging.NewPayloadLoggingService(zap.NewNop(), false, []string{})
+		Ω(err).Should(BeNil())
+
+		sut, err = transportGRPC.NewTransportService(
+			zap.NewNop(),
+			mockConfigurationService,
+			mockEndpointCreator,
+			middlewareProviderService,
+			sloService,
+			deprecationService,
+			correlationService,
+			redactionService,
+			payloadLoggingService,
+			mockAPIKeyService,
+			groupMock.NewMockGroupContract(mockCtrl))
+		Ω(err).Should(BeNil())
+
+		started = make(chan error, 1)
+		go func() {
+			started <- sut.Start()
+		}()
+
+		Eventually(checkHealth, 5*time.Second, 20*time.Millisecond).Should(Equal(healthpb.HealthCheckResponse_SERVING))
+	})
+
+	AfterEach(func() {
+		Ω(sut.Stop()).Should(BeNil())
+		mockCtrl.Finish()
+	})
+
+	Context("the health of the service is checked", func() {
+		It("should report the user service and the server are serving", func() {
+			for _, serviceName := range []string{"", "user.Service"} {
+				servingStatus, err := checkServiceHealth(serviceName)
+				Ω(err).Should(BeNil())
+				Ω(servingStatus).Should(Equal(healthpb.HealthCheckResponse_SERVING))
+			}
+		})
+
+		When("the service is unknown", func() {
+			It("should return NotFound error", func() {
+				_, err := checkServiceHealth("unknown.Service")
+				Ω(status.Code(err)).Should(Equal(codes.NotFound))
+			})
+		})
+	})
+
+	Context("the request carries fields the service does not know", func() {
+		When("the strict decoding is disabled", func() {
+			It("should drop the unknown fields and process the request", func() {
+				droppedCount := getUnknownFieldsCount("dropped")
+
+				err := updateUser(true, true)
+				Ω(status.Code(err)).ShouldNot(Equal(codes.InvalidArgument))
+				Ω(getUnknownFieldsCount("dropped")).Should(Equal(droppedCount + 1))
+			})
+		})
+
+		When("the strict decoding is enabled", func() {
+			BeforeEach(func() {
+				strictDecoding = true
+			})
+
+			It("should reject the request naming the unknown fields", func() {
+				rejectedCount := getUnknownFieldsCount("rejected")
+
+				err := updateUser(true, false)
+				Ω(status.Code(err)).Should(Equal(codes.InvalidArgument))
+				Ω(status.Convert(err).Message()).Should(ContainSubstring("newer contract: #99 "))
+
+				err = updateUser(false, true)
+				Ω(status.Code(err)).Should(Equal(codes.InvalidArgument))
+				Ω(status.Convert(err).Message()).Should(ContainSubstring("newer contract: user.#99 "))
+
+				err = updateUser(true, true)
+				Ω(status.Code(err)).Should(Equal(codes.InvalidArgument))
+				Ω(status.Convert(err).Message()).Should(ContainSubstring("newer contract: #99, user.#99 "))
+
+				Ω(getUnknownFieldsCount("rejected")).Should(Equal(rejectedCount + 3))
+			})
+
+			It("should process the requests carrying only the known fields", func() {
+				err := updateUser(false, false)
+				Ω(status.Code(err)).ShouldNot(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+
+	Context("the operation fails", func() {
+		It("should attach the reason and the message that is safe to show to the end users", func() {
+			readUserErr = commonErrors.NewNotFoundError()
+
+			response := readUser()
+			Ω(response.Error).Should(Equal(userGRPCContract.Error_USER_NOT_FOUND))
+
+			errorInfo := &errdetails.ErrorInfo{}
+			Ω(getErrorDetail(response, errorInfo)).Should(BeTrue())
+			Ω(errorInfo.Reason).Should(Equal("USER_NOT_FOUND"))
+			Ω(errorInfo.Domain).Should(Equal("user.decentralized-cloud"))
+
+			localizedMessage := &errdetails.LocalizedMessage{}
+			Ω(getErrorDetail(response, localizedMessage)).Should(BeTrue())
+			Ω(localizedMessage.Locale).Should(Equal("en-US"))
+			Ω(localizedMessage.Message).Should(Equal("The user does not exist."))
+
+			Ω(getErrorDetail(response, &errdetails.BadRequest{})).Should(BeFalse())
+			Ω(getErrorDetail(response, &errdetails.RetryInfo{})).Should(BeFalse())
+		})
+
+		When("the request is invalid", func() {
+			It("should attach the field violations by their path along the code of every failure", func() {
+				readUserErr = business.NewRequestValidationError(validation.Errors{
+					"email": errors.New("cannot be blank"),
+					"user": validation.Errors{
+						"emails": validation.Errors{"0": errors.New("must be a valid email address")},
+					},
+				})
+
+				response := readUser()
+				Ω(response.Error).Should(Equal(userGRPCContract.Error_BAD_REQUEST))
+
+				badRequest := &errdetails.BadRequest{}
+				Ω(getErrorDetail(response, badRequest)).Should(BeTrue())
+				Ω(badRequest.FieldViolations).Should(HaveLen(2))
+				Ω(badRequest.FieldViolations[0].Field).Should(Equal("email"))
+				Ω(badRequest.FieldViolations[0].Description).Should(Equal("cannot be blank"))
+				Ω(badRequest.FieldViolations[1].Field).Should(Equal("user.emails.0"))
+				Ω(badRequest.FieldViolations[1].Description).Should(Equal("must be a valid email address"))
+
+				validationErrors := &userGRPCContract.ValidationErrors{}
+				Ω(getErrorDetail(response, validationErrors)).Should(BeTrue())
+				Ω(validationErrors.Errors).Should(HaveLen(2))
+				Ω(validationErrors.Errors[0].Field).Should(Equal("email"))
+				Ω(validationErrors.Errors[0].Code).Should(Equal(business.ValidationCodeRequired))
+				Ω(validationErrors.Errors[1].Field).Should(Equal("user.emails.0"))
+				Ω(validationErrors.Errors[1].Code).Should(Equal(business.ValidationCodeFormat))
+			})
+		})
+
+		When("an argument is missing", func() {
+			It("should attach the field violation of the argument", func() {
+				readUserErr = commonErrors.NewArgumentNilError("email", "email is required")
+
+				badRequest := &errdetails.BadRequest{}
+				Ω(getErrorDetail(readUser(), badRequest)).Should(BeTrue())
+				Ω(badRequest.FieldViolations).Should(HaveLen(1))
+				Ω(badRequest.FieldViolations[0].Field).Should(Equal("email"))
+			})
+		})
+
+		When("the limit of the API keys is exceeded", func() {
+			It("should attach the quota failure", func() {
+				readUserErr = apikey.NewLimitExceededError("user@test.com", 10)
+
+				response := readUser()
+				Ω(response.Error).Should(Equal(userGRPCContract.Error_API_KEY_LIMIT_EXCEEDED))
+
+				quotaFailure := &errdetails.QuotaFailure{}
+				Ω(getErrorDetail(response, quotaFailure)).Should(BeTrue())
+				Ω(quotaFailure.Violations).Should(HaveLen(1))
+				Ω(quotaFailure.Violations[0].Subject).Should(Equal("apiKeys"))
+			})
+		})
+
+		When("the user has not accepted the latest policies", func() {
+			It("should attach the policies to accept as the precondition failure", func() {
+				readUserErr = consent.NewConsentRequiredError("user@test.com", []models.PolicyVersion{
+					{Policy: "terms", Version: "2"},
+					{Policy: "privacy", Version: "3"},
+				})
+
+				response := readUser()
+				Ω(response.Error).Should(Equal(userGRPCContract.Error_CONSENT_REQUIRED))
+
+				preconditionFailure := &errdetails.PreconditionFailure{}
+				Ω(getErrorDetail(response, preconditionFailure)).Should(BeTrue())
+				Ω(preconditionFailure.Violations).Should(HaveLen(2))
+				Ω(preconditionFailure.Violations[0].Type).Should(Equal("CONSENT"))
+				Ω(preconditionFailure.Violations[0].Subject).Should(Equal("terms"))
+				Ω(preconditionFailure.Violations[1].Subject).Should(Equal("privacy"))
+			})
+		})
+
+		When("a dependency of the service is not reachable", func() {
+			It("should ask the clients to retry after a while", func() {
+				readUserErr = repository.NewDependencyUnavailableError("database", 3, commonErrors.NewUnknownError("connection refused"))
+
+				response := readUser()
+				Ω(response.Error).Should(Equal(userGRPCContract.Error_DEPENDENCY_UNAVAILABLE))
+
+				retryInfo := &errdetails.RetryInfo{}
+				Ω(getErrorDetail(response, retryInfo)).Should(BeTrue())
+				Ω(retryInfo.RetryDelay.AsDuration()).Should(Equal(5 * time.Second))
+			})
+		})
+	})
+
+	Context("the user is sent and returned", func() {
+		It("should decode every field of the user and encode it back as it is", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			connection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+			Ω(err).Should(BeNil())
+
+			defer connection.Close()
+
+			joinedAt := timestamppb.New(time.Date(2021, 1, 2, 3, 4, 5, 6, time.UTC))
+			lockedAt := timestamppb.New(time.Date(2021, 2, 3, 4, 5, 6, 7, time.UTC))
+			createdAt := timestamppb.New(time.Date(2020, 3, 4, 5, 6, 7, 8, time.UTC))
+			updatedAt := timestamppb.New(time.Date(2021, 4, 5, 6, 7, 8, 9, time.UTC))
+			user := &userGRPCContract.User{
+				UserID: "user-id",
+				Memberships: []*userGRPCContract.TenantMembership{
+					{TenantID: "tenant-1", Role: "admin", JoinedAt: joinedAt},
+					{TenantID: "tenant-2", Role: "member", JoinedAt: joinedAt},
+				},
+				DataResidency:       "eu",
+				EmailVerified:       true,
+				Locked:              true,
+				LockedAt:            lockedAt,
+				FailedLoginAttempts: 5,
+				Labels:              map[string]string{"region": "eu", "plan": "pro"},
+				CreatedAt:           createdAt,
+				UpdatedAt:           updatedAt,
+				CreatedBy:           "creator@test.com",
+				UpdatedBy:           "updater@test.com",
+			}
+
+			ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", "write-key")
+			response, err := userGRPCContract.NewServiceClient(connection).UpdateUser(ctx, &userGRPCContract.UpdateUserRequest{
+				Email: "user@test.com",
+				User:  user,
+			})
+			Ω(err).Should(BeNil())
+			Ω(response.Error).Should(Equal(userGRPCContract.Error_NO_ERROR), response.ErrorMessage)
+			Ω(proto.Equal(response.User, user)).Should(BeTrue(), response.User.String())
+
+			response, err = userGRPCContract.NewServiceClient(connection).UpdateUser(ctx, &userGRPCContract.UpdateUserRequest{
+				Email: "user@test.com",
+			})
+			Ω(err).Should(BeNil())
+			Ω(proto.Equal(response.User, &userGRPCContract.User{})).Should(BeTrue(), response.User.String())
+		})
+	})
+
+	Context("the operations are called over the Connect protocol", func() {
+		BeforeEach(func() {
+			readUserErr = commonErrors.NewNotFoundError()
+		})
+
+		It("should serve the operation encoding the request and the response as JSON", func() {
+			recorder := callConnect(http.MethodPost, "ReadUser", "application/json", []byte(`{"email":"user@test.com"}`))
+			Ω(recorder.Code).Should(Equal(http.StatusOK))
+			Ω(recorder.Header().Get("Content-Type")).Should(Equal("application/json"))
+			Ω(recorder.Header().Get("X-Request-Id")).Should(Equal("test-request"))
+
+			var response map[string]interface{}
+			Ω(json.Unmarshal(recorder.Body.Bytes(), &response)).Should(Succeed())
+			Ω(response["error"]).Should(Equal("USER_NOT_FOUND"))
+		})
+
+		It("should serve the operation encoding the request and the response as binary protobuf", func() {
+			body, err := proto.Marshal(&userGRPCContract.ReadUserRequest{Email: "user@test.com"})
+			Ω(err).Should(BeNil())
+
+			recorder := callConnect(http.MethodPost, "ReadUser", "application/proto", body)
+			Ω(recorder.Code).Should(Equal(http.StatusOK))
+			Ω(recorder.Header().Get("Content-Type")).Should(Equal("application/proto"))
+
+			response := &userGRPCContract.ReadUserResponse{}
+			Ω(proto.Unmarshal(recorder.Body.Bytes(), response)).Should(Succeed())
+			Ω(response.Error).Should(Equal(userGRPCContract.Error_USER_NOT_FOUND))
+		})
+
+		When("the caller is not allowed to call the operation", func() {
+			It("should return the error the authorization fails with", func() {
+				recorder := callConnect(http.MethodPost, "ReadUser", "application/json", []byte(`{"email":"other@test.com"}`))
+				Ω(recorder.Code).Should(Equal(http.StatusUnauthorized))
+
+				code, _ := decodeConnectError(recorder)
+				Ω(code).Should(Equal("unauthenticated"))
+			})
+		})
+
+		When("the request is not a POST request", func() {
+			It("should return method not allowed", func() {
+				recorder := callConnect(http.MethodGet, "ReadUser", "application/json", nil)
+				Ω(recorder.Code).Should(Equal(http.StatusMethodNotAllowed))
+				Ω(recorder.Header().Get("Allow")).Should(Equal(http.MethodPost))
+			})
+		})
+
+		When("the request is not encoded as JSON or binary protobuf", func() {
+			It("should return unsupported media type", func() {
+				recorder := callConnect(http.MethodPost, "ReadUser", "text/plain", []byte("user@test.com"))
+				Ω(recorder.Code).Should(Equal(http.StatusUnsupportedMediaType))
+				Ω(recorder.Header().Get("Accept-Post")).Should(Equal("application/json, application/proto"))
+			})
+		})
+
+		When("the operation is not served over the Connect protocol", func() {
+			It("should return unimplemented error", func() {
+				for _, operation := range []string{"StreamSearchUsers", "Unknown"} {
+					recorder := callConnect(http.MethodPost, operation, "application/json", []byte(`{}`))
+					Ω(recorder.Code).Should(Equal(http.StatusNotImplemented), operation)
+
+					code, message := decodeConnectError(recorder)
+					Ω(code).Should(Equal("unimplemented"), operation)
+					Ω(message).Should(ContainSubstring(operation))
+				}
+			})
+		})
+
+		When("the request can not be decoded", func() {
+			It("should return invalid argument error", func() {
+				recorder := callConnect(http.MethodPost, "ReadUser", "application/json", []byte(`{"email":`))
+				Ω(recorder.Code).Should(Equal(http.StatusBadRequest))
+
+				code, _ := decodeConnectError(recorder)
+				Ω(code).Should(Equal("invalid_argument"))
+			})
+		})
+	})
+
+	Context("the reflection is disabled", func() {
+		It("should not serve the reflection service", func() {
+			_, err := listServices()
+			Ω(status.Code(err)).Should(Equal(codes.Unimplemented))
+		})
+	})
+
+	Context("the reflection is enabled", func() {
+		BeforeEach(func() {
+			reflectionEnabled = true
+		})
+
+		It("should list the user service and the health service", func() {
+			serviceNames, err := listServices()
+			Ω(err).Should(BeNil())
+			Ω(serviceNames).Should(ContainElement("user.Service"))
+			Ω(serviceNames).Should(ContainElement("grpc.health.v1.Health"))
+		})
+	})
+
+	Context("the service is stopped", func() {
+		It("should report it is not serving and keep accepting the calls for the drain delay before it stops", func() {
+			stopped := make(chan struct{})
+			stopStartedAt := time.Now()
+			go func() {
+				defer GinkgoRecover()
+
+				Ω(sut.Stop()).Should(BeNil())
+				close(stopped)
+			}()
+
+			// The listener is still open, so the load balancers can observe the service is not serving
+			Eventually(checkHealth, drainDelay/2, 20*time.Millisecond).Should(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+			Ω(stopped).ShouldNot(BeClosed())
+
+			Eventually(stopped, 5*time.Second).Should(BeClosed())
+			Ω(time.Since(stopStartedAt)).Should(BeNumerically(">=", drainDelay))
+			Eventually(started).Should(Receive(BeNil()))
+
+			_, err := checkHealth()
+			Ω(err).ShouldNot(BeNil())
+		})
+	})
+})
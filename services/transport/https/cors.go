@@ -0,0 +1,95 @@
+// Package https implements functions to expose user service endpoint using HTTPS protocol.
+package https
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/correlation"
+	"github.com/decentralized-cloud/user/services/deprecation"
+	"github.com/savsgio/atreugo/v11"
+)
+
+// corsAllowedHeaders are the headers the browsers are always allowed to send, on top of the configured ones
+var corsAllowedHeaders = []string{
+	"Authorization",
+	"Content-Type",
+	"Connect-Protocol-Version",
+	"Connect-Timeout-Ms",
+	"X-Api-Key",
+	correlation.RequestIDHeader,
+	deprecation.ClientNameHeader,
+}
+
+// corsExposedHeaders are the response headers the browsers let the web applications read
+var corsExposedHeaders = []string{
+	correlation.RequestIDHeader,
+	deprecation.DeprecationHeader,
+	deprecation.WarningHeader,
+}
+
+type corsPolicy struct {
+	allowAllOrigins bool
+	origins         map[string]bool
+	allowedMethods  string
+	allowedHeaders  string
+	exposedHeaders  string
+	maxAge          string
+}
+
+// newCORSPolicy creates the policy the browsers are allowed to call the HTTP endpoints with
+// allowedOrigins: Optional. The origins allowed to call the endpoints, * allows every origin
+// allowedMethods: Mandatory. The methods the browsers are allowed to call the endpoints with
+// allowedHeaders: Optional. The headers the browsers are allowed to send on top of the ones the service always allows
+// maxAge: Mandatory. How long the browsers cache the result of the preflight requests
+// Returns the new policy
+func newCORSPolicy(allowedOrigins, allowedMethods, allowedHeaders []string, maxAge time.Duration) *corsPolicy {
+	policy := &corsPolicy{
+		origins:        map[string]bool{},
+		allowedMethods: strings.Join(allowedMethods, ", "),
+		allowedHeaders: strings.Join(append(append([]string{}, corsAllowedHeaders...), allowedHeaders...), ", "),
+		exposedHeaders: strings.Join(corsExposedHeaders, ", "),
+		maxAge:         strconv.Itoa(int(maxAge.Seconds())),
+	}
+
+	for _, origin := range allowedOrigins {
+		if origin == "*" {
+			policy.allowAllOrigins = true
+		}
+
+		policy.origins[origin] = true
+	}
+
+	return policy
+}
+
+// middleware lets the browsers call the endpoints from the allowed origins and answers their preflight requests. The
+// requests from the other origins are still served, the browsers refuse to hand their responses to the web application.
+func (policy *corsPolicy) middleware(ctx *atreugo.RequestCtx) error {
+	origin := string(ctx.Request.Header.Peek("Origin"))
+	allowed := origin != "" && (policy.allowAllOrigins || policy.origins[origin])
+
+	ctx.Response.Header.Add("Vary", "Origin")
+
+	if allowed {
+		ctx.Response.Header.Set("Access-Control-Allow-Origin", origin)
+		ctx.Response.Header.Set("Access-Control-Expose-Headers", policy.exposedHeaders)
+	}
+
+	if string(ctx.Method()) == http.MethodOptions && len(ctx.Request.Header.Peek("Access-Control-Request-Method")) > 0 {
+		if allowed {
+			ctx.Response.Header.Set("Access-Control-Allow-Methods", policy.allowedMethods)
+			ctx.Response.Header.Set("Access-Control-Allow-Headers", policy.allowedHeaders)
+			ctx.Response.Header.Set("Access-Control-Max-Age", policy.maxAge)
+		}
+
+		// The preflight request is answered here, so the view the path is registered with is not called
+		ctx.Response.SetStatusCode(http.StatusNoContent)
+
+		return nil
+	}
+
+	return ctx.Next()
+}
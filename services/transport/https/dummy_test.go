@@ -1 +0,0 @@
-package https_test
@@ -0,0 +1,141 @@
+// Package https implements functions to expose user service endpoint using HTTPS protocol.
+package https
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/repository"
+	"github.com/decentralized-cloud/user/services/transport/grpc"
+	"github.com/savsgio/atreugo/v11"
+	"go.uber.org/zap"
+)
+
+const (
+	healthStatusUp   = "UP"
+	healthStatusDown = "DOWN"
+
+	// healthReasonDependencyUnavailable reports the dependency is not reached yet since the service started
+	healthReasonDependencyUnavailable = "DependencyUnavailable"
+
+	dependencyCheckTimeout = 3 * time.Second
+)
+
+type dependencyHealth struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type healthReport struct {
+	Status string                      `json:"status"`
+	Live   bool                        `json:"live"`
+	Ready  bool                        `json:"ready"`
+	Checks map[string]dependencyHealth `json:"checks"`
+}
+
+// livenessCheckHandler reports whether the process is alive. It deliberately ignores the dependencies
+// so an unavailable database does not get the pod restarted.
+func (service *transportService) livenessCheckHandler(ctx *atreugo.RequestCtx) error {
+	if grpc.Live {
+		ctx.Response.SetStatusCode(http.StatusOK)
+	} else {
+		ctx.Response.SetStatusCode(http.StatusServiceUnavailable)
+	}
+
+	return nil
+}
+
+// readinessCheckHandler reports whether the service can serve the traffic, that is the gRPC server is
+// ready and all the dependencies are reachable
+func (service *transportService) readinessCheckHandler(ctx *atreugo.RequestCtx) error {
+	if report := service.checkHealth(); report.Ready && report.Status == healthStatusUp {
+		ctx.Response.SetStatusCode(http.StatusOK)
+	} else {
+		ctx.Response.SetStatusCode(http.StatusServiceUnavailable)
+	}
+
+	return nil
+}
+
+// healthCheckHandler returns the detailed health report of the service and its dependencies
+func (service *transportService) healthCheckHandler(ctx *atreugo.RequestCtx) error {
+	report := service.checkHealth()
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	if report.Live && report.Ready && report.Status == healthStatusUp {
+		ctx.Response.SetStatusCode(http.StatusOK)
+	} else {
+		ctx.Response.SetStatusCode(http.StatusServiceUnavailable)
+	}
+
+	ctx.Response.Header.SetContentType("application/json")
+	ctx.Response.SetBody(body)
+
+	return nil
+}
+
+func (service *transportService) checkHealth() healthReport {
+	ctx, cancel := context.WithTimeout(context.Background(), dependencyCheckTimeout)
+	defer cancel()
+
+	report := healthReport{
+		Status: healthStatusUp,
+		Live:   grpc.Live,
+		Ready:  grpc.Ready,
+		Checks: map[string]dependencyHealth{
+			"database": service.checkDependency("database", service.repositoryService.Ping(ctx)),
+			"jwks":     service.checkDependency("jwks", service.checkJwks(ctx)),
+		},
+	}
+
+	for _, check := range report.Checks {
+		if check.Status != healthStatusUp {
+			report.Status = healthStatusDown
+		}
+	}
+
+	return report
+}
+
+func (service *transportService) checkDependency(name string, err error) dependencyHealth {
+	if err != nil {
+		service.logger.Warn("dependency health check failed", zap.String("dependency", name), zap.Error(err))
+
+		health := dependencyHealth{Status: healthStatusDown, Error: err.Error()}
+		if repository.IsDependencyUnavailableError(err) {
+			health.Reason = healthReasonDependencyUnavailable
+		}
+
+		return health
+	}
+
+	return dependencyHealth{Status: healthStatusUp}
+}
+
+func (service *transportService) checkJwks(ctx context.Context) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, service.jwksURL, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := service.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("JWKS endpoint returned status code %d", response.StatusCode)
+	}
+
+	return nil
+}
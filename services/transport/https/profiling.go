@@ -0,0 +1,58 @@
+// Package https implements functions to expose user service endpoint using HTTPS protocol.
+package https
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime/pprof"
+
+	"github.com/micro-business/go-core/pkg/util"
+	"github.com/savsgio/atreugo/v11"
+)
+
+// profiles contains the runtime profiles the HTTP server serves under /debug/pprof when the profiling is enabled
+var profiles = []string{"goroutine", "heap", "allocs", "threadcreate", "block", "mutex"}
+
+// versionHandler returns the version the service is built from
+func (service *transportService) versionHandler(ctx *atreugo.RequestCtx) error {
+	body, err := json.Marshal(util.GetVersion())
+	if err != nil {
+		return err
+	}
+
+	ctx.Response.SetStatusCode(http.StatusOK)
+	ctx.Response.Header.SetContentType("application/json")
+	ctx.Response.SetBody(body)
+
+	return nil
+}
+
+// createProfileHandler creates the handler that writes the runtime profile in the format go tool pprof reads, or in
+// the human readable text format if the debug query argument is set
+// name: Mandatory. The name of the runtime profile
+// Returns the handler
+func (service *transportService) createProfileHandler(name string) atreugo.View {
+	return func(ctx *atreugo.RequestCtx) error {
+		profile := pprof.Lookup(name)
+		if profile == nil {
+			ctx.Response.SetStatusCode(http.StatusNotFound)
+
+			return nil
+		}
+
+		debug, err := ctx.QueryArgs().GetUint("debug")
+		if err != nil {
+			debug = 0
+		}
+
+		if debug == 0 {
+			ctx.Response.Header.SetContentType("application/octet-stream")
+		} else {
+			ctx.Response.Header.SetContentType("text/plain; charset=utf-8")
+		}
+
+		ctx.Response.SetStatusCode(http.StatusOK)
+
+		return profile.WriteTo(ctx, debug)
+	}
+}
@@ -0,0 +1,55 @@
+// Package https implements functions to expose user service endpoint using HTTPS protocol.
+package https
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/savsgio/atreugo/v11"
+)
+
+type securityHeaders struct {
+	strictTransportSecurity string
+	contentSecurityPolicy   string
+}
+
+// newSecurityHeaders creates the security headers every HTTP response is sent with
+// hstsMaxAge: Optional. How long the browsers only call the host over HTTPS, Strict-Transport-Security is not sent if zero
+// hstsIncludeSubdomains: Mandatory. Whether the browsers only call the subdomains of the host over HTTPS too
+// contentSecurityPolicy: Optional. The Content-Security-Policy of the responses, not sent if empty
+// Returns the new security headers
+func newSecurityHeaders(
+	hstsMaxAge time.Duration,
+	hstsIncludeSubdomains bool,
+	contentSecurityPolicy string) *securityHeaders {
+	headers := &securityHeaders{
+		contentSecurityPolicy: contentSecurityPolicy,
+	}
+
+	if hstsMaxAge > 0 {
+		headers.strictTransportSecurity = "max-age=" + strconv.Itoa(int(hstsMaxAge.Seconds()))
+		if hstsIncludeSubdomains {
+			headers.strictTransportSecurity += "; includeSubDomains"
+		}
+	}
+
+	return headers
+}
+
+// middleware sets the security headers before the view is called, so the responses of every endpoint are sent with
+// them. The endpoints only return JSON and text, so the browsers neither sniff their type nor frame them.
+func (headers *securityHeaders) middleware(ctx *atreugo.RequestCtx) error {
+	ctx.Response.Header.Set("X-Content-Type-Options", "nosniff")
+	ctx.Response.Header.Set("X-Frame-Options", "DENY")
+	ctx.Response.Header.Set("Referrer-Policy", "no-referrer")
+
+	if headers.contentSecurityPolicy != "" {
+		ctx.Response.Header.Set("Content-Security-Policy", headers.contentSecurityPolicy)
+	}
+
+	if headers.strictTransportSecurity != "" {
+		ctx.Response.Header.Set("Strict-Transport-Security", headers.strictTransportSecurity)
+	}
+
+	return ctx.Next()
+}
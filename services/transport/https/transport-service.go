@@ -2,10 +2,12 @@
 package https
 
 import (
-	"fmt"
+	"net"
 	"net/http"
+	"strconv"
 
 	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/repository"
 	"github.com/decentralized-cloud/user/services/transport"
 	"github.com/decentralized-cloud/user/services/transport/grpc"
 	commonErrors "github.com/micro-business/go-core/system/errors"
@@ -16,16 +18,27 @@ import (
 
 type transportService struct {
 	logger               *zap.Logger
+	logLevel             *zap.AtomicLevel
 	configurationService configuration.ConfigurationContract
+	repositoryService    repository.RepositoryContract
+	connectService       transport.ConnectContract
+	jwksURL              string
+	httpClient           *http.Client
 }
 
 // NewTransportService creates new instance of the transportService, setting up all dependencies and returns the instance
 // logger: Mandatory. Reference to the logger service
+// logLevel: Optional. Reference to the level the logger logs at, the level can not be changed over HTTP if not provided
 // configurationService: Mandatory. Reference to the service that provides required configurations
+// repositoryService: Mandatory. Reference to the repository service that is checked for the database connectivity
+// connectService: Mandatory. Reference to the transport service that serves the operations over the Connect protocol
 // Returns the new service or error if something goes wrong
 func NewTransportService(
 	logger *zap.Logger,
-	configurationService configuration.ConfigurationContract) (transport.TransportContract, error) {
+	logLevel *zap.AtomicLevel,
+	configurationService configuration.ConfigurationContract,
+	repositoryService repository.RepositoryContract,
+	connectService transport.ConnectContract) (transport.TransportContract, error) {
 	if logger == nil {
 		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
 	}
@@ -34,16 +47,34 @@ func NewTransportService(
 		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
 	}
 
+	if repositoryService == nil {
+		return nil, commonErrors.NewArgumentNilError("repositoryService", "repositoryService is required")
+	}
+
+	if connectService == nil {
+		return nil, commonErrors.NewArgumentNilError("connectService", "connectService is required")
+	}
+
+	jwksURL, err := configurationService.GetJwksURL()
+	if err != nil {
+		return nil, err
+	}
+
 	return &transportService{
 		logger:               logger,
+		logLevel:             logLevel,
 		configurationService: configurationService,
+		repositoryService:    repositoryService,
+		connectService:       connectService,
+		jwksURL:              jwksURL,
+		httpClient:           &http.Client{Timeout: dependencyCheckTimeout},
 	}, nil
 }
 
 // Start starts the GraphQL transport service
 // Returns error if something goes wrong
 func (service *transportService) Start() error {
-	config := atreugo.Config{GracefulShutdown: true}
+	config := atreugo.Config{GracefulShutdown: true, Network: "tcp"}
 	var err error
 
 	host, err := service.configurationService.GetHttpHost()
@@ -56,39 +87,114 @@ func (service *transportService) Start() error {
 		return err
 	}
 
-	config.Addr = fmt.Sprintf("%s:%d", host, port)
+	config.Addr = net.JoinHostPort(host, strconv.Itoa(port))
 	server := atreugo.New(config)
 
+	securityHeaders, err := service.createSecurityHeaders()
+	if err != nil {
+		return err
+	}
+
+	corsPolicy, err := service.createCORSPolicy()
+	if err != nil {
+		return err
+	}
+
+	// The security headers are set first, so the answered preflight requests are sent with them too
+	server.UseBefore(securityHeaders.middleware, corsPolicy.middleware)
+
 	server.Path("GET", "/live", service.livenessCheckHandler)
 	server.Path("GET", "/ready", service.readinessCheckHandler)
+	server.Path("GET", "/health", service.healthCheckHandler)
 	server.NetHTTPPath("GET", "/metrics", promhttp.Handler())
-	service.logger.Info("HTTPS service started", zap.String("address", config.Addr))
+	server.Path("GET", "/version", service.versionHandler)
+
+	profilingEnabled, err := service.configurationService.GetHttpProfilingEnabled()
+	if err != nil {
+		return err
+	}
+
+	// The profiles reveal the internals of the service, so they are only served when asked for
+	if profilingEnabled {
+		for _, profile := range profiles {
+			server.Path("GET", "/debug/pprof/"+profile, service.createProfileHandler(profile))
+		}
+	}
+
+	logLevelEndpointEnabled, err := service.configurationService.GetLogLevelEndpointEnabled()
+	if err != nil {
+		return err
+	}
+
+	// The endpoint is not authenticated, so the level can only be changed over HTTP when asked for. The level is only
+	// known when the logger is created from the configuration, not when the logger is provided by the caller.
+	if logLevelEndpointEnabled && service.logLevel != nil {
+		server.NetHTTPPath("GET", "/debug/log-level", service.logLevel)
+		server.NetHTTPPath("PUT", "/debug/log-level", service.logLevel)
+	}
+
+	connectEnabled, err := service.configurationService.GetConnectEnabled()
+	if err != nil {
+		return err
+	}
+
+	// The browsers cannot speak gRPC, so the web console calls the same operations over the Connect protocol instead
+	if connectEnabled {
+		server.NetHTTPPath("POST", grpc.ConnectPathPrefix+"{method}", service.connectService.CreateConnectHandler())
+	}
+
+	service.logger.Info("HTTPS service started", zap.String("address", config.Addr), zap.Bool("connect", connectEnabled))
 
 	return server.ListenAndServe()
 }
 
-// Stop stops the GraphQL transport service
-// Returns error if something goes wrong
-func (service *transportService) Stop() error {
-	return nil
-}
+// createCORSPolicy creates the CORS policy of the HTTP endpoints from the configuration
+func (service *transportService) createCORSPolicy() (*corsPolicy, error) {
+	allowedOrigins, err := service.configurationService.GetCorsAllowedOrigins()
+	if err != nil {
+		return nil, err
+	}
+
+	allowedMethods, err := service.configurationService.GetCorsAllowedMethods()
+	if err != nil {
+		return nil, err
+	}
 
-func (service *transportService) livenessCheckHandler(ctx *atreugo.RequestCtx) error {
-	if grpc.Live {
-		ctx.Response.SetStatusCode(http.StatusOK)
-	} else {
-		ctx.Response.SetStatusCode(http.StatusServiceUnavailable)
+	allowedHeaders, err := service.configurationService.GetCorsAllowedHeaders()
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	maxAge, err := service.configurationService.GetCorsMaxAge()
+	if err != nil {
+		return nil, err
+	}
+
+	return newCORSPolicy(allowedOrigins, allowedMethods, allowedHeaders, maxAge), nil
 }
 
-func (service *transportService) readinessCheckHandler(ctx *atreugo.RequestCtx) error {
-	if grpc.Ready {
-		ctx.Response.SetStatusCode(http.StatusOK)
-	} else {
-		ctx.Response.SetStatusCode(http.StatusServiceUnavailable)
+// createSecurityHeaders creates the security headers of the HTTP responses from the configuration
+func (service *transportService) createSecurityHeaders() (*securityHeaders, error) {
+	hstsMaxAge, err := service.configurationService.GetHstsMaxAge()
+	if err != nil {
+		return nil, err
+	}
+
+	hstsIncludeSubdomains, err := service.configurationService.GetHstsIncludeSubdomains()
+	if err != nil {
+		return nil, err
 	}
 
+	contentSecurityPolicy, err := service.configurationService.GetContentSecurityPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	return newSecurityHeaders(hstsMaxAge, hstsIncludeSubdomains, contentSecurityPolicy), nil
+}
+
+// Stop stops the GraphQL transport service
+// Returns error if something goes wrong
+func (service *transportService) Stop() error {
 	return nil
 }
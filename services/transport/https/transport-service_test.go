@@ -0,0 +1,363 @@
+package https_test
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	repositoryMock "github.com/decentralized-cloud/user/services/repository/mock"
+	"github.com/decentralized-cloud/user/services/transport"
+	"github.com/decentralized-cloud/user/services/transport/https"
+	transportMock "github.com/decentralized-cloud/user/services/transport/mock"
+	"github.com/golang/mock/gomock"
+	"go.uber.org/zap"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestHTTPSTransportService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HTTPS Transport Service Tests")
+}
+
+var _ = Describe("HTTPS Transport Service Tests", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		sut              transport.TransportContract
+		baseURL          string
+		profilingEnabled bool
+		connectEnabled   bool
+		allowedOrigins   []string
+		allowedHeaders   []string
+		hstsMaxAge       time.Duration
+		hstsSubdomains   bool
+		securityPolicy   string
+	)
+
+	// get sends the GET request to the given path of the HTTP server
+	get := func(path string) (*http.Response, string) {
+		response, err := http.Get(baseURL + path)
+		Ω(err).Should(BeNil())
+
+		defer response.Body.Close()
+
+		body, err := ioutil.ReadAll(response.Body)
+		Ω(err).Should(BeNil())
+
+		return response, string(body)
+	}
+
+	// send sends the request with the given headers to the given path of the HTTP server
+	send := func(method string, path string, headers map[string]string) (*http.Response, string) {
+		request, err := http.NewRequest(method, baseURL+path, strings.NewReader("{}"))
+		Ω(err).Should(BeNil())
+
+		for name, value := range headers {
+			request.Header.Set(name, value)
+		}
+
+		response, err := http.DefaultClient.Do(request)
+		Ω(err).Should(BeNil())
+
+		defer response.Body.Close()
+
+		body, err := ioutil.ReadAll(response.Body)
+		Ω(err).Should(BeNil())
+
+		return response, string(body)
+	}
+
+	BeforeEach(func() {
+		profilingEnabled = false
+		connectEnabled = false
+		allowedOrigins = []string{}
+		allowedHeaders = []string{}
+		hstsMaxAge = 0
+		hstsSubdomains = false
+		securityPolicy = ""
+	})
+
+	JustBeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Ω(err).Should(BeNil())
+
+		port := listener.Addr().(*net.TCPAddr).Port
+		baseURL = "http://127.0.0.1:" + strconv.Itoa(port)
+		Ω(listener.Close()).Should(BeNil())
+
+		mockConfigurationService := configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.EXPECT().GetJwksURL().Return("http://127.0.0.1:1/jwks", nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetHttpHost().Return("127.0.0.1", nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetHttpPort().Return(port, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetHstsMaxAge().Return(hstsMaxAge, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetHstsIncludeSubdomains().Return(hstsSubdomains, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetContentSecurityPolicy().Return(securityPolicy, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetCorsAllowedOrigins().Return(allowedOrigins, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetCorsAllowedMethods().Return([]string{"GET", "POST"}, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetCorsAllowedHeaders().Return(allowedHeaders, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetCorsMaxAge().Return(time.Hour, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetHttpProfilingEnabled().Return(profilingEnabled, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetLogLevelEndpointEnabled().Return(false, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetConnectEnabled().Return(connectEnabled, nil).AnyTimes()
+
+		// The Connect handler echoes the operation it is called for
+		mockConnectService := transportMock.NewMockConnectContract(mockCtrl)
+		mockConnectService.EXPECT().CreateConnectHandler().Return(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			writer.Header().Set("Content-Type", "application/json")
+			_, _ = writer.Write([]byte(`{"path":"` + request.URL.Path + `"}`))
+		})).AnyTimes()
+
+		sut, err = https.NewTransportService(
+			zap.NewNop(),
+			nil,
+			mockConfigurationService,
+			repositoryMock.NewMockRepositoryContract(mockCtrl),
+			mockConnectService)
+		Ω(err).Should(BeNil())
+
+		// The server is not stopped by Stop, every test starts its own server on a free port instead
+		go func() {
+			defer GinkgoRecover()
+			Ω(sut.Start()).Should(BeNil())
+		}()
+
+		Eventually(func() error {
+			response, err := http.Get(baseURL + "/live")
+			if err == nil {
+				response.Body.Close()
+			}
+
+			return err
+		}, 5*time.Second, 20*time.Millisecond).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		Ω(sut.Stop()).Should(BeNil())
+		mockCtrl.Finish()
+	})
+
+	Context("the version is requested", func() {
+		It("should return the version the service is built from", func() {
+			response, _ := get("/version")
+			Ω(response.StatusCode).Should(Equal(http.StatusOK))
+			Ω(response.Header.Get("Content-Type")).Should(Equal("application/json"))
+		})
+	})
+
+	Context("the browsers call the endpoints", func() {
+		BeforeEach(func() {
+			allowedOrigins = []string{"https://console.test", "https://admin.test"}
+			allowedHeaders = []string{"X-Tenant-ID"}
+		})
+
+		It("should let the allowed origins read the responses", func() {
+			for _, origin := range allowedOrigins {
+				response, _ := send(http.MethodGet, "/version", map[string]string{"Origin": origin})
+				Ω(response.StatusCode).Should(Equal(http.StatusOK), origin)
+				Ω(response.Header.Get("Access-Control-Allow-Origin")).Should(Equal(origin))
+				Ω(response.Header.Get("Access-Control-Expose-Headers")).Should(Equal("X-Request-ID, deprecation, x-deprecation-warning"))
+				Ω(response.Header.Values("Vary")).Should(ContainElement("Origin"))
+			}
+		})
+
+		It("should answer the preflight requests with the configured methods, headers and max age", func() {
+			response, _ := send(http.MethodOptions, "/version", map[string]string{
+				"Origin":                        "https://console.test",
+				"Access-Control-Request-Method": "GET",
+			})
+			Ω(response.StatusCode).Should(Equal(http.StatusNoContent))
+			Ω(response.Header.Get("Access-Control-Allow-Origin")).Should(Equal("https://console.test"))
+			Ω(response.Header.Get("Access-Control-Allow-Methods")).Should(Equal("GET, POST"))
+			Ω(response.Header.Get("Access-Control-Allow-Headers")).Should(HavePrefix("Authorization, Content-Type, "))
+			Ω(response.Header.Get("Access-Control-Allow-Headers")).Should(HaveSuffix(", X-Tenant-ID"))
+			Ω(response.Header.Get("Access-Control-Max-Age")).Should(Equal("3600"))
+		})
+
+		When("the origin is not allowed", func() {
+			It("should serve the request without letting the browser read the response", func() {
+				response, _ := send(http.MethodGet, "/version", map[string]string{"Origin": "https://evil.test"})
+				Ω(response.StatusCode).Should(Equal(http.StatusOK))
+				Ω(response.Header.Get("Access-Control-Allow-Origin")).Should(BeEmpty())
+				Ω(response.Header.Get("Access-Control-Expose-Headers")).Should(BeEmpty())
+				Ω(response.Header.Values("Vary")).Should(ContainElement("Origin"))
+			})
+
+			It("should answer the preflight request without allowing any method or header", func() {
+				response, _ := send(http.MethodOptions, "/version", map[string]string{
+					"Origin":                        "https://evil.test",
+					"Access-Control-Request-Method": "GET",
+				})
+				Ω(response.StatusCode).Should(Equal(http.StatusNoContent))
+				Ω(response.Header.Get("Access-Control-Allow-Origin")).Should(BeEmpty())
+				Ω(response.Header.Get("Access-Control-Allow-Methods")).Should(BeEmpty())
+				Ω(response.Header.Get("Access-Control-Allow-Headers")).Should(BeEmpty())
+			})
+		})
+
+		When("every origin is allowed", func() {
+			BeforeEach(func() {
+				allowedOrigins = []string{"*"}
+			})
+
+			It("should let any origin read the responses", func() {
+				response, _ := send(http.MethodGet, "/version", map[string]string{"Origin": "https://any.test"})
+				Ω(response.Header.Get("Access-Control-Allow-Origin")).Should(Equal("https://any.test"))
+			})
+		})
+
+		When("no origin is allowed", func() {
+			BeforeEach(func() {
+				allowedOrigins = []string{}
+			})
+
+			It("should not let any origin read the responses", func() {
+				response, _ := send(http.MethodGet, "/version", map[string]string{"Origin": "https://console.test"})
+				Ω(response.Header.Get("Access-Control-Allow-Origin")).Should(BeEmpty())
+			})
+		})
+	})
+
+	Context("the responses are sent", func() {
+		It("should send the security headers with the responses of every endpoint", func() {
+			for _, path := range []string{"/live", "/version", "/metrics"} {
+				response, _ := get(path)
+				Ω(response.Header.Get("X-Content-Type-Options")).Should(Equal("nosniff"), path)
+				Ω(response.Header.Get("X-Frame-Options")).Should(Equal("DENY"), path)
+				Ω(response.Header.Get("Referrer-Policy")).Should(Equal("no-referrer"), path)
+				Ω(response.Header.Get("Content-Security-Policy")).Should(BeEmpty(), path)
+				Ω(response.Header.Get("Strict-Transport-Security")).Should(BeEmpty(), path)
+			}
+		})
+
+		When("the HSTS and the Content-Security-Policy are configured", func() {
+			BeforeEach(func() {
+				hstsMaxAge = 365 * 24 * time.Hour
+				securityPolicy = "default-src 'none'"
+			})
+
+			It("should send them with the responses", func() {
+				response, _ := get("/version")
+				Ω(response.Header.Get("Strict-Transport-Security")).Should(Equal("max-age=31536000"))
+				Ω(response.Header.Get("Content-Security-Policy")).Should(Equal("default-src 'none'"))
+			})
+		})
+
+		When("the HSTS includes the subdomains", func() {
+			BeforeEach(func() {
+				hstsMaxAge = time.Hour
+				hstsSubdomains = true
+			})
+
+			It("should ask the browsers to call the subdomains over HTTPS too", func() {
+				response, _ := get("/version")
+				Ω(response.Header.Get("Strict-Transport-Security")).Should(Equal("max-age=3600; includeSubDomains"))
+			})
+		})
+
+		When("the preflight request is answered", func() {
+			BeforeEach(func() {
+				allowedOrigins = []string{"https://console.test"}
+			})
+
+			It("should send the security headers with the answer too", func() {
+				response, _ := send(http.MethodOptions, "/version", map[string]string{
+					"Origin":                        "https://console.test",
+					"Access-Control-Request-Method": "GET",
+				})
+				Ω(response.StatusCode).Should(Equal(http.StatusNoContent))
+				Ω(response.Header.Get("X-Content-Type-Options")).Should(Equal("nosniff"))
+			})
+		})
+	})
+
+	Context("the operations are called over the Connect protocol", func() {
+		When("the Connect protocol is enabled", func() {
+			BeforeEach(func() {
+				connectEnabled = true
+				allowedOrigins = []string{"https://console.test"}
+			})
+
+			It("should pass the POST requests of the operations to the Connect handler", func() {
+				response, body := send(http.MethodPost, "/user.Service/ReadUser", map[string]string{"Content-Type": "application/json"})
+				Ω(response.StatusCode).Should(Equal(http.StatusOK))
+				Ω(body).Should(Equal(`{"path":"/user.Service/ReadUser"}`))
+			})
+
+			It("should answer the preflight requests of the browsers allowing the headers the Connect protocol needs", func() {
+				response, body := send(http.MethodOptions, "/user.Service/ReadUser", map[string]string{
+					"Origin":                         "https://console.test",
+					"Access-Control-Request-Method":  "POST",
+					"Access-Control-Request-Headers": "content-type,connect-protocol-version",
+				})
+				Ω(response.StatusCode).Should(Equal(http.StatusNoContent))
+				Ω(body).Should(BeEmpty())
+				Ω(response.Header.Get("Access-Control-Allow-Origin")).Should(Equal("https://console.test"))
+				Ω(response.Header.Get("Access-Control-Allow-Headers")).Should(ContainSubstring("Connect-Protocol-Version"))
+				Ω(response.Header.Get("Access-Control-Allow-Headers")).Should(ContainSubstring("Connect-Timeout-Ms"))
+			})
+
+			It("should let the browsers read the request id of the responses", func() {
+				response, _ := send(http.MethodPost, "/user.Service/ReadUser", map[string]string{
+					"Content-Type": "application/json",
+					"Origin":       "https://console.test",
+				})
+				Ω(response.StatusCode).Should(Equal(http.StatusOK))
+				Ω(response.Header.Get("Access-Control-Allow-Origin")).Should(Equal("https://console.test"))
+				Ω(response.Header.Get("Access-Control-Expose-Headers")).Should(ContainSubstring("X-Request-ID"))
+			})
+		})
+
+		When("the Connect protocol is not enabled", func() {
+			It("should not serve the operations", func() {
+				response, _ := send(http.MethodPost, "/user.Service/ReadUser", map[string]string{"Content-Type": "application/json"})
+				Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Context("the runtime profiles are requested", func() {
+		When("the profiling is enabled", func() {
+			BeforeEach(func() {
+				profilingEnabled = true
+			})
+
+			It("should return the profiles in the format go tool pprof reads", func() {
+				for _, profile := range []string{"goroutine", "heap", "allocs", "threadcreate", "block", "mutex"} {
+					response, body := get("/debug/pprof/" + profile)
+					Ω(response.StatusCode).Should(Equal(http.StatusOK), profile)
+					Ω(response.Header.Get("Content-Type")).Should(Equal("application/octet-stream"), profile)
+					Ω(body).ShouldNot(BeEmpty(), profile)
+				}
+			})
+
+			It("should return the profile in the text format if the debug query argument is set", func() {
+				response, body := get("/debug/pprof/goroutine?debug=1")
+				Ω(response.StatusCode).Should(Equal(http.StatusOK))
+				Ω(response.Header.Get("Content-Type")).Should(Equal("text/plain; charset=utf-8"))
+				Ω(body).Should(HavePrefix("goroutine profile: total "))
+			})
+
+			It("should return not found for an unknown profile", func() {
+				response, _ := get("/debug/pprof/unknown")
+				Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the profiling is not enabled", func() {
+			It("should not serve the profiles", func() {
+				for _, profile := range []string{"goroutine", "heap"} {
+					response, _ := get("/debug/pprof/" + profile)
+					Ω(response.StatusCode).Should(Equal(http.StatusNotFound), profile)
+				}
+			})
+		})
+	})
+})
@@ -0,0 +1,123 @@
+// Package transport implements different transport services required by the user service
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+const (
+	// jwksFetchTimeout bounds a single fetch of the key set, so an unreachable endpoint does not hold the calls
+	jwksFetchTimeout = 10 * time.Second
+
+	// jwksMinRefreshInterval is the shortest time between two fetches of the key set, so the tokens signed with an
+	// unknown key or an unreachable endpoint do not turn every call into a request to the identity provider. The key
+	// set is fetched more often only if the refresh interval is shorter.
+	jwksMinRefreshInterval = 10 * time.Second
+
+	// jwtAcceptableSkew is how far the clock of the identity provider may drift from the clock of the service before the
+	// time claims of the tokens, e.g. the expiry, are no longer accepted
+	jwtAcceptableSkew = 30 * time.Second
+)
+
+// JWKSProvider caches the JSON Web Key Set the access tokens are verified with. The key set is refreshed once the
+// refresh interval elapses or a token is signed with a key it does not contain. While the endpoint is unreachable
+// the cached key set keeps being used for one more refresh interval, after that the calls fail closed.
+type JWKSProvider struct {
+	jwksURL         string
+	refreshInterval time.Duration
+	mutex           sync.Mutex
+	keySet          jwk.Set
+	fetchedAt       time.Time
+	attemptedAt     time.Time
+	fetchErr        error
+}
+
+// NewJWKSProvider creates new instance of the JWKSProvider, the key set is fetched on the first call
+// jwksURL: Mandatory. The URL of the JSON Web Key Set
+// refreshInterval: Mandatory. How often the cached key set is refreshed
+// Returns the new provider
+func NewJWKSProvider(jwksURL string, refreshInterval time.Duration) *JWKSProvider {
+	return &JWKSProvider{
+		jwksURL:         jwksURL,
+		refreshInterval: refreshInterval,
+	}
+}
+
+// ParseAndVerifyToken parses the bearer token, verifies its signature with the cached key set and validates its
+// time claims, allowing for a small clock skew
+// bearerToken: Mandatory. The bearer token without the Bearer prefix
+// Returns either the verified token or error if the token can not be verified
+func (provider *JWKSProvider) ParseAndVerifyToken(bearerToken string) (jwt.Token, error) {
+	message, err := jws.ParseString(bearerToken)
+	if err != nil || len(message.Signatures()) == 0 {
+		return nil, errors.New("failed to parse the received token")
+	}
+
+	keyID := message.Signatures()[0].ProtectedHeaders().KeyID()
+	keySet, err := provider.getKeySet(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := keySet.LookupKeyID(keyID); !ok {
+		return nil, fmt.Errorf("the token is signed with the key %q that is not in the key set", keyID)
+	}
+
+	token, err := jwt.ParseString(bearerToken, jwt.WithKeySet(keySet), jwt.WithValidate(true), jwt.WithAcceptableSkew(jwtAcceptableSkew))
+	if err != nil {
+		return nil, errors.New("failed to parse and validate the received token")
+	}
+
+	return token, nil
+}
+
+// getKeySet returns the cached key set, refreshing it first if the refresh interval elapsed or it does not contain
+// the key the token is signed with
+// keyID: Mandatory. The ID of the key the token is signed with
+// Returns either the key set or error if no usable key set could be fetched
+func (provider *JWKSProvider) getKeySet(keyID string) (jwk.Set, error) {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	now := time.Now()
+	refresh := provider.keySet == nil || now.Sub(provider.fetchedAt) >= provider.refreshInterval
+	if !refresh {
+		_, found := provider.keySet.LookupKeyID(keyID)
+		refresh = !found
+	}
+
+	minRefreshInterval := jwksMinRefreshInterval
+	if provider.refreshInterval < minRefreshInterval {
+		minRefreshInterval = provider.refreshInterval
+	}
+
+	if refresh && now.Sub(provider.attemptedAt) >= minRefreshInterval {
+		provider.attemptedAt = now
+
+		ctx, cancel := context.WithTimeout(context.Background(), jwksFetchTimeout)
+		keySet, err := jwk.Fetch(ctx, provider.jwksURL)
+		cancel()
+
+		if err != nil {
+			provider.fetchErr = err
+		} else {
+			provider.keySet = keySet
+			provider.fetchedAt = now
+			provider.fetchErr = nil
+		}
+	}
+
+	if provider.keySet == nil || now.Sub(provider.fetchedAt) >= 2*provider.refreshInterval {
+		return nil, fmt.Errorf("the key set the token is verified with can not be fetched from the JWKS endpoint: %v", provider.fetchErr)
+	}
+
+	return provider.keySet, nil
+}
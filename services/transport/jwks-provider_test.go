@@ -0,0 +1,220 @@
+package transport_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/decentralized-cloud/user/services/transport"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/lucsky/cuid"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JWKS Provider Tests", func() {
+	var (
+		mutex       sync.Mutex
+		servedKeys  []jwk.Key
+		failFetches bool
+		fetchCount  int
+		jwksServer  *httptest.Server
+		email       string
+	)
+
+	newSigningKey := func() jwk.Key {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Ω(err).Should(BeNil())
+
+		signingKey, err := jwk.New(privateKey)
+		Ω(err).Should(BeNil())
+
+		_ = signingKey.Set(jwk.KeyIDKey, cuid.New())
+		_ = signingKey.Set(jwk.AlgorithmKey, jwa.RS256)
+
+		return signingKey
+	}
+
+	serveKeys := func(signingKeys ...jwk.Key) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		servedKeys = signingKeys
+	}
+
+	getFetchCount := func() int {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return fetchCount
+	}
+
+	signToken := func(signingKey jwk.Key, expiresAt time.Time) string {
+		token := jwt.New()
+		_ = token.Set(jwt.SubjectKey, email)
+		_ = token.Set(jwt.IssuedAtKey, time.Now())
+		_ = token.Set(jwt.ExpirationKey, expiresAt)
+		_ = token.Set("email", email)
+
+		signed, err := jwt.Sign(token, jwa.RS256, signingKey)
+		Ω(err).Should(BeNil())
+
+		return string(signed)
+	}
+
+	BeforeEach(func() {
+		servedKeys = nil
+		failFetches = false
+		fetchCount = 0
+		email = cuid.New() + "@test.com"
+
+		jwksServer = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			defer GinkgoRecover()
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			fetchCount++
+			if failFetches {
+				writer.WriteHeader(http.StatusInternalServerError)
+
+				return
+			}
+
+			keySet := jwk.NewSet()
+			for _, signingKey := range servedKeys {
+				publicKey, err := jwk.PublicKeyOf(signingKey)
+				Ω(err).Should(BeNil())
+
+				_ = publicKey.Set(jwk.KeyIDKey, signingKey.KeyID())
+				_ = publicKey.Set(jwk.AlgorithmKey, jwa.RS256)
+				keySet.Add(publicKey)
+			}
+
+			writer.Header().Set("Content-Type", "application/json")
+			Ω(json.NewEncoder(writer).Encode(keySet)).Should(BeNil())
+		}))
+	})
+
+	AfterEach(func() {
+		jwksServer.Close()
+	})
+
+	Context("token is signed with a key in the key set", func() {
+		It("should verify the token and only fetch the key set once", func() {
+			signingKey := newSigningKey()
+			serveKeys(signingKey)
+			sut := transport.NewJWKSProvider(jwksServer.URL, time.Hour)
+
+			for i := 0; i < 3; i++ {
+				token, err := sut.ParseAndVerifyToken(signToken(signingKey, time.Now().Add(time.Hour)))
+				Ω(err).Should(BeNil())
+				Ω(token.Subject()).Should(Equal(email))
+			}
+
+			Ω(getFetchCount()).Should(Equal(1))
+		})
+	})
+
+	Context("token is expired", func() {
+		It("should accept the token expired within the clock skew allowance", func() {
+			signingKey := newSigningKey()
+			serveKeys(signingKey)
+			sut := transport.NewJWKSProvider(jwksServer.URL, time.Hour)
+
+			_, err := sut.ParseAndVerifyToken(signToken(signingKey, time.Now().Add(-5*time.Second)))
+			Ω(err).Should(BeNil())
+		})
+
+		It("should reject the token expired longer ago than the clock skew allowance", func() {
+			signingKey := newSigningKey()
+			serveKeys(signingKey)
+			sut := transport.NewJWKSProvider(jwksServer.URL, time.Hour)
+
+			_, err := sut.ParseAndVerifyToken(signToken(signingKey, time.Now().Add(-5*time.Minute)))
+			Ω(err).ShouldNot(BeNil())
+		})
+	})
+
+	Context("token is signed with a key that is not in the key set", func() {
+		It("should reject the token without fetching the key set on every call", func() {
+			serveKeys(newSigningKey())
+			sut := transport.NewJWKSProvider(jwksServer.URL, time.Hour)
+			unknownKey := newSigningKey()
+
+			for i := 0; i < 3; i++ {
+				_, err := sut.ParseAndVerifyToken(signToken(unknownKey, time.Now().Add(time.Hour)))
+				Ω(err).ShouldNot(BeNil())
+			}
+
+			Ω(getFetchCount()).Should(Equal(1))
+		})
+	})
+
+	Context("key set is rotated", func() {
+		It("should refresh the key set and verify the token signed with the new key", func() {
+			oldKey := newSigningKey()
+			serveKeys(oldKey)
+			sut := transport.NewJWKSProvider(jwksServer.URL, 100*time.Millisecond)
+
+			_, err := sut.ParseAndVerifyToken(signToken(oldKey, time.Now().Add(time.Hour)))
+			Ω(err).Should(BeNil())
+
+			newKey := newSigningKey()
+			serveKeys(newKey)
+			token := signToken(newKey, time.Now().Add(time.Hour))
+
+			Eventually(func() error {
+				_, err := sut.ParseAndVerifyToken(token)
+
+				return err
+			}, 5*time.Second, 20*time.Millisecond).Should(Succeed())
+			Ω(getFetchCount()).Should(BeNumerically(">=", 2))
+
+			Eventually(func() error {
+				_, err := sut.ParseAndVerifyToken(signToken(oldKey, time.Now().Add(time.Hour)))
+
+				return err
+			}, 5*time.Second, 20*time.Millisecond).ShouldNot(Succeed())
+		})
+	})
+
+	Context("key set can not be fetched", func() {
+		It("should reject the token if the key set was never fetched", func() {
+			signingKey := newSigningKey()
+			serveKeys(signingKey)
+			failFetches = true
+			sut := transport.NewJWKSProvider(jwksServer.URL, time.Hour)
+
+			_, err := sut.ParseAndVerifyToken(signToken(signingKey, time.Now().Add(time.Hour)))
+			Ω(err).ShouldNot(BeNil())
+		})
+
+		It("should fail closed once the cached key set is too old", func() {
+			signingKey := newSigningKey()
+			serveKeys(signingKey)
+			sut := transport.NewJWKSProvider(jwksServer.URL, 100*time.Millisecond)
+			token := signToken(signingKey, time.Now().Add(time.Hour))
+
+			_, err := sut.ParseAndVerifyToken(token)
+			Ω(err).Should(BeNil())
+
+			mutex.Lock()
+			failFetches = true
+			mutex.Unlock()
+
+			Eventually(func() error {
+				_, err := sut.ParseAndVerifyToken(token)
+
+				return err
+			}, 5*time.Second, 20*time.Millisecond).ShouldNot(Succeed())
+		})
+	})
+})
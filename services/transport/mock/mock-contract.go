@@ -5,6 +5,7 @@
 package mock_transport
 
 import (
+	http "net/http"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -60,3 +61,68 @@ func (mr *MockTransportContractMockRecorder) Stop() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockTransportContract)(nil).Stop))
 }
+
+// MockConnectContract is a mock of ConnectContract interface.
+type MockConnectContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockConnectContractMockRecorder
+}
+
+// MockConnectContractMockRecorder is the mock recorder for MockConnectContract.
+type MockConnectContractMockRecorder struct {
+	mock *MockConnectContract
+}
+
+// NewMockConnectContract creates a new mock instance.
+func NewMockConnectContract(ctrl *gomock.Controller) *MockConnectContract {
+	mock := &MockConnectContract{ctrl: ctrl}
+	mock.recorder = &MockConnectContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConnectContract) EXPECT() *MockConnectContractMockRecorder {
+	return m.recorder
+}
+
+// CreateConnectHandler mocks base method.
+func (m *MockConnectContract) CreateConnectHandler() http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConnectHandler")
+	ret0, _ := ret[0].(http.Handler)
+	return ret0
+}
+
+// CreateConnectHandler indicates an expected call of CreateConnectHandler.
+func (mr *MockConnectContractMockRecorder) CreateConnectHandler() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConnectHandler", reflect.TypeOf((*MockConnectContract)(nil).CreateConnectHandler))
+}
+
+// Start mocks base method.
+func (m *MockConnectContract) Start() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockConnectContractMockRecorder) Start() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockConnectContract)(nil).Start))
+}
+
+// Stop mocks base method.
+func (m *MockConnectContract) Stop() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockConnectContractMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockConnectContract)(nil).Stop))
+}
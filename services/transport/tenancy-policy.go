@@ -0,0 +1,104 @@
+// Package transport implements different transport services required by the user service
+package transport
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+const (
+	// TokenClaimTenant is the field of the claim mapping the tenant of the caller is read from
+	TokenClaimTenant = "tenant"
+
+	// TenancyModeSingle indicates the users are shared by all the callers
+	TenancyModeSingle = "single"
+
+	// TenancyModeMulti indicates the users are scoped to the tenant of the caller
+	TenancyModeMulti = "multi"
+)
+
+// TenancyPolicy contains the claims the tenant of the caller and the scopes granted to the caller are read from
+type TenancyPolicy struct {
+	multiTenant          bool
+	tenantClaim          string
+	crossTenantReadScope string
+}
+
+// NewTenancyPolicy creates new instance of the TenancyPolicy. The tenant is read from the tenant_id claim if it is not mapped.
+// mode: Mandatory. Either single, the callers are not scoped to a tenant, or multi
+// claimMapping: Optional. The map of the field to the claim it is read from, only the tenant field is used
+// crossTenantReadScope: Optional. The scope allowing the caller to read the users of all the tenants
+// Returns the new tenancy policy
+func NewTenancyPolicy(mode string, claimMapping map[string]string, crossTenantReadScope string) *TenancyPolicy {
+	policy := &TenancyPolicy{
+		multiTenant:          mode == TenancyModeMulti,
+		tenantClaim:          "tenant_id",
+		crossTenantReadScope: crossTenantReadScope,
+	}
+
+	if claim, ok := claimMapping[TokenClaimTenant]; ok {
+		policy.tenantClaim = claim
+	}
+
+	return policy
+}
+
+// Apply reads the tenant of the caller from the claims of the verified token into the decision in the multi-tenant
+// mode. The users are required to belong to a tenant, while the internal services without a tenant call on behalf of
+// every tenant.
+// decision: Mandatory. The decision the checks are recorded to, the caller is expected to be read into it already
+// token: Mandatory. The verified token
+// Returns error if the token does not carry the tenant of the user, the error is returned as is by the failed check of the decision
+func (policy *TenancyPolicy) Apply(decision *AuthorizationDecision, token jwt.Token) error {
+	if !policy.multiTenant {
+		return nil
+	}
+
+	decision.TenantID = readStringClaim(token, policy.tenantClaim)
+
+	if len(decision.TenantID) > 0 {
+		decision.Pass("tenant-claim", fmt.Sprintf("The tenant is included in the %s claim", policy.tenantClaim))
+	} else if decision.Service == "" {
+		return decision.Fail("tenant-claim", fmt.Errorf("tenant is not included in the %s claim", policy.tenantClaim))
+	}
+
+	if policy.crossTenantReadScope != "" && hasScope(token, policy.crossTenantReadScope) {
+		decision.CrossTenantReads = true
+		decision.Pass("cross-tenant-read-scope", fmt.Sprintf("The %s scope allows reading the users of all the tenants", policy.crossTenantReadScope))
+	}
+
+	return nil
+}
+
+// hasScope indicates whether the scope claim of the token, either a space separated string or a list, includes the scope
+func hasScope(token jwt.Token, scope string) bool {
+	value, ok := token.Get("scope")
+	if !ok {
+		return false
+	}
+
+	var scopes []string
+
+	switch castedValue := value.(type) {
+	case string:
+		scopes = strings.Fields(castedValue)
+	case []string:
+		scopes = castedValue
+	case []interface{}:
+		for _, item := range castedValue {
+			if stringItem, ok := item.(string); ok {
+				scopes = append(scopes, stringItem)
+			}
+		}
+	}
+
+	for _, item := range scopes {
+		if item == scope {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,107 @@
+package transport_test
+
+import (
+	"github.com/decentralized-cloud/user/services/transport"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/lucsky/cuid"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tenancy Policy Tests", func() {
+	var (
+		token                jwt.Token
+		decision             *transport.AuthorizationDecision
+		tenantID             string
+		crossTenantReadScope string
+	)
+
+	BeforeEach(func() {
+		tenantID = cuid.New()
+		crossTenantReadScope = cuid.New()
+
+		token = jwt.New()
+		Ω(token.Set("tenant_id", tenantID)).Should(BeNil())
+
+		decision = transport.NewAuthorizationDecision("grpc", cuid.New())
+		decision.Email = cuid.New() + "@test.com"
+	})
+
+	Context("the single-tenant mode is configured", func() {
+		It("should not scope the caller to the tenant", func() {
+			err := transport.NewTenancyPolicy(transport.TenancyModeSingle, nil, crossTenantReadScope).Apply(decision, token)
+			Ω(err).Should(BeNil())
+			Ω(decision.TenantID).Should(BeEmpty())
+			Ω(decision.Checks).Should(BeEmpty())
+		})
+	})
+
+	Context("the multi-tenant mode is configured", func() {
+		It("should read the tenant from the tenant_id claim", func() {
+			err := transport.NewTenancyPolicy(transport.TenancyModeMulti, nil, crossTenantReadScope).Apply(decision, token)
+			Ω(err).Should(BeNil())
+			Ω(decision.TenantID).Should(Equal(tenantID))
+			Ω(decision.CrossTenantReads).Should(BeFalse())
+			Ω(decision.Checks[0].Name).Should(Equal("tenant-claim"))
+			Ω(decision.Allowed()).Should(BeTrue())
+		})
+
+		It("should read the tenant from the mapped claim", func() {
+			claim := cuid.New()
+			Ω(token.Set(claim, tenantID+"-mapped")).Should(BeNil())
+
+			err := transport.NewTenancyPolicy(
+				transport.TenancyModeMulti,
+				map[string]string{transport.TokenClaimTenant: claim},
+				crossTenantReadScope).Apply(decision, token)
+			Ω(err).Should(BeNil())
+			Ω(decision.TenantID).Should(Equal(tenantID + "-mapped"))
+		})
+
+		It("should deny the call if the tenant claim of the user is missing", func() {
+			Ω(token.Remove("tenant_id")).Should(BeNil())
+
+			err := transport.NewTenancyPolicy(transport.TenancyModeMulti, nil, crossTenantReadScope).Apply(decision, token)
+			Ω(err).ShouldNot(BeNil())
+			Ω(decision.Checks[0].Name).Should(Equal("tenant-claim"))
+			Ω(decision.Allowed()).Should(BeFalse())
+		})
+
+		It("should not scope the internal service without the tenant claim", func() {
+			Ω(token.Remove("tenant_id")).Should(BeNil())
+			decision.Email = ""
+			decision.Service = cuid.New()
+
+			err := transport.NewTenancyPolicy(transport.TenancyModeMulti, nil, crossTenantReadScope).Apply(decision, token)
+			Ω(err).Should(BeNil())
+			Ω(decision.TenantID).Should(BeEmpty())
+		})
+
+		It("should allow reading across the tenants if the scope claim includes the cross-tenant read scope", func() {
+			Ω(token.Set("scope", "openid "+crossTenantReadScope)).Should(BeNil())
+
+			err := transport.NewTenancyPolicy(transport.TenancyModeMulti, nil, crossTenantReadScope).Apply(decision, token)
+			Ω(err).Should(BeNil())
+			Ω(decision.TenantID).Should(Equal(tenantID))
+			Ω(decision.CrossTenantReads).Should(BeTrue())
+			Ω(decision.Checks[1].Name).Should(Equal("cross-tenant-read-scope"))
+		})
+
+		It("should allow reading across the tenants if the scope list includes the cross-tenant read scope", func() {
+			Ω(token.Set("scope", []interface{}{"openid", crossTenantReadScope})).Should(BeNil())
+
+			err := transport.NewTenancyPolicy(transport.TenancyModeMulti, nil, crossTenantReadScope).Apply(decision, token)
+			Ω(err).Should(BeNil())
+			Ω(decision.CrossTenantReads).Should(BeTrue())
+		})
+
+		It("should not allow reading across the tenants if the scope claim does not include the cross-tenant read scope", func() {
+			Ω(token.Set("scope", "openid "+crossTenantReadScope+"-other")).Should(BeNil())
+
+			err := transport.NewTenancyPolicy(transport.TenancyModeMulti, nil, crossTenantReadScope).Apply(decision, token)
+			Ω(err).Should(BeNil())
+			Ω(decision.CrossTenantReads).Should(BeFalse())
+		})
+	})
+})
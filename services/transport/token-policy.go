@@ -0,0 +1,130 @@
+// Package transport implements different transport services required by the user service
+package transport
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+const (
+	// TokenClaimEmail is the field of the claim mapping the email address of the caller is read from
+	TokenClaimEmail = "email"
+
+	// TokenClaimSubject is the field of the claim mapping the subject of the caller is read from
+	TokenClaimSubject = "subject"
+
+	// TokenClaimService is the field of the claim mapping the identity of the calling internal service is read from
+	TokenClaimService = "service"
+)
+
+// TokenPolicy contains the issuers and audiences the tokens are accepted from and the claims the caller is read from
+type TokenPolicy struct {
+	issuers      map[string]bool
+	audiences    map[string]bool
+	emailClaim   string
+	subjectClaim string
+	serviceClaim string
+}
+
+// NewTokenPolicy creates new instance of the TokenPolicy. The issuer and audience are only checked if any is
+// accepted, and the claims not mapped are read from the email, sub and service claims.
+// issuers: Optional. The issuers the tokens are accepted from
+// audiences: Optional. The audiences the tokens are accepted for, a token is accepted if it is issued for any of them
+// claimMapping: Optional. The map of the field, either email, subject or service, to the claim it is read from
+// Returns the new token policy
+func NewTokenPolicy(issuers []string, audiences []string, claimMapping map[string]string) *TokenPolicy {
+	policy := &TokenPolicy{
+		issuers:      map[string]bool{},
+		audiences:    map[string]bool{},
+		emailClaim:   "email",
+		subjectClaim: jwt.SubjectKey,
+		serviceClaim: "service",
+	}
+
+	for _, issuer := range issuers {
+		policy.issuers[issuer] = true
+	}
+
+	for _, audience := range audiences {
+		policy.audiences[audience] = true
+	}
+
+	if claim, ok := claimMapping[TokenClaimEmail]; ok {
+		policy.emailClaim = claim
+	}
+
+	if claim, ok := claimMapping[TokenClaimSubject]; ok {
+		policy.subjectClaim = claim
+	}
+
+	if claim, ok := claimMapping[TokenClaimService]; ok {
+		policy.serviceClaim = claim
+	}
+
+	return policy
+}
+
+// Apply checks the issuer and the audience of the verified token and reads the caller from its claims into the decision.
+// The client credential tokens issued to the internal services carry the service claim rather than an email address.
+// decision: Mandatory. The decision the checks are recorded to
+// token: Mandatory. The verified token
+// Returns error if the token is not accepted, the error is returned as is by the failed check of the decision
+func (policy *TokenPolicy) Apply(decision *AuthorizationDecision, token jwt.Token) error {
+	if len(policy.issuers) > 0 {
+		if !policy.issuers[token.Issuer()] {
+			return decision.Fail("issuer", fmt.Errorf("token issuer %q is not accepted", token.Issuer()))
+		}
+
+		decision.Pass("issuer", "The token issuer is accepted")
+	}
+
+	if len(policy.audiences) > 0 {
+		if !policy.isAudienceAccepted(token.Audience()) {
+			return decision.Fail("audience", fmt.Errorf("token audience %q is not accepted", strings.Join(token.Audience(), ",")))
+		}
+
+		decision.Pass("audience", "The token audience is accepted")
+	}
+
+	decision.Subject = readStringClaim(token, policy.subjectClaim)
+	decision.Email = readStringClaim(token, policy.emailClaim)
+	decision.Service = readStringClaim(token, policy.serviceClaim)
+
+	if len(decision.Service) > 0 {
+		decision.Pass("service-claim", fmt.Sprintf("The service identity is included in the %s claim", policy.serviceClaim))
+
+		return nil
+	}
+
+	if len(decision.Email) == 0 {
+		return decision.Fail("email-claim", fmt.Errorf("email address is not included in the %s claim", policy.emailClaim))
+	}
+
+	decision.Pass("email-claim", fmt.Sprintf("The email address is included in the %s claim", policy.emailClaim))
+
+	return nil
+}
+
+func (policy *TokenPolicy) isAudienceAccepted(audiences []string) bool {
+	for _, audience := range audiences {
+		if policy.audiences[audience] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readStringClaim returns the value of the claim, or empty string if the claim is missing or is not a string
+func readStringClaim(token jwt.Token, claim string) string {
+	value, ok := token.Get(claim)
+	if !ok {
+		return ""
+	}
+
+	stringValue, _ := value.(string)
+
+	return stringValue
+}
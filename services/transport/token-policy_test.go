@@ -0,0 +1,147 @@
+package transport_test
+
+import (
+	"github.com/decentralized-cloud/user/services/transport"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/lucsky/cuid"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Token Policy Tests", func() {
+	var (
+		token    jwt.Token
+		decision *transport.AuthorizationDecision
+		subject  string
+		email    string
+		issuer   string
+		audience string
+	)
+
+	BeforeEach(func() {
+		subject = cuid.New()
+		email = cuid.New() + "@test.com"
+		issuer = "https://" + cuid.New() + ".test.com"
+		audience = cuid.New()
+
+		token = jwt.New()
+		Ω(token.Set(jwt.SubjectKey, subject)).Should(BeNil())
+		Ω(token.Set(jwt.IssuerKey, issuer)).Should(BeNil())
+		Ω(token.Set(jwt.AudienceKey, []string{cuid.New(), audience})).Should(BeNil())
+		Ω(token.Set("email", email)).Should(BeNil())
+
+		decision = transport.NewAuthorizationDecision("grpc", cuid.New())
+	})
+
+	Context("no issuer, audience or claim mapping is configured", func() {
+		It("should read the caller from the email and sub claims", func() {
+			err := transport.NewTokenPolicy(nil, nil, nil).Apply(decision, token)
+			Ω(err).Should(BeNil())
+			Ω(decision.Subject).Should(Equal(subject))
+			Ω(decision.Email).Should(Equal(email))
+			Ω(decision.Checks).Should(HaveLen(1))
+			Ω(decision.Allowed()).Should(BeTrue())
+		})
+
+		It("should deny the call if the email claim is missing", func() {
+			Ω(token.Remove("email")).Should(BeNil())
+
+			err := transport.NewTokenPolicy(nil, nil, nil).Apply(decision, token)
+			Ω(err).ShouldNot(BeNil())
+			Ω(decision.Checks[0].Name).Should(Equal("email-claim"))
+			Ω(decision.Allowed()).Should(BeFalse())
+		})
+	})
+
+	Context("the accepted issuers are configured", func() {
+		It("should accept the token issued by any of them", func() {
+			err := transport.NewTokenPolicy([]string{cuid.New(), issuer}, nil, nil).Apply(decision, token)
+			Ω(err).Should(BeNil())
+			Ω(decision.Checks[0].Name).Should(Equal("issuer"))
+			Ω(decision.Allowed()).Should(BeTrue())
+		})
+
+		It("should deny the call if the token is issued by another issuer", func() {
+			err := transport.NewTokenPolicy([]string{cuid.New()}, nil, nil).Apply(decision, token)
+			Ω(err).ShouldNot(BeNil())
+			Ω(decision.Checks).Should(HaveLen(1))
+			Ω(decision.Checks[0].Name).Should(Equal("issuer"))
+			Ω(decision.Allowed()).Should(BeFalse())
+		})
+	})
+
+	Context("the accepted audiences are configured", func() {
+		It("should accept the token issued for any of them", func() {
+			err := transport.NewTokenPolicy(nil, []string{audience}, nil).Apply(decision, token)
+			Ω(err).Should(BeNil())
+			Ω(decision.Checks[0].Name).Should(Equal("audience"))
+			Ω(decision.Allowed()).Should(BeTrue())
+		})
+
+		It("should deny the call if the token is not issued for any of them", func() {
+			err := transport.NewTokenPolicy(nil, []string{cuid.New()}, nil).Apply(decision, token)
+			Ω(err).ShouldNot(BeNil())
+			Ω(decision.Checks[0].Name).Should(Equal("audience"))
+			Ω(decision.Allowed()).Should(BeFalse())
+		})
+
+		It("should deny the call if the token has no audience", func() {
+			Ω(token.Remove(jwt.AudienceKey)).Should(BeNil())
+
+			err := transport.NewTokenPolicy(nil, []string{audience}, nil).Apply(decision, token)
+			Ω(err).ShouldNot(BeNil())
+			Ω(decision.Allowed()).Should(BeFalse())
+		})
+	})
+
+	Context("the token is issued to an internal service", func() {
+		It("should read the service identity and not require an email address", func() {
+			service := cuid.New()
+			Ω(token.Remove("email")).Should(BeNil())
+			Ω(token.Set("service", service)).Should(BeNil())
+
+			err := transport.NewTokenPolicy(nil, nil, nil).Apply(decision, token)
+			Ω(err).Should(BeNil())
+			Ω(decision.Service).Should(Equal(service))
+			Ω(decision.Subject).Should(Equal(subject))
+			Ω(decision.Checks[0].Name).Should(Equal("service-claim"))
+			Ω(decision.Allowed()).Should(BeTrue())
+		})
+
+		It("should read the service identity from the mapped claim", func() {
+			clientID := cuid.New()
+			Ω(token.Set("azp", clientID)).Should(BeNil())
+
+			err := transport.NewTokenPolicy(nil, nil, map[string]string{transport.TokenClaimService: "azp"}).Apply(decision, token)
+			Ω(err).Should(BeNil())
+			Ω(decision.Service).Should(Equal(clientID))
+		})
+	})
+
+	Context("the claim mapping is configured", func() {
+		It("should read the caller from the mapped claims", func() {
+			username := cuid.New() + "@test.com"
+			objectID := cuid.New()
+			Ω(token.Set("preferred_username", username)).Should(BeNil())
+			Ω(token.Set("oid", objectID)).Should(BeNil())
+
+			err := transport.NewTokenPolicy(nil, nil, map[string]string{
+				transport.TokenClaimEmail:   "preferred_username",
+				transport.TokenClaimSubject: "oid",
+			}).Apply(decision, token)
+			Ω(err).Should(BeNil())
+			Ω(decision.Email).Should(Equal(username))
+			Ω(decision.Subject).Should(Equal(objectID))
+		})
+
+		It("should deny the call if the mapped claim is not a string", func() {
+			Ω(token.Set("preferred_username", []string{email})).Should(BeNil())
+
+			err := transport.NewTokenPolicy(nil, nil, map[string]string{transport.TokenClaimEmail: "preferred_username"}).Apply(decision, token)
+			Ω(err).ShouldNot(BeNil())
+			Ω(decision.Email).Should(BeEmpty())
+			Ω(decision.Allowed()).Should(BeFalse())
+		})
+	})
+})
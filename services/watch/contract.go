@@ -0,0 +1,33 @@
+// Package watch implements the delivery of the user changes to the clients watching the users, so they do not have
+// to poll the users to find out they changed
+package watch
+
+import (
+	"context"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// WatchContract declares the service that notifies the watchers of the user changes
+type WatchContract interface {
+	// Subscribe starts notifying the watcher of the changes that matched the filter until the context is done. The
+	// channel is closed once the context is done, or earlier if the watcher falls behind and its buffer is full.
+	// ctx: Mandatory The reference to the context the watcher is subscribed for
+	// filter: Mandatory. The changes the watcher is notified of
+	// Returns either the channel the changes are sent to or error if something goes wrong.
+	Subscribe(
+		ctx context.Context,
+		filter models.UserChangeFilter) (<-chan models.UserChange, error)
+
+	// Notify sends the change to all the watchers it matched the filter of without blocking
+	// change: Mandatory. The change made to the user
+	Notify(change models.UserChange)
+}
+
+// SourceContract declares the service that receives the user changes made by all the replicas and notifies the
+// watchers of them
+type SourceContract interface {
+	// Close stops receiving the user changes
+	// Returns error if something goes wrong.
+	Close() error
+}
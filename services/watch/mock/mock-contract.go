@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/watch/contract.go
+
+// Package mock_watch is a generated GoMock package.
+package mock_watch
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/decentralized-cloud/user/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWatchContract is a mock of WatchContract interface.
+type MockWatchContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockWatchContractMockRecorder
+}
+
+// MockWatchContractMockRecorder is the mock recorder for MockWatchContract.
+type MockWatchContractMockRecorder struct {
+	mock *MockWatchContract
+}
+
+// NewMockWatchContract creates a new mock instance.
+func NewMockWatchContract(ctrl *gomock.Controller) *MockWatchContract {
+	mock := &MockWatchContract{ctrl: ctrl}
+	mock.recorder = &MockWatchContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWatchContract) EXPECT() *MockWatchContractMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockWatchContract) Notify(change models.UserChange) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Notify", change)
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockWatchContractMockRecorder) Notify(change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockWatchContract)(nil).Notify), change)
+}
+
+// Subscribe mocks base method.
+func (m *MockWatchContract) Subscribe(ctx context.Context, filter models.UserChangeFilter) (<-chan models.UserChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, filter)
+	ret0, _ := ret[0].(<-chan models.UserChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockWatchContractMockRecorder) Subscribe(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockWatchContract)(nil).Subscribe), ctx, filter)
+}
+
+// MockSourceContract is a mock of SourceContract interface.
+type MockSourceContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockSourceContractMockRecorder
+}
+
+// MockSourceContractMockRecorder is the mock recorder for MockSourceContract.
+type MockSourceContractMockRecorder struct {
+	mock *MockSourceContract
+}
+
+// NewMockSourceContract creates a new mock instance.
+func NewMockSourceContract(ctrl *gomock.Controller) *MockSourceContract {
+	mock := &MockSourceContract{ctrl: ctrl}
+	mock.recorder = &MockSourceContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSourceContract) EXPECT() *MockSourceContractMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockSourceContract) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockSourceContractMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSourceContract)(nil).Close))
+}
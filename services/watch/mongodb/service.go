@@ -0,0 +1,314 @@
+// Package mongodb implements the watch service that reads the user changes from the MongoDB change stream of the
+// users collection, so the watchers can resume their streams after reconnecting
+package mongodb
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/watch"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// resumeErrorCodes are the codes the database fails to resume the change stream with, either because the token is
+// not a resume token or because the change it was received with is no longer kept in the oplog
+var resumeErrorCodes = []int{260, 280, 286}
+
+// changeEvent is the subset of the change stream event the user changes are mapped from
+type changeEvent struct {
+	ID            bson.Raw            `bson:"_id"`
+	OperationType string              `bson:"operationType"`
+	ClusterTime   primitive.Timestamp `bson:"clusterTime"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument *struct {
+		Email string `bson:"email"`
+	} `bson:"fullDocument"`
+	UpdateDescription *struct {
+		UpdatedFields bson.M   `bson:"updatedFields"`
+		RemovedFields []string `bson:"removedFields"`
+	} `bson:"updateDescription"`
+}
+
+type mongodbChangeStreamService struct {
+	logger                 *zap.Logger
+	getConnectionString    func() (string, error)
+	databaseName           string
+	databaseCollectionName string
+	bufferSize             int
+}
+
+// NewMongodbChangeStreamService creates new instance of the mongodbChangeStreamService, setting up all dependencies
+// and returns the instance. Every watcher reads its own change stream, so a watcher can resume after the last change
+// it received and a slow watcher only delays its own stream.
+// logger: Mandatory. Reference to the logger service
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewMongodbChangeStreamService(
+	logger *zap.Logger,
+	configurationService configuration.ConfigurationContract) (watch.WatchContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if _, err := configurationService.GetDatabaseConnectionString(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	databaseName, err := configurationService.GetDatabaseName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database name", err)
+	}
+
+	databaseCollectionName, err := configurationService.GetDatabaseCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database collection name", err)
+	}
+
+	bufferSize, err := configurationService.GetWatchBufferSize()
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongodbChangeStreamService{
+		logger:                 logger,
+		getConnectionString:    configurationService.GetDatabaseConnectionString,
+		databaseName:           databaseName,
+		databaseCollectionName: databaseCollectionName,
+		bufferSize:             bufferSize,
+	}, nil
+}
+
+// Subscribe opens a change stream for the watcher and sends it the changes that matched the filter until the context
+// is done. The channel is closed once the context is done, or earlier if the change stream fails, in which case the
+// watcher resumes with the resume token of the last change it received.
+// ctx: Mandatory The reference to the context the watcher is subscribed for
+// filter: Mandatory. The changes the watcher is notified of and the resume token to start after
+// Returns either the channel the changes are sent to or error if something goes wrong.
+func (service *mongodbChangeStreamService) Subscribe(
+	ctx context.Context,
+	filter models.UserChangeFilter) (<-chan models.UserChange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("the context of the watcher is already done", err)
+	}
+
+	streamOptions := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if filter.ResumeToken != "" {
+		resumeToken, err := base64.RawURLEncoding.DecodeString(filter.ResumeToken)
+		if err != nil || bson.Raw(resumeToken).Validate() != nil {
+			return nil, commonErrors.NewArgumentError("filter.ResumeToken", "resume token is invalid")
+		}
+
+		streamOptions.SetResumeAfter(bson.Raw(resumeToken))
+	}
+
+	connectionString, err := service.getConnectionString()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(connectionString))
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("could not connect to mongodb database", err)
+	}
+
+	collection := client.Database(service.databaseName).Collection(service.databaseCollectionName)
+
+	userEmails, err := readUserEmails(ctx, collection, filter.Emails)
+	if err != nil {
+		_ = client.Disconnect(context.Background())
+
+		return nil, err
+	}
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{
+		"operationType": bson.M{"$in": bson.A{"insert", "update", "replace", "delete"}},
+	}}}}
+
+	stream, err := collection.Watch(ctx, pipeline, streamOptions)
+	if err != nil {
+		_ = client.Disconnect(context.Background())
+
+		if commandErr, ok := err.(mongo.CommandError); ok && filter.ResumeToken != "" {
+			for _, code := range resumeErrorCodes {
+				if commandErr.HasErrorCode(code) {
+					return nil, commonErrors.NewArgumentErrorWithError(
+						"filter.ResumeToken",
+						"the change of the resume token is no longer kept, watch again without the resume token",
+						err)
+				}
+			}
+		}
+
+		return nil, commonErrors.NewUnknownErrorWithError("failed to open the change stream", err)
+	}
+
+	changes := make(chan models.UserChange, service.bufferSize)
+	go service.stream(ctx, client, stream, filter, userEmails, changes)
+
+	return changes, nil
+}
+
+// Notify does nothing, the changes are read from the change stream once they are persisted
+// change: Mandatory. The change made to the user
+func (service *mongodbChangeStreamService) Notify(change models.UserChange) {
+}
+
+// stream sends the changes read from the change stream to the watcher until the context is done or the change stream
+// fails. The change stream is not read further while the buffer of the watcher is full, so a slow watcher falls
+// behind the changes without missing any of them.
+func (service *mongodbChangeStreamService) stream(
+	ctx context.Context,
+	client *mongo.Client,
+	stream *mongo.ChangeStream,
+	filter models.UserChangeFilter,
+	userEmails map[primitive.ObjectID]string,
+	changes chan<- models.UserChange) {
+	defer func() {
+		_ = stream.Close(context.Background())
+		_ = client.Disconnect(context.Background())
+		close(changes)
+	}()
+
+	for stream.Next(ctx) {
+		var event changeEvent
+		if err := stream.Decode(&event); err != nil {
+			service.logger.Error("failed to decode change stream event", zap.Error(err))
+
+			return
+		}
+
+		change := mapChangeEvent(event, userEmails)
+
+		// The watched users created after the watch started are deleted by their ID as well
+		if change.Type == models.UserChangeCreated && isWatched(filter.Emails, change.Email) {
+			userEmails[event.DocumentKey.ID] = change.Email
+		}
+
+		if !watch.Matches(filter, change) {
+			continue
+		}
+
+		select {
+		case changes <- change:
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	if err := stream.Err(); err != nil && ctx.Err() == nil {
+		service.logger.Error("change stream failed", zap.Error(err))
+	}
+}
+
+// readUserEmails reads the IDs of the watched users, as the change stream only identifies the hard deleted users by
+// their ID
+// ctx: Mandatory The reference to the context
+// collection: Mandatory. The users collection
+// emails: Optional. The email addresses of the watched users
+// Returns either the email addresses keyed by the user ID or error if something goes wrong
+func readUserEmails(ctx context.Context, collection *mongo.Collection, emails []string) (map[primitive.ObjectID]string, error) {
+	userEmails := map[primitive.ObjectID]string{}
+	if len(emails) == 0 {
+		return userEmails, nil
+	}
+
+	cursor, err := collection.Find(
+		ctx,
+		bson.M{"email": bson.M{"$in": emails}},
+		options.Find().SetProjection(bson.M{"_id": 1, "email": 1}))
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read the watched users", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var user struct {
+			ID    primitive.ObjectID `bson:"_id"`
+			Email string             `bson:"email"`
+		}
+
+		if err = cursor.Decode(&user); err != nil {
+			return nil, commonErrors.NewUnknownErrorWithError("failed to read the watched users", err)
+		}
+
+		userEmails[user.ID] = user.Email
+	}
+
+	if err = cursor.Err(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to read the watched users", err)
+	}
+
+	return userEmails, nil
+}
+
+// mapChangeEvent maps the change stream event to the user change. The soft deletion and the restoration are updates of
+// the deletedAt field. The hard deleted users are only identified by their ID, so their email address is only known
+// if the user is watched by its email address and is empty otherwise, the cursor is set for the watchers to tell them
+// apart.
+// event: Mandatory. The change stream event
+// userEmails: Mandatory. The email addresses of the watched users keyed by the user ID
+// Returns the user change
+func mapChangeEvent(event changeEvent, userEmails map[primitive.ObjectID]string) models.UserChange {
+	change := models.UserChange{
+		Type:        models.UserChangeUpdated,
+		Email:       userEmails[event.DocumentKey.ID],
+		Cursor:      event.DocumentKey.ID.Hex(),
+		OccurredAt:  time.Unix(int64(event.ClusterTime.T), 0).UTC(),
+		ResumeToken: base64.RawURLEncoding.EncodeToString(event.ID),
+	}
+
+	if event.FullDocument != nil {
+		change.Email = event.FullDocument.Email
+	}
+
+	switch event.OperationType {
+	case "insert":
+		change.Type = models.UserChangeCreated
+
+	case "delete":
+		change.Type = models.UserChangeDeleted
+
+	case "update":
+		if event.UpdateDescription == nil {
+			break
+		}
+
+		if _, ok := event.UpdateDescription.UpdatedFields["deletedAt"]; ok {
+			change.Type = models.UserChangeDeleted
+			change.SoftDeleted = true
+		}
+
+		for _, field := range event.UpdateDescription.RemovedFields {
+			if field == "deletedAt" {
+				change.Type = models.UserChangeRestored
+			}
+		}
+	}
+
+	return change
+}
+
+func isWatched(emails []string, email string) bool {
+	for _, item := range emails {
+		if item == email {
+			return true
+		}
+	}
+
+	return false
+}
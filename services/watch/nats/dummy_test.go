@@ -0,0 +1 @@
+package nats_test
@@ -0,0 +1,177 @@
+// Package nats implements the source that receives the user lifecycle events all the replicas publish to NATS and
+// notifies the watchers of the replica of them
+package nats
+
+import (
+	"fmt"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/watch"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	natsgo "github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type natsSourceService struct {
+	logger        *zap.Logger
+	connection    *natsgo.Conn
+	watchService  watch.WatchContract
+	subscriptions []*natsgo.Subscription
+}
+
+// NewNatsSourceService creates new instance of the natsSourceService, setting up all dependencies, subscribing to the
+// subjects of the user lifecycle events and returns the instance. The subject of the email verification events is not
+// subscribed to, as only the service that sends the emails must receive the tokens.
+// logger: Mandatory. Reference to the logger service
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// watchService: Mandatory. Reference to the service that notifies the watchers of the user changes
+// Returns the new service or error if something goes wrong
+func NewNatsSourceService(
+	logger *zap.Logger,
+	configurationService configuration.ConfigurationContract,
+	watchService watch.WatchContract) (watch.SourceContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if watchService == nil {
+		return nil, commonErrors.NewArgumentNilError("watchService", "watchService is required")
+	}
+
+	connectionString, err := configurationService.GetEventingConnectionString()
+	if err != nil {
+		return nil, err
+	}
+
+	subjectPrefix, err := configurationService.GetEventingSubjectPrefix()
+	if err != nil {
+		return nil, err
+	}
+
+	connection, err := natsgo.Connect(
+		connectionString,
+		natsgo.Name("user-watch"),
+		natsgo.MaxReconnects(-1),
+		natsgo.RetryOnFailedConnect(true))
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to connect to NATS", err)
+	}
+
+	service := &natsSourceService{
+		logger:       logger,
+		connection:   connection,
+		watchService: watchService,
+	}
+
+	decoders := map[string]func(data []byte) (models.UserChange, error){
+		"created":  decodeUserCreatedEvent,
+		"updated":  decodeUserUpdatedEvent,
+		"deleted":  decodeUserDeletedEvent,
+		"restored": decodeUserRestoredEvent,
+	}
+
+	for eventName, decoder := range decoders {
+		subject := fmt.Sprintf("%s.%s", subjectPrefix, eventName)
+
+		subscription, err := connection.Subscribe(subject, service.createHandler(subject, decoder))
+		if err != nil {
+			connection.Close()
+
+			return nil, commonErrors.NewUnknownErrorWithError(fmt.Sprintf("failed to subscribe to %s", subject), err)
+		}
+
+		service.subscriptions = append(service.subscriptions, subscription)
+	}
+
+	return service, nil
+}
+
+// Close stops receiving the user lifecycle events and closes the connection to NATS
+// Returns error if something goes wrong.
+func (service *natsSourceService) Close() error {
+	for _, subscription := range service.subscriptions {
+		_ = subscription.Unsubscribe()
+	}
+
+	service.connection.Close()
+
+	return nil
+}
+
+func (service *natsSourceService) createHandler(
+	subject string,
+	decoder func(data []byte) (models.UserChange, error)) natsgo.MsgHandler {
+	return func(message *natsgo.Msg) {
+		change, err := decoder(message.Data)
+		if err != nil {
+			service.logger.Error("failed to decode user event", zap.String("subject", subject), zap.Error(err))
+
+			return
+		}
+
+		service.watchService.Notify(change)
+	}
+}
+
+func decodeUserCreatedEvent(data []byte) (models.UserChange, error) {
+	var event userGRPCContract.UserCreatedEvent
+	if err := proto.Unmarshal(data, &event); err != nil {
+		return models.UserChange{}, err
+	}
+
+	return models.UserChange{
+		Type:       models.UserChangeCreated,
+		Email:      event.Email,
+		Cursor:     event.Cursor,
+		OccurredAt: event.OccurredAt.AsTime(),
+	}, nil
+}
+
+func decodeUserUpdatedEvent(data []byte) (models.UserChange, error) {
+	var event userGRPCContract.UserUpdatedEvent
+	if err := proto.Unmarshal(data, &event); err != nil {
+		return models.UserChange{}, err
+	}
+
+	return models.UserChange{
+		Type:       models.UserChangeUpdated,
+		Email:      event.Email,
+		Cursor:     event.Cursor,
+		OccurredAt: event.OccurredAt.AsTime(),
+	}, nil
+}
+
+func decodeUserDeletedEvent(data []byte) (models.UserChange, error) {
+	var event userGRPCContract.UserDeletedEvent
+	if err := proto.Unmarshal(data, &event); err != nil {
+		return models.UserChange{}, err
+	}
+
+	return models.UserChange{
+		Type:        models.UserChangeDeleted,
+		Email:       event.Email,
+		OccurredAt:  event.OccurredAt.AsTime(),
+		SoftDeleted: event.SoftDeleted,
+	}, nil
+}
+
+func decodeUserRestoredEvent(data []byte) (models.UserChange, error) {
+	var event userGRPCContract.UserRestoredEvent
+	if err := proto.Unmarshal(data, &event); err != nil {
+		return models.UserChange{}, err
+	}
+
+	return models.UserChange{
+		Type:       models.UserChangeRestored,
+		Email:      event.Email,
+		Cursor:     event.Cursor,
+		OccurredAt: event.OccurredAt.AsTime(),
+	}, nil
+}
@@ -0,0 +1,171 @@
+// Package watch implements the delivery of the user changes to the clients watching the users, so they do not have
+// to poll the users to find out they changed
+package watch
+
+import (
+	"context"
+	"sync"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var watchersGauge = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "user_watch_watchers",
+		Help: "The number of the watchers currently notified of the user changes",
+	})
+
+var laggedWatchersCounter = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Name: "user_watch_lagged_watchers_total",
+		Help: "The number of the watchers disconnected because they fell behind the user changes",
+	})
+
+type watcher struct {
+	filter  models.UserChangeFilter
+	changes chan models.UserChange
+}
+
+type watchService struct {
+	logger     *zap.Logger
+	bufferSize int
+	mutex      sync.Mutex
+	watchers   map[*watcher]struct{}
+}
+
+// NewWatchService creates new instance of the watchService, setting up all dependencies and returns the instance
+// logger: Mandatory. Reference to the logger service
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewWatchService(
+	logger *zap.Logger,
+	configurationService configuration.ConfigurationContract) (WatchContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	bufferSize, err := configurationService.GetWatchBufferSize()
+	if err != nil {
+		return nil, err
+	}
+
+	return &watchService{
+		logger:     logger,
+		bufferSize: bufferSize,
+		watchers:   map[*watcher]struct{}{},
+	}, nil
+}
+
+// Subscribe starts notifying the watcher of the changes that matched the filter until the context is done. The
+// channel is closed once the context is done, or earlier if the watcher falls behind and its buffer is full.
+// ctx: Mandatory The reference to the context the watcher is subscribed for
+// filter: Mandatory. The changes the watcher is notified of
+// Returns either the channel the changes are sent to or error if something goes wrong.
+func (service *watchService) Subscribe(
+	ctx context.Context,
+	filter models.UserChangeFilter) (<-chan models.UserChange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("the context of the watcher is already done", err)
+	}
+
+	// The lifecycle events are not kept once the watchers are notified, so there is nothing to resume from
+	if filter.ResumeToken != "" {
+		return nil, commonErrors.NewArgumentError("filter.ResumeToken", "resuming the watch requires USER_WATCH_SOURCE to be change-stream")
+	}
+
+	subscribed := &watcher{
+		filter:  filter,
+		changes: make(chan models.UserChange, service.bufferSize),
+	}
+
+	service.mutex.Lock()
+	service.watchers[subscribed] = struct{}{}
+	service.mutex.Unlock()
+
+	watchersGauge.Inc()
+
+	go func() {
+		<-ctx.Done()
+
+		service.mutex.Lock()
+		defer service.mutex.Unlock()
+
+		service.remove(subscribed)
+	}()
+
+	return subscribed.changes, nil
+}
+
+// Notify sends the change to all the watchers it matched the filter of without blocking. A watcher whose buffer is
+// full is disconnected, so a slow watcher neither delays the change nor the other watchers.
+// change: Mandatory. The change made to the user
+func (service *watchService) Notify(change models.UserChange) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	for notified := range service.watchers {
+		if !Matches(notified.filter, change) {
+			continue
+		}
+
+		select {
+		case notified.changes <- change:
+		default:
+			service.logger.Warn("disconnecting the watcher that fell behind the user changes", zap.Int("bufferSize", service.bufferSize))
+			laggedWatchersCounter.Inc()
+			service.remove(notified)
+		}
+	}
+}
+
+// remove closes the channel of the watcher once, whether it is removed because its context is done or because it
+// fell behind. The mutex must be held by the caller.
+func (service *watchService) remove(removed *watcher) {
+	if _, ok := service.watchers[removed]; !ok {
+		return
+	}
+
+	delete(service.watchers, removed)
+	close(removed.changes)
+	watchersGauge.Dec()
+}
+
+// Matches returns true if the change matched the filter of the watcher
+// filter: Mandatory. The changes the watcher is notified of
+// change: Mandatory. The change made to the user
+func Matches(filter models.UserChangeFilter, change models.UserChange) bool {
+	if len(filter.Emails) > 0 && !containsEmail(filter.Emails, change.Email) {
+		return false
+	}
+
+	if len(filter.Types) == 0 {
+		return true
+	}
+
+	for _, changeType := range filter.Types {
+		if changeType == change.Type {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsEmail(emails []string, email string) bool {
+	for _, item := range emails {
+		if item == email {
+			return true
+		}
+	}
+
+	return false
+}
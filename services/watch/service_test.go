@@ -0,0 +1,165 @@
+package watch_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/decentralized-cloud/user/models"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	"github.com/decentralized-cloud/user/services/watch"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestWatchService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Watch Service Tests")
+}
+
+var _ = Describe("Watch Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      watch.WatchContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		ctx                      context.Context
+		cancel                   context.CancelFunc
+		email                    string
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.
+			EXPECT().
+			GetWatchBufferSize().
+			Return(2, nil).
+			AnyTimes()
+
+		var err error
+		sut, err = watch.NewWatchService(zap.NewNop(), mockConfigurationService)
+		Ω(err).Should(BeNil())
+
+		ctx, cancel = context.WithCancel(context.Background())
+		email = cuid.New() + "@test.com"
+	})
+
+	AfterEach(func() {
+		cancel()
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate WatchService", func() {
+		When("logger is not provided and NewWatchService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := watch.NewWatchService(nil, mockConfigurationService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("configuration service is not provided and NewWatchService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := watch.NewWatchService(zap.NewNop(), nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("WatchService is instantiated", func() {
+		When("a change that matched the filter is notified", func() {
+			It("should send the change to the watcher", func() {
+				changes, err := sut.Subscribe(ctx, models.UserChangeFilter{Emails: []string{email}})
+				Ω(err).Should(BeNil())
+
+				change := models.UserChange{Type: models.UserChangeUpdated, Email: email, Cursor: cuid.New()}
+				sut.Notify(change)
+
+				Ω(changes).Should(Receive(Equal(change)))
+			})
+		})
+
+		When("a change that did not match the filter is notified", func() {
+			It("should not send the change to the watcher", func() {
+				changes, err := sut.Subscribe(ctx, models.UserChangeFilter{
+					Emails: []string{email},
+					Types:  []models.UserChangeType{models.UserChangeDeleted},
+				})
+				Ω(err).Should(BeNil())
+
+				sut.Notify(models.UserChange{Type: models.UserChangeUpdated, Email: email})
+				sut.Notify(models.UserChange{Type: models.UserChangeDeleted, Email: cuid.New() + "@test.com"})
+
+				Consistently(changes).ShouldNot(Receive())
+			})
+		})
+
+		When("the watcher falls behind the changes", func() {
+			It("should close the channel after the buffered changes", func() {
+				changes, err := sut.Subscribe(ctx, models.UserChangeFilter{})
+				Ω(err).Should(BeNil())
+
+				for index := 0; index < 3; index++ {
+					sut.Notify(models.UserChange{Type: models.UserChangeUpdated, Email: email})
+				}
+
+				Ω(changes).Should(Receive())
+				Ω(changes).Should(Receive())
+				Ω(changes).Should(BeClosed())
+			})
+
+			It("should keep notifying the other watchers", func() {
+				_, err := sut.Subscribe(ctx, models.UserChangeFilter{})
+				Ω(err).Should(BeNil())
+
+				otherChanges, err := sut.Subscribe(ctx, models.UserChangeFilter{Emails: []string{email}})
+				Ω(err).Should(BeNil())
+
+				for index := 0; index < 3; index++ {
+					sut.Notify(models.UserChange{Type: models.UserChangeUpdated, Email: cuid.New() + "@test.com"})
+				}
+
+				change := models.UserChange{Type: models.UserChangeCreated, Email: email}
+				sut.Notify(change)
+
+				Ω(otherChanges).Should(Receive(Equal(change)))
+			})
+		})
+
+		When("the context of the watcher is done", func() {
+			It("should close the channel", func() {
+				changes, err := sut.Subscribe(ctx, models.UserChangeFilter{})
+				Ω(err).Should(BeNil())
+
+				cancel()
+
+				Eventually(changes).Should(BeClosed())
+				sut.Notify(models.UserChange{Type: models.UserChangeUpdated, Email: email})
+			})
+		})
+
+		When("Subscribe is called with the context that is already done", func() {
+			It("should return error", func() {
+				cancel()
+
+				changes, err := sut.Subscribe(ctx, models.UserChangeFilter{})
+				Ω(changes).Should(BeNil())
+				Ω(err).ShouldNot(BeNil())
+			})
+		})
+
+		When("Subscribe is called with a resume token", func() {
+			It("should return ArgumentError as the changes can not be resumed", func() {
+				changes, err := sut.Subscribe(ctx, models.UserChangeFilter{ResumeToken: cuid.New()})
+				Ω(changes).Should(BeNil())
+				Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+			})
+		})
+	})
+})
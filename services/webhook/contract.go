@@ -0,0 +1,158 @@
+// Package webhook implements the outbound webhooks the users subscribe to be called for their events
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+)
+
+// WebhookContract declares the service that manages the webhook subscriptions of the users and delivers the events
+// of the users to their webhooks
+type WebhookContract interface {
+	// CreateSubscription persists the new webhook subscription of the user
+	// ctx: Mandatory The reference to the context
+	// subscription: Mandatory. The subscription containing the email address of the user, the URL, the secret and the
+	// event types
+	// Returns either the persisted subscription or error if something goes wrong.
+	CreateSubscription(
+		ctx context.Context,
+		subscription *models.WebhookSubscription) (*models.WebhookSubscription, error)
+
+	// ListSubscriptions lists the webhook subscriptions of the user, the oldest first
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns either the subscriptions of the user or error if something goes wrong.
+	ListSubscriptions(
+		ctx context.Context,
+		email string) ([]models.WebhookSubscription, error)
+
+	// DeleteSubscription deletes the webhook subscription of the user, the events are no longer delivered to the
+	// webhook
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// subscriptionID: Mandatory. The ID of the subscription
+	// Returns error if something goes wrong. SubscriptionNotFoundError is returned if the user has no subscription
+	// with the ID.
+	DeleteSubscription(
+		ctx context.Context,
+		email string,
+		subscriptionID string) error
+
+	// DeleteSubscriptions deletes all the webhook subscriptions of the user and their deliveries
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns error if something goes wrong.
+	DeleteSubscriptions(
+		ctx context.Context,
+		email string) error
+
+	// ChangeEmail moves all the webhook subscriptions of the user and their deliveries to the new email address of the
+	// user
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The current email address of the user
+	// newEmail: Mandatory. The new email address of the user
+	// Returns error if something goes wrong.
+	ChangeEmail(
+		ctx context.Context,
+		email string,
+		newEmail string) error
+
+	// ListDeliveries lists the recent deliveries of the events to the webhooks of the user, the most recent first
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// subscriptionID: Optional. The ID of the subscription to list the deliveries of, all the subscriptions if empty
+	// limit: Mandatory. The maximum number of the deliveries to list
+	// Returns either the deliveries or error if something goes wrong.
+	ListDeliveries(
+		ctx context.Context,
+		email string,
+		subscriptionID string,
+		limit int) ([]models.WebhookDelivery, error)
+
+	// Deliver delivers the event to the webhooks of the user subscribed to its type. The webhooks are called in the
+	// background, retrying the failed calls, so the event is delivered even after the context is cancelled.
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user the event is raised for
+	// eventType: Mandatory. The type of the event
+	// Returns error if the deliveries could not be persisted.
+	Deliver(
+		ctx context.Context,
+		email string,
+		eventType models.WebhookEventType) error
+}
+
+// StoreContract declares the service that persists the webhook subscriptions and their deliveries
+type StoreContract interface {
+	// CreateSubscription persists the new webhook subscription
+	// ctx: Mandatory The reference to the context
+	// subscription: Mandatory. The subscription to persist
+	// Returns error if something goes wrong.
+	CreateSubscription(
+		ctx context.Context,
+		subscription *models.WebhookSubscription) error
+
+	// ListSubscriptions lists the persisted webhook subscriptions of the user, the oldest first
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns either the subscriptions of the user or error if something goes wrong.
+	ListSubscriptions(
+		ctx context.Context,
+		email string) ([]models.WebhookSubscription, error)
+
+	// DeleteSubscription deletes the persisted webhook subscription of the user, its deliveries are kept until
+	// they expire
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// subscriptionID: Mandatory. The ID of the subscription
+	// Returns error if something goes wrong. SubscriptionNotFoundError is returned if the user has no subscription
+	// with the ID.
+	DeleteSubscription(
+		ctx context.Context,
+		email string,
+		subscriptionID string) error
+
+	// DeleteSubscriptions deletes all the persisted webhook subscriptions of the user and their deliveries, it does
+	// nothing if the user has no subscription
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// Returns error if something goes wrong.
+	DeleteSubscriptions(
+		ctx context.Context,
+		email string) error
+
+	// ChangeEmail moves all the persisted webhook subscriptions of the user and their deliveries to the new email
+	// address, it does nothing if the user has no subscription
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The current email address of the user
+	// newEmail: Mandatory. The new email address of the user
+	// Returns error if something goes wrong.
+	ChangeEmail(
+		ctx context.Context,
+		email string,
+		newEmail string) error
+
+	// SaveDelivery creates the delivery or replaces the persisted delivery with the same ID
+	// ctx: Mandatory The reference to the context
+	// delivery: Mandatory. The delivery to persist
+	// Returns error if something goes wrong.
+	SaveDelivery(
+		ctx context.Context,
+		delivery *models.WebhookDelivery) error
+
+	// ListDeliveries lists the persisted deliveries to the webhooks of the user that are not expired, the most
+	// recent first
+	// ctx: Mandatory The reference to the context
+	// email: Mandatory. The email address of the user
+	// subscriptionID: Optional. The ID of the subscription to list the deliveries of, all the subscriptions if empty
+	// now: Mandatory. The current time the deliveries expired before are left out
+	// limit: Mandatory. The maximum number of the deliveries to list
+	// Returns either the deliveries or error if something goes wrong.
+	ListDeliveries(
+		ctx context.Context,
+		email string,
+		subscriptionID string,
+		now time.Time,
+		limit int) ([]models.WebhookDelivery, error)
+}
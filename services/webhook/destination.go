@@ -0,0 +1,135 @@
+// Package webhook implements the outbound webhooks the users subscribe to be called for their events
+package webhook
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// nonPublicNetworks are the networks the webhooks are not called in unless the private networks are allowed, so a
+// webhook can not be used to reach the service itself, the other services in its network or the cloud metadata
+// endpoints
+var nonPublicNetworks = parseNetworks(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.0.2.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"198.51.100.0/24",
+	"203.0.113.0/24",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"64:ff9b::/96",
+	"100::/64",
+	"2001:db8::/32",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+// newHTTPClient creates the client the webhooks are called with. The redirects are not followed, so a webhook can not
+// redirect the call to another destination, and unless the private networks are allowed the client refuses to
+// connect to any address that is not public. The address is checked once the host name is resolved, right before
+// connecting, so a host name resolving to another address than it did when the webhook was subscribed is still
+// refused.
+// timeout: Mandatory. How long a single call can take
+// privateNetworksAllowed: Mandatory. Whether the webhooks can be called in the private networks
+// Returns the new client
+func newHTTPClient(timeout time.Duration, privateNetworksAllowed bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	if !privateNetworksAllowed {
+		dialer.Control = func(_ string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !isPublicAddress(ip) {
+				return NewNonPublicDestinationError(host)
+			}
+
+			return nil
+		}
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// No proxy is used, the dialer could only check the address of the proxy rather than the webhook
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkDestination rejects the webhook URLs that are known not to be public before they are called, i.e. the URLs
+// with a localhost host name or a non-public IP address. The host names resolving to a non-public address are only
+// refused once called.
+// webhookURL: Mandatory. The URL of the webhook
+// Returns error if the URL is invalid or NonPublicDestinationError if the URL is known not to be public
+func checkDestination(webhookURL string) error {
+	parsedURL, err := url.Parse(webhookURL)
+	if err != nil {
+		return err
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(parsedURL.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return NewNonPublicDestinationError(host)
+	}
+
+	if ip := net.ParseIP(host); ip != nil && !isPublicAddress(ip) {
+		return NewNonPublicDestinationError(host)
+	}
+
+	return nil
+}
+
+// isPublicAddress indicates whether the address is not in any of the non-public networks
+func isPublicAddress(ip net.IP) bool {
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseNetworks parses the CIDR notations of the networks, panics if any of them is invalid
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}
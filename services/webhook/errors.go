@@ -0,0 +1,65 @@
+// Package webhook implements the outbound webhooks the users subscribe to be called for their events
+package webhook
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SubscriptionNotFoundError indicates the user has no webhook subscription with the ID
+type SubscriptionNotFoundError struct {
+	SubscriptionID string
+}
+
+// Error returns message for the SubscriptionNotFoundError error type
+// Returns the formatted error message
+func (e SubscriptionNotFoundError) Error() string {
+	return fmt.Sprintf("webhook subscription %s not found", e.SubscriptionID)
+}
+
+// IsSubscriptionNotFoundError indicates whether the error is of type SubscriptionNotFoundError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type SubscriptionNotFoundError
+func IsSubscriptionNotFoundError(err error) bool {
+	var subscriptionNotFoundError SubscriptionNotFoundError
+
+	return errors.As(err, &subscriptionNotFoundError)
+}
+
+// NewSubscriptionNotFoundError creates a new SubscriptionNotFoundError error
+// subscriptionID: Mandatory. The ID of the subscription
+// Returns the new error
+func NewSubscriptionNotFoundError(subscriptionID string) error {
+	return SubscriptionNotFoundError{
+		SubscriptionID: subscriptionID,
+	}
+}
+
+// NonPublicDestinationError indicates the webhook can not be called as its destination is not a public address
+type NonPublicDestinationError struct {
+	Destination string
+}
+
+// Error returns message for the NonPublicDestinationError error type
+// Returns the formatted error message
+func (e NonPublicDestinationError) Error() string {
+	return fmt.Sprintf("webhook destination %s is not a public address", e.Destination)
+}
+
+// IsNonPublicDestinationError indicates whether the error is of type NonPublicDestinationError
+// err: Optional. The error to check
+// Returns true if the error or any error it wraps is of type NonPublicDestinationError
+func IsNonPublicDestinationError(err error) bool {
+	var nonPublicDestinationError NonPublicDestinationError
+
+	return errors.As(err, &nonPublicDestinationError)
+}
+
+// NewNonPublicDestinationError creates a new NonPublicDestinationError error
+// destination: Mandatory. The host or the address the webhook is called at
+// Returns the new error
+func NewNonPublicDestinationError(destination string) error {
+	return NonPublicDestinationError{
+		Destination: destination,
+	}
+}
@@ -0,0 +1,261 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/webhook/contract.go
+
+// Package mock_webhook is a generated GoMock package.
+package mock_webhook
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/decentralized-cloud/user/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWebhookContract is a mock of WebhookContract interface.
+type MockWebhookContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookContractMockRecorder
+}
+
+// MockWebhookContractMockRecorder is the mock recorder for MockWebhookContract.
+type MockWebhookContractMockRecorder struct {
+	mock *MockWebhookContract
+}
+
+// NewMockWebhookContract creates a new mock instance.
+func NewMockWebhookContract(ctrl *gomock.Controller) *MockWebhookContract {
+	mock := &MockWebhookContract{ctrl: ctrl}
+	mock.recorder = &MockWebhookContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookContract) EXPECT() *MockWebhookContractMockRecorder {
+	return m.recorder
+}
+
+// ChangeEmail mocks base method.
+func (m *MockWebhookContract) ChangeEmail(ctx context.Context, email, newEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmail", ctx, email, newEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeEmail indicates an expected call of ChangeEmail.
+func (mr *MockWebhookContractMockRecorder) ChangeEmail(ctx, email, newEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmail", reflect.TypeOf((*MockWebhookContract)(nil).ChangeEmail), ctx, email, newEmail)
+}
+
+// CreateSubscription mocks base method.
+func (m *MockWebhookContract) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, subscription)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockWebhookContractMockRecorder) CreateSubscription(ctx, subscription interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockWebhookContract)(nil).CreateSubscription), ctx, subscription)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockWebhookContract) DeleteSubscription(ctx context.Context, email, subscriptionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, email, subscriptionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockWebhookContractMockRecorder) DeleteSubscription(ctx, email, subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockWebhookContract)(nil).DeleteSubscription), ctx, email, subscriptionID)
+}
+
+// DeleteSubscriptions mocks base method.
+func (m *MockWebhookContract) DeleteSubscriptions(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscriptions", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscriptions indicates an expected call of DeleteSubscriptions.
+func (mr *MockWebhookContractMockRecorder) DeleteSubscriptions(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscriptions", reflect.TypeOf((*MockWebhookContract)(nil).DeleteSubscriptions), ctx, email)
+}
+
+// Deliver mocks base method.
+func (m *MockWebhookContract) Deliver(ctx context.Context, email string, eventType models.WebhookEventType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliver", ctx, email, eventType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deliver indicates an expected call of Deliver.
+func (mr *MockWebhookContractMockRecorder) Deliver(ctx, email, eventType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockWebhookContract)(nil).Deliver), ctx, email, eventType)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockWebhookContract) ListDeliveries(ctx context.Context, email, subscriptionID string, limit int) ([]models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, email, subscriptionID, limit)
+	ret0, _ := ret[0].([]models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockWebhookContractMockRecorder) ListDeliveries(ctx, email, subscriptionID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWebhookContract)(nil).ListDeliveries), ctx, email, subscriptionID, limit)
+}
+
+// ListSubscriptions mocks base method.
+func (m *MockWebhookContract) ListSubscriptions(ctx context.Context, email string) ([]models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptions", ctx, email)
+	ret0, _ := ret[0].([]models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptions indicates an expected call of ListSubscriptions.
+func (mr *MockWebhookContractMockRecorder) ListSubscriptions(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptions", reflect.TypeOf((*MockWebhookContract)(nil).ListSubscriptions), ctx, email)
+}
+
+// MockStoreContract is a mock of StoreContract interface.
+type MockStoreContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreContractMockRecorder
+}
+
+// MockStoreContractMockRecorder is the mock recorder for MockStoreContract.
+type MockStoreContractMockRecorder struct {
+	mock *MockStoreContract
+}
+
+// NewMockStoreContract creates a new mock instance.
+func NewMockStoreContract(ctrl *gomock.Controller) *MockStoreContract {
+	mock := &MockStoreContract{ctrl: ctrl}
+	mock.recorder = &MockStoreContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreContract) EXPECT() *MockStoreContractMockRecorder {
+	return m.recorder
+}
+
+// ChangeEmail mocks base method.
+func (m *MockStoreContract) ChangeEmail(ctx context.Context, email, newEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmail", ctx, email, newEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeEmail indicates an expected call of ChangeEmail.
+func (mr *MockStoreContractMockRecorder) ChangeEmail(ctx, email, newEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmail", reflect.TypeOf((*MockStoreContract)(nil).ChangeEmail), ctx, email, newEmail)
+}
+
+// CreateSubscription mocks base method.
+func (m *MockStoreContract) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockStoreContractMockRecorder) CreateSubscription(ctx, subscription interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockStoreContract)(nil).CreateSubscription), ctx, subscription)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockStoreContract) DeleteSubscription(ctx context.Context, email, subscriptionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, email, subscriptionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockStoreContractMockRecorder) DeleteSubscription(ctx, email, subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockStoreContract)(nil).DeleteSubscription), ctx, email, subscriptionID)
+}
+
+// DeleteSubscriptions mocks base method.
+func (m *MockStoreContract) DeleteSubscriptions(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscriptions", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscriptions indicates an expected call of DeleteSubscriptions.
+func (mr *MockStoreContractMockRecorder) DeleteSubscriptions(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscriptions", reflect.TypeOf((*MockStoreContract)(nil).DeleteSubscriptions), ctx, email)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockStoreContract) ListDeliveries(ctx context.Context, email, subscriptionID string, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, email, subscriptionID, now, limit)
+	ret0, _ := ret[0].([]models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockStoreContractMockRecorder) ListDeliveries(ctx, email, subscriptionID, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockStoreContract)(nil).ListDeliveries), ctx, email, subscriptionID, now, limit)
+}
+
+// ListSubscriptions mocks base method.
+func (m *MockStoreContract) ListSubscriptions(ctx context.Context, email string) ([]models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptions", ctx, email)
+	ret0, _ := ret[0].([]models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptions indicates an expected call of ListSubscriptions.
+func (mr *MockStoreContractMockRecorder) ListSubscriptions(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptions", reflect.TypeOf((*MockStoreContract)(nil).ListSubscriptions), ctx, email)
+}
+
+// SaveDelivery mocks base method.
+func (m *MockStoreContract) SaveDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDelivery indicates an expected call of SaveDelivery.
+func (mr *MockStoreContractMockRecorder) SaveDelivery(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDelivery", reflect.TypeOf((*MockStoreContract)(nil).SaveDelivery), ctx, delivery)
+}
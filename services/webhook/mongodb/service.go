@@ -0,0 +1,351 @@
+// Package mongodb implements the MongoDB store that persists the webhook subscriptions and their deliveries
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/webhook"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongodbStoreService struct {
+	getConnectionString        func() (string, error)
+	databaseName               string
+	subscriptionCollectionName string
+	deliveryCollectionName     string
+}
+
+// NewMongodbStoreService creates new instance of the mongodbStoreService, setting up all dependencies, creating the
+// indexes of the webhook collections if they do not exist yet and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewMongodbStoreService(
+	configurationService configuration.ConfigurationContract) (webhook.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if _, err := configurationService.GetDatabaseConnectionString(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	databaseName, err := configurationService.GetDatabaseName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the database name", err)
+	}
+
+	subscriptionCollectionName, err := configurationService.GetWebhookSubscriptionCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the webhook subscription collection name", err)
+	}
+
+	deliveryCollectionName, err := configurationService.GetWebhookDeliveryCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the webhook delivery collection name", err)
+	}
+
+	service := &mongodbStoreService{
+		getConnectionString:        configurationService.GetDatabaseConnectionString,
+		databaseName:               databaseName,
+		subscriptionCollectionName: subscriptionCollectionName,
+		deliveryCollectionName:     deliveryCollectionName,
+	}
+
+	if err = service.createIndexes(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+// CreateSubscription persists the new webhook subscription
+// ctx: Mandatory The reference to the context
+// subscription: Mandatory. The subscription to persist
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) CreateSubscription(
+	ctx context.Context,
+	subscription *models.WebhookSubscription) error {
+	client, err := service.createClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	if _, err = service.subscriptions(client).InsertOne(ctx, subscription); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to create webhook subscription", err)
+	}
+
+	return nil
+}
+
+// ListSubscriptions lists the persisted webhook subscriptions of the user, the oldest first
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns either the subscriptions of the user or error if something goes wrong.
+func (service *mongodbStoreService) ListSubscriptions(
+	ctx context.Context,
+	email string) ([]models.WebhookSubscription, error) {
+	client, err := service.createClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(ctx, client)
+
+	cursor, err := service.subscriptions(client).Find(
+		ctx,
+		bson.D{{Key: "email", Value: email}},
+		options.Find().SetSort(bson.D{{Key: "createdat", Value: 1}}))
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list webhook subscriptions", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	subscriptions := []models.WebhookSubscription{}
+	if err = cursor.All(ctx, &subscriptions); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to decode webhook subscriptions", err)
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteSubscription deletes the persisted webhook subscription of the user, its deliveries are kept until they
+// expire
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// subscriptionID: Mandatory. The ID of the subscription
+// Returns error if something goes wrong. SubscriptionNotFoundError is returned if the user has no subscription
+// with the ID.
+func (service *mongodbStoreService) DeleteSubscription(
+	ctx context.Context,
+	email string,
+	subscriptionID string) error {
+	client, err := service.createClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	result, err := service.subscriptions(client).DeleteOne(ctx, bson.D{
+		{Key: "subscriptionid", Value: subscriptionID},
+		{Key: "email", Value: email},
+	})
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to delete webhook subscription", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return webhook.NewSubscriptionNotFoundError(subscriptionID)
+	}
+
+	return nil
+}
+
+// DeleteSubscriptions deletes all the persisted webhook subscriptions of the user and their deliveries, it does
+// nothing if the user has no subscription
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) DeleteSubscriptions(
+	ctx context.Context,
+	email string) error {
+	client, err := service.createClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	filter := bson.D{{Key: "email", Value: email}}
+	if _, err = service.subscriptions(client).DeleteMany(ctx, filter); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to delete webhook subscriptions", err)
+	}
+
+	if _, err = service.deliveries(client).DeleteMany(ctx, filter); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to delete webhook deliveries", err)
+	}
+
+	return nil
+}
+
+// ChangeEmail moves all the persisted webhook subscriptions of the user and their deliveries to the new email
+// address, it does nothing if the user has no subscription
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The current email address of the user
+// newEmail: Mandatory. The new email address of the user
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) ChangeEmail(
+	ctx context.Context,
+	email string,
+	newEmail string) error {
+	client, err := service.createClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	filter := bson.D{{Key: "email", Value: email}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "email", Value: newEmail}}}}
+	if _, err = service.subscriptions(client).UpdateMany(ctx, filter, update); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to change the email address of webhook subscriptions", err)
+	}
+
+	if _, err = service.deliveries(client).UpdateMany(ctx, filter, update); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to change the email address of webhook deliveries", err)
+	}
+
+	return nil
+}
+
+// SaveDelivery creates the delivery or replaces the persisted delivery with the same ID
+// ctx: Mandatory The reference to the context
+// delivery: Mandatory. The delivery to persist
+// Returns error if something goes wrong.
+func (service *mongodbStoreService) SaveDelivery(
+	ctx context.Context,
+	delivery *models.WebhookDelivery) error {
+	client, err := service.createClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	if _, err = service.deliveries(client).ReplaceOne(
+		ctx,
+		bson.D{{Key: "deliveryid", Value: delivery.DeliveryID}},
+		delivery,
+		options.Replace().SetUpsert(true)); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to save webhook delivery", err)
+	}
+
+	return nil
+}
+
+// ListDeliveries lists the persisted deliveries to the webhooks of the user that are not expired, the most recent
+// first
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// subscriptionID: Optional. The ID of the subscription to list the deliveries of, all the subscriptions if empty
+// now: Mandatory. The current time the deliveries expired before are left out
+// limit: Mandatory. The maximum number of the deliveries to list
+// Returns either the deliveries or error if something goes wrong.
+func (service *mongodbStoreService) ListDeliveries(
+	ctx context.Context,
+	email string,
+	subscriptionID string,
+	now time.Time,
+	limit int) ([]models.WebhookDelivery, error) {
+	client, err := service.createClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer disconnect(ctx, client)
+
+	filter := bson.D{
+		{Key: "email", Value: email},
+		{Key: "expiresat", Value: bson.D{{Key: "$gt", Value: now}}},
+	}
+
+	if subscriptionID != "" {
+		filter = append(filter, bson.E{Key: "subscriptionid", Value: subscriptionID})
+	}
+
+	cursor, err := service.deliveries(client).Find(
+		ctx,
+		filter,
+		options.Find().SetSort(bson.D{{Key: "createdat", Value: -1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list webhook deliveries", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	deliveries := []models.WebhookDelivery{}
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to decode webhook deliveries", err)
+	}
+
+	return deliveries, nil
+}
+
+// createIndexes creates the unique indexes on the subscription and delivery IDs, the indexes the subscriptions and
+// deliveries of a user are listed with and the TTL index MongoDB removes the expired deliveries with
+func (service *mongodbStoreService) createIndexes(ctx context.Context) error {
+	client, err := service.createClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer disconnect(ctx, client)
+
+	if _, err = service.subscriptions(client).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "subscriptionid", Value: 1}},
+			Options: options.Index().SetName("subscriptionid_1").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "email", Value: 1}, {Key: "createdat", Value: 1}},
+			Options: options.Index().SetName("email_1_createdat_1"),
+		},
+	}); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to create the webhook subscription indexes", err)
+	}
+
+	if _, err = service.deliveries(client).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "deliveryid", Value: 1}},
+			Options: options.Index().SetName("deliveryid_1").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "email", Value: 1}, {Key: "createdat", Value: -1}},
+			Options: options.Index().SetName("email_1_createdat_-1"),
+		},
+		{
+			Keys:    bson.D{{Key: "expiresat", Value: 1}},
+			Options: options.Index().SetName("expiresat_1").SetExpireAfterSeconds(0),
+		},
+	}); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to create the webhook delivery indexes", err)
+	}
+
+	return nil
+}
+
+func (service *mongodbStoreService) createClient(ctx context.Context) (*mongo.Client, error) {
+	connectionString, err := service.getConnectionString()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to mongodb", err)
+	}
+
+	clientOptions := options.Client().ApplyURI(connectionString)
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("could not connect to mongodb database", err)
+	}
+
+	return client, nil
+}
+
+func (service *mongodbStoreService) subscriptions(client *mongo.Client) *mongo.Collection {
+	return client.Database(service.databaseName).Collection(service.subscriptionCollectionName)
+}
+
+func (service *mongodbStoreService) deliveries(client *mongo.Client) *mongo.Collection {
+	return client.Database(service.databaseName).Collection(service.deliveryCollectionName)
+}
+
+func disconnect(ctx context.Context, client *mongo.Client) {
+	_ = client.Disconnect(ctx)
+}
@@ -0,0 +1,394 @@
+// Package postgres implements the PostgreSQL store that persists the webhook subscriptions and their deliveries
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/configuration"
+	configurationPostgres "github.com/decentralized-cloud/user/services/configuration/postgres"
+	"github.com/decentralized-cloud/user/services/webhook"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+)
+
+const (
+	subscriptionColumns = "subscription_id, email, url, secret, event_types, created_at"
+	deliveryColumns     = "delivery_id, subscription_id, email, event_type, status, attempts, status_code, error, created_at, updated_at, expires_at"
+)
+
+type postgresStoreService struct {
+	pool                  *pgxpool.Pool
+	subscriptionTableName string
+	deliveryTableName     string
+}
+
+// NewPostgresStoreService creates new instance of the postgresStoreService, setting up all dependencies, creating
+// the webhook tables if they do not exist yet and returns the instance
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// Returns the new service or error if something goes wrong
+func NewPostgresStoreService(
+	configurationService configuration.ConfigurationContract) (webhook.StoreContract, error) {
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	connectionString, err := configurationService.GetDatabaseConnectionString()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get connection string to postgres", err)
+	}
+
+	// The webhook collection names are used as the names of the tables the subscriptions and deliveries are
+	// persisted in
+	subscriptionTableName, err := configurationService.GetWebhookSubscriptionCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the webhook subscription table name", err)
+	}
+
+	deliveryTableName, err := configurationService.GetWebhookDeliveryCollectionName()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the webhook delivery table name", err)
+	}
+
+	ctx := context.Background()
+	pool, err := configurationPostgres.Connect(ctx, connectionString, configurationService.GetDatabaseConnectionString)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("could not connect to postgres database", err)
+	}
+
+	service := &postgresStoreService{
+		pool:                  pool,
+		subscriptionTableName: subscriptionTableName,
+		deliveryTableName:     deliveryTableName,
+	}
+
+	for _, statement := range []struct {
+		sql     string
+		message string
+	}{
+		{
+			sql: fmt.Sprintf(
+				`CREATE TABLE IF NOT EXISTS %s (
+					subscription_id TEXT PRIMARY KEY,
+					email TEXT NOT NULL,
+					url TEXT NOT NULL,
+					secret TEXT NOT NULL,
+					event_types TEXT[] NOT NULL,
+					created_at TIMESTAMPTZ NOT NULL)`,
+				service.subscriptionTable()),
+			message: "failed to create the webhook subscription table",
+		},
+		{
+			sql: fmt.Sprintf(
+				"CREATE INDEX IF NOT EXISTS %s ON %s (email, created_at)",
+				pgx.Identifier{subscriptionTableName + "_email_idx"}.Sanitize(),
+				service.subscriptionTable()),
+			message: "failed to create the webhook subscription email index",
+		},
+		{
+			sql: fmt.Sprintf(
+				`CREATE TABLE IF NOT EXISTS %s (
+					delivery_id TEXT PRIMARY KEY,
+					subscription_id TEXT NOT NULL,
+					email TEXT NOT NULL,
+					event_type TEXT NOT NULL,
+					status TEXT NOT NULL,
+					attempts INTEGER NOT NULL,
+					status_code INTEGER NOT NULL,
+					error TEXT NOT NULL,
+					created_at TIMESTAMPTZ NOT NULL,
+					updated_at TIMESTAMPTZ NOT NULL,
+					expires_at TIMESTAMPTZ NOT NULL)`,
+				service.deliveryTable()),
+			message: "failed to create the webhook delivery table",
+		},
+		{
+			sql: fmt.Sprintf(
+				"CREATE INDEX IF NOT EXISTS %s ON %s (email, created_at DESC)",
+				pgx.Identifier{deliveryTableName + "_email_idx"}.Sanitize(),
+				service.deliveryTable()),
+			message: "failed to create the webhook delivery email index",
+		},
+	} {
+		if _, err = pool.Exec(ctx, statement.sql); err != nil {
+			pool.Close()
+
+			return nil, commonErrors.NewUnknownErrorWithError(statement.message, err)
+		}
+	}
+
+	return service, nil
+}
+
+// CreateSubscription persists the new webhook subscription
+// ctx: Mandatory The reference to the context
+// subscription: Mandatory. The subscription to persist
+// Returns error if something goes wrong.
+func (service *postgresStoreService) CreateSubscription(
+	ctx context.Context,
+	subscription *models.WebhookSubscription) error {
+	eventTypes := make([]string, 0, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("INSERT INTO %s (%s) VALUES ($1, $2, $3, $4, $5, $6)", service.subscriptionTable(), subscriptionColumns),
+		subscription.SubscriptionID,
+		subscription.Email,
+		subscription.URL,
+		subscription.Secret,
+		eventTypes,
+		subscription.CreatedAt); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to create webhook subscription", err)
+	}
+
+	return nil
+}
+
+// ListSubscriptions lists the persisted webhook subscriptions of the user, the oldest first
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns either the subscriptions of the user or error if something goes wrong.
+func (service *postgresStoreService) ListSubscriptions(
+	ctx context.Context,
+	email string) ([]models.WebhookSubscription, error) {
+	rows, err := service.pool.Query(
+		ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE email = $1 ORDER BY created_at", subscriptionColumns, service.subscriptionTable()),
+		email)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list webhook subscriptions", err)
+	}
+
+	defer rows.Close()
+
+	subscriptions := []models.WebhookSubscription{}
+	for rows.Next() {
+		var subscription models.WebhookSubscription
+		var eventTypes []string
+
+		if err = rows.Scan(
+			&subscription.SubscriptionID,
+			&subscription.Email,
+			&subscription.URL,
+			&subscription.Secret,
+			&eventTypes,
+			&subscription.CreatedAt); err != nil {
+			return nil, commonErrors.NewUnknownErrorWithError("failed to decode webhook subscriptions", err)
+		}
+
+		for _, eventType := range eventTypes {
+			subscription.EventTypes = append(subscription.EventTypes, models.WebhookEventType(eventType))
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list webhook subscriptions", err)
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteSubscription deletes the persisted webhook subscription of the user, its deliveries are kept until they
+// expire
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// subscriptionID: Mandatory. The ID of the subscription
+// Returns error if something goes wrong. SubscriptionNotFoundError is returned if the user has no subscription
+// with the ID.
+func (service *postgresStoreService) DeleteSubscription(
+	ctx context.Context,
+	email string,
+	subscriptionID string) error {
+	result, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE subscription_id = $1 AND email = $2", service.subscriptionTable()),
+		subscriptionID,
+		email)
+	if err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to delete webhook subscription", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return webhook.NewSubscriptionNotFoundError(subscriptionID)
+	}
+
+	return nil
+}
+
+// DeleteSubscriptions deletes all the persisted webhook subscriptions of the user and their deliveries, it does
+// nothing if the user has no subscription
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns error if something goes wrong.
+func (service *postgresStoreService) DeleteSubscriptions(
+	ctx context.Context,
+	email string) error {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE email = $1", service.subscriptionTable()),
+		email); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to delete webhook subscriptions", err)
+	}
+
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE email = $1", service.deliveryTable()),
+		email); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to delete webhook deliveries", err)
+	}
+
+	return nil
+}
+
+// ChangeEmail moves all the persisted webhook subscriptions of the user and their deliveries to the new email
+// address, it does nothing if the user has no subscription
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The current email address of the user
+// newEmail: Mandatory. The new email address of the user
+// Returns error if something goes wrong.
+func (service *postgresStoreService) ChangeEmail(
+	ctx context.Context,
+	email string,
+	newEmail string) error {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("UPDATE %s SET email = $2 WHERE email = $1", service.subscriptionTable()),
+		email,
+		newEmail); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to change the email address of webhook subscriptions", err)
+	}
+
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("UPDATE %s SET email = $2 WHERE email = $1", service.deliveryTable()),
+		email,
+		newEmail); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to change the email address of webhook deliveries", err)
+	}
+
+	return nil
+}
+
+// SaveDelivery creates the delivery or replaces the persisted delivery with the same ID
+// ctx: Mandatory The reference to the context
+// delivery: Mandatory. The delivery to persist
+// Returns error if something goes wrong.
+func (service *postgresStoreService) SaveDelivery(
+	ctx context.Context,
+	delivery *models.WebhookDelivery) error {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf(
+			`INSERT INTO %s (%s) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			ON CONFLICT (delivery_id) DO UPDATE SET
+				status = EXCLUDED.status,
+				attempts = EXCLUDED.attempts,
+				status_code = EXCLUDED.status_code,
+				error = EXCLUDED.error,
+				updated_at = EXCLUDED.updated_at`,
+			service.deliveryTable(),
+			deliveryColumns),
+		delivery.DeliveryID,
+		delivery.SubscriptionID,
+		delivery.Email,
+		string(delivery.EventType),
+		string(delivery.Status),
+		delivery.Attempts,
+		delivery.StatusCode,
+		delivery.Error,
+		delivery.CreatedAt,
+		delivery.UpdatedAt,
+		delivery.ExpiresAt); err != nil {
+		return commonErrors.NewUnknownErrorWithError("failed to save webhook delivery", err)
+	}
+
+	return nil
+}
+
+// ListDeliveries lists the persisted deliveries to the webhooks of the user that are not expired, the most recent
+// first. PostgreSQL has no TTL index, so the expired deliveries of the user are deleted before they are listed.
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// subscriptionID: Optional. The ID of the subscription to list the deliveries of, all the subscriptions if empty
+// now: Mandatory. The current time the deliveries expired before are left out
+// limit: Mandatory. The maximum number of the deliveries to list
+// Returns either the deliveries or error if something goes wrong.
+func (service *postgresStoreService) ListDeliveries(
+	ctx context.Context,
+	email string,
+	subscriptionID string,
+	now time.Time,
+	limit int) ([]models.WebhookDelivery, error) {
+	if _, err := service.pool.Exec(
+		ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE email = $1 AND expires_at <= $2", service.deliveryTable()),
+		email,
+		now); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to delete expired webhook deliveries", err)
+	}
+
+	rows, err := service.pool.Query(
+		ctx,
+		fmt.Sprintf(
+			`SELECT %s FROM %s WHERE email = $1 AND expires_at > $2 AND ($3 = '' OR subscription_id = $3)
+			ORDER BY created_at DESC LIMIT $4`,
+			deliveryColumns,
+			service.deliveryTable()),
+		email,
+		now,
+		subscriptionID,
+		limit)
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list webhook deliveries", err)
+	}
+
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		var eventType string
+		var status string
+
+		if err = rows.Scan(
+			&delivery.DeliveryID,
+			&delivery.SubscriptionID,
+			&delivery.Email,
+			&eventType,
+			&status,
+			&delivery.Attempts,
+			&delivery.StatusCode,
+			&delivery.Error,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+			&delivery.ExpiresAt); err != nil {
+			return nil, commonErrors.NewUnknownErrorWithError("failed to decode webhook deliveries", err)
+		}
+
+		delivery.EventType = models.WebhookEventType(eventType)
+		delivery.Status = models.WebhookDeliveryStatus(status)
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to list webhook deliveries", err)
+	}
+
+	return deliveries, nil
+}
+
+func (service *postgresStoreService) subscriptionTable() string {
+	return pgx.Identifier{service.subscriptionTableName}.Sanitize()
+}
+
+func (service *postgresStoreService) deliveryTable() string {
+	return pgx.Identifier{service.deliveryTableName}.Sanitize()
+}
@@ -0,0 +1,407 @@
+// Package webhook implements the outbound webhooks the users subscribe to be called for their events
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	"github.com/decentralized-cloud/user/services/clock"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"github.com/decentralized-cloud/user/services/correlation"
+	"github.com/decentralized-cloud/user/services/idgenerator"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxSubscriptions is the maximum number of the webhook subscriptions a user can have, so a single event can not
+	// be fanned out to an unbounded number of calls
+	maxSubscriptions = 10
+
+	// signatureHeader is the header the HMAC-SHA256 signature of the timestamp and the body of the call is sent in
+	signatureHeader = "X-Webhook-Signature"
+
+	// timestampHeader is the header the unix time the call is signed at is sent in
+	timestampHeader = "X-Webhook-Timestamp"
+
+	// eventHeader is the header the type of the delivered event is sent in
+	eventHeader = "X-Webhook-Event"
+
+	// deliveryHeader is the header the ID of the delivery is sent in, so the receiver can ignore the retried calls it
+	// has already processed
+	deliveryHeader = "X-Webhook-Delivery"
+)
+
+type webhookService struct {
+	logger             *zap.Logger
+	storeService       StoreContract
+	clockService       clock.ClockContract
+	idGeneratorService idgenerator.IDGeneratorContract
+	httpClient         *http.Client
+	maxAttempts        int
+	retryBackoff       time.Duration
+	deliveryRetention  time.Duration
+
+	// privateNetworksAllowed indicates whether the webhooks can be called in the private networks
+	privateNetworksAllowed bool
+}
+
+// event is the body of the webhook calls
+type event struct {
+	DeliveryID string                  `json:"deliveryID"`
+	Type       models.WebhookEventType `json:"type"`
+	Email      string                  `json:"email"`
+	OccurredAt time.Time               `json:"occurredAt"`
+}
+
+// NewWebhookService creates new instance of the webhookService, setting up all dependencies and returns the instance
+// logger: Mandatory. Reference to the logger service
+// configurationService: Mandatory. Reference to the service that provides required configurations
+// storeService: Mandatory. Reference to the service that persists the webhook subscriptions and their deliveries
+// clockService: Mandatory. Reference to the service that provides the current time
+// idGeneratorService: Mandatory. Reference to the service that generates the IDs of the subscriptions and deliveries
+// Returns the new service or error if something goes wrong
+func NewWebhookService(
+	logger *zap.Logger,
+	configurationService configuration.ConfigurationContract,
+	storeService StoreContract,
+	clockService clock.ClockContract,
+	idGeneratorService idgenerator.IDGeneratorContract) (WebhookContract, error) {
+	if logger == nil {
+		return nil, commonErrors.NewArgumentNilError("logger", "logger is required")
+	}
+
+	if configurationService == nil {
+		return nil, commonErrors.NewArgumentNilError("configurationService", "configurationService is required")
+	}
+
+	if storeService == nil {
+		return nil, commonErrors.NewArgumentNilError("storeService", "storeService is required")
+	}
+
+	if clockService == nil {
+		return nil, commonErrors.NewArgumentNilError("clockService", "clockService is required")
+	}
+
+	if idGeneratorService == nil {
+		return nil, commonErrors.NewArgumentNilError("idGeneratorService", "idGeneratorService is required")
+	}
+
+	maxAttempts, err := configurationService.GetWebhookMaxAttempts()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the maximum number of webhook attempts", err)
+	}
+
+	retryBackoff, err := configurationService.GetWebhookRetryBackoff()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the webhook retry backoff", err)
+	}
+
+	timeout, err := configurationService.GetWebhookTimeout()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the webhook timeout", err)
+	}
+
+	deliveryRetention, err := configurationService.GetWebhookDeliveryRetention()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get the webhook delivery retention", err)
+	}
+
+	privateNetworksAllowed, err := configurationService.GetWebhookPrivateNetworksAllowed()
+	if err != nil {
+		return nil, commonErrors.NewUnknownErrorWithError("failed to get whether the webhooks can be called in the private networks", err)
+	}
+
+	return &webhookService{
+		logger:             logger,
+		storeService:       storeService,
+		clockService:       clockService,
+		idGeneratorService: idGeneratorService,
+		httpClient:         newHTTPClient(timeout, privateNetworksAllowed),
+		maxAttempts:        maxAttempts,
+		retryBackoff:       retryBackoff,
+		deliveryRetention:  deliveryRetention,
+
+		privateNetworksAllowed: privateNetworksAllowed,
+	}, nil
+}
+
+// CreateSubscription persists the new webhook subscription of the user
+// ctx: Mandatory The reference to the context
+// subscription: Mandatory. The subscription containing the email address of the user, the URL, the secret and the
+// event types
+// Returns either the persisted subscription or error if something goes wrong. ArgumentError is returned if the URL
+// is known not to be public and the webhooks can not be called in the private networks.
+func (service *webhookService) CreateSubscription(
+	ctx context.Context,
+	subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	if !service.privateNetworksAllowed {
+		if err := checkDestination(subscription.URL); err != nil {
+			return nil, commonErrors.NewArgumentErrorWithError("subscription", "the webhook URL must be public", err)
+		}
+	}
+
+	subscriptions, err := service.storeService.ListSubscriptions(ctx, subscription.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subscriptions) >= maxSubscriptions {
+		return nil, commonErrors.NewArgumentError(
+			"subscription",
+			fmt.Sprintf("a user can not have more than %d webhook subscriptions", maxSubscriptions))
+	}
+
+	createdSubscription := *subscription
+	createdSubscription.SubscriptionID = service.idGeneratorService.NewID()
+	createdSubscription.CreatedAt = service.clockService.Now()
+
+	if err = service.storeService.CreateSubscription(ctx, &createdSubscription); err != nil {
+		return nil, err
+	}
+
+	return &createdSubscription, nil
+}
+
+// ListSubscriptions lists the webhook subscriptions of the user, the oldest first
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns either the subscriptions of the user or error if something goes wrong.
+func (service *webhookService) ListSubscriptions(
+	ctx context.Context,
+	email string) ([]models.WebhookSubscription, error) {
+	return service.storeService.ListSubscriptions(ctx, email)
+}
+
+// DeleteSubscription deletes the webhook subscription of the user, the events are no longer delivered to the
+// webhook
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// subscriptionID: Mandatory. The ID of the subscription
+// Returns error if something goes wrong. SubscriptionNotFoundError is returned if the user has no subscription
+// with the ID.
+func (service *webhookService) DeleteSubscription(
+	ctx context.Context,
+	email string,
+	subscriptionID string) error {
+	return service.storeService.DeleteSubscription(ctx, email, subscriptionID)
+}
+
+// DeleteSubscriptions deletes all the webhook subscriptions of the user and their deliveries
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// Returns error if something goes wrong.
+func (service *webhookService) DeleteSubscriptions(
+	ctx context.Context,
+	email string) error {
+	return service.storeService.DeleteSubscriptions(ctx, email)
+}
+
+// ChangeEmail moves all the webhook subscriptions of the user and their deliveries to the new email address of the
+// user
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The current email address of the user
+// newEmail: Mandatory. The new email address of the user
+// Returns error if something goes wrong.
+func (service *webhookService) ChangeEmail(
+	ctx context.Context,
+	email string,
+	newEmail string) error {
+	return service.storeService.ChangeEmail(ctx, email, newEmail)
+}
+
+// ListDeliveries lists the recent deliveries of the events to the webhooks of the user, the most recent first
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user
+// subscriptionID: Optional. The ID of the subscription to list the deliveries of, all the subscriptions if empty
+// limit: Mandatory. The maximum number of the deliveries to list
+// Returns either the deliveries or error if something goes wrong.
+func (service *webhookService) ListDeliveries(
+	ctx context.Context,
+	email string,
+	subscriptionID string,
+	limit int) ([]models.WebhookDelivery, error) {
+	return service.storeService.ListDeliveries(ctx, email, subscriptionID, service.clockService.Now(), limit)
+}
+
+// Deliver delivers the event to the webhooks of the user subscribed to its type. The webhooks are called in the
+// background, retrying the failed calls, so the event is delivered even after the context is cancelled. The
+// deliveries interrupted by the service shutting down stay pending.
+// ctx: Mandatory The reference to the context
+// email: Mandatory. The email address of the user the event is raised for
+// eventType: Mandatory. The type of the event
+// Returns error if the deliveries could not be persisted.
+func (service *webhookService) Deliver(
+	ctx context.Context,
+	email string,
+	eventType models.WebhookEventType) error {
+	subscriptions, err := service.storeService.ListSubscriptions(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	logger := correlation.GetLogger(ctx, service.logger)
+	now := service.clockService.Now()
+
+	for index := range subscriptions {
+		subscription := subscriptions[index]
+		if !isSubscribedTo(subscription, eventType) {
+			continue
+		}
+
+		delivery := &models.WebhookDelivery{
+			DeliveryID:     service.idGeneratorService.NewID(),
+			SubscriptionID: subscription.SubscriptionID,
+			Email:          email,
+			EventType:      eventType,
+			Status:         models.WebhookDeliveryPending,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+			ExpiresAt:      now.Add(service.deliveryRetention),
+		}
+
+		// The delivery is only attempted if its status can be queried
+		if err = service.storeService.SaveDelivery(ctx, delivery); err != nil {
+			return err
+		}
+
+		var body []byte
+		if body, err = json.Marshal(event{
+			DeliveryID: delivery.DeliveryID,
+			Type:       eventType,
+			Email:      email,
+			OccurredAt: now,
+		}); err != nil {
+			return commonErrors.NewUnknownErrorWithError("failed to encode the webhook event", err)
+		}
+
+		go service.deliver(logger, &subscription, delivery, body)
+	}
+
+	return nil
+}
+
+// deliver calls the webhook until it succeeds, it fails with an error that is not worth retrying or the maximum
+// number of attempts is reached. The webhooks refused for not being public are not retried. The time to wait between the attempts is doubled after every attempt. The status of
+// the delivery is persisted after every attempt.
+func (service *webhookService) deliver(
+	logger *zap.Logger,
+	subscription *models.WebhookSubscription,
+	delivery *models.WebhookDelivery,
+	body []byte) {
+	ctx := context.Background()
+	backoff := service.retryBackoff
+
+	for {
+		delivery.Attempts++
+		statusCode, err := service.call(ctx, subscription, delivery, body)
+
+		delivery.StatusCode = statusCode
+		delivery.UpdatedAt = service.clockService.Now()
+		delivery.Error = ""
+
+		retry := false
+		switch {
+		case err == nil:
+			delivery.Status = models.WebhookDeliverySucceeded
+		case delivery.Attempts < service.maxAttempts && isRetryable(statusCode) && !IsNonPublicDestinationError(err):
+			delivery.Error = err.Error()
+			retry = true
+		default:
+			delivery.Status = models.WebhookDeliveryFailed
+			delivery.Error = err.Error()
+		}
+
+		if err = service.storeService.SaveDelivery(ctx, delivery); err != nil {
+			logger.Error(
+				"failed to persist the webhook delivery",
+				zap.String("deliveryID", delivery.DeliveryID),
+				zap.String("subscriptionID", delivery.SubscriptionID),
+				zap.Error(err))
+		}
+
+		if !retry {
+			return
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// call signs the body and posts it to the webhook
+// Returns the status code the webhook responded with, zero if it did not respond, and error if the call did not
+// succeed
+func (service *webhookService) call(
+	ctx context.Context,
+	subscription *models.WebhookSubscription,
+	delivery *models.WebhookDelivery,
+	body []byte) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(service.clockService.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(timestampHeader, timestamp)
+	request.Header.Set(eventHeader, string(delivery.EventType))
+	request.Header.Set(deliveryHeader, delivery.DeliveryID)
+	request.Header.Set(signatureHeader, "sha256="+Sign(subscription.Secret, timestamp, body))
+
+	response, err := service.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+
+	_ = response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("webhook responded with status code %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+// Sign signs the webhook call, the receivers verify the call by comparing the signature with the one sent in the
+// X-Webhook-Signature header after the sha256= prefix
+// secret: Mandatory. The secret of the webhook subscription
+// timestamp: Mandatory. The unix time sent in the X-Webhook-Timestamp header
+// body: Mandatory. The body of the call
+// Returns the hex encoded HMAC-SHA256 of the timestamp and the body joined with a dot
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(timestamp + "."))
+	_, _ = mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// isSubscribedTo indicates whether the subscription is subscribed to the event type
+func isSubscribedTo(subscription models.WebhookSubscription, eventType models.WebhookEventType) bool {
+	for _, subscribedEventType := range subscription.EventTypes {
+		if subscribedEventType == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRetryable returns false for the responses that are caused by the call itself, hence retrying would not help.
+// The webhooks that did not respond, failed, timed out or throttled the call are retried.
+func isRetryable(statusCode int) bool {
+	return statusCode == 0 ||
+		statusCode >= 500 ||
+		statusCode == http.StatusRequestTimeout ||
+		statusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,444 @@
+package webhook_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/decentralized-cloud/user/models"
+	clockMock "github.com/decentralized-cloud/user/services/clock/mock"
+	configurationMock "github.com/decentralized-cloud/user/services/configuration/mock"
+	idGeneratorMock "github.com/decentralized-cloud/user/services/idgenerator/mock"
+	"github.com/decentralized-cloud/user/services/webhook"
+	webhookMock "github.com/decentralized-cloud/user/services/webhook/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lucsky/cuid"
+	commonErrors "github.com/micro-business/go-core/system/errors"
+	"go.uber.org/zap"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestWebhookService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Webhook Service Tests")
+}
+
+var _ = Describe("Webhook Service Tests", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		sut                      webhook.WebhookContract
+		mockConfigurationService *configurationMock.MockConfigurationContract
+		mockStoreService         *webhookMock.MockStoreContract
+		mockClockService         *clockMock.MockClockContract
+		mockIDGeneratorService   *idGeneratorMock.MockIDGeneratorContract
+		lock                     sync.Mutex
+		subscriptions            []models.WebhookSubscription
+		deliveries               map[string]models.WebhookDelivery
+		now                      time.Time
+		ctx                      context.Context
+		email                    string
+		secret                   string
+		readDelivery             func(deliveryID string) models.WebhookDelivery
+		privateNetworksAllowed   bool
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+
+		mockConfigurationService = configurationMock.NewMockConfigurationContract(mockCtrl)
+		mockConfigurationService.EXPECT().GetWebhookMaxAttempts().Return(3, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetWebhookRetryBackoff().Return(time.Millisecond, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetWebhookTimeout().Return(5*time.Second, nil).AnyTimes()
+		mockConfigurationService.EXPECT().GetWebhookDeliveryRetention().Return(time.Hour, nil).AnyTimes()
+
+		// The test webhooks listen on the loopback address
+		privateNetworksAllowed = true
+		mockConfigurationService.
+			EXPECT().
+			GetWebhookPrivateNetworksAllowed().
+			DoAndReturn(func() (bool, error) {
+				return privateNetworksAllowed, nil
+			}).
+			AnyTimes()
+
+		// The store keeps the subscriptions and the deliveries in memory, guarded by the lock as the deliveries are
+		// saved by the background calls
+		subscriptions = []models.WebhookSubscription{}
+		deliveries = map[string]models.WebhookDelivery{}
+		mockStoreService = webhookMock.NewMockStoreContract(mockCtrl)
+		mockStoreService.
+			EXPECT().
+			ListSubscriptions(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, email string) ([]models.WebhookSubscription, error) {
+				lock.Lock()
+				defer lock.Unlock()
+
+				result := []models.WebhookSubscription{}
+				for _, subscription := range subscriptions {
+					if subscription.Email == email {
+						result = append(result, subscription)
+					}
+				}
+
+				return result, nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			CreateSubscription(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, subscription *models.WebhookSubscription) error {
+				lock.Lock()
+				defer lock.Unlock()
+
+				subscriptions = append(subscriptions, *subscription)
+
+				return nil
+			}).
+			AnyTimes()
+
+		mockStoreService.
+			EXPECT().
+			SaveDelivery(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, delivery *models.WebhookDelivery) error {
+				lock.Lock()
+				defer lock.Unlock()
+
+				deliveries[delivery.DeliveryID] = *delivery
+
+				return nil
+			}).
+			AnyTimes()
+
+		now = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockClockService = clockMock.NewMockClockContract(mockCtrl)
+		mockClockService.EXPECT().Now().Return(now).AnyTimes()
+
+		mockIDGeneratorService = idGeneratorMock.NewMockIDGeneratorContract(mockCtrl)
+		mockIDGeneratorService.EXPECT().NewID().DoAndReturn(cuid.New).AnyTimes()
+
+		ctx = context.Background()
+		email = cuid.New() + "@test.com"
+		secret = cuid.New()
+		readDelivery = func(deliveryID string) models.WebhookDelivery {
+			lock.Lock()
+			defer lock.Unlock()
+
+			return deliveries[deliveryID]
+		}
+
+		var err error
+		sut, err = webhook.NewWebhookService(zap.NewNop(), mockConfigurationService, mockStoreService, mockClockService, mockIDGeneratorService)
+		Ω(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("user tries to instantiate WebhookService", func() {
+		When("logger is not provided and NewWebhookService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := webhook.NewWebhookService(nil, mockConfigurationService, mockStoreService, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("configuration service is not provided and NewWebhookService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := webhook.NewWebhookService(zap.NewNop(), nil, mockStoreService, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("store service is not provided and NewWebhookService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := webhook.NewWebhookService(zap.NewNop(), mockConfigurationService, nil, mockClockService, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("clock service is not provided and NewWebhookService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := webhook.NewWebhookService(zap.NewNop(), mockConfigurationService, mockStoreService, nil, mockIDGeneratorService)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+
+		When("ID generator service is not provided and NewWebhookService is called", func() {
+			It("should return ArgumentNilError", func() {
+				service, err := webhook.NewWebhookService(zap.NewNop(), mockConfigurationService, mockStoreService, mockClockService, nil)
+				Ω(service).Should(BeNil())
+				Ω(commonErrors.IsArgumentNilError(err)).Should(BeTrue())
+			})
+		})
+	})
+
+	Context("WebhookService is instantiated", func() {
+		var (
+			server      *httptest.Server
+			statusCodes chan int
+			requests    chan *http.Request
+			bodies      chan []byte
+		)
+
+		BeforeEach(func() {
+			statusCodes = make(chan int, 10)
+			requests = make(chan *http.Request, 10)
+			bodies = make(chan []byte, 10)
+			server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				body, _ := ioutil.ReadAll(request.Body)
+				requests <- request
+				bodies <- body
+
+				statusCode := http.StatusOK
+				select {
+				case statusCode = <-statusCodes:
+				default:
+				}
+
+				writer.WriteHeader(statusCode)
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		subscribe := func(eventTypes ...models.WebhookEventType) *models.WebhookSubscription {
+			subscription, err := sut.CreateSubscription(ctx, &models.WebhookSubscription{
+				Email:      email,
+				URL:        server.URL,
+				Secret:     secret,
+				EventTypes: eventTypes,
+			})
+			Ω(err).Should(BeNil())
+
+			return subscription
+		}
+
+		deliveryIDs := func() []string {
+			lock.Lock()
+			defer lock.Unlock()
+
+			ids := []string{}
+			for deliveryID := range deliveries {
+				ids = append(ids, deliveryID)
+			}
+
+			return ids
+		}
+
+		When("CreateSubscription is called", func() {
+			It("should persist the subscription with a new ID", func() {
+				subscription := subscribe(models.WebhookEventUserUpdated)
+				Ω(subscription.SubscriptionID).ShouldNot(BeEmpty())
+				Ω(subscription.CreatedAt).Should(Equal(now))
+				Ω(subscriptions).Should(Equal([]models.WebhookSubscription{*subscription}))
+			})
+
+			It("should return ArgumentError if the user has too many subscriptions", func() {
+				for index := 0; index < 10; index++ {
+					subscribe(models.WebhookEventUserUpdated)
+				}
+
+				_, err := sut.CreateSubscription(ctx, &models.WebhookSubscription{Email: email, URL: server.URL})
+				Ω(commonErrors.IsArgumentError(err)).Should(BeTrue())
+			})
+		})
+
+		When("Deliver is called", func() {
+			It("should post the signed event to the subscribed webhooks and mark the delivery succeeded", func() {
+				subscription := subscribe(models.WebhookEventUserUpdated)
+				subscribe(models.WebhookEventUserDeleted)
+
+				Ω(sut.Deliver(ctx, email, models.WebhookEventUserUpdated)).Should(BeNil())
+
+				var request *http.Request
+				Eventually(requests).Should(Receive(&request))
+				var body []byte
+				Eventually(bodies).Should(Receive(&body))
+
+				Ω(request.Method).Should(Equal(http.MethodPost))
+				Ω(request.Header.Get("X-Webhook-Event")).Should(Equal(string(models.WebhookEventUserUpdated)))
+				Ω(request.Header.Get("X-Webhook-Signature")).Should(Equal(
+					"sha256=" + webhook.Sign(secret, request.Header.Get("X-Webhook-Timestamp"), body)))
+
+				deliveryID := request.Header.Get("X-Webhook-Delivery")
+				Eventually(func() models.WebhookDeliveryStatus {
+					return readDelivery(deliveryID).Status
+				}).Should(Equal(models.WebhookDeliverySucceeded))
+
+				delivery := readDelivery(deliveryID)
+				Ω(delivery.SubscriptionID).Should(Equal(subscription.SubscriptionID))
+				Ω(delivery.Attempts).Should(Equal(1))
+				Ω(delivery.StatusCode).Should(Equal(http.StatusOK))
+				Ω(delivery.ExpiresAt).Should(Equal(now.Add(time.Hour)))
+				Ω(deliveryIDs()).Should(HaveLen(1))
+			})
+
+			It("should retry the call the webhook failed to handle", func() {
+				subscribe(models.WebhookEventUserUpdated)
+				statusCodes <- http.StatusServiceUnavailable
+
+				Ω(sut.Deliver(ctx, email, models.WebhookEventUserUpdated)).Should(BeNil())
+
+				Eventually(deliveryIDs).Should(HaveLen(1))
+				deliveryID := deliveryIDs()[0]
+				Eventually(func() models.WebhookDeliveryStatus {
+					return readDelivery(deliveryID).Status
+				}).Should(Equal(models.WebhookDeliverySucceeded))
+				Ω(readDelivery(deliveryID).Attempts).Should(Equal(2))
+			})
+
+			It("should mark the delivery failed once the attempts are exhausted", func() {
+				subscribe(models.WebhookEventUserUpdated)
+				for index := 0; index < 3; index++ {
+					statusCodes <- http.StatusInternalServerError
+				}
+
+				Ω(sut.Deliver(ctx, email, models.WebhookEventUserUpdated)).Should(BeNil())
+
+				Eventually(deliveryIDs).Should(HaveLen(1))
+				deliveryID := deliveryIDs()[0]
+				Eventually(func() models.WebhookDeliveryStatus {
+					return readDelivery(deliveryID).Status
+				}).Should(Equal(models.WebhookDeliveryFailed))
+
+				delivery := readDelivery(deliveryID)
+				Ω(delivery.Attempts).Should(Equal(3))
+				Ω(delivery.StatusCode).Should(Equal(http.StatusInternalServerError))
+				Ω(delivery.Error).ShouldNot(BeEmpty())
+			})
+
+			It("should not retry the call the webhook rejected", func() {
+				subscribe(models.WebhookEventUserUpdated)
+				statusCodes <- http.StatusBadRequest
+
+				Ω(sut.Deliver(ctx, email, models.WebhookEventUserUpdated)).Should(BeNil())
+
+				Eventually(deliveryIDs).Should(HaveLen(1))
+				deliveryID := deliveryIDs()[0]
+				Eventually(func() models.WebhookDeliveryStatus {
+					return readDelivery(deliveryID).Status
+				}).Should(Equal(models.WebhookDeliveryFailed))
+				Ω(readDelivery(deliveryID).Attempts).Should(Equal(1))
+			})
+
+			It("should not call the webhooks not subscribed to the event", func() {
+				subscribe(models.WebhookEventUserDeleted)
+
+				Ω(sut.Deliver(ctx, email, models.WebhookEventUserUpdated)).Should(BeNil())
+				Consistently(requests, 50*time.Millisecond).ShouldNot(Receive())
+				Ω(deliveryIDs()).Should(BeEmpty())
+			})
+
+			It("should not follow the redirects the webhook responds with", func() {
+				redirected := make(chan struct{}, 1)
+				target := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+					redirected <- struct{}{}
+				}))
+				defer target.Close()
+
+				redirecting := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+				defer redirecting.Close()
+
+				_, err := sut.CreateSubscription(ctx, &models.WebhookSubscription{
+					Email:      email,
+					URL:        redirecting.URL,
+					Secret:     secret,
+					EventTypes: []models.WebhookEventType{models.WebhookEventUserUpdated},
+				})
+				Ω(err).Should(BeNil())
+
+				Ω(sut.Deliver(ctx, email, models.WebhookEventUserUpdated)).Should(BeNil())
+
+				Eventually(deliveryIDs).Should(HaveLen(1))
+				deliveryID := deliveryIDs()[0]
+				Eventually(func() models.WebhookDeliveryStatus {
+					return readDelivery(deliveryID).Status
+				}).Should(Equal(models.WebhookDeliveryFailed))
+				Ω(readDelivery(deliveryID).StatusCode).Should(Equal(http.StatusFound))
+				Consistently(redirected, 50*time.Millisecond).ShouldNot(Receive())
+			})
+		})
+
+		When("the webhooks can not be called in the private networks", func() {
+			BeforeEach(func() {
+				privateNetworksAllowed = false
+
+				var err error
+				sut, err = webhook.NewWebhookService(zap.NewNop(), mockConfigurationService, mockStoreService, mockClockService, mockIDGeneratorService)
+				Ω(err).Should(BeNil())
+			})
+
+			It("should return ArgumentError from CreateSubscription for the URLs known not to be public", func() {
+				for _, url := range []string{
+					"https://127.0.0.1/hook",
+					"https://localhost:8443/hook",
+					"https://10.1.2.3/hook",
+					"https://169.254.169.254/latest/meta-data",
+					"https://[::1]/hook",
+					"https://[::ffff:127.0.0.1]/hook",
+					"https://[fd00::1]/hook",
+				} {
+					_, err := sut.CreateSubscription(ctx, &models.WebhookSubscription{
+						Email:      email,
+						URL:        url,
+						Secret:     secret,
+						EventTypes: []models.WebhookEventType{models.WebhookEventUserUpdated},
+					})
+					Ω(commonErrors.IsArgumentError(err)).Should(BeTrue(), url)
+				}
+
+				Ω(subscriptions).Should(BeEmpty())
+			})
+
+			It("should persist the subscription with a public URL", func() {
+				_, err := sut.CreateSubscription(ctx, &models.WebhookSubscription{
+					Email:      email,
+					URL:        "https://example.com/hook",
+					Secret:     secret,
+					EventTypes: []models.WebhookEventType{models.WebhookEventUserUpdated},
+				})
+				Ω(err).Should(BeNil())
+			})
+
+			It("should refuse to call the webhook resolving to a non-public address without retrying", func() {
+				lock.Lock()
+				subscriptions = append(subscriptions, models.WebhookSubscription{
+					SubscriptionID: cuid.New(),
+					Email:          email,
+					URL:            server.URL,
+					Secret:         secret,
+					EventTypes:     []models.WebhookEventType{models.WebhookEventUserUpdated},
+				})
+				lock.Unlock()
+
+				Ω(sut.Deliver(ctx, email, models.WebhookEventUserUpdated)).Should(BeNil())
+
+				Eventually(deliveryIDs).Should(HaveLen(1))
+				deliveryID := deliveryIDs()[0]
+				Eventually(func() models.WebhookDeliveryStatus {
+					return readDelivery(deliveryID).Status
+				}).Should(Equal(models.WebhookDeliveryFailed))
+
+				delivery := readDelivery(deliveryID)
+				Ω(delivery.Attempts).Should(Equal(1))
+				Ω(delivery.StatusCode).Should(BeZero())
+				Ω(delivery.Error).Should(ContainSubstring("not a public address"))
+				Consistently(requests, 50*time.Millisecond).ShouldNot(Receive())
+			})
+		})
+	})
+})
@@ -0,0 +1,101 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// containerStartTimeout is how long a dependency is waited for to accept the connections once its container started
+	containerStartTimeout = 2 * time.Minute
+
+	// containerExpiry is how long Docker keeps a container alive, so the containers of an interrupted run are removed
+	// even if the suite never purges them
+	containerExpiry = 10 * time.Minute
+)
+
+// dependencies are the containers the service under test depends on. A new dependency, e.g. Redis or NATS, is added
+// by starting its container in startDependencies and purging it in purge.
+type dependencies struct {
+	pool    *dockertest.Pool
+	mongodb *dockertest.Resource
+
+	// MongodbConnectionString is the connection string the service reaches MongoDB with
+	MongodbConnectionString string
+}
+
+// startDependencies starts the containers of the dependencies and waits for them to accept the connections
+// Returns either the started dependencies or error if something goes wrong. The containers started before the error
+// are purged.
+func startDependencies() (*dependencies, error) {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to docker: %w", err)
+	}
+
+	pool.MaxWait = containerStartTimeout
+	started := &dependencies{pool: pool}
+
+	if err = started.startMongodb(); err != nil {
+		started.purge()
+
+		return nil, err
+	}
+
+	return started, nil
+}
+
+// startMongodb starts MongoDB and waits until it answers the ping
+func (started *dependencies) startMongodb() (err error) {
+	if started.mongodb, err = started.run("mongo", "4"); err != nil {
+		return err
+	}
+
+	started.MongodbConnectionString = "mongodb://" + started.mongodb.GetHostPort("27017/tcp")
+
+	return started.pool.Retry(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI(started.MongodbConnectionString))
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = client.Disconnect(ctx) }()
+
+		return client.Ping(ctx, nil)
+	})
+}
+
+// run starts the container of the image, removing it once it stops
+func (started *dependencies) run(repository string, tag string, env ...string) (*dockertest.Resource, error) {
+	resource, err := started.pool.RunWithOptions(
+		&dockertest.RunOptions{Repository: repository, Tag: tag, Env: env},
+		func(config *docker.HostConfig) {
+			config.AutoRemove = true
+			config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start %s:%s: %w", repository, tag, err)
+	}
+
+	_ = resource.Expire(uint(containerExpiry.Seconds()))
+
+	return resource, nil
+}
+
+// purge removes the containers of the dependencies that are started
+func (started *dependencies) purge() {
+	for _, resource := range []*dockertest.Resource{started.mongodb} {
+		if resource != nil {
+			_ = started.pool.Purge(resource)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+module github.com/decentralized-cloud/user/test/integration
+
+go 1.16
+
+require (
+	github.com/decentralized-cloud/user v0.0.0
+	github.com/lestrrat-go/jwx v1.2.1
+	github.com/lucsky/cuid v1.2.0
+	github.com/onsi/ginkgo v1.16.4
+	github.com/onsi/gomega v1.13.0
+	github.com/ory/dockertest/v3 v3.7.0
+	go.mongodb.org/mongo-driver v1.5.3
+	go.uber.org/zap v1.17.0
+	google.golang.org/grpc v1.38.0
+)
+
+// The integration tests always run against the service in this repository
+replace github.com/decentralized-cloud/user => ../..
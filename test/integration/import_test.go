@@ -0,0 +1,84 @@
+package integration_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/internal/cmd"
+	"github.com/lucsky/cuid"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Import Integration Tests", func() {
+	var (
+		directory string
+	)
+
+	BeforeEach(func() {
+		var err error
+		directory, err = ioutil.TempDir("", "import")
+		Ω(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+	})
+
+	When("the users are imported", func() {
+		It("should create the valid users in the database the service reads them from and skip the existing ones", func() {
+			existingEmail := cuid.New() + "@test.com"
+			createResponse, err := client.CreateUser(withToken(existingEmail), &userGRPCContract.CreateUserRequest{
+				User: &userGRPCContract.User{},
+			})
+			Ω(err).Should(BeNil())
+			Ω(createResponse.Error).Should(Equal(userGRPCContract.Error_NO_ERROR), createResponse.ErrorMessage)
+
+			emails := []string{cuid.New() + "@test.com", cuid.New() + "@test.com", cuid.New() + "@test.com"}
+			content := "email\n" + emails[0] + "\n" + emails[1] + "\n" + existingEmail + "\nnot-an-email\n" + emails[2] + "\n" + emails[0] + "\n"
+			file := filepath.Join(directory, "users.csv")
+			Ω(ioutil.WriteFile(file, []byte(content), 0600)).Should(Succeed())
+
+			var output, progress bytes.Buffer
+			command := cmd.NewRootCommand()
+			command.SetOut(&output)
+			command.SetErr(&progress)
+			command.SetArgs([]string{"import", file, "--batch-size", "2", "-o", "json"})
+			Ω(command.Execute()).Should(Succeed())
+
+			var result struct {
+				Total          int `json:"total"`
+				Created        int `json:"created"`
+				AlreadyExisted int `json:"alreadyExisted"`
+				Failed         int `json:"failed"`
+				FailedRows     []struct {
+					Row int `json:"row"`
+				} `json:"failedRows"`
+			}
+
+			Ω(json.Unmarshal(output.Bytes(), &result)).Should(Succeed())
+			Ω(result.Total).Should(Equal(6))
+			Ω(result.Created).Should(Equal(3))
+			Ω(result.AlreadyExisted).Should(Equal(1))
+			Ω(result.Failed).Should(Equal(2))
+			Ω(result.FailedRows).Should(HaveLen(2))
+			Ω(result.FailedRows[0].Row).Should(Equal(5))
+			Ω(result.FailedRows[1].Row).Should(Equal(7))
+
+			// The four valid users are created in two batches
+			Ω(progress.String()).Should(ContainSubstring("Imported 2/4 users"))
+			Ω(progress.String()).Should(ContainSubstring("Imported 4/4 users: 3 created, 1 already existed, 2 failed"))
+
+			for _, email := range emails {
+				readResponse, err := client.ReadUser(withToken(email), &userGRPCContract.ReadUserRequest{Email: email})
+				Ω(err).Should(BeNil())
+				Ω(readResponse.Error).Should(Equal(userGRPCContract.Error_NO_ERROR), readResponse.ErrorMessage)
+			}
+		})
+	})
+})
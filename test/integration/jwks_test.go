@@ -0,0 +1,111 @@
+package integration_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+// tokenLifetime is how long the access tokens the issuer signs are valid for
+const tokenLifetime = time.Hour
+
+// tokenIssuer plays the identity provider, it serves the JSON Web Key Set the service verifies the access tokens
+// with and signs the access tokens of the users the specs call the service on behalf of
+type tokenIssuer struct {
+	server     *httptest.Server
+	signingKey jwk.Key
+}
+
+// newTokenIssuer generates a signing key and serves its public key over HTTP
+// keyID: Mandatory. The ID of the signing key, the tokens are sent with it in the kid header
+// Returns either the issuer or error if something goes wrong
+func newTokenIssuer(keyID string) (*tokenIssuer, error) {
+	signingKey, err := newSigningKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := jwk.PublicKeyOf(signingKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the public key: %w", err)
+	}
+
+	_ = publicKey.Set(jwk.KeyIDKey, keyID)
+	_ = publicKey.Set(jwk.AlgorithmKey, jwa.RS256)
+
+	keySet := jwk.NewSet()
+	keySet.Add(publicKey)
+
+	content, err := json.Marshal(keySet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the key set: %w", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write(content)
+	}))
+
+	return &tokenIssuer{
+		server:     server,
+		signingKey: signingKey,
+	}, nil
+}
+
+// JwksURL returns the URL the key set is served at
+func (issuer *tokenIssuer) JwksURL() string {
+	return issuer.server.URL
+}
+
+// Issue signs the access token of the user
+// email: Mandatory. The email address of the user
+// Returns either the signed token or error if something goes wrong
+func (issuer *tokenIssuer) Issue(email string) (string, error) {
+	return signToken(issuer.signingKey, email)
+}
+
+// Close stops serving the key set
+func (issuer *tokenIssuer) Close() {
+	issuer.server.Close()
+}
+
+func newSigningKey(keyID string) (jwk.Key, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the signing key: %w", err)
+	}
+
+	signingKey, err := jwk.New(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the signing key: %w", err)
+	}
+
+	_ = signingKey.Set(jwk.KeyIDKey, keyID)
+	_ = signingKey.Set(jwk.AlgorithmKey, jwa.RS256)
+
+	return signingKey, nil
+}
+
+func signToken(signingKey jwk.Key, email string) (string, error) {
+	now := time.Now()
+	token := jwt.New()
+	_ = token.Set(jwt.SubjectKey, email)
+	_ = token.Set(jwt.IssuedAtKey, now)
+	_ = token.Set(jwt.ExpirationKey, now.Add(tokenLifetime))
+	_ = token.Set("email", email)
+
+	signed, err := jwt.Sign(token, jwa.RS256, signingKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign the access token: %w", err)
+	}
+
+	return string(signed), nil
+}
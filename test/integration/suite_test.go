@@ -0,0 +1,138 @@
+// Package integration_test runs the user service end to end, against its real dependencies started in Docker, and
+// calls it over its real gRPC surface with the access tokens a local identity provider signs. The suite requires
+// Docker and is run with make integration-test.
+package integration_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/decentralized-cloud/user/pkg/server"
+	"github.com/decentralized-cloud/user/services/configuration"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	// serviceStartTimeout is how long the service is waited for to report it is ready
+	serviceStartTimeout = time.Minute
+
+	// signingKeyID is the ID of the key the access tokens are signed with
+	signingKeyID = "integration"
+)
+
+var (
+	started    *dependencies
+	issuer     *tokenIssuer
+	stopServer context.CancelFunc
+	serverErrs chan error
+	connection *grpc.ClientConn
+	client     userGRPCContract.ServiceClient
+	httpURL    string
+)
+
+func TestIntegration(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Integration Tests")
+}
+
+var _ = BeforeSuite(func() {
+	var err error
+	started, err = startDependencies()
+	Ω(err).Should(BeNil())
+
+	issuer, err = newTokenIssuer(signingKeyID)
+	Ω(err).Should(BeNil())
+
+	grpcPort, httpPort, graphqlPort := freePort(), freePort(), freePort()
+	httpURL = "http://127.0.0.1:" + strconv.Itoa(httpPort)
+
+	// The service is configured through the environment variables, exactly like the binary
+	for name, value := range map[string]string{
+		"GRPC_PORT":                     strconv.Itoa(grpcPort),
+		"HTTP_PORT":                     strconv.Itoa(httpPort),
+		"GRAPHQL_PORT":                  strconv.Itoa(graphqlPort),
+		"DATABASE_TYPE":                 "mongodb",
+		"DATABASE_CONNECTION_STRING":    started.MongodbConnectionString,
+		"USER_DATABASE_NAME":            "user",
+		"USER_DATABASE_COLLECTION_NAME": "user",
+		"EVENTING_BROKER":               "none",
+		"JWKS_URL":                      issuer.JwksURL(),
+	} {
+		Ω(os.Setenv(name, value)).Should(BeNil())
+	}
+
+	configurationService, err := configuration.NewEnvConfigurationService()
+	Ω(err).Should(BeNil())
+
+	var ctx context.Context
+	ctx, stopServer = context.WithCancel(context.Background())
+	serverErrs = make(chan error, 1)
+
+	go func() {
+		serverErrs <- server.Run(ctx, configurationService, server.WithLogger(zap.NewNop()))
+	}()
+
+	Eventually(func() int {
+		response, err := http.Get(httpURL + "/ready")
+		if err != nil {
+			return 0
+		}
+
+		_ = response.Body.Close()
+
+		return response.StatusCode
+	}, serviceStartTimeout, time.Second).Should(Equal(http.StatusOK))
+
+	connection, err = grpc.Dial("127.0.0.1:"+strconv.Itoa(grpcPort), grpc.WithInsecure(), grpc.WithBlock())
+	Ω(err).Should(BeNil())
+
+	client = userGRPCContract.NewServiceClient(connection)
+})
+
+var _ = AfterSuite(func() {
+	if connection != nil {
+		_ = connection.Close()
+	}
+
+	if stopServer != nil {
+		stopServer()
+		Eventually(serverErrs, serviceStartTimeout).Should(Receive(BeNil()))
+	}
+
+	if issuer != nil {
+		issuer.Close()
+	}
+
+	if started != nil {
+		started.purge()
+	}
+})
+
+// withToken returns the context the calls are made with on behalf of the user
+func withToken(email string) context.Context {
+	token, err := issuer.Issue(email)
+	Ω(err).Should(BeNil())
+
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// freePort returns a port no process listens on, so the service can listen on it
+func freePort() int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Ω(err).Should(BeNil())
+
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
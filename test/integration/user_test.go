@@ -0,0 +1,85 @@
+package integration_test
+
+import (
+	"context"
+
+	userGRPCContract "github.com/decentralized-cloud/user/contract/grpc/go"
+	"github.com/lucsky/cuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("User Integration Tests", func() {
+	var (
+		email string
+		ctx   context.Context
+	)
+
+	BeforeEach(func() {
+		email = cuid.New() + "@test.com"
+		ctx = withToken(email)
+	})
+
+	When("the call is not authenticated", func() {
+		It("should fail with Unauthenticated", func() {
+			_, err := client.ReadUser(context.Background(), &userGRPCContract.ReadUserRequest{Email: email})
+			Ω(status.Code(err)).Should(Equal(codes.Unauthenticated))
+		})
+	})
+
+	When("the token is signed with a key the identity provider does not serve", func() {
+		It("should fail with Unauthenticated", func() {
+			signingKey, err := newSigningKey(cuid.New())
+			Ω(err).Should(BeNil())
+
+			token, err := signToken(signingKey, email)
+			Ω(err).Should(BeNil())
+
+			foreignCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+			_, err = client.ReadUser(foreignCtx, &userGRPCContract.ReadUserRequest{Email: email})
+			Ω(status.Code(err)).Should(Equal(codes.Unauthenticated))
+		})
+	})
+
+	When("the user creates, reads and deletes itself", func() {
+		It("should persist the user in MongoDB until it is deleted", func() {
+			labels := map[string]string{"department": "engineering"}
+
+			createResponse, err := client.CreateUser(ctx, &userGRPCContract.CreateUserRequest{
+				User: &userGRPCContract.User{Labels: labels},
+			})
+			Ω(err).Should(BeNil())
+			Ω(createResponse.Error).Should(Equal(userGRPCContract.Error_NO_ERROR), createResponse.ErrorMessage)
+
+			readResponse, err := client.ReadUser(ctx, &userGRPCContract.ReadUserRequest{Email: email})
+			Ω(err).Should(BeNil())
+			Ω(readResponse.Error).Should(Equal(userGRPCContract.Error_NO_ERROR), readResponse.ErrorMessage)
+			Ω(readResponse.User.Labels).Should(Equal(labels))
+
+			createResponse, err = client.CreateUser(ctx, &userGRPCContract.CreateUserRequest{
+				User: &userGRPCContract.User{},
+			})
+			Ω(err).Should(BeNil())
+			Ω(createResponse.Error).Should(Equal(userGRPCContract.Error_USER_ALREADY_EXISTS))
+
+			deleteResponse, err := client.DeleteUser(ctx, &userGRPCContract.DeleteUserRequest{Email: email})
+			Ω(err).Should(BeNil())
+			Ω(deleteResponse.Error).Should(Equal(userGRPCContract.Error_NO_ERROR), deleteResponse.ErrorMessage)
+
+			readResponse, err = client.ReadUser(ctx, &userGRPCContract.ReadUserRequest{Email: email})
+			Ω(err).Should(BeNil())
+			Ω(readResponse.Error).Should(Equal(userGRPCContract.Error_USER_NOT_FOUND))
+		})
+	})
+
+	When("the user reads another user", func() {
+		It("should fail with Unauthenticated", func() {
+			_, err := client.ReadUser(ctx, &userGRPCContract.ReadUserRequest{Email: cuid.New() + "@test.com"})
+			Ω(status.Code(err)).Should(Equal(codes.Unauthenticated))
+		})
+	})
+})